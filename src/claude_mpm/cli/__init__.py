@@ -15,6 +15,7 @@ from pathlib import Path
 
 from ..constants import CLICommands
 from ..utils.progress import StartupProgressBar
+from ..utils.theme import export_no_color
 from .command_config import needs_project_workspace
 from .executor import ensure_run_attributes, execute_command
 
@@ -22,6 +23,7 @@ from .executor import ensure_run_attributes, execute_command
 # removed - startup config prompt disabled, users can run `/mpm-configure` manually
 from .parser import create_parser, preprocess_args
 from .startup import (
+    resolve_merge_conflicts_on_startup,
     run_background_services,
     setup_configure_command_environment,
     setup_early_environment,
@@ -30,6 +32,8 @@ from .startup import (
 )
 from .startup_display import display_startup_banner, should_show_banner
 from .utils import ensure_directories
+from .verbosity import CommandOutput
+from .watch import watch, watch_interval
 
 # Version resolution
 # CRITICAL: Don't import 'paths' here - it triggers UnifiedPathManager initialization
@@ -96,13 +100,26 @@ def main(argv: list | None = None):
 
     setup_configure_command_environment(args)
 
+    # -q: hold back everything but errors and the result, including startup
+    output = CommandOutput.start(args)
+
     # CRITICAL: Setup logging BEFORE any initialization that creates loggers
     # This ensures that ensure_directories() and run_background_services()
     # respect the user's logging preference (default: OFF)
     logger = setup_mcp_server_logging(args)
 
+    # The no-color theme also covers rich consoles and child processes
+    export_no_color()
+
     ensure_directories(project=needs_project_workspace(args))
 
+    # Supervised shutdown: registered cleanup steps also run on SIGTERM/SIGHUP,
+    # not just on normal interpreter exit, so nothing is left behind.
+    from ..core.shutdown import get_shutdown_supervisor
+
+    shutdown_supervisor = get_shutdown_supervisor()
+    shutdown_supervisor.install()
+
     # Run migrations BEFORE banner (so we can show results in banner)
     # Migrations are quick and non-blocking, safe to run early
     applied_migrations: list[str] = []
@@ -154,10 +171,10 @@ def main(argv: list | None = None):
         thread = threading.Thread(target=endpoint.run, daemon=True)
         thread.start()
 
-        # Register shutdown handler for clean port release
-        import atexit
-
-        atexit.register(endpoint.shutdown)
+        # Register shutdown step for clean port release
+        shutdown_supervisor.register(
+            "message-endpoint", endpoint.shutdown, priority=10
+        )
         logger.info(f"Message injection endpoint started on port {inject_port}")
 
     # Set runtime mode BEFORE the background services guard so the env var is always
@@ -235,6 +252,7 @@ def main(argv: list | None = None):
             run_background_services(
                 force_sync=force_sync, headless=True, no_sync=no_sync
             )
+            resolve_merge_conflicts_on_startup(interactive=False)
         else:
             # Normal mode: Show single-line startup progress bar.
             # StartupProgressBar suppresses sub-step stdout while active,
@@ -263,6 +281,7 @@ def main(argv: list | None = None):
                     no_sync=no_sync,
                     progress=startup_pb,
                 )
+            resolve_merge_conflicts_on_startup()
             # Progress bar cleared on __exit__; now show the "starting" notice
             # Inform user about Claude Code initialization delay (3-5 seconds)
             # This message appears before os.execvpe() replaces our process
@@ -282,12 +301,22 @@ def main(argv: list | None = None):
         ensure_run_attributes(args)
 
     try:
-        return execute_command(args.command, args)
+        if (interval := watch_interval(args)) is not None:
+            title = " ".join(["claude-mpm", *processed_argv])
+            return output.finish(
+                watch(lambda: execute_command(args.command, args), interval, title)
+            )
+        return output.finish(execute_command(args.command, args))
     except KeyboardInterrupt:
         logger.info("Session interrupted by user")
-        return 0
+        return output.finish(0)
     except Exception as e:
+        output.finish(1)
         logger.error(f"Error: {e}")
+        # Keep the traceback for `claude-mpm debug bundle`
+        from ..services.diagnostics.debug_bundle import record_crash
+
+        record_crash(e)
         if args.debug:
             import traceback
 
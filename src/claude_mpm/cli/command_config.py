@@ -25,6 +25,38 @@ LIGHTWEIGHT_COMMANDS = {
     "doctor",
     "diagnose",
     "check-health",
+    "selftest",  # Runs in its own sandbox HOME
+    "cache-proxy",  # Standalone HTTP proxy, no hooks or monitor needed
+    "playground",  # Single-turn runs only, no session services
+    "eval",  # Cases run in their own temp workspaces
+    "golden",  # Replays run in a copy of the project
+    "graph",  # Reads and writes the graph file only
+    "logs",  # Reads log sources and the registry file only
+    "artifacts",  # Reads and writes .claude-mpm/artifacts only
+    "grep-output",  # Reads transcripts and the output index only
+    "storage",  # Reads and prunes claude-mpm's own data directories only
+    "trust",  # Reads and writes the trusted workspaces file only
+    "integrity",  # Reads manifests and redeploys from the local cache only
+    "batch",  # Runs agents in Kubernetes Jobs, nothing runs locally
+    "work-queue",  # Workers start their own headless sessions per task
+    "status",  # Reads project files and session logs only
+    "canary",  # Reads and writes canary state; sessions are assigned by run
+    "flags",  # Reads and writes feature flag files only
+    "tasks",  # Tracked commands run under their own detached watcher
+    "patch",  # Reads a diff and writes the files it names only
+    "post-edit",  # Runs the configured formatters on the given files
+    "resolve-conflicts",  # Single-turn agents per conflict, no session services
+    "owners",  # Reads CODEOWNERS, the mapping file and git blame only
+    "advisories",  # Reads lockfiles and queries OSV; acts through gh or the queue
+    "annotations",  # Reads and writes .claude-mpm/annotations only
+    "checklist",  # Reads project files and the checklist state only
+    "adoption",  # Reads and deletes the adoption record files only
+    "labels",  # Reads and writes .claude-mpm session labels only
+    "view",  # Reads and writes the saved views file and opens a browser
+    "export",  # Reads transcripts and adoption records, writes the export files
+    "routing",  # Reads the routing config, decision log and transcripts only
+    "templates",  # Reads the conversation template files only
+    "analyze",  # threat-model reads the code and writes its document only
     # Installation management
     "install",
     "uninstall",
@@ -50,16 +82,26 @@ LIGHTWEIGHT_COMMANDS = {
     "update-statusline",
 }
 
+# Commands that own the terminal; --quiet never holds back their output
+INTERACTIVE_COMMANDS = {
+    "run",
+    "start",
+    "configure",
+}
+
 # Read-only subcommands that do NOT need the project workspace directory.
 # Keyed by parent command name, value is the set of read-only subcommand values.
 # Subcommands NOT listed here are treated as workspace-needing (safe default).
 _READ_ONLY_SUBCOMMANDS: dict[str, set[str]] = {
     # monitor status/port only read state; start/stop/restart need the workspace
     "monitor": {"status", "port"},
-    # agents list/view are read-only; deploy/force-deploy/fix/clean need workspace
-    "agents": {"list", "view"},
-    # skills list is read-only; deploy needs workspace
-    "skills": {"list"},
+    # agents list/view/diff are read-only; deploy/force-deploy/fix/clean need
+    # workspace. diff must also skip the startup sync, which would overwrite
+    # the local modifications it is meant to show. capabilities only reads
+    # the deployed files, and its --json output must not be preceded by startup.
+    "agents": {"list", "view", "diff", "capabilities"},
+    # skills list/diff are read-only; deploy needs workspace
+    "skills": {"list", "diff"},
     # memory status/show/view are read-only; init/add/build/clean/optimize need workspace
     # optimize: writes to .claude-mpm/memories/ via MemoryOptimizer — workspace required
     # cross-ref: deprecated no-op (returns error dict, no writes) — read-only
@@ -108,7 +150,9 @@ def needs_project_workspace(args) -> bool:
     2. Lightweight commands (``LIGHTWEIGHT_COMMANDS``): NO workspace.
     3. Commands with read-only subcommands (``_READ_ONLY_SUBCOMMANDS``): only if
        the chosen subcommand is in the read-only set.  Unknown/missing subcommands
-       ERR TOWARD creating (workspace=True) per the bias rule.
+       ERR TOWARD creating (workspace=True) per the bias rule.  ``agents deploy
+       --dry-run`` (or ``--stage-dir``) also counts as read-only: it previews
+       the deployment, so the startup deployment must not run before it.
     4. All other commands: YES workspace.
 
     Bias rule: when in doubt, return True (create the dir).  A false positive is
@@ -140,6 +184,10 @@ def needs_project_workspace(args) -> bool:
         if subcommand is None:
             # No subcommand given — bias toward creating (True)
             return True
+        if (command, subcommand) == ("agents", "deploy") and (
+            getattr(args, "dry_run", False) or getattr(args, "stage_dir", None)
+        ):
+            return False
         # If the subcommand is in the read-only set, skip project init
         return subcommand not in _READ_ONLY_SUBCOMMANDS[command]
 
@@ -0,0 +1,139 @@
+"""
+Adoption command implementation for claude-mpm.
+
+WHY: Gives leads a per-user view of how a team uses claude-mpm (sessions,
+accepted outcomes, verification pass rate) and gives each user a way to see
+and delete what is recorded about them.
+
+DESIGN DECISIONS:
+- Thin wrapper around services.adoption
+- Recording is switched on with the adoption_metrics feature flag, so
+  opting in and out goes through ``claude-mpm flags`` like other previews
+- ``report --json`` prints the full report for dashboards elsewhere
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.adoption import (
+    DEFAULT_DAYS,
+    FLAG,
+    AdoptionLog,
+    build_report,
+    current_user,
+    recording_state,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class AdoptionCommand(BaseCommand):
+    """CLI command for team adoption metrics."""
+
+    VALID_COMMANDS = ("report", "status", "forget")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("adoption")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "adoption_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm adoption {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "report": self._report,
+            "status": self._status,
+            "forget": self._forget,
+        }
+        try:
+            return handlers[args.adoption_command](args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing adoption command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing adoption command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _report(self, args) -> CommandResult:
+        directories = [Path(d).expanduser() for d in getattr(args, "dirs", None) or []]
+        for directory in directories:
+            if not directory.is_dir():
+                return CommandResult.error_result(f"Not a directory: {directory}")
+        project = getattr(args, "project", None)
+        report = build_report(
+            directories or None,
+            days=getattr(args, "days", DEFAULT_DAYS),
+            project=Path(project) if project else None,
+            sort=getattr(args, "sort", "sessions"),
+        )
+        data = report.to_dict()
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        return CommandResult.success_result(report.render(), data=data)
+
+    def _status(self, args) -> CommandResult:
+        enabled, source = recording_state(self.project_dir)
+        log = AdoptionLog(current_user(self.project_dir))
+        sessions = log.sessions()
+        lines = [
+            f"Recording: {'on' if enabled else 'off'} ({source})",
+            f"User: {log.user}",
+            f"Records: {log.path} ({len(sessions)} sessions)",
+        ]
+        if not enabled:
+            lines.append(f"Opt in with 'claude-mpm flags enable {FLAG}'")
+        data = {
+            "enabled": enabled,
+            "source": source,
+            "user": log.user,
+            "path": str(log.path),
+            "sessions": len(sessions),
+        }
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _forget(self, args) -> CommandResult:
+        log = AdoptionLog(current_user(self.project_dir))
+        if not log.forget():
+            return CommandResult.success_result(f"No records for {log.user}")
+        message = f"Deleted the adoption records of {log.user}"
+        if recording_state(self.project_dir)[0]:
+            message += (
+                f"; recording is still on ('claude-mpm flags disable {FLAG}' "
+                "to stop it)"
+            )
+        return CommandResult.success_result(message)
+
+
+def manage_adoption(args) -> int:
+    """Main entry point for the adoption command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = AdoptionCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,169 @@
+"""
+Advisories command implementation for claude-mpm.
+
+WHY: The background advisory check runs at most once a day and on its own;
+users need to run one now (after changing a lockfile, or from CI), see what
+the last one found, and see which pins are being watched.
+
+DESIGN DECISIONS:
+- Thin wrapper around AdvisoryMonitor in services.advisories
+- ``check`` works whether or not background monitoring is enabled, and acts
+  on new advisories the same way; ``--dry-run`` only reports
+- ``check`` exits non-zero when an advisory could not be acted on, not when
+  advisories are found, so scheduled runs fail only on real errors
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.advisories import (
+    Advisory,
+    AdvisoryMonitor,
+    AdvisoryReport,
+    collect_dependencies,
+    load_report,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class AdvisoriesCommand(BaseCommand):
+    """Check pinned dependencies against security advisories."""
+
+    VALID_COMMANDS = ("check", "list", "deps")
+
+    def __init__(self, monitor: AdvisoryMonitor | None = None):
+        super().__init__("advisories")
+        self._monitor = monitor
+
+    @property
+    def monitor(self) -> AdvisoryMonitor:
+        if self._monitor is None:
+            self._monitor = AdvisoryMonitor(Path.cwd())
+        return self._monitor
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "advisories_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm advisories {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {"check": self._check, "list": self._list, "deps": self._deps}
+        try:
+            return handlers[args.advisories_command](args)
+        except Exception as e:
+            self.logger.error("Advisory check failed: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Advisory check failed: {e}")
+
+    def _check(self, args) -> CommandResult:
+        report = self.monitor.run(act=not args.dry_run)
+        if args.json:
+            return CommandResult(
+                success=not report.errors,
+                message=json.dumps(report.to_dict(), indent=2),
+                data=report.to_dict(),
+            )
+        message = _render(report)
+        if report.errors:
+            return CommandResult.error_result(message, data=report.to_dict())
+        return CommandResult.success_result(message, data=report.to_dict())
+
+    def _list(self, args) -> CommandResult:
+        report = load_report(self.monitor.project_dir)
+        if report is None:
+            return CommandResult.success_result(
+                "No advisory check has run for this project; "
+                "run 'claude-mpm advisories check'"
+            )
+        if args.json:
+            return CommandResult.success_result(
+                json.dumps(report.to_dict(), indent=2), data=report.to_dict()
+            )
+        return CommandResult.success_result(_render(report), data=report.to_dict())
+
+    def _deps(self, args) -> CommandResult:
+        dependencies = collect_dependencies(self.monitor.project_dir)
+        data = [
+            {
+                "ecosystem": d.ecosystem,
+                "name": d.name,
+                "version": d.version,
+                "manifest": d.manifest,
+            }
+            for d in dependencies
+        ]
+        if args.json:
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not dependencies:
+            return CommandResult.success_result(
+                "No pinned dependencies found in the project's lockfiles", data=data
+            )
+        lines = [
+            f"{d.ecosystem:<10} {d.name} {d.version}  ({d.manifest})"
+            for d in dependencies
+        ]
+        lines.append(f"\n{_count(len(dependencies), 'pinned dependency')}")
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+
+def _count(n: int, noun: str) -> str:
+    return f"{n} {noun if n == 1 else noun[:-1] + 'ies'}"
+
+
+def _describe(advisory: Advisory) -> str:
+    fixed = f"fixed in {', '.join(advisory.fixed)}" if advisory.fixed else "no fix"
+    return (
+        f"{advisory.severity:<9} {advisory.id}  {advisory.package} "
+        f"{advisory.version} ({advisory.manifest}), {fixed}"
+    )
+
+
+def _render(report: AdvisoryReport) -> str:
+    lines = []
+    for advisory in report.advisories:
+        new = "  [new]" if advisory.key in report.new else ""
+        lines.append(_describe(advisory) + new)
+        if advisory.summary:
+            lines.append(f"          {advisory.summary}")
+        if advisory.key in report.actions:
+            lines.append(f"          -> {report.actions[advisory.key]}")
+        if advisory.key in report.errors:
+            lines.append(f"          failed: {report.errors[advisory.key]}")
+    if lines:
+        lines.append("")
+    lines.append(
+        f"Checked {_count(report.dependencies, 'pinned dependency')} at "
+        f"{report.checked_at[:16].replace('T', ' ')}: "
+        f"{_count(len(report.advisories), 'advisory')} ({len(report.new)} new)"
+    )
+    return "\n".join(lines)
+
+
+def manage_advisories(args) -> int:
+    """Main entry point for the advisories command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = AdvisoriesCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(result.message if args.json else f"Error: {result.message}")
+    return 1
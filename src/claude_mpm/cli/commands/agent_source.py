@@ -14,11 +14,16 @@ the same proven patterns but for agent repositories instead of skills.
 
 import json
 import logging
+import os
 import re
 
 from ...config.agent_sources import AgentSourceConfiguration
 from ...models.git_repository import GitRepository
 from ...services.agents.git_source_manager import GitSourceManager
+from ...services.agents.sources import hosted_agent_sync
+from ...utils.table_view import TableView
+from ..list_columns import AGENT_SOURCE_COLUMNS
+from ..verbosity import emit_quiet_result, emit_result
 
 logger = logging.getLogger(__name__)
 
@@ -26,6 +31,9 @@ logger = logging.getLogger(__name__)
 def _test_repository_access(repo: GitRepository) -> dict:
     """Test if repository is accessible via GitHub API.
 
+    GitLab, Bitbucket and SSH repositories are checked through their own
+    API or ``git ls-remote`` instead.
+
     Design Decision: Test via GitHub API, not Git clone
 
     Rationale: GitHub API is faster and less resource-intensive than
@@ -46,10 +54,12 @@ def _test_repository_access(repo: GitRepository) -> dict:
         >>> print(result["accessible"])
         True
     """
-    import os
-
     import requests
 
+    if hosted_agent_sync.is_hosted(repo):
+        error = hosted_agent_sync.check_access(repo)
+        return {"accessible": error is None, "error": error}
+
     try:
         # Parse GitHub URL to extract owner/repo
         owner, repo_name = repo._parse_github_url(repo.url)
@@ -58,7 +68,7 @@ def _test_repository_access(repo: GitRepository) -> dict:
         api_url = f"https://api.github.com/repos/{owner}/{repo_name}"
 
         headers = {}
-        github_token = os.environ.get("GITHUB_TOKEN")
+        github_token = repo.resolved_token or os.environ.get("GITHUB_TOKEN")
         if github_token:
             headers["Authorization"] = f"token {github_token}"
 
@@ -183,6 +193,15 @@ def _generate_source_id(url: str) -> str:
     return sanitized or "unnamed-repo"
 
 
+def _set_repository_enabled(
+    config: AgentSourceConfiguration, source_id: str, enabled: bool
+) -> None:
+    """Enable or disable the repository *source_id* in *config*, if present."""
+    for repo in config.repositories:
+        if repo.identifier == source_id:
+            repo.enabled = enabled
+
+
 def agent_source_command(args) -> int:
     """Main entry point for agent-source commands.
 
@@ -275,12 +294,25 @@ def handle_add_agent_source(args) -> int:
 
         # Create new repository
         enabled = not args.disabled
+        token = getattr(args, "token", None)
+
+        # Security warning for direct tokens
+        if token and not token.startswith("$"):
+            print("⚠️  Warning: Direct token values in config are not recommended")
+            print("   Consider using environment variable reference instead:")
+            print("   --token $MY_PRIVATE_TOKEN")
+            print()
+
+        ssh_key = getattr(args, "ssh_key", None)
         repo = GitRepository(
             url=args.url,
             subdirectory=args.subdirectory,
             branch=getattr(args, "branch", "main"),
             priority=args.priority,
             enabled=enabled,
+            token=token,
+            ssh_key=ssh_key,
+            provider=getattr(args, "provider", None),
         )
 
         # Validate repository
@@ -358,18 +390,23 @@ def handle_add_agent_source(args) -> int:
             print()
             print("💡 Lower priority number = higher precedence")
 
-        # Add repository
-        config.add_repository(repo)
-        config.save()
+        # Add repository (re-read under the lock: another command may have
+        # changed the sources while this one was testing the repository)
+        AgentSourceConfiguration.update(lambda c: c.add_repository(repo))
 
         # Success message
         status_emoji = "✅" if enabled else "⚠️ "
         status_text = "enabled" if enabled else "disabled"
         print(f"{status_emoji} Added agent source: {repo.identifier}")
+        emit_quiet_result(repo.identifier)
         print(f"   URL: {args.url}")
         print(f"   Branch: {repo.branch}")
         if args.subdirectory:
             print(f"   Subdirectory: {args.subdirectory}")
+        if ssh_key:
+            print(f"   SSH key: {ssh_key}")
+        if repo.hosting and repo.hosting != "github":
+            print(f"   Provider: {repo.hosting}")
         print(f"   Priority: {args.priority}")
         print(f"   Status: {status_text}")
         print()
@@ -439,26 +476,33 @@ def handle_list_agent_sources(args) -> int:
                 print("💡 Add a source: claude-mpm agent-source add <git-url>")
                 return 0
 
-            filter_text = " (enabled only)" if args.enabled_only else ""
-            print(f"📚 Configured Agent Sources ({len(all_repos)} total{filter_text}):")
-            print()
-
+            rows = []
             for repo in all_repos:
-                status = "✅" if repo.enabled else "❌"
-                status_text = "Enabled" if repo.enabled else "Disabled"
                 is_system = repo.url == "https://github.com/bobmatnyc/claude-mpm-agents"
                 system_tag = " [System]" if is_system else ""
+                rows.append(
+                    {
+                        "identifier": f"{repo.identifier}{system_tag}",
+                        "enabled": repo.enabled,
+                        "priority": repo.priority,
+                        "branch": repo.branch,
+                        "subdirectory": repo.subdirectory,
+                        "url": repo.url,
+                    }
+                )
+            table = TableView.from_args(AGENT_SOURCE_COLUMNS, rows, args)
 
-                print(f"  {status} {repo.identifier}{system_tag} ({status_text})")
-                print(f"     URL: {repo.url}")
-                print(f"     Branch: {repo.branch}")
-                if repo.subdirectory:
-                    print(f"     Subdirectory: {repo.subdirectory}")
-                print(f"     Priority: {repo.priority}")
-                print()
+            filter_text = " (enabled only)" if args.enabled_only else ""
+            print(f"📚 Configured Agent Sources ({len(all_repos)} total{filter_text}):")
+            print()
+            emit_result(table.render())
+            print()
 
         return 0
 
+    except ValueError as e:
+        print(f"❌ {e}")
+        return 1
     except Exception as e:
         logger.error(f"Failed to list agent sources: {e}", exc_info=True)
         print(f"❌ Failed to list agent sources: {e}")
@@ -502,8 +546,9 @@ def handle_remove_agent_source(args) -> int:
                 return 0
 
         # Remove repository
-        config.remove_repository(args.source_id)
-        config.save()
+        AgentSourceConfiguration.update(
+            lambda c: c.remove_repository(args.source_id)
+        )
 
         print()
         print(f"✅ Removed agent source: {args.source_id}")
@@ -640,8 +685,9 @@ def handle_enable_agent_source(args) -> int:
             return 0
 
         # Enable repository
-        repo_to_enable.enabled = True
-        config.save()
+        AgentSourceConfiguration.update(
+            lambda c: _set_repository_enabled(c, args.source_id, True)
+        )
 
         print(f"✅ Enabled agent source: {args.source_id}")
         print()
@@ -685,8 +731,9 @@ def handle_disable_agent_source(args) -> int:
             return 0
 
         # Disable repository
-        repo_to_disable.enabled = False
-        config.save()
+        AgentSourceConfiguration.update(
+            lambda c: _set_repository_enabled(c, args.source_id, False)
+        )
 
         print(f"✅ Disabled agent source: {args.source_id}")
         print("   Agents from this source will not be available")
@@ -747,6 +794,10 @@ def handle_show_agent_source(args) -> int:
         print(f"  Branch: {repo_to_show.branch}")
         if repo_to_show.subdirectory:
             print(f"  Subdirectory: {repo_to_show.subdirectory}")
+        if repo_to_show.ssh_key:
+            print(f"  SSH key: {repo_to_show.ssh_key}")
+        if repo_to_show.hosting and repo_to_show.hosting != "github":
+            print(f"  Provider: {repo_to_show.hosting}")
         print(f"  Priority: {repo_to_show.priority}")
         print()
 
@@ -180,6 +180,11 @@ class AgentsCommand(AgentCommand):
                 ),
                 AgentCommands.CLEAN.value: self._clean_agents,
                 AgentCommands.VIEW.value: self._view_agent,
+                "diff": self._diff_agent,
+                "update": self._update_agents,
+                "explain": self._explain_agent,
+                "watch": self._watch_agents,
+                "capabilities": self._agent_capabilities,
                 AgentCommands.FIX.value: self._fix_agents,
                 "deps-check": self._check_agent_dependencies,
                 "deps-install": self._install_agent_dependencies,
@@ -293,6 +298,59 @@ class AgentsCommand(AgentCommand):
 
         return AgentFixHandler(self).view_agent(args)
 
+    def _diff_agent(self, args) -> CommandResult:
+        """Diff a deployed agent against its source (delegated)."""
+        from .agents_fix import AgentFixHandler
+
+        return AgentFixHandler(self).diff_agent(args)
+
+    def _update_agents(self, args) -> CommandResult:
+        """Upgrade pinned agents (delegated)."""
+        from .agents_update import AgentUpdateHandler
+
+        return AgentUpdateHandler(self).update_agents(args)
+
+    def _explain_agent(self, args) -> CommandResult:
+        """Show which override layer each part of an agent comes from (delegated)."""
+        from .agents_explain import AgentExplainHandler
+
+        return AgentExplainHandler(self).explain_agent(args)
+
+    def _watch_agents(self, args) -> CommandResult:
+        """Redeploy agents on every template or override change until Ctrl+C."""
+        from ...services.agents.agent_hot_reload import AgentReloader
+
+        reloader = AgentReloader(Path.cwd())
+        if not reloader.deployed():
+            message = f"No agents deployed in {reloader.agents_dir}"
+            print(f"❌ {message}")
+            return CommandResult.error_result(message)
+
+        def report(summary: dict) -> None:
+            for error in summary["errors"]:
+                print(f"❌ {error}")
+            for name in summary["conflicts"]:
+                print(
+                    f"⚠️  {name}: conflicts with local edits "
+                    "(run 'claude-mpm integrity resolve')"
+                )
+            for name in summary["reloaded"]:
+                print(f"🔄 Reloaded {name}")
+
+        watched = len(reloader.watched_dirs())
+        print(f"👀 Watching {watched} directories (Ctrl+C to stop)")
+        print(f"   Deploying to {reloader.agents_dir}")
+        print()
+        reloader.run(on_reload=report)
+        print()
+        return CommandResult.success_result("Stopped watching")
+
+    def _agent_capabilities(self, args) -> CommandResult:
+        """Report what each deployed agent is configured with (delegated)."""
+        from .agents_capabilities import AgentCapabilitiesHandler
+
+        return AgentCapabilitiesHandler(self).agent_capabilities(args)
+
     def _fix_agents(self, args) -> CommandResult:
         """Fix agent frontmatter issues (delegated)."""
         from .agents_fix import AgentFixHandler
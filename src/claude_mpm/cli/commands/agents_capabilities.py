@@ -0,0 +1,76 @@
+"""
+Capabilities handler for agents command.
+
+WHY: External tooling needs to know what each deployed agent is configured
+with. ``agents capabilities`` reports it from the deployed files (see
+services/agents/agent_capabilities.py), as a table or as versioned JSON.
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+from typing import TYPE_CHECKING
+
+from ..shared import CommandResult
+
+if TYPE_CHECKING:
+    from .agents import AgentsCommand
+
+
+class AgentCapabilitiesHandler:
+    """Handles ``agents capabilities``."""
+
+    def __init__(self, cmd: AgentsCommand) -> None:
+        self.cmd = cmd
+
+    def agent_capabilities(self, args) -> CommandResult:
+        """Report the model, tools, memory and skills of each deployed agent."""
+        from ...services.agents.agent_capabilities import capabilities_report
+
+        as_json = getattr(args, "json", False)
+        structured = as_json or self.cmd._is_structured_format(
+            self.cmd._get_output_format(args)
+        )
+        report = capabilities_report(Path.cwd())
+        agents = report["agents"]
+
+        if as_json:
+            print(json.dumps(report, indent=2))
+        elif not structured:
+            if not agents:
+                print("No agents deployed")
+            for agent in agents:
+                print(f"{agent['name']} ({agent['scope']})")
+                if agent["error"]:
+                    # YAML errors span several lines; --json has all of them
+                    print(f"  ❌ {agent['error'].splitlines()[0]}")
+                    continue
+                tools = agent["tools"]
+                details = [
+                    ("model", agent["model"] or "default"),
+                    ("tools", ", ".join(tools) if tools is not None else "all"),
+                ]
+                if agent["disallowed_tools"]:
+                    details.append(("denied", ", ".join(agent["disallowed_tools"])))
+                if agent["permissions"]:
+                    details.append(("permissions", "yes"))
+                skills = [
+                    skill
+                    + (" (not deployed)" if skill in agent["missing_skills"] else "")
+                    for skill in agent["skills"]
+                ]
+                details.append(("skills", ", ".join(skills) or "none"))
+                memory = agent["memory"]
+                memory_parts = []
+                if memory["scope"]:
+                    memory_parts.append(f"scope {memory['scope']}")
+                if memory["file"]:
+                    memory_parts.append(memory["file"])
+                details.append(("memory", ", ".join(memory_parts) or "none"))
+                details.append(("schema", agent["schema_version"] or "-"))
+                for label, value in details:
+                    print(f"  {label:<12} {value}")
+        return CommandResult.success_result(
+            f"Found {len(agents)} deployed agents", data=report
+        )
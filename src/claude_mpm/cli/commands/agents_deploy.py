@@ -8,11 +8,15 @@ deploy, minimal configuration deploy, and auto-configure deploy.
 
 from __future__ import annotations
 
+import sys
 from pathlib import Path
 from typing import TYPE_CHECKING
 
 from ...core.enums import OutputFormat
+from ...utils.bulk_operations import DEFAULT_JOBS
+from ...utils.progress import ProgressBar
 from ..shared import CommandResult
+from ..verbosity import emit_quiet_result
 
 if TYPE_CHECKING:
     from .agents import AgentsCommand
@@ -64,7 +68,11 @@ class AgentDeployHandler:
                 for r in source_config.get_enabled_repositories()
                 if "claude-mpm-agents" in r.identifier
             ][:1]
-            orchestrator = AgentSyncOrchestrator(show_progress=True)
+            # A dry run may print the rendered agents to stdout
+            dry_run = getattr(args, "dry_run", False) or (
+                getattr(args, "stage_dir", None) is not None
+            )
+            orchestrator = AgentSyncOrchestrator(show_progress=not dry_run)
             orch_result = orchestrator.sync(force=force, repos=default_repos)
 
             if not orch_result.enabled or (
@@ -83,25 +91,35 @@ class AgentDeployHandler:
                 f"Phase 1 complete: {orch_result.total_downloaded + orch_result.cache_hits} agents in cache"
                 f" ({orch_result.total_downloaded} downloaded, {orch_result.cache_hits} cached)"
             )
+            if dry_run:
+                return self.render_agents(args, project_dir)
             self._logger.info(f"Phase 2: Deploying agents to {project_dir}...")
 
             # Deploy from cache to project directory (deploy stays with GitSourceSyncService)
+            agent_count = orch_result.total_downloaded + orch_result.cache_hits
+            progress = ProgressBar(agent_count, prefix="Deploying agents")
             git_sync = GitSourceSyncService()
             deploy_result = git_sync.deploy_agents_to_project(
                 project_dir=project_dir,
                 agent_list=None,  # Deploy all cached agents
                 force=force,
+                max_workers=getattr(args, "jobs", DEFAULT_JOBS),
+                progress_callback=progress.update,
             )
+            failures = deploy_result.get("errors", {})
+            progress.finish(f"{len(deploy_result['failed'])} failed")
 
             # Format combined results for output
-            agent_count = orch_result.total_downloaded + orch_result.cache_hits
             combined_result = {
                 "deployed_count": len(deploy_result.get("deployed", []))
                 + len(deploy_result.get("updated", [])),
                 "deployed": deploy_result.get("deployed", []),
                 "updated": deploy_result.get("updated", []),
                 "skipped": deploy_result.get("skipped", []),
-                "errors": deploy_result.get("failed", []),
+                "errors": [
+                    f"{name}: {failures[name]}" if failures.get(name) else name
+                    for name in deploy_result.get("failed", [])
+                ],
                 "target_dir": deploy_result.get("deployment_dir", ""),
                 "sync_info": {
                     "cached_agents": agent_count,
@@ -116,10 +134,18 @@ class AgentDeployHandler:
                 combined_result, output_format=output_format, verbose=verbose
             )
             print(formatted)
+            if combined_result["target_dir"]:
+                emit_quiet_result(combined_result["target_dir"])
 
             success_count = len(deploy_result["deployed"]) + len(
                 deploy_result["updated"]
             )
+            if deploy_result["failed"]:
+                return CommandResult.error_result(
+                    f"Failed to deploy {len(deploy_result['failed'])} agent(s); "
+                    f"deployed {success_count}",
+                    data={"deploy_result": deploy_result},
+                )
             return CommandResult.success_result(
                 f"Deployed {success_count} agents from cache",
                 data={
@@ -137,6 +163,72 @@ class AgentDeployHandler:
             self._logger.error(f"Error deploying agents: {e}", exc_info=True)
             return CommandResult.error_result(f"Error deploying agents: {e}")
 
+    def render_agents(self, args, project_dir: Path) -> CommandResult:
+        """Render the agents a deploy would write, without deploying them.
+
+        The rendered markdown goes to stdout, or with --stage-dir to one file
+        per agent in that directory, so it can be diffed or inspected before
+        it replaces the live agents in .claude/agents.
+        """
+        import json
+
+        from ...services.agents.sources.git_source_sync_service import (
+            GitSourceSyncService,
+        )
+
+        result = GitSourceSyncService().render_agents_for_project(project_dir)
+        rendered = result["rendered"]
+        errors = [f"{name}: {error}" for name, error in result["errors"].items()]
+        stage_dir = getattr(args, "stage_dir", None)
+        if stage_dir is not None:
+            stage_dir = Path(stage_dir).expanduser().resolve()
+            if stage_dir == Path(result["deployment_dir"]).resolve():
+                message = "--stage-dir must not be the deployment directory"
+                print(f"❌ {message}")
+                return CommandResult.error_result(message)
+            stage_dir.mkdir(parents=True, exist_ok=True)
+            for agent in rendered:
+                (stage_dir / agent.filename).write_text(
+                    agent.content, encoding="utf-8"
+                )
+
+        data = {
+            "deployment_dir": result["deployment_dir"],
+            "stage_dir": str(stage_dir) if stage_dir else None,
+            "agents": [
+                {
+                    "filename": agent.filename,
+                    "source": str(agent.source),
+                    # Already on disk when staged
+                    **({} if stage_dir else {"content": agent.content}),
+                }
+                for agent in rendered
+            ],
+            "errors": errors,
+        }
+        if getattr(args, "json", False) or self.cmd._is_structured_format(
+            self.cmd._get_output_format(args)
+        ):
+            print(json.dumps(data, indent=2))
+        elif stage_dir:
+            print(f"🔍 DRY RUN: rendered {len(rendered)} agent(s) to {stage_dir}")
+            print(f"   Nothing was written to {result['deployment_dir']}")
+        else:
+            for agent in rendered:
+                print(f"===== {agent.filename} (from {agent.source}) =====")
+                print(agent.content.rstrip("\n"))
+        for error in errors:
+            print(f"❌ {error}", file=sys.stderr)
+
+        if errors:
+            return CommandResult.error_result(
+                f"Failed to render {len(errors)} agent(s); rendered {len(rendered)}",
+                data=data,
+            )
+        return CommandResult.success_result(
+            f"Rendered {len(rendered)} agents (dry run)", data=data
+        )
+
     def deploy_preset(self, args) -> CommandResult:
         """Deploy agents by preset name.
 
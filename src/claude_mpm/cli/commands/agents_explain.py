@@ -0,0 +1,98 @@
+"""
+Explain handler for agents command.
+
+WHY: With user and project overrides merged over an agent's template (see
+services/agents/agent_overrides.py), the deployed agent no longer matches
+any single file. ``agents explain`` shows which layer each frontmatter
+field and instructions section comes from.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+from typing import TYPE_CHECKING
+
+from ..shared import CommandResult
+
+if TYPE_CHECKING:
+    from .agents import AgentsCommand
+
+
+class AgentExplainHandler:
+    """Handles ``agents explain``."""
+
+    def __init__(self, cmd: AgentsCommand) -> None:
+        self.cmd = cmd
+
+    def explain_agent(self, args) -> CommandResult:
+        """Show the layers of an agent and what each one contributes."""
+        from ...services.agents.agent_composition import AgentComposer
+        from ...services.agents.agent_overrides import AgentOverrides
+        from ...services.deployment_diff import agent_source
+
+        name = getattr(args, "agent_name", None)
+        if not name:
+            return CommandResult.error_result("Agent name is required for explain")
+        structured = self.cmd._is_structured_format(self.cmd._get_output_format(args))
+
+        project_dir = Path.cwd()
+        overrides = AgentOverrides(project_dir)
+        source = agent_source(name, project_dir)
+        try:
+            if source is None:
+                raise FileNotFoundError(f"No template found for agent '{name}'")
+            # Bases and mixins are flattened into the template first
+            composition = AgentComposer(project_dir).compose(
+                source.read_text(encoding="utf-8"), source
+            )
+            merged = overrides.merge(
+                name, composition.content, template_path=source
+            )
+        except (OSError, ValueError) as e:
+            if not structured:
+                print(f"❌ {e}")
+            return CommandResult.error_result(str(e))
+
+        # Parts of the template that came from its base or a mixin
+        for key, layer in composition.origins.items():
+            if merged.origins.get(key) == "system" and layer != "agent":
+                merged.origins[key] = layer
+        headings = {heading for heading, _ in merged.sections}
+        fields = {k: v for k, v in merged.origins.items() if k not in headings}
+        sections = [
+            {"heading": heading, "layer": merged.origins.get(heading, "")}
+            for heading, text in merged.sections
+            if text
+        ]
+        applied = {layer.name: layer.path for layer in merged.layers}
+        layers = [
+            {"layer": layer, "path": str(path)} for layer, path in composition.files
+        ]
+        layers.append({"layer": "system", "path": str(source)})
+        layers += [
+            {"layer": layer, "path": str(path), "applied": layer in applied}
+            for layer, path in overrides.override_paths(name)
+        ]
+        data = {
+            "agent": name,
+            "layers": layers,
+            "fields": fields,
+            "sections": sections,
+        }
+
+        if not structured:
+            print(f"Agent: {name}\n")
+            print("Layers (later ones win):")
+            layer_width = max(8, *(len(layer["layer"]) for layer in layers))
+            for layer in layers:
+                missing = "" if layer.get("applied", True) else "  (no file)"
+                print(f"  {layer['layer']:<{layer_width}} {layer['path']}{missing}")
+            labels = [*fields, *(section["heading"] for section in sections)]
+            width = max(map(len, labels), default=0)
+            print("\nFrontmatter:")
+            for field_name, layer in fields.items():
+                print(f"  {field_name:<{width}}  {layer}")
+            print("\nInstructions:")
+            for section in sections:
+                print(f"  {section['heading']:<{width}}  {section['layer']}")
+        return CommandResult.success_result(f"Explained {name}", data=data)
@@ -1,9 +1,9 @@
 """
-Fix / validate / view / clean handler for agents command.
+Fix / validate / view / diff / clean handler for agents command.
 
 WHY: Extracted from agents.py to keep the main command file focused on routing.
-This handler manages clean, view, and fix (frontmatter validation) commands
-plus all related text-output helpers.
+This handler manages clean, view, diff and fix (frontmatter validation)
+commands plus all related text-output helpers.
 """
 
 from __future__ import annotations
@@ -18,7 +18,7 @@ if TYPE_CHECKING:
 
 
 class AgentFixHandler:
-    """Handles clean, view, and frontmatter-fix commands."""
+    """Handles clean, view, diff and frontmatter-fix commands."""
 
     def __init__(self, cmd: AgentsCommand) -> None:
         self.cmd = cmd
@@ -86,6 +86,51 @@ class AgentFixHandler:
             self._logger.error(f"Error viewing agent: {e}", exc_info=True)
             return CommandResult.error_result(f"Error viewing agent: {e}")
 
+    def diff_agent(self, args) -> CommandResult:
+        """Show how a deployed agent differs from its source or a revision."""
+        import json
+
+        from ...services.deployment_diff import agent_diff, agent_revision_diff
+        from ...utils.theme import get_theme
+
+        agent_name = getattr(args, "agent_name", None)
+        if not agent_name:
+            return CommandResult.error_result("Agent name is required for diff command")
+        as_json = getattr(args, "json", False)
+        structured = as_json or self.cmd._is_structured_format(
+            self.cmd._get_output_format(args)
+        )
+        revision = getattr(args, "rev", None)
+        try:
+            if revision:
+                diff = agent_revision_diff(agent_name, revision)
+            else:
+                diff = agent_diff(agent_name, latest=getattr(args, "latest", False))
+        except FileNotFoundError as e:
+            if not structured:
+                print(f"❌ {e}")
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self._logger.error(f"Error diffing agent: {e}", exc_info=True)
+            return CommandResult.error_result(f"Error diffing agent: {e}")
+
+        if as_json:
+            print(json.dumps(diff.to_dict(), indent=2))
+        elif not structured:
+            print(f"Deployed: {diff.deployed}")
+            if diff.revision:
+                print(f"Revision: {diff.revision}")
+            else:
+                print(f"Source:   {diff.source}")
+            if diff.modified:
+                print()
+                print(get_theme().diff(diff.render()), end="")
+            elif diff.revision:
+                print(f"No changes since {diff.revision}")
+            else:
+                print("No local modifications")
+        return CommandResult.success_result(f"Diffed {diff.name}", data=diff.to_dict())
+
     def fix_agents(self, args) -> CommandResult:
         """Fix agent frontmatter issues using validation service."""
         try:
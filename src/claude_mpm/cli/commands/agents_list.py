@@ -3,7 +3,8 @@ Listing / discovery handler for agents command.
 
 WHY: Extracted from agents.py to keep the main command file focused on routing.
 This handler manages all agent listing/discovery commands: show versions,
-list (system/deployed/by-tier), list available from sources, and discover.
+list (system/deployed/by-tier/inheritance tree), list available from sources,
+and discover.
 """
 
 from __future__ import annotations
@@ -12,7 +13,16 @@ from pathlib import Path
 from typing import TYPE_CHECKING
 
 from ...core.enums import OutputFormat
+from ...utils.table_view import (
+    TableView,
+    TreeNode,
+    render_tree,
+    sort_rows,
+    table_options,
+)
+from ..list_columns import AGENT_COLUMNS, AGENT_DEFAULT_COLUMNS
 from ..shared import CommandResult
+from ..verbosity import emit_result
 
 if TYPE_CHECKING:
     from .agents import AgentsCommand
@@ -82,6 +92,15 @@ class AgentListingHandler:
         try:
             output_format = self.cmd._get_output_format(args)
 
+            # Reject unknown --sort/--columns names before loading any agents
+            try:
+                TableView.from_args(AGENT_COLUMNS, [], args)
+            except ValueError as e:
+                print(f"❌ {e}")
+                return CommandResult.error_result(str(e))
+
+            if getattr(args, "tree", False) is True:
+                return self.list_agent_inheritance(args)
             if hasattr(args, "by_tier") and args.by_tier:
                 return self.list_agents_by_tier(args)
             if getattr(args, "system", False):
@@ -135,10 +154,7 @@ class AgentListingHandler:
                 for agent in agents
             ]
 
-            formatted = self.cmd._formatter.format_agent_list(
-                agents_data, output_format=output_format, verbose=verbose, quiet=quiet
-            )
-            print(formatted)
+            self._emit_agent_list(args, agents_data, output_format, verbose, quiet)
 
             return CommandResult.success_result(
                 f"Listed {len(agents)} agent templates",
@@ -181,11 +197,7 @@ class AgentListingHandler:
                 for agent in agents
             ]
 
-            # Format the agent list
-            formatted = self.cmd._formatter.format_agent_list(
-                agents_data, output_format=output_format, verbose=verbose, quiet=quiet
-            )
-            print(formatted)
+            self._emit_agent_list(args, agents_data, output_format, verbose, quiet)
 
             # Add warnings for text output
             if str(output_format).lower() == OutputFormat.TEXT and warnings:
@@ -206,6 +218,88 @@ class AgentListingHandler:
             self._logger.error(f"Error listing deployed agents: {e}", exc_info=True)
             return CommandResult.error_result(f"Error listing deployed agents: {e}")
 
+    def _emit_agent_list(
+        self, args, agents_data, output_format, verbose: bool, quiet: bool
+    ) -> None:
+        """Print agents, as an aligned table when ``--sort``/``--columns`` is set."""
+        sort, columns = table_options(args)
+        if sort:
+            agents_data[:] = sort_rows(agents_data, sort, AGENT_COLUMNS)
+
+        if self.cmd._is_structured_format(output_format) or not (
+            sort or columns or str(output_format).lower() == OutputFormat.TABLE
+        ):
+            formatted = self.cmd._formatter.format_agent_list(
+                agents_data, output_format=output_format, verbose=verbose, quiet=quiet
+            )
+            emit_result(formatted)
+            return
+
+        if not columns:
+            if quiet:
+                columns = ["name"]
+            elif verbose:
+                columns = [column.key for column in AGENT_COLUMNS]
+            else:
+                columns = AGENT_DEFAULT_COLUMNS
+        emit_result(TableView(AGENT_COLUMNS, agents_data, show=columns).render())
+
+    def list_agent_inheritance(self, args) -> CommandResult:
+        """Show system agents grouped under the BASE-AGENT.md files they inherit.
+
+        Each level of the tree is one BASE-AGENT.md, outermost first, the same
+        order ``AgentTemplateBuilder`` composes them in.
+        """
+        try:
+            from ...services.agents.deployment.agent_template_builder import (
+                AgentTemplateBuilder,
+            )
+
+            agents = self.cmd.listing_service.list_system_agents(verbose=False)
+            filter_term = getattr(args, "filter", None)
+            if filter_term:
+                agents = self.cmd._filter_agents(agents, filter_term)
+
+            builder = AgentTemplateBuilder()
+            roots: list[TreeNode] = []
+            nodes: dict[Path, TreeNode] = {}
+            inheritance: dict[str, list[str]] = {}
+            for agent in sorted(agents, key=lambda a: a.name):
+                bases = []
+                if agent.path:
+                    bases = builder._discover_base_agent_templates(Path(agent.path))
+                bases.reverse()
+                inheritance[agent.name] = [str(base) for base in bases]
+
+                siblings = roots
+                for base in bases:
+                    if base not in nodes:
+                        nodes[base] = TreeNode(str(base))
+                        siblings.append(nodes[base])
+                    siblings = nodes[base].children
+                version = f" ({agent.version})" if agent.version else ""
+                siblings.append(TreeNode(f"{agent.name}{version}"))
+
+            data = {"inheritance": inheritance, "count": len(agents)}
+            output_format = self.cmd._get_output_format(args)
+            if self.cmd._is_structured_format(output_format):
+                formatted = (
+                    self.cmd._formatter.format_as_json(data)
+                    if str(output_format).lower() == OutputFormat.JSON
+                    else self.cmd._formatter.format_as_yaml(data)
+                )
+                emit_result(formatted)
+            else:
+                emit_result(render_tree(f"Agents ({len(agents)})", roots))
+
+            return CommandResult.success_result(
+                f"Listed inheritance for {len(agents)} agents", data=data
+            )
+
+        except Exception as e:
+            self._logger.error(f"Error listing agent inheritance: {e}", exc_info=True)
+            return CommandResult.error_result(f"Error listing agent inheritance: {e}")
+
     def list_agents_by_tier(self, args) -> CommandResult:
         """List agents grouped by tier/precedence."""
         try:
@@ -256,7 +350,7 @@ class AgentListingHandler:
             formatted = self.cmd._formatter.format_agents_by_tier(
                 agents_by_tier, output_format=output_format
             )
-            print(formatted)
+            emit_result(formatted)
 
             return CommandResult.success_result(
                 "Agents listed by tier", data=agents_by_tier
@@ -0,0 +1,113 @@
+"""
+Update handler for agents command.
+
+WHY: Deployed agents are pinned in .claude-mpm/agents.lock so that syncs
+never change them behind the user's back. ``agents update`` is the one
+command that moves those pins to the latest synced templates and redeploys.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+from typing import TYPE_CHECKING
+
+from ..shared import CommandResult
+
+if TYPE_CHECKING:
+    from .agents import AgentsCommand
+
+
+class AgentUpdateHandler:
+    """Handles ``agents update``."""
+
+    def __init__(self, cmd: AgentsCommand) -> None:
+        self.cmd = cmd
+
+    @property
+    def _logger(self):
+        return self.cmd.logger
+
+    def update_agents(self, args) -> CommandResult:
+        """Sync agent sources, then re-pin and redeploy changed agents."""
+        from ...services.agents.agents_lock import AgentsLock
+        from ...services.agents.deployment_utils import deploy_agent_file
+
+        project_dir = Path.cwd()
+        lock = AgentsLock(project_dir)
+        structured = self.cmd._is_structured_format(self.cmd._get_output_format(args))
+        check = getattr(args, "check", False)
+
+        if not lock.exists():
+            message = (
+                "No agents are pinned yet; agents.lock is written on the next "
+                "'claude-mpm run'"
+            )
+            if not structured:
+                print(message)
+            return CommandResult.success_result(message, data={"updates": []})
+
+        names = list(getattr(args, "agent_names", None) or [])
+        unknown = sorted(set(names) - set(lock.entries()))
+        if unknown:
+            return CommandResult.error_result(
+                f"Not pinned in {lock.path.name}: {', '.join(unknown)}"
+            )
+
+        try:
+            self._sync_cache()
+        except Exception as e:
+            self._logger.warning(f"Agent sync failed, using cached templates: {e}")
+
+        cache_dir = Path.home() / ".claude-mpm" / "cache" / "agents"
+        updates = [u for u in lock.updates(cache_dir) if not names or u.name in names]
+        data = {
+            "updates": [
+                {
+                    "agent": u.name,
+                    "pinned": u.pinned.version,
+                    "available": u.version,
+                    "source": str(u.source),
+                }
+                for u in updates
+            ]
+        }
+
+        if not updates:
+            if not structured:
+                print("All pinned agents are up to date")
+            return CommandResult.success_result("No agent updates", data=data)
+
+        if check:
+            if not structured:
+                print(f"{len(updates)} agent update(s) available:")
+                for update in updates:
+                    print(f"  {update.describe()}")
+                print("\nRun 'claude-mpm agents update' to apply them")
+            return CommandResult.success_result(
+                f"{len(updates)} agent update(s) available", data=data
+            )
+
+        deploy_dir = project_dir / ".claude" / "agents"
+        failed = []
+        for update in updates:
+            lock.pin(update.name, update.source)
+            result = deploy_agent_file(update.source, deploy_dir)
+            if not result.success:
+                failed.append(f"{update.name}: {result.error}")
+            elif not structured:
+                suffix = " (merge conflict)" if result.action == "conflict" else ""
+                print(f"✓ Updated {update.describe()}{suffix}")
+
+        if failed:
+            return CommandResult.error_result(
+                f"Failed to update {len(failed)} agent(s): {'; '.join(failed)}",
+                data=data,
+            )
+        return CommandResult.success_result(
+            f"Updated {len(updates)} agent(s)", data=data
+        )
+
+    def _sync_cache(self) -> None:
+        from ...services.agents.sync_orchestrator import AgentSyncOrchestrator
+
+        AgentSyncOrchestrator(show_progress=False).sync()
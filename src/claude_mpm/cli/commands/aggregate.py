@@ -23,7 +23,8 @@ from ...services.event_aggregator import (
     start_aggregator,
     stop_aggregator,
 )
-from ..shared import BaseCommand, CommandResult
+from ..shared import BaseCommand, CommandResult, ListOptions, add_list_arguments
+from ..watch import add_watch_argument
 
 logger = get_logger("cli.aggregate")
 
@@ -39,7 +40,15 @@ class AggregateCommand(BaseCommand):
         if not hasattr(args, "aggregate_subcommand") or not args.aggregate_subcommand:
             return "No aggregate subcommand specified"
 
-        valid_commands = ["start", "stop", "status", "sessions", "view", "export"]
+        valid_commands = [
+            "start",
+            "stop",
+            "status",
+            "sessions",
+            "events",
+            "view",
+            "export",
+        ]
         if args.aggregate_subcommand not in valid_commands:
             return f"Unknown aggregate command: {args.aggregate_subcommand}. Valid commands: {', '.join(valid_commands)}"
 
@@ -54,6 +63,7 @@ class AggregateCommand(BaseCommand):
                 "stop": self._stop_command,
                 "status": self._status_command,
                 "sessions": self._sessions_command,
+                "events": self._events_command,
                 "view": self._view_command,
                 "export": self._export_command,
             }
@@ -91,6 +101,10 @@ class AggregateCommand(BaseCommand):
         """List captured sessions."""
         return sessions_command_legacy(args)
 
+    def _events_command(self, args) -> int:
+        """List the events of a captured session."""
+        return events_command_legacy(args)
+
     def _view_command(self, args) -> int:
         """View details of a specific session."""
         return view_command_legacy(args)
@@ -135,6 +149,8 @@ def aggregate_command_legacy(args):
         return status_command_legacy(args)
     if subcommand == "sessions":
         return sessions_command_legacy(args)
+    if subcommand == "events":
+        return events_command_legacy(args)
     if subcommand == "view":
         return view_command_legacy(args)
     if subcommand == "export":
@@ -270,17 +286,18 @@ def sessions_command_legacy(args):
 
     WHY: Shows what sessions have been captured for analysis.
     """
+    options = ListOptions.from_args(args, default_limit=10)
     aggregator = get_aggregator()
-    sessions = aggregator.list_sessions(limit=args.limit)
+    page = options.apply(aggregator.list_sessions(limit=None))
 
-    if not sessions:
+    if not page.items:
         print("No sessions found")
         return 0
 
-    print(f"Recent Sessions (showing {len(sessions)} of {args.limit} max)")
+    print(f"Recent Sessions ({page.summary('sessions')})")
     print("=" * 80)
 
-    for session in sessions:
+    for session in page.items:
         print(f"\n📁 {session['file']}")
         print(f"   Session ID: {session['session_id']}")
         print(f"   Start: {session['start_time']}")
@@ -289,11 +306,43 @@ def sessions_command_legacy(args):
         print(f"   Delegations: {session['delegations']}")
         print(f"   Prompt: {session['initial_prompt']}")
 
+    if hint := page.next_hint("claude-mpm aggregate sessions"):
+        print(f"\n{hint}")
     print("\nUse 'claude-mpm aggregate view <session_id>' to view details")
 
     return 0
 
 
+def events_command_legacy(args):
+    """List the events of a captured session.
+
+    WHY: ``view --show-events`` only shows the first events; this pages and
+    filters them with the same flags as every other list command.
+    """
+    aggregator = get_aggregator()
+    session = aggregator.load_session(args.session_id)
+
+    if not session:
+        print(f"Session not found: {args.session_id}")
+        print("Use 'claude-mpm aggregate sessions' to list available sessions")
+        return 1
+
+    options = ListOptions.from_args(args, default_limit=50)
+    page = options.apply(session.events)
+
+    print(f"Events for {session.session_id} ({page.summary('events')})")
+    print("-" * 80)
+    for event in page.items:
+        print(f"{event.timestamp} [{event.category.value:10s}] {event.event_type}")
+        if event.agent_context:
+            print(f"  Agent: {event.agent_context}")
+
+    if hint := page.next_hint(f"claude-mpm aggregate events {args.session_id}"):
+        print(f"\n{hint}")
+
+    return 0
+
+
 def view_command_legacy(args):
     """View details of a specific session.
 
@@ -485,20 +534,44 @@ def add_aggregate_parser(subparsers):
     aggregate_subparsers.add_parser("stop", help="Stop the event aggregator service")
 
     # Status command
-    aggregate_subparsers.add_parser(
+    status_parser = aggregate_subparsers.add_parser(
         "status", help="Show aggregator status and statistics"
     )
+    add_watch_argument(status_parser)
 
     # Sessions command
     sessions_parser = aggregate_subparsers.add_parser(
         "sessions", help="List captured sessions"
     )
-    sessions_parser.add_argument(
-        "--limit",
-        "-l",
-        type=int,
-        default=10,
-        help="Maximum number of sessions to show (default: 10)",
+    add_list_arguments(
+        sessions_parser,
+        {
+            "session_id": "session_id",
+            "start_time": "start_time",
+            "end_time": "end_time",
+            "prompt": "initial_prompt",
+        },
+        default_limit=10,
+        limit_flags=("--limit", "-l"),
+        noun="sessions",
+    )
+    add_watch_argument(sessions_parser)
+
+    # Events command
+    events_parser = aggregate_subparsers.add_parser(
+        "events", help="List the events of a captured session"
+    )
+    events_parser.add_argument("session_id", help="Session ID or prefix")
+    add_list_arguments(
+        events_parser,
+        {
+            "type": "event_type",
+            "category": "category",
+            "agent": "agent_context",
+            "correlation_id": "correlation_id",
+        },
+        default_limit=50,
+        noun="events",
     )
 
     # View command
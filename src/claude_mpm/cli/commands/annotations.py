@@ -0,0 +1,122 @@
+"""
+Annotations command implementation for claude-mpm.
+
+WHY: Lets reviews of agent work be written against the session itself, from
+the terminal as well as from the dashboard.
+
+DESIGN DECISIONS:
+- Thin wrapper around AnnotationStore
+- ``--line`` takes the line number of the transcript JSONL, as shown by any
+  editor or ``grep -n``; it is stored as the entry's uuid so the comment
+  still finds its event in reports
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.annotations import AnnotationStore
+from ..shared import BaseCommand, CommandResult
+
+
+class AnnotationsCommand(BaseCommand):
+    """CLI command for session review comments."""
+
+    VALID_COMMANDS = ("add", "list", "remove")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("annotations")
+        self.store = AnnotationStore(Path(project_dir or Path.cwd()))
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "annotations_command", None) not in self.VALID_COMMANDS:
+            return (
+                f"Usage: claude-mpm annotations {{{','.join(self.VALID_COMMANDS)}}}"
+            )
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "add": self._add,
+            "list": self._list,
+            "remove": self._remove,
+        }
+        try:
+            return handlers[args.annotations_command](args)
+        except (FileNotFoundError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error(
+                "Error executing annotations command: %s", e, exc_info=True
+            )
+            return CommandResult.error_result(
+                f"Error executing annotations command: {e}"
+            )
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _add(self, args) -> CommandResult:
+        annotation = self.store.add(
+            args.session,
+            args.text,
+            target=args.target,
+            line=args.line,
+            author=args.author,
+        )
+        return CommandResult.success_result(
+            f"Added comment {annotation.id} on {annotation.target}",
+            data=annotation.to_dict(),
+        )
+
+    def _list(self, args) -> CommandResult:
+        annotations = self.store.annotations(session_id=args.session)
+        data = [a.to_dict() for a in annotations]
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not annotations:
+            return CommandResult.success_result("No comments", data=data)
+        lines = []
+        for a in annotations:
+            where = f"line {a.line}" if a.line else a.target
+            lines.append(
+                f"{a.id}  {a.created_at[:19]}  {a.session_id}  {where}  "
+                f"{a.author}: {a.text}"
+            )
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _remove(self, args) -> CommandResult:
+        if not self.store.remove(args.id):
+            return CommandResult.error_result(f"No comment with id '{args.id}'")
+        return CommandResult.success_result(f"Removed comment {args.id}")
+
+
+def manage_annotations(args) -> int:
+    """Main entry point for the annotations command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = AnnotationsCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
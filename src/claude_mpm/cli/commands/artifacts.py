@@ -0,0 +1,145 @@
+"""
+Artifacts command implementation for claude-mpm.
+
+WHY: Gives agents a fixed place to put what a session produces and gives
+users a way to find it afterwards without digging through /tmp.
+
+DESIGN DECISIONS:
+- Thin wrapper around ArtifactStore
+- ``get`` prints the stored path by default so it composes with other
+  tools; ``--output`` copies the file out instead
+"""
+
+from __future__ import annotations
+
+import json
+import shutil
+from pathlib import Path
+
+from ...services.artifact_store import ArtifactStore
+from ..shared import BaseCommand, CommandResult
+
+
+class ArtifactsCommand(BaseCommand):
+    """CLI command for the session artifact store."""
+
+    VALID_COMMANDS = ("add", "list", "get", "prune")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("artifacts")
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.store = ArtifactStore(self.project_dir)
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "artifacts_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm artifacts {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "add": self._add,
+            "list": self._list,
+            "get": self._get,
+            "prune": self._prune,
+        }
+        try:
+            return handlers[args.artifacts_command](args)
+        except (FileNotFoundError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing artifacts command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing artifacts command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _add(self, args) -> CommandResult:
+        artifact = self.store.add(
+            Path(args.path),
+            session_id=args.session,
+            name=args.name,
+            kind=args.kind,
+            description=args.description,
+            move=args.move,
+        )
+        return CommandResult.success_result(
+            f"Stored {artifact.kind} {artifact.name} as {artifact.id} "
+            f"(session {artifact.session_id})",
+            data=artifact.to_dict(),
+        )
+
+    def _list(self, args) -> CommandResult:
+        artifacts = self.store.artifacts(session_id=args.session, kind=args.kind)
+        data = [a.to_dict() for a in artifacts]
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not artifacts:
+            return CommandResult.success_result("No artifacts stored", data=data)
+        lines = [
+            f"{a.id}  {a.kind:<7} {_format_size(a.size):>8}  {a.created_at[:19]}  "
+            f"{a.session_id}  {a.name}"
+            for a in artifacts
+        ]
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _get(self, args) -> CommandResult:
+        artifact = self.store.get(args.id)
+        if artifact is None:
+            return CommandResult.error_result(f"No artifact with id '{args.id}'")
+        path = self.store.path(artifact)
+        if not args.output:
+            return CommandResult.success_result(str(path), data=artifact.to_dict())
+        destination = Path(args.output).expanduser()
+        if destination.is_dir():
+            destination = destination / artifact.name
+        shutil.copy2(path, destination)
+        return CommandResult.success_result(
+            f"Copied {artifact.name} to {destination}", data=artifact.to_dict()
+        )
+
+    def _prune(self, args) -> CommandResult:
+        removed = self.store.prune()
+        if not removed:
+            return CommandResult.success_result("Nothing to prune", data=[])
+        return CommandResult.success_result(
+            f"Removed artifacts of {len(removed)} session(s): {', '.join(removed)}",
+            data=removed,
+        )
+
+
+def _format_size(size: int) -> str:
+    for unit in ("B", "KB", "MB"):
+        if size < 1024:
+            return f"{size:.0f}{unit}" if unit == "B" else f"{size:.1f}{unit}"
+        size /= 1024
+    return f"{size:.1f}GB"
+
+
+def manage_artifacts(args) -> int:
+    """Main entry point for the artifacts command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = ArtifactsCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,222 @@
+"""
+Batch command implementation for claude-mpm.
+
+WHY: Analysing many repositories means many headless agent runs; this
+command schedules them as Kubernetes Jobs and follows them to completion.
+
+DESIGN DECISIONS:
+- Thin wrapper around KubernetesBatch
+- ``run`` blocks until the batch finishes, printing progress as it goes;
+  ``--detach`` and ``resume`` split that across invocations
+- ``status`` collects runs that finished since the last check but never
+  submits new ones, so looking at a batch does not advance it
+"""
+
+from __future__ import annotations
+
+import json
+
+from ...services.kubernetes_jobs import (
+    ACTIVE,
+    FAILED,
+    PENDING,
+    SUCCEEDED,
+    KubectlError,
+    KubernetesBatch,
+    KubernetesConfig,
+    load_runs,
+    manifest_list,
+)
+from ..shared import BaseCommand, CommandResult
+from ..verbosity import emit_quiet_result
+
+_OVERRIDES = ("namespace", "context", "image", "parallelism", "cpu", "memory")
+
+
+def _format_summary(summary: dict[str, int]) -> str:
+    return ", ".join(
+        f"{summary.get(status, 0)} {status}"
+        for status in (SUCCEEDED, FAILED, ACTIVE, PENDING)
+    )
+
+
+class BatchCommand(BaseCommand):
+    """CLI command for Kubernetes batch agent runs."""
+
+    VALID_COMMANDS = ("run", "status", "resume", "cancel")
+
+    def __init__(self, state_dir=None, kubectl=None):
+        super().__init__("batch")
+        self.state_dir = state_dir
+        self.kubectl = kubectl
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "batch_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm batch {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "run": self._run,
+            "status": self._status,
+            "resume": self._resume,
+            "cancel": self._cancel,
+        }
+        try:
+            return handlers[args.batch_command](args)
+        except (FileNotFoundError, FileExistsError, ValueError, KubectlError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing batch command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing batch command: {e}")
+
+    def _open(self, batch_id: str) -> KubernetesBatch:
+        return KubernetesBatch.open(
+            batch_id, state_dir=self.state_dir, kubectl=self.kubectl
+        )
+
+    def _drive(self, batch: KubernetesBatch, args) -> CommandResult:
+        print(f"Batch {batch.batch_id}: {len(batch.runs)} run(s)")
+        emit_quiet_result(batch.batch_id)
+        summary = batch.run(
+            poll_interval=args.poll_interval,
+            on_progress=lambda s: print(f"  {_format_summary(s)}", flush=True),
+        )
+        message = (
+            f"Batch {batch.batch_id} finished: {_format_summary(summary)}\n"
+            f"Results: {batch.logs_dir}"
+        )
+        if summary.get(FAILED):
+            return CommandResult.error_result(message, data=summary)
+        return CommandResult.success_result(message, data=summary)
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _run(self, args) -> CommandResult:
+        config = KubernetesConfig.load()
+        for name in _OVERRIDES:
+            value = getattr(args, name, None)
+            if value is not None:
+                setattr(config, name, value)
+        runs = load_runs(args.runs_file)
+        if not runs:
+            return CommandResult.error_result(f"No runs in {args.runs_file}")
+
+        if args.dry_run:
+            batch = KubernetesBatch(
+                args.batch_id or "dry-run", runs, config=config, kubectl=self.kubectl
+            )
+            return CommandResult.success_result(
+                json.dumps(manifest_list(batch, runs), indent=2)
+            )
+
+        batch = KubernetesBatch.create(
+            runs,
+            batch_id=args.batch_id,
+            config=config,
+            state_dir=self.state_dir,
+            kubectl=self.kubectl,
+        )
+        if args.detach:
+            summary = batch.step()
+            emit_quiet_result(batch.batch_id)
+            return CommandResult.success_result(
+                f"Batch {batch.batch_id}: {_format_summary(summary)}\n"
+                f"Continue with 'claude-mpm batch resume {batch.batch_id}'",
+                data=summary,
+            )
+        return self._drive(batch, args)
+
+    def _status(self, args) -> CommandResult:
+        if not args.batch_id:
+            batches = [
+                self._open(batch_id)
+                for batch_id in KubernetesBatch.list_ids(self.state_dir)
+            ]
+            data = [
+                {"batch_id": b.batch_id, "created_at": b.created_at, **b.summary()}
+                for b in batches
+            ]
+            if args.json:
+                return CommandResult.success_result(json.dumps(data, indent=2))
+            if not batches:
+                return CommandResult.success_result("No batches yet", data=data)
+            lines = [
+                f"{b.batch_id:<28} {b.created_at[:19]}  {_format_summary(b.summary())}"
+                for b in batches
+            ]
+            return CommandResult.success_result("\n".join(lines), data=data)
+
+        batch = self._open(args.batch_id)
+        warning = None
+        try:
+            for run in batch.refresh():
+                batch.collect(run)
+            batch.save()
+        except KubectlError as e:
+            warning = f"Could not reach the cluster, showing saved state: {e}"
+        data = {
+            "batch_id": batch.batch_id,
+            "summary": batch.summary(),
+            "runs": [
+                {
+                    "id": run.id,
+                    "status": run.status,
+                    "job": run.job,
+                    "repo": run.repo,
+                    "detail": run.detail,
+                    "cost_usd": (run.result or {}).get("cost_usd"),
+                }
+                for run in batch.runs
+            ],
+        }
+        if args.json:
+            return CommandResult.success_result(json.dumps(data, indent=2))
+        lines = [f"Batch {batch.batch_id}: {_format_summary(batch.summary())}"]
+        if warning:
+            lines.append(warning)
+        for run in batch.runs:
+            detail = f"  ({run.detail})" if run.detail else ""
+            lines.append(f"  {run.status:<10} {run.id}{detail}")
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _resume(self, args) -> CommandResult:
+        return self._drive(self._open(args.batch_id), args)
+
+    def _cancel(self, args) -> CommandResult:
+        batch = self._open(args.batch_id)
+        cancelled = batch.cancel()
+        return CommandResult.success_result(
+            f"Cancelled {cancelled} run(s) in batch {batch.batch_id}"
+        )
+
+
+def manage_batch(args) -> int:
+    """Main entry point for the batch command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = BatchCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(result.message if result.data is not None else f"Error: {result.message}")
+    return 1
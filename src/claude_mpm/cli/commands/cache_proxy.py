@@ -0,0 +1,141 @@
+"""
+Cache-proxy command implementation for claude-mpm.
+
+WHY: Re-running the same prompts while tuning agent instructions should not
+cost tokens every time. This command runs the local caching proxy and
+manages its cache.
+
+DESIGN DECISIONS:
+- ``start`` runs in the foreground and prints the ANTHROPIC_BASE_URL to use,
+  so the proxy's lifetime is obvious and Ctrl+C stops it
+- Session hit/miss counts are printed on shutdown
+- Exports manage_cache_proxy(args) as the main entry point
+"""
+
+from __future__ import annotations
+
+import json
+import threading
+
+from ...services.model.caching_proxy import (
+    CachingProxy,
+    ResponseCache,
+    default_cache_dir,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class CacheProxyCommand(BaseCommand):
+    """CLI command for the response caching proxy."""
+
+    VALID_COMMANDS = ("start", "stats", "clear")
+
+    def __init__(self):
+        super().__init__("cache-proxy")
+
+    def validate_args(self, args) -> str | None:
+        proxy_command = getattr(args, "proxy_command", None)
+        if proxy_command and proxy_command not in self.VALID_COMMANDS:
+            return (
+                f"Unknown cache-proxy command: {proxy_command}. "
+                f"Valid commands: {', '.join(self.VALID_COMMANDS)}"
+            )
+        return None
+
+    def run(self, args) -> CommandResult:
+        cache = ResponseCache(getattr(args, "cache_dir", None) or default_cache_dir())
+        proxy_command = getattr(args, "proxy_command", None) or "stats"
+        handlers = {
+            "start": self._start,
+            "stats": self._stats,
+            "clear": self._clear,
+        }
+        try:
+            return handlers[proxy_command](args, cache)
+        except Exception as exc:
+            self.logger.error("Error executing cache-proxy: %s", exc, exc_info=True)
+            return CommandResult.error_result(f"Error executing cache-proxy: {exc}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _start(self, args, cache: ResponseCache) -> CommandResult:
+        proxy = CachingProxy(
+            cache,
+            mode=args.mode,
+            upstream=args.upstream,
+            host=args.host,
+            port=args.port,
+        )
+        try:
+            proxy.start()
+        except OSError as e:
+            return CommandResult.error_result(
+                f"Cannot listen on {args.host}:{args.port}: {e}"
+            )
+
+        print(f"Caching proxy ({proxy.mode}) listening on {proxy.base_url}")
+        print(f"Cache: {cache.cache_dir} ({cache.stats()['entries']} entries)")
+        print(f"Use it with:  export ANTHROPIC_BASE_URL={proxy.base_url}")
+        print("Press Ctrl+C to stop")
+
+        try:
+            threading.Event().wait()
+        except KeyboardInterrupt:
+            pass
+        finally:
+            proxy.stop()
+
+        stats = proxy.stats.to_dict()
+        summary = ", ".join(f"{name}: {count}" for name, count in stats.items())
+        return CommandResult.success_result(
+            f"Caching proxy stopped ({summary})", data=stats
+        )
+
+    def _stats(self, args, cache: ResponseCache) -> CommandResult:
+        stats = cache.stats()
+        if getattr(args, "json", False):
+            print(json.dumps(stats, indent=2))
+            return CommandResult.success_result("", data=stats)
+        return CommandResult.success_result(
+            f"{stats['entries']} cached responses "
+            f"({stats['bytes'] / 1024:.1f} KiB) in {stats['cache_dir']}",
+            data=stats,
+        )
+
+    def _clear(self, args, cache: ResponseCache) -> CommandResult:
+        removed = cache.clear()
+        return CommandResult.success_result(
+            f"Removed {removed} cached responses from {cache.cache_dir}",
+            data={"removed": removed},
+        )
+
+
+def manage_cache_proxy(args) -> int:
+    """Main entry point for the cache-proxy command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = CacheProxyCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
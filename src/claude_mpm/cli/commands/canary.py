@@ -0,0 +1,183 @@
+"""
+Canary command implementation for claude-mpm.
+
+WHY: New agent and PM instruction versions should reach a share of sessions
+first, and only everywhere once their sessions go no worse than the stable
+version's.
+
+DESIGN DECISIONS:
+- Thin wrapper around CanaryStore; sessions are assigned by ``run``
+- ``status`` prints both arms side by side and leaves the verdict to the
+  user, warning while either arm has fewer than MIN_SESSIONS measured
+  sessions
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.canary import ARMS, MIN_SESSIONS, ArmStats, CanaryStore
+from ..shared import BaseCommand, CommandResult
+
+
+class CanaryCommand(BaseCommand):
+    """CLI command for canary rollouts of agent and instruction changes."""
+
+    VALID_COMMANDS = ("start", "list", "status", "promote", "stop")
+
+    def __init__(self, store: CanaryStore | None = None):
+        super().__init__("canary")
+        self.store = store or CanaryStore()
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "canary_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm canary {{{','.join(self.VALID_COMMANDS)}}}"
+        if args.canary_command == "start" and not Path(args.file).is_file():
+            return f"Candidate file not found: {args.file}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "start": self._start,
+            "list": self._list,
+            "status": self._status,
+            "promote": self._promote,
+            "stop": self._stop,
+        }
+        try:
+            return handlers[args.canary_command](args)
+        except (KeyError, ValueError) as e:
+            return CommandResult.error_result(str(e.args[0]) if e.args else str(e))
+        except Exception as e:
+            self.logger.error("Error executing canary command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing canary command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _start(self, args) -> CommandResult:
+        canary = self.store.start(
+            args.name,
+            Path(args.file),
+            percent=getattr(args, "percent", None),
+            projects=[Path(p) for p in getattr(args, "project", None) or []],
+        )
+        lines = [f"Started canary for '{canary.name}'"]
+        if canary.percent:
+            lines.append(f"  {canary.percent}% of new sessions get the candidate")
+        lines += [f"  Always in {project}" for project in canary.projects]
+        lines.append(
+            f"Compare with 'claude-mpm canary status {canary.name}', then "
+            "promote or stop it"
+        )
+        return CommandResult.success_result("\n".join(lines), data=canary.to_dict())
+
+    def _list(self, args) -> CommandResult:
+        canaries = list(self.store.canaries().values())
+        data = [c.to_dict() for c in canaries]
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not canaries:
+            return CommandResult.success_result("No canaries", data=data)
+        lines = []
+        for canary in canaries:
+            scope = [f"{canary.percent}%"] + [Path(p).name for p in canary.projects]
+            lines.append(
+                f"{canary.name:<24} {canary.status:<9} {', '.join(scope)}  "
+                f"(since {canary.started_at[:16].replace('T', ' ')})"
+            )
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _status(self, args) -> CommandResult:
+        canary = self.store.get(args.name)
+        stats = self.store.compare(args.name)
+        data = {
+            "canary": canary.to_dict(),
+            "arms": {arm: stats[arm].to_dict() for arm in ARMS},
+        }
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        lines = [f"Canary '{canary.name}' ({canary.status})", ""]
+        lines += _comparison(stats["stable"], stats["candidate"])
+        if min(s.measured for s in stats.values()) < MIN_SESSIONS:
+            lines.append("")
+            lines.append(
+                f"Fewer than {MIN_SESSIONS} measured sessions in an arm; "
+                "the comparison is not meaningful yet"
+            )
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _promote(self, args) -> CommandResult:
+        canary = self.store.promote(args.name)
+        return CommandResult.success_result(
+            f"Promoted '{canary.name}': every new session gets the candidate",
+            data=canary.to_dict(),
+        )
+
+    def _stop(self, args) -> CommandResult:
+        canary = self.store.stop(args.name)
+        return CommandResult.success_result(
+            f"Stopped '{canary.name}': new sessions get the stable version",
+            data=canary.to_dict(),
+        )
+
+
+def _comparison(stable: ArmStats, candidate: ArmStats) -> list[str]:
+    rows = [
+        ("Sessions", stable.sessions, candidate.sessions, "{:d}"),
+        ("Measured", stable.measured, candidate.measured, "{:d}"),
+        ("Turns", stable.turns, candidate.turns, "{:d}"),
+        (
+            "Tool errors/turn",
+            stable.errors_per_turn,
+            candidate.errors_per_turn,
+            "{:.2f}",
+        ),
+        (
+            "Corrections",
+            stable.correction_rate * 100,
+            candidate.correction_rate * 100,
+            "{:.1f}%",
+        ),
+        (
+            "Cost/session",
+            stable.cost_per_session,
+            candidate.cost_per_session,
+            "${:.2f}",
+        ),
+    ]
+    lines = [f"{'':<18} {'stable':>10} {'candidate':>10}"]
+    for label, ours, theirs, fmt in rows:
+        lines.append(f"{label:<18} {fmt.format(ours):>10} {fmt.format(theirs):>10}")
+    return lines
+
+
+def manage_canary(args) -> int:
+    """Main entry point for the canary command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = CanaryCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(result.message if result.data is not None else f"Error: {result.message}")
+    return 1
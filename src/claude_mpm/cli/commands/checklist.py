@@ -0,0 +1,125 @@
+"""
+Checklist command implementation for claude-mpm.
+
+WHY: Shows how far a repository (or many) has got with onboarding, so
+platform teams can track adoption without checking out every repo.
+
+DESIGN DECISIONS:
+- Thin wrapper around Checklist
+- ``status`` with several projects prints one line per project; --json
+  prints the full results for collecting them elsewhere
+- ``status --strict`` fails unless every step is done, so it can gate CI
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.checklist import Checklist
+from ..shared import BaseCommand, CommandResult
+
+
+class ChecklistCommand(BaseCommand):
+    """CLI command for the onboarding checklist."""
+
+    VALID_COMMANDS = ("status", "init", "done", "undo")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("checklist")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "checklist_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm checklist {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "status": self._status,
+            "init": self._init,
+            "done": self._done,
+            "undo": self._undo,
+        }
+        try:
+            return handlers[args.checklist_command](args)
+        except (FileExistsError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing checklist command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing checklist command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _status(self, args) -> CommandResult:
+        projects = [Path(p) for p in getattr(args, "projects", None) or []]
+        statuses = []
+        for project in projects or [self.project_dir]:
+            if not project.is_dir():
+                return CommandResult.error_result(f"Not a directory: {project}")
+            statuses.append(Checklist(project).status())
+        data = [status.to_dict() for status in statuses]
+
+        if getattr(args, "json", False):
+            message = json.dumps(data if projects else data[0], indent=2)
+        elif len(statuses) == 1:
+            message = statuses[0].render()
+        else:
+            width = max(len(str(status.project_root)) for status in statuses)
+            lines = []
+            for status in statuses:
+                missing = [r.id for r in status.results if not r.done]
+                lines.append(
+                    f"{str(status.project_root):<{width}}  "
+                    f"{status.done}/{len(status.results)}  "
+                    + (f"missing: {', '.join(missing)}" if missing else "complete")
+                )
+            message = "\n".join(lines)
+
+        if getattr(args, "strict", False) and not all(s.complete for s in statuses):
+            return CommandResult.error_result(message, data=data)
+        return CommandResult.success_result(message, data=data)
+
+    def _init(self, args) -> CommandResult:
+        path = Checklist(self.project_dir).write_default()
+        return CommandResult.success_result(f"Wrote {path}")
+
+    def _done(self, args) -> CommandResult:
+        step = Checklist(self.project_dir).mark(args.step, note=args.note)
+        return CommandResult.success_result(f"✓ {step.title}")
+
+    def _undo(self, args) -> CommandResult:
+        step = Checklist(self.project_dir).mark(args.step, done=False)
+        return CommandResult.success_result(f"✗ {step.title}")
+
+
+def manage_checklist(args) -> int:
+    """Main entry point for the checklist command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = ChecklistCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        # An incomplete --strict status is a report, not an error message
+        print(result.message if result.data else f"Error: {result.message}")
+    return 1
@@ -74,6 +74,21 @@ def add_cleanup_parser(subparsers):
         help="Show what would be cleaned without making changes",
     )
 
+    parser.add_argument(
+        "--orphans",
+        action="store_true",
+        help=(
+            "Reap runtime leftovers from crashed sessions (stale PID/socket "
+            "files, servers for deleted projects) instead of conversation history"
+        ),
+    )
+
+    parser.add_argument(
+        "--include-tmux",
+        action="store_true",
+        help="With --orphans, also kill detached claude-mpm tmux sessions",
+    )
+
     parser.set_defaults(func=cleanup_memory)
 
 
@@ -411,11 +426,51 @@ def cleanup_memory(args):
 
     This function maintains backward compatibility while using the new BaseCommand pattern.
     """
+    if getattr(args, "orphans", False):
+        _cleanup_orphans(args)
+        return
+
     # For complex interactive commands like this, we'll delegate to the original implementation
     # but could be refactored to use the new pattern in the future
     _cleanup_memory_original(args)
 
 
+def _cleanup_orphans(args):
+    """Detect and reap runtime orphans left by crashed or killed sessions."""
+    from ...services.runtime_orphans import RuntimeOrphanScanner
+
+    print("🧹 Claude MPM Orphan Cleanup")
+    print("=" * 50)
+
+    scanner = RuntimeOrphanScanner()
+    orphans = scanner.scan(include_tmux=getattr(args, "include_tmux", False))
+
+    if not orphans:
+        print("✅ No orphaned runtime resources found")
+        return
+
+    print(f"\n🔍 Found {len(orphans)} orphaned resource(s):")
+    for orphan in orphans:
+        print(f"   • {orphan.describe()}")
+
+    if args.dry_run:
+        print("\n🔍 DRY RUN MODE - No changes made")
+        return
+
+    if not args.force:
+        print()
+        response = input("Reap these resources? [y/N]: ").strip().lower()
+        if response != "y":
+            print("❌ Cleanup cancelled")
+            return
+
+    results = scanner.reap(orphans)
+    failed = [(orphan, message) for orphan, ok, message in results if not ok]
+    print(f"\n✅ Reaped {len(results) - len(failed)} of {len(results)} resource(s)")
+    for orphan, message in failed:
+        print(f"   ❌ {orphan.target}: {message}")
+
+
 def _cleanup_memory_original(args):
     """Original cleanup implementation for backward compatibility."""
     from ...core.logger import get_logger
@@ -73,7 +73,7 @@ class ConfigCommand(BaseCommand):
             args.config_command = "auto"
             args.preview = True  # Default to preview when no args
 
-        valid_commands = ["validate", "view", "status", "auto", "gitignore"]
+        valid_commands = ["validate", "view", "status", "auto", "gitignore", "reload"]
         if args.config_command not in valid_commands:
             return f"Unknown config command: {args.config_command}. Valid commands: {', '.join(valid_commands)}"
 
@@ -92,10 +92,41 @@ class ConfigCommand(BaseCommand):
             return CommandResult.success_result("Gitignore recommendations displayed")
         if args.config_command == "auto":
             return self._auto_configure(args)
+        if args.config_command == "reload":
+            return self._reload_config(args)
         return CommandResult.error_result(
             f"Unknown config command: {args.config_command}"
         )
 
+    def _reload_config(self, args) -> CommandResult:
+        """Make the running daemon service apply its configuration again."""
+        from ...services.daemon_service import (
+            DaemonServiceError,
+            request_service_reload,
+        )
+
+        try:
+            pid, result = request_service_reload(
+                timeout=getattr(args, "timeout", 10.0)
+            )
+        except DaemonServiceError as e:
+            console.print(f"[red]✗ {e}[/red]")
+            return CommandResult.error_result(str(e))
+
+        data = {"pid": pid, **result.to_dict()}
+        if result.error:
+            message = f"Daemon service (PID {pid}) {result.summary()}"
+            console.print(f"[red]✗ {message}[/red]")
+            return CommandResult.error_result(result.error, data=data)
+        console.print(f"[green]✓ Reloaded daemon service (PID {pid})[/green]")
+        for change in result.changes or ["no changes"]:
+            console.print(f"  {change}")
+        for setting in result.restart_required:
+            console.print(
+                f"[yellow]⚠ Restart the service to apply {setting}[/yellow]"
+            )
+        return CommandResult.success_result("Configuration reloaded", data=data)
+
     def _validate_config(self, args) -> CommandResult:
         """Validate configuration file."""
         config_file = getattr(args, "config_file", None) or Path(
@@ -0,0 +1,198 @@
+"""
+Daemon command implementation for claude-mpm.
+
+WHY: Gives users one command to start, stop and inspect the shared daemon
+(per-project event servers, scheduler, agent watchers and Commander),
+replacing the per-project monitor daemons that used to accumulate silently.
+
+DESIGN DECISIONS:
+- Thin wrapper around SharedDaemon; all state handling lives in the service
+- ``start`` attaches the current project by default so ``daemon start`` in a
+  project directory is all most users need
+- ``run`` (or ``daemon --config DIR``) blocks in the foreground via
+  DaemonService for container and systemd deployments
+- Exports manage_daemon(args) as the main entry point
+"""
+
+from __future__ import annotations
+
+import json
+from datetime import datetime
+from pathlib import Path
+
+from ...services.daemon_service import (
+    DaemonService,
+    DaemonServiceConfig,
+    ReloadResult,
+)
+from ...services.shared_daemon import SharedDaemon
+from ..shared import BaseCommand, CommandResult
+
+
+class DaemonCommand(BaseCommand):
+    """CLI command for the shared user-level daemon."""
+
+    VALID_COMMANDS = ("start", "stop", "status", "attach", "detach", "run")
+
+    def __init__(self, daemon: SharedDaemon | None = None):
+        super().__init__("daemon")
+        self.daemon = daemon or SharedDaemon()
+
+    def validate_args(self, args) -> str | None:
+        daemon_command = getattr(args, "daemon_command", None)
+        if daemon_command and daemon_command not in self.VALID_COMMANDS:
+            return (
+                f"Unknown daemon command: {daemon_command}. "
+                f"Valid commands: {', '.join(self.VALID_COMMANDS)}"
+            )
+        return None
+
+    def run(self, args) -> CommandResult:
+        daemon_command = getattr(args, "daemon_command", None)
+        if not daemon_command:
+            daemon_command = (
+                "run" if getattr(args, "service_config", None) else "status"
+            )
+        handlers = {
+            "start": self._start,
+            "run": self._run,
+            "stop": self._stop,
+            "status": self._status,
+            "attach": self._attach,
+            "detach": self._detach,
+        }
+        try:
+            return handlers[daemon_command](args)
+        except Exception as exc:
+            self.logger.error("Error executing daemon command: %s", exc, exc_info=True)
+            return CommandResult.error_result(f"Error executing daemon command: {exc}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _start(self, args) -> CommandResult:
+        results = self.daemon.start(only=getattr(args, "components", None))
+        if not getattr(args, "no_attach", False):
+            self.daemon.attach(Path.cwd())
+
+        failed = [name for name, ok in results.items() if not ok]
+        summary = ", ".join(
+            f"{name}: {'running' if ok else 'FAILED'}" for name, ok in results.items()
+        )
+        if failed:
+            return CommandResult.error_result(
+                f"Shared daemon started with failures ({summary})",
+                data={"components": results},
+            )
+        return CommandResult.success_result(
+            f"Shared daemon running ({summary})", data={"components": results}
+        )
+
+    def _stop(self, args) -> CommandResult:
+        results = self.daemon.stop()
+        failed = [name for name, ok in results.items() if not ok]
+        if failed:
+            return CommandResult.error_result(
+                f"Failed to stop: {', '.join(failed)}", data={"components": results}
+            )
+        return CommandResult.success_result(
+            "Shared daemon stopped", data={"components": results}
+        )
+
+    def _run(self, args) -> CommandResult:
+        config_dir = getattr(args, "service_config", None)
+        config = DaemonServiceConfig.load(config_dir)
+        self.daemon.host = config.host
+        exit_code = DaemonService(config, self.daemon, config_dir=config_dir).run()
+        if exit_code:
+            return CommandResult.error_result("Daemon service exited with errors")
+        return CommandResult.success_result("Daemon service stopped")
+
+    def _status(self, args) -> CommandResult:
+        status = self.daemon.status()
+        if getattr(args, "json", False):
+            print(json.dumps(status, indent=2, default=str))
+            return CommandResult.success_result("", data=status)
+
+        lines = [
+            f"Shared daemon: {'running' if status['running'] else 'not running'}"
+        ]
+        if status.get("started_at"):
+            started = datetime.fromtimestamp(status["started_at"])
+            lines.append(f"  started: {started:%Y-%m-%d %H:%M:%S}")
+        service = self.daemon.service()
+        if service and service.get("pid"):
+            config_dir = service.get("config_dir") or "defaults"
+            lines.append(f"  service: PID {service['pid']} (config: {config_dir})")
+            reload = service.get("last_reload")
+            if reload:
+                reloaded = datetime.fromtimestamp(reload["reloaded_at"])
+                summary = ReloadResult.from_dict(reload).summary()
+                lines.append(f"  reloaded: {reloaded:%Y-%m-%d %H:%M:%S} ({summary})")
+
+        lines.append("Components:")
+        for name, info in status["components"].items():
+            instances = info.get("instances")
+            if instances is not None:
+                running = sum(1 for i in instances.values() if i.get("running"))
+                lines.append(f"  {name:<16} {running}/{len(instances)} projects")
+                continue
+            state = "running" if info.get("running") else "stopped"
+            pid = f" (PID {info['pid']})" if info.get("pid") else ""
+            port = f" port {info['port']}" if info.get("port") else ""
+            lines.append(f"  {name:<16} {state}{port}{pid}")
+
+        lines.append("Attached projects:")
+        if not status["projects"]:
+            lines.append("  (none)")
+        for root, info in sorted(status["projects"].items()):
+            port = f"port {info['port']}" if info.get("port") else ""
+            lines.append(f"  {info['namespace']:<32} {port:<10} {root}")
+
+        return CommandResult.success_result("\n".join(lines), data=status)
+
+    def _attach(self, args) -> CommandResult:
+        project = getattr(args, "project", None) or Path.cwd()
+        entry = self.daemon.attach(project)
+        return CommandResult.success_result(
+            f"Attached {Path(project).resolve()} as namespace {entry['namespace']}",
+            data=entry,
+        )
+
+    def _detach(self, args) -> CommandResult:
+        project = getattr(args, "project", None) or Path.cwd()
+        if self.daemon.detach(project):
+            return CommandResult.success_result(f"Detached {Path(project).resolve()}")
+        return CommandResult.error_result(
+            f"{Path(project).resolve()} is not attached to the shared daemon"
+        )
+
+
+def manage_daemon(args) -> int:
+    """Main entry point for the daemon command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = DaemonCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
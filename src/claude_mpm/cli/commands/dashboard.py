@@ -9,15 +9,19 @@ DESIGN DECISIONS:
 - Support both foreground and background operation modes
 - Integrate with EventBus for real-time event streaming
 - Provide browser auto-opening functionality
+- Auto-select ports via the user-level ServerDiscoveryRegistry so several
+  projects can run dashboards side by side
 """
 
 import signal
 import sys
+from pathlib import Path
 
 from ...constants import DashboardCommands
 from ...services.cli.unified_dashboard_manager import UnifiedDashboardManager
 from ...services.monitor.daemon import UnifiedMonitorDaemon
 from ...services.port_manager import PortManager
+from ...services.server_discovery import ServerDiscoveryRegistry
 from ..shared import BaseCommand, CommandResult
 
 
@@ -28,6 +32,7 @@ class DashboardCommand(BaseCommand):
         super().__init__("dashboard")
         self.dashboard_manager = UnifiedDashboardManager(self.logger)
         self.port_manager = PortManager()
+        self.discovery = ServerDiscoveryRegistry()
         self.server = None
 
     def validate_args(self, args) -> str | None:
@@ -51,6 +56,8 @@ class DashboardCommand(BaseCommand):
                 DashboardCommands.STOP.value: self._stop_dashboard,
                 DashboardCommands.STATUS.value: self._status_dashboard,
                 DashboardCommands.OPEN.value: self._open_dashboard,
+                DashboardCommands.LIST.value: self._list_dashboards,
+                DashboardCommands.SHARE.value: self._share_session,
             }
 
             if args.dashboard_command in command_map:
@@ -64,9 +71,33 @@ class DashboardCommand(BaseCommand):
             self.logger.error(f"Error executing dashboard command: {e}", exc_info=True)
             return CommandResult.error_result(f"Error executing dashboard command: {e}")
 
+    def _resolve_port(self, args, negotiate: bool) -> int | None:
+        """Determine which port a subcommand should act on.
+
+        An explicit ``--port`` always wins. Otherwise use the port this
+        project's server is registered on; when none is running and
+        ``negotiate`` is set, pick a free port no other project has claimed.
+        """
+        port = getattr(args, "port", None)
+        if port:
+            return port
+
+        entry = self.discovery.lookup(Path.cwd())
+        if entry:
+            return entry["port"]
+        if negotiate:
+            return self.discovery.negotiate_port(
+                Path.cwd(), host=getattr(args, "host", "localhost")
+            )
+        return self.port_manager.DEFAULT_PORT
+
     def _start_dashboard(self, args) -> CommandResult:
         """Start the dashboard server."""
-        port = getattr(args, "port", 8765)
+        port = self._resolve_port(args, negotiate=True)
+        if port is None:
+            return CommandResult.error_result(
+                "No free dashboard port available (8765-8785)"
+            )
         host = getattr(args, "host", "localhost")
         background = getattr(args, "background", False)
         use_stable = getattr(args, "stable", True)  # Default to stable server
@@ -141,7 +172,7 @@ class DashboardCommand(BaseCommand):
 
     def _stop_dashboard(self, args) -> CommandResult:
         """Stop the dashboard server."""
-        port = getattr(args, "port", 8765)
+        port = self._resolve_port(args, negotiate=False)
 
         self.logger.info(f"Stopping dashboard on port {port}")
 
@@ -158,8 +189,8 @@ class DashboardCommand(BaseCommand):
         verbose = getattr(args, "verbose", False)
         show_ports = getattr(args, "show_ports", False)
 
-        # Check default port first
-        default_port = 8765
+        # Check this project's registered port first (8765 if none)
+        default_port = self._resolve_port(args, negotiate=False)
         dashboard_running = self.dashboard_manager.is_dashboard_running(default_port)
 
         status_data = {
@@ -208,7 +239,11 @@ class DashboardCommand(BaseCommand):
 
     def _open_dashboard(self, args) -> CommandResult:
         """Open the dashboard in a browser, starting it if necessary."""
-        port = getattr(args, "port", 8765)
+        port = self._resolve_port(args, negotiate=True)
+        if port is None:
+            return CommandResult.error_result(
+                "No free dashboard port available (8765-8785)"
+            )
 
         # Check if dashboard is running
         if not self.dashboard_manager.is_dashboard_running(port):
@@ -240,6 +275,84 @@ class DashboardCommand(BaseCommand):
             data={"url": dashboard_url, "port": port},
         )
 
+    def _list_dashboards(self, args) -> CommandResult:
+        """List dashboard servers registered by every local project."""
+        self.discovery.prune_dead()
+        servers = self.discovery.list_servers()
+        current = str(Path.cwd().resolve())
+
+        if getattr(args, "json", False):
+            import json
+
+            print(json.dumps(servers, indent=2))
+            return CommandResult.success_result("", data={"servers": servers})
+
+        if not servers:
+            return CommandResult.success_result(
+                "No dashboard servers running", data={"servers": []}
+            )
+
+        lines = ["Local dashboard servers:"]
+        for info in servers:
+            marker = "*" if info.get("project_root") == current else " "
+            url = f"http://{info.get('host', 'localhost')}:{info['port']}"
+            lines.append(f" {marker} {url:<28} {info.get('project_root')}")
+        return CommandResult.success_result(
+            "\n".join(lines), data={"servers": servers}
+        )
+
+    def _share_session(self, args) -> CommandResult:
+        """Create, list or revoke read-only observer links."""
+        from ...services.monitor.observers import ObserverLinks
+
+        links = ObserverLinks(Path.cwd())
+        if getattr(args, "revoke", None):
+            if not links.revoke(args.revoke):
+                return CommandResult.error_result(f"No observer link {args.revoke}")
+            return CommandResult.success_result(
+                f"Revoked observer link {args.revoke}", data={"id": args.revoke}
+            )
+
+        if getattr(args, "list", False):
+            active = [link.to_dict() for link in links.active()]
+            if not active:
+                return CommandResult.success_result(
+                    "No observer links", data={"links": []}
+                )
+            lines = ["Observer links:"]
+            for link in active:
+                label = f" ({link['label']})" if link["label"] else ""
+                lines.append(
+                    f"  {link['id']}  session {link['session_id']}{label}, "
+                    f"expires {link['expires_at'][:16].replace('T', ' ')} UTC"
+                )
+            return CommandResult.success_result(
+                "\n".join(lines), data={"links": active}
+            )
+
+        if not getattr(args, "session_id", None):
+            return CommandResult.error_result(
+                "A session id is required (or use --list / --revoke)"
+            )
+        try:
+            link, token = links.create(
+                args.session_id, hours=args.hours, label=args.label
+            )
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        port = self._resolve_port(args, negotiate=False)
+        url = f"{self.dashboard_manager.get_dashboard_url(port)}/observe/{token}"
+        message = (
+            f"Read-only link to session {link.session_id} "
+            f"(id {link.id}, expires {link.expires_at[:16].replace('T', ' ')} UTC):\n"
+            f"  {url}\n"
+            "Anyone with the link can watch the session; revoke it with "
+            f"'claude-mpm dashboard share --revoke {link.id}'"
+        )
+        return CommandResult.success_result(
+            message, data={"link": link.to_dict(), "url": url}
+        )
+
     def _check_port_available(self, port: int) -> bool:
         """Check if a port is available for binding."""
         import socket
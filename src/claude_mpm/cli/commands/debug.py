@@ -8,6 +8,7 @@ This module provides comprehensive debugging tools for developers, including:
 - Cache inspection and management
 - Performance profiling and analysis
 - SocketIO event monitoring
+- Redacted diagnostics bundles for bug reports
 """
 
 import contextlib
@@ -52,10 +53,43 @@ def manage_debug(args):
         return debug_cache(args, logger)
     if args.debug_command == "performance":
         return debug_performance(args, logger)
+    if args.debug_command == "bundle":
+        return debug_bundle(args, logger)
     logger.error(f"Unknown debug command: {args.debug_command}")
     return 1
 
 
+def debug_bundle(args, logger):
+    """
+    Create a redacted diagnostics archive suitable for bug reports.
+
+    Args:
+        args: Parsed command-line arguments
+        logger: Logger instance
+
+    Returns:
+        int: Exit code
+    """
+    from ...services.diagnostics.debug_bundle import DebugBundleBuilder
+
+    builder = DebugBundleBuilder(
+        max_log_lines=getattr(args, "log_lines", 500),
+        max_events=getattr(args, "max_events", 200),
+    )
+    try:
+        path, manifest = builder.build(getattr(args, "output", None))
+    except OSError as e:
+        logger.error(f"Failed to create debug bundle: {e}")
+        return 1
+
+    print(f"📦 Debug bundle written to {path}")
+    print(f"   {len(manifest['files'])} file(s) included, secrets redacted")
+    for skipped in manifest["skipped"]:
+        print(f"   ⚠️  skipped {skipped}")
+    print("   Review the archive before attaching it to a bug report.")
+    return 0
+
+
 def debug_socketio(args, logger):
     """
     Debug SocketIO events using the professional debugging tool.
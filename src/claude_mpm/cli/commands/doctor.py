@@ -62,6 +62,8 @@ def add_doctor_parser(subparsers):
             "claude",
             "agents",
             "agent-sources",
+            "dev-environment",
+            "containers",
             "mcp",
             "memory-capture",
             "monitor",
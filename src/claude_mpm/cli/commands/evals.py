@@ -0,0 +1,200 @@
+"""
+Eval command implementation for claude-mpm.
+
+WHY: After editing an agent's instructions, ``claude-mpm eval run`` answers
+"did anything get worse?" with a score and a list of regressed cases.
+
+DESIGN DECISIONS:
+- Thin wrapper around EvalRunner and EvalHistory in the service layer
+- Each run is compared with the previous run of the same suite and agent
+  before being recorded; regressions make the command exit non-zero
+- Cases stream as they finish, since live cases can take minutes
+"""
+
+from __future__ import annotations
+
+import json
+
+from ...services.evals import (
+    EvalHistory,
+    EvalRunner,
+    EvalSuiteError,
+    find_regressions,
+    load_suite,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class EvalCommand(BaseCommand):
+    """CLI command for agent evaluation suites."""
+
+    VALID_COMMANDS = ("run", "history")
+
+    def __init__(
+        self, runner: EvalRunner | None = None, history: EvalHistory | None = None
+    ):
+        super().__init__("eval")
+        self.runner = runner
+        self.history = history or EvalHistory()
+
+    def validate_args(self, args) -> str | None:
+        eval_command = getattr(args, "eval_command", None)
+        if eval_command not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm eval {{{','.join(self.VALID_COMMANDS)}}}"
+        min_score = getattr(args, "min_score", None)
+        if min_score is not None and not 0 <= min_score <= 1:
+            return "--min-score must be between 0 and 1"
+        return None
+
+    def run(self, args) -> CommandResult:
+        try:
+            if args.eval_command == "history":
+                return self._history(args)
+            return self._run(args)
+        except EvalSuiteError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing eval command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing eval command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _make_runner(self, args) -> EvalRunner:
+        if self.runner is not None:
+            return self.runner
+        replay = getattr(args, "replay", None)
+        factory = None
+        if replay:
+            from ...services.agents.playground import ReplayResponder
+
+            responder = ReplayResponder(replay)
+
+            def factory(_config):
+                return responder
+
+        return EvalRunner(
+            runtime_factory=factory,
+            keep_workspaces=getattr(args, "keep_workspaces", False),
+        )
+
+    def _run(self, args) -> CommandResult:
+        suite = load_suite(args.suite)
+        as_json = getattr(args, "json", False)
+
+        def report(case):
+            if as_json:
+                return
+            icon = "✅" if case.passed else "❌"
+            print(f"{icon} {case.id:<24} score {case.score:.2f} ({case.duration:.1f}s)")
+            if case.error:
+                print(f"     error: {case.error}")
+            for assertion in case.assertions:
+                if not assertion.passed:
+                    print(f"     {assertion.type}: {assertion.detail}")
+            if case.workspace:
+                print(f"     workspace: {case.workspace}")
+
+        if not as_json:
+            print(f"Running suite '{suite.name}' ({len(suite.cases)} cases)")
+        result = self._make_runner(args).run(
+            suite, agent=args.agent, model=args.model, on_case=report
+        )
+
+        record = result.to_record()
+        previous = self.history.previous(result.suite, result.agent)
+        regressions = find_regressions(previous, record) if previous else []
+        if not getattr(args, "no_history", False):
+            self.history.record(record)
+
+        data = {
+            **result.to_dict(),
+            "previous": previous,
+            "regressions": regressions,
+        }
+        lines = [
+            f"Suite '{result.suite}' on {result.agent} v{result.agent_version} "
+            f"(prompt {result.prompt_hash}): score {result.score:.2f}, "
+            f"{result.passed}/{len(result.cases)} cases passed"
+        ]
+        if previous:
+            delta = record["score"] - previous["score"]
+            lines.append(
+                f"Previous run: {previous['score']:.2f} "
+                f"(v{previous['agent_version']}, {previous['timestamp'][:19]}), "
+                f"change {delta:+.2f}"
+            )
+        for reg in regressions:
+            status = "now failing" if reg["now_failing"] else "score dropped"
+            lines.append(
+                f"  REGRESSION {reg['id']}: {reg['before']:.2f} → "
+                f"{reg['after']:.2f} ({status})"
+            )
+
+        if as_json:
+            print(json.dumps(data, indent=2))
+            lines = []
+        message = "\n".join(lines)
+
+        min_score = getattr(args, "min_score", None)
+        if regressions:
+            return CommandResult.error_result(
+                message or "regressions detected", data=data
+            )
+        if min_score is not None and result.score < min_score:
+            return CommandResult.error_result(
+                message or f"score below {min_score}", data=data
+            )
+        return CommandResult.success_result(message, data=data)
+
+    def _history(self, args) -> CommandResult:
+        runs = self.history.runs(suite=args.suite, agent=args.agent)[-args.limit :]
+        if getattr(args, "json", False):
+            print(json.dumps(runs, indent=2))
+            return CommandResult.success_result("", data={"runs": runs})
+        if not runs:
+            return CommandResult.success_result(
+                "No evaluation runs recorded", data={"runs": []}
+            )
+
+        lines = [
+            f"{'timestamp':<20} {'suite':<20} {'agent':<16} {'version':<10} "
+            f"{'prompt':<12} {'score':>5}  passed"
+        ]
+        for run in runs:
+            lines.append(
+                f"{run['timestamp'][:19]:<20} {run['suite']:<20} {run['agent']:<16} "
+                f"{run['agent_version']:<10} {run['prompt_hash']:<12} "
+                f"{run['score']:>5.2f}  {run['passed']}/{run['total']}"
+            )
+        return CommandResult.success_result("\n".join(lines), data={"runs": runs})
+
+
+def manage_eval(args) -> int:
+    """Main entry point for the eval command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = EvalCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
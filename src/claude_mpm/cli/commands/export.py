@@ -0,0 +1,106 @@
+"""
+Export command implementation for claude-mpm.
+
+WHY: Lets teams pull their session data into their own BI tools instead of
+relying only on the built-in cost, label and adoption reports.
+
+DESIGN DECISIONS:
+- Thin wrapper around AnalyticsExport, which holds the table schemas
+- Reports the files written and their row counts, so a scheduled export
+  job's log shows what it loaded
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.analytics_export import AnalyticsExport, parse_since
+from ..shared import BaseCommand, CommandResult
+
+
+class ExportCommand(BaseCommand):
+    """CLI command for exporting session data."""
+
+    VALID_COMMANDS = ("analytics",)
+
+    def __init__(self):
+        super().__init__("export")
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "export_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm export {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {"analytics": self._analytics}
+        try:
+            return handlers[args.export_command](args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing export command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing export command: {e}")
+
+    def _analytics(self, args) -> CommandResult:
+        export = AnalyticsExport(
+            since=parse_since(args.since), include_text=args.include_text
+        )
+        projects = [Path(p).expanduser() for p in args.project or [Path.cwd()]]
+        sessions = 0
+        for project in projects:
+            if not project.is_dir():
+                raise ValueError(f"Project directory not found: {project}")
+            sessions += export.add_project(project)
+        written = export.write(Path(args.output), args.format, args.table)
+
+        data = {
+            "format": args.format,
+            "since": args.since,
+            "projects": [str(p.resolve()) for p in projects],
+            "sessions": sessions,
+            "files": {
+                table: {"path": str(path), "rows": len(export.rows[table])}
+                for table, path in written.items()
+            },
+            "skipped": [{"path": p, "error": e} for p, e in export.skipped],
+        }
+        if args.json:
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        lines = [f"Exported {sessions} session(s) since {args.since}:"]
+        lines += [
+            f"  {path}  ({len(export.rows[table])} rows)"
+            for table, path in written.items()
+        ]
+        if export.skipped:
+            lines.append(f"Skipped {len(export.skipped)} unreadable transcript(s)")
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+
+def manage_export(args) -> int:
+    """Main entry point for the export command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = ExportCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
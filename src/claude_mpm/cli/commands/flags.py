@@ -0,0 +1,124 @@
+"""
+Flags command implementation for claude-mpm.
+
+WHY: Users opt into preview features without setting environment variables
+or patching code, and can see which previews are on and why.
+
+DESIGN DECISIONS:
+- Thin wrapper around FeatureFlags; ``list`` refreshes the organization's
+  flag config first so it shows what the next session will get
+- ``enable``/``disable`` print the layer that still wins when an environment
+  variable overrides the new choice, rather than silently having no effect
+"""
+
+from __future__ import annotations
+
+import json
+from collections.abc import Callable
+from pathlib import Path
+
+from ...services.feature_flags import ENV, ENV_PREFIX, FeatureFlags, refresh_org_flags
+from ...utils.table_view import TableView
+from ..list_columns import FLAG_COLUMNS
+from ..shared import BaseCommand, CommandResult
+
+
+class FlagsCommand(BaseCommand):
+    """CLI command for feature flags."""
+
+    VALID_COMMANDS = ("list", "enable", "disable")
+
+    def __init__(
+        self,
+        project_root: Path | None = None,
+        refresh: Callable[[], object] | None = refresh_org_flags,
+    ):
+        super().__init__("flags")
+        self.project_root = project_root
+        self.refresh = refresh
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "flags_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm flags {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "list": self._list,
+            "enable": self._set,
+            "disable": self._set,
+        }
+        try:
+            return handlers[args.flags_command](args)
+        except (KeyError, ValueError) as e:
+            return CommandResult.error_result(str(e.args[0]) if e.args else str(e))
+        except Exception as e:
+            self.logger.error("Error executing flags command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing flags command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _list(self, args) -> CommandResult:
+        if self.refresh is not None:
+            self.refresh()
+        states = FeatureFlags(self.project_root).states()
+        data = [state.to_dict() for state in states]
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        rows = [
+            {**row, "source": row["source"] + (" (locked)" if row["locked"] else "")}
+            for row in data
+        ]
+        table = TableView.from_args(FLAG_COLUMNS, rows, args)
+        return CommandResult.success_result(table.render(), data=data)
+
+    def _set(self, args) -> CommandResult:
+        enabled = args.flags_command == "enable"
+        project = getattr(args, "project", False)
+        flags = FeatureFlags(self.project_root)
+        path = flags.set(args.flag, enabled, project=project)
+        state = flags.state(args.flag)
+        word = "on" if enabled else "off"
+        lines = [f"Turned '{args.flag}' {word} ({path})"]
+        if state.enabled != enabled and state.source == ENV:
+            lines.append(
+                f"{ENV_PREFIX}{args.flag.upper()} is set and still turns it "
+                f"{'on' if state.enabled else 'off'} in this shell"
+            )
+        elif state.enabled != enabled:
+            lines.append(
+                f"The {state.source} setting still turns it "
+                f"{'on' if state.enabled else 'off'} here"
+            )
+        return CommandResult.success_result("\n".join(lines), data=state.to_dict())
+
+
+def manage_flags(args) -> int:
+    """Main entry point for the flags command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = FlagsCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
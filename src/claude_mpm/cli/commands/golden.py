@@ -0,0 +1,254 @@
+"""
+Golden command implementation for claude-mpm.
+
+WHY: ``claude-mpm golden run`` after an upgrade answers "do the agents still
+work the way they did?" by replaying recorded sessions and diffing the tool
+calls each prompt triggered.
+
+DESIGN DECISIONS:
+- Thin wrapper around GoldenStore, GoldenReplayer and diff_turns
+- ``--against`` diffs a session that already ran, for when the replay was
+  done by hand or in CI with its own runner
+- Any difference makes the command exit non-zero so it can gate upgrades
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.evals.golden import (
+    GoldenDiff,
+    GoldenReplayer,
+    GoldenStore,
+    diff_turns,
+    record_golden,
+)
+from ...services.model.recordings import load_recording
+from ..shared import BaseCommand, CommandResult
+
+
+class GoldenCommand(BaseCommand):
+    """CLI command for golden transcript regression tests."""
+
+    VALID_COMMANDS = ("record", "run", "list", "show")
+
+    def __init__(
+        self,
+        store: GoldenStore | None = None,
+        replayer: GoldenReplayer | None = None,
+    ):
+        super().__init__("golden")
+        self.store = store or GoldenStore()
+        self.replayer = replayer
+
+    def validate_args(self, args) -> str | None:
+        golden_command = getattr(args, "golden_command", None)
+        if golden_command not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm golden {{{','.join(self.VALID_COMMANDS)}}}"
+        if golden_command == "run" and getattr(args, "against", None):
+            if len(getattr(args, "names", []) or []) != 1:
+                return "--against needs exactly one golden name"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "record": self._record,
+            "run": self._run,
+            "list": self._list,
+            "show": self._show,
+        }
+        try:
+            return handlers[args.golden_command](args)
+        except (FileNotFoundError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing golden command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing golden command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _record(self, args) -> CommandResult:
+        if self.store.exists(args.name) and not getattr(args, "force", False):
+            return CommandResult.error_result(
+                f"golden '{args.name}' already exists (use --force to overwrite)"
+            )
+        transcript = _resolve_session(getattr(args, "session", None))
+        golden = record_golden(args.name, transcript, str(Path.cwd()))
+        path = self.store.save(golden)
+        return CommandResult.success_result(
+            f"Recorded golden '{golden.name}': {len(golden.turns)} turns, "
+            f"{golden.tool_call_count} tool calls → {path}",
+            data=golden.to_dict(),
+        )
+
+    def _run(self, args) -> CommandResult:
+        names = args.names or [g.name for g in self.store.load_all()]
+        if not names:
+            return CommandResult.error_result(
+                "No golden transcripts recorded (see 'claude-mpm golden record')"
+            )
+
+        as_json = getattr(args, "json", False)
+        diffs = [self._run_one(name, args) for name in names]
+        data = {"goldens": [d.to_dict() for d in diffs]}
+        regressed = [d.name for d in diffs if d.regressed]
+
+        if as_json:
+            print(json.dumps(data, indent=2))
+            message = ""
+        else:
+            message = "\n".join(_format_diff(d) for d in diffs)
+            message += (
+                f"\n\n{len(diffs) - len(regressed)}/{len(diffs)} goldens unchanged"
+            )
+
+        if regressed:
+            return CommandResult.error_result(
+                message or f"behaviour changed: {', '.join(regressed)}", data=data
+            )
+        return CommandResult.success_result(message, data=data)
+
+    def _run_one(self, name: str, args) -> GoldenDiff:
+        golden = self.store.load(name)
+        strict = getattr(args, "strict", False)
+        against = getattr(args, "against", None)
+        if against:
+            transcript = _resolve_session(against)
+            return diff_turns(
+                name,
+                golden.turns,
+                load_recording(transcript),
+                golden.cwd,
+                str(Path.cwd()),
+                strict,
+            )
+
+        replayer = self.replayer or GoldenReplayer(
+            in_place=getattr(args, "in_place", False),
+            keep_workspace=getattr(args, "keep_workspace", False),
+        )
+        if not getattr(args, "json", False):
+            print(f"Replaying '{name}' ({len(golden.turns)} turns)...")
+        try:
+            actual = replayer.replay(
+                golden,
+                model=getattr(args, "model", None),
+                permission_mode=getattr(args, "permission_mode", "acceptEdits"),
+            )
+        except Exception as e:
+            self.logger.debug(f"Replay of {name} failed", exc_info=True)
+            return GoldenDiff(name=name, turns=[], error=f"{type(e).__name__}: {e}")
+        return diff_turns(
+            name, golden.turns, actual, golden.cwd, str(replayer.workspace), strict
+        )
+
+    def _list(self, args) -> CommandResult:
+        goldens = self.store.load_all()
+        if not goldens:
+            return CommandResult.success_result(
+                "No golden transcripts recorded", data={"goldens": []}
+            )
+        lines = [f"{'name':<28} {'turns':>5} {'tools':>5}  recorded with"]
+        for golden in goldens:
+            lines.append(
+                f"{golden.name:<28} {len(golden.turns):>5} "
+                f"{golden.tool_call_count:>5}  v{golden.framework_version} "
+                f"({golden.created_at[:10]})"
+            )
+        return CommandResult.success_result(
+            "\n".join(lines), data={"goldens": [g.name for g in goldens]}
+        )
+
+    def _show(self, args) -> CommandResult:
+        golden = self.store.load(args.name)
+        lines = [
+            f"Golden '{golden.name}' (v{golden.framework_version}, {golden.cwd})",
+        ]
+        for index, turn in enumerate(golden.turns, 1):
+            lines.append(f"\n[{index}] {_first_line(turn.prompt)}")
+            for call in turn.tool_calls:
+                lines.append(f"    → {call.name}")
+            lines.append(f"    ← {_first_line(turn.reply)}")
+        return CommandResult.success_result("\n".join(lines), data=golden.to_dict())
+
+
+def _first_line(text: str, width: int = 100) -> str:
+    line = (text.strip().splitlines() or [""])[0]
+    return line if len(line) <= width else line[: width - 1] + "…"
+
+
+def _format_diff(diff: GoldenDiff) -> str:
+    icon = "❌" if diff.regressed else "✅"
+    lines = [f"{icon} {diff.name}"]
+    if diff.error:
+        lines.append(f"     error: {diff.error}")
+    for turn in diff.turns:
+        if not turn.changed:
+            continue
+        lines.append(f"     turn {turn.index + 1}: {_first_line(turn.prompt, 60)}")
+        if turn.missing:
+            lines.append("       not reached in the replay")
+            continue
+        lines.extend(f"       {change}" for change in turn.tool_changes)
+        if turn.reply_changed:
+            lines.append(
+                f"       reply changed (similarity {turn.reply_similarity:.2f})"
+            )
+    if diff.extra_turns:
+        lines.append(f"     {diff.extra_turns} extra turn(s) in the replay")
+    return "\n".join(lines)
+
+
+def _resolve_session(value: str | None) -> Path:
+    """A transcript path, a session id in this project, or the latest session."""
+    from ...services.session_analysis.transcript_parser import (
+        find_most_recent_session,
+        locate_transcript,
+    )
+
+    cwd = str(Path.cwd())
+    if value:
+        path = Path(value).expanduser()
+        if path.is_file():
+            return path
+        transcript = locate_transcript(value, cwd)
+        if transcript.is_file():
+            return transcript
+        raise FileNotFoundError(f"No transcript or session found for '{value}'")
+
+    session_id = find_most_recent_session(cwd)
+    if session_id is None:
+        raise FileNotFoundError(f"No Claude Code sessions recorded for {cwd}")
+    return locate_transcript(session_id, cwd)
+
+
+def manage_golden(args) -> int:
+    """Main entry point for the golden command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = GoldenCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
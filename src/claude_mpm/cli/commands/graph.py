@@ -0,0 +1,185 @@
+"""
+Graph command implementation for claude-mpm.
+
+WHY: Lets users build the knowledge graph before agents need it, fold in a
+session's edits after the fact, and check what the graph knows without
+starting the MCP server or the dashboard.
+
+DESIGN DECISIONS:
+- Thin wrapper around KnowledgeGraphBuilder, KnowledgeGraph and
+  ContractAnalyzer
+- ``query``, ``show`` and ``contracts`` load the saved graph, building it on
+  first use
+- ``contracts`` exits non-zero on drift so it can gate CI
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.knowledge_graph import (
+    ContractAnalyzer,
+    ContractReport,
+    KnowledgeGraphBuilder,
+    default_graph_path,
+    load_or_build,
+)
+from ..shared import BaseCommand, CommandResult
+from .golden import _resolve_session
+
+
+class GraphCommand(BaseCommand):
+    """CLI command for the project knowledge graph."""
+
+    VALID_COMMANDS = ("build", "update", "query", "show", "contracts")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("graph")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "graph_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm graph {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "build": self._build,
+            "update": self._update,
+            "query": self._query,
+            "show": self._show,
+            "contracts": self._contracts,
+        }
+        try:
+            return handlers[args.graph_command](args)
+        except FileNotFoundError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing graph command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing graph command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _build(self, args) -> CommandResult:
+        graph = load_or_build(self.project_dir, rebuild=True)
+        summary = graph.summary()
+        return CommandResult.success_result(
+            f"Knowledge graph: {_format_counts(summary)} "
+            f"→ {default_graph_path(self.project_dir)}",
+            data=summary,
+        )
+
+    def _update(self, args) -> CommandResult:
+        transcript = _resolve_session(getattr(args, "session", None))
+        graph = load_or_build(self.project_dir)
+        touched = KnowledgeGraphBuilder(self.project_dir).update_from_session(
+            graph, transcript
+        )
+        graph.save(default_graph_path(self.project_dir))
+        summary = graph.summary()
+        return CommandResult.success_result(
+            f"Re-extracted {len(touched)} file(s) from session {transcript.stem}: "
+            f"{_format_counts(summary)}",
+            data={"files": touched, **summary},
+        )
+
+    def _query(self, args) -> CommandResult:
+        graph = load_or_build(self.project_dir)
+        entities = graph.search(args.text, type=args.type, limit=args.limit)
+        data = [e.to_dict() for e in entities]
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not entities:
+            return CommandResult.success_result("No matching entities", data=data)
+        lines = [f"{e.type:<9} {e.name:<40} {e.id}" for e in entities]
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _show(self, args) -> CommandResult:
+        graph = load_or_build(self.project_dir)
+        entity = graph.describe(args.id)
+        if entity is None:
+            return CommandResult.error_result(f"No entity with id '{args.id}'")
+        if getattr(args, "json", False):
+            return CommandResult.success_result(
+                json.dumps(entity, indent=2), data=entity
+            )
+        return CommandResult.success_result(_format_entity(entity), data=entity)
+
+    def _contracts(self, args) -> CommandResult:
+        graph = load_or_build(self.project_dir)
+        report = ContractAnalyzer(self.project_dir).analyze(graph)
+        data = report.to_dict()
+        if getattr(args, "json", False):
+            message = json.dumps(data, indent=2)
+        else:
+            message = _format_contracts(report)
+        if report.drifted:
+            return CommandResult.error_result(message, data=data)
+        return CommandResult.success_result(message, data=data)
+
+
+def _format_counts(summary: dict) -> str:
+    counts = ", ".join(f"{n} {t}s" for t, n in summary["by_type"].items())
+    return f"{counts}, {summary['relations']} relations"
+
+
+def _format_entity(entity: dict) -> str:
+    lines = [f"{entity['name']} ({entity['type']})", f"  id: {entity['id']}"]
+    lines.extend(f"  {key}: {value}" for key, value in entity["attributes"].items())
+    for neighbor in entity["relations"]:
+        other = neighbor["entity"]
+        label = (
+            neighbor["relation"]
+            if neighbor["direction"] == "out"
+            else f"{neighbor['relation']} by"
+        )
+        lines.append(f"  {label} → {other.get('name', other['id'])} [{other['id']}]")
+    return "\n".join(lines)
+
+
+def _format_contracts(report: ContractReport) -> str:
+    if not report.specs:
+        return "No OpenAPI specs or .proto files found"
+    implemented = sum(1 for op in report.operations if op.implemented_by)
+    lines = [
+        f"{implemented}/{len(report.operations)} contract operations implemented "
+        f"({', '.join(report.specs)})"
+    ]
+    for issue in report.issues:
+        location = f" [{issue.file}:{issue.line}]" if issue.file else ""
+        lines.append(f"  {issue.kind:<22} {issue.detail}{location}")
+    if not report.issues:
+        lines.append("No drift between contracts and code")
+    return "\n".join(lines)
+
+
+def manage_graph(args) -> int:
+    """Main entry point for the graph command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = GraphCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
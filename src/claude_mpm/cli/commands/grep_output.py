@@ -0,0 +1,112 @@
+"""
+Grep-output command implementation for claude-mpm.
+
+WHY: Answers "where did this value come from?" across past sessions by
+searching what tools actually printed, not the code as it is now.
+
+DESIGN DECISIONS:
+- Thin wrapper around OutputIndex; the index is brought up to date before
+  every search so results always include the running session
+- Output mirrors ripgrep's heading mode: one header per matching record,
+  ``N:`` for matching lines and ``N-`` for context
+- Exits 1 when nothing matches, like grep
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from pathlib import Path
+
+from ...services.session_analysis.output_index import OutputIndex, OutputMatch
+from ..shared import BaseCommand, CommandResult
+
+
+class GrepOutputCommand(BaseCommand):
+    """CLI command for searching indexed session output."""
+
+    def __init__(
+        self, project_dir: Path | None = None, index: OutputIndex | None = None
+    ):
+        super().__init__("grep-output")
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.index = index or OutputIndex(self.project_dir)
+
+    def validate_args(self, args) -> str | None:
+        if not getattr(args, "pattern", None):
+            return "Usage: claude-mpm grep-output PATTERN [--session ID]"
+        if getattr(args, "context", 0) < 0:
+            return "--context must not be negative"
+        return None
+
+    def run(self, args) -> CommandResult:
+        try:
+            self.index.update(rebuild=args.reindex)
+            matches = self.index.grep(
+                args.pattern,
+                session_id=args.session,
+                ignore_case=args.ignore_case,
+                fixed_strings=args.fixed_strings,
+                kinds=tuple(args.kind) if args.kind else None,
+                tool=args.tool,
+                context=args.context,
+                max_count=args.max_count,
+            )
+        except re.error as e:
+            return CommandResult.error_result(f"Invalid pattern: {e}")
+        except Exception as e:
+            self.logger.error("Error searching session output: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error searching session output: {e}")
+
+        data = [m.to_dict() for m in matches]
+        if getattr(args, "json", False):
+            message = json.dumps(data, indent=2)
+        else:
+            message = "\n\n".join(_format_match(m) for m in matches)
+        if not matches:
+            return CommandResult.error_result(
+                message if args.json else "No matches", data=data
+            )
+        return CommandResult.success_result(message, data=data)
+
+
+def _format_match(match: OutputMatch) -> str:
+    where = match.tool or match.kind
+    if match.kind == "tool_input":
+        where = f"{where} input"
+    agent = f" [{match.agent}]" if match.agent else ""
+    header = (
+        f"{match.session_id[:8]}#{match.seq} {match.timestamp or '-'} {where}{agent}"
+    )
+    lines = [header]
+    previous = None
+    for number, text, is_match in match.lines:
+        if previous is not None and number > previous + 1:
+            lines.append("--")
+        lines.append(f"{number}{':' if is_match else '-'}{text}")
+        previous = number
+    return "\n".join(lines)
+
+
+def manage_grep_output(args) -> int:
+    """Main entry point for the grep-output command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 when something matched, 1 otherwise).
+    """
+    command = GrepOutputCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.message:
+        print(result.message)
+    return 0 if result.success else 1
@@ -0,0 +1,234 @@
+"""
+Integrity command implementation for claude-mpm.
+
+WHY: Startup only alerts on deployed agents and skills that changed outside
+claude-mpm; users need the full report and a way to restore the files.
+Updates that conflict with local edits are left unapplied until someone
+picks a resolution.
+
+DESIGN DECISIONS:
+- Thin wrapper around IntegrityManifest and deployment_merge
+- Checks the project's manifest and the user-level one (~/.claude-mpm), as
+  startup does
+- Exits non-zero from ``verify`` when something alerts, so CI can gate on it
+- ``resolve`` prompts per conflict (keep deployed, take upstream, edit the
+  merge in $EDITOR); ``--take`` resolves all of them without prompting
+"""
+
+from __future__ import annotations
+
+import difflib
+import json
+import sys
+from collections.abc import Callable
+from pathlib import Path
+
+from ...services.deployment_integrity import ALERTS, IntegrityManifest
+from ...services.deployment_merge import (
+    MergeConflict,
+    has_conflict_markers,
+    pending_conflicts,
+    resolve_conflict,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class IntegrityCommand(BaseCommand):
+    """CLI command for verifying deployed agents and skills."""
+
+    VALID_COMMANDS = ("verify", "redeploy", "resolve")
+
+    def __init__(self, roots: list[Path] | None = None):
+        super().__init__("integrity")
+        self.roots = roots or list(dict.fromkeys([Path.cwd().resolve(), Path.home()]))
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "integrity_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm integrity {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "verify": self._verify,
+            "redeploy": self._redeploy,
+            "resolve": self._resolve,
+        }
+        try:
+            return handlers[args.integrity_command](args)
+        except Exception as e:
+            self.logger.error("Error executing integrity command: %s", e, exc_info=True)
+            return CommandResult.error_result(
+                f"Error executing integrity command: {e}"
+            )
+
+    def _manifests(self) -> list[IntegrityManifest]:
+        manifests = [IntegrityManifest(root) for root in self.roots]
+        return [m for m in manifests if m.path.is_file()]
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _verify(self, args) -> CommandResult:
+        manifests = self._manifests()
+        data = [
+            {
+                "manifest": str(m.path),
+                "files": len(m.entries()),
+                "issues": [i.to_dict() for i in m.verify()],
+            }
+            for m in manifests
+        ]
+        alerts = [i for d in data for i in d["issues"] if i["issue"] in ALERTS]
+        if getattr(args, "json", False):
+            message = json.dumps(data, indent=2)
+        elif not manifests:
+            return CommandResult.success_result(
+                "No deployments recorded yet", data=data
+            )
+        else:
+            lines = []
+            for d in data:
+                lines.append(f"{d['manifest']}: {d['files']} file(s)")
+                lines += [
+                    f"  {i['issue']:<16} {i['path']}"
+                    + (f"  ({i['detail']})" if i["detail"] else "")
+                    for i in d["issues"]
+                ]
+            if alerts:
+                lines.append("")
+                lines.append(
+                    f"{len(alerts)} file(s) changed outside claude-mpm; restore "
+                    "them with 'claude-mpm integrity redeploy'"
+                )
+            else:
+                lines.append("No out-of-band modifications found")
+            message = "\n".join(lines)
+        if alerts:
+            return CommandResult.error_result(message, data=data)
+        return CommandResult.success_result(message, data=data)
+
+    def _redeploy(self, args) -> CommandResult:
+        restored = []
+        skipped = []
+        for manifest in self._manifests():
+            issues = manifest.verify()
+            done = manifest.redeploy(issues)
+            restored += [str(manifest.project_root / path) for path in done]
+            skipped += [
+                str(manifest.project_root / i.path)
+                for i in issues
+                if i.issue in ALERTS and i.path not in done
+            ]
+        lines = [f"Restored {len(restored)} file(s)"]
+        lines += [f"  {path}" for path in restored]
+        if skipped:
+            lines.append(
+                f"Not restored ({len(skipped)}); their cached source is modified "
+                "or missing, re-sync the source first:"
+            )
+            lines += [f"  {path}" for path in skipped]
+        data = {"restored": restored, "skipped": skipped}
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _resolve(self, args) -> CommandResult:
+        conflicts = [c for root in self.roots for c in pending_conflicts(root)]
+        if not conflicts:
+            return CommandResult.success_result("No merge conflicts to resolve")
+        take = getattr(args, "take", None)
+        if take in ("local", "upstream"):
+            for conflict in conflicts:
+                content = conflict.local() if take == "local" else conflict.upstream
+                resolve_conflict(conflict, content)
+            resolved = len(conflicts)
+        elif not sys.stdin.isatty():
+            return CommandResult.error_result(
+                f"{len(conflicts)} merge conflict(s) pending; run in a terminal "
+                "or pass --take local|upstream"
+            )
+        else:
+            resolved = prompt_resolutions(conflicts)
+        message = f"Resolved {resolved} of {len(conflicts)} merge conflict(s)"
+        return CommandResult.success_result(message, data={"resolved": resolved})
+
+
+_CHOICES = "[l]keep deployed, [u]take upstream, [e]dit merge, [d]iff, [s]kip? "
+
+
+def prompt_resolutions(
+    conflicts: list[MergeConflict],
+    ask: Callable[[str], str] = input,
+    edit: Callable[[str], str] | None = None,
+) -> int:
+    """Ask how to resolve each conflict; returns how many were resolved."""
+    if edit is None:
+        from .playground import _edit_in_editor as edit
+    resolved = 0
+    for conflict in conflicts:
+        merge = conflict.merged()
+        print(
+            f"\n{conflict.path}: local edits conflict with the {conflict.kind} "
+            f"update ({merge.conflicts} conflicting region(s))"
+        )
+        while True:
+            try:
+                answer = ask(_CHOICES).strip().lower()[:1]
+            except EOFError:
+                return resolved
+            if answer == "d":
+                from ...utils.theme import get_theme
+
+                diff = difflib.unified_diff(
+                    conflict.local().splitlines(keepends=True),
+                    conflict.upstream.splitlines(keepends=True),
+                    fromfile=f"deployed/{conflict.path}",
+                    tofile=f"upstream/{conflict.path}",
+                )
+                print(get_theme().diff("".join(diff)), end="")
+                continue
+            if answer == "e":
+                content = edit(merge.text)
+                if has_conflict_markers(content):
+                    print("Conflict markers remain; edit again or pick another option")
+                    merge.text = content
+                    continue
+            elif answer in ("l", "u"):
+                content = conflict.local() if answer == "l" else conflict.upstream
+            elif answer == "s":
+                break
+            else:
+                continue
+            resolve_conflict(conflict, content)
+            resolved += 1
+            print(f"Resolved {conflict.target}")
+            break
+    return resolved
+
+
+def manage_integrity(args) -> int:
+    """Main entry point for the integrity command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = IntegrityCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(result.message if result.data is not None else f"Error: {result.message}")
+    return 1
@@ -0,0 +1,159 @@
+"""
+Labels command implementation for claude-mpm.
+
+WHY: Lets users label sessions after the fact (labels given at launch come
+from ``run --label``) and slice sessions and their cost by label.
+
+DESIGN DECISIONS:
+- Thin wrapper around SessionLabels and label_costs
+- ``list`` shows the taxonomy and how often each label is used; ``sessions``
+  and ``costs`` take repeatable --label filters that must all match
+- Every listing has --json for reports built elsewhere
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.session_labels import SessionLabels, label_costs
+from ..shared import BaseCommand, CommandResult
+
+
+class LabelsCommand(BaseCommand):
+    """CLI command for session labels."""
+
+    VALID_COMMANDS = ("list", "add", "remove", "sessions", "costs")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("labels")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "labels_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm labels {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "list": self._list,
+            "add": self._add,
+            "remove": self._remove,
+            "sessions": self._sessions,
+            "costs": self._costs,
+        }
+        try:
+            return handlers[args.labels_command](args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing labels command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing labels command: {e}")
+
+    @staticmethod
+    def _output(args, data, text: str) -> CommandResult:
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        return CommandResult.success_result(text, data=data)
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _list(self, args) -> CommandResult:
+        store = SessionLabels(self.project_dir)
+        taxonomy = store.taxonomy()
+        counts = store.counts()
+        data = [
+            {"label": label, "sessions": count, "description": taxonomy.get(label, "")}
+            for label, count in counts.items()
+        ]
+        if not data:
+            return self._output(
+                args,
+                data,
+                "No labels yet. Label a session with "
+                "'claude-mpm labels add <session-id> <label>' or 'run --label'.",
+            )
+        width = max(len(row["label"]) for row in data)
+        lines = [
+            f"{row['label']:<{width}}  {row['sessions']:>4}  {row['description']}"
+            .rstrip()
+            for row in data
+        ]
+        if store.strict:
+            lines.append(f"\nOnly these labels are allowed ({store.taxonomy_path})")
+        return self._output(args, data, "\n".join(lines))
+
+    def _add(self, args) -> CommandResult:
+        labels = SessionLabels(self.project_dir).add(args.session_id, args.labels)
+        return CommandResult.success_result(
+            f"{args.session_id}: {', '.join(labels)}", data={"labels": labels}
+        )
+
+    def _remove(self, args) -> CommandResult:
+        labels = SessionLabels(self.project_dir).remove(
+            args.session_id, args.labels or None
+        )
+        return CommandResult.success_result(
+            f"{args.session_id}: {', '.join(labels) or 'no labels'}",
+            data={"labels": labels},
+        )
+
+    def _sessions(self, args) -> CommandResult:
+        sessions = SessionLabels(self.project_dir).sessions(args.label)
+        data = [
+            {"session_id": session_id, "labels": labels}
+            for session_id, labels in sessions.items()
+        ]
+        if not data:
+            return self._output(args, data, "No matching sessions")
+        lines = [f"{row['session_id']}  {', '.join(row['labels'])}" for row in data]
+        return self._output(args, data, "\n".join(lines))
+
+    def _costs(self, args) -> CommandResult:
+        costs = label_costs(self.project_dir, args.label)
+        data = [cost.to_dict() for cost in costs]
+        if not data:
+            return self._output(args, data, "No matching sessions")
+        width = max(len(cost.label) for cost in costs)
+        lines = [f"{'LABEL':<{width}}  SESSIONS  TURNS  COST (USD)"]
+        for cost in costs:
+            line = (
+                f"{cost.label:<{width}}  {cost.sessions:>8}  {cost.turns:>5}  "
+                f"{cost.cost_usd:>10.4f}"
+            )
+            if cost.missing:
+                line += f"  ({cost.missing} without transcript)"
+            lines.append(line)
+        lines.append("\nEstimated at public list prices from the session transcripts.")
+        return self._output(args, data, "\n".join(lines))
+
+
+def manage_labels(args) -> int:
+    """Main entry point for the labels command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = LabelsCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,141 @@
+"""
+Logs command implementation for claude-mpm.
+
+WHY: Log sources are per project and have to be registered before agents
+can query them; users also want to run the agent's query themselves to see
+what it saw.
+
+DESIGN DECISIONS:
+- Thin wrapper around LogSourceRegistry and LogQuery
+- ``add`` does not check that the file or container exists yet: services are
+  often registered before they are first started
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.log_sources import (
+    DOCKER,
+    FILE,
+    JOURNALD,
+    LogQuery,
+    LogSource,
+    LogSourceError,
+    LogSourceRegistry,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class LogsCommand(BaseCommand):
+    """CLI command for project log sources."""
+
+    VALID_COMMANDS = ("add", "remove", "list", "query")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("logs")
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.registry = LogSourceRegistry(self.project_dir)
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "logs_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm logs {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "add": self._add,
+            "remove": self._remove,
+            "list": self._list,
+            "query": self._query,
+        }
+        try:
+            return handlers[args.logs_command](args)
+        except LogSourceError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing logs command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing logs command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _add(self, args) -> CommandResult:
+        for kind, target in (
+            (FILE, args.file),
+            (DOCKER, args.docker),
+            (JOURNALD, args.journald),
+        ):
+            if target:
+                source = LogSource(args.name, kind, target)
+                break
+        self.registry.add(source)
+        return CommandResult.success_result(
+            f"Registered log source '{source.name}' ({source.kind}: {source.target})",
+            data=source.to_dict(),
+        )
+
+    def _remove(self, args) -> CommandResult:
+        if not self.registry.remove(args.name):
+            return CommandResult.error_result(f"Unknown log source '{args.name}'")
+        return CommandResult.success_result(f"Removed log source '{args.name}'")
+
+    def _list(self, args) -> CommandResult:
+        sources = self.registry.sources()
+        data = [s.to_dict() for s in sources]
+        if not sources:
+            return CommandResult.success_result("No log sources registered", data=data)
+        lines = [f"{s.name:<20} {s.kind:<9} {s.target}" for s in sources]
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _query(self, args) -> CommandResult:
+        lines = LogQuery(self.project_dir).query(
+            source=args.source,
+            since=args.since,
+            until=args.until,
+            grep=args.grep,
+            ignore_case=not args.case_sensitive,
+            limit=args.limit,
+        )
+        data = [line.to_dict() for line in lines]
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not lines:
+            return CommandResult.success_result("No matching log lines", data=data)
+        multiple = len({line.source for line in lines}) > 1
+        text = "\n".join(
+            f"[{line.source}] {line.text}" if multiple else line.text
+            for line in lines
+        )
+        return CommandResult.success_result(text, data=data)
+
+
+def manage_logs(args) -> int:
+    """Main entry point for the logs command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = LogsCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -179,6 +179,12 @@ class MCPCommandRouter:
             "session": "claude_mpm.mcp.session_server",
             "session-http": "claude_mpm.mcp.session_server_http",
             "confluence": "claude_mpm.mcp.confluence_server",
+            "knowledge-graph": "claude_mpm.mcp.knowledge_graph_server",
+            "database-schema": "claude_mpm.mcp.database_schema_server",
+            "logs": "claude_mpm.mcp.logs_server",
+            "page-capture": "claude_mpm.mcp.page_capture_server",
+            "code-edit": "claude_mpm.mcp.code_edit_server",
+            "http": "claude_mpm.mcp.http_server",
         }
         server_name = getattr(args, "server_name", None)
         if not server_name or server_name not in SERVE_MAP:
@@ -107,6 +107,7 @@ class MemoryManagementCommand(MemoryCommand):
                 "build",
                 "cross-ref",
                 "route",
+                "lessons",
             ]
             if args.memory_command not in valid_commands:
                 return f"Unknown memory command: {args.memory_command}. Valid commands: {', '.join(valid_commands)}"
@@ -131,6 +132,7 @@ class MemoryManagementCommand(MemoryCommand):
                 "cross-ref": self._cross_reference_memory,
                 "show": self._show_memories,
                 "route": self._route_memory_command,
+                "lessons": self._manage_lessons,
             }
 
             if args.memory_command in command_map:
@@ -364,6 +366,72 @@ class MemoryManagementCommand(MemoryCommand):
             self.logger.error(f"Error adding learning: {e}", exc_info=True)
             return CommandResult.error_result(f"Error adding learning: {e}")
 
+    def _manage_lessons(self, args) -> CommandResult:
+        """List, approve or reject lessons proposed from verification failures."""
+        from ...services.memory.lesson_proposals import LessonProposalStore
+
+        try:
+            output_format = self._get_output_format(args)
+            store = LessonProposalStore(self.memory_manager.memories_dir)
+            action = getattr(args, "lessons_action", "list") or "list"
+            pending = store.pending()
+
+            if action == "list":
+                if self._is_structured_format(output_format):
+                    return CommandResult.success_result(
+                        f"{len(pending)} pending lessons",
+                        data={"lessons": [vars(lesson) for lesson in pending]},
+                    )
+                if not pending:
+                    print("No lessons waiting for approval")
+                    return CommandResult.success_result("No pending lessons")
+                print(f"📝 {len(pending)} lesson(s) waiting for approval:\n")
+                for lesson in pending:
+                    print(f"  [{lesson.id}] {lesson.agent} · {lesson.category}")
+                    print(f"      cause: {lesson.cause}")
+                    print(f"      fix:   {lesson.fix}")
+                print(
+                    "\nApprove with: claude-mpm memory lessons approve <id>... "
+                    "(or --all)"
+                )
+                return CommandResult.success_result(f"{len(pending)} pending lessons")
+
+            ids = [lesson.id for lesson in pending] if args.all else args.lesson_ids
+            if not ids:
+                return CommandResult.error_result(
+                    f"Specify lesson ids to {action} or use --all"
+                )
+            unknown = sorted(set(ids) - {lesson.id for lesson in pending})
+            if unknown:
+                return CommandResult.error_result(
+                    f"No pending lesson with id: {', '.join(unknown)}"
+                )
+
+            if action == "approve":
+                done = store.approve(
+                    ids, self.memory_manager, agent=getattr(args, "agent", None)
+                )
+                for lesson in done:
+                    print(f"✅ Added lesson {lesson.id} to {lesson.agent} memory")
+                if len(done) < len(ids):
+                    return CommandResult.error_result(
+                        f"Failed to write {len(ids) - len(done)} lesson(s) to memory"
+                    )
+            else:
+                done = store.reject(ids)
+                for lesson in done:
+                    print(f"🗑️  Rejected lesson {lesson.id}")
+
+            verb = "Approved" if action == "approve" else "Rejected"
+            return CommandResult.success_result(
+                f"{verb} {len(done)} lesson(s)",
+                data={"lessons": [lesson.id for lesson in done]},
+            )
+
+        except Exception as e:
+            self.logger.error(f"Error managing lessons: {e}", exc_info=True)
+            return CommandResult.error_result(f"Error managing lessons: {e}")
+
     def _clean_memory(self, args) -> CommandResult:
         """Clean up old/unused memory files."""
         try:
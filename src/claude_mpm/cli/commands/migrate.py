@@ -7,6 +7,7 @@ configuration migrations with verbose output. Primarily used for:
 - Previewing changes with --dry-run
 - Running migrations on a specific project directory
 - Re-running migrations after a failed startup migration
+- Inspecting and rolling back the versioned ~/.claude-mpm state schema
 """
 
 import argparse
@@ -58,6 +59,49 @@ class MigrateCommand(BaseCommand):
             f"Listed {total} migrations ({n_applied} applied, {n_pending} pending)"
         )
 
+    def _state_status(self) -> CommandResult:
+        """Show the local state schema version and available backups."""
+        from ...migrations.state import StateMigrator
+
+        migrator = StateMigrator()
+        current = migrator.current_version()
+        target = migrator.target_version()
+        backups = migrator.list_backups()
+
+        print(f"\nLocal state: {migrator.state_dir}")
+        print(f"  Schema version: {current} (latest: {target})")
+        for migration in migrator.pending():
+            print(f"  pending v{migration.schema_version}: {migration.description}")
+        print(f"  Backups ({len(backups)}):")
+        for backup in backups:
+            print(f"    {backup.name}")
+
+        return CommandResult.success_result(
+            f"State schema v{current} of v{target}",
+            data={
+                "current": current,
+                "target": target,
+                "backups": [str(b) for b in backups],
+            },
+        )
+
+    def _rollback_state(self, name: str) -> CommandResult:
+        """Restore ~/.claude-mpm from a state backup."""
+        from ...migrations.state import StateMigrator
+
+        migrator = StateMigrator()
+        backup = None if name == "latest" else migrator.backup_root / name
+        if backup is not None and not backup.is_dir():
+            return CommandResult.error_result(f"No such state backup: {name}")
+        try:
+            restored = migrator.restore(backup)
+        except FileNotFoundError as e:
+            return CommandResult.error_result(str(e))
+
+        print(f"Restored local state from {restored.name}")
+        print(f"Schema version is now {migrator.current_version()}")
+        return CommandResult.success_result(f"Rolled back to {restored.name}")
+
     def run(self, args: object) -> CommandResult:
         """Execute pending migrations with verbose output."""
         list_only = getattr(args, "list", False)
@@ -66,6 +110,10 @@ class MigrateCommand(BaseCommand):
 
         if list_only:
             return self._list_migrations()
+        if getattr(args, "state_status", False):
+            return self._state_status()
+        if getattr(args, "rollback_state", None):
+            return self._rollback_state(args.rollback_state)
 
         if project_dir:
             project_path = Path(project_dir).resolve()
@@ -184,6 +232,18 @@ def add_migrate_parser(subparsers: argparse._SubParsersAction) -> None:
         default=None,
         help="Project directory to migrate (default: current directory)",
     )
+    parser.add_argument(
+        "--state-status",
+        action="store_true",
+        help="Show the ~/.claude-mpm state schema version and backups",
+    )
+    parser.add_argument(
+        "--rollback-state",
+        nargs="?",
+        const="latest",
+        metavar="BACKUP",
+        help="Restore ~/.claude-mpm state from a backup (default: latest)",
+    )
 
 
 def manage_migrate(args: object) -> int:
@@ -11,10 +11,15 @@ DESIGN DECISIONS:
 - Support both foreground and daemon modes
 - Real AST analysis using CodeTreeAnalyzer
 - Integrated dashboard and Socket.IO server
+- ``start`` without --port attaches the project to the shared daemon while it
+  runs, rather than starting a server of its own
 """
 
+from pathlib import Path
+
 from ...constants import MonitorCommands
 from ...services.monitor.daemon import UnifiedMonitorDaemon
+from ...services.shared_daemon import SharedDaemon
 from ..shared import BaseCommand, CommandResult
 
 
@@ -65,8 +70,6 @@ class MonitorCommand(BaseCommand):
     def _start_monitor(self, args) -> CommandResult:
         """Start the unified monitor daemon."""
         port = getattr(args, "port", None)
-        if port is None:
-            port = 8765  # Default to 8765 for unified monitor
         host = getattr(args, "host", "localhost")
 
         # Check for explicit foreground flag first, then background flag
@@ -79,6 +82,21 @@ class MonitorCommand(BaseCommand):
             # Default to daemon/background mode
             daemon_mode = True
 
+        if port is None and daemon_mode:
+            entry = SharedDaemon().serve_project(Path.cwd())
+            if entry:
+                return CommandResult.success_result(
+                    f"Monitor served by the shared daemon on {host}:{entry['port']} "
+                    f"(namespace {entry['namespace']})",
+                    data={
+                        "url": f"http://{host}:{entry['port']}",
+                        "port": entry["port"],
+                        "namespace": entry["namespace"],
+                    },
+                )
+        if port is None:
+            port = 8765  # Default to 8765 for unified monitor
+
         mode_str = "background/daemon" if daemon_mode else "foreground"
         self.logger.info(
             f"Starting unified monitor daemon on {host}:{port} (mode: {mode_str})"
@@ -0,0 +1,133 @@
+"""
+Owners command implementation for claude-mpm.
+
+WHY: Before filing a finding or asking for a review, an agent (or a person)
+needs to know who owns the code in question. This command answers from the
+same rules the ownership hook routes tickets and review requests with.
+
+DESIGN DECISIONS:
+- Paths are taken relative to the current directory and looked up relative
+  to the repository root, so the command works from any subdirectory
+- ``PATH:LINE`` and ``PATH:START-END`` narrow the git blame fallback to
+  those lines
+- Lookups work whether or not ownership routing is enabled
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import sys
+from pathlib import Path
+
+from ...services.ownership import (
+    FileRef,
+    Ownership,
+    OwnershipResolver,
+    find_project_root,
+)
+from ..shared import BaseCommand, CommandResult
+
+_LINES_RE = re.compile(r"^(.*?)(?::(\d+)(?:-(\d+))?)?$")
+
+
+class OwnersCommand(BaseCommand):
+    """Show the owners of files or line ranges."""
+
+    def __init__(self):
+        super().__init__("owners")
+
+    def validate_args(self, args) -> str | None:
+        if not args.paths and not args.changed:
+            return "Give one or more paths, or --changed"
+        if args.paths and args.changed:
+            return "Give paths or --changed, not both"
+        return None
+
+    def run(self, args) -> CommandResult:
+        root = find_project_root(Path.cwd())
+        resolver = OwnershipResolver(root)
+        if args.changed:
+            refs = [FileRef(path) for path in resolver.changed_files(args.base)]
+            if not refs:
+                return CommandResult.success_result("No changed files")
+        else:
+            try:
+                refs = [_parse_ref(arg, root) for arg in args.paths]
+            except ValueError as e:
+                return CommandResult.error_result(str(e))
+
+        results = [resolver.owners_for(ref.path, ref.start, ref.end) for ref in refs]
+        if args.json:
+            print(json.dumps([r.to_dict() for r in results], indent=2))
+        else:
+            width = max(len(_label(ref)) for ref in refs)
+            for ref, ownership in zip(refs, results, strict=True):
+                print(f"{_label(ref):<{width}}  {_describe(ownership)}")
+
+        unowned = sum(not r.owners for r in results)
+        message = f"{len(results) - unowned} of {len(results)} file(s) have an owner"
+        return CommandResult.success_result(
+            message, data={"owners": [r.to_dict() for r in results]}
+        )
+
+
+def _parse_ref(arg: str, root: Path) -> FileRef:
+    match = _LINES_RE.match(arg)
+    path, start, end = match.group(1), match.group(2), match.group(3)
+    if not Path(path).is_file():
+        if Path(arg).is_file():  # a file name that contains ":"
+            path, start, end = arg, None, None
+        else:
+            raise ValueError(f"No such file: {path}")
+    try:
+        relative = Path(path).resolve().relative_to(root)
+    except ValueError:
+        raise ValueError(f"{path} is outside the repository {root}") from None
+    first = int(start) if start else None
+    return FileRef(relative.as_posix(), first, int(end) if end else first)
+
+
+def _label(ref: FileRef) -> str:
+    if ref.start is None:
+        return ref.path
+    if ref.end == ref.start:
+        return f"{ref.path}:{ref.start}"
+    return f"{ref.path}:{ref.start}-{ref.end}"
+
+
+def _describe(ownership: Ownership) -> str:
+    if not ownership.owners:
+        return "(no owner)" if not ownership.source else "(unowned by rule)"
+    owners = " ".join(ownership.owners)
+    rule = f" {ownership.rule}" if ownership.rule else ""
+    return f"{owners}  [{ownership.source}{rule}]"
+
+
+def manage_owners(args) -> int:
+    """Main entry point for the owners command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = OwnersCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message and not args.json:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}", file=sys.stderr if args.json else sys.stdout)
+    return 1
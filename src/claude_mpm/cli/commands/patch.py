@@ -0,0 +1,136 @@
+"""
+Patch command implementation for claude-mpm.
+
+WHY: ``patch`` and ``git apply`` reject a hunk over one re-indented line and
+say little about what differed. ``claude-mpm patch`` places hunks with the
+patch engine's strategies and, when a hunk cannot be placed, shows the
+closest region of the file and the first line that differs.
+
+DESIGN DECISIONS:
+- Thin wrapper around PatchEngine
+- Hunks placed exactly where the header says are not listed; moved or
+  fuzzily placed hunks are, so a reviewer sees what was bent
+"""
+
+from __future__ import annotations
+
+import json
+import sys
+from pathlib import Path
+
+from ...services.patching import (
+    PatchConfig,
+    PatchEngine,
+    PatchParseError,
+    PatchResult,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+def _hunk_line(hunk) -> str:
+    if not hunk.applied:
+        return f"    {hunk.header} did not apply"
+    details = [f"at line {hunk.line}"]
+    if hunk.offset:
+        details.append(f"offset {hunk.offset:+d}")
+    details.append(f"{hunk.strategy}")
+    details.extend(hunk.notes)
+    return f"    {hunk.header} applied {', '.join(details)}"
+
+
+def format_result(result: PatchResult) -> str:
+    """Human-readable report: one line per file, plus notable hunks."""
+    lines = []
+    for file in result.files:
+        mark = "✓" if file.ok else "✗"
+        lines.append(f"{mark} {file.path} ({file.action})")
+        for hunk in file.hunks:
+            if hunk.applied and not (hunk.offset or hunk.notes):
+                continue
+            lines.append(_hunk_line(hunk))
+            if hunk.diagnostic:
+                lines.extend(f"      {line}" for line in hunk.diagnostic.splitlines())
+        if file.error:
+            lines.append(f"    {file.error}")
+    return "\n".join(lines)
+
+
+class PatchCommand(BaseCommand):
+    """CLI command for applying and checking patches."""
+
+    VALID_COMMANDS = ("apply", "check")
+
+    def __init__(self):
+        super().__init__("patch")
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "patch_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm patch {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        try:
+            if args.patch_file == "-":
+                text = sys.stdin.read()
+            else:
+                text = Path(args.patch_file).read_text(encoding="utf-8")
+            config = PatchConfig.load()
+            if args.max_fuzz is not None:
+                config.max_fuzz = args.max_fuzz
+            engine = PatchEngine(
+                root=args.directory, config=config, strategies=args.strategies
+            )
+            dry_run = args.patch_command == "check" or args.dry_run
+            result = engine.apply(text, strip=args.strip, dry_run=dry_run)
+        except (OSError, PatchParseError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+
+        data = result.to_dict()
+        if args.json:
+            print(json.dumps(data, indent=2))
+        else:
+            print(format_result(result))
+        if not result.ok:
+            failed = sum(not f.ok for f in result.files)
+            return CommandResult.error_result(
+                f"Patch does not apply ({failed} file(s) failed); no files were "
+                "changed",
+                data=data,
+            )
+        if result.dry_run:
+            return CommandResult.success_result(
+                f"Patch applies to {len(result.files)} file(s) (nothing written)",
+                data=data,
+            )
+        return CommandResult.success_result(
+            f"Patched {len(result.files)} file(s)", data=data
+        )
+
+
+def manage_patch(args) -> int:
+    """Main entry point for the patch command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = PatchCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message and not args.json:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}", file=sys.stderr if args.json else sys.stdout)
+    return 1
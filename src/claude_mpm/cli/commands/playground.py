@@ -0,0 +1,252 @@
+"""
+Playground command implementation for claude-mpm.
+
+WHY: Gives agent authors an edit → try → compare loop without redeploying or
+starting full sessions. All state lives in PromptPlayground; this module is
+the REPL around it.
+
+DESIGN DECISIONS:
+- Plain lines are prompts; ``:``-prefixed lines are playground commands
+- Edits open $EDITOR on the draft (or ``:load`` reads a file) and each change
+  is a new revision, so replies can be compared across revisions
+- ``:accept`` shows the diff and asks before writing the template
+"""
+
+from __future__ import annotations
+
+import asyncio
+import difflib
+import os
+import shlex
+import subprocess  # nosec B404
+import tempfile
+from collections.abc import Callable
+from pathlib import Path
+
+from ...services.agents.playground import (
+    PromptPlayground,
+    ReplayResponder,
+    locate_agent_source,
+)
+from ...utils.theme import get_theme
+from ..shared import BaseCommand, CommandResult
+
+HELP_TEXT = """\
+Type a prompt to run it against the current draft, or a command:
+  :show            Show the effective system prompt
+  :edit            Edit the instructions in $EDITOR (new revision)
+  :load PATH       Replace the instructions with a file's contents
+  :diff            Diff the draft against the template
+  :compare [TEXT]  Replies to a prompt (default: last) across revisions
+  :reset           Discard the draft
+  :accept          Save the draft to the project agent template
+  :status          Show agent, revision and source
+  :quit            Leave the playground"""
+
+
+class PlaygroundCommand(BaseCommand):
+    """Interactive prompt engineering REPL for one agent."""
+
+    def __init__(
+        self,
+        input_func: Callable[[str], str] = input,
+        edit_func: Callable[[str], str] | None = None,
+    ):
+        super().__init__("playground")
+        self.input = input_func
+        self.edit = edit_func or _edit_in_editor
+        self.playground: PromptPlayground | None = None
+
+    def validate_args(self, args) -> str | None:
+        replay = getattr(args, "replay", None)
+        if replay is not None and not Path(replay).is_file():
+            return f"Recording not found: {replay}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        source = locate_agent_source(args.agent)
+        if source is None:
+            return CommandResult.error_result(
+                f"Agent '{args.agent}' not found in project, user, cache or "
+                "bundled agents"
+            )
+
+        if getattr(args, "replay", None):
+            responder = ReplayResponder(args.replay)
+        else:
+            from ...services.agents.cli_runtime import CLIAgentRunner
+
+            responder = CLIAgentRunner(cwd=str(Path.cwd()))
+
+        self.playground = PromptPlayground(
+            source, responder, model=getattr(args, "model", None)
+        )
+        print(f"Playground for '{source.name}' ({source.kind}: {source.path})")
+        print("Type :help for commands")
+
+        self._loop()
+        return CommandResult.success_result(
+            "Left playground", data=self.playground.summary()
+        )
+
+    # ------------------------------------------------------------------
+    # REPL
+    # ------------------------------------------------------------------
+
+    def _loop(self) -> None:
+        while True:
+            try:
+                line = self.input(f"[r{self.playground.revision}]> ").strip()
+            except (EOFError, KeyboardInterrupt):
+                print()
+                line = ":quit"
+            if not line:
+                continue
+            if not line.startswith(":"):
+                self._ask(line)
+                continue
+
+            name, _, rest = line[1:].partition(" ")
+            if name in ("quit", "q", "exit"):
+                if self._confirm_quit():
+                    return
+                continue
+            handler = getattr(self, f"_cmd_{name}", None)
+            if handler is None:
+                print(f"Unknown command :{name} (try :help)")
+                continue
+            try:
+                handler(rest.strip())
+            except Exception as e:
+                self.logger.error(f"Playground command :{name} failed: {e}")
+                print(f"Error: {e}")
+
+    def _ask(self, prompt: str) -> None:
+        turn = asyncio.run(self.playground.ask(prompt))
+        label = "error" if turn.is_error else f"{(turn.duration_ms or 0) / 1000:.1f}s"
+        print(f"--- r{turn.revision} ({label}) ---")
+        print(turn.reply)
+
+    def _confirm_quit(self) -> bool:
+        if not self.playground.dirty:
+            return True
+        answer = self.input("Discard unsaved draft? [y/N] ").strip().lower()
+        return answer in ("y", "yes")
+
+    # ------------------------------------------------------------------
+    # Commands
+    # ------------------------------------------------------------------
+
+    def _cmd_help(self, _arg: str) -> None:
+        print(HELP_TEXT)
+
+    def _cmd_show(self, _arg: str) -> None:
+        print(self.playground.effective_prompt())
+
+    def _cmd_status(self, _arg: str) -> None:
+        for key, value in self.playground.summary().items():
+            print(f"  {key:<9} {value}")
+
+    def _cmd_edit(self, _arg: str) -> None:
+        self._apply(self.edit(self.playground.draft))
+
+    def _cmd_load(self, arg: str) -> None:
+        if not arg:
+            print("Usage: :load PATH")
+            return
+        self._apply(Path(arg).expanduser().read_text(encoding="utf-8"))
+
+    def _apply(self, text: str) -> None:
+        if self.playground.set_draft(text):
+            print(f"Draft updated (revision {self.playground.revision})")
+        else:
+            print("No changes")
+
+    def _cmd_diff(self, _arg: str) -> None:
+        print(get_theme().diff(self.playground.diff()) or "Draft matches the template")
+
+    def _cmd_reset(self, _arg: str) -> None:
+        if self.playground.reset():
+            print(f"Draft reset (revision {self.playground.revision})")
+        else:
+            print("Draft already matches the template")
+
+    def _cmd_compare(self, arg: str) -> None:
+        turns = self.playground.compare(arg or None)
+        if not turns:
+            print("Nothing to compare yet; run a prompt first")
+            return
+        print(f"Prompt: {turns[-1].prompt}")
+        for turn in turns:
+            print(f"--- r{turn.revision} ---")
+            print(turn.reply)
+        if len(turns) > 1:
+            before, after = turns[-2], turns[-1]
+            print(f"--- reply diff r{before.revision} → r{after.revision} ---")
+            diff = "".join(
+                difflib.unified_diff(
+                    f"{before.reply}\n".splitlines(keepends=True),
+                    f"{after.reply}\n".splitlines(keepends=True),
+                    fromfile=f"r{before.revision}",
+                    tofile=f"r{after.revision}",
+                )
+            )
+            print(get_theme().diff(diff) or "(identical)")
+
+    def _cmd_accept(self, _arg: str) -> None:
+        if not self.playground.dirty:
+            print("Nothing to accept; the draft matches the template")
+            return
+        target = self.playground.target_path()
+        print(get_theme().diff(self.playground.diff()))
+        answer = self.input(f"Write these changes to {target}? [y/N] ")
+        if answer.strip().lower() not in ("y", "yes"):
+            print("Not saved")
+            return
+        path = self.playground.accept()
+        print(f"Saved to {path}")
+        print("Run 'claude-mpm agents deploy' to deploy the updated agent")
+
+
+def _edit_in_editor(text: str) -> str:
+    """Open ``text`` in $EDITOR and return the edited contents."""
+    editor = os.environ.get("EDITOR", "nano")
+    with tempfile.NamedTemporaryFile(
+        "w", suffix=".md", prefix="mpm-playground-", delete=False, encoding="utf-8"
+    ) as handle:
+        handle.write(text)
+        path = Path(handle.name)
+    try:
+        subprocess.run([*shlex.split(editor), str(path)], check=True)  # nosec B603
+        return path.read_text(encoding="utf-8")
+    finally:
+        path.unlink(missing_ok=True)
+
+
+def manage_playground(args) -> int:
+    """Main entry point for the playground command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = PlaygroundCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,112 @@
+"""
+Post-edit command implementation for claude-mpm.
+
+WHY: The post-edit pipeline runs inside a hook, where a missing formatter or
+a bad command only shows up as a note to the agent. ``show`` makes the
+configuration visible and ``run`` tries it on real files.
+
+DESIGN DECISIONS:
+- Thin wrapper around PostEditPipeline, loaded the same way the hook loads it
+- ``run`` ignores ``post_edit.enabled`` so a pipeline can be tried before it
+  is switched on
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.post_edit_pipeline import PostEditPipeline
+from ...utils.table_view import TableView
+from ..list_columns import POST_EDIT_COLUMNS
+from ..shared import BaseCommand, CommandResult
+
+
+class PostEditCommand(BaseCommand):
+    """CLI command for the post-edit formatting pipeline."""
+
+    VALID_COMMANDS = ("show", "run")
+
+    def __init__(self, project_root: Path | None = None):
+        super().__init__("post-edit")
+        self.project_root = project_root or Path.cwd()
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "post_edit_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm post-edit {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        try:
+            pipeline = PostEditPipeline.for_project(self.project_root)
+            if args.post_edit_command == "show":
+                return self._show(pipeline, args)
+            return self._run(pipeline, args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing post-edit command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing post-edit command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _show(self, pipeline: PostEditPipeline, args) -> CommandResult:
+        rows = pipeline.describe()
+        data = {"enabled": pipeline.enabled, "steps": rows}
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        state = "on" if pipeline.enabled else "off (set post_edit.enabled: true)"
+        table = TableView.from_args(POST_EDIT_COLUMNS, rows, args)
+        return CommandResult.success_result(
+            f"Post-edit pipeline: {state}\n\n{table.render()}", data=data
+        )
+
+    def _run(self, pipeline: PostEditPipeline, args) -> CommandResult:
+        results = []
+        lines = []
+        for name in args.files:
+            result = pipeline.run(Path(name).resolve())
+            if result is None:
+                lines.append(f"{name}: no steps apply (excluded or unknown type)")
+                continue
+            results.append(result)
+            lines.append(result.summary())
+            lines.extend(
+                f"  {step.name}: {step.tool} {step.status}"
+                + (f" ({step.detail})" if step.detail else "")
+                for step in result.steps
+            )
+        data = [result.to_dict() for result in results]
+        if getattr(args, "json", False):
+            message = json.dumps(data, indent=2)
+        else:
+            message = "\n".join(lines)
+        if any(result.failed for result in results):
+            return CommandResult.error_result(message, data=data)
+        return CommandResult.success_result(message, data=data)
+
+
+def manage_post_edit(args) -> int:
+    """Main entry point for the post-edit command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = PostEditCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.message:
+        print(result.message)
+    return 0 if result.success else 1
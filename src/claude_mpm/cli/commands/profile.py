@@ -82,8 +82,7 @@ class ProfileCommand(BaseCommand):
             return CommandResult.success_result("No profiles available")
 
         # Load current active profile
-        main_config = self.config_loader.load_main_config()
-        active_profile = main_config.get("active_profile")
+        active_profile = self._active_profile()
 
         # Create table
         table = Table(title="Available Deployment Profiles")
@@ -164,11 +163,16 @@ class ProfileCommand(BaseCommand):
             {"profile": profile_name, "summary": summary},
         )
 
+    def _active_profile(self) -> str | None:
+        """Get the active profile, preferring the project's own selection."""
+        return ProfileManager.configured_profile() or (
+            self.config_loader.load_main_config().get("active_profile")
+        )
+
     def _show_status(self, args) -> CommandResult:
         """Show current profile status."""
         # Load current active profile
-        main_config = self.config_loader.load_main_config()
-        active_profile = main_config.get("active_profile")
+        active_profile = self._active_profile()
 
         if not active_profile:
             console.print(
@@ -204,6 +208,8 @@ class ProfileCommand(BaseCommand):
         console.print(
             f"  Disabled patterns: [cyan]{summary['disabled_patterns_count']}[/cyan]"
         )
+        if sources := self.profile_manager.get_skill_sources():
+            console.print(f"  Sources: [cyan]{', '.join(sorted(sources))}[/cyan]")
 
         return CommandResult.success_result(
             f"Profile '{active_profile}' status",
@@ -249,6 +255,14 @@ class ProfileCommand(BaseCommand):
                 console.print(f"  • {agent}")
             console.print()
 
+        # Show skill sources
+        skill_sources = self.profile_manager.get_skill_sources()
+        if skill_sources:
+            console.print("[bold]Skill Sources:[/bold]")
+            for source in sorted(skill_sources):
+                console.print(f"  • {source}")
+            console.print()
+
         # Show enabled skills
         enabled_skills = self.profile_manager.get_enabled_skills()
         if enabled_skills:
@@ -270,6 +284,7 @@ class ProfileCommand(BaseCommand):
                 "profile": profile_name,
                 "enabled_agents": list(enabled_agents),
                 "disabled_agents": list(disabled_agents),
+                "skill_sources": sorted(skill_sources),
                 "enabled_skills": list(enabled_skills),
                 "disabled_patterns": disabled_patterns,
             },
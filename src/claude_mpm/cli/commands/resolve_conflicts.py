@@ -0,0 +1,172 @@
+"""
+Resolve-conflicts command implementation for claude-mpm.
+
+WHY: After a merge, rebase or cherry-pick stops on conflicts, each conflict
+region goes to its own agent with both sides and their history. This module
+shows each proposal as a diff and writes only the ones the user accepts.
+
+DESIGN DECISIONS:
+- All proposals are gathered first, then reviewed one by one, so the review
+  is not interrupted by agents still working
+- ``--yes`` accepts every successful proposal; ``--dry-run`` and ``--json``
+  only show them
+- Rejected regions keep their markers and their file stays unstaged
+"""
+
+from __future__ import annotations
+
+import json
+import sys
+from collections.abc import Callable
+
+from ...services.conflict_resolution import (
+    ConflictedFile,
+    ConflictError,
+    ConflictResolver,
+    Proposal,
+)
+from ...utils.theme import get_theme
+from ..shared import BaseCommand, CommandResult
+
+
+class ResolveConflictsCommand(BaseCommand):
+    """Propose agent resolutions for merge conflicts and apply accepted ones."""
+
+    def __init__(
+        self,
+        input_func: Callable[[str], str] = input,
+        resolver_factory: Callable[..., ConflictResolver] = ConflictResolver,
+    ):
+        super().__init__("resolve-conflicts")
+        self.input = input_func
+        self.resolver_factory = resolver_factory
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "jobs", 1) < 1:
+            return "--jobs must be at least 1"
+        return None
+
+    def run(self, args) -> CommandResult:
+        try:
+            resolver = self.resolver_factory(model=args.model, max_parallel=args.jobs)
+            files = resolver.load(args.paths)
+        except ConflictError as e:
+            return CommandResult.error_result(str(e))
+        if not files:
+            return CommandResult.success_result("No conflicts to resolve")
+
+        total = sum(len(f.hunks) for f in files)
+        if not args.json:
+            print(
+                f"Asking agents to resolve {total} conflict(s) in "
+                f"{len(files)} file(s)..."
+            )
+        proposals = resolver.propose(files)
+
+        if args.json or args.dry_run:
+            if args.json:
+                print(json.dumps([p.to_dict() for p in proposals], indent=2))
+            else:
+                for file in files:
+                    for proposal in _for_file(proposals, file):
+                        self._show(file, proposal)
+            failed = sum(not p.ok for p in proposals)
+            return CommandResult.success_result(
+                f"{total - failed} of {total} conflict(s) have a proposed "
+                "resolution (nothing written)",
+                data={"proposals": [p.to_dict() for p in proposals]},
+            )
+
+        return self._review(resolver, files, proposals, args)
+
+    def _review(
+        self,
+        resolver: ConflictResolver,
+        files: list[ConflictedFile],
+        proposals: list[Proposal],
+        args,
+    ) -> CommandResult:
+        applied, staged, quit_review = 0, [], False
+        for file in files:
+            accepted = []
+            for proposal in _for_file(proposals, file):
+                if quit_review:
+                    break
+                self._show(file, proposal)
+                if not proposal.ok:
+                    continue
+                answer = "y" if args.yes else self._ask()
+                if answer == "q":
+                    quit_review = True
+                elif answer == "y":
+                    accepted.append(proposal)
+            if not accepted:
+                continue
+            try:
+                if resolver.apply(file, accepted, stage=not args.no_stage):
+                    staged.append(file.path)
+            except (ConflictError, OSError) as e:
+                print(f"Error: {e}")
+                continue
+            applied += len(accepted)
+            print(f"Wrote {len(accepted)} resolution(s) to {file.path}")
+
+        total = len(proposals)
+        message = f"Applied {applied} of {total} conflict resolution(s)"
+        if staged and not args.no_stage:
+            message += f"; staged {len(staged)} fully resolved file(s)"
+        data = {"applied": applied, "total": total, "resolved_files": staged}
+        return CommandResult.success_result(message, data=data)
+
+    def _ask(self) -> str:
+        try:
+            answer = self.input("Apply this resolution? [y/N/q] ").strip().lower()
+        except (EOFError, KeyboardInterrupt):
+            print()
+            return "q"
+        return {"yes": "y", "quit": "q"}.get(answer, answer)
+
+    @staticmethod
+    def _show(file: ConflictedFile, proposal: Proposal) -> None:
+        print(f"\n=== {file.path}: {proposal.hunk.header} ===")
+        if not proposal.ok:
+            print(f"No proposal: {proposal.error}")
+            return
+        if proposal.explanation:
+            print(proposal.explanation)
+        print(get_theme().diff(proposal.diff()) or "Keeps our side unchanged")
+
+
+def _for_file(proposals: list[Proposal], file: ConflictedFile) -> list[Proposal]:
+    return sorted(
+        (p for p in proposals if p.path == file.path), key=lambda p: p.hunk.index
+    )
+
+
+def manage_resolve_conflicts(args) -> int:
+    """Main entry point for the resolve-conflicts command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = ResolveConflictsCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message and not args.json:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}", file=sys.stderr if args.json else sys.stdout)
+    return 1
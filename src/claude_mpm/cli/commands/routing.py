@@ -0,0 +1,193 @@
+"""
+Routing command implementation for claude-mpm.
+
+WHY: Lets users see which agent each task category goes to, try a task
+against the routes before relying on them, and read the outcome of an A/B
+split between two agent variants.
+
+DESIGN DECISIONS:
+- Thin wrapper around TaskRouter, which the PreToolUse hook uses too, so
+  ``routing test`` answers exactly as a delegation would be routed
+- ``test`` does not record anything, so trying tasks never skews a report
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.task_routing import (
+    MIN_MEASURED,
+    TaskRouter,
+    better_variant,
+    is_deployed,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class RoutingCommand(BaseCommand):
+    """CLI command for task-type routing."""
+
+    VALID_COMMANDS = ("show", "test", "report")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("routing")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "routing_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm routing {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "show": self._show,
+            "test": self._test,
+            "report": self._report,
+        }
+        try:
+            return handlers[args.routing_command](args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing routing command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing routing command: {e}")
+
+    @staticmethod
+    def _output(args, data, text: str) -> CommandResult:
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        return CommandResult.success_result(text, data=data)
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _show(self, args) -> CommandResult:
+        routes = TaskRouter(self.project_dir).routes
+        data = [
+            {
+                **route.to_dict(),
+                "deployed": {
+                    name: is_deployed(name, self.project_dir) for name in route.agents
+                },
+            }
+            for route in routes
+        ]
+        if not routes:
+            return self._output(
+                args,
+                data,
+                "No task routes. Add them under agents.routing in "
+                ".claude-mpm/configuration.yaml.",
+            )
+        lines = []
+        for route, entry in zip(routes, data, strict=True):
+            targets = ", ".join(
+                _share(route, name)
+                + ("" if entry["deployed"][name] else " (not deployed)")
+                for name in route.agents
+            )
+            scope = ""
+            if route.from_agents:
+                scope = f" (from {', '.join(route.from_agents)})"
+            lines.append(f"{route.category}{scope}: {targets}")
+            lines.append(f"  keywords: {', '.join(route.keywords)}")
+        return self._output(args, data, "\n".join(lines))
+
+    def _test(self, args) -> CommandResult:
+        router = TaskRouter(self.project_dir)
+        route = router.classify(args.task, args.agent)
+        if route is None:
+            data = {"category": None, "agent": args.agent}
+            return self._output(
+                args, data, f"No route matches; the task stays with {args.agent}"
+            )
+        data = {
+            "category": route.category,
+            "agents": route.agents,
+            "split": route.is_split,
+        }
+        if route.is_split:
+            shares = ", ".join(_share(route, name) for name in route.agents)
+            text = f"{route.category}: split between {shares}"
+        else:
+            text = f"{route.category}: goes to {next(iter(route.agents))}"
+        return self._output(args, data, text)
+
+    def _report(self, args) -> CommandResult:
+        from ...services.analytics_export import parse_since
+
+        router = TaskRouter(self.project_dir)
+        stats = router.report(args.category, parse_since(args.since))
+        data = {
+            category: {
+                "variants": {name: v.to_dict() for name, v in variants.items()},
+                "better": better_variant(variants),
+            }
+            for category, variants in stats.items()
+        }
+        if not stats:
+            return self._output(args, data, f"No routed delegations since {args.since}")
+        lines = []
+        for category, variants in stats.items():
+            lines.append(f"{category}:")
+            lines.append(
+                f"  {'AGENT':<24} {'ROUTED':>6} {'MEASURED':>8} {'ERRORS':>6} "
+                f"{'CORRECTED':>9} {'SUCCESS':>7} {'AVG TIME':>8} {'AVG TOKENS':>10}"
+            )
+            for name, v in variants.items():
+                duration = (
+                    f"{v.avg_duration_s:.0f}s" if v.avg_duration_s is not None else "-"
+                )
+                tokens = f"{v.avg_tokens:,.0f}" if v.avg_tokens is not None else "-"
+                lines.append(
+                    f"  {name:<24} {v.delegations:>6} {v.measured:>8} "
+                    f"{v.errors:>6} {v.corrections:>9} {v.success_rate:>7.0%} "
+                    f"{duration:>8} {tokens:>10}"
+                )
+            if len(variants) > 1:
+                better = data[category]["better"]
+                lines.append(
+                    f"  Better: {better}"
+                    if better
+                    else f"  No clear winner yet (needs {MIN_MEASURED} measured "
+                    "delegations per agent and a difference)"
+                )
+        return self._output(args, data, "\n".join(lines))
+
+
+def _share(route, name: str) -> str:
+    """Agent name with its share of a split's traffic."""
+    if not route.is_split:
+        return name
+    return f"{name} {route.agents[name] * 100 // sum(route.agents.values())}%"
+
+
+def manage_routing(args) -> int:
+    """Main entry point for the routing command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = RoutingCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
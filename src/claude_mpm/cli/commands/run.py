@@ -25,6 +25,7 @@ import subprocess  # nosec B404 - required for process management
 import sys
 from datetime import UTC, datetime
 from pathlib import Path
+from typing import Any
 
 from ...constants import LogLevel
 from ...core.logger import get_logger
@@ -112,6 +113,10 @@ def filter_claude_mpm_args(claude_args):
         "--max-turns",
         "--exit-condition",
         "--on-complete",
+        # Per-session overrides (MPM-specific, applied before launch)
+        "--env",
+        "--cwd",
+        "--label",
     }
 
     filtered_args = []
@@ -144,6 +149,10 @@ def filter_claude_mpm_args(claude_args):
                 "--max-turns",
                 "--exit-condition",
                 "--on-complete",
+                # Per-session overrides
+                "--env",
+                "--cwd",
+                "--label",
             }
             optional_value_flags = {
                 "--mpm-resume"
@@ -473,7 +482,9 @@ class RunCommand(BaseCommand):
             else:
                 # Find available port and start server
                 websocket_port = dashboard_manager.find_available_port(8765)
-                success, _ = dashboard_manager.start_server(port=websocket_port)
+                success, server_info = dashboard_manager.start_server(
+                    port=websocket_port
+                )
 
                 if not success:
                     self.logger.warning(
@@ -481,6 +492,8 @@ class RunCommand(BaseCommand):
                     )
                     monitor_mode = False
                 else:
+                    # The shared daemon may serve the project on another port
+                    websocket_port = server_info.port
                     # Use UnifiedDashboardManager for browser opening only
                     dashboard_manager = UnifiedDashboardManager(self.logger)
                     monitor_url = dashboard_manager.get_dashboard_url(websocket_port)
@@ -738,6 +751,194 @@ def _handle_reload_agents(logger):
         print("⚠️  Continuing with existing agents...")
 
 
+def _session_overrides(args) -> dict[str, Any]:
+    """The --env/--cwd overrides for this session, as stored in its metadata.
+
+    A session resumed with --mpm-resume starts from the overrides it was
+    created with; values given on this command line replace them.
+
+    Raises:
+        ValueError: for a malformed --env or a --cwd that is not a directory
+    """
+    stored: dict[str, Any] = {}
+    resume = getattr(args, "mpm_resume", None)
+    if resume:
+        session_manager = SessionManager()
+        if resume == "last":
+            resume = session_manager.get_last_interactive_session()
+        session_data = session_manager.get_session_info(resume) if resume else None
+        stored = (session_data or {}).get("metadata", {})
+
+    env = dict(stored.get("env") or {})
+    for item in getattr(args, "session_env", None) or []:
+        name, sep, value = item.partition("=")
+        if not sep or not name:
+            raise ValueError(f"--env expects KEY=VALUE, got {item!r}")
+        env[name] = value
+
+    cwd = stored.get("cwd")
+    if getattr(args, "cwd", None):
+        cwd = str(Path(args.cwd).expanduser().resolve())
+    if cwd and not Path(cwd).is_dir():
+        raise ValueError(f"--cwd {cwd} is not a directory")
+
+    overrides: dict[str, Any] = {}
+    if env:
+        overrides["env"] = env
+    if cwd:
+        overrides["cwd"] = cwd
+    return overrides
+
+
+def _apply_session_overrides(args) -> dict[str, Any]:
+    """Change into the session's --cwd and export its --env variables.
+
+    Only this process and the Claude Code it launches see them, so parallel
+    sessions can each target their own directory and configuration. Returns
+    the overrides to store in the session's metadata.
+    """
+    try:
+        overrides = _session_overrides(args)
+    except ValueError as e:
+        print(f"❌ {e}", file=sys.stderr)
+        sys.exit(1)
+    if "cwd" in overrides:
+        os.chdir(overrides["cwd"])
+    os.environ.update(overrides.get("env", {}))
+    return overrides
+
+
+def _export_session_labels(args) -> None:
+    """Validate --label and hand the labels to the SessionStart hook.
+
+    Claude Code picks the session id only once it starts, so the hook
+    records the labels under that id.
+    """
+    from ...services import session_labels
+
+    if not getattr(args, "session_labels", None):
+        return
+    try:
+        labels = session_labels.SessionLabels(Path.cwd()).validate(
+            args.session_labels
+        )
+    except ValueError as e:
+        print(f"❌ {e}", file=sys.stderr)
+        sys.exit(1)
+    os.environ[session_labels.ENV_VAR] = ",".join(labels)
+
+
+def _check_workspace_trust(args) -> None:
+    """Decide workspace trust for this session and export it to hooks.
+
+    Prompts once per repository on interactive runs; headless and piped runs
+    never prompt and run restricted until the workspace is trusted, with a
+    notice on stderr that names the restriction and how to lift it. The
+    decision is exported as CLAUDE_MPM_WORKSPACE_RESTRICTED so the PreToolUse
+    dispatcher enforces it too.
+    """
+    from ...services import workspace_trust
+
+    interactive = not (
+        getattr(args, "headless", False)
+        or getattr(args, "non_interactive", False)
+        or getattr(args, "input", None)
+        or getattr(args, "prompt", None)
+    )
+    try:
+        trusted = workspace_trust.resolve_workspace_trust(
+            Path.cwd(), interactive=None if interactive else False
+        )
+    except Exception as e:
+        # A broken trust store must not silently grant trust
+        get_logger("cli").warning(f"Workspace trust check failed: {e}")
+        trusted = False
+    if trusted:
+        os.environ.pop(workspace_trust.RESTRICTED_ENV_VAR, None)
+    else:
+        os.environ[workspace_trust.RESTRICTED_ENV_VAR] = "1"
+
+
+def _workspace_trust_args() -> list[str]:
+    """Claude CLI flags for the restricted policy, if this session needs them."""
+    from ...services import workspace_trust
+
+    if not workspace_trust.is_restricted_session():
+        return []
+    return workspace_trust.restricted_claude_args()
+
+
+def _export_feature_flags() -> None:
+    """Export the environment Claude Code needs for enabled feature flags.
+
+    A variable the user already set is left alone.
+    """
+    try:
+        from ...services.feature_flags import FeatureFlags
+
+        for name, value in FeatureFlags().exported_env().items():
+            os.environ.setdefault(name, value)
+    except Exception as e:
+        get_logger("cli").warning(f"Could not apply feature flags: {e}")
+
+
+def _apply_dev_environment() -> None:
+    """Provision and export the project's declared tools (dev_environment).
+
+    Untrusted workspaces are only checked. With ``strict: true`` a tool that
+    is still missing stops the session from starting.
+    """
+    from ...services import dev_environment, workspace_trust
+
+    logger = get_logger("cli")
+    try:
+        spec = dev_environment.EnvironmentSpec.from_config()
+        if spec is None:
+            return
+        trusted = not workspace_trust.is_restricted_session()
+        report = dev_environment.resolve_environment(spec, provision=trusted)
+    except Exception as e:
+        logger.warning(f"Could not apply dev_environment: {e}")
+        return
+    if trusted:
+        dev_environment.apply_environment(report)
+    if report.provisioned:
+        print(f"🔧 Installed {', '.join(report.provisioned)} via {report.manager}")
+    if report.ok:
+        logger.info(f"Dev environment: {report.summary()}")
+        return
+    print(f"⚠️  Dev environment: {report.summary()}", file=sys.stderr)
+    if spec.strict:
+        print(
+            "❌ dev_environment.strict is set; not starting until this is fixed",
+            file=sys.stderr,
+        )
+        sys.exit(1)
+
+
+def _canary_args(args, claude_args: list[str]) -> list[str]:
+    """Assign a new session to running canaries; returns its --session-id.
+
+    Resumed and continued sessions keep what they started with. An explicit
+    --instructions-override is exported first so it wins over an
+    instructions canary.
+    """
+    resuming = ("--resume", "-r", "--continue", "-c", "--session-id")
+    if any(flag in claude_args for flag in resuming):
+        return []
+    override = getattr(args, "instructions_override", None)
+    if isinstance(override, str) and override:
+        os.environ["CLAUDE_MPM_INSTRUCTIONS_OVERRIDE"] = override
+    try:
+        from ...services.canary import CanaryStore
+
+        session_id = CanaryStore().start_session(Path.cwd())
+    except Exception as e:
+        get_logger("cli").warning(f"Canary assignment failed: {e}")
+        return []
+    return ["--session-id", session_id] if session_id else []
+
+
 def _run_headless_session(args) -> int:
     """
     Run Claude in headless mode with stream-json output.
@@ -806,6 +1007,11 @@ def _run_headless_session(args) -> int:
         claude_args.extend(["--disallowedTools", args.disallowedTools])
     if getattr(args, "fork_session", False):
         claude_args.append("--fork-session")
+    claude_args.extend(_workspace_trust_args())
+    _export_feature_flags()
+    _apply_dev_environment()
+    if not getattr(args, "mpm_resume", None):
+        claude_args.extend(_canary_args(args, claude_args))
 
     # Use ClaudeRunner (not MinimalRunner) to ensure _create_system_prompt is available
     # This is required for PM system prompt injection in headless mode
@@ -959,6 +1165,19 @@ def run_session_legacy(args):
     if getattr(args, "no_dangerously_skip_permissions", False):
         os.environ["CLAUDE_MPM_NO_SKIP_PERMISSIONS"] = "1"
 
+    # --chaos reaches the hook processes through the environment
+    if getattr(args, "chaos", False):
+        from ...services import chaos
+
+        os.environ[chaos.ENV_VAR] = "1"
+
+    # --cwd/--env first, so trust and everything after apply to that directory
+    session_overrides = _apply_session_overrides(args)
+    _export_session_labels(args)
+
+    # Untrusted workspaces run restricted (no shell, network or repo hooks)
+    _check_workspace_trust(args)
+
     # Handle headless mode early - bypass all Rich console output
     if getattr(args, "headless", False):
         exit_code = _run_headless_session(args)
@@ -1291,6 +1510,10 @@ def run_session_legacy(args):
     # Filter out claude-mpm specific flags before passing to Claude CLI
     logger.debug(f"Pre-filter claude_args: {raw_claude_args}")
     claude_args = filter_claude_mpm_args(raw_claude_args)
+    claude_args.extend(_workspace_trust_args())
+    _export_feature_flags()
+    _apply_dev_environment()
+    claude_args.extend(_canary_args(args, claude_args))
     monitor_mode = getattr(args, "monitor", False)
 
     # Enhanced debug logging for argument filtering
@@ -1351,6 +1574,7 @@ def run_session_legacy(args):
                 )
 
                 if success:
+                    websocket_port = server_info.port
                     print(f"✓ Socket.IO server enabled at {server_info.url}")
                     if launch_method == "exec":
                         print(
@@ -1408,10 +1632,11 @@ def run_session_legacy(args):
         if session:
             session.last_used = datetime.now(UTC).isoformat()
             session.use_count += 1
+            session.metadata.update(session_overrides)
             session_manager.save_session(session)
     else:
-        # Create a new session for tracking
-        new_session = session_manager.create_session("default")
+        # Create a new session for tracking, with its --env/--cwd overrides
+        new_session = session_manager.create_session("default", session_overrides)
         context = create_simple_context()
         logger.info(f"Created new session {new_session.id}")
 
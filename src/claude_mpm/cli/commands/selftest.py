@@ -0,0 +1,131 @@
+"""
+Selftest command implementation for claude-mpm.
+
+WHY: "Does my install actually work?" should have a one-command answer that
+does not require an API key or a real Claude session.
+
+DESIGN DECISIONS:
+- Thin wrapper around SelftestRunner; scenarios live in the service layer
+- Results stream as each scenario finishes so slow steps are visible
+- Exit code is non-zero if any scenario failed (skipped ones do not count)
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.selftest import MockModelBackend, SelftestRunner
+from ..shared import BaseCommand, CommandResult
+
+_STATUS_ICONS = {"passed": "✅", "failed": "❌", "skipped": "⏭️ "}
+
+
+class SelftestCommand(BaseCommand):
+    """CLI command for the end-to-end selftest."""
+
+    def __init__(self, runner: SelftestRunner | None = None):
+        super().__init__("selftest")
+        self.runner = runner or SelftestRunner()
+
+    def validate_args(self, args) -> str | None:
+        known = {s.name for s in self.runner.scenarios}
+        unknown = [n for n in getattr(args, "scenarios", None) or [] if n not in known]
+        if unknown:
+            return (
+                f"Unknown scenario(s): {', '.join(unknown)}. "
+                f"Available: {', '.join(sorted(known))}"
+            )
+
+        replay = getattr(args, "replay", None)
+        if replay:
+            path = _resolve_recording(replay)
+            if path is None:
+                return f"No recording or session found for '{replay}'"
+            try:
+                self.runner.backend = MockModelBackend.from_recording(path)
+            except (OSError, ValueError) as e:
+                return f"Cannot replay {path}: {e}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        if getattr(args, "list", False):
+            lines = [f"  {s.name:<18} {s.description}" for s in self.runner.scenarios]
+            return CommandResult.success_result(
+                "Selftest scenarios:\n" + "\n".join(lines)
+            )
+
+        as_json = getattr(args, "json", False)
+        self.runner.keep_workspace = getattr(args, "keep_workspace", False)
+
+        def report(result):
+            if not as_json:
+                icon = _STATUS_ICONS.get(result.status, "?")
+                print(
+                    f"{icon} {result.name:<18} {result.detail} "
+                    f"({result.duration:.1f}s)"
+                )
+
+        if not as_json:
+            source = getattr(args, "replay", None) or "mock model backend"
+            print(f"Running claude-mpm selftest ({source}, no API calls)")
+        results = self.runner.run(getattr(args, "scenarios", None), on_result=report)
+
+        data = {"results": [r.to_dict() for r in results]}
+        if self.runner.keep_workspace:
+            data["workspace"] = str(self.runner.workspace)
+        if as_json:
+            print(json.dumps(data, indent=2))
+
+        failed = [r.name for r in results if r.status == "failed"]
+        if failed:
+            return CommandResult.error_result(
+                "" if as_json else f"Selftest failed: {', '.join(failed)}", data=data
+            )
+
+        passed = sum(1 for r in results if r.status == "passed")
+        message = f"Selftest passed ({passed}/{len(results)} scenarios)"
+        if self.runner.keep_workspace:
+            message += f"\nWorkspace kept at {self.runner.workspace}"
+        return CommandResult.success_result("" if as_json else message, data=data)
+
+
+def _resolve_recording(value: str) -> Path | None:
+    """A recording path, or the transcript of a session in this project."""
+    path = Path(value).expanduser()
+    if path.is_file():
+        return path
+
+    from ...services.session_analysis.transcript_parser import locate_transcript
+
+    transcript = locate_transcript(value, str(Path.cwd()))
+    return transcript if transcript.is_file() else None
+
+
+def manage_selftest(args) -> int:
+    """Main entry point for the selftest command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = SelftestCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -107,6 +107,13 @@ def run_session_report(args: argparse.Namespace) -> int:
         )
         return 1
 
+    # -- Review comments and labels -------------------------------------------
+    from ...services.annotations import AnnotationStore
+    from ...services.session_labels import SessionLabels
+
+    annotations = AnnotationStore(project_path).annotations(session_id)
+    labels = SessionLabels(project_path).labels(session_id)
+
     # -- Render ---------------------------------------------------------------
     output_arg: str | None = getattr(args, "output", None)
 
@@ -114,7 +121,7 @@ def run_session_report(args: argparse.Namespace) -> int:
         # Default: write to docs/reporting/session-tracker/{session_id}.md
         output_path = _DEFAULT_OUTPUT_DIR / f"{session_id}.md"
         try:
-            write_report(report, output_path)
+            write_report(report, output_path, annotations, labels)
         except OSError as exc:
             print(f"Failed to write report: {exc}", file=sys.stderr)
             return 1
@@ -122,13 +129,13 @@ def run_session_report(args: argparse.Namespace) -> int:
 
     elif output_arg == "-":
         # Stdout
-        sys.stdout.write(render_markdown(report))
+        sys.stdout.write(render_markdown(report, annotations, labels))
 
     else:
         # Explicit file path
         out_path = Path(output_arg)
         try:
-            write_report(report, out_path)
+            write_report(report, out_path, annotations, labels)
         except OSError as exc:
             print(f"Failed to write report: {exc}", file=sys.stderr)
             return 1
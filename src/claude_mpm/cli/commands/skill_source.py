@@ -17,10 +17,39 @@ import json
 import logging
 import os
 import re
-
-from ...config.skill_sources import SkillSource, SkillSourceConfiguration
-from ...services.skills.git_skill_source_manager import GitSkillSourceManager
+from pathlib import Path
+from urllib.parse import urlparse
+
+from ...config.skill_sources import (
+    SkillSource,
+    SkillSourceConfiguration,
+    is_registry_url,
+)
+from ...services.skills.git_hosts import get_host
+from ...services.skills.git_skill_source_manager import (
+    GitSkillSourceManager,
+    check_ssh_source_access,
+    resolve_source_commit,
+)
+from ...services.skills.http_registry import (
+    INDEX_FILE,
+    RegistryError,
+    build_registry,
+    check_registry_access,
+)
 from ...services.skills.skill_discovery_service import SkillDiscoveryService
+from ...services.skills.skill_signing import (
+    MANIFEST_FILE,
+    SignatureError,
+    TrustedKey,
+    sign_collection,
+)
+from ...services.skills.skills_lock import SkillsLock
+from ...utils.bulk_operations import DEFAULT_JOBS
+from ...utils.progress import ProgressBar
+from ...utils.table_view import TableView
+from ..list_columns import SKILL_SOURCE_COLUMNS
+from ..verbosity import emit_quiet_result, emit_result
 
 logger = logging.getLogger(__name__)
 
@@ -59,7 +88,9 @@ def _test_skill_repository_access(source: SkillSource) -> dict:
 
     Rationale: GitHub API is faster and less resource-intensive than
     cloning the repository. We can validate access and existence without
-    downloading any files.
+    downloading any files. SSH sources cannot use the API with a deploy key,
+    so they are checked with ``git ls-remote`` instead; GitLab and Bitbucket
+    sources are checked through their own APIs.
 
     Args:
         source: SkillSource to test
@@ -75,7 +106,22 @@ def _test_skill_repository_access(source: SkillSource) -> dict:
         >>> print(result["accessible"])
         True
     """
-    import requests
+    from ...services import http_client
+
+    if source.is_local:
+        if source.local_path.is_dir():
+            return {"accessible": True, "error": None}
+        return {"accessible": False, "error": f"Not a directory: {source.url}"}
+    if source.is_http:
+        error = check_registry_access(source)
+        return {"accessible": error is None, "error": error}
+    if source.is_ssh:
+        error = check_ssh_source_access(source)
+        return {"accessible": error is None, "error": error}
+    host = get_host(source)
+    if host is not None:
+        error = host.check_access()
+        return {"accessible": error is None, "error": error}
 
     try:
         # Parse GitHub URL
@@ -96,7 +142,7 @@ def _test_skill_repository_access(source: SkillSource) -> dict:
         if token:
             headers["Authorization"] = f"token {token}"
 
-        response = requests.get(api_url, headers=headers, timeout=10)
+        response = http_client.get(api_url, headers=headers, timeout=10)
 
         if response.status_code == 200:
             return {"accessible": True, "error": None}
@@ -140,6 +186,8 @@ def _test_skill_repository_sync(source: SkillSource) -> dict:
         - synced: bool (True if sync successful)
         - skills_discovered: int (number of skills found)
         - error: str (error message if sync failed)
+        - signature: dict (the signature check, for a source with trusted
+          keys)
 
     Example:
         >>> source = SkillSource(id="custom", type="git", url="https://github.com/owner/repo")
@@ -181,6 +229,7 @@ def _test_skill_repository_sync(source: SkillSource) -> dict:
                 "synced": True,
                 "skills_discovered": sync_result.get("skills_discovered", 0),
                 "error": None,
+                "signature": sync_result.get("signature"),
             }
 
     except Exception as e:
@@ -203,7 +252,15 @@ def _generate_source_id(url: str) -> str:
         https://github.com/owner/repo.git -> repo
         https://github.com/owner/repo -> repo
         git@github.com:owner/repo.git -> repo
+        https://skills.example.com/index.json -> skills
+        https://artifacts.example.com/skills/platform/index.json -> platform
     """
+    if is_registry_url(url):
+        # Registry: the folder holding the index, else the host's first label
+        parsed = urlparse(url)
+        folder = parsed.path.rsplit("/", 1)[0].strip("/")
+        url = folder or (parsed.hostname or "").split(".")[0]
+
     # Remove .git suffix
     url_clean = url.rstrip("/").removesuffix(".git")
 
@@ -227,6 +284,39 @@ def _generate_source_id(url: str) -> str:
     return sanitized or "unnamed-repo"
 
 
+def _is_local_path(url: str) -> bool:
+    """Whether an ``add`` argument names a local directory rather than a URL."""
+    if "://" in url or re.match(r"^[\w.-]+@[\w.-]+:", url):
+        return False
+    return url.startswith((".", "/", "~")) or Path(url).is_dir()
+
+
+def _watch_local_source(source: SkillSource, config: SkillSourceConfiguration) -> int:
+    """Redeploy a local source's skills on every change until Ctrl+C."""
+    from ...services.skills.local_skill_watcher import LocalSkillWatcher
+
+    watcher = LocalSkillWatcher(GitSkillSourceManager(config=config), source)
+
+    def report(result: dict) -> None:
+        if "error" in result:
+            print(f"❌ Sync failed: {result['error']}")
+            return
+        for error in result["errors"]:
+            print(f"❌ {error}")
+        for name in result["deployed"]:
+            print(f"🔄 Deployed {name}")
+        for name in result["removed"]:
+            print(f"🗑️  Removed {name}")
+
+    print(f"👀 Watching {source.local_path} (Ctrl+C to stop)")
+    print(f"   Deploying to {watcher.target_dir}")
+    print()
+    watcher.run(on_sync=report)
+    print()
+    print("✅ Stopped watching")
+    return 0
+
+
 def skill_source_command(args) -> int:
     """Main entry point for skill-source commands.
 
@@ -246,6 +336,10 @@ def skill_source_command(args) -> int:
         "enable": handle_enable_skill_source,
         "disable": handle_disable_skill_source,
         "show": handle_show_skill_source,
+        "watch": handle_watch_skill_source,
+        "sign": handle_sign_skill_source,
+        "pack": handle_pack_skill_source,
+        "verify": handle_verify_skill_sources,
     }
 
     handler = handlers.get(getattr(args, "skill_source_command", None))
@@ -267,7 +361,8 @@ def handle_add_skill_source(args) -> int:
     """Add a new skill source with immediate testing.
 
     Args:
-        args: Parsed arguments with url, priority, branch, disabled, test, skip_test
+        args: Parsed arguments with url, id, priority, branch, disabled, test,
+            skip_test
 
     Returns:
         Exit code
@@ -282,13 +377,26 @@ def handle_add_skill_source(args) -> int:
     - --test: Test only, don't save to configuration
     - --no-test: Skip testing entirely (not recommended)
     - Default: Test and save if successful
+
+    A local directory is added as a "local" source (stored as its absolute
+    path); with --watch the command keeps running and hot-syncs it. A URL
+    ending in .json is added as an "http" registry source.
     """
     try:
         # Load configuration
         config = SkillSourceConfiguration()
 
-        # Generate source ID from URL
-        source_id = _generate_source_id(args.url)
+        local = _is_local_path(args.url)
+        registry = not local and is_registry_url(args.url)
+        watch = getattr(args, "watch", False)
+        if watch and not local:
+            print("❌ --watch only applies to a local directory source")
+            return 1
+        if local:
+            args.url = str(Path(args.url).expanduser().resolve())
+
+        # Use the given ID, or generate one from the URL
+        source_id = getattr(args, "id", None) or _generate_source_id(args.url)
 
         # Check if already exists
         existing = config.get_source(source_id)
@@ -315,14 +423,27 @@ def handle_add_skill_source(args) -> int:
             print("   --token $MY_PRIVATE_TOKEN")
             print()
 
+        ssh_key = getattr(args, "ssh_key", None)
+        provider = getattr(args, "provider", None)
+        trusted_keys = getattr(args, "trusted_keys", None) or []
+        for key in trusted_keys:
+            try:
+                TrustedKey.parse(key)
+            except SignatureError as e:
+                print(f"❌ {e}")
+                return 1
+
         source = SkillSource(
             id=source_id,
-            type="git",
+            type="local" if local else "http" if registry else "git",
             url=args.url,
             branch=args.branch,
             priority=args.priority,
             enabled=enabled,
             token=token,
+            ssh_key=ssh_key,
+            provider=provider,
+            trusted_keys=trusted_keys,
         )
 
         # Determine if we should test
@@ -331,7 +452,8 @@ def handle_add_skill_source(args) -> int:
 
         # Test repository access unless explicitly skipped
         if not skip_test:
-            print(f"🔍 Testing repository access: {args.url}")
+            what = "directory" if local else "registry" if registry else "repository"
+            print(f"🔍 Testing {what} access: {args.url}")
             print()
 
             test_result = _test_skill_repository_access(source)
@@ -342,7 +464,7 @@ def handle_add_skill_source(args) -> int:
                 print("💡 Check the URL and try again")
                 return 1
 
-            print("✅ Repository accessible")
+            print(f"✅ {what.capitalize()} accessible")
 
             # Test sync and discovery
             print("🔍 Testing sync and skill discovery...")
@@ -359,9 +481,19 @@ def handle_add_skill_source(args) -> int:
                 )
                 return 1
 
+            signature = sync_result.get("signature")
+            if signature and not signature["verified"]:
+                print(f"❌ Signature verification failed: {signature['error']}")
+                print()
+                print("💡 Check the trusted key, or sign the repository with")
+                print("   claude-mpm skill-source sign")
+                return 1
+
             skills_count = sync_result.get("skills_discovered", 0)
             print("✅ Sync successful")
             print(f"   Discovered {skills_count} skills")
+            if signature:
+                print(f"   Signed by {signature['signer']}")
             print()
 
         # If test mode, stop here
@@ -384,16 +516,48 @@ def handle_add_skill_source(args) -> int:
         # Add source
         config.add_source(source)
 
+        # Pin the branch head in the project's skills.lock (a source added
+        # without testing is pinned on its first sync instead)
+        commit_note = "pinned on first sync"
+        if local:
+            commit_note = "local directory (not pinned)"
+        elif registry:
+            commit_note = "registry (not pinned)"
+        elif not skip_test:
+            try:
+                pin = SkillsLock().pin(source, resolve_source_commit(source))
+                commit_note = f"{pin.commit[:12]} (skills.lock)"
+            except (RuntimeError, ValueError) as e:
+                commit_note = f"not pinned yet ({e})"
+
         # Success message
         status_emoji = "✅" if enabled else "⚠️ "
         status_text = "enabled" if enabled else "disabled"
         print(f"{status_emoji} Added skill source: {source_id}")
-        print(f"   URL: {args.url}")
-        print(f"   Branch: {args.branch}")
+        emit_quiet_result(source_id)
+        if local:
+            print(f"   Path: {args.url}")
+        elif registry:
+            print(f"   Index: {args.url}")
+        else:
+            print(f"   URL: {args.url}")
+            print(f"   Branch: {args.branch}")
+        print(f"   Commit: {commit_note}")
+        if ssh_key:
+            print(f"   SSH key: {ssh_key}")
+        if source.hosting and source.hosting != "github":
+            print(f"   Provider: {source.hosting}")
+        for key in trusted_keys:
+            print(f"   Trusted key: {TrustedKey.parse(key)}")
         print(f"   Priority: {args.priority}")
         print(f"   Status: {status_text}")
         print()
 
+        if watch:
+            if not enabled:
+                print(f"💡 Enable it first: claude-mpm skill-source enable {source_id}")
+                return 1
+            return _watch_local_source(source, config)
         if enabled:
             print("💡 Repository configured and tested successfully")
             print("   Skills from this source will be available on next startup")
@@ -440,6 +604,8 @@ def handle_list_skill_sources(args) -> int:
                     "branch": s.branch,
                     "priority": s.priority,
                     "enabled": s.enabled,
+                    **({"ssh_key": s.ssh_key} if s.ssh_key else {}),
+                    **({"provider": s.provider} if s.provider else {}),
                 }
                 for s in sources
             ]
@@ -452,21 +618,29 @@ def handle_list_skill_sources(args) -> int:
                 print("💡 Add a source: claude-mpm skill-source add <git-url>")
                 return 0
 
+            rows = [
+                {
+                    "id": s.id,
+                    "enabled": s.enabled,
+                    "priority": s.priority,
+                    "branch": s.branch,
+                    "url": s.url,
+                }
+                for s in sources
+            ]
+            table = TableView.from_args(SKILL_SOURCE_COLUMNS, rows, args)
+
             filter_text = " (enabled only)" if args.enabled_only else ""
             print(f"📚 Configured Skill Sources ({len(sources)} total{filter_text}):")
             print()
-
-            for source in sources:
-                status = "✅" if source.enabled else "❌"
-                status_text = "Enabled" if source.enabled else "Disabled"
-                print(f"  {status} {source.id} ({status_text})")
-                print(f"     URL: {source.url}")
-                print(f"     Branch: {source.branch}")
-                print(f"     Priority: {source.priority}")
-                print()
+            emit_result(table.render())
+            print()
 
         return 0
 
+    except ValueError as e:
+        print(f"❌ {e}")
+        return 1
     except Exception as e:
         logger.error(f"Failed to list skill sources: {e}", exc_info=True)
         print(f"❌ Failed to list skill sources: {e}")
@@ -503,11 +677,14 @@ def handle_remove_skill_source(args) -> int:
                 print("❌ Cancelled")
                 return 0
 
-        # Remove source
-        config.remove_source(args.source_id)
+        # Remove source, its pin, its cache and the skills only it provided
+        manager = GitSkillSourceManager(config, lock=SkillsLock())
+        result = manager.remove_source(args.source_id)
 
         print()
         print(f"✅ Removed skill source: {args.source_id}")
+        if result["skills_removed"]:
+            print(f"   Removed skills: {', '.join(result['skills_removed'])}")
 
         return 0
 
@@ -528,7 +705,9 @@ def handle_update_skill_sources(args) -> int:
     """
     try:
         config = SkillSourceConfiguration()
-        manager = GitSkillSourceManager(config)
+        # Refresh caches at the commits pinned in skills.lock; moving a pin
+        # forward is 'skills update'
+        manager = GitSkillSourceManager(config, lock=SkillsLock())
 
         if args.source_id:
             # Update specific source
@@ -549,6 +728,8 @@ def handle_update_skill_sources(args) -> int:
                 print(f"✅ Successfully updated {args.source_id}")
                 skills_count = result.get("skills_discovered", 0)
                 print(f"   Skills discovered: {skills_count}")
+                if "signature" in result:
+                    print(f"   Signature: {_describe_signature(result['signature'])}")
 
                 if skills_count > 0:
                     print()
@@ -561,9 +742,19 @@ def handle_update_skill_sources(args) -> int:
                 print(f"   Error: {error_msg}")
                 return 1
         else:
-            # Update all sources
+            # Update all sources, several at a time
             print("🔄 Updating all skill sources...")
-            results = manager.sync_all_sources(force=args.force)
+            progress = ProgressBar(
+                len(config.get_enabled_sources()), prefix="Updating sources"
+            )
+            results = manager.sync_all_sources(
+                force=args.force,
+                max_workers=getattr(args, "jobs", DEFAULT_JOBS),
+                source_progress_callback=progress.update,
+            )
+            progress.finish(
+                f"{results['synced_count']} updated, {results['failed_count']} failed"
+            )
 
             success_count = results["synced_count"]
             total_count = success_count + results["failed_count"]
@@ -576,6 +767,9 @@ def handle_update_skill_sources(args) -> int:
                 if result.get("synced"):
                     skills_count = result.get("skills_discovered", 0)
                     print(f"   ✅ {source_id}: {skills_count} skills")
+                    if "signature" in result:
+                        signature = _describe_signature(result["signature"])
+                        print(f"      Signature: {signature}")
                 else:
                     error_msg = result.get("error", "Unknown error")
                     print(f"   ❌ {source_id}: {error_msg}")
@@ -584,6 +778,9 @@ def handle_update_skill_sources(args) -> int:
                 print()
                 print("💡 List all skills: claude-mpm skills list")
 
+            if results["failed_count"]:
+                return 1
+
         return 0
 
     except Exception as e:
@@ -698,8 +895,19 @@ def handle_show_skill_source(args) -> int:
         print(f"📚 Skill Source: {source.id}")
         print()
         print(f"  Status: {status_emoji} {status_text}")
-        print(f"  URL: {source.url}")
-        print(f"  Branch: {source.branch}")
+        if source.is_local:
+            print(f"  Path: {source.url}")
+        elif source.is_http:
+            print(f"  Index: {source.url}")
+        else:
+            print(f"  URL: {source.url}")
+            print(f"  Branch: {source.branch}")
+        if source.ssh_key:
+            print(f"  SSH key: {source.ssh_key}")
+        if source.hosting and source.hosting != "github":
+            print(f"  Provider: {source.hosting}")
+        for key in source.trusted_keys:
+            print(f"  Trusted key: {key}")
         print(f"  Priority: {source.priority}")
         print()
 
@@ -745,3 +953,157 @@ def handle_show_skill_source(args) -> int:
         logger.error(f"Failed to show skill source: {e}", exc_info=True)
         print(f"❌ Failed to show skill source: {e}")
         return 1
+
+
+def handle_watch_skill_source(args) -> int:
+    """Redeploy a local skill source whenever its files change.
+
+    Args:
+        args: Parsed arguments with source_id
+
+    Returns:
+        Exit code
+    """
+    try:
+        config = SkillSourceConfiguration()
+        source = config.get_source(args.source_id)
+
+        if not source:
+            print(f"❌ Source not found: {args.source_id}")
+            print()
+            print("💡 List sources: claude-mpm skill-source list")
+            return 1
+        if not source.is_local:
+            print(f"❌ {args.source_id} is not a local directory source")
+            return 1
+        if not source.enabled:
+            print(f"❌ Source is disabled: {args.source_id}")
+            print()
+            print(f"💡 Enable it: claude-mpm skill-source enable {args.source_id}")
+            return 1
+
+        return _watch_local_source(source, config)
+
+    except Exception as e:
+        logger.error(f"Failed to watch skill source: {e}", exc_info=True)
+        print(f"❌ Failed to watch skill source: {e}")
+        return 1
+
+
+def _describe_signature(signature: dict) -> str:
+    """One line for a sync result's signature check."""
+    if signature["verified"]:
+        return f"✅ signed by {signature['signer']}"
+    return f"⚠️  {signature['error']}"
+
+
+def handle_sign_skill_source(args) -> int:
+    """Write and sign the manifest of a skill collection.
+
+    Args:
+        args: Parsed arguments with path, minisign_key, sigstore
+
+    Returns:
+        Exit code
+    """
+    root = Path(args.path).expanduser().resolve()
+    if not root.is_dir():
+        print(f"❌ Not a directory: {root}")
+        return 1
+
+    key = Path(args.minisign_key).expanduser() if args.minisign_key else None
+    try:
+        signature = sign_collection(root, minisign_key=key, sigstore=args.sigstore)
+    except SignatureError as e:
+        print(f"❌ Signing failed: {e}")
+        return 1
+
+    print(f"✅ Signed {root}")
+    print(f"   Manifest: {root / MANIFEST_FILE}")
+    print(f"   Signature: {signature}")
+    print()
+    print("💡 Commit both files; sources that trust the key verify them on sync")
+    return 0
+
+
+def handle_pack_skill_source(args) -> int:
+    """Pack a skill collection into a static HTTP registry.
+
+    Args:
+        args: Parsed arguments with path, output
+
+    Returns:
+        Exit code
+    """
+    root = Path(args.path).expanduser().resolve()
+    if not root.is_dir():
+        print(f"❌ Not a directory: {root}")
+        return 1
+
+    output = Path(args.output).expanduser().resolve()
+    try:
+        index = build_registry(root, output)
+    except RegistryError as e:
+        print(f"❌ Packing failed: {e}")
+        return 1
+
+    print(f"✅ Packed {len(index.skills)} skills from {root}")
+    print(f"   Index: {output / INDEX_FILE}")
+    if index.files:
+        print(f"   Other files: {len(index.files)}")
+    print()
+    print("💡 Upload the directory to your web or artifact server, then run")
+    print(f"   claude-mpm skill-source add https://<server>/<path>/{INDEX_FILE}")
+    return 0
+
+
+def handle_verify_skill_sources(args) -> int:
+    """Check synced skill sources against their trusted keys.
+
+    Args:
+        args: Parsed arguments with source_id (optional)
+
+    Returns:
+        Exit code (1 if any checked source fails)
+    """
+    try:
+        config = SkillSourceConfiguration()
+        manager = GitSkillSourceManager(config)
+
+        if args.source_id:
+            source = config.get_source(args.source_id)
+            if not source:
+                print(f"❌ Source not found: {args.source_id}")
+                print()
+                print("💡 List sources: claude-mpm skill-source list")
+                return 1
+            sources = [source]
+        else:
+            sources = config.get_enabled_sources()
+
+        failed = 0
+        for source in sources:
+            try:
+                verification = manager.verify_source(source)
+            except SignatureError as e:
+                print(f"❌ {e}")
+                print("   Its cache was cleared; it will not deploy until it verifies")
+                failed += 1
+                continue
+            if verification is None:
+                reason = "local directory" if source.is_local else "no trusted keys"
+                print(f"➖ {source.id}: not checked ({reason})")
+            elif verification.verified:
+                print(f"✅ {source.id}: signed by {verification.signer}")
+                if verification.removed:
+                    print(f"   Removed {len(verification.removed)} unsigned files")
+            else:
+                print(f"⚠️  {source.id}: {verification.error}")
+                failed += 1
+
+        return 1 if failed else 0
+
+    except Exception as e:
+        logger.error(f"Failed to verify skill sources: {e}", exc_info=True)
+        print(f"❌ Failed to verify skill sources: {e}")
+        return 1
@@ -28,9 +28,13 @@ from rich.table import Table
 
 from ...constants import SkillsCommands
 from ...core.deployment_context import DeploymentContext
+from ...services.deployment_delta import DeploymentDelta
 from ...services.skills_deployer import SkillsDeployerService
 from ...skills.skills_service import SkillsService
-from ..shared import BaseCommand, CommandResult
+from ...utils.bulk_operations import DEFAULT_JOBS
+from ...utils.progress import ProgressBar
+from ..shared import BaseCommand, CommandResult, ListOptions
+from ..verbosity import emit_quiet_result
 
 console = Console()
 
@@ -67,6 +71,12 @@ class SkillsManagementCommand(BaseCommand):
             elif args.skills_command == SkillsCommands.INFO.value:
                 if not hasattr(args, "skill_name") or not args.skill_name:
                     return "Info command requires a skill name"
+            elif args.skills_command == SkillsCommands.DIFF.value:
+                if not hasattr(args, "skill_name") or not args.skill_name:
+                    return "Diff command requires a skill name"
+            elif args.skills_command == SkillsCommands.ROLLBACK.value:
+                if not hasattr(args, "skill_name") or not args.skill_name:
+                    return "Rollback command requires a skill name"
         return None
 
     def run(self, args) -> CommandResult:
@@ -89,10 +99,17 @@ class SkillsManagementCommand(BaseCommand):
             # Route to appropriate subcommand
             command_map = {
                 SkillsCommands.LIST.value: self._list_skills,
+                SkillsCommands.SEARCH.value: self._search_skills,
                 SkillsCommands.DEPLOY.value: self._deploy_skills,
                 SkillsCommands.VALIDATE.value: self._validate_skill,
+                SkillsCommands.LINT.value: self._lint_skills,
                 SkillsCommands.UPDATE.value: self._update_skills,
                 SkillsCommands.INFO.value: self._show_skill_info,
+                SkillsCommands.DIFF.value: self._diff_skill,
+                SkillsCommands.OUTDATED.value: self._outdated_skills,
+                SkillsCommands.ROLLBACK.value: self._rollback_skill,
+                SkillsCommands.TEST.value: self._test_skills,
+                SkillsCommands.NEW.value: self._new_skill,
                 SkillsCommands.CONFIG.value: self._manage_config,
                 SkillsCommands.CONFIGURE.value: self._configure_skills,
                 SkillsCommands.SELECT.value: self._select_skills_interactive,
@@ -111,6 +128,8 @@ class SkillsManagementCommand(BaseCommand):
                 SkillsCommands.COLLECTION_SET_DEFAULT.value: self._collection_set_default,
                 # Dedup sweep
                 SkillsCommands.DEDUP.value: self._dedup_skills,
+                # Usage analytics
+                SkillsCommands.STATS.value: self._skill_stats,
             }
 
             handler = command_map.get(args.skills_command)
@@ -135,6 +154,8 @@ class SkillsManagementCommand(BaseCommand):
     def _list_skills(self, args) -> CommandResult:
         """List available skills."""
         try:
+            options = ListOptions.from_args(args)
+
             # Get skills based on filter
             if hasattr(args, "agent") and args.agent:
                 skills = self.skills_service.get_skills_for_agent(args.agent)
@@ -142,13 +163,14 @@ class SkillsManagementCommand(BaseCommand):
                     f"\n[bold cyan]Skills for agent '{args.agent}':[/bold cyan]\n"
                 )
 
-                if not skills:
+                page = options.apply({"name": name} for name in skills)
+                if not page.items:
                     console.print(
                         f"[yellow]No skills found for agent '{args.agent}'[/yellow]"
                     )
                     return CommandResult(success=True, exit_code=0)
 
-                for skill_name in skills:
+                for skill_name in (row["name"] for row in page.items):
                     # Get skill metadata
                     skill_info = self._get_skill_metadata(skill_name)
                     if skill_info:
@@ -175,13 +197,18 @@ class SkillsManagementCommand(BaseCommand):
                 else:
                     console.print("\n[bold cyan]Available Skills:[/bold cyan]\n")
 
-                if not skills:
+                # Page in display order so --offset lines up with what is shown
+                skills = sorted(
+                    skills, key=lambda s: (s.get("category", ""), s.get("name", ""))
+                )
+                page = options.apply(skills)
+                if not page.items:
                     console.print("[yellow]No skills found[/yellow]")
                     return CommandResult(success=True, exit_code=0)
 
                 # Group by category
                 by_category = {}
-                for skill in skills:
+                for skill in page.items:
                     category = skill.get("category", "uncategorized")
                     if category not in by_category:
                         by_category[category] = []
@@ -204,12 +231,67 @@ class SkillsManagementCommand(BaseCommand):
                                 console.print(f"    [dim]Version: {version}[/dim]")
                     console.print()
 
+            if page.has_more or options.active:
+                console.print(f"[dim]{page.summary('skills')}[/dim]")
+            if hint := page.next_hint("claude-mpm skills list"):
+                console.print(f"[dim]{hint}[/dim]")
+
+            self._print_conflicts(self._source_conflicts())
+
             return CommandResult(success=True, exit_code=0)
 
         except Exception as e:
             console.print(f"[red]Error listing skills: {e}[/red]")
             return CommandResult(success=False, message=str(e), exit_code=1)
 
+    def _search_skills(self, args) -> CommandResult:
+        """Search skill names, descriptions and tags across skill sources."""
+        import json
+
+        from rich.markup import escape
+
+        from ...services.skills.skill_search import search_skills
+
+        query = " ".join(args.query)
+        source_id = getattr(args, "source", None)
+        result = search_skills(query, source_id=source_id)
+
+        if getattr(args, "json", False):
+            print(json.dumps(result.to_dict(), indent=2))
+            return CommandResult(success=True, exit_code=0)
+
+        for source, reason in result.skipped.items():
+            console.print(
+                f"[yellow]Skipped source '{source}': {escape(reason)}[/yellow]"
+            )
+        if not result.matches:
+            console.print(f"[yellow]No skills match '{escape(query)}'[/yellow]")
+            return CommandResult(success=True, exit_code=0)
+
+        table = Table(show_header=True, header_style="bold cyan")
+        table.add_column("Skill", style="green")
+        table.add_column("Version")
+        table.add_column("Source")
+        table.add_column("Description", overflow="fold")
+        for match in result.matches:
+            source = match.source_id
+            if match.origin == "index":
+                source += " [dim](index)[/dim]"
+            if match.shadowed_by:
+                source += f" [dim](overridden by {match.shadowed_by})[/dim]"
+            description = match.description
+            if len(description) > 80:
+                description = description[:77] + "..."
+            table.add_row(
+                escape(match.name),
+                match.version or "-",
+                source,
+                escape(description),
+            )
+        console.print(table)
+        console.print(f"[dim]{len(result.matches)} match(es)[/dim]")
+        return CommandResult(success=True, exit_code=0)
+
     @staticmethod
     def _normalize_deploy_result(result: dict) -> dict:
         """Normalize ``deploy_skills`` flat output to the project-deploy shape.
@@ -227,6 +309,8 @@ class SkillsManagementCommand(BaseCommand):
             "updated": [],
             "skipped": result.get("skipped_skills", []),
             "failed": result.get("errors", []),
+            "changes": result.get("changes", {}),
+            "dependencies": result.get("dependencies", []),
             "deployment_dir": result.get(
                 "deployment_dir", str(Path.home() / ".claude" / "skills")
             ),
@@ -246,34 +330,112 @@ class SkillsManagementCommand(BaseCommand):
         'project' deploys to the project-local skills directory (issue #806).
         WHY: The handler previously ignored args.scope and always deployed
         project-local, making --scope user a silent no-op.
+
+        Project deploys without --skill honor the skill profile the project
+        selects in .claude-mpm/configuration.yaml (unless --no-profile), so
+        each repository gets only its own skill set.
+
+        --tag selects the skills carrying any of the given tags (together
+        with any --skill names) instead of the profile.
         """
         try:
             from ...config.skill_sources import SkillSourceConfiguration
+            from ...services.profile_manager import ProfileManager
             from ...services.skills.git_skill_source_manager import (
                 GitSkillSourceManager,
             )
+            from ...services.skills.selective_skill_deployer import (
+                select_skills_by_tag,
+            )
+            from ...services.skills.skills_lock import SkillsLock
 
             force = getattr(args, "force", False)
             specific_skills = getattr(args, "skills", None)
+            tags = getattr(args, "tags", None)
             scope = getattr(args, "scope", "project")
+            jobs = getattr(args, "jobs", DEFAULT_JOBS)
 
             console.print("\n[bold cyan]Deploying skills...[/bold cyan]\n")
 
-            # Initialize git skill source manager
+            # Initialize git skill source manager; sources sync at the
+            # commits pinned in the project's skills.lock
             config = SkillSourceConfiguration()
-            git_skill_manager = GitSkillSourceManager(config)
             project_dir = Path.cwd()
+            git_skill_manager = GitSkillSourceManager(
+                config, lock=SkillsLock(project_dir)
+            )
 
             # Phase 1: Sync skills to cache
             console.print("[dim]Phase 1: Syncing skills to cache...[/dim]")
-            sync_results = git_skill_manager.sync_all_sources(force=force)
-
-            synced_count = sum(
-                1
-                for result in sync_results.get("sources", {}).values()
-                if result.get("synced")
+            sync_results = git_skill_manager.sync_all_sources(
+                force=force, max_workers=jobs
             )
-            console.print(f"[dim]Synced {synced_count} skill source(s)[/dim]\n")
+
+            sync_failures = {
+                source_id: result.get("error", "Unknown error")
+                for source_id, result in sync_results.get("sources", {}).items()
+                if not result.get("synced")
+            }
+            synced_count = len(sync_results.get("sources", {})) - len(sync_failures)
+            console.print(f"[dim]Synced {synced_count} skill source(s)[/dim]")
+            for source_id, error in sync_failures.items():
+                console.print(f"[red]  ✗ {source_id}: {error}[/red]")
+            console.print()
+
+            # Tagged skills join any named ones; the user scope matches
+            # deployment names
+            if tags:
+                available = git_skill_manager.get_all_skills()
+                tagged = select_skills_by_tag(available, tags)
+                console.print(
+                    f"[cyan]{len(tagged)} of {len(available)} skill(s) tagged "
+                    f"{', '.join(tags)}[/cyan]\n"
+                )
+                if not tagged:
+                    console.print(
+                        "[yellow]No skill carries these tags; nothing deployed"
+                        "[/yellow]"
+                    )
+                    return CommandResult(
+                        success=False,
+                        message=f"No skills tagged {', '.join(tags)}",
+                        exit_code=1,
+                    )
+                key = "deployment_name" if scope == "user" else "name"
+                specific_skills = list(specific_skills or []) + [
+                    skill[key] for skill in tagged if skill.get(key)
+                ]
+
+            # Project deploys follow the project's skill profile
+            profile = None
+            if scope == "project" and not specific_skills:
+                if not getattr(args, "no_profile", False):
+                    profile = ProfileManager.for_project(project_dir)
+            if profile:
+                available = git_skill_manager.get_all_skills()
+                specific_skills = [
+                    skill["name"] for skill in profile.filter_skills(available)
+                ]
+                console.print(
+                    f"[cyan]Using skill profile '{profile.active_profile}': "
+                    f"{len(specific_skills)} of {len(available)} skill(s)[/cyan]"
+                )
+                if not specific_skills:
+                    console.print(
+                        "[yellow]The profile matches no available skills; "
+                        "check its skills and sources lists[/yellow]"
+                    )
+                console.print()
+
+            # Phase 2 progress: one tick per skill
+            if specific_skills is not None:
+                total = len(specific_skills)
+            else:
+                total = sum(
+                    result.get("skills_discovered", 0)
+                    for result in sync_results.get("sources", {}).values()
+                )
+            progress = ProgressBar(total, prefix="Deploying skills")
 
             # Phase 2: Deploy from cache to the scope-selected destination
             if scope == "user":
@@ -284,6 +446,8 @@ class SkillsManagementCommand(BaseCommand):
                     target_dir=Path.home() / ".claude" / "skills",
                     force=force,
                     skill_filter=set(specific_skills) if specific_skills else None,
+                    max_workers=jobs,
+                    progress_callback=progress.update,
                 )
                 deploy_result = self._normalize_deploy_result(deploy_result)
             else:
@@ -294,7 +458,17 @@ class SkillsManagementCommand(BaseCommand):
                     project_dir=project_dir,
                     skill_list=specific_skills,
                     force=force,
+                    max_workers=jobs,
+                    progress_callback=progress.update,
+                )
+            progress.finish(f"{len(deploy_result['failed'])} failed")
+
+            if dependencies := deploy_result.get("dependencies"):
+                console.print(
+                    f"[dim]Including {len(dependencies)} required skill(s): "
+                    f"{', '.join(dependencies)}[/dim]\n"
                 )
+            self._print_conflicts(git_skill_manager.conflicts)
 
             # Display results
             if deploy_result["deployed"]:
@@ -309,8 +483,14 @@ class SkillsManagementCommand(BaseCommand):
                 console.print(
                     f"[green]⟳ Updated {len(deploy_result['updated'])} skill(s):[/green]"
                 )
+                changes = deploy_result.get("changes", {})
                 for skill in deploy_result["updated"]:
-                    console.print(f"  • {skill}")
+                    summary = (
+                        DeploymentDelta(**changes[skill]).summary()
+                        if skill in changes
+                        else ""
+                    )
+                    console.print(f"  • {skill}" + (f" ({summary})" if summary else ""))
                 console.print()
 
             if deploy_result["skipped"]:
@@ -325,8 +505,10 @@ class SkillsManagementCommand(BaseCommand):
                 console.print(
                     f"[red]✗ Failed to deploy {len(deploy_result['failed'])} skill(s):[/red]"
                 )
+                errors = deploy_result.get("errors", {})
                 for skill in deploy_result["failed"]:
-                    console.print(f"  • {skill}")
+                    error = errors.get(skill)
+                    console.print(f"  • {skill}" + (f": {error}" if error else ""))
                 console.print()
 
             # Summary
@@ -347,11 +529,13 @@ class SkillsManagementCommand(BaseCommand):
             console.print(
                 f"[dim]Deployment directory: {deploy_result['deployment_dir']}[/dim]\n"
             )
+            emit_quiet_result(deploy_result["deployment_dir"])
 
-            # Exit with error if any deployments failed
-            exit_code = 1 if deploy_result["failed"] else 0
+            # Exit with error if any deployment or source sync failed
+            failed = bool(deploy_result["failed"] or sync_failures)
+            exit_code = 1 if failed else 0
             return CommandResult(
-                success=not deploy_result["failed"],
+                success=not failed,
                 message=f"Deployed {success_count} skills from cache",
                 exit_code=exit_code,
             )
@@ -360,6 +544,36 @@ class SkillsManagementCommand(BaseCommand):
             console.print(f"[red]Error deploying skills: {e}[/red]")
             return CommandResult(success=False, message=str(e), exit_code=1)
 
+    def _source_conflicts(self) -> list:
+        """Skills the configured sources provide under the same name."""
+        try:
+            from ...config.skill_sources import SkillSourceConfiguration
+            from ...services.skills.git_skill_source_manager import (
+                GitSkillSourceManager,
+            )
+
+            manager = GitSkillSourceManager(SkillSourceConfiguration())
+            manager.get_all_skills()
+            return manager.conflicts
+        except Exception as e:
+            console.print(f"[dim]Could not check skill sources: {e}[/dim]")
+            return []
+
+    def _print_conflicts(self, conflicts: list) -> None:
+        """Show which source's copy wins each conflicting name."""
+        if not conflicts:
+            return
+        console.print(
+            f"[yellow]⚠ {len(conflicts)} skill name conflict(s) between "
+            "sources:[/yellow]"
+        )
+        for conflict in conflicts:
+            console.print(f"  • {conflict.describe()}")
+        console.print(
+            "[dim]Deploy a specific copy with <source>:<skill>, or set "
+            "skills.conflicts precedence/aliases in configuration.yaml[/dim]\n"
+        )
+
     def _validate_skill(self, args) -> CommandResult:
         """Validate skill structure and metadata."""
         try:
@@ -408,6 +622,72 @@ class SkillsManagementCommand(BaseCommand):
             console.print(f"[red]Error validating skill: {e}[/red]")
             return CommandResult(success=False, message=str(e), exit_code=1)
 
+    def _lint_skills(self, args) -> CommandResult:
+        """Lint a skill, a directory of skills, or a skill found by name."""
+        import json
+
+        from rich.markup import escape
+
+        from ...services.skills import skill_linter
+
+        target = getattr(args, "target", ".")
+        path = Path(target).expanduser()
+        if not path.exists():
+            path = skill_linter.locate_skill(target)
+            if path is None:
+                console.print(f"[red]No skill directory or skill named {target}[/red]")
+                return CommandResult(success=False, exit_code=1)
+        skill_dirs = skill_linter.find_skill_dirs(path)
+        if not skill_dirs:
+            console.print(f"[yellow]No SKILL.md found under {path}[/yellow]")
+            return CommandResult(success=False, exit_code=1)
+
+        strict = getattr(args, "strict", False)
+        reports = [
+            skill_linter.lint_skill(
+                skill_dir,
+                fix=getattr(args, "fix", False),
+                max_lines=getattr(args, "max_lines", None) or skill_linter.MAX_LINES,
+                max_tokens=getattr(args, "max_tokens", None)
+                or skill_linter.MAX_TOKENS,
+                required_sections=tuple(
+                    getattr(args, "require_sections", None)
+                    or skill_linter.REQUIRED_SECTIONS
+                ),
+            )
+            for skill_dir in skill_dirs
+        ]
+        failed = [report for report in reports if not report.passed(strict)]
+
+        if getattr(args, "json", False):
+            print(json.dumps([report.to_dict() for report in reports], indent=2))
+            return CommandResult(success=not failed, exit_code=1 if failed else 0)
+
+        for report in reports:
+            mark = "[red]✗[/red]" if report in failed else "[green]✓[/green]"
+            console.print(f"{mark} {escape(report.name)} [dim]{report.skill_dir}[/dim]")
+            for issue in report.fixed:
+                console.print(
+                    f"    [cyan]fixed[/cyan] {issue.location()}: "
+                    f"{escape(issue.message)}"
+                )
+            for issue in report.issues:
+                color = "red" if issue.severity == skill_linter.ERROR else "yellow"
+                hint = " (fixable with --fix)" if issue.fixable else ""
+                console.print(
+                    f"    [{color}]{issue.severity}[/{color}] {issue.location()}: "
+                    f"{escape(issue.message)}[dim]{hint}[/dim]"
+                )
+
+        errors = sum(len(report.errors) for report in reports)
+        warnings = sum(len(report.warnings) for report in reports)
+        fixed = sum(len(report.fixed) for report in reports)
+        console.print(
+            f"\n[bold]Summary:[/bold] {len(reports)} skill(s), {errors} error(s), "
+            f"{warnings} warning(s)" + (f", {fixed} fixed" if fixed else "") + "\n"
+        )
+        return CommandResult(success=not failed, exit_code=1 if failed else 0)
+
     def _update_skills(self, args) -> CommandResult:
         """Check for and install skill updates."""
         try:
@@ -418,11 +698,15 @@ class SkillsManagementCommand(BaseCommand):
             action = "Checking" if check_only else "Updating"
             console.print(f"\n[bold cyan]{action} skills...[/bold cyan]\n")
 
+            pins_ok = self._update_source_pins(
+                check_only, getattr(args, "sources", None)
+            )
+
             result = self.skills_service.check_for_updates(skill_names)
 
             if not result.get("updates_available"):
                 console.print("[green]All skills are up to date[/green]\n")
-                return CommandResult(success=True, exit_code=0)
+                return CommandResult(success=pins_ok, exit_code=0 if pins_ok else 1)
 
             # Display available updates
             console.print(
@@ -439,7 +723,7 @@ class SkillsManagementCommand(BaseCommand):
                 console.print(
                     "[dim]Run without --check-only to install updates[/dim]\n"
                 )
-                return CommandResult(success=True, exit_code=0)
+                return CommandResult(success=pins_ok, exit_code=0 if pins_ok else 1)
 
             # Install updates
             console.print("[bold cyan]Installing updates...[/bold cyan]\n")
@@ -460,15 +744,378 @@ class SkillsManagementCommand(BaseCommand):
                     console.print(f"  • {skill}: {error}")
                 console.print()
 
-            exit_code = 1 if install_result.get("errors") else 0
-            return CommandResult(
-                success=not install_result.get("errors"), exit_code=exit_code
-            )
+            failed = bool(install_result.get("errors")) or not pins_ok
+            return CommandResult(success=not failed, exit_code=1 if failed else 0)
 
         except Exception as e:
             console.print(f"[red]Error updating skills: {e}[/red]")
             return CommandResult(success=False, message=str(e), exit_code=1)
 
+    def _update_source_pins(
+        self, check_only: bool, source_ids: list[str] | None = None
+    ) -> bool:
+        """Move the project's skills.lock pins to each source's branch head.
+
+        Does nothing for projects without a skills.lock. Sources whose pin
+        moves are synced to the new commit; 'skills deploy' then deploys it.
+
+        Returns:
+            False if any source could not be resolved or synced
+        """
+        from ...config.skill_sources import SkillSourceConfiguration
+        from ...services.skills.git_skill_source_manager import (
+            GitSkillSourceManager,
+            resolve_source_commit,
+        )
+        from ...services.skills.skills_lock import SkillsLock
+
+        lock = SkillsLock(Path.cwd())
+        if not lock.exists():
+            return True
+        config = SkillSourceConfiguration()
+        sources = [
+            source
+            for source in config.get_enabled_sources()
+            if (not source_ids or source.id in source_ids) and source.is_git
+        ]
+        if not sources:
+            return True
+
+        console.print(f"[bold]Skill sources ({lock.path}):[/bold]")
+        manager = GitSkillSourceManager(config, lock=lock)
+        ok = True
+        moved = 0
+        for source in sources:
+            pinned = lock.get(source)
+            previous = pinned.commit[:12] if pinned else "unpinned"
+            try:
+                head = resolve_source_commit(source)
+            except (RuntimeError, ValueError) as e:
+                console.print(f"[red]  ✗ {source.id}: {e}[/red]")
+                ok = False
+                continue
+            if pinned and pinned.commit == head:
+                console.print(f"  • {source.id}: {previous} (up to date)")
+                continue
+            console.print(f"  • {source.id}: {previous} → {head[:12]}")
+            if check_only:
+                continue
+            lock.pin(source, head)
+            moved += 1
+            result = manager.sync_source(source.id)
+            if not result.get("synced"):
+                console.print(f"[red]  ✗ {source.id}: {result.get('error')}[/red]")
+                ok = False
+        if moved:
+            console.print(
+                "[dim]Run 'claude-mpm skills deploy' to deploy the new "
+                "commits[/dim]"
+            )
+        console.print()
+        return ok
+
+    def _diff_skill(self, args) -> CommandResult:
+        """Show how a deployed skill differs from its source."""
+        import json
+
+        from ...services.deployment_diff import skill_diff
+        from ...utils.theme import get_theme
+
+        try:
+            diff = skill_diff(args.skill_name)
+        except FileNotFoundError as e:
+            console.print(f"[red]{e}[/red]")
+            return CommandResult(success=False, exit_code=1)
+
+        if getattr(args, "json", False):
+            print(json.dumps(diff.to_dict(), indent=2))
+            return CommandResult(success=True, exit_code=0)
+
+        print(f"Deployed: {diff.deployed}")
+        print(f"Source:   {diff.source}")
+        if diff.modified:
+            print()
+            print(get_theme().diff(diff.render()), end="")
+        else:
+            print("No local modifications")
+        return CommandResult(success=True, exit_code=0)
+
+    def _outdated_skills(self, args) -> CommandResult:
+        """List deployed skills whose upstream source has changed them."""
+        import json
+
+        from rich.markup import escape
+
+        from ...services.skills.skill_updates import check_skill_updates, save_report
+        from ...services.skills.skills_lock import SkillsLock
+
+        report = check_skill_updates(getattr(args, "sources", None))
+        save_report(report)
+
+        if getattr(args, "json", False):
+            print(json.dumps(report.to_dict(), indent=2))
+            return CommandResult(success=True, exit_code=0)
+
+        if not report.sources:
+            console.print("[yellow]No git skill sources to check[/yellow]")
+            return CommandResult(success=True, exit_code=0)
+
+        for status in report.sources:
+            if status.error:
+                console.print(
+                    f"[yellow]{status.source_id}: {escape(status.error)}[/yellow]"
+                )
+                continue
+            if not status.moved:
+                console.print(f"[green]{status.source_id}: up to date[/green]")
+                continue
+            console.print(
+                f"[bold]{status.source_id}[/bold]: {status.current[:12]} → "
+                f"{status.latest[:12]}"
+                + ("" if status.updates else " (no deployed skills changed)")
+            )
+            for update in status.updates:
+                versions = ""
+                if update.deployed_version or update.latest_version:
+                    versions = (
+                        f" {update.deployed_version or '?'} → "
+                        f"{update.latest_version or '?'}"
+                    )
+                console.print(f"  • [green]{escape(update.name)}[/green]{versions}")
+                changed = escape(", ".join(update.files))
+                console.print(f"    [dim]Changed: {changed}[/dim]")
+                for commit in update.commits:
+                    console.print(f"    {escape(commit)}")
+
+        count = len(report.updates)
+        if not count:
+            if not any(status.error for status in report.sources):
+                console.print("\nAll deployed skills are up to date")
+            return CommandResult(success=True, exit_code=0)
+        if SkillsLock(Path.cwd()).exists():
+            hint = "'claude-mpm skills update' to move the skills.lock pins"
+        else:
+            hint = "'claude-mpm skill-source update' to sync the sources"
+        console.print(f"\n{count} skill(s) have updates. Run {hint}.")
+        return CommandResult(success=True, exit_code=0)
+
+    def _rollback_skill(self, args) -> CommandResult:
+        """Restore a deployed skill's previous version, or list its versions."""
+        from rich.markup import escape
+
+        from ...services.skills.skill_history import (
+            SkillHistory,
+            SkillHistoryError,
+            content_id,
+            find_deployed_skill,
+            release_skill,
+            rollback_skill,
+        )
+
+        name = args.skill_name
+        history = SkillHistory()
+        try:
+            if getattr(args, "list", False):
+                versions = history.versions(name)
+                if not versions:
+                    console.print(
+                        f"[yellow]No versions of {escape(name)} recorded[/yellow]"
+                    )
+                    return CommandResult(success=True, exit_code=0)
+                deployed = find_deployed_skill(name)
+                current = content_id(deployed) if deployed else None
+                held = history.held(name, deployed) if deployed else None
+                for version in reversed(versions):
+                    marks = []
+                    if version.id == current:
+                        marks.append("deployed")
+                    if version.id == held:
+                        marks.append("held")
+                    suffix = f"  [green]({', '.join(marks)})[/green]" if marks else ""
+                    console.print(f"  {escape(version.describe())}{suffix}")
+                return CommandResult(success=True, exit_code=0)
+
+            if getattr(args, "release", False):
+                deployed = release_skill(name, history)
+                console.print(
+                    f"[green]✓ {escape(name)} will update again[/green] "
+                    f"[dim]({deployed})[/dim]"
+                )
+                return CommandResult(success=True, exit_code=0)
+
+            rollback = rollback_skill(name, getattr(args, "to", None), history)
+        except SkillHistoryError as e:
+            console.print(f"[red]{escape(str(e))}[/red]")
+            return CommandResult(success=False, exit_code=1)
+
+        console.print(
+            f"[green]✓ Rolled back {escape(name)} to "
+            f"{escape(rollback.version.describe())}[/green]"
+        )
+        console.print(f"[dim]{rollback.deployed}[/dim]")
+        console.print(
+            f"Deployments now skip {escape(name)}; run 'claude-mpm skills "
+            f"rollback {escape(name)} --release' to take updates again."
+        )
+        return CommandResult(success=True, exit_code=0)
+
+    def _test_skills(self, args) -> CommandResult:
+        """Run skills' example prompts against a headless agent."""
+        import json
+
+        from rich.markup import escape
+
+        from ...services.skills import skill_testing
+        from ...services.skills.skill_linter import locate_skill
+
+        target = getattr(args, "target", ".")
+        path = Path(target).expanduser()
+        if not path.exists():
+            path = locate_skill(target)
+            if path is None:
+                console.print(f"[red]No skill directory or skill named {target}[/red]")
+                return CommandResult(success=False, exit_code=1)
+        skill_dirs = skill_testing.find_tested_skills(path)
+        if not skill_dirs:
+            console.print(
+                f"[yellow]No skill with a {skill_testing.TESTS_FILE} under "
+                f"{path}[/yellow]"
+            )
+            return CommandResult(success=False, exit_code=1)
+
+        reports = []
+        for skill_dir in skill_dirs:
+            tester = skill_testing.SkillTester(
+                skill_dir,
+                model=getattr(args, "model", None),
+                jobs=getattr(args, "jobs", DEFAULT_JOBS),
+            )
+            try:
+                reports.append(tester.run(getattr(args, "cases", None)))
+            except skill_testing.SkillTestError as e:
+                report = skill_testing.SkillTestReport(tester.name, skill_dir)
+                report.results.append(
+                    skill_testing.CaseResult(skill_testing.TESTS_FILE, error=str(e))
+                )
+                reports.append(report)
+
+        if getattr(args, "junit", None):
+            Path(args.junit).write_text(
+                skill_testing.junit_xml(reports), encoding="utf-8"
+            )
+        failed = [report for report in reports if not report.passed]
+
+        if getattr(args, "json", False):
+            print(json.dumps([report.to_dict() for report in reports], indent=2))
+            return CommandResult(success=not failed, exit_code=1 if failed else 0)
+
+        for report in reports:
+            mark = "[red]✗[/red]" if report in failed else "[green]✓[/green]"
+            console.print(
+                f"{mark} {escape(report.skill)} [dim]{report.skill_dir}[/dim]"
+            )
+            for result in report.results:
+                if result.passed:
+                    tools = ", ".join(dict.fromkeys(result.tools_called)) or "none"
+                    console.print(
+                        f"    [green]pass[/green] {escape(result.name)} "
+                        f"[dim](tools: {escape(tools)})[/dim]"
+                    )
+                    continue
+                console.print(f"    [red]fail[/red] {escape(result.name)}")
+                for problem in [result.error] if result.error else result.failures:
+                    console.print(f"        {escape(problem)}")
+
+        cases = sum(len(report.results) for report in reports)
+        failures = sum(len(report.failed) for report in reports)
+        cost = sum(r.cost_usd or 0 for report in reports for r in report.results)
+        console.print(
+            f"\n[bold]Summary:[/bold] {len(reports)} skill(s), {cases} case(s), "
+            f"{failures} failed" + (f", ${cost:.2f}" if cost else "") + "\n"
+        )
+        return CommandResult(success=not failed, exit_code=1 if failed else 0)
+
+    def _new_skill(self, args) -> CommandResult:
+        """Scaffold a skill directory from flags, prompting for what is missing."""
+        import sys
+
+        from rich.markup import escape
+        from rich.prompt import Confirm, Prompt
+
+        from ...services.skills import skill_linter, skill_scaffold
+
+        interactive = not getattr(args, "no_input", False) and sys.stdin.isatty()
+
+        def ask(value, question, default=""):
+            if value is not None or not interactive:
+                return value or default
+            return Prompt.ask(question, default=default or None) or ""
+
+        def ask_list(values, question):
+            answer = ask(None if values is None else ", ".join(values), question)
+            return [item.strip() for item in answer.split(",") if item.strip()]
+
+        name = ask(getattr(args, "name", None), "Skill name (lowercase, hyphens)")
+        description = ask(getattr(args, "description", None), "Description")
+        if not name or not description:
+            console.print(
+                "[red]A skill name and --description are required[/red] "
+                "(or run 'claude-mpm skills new' on a terminal to be prompted)"
+            )
+            return CommandResult(success=False, exit_code=1)
+        spec = skill_scaffold.SkillSpec(
+            name=name,
+            description=description,
+            when_to_use=ask(
+                getattr(args, "when_to_use", None),
+                "When should the agent use it",
+                default=description,
+            ),
+            category=ask(
+                getattr(args, "category", None),
+                "Category",
+                default=skill_scaffold.DEFAULT_CATEGORY,
+            ),
+            tags=ask_list(getattr(args, "tags", None), "Tags (comma separated)"),
+            agent_types=ask_list(
+                getattr(args, "agent_types", None),
+                "Agents it is for (comma separated, empty for any)",
+            ),
+        )
+        with_tests = getattr(args, "with_tests", None)
+        if with_tests is None:
+            with_tests = interactive and Confirm.ask(
+                "Add a tests.yaml and fixture project?", default=False
+            )
+
+        try:
+            written = skill_scaffold.scaffold_skill(
+                spec,
+                Path(getattr(args, "dir", ".")).expanduser(),
+                with_tests=bool(with_tests),
+                force=getattr(args, "force", False),
+            )
+        except skill_scaffold.SkillScaffoldError as e:
+            console.print(f"[red]{escape(str(e))}[/red]")
+            return CommandResult(success=False, exit_code=1)
+
+        skill_dir = written[0].parent
+        console.print(f"[green]✓ Created skill {escape(spec.name)}[/green]")
+        for path in written:
+            console.print(f"    {path.relative_to(skill_dir.parent)}")
+        report = skill_linter.lint_skill(skill_dir)
+        for issue in report.issues:
+            console.print(
+                f"    [yellow]{issue.severity}[/yellow] {issue.location()}: "
+                f"{escape(issue.message)}"
+            )
+        console.print(
+            "\nFill in the TODOs, then check the skill with "
+            f"'claude-mpm skills lint {skill_dir}'"
+            + (f" and 'claude-mpm skills test {skill_dir}'" if with_tests else "")
+        )
+        return CommandResult(success=True, exit_code=0)
+
     def _show_skill_info(self, args) -> CommandResult:
         """Show detailed skill information."""
         try:
@@ -588,6 +1235,8 @@ class SkillsManagementCommand(BaseCommand):
 
     def _deploy_from_github(self, args) -> CommandResult:
         """Deploy skills from GitHub repository."""
+        from rich.markup import escape
+
         try:
             collection = getattr(args, "collection", None)
             toolchain = getattr(args, "toolchain", None)
@@ -595,6 +1244,12 @@ class SkillsManagementCommand(BaseCommand):
             force = getattr(args, "force", False)
             deploy_all = getattr(args, "all", False)
             scope = getattr(args, "scope", "user")
+            selectors = getattr(args, "skill_selectors", None) or []
+            if selectors and deploy_all:
+                console.print("[red]Name skills to deploy or use --all, not both[/red]")
+                return CommandResult(
+                    success=False, message="Skills named with --all", exit_code=1
+                )
 
             # Resolve skills_dir based on scope
             from pathlib import Path
@@ -615,21 +1270,30 @@ class SkillsManagementCommand(BaseCommand):
                     "\n[bold cyan]Deploying skills from default collection...[/bold cyan]\n"
                 )
 
-            # Use selective deployment unless --all flag is provided
+            # Use selective deployment unless --all flag or skills are given
             # Selective mode deploys only agent-referenced skills
             # --all mode deploys all available skills from the collection
+            # Named skills are deployed (and kept) whether referenced or not
             result = self.skills_deployer.deploy_skills(
                 collection=collection,
                 toolchain=toolchain,
                 categories=categories,
                 force=force,
-                selective=not deploy_all,
+                selective=not (deploy_all or selectors),
                 skills_dir=skills_dir,
+                skill_names=selectors or None,
+                mark_requested=bool(selectors),
             )
 
             # Display results
             # Show selective mode summary
-            if result.get("selective_mode"):
+            if selectors:
+                matched = result["deployed_count"] + result["skipped_count"]
+                console.print(
+                    f"[cyan]📌 {matched} skill(s) match "
+                    f"{escape(', '.join(selectors))}[/cyan]\n"
+                )
+            elif result.get("selective_mode"):
                 total_available = result.get("total_available", 0)
                 deployed_count = result["deployed_count"]
                 console.print(
@@ -1011,6 +1675,81 @@ class SkillsManagementCommand(BaseCommand):
 
         return CommandResult(success=True, exit_code=0)
 
+    def _skill_stats(self, args) -> CommandResult:
+        """Show per-skill effectiveness scores.
+
+        WHAT: Scans Claude Code session transcripts for Skill invocations,
+              correlates each with tool errors, retries and cost in its turn,
+              and prints a score plus a keep/rewrite/retire recommendation.
+              Deployed skills that were never invoked are listed as unused.
+        WHY: Tells maintainers which skills earn their context budget and
+             which should be rewritten or retired.
+        """
+        import json
+
+        from ...services.skills.skill_effectiveness import SkillEffectivenessAnalyzer
+
+        analyzer = SkillEffectivenessAnalyzer(
+            all_projects=getattr(args, "all_projects", False),
+            days=getattr(args, "days", None),
+        )
+        stats = analyzer.stats()
+
+        sort = getattr(args, "sort", "score")
+        sort_keys = {
+            "score": lambda s: (s.score is None, s.score or 0.0, s.name),
+            "invocations": lambda s: (-s.invocations, s.name),
+            "cost": lambda s: (-s.avg_cost_usd, s.name),
+            "name": lambda s: s.name,
+        }
+        stats.sort(key=sort_keys.get(sort, sort_keys["score"]))
+
+        if getattr(args, "json", False):
+            print(json.dumps([s.to_dict() for s in stats], indent=2))
+            return CommandResult(success=True, exit_code=0)
+
+        if not stats:
+            console.print(
+                "[yellow]No skill invocations found and no skills deployed.[/yellow]"
+            )
+            return CommandResult(success=True, exit_code=0)
+
+        scope = "all projects" if analyzer.all_projects else str(analyzer.project_dir)
+        if analyzer.days:
+            scope += f", last {analyzer.days} days"
+        console.print(
+            f"\n[bold cyan]Skill Effectiveness[/bold cyan] [dim]({scope})[/dim]\n"
+        )
+
+        styles = {"keep": "green", "rewrite": "yellow", "retire": "red"}
+        table = Table(show_header=True, header_style="bold cyan")
+        table.add_column("Skill", style="white")
+        table.add_column("Score", justify="right")
+        table.add_column("Uses", justify="right")
+        table.add_column("Success", justify="right")
+        table.add_column("Retries", justify="right")
+        table.add_column("Avg cost", justify="right")
+        table.add_column("Last used", style="dim")
+        table.add_column("Recommendation")
+        for s in stats:
+            style = styles.get(s.recommendation.split()[0], "dim")
+            table.add_row(
+                s.name,
+                "-" if s.score is None else f"{s.score:.0f}",
+                str(s.invocations),
+                f"{s.success_rate:.0%}" if s.invocations else "-",
+                f"{s.retry_rate:.0%}" if s.invocations else "-",
+                f"${s.avg_cost_usd:.3f}" if s.invocations else "-",
+                s.last_used.strftime("%Y-%m-%d") if s.last_used else "never",
+                f"[{style}]{s.recommendation}[/{style}]",
+            )
+        console.print(table)
+        console.print(
+            "[dim]Success: skill loaded, fewer than 3 tool errors in the turn and "
+            "no retry. Scores need at least 3 uses.[/dim]\n"
+        )
+        return CommandResult(success=True, exit_code=0)
+
     def _get_skill_metadata(self, skill_name: str) -> dict | None:
         """Get skill metadata from SKILL.md file."""
         try:
@@ -1028,11 +1767,21 @@ class SkillsManagementCommand(BaseCommand):
             return None
 
     # === Collection Management Commands ===
+    #
+    # Collections are skill sources; collection-list/add/remove/enable/disable
+    # are kept as aliases of the skill-source commands.
+
+    def _print_skill_source_hint(self, command: str) -> None:
+        console.print(
+            "[dim]Collections are skill sources: "
+            f"'claude-mpm skill-source {command}' does the same.[/dim]"
+        )
 
     def _collection_list(self, args) -> CommandResult:
         """List all configured skill collections."""
         try:
             result = self.skills_deployer.list_collections()
+            self._print_skill_source_hint("list")
 
             console.print("\n[bold cyan]Skill Collections:[/bold cyan]\n")
             console.print(
@@ -1045,7 +1794,7 @@ class SkillsManagementCommand(BaseCommand):
             if not result["collections"]:
                 console.print("[yellow]No collections configured.[/yellow]")
                 console.print(
-                    "[dim]Use 'claude-mpm skills collection-add' to add a collection.[/dim]\n"
+                    "[dim]Use 'claude-mpm skill-source add' to add a collection.[/dim]\n"
                 )
                 return CommandResult(success=True, exit_code=0)
 
@@ -1107,6 +1856,7 @@ class SkillsManagementCommand(BaseCommand):
             console.print(f"[green]✓ {result['message']}[/green]")
             console.print(f"  [dim]URL: {url}[/dim]")
             console.print(f"  [dim]Priority: {priority}[/dim]\n")
+            self._print_skill_source_hint(f"add {url} --id {name}")
 
             return CommandResult(success=True, exit_code=0)
 
@@ -1136,13 +1886,11 @@ class SkillsManagementCommand(BaseCommand):
             result = self.skills_deployer.remove_collection(name)
 
             console.print(f"[green]✓ {result['message']}[/green]")
-            if result.get("directory_removed"):
-                console.print("  [dim]Collection directory removed[/dim]")
-            elif result.get("directory_error"):
-                console.print(
-                    f"  [yellow]Warning: {result['directory_error']}[/yellow]"
-                )
+            if result.get("skills_removed"):
+                removed = ", ".join(result["skills_removed"])
+                console.print(f"  [dim]Removed skills: {removed}[/dim]")
             console.print()
+            self._print_skill_source_hint(f"remove {name}")
 
             return CommandResult(success=True, exit_code=0)
 
@@ -1168,6 +1916,7 @@ class SkillsManagementCommand(BaseCommand):
             result = self.skills_deployer.enable_collection(name)
 
             console.print(f"\n[green]✓ {result['message']}[/green]\n")
+            self._print_skill_source_hint(f"enable {name}")
 
             return CommandResult(success=True, exit_code=0)
 
@@ -1193,6 +1942,7 @@ class SkillsManagementCommand(BaseCommand):
             result = self.skills_deployer.disable_collection(name)
 
             console.print(f"\n[green]✓ {result['message']}[/green]\n")
+            self._print_skill_source_hint(f"disable {name}")
 
             return CommandResult(success=True, exit_code=0)
 
@@ -1808,9 +2558,12 @@ class SkillsManagementCommand(BaseCommand):
             from ...services.skills.git_skill_source_manager import (
                 GitSkillSourceManager,
             )
+            from ...services.skills.skills_lock import SkillsLock
 
             config = SkillSourceConfiguration()
-            git_skill_manager = GitSkillSourceManager(config)
+            git_skill_manager = GitSkillSourceManager(
+                config, lock=SkillsLock(Path.cwd())
+            )
 
             # Sync sources first
             console.print("[dim]Syncing skill sources...[/dim]")
@@ -1842,8 +2595,10 @@ class SkillsManagementCommand(BaseCommand):
                 console.print(
                     f"[red]✗ Failed to deploy {len(deploy_result['failed'])} skill(s):[/red]"
                 )
+                errors = deploy_result.get("errors", {})
                 for skill in deploy_result["failed"]:
-                    console.print(f"  • {skill}")
+                    error = errors.get(skill)
+                    console.print(f"  • {skill}" + (f": {error}" if error else ""))
                 console.print()
 
             # Summary
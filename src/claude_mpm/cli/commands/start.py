@@ -0,0 +1,103 @@
+"""
+Start command implementation for claude-mpm.
+
+WHY: ``claude-mpm start --template bug-triage --var issue=123`` launches a
+run from a conversation template (see services/conversation_templates.py):
+the rendered prompt becomes the PM's first message and the template's agents
+and policies become the run's options.
+
+DESIGN DECISIONS:
+- The template is applied to the parsed arguments and the session is then
+  launched by run_session, so start behaves exactly like run with the same
+  options
+- Command-line labels and --env values are added after the template's, so
+  they win; --model and --max-turns replace the template's
+"""
+
+from __future__ import annotations
+
+import sys
+from pathlib import Path
+
+from ...services.conversation_templates import (
+    ConversationTemplate,
+    agent_denials,
+    find_template,
+    parse_vars,
+)
+
+
+def apply_template(args, project_dir: Path) -> ConversationTemplate:
+    """Turn the template named by ``args.template`` into run options on *args*.
+
+    Raises:
+        ValueError: If the template cannot be found, rendered or combined
+            with the other options.
+    """
+    if getattr(args, "resume", None) or getattr(args, "mpm_resume", None):
+        raise ValueError(
+            "A template starts a new conversation; it cannot be resumed with "
+            "--resume or --mpm-resume"
+        )
+    template = find_template(args.template, project_dir)
+    prompt = template.render(parse_vars(getattr(args, "template_vars", None)))
+    policies = template.policies
+
+    args.session_labels = [*policies.labels, *(args.session_labels or [])]
+    args.session_env = [
+        *(f"{name}={value}" for name, value in policies.env.items()),
+        *(args.session_env or []),
+    ]
+    args.chaos = args.chaos or policies.chaos
+    if policies.skip_permissions is False:
+        args.no_dangerously_skip_permissions = True
+    if args.max_turns is None:
+        args.max_turns = policies.max_turns
+
+    claude_args = list(args.claude_args or [])
+    if policies.model and not args.model and "--model" not in claude_args:
+        claude_args += ["--model", policies.model]
+    denied = [
+        *policies.disallowed_tools,
+        *agent_denials(template.agents, project_dir),
+    ]
+    if args.disallowedTools:
+        denied.append(args.disallowedTools)
+        args.disallowedTools = None
+    if denied:
+        claude_args += ["--disallowedTools", ",".join(denied)]
+
+    if args.headless or args.non_interactive:
+        if args.input:
+            raise ValueError("--input cannot be combined with a template's prompt")
+        args.input = prompt
+    else:
+        # Claude Code starts an interactive session with this first message.
+        # It goes first: after a list option such as --disallowedTools it
+        # would be read as one more value.
+        claude_args.insert(0, prompt)
+    args.claude_args = claude_args
+    return template
+
+
+def start_session(args) -> int:
+    """Main entry point for the start command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    from .run import run_session
+
+    project_dir = Path(args.cwd).expanduser().resolve() if args.cwd else Path.cwd()
+    try:
+        template = apply_template(args, project_dir)
+    except ValueError as e:
+        print(f"❌ {e}", file=sys.stderr)
+        return 1
+    if not args.headless:
+        agents = ", ".join(template.agents) or "all deployed agents"
+        print(f"📋 Starting from template '{template.name}' ({agents})")
+    return run_session(args)
@@ -0,0 +1,89 @@
+"""
+Status command implementation for claude-mpm.
+
+WHY: Shows everything claude-mpm manages in the current repository in one
+place, and writes it to a file the team can commit.
+
+DESIGN DECISIONS:
+- Thin wrapper around collect_project_status
+- Without --deep only the counts are shown, so it stays a quick check
+- --write implies --deep and prints where the file was written, so it can
+  run from a pre-commit hook or CI job
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.project_status import collect_project_status, write_status_file
+from ..shared import BaseCommand, CommandResult
+
+
+class StatusCommand(BaseCommand):
+    """CLI command for the project status summary."""
+
+    def __init__(self, project_root: Path | None = None):
+        super().__init__("status")
+        self.project_root = project_root or Path.cwd()
+
+    def validate_args(self, args) -> str | None:
+        sessions = getattr(args, "sessions", None)
+        if isinstance(sessions, int) and sessions < 0:
+            return "--sessions must be 0 or more"
+        return None
+
+    def run(self, args) -> CommandResult:
+        try:
+            return self._status(args)
+        except Exception as e:
+            self.logger.error("Error executing status command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing status command: {e}")
+
+    def _status(self, args) -> CommandResult:
+        sessions = getattr(args, "sessions", None)
+        status = collect_project_status(
+            self.project_root,
+            **({"session_limit": sessions} if isinstance(sessions, int) else {}),
+        )
+        data = status.to_dict()
+        write = getattr(args, "write", None)
+        if isinstance(write, str):
+            path = write_status_file(status, Path(write) if write else None)
+            return CommandResult.success_result(
+                f"Wrote {path}", data={**data, "written": str(path)}
+            )
+        if getattr(args, "json", False) is True:
+            return CommandResult.success_result(json.dumps(data, indent=2))
+        if getattr(args, "deep", False) is True:
+            return CommandResult.success_result(status.render_markdown(), data=data)
+        return CommandResult.success_result(status.render_summary(), data=data)
+
+
+def manage_status(args) -> int:
+    """Main entry point for the status command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = StatusCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message.rstrip("\n"))
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,209 @@
+"""
+Storage command implementation for claude-mpm.
+
+WHY: Users had no way to see what was filling ~/.claude-mpm or to reclaim
+the space short of deleting directories by hand.
+
+DESIGN DECISIONS:
+- Thin wrapper around StorageManager
+- ``status`` also shows the total of ~/.claude-mpm, so space used by
+  unmanaged directories is visible rather than silently unaccounted for
+- ``encrypt``/``decrypt``/``cat`` wrap DataEncryption for converting and
+  reading files at rest; ``encrypt`` refuses while encryption is disabled so
+  new writes never silently go back to plaintext
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.data_encryption import (
+    DataEncryption,
+    DataEncryptionError,
+    scope_files,
+)
+from ...services.storage_retention import StorageManager, format_size, total_size
+from ..shared import BaseCommand, CommandResult
+
+
+class StorageCommand(BaseCommand):
+    """CLI command for disk usage and retention."""
+
+    VALID_COMMANDS = ("status", "prune", "encrypt", "decrypt", "cat")
+
+    def __init__(
+        self,
+        manager: StorageManager | None = None,
+        encryption: DataEncryption | None = None,
+    ):
+        super().__init__("storage")
+        self.manager = manager or StorageManager()
+        self._encryption = encryption
+
+    @property
+    def encryption(self) -> DataEncryption:
+        if self._encryption is None:
+            self._encryption = DataEncryption()
+        return self._encryption
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "storage_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm storage {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "status": self._status,
+            "prune": self._prune,
+            "encrypt": self._encrypt,
+            "decrypt": self._decrypt,
+            "cat": self._cat,
+        }
+        try:
+            return handlers[args.storage_command](args)
+        except (DataEncryptionError, FileNotFoundError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing storage command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing storage command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _status(self, args) -> CommandResult:
+        usages = self.manager.status()
+        data = {
+            "home_dir": str(self.manager.home_dir),
+            "home_total": total_size(self.manager.home_dir),
+            "categories": [u.to_dict() for u in usages],
+            "encryption": self._encryption_status(),
+        }
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        lines = [
+            f"{'CATEGORY':<12} {'FILES':>7} {'SIZE':>9} {'PRUNABLE':>9}  "
+            f"{'OLDEST':<10}  POLICY"
+        ]
+        for usage in usages:
+            lines.append(
+                f"{usage.name:<12} {usage.files:>7} {format_size(usage.size):>9} "
+                f"{format_size(usage.prunable_size):>9}  "
+                f"{(usage.oldest or '-')[:10]:<10}  "
+                f"{_describe_policy(usage.max_age_days, usage.max_size_mb)}"
+            )
+        lines.append("")
+        lines.append(
+            f"{self.manager.home_dir} uses {format_size(data['home_total'])} in total"
+        )
+        encryption = data["encryption"]
+        lines.append(
+            f"Encryption: {encryption['backend']} for "
+            f"{', '.join(encryption['scopes'])}"
+            if encryption["enabled"]
+            else "Encryption: off"
+        )
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _prune(self, args) -> CommandResult:
+        results = self.manager.prune(categories=args.category, dry_run=args.dry_run)
+        data = [r.to_dict() for r in results]
+        verb = "Would remove" if args.dry_run else "Removed"
+        lines = [
+            f"{verb} {r.files} file(s), {format_size(r.size)} from {r.name}"
+            + (f" ({len(r.sessions)} artifact session(s))" if r.sessions else "")
+            for r in results
+            if r.files or r.size or r.sessions
+        ]
+        if not lines:
+            return CommandResult.success_result("Nothing to prune", data=data)
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _encrypt(self, args) -> CommandResult:
+        config = self.encryption.config
+        if not config.enabled:
+            return CommandResult.error_result(
+                "Encryption is disabled; set 'encryption.enabled: true' in "
+                ".claude-mpm/configuration.yaml first"
+            )
+        scopes = args.scope or config.scopes
+        changed = [
+            str(path)
+            for scope in scopes
+            for path in scope_files(scope, self.manager.project_dir)
+            if self.encryption.encrypt_file(path)
+        ]
+        return CommandResult.success_result(
+            f"Encrypted {len(changed)} file(s) with {config.backend}", data=changed
+        )
+
+    def _decrypt(self, args) -> CommandResult:
+        scopes = args.scope or self.encryption.config.scopes
+        changed = [
+            str(path)
+            for scope in scopes
+            for path in scope_files(scope, self.manager.project_dir)
+            if self.encryption.decrypt_file(path)
+        ]
+        note = (
+            " (encryption is still enabled; new writes will be encrypted)"
+            if self.encryption.config.enabled and changed
+            else ""
+        )
+        return CommandResult.success_result(
+            f"Decrypted {len(changed)} file(s){note}", data=changed
+        )
+
+    def _cat(self, args) -> CommandResult:
+        text = self.encryption.read_text(Path(args.path).expanduser())
+        return CommandResult.success_result(text.rstrip("\n"), data=None)
+
+    def _encryption_status(self) -> dict:
+        try:
+            config = self.encryption.config
+        except DataEncryptionError as e:
+            return {"enabled": False, "error": str(e), "backend": None, "scopes": []}
+        return {
+            "enabled": config.enabled,
+            "backend": config.backend,
+            "scopes": list(config.scopes),
+        }
+
+
+def _describe_policy(max_age_days: int | None, max_size_mb: int | None) -> str:
+    parts = []
+    if max_age_days is not None:
+        parts.append(f"{max_age_days}d")
+    if max_size_mb is not None:
+        parts.append(format_size(max_size_mb * 1024 * 1024))
+    return ", ".join(parts) or "keep all"
+
+
+def manage_storage(args) -> int:
+    """Main entry point for the storage command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = StorageCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
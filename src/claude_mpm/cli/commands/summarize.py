@@ -11,6 +11,9 @@ Design Decision: Uses simple text processing techniques:
 - Executive: Opening + conclusion extraction
 
 Why: Lightweight, fast, no dependencies, works offline.
+
+A project can send the ``document`` use case to a model instead (see
+services/summarizers.py), or pick one per run with ``--backend``.
 """
 
 import json
@@ -28,6 +31,15 @@ class SummaryStyle(StrEnum):
     EXECUTIVE = "executive"
 
 
+# How each style is asked of a model backend
+STYLE_INSTRUCTIONS = {
+    SummaryStyle.BRIEF: "Write a single short paragraph.",
+    SummaryStyle.DETAILED: "Cover the introduction, key points and conclusion.",
+    SummaryStyle.BULLET_POINTS: "Write a markdown bullet list of the key points.",
+    SummaryStyle.EXECUTIVE: "Give the overview and the conclusion or decision.",
+}
+
+
 class OutputFormat(StrEnum):
     """Output format types."""
 
@@ -321,13 +333,25 @@ def summarize_command(args) -> int:
         # Read file content
         content = file_path.read_text(encoding="utf-8")
 
-        # Create summarizer
-        summarizer = DocumentSummarizer(max_words=args.max_words)
-
-        # Generate summary
-        summary = summarizer.summarize(
-            content, style=SummaryStyle(args.style), lines_limit=args.lines
-        )
+        from ...services.summarizers import ExtractiveSummarizer, summarizer_for
+
+        style = SummaryStyle(args.style)
+        configured = summarizer_for("document", backend=getattr(args, "backend", None))
+
+        if configured.backend.name == "extractive":
+            summarizer = DocumentSummarizer(max_words=args.max_words)
+            summary = summarizer.summarize(
+                content, style=style, lines_limit=args.lines
+            )
+        else:
+            if args.lines:
+                content = "\n".join(content.split("\n")[: args.lines])
+            # Falling back keeps the style that was asked for
+            if configured.fallback and configured.fallback.name == "extractive":
+                configured.fallback = ExtractiveSummarizer(style.value)
+            summary = configured.summarize(
+                content, args.max_words, STYLE_INSTRUCTIONS[style]
+            ).text
 
         # Format output
         output = format_output(summary, OutputFormat(args.output), file_path)
@@ -402,6 +426,17 @@ Examples:
         help="Output format (default: text)",
     )
 
+    parser.add_argument(
+        "--backend",
+        type=str,
+        choices=["extractive", "claude", "local"],
+        default=None,
+        help=(
+            "Summarizer backend (default: the 'document' use case in "
+            "summarization config, else extractive)"
+        ),
+    )
+
     parser.add_argument(
         "--lines",
         type=int,
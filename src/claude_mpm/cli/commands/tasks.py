@@ -0,0 +1,176 @@
+"""
+Tasks command implementation for claude-mpm.
+
+WHY: An agent that runs a training job or a long build as a normal tool call
+is stuck until it finishes. ``tasks start`` hands the command to a detached
+watcher and returns the task id at once; the agent checks back with
+``tasks show`` or the event log.
+
+DESIGN DECISIONS:
+- Thin wrapper around TaskTracker
+- ``start`` prints only the task id with --quiet, so scripts and agents can
+  capture it
+- ``show`` includes the end of the output, which is usually what the agent
+  needs to decide what to do next
+"""
+
+from __future__ import annotations
+
+import json
+
+from ...services.long_tasks import FINISHED, RUNNING, LongTask, TaskTracker
+from ..shared import BaseCommand, CommandResult
+from ..verbosity import emit_quiet_result
+
+SHOW_LINES = 20
+
+
+def _progress(task: LongTask) -> str:
+    return f"{task.percent:g}%" if task.percent is not None else "-"
+
+
+def _gpu_summary(task: LongTask) -> str:
+    return ", ".join(
+        f"GPU{gpu['index']} {gpu['utilization']}% "
+        f"{gpu['memory_used_mb']}/{gpu['memory_total_mb']} MiB"
+        for gpu in task.gpu
+    )
+
+
+class TasksCommand(BaseCommand):
+    """CLI command for detached long-running tasks."""
+
+    VALID_COMMANDS = ("start", "list", "show", "logs", "stop")
+
+    def __init__(self, tracker: TaskTracker | None = None):
+        super().__init__("tasks")
+        self._tracker = tracker
+
+    @property
+    def tracker(self) -> TaskTracker:
+        if self._tracker is None:
+            self._tracker = TaskTracker()
+        return self._tracker
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "tasks_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm tasks {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "start": self._start,
+            "list": self._list,
+            "show": self._show,
+            "logs": self._logs,
+            "stop": self._stop,
+        }
+        try:
+            return handlers[args.tasks_command](args)
+        except (FileNotFoundError, ValueError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing tasks command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing tasks command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _start(self, args) -> CommandResult:
+        command = list(args.task_command or [])
+        if command[:1] == ["--"]:
+            command = command[1:]
+        task = self.tracker.start(
+            command,
+            name=args.name,
+            cwd=args.task_cwd,
+            progress_pattern=args.progress_pattern,
+        )
+        emit_quiet_result(task.id)
+        return CommandResult.success_result(
+            f"Started task {task.id}: {task.command_line}\n"
+            f"Check on it with 'claude-mpm tasks show {task.id}'",
+            data=task.to_dict(),
+        )
+
+    def _list(self, args) -> CommandResult:
+        tasks = self.tracker.list_tasks()
+        if args.running:
+            tasks = [task for task in tasks if task.status == RUNNING]
+        data = [task.to_dict() for task in tasks]
+        if args.json:
+            return CommandResult.success_result(json.dumps(data, indent=2))
+        if not tasks:
+            return CommandResult.success_result("No tasks", data=data)
+        lines = []
+        for task in tasks:
+            lines.append(
+                f"{task.id:<28} {task.status:<10} {_progress(task):>6}  "
+                f"{task.command_line}"
+            )
+            if task.status == RUNNING and task.gpu:
+                lines.append(f"{'':<28} {_gpu_summary(task)}")
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _show(self, args) -> CommandResult:
+        task = self.tracker.get(args.task_id)
+        output = self.tracker.tail(task.id, SHOW_LINES)
+        if args.json:
+            return CommandResult.success_result(
+                json.dumps({**task.to_dict(), "output": output}, indent=2)
+            )
+        lines = [
+            f"Task {task.id}: {task.status}",
+            f"  Command:  {task.command_line}",
+            f"  Cwd:      {task.cwd}",
+            f"  Started:  {(task.started_at or '')[:19]}",
+            f"  Progress: {_progress(task)}",
+        ]
+        if task.status in FINISHED:
+            lines.append(f"  Finished: {(task.finished_at or '')[:19]}")
+            if task.exit_code is not None:
+                lines.append(f"  Exit:     {task.exit_code}")
+        if task.gpu:
+            lines.append(f"  GPU:      {_gpu_summary(task)}")
+        if output:
+            lines.extend(["", f"Last {SHOW_LINES} lines of output:", output])
+        return CommandResult.success_result("\n".join(lines), data=task.to_dict())
+
+    def _logs(self, args) -> CommandResult:
+        return CommandResult.success_result(self.tracker.tail(args.task_id, args.lines))
+
+    def _stop(self, args) -> CommandResult:
+        task = self.tracker.stop(args.task_id)
+        if task.status in FINISHED:
+            return CommandResult.success_result(f"Task {task.id} already {task.status}")
+        return CommandResult.success_result(f"Stopping task {task.id}")
+
+
+def manage_tasks(args) -> int:
+    """Main entry point for the tasks command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = TasksCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,173 @@
+"""
+Templates command implementation for claude-mpm.
+
+WHY: Lets users find the conversation templates available to a project and
+preview what ``claude-mpm start --template`` would send and set, without
+launching a session.
+
+DESIGN DECISIONS:
+- ``show`` renders with the values given and keeps placeholders for the
+  rest, so a template can be read before its variables are known
+- Agents a template needs but that are not deployed are reported by
+  ``show`` rather than failing it; ``start`` refuses to launch without them
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.conversation_templates import (
+    agent_denials,
+    find_template,
+    list_templates,
+    parse_vars,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class TemplatesCommand(BaseCommand):
+    """CLI command for conversation templates."""
+
+    VALID_COMMANDS = ("list", "show")
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("templates")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "templates_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm templates {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "list": self._list,
+            "show": self._show,
+        }
+        try:
+            return handlers[args.templates_command](args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing templates command: %s", e, exc_info=True)
+            return CommandResult.error_result(
+                f"Error executing templates command: {e}"
+            )
+
+    @staticmethod
+    def _output(args, data, text: str) -> CommandResult:
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        return CommandResult.success_result(text, data=data)
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _list(self, args) -> CommandResult:
+        templates = list_templates(self.project_dir)
+        data = [template.to_dict() for template in templates]
+        if not templates:
+            return self._output(
+                args,
+                data,
+                "No conversation templates. Add them to "
+                ".claude-mpm/conversation-templates/.",
+            )
+        width = max(len(template.name) for template in templates)
+        lines = []
+        for template in templates:
+            variables = " ".join(
+                f"{name}=" if variable.default is None else f"[{name}]"
+                for name, variable in template.variables.items()
+            )
+            lines.append(
+                f"{template.name:<{width}}  {template.description}"
+                + (f"  ({variables})" if variables else "")
+                + f"  [{template.source}]"
+            )
+        return self._output(args, data, "\n".join(lines))
+
+    def _show(self, args) -> CommandResult:
+        template = find_template(args.name, self.project_dir)
+        prompt = template.render(
+            parse_vars(getattr(args, "template_vars", None)), partial=True
+        )
+        try:
+            denied_agents = agent_denials(template.agents, self.project_dir)
+            agent_problem = None
+        except ValueError as e:
+            denied_agents, agent_problem = [], str(e)
+        data = {
+            **template.to_dict(),
+            "prompt": prompt,
+            "denied_agents": denied_agents,
+            "agent_problem": agent_problem,
+        }
+
+        policies = template.policies
+        lines = [f"Template: {template.name} ({template.source}: {template.path})"]
+        if template.description:
+            lines.append(template.description)
+        lines += ["", "Prompt:", *(f"  {line}" for line in prompt.splitlines())]
+        if template.variables:
+            lines += ["", "Variables:"]
+            for name, variable in template.variables.items():
+                default = (
+                    "required"
+                    if variable.default is None
+                    else f"default: {variable.default}"
+                )
+                description = (
+                    f" {variable.description}" if variable.description else ""
+                )
+                lines.append(f"  {name}{description} ({default})")
+        lines += ["", f"Agents: {', '.join(template.agents) or 'all deployed'}"]
+        if agent_problem:
+            lines.append(f"  ⚠️  {agent_problem}")
+        settings = [
+            ("labels", ", ".join(policies.labels)),
+            ("env", ", ".join(f"{k}={v}" for k, v in policies.env.items())),
+            ("model", policies.model or ""),
+            ("max turns", str(policies.max_turns or "")),
+            ("denied tools", ", ".join(policies.disallowed_tools)),
+            (
+                "permissions",
+                "ask" if policies.skip_permissions is False else "",
+            ),
+            ("chaos", "on" if policies.chaos else ""),
+        ]
+        if any(value for _, value in settings):
+            lines += ["", "Policies:"]
+            lines += [f"  {label:<13} {value}" for label, value in settings if value]
+        return self._output(args, data, "\n".join(lines))
+
+
+def manage_templates(args) -> int:
+    """Main entry point for the templates command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = TemplatesCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
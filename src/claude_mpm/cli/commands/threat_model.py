@@ -0,0 +1,89 @@
+"""
+Threat model command implementation for claude-mpm.
+
+WHY: ``claude-mpm analyze threat-model`` drafts the project's STRIDE threat
+model from the code and brings it up to date after changes, so it can run
+after a session or gate CI with ``--check``.
+
+DESIGN DECISIONS:
+- Thin wrapper around ThreatModeler
+- ``--check`` fails when the document on disk is out of date, without
+  writing it
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.threat_model import STRIDE, ThreatModeler, ThreatModelUpdate
+from ..shared import BaseCommand, CommandResult
+
+
+class ThreatModelCommand(BaseCommand):
+    """CLI command for the project threat model."""
+
+    def __init__(self, project_dir: Path | None = None):
+        super().__init__("analyze threat-model")
+        self.project_dir = Path(project_dir or Path.cwd())
+
+    def run(self, args) -> CommandResult:
+        try:
+            modeler = ThreatModeler(self.project_dir, getattr(args, "output", None))
+            check = getattr(args, "check", False)
+            update = modeler.update(
+                check=check, rebuild=getattr(args, "rebuild", False)
+            )
+        except Exception as e:
+            self.logger.error("Error building threat model: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error building threat model: {e}")
+
+        data = update.to_dict()
+        if getattr(args, "json", False):
+            message = json.dumps(data, indent=2)
+        else:
+            message = _format_update(update, check)
+        if check and update.changed:
+            return CommandResult.error_result(message, data=data)
+        return CommandResult.success_result(message, data=data)
+
+
+def _format_update(update: ThreatModelUpdate, check: bool) -> str:
+    try:
+        path = update.path.relative_to(Path.cwd())
+    except ValueError:
+        path = update.path
+    if check:
+        state = (
+            "is out of date; run 'claude-mpm analyze threat-model'"
+            if update.changed
+            else "is up to date"
+        )
+        lines = [f"{path} {state}: {update.summary()}"]
+    else:
+        verb = "Updated" if update.changed else "Unchanged"
+        lines = [f"{verb} {path}: {update.summary()}"]
+    new = set(update.new)
+    for threat in update.model.threats:
+        if threat.id in new:
+            title = STRIDE[threat.category][0]
+            lines.append(
+                f"  new  {threat.id}  {threat.severity:<6}  {title}: {threat.title}"
+            )
+    lines.extend(f"  resolved  {id_}" for id_ in update.resolved)
+    return "\n".join(lines)
+
+
+def manage_threat_model(args) -> int:
+    """Main entry point for ``analyze threat-model``.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure or an out-of-date document).
+    """
+    result = ThreatModelCommand().run(args)
+    if result.message:
+        print(result.message if result.success else f"Error: {result.message}")
+    return 0 if result.success else 1
@@ -23,7 +23,9 @@ from ...services.ticket_services import (
     TicketValidationService,
     TicketWorkflowService,
 )
-from ..shared import BaseCommand, CommandResult
+from ...utils.table_view import TableView, table_options
+from ..list_columns import TICKET_COLUMNS
+from ..shared import BaseCommand, CommandResult, ListOptions
 
 
 class TicketsCommand(BaseCommand):
@@ -131,6 +133,13 @@ class TicketsCommand(BaseCommand):
             page_size = getattr(args, "page_size", 20)
             limit = getattr(args, "limit", page_size)
 
+            # --offset/--filter switch paging to offset-based, --limit per page
+            options = ListOptions.from_args(args, default_limit=page_size)
+            offset = None
+            if options.active:
+                offset = options.offset
+                page_size = limit = options.limit
+
             # Validate pagination
             valid, error = self.validator.validate_pagination(page, page_size)
             if not valid:
@@ -148,18 +157,29 @@ class TicketsCommand(BaseCommand):
                 page_size=page_size,
                 type_filter=type_filter,
                 status_filter=status_filter,
+                offset=offset,
+                match=options.matches if options.filters else None,
             )
 
             if result["success"]:
-                # Format and display output
-                output_lines = self.formatter.format_ticket_list(
-                    result["tickets"],
-                    page=page,
-                    page_size=page_size,
-                    verbose=getattr(args, "verbose", False),
-                )
-                for line in output_lines:
-                    print(line)
+                tickets = result["tickets"]
+                if getattr(args, "tree", False) is True:
+                    print(self.formatter.format_ticket_tree(tickets))
+                elif any(table_options(args)):
+                    table = TableView.from_args(
+                        TICKET_COLUMNS, self.formatter.ticket_rows(tickets), args
+                    )
+                    print(table.render())
+                else:
+                    # Format and display output
+                    output_lines = self.formatter.format_ticket_list(
+                        tickets,
+                        page=page,
+                        page_size=page_size,
+                        verbose=getattr(args, "verbose", False),
+                    )
+                    for line in output_lines:
+                        print(line)
                 return CommandResult.success_result("Tickets listed successfully")
             print(self.formatter.format_error(result["error"]))
             return CommandResult.error_result(result["error"])
@@ -0,0 +1,147 @@
+"""
+Trust command implementation for claude-mpm.
+
+WHY: The first-run prompt records a decision per repository; users need to
+see and change those decisions later, and to trust a repository up front
+before a headless run, which never prompts.
+
+DESIGN DECISIONS:
+- Thin wrapper around WorkspaceTrustStore
+- Paths are resolved to their repository root, so ``trust add`` from a
+  subdirectory trusts the whole repository, as the prompt does
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from ...services.workspace_trust import (
+    TRUSTED,
+    UNKNOWN,
+    WorkspaceTrustStore,
+    workspace_root,
+)
+from ..shared import BaseCommand, CommandResult
+
+
+class TrustCommand(BaseCommand):
+    """CLI command for workspace trust decisions."""
+
+    VALID_COMMANDS = ("status", "add", "deny", "remove", "list")
+
+    def __init__(self, store: WorkspaceTrustStore | None = None):
+        super().__init__("trust")
+        self.store = store or WorkspaceTrustStore()
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "trust_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm trust {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "status": self._status,
+            "add": self._add,
+            "deny": self._deny,
+            "remove": self._remove,
+            "list": self._list,
+        }
+        try:
+            return handlers[args.trust_command](args)
+        except Exception as e:
+            self.logger.error("Error executing trust command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing trust command: {e}")
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _status(self, args) -> CommandResult:
+        path = Path(args.path).expanduser()
+        root = workspace_root(path)
+        status = self.store.status(path)
+        data = {"workspace": str(root), "status": status}
+        if not self.store.config.enabled:
+            message = "Workspace trust is disabled; every workspace is trusted"
+        elif status == TRUSTED:
+            message = f"{root} is trusted"
+        elif status == UNKNOWN:
+            message = f"{root} has not been decided; the next run will ask"
+        else:
+            message = (
+                f"{root} is untrusted; sessions run without shell, network "
+                "tools or repository hooks"
+            )
+        return CommandResult.success_result(message, data=data)
+
+    def _add(self, args) -> CommandResult:
+        root = self.store.trust(Path(args.path).expanduser())
+        return CommandResult.success_result(
+            f"Trusted {root}", data={"workspace": str(root)}
+        )
+
+    def _deny(self, args) -> CommandResult:
+        root = self.store.distrust(Path(args.path).expanduser())
+        return CommandResult.success_result(
+            f"Marked {root} untrusted", data={"workspace": str(root)}
+        )
+
+    def _remove(self, args) -> CommandResult:
+        path = Path(args.path).expanduser()
+        root = workspace_root(path)
+        if not self.store.forget(path):
+            return CommandResult.error_result(f"No trust decision recorded for {root}")
+        return CommandResult.success_result(
+            f"Forgot the trust decision for {root}", data={"workspace": str(root)}
+        )
+
+    def _list(self, args) -> CommandResult:
+        entries = [e.to_dict() for e in self.store.entries()]
+        data = {
+            "entries": entries,
+            "trusted_paths": self.store.config.trusted_paths,
+        }
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        if not entries and not data["trusted_paths"]:
+            return CommandResult.success_result(
+                "No trust decisions recorded", data=data
+            )
+        lines = [
+            f"{e['decision']:<10} {e['decided_at'][:10]}  {e['path']}" for e in entries
+        ]
+        lines += [
+            f"{'trusted':<10} {'config':<10}  {path}"
+            for path in data["trusted_paths"]
+        ]
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+
+def manage_trust(args) -> int:
+    """Main entry point for the trust command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = TrustCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,171 @@
+"""
+View command implementation for claude-mpm.
+
+WHY: Lets users save dashboard filter and layout combinations and open the
+dashboard straight into one of them, rather than setting the filters by
+hand on every visit.
+
+DESIGN DECISIONS:
+- Thin wrapper around DashboardViews, which the dashboard's /api/views
+  routes use too, so views saved from either side show up in both
+- ``open`` warns rather than fails when the monitor is not running, since
+  the URL works once it is started
+"""
+
+from __future__ import annotations
+
+import json
+import socket
+import webbrowser
+
+from ...services.dashboard_views import DashboardView, DashboardViews, view_url
+from ..shared import BaseCommand, CommandResult
+
+
+class ViewCommand(BaseCommand):
+    """CLI command for saved dashboard views."""
+
+    VALID_COMMANDS = ("list", "show", "save", "delete", "open")
+
+    def __init__(self, views: DashboardViews | None = None):
+        super().__init__("view")
+        self.views = views or DashboardViews()
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "view_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm view {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "list": self._list,
+            "show": self._show,
+            "save": self._save,
+            "delete": self._delete,
+            "open": self._open,
+        }
+        try:
+            return handlers[args.view_command](args)
+        except ValueError as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error("Error executing view command: %s", e, exc_info=True)
+            return CommandResult.error_result(f"Error executing view command: {e}")
+
+    @staticmethod
+    def _output(args, data, text: str) -> CommandResult:
+        if getattr(args, "json", False):
+            return CommandResult.success_result(json.dumps(data, indent=2), data=data)
+        return CommandResult.success_result(text, data=data)
+
+    def _get(self, name: str) -> DashboardView:
+        view = self.views.get(name)
+        if view is None:
+            raise ValueError(
+                f"No saved view named '{name}'. List views with 'claude-mpm view list'."
+            )
+        return view
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _list(self, args) -> CommandResult:
+        views = self.views.all()
+        data = [view.to_dict() for view in views]
+        if not views:
+            return self._output(
+                args,
+                data,
+                "No saved views. Save one with 'claude-mpm view save <name>' or "
+                "from the dashboard's View menu.",
+            )
+        width = max(len(view.name) for view in views)
+        lines = []
+        for view in views:
+            lines.append(f"{view.name:<{width}}  {view.summary()}")
+            if view.description:
+                lines.append(f"{'':<{width}}  {view.description}")
+        return self._output(args, data, "\n".join(lines))
+
+    def _show(self, args) -> CommandResult:
+        view = self._get(args.name)
+        text = "\n".join(
+            f"{field}: {value}"
+            for field, value in view.to_dict().items()
+            if value not in ("", None)
+        )
+        return self._output(args, view.to_dict(), text)
+
+    def _save(self, args) -> CommandResult:
+        view = self.views.save(
+            DashboardView(
+                name=args.name,
+                description=args.description,
+                tab=args.tab,
+                project=args.project,
+                label=args.label,
+                stream=args.stream,
+                since=args.since,
+                left_width=args.left_width,
+            )
+        )
+        return CommandResult.success_result(
+            f"Saved view '{view.name}': {view.summary()}", data=view.to_dict()
+        )
+
+    def _delete(self, args) -> CommandResult:
+        if not self.views.delete(args.name):
+            raise ValueError(f"No saved view named '{args.name}'")
+        return CommandResult.success_result(f"Deleted view '{args.name}'")
+
+    def _open(self, args) -> CommandResult:
+        view = self._get(args.name)
+        url = view_url(f"http://{args.host}:{args.port}", view.name)
+        if not _monitor_reachable(args.host, args.port):
+            print(
+                f"⚠️  No monitor on {args.host}:{args.port}; start it with "
+                "'claude-mpm monitor start'"
+            )
+        if args.no_browser or not webbrowser.open(url):
+            return CommandResult.success_result(url, data={"url": url})
+        return CommandResult.success_result(
+            f"Opened view '{view.name}': {url}", data={"url": url}
+        )
+
+
+def _monitor_reachable(host: str, port: int) -> bool:
+    try:
+        with socket.create_connection((host, port), timeout=0.5):
+            return True
+    except OSError:
+        return False
+
+
+def manage_view(args) -> int:
+    """Main entry point for the view command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = ViewCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
@@ -0,0 +1,235 @@
+"""
+Work queue command implementation for claude-mpm.
+
+WHY: Teams running more sessions than one machine can handle submit tasks to
+a shared broker and run workers wherever there is capacity.
+
+DESIGN DECISIONS:
+- Thin wrapper around the brokers and QueueWorker in services.work_queue
+- ``worker`` runs in the foreground and stops cleanly on SIGTERM/SIGINT,
+  handing back nothing half-done, so it fits systemd and containers
+- ``worker --warm-sessions N`` runs tasks for the current directory on a
+  warm session pool; everything else still starts a headless session
+- ``submit --after`` refuses IDs the broker does not know, so a typo cannot
+  leave a task waiting forever
+"""
+
+from __future__ import annotations
+
+import json
+import signal
+import threading
+import time
+from dataclasses import asdict
+from pathlib import Path
+
+from ...services.agents.session_pool import PooledTaskRunner, SessionPoolConfig
+from ...services.work_queue import (
+    FAILED,
+    PENDING,
+    STATUSES,
+    QueueTask,
+    QueueWorker,
+    WorkQueueConfig,
+    create_broker,
+    run_headless,
+)
+from ..shared import BaseCommand, CommandResult
+from ..verbosity import emit_quiet_result
+
+
+class WorkQueueCommand(BaseCommand):
+    """CLI command for the distributed work queue."""
+
+    VALID_COMMANDS = ("submit", "worker", "status", "tasks", "show", "requeue")
+
+    def __init__(self, broker=None, config: WorkQueueConfig | None = None):
+        super().__init__("work-queue")
+        self._broker = broker
+        self._config = config
+
+    def validate_args(self, args) -> str | None:
+        if getattr(args, "work_queue_command", None) not in self.VALID_COMMANDS:
+            return f"Usage: claude-mpm work-queue {{{','.join(self.VALID_COMMANDS)}}}"
+        return None
+
+    def run(self, args) -> CommandResult:
+        handlers = {
+            "submit": self._submit,
+            "worker": self._worker,
+            "status": self._status,
+            "tasks": self._tasks,
+            "show": self._show,
+            "requeue": self._requeue,
+        }
+        try:
+            return handlers[args.work_queue_command](args)
+        except (ValueError, RuntimeError) as e:
+            return CommandResult.error_result(str(e))
+        except Exception as e:
+            self.logger.error(
+                "Error executing work-queue command: %s", e, exc_info=True
+            )
+            return CommandResult.error_result(
+                f"Error executing work-queue command: {e}"
+            )
+
+    def _setup(self, args):
+        if self._config is None:
+            self._config = WorkQueueConfig.load()
+            if getattr(args, "broker", None):
+                self._config.broker = args.broker
+            if getattr(args, "queue", None):
+                self._config.queue = args.queue
+        if self._broker is None:
+            self._broker = create_broker(self._config)
+        return self._broker, self._config
+
+    # ------------------------------------------------------------------
+    # Subcommand handlers
+    # ------------------------------------------------------------------
+
+    def _submit(self, args) -> CommandResult:
+        broker, _ = self._setup(args)
+        depends_on = getattr(args, "depends_on", None) or []
+        unknown = [task_id for task_id in depends_on if broker.get(task_id) is None]
+        if unknown:
+            return CommandResult.error_result(f"No task {', '.join(unknown)}")
+        task = broker.enqueue(
+            QueueTask(
+                prompt=args.prompt,
+                cwd=args.cwd,
+                repo=args.repo,
+                ref=args.ref,
+                model=getattr(args, "model", None),
+                max_turns=args.task_max_turns,
+                agent=getattr(args, "agent", None),
+                task_type=getattr(args, "task_type", None),
+                depends_on=depends_on,
+            )
+        )
+        emit_quiet_result(task.id)
+        return CommandResult.success_result(
+            f"Queued task {task.id}", data={"id": task.id}
+        )
+
+    def _worker(self, args) -> CommandResult:
+        broker, config = self._setup(args)
+        pool_config = SessionPoolConfig.load()
+        warm = getattr(args, "warm_sessions", None)
+        if warm is not None:
+            pool_config.size, pool_config.enabled = warm, warm > 0
+        pooled = None
+        if pool_config.enabled and pool_config.size > 0:
+            print(f"Warming {pool_config.size} session(s) in {Path.cwd()}")
+            pooled = PooledTaskRunner(Path.cwd(), pool_config, fallback=run_headless)
+        worker = QueueWorker(
+            broker,
+            config,
+            worker_id=args.worker_id,
+            runner=pooled or run_headless,
+        )
+        if threading.current_thread() is threading.main_thread():
+            for signum in (signal.SIGTERM, signal.SIGINT):
+                signal.signal(signum, lambda *_: worker.stop())
+        print(f"Worker {worker.id} pulling from {config.broker} ({config.queue})")
+        try:
+            processed = worker.run(once=args.once)
+        finally:
+            if pooled is not None:
+                pooled.close()
+        message = f"Worker {worker.id} stopped after {processed} task(s)"
+        if pooled is not None:
+            stats = pooled.pool.stats
+            message += f" ({stats.hits} warm, {stats.misses} cold)"
+        return CommandResult.success_result(message)
+
+    def _status(self, args) -> CommandResult:
+        broker, config = self._setup(args)
+        now = time.time()
+        counts = {status: len(broker.tasks(status)) for status in STATUSES}
+        workers = [
+            {**asdict(w), "seconds_since_heartbeat": round(now - w.last_heartbeat, 1)}
+            for w in broker.workers()
+        ]
+        data = {"broker": config.broker, "queue": config.queue, "tasks": counts}
+        data["workers"] = workers
+        if args.json:
+            return CommandResult.success_result(json.dumps(data, indent=2))
+        lines = [
+            f"Queue {config.queue} on {config.broker}",
+            "Tasks: " + ", ".join(f"{n} {s}" for s, n in counts.items()),
+            f"Workers: {len(workers)}",
+        ]
+        for w in workers:
+            stale = w["seconds_since_heartbeat"] > config.worker_timeout
+            lines.append(
+                f"  {w['id']:<32} {w['host']:<20} "
+                f"heartbeat {w['seconds_since_heartbeat']:.0f}s ago"
+                + (" (missed)" if stale else "")
+                + (f"  running {w['current_task']}" if w["current_task"] else "")
+            )
+        return CommandResult.success_result("\n".join(lines), data=data)
+
+    def _tasks(self, args) -> CommandResult:
+        broker, _ = self._setup(args)
+        tasks = broker.tasks(args.task_status)
+        if args.json:
+            return CommandResult.success_result(
+                json.dumps([asdict(t) for t in tasks], indent=2)
+            )
+        if not tasks:
+            return CommandResult.success_result("No tasks")
+        lines = []
+        for t in tasks:
+            lines.append(
+                f"{t.id}  {t.status:<10} {t.worker or '-':<24} {t.prompt[:50]}"
+            )
+            if t.detail and t.status in (PENDING, FAILED):
+                lines.append(f"{'':14}{t.detail}")
+        return CommandResult.success_result("\n".join(lines))
+
+    def _show(self, args) -> CommandResult:
+        broker, _ = self._setup(args)
+        task = broker.get(args.task_id)
+        if task is None:
+            return CommandResult.error_result(f"No task {args.task_id}")
+        return CommandResult.success_result(
+            json.dumps(asdict(task), indent=2), data=asdict(task)
+        )
+
+    def _requeue(self, args) -> CommandResult:
+        broker, config = self._setup(args)
+        tasks = broker.requeue_dead(config.worker_timeout, config.max_attempts)
+        lines = [f"Recovered {len(tasks)} task(s) from stopped workers"]
+        lines += [f"  {t.id}  {t.status}" for t in tasks]
+        return CommandResult.success_result("\n".join(lines))
+
+
+def manage_work_queue(args) -> int:
+    """Main entry point for the work-queue command.
+
+    Args:
+        args: Parsed CLI arguments.
+
+    Returns:
+        Exit code (0 for success, 1 for failure).
+    """
+    command = WorkQueueCommand()
+    error = command.validate_args(args)
+
+    if error:
+        command.logger.error(error)
+        print(f"Error: {error}")
+        return 1
+
+    result = command.run(args)
+
+    if result.success:
+        if result.message:
+            print(result.message)
+        return 0
+
+    if result.message:
+        print(f"Error: {result.message}")
+    return 1
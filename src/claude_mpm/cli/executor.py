@@ -460,6 +460,245 @@ def execute_command(command: str, args) -> int:
         result = manage_serve(args)
         return result if result is not None else 0
 
+    # Handle daemon command (shared user-level daemon) with lazy import
+    if command == "daemon":
+        from .commands.daemon import manage_daemon
+
+        result = manage_daemon(args)
+        return result if result is not None else 0
+
+    # Handle selftest command (end-to-end checks) with lazy import
+    if command == "selftest":
+        from .commands.selftest import manage_selftest
+
+        result = manage_selftest(args)
+        return result if result is not None else 0
+
+    # Handle cache-proxy command (response caching proxy) with lazy import
+    if command == "cache-proxy":
+        from .commands.cache_proxy import manage_cache_proxy
+
+        result = manage_cache_proxy(args)
+        return result if result is not None else 0
+
+    # Handle playground command (prompt engineering REPL) with lazy import
+    if command == "playground":
+        from .commands.playground import manage_playground
+
+        result = manage_playground(args)
+        return result if result is not None else 0
+
+    # Handle eval command (agent evaluation suites) with lazy import
+    if command == "eval":
+        from .commands.evals import manage_eval
+
+        result = manage_eval(args)
+        return result if result is not None else 0
+
+    # Handle golden command (golden transcript regression tests) with lazy import
+    if command == "golden":
+        from .commands.golden import manage_golden
+
+        result = manage_golden(args)
+        return result if result is not None else 0
+
+    # Handle analyze threat-model (the analyze command itself has no handler)
+    if (
+        command in ("analyze", "analysis", "code-analyze")
+        and getattr(args, "analyze_command", None) == "threat-model"
+    ):
+        from .commands.threat_model import manage_threat_model
+
+        return manage_threat_model(args)
+
+    # Handle graph command (project knowledge graph) with lazy import
+    if command == "graph":
+        from .commands.graph import manage_graph
+
+        result = manage_graph(args)
+        return result if result is not None else 0
+
+    # Handle logs command (project log sources) with lazy import
+    if command == "logs":
+        from .commands.logs import manage_logs
+
+        result = manage_logs(args)
+        return result if result is not None else 0
+
+    # Handle artifacts command (session artifact store) with lazy import
+    if command == "artifacts":
+        from .commands.artifacts import manage_artifacts
+
+        result = manage_artifacts(args)
+        return result if result is not None else 0
+
+    # Handle grep-output command (session output search) with lazy import
+    if command == "grep-output":
+        from .commands.grep_output import manage_grep_output
+
+        result = manage_grep_output(args)
+        return result if result is not None else 0
+
+    # Handle storage command (disk usage and retention) with lazy import
+    if command == "storage":
+        from .commands.storage import manage_storage
+
+        result = manage_storage(args)
+        return result if result is not None else 0
+
+    # Handle trust command (workspace trust decisions) with lazy import
+    if command == "trust":
+        from .commands.trust import manage_trust
+
+        result = manage_trust(args)
+        return result if result is not None else 0
+
+    # Handle integrity command (deployed agent/skill verification) with lazy import
+    if command == "integrity":
+        from .commands.integrity import manage_integrity
+
+        result = manage_integrity(args)
+        return result if result is not None else 0
+
+    # Handle batch command (Kubernetes batch agent runs) with lazy import
+    if command == "batch":
+        from .commands.batch import manage_batch
+
+        result = manage_batch(args)
+        return result if result is not None else 0
+
+    # Handle work-queue command (distributed task queue) with lazy import
+    if command == "work-queue":
+        from .commands.work_queue import manage_work_queue
+
+        result = manage_work_queue(args)
+        return result if result is not None else 0
+
+    # Handle status command (project summary) with lazy import
+    if command == "status":
+        from .commands.status import manage_status
+
+        result = manage_status(args)
+        return result if result is not None else 0
+
+    # Handle canary command (staged rollouts) with lazy import
+    if command == "canary":
+        from .commands.canary import manage_canary
+
+        result = manage_canary(args)
+        return result if result is not None else 0
+
+    # Handle flags command (feature flags) with lazy import
+    if command == "flags":
+        from .commands.flags import manage_flags
+
+        result = manage_flags(args)
+        return result if result is not None else 0
+
+    # Handle tasks command (detached long-running commands) with lazy import
+    if command == "tasks":
+        from .commands.tasks import manage_tasks
+
+        result = manage_tasks(args)
+        return result if result is not None else 0
+
+    # Handle patch command (fuzz-tolerant diff application) with lazy import
+    if command == "patch":
+        from .commands.patch import manage_patch
+
+        result = manage_patch(args)
+        return result if result is not None else 0
+
+    # Handle post-edit command (formatting pipeline) with lazy import
+    if command == "post-edit":
+        from .commands.post_edit import manage_post_edit
+
+        result = manage_post_edit(args)
+        return result if result is not None else 0
+
+    # Handle resolve-conflicts command (agent conflict resolution) with lazy import
+    if command == "resolve-conflicts":
+        from .commands.resolve_conflicts import manage_resolve_conflicts
+
+        result = manage_resolve_conflicts(args)
+        return result if result is not None else 0
+
+    # Handle owners command (code ownership lookup) with lazy import
+    if command == "owners":
+        from .commands.owners import manage_owners
+
+        result = manage_owners(args)
+        return result if result is not None else 0
+
+    # Handle advisories command (security advisories for pinned deps) with lazy import
+    if command == "advisories":
+        from .commands.advisories import manage_advisories
+
+        result = manage_advisories(args)
+        return result if result is not None else 0
+
+    # Handle annotations command (review comments on sessions) with lazy import
+    if command == "annotations":
+        from .commands.annotations import manage_annotations
+
+        result = manage_annotations(args)
+        return result if result is not None else 0
+
+    # Handle checklist command (onboarding checklist) with lazy import
+    if command == "checklist":
+        from .commands.checklist import manage_checklist
+
+        result = manage_checklist(args)
+        return result if result is not None else 0
+
+    # Handle adoption command (team adoption metrics) with lazy import
+    if command == "adoption":
+        from .commands.adoption import manage_adoption
+
+        result = manage_adoption(args)
+        return result if result is not None else 0
+
+    # Handle labels command (session labels) with lazy import
+    if command == "labels":
+        from .commands.labels import manage_labels
+
+        result = manage_labels(args)
+        return result if result is not None else 0
+
+    # Handle view command (saved dashboard views) with lazy import
+    if command == "view":
+        from .commands.view import manage_view
+
+        result = manage_view(args)
+        return result if result is not None else 0
+
+    # Handle export command (session analytics for BI tools) with lazy import
+    if command == "export":
+        from .commands.export import manage_export
+
+        result = manage_export(args)
+        return result if result is not None else 0
+
+    # Handle routing command (task-type routing of delegations) with lazy import
+    if command == "routing":
+        from .commands.routing import manage_routing
+
+        result = manage_routing(args)
+        return result if result is not None else 0
+
+    # Handle start command (a run from a conversation template) with lazy import
+    if command == "start":
+        from .commands.start import start_session
+
+        return start_session(args)
+
+    # Handle templates command (conversation templates) with lazy import
+    if command == "templates":
+        from .commands.templates import manage_templates
+
+        result = manage_templates(args)
+        return result if result is not None else 0
+
     # Handle ztk-stats command with lazy import
     if command == "ztk-stats":
         from .commands.ztk_stats import run_ztk_stats
@@ -580,6 +819,39 @@ def execute_command(command: str, args) -> int:
         "search-index",
         "si",
         "session",
+        "daemon",
+        "selftest",
+        "cache-proxy",
+        "playground",
+        "eval",
+        "golden",
+        "graph",
+        "logs",
+        "artifacts",
+        "grep-output",
+        "storage",
+        "trust",
+        "integrity",
+        "batch",
+        "work-queue",
+        "status",
+        "canary",
+        "flags",
+        "tasks",
+        "patch",
+        "post-edit",
+        "resolve-conflicts",
+        "owners",
+        "advisories",
+        "annotations",
+        "checklist",
+        "adoption",
+        "labels",
+        "view",
+        "export",
+        "routing",
+        "start",
+        "templates",
     ]
 
     suggestion = suggest_similar_commands(command, all_commands)
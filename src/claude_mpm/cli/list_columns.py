@@ -0,0 +1,70 @@
+"""Table columns for list commands.
+
+WHY: A parser adds ``--sort``/``--columns`` from the same column list the
+command renders with, so help text and validation never disagree. Kept apart
+from the command modules so parsers don't import their services.
+"""
+
+from ..utils.table_view import Column
+
+PRIORITY_RANK = {"critical": 0, "high": 1, "medium": 2, "low": 3}
+
+
+def _enabled(value: bool) -> str:
+    return "Enabled" if value else "Disabled"
+
+
+SKILL_SOURCE_COLUMNS = [
+    Column("id", "ID"),
+    Column("enabled", "Status", format=_enabled),
+    Column("priority", "Priority", justify="right"),
+    Column("branch", "Branch"),
+    Column("url", "URL"),
+]
+
+FLAG_COLUMNS = [
+    Column("name", "Flag"),
+    Column("enabled", "State", format=lambda on: "on" if on else "off"),
+    Column("source", "Set by"),
+    Column("description", "Description"),
+]
+
+POST_EDIT_COLUMNS = [
+    Column("language", "Language"),
+    Column("suffixes", "Files"),
+    Column("step", "Step"),
+    Column("command", "Command"),
+    Column("tool", "Tool"),
+]
+
+AGENT_SOURCE_COLUMNS = [
+    Column("identifier", "ID"),
+    Column("enabled", "Status", format=_enabled),
+    Column("priority", "Priority", justify="right"),
+    Column("branch", "Branch"),
+    Column("subdirectory", "Subdirectory"),
+    Column("url", "URL"),
+]
+
+AGENT_COLUMNS = [
+    Column("name", "Name"),
+    Column("version", "Version"),
+    Column("source", "Source"),
+    Column("tier", "Tier"),
+    Column("description", "Description"),
+    Column("path", "Path"),
+]
+AGENT_DEFAULT_COLUMNS = ["name", "version", "source", "description"]
+
+TICKET_COLUMNS = [
+    Column("id", "ID"),
+    Column("title", "Title"),
+    Column("status", "Status"),
+    Column(
+        "priority",
+        "Priority",
+        sort_key=lambda value: PRIORITY_RANK.get(str(value).lower(), 99),
+    ),
+    Column("type", "Type"),
+    Column("created_at", "Created"),
+]
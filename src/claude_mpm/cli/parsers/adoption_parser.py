@@ -0,0 +1,71 @@
+"""
+Adoption command parser for claude-mpm CLI.
+
+WHY: Users who turn on the adoption_metrics flag have their sessions counted.
+This parser lets leads report over those counts and lets each user check or
+delete what is recorded about them.
+"""
+
+import argparse
+
+from ...services.adoption import DEFAULT_DAYS, SORT_KEYS
+
+
+def add_adoption_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the adoption subparser with report, status and forget.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured adoption subparser
+    """
+    adoption_parser = subparsers.add_parser(
+        "adoption",
+        help="Team adoption metrics and usage leaderboard (opt-in)",
+        description=(
+            "Report sessions, accepted outcomes (commits) and verification "
+            "pass rates per user. Only users who turned on the adoption_metrics "
+            "flag are recorded, and only counts are kept."
+        ),
+    )
+    adoption_subparsers = adoption_parser.add_subparsers(
+        dest="adoption_command", help="Adoption commands", metavar="SUBCOMMAND"
+    )
+
+    report_parser = adoption_subparsers.add_parser(
+        "report", help="Show the per-user leaderboard"
+    )
+    report_parser.add_argument(
+        "--dir",
+        dest="dirs",
+        action="append",
+        metavar="DIR",
+        help=(
+            "Directory of collected record files (repeatable; default: "
+            "CLAUDE_MPM_ADOPTION_DIR or ~/.claude-mpm/adoption)"
+        ),
+    )
+    report_parser.add_argument(
+        "--days",
+        type=int,
+        default=DEFAULT_DAYS,
+        help=f"Period to report on (default: {DEFAULT_DAYS})",
+    )
+    report_parser.add_argument(
+        "--project", help="Only count sessions in this project directory"
+    )
+    report_parser.add_argument(
+        "--sort",
+        choices=SORT_KEYS,
+        default="sessions",
+        help="Rank users by (default: sessions)",
+    )
+    report_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    adoption_subparsers.add_parser(
+        "status", help="Show whether your sessions are recorded, and where"
+    )
+    adoption_subparsers.add_parser("forget", help="Delete your adoption records")
+
+    return adoption_parser
@@ -0,0 +1,56 @@
+"""
+Advisories command parser for claude-mpm CLI.
+
+WHY: Exposes the security advisory check over the project's pinned
+dependencies: run it now, show the last results, or list what is watched.
+"""
+
+import argparse
+
+
+def add_advisories_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the advisories subparser with check, list and deps.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured advisories subparser
+    """
+    advisories_parser = subparsers.add_parser(
+        "advisories",
+        help="Check pinned dependencies against security advisories",
+        description=(
+            "Look the versions pinned in the project's lockfiles up in OSV, "
+            "which includes the GitHub Advisory Database, and act on new "
+            "advisories as 'advisories.action' says: open a GitHub issue, "
+            "queue an upgrade task on the work queue, or only report. With "
+            "'advisories.enabled', startup runs the check in the background "
+            "once per 'advisories.interval_hours'."
+        ),
+    )
+    advisories_subparsers = advisories_parser.add_subparsers(
+        dest="advisories_command", help="Advisories commands", metavar="SUBCOMMAND"
+    )
+
+    check_parser = advisories_subparsers.add_parser(
+        "check", help="Check now and act on new advisories"
+    )
+    check_parser.add_argument(
+        "--dry-run",
+        action="store_true",
+        help="Report advisories without opening issues or queueing tasks",
+    )
+    check_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    list_parser = advisories_subparsers.add_parser(
+        "list", help="Show the advisories the last check found"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    deps_parser = advisories_subparsers.add_parser(
+        "deps", help="List the pinned dependencies the check looks up"
+    )
+    deps_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return advisories_parser
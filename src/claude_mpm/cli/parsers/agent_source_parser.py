@@ -15,6 +15,9 @@ the same proven patterns but for agent repositories instead of skills.
 
 import argparse
 
+from ...config.skill_sources import PROVIDERS
+from ...utils.table_view import add_table_arguments
+from ..list_columns import AGENT_SOURCE_COLUMNS
 from .base_parser import add_common_arguments
 
 
@@ -50,7 +53,11 @@ def add_agent_source_subparser(subparsers) -> argparse.ArgumentParser:
     )
     add_parser.add_argument(
         "url",
-        help="Git repository URL (e.g., https://github.com/owner/repo)",
+        help=(
+            "Git repository URL (e.g., https://github.com/owner/repo, "
+            "https://gitlab.com/group/repo, https://bitbucket.org/workspace/repo "
+            "or git@github.com:owner/repo.git)"
+        ),
     )
     add_parser.add_argument(
         "--subdirectory",
@@ -85,6 +92,23 @@ def add_agent_source_subparser(subparsers) -> argparse.ArgumentParser:
         dest="skip_test",
         help="Skip immediate testing (not recommended)",
     )
+    add_parser.add_argument(
+        "--token",
+        help=(
+            "Access token or env var reference (e.g., $PRIVATE_TOKEN); defaults "
+            "to GITHUB_TOKEN, GITLAB_TOKEN or BITBUCKET_TOKEN"
+        ),
+    )
+    add_parser.add_argument(
+        "--provider",
+        choices=PROVIDERS,
+        help="Hosting service, for a self-hosted GitLab the URL does not identify",
+    )
+    add_parser.add_argument(
+        "--ssh-key",
+        metavar="PATH",
+        help="Private key (e.g., a deploy key) to use with an SSH URL",
+    )
 
     # Remove repository
     remove_parser = agent_source_subparsers.add_parser(
@@ -123,6 +147,7 @@ def add_agent_source_subparser(subparsers) -> argparse.ArgumentParser:
         action="store_true",
         help="Output as JSON",
     )
+    add_table_arguments(list_parser, AGENT_SOURCE_COLUMNS)
 
     # Update/sync repositories
     update_parser = agent_source_subparsers.add_parser(
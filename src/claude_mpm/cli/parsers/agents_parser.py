@@ -13,6 +13,9 @@ deployment, listing, validation, etc., warranting their own module.
 import argparse
 
 from ...constants import AgentCommands, CLICommands
+from ...utils.bulk_operations import add_jobs_argument
+from ...utils.table_view import add_table_arguments
+from ..list_columns import AGENT_COLUMNS
 from .base_parser import add_common_arguments
 
 
@@ -74,6 +77,12 @@ Available commands:
         type=str,
         help="Filter agents by name, type, category, or tags (case-insensitive substring match)",
     )
+    list_agents_parser.add_argument(
+        "--tree",
+        action="store_true",
+        help="Show system agents under the BASE-AGENT.md files they inherit",
+    )
+    add_table_arguments(list_agents_parser, AGENT_COLUMNS)
 
     # View agent details
     view_agent_parser = agents_subparsers.add_parser(
@@ -88,6 +97,95 @@ Available commands:
         "--show-config", action="store_true", help="Show agent configuration"
     )
 
+    # Diff deployed agent against its source
+    diff_agent_parser = agents_subparsers.add_parser(
+        "diff",
+        help="Show local modifications of a deployed agent against its source",
+        description=(
+            "Compare a deployed agent (.claude/agents/<name>.md) with what "
+            "deploying its source would write, so local edits are visible "
+            "before a sync or update overwrites them. --latest compares with "
+            "the newest cached template instead, --rev with the deployed file "
+            "as committed at a git revision."
+        ),
+    )
+    diff_agent_parser.add_argument("agent_name", help="Name of the deployed agent")
+    diff_against = diff_agent_parser.add_mutually_exclusive_group()
+    diff_against.add_argument(
+        "--latest",
+        action="store_true",
+        help="Compare with the newest template in the agent cache",
+    )
+    diff_against.add_argument(
+        "--rev",
+        metavar="REVISION",
+        help="Compare with the deployed file at a git revision (e.g. HEAD~1)",
+    )
+    diff_agent_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    # Move agents.lock pins to the latest templates
+    update_agents_parser = agents_subparsers.add_parser(
+        "update",
+        help="Upgrade pinned agents to their latest templates",
+        description=(
+            "Deployed agents are pinned in .claude-mpm/agents.lock, so syncs "
+            "and 'claude-mpm run' never change them. This syncs agent sources, "
+            "moves the pins of changed agents to the new templates and "
+            "redeploys them."
+        ),
+    )
+    update_agents_parser.add_argument(
+        "agent_names",
+        nargs="*",
+        metavar="AGENT",
+        help="Agents to update (default: all pinned agents)",
+    )
+    update_agents_parser.add_argument(
+        "--check",
+        action="store_true",
+        help="Only list the available updates",
+    )
+
+    # Show which layer (template, user or project override) defines what
+    explain_agent_parser = agents_subparsers.add_parser(
+        "explain",
+        help="Show which override layer each part of an agent comes from",
+        description=(
+            "Agents are deployed from their template with partial overrides "
+            "from ~/.claude-mpm/agent-overrides/ and .claude-mpm/agent-overrides/ "
+            "merged over it. This lists, per frontmatter field and instructions "
+            "section, the layer that sets it."
+        ),
+    )
+    explain_agent_parser.add_argument("agent_name", help="Name of the agent")
+
+    # Redeploy agents when their templates or overrides change
+    agents_subparsers.add_parser(
+        "watch",
+        help="Redeploy agents when their templates or overrides change",
+        description=(
+            "Watches the templates and override files of this project's "
+            "deployed agents and redeploys an agent after each change. "
+            "Running sessions pick up the new instructions on the agent's "
+            "next delegation."
+        ),
+    )
+
+    # Machine-readable inventory of the deployed agents
+    capabilities_parser = agents_subparsers.add_parser(
+        "capabilities",
+        help="Report the model, tools, memory and skills of each deployed agent",
+        description=(
+            "Reads every deployed agent (.claude/agents/ in the project, then "
+            "~/.claude/agents/) and reports its model, tools, memory settings, "
+            "referenced skills and schema version. --json output is versioned "
+            "for external tooling."
+        ),
+    )
+    capabilities_parser.add_argument(
+        "--json", action="store_true", help="Output JSON"
+    )
+
     # Create local agent
     create_agent_parser = agents_subparsers.add_parser(
         "create", help="Create a new local agent template"
@@ -219,13 +317,27 @@ Available commands:
     deploy_agents_parser.add_argument(
         "--dry-run",
         action="store_true",
-        help="Show what would be deployed without actually deploying",
+        help=(
+            "Print the agents as they would be deployed (base, mixins, "
+            "overrides and permissions applied) without writing .claude/agents; "
+            "with --preset, list the agents"
+        ),
+    )
+    deploy_agents_parser.add_argument(
+        "--stage-dir",
+        type=Path,
+        metavar="DIR",
+        help="Write the rendered agents to DIR instead of stdout (implies --dry-run)",
+    )
+    deploy_agents_parser.add_argument(
+        "--json", action="store_true", help="With --dry-run, output JSON"
     )
     deploy_agents_parser.add_argument(
         "--preset",
         type=str,
         help="Deploy agents by preset name (minimal, python-dev, nextjs-fullstack, etc.)",
     )
+    add_jobs_argument(deploy_agents_parser)
 
     # Validate agents
     validate_agents_parser = agents_subparsers.add_parser(
@@ -129,6 +129,39 @@ def add_analyze_subparser(subparsers):
 
     # Note: --verbose/-v is already defined in base_parser, so removed to avoid conflict
 
+    analyze_subparsers = parser.add_subparsers(
+        dest="analyze_command", metavar="SUBCOMMAND", help="Analysis commands"
+    )
+    threat_model_parser = analyze_subparsers.add_parser(
+        "threat-model",
+        help="Draft or update a STRIDE threat model of the project",
+        description=(
+            "Combine the knowledge graph, entry points, data-flow findings and "
+            "dependencies into a STRIDE threat model. Running it again updates "
+            "the document and keeps edited Status and Mitigation lines."
+        ),
+    )
+    threat_model_parser.add_argument(
+        "--output",
+        "-o",
+        type=Path,
+        default=None,
+        help="Threat model document (default: docs/threat-model.md)",
+    )
+    threat_model_parser.add_argument(
+        "--check",
+        action="store_true",
+        help="Write nothing; exit 1 if the document is out of date",
+    )
+    threat_model_parser.add_argument(
+        "--rebuild",
+        action="store_true",
+        help="Rescan every file instead of only those changed since the last run",
+    )
+    threat_model_parser.add_argument(
+        "--json", action="store_true", help="Print the threats as JSON"
+    )
+
     # Import the command function
     from ..commands.analyze import analyze_command
 
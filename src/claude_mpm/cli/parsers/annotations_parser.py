@@ -0,0 +1,66 @@
+"""
+Annotations command parser for claude-mpm CLI.
+
+WHY: Review comments on a session's events are stored with the project in
+.claude-mpm/annotations/. This parser lets users add them from the terminal,
+next to the dashboard's comment box, and list or remove them.
+"""
+
+import argparse
+
+
+def add_annotations_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the annotations subparser with add, list and remove.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured annotations subparser
+    """
+    annotations_parser = subparsers.add_parser(
+        "annotations",
+        help="Comment on session events and transcript lines",
+        description=(
+            "Attach review comments to events of a session. Comments are kept "
+            "in .claude-mpm/annotations/ and shown next to their events in the "
+            "dashboard and in session-report output."
+        ),
+    )
+    annotations_subparsers = annotations_parser.add_subparsers(
+        dest="annotations_command",
+        help="Annotations commands",
+        metavar="SUBCOMMAND",
+    )
+
+    add_parser = annotations_subparsers.add_parser(
+        "add", help="Comment on a transcript line or event"
+    )
+    add_parser.add_argument("session", help="Session id")
+    add_parser.add_argument("text", help="The comment")
+    target = add_parser.add_mutually_exclusive_group(required=True)
+    target.add_argument(
+        "--line",
+        type=int,
+        default=None,
+        help="Line number (from 1) of the session's transcript JSONL",
+    )
+    target.add_argument(
+        "--target",
+        default=None,
+        help="Event key, e.g. transcript:<uuid> or tool:<correlation_id>",
+    )
+    add_parser.add_argument(
+        "--author", default=None, help="Comment author (default: current user)"
+    )
+
+    list_parser = annotations_subparsers.add_parser("list", help="List comments")
+    list_parser.add_argument("session", nargs="?", help="Only this session")
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    remove_parser = annotations_subparsers.add_parser(
+        "remove", help="Delete a comment"
+    )
+    remove_parser.add_argument("id", help="Comment id from 'annotations list'")
+
+    return annotations_parser
@@ -0,0 +1,78 @@
+"""
+Artifacts command parser for claude-mpm CLI.
+
+WHY: Session outputs (reports, images, build outputs) live in the per-session
+artifact store under .claude-mpm/artifacts/. This parser lets agents and
+users add files to it, find them again, and apply the retention policy.
+"""
+
+import argparse
+
+ARTIFACT_KINDS = ("report", "image", "build", "other")
+
+
+def add_artifacts_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the artifacts subparser with add, list, get and prune.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured artifacts subparser
+    """
+    artifacts_parser = subparsers.add_parser(
+        "artifacts",
+        help="Store and retrieve files produced by sessions",
+        description=(
+            "Keep reports, generated images and build outputs per session in "
+            ".claude-mpm/artifacts/ instead of /tmp. Artifacts are also listed, "
+            "with download links, in the dashboard's Artifacts tab."
+        ),
+    )
+    artifacts_subparsers = artifacts_parser.add_subparsers(
+        dest="artifacts_command", help="Artifacts commands", metavar="SUBCOMMAND"
+    )
+
+    add_parser = artifacts_subparsers.add_parser(
+        "add", help="Copy a file into the current session's artifacts"
+    )
+    add_parser.add_argument("path", help="File to store")
+    add_parser.add_argument(
+        "--session",
+        default=None,
+        help="Session id (default: $CLAUDE_SESSION_ID, else 'default')",
+    )
+    add_parser.add_argument("--name", default=None, help="Stored file name")
+    add_parser.add_argument(
+        "--kind",
+        choices=ARTIFACT_KINDS,
+        default=None,
+        help="Artifact kind (default: inferred from the extension)",
+    )
+    add_parser.add_argument(
+        "--description", default="", help="What the artifact is, for later readers"
+    )
+    add_parser.add_argument(
+        "--move", action="store_true", help="Move the file instead of copying it"
+    )
+
+    list_parser = artifacts_subparsers.add_parser("list", help="List artifacts")
+    list_parser.add_argument("--session", default=None, help="Only this session")
+    list_parser.add_argument(
+        "--kind", choices=ARTIFACT_KINDS, default=None, help="Only this kind"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    get_parser = artifacts_subparsers.add_parser(
+        "get", help="Show an artifact's path or copy it out"
+    )
+    get_parser.add_argument("id", help="Artifact id from 'artifacts list'")
+    get_parser.add_argument(
+        "--output", "-o", default=None, help="Copy the artifact to this path"
+    )
+
+    artifacts_subparsers.add_parser(
+        "prune", help="Remove old sessions' artifacts per the retention policy"
+    )
+
+    return artifacts_parser
@@ -161,6 +161,10 @@ def add_common_arguments(
             "--version", action="version", version=f"%(prog)s {enhanced_version}"
         )
 
+    # Subparsers leave -v/-q unset unless given, so their defaults don't
+    # overwrite the main parser's value in "claude-mpm -q <command>"
+    unset = {} if version is not None else {"default": argparse.SUPPRESS}
+
     # Logging arguments
     logging_group = parser.add_argument_group("logging options")
     logging_group.add_argument(
@@ -172,14 +176,16 @@ def add_common_arguments(
     logging_group.add_argument(
         "-v",
         "--verbose",
-        action="store_true",
-        help="Enable verbose logging (deprecated, use --logging INFO)",
+        action="count",
+        help="More detail and INFO logging; -vv adds DEBUG logging",
+        **({"default": 0} | unset),
     )
     logging_group.add_argument(
         "-q",
         "--quiet",
         action="store_true",
-        help="Suppress all output except errors (deprecated, use --logging ERROR)",
+        help="Print only errors and the result (IDs, paths), for scripts",
+        **unset,
     )
     logging_group.add_argument(
         "--logging",
@@ -422,6 +428,30 @@ def add_top_level_run_arguments(parser: argparse.ArgumentParser) -> None:
         help="Path to a file whose contents replace INSTRUCTIONS.md for this session "
         "(env: CLAUDE_MPM_INSTRUCTIONS_OVERRIDE)",
     )
+    run_group.add_argument(
+        "--env",
+        action="append",
+        dest="session_env",
+        metavar="KEY=VALUE",
+        help="Set an environment variable for this session only "
+        "(repeatable; stored with the session and restored by --mpm-resume)",
+    )
+    run_group.add_argument(
+        "--label",
+        action="append",
+        dest="session_labels",
+        metavar="LABEL",
+        help="Label this session, e.g. bugfix or customer-x (repeatable or "
+        "comma-separated; see 'claude-mpm labels')",
+    )
+    run_group.add_argument(
+        "--cwd",
+        type=str,
+        default=None,
+        metavar="PATH",
+        help="Run the session in PATH, relative to the current directory "
+        "(stored with the session and restored by --mpm-resume)",
+    )
 
     # Dependency checking options (for backward compatibility at top level)
     dep_group_top = parser.add_argument_group(
@@ -511,9 +541,10 @@ def create_parser(
 
     # Import and add core subparsers one by one to avoid issues
     try:
-        from .run_parser import add_run_subparser
+        from .run_parser import add_run_subparser, add_start_subparser
 
         add_run_subparser(subparsers)
+        add_start_subparser(subparsers)
     except ImportError:
         pass
 
@@ -804,6 +835,262 @@ def create_parser(
     except ImportError:
         pass
 
+    # Add daemon command parser (shared user-level daemon)
+    try:
+        from .daemon_parser import add_daemon_subparser
+
+        add_daemon_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add selftest command parser (end-to-end checks with mock backend)
+    try:
+        from .selftest_parser import add_selftest_subparser
+
+        add_selftest_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add cache-proxy command parser (response caching for development)
+    try:
+        from .cache_proxy_parser import add_cache_proxy_subparser
+
+        add_cache_proxy_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add playground command parser (prompt engineering REPL)
+    try:
+        from .playground_parser import add_playground_subparser
+
+        add_playground_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add eval command parser (agent evaluation suites)
+    try:
+        from .eval_parser import add_eval_subparser
+
+        add_eval_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add golden command parser (golden transcript regression tests)
+    try:
+        from .golden_parser import add_golden_subparser
+
+        add_golden_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add graph command parser (project knowledge graph)
+    try:
+        from .graph_parser import add_graph_subparser
+
+        add_graph_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add logs command parser (log sources for debugging agents)
+    try:
+        from .logs_parser import add_logs_subparser
+
+        add_logs_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add artifacts command parser (per-session artifact store)
+    try:
+        from .artifacts_parser import add_artifacts_subparser
+
+        add_artifacts_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add grep-output command parser (search indexed session output)
+    try:
+        from .grep_output_parser import add_grep_output_subparser
+
+        add_grep_output_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add storage command parser (disk usage and retention)
+    try:
+        from .storage_parser import add_storage_subparser
+
+        add_storage_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add trust command parser (workspace trust decisions)
+    try:
+        from .trust_parser import add_trust_subparser
+
+        add_trust_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add integrity command parser (deployed agent/skill verification)
+    try:
+        from .integrity_parser import add_integrity_subparser
+
+        add_integrity_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add batch command parser (Kubernetes batch agent runs)
+    try:
+        from .batch_parser import add_batch_subparser
+
+        add_batch_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add work-queue command parser (distributed task queue)
+    try:
+        from .work_queue_parser import add_work_queue_subparser
+
+        add_work_queue_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add status command parser (project summary of what claude-mpm manages)
+    try:
+        from .status_parser import add_status_subparser
+
+        add_status_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add canary command parser (staged rollout of agent/instruction changes)
+    try:
+        from .canary_parser import add_canary_subparser
+
+        add_canary_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add flags command parser (opt-in to preview features)
+    try:
+        from .flags_parser import add_flags_subparser
+
+        add_flags_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add tasks command parser (detached long-running commands)
+    try:
+        from .tasks_parser import add_tasks_subparser
+
+        add_tasks_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add patch command parser (fuzz-tolerant diff application)
+    try:
+        from .patch_parser import add_patch_subparser
+
+        add_patch_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add post-edit command parser (formatters run on edited files)
+    try:
+        from .post_edit_parser import add_post_edit_subparser
+
+        add_post_edit_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add resolve-conflicts command parser (agent conflict resolution)
+    try:
+        from .resolve_conflicts_parser import add_resolve_conflicts_subparser
+
+        add_resolve_conflicts_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add owners command parser (code ownership lookup)
+    try:
+        from .owners_parser import add_owners_subparser
+
+        add_owners_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add advisories command parser (security advisories for pinned deps)
+    try:
+        from .advisories_parser import add_advisories_subparser
+
+        add_advisories_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add annotations command parser (review comments on session events)
+    try:
+        from .annotations_parser import add_annotations_subparser
+
+        add_annotations_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add checklist command parser (per-project onboarding checklist)
+    try:
+        from .checklist_parser import add_checklist_subparser
+
+        add_checklist_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add adoption command parser (opt-in team adoption metrics)
+    try:
+        from .adoption_parser import add_adoption_subparser
+
+        add_adoption_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add labels command parser (session labels and label taxonomy)
+    try:
+        from .labels_parser import add_labels_subparser
+
+        add_labels_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add view command parser (saved dashboard views)
+    try:
+        from .view_parser import add_view_subparser
+
+        add_view_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add export command parser (session analytics for BI tools)
+    try:
+        from .export_parser import add_export_subparser
+
+        add_export_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add routing command parser (task-type routing and A/B agent splits)
+    try:
+        from .routing_parser import add_routing_subparser
+
+        add_routing_subparser(subparsers)
+    except ImportError:
+        pass
+
+    # Add templates command parser (conversation templates for start)
+    try:
+        from .templates_parser import add_templates_subparser
+
+        add_templates_subparser(subparsers)
+    except ImportError:
+        pass
+
     # Import and add additional command parsers from commands module
     try:
         from ..commands.aggregate import add_aggregate_parser
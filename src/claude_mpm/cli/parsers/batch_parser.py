@@ -0,0 +1,97 @@
+"""
+Batch command parser for claude-mpm CLI.
+
+WHY: Large batch jobs (one agent run per repository, hundreds of
+repositories) are scheduled as Kubernetes Jobs. This parser exposes
+starting a batch, following it, and cancelling it.
+"""
+
+import argparse
+from pathlib import Path
+
+from ..watch import add_watch_argument
+
+
+def add_batch_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the batch subparser with run, status, resume and cancel.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured batch subparser
+    """
+    batch_parser = subparsers.add_parser(
+        "batch",
+        help="Run non-interactive agent runs as Kubernetes Jobs",
+        description=(
+            "Schedule agent runs from a JSON Lines file as Kubernetes Jobs, "
+            "one Job per run, and collect their results into the event log "
+            "and .claude-mpm/batches/. Cluster defaults come from the "
+            "'kubernetes' section of the configuration."
+        ),
+    )
+    batch_subparsers = batch_parser.add_subparsers(
+        dest="batch_command", help="Batch commands", metavar="SUBCOMMAND"
+    )
+
+    run_parser = batch_subparsers.add_parser(
+        "run", help="Submit the runs in a file and wait for them to finish"
+    )
+    run_parser.add_argument(
+        "runs_file",
+        type=Path,
+        help="JSON Lines file with one run per line (prompt, repo, ref, ...)",
+    )
+    run_parser.add_argument("--batch-id", help="Name for the batch (default: time)")
+    run_parser.add_argument("--namespace", help="Kubernetes namespace")
+    run_parser.add_argument("--context", help="kubectl context")
+    run_parser.add_argument("--image", help="Container image for the runs")
+    run_parser.add_argument(
+        "--parallelism", type=int, help="Jobs active at once (default: 10)"
+    )
+    run_parser.add_argument("--cpu", help="Default CPU request and limit per run")
+    run_parser.add_argument(
+        "--memory", help="Default memory request and limit per run"
+    )
+    run_parser.add_argument(
+        "--detach",
+        action="store_true",
+        help="Submit the first Jobs and exit; continue with 'batch resume'",
+    )
+    run_parser.add_argument(
+        "--dry-run",
+        action="store_true",
+        help="Print the Job manifests instead of submitting them",
+    )
+    run_parser.add_argument(
+        "--poll-interval",
+        type=float,
+        default=15.0,
+        help="Seconds between checks on the Jobs (default: 15)",
+    )
+
+    status_parser = batch_subparsers.add_parser(
+        "status", help="List batches, or show the runs of one batch"
+    )
+    status_parser.add_argument("batch_id", nargs="?", help="Batch to show")
+    status_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_watch_argument(status_parser)
+
+    resume_parser = batch_subparsers.add_parser(
+        "resume", help="Continue driving a batch until every run has finished"
+    )
+    resume_parser.add_argument("batch_id", help="Batch to resume")
+    resume_parser.add_argument(
+        "--poll-interval",
+        type=float,
+        default=15.0,
+        help="Seconds between checks on the Jobs (default: 15)",
+    )
+
+    cancel_parser = batch_subparsers.add_parser(
+        "cancel", help="Delete a batch's Jobs and stop scheduling its runs"
+    )
+    cancel_parser.add_argument("batch_id", help="Batch to cancel")
+
+    return batch_parser
@@ -0,0 +1,84 @@
+"""
+Cache-proxy command parser for claude-mpm CLI.
+
+WHY: Iterating on agent instructions replays many identical model requests.
+The caching proxy records them once and serves them back, so this parser
+exposes starting the proxy and inspecting or clearing its cache.
+"""
+
+import argparse
+from pathlib import Path
+
+
+def add_cache_proxy_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the cache-proxy subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured cache-proxy subparser
+    """
+    from ...services.model.caching_proxy import (
+        DEFAULT_PORT,
+        DEFAULT_UPSTREAM,
+        PROXY_MODES,
+    )
+
+    proxy_parser = subparsers.add_parser(
+        "cache-proxy",
+        help="Local proxy that caches model API responses for development",
+        description=(
+            "Run a local proxy between Claude Code and the model API that records "
+            "request/response pairs and serves them back. Point Claude Code at it "
+            "with ANTHROPIC_BASE_URL."
+        ),
+    )
+    proxy_parser.add_argument(
+        "--cache-dir",
+        type=Path,
+        default=None,
+        help="Cache directory (default: .claude-mpm/cache/responses)",
+    )
+
+    proxy_subparsers = proxy_parser.add_subparsers(
+        dest="proxy_command", help="Cache-proxy commands", metavar="SUBCOMMAND"
+    )
+
+    start_parser = proxy_subparsers.add_parser(
+        "start", help="Run the proxy in the foreground until interrupted"
+    )
+    start_parser.add_argument(
+        "--mode",
+        choices=PROXY_MODES,
+        default="auto",
+        help=(
+            "auto: serve hits, record misses; record: always forward and "
+            "overwrite; replay: cache only, never call the API (default: auto)"
+        ),
+    )
+    start_parser.add_argument(
+        "--host", default="127.0.0.1", help="Interface to bind (default: 127.0.0.1)"
+    )
+    start_parser.add_argument(
+        "--port",
+        type=int,
+        default=DEFAULT_PORT,
+        help=f"Port to listen on (default: {DEFAULT_PORT})",
+    )
+    start_parser.add_argument(
+        "--upstream",
+        default=DEFAULT_UPSTREAM,
+        help=f"Model API base URL (default: {DEFAULT_UPSTREAM})",
+    )
+
+    stats_parser = proxy_subparsers.add_parser(
+        "stats", help="Show cached entry count and size"
+    )
+    stats_parser.add_argument(
+        "--json", action="store_true", help="Output stats as JSON"
+    )
+
+    proxy_subparsers.add_parser("clear", help="Delete all cached responses")
+
+    return proxy_parser
@@ -0,0 +1,82 @@
+"""
+Canary command parser for claude-mpm CLI.
+
+WHY: Rolls a new agent or PM instruction version out to a share of sessions
+or to chosen projects first, compares its sessions with the stable
+version's, and promotes or stops it.
+"""
+
+import argparse
+
+
+def _percent(value: str) -> int:
+    try:
+        number = int(value)
+    except ValueError:
+        raise argparse.ArgumentTypeError(f"expected a number, got '{value}'") from None
+    if not 0 <= number <= 100:
+        raise argparse.ArgumentTypeError(f"must be 0-100, got {number}")
+    return number
+
+
+def add_canary_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the canary subparser with start, list, status, promote and stop.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured canary subparser
+    """
+    canary_parser = subparsers.add_parser(
+        "canary",
+        help="Roll out agent or instruction changes to some sessions first",
+        description=(
+            "Give a candidate version of an agent (or, named 'instructions', "
+            "of the PM instructions) to a percentage of new sessions or to "
+            "specific projects, compare their outcomes with the stable "
+            "version's, then promote it to every session or stop it."
+        ),
+    )
+    canary_subparsers = canary_parser.add_subparsers(
+        dest="canary_command", help="Canary commands", metavar="SUBCOMMAND"
+    )
+
+    start_parser = canary_subparsers.add_parser(
+        "start", help="Start rolling out a candidate version"
+    )
+    start_parser.add_argument(
+        "name", help="Agent name, or 'instructions' for the PM instructions"
+    )
+    start_parser.add_argument("file", help="The candidate version")
+    start_parser.add_argument(
+        "--percent",
+        type=_percent,
+        default=None,
+        help="Share of new sessions that get the candidate "
+        "(default: 10, or 0 with --project)",
+    )
+    start_parser.add_argument(
+        "--project",
+        action="append",
+        metavar="DIR",
+        help="Project whose sessions always get the candidate (repeatable)",
+    )
+
+    list_parser = canary_subparsers.add_parser("list", help="List canaries")
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    status_parser = canary_subparsers.add_parser(
+        "status", help="Compare the candidate's sessions with the stable version's"
+    )
+    status_parser.add_argument("name", help="Agent name or 'instructions'")
+    status_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    for name, help_text in (
+        ("promote", "Give every new session the candidate"),
+        ("stop", "Return every new session to the stable version"),
+    ):
+        action_parser = canary_subparsers.add_parser(name, help=help_text)
+        action_parser.add_argument("name", help="Agent name or 'instructions'")
+
+    return canary_parser
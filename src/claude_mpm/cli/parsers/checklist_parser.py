@@ -0,0 +1,66 @@
+"""
+Checklist command parser for claude-mpm CLI.
+
+WHY: Platform teams define an onboarding checklist per repository in
+.claude-mpm/checklist.yaml. This parser lets them check it for one or many
+repositories, start one from the default steps and tick off manual steps.
+"""
+
+import argparse
+
+
+def add_checklist_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the checklist subparser with status, init, done and undo.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured checklist subparser
+    """
+    checklist_parser = subparsers.add_parser(
+        "checklist",
+        help="Track a project's claude-mpm onboarding checklist",
+        description=(
+            "Check a repository against its onboarding steps (agents deployed, "
+            "hooks installed, CI wired up, configuration set, manual steps). "
+            "Steps come from .claude-mpm/checklist.yaml, or default steps "
+            "without one."
+        ),
+    )
+    checklist_subparsers = checklist_parser.add_subparsers(
+        dest="checklist_command", help="Checklist commands", metavar="SUBCOMMAND"
+    )
+
+    status_parser = checklist_subparsers.add_parser(
+        "status", help="Show which steps are done"
+    )
+    status_parser.add_argument(
+        "projects",
+        nargs="*",
+        metavar="PROJECT",
+        help="Repositories to check (default: the current directory)",
+    )
+    status_parser.add_argument("--json", action="store_true", help="Output JSON")
+    status_parser.add_argument(
+        "--strict",
+        action="store_true",
+        help="Exit with status 1 unless every step is done (for CI)",
+    )
+
+    checklist_subparsers.add_parser(
+        "init", help="Write the default steps to .claude-mpm/checklist.yaml"
+    )
+
+    done_parser = checklist_subparsers.add_parser(
+        "done", help="Mark a manual step as done"
+    )
+    done_parser.add_argument("step", help="Step id")
+    done_parser.add_argument("--note", default="", help="Why or how it was done")
+
+    undo_parser = checklist_subparsers.add_parser(
+        "undo", help="Mark a manual step as not done"
+    )
+    undo_parser.add_argument("step", help="Step id")
+
+    return checklist_parser
@@ -40,6 +40,7 @@ Available commands:
   view        View current configuration settings
   validate    Validate configuration files
   status      Show configuration health and status
+  reload      Apply configuration changes to the running daemon service
 
 Running 'config' with no subcommand defaults to 'auto' in preview mode.
 """,
@@ -217,4 +218,24 @@ The command provides safety features including:
         help="Specific config file to check (default: all)",
     )
 
+    # Reload subcommand
+    reload_parser = config_subparsers.add_parser(
+        "reload",
+        help="Apply configuration changes to the running daemon service",
+        description=(
+            "Signal the foreground daemon service ('daemon run') to read its "
+            "configuration again (same as sending it SIGHUP). Components, "
+            "projects and the check interval change in place; running "
+            "sessions are kept."
+        ),
+    )
+    add_common_arguments(reload_parser)
+    reload_parser.add_argument(
+        "--timeout",
+        type=float,
+        default=10.0,
+        metavar="SECONDS",
+        help="How long to wait for the service to reload (default: 10)",
+    )
+
     return config_parser
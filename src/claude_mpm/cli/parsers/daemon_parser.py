@@ -0,0 +1,108 @@
+"""
+Daemon command parser for claude-mpm CLI.
+
+WHY: This module contains all arguments for the shared user-level daemon that
+hosts the event servers, scheduler, agent watchers and Commander for every
+project on the machine.
+
+DESIGN DECISION: Like ``serve``, the daemon is global (not CWD-relative); only
+``attach`` / ``detach`` act on the current project. ``daemon --config DIR`` is
+shorthand for ``daemon run --config DIR``, the foreground service mode used by
+the container image.
+"""
+
+import argparse
+from pathlib import Path
+
+from ..watch import add_watch_argument
+
+
+def add_daemon_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the daemon subparser with lifecycle and project commands.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured daemon subparser
+    """
+    daemon_parser = subparsers.add_parser(
+        "daemon",
+        help="Manage the shared user-level claude-mpm daemon",
+        description=(
+            "One background daemon per user hosts the event server and session "
+            "runner for all projects. Projects attach to it instead of starting "
+            "their own servers."
+        ),
+    )
+
+    daemon_parser.add_argument(
+        "--config",
+        type=Path,
+        default=None,
+        dest="service_config",
+        metavar="DIR",
+        help=(
+            "Run in the foreground as a service, reading DIR/configuration.yaml "
+            "(same as 'daemon run --config DIR')"
+        ),
+    )
+
+    daemon_subparsers = daemon_parser.add_subparsers(
+        dest="daemon_command", help="Daemon commands", metavar="SUBCOMMAND"
+    )
+
+    start_parser = daemon_subparsers.add_parser(
+        "start",
+        help="Start the shared daemon and attach the current project",
+    )
+    start_parser.add_argument(
+        "--component",
+        action="append",
+        dest="components",
+        metavar="NAME",
+        help="Only start this component (repeatable; default: all)",
+    )
+    start_parser.add_argument(
+        "--no-attach",
+        action="store_true",
+        help="Do not attach the current project",
+    )
+
+    daemon_subparsers.add_parser("stop", help="Stop the shared daemon")
+
+    run_parser = daemon_subparsers.add_parser(
+        "run",
+        help="Run in the foreground with a health endpoint (containers, systemd)",
+    )
+    run_parser.add_argument(
+        "--config",
+        type=Path,
+        default=None,
+        dest="service_config",
+        metavar="DIR",
+        help="Directory containing configuration.yaml (daemon section)",
+    )
+
+    status_parser = daemon_subparsers.add_parser(
+        "status", help="Show daemon components and attached projects"
+    )
+    status_parser.add_argument(
+        "--json", action="store_true", help="Output status as JSON"
+    )
+    add_watch_argument(status_parser)
+
+    for name, help_text in (
+        ("attach", "Attach a project to the shared daemon"),
+        ("detach", "Detach a project from the shared daemon"),
+    ):
+        project_parser = daemon_subparsers.add_parser(name, help=help_text)
+        project_parser.add_argument(
+            "project",
+            nargs="?",
+            type=Path,
+            default=None,
+            help="Project directory (default: current directory)",
+        )
+
+    return daemon_parser
@@ -46,8 +46,8 @@ def add_dashboard_subparser(subparsers) -> argparse.ArgumentParser:
     start_dashboard_parser.add_argument(
         "--port",
         type=int,
-        default=8765,
-        help="Port to start dashboard on (default: 8765)",
+        default=None,
+        help="Port to start dashboard on (default: auto-select a free port)",
     )
     start_dashboard_parser.add_argument(
         "--host",
@@ -73,8 +73,8 @@ def add_dashboard_subparser(subparsers) -> argparse.ArgumentParser:
     stop_dashboard_parser.add_argument(
         "--port",
         type=int,
-        default=8765,
-        help="Port of dashboard to stop (default: 8765)",
+        default=None,
+        help="Port of dashboard to stop (default: this project's dashboard)",
     )
     stop_dashboard_parser.add_argument(
         "--all",
@@ -106,8 +106,54 @@ def add_dashboard_subparser(subparsers) -> argparse.ArgumentParser:
     open_dashboard_parser.add_argument(
         "--port",
         type=int,
-        default=8765,
-        help="Port of dashboard to open (default: 8765)",
+        default=None,
+        help="Port of dashboard to open (default: this project's dashboard)",
+    )
+
+    # List dashboards running for all local projects
+    list_dashboard_parser = dashboard_subparsers.add_parser(
+        DashboardCommands.LIST.value,
+        help="List dashboard servers running for all local projects",
+    )
+    list_dashboard_parser.add_argument(
+        "--json",
+        action="store_true",
+        help="Output as JSON",
+    )
+
+    # Read-only observer links for one session
+    share_dashboard_parser = dashboard_subparsers.add_parser(
+        DashboardCommands.SHARE.value,
+        help="Create a read-only observer link to a session",
+        description=(
+            "Create a link that streams one session's events live to another "
+            "person's browser, without access to anything else in the "
+            "dashboard. The token is shown once."
+        ),
+    )
+    share_dashboard_parser.add_argument(
+        "session_id", nargs="?", help="Session to share (as shown in the dashboard)"
+    )
+    share_dashboard_parser.add_argument(
+        "--hours",
+        type=float,
+        default=8.0,
+        help="Hours until the link expires (default: 8)",
+    )
+    share_dashboard_parser.add_argument(
+        "--label", default="", help="Name shown to the observer"
+    )
+    share_dashboard_parser.add_argument(
+        "--port",
+        type=int,
+        default=None,
+        help="Port of the dashboard to link to (default: this project's dashboard)",
+    )
+    share_dashboard_parser.add_argument(
+        "--list", action="store_true", help="List unexpired observer links"
+    )
+    share_dashboard_parser.add_argument(
+        "--revoke", metavar="LINK_ID", help="Revoke a link and disconnect its viewers"
     )
 
     return dashboard_parser
@@ -6,6 +6,7 @@ providing developer-focused commands for monitoring and analysis.
 """
 
 import argparse
+from pathlib import Path
 
 from .base_parser import add_common_arguments
 
@@ -59,6 +60,9 @@ def add_debug_subparser(subparsers) -> argparse.ArgumentParser:
     # Performance debugging
     _add_performance_parser(debug_subparsers)
 
+    # Bug report bundle
+    _add_bundle_parser(debug_subparsers)
+
     return debug_parser
 
 
@@ -317,3 +321,34 @@ def _add_performance_parser(subparsers):
     perf_group.add_argument(
         "--benchmark", action="store_true", help="Run performance benchmarks"
     )
+
+
+def _add_bundle_parser(subparsers):
+    """Add bug report bundle subcommand."""
+    bundle_parser = subparsers.add_parser(
+        "bundle",
+        help="Create a redacted diagnostics archive for bug reports",
+        description=(
+            "Gather logs, redacted configuration, environment info, recent events "
+            "and the last failing command's traceback into a single zip file."
+        ),
+    )
+    bundle_parser.add_argument(
+        "--output",
+        "-o",
+        type=Path,
+        metavar="PATH",
+        help="Archive path or directory (default: ./claude-mpm-debug-<timestamp>.zip)",
+    )
+    bundle_parser.add_argument(
+        "--log-lines",
+        type=int,
+        default=500,
+        help="Lines to keep from the end of each log file (default: 500)",
+    )
+    bundle_parser.add_argument(
+        "--max-events",
+        type=int,
+        default=200,
+        help="Most recent events to include (default: 200)",
+    )
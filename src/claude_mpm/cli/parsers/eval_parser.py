@@ -0,0 +1,86 @@
+"""
+Eval command parser for claude-mpm CLI.
+
+WHY: Agent prompt changes need a regression gate. This parser exposes
+running evaluation suites against an agent and browsing the scoring history.
+"""
+
+import argparse
+from pathlib import Path
+
+
+def add_eval_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the eval subparser with run and history commands.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured eval subparser
+    """
+    eval_parser = subparsers.add_parser(
+        "eval",
+        help="Run agent evaluation suites and track scores",
+        description=(
+            "Run YAML evaluation suites (contains, regex, file-created, tests-pass "
+            "and LLM-graded rubric assertions) against an agent and compare the "
+            "score with previous runs to catch regressions."
+        ),
+    )
+    eval_subparsers = eval_parser.add_subparsers(
+        dest="eval_command", help="Eval commands", metavar="SUBCOMMAND"
+    )
+
+    run_parser = eval_subparsers.add_parser("run", help="Run an evaluation suite")
+    run_parser.add_argument(
+        "--suite",
+        required=True,
+        type=Path,
+        help="Suite file (e.g. evals/engineer.yaml)",
+    )
+    run_parser.add_argument(
+        "--agent", default=None, help="Agent to evaluate (default: the suite's agent)"
+    )
+    run_parser.add_argument(
+        "--model", default=None, help="Model override (default: the suite's model)"
+    )
+    run_parser.add_argument(
+        "--replay",
+        type=Path,
+        default=None,
+        metavar="RECORDING",
+        help="Answer from a recorded session instead of calling the model",
+    )
+    run_parser.add_argument(
+        "--min-score",
+        type=float,
+        default=None,
+        help="Fail if the suite score (0-1) is below this value",
+    )
+    run_parser.add_argument(
+        "--no-history",
+        action="store_true",
+        help="Do not record this run in the scoring history",
+    )
+    run_parser.add_argument(
+        "--keep-workspaces",
+        action="store_true",
+        help="Keep each case's workspace for inspection",
+    )
+    run_parser.add_argument(
+        "--json", action="store_true", help="Output results as JSON"
+    )
+
+    history_parser = eval_subparsers.add_parser(
+        "history", help="Show recorded scores per agent version"
+    )
+    history_parser.add_argument("--suite", default=None, help="Only this suite name")
+    history_parser.add_argument("--agent", default=None, help="Only this agent")
+    history_parser.add_argument(
+        "--limit", type=int, default=20, help="Most recent runs to show (default: 20)"
+    )
+    history_parser.add_argument(
+        "--json", action="store_true", help="Output history as JSON"
+    )
+
+    return eval_parser
@@ -0,0 +1,90 @@
+"""
+Export command parser for claude-mpm CLI.
+
+WHY: Teams build their own reporting in Metabase, Looker or a warehouse.
+This parser exposes ``export analytics``, which writes sessions, events,
+costs and outcomes as CSV or Parquet tables those tools can load.
+"""
+
+import argparse
+
+from ...services.analytics_export import (
+    DEFAULT_OUTPUT,
+    DEFAULT_SINCE,
+    FORMATS,
+    TABLES,
+)
+
+
+def add_export_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the export subparser with the analytics subcommand.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured export subparser
+    """
+    export_parser = subparsers.add_parser(
+        "export",
+        help="Export session data for BI and reporting tools",
+        description=(
+            "Write session data as flat tables for tools such as Metabase or "
+            "Looker. Prompt and response text is left out unless "
+            "--include-text is given."
+        ),
+    )
+    export_subparsers = export_parser.add_subparsers(
+        dest="export_command", help="Export commands", metavar="SUBCOMMAND"
+    )
+
+    analytics_parser = export_subparsers.add_parser(
+        "analytics",
+        help="Export sessions, events, costs and outcomes as CSV or Parquet",
+        description=(
+            "Write one file per table (sessions, events, costs, outcomes) from "
+            "the project's Claude Code transcripts, session labels and "
+            "adoption records. Tables join on session_id and project."
+        ),
+    )
+    analytics_parser.add_argument(
+        "--format",
+        choices=FORMATS,
+        default="csv",
+        help="File format (default: csv; parquet needs pyarrow)",
+    )
+    analytics_parser.add_argument(
+        "--since",
+        default=DEFAULT_SINCE,
+        help=(
+            f"Sessions active since a duration ago (30d, 12h) or an ISO date "
+            f"(default: {DEFAULT_SINCE})"
+        ),
+    )
+    analytics_parser.add_argument(
+        "--output",
+        "-o",
+        default=DEFAULT_OUTPUT,
+        metavar="DIR",
+        help=f"Directory to write the tables to (default: ./{DEFAULT_OUTPUT})",
+    )
+    analytics_parser.add_argument(
+        "--project",
+        action="append",
+        metavar="DIR",
+        help="Project to export (repeatable; default: the current directory)",
+    )
+    analytics_parser.add_argument(
+        "--table",
+        action="append",
+        choices=list(TABLES),
+        help="Only export this table (repeatable; default: all)",
+    )
+    analytics_parser.add_argument(
+        "--include-text",
+        action="store_true",
+        help="Include session titles and event summaries",
+    )
+    analytics_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return export_parser
@@ -0,0 +1,56 @@
+"""
+Flags command parser for claude-mpm CLI.
+
+WHY: Preview features are switched on by feature flags; this parser lets
+users see which flags exist and opt in or out per user or per project.
+"""
+
+import argparse
+
+from ...utils.table_view import add_table_arguments
+from ..list_columns import FLAG_COLUMNS
+
+
+def add_flags_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the flags subparser with list, enable and disable.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured flags subparser
+    """
+    flags_parser = subparsers.add_parser(
+        "flags",
+        help="List and toggle feature flags for preview features",
+        description=(
+            "Feature flags switch preview features on. A flag's value comes "
+            "from CLAUDE_MPM_FLAG_<NAME>, then the project's choice, then "
+            "yours, then your organization's flag config "
+            "(feature_flags.org_url); flags the organization locks cannot be "
+            "changed."
+        ),
+    )
+    flags_subparsers = flags_parser.add_subparsers(
+        dest="flags_command", help="Flags commands", metavar="SUBCOMMAND"
+    )
+
+    list_parser = flags_subparsers.add_parser(
+        "list", help="Show each flag, whether it is on and what set it"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_table_arguments(list_parser, FLAG_COLUMNS)
+
+    for name, help_text in (
+        ("enable", "Turn a flag on"),
+        ("disable", "Turn a flag off"),
+    ):
+        sub = flags_subparsers.add_parser(name, help=help_text)
+        sub.add_argument("flag", help="Flag name (see 'claude-mpm flags list')")
+        sub.add_argument(
+            "--project",
+            action="store_true",
+            help="Set it for this project only (.claude-mpm/feature-flags.json)",
+        )
+
+    return flags_parser
@@ -0,0 +1,93 @@
+"""
+Golden command parser for claude-mpm CLI.
+
+WHY: Upgrades can change how agents work without changing what eval suites
+check. This parser exposes recording a known-good session as a golden
+transcript and re-running it to diff the tool-call sequence.
+"""
+
+import argparse
+
+
+def add_golden_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the golden subparser with record, run, list and show commands.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured golden subparser
+    """
+    golden_parser = subparsers.add_parser(
+        "golden",
+        help="Record sessions as golden transcripts and replay them for regressions",
+        description=(
+            "Record a known-good session as a golden transcript, then re-run its "
+            "prompts after framework or agent upgrades and diff the tool calls "
+            "and replies against the recording."
+        ),
+    )
+    golden_subparsers = golden_parser.add_subparsers(
+        dest="golden_command", help="Golden commands", metavar="SUBCOMMAND"
+    )
+
+    record_parser = golden_subparsers.add_parser(
+        "record", help="Save a session as a golden transcript"
+    )
+    record_parser.add_argument("name", help="Golden name (e.g. add-login-endpoint)")
+    record_parser.add_argument(
+        "--session",
+        default=None,
+        metavar="PATH|SESSION_ID",
+        help="Transcript file or session id (default: most recent session here)",
+    )
+    record_parser.add_argument(
+        "--force", action="store_true", help="Overwrite an existing golden"
+    )
+
+    run_parser = golden_subparsers.add_parser(
+        "run", help="Replay golden transcripts and diff the behaviour"
+    )
+    run_parser.add_argument(
+        "names", nargs="*", help="Goldens to run (default: all recorded goldens)"
+    )
+    run_parser.add_argument(
+        "--against",
+        default=None,
+        metavar="PATH|SESSION_ID",
+        help="Diff an existing session instead of replaying (single golden only)",
+    )
+    run_parser.add_argument("--model", default=None, help="Model for the replay")
+    run_parser.add_argument(
+        "--permission-mode",
+        default="acceptEdits",
+        choices=["default", "acceptEdits", "bypassPermissions", "plan"],
+        help="Permission mode for the replay (default: acceptEdits)",
+    )
+    run_parser.add_argument(
+        "--strict",
+        action="store_true",
+        help="Also compare each tool call's file path, command or pattern",
+    )
+    run_parser.add_argument(
+        "--in-place",
+        action="store_true",
+        help="Replay in the project itself instead of a temporary copy",
+    )
+    run_parser.add_argument(
+        "--keep-workspace",
+        action="store_true",
+        help="Keep the temporary copy the replay ran in",
+    )
+    run_parser.add_argument(
+        "--json", action="store_true", help="Output diffs as JSON"
+    )
+
+    golden_subparsers.add_parser("list", help="List recorded golden transcripts")
+
+    show_parser = golden_subparsers.add_parser(
+        "show", help="Show the prompts and tool calls of a golden"
+    )
+    show_parser.add_argument("name", help="Golden name")
+
+    return golden_parser
@@ -0,0 +1,75 @@
+"""
+Graph command parser for claude-mpm CLI.
+
+WHY: The project knowledge graph is built lazily by its MCP server and the
+dashboard. This parser exposes building it up front, folding in a session's
+edits, querying it from the terminal, and checking API contracts for drift.
+"""
+
+import argparse
+
+ENTITY_TYPES = ("service", "endpoint", "schema", "owner", "contract")
+
+
+def add_graph_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the graph subparser with build, update, query, show and contracts.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured graph subparser
+    """
+    graph_parser = subparsers.add_parser(
+        "graph",
+        help="Build and query the project knowledge graph",
+        description=(
+            "Extract services, endpoints, schemas and owners from the codebase "
+            "into .claude-mpm/knowledge_graph.json. Agents query the graph "
+            "through 'claude-mpm mcp serve knowledge-graph'."
+        ),
+    )
+    graph_subparsers = graph_parser.add_subparsers(
+        dest="graph_command", help="Graph commands", metavar="SUBCOMMAND"
+    )
+
+    graph_subparsers.add_parser("build", help="Rebuild the graph from the codebase")
+
+    update_parser = graph_subparsers.add_parser(
+        "update", help="Re-extract the files a session wrote or edited"
+    )
+    update_parser.add_argument(
+        "--session",
+        default=None,
+        metavar="PATH|SESSION_ID",
+        help="Transcript file or session id (default: most recent session here)",
+    )
+
+    query_parser = graph_subparsers.add_parser("query", help="Search entities")
+    query_parser.add_argument("text", nargs="?", default="", help="Text to match")
+    query_parser.add_argument(
+        "--type", choices=ENTITY_TYPES, default=None, help="Only this entity type"
+    )
+    query_parser.add_argument(
+        "--limit", type=int, default=50, help="Maximum results (default: 50)"
+    )
+    query_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    show_parser = graph_subparsers.add_parser(
+        "show", help="Show an entity and its relations"
+    )
+    show_parser.add_argument("id", help="Entity id (e.g. service:api)")
+    show_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    contracts_parser = graph_subparsers.add_parser(
+        "contracts",
+        help="Check OpenAPI specs and .proto files against the code",
+        description=(
+            "Link OpenAPI operations and protobuf RPCs to the handlers that "
+            "implement them and report drift. Exits 1 when the code and a "
+            "contract disagree."
+        ),
+    )
+    contracts_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return graph_parser
@@ -0,0 +1,73 @@
+"""
+Grep-output command parser for claude-mpm CLI.
+
+WHY: Finding where a value first appeared in a session means searching tool
+output buried in JSONL transcripts. This parser exposes a ripgrep-style
+search over the indexed output of this project's sessions.
+"""
+
+import argparse
+
+RECORD_KINDS = ("tool_result", "tool_input", "assistant", "user")
+
+
+def add_grep_output_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the grep-output subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured grep-output subparser
+    """
+    parser = subparsers.add_parser(
+        "grep-output",
+        help="Search tool output and messages from this project's sessions",
+        description=(
+            "Search the captured terminal output, tool results, tool inputs and "
+            "messages of this project's Claude Code sessions (subagents "
+            "included). Transcripts are indexed into .claude-mpm/output_index.db "
+            "on first use and incrementally afterwards (in memory only when "
+            "transcripts are encrypted at rest)."
+        ),
+    )
+    parser.add_argument("pattern", help="Regular expression (Python syntax)")
+    parser.add_argument(
+        "--session", "-s", default=None, help="Session id or id prefix (default: all)"
+    )
+    parser.add_argument(
+        "--ignore-case", "-i", action="store_true", help="Case-insensitive match"
+    )
+    parser.add_argument(
+        "--fixed-strings",
+        "-F",
+        action="store_true",
+        help="Treat the pattern as a literal string",
+    )
+    parser.add_argument(
+        "--kind",
+        action="append",
+        choices=RECORD_KINDS,
+        default=None,
+        help="Only these record kinds (repeatable; default: all)",
+    )
+    parser.add_argument(
+        "--tool", default=None, help="Only records of this tool, e.g. Bash"
+    )
+    parser.add_argument(
+        "--context", "-C", type=int, default=0, metavar="N", help="Lines of context"
+    )
+    parser.add_argument(
+        "--max-count",
+        "-m",
+        type=int,
+        default=None,
+        metavar="N",
+        help="Stop after N matching records",
+    )
+    parser.add_argument(
+        "--reindex", action="store_true", help="Rebuild the index from scratch"
+    )
+    parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return parser
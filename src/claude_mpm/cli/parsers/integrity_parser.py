@@ -0,0 +1,60 @@
+"""
+Integrity command parser for claude-mpm CLI.
+
+WHY: Deployed agents and skills are recorded with the hashes and commit of
+their sources. This parser exposes verifying them and restoring files that
+were modified outside claude-mpm, and resolving updates that conflict with
+local edits.
+"""
+
+import argparse
+
+
+def add_integrity_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the integrity subparser with verify, redeploy and resolve.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured integrity subparser
+    """
+    integrity_parser = subparsers.add_parser(
+        "integrity",
+        help="Verify deployed agents and skills against their sources",
+        description=(
+            "Check deployed agent and skill files against the content hashes "
+            "and source commits recorded when they were deployed, and restore "
+            "files that were modified outside claude-mpm. Startup runs the "
+            "same check unless 'integrity.verify_on_startup' is false."
+        ),
+    )
+    integrity_subparsers = integrity_parser.add_subparsers(
+        dest="integrity_command", help="Integrity commands", metavar="SUBCOMMAND"
+    )
+
+    verify_parser = integrity_subparsers.add_parser(
+        "verify", help="Report deployed files that differ from what was deployed"
+    )
+    verify_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    integrity_subparsers.add_parser(
+        "redeploy", help="Restore modified files from their verified sources"
+    )
+
+    resolve_parser = integrity_subparsers.add_parser(
+        "resolve",
+        help="Resolve updates that conflict with local edits",
+        description=(
+            "Agent and skill updates that overlap local edits to the deployed "
+            "files are not applied. Pick, for each, the deployed version, the "
+            "upstream version, or edit the merge with conflict markers."
+        ),
+    )
+    resolve_parser.add_argument(
+        "--take",
+        choices=("local", "upstream"),
+        help="Resolve every conflict with this side instead of prompting",
+    )
+
+    return integrity_parser
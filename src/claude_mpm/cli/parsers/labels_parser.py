@@ -0,0 +1,67 @@
+"""
+Labels command parser for claude-mpm CLI.
+
+WHY: Sessions carry labels (bugfix, spike, customer-x) so work can be sliced
+by type. This parser lets users label sessions after launch and list
+sessions and their estimated cost by label.
+"""
+
+import argparse
+
+
+def add_labels_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the labels subparser with list, add, remove, sessions and costs.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured labels subparser
+    """
+    labels_parser = subparsers.add_parser(
+        "labels",
+        help="Label sessions and slice them by label",
+        description=(
+            "Label sessions by work type (bugfix, spike, customer-x). Labels "
+            "are given at launch with 'claude-mpm run --label' or later with "
+            "'labels add', and stored in .claude-mpm/session-labels.json. "
+            "An optional .claude-mpm/labels.yaml declares the team's labels."
+        ),
+    )
+    labels_subparsers = labels_parser.add_subparsers(
+        dest="labels_command", help="Labels commands", metavar="SUBCOMMAND"
+    )
+
+    list_parser = labels_subparsers.add_parser(
+        "list", help="Show labels in use and declared in labels.yaml"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    add_parser = labels_subparsers.add_parser("add", help="Label a session")
+    add_parser.add_argument("session_id", help="Claude session id")
+    add_parser.add_argument(
+        "labels", nargs="+", metavar="LABEL", help="Labels (or comma-separated)"
+    )
+
+    remove_parser = labels_subparsers.add_parser(
+        "remove", help="Remove labels from a session"
+    )
+    remove_parser.add_argument("session_id", help="Claude session id")
+    remove_parser.add_argument(
+        "labels", nargs="*", metavar="LABEL", help="Labels to remove (default: all)"
+    )
+
+    for name, help_text in (
+        ("sessions", "List labeled sessions"),
+        ("costs", "Estimated session cost per label"),
+    ):
+        filter_parser = labels_subparsers.add_parser(name, help=help_text)
+        filter_parser.add_argument(
+            "--label",
+            action="append",
+            metavar="LABEL",
+            help="Only sessions with this label (repeatable; all must match)",
+        )
+        filter_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return labels_parser
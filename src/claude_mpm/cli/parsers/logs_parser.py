@@ -0,0 +1,73 @@
+"""
+Logs command parser for claude-mpm CLI.
+
+WHY: Debugging agents read runtime logs through 'claude-mpm mcp serve logs',
+but only from sources the project has registered. This parser manages that
+registry and runs the same query from the terminal.
+"""
+
+import argparse
+
+
+def add_logs_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the logs subparser with add, remove, list and query.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured logs subparser
+    """
+    logs_parser = subparsers.add_parser(
+        "logs",
+        help="Register project log sources and query them",
+        description=(
+            "Register where this project's runtime logs live (files, Docker "
+            "containers, journald units) in .claude-mpm/log_sources.json. "
+            "Agents query them through 'claude-mpm mcp serve logs'."
+        ),
+    )
+    logs_subparsers = logs_parser.add_subparsers(
+        dest="logs_command", help="Logs commands", metavar="SUBCOMMAND"
+    )
+
+    add_parser = logs_subparsers.add_parser("add", help="Register a log source")
+    add_parser.add_argument("name", help="Source name, e.g. api or worker")
+    target = add_parser.add_mutually_exclusive_group(required=True)
+    target.add_argument(
+        "--file", metavar="PATH", help="Log file (relative to the project or absolute)"
+    )
+    target.add_argument("--docker", metavar="CONTAINER", help="Docker container")
+    target.add_argument("--journald", metavar="UNIT", help="systemd unit")
+
+    remove_parser = logs_subparsers.add_parser(
+        "remove", help="Unregister a log source"
+    )
+    remove_parser.add_argument("name", help="Source name")
+
+    logs_subparsers.add_parser("list", help="List registered log sources")
+
+    query_parser = logs_subparsers.add_parser(
+        "query", help="Show matching lines from the registered sources"
+    )
+    query_parser.add_argument(
+        "source", nargs="?", default=None, help="Source name (default: all)"
+    )
+    query_parser.add_argument(
+        "--since", default=None, help="Start: 15m, 2h, 1d ago or ISO timestamp"
+    )
+    query_parser.add_argument(
+        "--until", default=None, help="End: 15m, 2h, 1d ago or ISO timestamp"
+    )
+    query_parser.add_argument(
+        "--grep", default=None, metavar="REGEX", help="Only lines matching REGEX"
+    )
+    query_parser.add_argument(
+        "--case-sensitive", action="store_true", help="Match --grep case-sensitively"
+    )
+    query_parser.add_argument(
+        "--limit", type=int, default=200, help="Most recent lines (default: 200)"
+    )
+    query_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return logs_parser
@@ -203,7 +203,11 @@ def add_mcp_subparser(subparsers) -> argparse.ArgumentParser:
     )
     serve_parser.add_argument(
         "server_name",
-        help="Server to launch: messaging, slack-proxy, session, session-http, confluence",
+        help=(
+            "Server to launch: messaging, slack-proxy, session, session-http, "
+            "confluence, knowledge-graph, database-schema, logs, "
+            "page-capture, http, code-edit"
+        ),
     )
 
     # =========================================================================
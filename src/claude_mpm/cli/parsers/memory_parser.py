@@ -135,4 +135,26 @@ def add_memory_subparser(subparsers) -> argparse.ArgumentParser:
         "--strict", action="store_true", help="Use strict validation rules"
     )
 
+    # Lessons command (approve lessons learned from verification failures)
+    lessons_parser = memory_subparsers.add_parser(
+        MemoryCommands.LESSONS.value,
+        help="Review lessons proposed from verification failures",
+    )
+    lessons_parser.add_argument(
+        "lessons_action",
+        nargs="?",
+        choices=["list", "approve", "reject"],
+        default="list",
+        help="Action to take (default: list)",
+    )
+    lessons_parser.add_argument(
+        "lesson_ids", nargs="*", help="Lesson ids to approve or reject"
+    )
+    lessons_parser.add_argument(
+        "--all", action="store_true", help="Apply the action to every pending lesson"
+    )
+    lessons_parser.add_argument(
+        "--agent", help="Write approved lessons to this agent's memory instead"
+    )
+
     return memory_parser
@@ -0,0 +1,48 @@
+"""
+Owners command parser for claude-mpm CLI.
+
+WHY: Agents and users need to know who owns a file or a line range before
+filing a finding or asking for a review; this command answers from the same
+rules the ownership hook routes with.
+"""
+
+import argparse
+
+
+def add_owners_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the owners subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured owners subparser
+    """
+    parser = subparsers.add_parser(
+        "owners",
+        help="Show who owns files, from the mapping file, CODEOWNERS and git blame",
+        description=(
+            "Look up the owners of files or line ranges (PATH:LINE or "
+            "PATH:START-END). Rules in the ownership mapping file come "
+            "first, then CODEOWNERS, then the author of most of the lines "
+            "according to git blame."
+        ),
+    )
+    parser.add_argument(
+        "paths",
+        nargs="*",
+        metavar="PATH[:LINES]",
+        help="Files to look up, optionally with a line or line range",
+    )
+    parser.add_argument(
+        "--changed",
+        action="store_true",
+        help="Look up the files this branch changes instead",
+    )
+    parser.add_argument(
+        "--base",
+        metavar="REF",
+        help="Branch the changes are measured from (default: origin/HEAD, main)",
+    )
+    parser.add_argument("--json", action="store_true", help="Output JSON")
+    return parser
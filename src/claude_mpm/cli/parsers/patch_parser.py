@@ -0,0 +1,84 @@
+"""
+Patch command parser for claude-mpm CLI.
+
+WHY: Agents hand over changes as unified diffs that often drift from the file
+by whitespace or a stale context line. This parser exposes applying such a
+patch with fuzz-tolerant strategies, and checking it without writing.
+"""
+
+import argparse
+
+
+def _add_patch_arguments(parser: argparse.ArgumentParser) -> None:
+    parser.add_argument(
+        "patch_file", metavar="PATCH", help="Patch file, or - for stdin"
+    )
+    parser.add_argument(
+        "--strategy",
+        action="append",
+        dest="strategies",
+        metavar="NAME",
+        help=(
+            "Strategy to place hunks with: exact, fuzzy or ast (repeat to try "
+            "several in order; default from patching.strategies)"
+        ),
+    )
+    parser.add_argument(
+        "--max-fuzz",
+        type=int,
+        metavar="N",
+        help="Context lines at each end of a hunk fuzzy matching may ignore",
+    )
+    parser.add_argument(
+        "-p",
+        "--strip",
+        type=int,
+        metavar="N",
+        help="Leading path components to strip (default: git's a/ and b/)",
+    )
+    parser.add_argument(
+        "-d",
+        "--directory",
+        help="Directory the patch's paths are relative to (default: current)",
+    )
+    parser.add_argument("--json", action="store_true", help="Output JSON")
+
+
+def add_patch_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the patch subparser with apply and check.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured patch subparser
+    """
+    patch_parser = subparsers.add_parser(
+        "patch",
+        help="Apply unified diffs, tolerating whitespace drift",
+        description=(
+            "Apply a unified diff. Each hunk is placed by the first strategy "
+            "that finds it: exact (the hunk may move), ast (Python: fuzzy "
+            "matching inside the function named in the hunk header) and "
+            "fuzzy (ignores whitespace and up to --max-fuzz context lines). "
+            "Nothing is written unless the whole patch applies."
+        ),
+    )
+    patch_subparsers = patch_parser.add_subparsers(
+        dest="patch_command", help="Patch commands", metavar="SUBCOMMAND"
+    )
+
+    apply_parser = patch_subparsers.add_parser(
+        "apply", help="Apply a patch if every hunk finds its place"
+    )
+    _add_patch_arguments(apply_parser)
+    apply_parser.add_argument(
+        "--dry-run", action="store_true", help="Only check the patch (like check)"
+    )
+
+    check_parser = patch_subparsers.add_parser(
+        "check", help="Check where each hunk would apply, without writing"
+    )
+    _add_patch_arguments(check_parser)
+
+    return patch_parser
@@ -0,0 +1,44 @@
+"""
+Playground command parser for claude-mpm CLI.
+
+WHY: Prompt engineering on agent instructions needs a tight edit/try/compare
+loop; this parser exposes the interactive playground REPL for one agent.
+"""
+
+import argparse
+from pathlib import Path
+
+
+def add_playground_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the playground subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured playground subparser
+    """
+    playground_parser = subparsers.add_parser(
+        "playground",
+        help="Interactively edit and test an agent's instructions",
+        description=(
+            "Open a REPL for one agent: view its effective system prompt, edit the "
+            "instructions, run single-turn prompts against each revision, compare "
+            "the replies and save accepted changes to the project agent template."
+        ),
+    )
+    playground_parser.add_argument(
+        "--agent", required=True, metavar="NAME", help="Agent to work on"
+    )
+    playground_parser.add_argument(
+        "--model", default=None, help="Model for single-turn runs (default: agent's)"
+    )
+    playground_parser.add_argument(
+        "--replay",
+        type=Path,
+        default=None,
+        metavar="RECORDING",
+        help="Answer from a recorded session instead of calling the model",
+    )
+
+    return playground_parser
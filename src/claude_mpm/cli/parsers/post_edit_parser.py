@@ -0,0 +1,48 @@
+"""
+Post-edit command parser for claude-mpm CLI.
+
+WHY: The post-edit pipeline formats files as agents edit them; this parser
+lets users check which formatters it would run and run them by hand.
+"""
+
+import argparse
+
+from ...utils.table_view import add_table_arguments
+from ..list_columns import POST_EDIT_COLUMNS
+
+
+def add_post_edit_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the post-edit subparser with show and run.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured post-edit subparser
+    """
+    post_edit_parser = subparsers.add_parser(
+        "post-edit",
+        help="Show or run the formatters applied to files agents edit",
+        description=(
+            "When post_edit.enabled is set in .claude-mpm/configuration.yaml, "
+            "each file an agent writes or edits has its imports organized and "
+            "is formatted with the tools configured for its language."
+        ),
+    )
+    post_edit_subparsers = post_edit_parser.add_subparsers(
+        dest="post_edit_command", help="Post-edit commands", metavar="SUBCOMMAND"
+    )
+
+    show_parser = post_edit_subparsers.add_parser(
+        "show", help="List each language's steps and whether their tools exist"
+    )
+    show_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_table_arguments(show_parser, POST_EDIT_COLUMNS)
+
+    run_parser = post_edit_subparsers.add_parser(
+        "run", help="Run the pipeline on files, even when it is not enabled"
+    )
+    run_parser.add_argument("files", nargs="+", help="Files to format")
+    run_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return post_edit_parser
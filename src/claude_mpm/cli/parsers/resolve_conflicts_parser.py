@@ -0,0 +1,61 @@
+"""
+Resolve-conflicts command parser for claude-mpm CLI.
+
+WHY: When a merge, rebase or cherry-pick stops on conflicts, this command
+asks an agent per conflict for a resolution and applies the ones the user
+accepts.
+"""
+
+import argparse
+
+
+def add_resolve_conflicts_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the resolve-conflicts subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured resolve-conflicts subparser
+    """
+    parser = subparsers.add_parser(
+        "resolve-conflicts",
+        help="Propose agent resolutions for merge conflicts and apply them",
+        description=(
+            "Find the files git reports as conflicted and ask an agent to "
+            "resolve each conflict, given both sides, the base and the "
+            "commits on each side. Each proposal is shown as a diff and "
+            "written only after you accept it. Files with no conflicts left "
+            "are staged."
+        ),
+    )
+    parser.add_argument(
+        "paths",
+        nargs="*",
+        metavar="PATH",
+        help="Conflicted files to resolve (default: all)",
+    )
+    parser.add_argument(
+        "-y", "--yes", action="store_true", help="Apply every proposal without asking"
+    )
+    parser.add_argument(
+        "--dry-run", action="store_true", help="Show proposals without writing"
+    )
+    parser.add_argument(
+        "--no-stage",
+        action="store_true",
+        help="Do not git add files once all their conflicts are resolved",
+    )
+    parser.add_argument("--model", help="Model for the resolving agents")
+    parser.add_argument(
+        "-j",
+        "--jobs",
+        type=int,
+        default=4,
+        metavar="N",
+        help="Conflicts to resolve at the same time (default: 4)",
+    )
+    parser.add_argument(
+        "--json", action="store_true", help="Output proposals as JSON (nothing written)"
+    )
+    return parser
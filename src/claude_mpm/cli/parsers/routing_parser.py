@@ -0,0 +1,64 @@
+"""
+Routing command parser for claude-mpm CLI.
+
+WHY: Task-type routing sends delegations of a category (refactor,
+test-writing, ...) to a configured agent or splits them between agent
+variants. This parser lets users check the routes, try a task against
+them, and compare the variants of a split.
+"""
+
+import argparse
+
+
+def add_routing_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the routing subparser with show, test and report.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured routing subparser
+    """
+    routing_parser = subparsers.add_parser(
+        "routing",
+        help="Show and evaluate task-type routing of delegations",
+        description=(
+            "Task routes are configured under agents.routing in "
+            ".claude-mpm/configuration.yaml. Delegations whose description "
+            "or prompt matches a category go to its agent, or are split "
+            "between agent variants by weight."
+        ),
+    )
+    routing_subparsers = routing_parser.add_subparsers(
+        dest="routing_command", help="Routing commands", metavar="SUBCOMMAND"
+    )
+
+    show_parser = routing_subparsers.add_parser(
+        "show", help="List the routes and whether their agents are deployed"
+    )
+    show_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    test_parser = routing_subparsers.add_parser(
+        "test", help="Show where a delegation with this task would go"
+    )
+    test_parser.add_argument("task", help="Delegation description or prompt")
+    test_parser.add_argument(
+        "--agent",
+        default="engineer",
+        help="Agent the PM would delegate to (default: engineer)",
+    )
+    test_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    report_parser = routing_subparsers.add_parser(
+        "report", help="Compare the outcomes of the agents each category went to"
+    )
+    report_parser.add_argument("--category", help="Only this category")
+    report_parser.add_argument(
+        "--since",
+        default="30d",
+        help="Delegations since a duration ago (7d, 12h) or an ISO date "
+        "(default: 30d)",
+    )
+    report_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return routing_parser
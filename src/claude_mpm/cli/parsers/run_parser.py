@@ -130,6 +130,37 @@ def add_run_arguments(parser: argparse.ArgumentParser) -> None:
         help="Force-refresh the MPM-managed statusline.sh before starting the session "
         "(re-runs statusline autoconfig regardless of prior migration state)",
     )
+    run_group.add_argument(
+        "--env",
+        action="append",
+        dest="session_env",
+        metavar="KEY=VALUE",
+        help="Set an environment variable for this session only "
+        "(repeatable; stored with the session and restored by --mpm-resume)",
+    )
+    run_group.add_argument(
+        "--label",
+        action="append",
+        dest="session_labels",
+        metavar="LABEL",
+        help="Label this session, e.g. bugfix or customer-x (repeatable or "
+        "comma-separated; see 'claude-mpm labels')",
+    )
+    run_group.add_argument(
+        "--chaos",
+        action="store_true",
+        help="Inject random tool failures, rate limits, delayed and dropped "
+        "hook events to test error handling (rates from chaos in "
+        "configuration.yaml)",
+    )
+    run_group.add_argument(
+        "--cwd",
+        type=str,
+        default=None,
+        metavar="PATH",
+        help="Run the session in PATH, relative to the current directory "
+        "(stored with the session and restored by --mpm-resume)",
+    )
 
     # Dependency checking options
     dep_group = parser.add_argument_group("dependency options")
@@ -327,3 +358,49 @@ def add_run_subparser(subparsers) -> argparse.ArgumentParser:
     add_run_arguments(run_parser)
 
     return run_parser
+
+
+def add_start_subparser(subparsers) -> argparse.ArgumentParser:
+    """
+    Add the start subparser: a run launched from a conversation template.
+
+    WHY: Templates pre-fill the PM's first prompt, pick the session's agents
+    and set its policies (see services/conversation_templates.py); every run
+    option still applies on top of them.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured start subparser
+    """
+    start_parser = subparsers.add_parser(
+        "start",
+        help="Start a session from a conversation template",
+        description=(
+            "Start an orchestrated session from a conversation template: its "
+            "prompt is filled in with --var values and sent as the first "
+            "message, and its agents and policies apply to the session. "
+            "List templates with 'claude-mpm templates list'."
+        ),
+        formatter_class=argparse.RawDescriptionHelpFormatter,
+    )
+    add_common_arguments(start_parser)
+    template_group = start_parser.add_argument_group("template options")
+    template_group.add_argument(
+        "--template",
+        "-t",
+        required=True,
+        metavar="NAME",
+        help="Conversation template to start from",
+    )
+    template_group.add_argument(
+        "--var",
+        action="append",
+        dest="template_vars",
+        metavar="NAME=VALUE",
+        help="Value of a template variable (repeatable)",
+    )
+    add_run_arguments(start_parser)
+
+    return start_parser
@@ -0,0 +1,58 @@
+"""
+Selftest command parser for claude-mpm CLI.
+
+WHY: Lets users validate an installation end to end (agent deployment, hook
+delivery, dashboard events) against a mock model backend before relying on
+it, without spending tokens.
+"""
+
+import argparse
+
+
+def add_selftest_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the selftest subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured selftest subparser
+    """
+    selftest_parser = subparsers.add_parser(
+        "selftest",
+        help="Run end-to-end checks against a mock model backend",
+        description=(
+            "Run scripted end-to-end scenarios in an isolated sandbox: deploy an "
+            "agent, run a mock session, send its hook events through claude-hook "
+            "and verify the dashboard receives them. No API calls are made."
+        ),
+    )
+    selftest_parser.add_argument(
+        "--scenario",
+        action="append",
+        dest="scenarios",
+        metavar="NAME",
+        help="Only run this scenario and its prerequisites (repeatable)",
+    )
+    selftest_parser.add_argument(
+        "--list", action="store_true", help="List available scenarios and exit"
+    )
+    selftest_parser.add_argument(
+        "--replay",
+        metavar="PATH|SESSION_ID",
+        help=(
+            "Replay a recorded session (fixture file, transcript .jsonl, or a "
+            "Claude Code session id for this project) instead of the built-in "
+            "script"
+        ),
+    )
+    selftest_parser.add_argument(
+        "--keep-workspace",
+        action="store_true",
+        help="Keep the sandbox directory for inspection after the run",
+    )
+    selftest_parser.add_argument(
+        "--json", action="store_true", help="Output results as JSON"
+    )
+
+    return selftest_parser
@@ -11,6 +11,8 @@ log files live in ~/.claude-mpm/ to allow management from any directory.
 
 import argparse
 
+from ..watch import add_watch_argument
+
 
 def add_serve_subparser(subparsers) -> argparse.ArgumentParser:
     """Add the serve subparser with all daemon lifecycle commands.
@@ -168,5 +170,6 @@ def add_serve_subparser(subparsers) -> argparse.ArgumentParser:
         action="store_true",
         help="Show detailed status information",
     )
+    add_watch_argument(status_parser)
 
     return serve_parser
@@ -12,6 +12,10 @@ maintain clear separation of concerns:
 
 import argparse
 
+from ...config.skill_sources import PROVIDERS
+from ...utils.bulk_operations import add_jobs_argument
+from ...utils.table_view import add_table_arguments
+from ..list_columns import SKILL_SOURCE_COLUMNS
 from .base_parser import add_common_arguments
 
 
@@ -47,7 +51,16 @@ def add_skill_source_subparser(subparsers) -> argparse.ArgumentParser:
     )
     add_parser.add_argument(
         "url",
-        help="Git repository URL (e.g., https://github.com/owner/repo)",
+        help=(
+            "Git repository URL (e.g., https://github.com/owner/repo, "
+            "https://gitlab.com/group/repo, https://bitbucket.org/workspace/repo "
+            "or git@github.com:owner/repo.git), the index.json URL of a skill "
+            "registry, or a local directory of skills (e.g., ./my-skills)"
+        ),
+    )
+    add_parser.add_argument(
+        "--id",
+        help="Source identifier (default: derived from the URL or directory name)",
     )
     add_parser.add_argument(
         "--branch",
@@ -78,7 +91,38 @@ def add_skill_source_subparser(subparsers) -> argparse.ArgumentParser:
     )
     add_parser.add_argument(
         "--token",
-        help="GitHub token or env var reference (e.g., ghp_xxx or $PRIVATE_TOKEN)",
+        help=(
+            "Access token or env var reference (e.g., $PRIVATE_TOKEN); defaults "
+            "to GITHUB_TOKEN, GITLAB_TOKEN or BITBUCKET_TOKEN"
+        ),
+    )
+    add_parser.add_argument(
+        "--provider",
+        choices=PROVIDERS,
+        help="Hosting service, for a self-hosted GitLab the URL does not identify",
+    )
+    add_parser.add_argument(
+        "--ssh-key",
+        metavar="PATH",
+        help="Private key (e.g., a deploy key) to use with an SSH URL",
+    )
+    add_parser.add_argument(
+        "--trusted-key",
+        action="append",
+        dest="trusted_keys",
+        metavar="KEY",
+        help=(
+            "Require the source's skills.manifest to be signed by KEY: a "
+            "minisign public key or 'sigstore:ISSUER IDENTITY' (repeatable)"
+        ),
+    )
+    add_parser.add_argument(
+        "--watch",
+        action="store_true",
+        help=(
+            "For a local directory: keep running and redeploy its skills to "
+            "~/.claude/skills whenever they change"
+        ),
     )
 
     # Remove repository
@@ -118,6 +162,7 @@ def add_skill_source_subparser(subparsers) -> argparse.ArgumentParser:
         action="store_true",
         help="Output as JSON",
     )
+    add_table_arguments(list_parser, SKILL_SOURCE_COLUMNS)
 
     # Update/sync repositories
     update_parser = skill_source_subparsers.add_parser(
@@ -134,6 +179,7 @@ def add_skill_source_subparser(subparsers) -> argparse.ArgumentParser:
         action="store_true",
         help="Force update even if cache is fresh",
     )
+    add_jobs_argument(update_parser)
 
     # Enable repository
     enable_parser = skill_source_subparsers.add_parser(
@@ -170,4 +216,77 @@ def add_skill_source_subparser(subparsers) -> argparse.ArgumentParser:
         help="Also list skills from this source",
     )
 
+    # Watch a local source
+    watch_parser = skill_source_subparsers.add_parser(
+        "watch",
+        help="Redeploy a local skill source whenever its files change",
+    )
+    watch_parser.add_argument(
+        "source_id",
+        help="Local source identifier to watch",
+    )
+
+    # Sign a skill collection
+    sign_parser = skill_source_subparsers.add_parser(
+        "sign",
+        help="Write and sign the manifest of a skill collection",
+        description=(
+            "Write skills.manifest (the SHA-256 of every file a sync fetches) "
+            "at the root of a skill repository and sign it with minisign or "
+            "Sigstore. Commit the manifest and its signature; sources that "
+            "trust the key verify them on every sync."
+        ),
+    )
+    sign_parser.add_argument(
+        "path",
+        nargs="?",
+        default=".",
+        help="Root of the skill repository (default: current directory)",
+    )
+    method = sign_parser.add_mutually_exclusive_group(required=True)
+    method.add_argument(
+        "--minisign-key",
+        metavar="PATH",
+        help="Sign with this minisign secret key",
+    )
+    method.add_argument(
+        "--sigstore",
+        action="store_true",
+        help="Sign keylessly with Sigstore (cosign sign-blob)",
+    )
+
+    # Pack a skill collection as a registry
+    pack_parser = skill_source_subparsers.add_parser(
+        "pack",
+        help="Pack a skill collection into a static HTTP registry",
+        description=(
+            "Write index.json and one tar.gz archive per skill, with their "
+            "SHA-256, into a directory any web or artifact server can serve. "
+            "Add the served index.json with 'skill-source add'."
+        ),
+    )
+    pack_parser.add_argument(
+        "path",
+        nargs="?",
+        default=".",
+        help="Root of the skill collection (default: current directory)",
+    )
+    pack_parser.add_argument(
+        "--output",
+        "-o",
+        default="registry",
+        help="Directory to write the registry to (default: ./registry)",
+    )
+
+    # Verify synced sources
+    verify_parser = skill_source_subparsers.add_parser(
+        "verify",
+        help="Check synced skill sources against their trusted keys",
+    )
+    verify_parser.add_argument(
+        "source_id",
+        nargs="?",
+        help="Optional: Verify only this source (default: all enabled sources)",
+    )
+
     return skill_source_parser
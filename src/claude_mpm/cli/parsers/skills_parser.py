@@ -11,6 +11,8 @@ for listing, deploying, validating, updating, and configuring Claude Code skills
 import argparse
 
 from ...constants import CLICommands, SkillsCommands
+from ...utils.bulk_operations import add_jobs_argument
+from ..shared.list_options import add_list_arguments
 from .base_parser import add_common_arguments
 
 
@@ -48,6 +50,17 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
     list_parser.add_argument(
         "--agent", help="Show skills for specific agent (e.g., engineer, pm)"
     )
+    add_list_arguments(
+        list_parser,
+        {
+            "name": "name",
+            "category": "category",
+            "id": "canonical_id",
+            "version": "metadata.version",
+            "tag": "metadata.tags",
+        },
+        noun="skills",
+    )
     list_parser.add_argument(
         "--verbose",
         "-v",
@@ -55,6 +68,24 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         help="Show detailed skill information",
     )
 
+    # Search command
+    search_parser = skills_subparsers.add_parser(
+        SkillsCommands.SEARCH.value,
+        help="Search skills across all configured skill sources",
+        description=(
+            "Search skill names, descriptions and tags in every enabled skill "
+            "source. Synced sources are searched in the local cache; others "
+            "through the manifest.json index of their repository."
+        ),
+    )
+    search_parser.add_argument(
+        "query", nargs="+", help="Words to search for (all must match)"
+    )
+    search_parser.add_argument(
+        "--source", metavar="ID", help="Search only this skill source"
+    )
+    search_parser.add_argument("--json", action="store_true", help="Output JSON")
+
     # Deploy command
     deploy_parser = skills_subparsers.add_parser(
         SkillsCommands.DEPLOY.value, help="Deploy bundled skills to project"
@@ -68,7 +99,16 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         "--skill",
         action="append",
         dest="skills",
-        help="Deploy specific skill(s) only (can be used multiple times)",
+        help="Deploy specific skill(s) only (can be used multiple times); "
+        "<source>:<skill> picks one source's copy of a conflicting skill",
+    )
+    deploy_parser.add_argument(
+        "--tag",
+        action="append",
+        dest="tags",
+        metavar="TAG",
+        help="Deploy only skills with this tag in their frontmatter, or in a "
+        "folder of this name in their source (can be used multiple times)",
     )
     deploy_parser.add_argument(
         "--scope",
@@ -77,6 +117,12 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         help="Deployment scope: 'project' deploys to {project}/.claude/skills/, "
         "'user' deploys to ~/.claude/skills/ (default: project)",
     )
+    deploy_parser.add_argument(
+        "--no-profile",
+        action="store_true",
+        help="Ignore the skill profile selected in .claude-mpm/configuration.yaml",
+    )
+    add_jobs_argument(deploy_parser)
 
     # Validate command
     validate_parser = skills_subparsers.add_parser(
@@ -89,9 +135,63 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         help="Use strict validation (treat warnings as errors)",
     )
 
+    # Lint command
+    lint_parser = skills_subparsers.add_parser(
+        SkillsCommands.LINT.value,
+        help="Check skills for problems that break deployment",
+        description=(
+            "Check skill frontmatter, required sections, relative links and "
+            "size budgets. PATH may be a skill directory, a directory of "
+            "skills, or the name of a deployed, cached or bundled skill."
+        ),
+    )
+    lint_parser.add_argument(
+        "target",
+        nargs="?",
+        default=".",
+        metavar="PATH|NAME",
+        help="Skill directory, directory of skills, or skill name (default: .)",
+    )
+    lint_parser.add_argument(
+        "--fix",
+        action="store_true",
+        help="Correct mechanical issues (encoding, name format, list fields, "
+        "missing title) in place",
+    )
+    lint_parser.add_argument(
+        "--strict",
+        action="store_true",
+        help="Fail on warnings as well as errors",
+    )
+    lint_parser.add_argument(
+        "--max-lines",
+        type=int,
+        default=None,
+        metavar="N",
+        help="Line budget for SKILL.md (default: 200)",
+    )
+    lint_parser.add_argument(
+        "--max-tokens",
+        type=int,
+        default=None,
+        metavar="N",
+        help="Token budget for SKILL.md when it sets no context_limit "
+        "(default: 5000)",
+    )
+    lint_parser.add_argument(
+        "--require-section",
+        action="append",
+        dest="require_sections",
+        metavar="HEADING",
+        help="Section every skill must have (repeatable; default: When to Use)",
+    )
+    lint_parser.add_argument("--json", action="store_true", help="Output JSON")
+
     # Update command
     update_parser = skills_subparsers.add_parser(
-        SkillsCommands.UPDATE.value, help="Check for and install skill updates"
+        SkillsCommands.UPDATE.value,
+        help="Check for and install skill updates, moving skills.lock pins to "
+        "the latest commits",
     )
     update_parser.add_argument(
         "skill_names",
@@ -108,6 +208,163 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         action="store_true",
         help="Force update even if versions match",
     )
+    update_parser.add_argument(
+        "--source",
+        action="append",
+        dest="sources",
+        metavar="SOURCE_ID",
+        help="Move only this source's skills.lock pin (can be used multiple "
+        "times; default: all sources)",
+    )
+
+    # Outdated command
+    outdated_parser = skills_subparsers.add_parser(
+        SkillsCommands.OUTDATED.value,
+        help="List deployed skills that changed in their upstream sources",
+        description=(
+            "Compare the commit each skill source was deployed from (its "
+            "skills.lock pin, or the last sync) with the head of its branch, "
+            "and list the deployed skills that changed, with their versions "
+            "and the commits that touched them."
+        ),
+    )
+    outdated_parser.add_argument(
+        "--source",
+        action="append",
+        dest="sources",
+        metavar="SOURCE_ID",
+        help="Check only this source (can be used multiple times)",
+    )
+    outdated_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    # Rollback command
+    rollback_parser = skills_subparsers.add_parser(
+        SkillsCommands.ROLLBACK.value,
+        help="Restore a deployed skill's previous version",
+        description=(
+            "Restore a deployed skill to the version deployed before the "
+            "current one, or to the version given with --to, and hold it "
+            "there: deployments skip the skill until --release."
+        ),
+    )
+    rollback_parser.add_argument("skill_name", help="Name of the deployed skill")
+    rollback_action = rollback_parser.add_mutually_exclusive_group()
+    rollback_action.add_argument(
+        "--to",
+        metavar="SHA|VERSION",
+        default=None,
+        help="Version ID, source commit or skill version to restore",
+    )
+    rollback_action.add_argument(
+        "--list",
+        action="store_true",
+        help="List the recorded versions instead of rolling back",
+    )
+    rollback_action.add_argument(
+        "--release",
+        action="store_true",
+        help="Let deployments update the skill again",
+    )
+
+    # Test command
+    test_parser = skills_subparsers.add_parser(
+        SkillsCommands.TEST.value,
+        help="Run a skill's example prompts against a headless agent",
+        description=(
+            "Run the cases in each skill's tests.yaml against a headless agent "
+            "session with the skill loaded, and check the tool calls and output "
+            "they expect. PATH may be a skill directory, a directory of skills, "
+            "or the name of a deployed, cached or bundled skill. Exits non-zero "
+            "when a case fails."
+        ),
+    )
+    test_parser.add_argument(
+        "target",
+        nargs="?",
+        default=".",
+        metavar="PATH|NAME",
+        help="Skill directory, directory of skills, or skill name (default: .)",
+    )
+    test_parser.add_argument(
+        "-k",
+        "--case",
+        action="append",
+        dest="cases",
+        metavar="TEXT",
+        help="Run only cases whose name contains TEXT (can be used multiple times)",
+    )
+    test_parser.add_argument(
+        "--model",
+        default=None,
+        help="Model for every case, overriding tests.yaml",
+    )
+    test_parser.add_argument(
+        "--junit",
+        metavar="FILE",
+        default=None,
+        help="Also write the results as JUnit XML to FILE",
+    )
+    add_jobs_argument(test_parser)
+    test_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    # New command
+    new_parser = skills_subparsers.add_parser(
+        SkillsCommands.NEW.value,
+        help="Scaffold a new skill directory",
+        description=(
+            "Create DIR/NAME with a SKILL.md carrying complete frontmatter and "
+            "the usual sections, an examples reference and, with --with-tests, "
+            "a tests.yaml and fixture project. Details not given as flags are "
+            "asked for on a terminal."
+        ),
+    )
+    new_parser.add_argument(
+        "name", nargs="?", default=None, help="Skill name (lowercase, hyphens)"
+    )
+    new_parser.add_argument("--description", default=None, help="One-line summary")
+    new_parser.add_argument(
+        "--when-to-use",
+        default=None,
+        help="When the agent should use the skill (default: the description)",
+    )
+    new_parser.add_argument(
+        "--category", default=None, help="Skill category (default: universal)"
+    )
+    new_parser.add_argument(
+        "--tag",
+        action="append",
+        dest="tags",
+        metavar="TAG",
+        help="Tag (can be used multiple times)",
+    )
+    new_parser.add_argument(
+        "--agent-type",
+        action="append",
+        dest="agent_types",
+        metavar="AGENT",
+        help="Agent the skill is meant for (can be used multiple times)",
+    )
+    new_parser.add_argument(
+        "--dir",
+        default=".",
+        help="Directory to create the skill in (default: current directory)",
+    )
+    new_parser.add_argument(
+        "--with-tests",
+        action="store_true",
+        default=None,
+        help="Also create tests.yaml and a fixture project for 'skills test'",
+    )
+    new_parser.add_argument(
+        "--force",
+        action="store_true",
+        help="Write into an existing non-empty skill directory",
+    )
+    new_parser.add_argument(
+        "--no-input",
+        action="store_true",
+        help="Never prompt; fail when the name or description is missing",
+    )
 
     # Info command
     info_parser = skills_subparsers.add_parser(
@@ -120,6 +377,19 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         help="Show full skill content (SKILL.md)",
     )
 
+    # Diff command
+    diff_parser = skills_subparsers.add_parser(
+        SkillsCommands.DIFF.value,
+        help="Show local modifications of a deployed skill against its source",
+        description=(
+            "Compare a deployed skill directory (.claude/skills/<name>) with "
+            "its source, so local edits are visible before a sync or update "
+            "overwrites them."
+        ),
+    )
+    diff_parser.add_argument("skill_name", help="Name of the deployed skill")
+    diff_parser.add_argument("--json", action="store_true", help="Output JSON")
+
     # Config command
     config_parser = skills_subparsers.add_parser(
         SkillsCommands.CONFIG.value, help="View or edit skills configuration"
@@ -193,6 +463,13 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         SkillsCommands.DEPLOY_FROM_GITHUB.value,
         help="Deploy skills from GitHub to ~/.claude/skills/ for Claude Code",
     )
+    deploy_github_parser.add_argument(
+        "skill_selectors",
+        nargs="*",
+        metavar="SKILL",
+        help="Skills to deploy by name or glob (e.g. 'toolchains-python-*'), "
+        "whether or not an agent references them (default: agent-referenced)",
+    )
     deploy_github_parser.add_argument(
         "--collection",
         "-c",
@@ -265,17 +542,17 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         help="Remove all deployed skills",
     )
 
-    # Collection management commands
+    # Collection management commands (collections are skill sources)
     # List collections
     skills_subparsers.add_parser(
         SkillsCommands.COLLECTION_LIST.value,
-        help="List all configured skill collections",
+        help="List skill sources (alias of skill-source list)",
     )
 
     # Add collection
     collection_add_parser = skills_subparsers.add_parser(
         SkillsCommands.COLLECTION_ADD.value,
-        help="Add a new skill collection from GitHub",
+        help="Add a skill source (alias of skill-source add URL --id NAME)",
     )
     collection_add_parser.add_argument(
         "collection_name",
@@ -283,7 +560,7 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
     )
     collection_add_parser.add_argument(
         "collection_url",
-        help="GitHub repository URL (e.g., https://github.com/obra/superpowers)",
+        help="Repository URL (e.g., https://github.com/obra/superpowers)",
     )
     collection_add_parser.add_argument(
         "--priority",
@@ -295,7 +572,7 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
     # Remove collection
     collection_remove_parser = skills_subparsers.add_parser(
         SkillsCommands.COLLECTION_REMOVE.value,
-        help="Remove a skill collection",
+        help="Remove a skill source (alias of skill-source remove)",
     )
     collection_remove_parser.add_argument(
         "collection_name",
@@ -305,7 +582,7 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
     # Enable collection
     collection_enable_parser = skills_subparsers.add_parser(
         SkillsCommands.COLLECTION_ENABLE.value,
-        help="Enable a disabled collection",
+        help="Enable a skill source (alias of skill-source enable)",
     )
     collection_enable_parser.add_argument(
         "collection_name",
@@ -315,7 +592,7 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
     # Disable collection
     collection_disable_parser = skills_subparsers.add_parser(
         SkillsCommands.COLLECTION_DISABLE.value,
-        help="Disable a collection without removing it",
+        help="Disable a skill source (alias of skill-source disable)",
     )
     collection_disable_parser.add_argument(
         "collection_name",
@@ -325,7 +602,7 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
     # Set default collection
     collection_set_default_parser = skills_subparsers.add_parser(
         SkillsCommands.COLLECTION_SET_DEFAULT.value,
-        help="Set the default collection for deployments",
+        help="Set the skill source deploy-github uses by default",
     )
     collection_set_default_parser.add_argument(
         "collection_name",
@@ -361,4 +638,32 @@ def add_skills_subparser(subparsers) -> argparse.ArgumentParser:
         ),
     )
 
+    # Effectiveness stats command
+    stats_parser = skills_subparsers.add_parser(
+        SkillsCommands.STATS.value,
+        help="Score skills by session outcomes (success, retries, cost)",
+    )
+    stats_parser.add_argument(
+        "--all-projects",
+        action="store_true",
+        help="Include sessions from every project, not just the current one",
+    )
+    stats_parser.add_argument(
+        "--days",
+        type=int,
+        default=None,
+        help="Only consider sessions active in the last N days",
+    )
+    stats_parser.add_argument(
+        "--sort",
+        choices=["score", "invocations", "cost", "name"],
+        default="score",
+        help="Sort order (default: score, worst first)",
+    )
+    stats_parser.add_argument(
+        "--json",
+        action="store_true",
+        help="Output stats as JSON",
+    )
+
     return skills_parser
@@ -0,0 +1,66 @@
+"""
+Status command parser for claude-mpm CLI.
+
+WHY: Summarises the agents, skills, hooks, MCP servers and recent sessions
+claude-mpm manages in the current repository, optionally as a committed
+.claude-mpm/README.md.
+"""
+
+import argparse
+
+from ..watch import add_watch_argument
+
+
+def _non_negative(value: str) -> int:
+    try:
+        number = int(value)
+    except ValueError:
+        raise argparse.ArgumentTypeError(f"expected a number, got '{value}'") from None
+    if number < 0:
+        raise argparse.ArgumentTypeError(f"must be 0 or more, got {number}")
+    return number
+
+
+def add_status_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the status subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured status subparser
+    """
+    status_parser = subparsers.add_parser(
+        "status",
+        help="Summarise what claude-mpm manages in this repository",
+        description=(
+            "Show the agents and versions, skills, hooks, MCP servers and "
+            "recent sessions claude-mpm manages in the current repository. "
+            "--write saves the full summary as .claude-mpm/README.md so it "
+            "can be committed for the team."
+        ),
+    )
+    status_parser.add_argument(
+        "--deep",
+        action="store_true",
+        help="Show every agent, skill, hook, MCP server and session, not counts",
+    )
+    status_parser.add_argument(
+        "--write",
+        nargs="?",
+        const="",
+        default=None,
+        metavar="PATH",
+        help="Write the full summary as markdown (default: .claude-mpm/README.md)",
+    )
+    status_parser.add_argument(
+        "--sessions",
+        type=_non_negative,
+        default=None,
+        metavar="N",
+        help="Number of recent sessions to include (default: 5)",
+    )
+    status_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_watch_argument(status_parser)
+
+    return status_parser
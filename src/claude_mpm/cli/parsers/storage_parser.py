@@ -0,0 +1,82 @@
+"""
+Storage command parser for claude-mpm CLI.
+
+WHY: ~/.claude-mpm and the project's .claude-mpm accumulate session logs,
+spooled events, caches and artifacts. This parser exposes how much each
+category uses and prunes them per the configured retention policies.
+"""
+
+import argparse
+
+from ..watch import add_watch_argument
+
+STORAGE_CATEGORIES = ("transcripts", "events", "logs", "caches", "artifacts")
+ENCRYPTION_SCOPES = ("transcripts", "memories", "events")
+
+
+def add_storage_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the storage subparser with status, prune, encrypt, decrypt and cat.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured storage subparser
+    """
+    storage_parser = subparsers.add_parser(
+        "storage",
+        help="Show disk usage and prune old claude-mpm data",
+        description=(
+            "Report disk usage of transcripts, events, logs, caches and "
+            "artifacts, and prune them by the age and size limits in the "
+            "'storage.retention' configuration. With 'storage.auto_prune' set, "
+            "startup also prunes once a day. With 'encryption.enabled', "
+            "encrypt and decrypt convert transcripts, memories and the event "
+            "log at rest."
+        ),
+    )
+    storage_subparsers = storage_parser.add_subparsers(
+        dest="storage_command", help="Storage commands", metavar="SUBCOMMAND"
+    )
+
+    status_parser = storage_subparsers.add_parser(
+        "status", help="Show disk usage per category and what a prune would free"
+    )
+    status_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_watch_argument(status_parser)
+
+    prune_parser = storage_subparsers.add_parser(
+        "prune", help="Remove data past the retention limits"
+    )
+    prune_parser.add_argument(
+        "--category",
+        action="append",
+        choices=STORAGE_CATEGORIES,
+        default=None,
+        help="Only prune this category (repeatable; default: all)",
+    )
+    prune_parser.add_argument(
+        "--dry-run",
+        action="store_true",
+        help="Show what would be removed without removing it",
+    )
+
+    for name, help_text in (
+        ("encrypt", "Encrypt existing files in place (needs encryption.enabled)"),
+        ("decrypt", "Decrypt encrypted files back to plaintext in place"),
+    ):
+        scope_parser = storage_subparsers.add_parser(name, help=help_text)
+        scope_parser.add_argument(
+            "--scope",
+            action="append",
+            choices=ENCRYPTION_SCOPES,
+            default=None,
+            help="Only this scope (repeatable; default: all configured scopes)",
+        )
+
+    cat_parser = storage_subparsers.add_parser(
+        "cat", help="Print a file, decrypting it if it is encrypted"
+    )
+    cat_parser.add_argument("path", help="File to print")
+
+    return storage_parser
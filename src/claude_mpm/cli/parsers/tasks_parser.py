@@ -0,0 +1,85 @@
+"""
+Tasks command parser for claude-mpm CLI.
+
+WHY: Long-running commands (training jobs, large builds) are started under a
+detached watcher so a session does not block on them. This parser exposes
+starting a tracked task, checking on it, reading its output and stopping it.
+"""
+
+import argparse
+
+from ..watch import add_watch_argument
+
+
+def add_tasks_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the tasks subparser with start, list, show, logs and stop.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured tasks subparser
+    """
+    tasks_parser = subparsers.add_parser(
+        "tasks",
+        help="Run long commands detached and track their progress",
+        description=(
+            "Start a long-running command under a detached watcher and return "
+            "at once. The watcher parses progress from the command's output, "
+            "samples GPU use, and records progress, completion and failure in "
+            "the event log. State lives in .claude-mpm/tasks/."
+        ),
+    )
+    tasks_subparsers = tasks_parser.add_subparsers(
+        dest="tasks_command", help="Tasks commands", metavar="SUBCOMMAND"
+    )
+
+    start_parser = tasks_subparsers.add_parser(
+        "start", help="Start a command in the background and print its task id"
+    )
+    start_parser.add_argument("--name", help="Name for the task")
+    start_parser.add_argument(
+        "--cwd", dest="task_cwd", help="Directory to run in (default: current)"
+    )
+    start_parser.add_argument(
+        "--progress-pattern",
+        metavar="REGEX",
+        help="Regex with a 'percent' group, or 'current' and 'total' groups",
+    )
+    start_parser.add_argument(
+        "task_command",
+        nargs=argparse.REMAINDER,
+        metavar="-- COMMAND",
+        help="Command to run, after '--'",
+    )
+
+    list_parser = tasks_subparsers.add_parser(
+        "list", help="List tasks with their status and progress"
+    )
+    list_parser.add_argument(
+        "--running", action="store_true", help="Only tasks that are still running"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_watch_argument(list_parser)
+
+    show_parser = tasks_subparsers.add_parser(
+        "show", help="Show a task's state and the end of its output"
+    )
+    show_parser.add_argument("task_id", help="Task to show")
+    show_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_watch_argument(show_parser)
+
+    logs_parser = tasks_subparsers.add_parser("logs", help="Print a task's output")
+    logs_parser.add_argument("task_id", help="Task whose output to print")
+    logs_parser.add_argument(
+        "-n",
+        "--lines",
+        type=int,
+        default=50,
+        help="Number of lines from the end (default: 50)",
+    )
+
+    stop_parser = tasks_subparsers.add_parser("stop", help="Stop a running task")
+    stop_parser.add_argument("task_id", help="Task to stop")
+
+    return tasks_parser
@@ -0,0 +1,53 @@
+"""
+Templates command parser for claude-mpm CLI.
+
+WHY: Conversation templates are launched with ``claude-mpm start``; this
+parser lets users see which templates exist, where each comes from, and
+what a template would send and set before launching it.
+"""
+
+import argparse
+
+
+def add_templates_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the templates subparser with list and show.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured templates subparser
+    """
+    templates_parser = subparsers.add_parser(
+        "templates",
+        help="List and preview conversation templates",
+        description=(
+            "Conversation templates live in .claude-mpm/conversation-templates/ "
+            "(project), ~/.claude-mpm/conversation-templates/ (user) and the "
+            "conversation-templates/ directory of skill sources. Start one "
+            "with 'claude-mpm start --template NAME'."
+        ),
+    )
+    templates_subparsers = templates_parser.add_subparsers(
+        dest="templates_command", help="Templates commands", metavar="SUBCOMMAND"
+    )
+
+    list_parser = templates_subparsers.add_parser(
+        "list", help="List the templates and where each comes from"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    show_parser = templates_subparsers.add_parser(
+        "show", help="Show the prompt, agents and policies a template would use"
+    )
+    show_parser.add_argument("name", help="Template name")
+    show_parser.add_argument(
+        "--var",
+        action="append",
+        dest="template_vars",
+        metavar="NAME=VALUE",
+        help="Value of a template variable (repeatable)",
+    )
+    show_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return templates_parser
@@ -11,7 +11,10 @@ that warrants a dedicated module.
 import argparse
 
 from ...constants import CLICommands, TicketCommands
+from ...utils.table_view import add_table_arguments
 from ..constants import TicketStatus
+from ..list_columns import TICKET_COLUMNS
+from ..shared.list_options import add_list_arguments
 from .base_parser import add_common_arguments
 
 
@@ -99,11 +102,21 @@ def add_tickets_subparser(subparsers) -> argparse.ArgumentParser:
         choices=["low", "medium", "high", "critical"],
         help="Filter by priority",
     )
-    list_tickets_parser.add_argument(
-        "--limit",
-        type=int,
-        default=20,
-        help="Maximum number of tickets to show (default: 20)",
+    add_list_arguments(
+        list_tickets_parser,
+        {
+            "id": "id",
+            "title": "title",
+            "status": "status",
+            "priority": "priority",
+            "type": "metadata.ticket_type",
+            "tag": "tags",
+            "assignee": "assignees",
+            "parent_epic": "metadata.parent_epic",
+            "parent_issue": "metadata.parent_issue",
+        },
+        default_limit=20,
+        noun="tickets",
     )
     list_tickets_parser.add_argument(
         "--page", type=int, default=1, help="Page number for pagination (default: 1)"
@@ -117,6 +130,12 @@ def add_tickets_subparser(subparsers) -> argparse.ArgumentParser:
     list_tickets_parser.add_argument(
         "--verbose", action="store_true", help="Show detailed ticket information"
     )
+    list_tickets_parser.add_argument(
+        "--tree",
+        action="store_true",
+        help="Show tickets nested under their parent epic or issue",
+    )
+    add_table_arguments(list_tickets_parser, TICKET_COLUMNS)
 
     # View ticket
     view_ticket_parser = tickets_subparsers.add_parser(
@@ -0,0 +1,56 @@
+"""
+Trust command parser for claude-mpm CLI.
+
+WHY: Sessions in repositories that have not been trusted run restricted (no
+shell, no network tools, no repository hooks). This parser exposes the trust
+decisions so users can review, grant and revoke them outside the first-run
+prompt.
+"""
+
+import argparse
+
+
+def add_trust_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the trust subparser with status, add, deny, remove and list.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured trust subparser
+    """
+    trust_parser = subparsers.add_parser(
+        "trust",
+        help="Manage which workspaces run with full tool access",
+        description=(
+            "Sessions in untrusted workspaces run without shell commands, "
+            "network tools, or hooks, settings and MCP servers from the "
+            "repository. Trust applies to the repository root and everything "
+            "below it. Directories listed in 'workspace_trust.trusted_paths' "
+            "are always trusted."
+        ),
+    )
+    trust_subparsers = trust_parser.add_subparsers(
+        dest="trust_command", help="Trust commands", metavar="SUBCOMMAND"
+    )
+
+    for name, help_text in (
+        ("status", "Show whether a workspace is trusted"),
+        ("add", "Trust a workspace"),
+        ("deny", "Mark a workspace untrusted without being asked again"),
+        ("remove", "Forget the decision, so the next run asks again"),
+    ):
+        sub = trust_subparsers.add_parser(name, help=help_text)
+        sub.add_argument(
+            "path",
+            nargs="?",
+            default=".",
+            help="Directory inside the workspace (default: current directory)",
+        )
+
+    list_parser = trust_subparsers.add_parser(
+        "list", help="List remembered trust decisions"
+    )
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    return trust_parser
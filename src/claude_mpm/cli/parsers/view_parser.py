@@ -0,0 +1,92 @@
+"""
+View command parser for claude-mpm CLI.
+
+WHY: Saved views bring the dashboard back to a set of filters and a layout
+("blocked sessions across all projects") in one step. This parser lets users
+save, list and delete views, and open one in the browser.
+"""
+
+import argparse
+
+from ...services.dashboard_views import PROJECT_SCOPES, SINCE, TABS
+
+
+def add_view_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the view subparser with list, show, save, delete and open.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured view subparser
+    """
+    view_parser = subparsers.add_parser(
+        "view",
+        help="Save dashboard filter and layout combinations and open them",
+        description=(
+            "Saved views are named dashboard filters and layouts, stored in "
+            "~/.claude-mpm/dashboard-views.json and shared by every project. "
+            "Pick them from the dashboard's View menu or open one with "
+            "'claude-mpm view open <name>'."
+        ),
+    )
+    view_subparsers = view_parser.add_subparsers(
+        dest="view_command", help="View commands", metavar="SUBCOMMAND"
+    )
+
+    list_parser = view_subparsers.add_parser("list", help="List saved views")
+    list_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    show_parser = view_subparsers.add_parser("show", help="Show a saved view")
+    show_parser.add_argument("name", help="View name")
+    show_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    save_parser = view_subparsers.add_parser(
+        "save", help="Save a view (replaces a view of the same name)"
+    )
+    save_parser.add_argument("name", help="View name (letters, digits, '_', '-')")
+    save_parser.add_argument("--description", default="", help="What the view shows")
+    save_parser.add_argument(
+        "--tab", choices=TABS, default="events", help="Tab to show (default: events)"
+    )
+    save_parser.add_argument(
+        "--project",
+        choices=PROJECT_SCOPES,
+        default="current",
+        help="Sessions of the current project or all projects (default: current)",
+    )
+    save_parser.add_argument("--label", default="", help="Only sessions with a label")
+    save_parser.add_argument("--stream", default="", help="Only one session id")
+    save_parser.add_argument(
+        "--since",
+        choices=[value for value in SINCE if value],
+        default="",
+        help="Only events from the last hour or since midnight",
+    )
+    save_parser.add_argument(
+        "--left-width",
+        type=int,
+        metavar="PERCENT",
+        help="Width of the left panel in percent (10-90)",
+    )
+
+    delete_parser = view_subparsers.add_parser("delete", help="Delete a saved view")
+    delete_parser.add_argument("name", help="View name")
+
+    open_parser = view_subparsers.add_parser(
+        "open", help="Open the dashboard with a saved view"
+    )
+    open_parser.add_argument("name", help="View name")
+    open_parser.add_argument(
+        "--host", default="localhost", help="Monitor host (default: localhost)"
+    )
+    open_parser.add_argument(
+        "--port", type=int, default=8765, help="Monitor port (default: 8765)"
+    )
+    open_parser.add_argument(
+        "--no-browser",
+        action="store_true",
+        help="Print the view's URL instead of opening it",
+    )
+
+    return view_parser
@@ -0,0 +1,109 @@
+"""
+Work queue command parser for claude-mpm CLI.
+
+WHY: Delegated tasks can be spread across several worker machines through a
+shared broker (Redis, or SQLite on one host). This parser exposes submitting
+tasks, running a worker, and inspecting workers and tasks.
+"""
+
+import argparse
+
+from ..watch import add_watch_argument
+
+
+def add_work_queue_subparser(subparsers) -> argparse.ArgumentParser:
+    """Add the work-queue subparser.
+
+    Args:
+        subparsers: The subparsers object from the main parser
+
+    Returns:
+        The configured work-queue subparser
+    """
+    wq_parser = subparsers.add_parser(
+        "work-queue",
+        help="Distribute delegated tasks across worker machines",
+        description=(
+            "Queue agent tasks in a shared broker and run them on any number "
+            "of workers. Workers heartbeat to the broker; tasks held by a "
+            "worker that stops heartbeating are re-queued. The broker comes "
+            "from 'work_queue.broker' or CLAUDE_MPM_QUEUE_BROKER."
+        ),
+    )
+    wq_parser.add_argument(
+        "--broker",
+        help="Broker URL, e.g. redis://host:6379/0 or sqlite:///path/queue.db",
+    )
+    wq_parser.add_argument("--queue", help="Queue name (default: default)")
+    wq_subparsers = wq_parser.add_subparsers(
+        dest="work_queue_command", help="Work queue commands", metavar="SUBCOMMAND"
+    )
+
+    submit_parser = wq_subparsers.add_parser("submit", help="Queue a task")
+    submit_parser.add_argument("prompt", help="Task prompt")
+    location = submit_parser.add_mutually_exclusive_group()
+    location.add_argument(
+        "--cwd", help="Directory to run in, as seen from the worker machines"
+    )
+    location.add_argument("--repo", help="Git repository to clone and run in")
+    submit_parser.add_argument("--ref", help="Branch or tag of --repo")
+    submit_parser.add_argument("--model", help="Model for the session")
+    submit_parser.add_argument(
+        "--max-turns", type=int, dest="task_max_turns", help="Turn limit"
+    )
+    submit_parser.add_argument(
+        "--agent", help="Agent the task is for (selects its retry policy)"
+    )
+    submit_parser.add_argument(
+        "--type",
+        dest="task_type",
+        help="Task type (selects its retry policy, before --agent)",
+    )
+    submit_parser.add_argument(
+        "--after",
+        action="append",
+        dest="depends_on",
+        metavar="TASK_ID",
+        help="Run only after this task succeeded (can be used multiple times)",
+    )
+
+    worker_parser = wq_subparsers.add_parser(
+        "worker", help="Run a worker that pulls and runs tasks until stopped"
+    )
+    worker_parser.add_argument("--id", dest="worker_id", help="Worker id")
+    worker_parser.add_argument(
+        "--once", action="store_true", help="Exit when the queue is empty"
+    )
+    worker_parser.add_argument(
+        "--warm-sessions",
+        type=int,
+        metavar="N",
+        help=(
+            "Keep N sessions warm for tasks that run in this directory "
+            "(default: session_pool config; 0 disables)"
+        ),
+    )
+
+    status_parser = wq_subparsers.add_parser(
+        "status", help="Show workers and task counts"
+    )
+    status_parser.add_argument("--json", action="store_true", help="Output JSON")
+    add_watch_argument(status_parser)
+
+    tasks_parser = wq_subparsers.add_parser("tasks", help="List tasks")
+    tasks_parser.add_argument(
+        "--status",
+        dest="task_status",
+        choices=["pending", "running", "succeeded", "failed"],
+        help="Only tasks with this status",
+    )
+    tasks_parser.add_argument("--json", action="store_true", help="Output JSON")
+
+    show_parser = wq_subparsers.add_parser("show", help="Show one task and result")
+    show_parser.add_argument("task_id", help="Task id")
+
+    wq_subparsers.add_parser(
+        "requeue", help="Re-queue tasks held by workers that stopped heartbeating"
+    )
+
+    return wq_parser
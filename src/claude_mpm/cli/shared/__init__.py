@@ -15,6 +15,7 @@ from .argument_patterns import (
 )
 from .base_command import AgentCommand, BaseCommand, CommandResult, MemoryCommand
 from .error_handling import CLIErrorHandler, handle_cli_errors
+from .list_options import ListOptions, ListPage, add_list_arguments
 from .output_formatters import OutputFormatter, format_output
 
 __all__ = [
@@ -26,12 +27,16 @@ __all__ = [
     "CommandResult",
     # Argument patterns
     "CommonArguments",
+    # List paging and filtering
+    "ListOptions",
+    "ListPage",
     "MemoryCommand",
     # Output formatting
     "OutputFormatter",
     "add_agent_arguments",
     "add_common_arguments",
     "add_config_arguments",
+    "add_list_arguments",
     "add_logging_arguments",
     "add_memory_arguments",
     "add_output_arguments",
@@ -81,12 +81,15 @@ class BaseCommand(ABC):
         """Setup logging based on command arguments."""
         import logging
 
-        # Set log level based on arguments
-        if hasattr(args, "debug") and args.debug:
+        from ..verbosity import DEBUG, QUIET, VERBOSE, verbosity_from_args
+
+        # Set log level based on -q, -v, -vv and --debug
+        level = verbosity_from_args(args)
+        if level == DEBUG:
             logging.getLogger().setLevel(logging.DEBUG)
-        elif hasattr(args, "verbose") and args.verbose:
+        elif level == VERBOSE:
             logging.getLogger().setLevel(logging.INFO)
-        elif hasattr(args, "quiet") and args.quiet:
+        elif level == QUIET:
             logging.getLogger().setLevel(logging.WARNING)
 
     def load_config(self, args) -> None:
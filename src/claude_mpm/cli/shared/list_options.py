@@ -0,0 +1,221 @@
+"""
+Shared ``--limit``/``--offset``/``--filter`` handling for list commands.
+
+WHY: Each list command grew its own paging flags (``--limit`` here,
+``--event-limit`` there, ``--page`` elsewhere) and most had no way to filter
+at all. ``add_list_arguments`` gives every list command the same three flags
+and ``ListOptions`` applies them the same way.
+
+SEMANTICS (identical for every list command):
+- ``--filter key=value`` keeps items whose field matches the value. Matching
+  is case-insensitive and ``*``/``?`` are wildcards. List fields (tags) match
+  when any element does.
+- Different keys must all match; repeating a key matches any of its values
+  (``--filter status=open --filter status=blocked``).
+- Filters apply first, then ``--offset`` skips items, then ``--limit`` caps
+  what is left. Totals and "next page" hints count filtered items.
+"""
+
+from __future__ import annotations
+
+import argparse
+import enum
+import fnmatch
+from collections.abc import Iterable, Mapping, Sequence
+from dataclasses import dataclass, field
+from typing import Any
+
+
+def _non_negative_int(value: str) -> int:
+    try:
+        number = int(value)
+    except ValueError:
+        raise argparse.ArgumentTypeError(f"expected a number, got '{value}'") from None
+    if number < 0:
+        raise argparse.ArgumentTypeError(f"must be 0 or more, got {number}")
+    return number
+
+
+def _positive_int(value: str) -> int:
+    number = _non_negative_int(value)
+    if number == 0:
+        raise argparse.ArgumentTypeError("must be 1 or more")
+    return number
+
+
+def filter_type(fields: Mapping[str, str]):
+    """An argparse ``type`` that parses ``key=value`` against *fields*.
+
+    *fields* maps the key users type to the item field it reads (dotted for
+    nested fields, e.g. ``{"type": "metadata.ticket_type"}``). The parsed
+    value is ``(field_path, value)``.
+    """
+
+    def parse(text: str) -> tuple[str, str]:
+        key, sep, value = text.partition("=")
+        key = key.strip()
+        if not sep or not key:
+            raise argparse.ArgumentTypeError(
+                f"expected key=value, got '{text}' (keys: {', '.join(fields)})"
+            )
+        if key not in fields:
+            raise argparse.ArgumentTypeError(
+                f"unknown filter key '{key}' (keys: {', '.join(fields)})"
+            )
+        return fields[key], value.strip()
+
+    return parse
+
+
+def add_list_arguments(
+    parser: argparse.ArgumentParser,
+    fields: Mapping[str, str] | Sequence[str],
+    *,
+    default_limit: int | None = None,
+    limit_flags: Sequence[str] = ("--limit",),
+    noun: str = "items",
+) -> None:
+    """Add ``--limit``, ``--offset`` and ``--filter`` to a list parser.
+
+    Args:
+        parser: The list subcommand's parser.
+        fields: Filterable keys, or a mapping of key to item field path.
+        default_limit: ``--limit`` default; ``None`` shows everything.
+        limit_flags: Flags for ``--limit``, to keep an existing short alias.
+        noun: What the command lists, for help text.
+    """
+    if not isinstance(fields, Mapping):
+        fields = {key: key for key in fields}
+    default = f" (default: {default_limit})" if default_limit else ""
+    parser.add_argument(
+        *limit_flags,
+        dest="limit",
+        type=_positive_int,
+        default=default_limit,
+        metavar="N",
+        help=f"Show at most N {noun}{default}",
+    )
+    parser.add_argument(
+        "--offset",
+        type=_non_negative_int,
+        default=0,
+        metavar="N",
+        help=f"Skip the first N {noun} (after filtering)",
+    )
+    parser.add_argument(
+        "--filter",
+        dest="filters",
+        action="append",
+        type=filter_type(fields),
+        metavar="KEY=VALUE",
+        help=(
+            "Keep matches; * and ? are wildcards, repeat to combine "
+            f"(keys: {', '.join(fields)})"
+        ),
+    )
+
+
+def field_value(item: Any, path: str) -> Any:
+    """Read a dotted *path* from a dict or object; ``None`` when missing."""
+    value = item
+    for part in path.split("."):
+        if isinstance(value, Mapping):
+            value = value.get(part)
+        else:
+            value = getattr(value, part, None)
+        if value is None:
+            return None
+    return value
+
+
+def _matches(value: Any, pattern: str) -> bool:
+    if isinstance(value, (list, tuple, set, frozenset)):
+        return any(_matches(element, pattern) for element in value)
+    if isinstance(value, enum.Enum):
+        value = value.value
+    if isinstance(value, bool):
+        value = str(value).lower()
+    text = "" if value is None else str(value)
+    return fnmatch.fnmatchcase(text.lower(), pattern.lower())
+
+
+@dataclass
+class ListPage:
+    """One page of a filtered list."""
+
+    items: list[Any]
+    total: int
+    offset: int = 0
+    limit: int | None = None
+
+    @property
+    def has_more(self) -> bool:
+        return self.offset + len(self.items) < self.total
+
+    def summary(self, noun: str = "items") -> str:
+        """E.g. ``Showing 11-20 of 53 sessions``."""
+        if not self.items:
+            return f"Showing 0 of {self.total} {noun}"
+        first = self.offset + 1
+        last = self.offset + len(self.items)
+        return f"Showing {first}-{last} of {self.total} {noun}"
+
+    def next_hint(self, command: str) -> str | None:
+        """The command line for the next page, or ``None`` on the last page."""
+        if not self.has_more:
+            return None
+        next_offset = self.offset + len(self.items)
+        return f"Next page: {command} --offset {next_offset} --limit {self.limit}"
+
+
+@dataclass
+class ListOptions:
+    """Parsed ``--limit``/``--offset``/``--filter`` values."""
+
+    limit: int | None = None
+    offset: int = 0
+    filters: dict[str, list[str]] = field(default_factory=dict)
+
+    @classmethod
+    def from_args(cls, args: Any, default_limit: int | None = None) -> ListOptions:
+        """Read the flags from *args*; values that are missing or of the wrong
+        type (e.g. args built without these flags) fall back to defaults."""
+        limit = getattr(args, "limit", None)
+        offset = getattr(args, "offset", 0)
+        filters: dict[str, list[str]] = {}
+        parsed = getattr(args, "filters", None)
+        if isinstance(parsed, list):
+            for entry in parsed:
+                if isinstance(entry, tuple) and len(entry) == 2:
+                    filters.setdefault(entry[0], []).append(entry[1])
+        return cls(
+            limit=limit if _is_int(limit) else default_limit,
+            offset=offset if _is_int(offset) else 0,
+            filters=filters,
+        )
+
+    @property
+    def active(self) -> bool:
+        """Whether ``--offset`` or ``--filter`` was given."""
+        return bool(self.offset or self.filters)
+
+    def matches(self, item: Any) -> bool:
+        return all(
+            any(_matches(field_value(item, path), value) for value in values)
+            for path, values in self.filters.items()
+        )
+
+    def apply(self, items: Iterable[Any]) -> ListPage:
+        """Filter, then skip ``offset`` items, then keep ``limit`` of them."""
+        kept = [item for item in items if self.matches(item)]
+        end = None if self.limit is None else self.offset + self.limit
+        return ListPage(
+            items=kept[self.offset : end],
+            total=len(kept),
+            offset=self.offset,
+            limit=self.limit,
+        )
+
+
+def _is_int(value: Any) -> bool:
+    return isinstance(value, int) and not isinstance(value, bool)
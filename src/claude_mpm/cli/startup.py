@@ -1517,6 +1517,14 @@ def sync_remote_agents_on_startup(force_sync: bool = False):
                 len(pipeline_config.enabled_agents),
             )
 
+        # Pin the deployed agents before the sync fetches newer templates, so
+        # that only 'agents update' upgrades them
+        from ..services.agents.agents_lock import AgentsLock
+
+        agent_cache = Path.home() / ".claude-mpm" / "cache" / "agents"
+        agents_lock = AgentsLock(project_root)
+        agents_lock.pin_deployed(project_root / ".claude" / "agents", agent_cache)
+
         # Phase 1: Sync files from Git sources
         result = sync_agents_on_startup(force_refresh=force_sync)
 
@@ -1641,6 +1649,13 @@ def sync_remote_agents_on_startup(force_sync: bool = False):
                         )
                         print("   Run with --verbose for detailed error information.\n")
 
+                updates = agents_lock.updates(agent_cache)
+                if updates and sys.stdout.isatty():
+                    print(
+                        f"ℹ️  {len(updates)} pinned agent(s) have updates; "
+                        "run 'claude-mpm agents update' to review them"
+                    )
+
                 # Save deployment state to prevent duplicate deployment in ClaudeRunner
                 # This ensures setup_agents() skips deployment since we already reconciled
                 _save_deployment_state_after_reconciliation(
@@ -1734,6 +1749,7 @@ def sync_remote_skills_on_startup(force_sync: bool = False):
             get_skills_to_deploy,
             save_agent_skills_to_config,
         )
+        from ..services.skills.skills_lock import SkillsLock
         from ..utils.progress import ProgressBar
 
         # Load active profile if configured
@@ -1741,9 +1757,13 @@ def sync_remote_skills_on_startup(force_sync: bool = False):
         project_root = Path.cwd()
 
         profile_manager = ProfileManager(project_dir=project_root)
-        config_loader = ConfigLoader()
-        main_config = config_loader.load_main_config()
-        active_profile = main_config.get("active_profile")
+        # The project's .claude-mpm/configuration.yaml selects its own profile;
+        # the main config's active_profile is the fallback
+        active_profile = ProfileManager.configured_profile(project_root)
+        if not active_profile:
+            config_loader = ConfigLoader()
+            main_config = config_loader.load_main_config()
+            active_profile = main_config.get("active_profile")
 
         if active_profile:
             success = profile_manager.load_profile(active_profile)
@@ -1759,7 +1779,8 @@ def sync_remote_skills_on_startup(force_sync: bool = False):
                 )
 
         config = SkillSourceConfiguration()
-        manager = GitSkillSourceManager(config)
+        # Sync the commits pinned in the project's skills.lock, not branch heads
+        manager = GitSkillSourceManager(config, lock=SkillsLock(project_root))
 
         # Get enabled sources
         enabled_sources = config.get_enabled_sources()
@@ -1900,10 +1921,20 @@ def sync_remote_skills_on_startup(force_sync: bool = False):
             if skills_to_deploy:
                 # Filter the resolved skill list
                 original_count = len(skills_to_deploy)
+                profile_names = {
+                    skill["name"]
+                    for skill in profile_manager.filter_skills(
+                        manager.get_all_skills()
+                    )
+                }
                 filtered_skills = [
                     skill
                     for skill in skills_to_deploy
                     if profile_manager.is_skill_enabled(skill)
+                    and (
+                        not profile_manager.get_skill_sources()
+                        or skill in profile_names
+                    )
                 ]
                 filtered_count = original_count - len(filtered_skills)
 
@@ -1925,9 +1956,7 @@ def sync_remote_skills_on_startup(force_sync: bool = False):
                 # No explicit skill list - filter from all available
                 all_skills = manager.get_all_skills()
                 filtered_skills = [
-                    skill["name"]
-                    for skill in all_skills
-                    if profile_manager.is_skill_enabled(skill["name"])
+                    skill["name"] for skill in profile_manager.filter_skills(all_skills)
                 ]
                 skills_to_deploy = filtered_skills
                 skill_source = "profile filtered"
@@ -2220,6 +2249,57 @@ def show_skill_summary():
         logger.debug(f"Failed to generate skill summary: {e}")
 
 
+def show_skill_update_notice(no_sync: bool = False) -> None:
+    """Tell the user when deployed skills have updates in their sources.
+
+    The notice comes from the last saved check, so startup never waits on
+    the network; a new check runs in the background once per sync TTL.
+    Disabled with ``skills.update_notice: false``.
+    """
+    try:
+        from ..services.skills.skill_updates import (
+            load_report,
+            notice_enabled,
+            refresh_report_in_background,
+            update_notice,
+        )
+
+        if not notice_enabled():
+            return
+        notice = update_notice(load_report())
+        if notice and sys.stdout.isatty():
+            print(f"ℹ Skills: {notice}", flush=True)
+        if not no_sync and not _is_sync_fresh("skill_updates"):
+            refresh_report_in_background()
+            _mark_sync_done("skill_updates")
+    except Exception as e:
+        from ..core.logger import get_logger
+
+        get_logger("cli").debug(f"Skill update notice failed: {e}")
+
+
+def show_advisory_notice(no_sync: bool = False) -> None:
+    """Tell the user when security advisories affect the project's pins.
+
+    The notice comes from the last check; a new check runs as a detached
+    process once per ``advisories.interval_hours`` when
+    ``advisories.enabled`` is set.
+    """
+    try:
+        from ..services.advisories import advisory_notice, check_in_background
+
+        project_dir = Path.cwd()
+        notice = advisory_notice(project_dir)
+        if notice and sys.stdout.isatty():
+            print(f"⚠ Security: {notice}", flush=True)
+        if not no_sync:
+            check_in_background(project_dir)
+    except Exception as e:
+        from ..core.logger import get_logger
+
+        get_logger("cli").debug(f"Advisory check failed to start: {e}")
+
+
 def verify_and_show_pm_skills():
     """Verify PM skills and display status with enhanced validation.
 
@@ -2400,7 +2480,8 @@ def sync_deployment_on_startup(force_sync: bool = False, no_sync: bool = False)
     Order:
     1. Hook cleanup (remove ~/.claude/hooks/claude-mpm/)
     2. Hook reinstall (update .claude/settings.local.json)
-    3. Agent sync from remote Git sources
+    3. Integrity check of deployed agents and skills
+    4. Agent sync from remote Git sources
 
     Args:
         force_sync: Force download even if cache is fresh (bypasses ETag).
@@ -2413,6 +2494,15 @@ def sync_deployment_on_startup(force_sync: bool = False, no_sync: bool = False)
     # Step 1-2: Hooks (cleanup + reinstall handled by sync_hooks_on_startup)
     sync_hooks_on_startup()  # Shows "Syncing Claude Code hooks... ✓"
 
+    # Step 2b: Verify deployed agents/skills against the integrity manifest
+    # before the sync below quietly overwrites out-of-band modifications
+    try:
+        from ..services.deployment_integrity import verify_on_startup
+
+        verify_on_startup()
+    except Exception:
+        pass  # Non-fatal — verification must never block startup
+
     # Step 3: Agents from remote sources (skip if --no-sync requested)
     if no_sync:
         from ..core.logger import get_logger as _get_logger
@@ -2423,6 +2513,36 @@ def sync_deployment_on_startup(force_sync: bool = False, no_sync: bool = False)
     show_agent_summary()  # Display agent counts after deployment
 
 
+def resolve_merge_conflicts_on_startup(interactive: bool = True) -> None:
+    """Offer to resolve agent/skill updates that conflicted with local edits.
+
+    Runs after the startup progress bar is gone, so the prompt is readable.
+    Without a terminal (or in headless mode) it only warns.
+    """
+    try:
+        from ..services.deployment_merge import pending_conflicts
+
+        roots = dict.fromkeys([Path.cwd().resolve(), Path.home()])
+        conflicts = [c for root in roots for c in pending_conflicts(root)]
+        if not conflicts:
+            return
+        if interactive and sys.stdin.isatty() and sys.stdout.isatty():
+            from .commands.integrity import prompt_resolutions
+
+            prompt_resolutions(conflicts)
+            return
+        print(
+            f"⚠️  {len(conflicts)} agent/skill update(s) conflict with local "
+            "edits and were not applied:",
+            file=sys.stderr,
+        )
+        for conflict in conflicts[:10]:
+            print(f"   {conflict.path}", file=sys.stderr)
+        print("   Resolve with 'claude-mpm integrity resolve'", file=sys.stderr)
+    except Exception:
+        pass  # Non-fatal — conflicts stay pending for the next run
+
+
 def generate_dynamic_domain_authority_skills():
     """Generate dynamic skills for agent and tool selection.
 
@@ -2547,6 +2667,29 @@ def run_background_services(
         _step("Checking for updates")
         check_for_updates_async()
 
+        # Refresh the organization's feature flag config, off the startup path
+        if not _is_sync_fresh("org_flags"):
+            try:
+                from ..services.feature_flags import refresh_org_flags_in_background
+
+                refresh_org_flags_in_background()
+                _mark_sync_done("org_flags")
+            except Exception:
+                pass  # Non-fatal — the cached org config stays in effect
+
+        # Apply storage retention policies once a day (when storage.auto_prune
+        # is on), off the startup path
+        if not _is_sync_fresh("storage_prune"):
+            try:
+                from ..services.storage_retention import prune_in_background
+
+                prune_in_background()
+                _mark_sync_done("storage_prune")
+            except Exception:
+                pass  # Non-fatal — pruning is retried on the next startup
+
+        show_advisory_notice(no_sync=no_sync)
+
         # Skills deployment order (precedence: remote > bundled)
         # 1. Deploy bundled skills first (base layer from package) — TTL: 24h
         # 2. Sync and deploy remote skills (Git sources, can override bundled) — TTL: 1h
@@ -2576,6 +2719,7 @@ def run_background_services(
             _step("Discovering skills")
             discover_and_link_runtime_skills()  # Discovery: user-added skills
             show_skill_summary()  # Display skill counts after deployment
+        show_skill_update_notice(no_sync=no_sync)
 
         # Generate dynamic domain authority skills for PM
         _step("Building domain skills")
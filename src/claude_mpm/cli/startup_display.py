@@ -14,19 +14,21 @@ from pathlib import Path
 from claude_mpm.core.logging_utils import get_logger
 from claude_mpm.services.trusty_status import get_trusty_status
 from claude_mpm.utils.git_analyzer import is_git_repository
+from claude_mpm.utils.theme import get_theme
 
 logger = get_logger(__name__)
 
-# ANSI color codes
-CYAN = "\033[36m"  # Cyan for header highlight (Claude Code style)
-DIM = "\033[2m"  # Dim text for subtle launch message
-RESET = "\033[0m"
 
 # Banner dimension defaults (will be calculated based on terminal width)
 NARROW_WIDTH_THRESHOLD = 60  # Below this, use compact single-line banner
 DEFAULT_WIDTH = 80  # Default if terminal width cannot be determined
 
 
+def _accent(text: str) -> str:
+    """Header highlight (cyan in the default theme, Claude Code style)."""
+    return get_theme().ansi("info", text)
+
+
 def _get_terminal_width() -> int:
     """
     Get the full terminal width.
@@ -188,9 +190,9 @@ def _parse_changelog_highlights(max_items: int = 3, max_width: int = 130) -> lis
 def _get_alien_art() -> list[str]:
     """Return multi-alien ASCII art with teal/cyan highlighting."""
     return [
-        f"{CYAN}▐▛███▜▌ ▐▛███▜▌{RESET}",  # Two aliens - Width: 15 chars
-        f"{CYAN}▝▜█████▛▘▝▜█████▛▘{RESET}",  # Two aliens base - Width: 18 chars
-        f"{CYAN}▘▘ ▝▝    ▘▘ ▝▝{RESET}",  # Two aliens feet - Width: 14 chars
+        _accent("▐▛███▜▌ ▐▛███▜▌"),  # Two aliens - Width: 15 chars
+        _accent("▝▜█████▛▘▝▜█████▛▘"),  # Two aliens base - Width: 18 chars
+        _accent("▘▘ ▝▝    ▘▘ ▝▝"),  # Two aliens feet - Width: 14 chars
     ]
 
 
@@ -472,7 +474,7 @@ def display_startup_banner(
 
     # Narrow terminal: compact single-line banner
     if terminal_width < NARROW_WIDTH_THRESHOLD:
-        print(f"{CYAN}Claude MPM v{version}{RESET}")
+        print(_accent(f"Claude MPM v{version}"))
         _, ztk_message = _get_ztk_status()
         print(ztk_message)
         # Trusty daemon connection status (#598) — suppressed services return
@@ -496,7 +498,7 @@ def display_startup_banner(
     # Build header line with cyan highlight (Claude Code style)
     header = f"─── Claude MPM v{version} "
     header_padding = "─" * (terminal_width - len(header) - 2)  # -2 for ╭╮
-    top_line = f"╭{CYAN}{header}{header_padding}{RESET}╮"
+    top_line = f"╭{_accent(header + header_padding)}╮"
 
     # Build content lines (plain text, no color)
     lines = []
@@ -756,6 +758,9 @@ def should_show_banner(args) -> bool:
         return False
     if hasattr(args, "version") and args.version:
         return False
+    # -q prints only errors and the command's result
+    if hasattr(args, "quiet") and args.quiet:
+        return False
 
     # Check for commands that should skip banner
     # Lightweight commands are fast utilities that should run immediately
@@ -769,10 +774,15 @@ def should_show_banner(args) -> bool:
     if hasattr(args, "command"):
         command = args.command
 
-        # Skip for agents list
+        # Skip for agents list and capabilities (read by external tooling)
         if command == "agents":
             agents_cmd = getattr(args, "agents_command", None)
-            if agents_cmd == "list":
+            if agents_cmd in ("list", "capabilities"):
+                return False
+            # A deploy dry run prints the rendered agents to stdout
+            if agents_cmd == "deploy" and (
+                getattr(args, "dry_run", False) or getattr(args, "stage_dir", None)
+            ):
                 return False
 
         # Skip for skills list
@@ -2057,6 +2057,16 @@ def run_migrations() -> list[str]:
     """
     applied_migrations: list[str] = []
 
+    # Versioned ~/.claude-mpm state first: later migrations may read it.
+    # Backs up and rolls back on its own; never raises.
+    from ..migrations.state import run_state_migrations
+
+    try:
+        from .. import __version__ as package_version
+    except ImportError:
+        package_version = None
+    applied_migrations.extend(run_state_migrations(package_version))
+
     for migration in MIGRATIONS:
         try:
             # Skip if already completed
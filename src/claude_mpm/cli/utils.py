@@ -169,9 +169,11 @@ def setup_logging(args) -> logging.Logger:
     if not hasattr(args, "logging") or args.logging is None:
         args.logging = LogLevel.OFF.value
 
-    # Handle deprecated --verbose flag
+    # -v enables INFO logging, -vv DEBUG (unless --logging was given)
     if hasattr(args, "verbose") and args.verbose and args.logging == LogLevel.OFF.value:
-        args.logging = LogLevel.INFO.value
+        args.logging = (
+            LogLevel.DEBUG.value if args.verbose >= 2 else LogLevel.INFO.value
+        )
 
     # Handle deprecated --debug flag
     if hasattr(args, "debug") and args.debug:
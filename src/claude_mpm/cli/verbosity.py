@@ -0,0 +1,135 @@
+"""Output verbosity levels for CLI commands.
+
+WHAT: Every command takes the same verbosity flags (see
+``add_common_arguments``):
+
+    -q/--quiet   QUIET    errors and the command's result (IDs, paths) only
+    (default)    NORMAL
+    -v           VERBOSE  extra detail and INFO logging
+    -vv          DEBUG    DEBUG logging (same as --debug)
+
+In quiet mode ``CommandOutput`` holds back everything written to stdout while
+the command runs; only ``emit_result()`` reaches stdout. If the command fails,
+the held-back output is written to stderr so the reason is not lost.
+
+WHY: Commands print banners, progress bars and emoji summaries, which breaks
+shell pipelines such as ``ID=$(claude-mpm skill-source add URL -q)``.
+
+DESIGN DECISIONS:
+- Output is held back by swapping ``sys.stdout`` rather than threading a flag
+  through every print; rich Consoles created without a file write to the
+  current ``sys.stdout``, so they follow
+- ``--format json``/``yaml`` output is the result itself, so quiet mode leaves
+  stdout alone for those
+- Interactive commands (``run``, ``configure``) own the terminal and are never
+  held back
+"""
+
+from __future__ import annotations
+
+import io
+import sys
+from typing import Any, TextIO
+
+from .command_config import INTERACTIVE_COMMANDS
+
+QUIET = 0
+NORMAL = 1
+VERBOSE = 2
+DEBUG = 3
+
+MACHINE_FORMATS = {"json", "yaml"}
+
+_result_stream: TextIO | None = None
+
+
+def verbosity_from_args(args: Any) -> int:
+    """The verbosity level selected by -q, -v, -vv and --debug."""
+    if getattr(args, "debug", False):
+        return DEBUG
+    if getattr(args, "quiet", False):
+        return QUIET
+    verbose = int(getattr(args, "verbose", 0) or 0)
+    return min(NORMAL + verbose, DEBUG)
+
+
+def emit_result(*values: Any) -> None:
+    """Print the command's primary result; shown even with --quiet."""
+    print(*values, file=_result_stream or sys.stdout, flush=True)
+
+
+def emit_quiet_result(*values: Any) -> None:
+    """Print the result on its own line, only when --quiet holds output back.
+
+    For commands whose normal output already mentions the result inside a
+    friendlier message (``✅ Added skill source: my-skills``).
+    """
+    if _result_stream is not None:
+        print(*values, file=_result_stream, flush=True)
+
+
+def _wants_machine_output(args: Any) -> bool:
+    for attr in ("format", "output_format"):
+        value = getattr(args, attr, None)
+        if str(getattr(value, "value", value)).lower() in MACHINE_FORMATS:
+            return True
+    return False
+
+
+class CommandOutput:
+    """Applies the verbosity level to one command invocation.
+
+    Usage::
+
+        output = CommandOutput.start(args)
+        exit_code = execute_command(args.command, args)
+        return output.finish(exit_code)
+    """
+
+    def __init__(self, level: int, hold: bool):
+        self.level = level
+        self._stdout: TextIO | None = None
+        self._held: io.TextIOWrapper | None = None
+        if hold:
+            self._hold()
+
+    @classmethod
+    def start(cls, args: Any) -> CommandOutput:
+        level = verbosity_from_args(args)
+        command = getattr(args, "command", None)
+        hold = (
+            level == QUIET
+            and command not in INTERACTIVE_COMMANDS
+            and command is not None
+            and not _wants_machine_output(args)
+        )
+        return cls(level, hold)
+
+    @property
+    def quiet(self) -> bool:
+        return self.level == QUIET
+
+    def finish(self, exit_code: int | None) -> int | None:
+        """Restore stdout; on failure, replay held-back output to stderr."""
+        global _result_stream
+        if self._held is None:
+            return exit_code
+        sys.stdout = self._stdout
+        _result_stream = None
+        self._held.flush()
+        held = self._held.buffer.getvalue().decode("utf-8", errors="replace")
+        self._held = None
+        if exit_code and held:
+            sys.stderr.write(held)
+            sys.stderr.flush()
+        return exit_code
+
+    def _hold(self) -> None:
+        global _result_stream
+        self._stdout = sys.stdout
+        _result_stream = sys.stdout
+        # A real text layer over bytes, so code writing to sys.stdout.buffer works
+        self._held = io.TextIOWrapper(
+            io.BytesIO(), encoding="utf-8", write_through=True
+        )
+        sys.stdout = self._held
@@ -0,0 +1,138 @@
+"""Watch mode for status commands.
+
+WHAT: ``add_watch_argument`` gives a status or list subcommand a
+``--watch [SECONDS]`` flag. When it is set, ``main()`` hands the command to
+``watch()``, which re-runs it every interval and redraws its output in place
+under a one-line header, like ``watch(1)``, until Ctrl+C.
+
+WHY: Keeping an eye on a batch, the work queue or captured sessions meant
+re-running the command by hand or opening the full dashboard.
+
+DESIGN DECISIONS:
+- Commands need no changes: each refresh runs the normal handler with stdout
+  captured, so a command only has to be safe to run repeatedly
+- The screen is redrawn from the top and each line is cleared as it is
+  written, instead of clearing the whole screen first, so it doesn't flicker
+- When stdout is not a terminal, refreshes are appended one after another
+  (there is nothing to redraw in a log file)
+- A failing refresh keeps watching and shows its exit code in the header;
+  Ctrl+C ends the watch with exit code 0
+"""
+
+from __future__ import annotations
+
+import argparse
+import contextlib
+import io
+import sys
+import time
+from collections.abc import Callable
+from datetime import datetime
+from typing import Any, TextIO
+
+DEFAULT_INTERVAL = 2.0
+MIN_INTERVAL = 0.5
+
+_HOME = "\x1b[H"
+_CLEAR_LINE = "\x1b[K"
+_CLEAR_BELOW = "\x1b[J"
+
+
+def _interval(value: str) -> float:
+    try:
+        seconds = float(value)
+    except ValueError:
+        raise argparse.ArgumentTypeError(f"expected seconds, got '{value}'") from None
+    if seconds < MIN_INTERVAL:
+        raise argparse.ArgumentTypeError(f"must be at least {MIN_INTERVAL} seconds")
+    return seconds
+
+
+def add_watch_argument(parser: argparse.ArgumentParser) -> None:
+    """Add ``--watch [SECONDS]`` to a subcommand that is safe to re-run."""
+    parser.add_argument(
+        "--watch",
+        nargs="?",
+        const=DEFAULT_INTERVAL,
+        default=None,
+        type=_interval,
+        metavar="SECONDS",
+        help=(
+            "Refresh the output in place every SECONDS "
+            f"(default: {DEFAULT_INTERVAL:g}) until Ctrl+C"
+        ),
+    )
+
+
+def watch_interval(args: Any) -> float | None:
+    """The ``--watch`` interval in *args*, or ``None`` when not watching."""
+    value = getattr(args, "watch", None)
+    if isinstance(value, (int, float)) and not isinstance(value, bool):
+        return float(value)
+    return None
+
+
+def _capture(run: Callable[[], int | None]) -> tuple[int, str]:
+    buffer = io.StringIO()
+    with contextlib.redirect_stdout(buffer):
+        try:
+            code = run()
+        except Exception as e:  # keep watching through a failed refresh
+            print(f"Error: {e}")
+            code = 1
+    return (code or 0), buffer.getvalue()
+
+
+def watch(
+    run: Callable[[], int | None],
+    interval: float,
+    title: str,
+    *,
+    stream: TextIO | None = None,
+    sleep: Callable[[float], None] = time.sleep,
+    now: Callable[[], datetime] = datetime.now,
+    refreshes: int | None = None,
+) -> int:
+    """Re-run *run* every *interval* seconds, redrawing its output.
+
+    Args:
+        run: Runs the command once, printing to stdout; returns its exit code.
+        interval: Seconds between refreshes.
+        title: Shown in the header, usually the command line.
+        stream: Where to draw (default: the current ``sys.stdout``).
+        sleep, now: Injectable for tests.
+        refreshes: Stop after this many refreshes (default: until Ctrl+C).
+
+    Returns:
+        0 when stopped with Ctrl+C, otherwise the last refresh's exit code.
+    """
+    stream = sys.stdout if stream is None else stream
+    try:
+        in_place = stream.isatty()
+    except (AttributeError, ValueError):
+        in_place = False
+
+    code = 0
+    count = 0
+    try:
+        while refreshes is None or count < refreshes:
+            code, output = _capture(run)
+            count += 1
+            status = "" if code == 0 else f"  [exit {code}]"
+            header = (
+                f"Every {interval:g}s: {title}{status}"
+                f"    {now():%Y-%m-%d %H:%M:%S}  (Ctrl+C to stop)"
+            )
+            lines = [header, "", *output.rstrip("\n").splitlines()]
+            if in_place:
+                body = "".join(f"{line}{_CLEAR_LINE}\n" for line in lines)
+                stream.write(f"{_HOME}{body}{_CLEAR_BELOW}")
+            else:
+                stream.write("\n".join(lines) + "\n\n")
+            stream.flush()
+            if refreshes is not None and count >= refreshes:
+                break
+            sleep(interval)
+    except KeyboardInterrupt:
+        return 0
+    return code
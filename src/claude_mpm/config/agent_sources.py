@@ -1,11 +1,13 @@
 """Configuration for agent sources (Git repositories)."""
 
 import logging
+from collections.abc import Callable
 from dataclasses import dataclass, field
 from pathlib import Path
 
 import yaml
 
+from claude_mpm.core.state_files import state_lock, write_atomic
 from claude_mpm.models.git_repository import GitRepository
 
 logger = logging.getLogger(__name__)
@@ -111,6 +113,9 @@ class AgentSourceConfiguration:
                     branch=repo_data.get("branch", "main"),
                     enabled=repo_data.get("enabled", True),
                     priority=repo_data.get("priority", 100),
+                    token=repo_data.get("token"),
+                    ssh_key=repo_data.get("ssh_key"),
+                    provider=repo_data.get("provider"),
                 )
                 repositories.append(repo)
 
@@ -155,6 +160,9 @@ class AgentSourceConfiguration:
                 repo_dict["branch"] = repo.branch
             repo_dict["enabled"] = repo.enabled
             repo_dict["priority"] = repo.priority
+            for key in ("token", "ssh_key", "provider"):
+                if getattr(repo, key):
+                    repo_dict[key] = getattr(repo, key)
             repos_data.append(repo_dict)
 
         data = {
@@ -162,48 +170,61 @@ class AgentSourceConfiguration:
             "repositories": repos_data,
         }
 
+        # Header comments if requested
+        header = ""
+        if include_comments:
+            header = (
+                "# Claude MPM Agent Sources Configuration\n"
+                "#\n"
+                "# This file configures where Claude MPM discovers agent templates.\n"
+                "# Git sources are the recommended approach for agent management.\n"
+                "#\n"
+                "# disable_system_repo: Set to true to use git sources instead of "
+                "built-in templates\n"
+                "# repositories: List of git repositories containing agent markdown "
+                "files\n"
+                "#\n"
+                "# Default repository: https://github.com/bobmatnyc/claude-mpm-agents\n"
+                "#\n\n"
+            )
+        content = header + yaml.safe_dump(
+            data, default_flow_style=False, sort_keys=False
+        )
+
         try:
-            # Write atomically: write to temp file, then rename
-            temp_path = config_path.with_suffix(".yaml.tmp")
-            with open(temp_path, "w") as f:
-                # Write header comments if requested
-                if include_comments:
-                    f.write("# Claude MPM Agent Sources Configuration\n")
-                    f.write("#\n")
-                    f.write(
-                        "# This file configures where Claude MPM discovers agent templates.\n"
-                    )
-                    f.write(
-                        "# Git sources are the recommended approach for agent management.\n"
-                    )
-                    f.write("#\n")
-                    f.write(
-                        "# disable_system_repo: Set to true to use git sources instead of built-in templates\n"
-                    )
-                    f.write(
-                        "# repositories: List of git repositories containing agent markdown files\n"
-                    )
-                    f.write("#\n")
-                    f.write(
-                        "# Default repository: https://github.com/bobmatnyc/claude-mpm-agents\n"
-                    )
-                    f.write("#\n\n")
-
-                yaml.safe_dump(data, f, default_flow_style=False, sort_keys=False)
-
-            # Atomic rename
-            temp_path.replace(config_path)
+            # Write atomically under the file lock: a unique temp file, then rename
+            with state_lock(config_path):
+                write_atomic(config_path, content)
 
             logger.info(f"Configuration saved to {config_path}")
 
         except Exception as e:
             logger.error(f"Failed to save configuration to {config_path}: {e}")
-            # Clean up temp file if it exists
-            temp_path = config_path.with_suffix(".yaml.tmp")
-            if temp_path.exists():
-                temp_path.unlink()
             raise
 
+    @classmethod
+    def update(
+        cls,
+        mutate: Callable[["AgentSourceConfiguration"], None],
+        config_path: Path | None = None,
+    ) -> "AgentSourceConfiguration":
+        """Apply *mutate* to the saved configuration as one locked step.
+
+        WHY: ``load()`` ... ``save()`` in a command writes back whatever it
+        loaded, undoing a source another claude-mpm process added meanwhile.
+        Re-reading under the lock and applying just this change avoids that.
+
+        Example:
+            >>> AgentSourceConfiguration.update(lambda c: c.add_repository(repo))
+        """
+        if config_path is None:
+            config_path = Path.home() / ".claude-mpm" / "config" / "agent_sources.yaml"
+        with state_lock(config_path):
+            config = cls.load(config_path)
+            mutate(config)
+            config.save(config_path)
+        return config
+
     def get_system_repo(self) -> GitRepository | None:
         """Get system repository if not disabled.
 
@@ -12,7 +12,7 @@ Supports loading from YAML files and environment variables with sensible default
 CONFIGURATION STRUCTURE:
 ```yaml
 content_agent:
-  model_provider: auto  # auto|ollama|claude|privacy
+  model_provider: auto  # auto|ollama|claude|privacy|mock
 
   ollama:
     enabled: true
@@ -31,6 +31,10 @@ content_agent:
     model: sonnet
     max_tokens: 4096
     temperature: 0.7
+
+  mock:
+    recording: tests/fixtures/session.json  # fixture or transcript .jsonl
+    latency: 0
 ```
 
 ENVIRONMENT VARIABLES:
@@ -39,6 +43,7 @@ ENVIRONMENT VARIABLES:
 - OLLAMA_ENABLED: Enable/disable Ollama
 - CLAUDE_ENABLED: Enable/disable Claude
 - ANTHROPIC_API_KEY: Claude API key
+- MOCK_RECORDING: Recording replayed by the mock provider
 """
 
 import os
@@ -114,6 +119,30 @@ class ClaudeConfig(BaseModel):
         extra = "allow"
 
 
+class MockConfig(BaseModel):
+    """
+    Configuration for the mock (replay) provider.
+
+    WHY: Lets tests and local development point the router at a recorded
+    session instead of a live model.
+    """
+
+    recording: str | None = Field(
+        default=None,
+        description="Fixture file or Claude Code transcript to replay",
+    )
+    model: str = Field(default="mock", description="Reported model name")
+    latency: float = Field(
+        default=0.0,
+        description="Artificial response delay in seconds",
+    )
+
+    class Config:
+        """Pydantic config."""
+
+        extra = "allow"
+
+
 class ModelProviderConfig(BaseModel):
     """
     Main model provider configuration.
@@ -124,7 +153,7 @@ class ModelProviderConfig(BaseModel):
 
     provider: str = Field(
         default="auto",
-        description="Provider strategy: auto|ollama|claude|privacy",
+        description="Provider strategy: auto|ollama|claude|privacy|mock",
     )
     ollama: OllamaConfig = Field(
         default_factory=OllamaConfig,
@@ -134,6 +163,10 @@ class ModelProviderConfig(BaseModel):
         default_factory=ClaudeConfig,
         description="Claude provider configuration",
     )
+    mock: MockConfig = Field(
+        default_factory=MockConfig,
+        description="Mock (replay) provider configuration",
+    )
 
     class Config:
         """Pydantic config."""
@@ -368,6 +401,9 @@ class ModelConfigManager:
             except ValueError:
                 pass
 
+        if "MOCK_RECORDING" in os.environ:
+            config.setdefault("mock", {})["recording"] = os.environ["MOCK_RECORDING"]
+
         return config
 
     @staticmethod
@@ -386,6 +422,7 @@ class ModelConfigManager:
             "fallback_enabled": config.ollama.fallback_to_cloud,
             "ollama_config": ModelConfigManager.get_ollama_config(config),
             "claude_config": ModelConfigManager.get_claude_config(config),
+            "mock_config": ModelConfigManager.get_mock_config(config),
         }
 
     @staticmethod
@@ -423,6 +460,23 @@ class ModelConfigManager:
             "temperature": config.claude.temperature,
         }
 
+    @staticmethod
+    def get_mock_config(config: ModelProviderConfig) -> dict[str, Any]:
+        """
+        Get mock provider configuration.
+
+        Args:
+            config: Model provider configuration
+
+        Returns:
+            Dictionary suitable for MockProvider initialization
+        """
+        return {
+            "recording": config.mock.recording,
+            "model": config.mock.model,
+            "latency": config.mock.latency,
+        }
+
     @staticmethod
     def create_sample_config(output_path: str) -> None:
         """
@@ -437,11 +491,12 @@ class ModelConfigManager:
 # ==========================================
 
 content_agent:
-  # Provider strategy: auto|ollama|claude|privacy
+  # Provider strategy: auto|ollama|claude|privacy|mock
   # - auto: Try Ollama first, fallback to Claude
   # - ollama: Local-only, fail if unavailable
   # - claude: Cloud-only, always use Claude
   # - privacy: Like ollama but with privacy-focused error messages
+  # - mock: Replay a recorded session; no model calls, no tokens
   model_provider: auto
 
   # Ollama Configuration (local models)
@@ -478,6 +533,7 @@ content_agent:
 # - CLAUDE_ENABLED: Enable/disable Claude (true/false)
 # - ANTHROPIC_API_KEY: Claude API key
 # - CLAUDE_MODEL: Override Claude model
+# - MOCK_RECORDING: Recording replayed when model_provider is mock
 """
 
         output_path_obj = Path(output_path)
@@ -488,6 +544,7 @@ content_agent:
 
 __all__ = [
     "ClaudeConfig",
+    "MockConfig",
     "ModelConfigManager",
     "ModelProviderConfig",
     "OllamaConfig",
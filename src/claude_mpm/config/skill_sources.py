@@ -31,29 +31,89 @@ Example:
     >>> config.save()
 """
 
-from dataclasses import dataclass
+import os
+import re
+from dataclasses import dataclass, field
 from pathlib import Path
 from urllib.parse import urlparse
 
 import yaml
 
 from claude_mpm.core.logging_config import get_logger
+from claude_mpm.core.state_files import state_lock, write_atomic
 
 logger = get_logger(__name__)
 
+# scp-like SSH URL: git@github.com:owner/repo.git
+_SCP_URL_RE = re.compile(r"^(?P<user>[\w.-]+)@(?P<host>[\w.-]+):(?P<path>[^/].*)$")
+
+
+def parse_ssh_url(url: str) -> tuple[str, str] | None:
+    """Return (host, repo path) of an SSH Git URL, or None for other URLs.
+
+    Accepts both ``git@host:owner/repo.git`` and
+    ``ssh://git@host[:port]/owner/repo.git``.
+    """
+    if url.startswith("ssh://"):
+        parsed = urlparse(url)
+        return parsed.hostname or "", parsed.path
+    match = _SCP_URL_RE.match(url)
+    if match:
+        return match["host"], match["path"]
+    return None
+
+
+# Hosting services HTTPS sources can be synced from
+PROVIDERS = ("github", "gitlab", "bitbucket")
+
+# "git" sources are repositories; "local" sources are directories on disk;
+# "http" sources are static registries (an index.json and skill archives)
+SOURCE_TYPES = ("git", "local", "http")
+
+
+def is_registry_url(url: str) -> bool:
+    """Whether *url* names a registry index rather than a repository."""
+    parsed = urlparse(url)
+    return parsed.scheme in ("http", "https") and parsed.path.endswith(".json")
+
+
+def detect_provider(url: str) -> str | None:
+    """Return the hosting service of an HTTPS Git URL, or None if unknown.
+
+    A host with a "gitlab" label (gitlab.com, gitlab.example.com) is taken to
+    be GitLab; self-hosted GitLab under any other name needs an explicit
+    provider.
+    """
+    host = (urlparse(url).hostname or "").lower()
+    if host == "github.com" or host.endswith(".github.com"):
+        return "github"
+    if host == "bitbucket.org":
+        return "bitbucket"
+    if "gitlab" in host.split("."):
+        return "gitlab"
+    return None
+
 
 @dataclass
 class SkillSource:
-    """Represents a single skill source (Git repository).
+    """Represents a single skill source (Git repository or local directory).
 
     Attributes:
         id: Unique identifier for this source (e.g., "system", "custom")
-        type: Source type (currently only "git" supported)
-        url: Full Git repository URL
+        type: Source type: "git", "local" for a directory on disk, or "http"
+            for a static registry
+        url: Full Git repository URL, the absolute path of a local source,
+            or the index.json URL of a registry
         branch: Git branch to use (default: "main")
         priority: Priority for skill resolution (lower = higher precedence)
         enabled: Whether this source should be synced
-        token: Optional GitHub token or env var reference (e.g., "$MY_TOKEN")
+        token: Optional access token or env var reference (e.g., "$MY_TOKEN")
+        ssh_key: Optional private key for SSH URLs (e.g., "~/.ssh/skills_deploy")
+        provider: Hosting service of an HTTPS URL ("github", "gitlab" or
+            "bitbucket"); detected from the host when unset
+        trusted_keys: Keys the source's skills.manifest must be signed by:
+            minisign public keys, or "sigstore:ISSUER IDENTITY"; see
+            services/skills/skill_signing.py
 
     Priority System:
         - 0: Reserved for system repository (highest precedence)
@@ -66,6 +126,29 @@ class SkillSource:
         - Env var reference: "$PRIVATE_REPO_TOKEN" (resolved at runtime)
         - If None, falls back to GITHUB_TOKEN or GH_TOKEN env vars
         - Priority: source.token > GITHUB_TOKEN > GH_TOKEN
+        - GitLab and Bitbucket sources fall back to their own variables;
+          see services/skills/git_hosts.py
+
+    Local Directories:
+        - type "local" syncs skills from a directory (url is its path)
+          instead of a repository, for developing skills; branch, token,
+          ssh_key and provider do not apply and it is never pinned in
+          skills.lock
+        - "~" and "$VARS" in the path expand
+
+    HTTP Registries:
+        - type "http" syncs skill archives listed in an index.json served
+          from any web or artifact server; see
+          services/skills/http_registry.py for the format
+        - token is sent as a Bearer token ("user:secret" as Basic auth);
+          branch, ssh_key and provider do not apply and it is never pinned
+          in skills.lock
+
+    SSH Authentication:
+        - URLs like "git@github.com:org/skills.git" are synced with git over
+          SSH instead of the GitHub API, so deploy keys work without a token
+        - ssh_key selects the key for this source ("~" and "$VARS" expand);
+          without it ssh uses its own configuration and agent
 
     Example:
         >>> source = SkillSource(
@@ -82,6 +165,12 @@ class SkillSource:
         ...     url="https://github.com/myorg/private-skills",
         ...     token="$PRIVATE_REPO_TOKEN"
         ... )
+        >>> deploy_key_source = SkillSource(
+        ...     id="org-skills",
+        ...     type="git",
+        ...     url="git@github.com:org/skills.git",
+        ...     ssh_key="~/.ssh/org_skills_deploy",
+        ... )
     """
 
     id: str
@@ -91,6 +180,9 @@ class SkillSource:
     priority: int = 100
     enabled: bool = True
     token: str | None = None
+    ssh_key: str | None = None
+    provider: str | None = None
+    trusted_keys: list[str] = field(default_factory=list)
 
     def __post_init__(self):
         """Validate skill source configuration after initialization.
@@ -98,10 +190,54 @@ class SkillSource:
         Raises:
             ValueError: If validation fails
         """
+        if isinstance(self.trusted_keys, str):  # a single key in YAML
+            self.trusted_keys = [self.trusted_keys]
         errors = self.validate()
         if errors:
             raise ValueError(f"Invalid skill source configuration: {', '.join(errors)}")
 
+    @property
+    def is_local(self) -> bool:
+        """Whether this source is a directory on disk rather than a repository."""
+        return self.type == "local"
+
+    @property
+    def is_http(self) -> bool:
+        """Whether this source is a static HTTP registry."""
+        return self.type == "http"
+
+    @property
+    def is_git(self) -> bool:
+        """Whether this source is a repository, with commits to pin."""
+        return self.type == "git"
+
+    @property
+    def local_path(self) -> Path | None:
+        """The directory of a local source, with "~" and variables expanded."""
+        if not self.is_local:
+            return None
+        return Path(os.path.expandvars(self.url)).expanduser()
+
+    @property
+    def is_ssh(self) -> bool:
+        """Whether this source is cloned over SSH rather than the GitHub API."""
+        return self.is_git and parse_ssh_url(self.url or "") is not None
+
+    @property
+    def ssh_key_path(self) -> Path | None:
+        """The configured SSH key with "~" and environment variables expanded."""
+        if not self.ssh_key:
+            return None
+        return Path(os.path.expandvars(self.ssh_key)).expanduser()
+
+    @property
+    def hosting(self) -> str | None:
+        """The hosting service of an HTTPS source: the configured provider,
+        else the one detected from the URL (None for SSH or unknown hosts)."""
+        if self.is_ssh or not self.is_git:
+            return None
+        return self.provider or detect_provider(self.url or "")
+
     def validate(self) -> list[str]:
         """Validate skill source configuration.
 
@@ -110,8 +246,9 @@ class SkillSource:
 
         Validation checks:
             - ID is not empty and follows naming rules
-            - Type is supported (currently only "git")
-            - URL is valid and points to a Git repository
+            - Type is supported ("git", "local" or "http")
+            - URL is valid and points to a Git repository (an absolute path
+              for a local source, an http(s) URL for a registry)
             - Branch name is valid
             - Priority is in valid range (0-1000)
         """
@@ -126,22 +263,57 @@ class SkillSource:
             )
 
         # Validate type
-        if self.type != "git":
-            errors.append(f"Only 'git' type is currently supported, got: {self.type}")
+        if self.type not in SOURCE_TYPES:
+            errors.append(
+                f"Type must be one of {', '.join(SOURCE_TYPES)}, got: {self.type}"
+            )
 
         # Validate URL
+        ssh = parse_ssh_url(self.url or "") if self.is_git else None
         if not self.url or not self.url.strip():
             errors.append("URL cannot be empty")
+        elif self.is_local:
+            if not self.local_path.is_absolute():
+                errors.append(f"Local source path must be absolute, got: {self.url}")
+            for name in ("token", "ssh_key", "provider", "trusted_keys"):
+                if getattr(self, name):
+                    errors.append(f"{name} does not apply to a local source")
+            return errors + self._validate_common()
+        elif self.is_http:
+            parsed = urlparse(self.url)
+            if parsed.scheme not in ("http", "https") or not parsed.netloc:
+                errors.append(
+                    f"Registry URL must be an http:// or https:// URL, got: {self.url}"
+                )
+            for name in ("ssh_key", "provider"):
+                if getattr(self, name):
+                    errors.append(f"{name} does not apply to a registry source")
+            return errors + self._validate_common()
+        elif ssh is not None:
+            host, path = ssh
+            if not host:
+                errors.append(f"SSH URL must include a host, got: {self.url}")
+            path_parts = [p for p in path.strip("/").split("/") if p]
+            if len(path_parts) < 2:
+                errors.append(f"URL must include owner/repo path, got: {path}")
         else:
             try:
                 parsed = urlparse(self.url)
                 if parsed.scheme not in ("http", "https"):
                     errors.append(
-                        f"URL must use http:// or https:// protocol, got: {parsed.scheme}"
+                        f"URL must use http:// or https:// protocol, or be an SSH "
+                        f"URL (git@host:owner/repo.git), got: {parsed.scheme}"
+                    )
+                if self.provider is not None and self.provider not in PROVIDERS:
+                    errors.append(
+                        f"Provider must be one of {', '.join(PROVIDERS)}, "
+                        f"got: {self.provider}"
                     )
-                if not parsed.netloc.endswith("github.com"):
+                elif self.hosting is None:
                     errors.append(
-                        f"URL must be a GitHub repository, got: {parsed.netloc}"
+                        "URL must be a GitHub, GitLab or Bitbucket repository "
+                        "(set provider for a self-hosted GitLab), "
+                        f"got: {parsed.netloc}"
                     )
                 path_parts = [p for p in parsed.path.strip("/").split("/") if p]
                 if len(path_parts) < 2:
@@ -151,6 +323,18 @@ class SkillSource:
             except Exception as e:
                 errors.append(f"Invalid URL format: {e}")
 
+        if self.ssh_key and ssh is None:
+            errors.append(
+                "ssh_key requires an SSH URL (git@host:owner/repo.git), "
+                f"got: {self.url}"
+            )
+
+        return errors + self._validate_common()
+
+    def _validate_common(self) -> list[str]:
+        """Checks shared by every source type: branch and priority."""
+        errors = []
+
         # Validate branch
         if not self.branch or not self.branch.strip():
             errors.append("Branch name cannot be empty")
@@ -276,6 +460,9 @@ class SkillSourceConfiguration:
                         priority=source_data.get("priority", 100),
                         enabled=source_data.get("enabled", True),
                         token=source_data.get("token"),
+                        ssh_key=source_data.get("ssh_key"),
+                        provider=source_data.get("provider"),
+                        trusted_keys=source_data.get("trusted_keys") or [],
                     )
                     sources.append(source)
                 except (KeyError, ValueError) as e:
@@ -341,19 +528,23 @@ class SkillSourceConfiguration:
                     "priority": source.priority,
                     "enabled": source.enabled,
                     **({"token": source.token} if source.token else {}),
+                    **({"ssh_key": source.ssh_key} if source.ssh_key else {}),
+                    **({"provider": source.provider} if source.provider else {}),
+                    **(
+                        {"trusted_keys": source.trusted_keys}
+                        if source.trusted_keys
+                        else {}
+                    ),
                 }
                 for source in sources
             ]
         }
 
         try:
-            # Write atomically: write to temp file, then rename
-            temp_path = self.config_path.with_suffix(".yaml.tmp")
-            with open(temp_path, "w", encoding="utf-8") as f:
-                yaml.safe_dump(data, f, default_flow_style=False, sort_keys=False)
-
-            # Atomic rename
-            temp_path.replace(self.config_path)
+            # Write atomically under the file lock: a unique temp file, then rename
+            content = yaml.safe_dump(data, default_flow_style=False, sort_keys=False)
+            with state_lock(self.config_path):
+                write_atomic(self.config_path, content)
 
             self.logger.info(
                 f"Configuration saved to {self.config_path} ({len(sources)} sources)"
@@ -363,9 +554,6 @@ class SkillSourceConfiguration:
             self.logger.error(
                 f"Failed to save configuration to {self.config_path}: {e}"
             )
-            # Clean up temp file if it exists
-            if temp_path.exists():
-                temp_path.unlink()
             raise
 
     def add_source(self, source: SkillSource) -> None:
@@ -382,23 +570,27 @@ class SkillSourceConfiguration:
             >>> source = SkillSource(id="custom", type="git", url="...")
             >>> config.add_source(source)
         """
-        sources = self.load()
+        # Locked read-modify-write, so concurrent commands don't undo each other
+        with state_lock(self.config_path):
+            sources = self.load()
 
-        # Check for duplicate IDs
-        if any(s.id == source.id for s in sources):
-            raise ValueError(f"Source with ID '{source.id}' already exists")
+            # Check for duplicate IDs
+            if any(s.id == source.id for s in sources):
+                raise ValueError(f"Source with ID '{source.id}' already exists")
 
-        # Check for priority conflicts (warn, don't fail)
-        conflicts = [s for s in sources if s.priority == source.priority and s.enabled]
-        if conflicts:
-            self.logger.warning(
-                f"Priority {source.priority} conflicts with existing sources: "
-                f"{', '.join(s.id for s in conflicts)}"
-            )
+            # Check for priority conflicts (warn, don't fail)
+            conflicts = [
+                s for s in sources if s.priority == source.priority and s.enabled
+            ]
+            if conflicts:
+                self.logger.warning(
+                    f"Priority {source.priority} conflicts with existing sources: "
+                    f"{', '.join(s.id for s in conflicts)}"
+                )
 
-        sources.append(source)
-        self.save(sources)
-        self.logger.info(f"Added skill source: {source.id}")
+            sources.append(source)
+            self.save(sources)
+            self.logger.info(f"Added skill source: {source.id}")
 
     def remove_source(self, source_id: str) -> bool:
         """Remove a skill source by ID.
@@ -415,18 +607,19 @@ class SkillSourceConfiguration:
             >>> print(removed)
             True
         """
-        sources = self.load()
-        initial_count = len(sources)
+        with state_lock(self.config_path):
+            sources = self.load()
+            initial_count = len(sources)
 
-        sources = [s for s in sources if s.id != source_id]
+            sources = [s for s in sources if s.id != source_id]
 
-        if len(sources) == initial_count:
-            self.logger.warning(f"Source not found: {source_id}")
-            return False
+            if len(sources) == initial_count:
+                self.logger.warning(f"Source not found: {source_id}")
+                return False
 
-        self.save(sources)
-        self.logger.info(f"Removed skill source: {source_id}")
-        return True
+            self.save(sources)
+            self.logger.info(f"Removed skill source: {source_id}")
+            return True
 
     def get_source(self, source_id: str) -> SkillSource | None:
         """Get a specific skill source by ID.
@@ -463,35 +656,36 @@ class SkillSourceConfiguration:
             >>> config = SkillSourceConfiguration()
             >>> config.update_source("custom", enabled=False, priority=200)
         """
-        sources = self.load()
-
-        # Find source to update
-        source_index = None
-        for i, source in enumerate(sources):
-            if source.id == source_id:
-                source_index = i
-                break
-
-        if source_index is None:
-            raise ValueError(f"Source not found: {source_id}")
-
-        # Apply updates
-        source = sources[source_index]
-        for key, value in updates.items():
-            if hasattr(source, key):
-                setattr(source, key, value)
-            else:
-                raise ValueError(f"Invalid update field: {key}")
-
-        # Validate updated source
-        errors = source.validate()
-        if errors:
-            raise ValueError(
-                f"Invalid updates for source '{source_id}': {', '.join(errors)}"
-            )
+        with state_lock(self.config_path):
+            sources = self.load()
+
+            # Find source to update
+            source_index = None
+            for i, source in enumerate(sources):
+                if source.id == source_id:
+                    source_index = i
+                    break
+
+            if source_index is None:
+                raise ValueError(f"Source not found: {source_id}")
+
+            # Apply updates
+            source = sources[source_index]
+            for key, value in updates.items():
+                if hasattr(source, key):
+                    setattr(source, key, value)
+                else:
+                    raise ValueError(f"Invalid update field: {key}")
+
+            # Validate updated source
+            errors = source.validate()
+            if errors:
+                raise ValueError(
+                    f"Invalid updates for source '{source_id}': {', '.join(errors)}"
+                )
 
-        self.save(sources)
-        self.logger.info(f"Updated skill source: {source_id}")
+            self.save(sources)
+            self.logger.info(f"Updated skill source: {source_id}")
 
     def get_enabled_sources(self) -> list[SkillSource]:
         """Get all enabled skill sources sorted by priority.
@@ -131,6 +131,7 @@ class MemoryCommands(StrEnum):
     CROSS_REF = "cross-ref"
     ROUTE = "route"
     SHOW = "show"
+    LESSONS = "lessons"  # Review lessons proposed from verification failures
 
 
 class MonitorCommands(StrEnum):
@@ -150,6 +151,8 @@ class DashboardCommands(StrEnum):
     STOP = "stop"
     STATUS = "status"
     OPEN = "open"
+    LIST = "list"
+    SHARE = "share"
 
 
 class ConfigCommands(StrEnum):
@@ -209,10 +212,17 @@ class SkillsCommands(StrEnum):
     """Skills subcommand constants."""
 
     LIST = "list"
+    SEARCH = "search"  # Names, descriptions and tags across all skill sources
     DEPLOY = "deploy"
     VALIDATE = "validate"
+    LINT = "lint"  # Frontmatter, sections, links and size budgets; --fix
     UPDATE = "update"
     INFO = "info"
+    DIFF = "diff"  # Deployed copy vs its source
+    OUTDATED = "outdated"  # Deployed skills whose source moved upstream
+    ROLLBACK = "rollback"  # Restore a deployed skill's previous version
+    TEST = "test"  # Run a skill's example prompts against a headless agent
+    NEW = "new"  # Scaffold a skill directory from prompts or flags
     CONFIG = "config"
     CONFIGURE = "configure"  # Interactive skills selection (like agents configure)
     SELECT = "select"  # Interactive topic-grouped skill selector
@@ -231,6 +241,8 @@ class SkillsCommands(StrEnum):
     COLLECTION_SET_DEFAULT = "collection-set-default"
     # Deduplication sweep
     DEDUP = "dedup"
+    # Usage analytics
+    STATS = "stats"  # Per-skill effectiveness scores from session transcripts
 
 
 class CLIFlags(StrEnum):
@@ -6,9 +6,13 @@ writes from CLI + UI, multiple browser tabs, or external tools.
 Design decisions:
 - POSIX advisory locks (fcntl.flock), not mandatory locks
 - Separate .lock file (not locking the config file itself)
-- 5-second timeout with non-blocking retry loop
+- 5-second timeout with non-blocking retry loop (CLAUDE_MPM_LOCK_TIMEOUT)
 - Context manager pattern for exception-safe usage
 - Per-file granularity (lock agent_sources.yaml independently from skill_sources.yaml)
+- Re-entrant within a thread, so a locked read-modify-write can call a
+  save() that takes the same lock
+- The kernel drops flock() locks when a process dies, so there are no stale
+  locks to clean up; the PID in the lock file is only for error messages
 
 Limitations:
 - POSIX-only (macOS, Linux). Not Windows-compatible.
@@ -18,6 +22,7 @@ Limitations:
 
 import fcntl
 import os
+import threading
 import time
 from collections.abc import Generator
 from contextlib import contextmanager
@@ -36,46 +41,73 @@ class ConfigFileLockTimeout(ConfigFileLockError):
     """Raised when lock acquisition times out."""
 
 
+LOCK_TIMEOUT_ENV = "CLAUDE_MPM_LOCK_TIMEOUT"
+DEFAULT_LOCK_TIMEOUT = 5.0
+
+# Locks held by each thread, so nested acquisition of the same file (a
+# locked read-modify-write calling a save() that locks too) does not wait on
+# itself: flock() treats a second open() of the lock file as a new owner.
+_held = threading.local()
+
+
+def lock_timeout() -> float:
+    """How long writers wait for a state file lock (the lock-wait policy).
+
+    ``CLAUDE_MPM_LOCK_TIMEOUT`` overrides the default of 5 seconds; ``0``
+    fails immediately when another process holds the lock.
+    """
+    value = os.environ.get(LOCK_TIMEOUT_ENV)
+    if value:
+        try:
+            return max(0.0, float(value))
+        except ValueError:
+            logger.warning(f"Ignoring invalid {LOCK_TIMEOUT_ENV}={value!r}")
+    return DEFAULT_LOCK_TIMEOUT
+
+
+def _lock_holder(lock_path: Path) -> str:
+    try:
+        pid = lock_path.read_text().strip()
+    except OSError:
+        return ""
+    return f" (held by PID {pid})" if pid else ""
+
+
 @contextmanager
-def config_file_lock(
-    config_path: Path,
-    timeout: float = 5.0,
+def acquire_file_lock(
+    path: Path,
+    timeout: float | None = None,
     poll_interval: float = 0.1,
 ) -> Generator[None]:
-    """Acquire an advisory file lock on a configuration file.
+    """Hold the advisory lock for *path* without wrapping body exceptions.
 
-    Args:
-        config_path: Path to the config file being modified.
-                     The lock file will be created at config_path.with_suffix('.lock').
-        timeout: Maximum seconds to wait for lock acquisition.
-                 Default 5.0s -- fail fast, don't block the UI.
-        poll_interval: Seconds between lock retry attempts.
-
-    Yields:
-        None -- the lock is held for the duration of the with block.
+    Re-entrant within a thread. *timeout* defaults to ``lock_timeout()``.
 
     Raises:
-        ConfigFileLockTimeout: If lock cannot be acquired within timeout.
-        ConfigFileLockError: If lock file cannot be created or other I/O error.
-
-    Usage:
-        with config_file_lock(Path("~/.claude-mpm/config/agent_sources.yaml")):
-            config = AgentSourceConfiguration.load()
-            config.add_repository(repo)
-            config.save()
+        ConfigFileLockTimeout: If the lock cannot be acquired within timeout.
     """
-    lock_path = config_path.with_suffix(config_path.suffix + ".lock")
+    path = Path(path)
+    lock_path = path.with_suffix(path.suffix + ".lock")
+    key = str(lock_path.absolute())
+    held: dict[str, int] = _held.__dict__.setdefault("counts", {})
+    if held.get(key):
+        held[key] += 1
+        try:
+            yield
+        finally:
+            held[key] -= 1
+        return
+
+    if timeout is None:
+        timeout = lock_timeout()
 
     # Ensure parent directory exists (config file may not exist yet)
     lock_path.parent.mkdir(parents=True, exist_ok=True)
 
-    lock_fd = None
+    # "a+" rather than "w": opening must not erase the holder's PID
+    lock_fd = open(lock_path, "a+")  # noqa: SIM115
     start_time = time.monotonic()
-
     try:
-        # Open (or create) the lock file
-        lock_fd = open(lock_path, "w")
-
         # Retry loop with timeout
         while True:
             try:
@@ -86,9 +118,10 @@ def config_file_lock(
                 elapsed = time.monotonic() - start_time
                 if elapsed >= timeout:
                     raise ConfigFileLockTimeout(
-                        f"Could not acquire lock on {config_path} "
-                        f"after {timeout}s. Another process may be "
-                        f"modifying this file."
+                        f"Could not acquire lock on {path} after {timeout}s"
+                        f"{_lock_holder(lock_path)}. Another process may be "
+                        f"modifying this file; set {LOCK_TIMEOUT_ENV} to "
+                        "wait longer."
                     ) from None
                 time.sleep(poll_interval)
 
@@ -98,20 +131,55 @@ def config_file_lock(
         lock_fd.write(f"{os.getpid()}\n")
         lock_fd.flush()
 
-        yield
+        held[key] = 1
+        try:
+            yield
+        finally:
+            held.pop(key, None)
+    finally:
+        try:
+            fcntl.flock(lock_fd, fcntl.LOCK_UN)
+            lock_fd.close()
+            logger.debug(f"Lock released: {lock_path}")
+        except Exception:
+            logger.debug("Error releasing lock %s", lock_path, exc_info=True)
+
+
+@contextmanager
+def config_file_lock(
+    config_path: Path,
+    timeout: float | None = None,
+    poll_interval: float = 0.1,
+) -> Generator[None]:
+    """Acquire an advisory file lock on a configuration file.
+
+    Args:
+        config_path: Path to the config file being modified.
+                     The lock file will be created at config_path.with_suffix('.lock').
+        timeout: Maximum seconds to wait for lock acquisition.
+                 Default: ``lock_timeout()`` (5s) -- fail fast, don't block the UI.
+        poll_interval: Seconds between lock retry attempts.
 
+    Yields:
+        None -- the lock is held for the duration of the with block.
+
+    Raises:
+        ConfigFileLockTimeout: If lock cannot be acquired within timeout.
+        ConfigFileLockError: If lock file cannot be created or other I/O error.
+
+    Usage:
+        with config_file_lock(Path("~/.claude-mpm/config/agent_sources.yaml")):
+            config = AgentSourceConfiguration.load()
+            config.add_repository(repo)
+            config.save()
+    """
+    try:
+        with acquire_file_lock(config_path, timeout, poll_interval):
+            yield
     except ConfigFileLockError:
         raise
     except Exception as e:
         raise ConfigFileLockError(f"Error managing lock for {config_path}: {e}") from e
-    finally:
-        if lock_fd is not None:
-            try:
-                fcntl.flock(lock_fd, fcntl.LOCK_UN)
-                lock_fd.close()
-                logger.debug(f"Lock released: {lock_path}")
-            except Exception:
-                logger.debug("Error releasing lock %s", lock_path, exc_info=True)
 
 
 def get_config_file_mtime(config_path: Path) -> float:
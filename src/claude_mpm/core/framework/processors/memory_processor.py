@@ -4,6 +4,7 @@ from pathlib import Path
 from typing import Any
 
 from claude_mpm.core.logging_utils import get_logger
+from claude_mpm.services import data_encryption
 from claude_mpm.utils.agent_filters import normalize_agent_id
 
 
@@ -33,7 +34,7 @@ class MemoryProcessor:
         project_memory_file = Path.cwd() / ".claude-mpm" / "memories" / "PM_memories.md"
         if project_memory_file.exists():
             try:
-                content = project_memory_file.read_text()
+                content = data_encryption.reveal(project_memory_file.read_text())
                 memories["actual_memories"] = content
                 memories["memory_source"] = "project"
                 self.logger.info(
@@ -47,7 +48,7 @@ class MemoryProcessor:
         user_memory_file = Path.home() / ".claude-mpm" / "memories" / "PM_memories.md"
         if user_memory_file.exists():
             try:
-                content = user_memory_file.read_text()
+                content = data_encryption.reveal(user_memory_file.read_text())
                 memories["actual_memories"] = content
                 memories["memory_source"] = "user"
                 self.logger.info(f"Loaded PM memories from user: {user_memory_file}")
@@ -83,7 +84,7 @@ class MemoryProcessor:
                 memory_file = memory_dir / memory_filename
                 if memory_file.exists():
                     try:
-                        content = memory_file.read_text()
+                        content = data_encryption.reveal(memory_file.read_text())
                         agent_memories[agent_name] = content
                         self.logger.debug(
                             f"Loaded memories for {agent_name} from {memory_file}"
@@ -100,7 +101,9 @@ class MemoryProcessor:
                         legacy_file = memory_dir / legacy_filename
                         if legacy_file.exists():
                             try:
-                                content = legacy_file.read_text()
+                                content = data_encryption.reveal(
+                                    legacy_file.read_text()
+                                )
                                 agent_memories[agent_name] = content
                                 self.logger.debug(
                                     f"Loaded legacy memories for {agent_name} "
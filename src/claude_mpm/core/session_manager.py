@@ -14,6 +14,7 @@ from pathlib import Path
 from typing import Any
 
 from ..core.logger import get_logger
+from .state_files import merge_records
 
 logger = get_logger(__name__)
 
@@ -33,6 +34,8 @@ class SessionManager:
         self.session_dir = session_dir or Path.home() / ".claude-mpm" / "sessions"
         self.session_dir.mkdir(parents=True, exist_ok=True)
         self.active_sessions: dict[str, dict[str, Any]] = {}
+        # Sessions as last read from or written to disk (see _save_sessions)
+        self._baseline: dict[str, dict[str, Any]] = {}
         self._load_sessions()
 
     def create_session(self, context: str = "default") -> str:
@@ -190,11 +193,16 @@ class SessionManager:
         return None
 
     def _save_sessions(self):
-        """Save sessions to disk."""
+        """Save sessions to disk.
+
+        Only sessions this process created, changed or removed are written;
+        sessions saved meanwhile by other claude-mpm processes are kept.
+        """
         session_file = self.session_dir / "active_sessions.json"
         try:
-            with session_file.open("w") as f:
-                json.dump(self.active_sessions, f, indent=2)
+            merged = merge_records(session_file, self.active_sessions, self._baseline)
+            self.active_sessions = merged
+            self._baseline = json.loads(json.dumps(merged))
         except Exception as e:
             logger.error(f"Failed to save sessions: {e}")
 
@@ -205,6 +213,7 @@ class SessionManager:
             try:
                 with session_file.open() as f:
                     self.active_sessions = json.load(f)
+                self._baseline = json.loads(json.dumps(self.active_sessions))
 
                 # Clean up old sessions on load (archive by default)
                 self.cleanup_old_sessions(archive=True)
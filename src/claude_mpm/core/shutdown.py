@@ -0,0 +1,158 @@
+"""Supervised shutdown sequence for claude-mpm processes.
+
+WHY this is needed:
+- Cleanup used to be scattered across ad-hoc ``atexit.register`` calls that
+  never run on SIGTERM/SIGHUP (terminal closed, tmux killed), leaving PID
+  files, lock files and socket servers behind
+- Cleanup order matters: servers must withdraw from discovery before their
+  PID file disappears, and network listeners must close before the process
+  reports itself gone
+
+DESIGN DECISIONS:
+- One process-wide supervisor; components register named steps with a
+  priority (lower runs first)
+- Every step runs at most once, is isolated from the others' exceptions and
+  is bounded by a timeout so a hung step cannot block exit
+- SIGTERM/SIGHUP run the sequence and then exit with the conventional
+  128 + signum status; atexit covers normal interpreter exit
+"""
+
+import atexit
+import signal
+import sys
+import threading
+from collections.abc import Callable
+from dataclasses import dataclass, field
+
+from .logging_utils import get_logger
+
+logger = get_logger(__name__)
+
+DEFAULT_STEP_TIMEOUT = 3.0
+
+
+@dataclass(order=True)
+class ShutdownStep:
+    """A single named cleanup action."""
+
+    priority: int
+    name: str = field(compare=False)
+    callback: Callable[[], object] = field(compare=False)
+    timeout: float = field(default=DEFAULT_STEP_TIMEOUT, compare=False)
+
+
+class ShutdownSupervisor:
+    """Runs registered cleanup steps exactly once, in priority order."""
+
+    def __init__(self):
+        self._steps: dict[str, ShutdownStep] = {}
+        self._lock = threading.Lock()
+        self._done = False
+        self._installed = False
+
+    def register(
+        self,
+        name: str,
+        callback: Callable[[], object],
+        priority: int = 50,
+        timeout: float = DEFAULT_STEP_TIMEOUT,
+    ) -> None:
+        """Register (or replace) a cleanup step.
+
+        Args:
+            name: Unique step name; re-registering replaces the old step
+            callback: Zero-argument cleanup function
+            priority: Lower values run first
+            timeout: Seconds to wait for the step before moving on
+        """
+        with self._lock:
+            self._steps[name] = ShutdownStep(priority, name, callback, timeout)
+
+    def unregister(self, name: str) -> None:
+        with self._lock:
+            self._steps.pop(name, None)
+
+    @property
+    def step_names(self) -> list[str]:
+        with self._lock:
+            return [step.name for step in sorted(self._steps.values())]
+
+    def shutdown(self, reason: str = "exit") -> dict[str, str]:
+        """Run every registered step once.
+
+        Returns:
+            Mapping of step name to outcome (``ok``, ``error: ...`` or
+            ``timeout``). Empty if the sequence already ran.
+        """
+        with self._lock:
+            if self._done:
+                return {}
+            self._done = True
+            steps = sorted(self._steps.values())
+
+        logger.debug(f"Shutdown sequence starting ({reason}): {len(steps)} steps")
+        outcomes: dict[str, str] = {}
+        for step in steps:
+            outcomes[step.name] = self._run_step(step)
+        return outcomes
+
+    @staticmethod
+    def _run_step(step: ShutdownStep) -> str:
+        result: dict[str, str] = {}
+
+        def target():
+            try:
+                step.callback()
+                result["outcome"] = "ok"
+            except Exception as e:
+                result["outcome"] = f"error: {e}"
+
+        worker = threading.Thread(
+            target=target, name=f"shutdown-{step.name}", daemon=True
+        )
+        worker.start()
+        worker.join(step.timeout)
+        if worker.is_alive():
+            logger.warning(f"Shutdown step '{step.name}' timed out")
+            return "timeout"
+
+        outcome = result.get("outcome", "ok")
+        if outcome != "ok":
+            logger.warning(f"Shutdown step '{step.name}' failed: {outcome}")
+        return outcome
+
+    def install(self) -> None:
+        """Hook the sequence into atexit and termination signals.
+
+        Only the main thread may install signal handlers; elsewhere we fall
+        back to atexit alone.
+        """
+        if self._installed:
+            return
+        self._installed = True
+        atexit.register(self.shutdown, "atexit")
+
+        if threading.current_thread() is not threading.main_thread():
+            return
+        for sig in (signal.SIGTERM, getattr(signal, "SIGHUP", None)):
+            if sig is None:
+                continue
+            try:
+                signal.signal(sig, self._handle_signal)
+            except (OSError, ValueError):
+                pass
+
+    def _handle_signal(self, signum, _frame) -> None:
+        self.shutdown(f"signal {signum}")
+        sys.exit(128 + signum)
+
+
+_supervisor: ShutdownSupervisor | None = None
+
+
+def get_shutdown_supervisor() -> ShutdownSupervisor:
+    """Return the process-wide shutdown supervisor."""
+    global _supervisor
+    if _supervisor is None:
+        _supervisor = ShutdownSupervisor()
+    return _supervisor
@@ -0,0 +1,146 @@
+"""Concurrency-safe persistence for claude-mpm state files.
+
+WHAT: Helpers every persisted registry (sources, sessions, projects) uses so
+that two claude-mpm processes running at once cannot corrupt or silently
+undo each other's writes:
+
+- ``write_atomic``: write to a unique temporary file and ``os.replace`` it
+  into place, so readers only ever see a complete old or new file
+- ``state_lock``: the advisory lock from ``config_file_lock`` under the
+  shared lock-wait policy (``CLAUDE_MPM_LOCK_TIMEOUT``, default 5s)
+- ``update_json``: locked read-modify-write of a JSON file
+- ``merge_records``: locked three-way merge of a JSON object of records, for
+  registries that keep an in-memory copy (each process writes back only the
+  records it changed or removed since it last read the file)
+
+WHY: Writes used to open the target with "w" (truncating it) or share one
+fixed ``.tmp`` name, and saves wrote back a whole in-memory copy. Two
+commands at once produced truncated JSON, interleaved temp files, or lost
+the other process's sessions and sources.
+
+DESIGN DECISIONS:
+- Readers take no lock: atomic replacement already guarantees a whole file,
+  and reads must never wait behind a slow writer
+- Writers that give up after the lock-wait timeout raise
+  ``ConfigFileLockTimeout`` naming the PID holding the lock, rather than
+  writing anyway
+- A corrupt or unreadable file is treated as empty by the updaters and
+  logged, so one bad write cannot wedge every later command
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import tempfile
+from collections.abc import Callable, Generator
+from contextlib import contextmanager
+from pathlib import Path
+from typing import Any
+
+from .config_file_lock import acquire_file_lock
+from .logging_config import get_logger
+
+logger = get_logger(__name__)
+
+
+@contextmanager
+def state_lock(path: Path, timeout: float | None = None) -> Generator[None]:
+    """Hold the lock for state file *path* (re-entrant within a thread)."""
+    with acquire_file_lock(Path(path), timeout):
+        yield
+
+
+def write_atomic(path: Path, content: str, encoding: str = "utf-8") -> None:
+    """Replace *path* with *content* in one step.
+
+    The file keeps its permissions; a new file gets 0o644 minus the umask
+    rather than mkstemp's 0o600.
+    """
+    path = Path(path)
+    path.parent.mkdir(parents=True, exist_ok=True)
+    try:
+        mode = path.stat().st_mode & 0o777
+    except FileNotFoundError:
+        umask = os.umask(0)
+        os.umask(umask)
+        mode = 0o644 & ~umask
+    fd, tmp = tempfile.mkstemp(dir=path.parent, prefix=f".{path.name}.", suffix=".tmp")
+    try:
+        with os.fdopen(fd, "w", encoding=encoding) as f:
+            f.write(content)
+            f.flush()
+            os.fsync(f.fileno())
+        os.chmod(tmp, mode)
+        os.replace(tmp, path)
+    except BaseException:
+        Path(tmp).unlink(missing_ok=True)
+        raise
+
+
+def read_json(path: Path, default: Any = None) -> Any:
+    """Read JSON from *path*, or *default* if it is missing or unreadable."""
+    try:
+        return json.loads(Path(path).read_text(encoding="utf-8"))
+    except FileNotFoundError:
+        return default
+    except (OSError, ValueError) as e:
+        logger.warning(f"Ignoring unreadable state file {path}: {e}")
+        return default
+
+
+def update_json(
+    path: Path,
+    mutate: Callable[[Any], Any],
+    default: Callable[[], Any] = dict,
+    timeout: float | None = None,
+) -> Any:
+    """Locked read-modify-write of a JSON file.
+
+    *mutate* receives the current content (``default()`` when the file is
+    missing) and either changes it in place and returns None, or returns the
+    new content. Returns what was written.
+    """
+    with state_lock(path, timeout):
+        data = read_json(path, None)
+        if data is None:
+            data = default()
+        result = mutate(data)
+        if result is None:
+            result = data
+        write_atomic(path, json.dumps(result, indent=2))
+        return result
+
+
+def merge_records(
+    path: Path,
+    records: dict[str, Any],
+    baseline: dict[str, Any],
+    timeout: float | None = None,
+) -> dict[str, Any]:
+    """Write back this process's changes to a JSON object of records.
+
+    Args:
+        path: The JSON file holding ``{record_id: record}``.
+        records: This process's current records.
+        baseline: The records as this process last read or wrote them.
+
+    Records that differ from *baseline* are written, records in *baseline*
+    but no longer in *records* are deleted, and everything else on disk,
+    including records other processes added or changed, is kept.
+
+    Returns:
+        The merged records now on disk, to use as the next baseline.
+    """
+
+    def merge(on_disk: dict[str, Any]) -> dict[str, Any]:
+        if not isinstance(on_disk, dict):
+            on_disk = {}
+        for record_id in baseline.keys() - records.keys():
+            on_disk.pop(record_id, None)
+        for record_id, record in records.items():
+            if baseline.get(record_id) != record:
+                on_disk[record_id] = record
+        return on_disk
+
+    return update_json(path, merge, timeout=timeout)
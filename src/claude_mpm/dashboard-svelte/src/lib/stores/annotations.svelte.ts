@@ -0,0 +1,67 @@
+import { writable } from 'svelte/store';
+import type { ClaudeEvent, Tool } from '$lib/types/events';
+
+export interface Annotation {
+	id: string;
+	session_id: string;
+	target: string;
+	text: string;
+	author: string;
+	created_at: string;
+	line: number | null;
+}
+
+// Comments of one session, keyed by the session id
+export const annotationsStore = writable<Record<string, Annotation[]>>({});
+
+// Targets match services/annotations.py: tool calls by correlation id, other
+// events by subtype and timestamp (client-side event ids are not stable)
+export function toolTarget(tool: Tool): string {
+	return `tool:${tool.id}`;
+}
+
+export function eventTarget(event: ClaudeEvent): string {
+	if (event.correlation_id) return `tool:${event.correlation_id}`;
+	return `event:${event.subtype || event.type}@${event.timestamp}`;
+}
+
+export function eventSessionId(event: ClaudeEvent | null | undefined): string {
+	const data = (event?.data ?? {}) as Record<string, unknown>;
+	return String(event?.session_id || event?.sessionId || data.session_id || '');
+}
+
+async function request(url: string, init?: RequestInit): Promise<any> {
+	const response = await fetch(url, init);
+	const result = await response.json();
+	if (!response.ok || !result.success) {
+		throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+	}
+	return result;
+}
+
+export async function loadAnnotations(sessionId: string): Promise<void> {
+	if (!sessionId) return;
+	const params = new URLSearchParams({ session: sessionId });
+	const result = await request(`/api/annotations?${params}`);
+	annotationsStore.update(s => ({ ...s, [sessionId]: result.annotations }));
+}
+
+export async function addAnnotation(
+	sessionId: string,
+	target: string,
+	text: string
+): Promise<void> {
+	await request('/api/annotations', {
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify({ session_id: sessionId, target, text }),
+	});
+	await loadAnnotations(sessionId);
+}
+
+export async function removeAnnotation(annotation: Annotation): Promise<void> {
+	await request(`/api/annotations/${encodeURIComponent(annotation.id)}`, {
+		method: 'DELETE',
+	});
+	await loadAnnotations(annotation.session_id);
+}
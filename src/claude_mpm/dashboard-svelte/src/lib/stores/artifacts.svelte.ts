@@ -0,0 +1,64 @@
+import { writable } from 'svelte/store';
+
+export type ArtifactKind = 'report' | 'image' | 'build' | 'other';
+
+export interface Artifact {
+	id: string;
+	session_id: string;
+	name: string;
+	kind: ArtifactKind;
+	size: number;
+	created_at: string;
+	description: string;
+	source: string;
+	media_type: string;
+}
+
+interface ArtifactsState {
+	artifacts: Artifact[];
+	loading: boolean;
+	error: string | null;
+}
+
+export const artifactsStore = writable<ArtifactsState>({
+	artifacts: [],
+	loading: false,
+	error: null,
+});
+
+// Shared between the list (left panel) and detail (right panel) views
+export const selectedArtifact = writable<Artifact | null>(null);
+
+export function artifactDownloadUrl(artifact: Artifact, inline = false): string {
+	const url = `/api/artifacts/${encodeURIComponent(artifact.id)}/download`;
+	return inline ? `${url}?inline=1` : url;
+}
+
+export function isPreviewable(artifact: Artifact): boolean {
+	return artifact.media_type.startsWith('image/') && artifact.media_type !== 'image/svg+xml';
+}
+
+export function formatSize(size: number): string {
+	if (size < 1024) return `${size} B`;
+	if (size < 1024 * 1024) return `${(size / 1024).toFixed(1)} KB`;
+	return `${(size / (1024 * 1024)).toFixed(1)} MB`;
+}
+
+export async function loadArtifacts(kind: ArtifactKind | '' = ''): Promise<void> {
+	artifactsStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		const params = new URLSearchParams(kind ? { kind } : {});
+		const response = await fetch(`/api/artifacts?${params}`);
+		const result = await response.json();
+		if (!response.ok || !result.success) {
+			throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+		}
+		artifactsStore.set({ artifacts: result.artifacts, loading: false, error: null });
+	} catch (e) {
+		artifactsStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to load artifacts',
+		}));
+	}
+}
@@ -0,0 +1,56 @@
+import { writable } from 'svelte/store';
+
+export interface ChecklistStep {
+	id: string;
+	title: string;
+	check: string;
+	done: boolean;
+	detail: string;
+	hint: string;
+}
+
+export interface ChecklistStatus {
+	project_root: string;
+	source: string;
+	done: number;
+	total: number;
+	complete: boolean;
+	steps: ChecklistStep[];
+}
+
+interface ChecklistState {
+	status: ChecklistStatus | null;
+	loading: boolean;
+	error: string | null;
+}
+
+export const checklistStore = writable<ChecklistState>({
+	status: null,
+	loading: false,
+	error: null,
+});
+
+// Shared between the list (left panel) and detail (right panel) views
+export const selectedStep = writable<ChecklistStep | null>(null);
+
+export async function loadChecklist(): Promise<void> {
+	checklistStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		const response = await fetch('/api/checklist');
+		const result = await response.json();
+		if (!response.ok || !result.success) {
+			throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+		}
+		checklistStore.set({ status: result, loading: false, error: null });
+		// Keep the detail panel in step with the refreshed results
+		selectedStep.update(step =>
+			step ? (result.steps as ChecklistStep[]).find(s => s.id === step.id) ?? null : null
+		);
+	} catch (e) {
+		checklistStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to load checklist',
+		}));
+	}
+}
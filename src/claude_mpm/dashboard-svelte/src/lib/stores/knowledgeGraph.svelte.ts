@@ -0,0 +1,103 @@
+import { writable } from 'svelte/store';
+
+export type EntityType = 'service' | 'endpoint' | 'schema' | 'owner' | 'contract';
+
+export interface GraphEntity {
+	id: string;
+	type: EntityType;
+	name: string;
+	attributes: Record<string, unknown>;
+	files: string[];
+}
+
+export interface GraphNeighbor {
+	relation: string;
+	direction: 'in' | 'out';
+	entity: Partial<GraphEntity> & { id: string };
+}
+
+export interface GraphEntityDetail extends GraphEntity {
+	relations: GraphNeighbor[];
+}
+
+export interface GraphSummary {
+	entities: number;
+	relations: number;
+	by_type: Record<EntityType, number>;
+	updated_at: string | null;
+}
+
+interface KnowledgeGraphState {
+	summary: GraphSummary | null;
+	entities: GraphEntity[];
+	loading: boolean;
+	error: string | null;
+}
+
+const initialState: KnowledgeGraphState = {
+	summary: null,
+	entities: [],
+	loading: false,
+	error: null,
+};
+
+export const knowledgeGraphStore = writable<KnowledgeGraphState>(initialState);
+
+// Shared between the list (left panel) and detail (right panel) views
+export const selectedGraphEntity = writable<GraphEntityDetail | null>(null);
+
+async function getJson(url: string, init?: RequestInit): Promise<any> {
+	const response = await fetch(url, init);
+	const result = await response.json();
+	if (!response.ok || !result.success) {
+		throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+	}
+	return result;
+}
+
+export async function loadKnowledgeGraph(query = '', type: EntityType | '' = ''): Promise<void> {
+	knowledgeGraphStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		const params = new URLSearchParams({ q: query });
+		if (type) params.set('type', type);
+		const result = await getJson(`/api/knowledge-graph?${params}`);
+		knowledgeGraphStore.set({
+			summary: result.summary,
+			entities: result.entities,
+			loading: false,
+			error: null,
+		});
+	} catch (e) {
+		knowledgeGraphStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to load knowledge graph',
+		}));
+	}
+}
+
+export async function selectGraphEntity(id: string): Promise<void> {
+	try {
+		const result = await getJson(`/api/knowledge-graph/entity?id=${encodeURIComponent(id)}`);
+		selectedGraphEntity.set(result.entity);
+	} catch (e) {
+		console.error('[KnowledgeGraph] Failed to load entity:', id, e);
+		selectedGraphEntity.set(null);
+	}
+}
+
+export async function rebuildKnowledgeGraph(query = '', type: EntityType | '' = ''): Promise<void> {
+	knowledgeGraphStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		await getJson('/api/knowledge-graph/rebuild', { method: 'POST' });
+		selectedGraphEntity.set(null);
+	} catch (e) {
+		knowledgeGraphStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to rebuild knowledge graph',
+		}));
+		return;
+	}
+	await loadKnowledgeGraph(query, type);
+}
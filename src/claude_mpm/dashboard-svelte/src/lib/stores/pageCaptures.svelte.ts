@@ -0,0 +1,66 @@
+import { writable } from 'svelte/store';
+
+export type CaptureMode = 'screenshot' | 'dom' | 'both';
+
+export interface PageCapture {
+	id: string;
+	session_id: string;
+	url: string;
+	created_at: string;
+	mode: CaptureMode;
+	title: string;
+	status: number | null;
+	viewport: [number, number];
+	selector: string | null;
+	screenshot: string | null;
+	dom: string | null;
+	console_errors: string[];
+}
+
+interface PageCapturesState {
+	captures: PageCapture[];
+	loading: boolean;
+	error: string | null;
+}
+
+export const pageCapturesStore = writable<PageCapturesState>({
+	captures: [],
+	loading: false,
+	error: null,
+});
+
+// Shared between the list (left panel) and detail (right panel) views
+export const selectedCapture = writable<PageCapture | null>(null);
+
+export function captureArtifactUrl(capture: PageCapture, kind: 'screenshot' | 'dom'): string {
+	const session = encodeURIComponent(capture.session_id);
+	const id = encodeURIComponent(capture.id);
+	return `/api/captures/${session}/${id}/${kind}`;
+}
+
+export async function loadPageCaptures(session = ''): Promise<void> {
+	pageCapturesStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		const params = new URLSearchParams(session ? { session } : {});
+		const response = await fetch(`/api/captures?${params}`);
+		const result = await response.json();
+		if (!response.ok || !result.success) {
+			throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+		}
+		pageCapturesStore.set({ captures: result.captures, loading: false, error: null });
+	} catch (e) {
+		pageCapturesStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to load captures',
+		}));
+	}
+}
+
+export async function loadCaptureDom(capture: PageCapture): Promise<string> {
+	const response = await fetch(captureArtifactUrl(capture, 'dom'));
+	if (!response.ok) {
+		throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+	}
+	return response.text();
+}
@@ -0,0 +1,46 @@
+import { writable } from 'svelte/store';
+
+interface SessionLabelsState {
+	// Labels by session id
+	sessions: Record<string, string[]>;
+	// Sessions per label, including declared labels nobody used yet
+	counts: Record<string, number>;
+	taxonomy: Record<string, string>;
+	loading: boolean;
+	error: string | null;
+}
+
+export const sessionLabelsStore = writable<SessionLabelsState>({
+	sessions: {},
+	counts: {},
+	taxonomy: {},
+	loading: false,
+	error: null,
+});
+
+// Label the header's session list is filtered by ('' shows every session)
+export const selectedLabel = writable<string>('');
+
+export async function loadSessionLabels(): Promise<void> {
+	sessionLabelsStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		const response = await fetch('/api/session-labels');
+		const result = await response.json();
+		if (!response.ok || !result.success) {
+			throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+		}
+		sessionLabelsStore.set({
+			sessions: result.sessions,
+			counts: result.counts,
+			taxonomy: result.taxonomy,
+			loading: false,
+			error: null,
+		});
+	} catch (e) {
+		sessionLabelsStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to load session labels',
+		}));
+	}
+}
@@ -0,0 +1,117 @@
+import { writable, get } from 'svelte/store';
+import { socketStore } from './socket.svelte';
+import { selectedLabel } from './sessionLabels.svelte';
+
+// Matches DashboardView in services/dashboard_views.py
+export interface DashboardView {
+	name: string;
+	description: string;
+	tab: string;
+	project: 'current' | 'all';
+	label: string;
+	stream: string;
+	since: '' | '1h' | 'today';
+	left_width: number | null;
+	updated_at: string;
+}
+
+interface ViewsState {
+	views: DashboardView[];
+	loading: boolean;
+	error: string | null;
+}
+
+export const viewsStore = writable<ViewsState>({
+	views: [],
+	loading: false,
+	error: null,
+});
+
+// The view last applied or saved; changing a filter by hand keeps it selected
+export const activeView = writable<DashboardView | null>(null);
+
+// Event time window applied to the event stream ('' shows every event)
+export const sinceFilter = writable<'' | '1h' | 'today'>('');
+
+// Tab and left panel width, kept up to date by the page so they can be saved
+export const currentLayout = writable<{ tab: string; left_width: number }>({
+	tab: 'events',
+	left_width: 40,
+});
+
+async function request(url: string, init?: RequestInit): Promise<any> {
+	const response = await fetch(url, init);
+	const result = await response.json();
+	if (!response.ok || !result.success) {
+		throw new Error(result.error || `HTTP ${response.status}: ${response.statusText}`);
+	}
+	return result;
+}
+
+export async function loadViews(): Promise<void> {
+	viewsStore.update(s => ({ ...s, loading: true, error: null }));
+	try {
+		const result = await request('/api/views');
+		viewsStore.set({ views: result.views, loading: false, error: null });
+	} catch (e) {
+		viewsStore.update(s => ({
+			...s,
+			loading: false,
+			error: e instanceof Error ? e.message : 'Failed to load saved views',
+		}));
+	}
+}
+
+// Timestamp (ms) events must be newer than for the given window, or 0
+export function sinceCutoff(since: '' | '1h' | 'today', now: number = Date.now()): number {
+	if (since === '1h') return now - 60 * 60 * 1000;
+	if (since === 'today') {
+		const midnight = new Date(now);
+		midnight.setHours(0, 0, 0, 0);
+		return midnight.getTime();
+	}
+	return 0;
+}
+
+export function applyView(view: DashboardView): void {
+	socketStore.setProjectFilter(view.project);
+	selectedLabel.set(view.label);
+	socketStore.setSelectedStream(view.stream || 'all-streams');
+	sinceFilter.set(view.since);
+	activeView.set(view);
+}
+
+export async function openView(name: string): Promise<void> {
+	if (get(viewsStore).views.length === 0) await loadViews();
+	const view = get(viewsStore).views.find(v => v.name === name);
+	if (!view) throw new Error(`No saved view named '${name}'`);
+	applyView(view);
+}
+
+// Save the dashboard's current filters and layout as view *name*
+export async function saveCurrentView(name: string, description: string = ''): Promise<DashboardView> {
+	const layout = get(currentLayout);
+	const stream = get(socketStore.selectedStream);
+	const result = await request(`/api/views/${encodeURIComponent(name)}`, {
+		method: 'PUT',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify({
+			description,
+			tab: layout.tab,
+			project: get(socketStore.projectFilter),
+			label: get(selectedLabel),
+			stream: stream === 'all-streams' ? '' : stream,
+			since: get(sinceFilter),
+			left_width: Math.min(90, Math.max(10, Math.round(layout.left_width))),
+		}),
+	});
+	await loadViews();
+	activeView.set(result.view);
+	return result.view;
+}
+
+export async function deleteView(name: string): Promise<void> {
+	await request(`/api/views/${encodeURIComponent(name)}`, { method: 'DELETE' });
+	if (get(activeView)?.name === name) activeView.set(null);
+	await loadViews();
+}
@@ -0,0 +1,93 @@
+"""PreToolUse / PostToolUse hook: per-agent concurrency limits.
+
+WHAT: Enforces ``agents.max_concurrent`` (see
+      ``claude_mpm.services.agent_concurrency``). An Agent (or legacy Task)
+      call takes a slot for its ``subagent_type`` before it runs and gives
+      it back when it returns; a call past the agent's limit is denied with
+      a reason that tells the PM to wait for a running delegation.
+WHY:  Agents that share a database or a rate-limited API break when the PM
+      fans out to many of them at once.
+
+Behaviour contract
+------------------
+- Off unless the project configures ``agents.max_concurrent``.
+- Agents without a limit pass through untouched.
+- Fail-safe: any error degrades to ``{"continue": True}``; a release that
+  fails leaves the slot to expire.
+"""
+
+from __future__ import annotations
+
+import logging
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+DELEGATION_TOOLS = ("Agent", "Task")
+
+
+def build_concurrency_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Take a slot for a delegation, or deny it when its agent is at its limit.
+
+    Returns:
+        ``{"continue": True}`` when the call may run, otherwise a PreToolUse
+        deny. Never raises.
+    """
+    try:
+        if event.get("tool_name") not in DELEGATION_TOOLS:
+            return {"continue": True}
+        tool_input = event.get("tool_input") or {}
+        agent_type = tool_input.get("subagent_type") or ""
+        cwd = event.get("cwd") or ""
+        if not agent_type or not cwd:
+            return {"continue": True}
+
+        from claude_mpm.services.agent_concurrency import AgentSlots
+        from claude_mpm.services.ownership import find_project_root
+
+        slots = AgentSlots(find_project_root(cwd))
+        if not slots.limits:
+            return {"continue": True}
+        decision = slots.acquire(
+            agent_type,
+            slot_id=event.get("tool_use_id"),
+            session_id=event.get("session_id", ""),
+        )
+        if decision.granted:
+            return {"continue": True}
+        return {
+            "hookSpecificOutput": {
+                "hookEventName": "PreToolUse",
+                "permissionDecision": "deny",
+                "permissionDecisionReason": decision.reason(),
+            }
+        }
+    except Exception as exc:
+        logger.debug("agent_concurrency_hook: error (degrading): %s", exc)
+        return {"continue": True}
+
+
+def release_for_event(event: dict[str, Any]) -> bool:
+    """Give back the slot of a delegation that returned (PostToolUse).
+
+    Returns:
+        Whether a slot was released. Never raises.
+    """
+    try:
+        if event.get("tool_name") not in DELEGATION_TOOLS:
+            return False
+        cwd = event.get("cwd") or ""
+        if not cwd:
+            return False
+
+        from claude_mpm.services.agent_concurrency import AgentSlots
+        from claude_mpm.services.ownership import find_project_root
+
+        tool_input = event.get("tool_input") or {}
+        slots = AgentSlots(find_project_root(cwd), limits={})
+        return slots.release(
+            event.get("tool_use_id"), tool_input.get("subagent_type") or ""
+        )
+    except Exception as exc:
+        logger.debug("agent_concurrency_hook: release failed: %s", exc)
+        return False
@@ -0,0 +1,110 @@
+"""PreToolUse hook: hand delegations the current instructions of edited agents.
+
+WHAT: On an ``Agent`` delegation, redeploys the agent if its template or
+      overrides changed (see services/agents/agent_hot_reload.py). When the
+      agent was reloaded after the session started, the delegation prompt
+      is prefixed with the agent's current instructions and the PM is told
+      the agent was reloaded.
+WHY:  Claude Code reads agent definitions when a session starts, so an
+      edited agent otherwise needs a new session, losing its context.
+
+Behaviour contract
+------------------
+- Returns ``{}`` for other tools, agents that are not deployed in the
+  project and agents not reloaded during this session.
+- The session's start is the first timestamp in its transcript; when it
+  cannot be read, any reload counts as during the session.
+- Fail-safe: any error degrades to ``{}``.
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+from datetime import datetime
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+_SCAN_LINES = 20
+
+
+def session_started_at(transcript_path: str | None) -> datetime | None:
+    """The first timestamp in a session transcript, if it can be read."""
+    if not transcript_path:
+        return None
+    try:
+        with open(transcript_path, encoding="utf-8") as transcript:
+            for _, line in zip(range(_SCAN_LINES), transcript, strict=False):
+                try:
+                    timestamp = json.loads(line).get("timestamp")
+                except (ValueError, AttributeError):
+                    continue
+                if timestamp:
+                    return datetime.fromisoformat(timestamp.replace("Z", "+00:00"))
+    except (OSError, ValueError):
+        pass
+    return None
+
+
+def build_reload_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Redeploy a stale delegated agent and pass on its current instructions.
+
+    Returns:
+        ``{}`` when nothing changes, otherwise a ``hookSpecificOutput`` with
+        the rewritten ``updatedInput`` and an ``additionalContext`` notice.
+        Never raises.
+    """
+    try:
+        tool_input = event.get("tool_input")
+        if event.get("tool_name") != "Agent" or not isinstance(tool_input, dict):
+            return {}
+        name = str(tool_input.get("subagent_type") or "").strip()
+        name = name.rsplit(":", 1)[-1].lower().replace("_", "-")
+        cwd = event.get("cwd") or ""
+        if not name or "/" in name or not cwd:
+            return {}
+
+        from claude_mpm.services.agents.agent_hot_reload import (
+            AgentReloader,
+            current_instructions,
+        )
+        from claude_mpm.services.ownership import find_project_root
+
+        project_root = find_project_root(cwd)
+        reloader = AgentReloader(project_root)
+        if name not in reloader.deployed():
+            return {}
+        if reloader.is_stale(name):
+            reloader.reload(name)
+        reloaded_at = reloader.reloaded_at(name)
+        if reloaded_at is None:
+            return {}
+        started_at = session_started_at(event.get("transcript_path"))
+        if started_at is not None and reloaded_at <= started_at:
+            return {}
+
+        instructions = current_instructions(project_root, name)
+        prompt = str(tool_input.get("prompt") or "")
+        updated = dict(tool_input)
+        updated["prompt"] = (
+            f"Your agent definition ({name}) was updated during this session. "
+            "These are your current instructions; they replace the ones you "
+            "were started with:\n\n"
+            f"<agent-instructions>\n{instructions}\n</agent-instructions>\n\n"
+            f"{prompt}"
+        )
+        return {
+            "hookSpecificOutput": {
+                "hookEventName": "PreToolUse",
+                "additionalContext": (
+                    f"Agent '{name}' was reloaded at "
+                    f"{reloaded_at.astimezone().strftime('%H:%M')}; this "
+                    "delegation uses its current instructions"
+                ),
+                "updatedInput": updated,
+            }
+        }
+    except Exception as exc:
+        logger.debug("agent_reload_hook: error (degrading): %s", exc)
+        return {}
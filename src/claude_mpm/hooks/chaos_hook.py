@@ -0,0 +1,54 @@
+"""PreToolUse hook: chaos mode tool failures and rate limits.
+
+WHAT: While chaos mode is on (see ``claude_mpm.services.chaos``), refuses a
+      share of tool calls with a simulated failure or 429 rate limit, so
+      hooks and agents downstream see the error paths they must handle.
+WHY:  Error handling that never runs before production is untested error
+      handling.
+
+Behaviour contract
+------------------
+- Off unless ``chaos.enabled`` or ``CLAUDE_MPM_CHAOS=1``.
+- A refused call's reason starts with ``[chaos]``.
+- Fail-safe: any error degrades to ``{"continue": True}``; chaos mode never
+  breaks a session by accident.
+"""
+
+from __future__ import annotations
+
+import logging
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+
+def build_chaos_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Deny a tool call with an injected fault, or let it through.
+
+    Returns:
+        ``{"continue": True}`` when no fault is injected, otherwise a
+        PreToolUse deny. Never raises.
+    """
+    try:
+        tool_name = event.get("tool_name") or ""
+        if not tool_name:
+            return {"continue": True}
+
+        from claude_mpm.services.chaos import load_injector
+        from claude_mpm.services.ownership import find_project_root
+
+        cwd = event.get("cwd") or ""
+        injector = load_injector(find_project_root(cwd) if cwd else None)
+        fault = injector.tool_fault(tool_name, event.get("tool_use_id"))
+        if fault is None:
+            return {"continue": True}
+        return {
+            "hookSpecificOutput": {
+                "hookEventName": "PreToolUse",
+                "permissionDecision": "deny",
+                "permissionDecisionReason": fault.reason(),
+            }
+        }
+    except Exception as exc:
+        logger.debug("chaos_hook: error (degrading): %s", exc)
+        return {"continue": True}
@@ -99,6 +99,11 @@ class PassthroughHandlers:
                 self.base._check_paused_session_tasks(working_dir)
             )
 
+        # Record labels from `run --label` under the new session id
+        from claude_mpm.services.session_labels import record_session_start
+
+        session_start_data["labels"] = record_session_start(event)
+
         # Debug logging
         _log(
             f"Hook handler: Processing SessionStart - session: '{session_id}', pending_tasks: {session_start_data.get('pending_task_count', 0)}"
@@ -407,6 +407,11 @@ class StopHandler:
         # Emit stop event to Socket.IO
         self._emit_stop_event(event, session_id, metadata)
 
+        # Count the turn for the team adoption report (adoption_metrics flag)
+        from claude_mpm.services.adoption import record_stop_event
+
+        record_stop_event(event)
+
         # Generate rich resume log from live session state (fixes #462).
         # Without this call, generate_resume_log() is never invoked and the
         # /mpm-session-resume command falls back to the empty stub at
@@ -172,6 +172,39 @@ def _build_terminal_title_sequence(event: dict) -> str:
         return f"\x1b]0;{title}\x07"
 
 
+def _run_post_edit_pipeline(event: dict) -> str:
+    """Run the post-edit pipeline on an edited file; fail-open.
+
+    Returns a note for the agent when a formatter rewrote the file or a step
+    failed, or an empty string.
+    """
+    try:
+        from claude_mpm.services.post_edit_pipeline import run_for_tool_event
+
+        result = run_for_tool_event(event)
+    except Exception as e:
+        _log(f"post-edit pipeline failed (fail-open): {e}")
+        return ""
+    if result is None or not (result.changed or result.failed):
+        return ""
+    note = f"Post-edit pipeline: {result.summary()}."
+    if result.changed:
+        note += " Re-read the file before editing it again."
+    return note
+
+
+def _gate_rewrite_response(updated_input: dict, reason: str) -> dict:
+    """Allow a tool call with the input the gate hooks rewrote."""
+    output = {
+        "hookEventName": "PreToolUse",
+        "permissionDecision": "allow",
+        "updatedInput": updated_input,
+    }
+    if reason:
+        output["permissionDecisionReason"] = reason
+    return {"hookSpecificOutput": output}
+
+
 class ToolHandler:
     """Handle PreToolUse and PostToolUse events."""
 
@@ -224,6 +257,38 @@ class ToolHandler:
             # Allow-with-warning: stash reason, continue pipeline.
             _cb_warning_reason = cb_decision.get("permissionDecisionReason", "")
 
+        # Commit message / PR description gate, then ownership routing
+        # (assignees and reviewers).  They run before the rewriting hooks
+        # below so a denial skips them and a rewrite is what they see; their
+        # reasons ride along with the circuit-breaker warning.
+        _gate_input: dict | None = None
+        try:
+            from claude_mpm.hooks.message_gate_hook import build_message_gate_response
+            from claude_mpm.hooks.ownership_hook import build_ownership_response
+
+            _gate_builders = (build_message_gate_response, build_ownership_response)
+        except Exception as _e:
+            _gate_builders = ()
+            if DEBUG:
+                _log(f"gate hooks unavailable (fail-open): {_e}")
+        for _build in _gate_builders:
+            try:
+                _gate_hso = _build(event).get("hookSpecificOutput")
+                if not isinstance(_gate_hso, dict):
+                    continue
+                if _gate_hso.get("permissionDecision") == "deny":
+                    return {"hookSpecificOutput": _gate_hso}
+                if isinstance(_gate_hso.get("updatedInput"), dict):
+                    _gate_input = _gate_hso["updatedInput"]
+                    event = {**event, "tool_input": _gate_input}
+                _gate_reason = _gate_hso.get("permissionDecisionReason", "")
+                _cb_warning_reason = "; ".join(
+                    filter(None, [_cb_warning_reason, _gate_reason])
+                )
+            except Exception as _e:
+                if DEBUG:
+                    _log(f"{_build.__module__} failed (fail-open): {_e}")
+
         # Model-tier injection (Agent calls) and ztk rewriting (Bash calls).
         # These were previously handled by the pretooluse_dispatcher subprocess;
         # calling them as functions here removes that extra process per tool call.
@@ -253,6 +318,19 @@ class ToolHandler:
                 _log(f"gh_footer_hook import failed (fail-open): {_e}")
 
         _tool_name_early = event.get("tool_name", "")
+        if _tool_name_early in ("Agent", "Task"):
+            # Per-agent concurrency limit: a delegation past it is denied
+            try:
+                from claude_mpm.hooks.agent_concurrency_hook import (
+                    build_concurrency_response,
+                )
+
+                _limited = build_concurrency_response(event)
+                if _limited.get("hookSpecificOutput"):
+                    return _limited
+            except Exception as _e:
+                if DEBUG:
+                    _log(f"agent_concurrency_hook failed (fail-open): {_e}")
         if _tool_name_early == "Agent":
             try:
                 from claude_mpm.hooks.model_tier_hook import build_model_tier_response
@@ -327,6 +405,8 @@ class ToolHandler:
                     ):
                         _hso["permissionDecisionReason"] = _cb_warning_reason
                 return _footer_rewrote
+            if _gate_input is not None:
+                return _gate_rewrite_response(_gate_input, _cb_warning_reason)
         elif _tool_name_early.startswith("mcp__github__"):
             # MCP GitHub tool calls (create_pull_request, create_issue, etc.)
             # also need footer normalisation via gh_footer_hook.
@@ -348,6 +428,8 @@ class ToolHandler:
             except Exception as _e:
                 if DEBUG:
                     _log(f"gh_footer_hook (mcp) failed (fail-open): {_e}")
+            if _gate_input is not None:
+                return _gate_rewrite_response(_gate_input, _cb_warning_reason)
 
         # Enhanced debug logging for session correlation
         session_id = event.get("session_id", "")
@@ -431,6 +513,11 @@ class ToolHandler:
                     f"  - Emitted todo_updated event with {len(tool_params['todos'])} todos for session {session_id[:8]}..."
                 )
 
+        # Ownership routing rewrites tools no other hook handles
+        # (mcp-ticketer ticket creation).
+        if _gate_input is not None:
+            return _gate_rewrite_response(_gate_input, _cb_warning_reason)
+
         # Normal path: no input modification, no deny.
         # If the circuit breaker fired an allow-with-warning and this is not
         # an Agent/Bash call (those attach it above), surface the warning now.
@@ -668,6 +755,12 @@ class ToolHandler:
         if tool_call_id:
             post_tool_data["correlation_id"] = tool_call_id
 
+        # A returned delegation frees its agent's concurrency slot
+        if tool_name in ("Task", "Agent"):
+            from claude_mpm.hooks.agent_concurrency_hook import release_for_event
+
+            release_for_event(event)
+
         # Handle Task delegation completion for memory hooks and response tracking
         if tool_name == "Task":
             session_id = event.get("session_id", "")
@@ -698,6 +791,12 @@ class ToolHandler:
 
         self.hook_handler._emit_socketio_event("", "post_tool", post_tool_data)
 
+        # Count commits and verification runs for the team adoption report
+        # (default-off, adoption_metrics flag); fail-open.
+        from claude_mpm.services.adoption import record_tool_event
+
+        record_tool_event(event)
+
         # NOTE: context-usage.json is updated by the Stop event handler, not here.
         # Claude Code's PostToolUse events do NOT include a "usage" field; only
         # the Stop event carries cumulative session token totals. The stop_handler
@@ -723,4 +822,18 @@ class ToolHandler:
             if seq:
                 return {"terminalSequence": seq}
 
+        # Post-edit pipeline (default-off, post_edit.enabled in the project's
+        # configuration.yaml): format and organize imports in the file the
+        # tool just wrote, and tell the agent when it was rewritten so its
+        # next edit starts from the formatted text.
+        if tool_name in ("Write", "Edit", "MultiEdit") and exit_code == 0:
+            context = _run_post_edit_pipeline(event)
+            if context:
+                return {
+                    "hookSpecificOutput": {
+                        "hookEventName": "PostToolUse",
+                        "additionalContext": context,
+                    }
+                }
+
         return None
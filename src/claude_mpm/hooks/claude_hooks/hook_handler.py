@@ -543,7 +543,8 @@ class ClaudeHookHandler:
                     and "hookSpecificOutput" in handler_result
                 ):
                     # PreToolUse hook returned a permissionDecision envelope
-                    # (e.g. context circuit-breaker deny) -- emit it directly.
+                    # (e.g. context circuit-breaker deny), or PostToolUse
+                    # returned additionalContext -- emit it directly.
                     print(json.dumps(handler_result), flush=True)
                 elif (
                     isinstance(handler_result, dict)
@@ -690,6 +691,7 @@ class ClaudeHookHandler:
                 # Stop handlers can return decision dicts (e.g., {"decision": "block", "reason": "..."})
                 # PermissionRequest handlers return hookSpecificOutput allow/deny decisions.
                 # PostToolUse handlers may return {"terminalSequence": "..."} for tab-title updates.
+                # They may also return hookSpecificOutput (post-edit pipeline notes).
                 if (
                     (hook_type == "PreToolUse" and result is not None)
                     or (
@@ -705,7 +707,10 @@ class ClaudeHookHandler:
                     or (
                         hook_type == "PostToolUse"
                         and isinstance(result, dict)
-                        and "terminalSequence" in result
+                        and (
+                            "terminalSequence" in result
+                            or "hookSpecificOutput" in result
+                        )
                     )
                 ):
                     return_value = result
@@ -4,6 +4,7 @@
 from .connection_manager_http import ConnectionManagerService
 from .container import HookServiceContainer, get_container
 from .duplicate_detector import DuplicateEventDetector
+from .event_spool import EventSpool
 from .protocols import (
     IAutoPauseHandler,
     IConnectionManager,
@@ -20,6 +21,7 @@ from .subagent_processor import SubagentResponseProcessor
 __all__ = [
     "ConnectionManagerService",
     "DuplicateEventDetector",
+    "EventSpool",
     "HookServiceContainer",
     "IAutoPauseHandler",
     "IConnectionManager",
@@ -3,6 +3,8 @@
 This service manages:
 - HTTP POST event emission for ephemeral hook processes
 - Direct event emission without EventBus complexity
+- Offline spooling of undeliverable events (see event_spool.py)
+- Chaos mode delays and dropped deliveries (see services/chaos.py)
 
 DESIGN DECISION: Use stateless HTTP POST instead of persistent SocketIO
 connections because hook handlers are ephemeral processes (< 1 second lifetime).
@@ -16,9 +18,14 @@ is simpler and more reliable for ephemeral processes.
 """
 
 import os
+import time
 from concurrent.futures import ThreadPoolExecutor
 from datetime import UTC, datetime
 
+from claude_mpm.services.chaos import load_injector
+
+from .event_spool import EventSpool
+
 # Try to import _log from hook_handler, fall back to no-op
 try:
     from claude_mpm.hooks.claude_hooks.hook_handler import _log
@@ -74,8 +81,7 @@ class ConnectionManagerService:
         self.event_normalizer = EventNormalizer()
 
         # Server configuration for HTTP POST
-        self.server_host = os.environ.get("CLAUDE_MPM_SERVER_HOST", "localhost")
-        self.server_port = int(os.environ.get("CLAUDE_MPM_SERVER_PORT", "8765"))
+        self.server_host, self.server_port = self._resolve_server(os.getcwd())
         self.http_endpoint = f"http://{self.server_host}:{self.server_port}/api/events"
 
         # Thread pool for non-blocking HTTP requests
@@ -85,11 +91,41 @@ class ConnectionManagerService:
             max_workers=2, thread_name_prefix="http-emit"
         )
 
+        # Offline queue: events that fail to deliver are spooled to disk and
+        # replayed after the next successful POST instead of being dropped.
+        self.event_spool = EventSpool()
+
+        # Chaos mode: late and dropped deliveries exercise the spool
+        self.chaos = load_injector(os.getcwd())
+
         if DEBUG:
             _log(
                 f"✅ HTTP connection manager initialized - endpoint: {self.http_endpoint}"
             )
 
+    @staticmethod
+    def _resolve_server(cwd: str) -> tuple[str, int]:
+        """Host and port of this project's dashboard server.
+
+        WHY: Each project's server may be on its own negotiated port, so the
+        discovery registry is asked first; the environment and the default
+        port only apply when the project has no live server registered.
+        """
+        try:
+            from claude_mpm.services.server_discovery import ServerDiscoveryRegistry
+
+            entry = ServerDiscoveryRegistry().lookup_nearest(cwd)
+        except Exception as e:
+            entry = None
+            if DEBUG:
+                _log(f"⚠️ Server discovery lookup failed: {e}")
+        if entry and entry.get("port"):
+            return entry.get("host") or "localhost", int(entry["port"])
+        return (
+            os.environ.get("CLAUDE_MPM_SERVER_HOST", "localhost"),
+            int(os.environ.get("CLAUDE_MPM_SERVER_PORT", "8765")),
+        )
+
     def emit_event(self, namespace: str, event: str, data: dict):
         """Emit event using HTTP POST.
 
@@ -149,15 +185,40 @@ class ConnectionManagerService:
         self._http_executor.submit(self._http_emit_blocking, namespace, event, data)
 
     def _http_emit_blocking(self, namespace: str, event: str, data: dict):
-        """HTTP emission in background thread (blocking operation isolated)."""
-        try:
-            # Create payload for HTTP API
-            payload = {
-                "namespace": namespace,
-                "event": "claude_event",  # Standard event name for dashboard
-                "data": data,
-            }
+        """HTTP emission in background thread (blocking operation isolated).
+
+        Undelivered payloads go to the offline spool; after a successful POST
+        any spooled backlog is replayed so the dashboard history has no gaps.
+        """
+        # Create payload for HTTP API
+        payload = {
+            "namespace": namespace,
+            "event": "claude_event",  # Standard event name for dashboard
+            "data": data,
+        }
+
+        delay = self.chaos.event_delay(data.get("correlation_id"))
+        if delay:
+            time.sleep(delay)
 
+        if self._post_payload(payload, event):
+            flushed = self.event_spool.flush(self._post_payload)
+            if DEBUG and flushed:
+                _log(f"✅ Replayed {flushed} spooled event(s)")
+        elif self.event_spool.enqueue(payload) and DEBUG:
+            _log(f"📥 Spooled undelivered event: {event}")
+
+    def _post_payload(self, payload: dict, event: str = "spooled") -> bool:
+        """POST a single payload to the dashboard server.
+
+        Returns:
+            True if the server accepted the event
+        """
+        if self.chaos.drop_event():
+            if DEBUG:
+                _log(f"⚠️ Chaos mode dropped delivery of: {event}")
+            return False
+        try:
             # Send HTTP POST with reasonable timeout
             response = requests.post(
                 self.http_endpoint,
@@ -169,7 +230,8 @@ class ConnectionManagerService:
             if response.status_code in [200, 204]:
                 if DEBUG:
                     _log(f"✅ HTTP POST successful: {event}")
-            elif DEBUG:
+                return True
+            if DEBUG:
                 _log(f"⚠️ HTTP POST failed with status {response.status_code}: {event}")
 
         except requests.exceptions.Timeout:
@@ -183,6 +245,7 @@ class ConnectionManagerService:
         except Exception as e:
             if DEBUG:
                 _log(f"⚠️ HTTP POST error for {event}: {e}")
+        return False
 
     def cleanup(self):
         """Cleanup connections on service destruction.
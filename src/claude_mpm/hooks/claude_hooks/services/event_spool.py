@@ -0,0 +1,145 @@
+"""Disk-backed offline queue for hook events.
+
+This service manages:
+- Spooling events to disk when the dashboard server is unreachable
+- Replaying spooled events in order once connectivity returns
+
+DESIGN DECISION: One JSON file per event instead of a shared JSONL file.
+Hook handlers are ephemeral and run concurrently (one process per tool call),
+so appending to a shared file would need cross-process locking. Writing each
+event to its own file via tmp + rename is atomic on POSIX and lock-free, and
+the time-ordered filename gives us FIFO replay for free.
+
+DESIGN DECISION: Bounded spool. When the server stays down for a long time the
+oldest events are dropped once ``max_events`` is exceeded, so a forgotten
+dashboard can never fill the disk.
+"""
+
+import json
+import os
+import time
+import uuid
+from collections.abc import Callable
+from pathlib import Path
+from typing import Any
+
+# Default spool location (user-level; hooks from every project share it)
+DEFAULT_SPOOL_DIR = Path.home() / ".claude-mpm" / "event_spool"
+
+# Upper bound on spooled events before the oldest are discarded
+DEFAULT_MAX_EVENTS = 1000
+
+# Maximum events replayed per flush. Keeps a single hook process from spending
+# its whole (short) lifetime draining a large backlog.
+DEFAULT_FLUSH_BATCH = 50
+
+
+class EventSpool:
+    """FIFO on-disk queue of undelivered hook event payloads."""
+
+    def __init__(
+        self,
+        spool_dir: Path | None = None,
+        max_events: int = DEFAULT_MAX_EVENTS,
+    ):
+        """Initialize the spool.
+
+        Args:
+            spool_dir: Directory holding spooled events
+                (default: ~/.claude-mpm/event_spool, or
+                ``CLAUDE_MPM_EVENT_SPOOL_DIR`` when set)
+            max_events: Maximum number of events retained on disk
+        """
+        if spool_dir is None:
+            env_dir = os.environ.get("CLAUDE_MPM_EVENT_SPOOL_DIR")
+            spool_dir = Path(env_dir) if env_dir else DEFAULT_SPOOL_DIR
+        self.spool_dir = spool_dir
+        self.max_events = max_events
+
+    def _pending_files(self) -> list[Path]:
+        """Return spooled event files, oldest first."""
+        if not self.spool_dir.exists():
+            return []
+        return sorted(self.spool_dir.glob("*.json"))
+
+    def pending_count(self) -> int:
+        """Number of events waiting for delivery."""
+        return len(self._pending_files())
+
+    def enqueue(self, payload: dict[str, Any]) -> bool:
+        """Persist an undelivered payload.
+
+        Args:
+            payload: The exact HTTP payload that failed to deliver
+
+        Returns:
+            True if the payload was written to disk
+        """
+        try:
+            self.spool_dir.mkdir(parents=True, exist_ok=True)
+            # time_ns prefix keeps lexical order == arrival order; the uuid
+            # suffix disambiguates events from concurrent hook processes.
+            name = f"{time.time_ns():020d}-{uuid.uuid4().hex[:8]}.json"
+            target = self.spool_dir / name
+            tmp = self.spool_dir / f".{name}.tmp"
+            tmp.write_text(json.dumps(payload))
+            tmp.replace(target)
+        except (OSError, TypeError, ValueError):
+            return False
+
+        self._enforce_limit()
+        return True
+
+    def _enforce_limit(self) -> None:
+        """Drop the oldest events once the spool exceeds ``max_events``."""
+        files = self._pending_files()
+        overflow = len(files) - self.max_events
+        for path in files[: max(overflow, 0)]:
+            path.unlink(missing_ok=True)
+
+    def flush(
+        self,
+        send: Callable[[dict[str, Any]], bool],
+        limit: int = DEFAULT_FLUSH_BATCH,
+    ) -> int:
+        """Replay spooled events through ``send`` in arrival order.
+
+        Stops at the first failed delivery so ordering is preserved and we do
+        not hammer a server that just went away again.
+
+        Args:
+            send: Callable delivering one payload; returns True on success
+            limit: Maximum events to replay in this call
+
+        Returns:
+            Number of events delivered and removed from the spool
+        """
+        delivered = 0
+        for path in self._pending_files()[:limit]:
+            try:
+                payload = json.loads(path.read_text())
+            except FileNotFoundError:
+                # Another hook process delivered it first
+                continue
+            except (OSError, json.JSONDecodeError):
+                # Corrupt entry - discard rather than block the queue forever
+                path.unlink(missing_ok=True)
+                continue
+
+            if not send(payload):
+                break
+
+            path.unlink(missing_ok=True)
+            delivered += 1
+        return delivered
+
+    def clear(self) -> int:
+        """Discard all spooled events.
+
+        Returns:
+            Number of events removed
+        """
+        files = self._pending_files()
+        for path in files:
+            path.unlink(missing_ok=True)
+        return len(files)
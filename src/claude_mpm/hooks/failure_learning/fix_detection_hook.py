@@ -49,6 +49,9 @@ class FixDetectionHook(BaseHook):
         "Task",  # Subagent delegation
     ]
 
+    # Tools whose successful use is recorded as part of a pending fix
+    EDIT_TOOLS = ["Edit", "MultiEdit", "Write", "NotebookEdit"]
+
     def __init__(self):
         """Initialize the fix detection hook."""
         super().__init__(
@@ -74,14 +77,23 @@ class FixDetectionHook(BaseHook):
             tool_name = context.data.get("tool_name")
             exit_code = context.data.get("exit_code", 0)
 
-            # Only process monitored tools
-            if tool_name not in self.MONITORED_TOOLS:
-                return HookResult(success=True, modified=False)
-
             # Only process successful executions
             if exit_code != 0:
                 return HookResult(success=True, modified=False)
 
+            # Edits made while a failure is open become part of its fix
+            if tool_name in self.EDIT_TOOLS:
+                tool_input = context.data.get("tool_input") or {}
+                file_path = tool_input.get("file_path") or tool_input.get(
+                    "notebook_path"
+                )
+                if file_path:
+                    self.tracker.record_change(file_path)
+
+            # Only process monitored tools
+            if tool_name not in self.MONITORED_TOOLS:
+                return HookResult(success=True, modified=False)
+
             # Check if there are any unfixed failures to potentially match
             unfixed_failures = self.tracker.get_unfixed_failures()
             if not unfixed_failures:
@@ -18,6 +18,7 @@ Integration points:
 - Extracts learnings using FailureTracker
 - Formats learnings as markdown
 - Writes to agent memory files via AgentMemoryManager
+- Proposes verification gate lessons for user approval via LessonProposalStore
 """
 
 import logging
@@ -45,14 +46,33 @@ class LearningExtractionHook(BaseHook):
     AI to analyze git diffs, code changes, and generate richer learnings.
     """
 
-    def __init__(self):
-        """Initialize the learning extraction hook."""
+    def __init__(self, require_approval: bool = True, lesson_store=None):
+        """Initialize the learning extraction hook.
+
+        Args:
+            require_approval: Propose verification gate lessons instead of
+                writing them to memory directly
+            lesson_store: LessonProposalStore to use (default: project store)
+        """
         super().__init__(
             name="learning_extraction",
             priority=89,  # Last in the chain, after fix detection
         )
         self.tracker = get_failure_tracker()
+        self.require_approval = require_approval
         self._memory_manager = None
+        self._lesson_store = lesson_store
+
+    @property
+    def lesson_store(self):
+        """Lazy-load the lesson proposal store for the current project."""
+        if self._lesson_store is None:
+            from claude_mpm.services.memory.lesson_proposals import (
+                LessonProposalStore,
+            )
+
+            self._lesson_store = LessonProposalStore()
+        return self._lesson_store
 
     @property
     def memory_manager(self):
@@ -112,6 +132,12 @@ class LearningExtractionHook(BaseHook):
                 target_agent=self._determine_target_agent(context, failure_event),
             )
 
+            # Verification gate lessons wait for user approval
+            if self.require_approval and self.tracker.is_verification_failure(
+                failure_event
+            ):
+                return self._propose_lesson(learning)
+
             # Format learning as markdown
             learning_markdown = learning.to_markdown()
 
@@ -167,6 +193,39 @@ class LearningExtractionHook(BaseHook):
         metadata = context.metadata or {}
         return metadata.get("fix_detected", False)
 
+    def _propose_lesson(self, learning) -> HookResult:
+        """Store a verification gate lesson as a pending memory proposal.
+
+        WHY: Verification lessons are extracted without review, so the user
+        approves them (``claude-mpm memory lessons approve``) before they
+        reach an agent's memory and every prompt that agent receives.
+
+        Args:
+            learning: The extracted Learning
+
+        Returns:
+            HookResult describing the proposal
+        """
+        from claude_mpm.services.memory.lesson_proposals import LessonProposal
+
+        proposal = LessonProposal.from_learning(learning)
+        created = self.lesson_store.propose(proposal)
+        logger.info(
+            f"Lesson {proposal.id} for {proposal.agent} "
+            f"{'proposed' if created else 'already proposed'}: {proposal.cause}"
+        )
+        return HookResult(
+            success=True,
+            modified=False,
+            metadata={
+                "learning_extracted": True,
+                "learning_proposed": created,
+                "lesson_id": proposal.id,
+                "target_agent": learning.target_agent,
+                "learning_category": learning.category,
+            },
+        )
+
     def _determine_target_agent(self, context: HookContext, failure_event: Any) -> str:
         """Determine which agent should receive the learning.
 
@@ -270,12 +329,17 @@ class LearningExtractionHook(BaseHook):
         return items
 
 
-def get_learning_extraction_hook() -> LearningExtractionHook:
+def get_learning_extraction_hook(
+    require_approval: bool = True,
+) -> LearningExtractionHook:
     """Factory function to create learning extraction hook.
 
     WHY: Provides consistent hook creation pattern used throughout the framework.
 
+    Args:
+        require_approval: Propose verification gate lessons for approval
+
     Returns:
         Configured LearningExtractionHook instance
     """
-    return LearningExtractionHook()
+    return LearningExtractionHook(require_approval=require_approval)
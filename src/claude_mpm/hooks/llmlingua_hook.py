@@ -20,7 +20,8 @@ already produces the rewritten payload.
 
 Behavior
 --------
-- Opt-in only: ``CLAUDE_MPM_USE_LLMLINGUA=1`` must be set; otherwise pass-through.
+- Opt-in only: ``CLAUDE_MPM_USE_LLMLINGUA=1`` or the ``llmlingua`` feature
+  flag (``claude-mpm flags enable llmlingua``); otherwise pass-through.
 - Lazy import of ``llmlingua`` so the dependency stays optional.
 - Singleton ``PromptCompressor`` cached across invocations (~2-3s cold start
   is paid once per process).
@@ -71,8 +72,19 @@ def _log_debug(message: str) -> None:
 
 
 def _is_enabled() -> bool:
-    """Return True iff the opt-in env var is set to a truthy value."""
-    return os.environ.get(_ENABLE_ENV_VAR, "").lower() in ("1", "true", "yes")
+    """Return True iff the opt-in env var or the ``llmlingua`` flag is on.
+
+    A set env var decides either way; otherwise the feature flag does.
+    """
+    value = os.environ.get(_ENABLE_ENV_VAR, "").lower()
+    if value:
+        return value in ("1", "true", "yes")
+    try:
+        from claude_mpm.services.feature_flags import is_enabled
+
+        return is_enabled("llmlingua")
+    except Exception:
+        return False
 
 
 def _estimate_tokens(text: str) -> int:
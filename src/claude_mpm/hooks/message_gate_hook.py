@@ -0,0 +1,562 @@
+"""PreToolUse hook: hold commit messages and PR descriptions to project rules.
+
+WHAT: Checks the message of ``git commit`` Bash commands (``-m``/``--message``
+      and ``-F``/``--file``), the title and body of ``gh pr create``/``gh pr
+      edit``, and GitHub MCP pull request calls against configurable rules:
+
+      - ``subject_max_length``: the subject (first line or PR title) fits
+      - ``imperative``: the subject starts "Add", not "Added"/"Adds"/"Adding"
+      - ``require_ticket``: a ticket reference appears somewhere
+      - ``changelog``: the subject carries a changelog type ("feat: ...")
+
+      Depending on ``action`` the hook rewrites what it can fix (the verb
+      form, a missing ticket taken from the branch name), blocks the command
+      with the list of problems so the agent retries, or only warns.
+WHY:  Agents write commit messages and PR bodies in whatever style the model
+      prefers, and the history and changelog inherit it. Checking before the
+      command runs is the only point where the message can still change.
+
+Configuration (``message_gate`` in ~/.claude-mpm/config/configuration.yaml,
+overridden per key by <project>/.claude-mpm/configuration.yaml)::
+
+    message_gate:
+      enabled: true
+      action: rewrite          # rewrite | block | warn
+      subject_max_length: 72
+      imperative: true
+      require_ticket: false
+      ticket_pattern: '\\b[A-Z][A-Z0-9]+-\\d+\\b|#\\d+'
+      changelog: false
+      changelog_types: [feat, fix, docs, refactor, perf, test, build, ci, chore]
+
+Behaviour contract
+------------------
+- Off unless ``enabled: true``.
+- ``rewrite`` fixes what it can; if problems remain the command is denied
+  with the list (nothing is rewritten). ``block`` denies on any problem.
+  ``warn`` allows the command and attaches the problems as the reason.
+- Messages the hook cannot read exactly (shell expansions other than the
+  ``"$(cat <<'EOF' ... EOF)"`` heredoc idiom, ``-F -``) are let through.
+- Fail-safe: any error degrades to ``{"continue": True}``.
+"""
+
+from __future__ import annotations
+
+import logging
+import re
+import shlex
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.hooks.gh_footer_hook import (
+    _BODY_FLAG_RE,
+    _extract_body_file,
+    _extract_body_inline,
+    _requote,
+)
+
+logger = logging.getLogger(__name__)
+
+CONFIG_SECTION = "message_gate"
+ACTIONS = ("rewrite", "block", "warn")
+DEFAULT_TICKET_PATTERN = r"\b[A-Z][A-Z0-9]+-\d+\b|#\d+"
+DEFAULT_CHANGELOG_TYPES = (
+    "feat",
+    "fix",
+    "docs",
+    "refactor",
+    "perf",
+    "test",
+    "build",
+    "ci",
+    "chore",
+    "style",
+    "revert",
+)
+
+# Verbs recognised in their imperative form. A subject is only rewritten
+# when its first word is an inflection of one of these, so words that merely
+# look inflected ("Settings", "Docs", "Needed") are left alone.
+IMPERATIVE_VERBS = frozenset(
+    """
+    add adjust allow apply avoid bump change clarify clean cleanup convert
+    correct create declare default defer delete deprecate detect disable drop
+    enable ensure expose extend extract fix guard handle harden hide implement
+    improve include inline introduce keep log make mark merge migrate move
+    normalize normalise optimize optimise parse pass pin prefer prevent print
+    read record reduce refactor register reject release remove rename reorder
+    replace report require reset resolve restore restrict retry return reuse
+    revert rework run show simplify skip sort split stop store support switch
+    sync test tidy track trim unify update upgrade use validate wire wrap write
+    """.split()
+)
+
+# Leading ticket or changelog prefix before the subject's first word:
+# "[ABC-12] ", "ABC-12: ", "feat(cli)!: "
+_SUBJECT_PREFIX_RE = re.compile(
+    r"^(?:\[[^\]]+\]\s*|[A-Z][A-Z0-9]+-\d+:?\s+)?(?:[a-z]+(?:\([^)]*\))?!?:\s*)?"
+)
+_FIRST_WORD_RE = re.compile(r"[A-Za-z]+")
+
+
+@dataclass
+class GateConfig:
+    enabled: bool = False
+    action: str = "rewrite"
+    subject_max_length: int = 72
+    imperative: bool = True
+    require_ticket: bool = False
+    ticket_pattern: str = DEFAULT_TICKET_PATTERN
+    changelog: bool = False
+    changelog_types: tuple[str, ...] = DEFAULT_CHANGELOG_TYPES
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> GateConfig:
+        config = cls()
+        for key, value in data.items():
+            if key == "changelog_types" and isinstance(value, list):
+                config.changelog_types = tuple(str(v) for v in value)
+            elif hasattr(config, key) and key != "changelog_types":
+                setattr(config, key, value)
+        if config.action not in ACTIONS:
+            logger.debug("message_gate: unknown action %r", config.action)
+            config.action = "rewrite"
+        return config
+
+
+@dataclass
+class Review:
+    """The outcome of checking one subject and body."""
+
+    subject: str
+    body: str
+    fixed: list[str] = field(default_factory=list)
+    problems: list[str] = field(default_factory=list)
+
+    @property
+    def changed(self) -> bool:
+        return bool(self.fixed)
+
+
+# ---------------------------------------------------------------------------
+# Configuration
+# ---------------------------------------------------------------------------
+
+_CONFIG_CACHE: dict[str, GateConfig] = {}
+
+
+def _load_section(path: Path) -> dict[str, Any]:
+    if not path.is_file():
+        return {}
+    try:
+        import yaml  # type: ignore[import-untyped]
+
+        with path.open("r", encoding="utf-8") as f:
+            data = yaml.safe_load(f) or {}
+    except Exception:
+        return {}
+    section = data.get(CONFIG_SECTION) if isinstance(data, dict) else None
+    return section if isinstance(section, dict) else {}
+
+
+def load_gate_config(cwd: str | Path | None) -> GateConfig:
+    """The gate configuration for *cwd*: user file, then project file."""
+    key = str(cwd or "")
+    if key not in _CONFIG_CACHE:
+        merged = _load_section(
+            Path.home() / ".claude-mpm" / "config" / "configuration.yaml"
+        )
+        if cwd:
+            merged.update(
+                _load_section(Path(cwd) / ".claude-mpm" / "configuration.yaml")
+            )
+        _CONFIG_CACHE[key] = GateConfig.from_dict(merged)
+    return _CONFIG_CACHE[key]
+
+
+def branch_ticket(cwd: str | Path | None, pattern: str) -> str | None:
+    """The first ticket reference in the current branch name, if any."""
+    if not cwd:
+        return None
+    for directory in (Path(cwd), *Path(cwd).parents):
+        dot_git = directory / ".git"
+        try:
+            if dot_git.is_file():
+                gitdir = dot_git.read_text(encoding="utf-8").split(":", 1)[1].strip()
+                dot_git = (directory / gitdir).resolve()
+            if not (dot_git / "HEAD").is_file():
+                continue
+            head = (dot_git / "HEAD").read_text(encoding="utf-8").strip()
+        except (OSError, IndexError):
+            return None
+        if not head.startswith("ref: refs/heads/"):
+            return None
+        match = re.search(pattern, head.removeprefix("ref: refs/heads/"))
+        return match.group(0) if match else None
+    return None
+
+
+# ---------------------------------------------------------------------------
+# Rules
+# ---------------------------------------------------------------------------
+
+
+def imperative_form(word: str) -> str | None:
+    """The imperative form of an inflected verb ("Added" -> "Add"), or None.
+
+    None means the word is already imperative or is not a known verb.
+    """
+    lower = word.lower()
+    if lower in IMPERATIVE_VERBS:
+        return None
+    candidates = []
+    if lower.endswith("ies"):
+        candidates.append(lower[:-3] + "y")
+    if lower.endswith("ied"):
+        candidates.append(lower[:-3] + "y")
+    if lower.endswith("ing"):
+        candidates += [lower[:-3], lower[:-3] + "e", lower[:-4]]
+    if lower.endswith("ed"):
+        candidates += [lower[:-2], lower[:-1], lower[:-3]]
+    if lower.endswith("es"):
+        candidates.append(lower[:-2])
+    if lower.endswith("s"):
+        candidates.append(lower[:-1])
+    for candidate in candidates:
+        if candidate in IMPERATIVE_VERBS:
+            return candidate.capitalize() if word[0].isupper() else candidate
+    return None
+
+
+def review_message(
+    subject: str, body: str, config: GateConfig, ticket: str | None = None
+) -> Review:
+    """Check *subject* and *body*, fixing what can be fixed.
+
+    Args:
+        subject: Commit subject line or PR title
+        body: Commit body or PR description
+        config: The rules to apply
+        ticket: Ticket to add when one is required but missing (usually
+            taken from the branch name)
+    """
+    review = Review(subject=subject.strip(), body=body)
+
+    if not review.subject:
+        review.problems.append("the subject is empty")
+        return review
+
+    if config.imperative:
+        prefix = _SUBJECT_PREFIX_RE.match(review.subject).group(0)
+        word = _FIRST_WORD_RE.match(review.subject, len(prefix))
+        if word:
+            fixed = imperative_form(word.group(0))
+            if fixed:
+                start, end = word.span()
+                review.subject = review.subject[:start] + fixed + review.subject[end:]
+                review.fixed.append(
+                    f"the subject starts with {word.group(0)!r} (use {fixed!r})"
+                )
+
+    if len(review.subject) > config.subject_max_length:
+        review.problems.append(
+            f"the subject is {len(review.subject)} characters "
+            f"(at most {config.subject_max_length})"
+        )
+
+    if config.changelog:
+        types = "|".join(re.escape(t) for t in config.changelog_types)
+        if not re.match(rf"^(?:{types})(?:\([^)]*\))?!?: \S", review.subject):
+            examples = ", ".join(f"{t}:" for t in config.changelog_types[:3])
+            review.problems.append(
+                f"the subject has no changelog type prefix ({examples} ...)"
+            )
+
+    if config.require_ticket:
+        text = f"{review.subject}\n{review.body}"
+        if not re.search(config.ticket_pattern, text):
+            if ticket:
+                review.body = f"{review.body.rstrip()}\n\nRefs: {ticket}".lstrip()
+                review.fixed.append(f"no ticket reference (added {ticket})")
+            else:
+                review.problems.append("no ticket reference")
+    return review
+
+
+# ---------------------------------------------------------------------------
+# git commit
+# ---------------------------------------------------------------------------
+
+# One shell word: quoted and bare parts run together, stopping at
+# whitespace and control operators.
+_WORD = r"""(?:"(?:[^"\\]|\\.)*"|'[^']*'|\\.|[^\s;&|"'\\])+"""
+_WORD_RE = re.compile(_WORD)
+_GIT_COMMIT_RE = re.compile(
+    r"(?:^|[;&|(\s])git(?:\s+(?:-C|-c)\s+" + _WORD + r")*\s+commit(?=\s|$)"
+)
+_MESSAGE_FLAG_RE = re.compile(
+    r"(--message=|--message\s+|-[A-Za-z]*m(?:=|\s*))(" + _WORD + ")"
+)
+_FILE_FLAG_RE = re.compile(r"(--file=|--file\s+|-F\s*)(" + _WORD + ")")
+_OPERATOR_RE = re.compile(r"\s*(?:&&|\|\||[;|&\n)])")
+_WORD_PART_RE = re.compile(r""""((?:[^"\\]|\\.)*)"|'([^']*)'|\\(.)|([^"'\\]+)""")
+_HEREDOC_RE = re.compile(
+    r"^\$\(cat\s+<<-?\s*(['\"]?)(\w+)\1\n(.*?)\n\s*\2\s*\)$", re.DOTALL
+)
+
+
+def decode_word(word: str) -> str | None:
+    """The literal value of a shell word, or None if it expands anything."""
+    heredoc = _HEREDOC_RE.match(word[1:-1]) if word[:1] == word[-1:] == '"' else None
+    if heredoc:
+        return heredoc.group(3)
+    value = []
+    for part in _WORD_PART_RE.finditer(word):
+        double, single, escaped, bare = part.groups()
+        if single is not None:
+            value.append(single)
+        elif escaped is not None:
+            value.append(escaped)
+        elif double is not None:
+            if re.search(r"(?<!\\)[$`]", double):
+                return None
+            value.append(re.sub(r'\\([$`"\\\n])', r"\1", double))
+        else:
+            if re.search(r"[$`*?~]", bare):
+                return None
+            value.append(bare)
+    return "".join(value)
+
+
+@dataclass
+class _CommitMessage:
+    text: str
+    spans: list[tuple[int, int, str]]  # (start, end, kept flag prefix)
+    file: Path | None = None
+
+
+def _find_commit_message(command: str, cwd: str | Path | None) -> _CommitMessage | None:
+    commit = _GIT_COMMIT_RE.search(command)
+    if not commit:
+        return None
+    pos, paragraphs, spans, file = commit.end(), [], [], None
+    while pos < len(command):
+        while pos < len(command) and command[pos] in " \t":
+            pos += 1
+        if pos >= len(command) or _OPERATOR_RE.match(command, pos):
+            break
+        if message := _MESSAGE_FLAG_RE.match(command, pos):
+            text = decode_word(message.group(2))
+            if text is None:
+                return None
+            paragraphs.append(text)
+            # "-am msg" keeps its other flags: "-a"
+            flag = message.group(1).strip(" =")
+            kept = "" if flag.startswith("--") else flag[:-1].rstrip("-")
+            spans.append((message.start(), message.end(), kept))
+            pos = message.end()
+        elif attached := _FILE_FLAG_RE.match(command, pos):
+            path = decode_word(attached.group(2))
+            if path is None or path == "-":
+                return None
+            file = _resolve(path, cwd)
+            pos = attached.end()
+        elif word := _WORD_RE.match(command, pos):
+            pos = word.end()
+        else:
+            break
+    if paragraphs:
+        return _CommitMessage("\n\n".join(paragraphs), spans)
+    if file is not None:
+        try:
+            return _CommitMessage(file.read_text(encoding="utf-8"), [], file)
+        except OSError:
+            return None
+    return None
+
+
+def _resolve(path: str, cwd: str | Path | None) -> Path:
+    return Path(path) if Path(path).is_absolute() else Path(cwd or ".") / path
+
+
+def _split_message(text: str) -> tuple[str, str]:
+    subject, _, body = text.strip("\n").partition("\n")
+    return subject, body.strip("\n")
+
+
+def _join_message(subject: str, body: str) -> str:
+    return f"{subject}\n\n{body}" if body else subject
+
+
+def _rewrite_commit(command: str, message: _CommitMessage, new_text: str) -> str:
+    if message.file is not None:
+        message.file.write_text(new_text + "\n", encoding="utf-8")
+        return command
+    args = " ".join(
+        f"-m {shlex.quote(p)}" for p in new_text.split("\n\n") if p.strip()
+    )
+    (first_start, first_end, kept), *rest = message.spans
+    for start, end, other_kept in reversed(rest):
+        command = command[:start] + other_kept + command[end:]
+    replacement = f"{kept} {args}" if kept else args
+    return command[:first_start] + replacement + command[first_end:]
+
+
+# ---------------------------------------------------------------------------
+# gh pr create / edit
+# ---------------------------------------------------------------------------
+
+_GH_PR_RE = re.compile(r"\bgh\s+pr\s+(?:create|edit)\b")
+_TITLE_FLAG_RE = re.compile(r"((?:^|\s)(?:--title|-t))(=|\s+)(" + _WORD + ")")
+
+_MCP_PR_TOOLS = frozenset(
+    {"mcp__github__create_pull_request", "mcp__github__update_pull_request"}
+)
+
+
+@dataclass
+class _PullRequest:
+    subject: str
+    body: str
+    body_match: re.Match | None = None  # type: ignore[type-arg]
+    body_file: Path | None = None
+
+
+def _find_pull_request(command: str, cwd: str | Path | None) -> _PullRequest | None:
+    title = _TITLE_FLAG_RE.search(command)
+    subject = decode_word(title.group(3)) if title else None
+    if subject is None:
+        return None
+    pr = _PullRequest(subject, "")
+    if (inline := _extract_body_inline(command)) is not None:
+        pr.body, pr.body_match = inline
+    elif (path := _extract_body_file(command)) is not None and path != "-":
+        pr.body_file = _resolve(path, cwd)
+        try:
+            pr.body = pr.body_file.read_text(encoding="utf-8")
+        except OSError:
+            return None
+    return pr
+
+
+def _rewrite_pull_request(command: str, pr: _PullRequest, review: Review) -> str:
+    subject = review.subject
+    if review.body != pr.body:
+        if pr.body_match is not None:
+            value = pr.body_match.group(3)
+            quote = value[:1] if value[:1] in ('"', "'") else ""
+            command = _BODY_FLAG_RE.sub(
+                lambda m: m.group(1) + m.group(2) + _requote(review.body, quote),
+                command,
+                count=1,
+            )
+        elif pr.body_file is not None:
+            pr.body_file.write_text(review.body, encoding="utf-8")
+        else:
+            # No body to carry the added lines: append them to the title
+            subject = f"{subject} ({review.body.strip().removeprefix('Refs: ')})"
+    title = _TITLE_FLAG_RE.search(command)
+    return command[: title.start(3)] + shlex.quote(subject) + command[title.end(3) :]
+
+
+# ---------------------------------------------------------------------------
+# Top-level hook entry point
+# ---------------------------------------------------------------------------
+
+
+def _response(decision: str, reason: str) -> dict[str, Any]:
+    return {
+        "hookSpecificOutput": {
+            "hookEventName": "PreToolUse",
+            "permissionDecision": decision,
+            "permissionDecisionReason": reason,
+        }
+    }
+
+
+def _decide(review: Review, config: GateConfig, kind: str) -> dict[str, Any]:
+    problems = review.problems
+    if config.action != "rewrite":
+        problems = [*review.fixed, *problems]
+    if not problems and not review.changed:
+        return {"continue": True}
+
+    listing = "; ".join(problems)
+    if config.action == "warn":
+        return _response("allow", f"{kind} does not follow project rules: {listing}")
+    if problems:
+        return _response(
+            "deny",
+            f"{kind} does not follow project rules: {listing}. "
+            "Fix it and run the command again.",
+        )
+    return _response("allow", f"Rewrote {kind.lower()}: {'; '.join(review.fixed)}")
+
+
+def build_message_gate_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Build a PreToolUse response that gates commit and PR messages.
+
+    Returns:
+        ``{"continue": True}`` when the gate is off, the call carries no
+        message, or the message follows the rules; otherwise a
+        ``hookSpecificOutput`` that denies, warns, or carries the rewritten
+        ``updatedInput``. Never raises.
+    """
+    try:
+        tool_name: str = event.get("tool_name", "")
+        tool_input: dict[str, Any] = event.get("tool_input", {}) or {}
+        cwd = event.get("cwd") or None
+        command = tool_input.get("command", "") if tool_name == "Bash" else ""
+        # Cheap checks first: the configuration (YAML) is only read for
+        # commands that carry a message
+        if tool_name not in _MCP_PR_TOOLS and not (
+            isinstance(command, str)
+            and (_GIT_COMMIT_RE.search(command) or _GH_PR_RE.search(command))
+        ):
+            return {"continue": True}
+        config = load_gate_config(cwd)
+        if not config.enabled:
+            return {"continue": True}
+
+        if tool_name in _MCP_PR_TOOLS:
+            subject, body = tool_input.get("title"), tool_input.get("body") or ""
+            if not isinstance(subject, str) or not isinstance(body, str):
+                return {"continue": True}
+            kind = "Pull request"
+
+            def rewrite(review: Review) -> dict[str, Any]:
+                return {**tool_input, "title": review.subject, "body": review.body}
+
+        elif _GH_PR_RE.search(command):
+            pr = _find_pull_request(command, cwd)
+            if pr is None:
+                return {"continue": True}
+            subject, body, kind = pr.subject, pr.body, "Pull request"
+
+            def rewrite(review: Review) -> dict[str, Any]:
+                new_command = _rewrite_pull_request(command, pr, review)
+                return {**tool_input, "command": new_command}
+
+        else:
+            message = _find_commit_message(command, cwd)
+            if message is None:
+                return {"continue": True}
+            subject, body = _split_message(message.text)
+            kind = "Commit message"
+
+            def rewrite(review: Review) -> dict[str, Any]:
+                new_text = _join_message(review.subject, review.body)
+                new_command = _rewrite_commit(command, message, new_text)
+                return {**tool_input, "command": new_command}
+
+        review = review_message(
+            subject, body, config, branch_ticket(cwd, config.ticket_pattern)
+        )
+        response = _decide(review, config, kind)
+        if config.action == "rewrite" and review.changed and not review.problems:
+            response["hookSpecificOutput"]["updatedInput"] = rewrite(review)
+        return response
+    except Exception as exc:
+        logger.debug("message_gate_hook: error (degrading): %s", exc)
+        return {"continue": True}
@@ -0,0 +1,238 @@
+"""PreToolUse hook: route tickets and review requests to the code's owners.
+
+WHAT: When an agent opens a ticket or a pull request without naming anyone,
+      fills in who it goes to from the ownership rules (mapping file,
+      CODEOWNERS, git blame; see ``claude_mpm.services.ownership``):
+
+      - ``gh issue create``: ``--assignee`` from the files the title and body
+        mention (``src/app.py:42`` narrows blame to that line)
+      - ``mcp__github__create_issue``: ``assignees``, the same way
+      - mcp-ticketer create tools: ``assignee``, the same way
+      - ``gh pr create``: ``--reviewer`` from the files the branch changes,
+        leaving out the local git user
+WHY:  Findings and tickets filed by agents otherwise land unassigned and
+      review requests go nowhere until someone triages them by hand.
+
+Configuration: the ``ownership`` section of configuration.yaml and the
+mapping file it names (default .claude-mpm/owners.yaml).
+
+Behaviour contract
+------------------
+- Off unless ``ownership.enabled: true``.
+- Never replaces an assignee or reviewer the call already names.
+- GitHub takes handles only: owners that are not ``@handles`` (blame emails
+  with no ``authors`` entry) are left out there, and teams are asked to
+  review but never assigned.
+- Fail-safe: any error degrades to ``{"continue": True}``.
+"""
+
+from __future__ import annotations
+
+import logging
+import re
+import shlex
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.hooks.message_gate_hook import _OPERATOR_RE, _WORD_RE, decode_word
+
+logger = logging.getLogger(__name__)
+
+_GH_ISSUE_CREATE_RE = re.compile(r"\bgh\s+issue\s+create\b")
+_GH_PR_CREATE_RE = re.compile(r"\bgh\s+pr\s+create\b")
+_GITHUB_ISSUE_TOOL = "mcp__github__create_issue"
+_TICKETER_PREFIX = "mcp__mcp-ticketer__"
+
+
+def _gh_flags(command: str, start: int) -> dict[str, str | None]:
+    """Flags of the gh invocation that starts at *start*, with their values."""
+    words, pos = [], start
+    while pos < len(command):
+        while pos < len(command) and command[pos] in " \t":
+            pos += 1
+        if pos >= len(command) or _OPERATOR_RE.match(command, pos):
+            break
+        word = _WORD_RE.match(command, pos)
+        if not word:
+            break
+        words.append(word.group(0))
+        pos = word.end()
+    flags: dict[str, str | None] = {}
+    for i, word in enumerate(words):
+        if not word.startswith("-"):
+            continue
+        name, eq, value = word.partition("=")
+        if eq:
+            flags[name] = decode_word(value)
+        elif i + 1 < len(words) and not words[i + 1].startswith("-"):
+            flags[name] = decode_word(words[i + 1])
+        else:
+            flags[name] = None
+    return flags
+
+
+def _flag(flags: dict[str, str | None], *names: str) -> str | None:
+    for name in names:
+        if name in flags:
+            return flags[name] or ""
+    return None
+
+
+def _issue_text(flags: dict[str, str | None], cwd: str) -> str:
+    text = [_flag(flags, "--title", "-t") or "", _flag(flags, "--body", "-b") or ""]
+    body_file = _flag(flags, "--body-file", "-F")
+    if body_file and body_file != "-":
+        path = Path(cwd) / body_file  # an absolute body_file replaces cwd
+        try:
+            text.append(path.read_text(encoding="utf-8"))
+        except OSError:
+            pass
+    return "\n".join(text)
+
+
+def _insert_flag(
+    command: str,
+    match: re.Match,  # type: ignore[type-arg]
+    flag: str,
+    values: list[str],
+) -> str:
+    value = shlex.quote(",".join(values))
+    return f"{command[: match.end()]} {flag} {value}{command[match.end() :]}"
+
+
+def _response(updated_input: dict[str, Any], reason: str) -> dict[str, Any]:
+    return {
+        "hookSpecificOutput": {
+            "hookEventName": "PreToolUse",
+            "permissionDecision": "allow",
+            "permissionDecisionReason": reason,
+            "updatedInput": updated_input,
+        }
+    }
+
+
+def _reason(action: str, picked: dict[str, Any]) -> str:
+    owners = "; ".join(
+        f"{name} owns {', '.join(route.paths[:3])} ({route.source})"
+        for name, route in picked.items()
+    )
+    return f"{action} {', '.join(picked)}: {owners}"
+
+
+def _assignees(resolver: Any, routes: list[Any], github: bool) -> dict[str, Any]:
+    """Assignees for *routes*; GitHub takes @handles of people only."""
+    picked: dict[str, Any] = {}
+    for route in routes:
+        name = resolver.assignee_for(route.owner)
+        if "/" in name or (github and not name.startswith("@")):
+            continue
+        picked.setdefault(name.removeprefix("@"), route)
+        if len(picked) >= resolver.config.max_assignees:
+            break
+    return picked
+
+
+def _reviewers(resolver: Any, routes: list[Any]) -> dict[str, Any]:
+    """Reviewers for *routes*: @handles and @org/teams, without the author."""
+    me = resolver.current_owner()
+    picked: dict[str, Any] = {}
+    for route in routes:
+        if route.owner.startswith("@") and route.owner != me:
+            picked[route.owner.removeprefix("@")] = route
+        if len(picked) >= resolver.config.max_reviewers:
+            break
+    return picked
+
+
+def _is_ticket_create(tool_name: str, tool_input: dict[str, Any]) -> bool:
+    if not tool_name.startswith(_TICKETER_PREFIX):
+        return False
+    name = tool_name[len(_TICKETER_PREFIX) :]
+    if name in ("ticket", "issue", "task", "epic"):  # one tool, many actions
+        return tool_input.get("action") == "create"
+    return "create" in name.split("_") and "bulk" not in name
+
+
+def build_ownership_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Build a PreToolUse response that routes tickets and reviews to owners.
+
+    Returns:
+        ``{"continue": True}`` when routing is off, the call already names
+        someone, or no owner is found; otherwise an allow with the
+        ``updatedInput`` that names them. Never raises.
+    """
+    try:
+        tool_name: str = event.get("tool_name", "")
+        tool_input: dict[str, Any] = event.get("tool_input", {}) or {}
+        cwd = event.get("cwd") or ""
+        command = tool_input.get("command", "") if tool_name == "Bash" else ""
+        if not isinstance(command, str):
+            return {"continue": True}
+        issue = _GH_ISSUE_CREATE_RE.search(command)
+        pr = _GH_PR_CREATE_RE.search(command)
+        ticket = _is_ticket_create(tool_name, tool_input)
+        # Cheap checks first: configuration and git are only touched for
+        # calls that open something
+        if not cwd or not (issue or pr or ticket or tool_name == _GITHUB_ISSUE_TOOL):
+            return {"continue": True}
+
+        from claude_mpm.services.ownership import (
+            FileRef,
+            OwnershipResolver,
+            find_file_refs,
+            find_project_root,
+            load_ownership_config,
+        )
+
+        root = find_project_root(cwd)
+        config = load_ownership_config(root)
+        if not config.enabled:
+            return {"continue": True}
+        resolver = OwnershipResolver(root, config)
+
+        if pr:
+            flags = _gh_flags(command, pr.end())
+            named = _flag(flags, "--reviewer", "-r") is not None
+            if named or not config.request_reviews:
+                return {"continue": True}
+            files = resolver.changed_files(_flag(flags, "--base", "-B") or None)
+            routes = resolver.route([FileRef(path) for path in files])
+            picked = _reviewers(resolver, routes)
+            if not picked:
+                return {"continue": True}
+            new_command = _insert_flag(command, pr, "--reviewer", list(picked))
+            return _response(
+                {**tool_input, "command": new_command},
+                _reason("Requested review from", picked),
+            )
+
+        if not config.assign_tickets:
+            return {"continue": True}
+        if issue:
+            flags = _gh_flags(command, issue.end())
+            if _flag(flags, "--assignee", "-a") is not None:
+                return {"continue": True}
+            text = _issue_text(flags, cwd)
+        elif ticket:
+            if tool_input.get("assignee"):
+                return {"continue": True}
+            text = f"{tool_input.get('title', '')}\n{tool_input.get('description', '')}"
+        else:
+            if tool_input.get("assignees"):
+                return {"continue": True}
+            text = f"{tool_input.get('title', '')}\n{tool_input.get('body', '')}"
+
+        routes = resolver.route(find_file_refs(text, root))
+        picked = _assignees(resolver, routes, github=not ticket)
+        if not picked:
+            return {"continue": True}
+        names, reason = list(picked), _reason("Assigned to", picked)
+        if issue:
+            new_command = _insert_flag(command, issue, "--assignee", names)
+            return _response({**tool_input, "command": new_command}, reason)
+        if ticket:
+            return _response({**tool_input, "assignee": names[0]}, reason)
+        return _response({**tool_input, "assignees": names}, reason)
+    except Exception as exc:
+        logger.debug("ownership_hook: error (degrading): %s", exc)
+        return {"continue": True}
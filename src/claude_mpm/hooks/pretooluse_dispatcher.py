@@ -21,13 +21,25 @@ Processing order
 ----------------
 1. Parse the event from stdin.  On any failure, emit pass-through (fail-open).
 2. Route ``PermissionRequest`` events to the permission policy engine.
-3. For ``PreToolUse``: run the context circuit breaker.  It now emits
+3. In a restricted (untrusted) workspace, deny shell and network tools.
+   Deny a subagent's calls that its agent's permissions do not allow
+   (tools, Bash allowlist, writable paths, network).
+   In chaos mode, deny a share of calls with simulated failures and rate
+   limits (see ``services/chaos.py``).
+4. For ``PreToolUse``: run the context circuit breaker.  It now emits
    ``permissionDecision: "allow"`` with a warning reason (not a hard block).
    A non-blocking allow-with-warning must NOT interrupt the dispatch pipeline —
    model-tier injection / ztk rewriting must still run.  Only an actual
    ``permissionDecision: "deny"`` short-circuits immediately.
-4. Branch on ``tool_name``:
-   * ``Agent`` -> model tier injection (warning attached if present).
+5. Run the commit message / PR description gate, then ownership routing
+   (assignees and reviewers from the code's owners).  A denial returns
+   immediately; a rewrite is what the later steps see.
+6. Branch on ``tool_name``:
+   * ``Agent`` -> task-type routing (``subagent_type`` rewritten to the
+     agent the task's category goes to), per-agent concurrency limit (a
+     denial returns immediately), hot reload of an edited agent (its current
+     instructions prefixed to the prompt), then model tier injection
+     (warning attached if present).
    * ``Bash``  -> ztk rewrite (warning attached if present).
    * anything else -> pass-through (with allow+reason if breaker fired).
 
@@ -44,11 +56,19 @@ import sys
 from typing import Any
 
 from claude_mpm.hooks import (
+    agent_concurrency_hook,
+    agent_reload_hook,
+    chaos_hook,
     context_circuit_breaker,
     gh_footer_hook,
+    message_gate_hook,
     model_tier_hook,
+    ownership_hook,
+    task_routing_hook,
+    tool_permissions_hook,
     ztk_hook,
 )
+from claude_mpm.services import workspace_trust
 
 
 def _passthrough() -> dict[str, Any]:
@@ -56,6 +76,17 @@ def _passthrough() -> dict[str, Any]:
     return {"continue": True}
 
 
+def _rewrite_response(updated_input: dict[str, Any]) -> dict[str, Any]:
+    """Allow the tool call with *updated_input* in place of the original."""
+    return {
+        "hookSpecificOutput": {
+            "hookEventName": "PreToolUse",
+            "permissionDecision": "allow",
+            "updatedInput": updated_input,
+        }
+    }
+
+
 def _circuit_breaker_deny_response(decision: dict[str, Any]) -> dict[str, Any]:
     """Wrap a circuit-breaker *deny* decision in the PreToolUse wire format.
 
@@ -72,6 +103,42 @@ def _circuit_breaker_deny_response(decision: dict[str, Any]) -> dict[str, Any]:
     }
 
 
+def _untrusted_workspace_deny_response(tool_name: str) -> dict[str, Any]:
+    """Deny a shell or network tool in a restricted (untrusted) workspace."""
+    return {
+        "hookSpecificOutput": {
+            "hookEventName": "PreToolUse",
+            "permissionDecision": "deny",
+            "permissionDecisionReason": (
+                f"{tool_name} is disabled in untrusted workspaces; run "
+                "'claude-mpm trust add' to trust this repository"
+            ),
+        }
+    }
+
+
+def _merge_reload_into_response(
+    response: dict[str, Any], reload_output: dict[str, Any]
+) -> dict[str, Any]:
+    """Combine an agent reload (or task routing) rewrite with *response*.
+
+    The model tier rewrite is built on the reloaded input, so it wins when
+    present; the reload notice is kept alongside its context.
+    """
+    hso = response.get("hookSpecificOutput")
+    if not isinstance(hso, dict):
+        return {"hookSpecificOutput": reload_output}
+    merged = dict(hso)
+    merged.setdefault("updatedInput", reload_output["updatedInput"])
+    merged["additionalContext"] = "; ".join(
+        filter(
+            None,
+            [reload_output.get("additionalContext"), hso.get("additionalContext")],
+        )
+    )
+    return {**response, "hookSpecificOutput": merged}
+
+
 def _merge_warning_into_response(
     response: dict[str, Any], warning_reason: str
 ) -> dict[str, Any]:
@@ -111,7 +178,12 @@ def dispatch(event: dict[str, Any]) -> dict[str, Any]:
 
     WHAT: Reads a single hook event and routes it through the full
           PreToolUse concern stack in order — PermissionRequest routing,
-          context circuit breaker, model-tier injection (Agent), gh-footer
+          workspace trust, agent tool permissions, chaos mode fault
+          injection, context circuit breaker,
+          commit message / PR description gate, ownership routing,
+          task-type routing, per-agent concurrency limits, agent hot
+          reload and model-tier injection (Agent),
+          gh-footer
           normalisation + ztk rewrite (Bash), and MCP GitHub body
           normalisation — then returns exactly one wire-format response
           dict ready for JSON serialisation.
@@ -136,6 +208,25 @@ def dispatch(event: dict[str, Any]) -> dict[str, Any]:
         if hook_event == "PermissionRequest":
             return model_tier_hook.build_permission_request_response(event)
 
+        # Untrusted workspace: shell and network tools stay off until the
+        # user runs ``claude-mpm trust add``.
+        tool_name = event.get("tool_name", "")
+        if (
+            workspace_trust.is_restricted_session()
+            and tool_name in workspace_trust.RESTRICTED_TOOLS
+        ):
+            return _untrusted_workspace_deny_response(tool_name)
+
+        # Least-privilege agents: the subagent's declared permissions.
+        permissions = tool_permissions_hook.build_permissions_response(event)
+        if permissions.get("hookSpecificOutput"):
+            return permissions
+
+        # Chaos mode: an injected fault stands in for the tool's own result.
+        chaos = chaos_hook.build_chaos_response(event)
+        if chaos.get("hookSpecificOutput"):
+            return chaos
+
         # Context circuit breaker runs first.  It emits either:
         #   - "deny" → hard block (short-circuit immediately).
         #   - "allow" + reason → allow-with-warning (do NOT short-circuit;
@@ -152,10 +243,52 @@ def dispatch(event: dict[str, Any]) -> dict[str, Any]:
             # Allow-with-warning: stash the reason, continue the pipeline.
             warning_reason = breaker_decision.get("permissionDecisionReason", "")
 
+        # Commit message / PR description gate, then ownership routing.  They
+        # run before the other rewriters so those see their rewrites, and a
+        # denial skips the rest.
+        gate_input: dict | None = None
+        for build_response in (
+            message_gate_hook.build_message_gate_response,
+            ownership_hook.build_ownership_response,
+        ):
+            gate_output = build_response(event).get("hookSpecificOutput")
+            if not isinstance(gate_output, dict):
+                continue
+            if gate_output.get("permissionDecision") == "deny":
+                return {"hookSpecificOutput": gate_output}
+            if isinstance(gate_output.get("updatedInput"), dict):
+                gate_input = gate_output["updatedInput"]
+                event = {**event, "tool_input": gate_input}
+            gate_reason = gate_output.get("permissionDecisionReason", "")
+            warning_reason = "; ".join(filter(None, [warning_reason, gate_reason]))
+
         # Branch on the tool being invoked.
-        tool_name = event.get("tool_name", "")
+        routing_output: dict | None = None
+        if tool_name in agent_concurrency_hook.DELEGATION_TOOLS:
+            # Task-type routing first, so the limit and hot reload apply to
+            # the agent the delegation is sent to
+            routing_output = task_routing_hook.build_routing_response(event).get(
+                "hookSpecificOutput"
+            )
+            if routing_output:
+                gate_input = routing_output["updatedInput"]
+                event = {**event, "tool_input": gate_input}
+            limited = agent_concurrency_hook.build_concurrency_response(event)
+            if limited.get("hookSpecificOutput"):
+                return limited
         if tool_name == "Agent":
+            # Agents edited during the session: redeploy, and give the
+            # subagent the instructions Claude Code loaded before the edit
+            reload_output = agent_reload_hook.build_reload_response(event).get(
+                "hookSpecificOutput"
+            )
+            if reload_output:
+                event = {**event, "tool_input": reload_output["updatedInput"]}
             response = model_tier_hook.build_model_tier_response(event)
+            if reload_output:
+                response = _merge_reload_into_response(response, reload_output)
+            if routing_output:
+                response = _merge_reload_into_response(response, routing_output)
             return _merge_warning_into_response(response, warning_reason)
         if tool_name == "Bash":
             # gh_footer_hook runs first so the footer is fixed before ztk
@@ -176,6 +309,10 @@ def dispatch(event: dict[str, Any]) -> dict[str, Any]:
                 return _merge_warning_into_response(response, warning_reason)
             if _footer_rewrite is not None:
                 return _merge_warning_into_response(_footer_rewrite, warning_reason)
+            if gate_input is not None:
+                return _merge_warning_into_response(
+                    _rewrite_response(gate_input), warning_reason
+                )
             return _merge_warning_into_response(_passthrough(), warning_reason)
         if tool_name.startswith("mcp__github__"):
             # MCP GitHub body normalisation (create_pull_request, create_issue…).
@@ -183,7 +320,7 @@ def dispatch(event: dict[str, Any]) -> dict[str, Any]:
             if _mcp_resp.get("hookSpecificOutput"):
                 return _merge_warning_into_response(_mcp_resp, warning_reason)
 
-        base = _passthrough()
+        base = _passthrough() if gate_input is None else _rewrite_response(gate_input)
         return _merge_warning_into_response(base, warning_reason)
     except Exception:
         # Fail-open: a hook crash must never block a tool call.
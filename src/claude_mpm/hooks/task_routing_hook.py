@@ -0,0 +1,82 @@
+"""PreToolUse hook: route delegations by task type (see services/task_routing.py).
+
+WHAT: On an ``Agent`` (or legacy ``Task``) delegation, matches its
+      description and prompt against the project's ``agents.routing``
+      categories. A matching delegation is recorded and, when the route
+      names another agent, its ``subagent_type`` is rewritten and the PM is
+      told where it went.
+WHY:  Lets a project send a kind of work to a specialist agent, or split it
+      between an agent and a rewritten variant to compare them, without the
+      PM's instructions changing.
+
+Behaviour contract
+------------------
+- Returns ``{}`` when routing is not configured, nothing matches, or the
+  route keeps the PM's agent (the decision is still recorded for A/B
+  splits' comparison).
+- Fail-safe: any error degrades to ``{}``.
+"""
+
+from __future__ import annotations
+
+import logging
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+DELEGATION_TOOLS = ("Agent", "Task")
+
+
+def build_routing_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Send a delegation to the agent its task category is routed to.
+
+    Returns:
+        ``{}`` when the delegation keeps its agent, otherwise a
+        ``hookSpecificOutput`` with the rewritten ``updatedInput`` and an
+        ``additionalContext`` notice. Never raises.
+    """
+    try:
+        tool_input = event.get("tool_input")
+        if event.get("tool_name") not in DELEGATION_TOOLS or not isinstance(
+            tool_input, dict
+        ):
+            return {}
+        agent = str(tool_input.get("subagent_type") or "").strip()
+        cwd = event.get("cwd") or ""
+        if not agent or not cwd:
+            return {}
+
+        from claude_mpm.services.ownership import find_project_root
+        from claude_mpm.services.task_routing import TaskRouter
+
+        router = TaskRouter(find_project_root(cwd))
+        if not router.routes:
+            return {}
+        text = "\n".join(
+            str(tool_input.get(key) or "") for key in ("description", "prompt")
+        )
+        decision = router.route(
+            agent,
+            text,
+            tool_use_id=event.get("tool_use_id") or "",
+            session_id=event.get("session_id") or "",
+        )
+        if decision is None:
+            return {}
+        router.record(decision)
+        if not decision.changed:
+            return {}
+        split = " (A/B split)" if decision.split else ""
+        return {
+            "hookSpecificOutput": {
+                "hookEventName": "PreToolUse",
+                "additionalContext": (
+                    f"Task routing sent this {decision.category} delegation to "
+                    f"'{decision.agent}' instead of '{agent}'{split}"
+                ),
+                "updatedInput": {**tool_input, "subagent_type": decision.agent},
+            }
+        }
+    except Exception as exc:
+        logger.debug("task_routing_hook: error (degrading): %s", exc)
+        return {}
@@ -0,0 +1,75 @@
+"""PreToolUse hook: per-agent tool permissions.
+
+WHAT: Denies a subagent's tool call when it breaks the ``permissions`` of
+      the agent's deployed file: a tool it may not use, a Bash command
+      outside its allowlist, a write outside its writable paths, or network
+      access when it has none (see services/agents/tool_permissions.py).
+WHY:  The deployed tool list keeps other tools out of the agent's reach,
+      but only a runtime check can look at the command or path of a call.
+
+Behaviour contract
+------------------
+- Only subagent calls are checked; events without ``agent_type`` (the main
+  session) pass through.
+- Agents without a ``permissions`` block pass through.
+- An agent whose deployed permissions block is invalid is denied.
+- Any other error degrades to ``{"continue": True}``.
+"""
+
+from __future__ import annotations
+
+import logging
+from pathlib import Path
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+
+def _deny(reason: str) -> dict[str, Any]:
+    return {
+        "hookSpecificOutput": {
+            "hookEventName": "PreToolUse",
+            "permissionDecision": "deny",
+            "permissionDecisionReason": reason,
+        }
+    }
+
+
+def build_permissions_response(event: dict[str, Any]) -> dict[str, Any]:
+    """Deny a subagent's tool call its agent's permissions do not allow.
+
+    Returns:
+        ``{"continue": True}`` when the call is allowed, otherwise a
+        PreToolUse deny. Never raises.
+    """
+    try:
+        agent_type = event.get("agent_type") or ""
+        tool_name = event.get("tool_name") or ""
+        if not agent_type or not tool_name:
+            return {"continue": True}
+
+        from claude_mpm.services.agents.tool_permissions import agent_permissions
+        from claude_mpm.services.ownership import find_project_root
+
+        cwd = event.get("cwd") or ""
+        project_root = find_project_root(cwd) if cwd else Path.cwd()
+        try:
+            permissions = agent_permissions(agent_type, project_root)
+        except ValueError as exc:
+            return _deny(
+                f"Agent '{agent_type}' has invalid permissions ({exc}); "
+                "fix its permissions block and redeploy"
+            )
+        if permissions is None:
+            return {"continue": True}
+
+        tool_input = event.get("tool_input")
+        reason = permissions.check(
+            tool_name, tool_input if isinstance(tool_input, dict) else {}, project_root
+        )
+        if reason is None:
+            return {"continue": True}
+        return _deny(f"Agent '{agent_type}' permissions: {reason}")
+    except Exception as exc:
+        logger.debug("tool_permissions_hook: error (degrading): %s", exc)
+        return {"continue": True}
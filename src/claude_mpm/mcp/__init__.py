@@ -72,14 +72,68 @@ except ImportError:
     MessagingMCPServer = None  # type: ignore[assignment,misc]
     messaging_server_main = None  # type: ignore[assignment]
 
+try:
+    from claude_mpm.mcp.code_edit_server import (
+        CodeEditMCPServer,
+        main as code_edit_server_main,
+    )
+except ImportError:
+    CodeEditMCPServer = None  # type: ignore[assignment,misc]
+    code_edit_server_main = None  # type: ignore[assignment]
+
+try:
+    from claude_mpm.mcp.database_schema_server import (
+        DatabaseSchemaMCPServer,
+        main as database_schema_server_main,
+    )
+except ImportError:
+    DatabaseSchemaMCPServer = None  # type: ignore[assignment,misc]
+    database_schema_server_main = None  # type: ignore[assignment]
+
+try:
+    from claude_mpm.mcp.http_server import HttpMCPServer, main as http_server_main
+except ImportError:
+    HttpMCPServer = None  # type: ignore[assignment,misc]
+    http_server_main = None  # type: ignore[assignment]
+
+try:
+    from claude_mpm.mcp.knowledge_graph_server import (
+        KnowledgeGraphMCPServer,
+        main as knowledge_graph_server_main,
+    )
+except ImportError:
+    KnowledgeGraphMCPServer = None  # type: ignore[assignment,misc]
+    knowledge_graph_server_main = None  # type: ignore[assignment]
+
+try:
+    from claude_mpm.mcp.logs_server import LogsMCPServer, main as logs_server_main
+except ImportError:
+    LogsMCPServer = None  # type: ignore[assignment,misc]
+    logs_server_main = None  # type: ignore[assignment]
+
+try:
+    from claude_mpm.mcp.page_capture_server import (
+        PageCaptureMCPServer,
+        main as page_capture_server_main,
+    )
+except ImportError:
+    PageCaptureMCPServer = None  # type: ignore[assignment,misc]
+    page_capture_server_main = None  # type: ignore[assignment]
+
 __all__ = [
     "APIError",
     "ClaudeMPMSubprocess",
+    "CodeEditMCPServer",
     "ContextWindowError",
+    "DatabaseSchemaMCPServer",
+    "HttpMCPServer",
+    "KnowledgeGraphMCPServer",
+    "LogsMCPServer",
     "MCPProcessManager",
     "MessagingMCPServer",
     "NDJSONStreamParser",
     "NgrokTunnel",
+    "PageCaptureMCPServer",
     "RateLimitError",
     "RcloneConfig",
     "RcloneManager",
@@ -94,9 +148,15 @@ __all__ = [
     "SessionStatus",
     "TunnelInfo",
     "check_rclone_available",
+    "code_edit_server_main",
+    "database_schema_server_main",
     "extract_session_id",
     "extract_session_id_from_stream",
+    "http_server_main",
+    "knowledge_graph_server_main",
+    "logs_server_main",
     "messaging_server_main",
+    "page_capture_server_main",
     "parse_error",
     "session_server_http_main",
     "session_server_main",
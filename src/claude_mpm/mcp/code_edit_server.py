@@ -0,0 +1,244 @@
+"""Internal MCP server for syntax-aware code edits.
+
+WHY: Agents editing code with string replacement miss when the old text is
+repeated, and silently break structure when it is near an edge (a method
+pasted after its class). These tools locate functions, classes and
+identifiers through the syntax tree, re-parse the result and refuse edits
+that would not parse, so an edit is structurally valid or not made.
+
+Parsing is CPU-bound and runs in asyncio.to_thread() to keep the server's
+event loop responsive.
+"""
+
+import asyncio
+import json
+import logging
+from pathlib import Path
+from typing import Any
+
+from mcp.server import Server
+from mcp.server.stdio import stdio_server
+from mcp.types import TextContent, Tool
+
+from claude_mpm.mcp.messaging_server import _resolve_default_project_root
+from claude_mpm.services.code_edit import BODY, WRAP_TARGETS, CodeEditor
+
+logger = logging.getLogger(__name__)
+
+_PATH = {"type": "string", "description": "File path relative to the project root"}
+_DRY_RUN = {
+    "type": "boolean",
+    "description": "Return the diff without writing the file",
+}
+
+
+class CodeEditMCPServer:
+    """MCP server for structural code edits.
+
+    Exposes 4 tools:
+      list_definitions, rename_symbol, insert_method, wrap_function
+    """
+
+    def __init__(self, project_root: Path | None = None) -> None:
+        """Initialise the Code Edit MCP server."""
+        self.server = Server("mpm-code-edit")
+        self.project_root = project_root or _resolve_default_project_root()
+        self.editor = CodeEditor(self.project_root)
+        self._setup_handlers()
+
+    def _setup_handlers(self) -> None:
+        """Register MCP tool handlers (see MessagingMCPServer._setup_handlers)."""
+        self.server.list_tools()(self._handle_list_tools)
+        self.server.call_tool()(self._handle_call_tool)
+
+    async def _handle_list_tools(self) -> list[Tool]:
+        """Return list of available tools."""
+        return [
+            Tool(
+                name="list_definitions",
+                description=(
+                    "List the functions, methods, classes and other definitions "
+                    "in a file with their line ranges. Names are what the edit "
+                    "tools accept (Parent.name for members)."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {"path": _PATH},
+                    "required": ["path"],
+                },
+            ),
+            Tool(
+                name="rename_symbol",
+                description=(
+                    "Rename every identifier with a given name in a file, or only "
+                    "inside one function or class (within). Strings and comments "
+                    "are left alone. Renames by name, not by binding: attributes "
+                    "of other objects with the same name change too, so narrow "
+                    "with within when the name is common."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "path": _PATH,
+                        "old_name": {"type": "string"},
+                        "new_name": {"type": "string"},
+                        "within": {
+                            "type": "string",
+                            "description": "Definition to limit the rename to",
+                        },
+                        "dry_run": _DRY_RUN,
+                    },
+                    "required": ["path", "old_name", "new_name"],
+                },
+            ),
+            Tool(
+                name="insert_method",
+                description=(
+                    "Insert a method into a class, interface, Rust impl block or "
+                    "trait (for Go, after the type's methods), re-indented to "
+                    "fit. Goes last unless after names an existing member."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "path": _PATH,
+                        "container": {
+                            "type": "string",
+                            "description": "Class, interface, type or impl name",
+                        },
+                        "code": {
+                            "type": "string",
+                            "description": "The method, at any indentation",
+                        },
+                        "after": {
+                            "type": "string",
+                            "description": "Member to insert after",
+                        },
+                        "dry_run": _DRY_RUN,
+                    },
+                    "required": ["path", "container", "code"],
+                },
+            ),
+            Tool(
+                name="wrap_function",
+                description=(
+                    "Wrap a function's body (or the whole function) in a template, "
+                    "e.g. 'try:\\n    {code}\\nexcept OSError:\\n    raise'. The "
+                    "template needs one line holding only {code}; the wrapped "
+                    "lines are indented as that line is."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "path": _PATH,
+                        "function": {
+                            "type": "string",
+                            "description": "Function or Parent.method name",
+                        },
+                        "template": {"type": "string"},
+                        "target": {
+                            "type": "string",
+                            "enum": list(WRAP_TARGETS),
+                            "description": f"What to wrap (default {BODY})",
+                        },
+                        "dry_run": _DRY_RUN,
+                    },
+                    "required": ["path", "function", "template"],
+                },
+            ),
+        ]
+
+    async def _handle_call_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> list[TextContent]:
+        """Handle tool calls by dispatching to the appropriate handler."""
+        try:
+            result = await self._dispatch_tool(name, arguments or {})
+            return [TextContent(type="text", text=json.dumps(result, indent=2))]
+        except Exception as e:
+            logger.exception(f"Error executing tool {name}: {e}")
+            return [
+                TextContent(type="text", text=json.dumps({"error": str(e)}, indent=2))
+            ]
+
+    async def _dispatch_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Dispatch tool call to appropriate handler.
+
+        Raises:
+            ValueError: If tool name is not recognised.
+        """
+        handlers = {
+            "list_definitions": self._list_definitions,
+            "rename_symbol": self._rename_symbol,
+            "insert_method": self._insert_method,
+            "wrap_function": self._wrap_function,
+        }
+        handler = handlers.get(name)
+        if handler is None:
+            raise ValueError(f"Unknown tool: {name}")
+        return await handler(arguments)
+
+    # ------------------------------------------------------------------
+    # Tool handlers
+    # ------------------------------------------------------------------
+
+    async def _list_definitions(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        definitions = await asyncio.to_thread(
+            self.editor.definitions, arguments["path"]
+        )
+        return {"path": arguments["path"], "definitions": definitions}
+
+    async def _rename_symbol(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        result = await asyncio.to_thread(
+            self.editor.rename_symbol,
+            arguments["path"],
+            arguments["old_name"],
+            arguments["new_name"],
+            within=arguments.get("within"),
+            dry_run=bool(arguments.get("dry_run", False)),
+        )
+        return result.to_dict()
+
+    async def _insert_method(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        result = await asyncio.to_thread(
+            self.editor.insert_method,
+            arguments["path"],
+            arguments["container"],
+            arguments["code"],
+            after=arguments.get("after"),
+            dry_run=bool(arguments.get("dry_run", False)),
+        )
+        return result.to_dict()
+
+    async def _wrap_function(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        result = await asyncio.to_thread(
+            self.editor.wrap_function,
+            arguments["path"],
+            arguments["function"],
+            arguments["template"],
+            target=arguments.get("target", BODY),
+            dry_run=bool(arguments.get("dry_run", False)),
+        )
+        return result.to_dict()
+
+    async def run(self) -> None:
+        """Run the MCP server using stdio transport."""
+        async with stdio_server() as (read_stream, write_stream):
+            await self.server.run(
+                read_stream,
+                write_stream,
+                self.server.create_initialization_options(),
+            )
+
+
+def main() -> None:
+    """Entry point for the Code Edit MCP server."""
+    logging.basicConfig(level=logging.INFO)
+    server = CodeEditMCPServer()
+    asyncio.run(server.run())
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,188 @@
+"""Internal MCP server for the configured dev database schema.
+
+WHY: Migrations and query changes written against a guessed schema fail at
+review time or, worse, at deploy time. This server lets agents read the real
+tables, columns and indexes of the dev database named in the
+``database_schema`` config section. It is read-only and never runs queries
+against application data.
+
+Introspection blocks on the database driver and is wrapped in
+asyncio.to_thread() to avoid blocking the event loop.
+"""
+
+import asyncio
+import json
+import logging
+from typing import Any
+
+from mcp.server import Server
+from mcp.server.stdio import stdio_server
+from mcp.types import TextContent, Tool
+
+from claude_mpm.services.database_schema import (
+    DatabaseSchemaConfig,
+    DatabaseSchemaService,
+)
+
+logger = logging.getLogger(__name__)
+
+
+class DatabaseSchemaMCPServer:
+    """MCP server exposing the dev database schema.
+
+    Exposes 3 tools:
+      db_list_tables, db_describe_table, db_find_columns
+    """
+
+    def __init__(self, config: DatabaseSchemaConfig | None = None) -> None:
+        """Initialise the Database Schema MCP server."""
+        self.server = Server("mpm-database-schema")
+        self.schema = DatabaseSchemaService(config)
+        self._setup_handlers()
+
+    def _setup_handlers(self) -> None:
+        """Register MCP tool handlers (see MessagingMCPServer._setup_handlers)."""
+        self.server.list_tools()(self._handle_list_tools)
+        self.server.call_tool()(self._handle_call_tool)
+
+    async def _handle_list_tools(self) -> list[Tool]:
+        """Return list of available tools."""
+        return [
+            Tool(
+                name="db_list_tables",
+                description=(
+                    "List tables and views in the dev database with their column "
+                    "counts. Call before writing a migration or query."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "filter": {
+                            "type": "string",
+                            "description": "Only tables whose name contains this",
+                        },
+                        "refresh": {
+                            "type": "boolean",
+                            "description": "Re-read the schema instead of the cache",
+                        },
+                    },
+                },
+            ),
+            Tool(
+                name="db_describe_table",
+                description=(
+                    "Columns (type, nullability, default, primary key) and "
+                    "indexes of one table as it exists in the dev database."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "table": {
+                            "type": "string",
+                            "description": "Table name, optionally schema-qualified",
+                        }
+                    },
+                    "required": ["table"],
+                },
+            ),
+            Tool(
+                name="db_find_columns",
+                description=(
+                    "Find columns by name across all tables, e.g. every table "
+                    "with a user_id column."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "name": {
+                            "type": "string",
+                            "description": "Text the column name contains",
+                        }
+                    },
+                    "required": ["name"],
+                },
+            ),
+        ]
+
+    async def _handle_call_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> list[TextContent]:
+        """Handle tool calls by dispatching to the appropriate handler."""
+        try:
+            result = await self._dispatch_tool(name, arguments or {})
+            return [TextContent(type="text", text=json.dumps(result, indent=2))]
+        except Exception as e:
+            logger.exception(f"Error executing tool {name}: {e}")
+            return [
+                TextContent(type="text", text=json.dumps({"error": str(e)}, indent=2))
+            ]
+
+    async def _dispatch_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Dispatch tool call to appropriate handler.
+
+        Raises:
+            ValueError: If tool name is not recognised.
+        """
+        handlers = {
+            "db_list_tables": self._db_list_tables,
+            "db_describe_table": self._db_describe_table,
+            "db_find_columns": self._db_find_columns,
+        }
+        handler = handlers.get(name)
+        if handler is None:
+            raise ValueError(f"Unknown tool: {name}")
+        return await handler(arguments)
+
+    # ------------------------------------------------------------------
+    # Tool handlers
+    # ------------------------------------------------------------------
+
+    async def _db_list_tables(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        tables = await asyncio.to_thread(
+            self.schema.tables, bool(arguments.get("refresh"))
+        )
+        needle = arguments.get("filter", "").lower()
+        listed = [
+            {
+                "name": table.qualified_name,
+                "kind": table.kind,
+                "columns": len(table.columns),
+            }
+            for table in tables
+            if needle in table.qualified_name.lower()
+        ]
+        return {**self.schema.describe(), "count": len(listed), "tables": listed}
+
+    async def _db_describe_table(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        await asyncio.to_thread(self.schema.tables)
+        table = self.schema.table(arguments["table"])
+        if table is None:
+            return {"error": f"No table named {arguments['table']!r}"}
+        return table.to_dict()
+
+    async def _db_find_columns(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        await asyncio.to_thread(self.schema.tables)
+        columns = self.schema.find_columns(arguments["name"])
+        return {"count": len(columns), "columns": columns}
+
+    async def run(self) -> None:
+        """Run the MCP server using stdio transport."""
+        async with stdio_server() as (read_stream, write_stream):
+            await self.server.run(
+                read_stream,
+                write_stream,
+                self.server.create_initialization_options(),
+            )
+
+
+def main() -> None:
+    """Entry point for the Database Schema MCP server."""
+    logging.basicConfig(level=logging.INFO)
+    server = DatabaseSchemaMCPServer()
+    asyncio.run(server.run())
+
+
+if __name__ == "__main__":
+    main()
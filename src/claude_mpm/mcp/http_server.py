@@ -0,0 +1,170 @@
+"""Internal MCP server for policy-controlled HTTP requests.
+
+WHY: Agents working on API integrations need to see real responses, but an
+unrestricted fetch tool can reach anything. This server only calls hosts in
+the ``http_tool.allowed_hosts`` config, caps response sizes, and records
+exchanges as JSON fixtures the project's tests can replay.
+
+Requests block and are wrapped in asyncio.to_thread() to avoid blocking the
+event loop.
+"""
+
+import asyncio
+import json
+import logging
+from pathlib import Path
+from typing import Any
+
+from mcp.server import Server
+from mcp.server.stdio import stdio_server
+from mcp.types import TextContent, Tool
+
+from claude_mpm.mcp.messaging_server import _resolve_default_project_root
+from claude_mpm.services.http_fixtures import METHODS, HttpRequestTool
+
+logger = logging.getLogger(__name__)
+
+
+class HttpMCPServer:
+    """MCP server exposing allowlisted HTTP requests and their fixtures.
+
+    Exposes 3 tools:
+      http_request, list_http_fixtures, get_http_fixture
+    """
+
+    def __init__(self, project_root: Path | None = None) -> None:
+        """Initialise the HTTP MCP server."""
+        self.server = Server("mpm-http")
+        self.project_root = project_root or _resolve_default_project_root()
+        self.http = HttpRequestTool(self.project_root)
+        self._setup_handlers()
+
+    def _setup_handlers(self) -> None:
+        """Register MCP tool handlers (see MessagingMCPServer._setup_handlers)."""
+        self.server.list_tools()(self._handle_list_tools)
+        self.server.call_tool()(self._handle_call_tool)
+
+    async def _handle_list_tools(self) -> list[Tool]:
+        """Return list of available tools."""
+        allowed = ", ".join(self.http.config.allowed_hosts)
+        return [
+            Tool(
+                name="http_request",
+                description=(
+                    f"Send an HTTP request to an allowlisted host ({allowed}). "
+                    "Set record_as to save the exchange as a test fixture under "
+                    f"{self.http.config.fixtures_dir}/."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "method": {"type": "string", "enum": list(METHODS)},
+                        "url": {"type": "string"},
+                        "headers": {
+                            "type": "object",
+                            "additionalProperties": {"type": "string"},
+                        },
+                        "body": {"type": "string", "description": "Raw body"},
+                        "json": {"description": "JSON body (sets Content-Type)"},
+                        "record_as": {
+                            "type": "string",
+                            "description": "Fixture name, e.g. get_user_200",
+                        },
+                    },
+                    "required": ["method", "url"],
+                },
+            ),
+            Tool(
+                name="list_http_fixtures",
+                description="List recorded HTTP fixtures.",
+                inputSchema={"type": "object", "properties": {}},
+            ),
+            Tool(
+                name="get_http_fixture",
+                description="Show one recorded request/response pair.",
+                inputSchema={
+                    "type": "object",
+                    "properties": {"name": {"type": "string"}},
+                    "required": ["name"],
+                },
+            ),
+        ]
+
+    async def _handle_call_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> list[TextContent]:
+        """Handle tool calls by dispatching to the appropriate handler."""
+        try:
+            result = await self._dispatch_tool(name, arguments or {})
+            return [TextContent(type="text", text=json.dumps(result, indent=2))]
+        except Exception as e:
+            logger.exception(f"Error executing tool {name}: {e}")
+            return [
+                TextContent(type="text", text=json.dumps({"error": str(e)}, indent=2))
+            ]
+
+    async def _dispatch_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Dispatch tool call to appropriate handler.
+
+        Raises:
+            ValueError: If tool name is not recognised.
+        """
+        handlers = {
+            "http_request": self._http_request,
+            "list_http_fixtures": self._list_http_fixtures,
+            "get_http_fixture": self._get_http_fixture,
+        }
+        handler = handlers.get(name)
+        if handler is None:
+            raise ValueError(f"Unknown tool: {name}")
+        return await handler(arguments)
+
+    # ------------------------------------------------------------------
+    # Tool handlers
+    # ------------------------------------------------------------------
+
+    async def _http_request(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        exchange = await asyncio.to_thread(
+            self.http.request,
+            arguments["method"],
+            arguments["url"],
+            headers=arguments.get("headers"),
+            body=arguments.get("body"),
+            json_body=arguments.get("json"),
+            record_as=arguments.get("record_as"),
+        )
+        result = exchange.to_dict()
+        if arguments.get("record_as"):
+            result["fixture"] = str(self.http.fixtures.path(arguments["record_as"]))
+        return result
+
+    async def _list_http_fixtures(self, _: dict[str, Any]) -> dict[str, Any]:
+        return {
+            "directory": str(self.http.fixtures.directory),
+            "fixtures": self.http.fixtures.names(),
+        }
+
+    async def _get_http_fixture(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        return self.http.fixtures.load(arguments["name"]).to_dict()
+
+    async def run(self) -> None:
+        """Run the MCP server using stdio transport."""
+        async with stdio_server() as (read_stream, write_stream):
+            await self.server.run(
+                read_stream,
+                write_stream,
+                self.server.create_initialization_options(),
+            )
+
+
+def main() -> None:
+    """Entry point for the HTTP MCP server."""
+    logging.basicConfig(level=logging.INFO)
+    server = HttpMCPServer()
+    asyncio.run(server.run())
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,234 @@
+"""Internal MCP server for the project knowledge graph.
+
+WHY: Agents asking "which service exposes /users?" or "who owns billing?"
+otherwise grep the whole codebase every session. This server answers from
+the graph in .claude-mpm/knowledge_graph.json, building it on first use, and
+checks OpenAPI/protobuf contracts so agents notice drift they introduce.
+
+All graph operations touch the filesystem and are wrapped in
+asyncio.to_thread() to avoid blocking the event loop.
+"""
+
+import asyncio
+import json
+import logging
+from pathlib import Path
+from typing import Any
+
+from mcp.server import Server
+from mcp.server.stdio import stdio_server
+from mcp.types import TextContent, Tool
+
+from claude_mpm.mcp.messaging_server import _resolve_default_project_root
+from claude_mpm.services.knowledge_graph import (
+    ENTITY_TYPES,
+    ContractAnalyzer,
+    KnowledgeGraph,
+    KnowledgeGraphBuilder,
+    default_graph_path,
+    load_or_build,
+)
+
+logger = logging.getLogger(__name__)
+
+
+class KnowledgeGraphMCPServer:
+    """MCP server exposing the project knowledge graph.
+
+    Exposes 5 tools:
+      kg_search, kg_entity, kg_summary, kg_refresh, kg_contracts
+    """
+
+    def __init__(self, project_root: Path | None = None) -> None:
+        """Initialise the Knowledge Graph MCP server."""
+        self.server = Server("mpm-knowledge-graph")
+        self.project_root = project_root or _resolve_default_project_root()
+        self._graph: KnowledgeGraph | None = None
+        self._setup_handlers()
+
+    def _setup_handlers(self) -> None:
+        """Register MCP tool handlers (see MessagingMCPServer._setup_handlers)."""
+        self.server.list_tools()(self._handle_list_tools)
+        self.server.call_tool()(self._handle_call_tool)
+
+    async def _get_graph(self) -> KnowledgeGraph:
+        if self._graph is None:
+            self._graph = await asyncio.to_thread(load_or_build, self.project_root)
+        return self._graph
+
+    async def _handle_list_tools(self) -> list[Tool]:
+        """Return list of available tools."""
+        return [
+            Tool(
+                name="kg_search",
+                description=(
+                    "Search the project knowledge graph for services, HTTP "
+                    "endpoints, schemas, owners and API contract operations by "
+                    "name or attribute."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "query": {
+                            "type": "string",
+                            "description": "Text to match, e.g. 'users' or 'POST'",
+                        },
+                        "type": {
+                            "type": "string",
+                            "enum": list(ENTITY_TYPES),
+                            "description": "Restrict results to one entity type",
+                        },
+                        "limit": {
+                            "type": "integer",
+                            "description": "Maximum results (default 20)",
+                        },
+                    },
+                },
+            ),
+            Tool(
+                name="kg_entity",
+                description=(
+                    "Get one entity with its relations: the endpoints and schemas "
+                    "a service exposes, the schemas an endpoint uses, its owners."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "id": {
+                            "type": "string",
+                            "description": "Entity id from kg_search, e.g. service:api",
+                        }
+                    },
+                    "required": ["id"],
+                },
+            ),
+            Tool(
+                name="kg_summary",
+                description="Count entities by type and show when the graph was built.",
+                inputSchema={"type": "object", "properties": {}},
+            ),
+            Tool(
+                name="kg_refresh",
+                description=(
+                    "Rebuild the graph from the codebase, or fold in the files a "
+                    "session edited when session_transcript is given."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "session_transcript": {
+                            "type": "string",
+                            "description": "Path to a session .jsonl transcript",
+                        }
+                    },
+                },
+            ),
+            Tool(
+                name="kg_contracts",
+                description=(
+                    "Check OpenAPI specs and .proto files against the code: which "
+                    "handler implements each operation, and where contract and "
+                    "code drift apart. Run after changing handlers or models."
+                ),
+                inputSchema={"type": "object", "properties": {}},
+            ),
+        ]
+
+    async def _handle_call_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> list[TextContent]:
+        """Handle tool calls by dispatching to the appropriate handler."""
+        try:
+            result = await self._dispatch_tool(name, arguments or {})
+            return [TextContent(type="text", text=json.dumps(result, indent=2))]
+        except Exception as e:
+            logger.exception(f"Error executing tool {name}: {e}")
+            return [
+                TextContent(type="text", text=json.dumps({"error": str(e)}, indent=2))
+            ]
+
+    async def _dispatch_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Dispatch tool call to appropriate handler.
+
+        Raises:
+            ValueError: If tool name is not recognised.
+        """
+        handlers = {
+            "kg_search": self._kg_search,
+            "kg_entity": self._kg_entity,
+            "kg_summary": self._kg_summary,
+            "kg_refresh": self._kg_refresh,
+            "kg_contracts": self._kg_contracts,
+        }
+        handler = handlers.get(name)
+        if handler is None:
+            raise ValueError(f"Unknown tool: {name}")
+        return await handler(arguments)
+
+    # ------------------------------------------------------------------
+    # Tool handlers
+    # ------------------------------------------------------------------
+
+    async def _kg_search(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        graph = await self._get_graph()
+        entities = graph.search(
+            arguments.get("query", ""),
+            type=arguments.get("type"),
+            limit=int(arguments.get("limit", 20)),
+        )
+        return {"count": len(entities), "entities": [e.to_dict() for e in entities]}
+
+    async def _kg_entity(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        graph = await self._get_graph()
+        entity = graph.describe(arguments["id"])
+        if entity is None:
+            return {"error": f"No entity with id {arguments['id']!r}"}
+        return entity
+
+    async def _kg_summary(self, _: dict[str, Any]) -> dict[str, Any]:
+        graph = await self._get_graph()
+        return graph.summary()
+
+    async def _kg_refresh(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        transcript = arguments.get("session_transcript")
+        if not transcript:
+            self._graph = await asyncio.to_thread(
+                load_or_build, self.project_root, True
+            )
+            return {"rebuilt": True, **self._graph.summary()}
+
+        graph = await self._get_graph()
+        builder = KnowledgeGraphBuilder(self.project_root)
+        touched = await asyncio.to_thread(
+            builder.update_from_session, graph, Path(transcript).expanduser()
+        )
+        await asyncio.to_thread(graph.save, default_graph_path(self.project_root))
+        return {"rebuilt": False, "files": touched, **graph.summary()}
+
+    async def _kg_contracts(self, _: dict[str, Any]) -> dict[str, Any]:
+        graph = await self._get_graph()
+        analyzer = ContractAnalyzer(self.project_root)
+        report = await asyncio.to_thread(analyzer.analyze, graph)
+        return {"drifted": report.drifted, **report.to_dict()}
+
+    async def run(self) -> None:
+        """Run the MCP server using stdio transport."""
+        async with stdio_server() as (read_stream, write_stream):
+            await self.server.run(
+                read_stream,
+                write_stream,
+                self.server.create_initialization_options(),
+            )
+
+
+def main() -> None:
+    """Entry point for the Knowledge Graph MCP server."""
+    logging.basicConfig(level=logging.INFO)
+    server = KnowledgeGraphMCPServer()
+    asyncio.run(server.run())
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,164 @@
+"""Internal MCP server for the project's runtime logs.
+
+WHY: A debugging agent that can only read code has to guess at what the
+program did. This server lets it query the log sources registered with
+'claude-mpm logs add' (files, Docker containers, journald units) by time
+range and pattern.
+
+Reading logs blocks on file IO and subprocesses and is wrapped in
+asyncio.to_thread() to avoid blocking the event loop.
+"""
+
+import asyncio
+import json
+import logging
+from pathlib import Path
+from typing import Any
+
+from mcp.server import Server
+from mcp.server.stdio import stdio_server
+from mcp.types import TextContent, Tool
+
+from claude_mpm.mcp.messaging_server import _resolve_default_project_root
+from claude_mpm.services.log_sources import DEFAULT_LIMIT, LogQuery
+
+logger = logging.getLogger(__name__)
+
+
+class LogsMCPServer:
+    """MCP server exposing registered log sources.
+
+    Exposes 2 tools:
+      list_log_sources, query_logs
+    """
+
+    def __init__(self, project_root: Path | None = None) -> None:
+        """Initialise the Logs MCP server."""
+        self.server = Server("mpm-logs")
+        self.project_root = project_root or _resolve_default_project_root()
+        self.logs = LogQuery(self.project_root)
+        self._setup_handlers()
+
+    def _setup_handlers(self) -> None:
+        """Register MCP tool handlers (see MessagingMCPServer._setup_handlers)."""
+        self.server.list_tools()(self._handle_list_tools)
+        self.server.call_tool()(self._handle_call_tool)
+
+    async def _handle_list_tools(self) -> list[Tool]:
+        """Return list of available tools."""
+        return [
+            Tool(
+                name="list_log_sources",
+                description="List the log sources registered for this project.",
+                inputSchema={"type": "object", "properties": {}},
+            ),
+            Tool(
+                name="query_logs",
+                description=(
+                    "Read runtime logs from the project's registered sources, "
+                    "filtered by time range and regex. Use while debugging to see "
+                    "what the program actually did."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "source": {
+                            "type": "string",
+                            "description": "Source name (default: all sources)",
+                        },
+                        "since": {
+                            "type": "string",
+                            "description": "Start: 15m, 2h, 1d ago or ISO timestamp",
+                        },
+                        "until": {
+                            "type": "string",
+                            "description": "End: 15m, 2h, 1d ago or ISO timestamp",
+                        },
+                        "grep": {
+                            "type": "string",
+                            "description": "Regex a line must match",
+                        },
+                        "case_sensitive": {
+                            "type": "boolean",
+                            "description": "Match grep case-sensitively",
+                        },
+                        "limit": {
+                            "type": "integer",
+                            "description": (
+                                f"Most recent lines to return (default {DEFAULT_LIMIT})"
+                            ),
+                        },
+                    },
+                },
+            ),
+        ]
+
+    async def _handle_call_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> list[TextContent]:
+        """Handle tool calls by dispatching to the appropriate handler."""
+        try:
+            result = await self._dispatch_tool(name, arguments or {})
+            return [TextContent(type="text", text=json.dumps(result, indent=2))]
+        except Exception as e:
+            logger.exception(f"Error executing tool {name}: {e}")
+            return [
+                TextContent(type="text", text=json.dumps({"error": str(e)}, indent=2))
+            ]
+
+    async def _dispatch_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Dispatch tool call to appropriate handler.
+
+        Raises:
+            ValueError: If tool name is not recognised.
+        """
+        handlers = {
+            "list_log_sources": self._list_log_sources,
+            "query_logs": self._query_logs,
+        }
+        handler = handlers.get(name)
+        if handler is None:
+            raise ValueError(f"Unknown tool: {name}")
+        return await handler(arguments)
+
+    # ------------------------------------------------------------------
+    # Tool handlers
+    # ------------------------------------------------------------------
+
+    async def _list_log_sources(self, _: dict[str, Any]) -> dict[str, Any]:
+        sources = await asyncio.to_thread(self.logs.registry.sources)
+        return {"sources": [s.to_dict() for s in sources]}
+
+    async def _query_logs(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        lines = await asyncio.to_thread(
+            self.logs.query,
+            source=arguments.get("source"),
+            since=arguments.get("since"),
+            until=arguments.get("until"),
+            grep=arguments.get("grep"),
+            ignore_case=not arguments.get("case_sensitive", False),
+            limit=int(arguments.get("limit", DEFAULT_LIMIT)),
+        )
+        return {"count": len(lines), "lines": [line.to_dict() for line in lines]}
+
+    async def run(self) -> None:
+        """Run the MCP server using stdio transport."""
+        async with stdio_server() as (read_stream, write_stream):
+            await self.server.run(
+                read_stream,
+                write_stream,
+                self.server.create_initialization_options(),
+            )
+
+
+def main() -> None:
+    """Entry point for the Logs MCP server."""
+    logging.basicConfig(level=logging.INFO)
+    server = LogsMCPServer()
+    asyncio.run(server.run())
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,207 @@
+"""Internal MCP server for screenshots and DOM snapshots of local pages.
+
+WHY: Frontend changes are only verified once someone looks at the page.
+This server lets agents capture their local dev server with Playwright and
+read the screenshot or rendered HTML back. Captures are stored per session
+and shown in the dashboard's Captures tab.
+
+Playwright's sync API blocks and is run in asyncio.to_thread(), which also
+keeps it off the server's event loop where the sync API refuses to run.
+"""
+
+import asyncio
+import json
+import logging
+from pathlib import Path
+from typing import Any
+
+from mcp.server import Server
+from mcp.server.stdio import stdio_server
+from mcp.types import TextContent, Tool
+
+from claude_mpm.mcp.messaging_server import _resolve_default_project_root
+from claude_mpm.services.page_capture import (
+    BOTH,
+    CAPTURE_MODES,
+    DOM,
+    SCREENSHOT,
+    Capture,
+    PageCaptureService,
+    current_session_id,
+)
+
+logger = logging.getLogger(__name__)
+
+# Rendered HTML is returned inline up to this size; the full file is on disk
+MAX_INLINE_DOM = 20_000
+
+
+class PageCaptureMCPServer:
+    """MCP server for capturing local dev pages.
+
+    Exposes 2 tools:
+      capture_page, list_captures
+    """
+
+    def __init__(self, project_root: Path | None = None) -> None:
+        """Initialise the Page Capture MCP server."""
+        self.server = Server("mpm-page-capture")
+        self.project_root = project_root or _resolve_default_project_root()
+        self.captures = PageCaptureService(self.project_root)
+        self._setup_handlers()
+
+    def _setup_handlers(self) -> None:
+        """Register MCP tool handlers (see MessagingMCPServer._setup_handlers)."""
+        self.server.list_tools()(self._handle_list_tools)
+        self.server.call_tool()(self._handle_call_tool)
+
+    async def _handle_list_tools(self) -> list[Tool]:
+        """Return list of available tools."""
+        return [
+            Tool(
+                name="capture_page",
+                description=(
+                    "Open a local dev URL (http://localhost:PORT/...) in headless "
+                    "Chromium and save a screenshot and/or the rendered DOM. Use "
+                    "after UI changes to check the result; Read the returned "
+                    "screenshot_path to see the image."
+                ),
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "url": {"type": "string", "description": "Local dev URL"},
+                        "mode": {
+                            "type": "string",
+                            "enum": list(CAPTURE_MODES),
+                            "description": f"What to capture (default {BOTH})",
+                        },
+                        "selector": {
+                            "type": "string",
+                            "description": "CSS selector to capture one element",
+                        },
+                        "wait_for": {
+                            "type": "string",
+                            "description": "CSS selector to wait for before capturing",
+                        },
+                        "full_page": {
+                            "type": "boolean",
+                            "description": "Screenshot the whole scrollable page",
+                        },
+                        "width": {
+                            "type": "integer",
+                            "description": "Viewport width (default 1280)",
+                        },
+                        "height": {
+                            "type": "integer",
+                            "description": "Viewport height (default 800)",
+                        },
+                    },
+                    "required": ["url"],
+                },
+            ),
+            Tool(
+                name="list_captures",
+                description="List captures taken in this session (or all sessions).",
+                inputSchema={
+                    "type": "object",
+                    "properties": {
+                        "all_sessions": {
+                            "type": "boolean",
+                            "description": "Include captures from other sessions",
+                        }
+                    },
+                },
+            ),
+        ]
+
+    async def _handle_call_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> list[TextContent]:
+        """Handle tool calls by dispatching to the appropriate handler."""
+        try:
+            result = await self._dispatch_tool(name, arguments or {})
+            return [TextContent(type="text", text=json.dumps(result, indent=2))]
+        except Exception as e:
+            logger.exception(f"Error executing tool {name}: {e}")
+            return [
+                TextContent(type="text", text=json.dumps({"error": str(e)}, indent=2))
+            ]
+
+    async def _dispatch_tool(
+        self, name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Dispatch tool call to appropriate handler.
+
+        Raises:
+            ValueError: If tool name is not recognised.
+        """
+        handlers = {
+            "capture_page": self._capture_page,
+            "list_captures": self._list_captures,
+        }
+        handler = handlers.get(name)
+        if handler is None:
+            raise ValueError(f"Unknown tool: {name}")
+        return await handler(arguments)
+
+    # ------------------------------------------------------------------
+    # Tool handlers
+    # ------------------------------------------------------------------
+
+    async def _capture_page(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        capture = await asyncio.to_thread(
+            self.captures.capture,
+            arguments["url"],
+            mode=arguments.get("mode", BOTH),
+            selector=arguments.get("selector"),
+            full_page=bool(arguments.get("full_page", False)),
+            viewport=(
+                int(arguments.get("width", 1280)),
+                int(arguments.get("height", 800)),
+            ),
+            wait_for=arguments.get("wait_for"),
+        )
+        result = self._describe(capture)
+        dom_path = self.captures.artifact_path(capture, DOM)
+        if dom_path is not None:
+            html = dom_path.read_text(encoding="utf-8")
+            result["dom_truncated"] = len(html) > MAX_INLINE_DOM
+            result["dom_html"] = html[:MAX_INLINE_DOM]
+        return result
+
+    async def _list_captures(self, arguments: dict[str, Any]) -> dict[str, Any]:
+        session = None if arguments.get("all_sessions") else current_session_id()
+        captures = await asyncio.to_thread(self.captures.captures, session)
+        return {
+            "count": len(captures),
+            "captures": [self._describe(c) for c in captures],
+        }
+
+    def _describe(self, capture: Capture) -> dict[str, Any]:
+        screenshot = self.captures.artifact_path(capture, SCREENSHOT)
+        dom = self.captures.artifact_path(capture, DOM)
+        return {
+            **capture.to_dict(),
+            "screenshot_path": str(screenshot) if screenshot else None,
+            "dom_path": str(dom) if dom else None,
+        }
+
+    async def run(self) -> None:
+        """Run the MCP server using stdio transport."""
+        async with stdio_server() as (read_stream, write_stream):
+            await self.server.run(
+                read_stream,
+                write_stream,
+                self.server.create_initialization_options(),
+            )
+
+
+def main() -> None:
+    """Entry point for the Page Capture MCP server."""
+    logging.basicConfig(level=logging.INFO)
+    server = PageCaptureMCPServer()
+    asyncio.run(server.run())
+
+
+if __name__ == "__main__":
+    main()
@@ -250,6 +250,13 @@ def _run_remove_stale_pm_instructions_cache_migration() -> bool:
     return run_migration()
 
 
+def _run_skill_collections_to_sources_migration() -> bool:
+    """Convert skill collections in ~/.claude-mpm/config.json into skill sources."""
+    from .v6_5_75_skill_collections_to_sources import run_migration
+
+    return run_migration()
+
+
 def _run_remove_absolute_hook_paths_migration() -> bool:
     """Replace absolute MPM hook paths with the portable 'claude-hook' entry point (issue #563)."""
     from pathlib import Path
@@ -457,6 +464,12 @@ MIGRATIONS: list[Migration] = [
         # user-level dir) is swept the next time claude-mpm starts in it.
         run_always=True,
     ),
+    Migration(
+        id="v6_5_75_skill_collections_to_sources",
+        version="6.5.75",
+        description="Convert skill collections (skills.collections in ~/.claude-mpm/config.json) into skill sources and remove their clones from ~/.claude/skills/",
+        run=_run_skill_collections_to_sources_migration,
+    ),
 ]
 
 
@@ -0,0 +1,252 @@
+"""
+Schema-versioned migrations for local user state in ~/.claude-mpm.
+
+WHY: The registry migrations in :mod:`.registry` fix up *project* settings
+(.claude/, .mcp.json) and are best-effort probes. Local user state (config,
+caches, event DB, memories) needs something stricter: when its layout
+changes between releases the upgrade must either complete fully or leave the
+previous state untouched. Before this, the answer was "delete your cache".
+
+DESIGN DECISIONS:
+- Integer schema versions recorded in ``state_schema.json``; each
+  :class:`StateMigration` upgrades exactly one version, in order
+- Snapshot before migrating: the state directory (minus rebuildable dirs) is
+  copied to ``state-backups/<timestamp>-v<from>`` and restored automatically
+  if any step raises. Version-only steps (``upgrade=None``) rewrite nothing,
+  so when every pending step is one no snapshot is taken
+- Rebuildable directories (``cache``, ``logs``) are not snapshotted; a
+  migration may delete them but must not rewrite them in place
+- Runs under the config file lock so two CLIs starting at once cannot
+  migrate concurrently
+- Only the newest ``KEEP_BACKUPS`` snapshots are kept
+
+Adding a migration: append a ``StateMigration`` with the next schema version
+to ``STATE_MIGRATIONS``. Its ``upgrade`` receives the state directory.
+"""
+
+from __future__ import annotations
+
+import json
+import shutil
+from collections.abc import Callable
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import NamedTuple
+
+from ..core.config_file_lock import config_file_lock
+from ..core.logging_utils import get_logger
+
+logger = get_logger(__name__)
+
+SCHEMA_FILE_NAME = "state_schema.json"
+BACKUP_DIR_NAME = "state-backups"
+KEEP_BACKUPS = 3
+
+# Rebuildable or transient entries that are never snapshotted or restored
+UNVERSIONED_ENTRIES = frozenset(
+    {BACKUP_DIR_NAME, "cache", "logs", SCHEMA_FILE_NAME, SCHEMA_FILE_NAME + ".lock"}
+)
+
+
+class StateMigration(NamedTuple):
+    """One step in the local state schema."""
+
+    schema_version: int  # Version the state is at after this step
+    id: str
+    description: str
+    # Receives the state dir; raise to abort. None only bumps the version.
+    upgrade: Callable[[Path], None] | None = None
+
+
+STATE_MIGRATIONS: list[StateMigration] = [
+    StateMigration(
+        schema_version=1,
+        id="state_baseline",
+        description="Start tracking the local state schema version",
+    ),
+]
+
+
+class StateMigrationError(Exception):
+    """A state migration failed; the previous state was restored."""
+
+
+class StateMigrator:
+    """Applies pending :class:`StateMigration` steps with backup/rollback."""
+
+    def __init__(
+        self,
+        state_dir: Path | None = None,
+        migrations: list[StateMigration] | None = None,
+    ):
+        self.state_dir = state_dir or Path.home() / ".claude-mpm"
+        self.migrations = sorted(
+            STATE_MIGRATIONS if migrations is None else migrations,
+            key=lambda m: m.schema_version,
+        )
+        self.schema_file = self.state_dir / SCHEMA_FILE_NAME
+        self.backup_root = self.state_dir / BACKUP_DIR_NAME
+
+    # ------------------------------------------------------------------
+    # Version bookkeeping
+    # ------------------------------------------------------------------
+
+    def _read_schema(self) -> dict:
+        try:
+            return json.loads(self.schema_file.read_text())
+        except (OSError, ValueError):
+            return {}
+
+    def current_version(self) -> int:
+        return int(self._read_schema().get("schema_version", 0))
+
+    def target_version(self) -> int:
+        return self.migrations[-1].schema_version if self.migrations else 0
+
+    def pending(self) -> list[StateMigration]:
+        current = self.current_version()
+        return [m for m in self.migrations if m.schema_version > current]
+
+    def _write_schema(self, version: int, package_version: str | None) -> None:
+        record = {
+            "schema_version": version,
+            "package_version": package_version,
+            "updated_at": datetime.now(UTC).isoformat(),
+        }
+        tmp = self.schema_file.with_suffix(".tmp")
+        tmp.write_text(json.dumps(record, indent=2))
+        tmp.replace(self.schema_file)
+
+    # ------------------------------------------------------------------
+    # Migration
+    # ------------------------------------------------------------------
+
+    def migrate(self, package_version: str | None = None) -> list[StateMigration]:
+        """Apply pending migrations; roll back everything if one fails.
+
+        Returns:
+            The migrations that were applied (empty when up to date)
+
+        Raises:
+            StateMigrationError: A step failed and the snapshot was restored
+        """
+        if not self.pending():
+            return []
+
+        self.state_dir.mkdir(parents=True, exist_ok=True)
+        applied: list[StateMigration] = []
+        failure: StateMigrationError | None = None
+        # config_file_lock wraps exceptions raised inside it, so failures are
+        # recorded here and raised once the lock is released
+        with config_file_lock(self.schema_file):
+            # Another process may have finished while we waited for the lock
+            pending = self.pending()
+            if not pending:
+                return []
+
+            backup = None
+            if any(m.upgrade for m in pending):
+                backup = self.backup(label=f"v{self.current_version()}")
+            for migration in pending:
+                try:
+                    if migration.upgrade:
+                        migration.upgrade(self.state_dir)
+                except Exception as e:
+                    logger.error(f"State migration {migration.id} failed: {e}")
+                    self.restore(backup)
+                    failure = StateMigrationError(
+                        f"{migration.id} failed ({e}); restored state from {backup}"
+                    )
+                    failure.__cause__ = e
+                    applied = []
+                    break
+                applied.append(migration)
+                self._write_schema(migration.schema_version, package_version)
+                logger.info(f"State migration applied: {migration.id}")
+            else:
+                self.prune_backups()
+
+        if failure is not None:
+            raise failure
+        return applied
+
+    # ------------------------------------------------------------------
+    # Backups
+    # ------------------------------------------------------------------
+
+    def _versioned_entries(self, root: Path) -> list[Path]:
+        if not root.is_dir():
+            return []
+        return [p for p in root.iterdir() if p.name not in UNVERSIONED_ENTRIES]
+
+    def backup(self, label: str = "manual") -> Path:
+        """Snapshot the versioned part of the state directory."""
+        stamp = datetime.now(UTC).strftime("%Y%m%d-%H%M%S-%f")
+        target = self.backup_root / f"{stamp}-{label}"
+        target.mkdir(parents=True)
+        for entry in self._versioned_entries(self.state_dir):
+            if entry.is_dir() and not entry.is_symlink():
+                shutil.copytree(entry, target / entry.name, symlinks=True)
+            else:
+                shutil.copy2(entry, target / entry.name, follow_symlinks=False)
+        if self.schema_file.exists():
+            shutil.copy2(self.schema_file, target / SCHEMA_FILE_NAME)
+        return target
+
+    def list_backups(self) -> list[Path]:
+        """Snapshots, newest first."""
+        if not self.backup_root.is_dir():
+            return []
+        return sorted(
+            (p for p in self.backup_root.iterdir() if p.is_dir()), reverse=True
+        )
+
+    def restore(self, backup: Path | None = None) -> Path:
+        """Replace the versioned state with a snapshot (latest by default)."""
+        if backup is None:
+            backups = self.list_backups()
+            if not backups:
+                raise FileNotFoundError(f"No state backups in {self.backup_root}")
+            backup = backups[0]
+
+        for entry in self._versioned_entries(self.state_dir):
+            if entry.is_dir() and not entry.is_symlink():
+                shutil.rmtree(entry)
+            else:
+                entry.unlink()
+        for entry in self._versioned_entries(backup):
+            if entry.is_dir() and not entry.is_symlink():
+                shutil.copytree(entry, self.state_dir / entry.name, symlinks=True)
+            else:
+                shutil.copy2(entry, self.state_dir / entry.name, follow_symlinks=False)
+
+        saved_schema = backup / SCHEMA_FILE_NAME
+        if saved_schema.exists():
+            shutil.copy2(saved_schema, self.schema_file)
+        else:
+            self.schema_file.unlink(missing_ok=True)
+        return backup
+
+    def prune_backups(self, keep: int = KEEP_BACKUPS) -> int:
+        stale = self.list_backups()[keep:]
+        for path in stale:
+            shutil.rmtree(path, ignore_errors=True)
+        return len(stale)
+
+
+def run_state_migrations(package_version: str | None = None) -> list[str]:
+    """Startup entry point. Never raises; failures are already rolled back.
+
+    Returns:
+        Descriptions of applied migrations
+    """
+    try:
+        applied = StateMigrator().migrate(package_version)
+    except StateMigrationError as e:
+        logger.warning(f"Local state migration rolled back: {e}")
+        print(f"⚠️  Local state migration failed and was rolled back: {e}")
+        return []
+    except Exception as e:
+        logger.warning(f"Local state migration skipped: {e}")
+        return []
+    return [m.description for m in applied]
@@ -0,0 +1,142 @@
+"""Migration 6.5.75: Convert skill collections into skill sources.
+
+WHAT: Moves each entry of ``skills.collections`` in ``~/.claude-mpm/config.json``
+into ``~/.claude-mpm/config/skill_sources.yaml``, points
+``skills.default_collection`` at the matching source, and deletes the
+collection clones that ``skills deploy-github`` kept in ``~/.claude/skills/``.
+
+WHY: ``skills collection-add`` and ``skill-source add`` were two parallel
+systems with their own config, cache and deploy path. Collections are now
+skill sources, so existing collections must become sources to keep working.
+
+SAFETY:
+- A collection whose repository is already a source (e.g. the default
+  claude-mpm collection and the ``system`` source) is mapped onto that source
+  instead of being added twice.
+- A collection whose name is taken by a source with another URL is added as
+  ``<name>-collection``.
+- Entries that fail validation stay in ``skills.collections`` and are logged;
+  the rest are migrated.
+- ``config.json`` is backed up before it is rewritten.
+- Only directories that are git clones are deleted from ``~/.claude/skills/``;
+  the skills deployed from them are flat directories and are kept.
+- Idempotent: once ``skills.collections`` is gone there is nothing to do.
+"""
+
+import json
+import logging
+import shutil
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+
+logger = logging.getLogger(__name__)
+
+
+def _normalize_url(url: str) -> str:
+    return url.strip().rstrip("/").removesuffix(".git").lower()
+
+
+def _config_path() -> Path:
+    return Path.home() / ".claude-mpm" / "config.json"
+
+
+def migrate_collections(
+    config: dict[str, Any], sources: SkillSourceConfiguration
+) -> tuple[dict[str, str], list[str]]:
+    """Add the collections in *config* to *sources*.
+
+    Returns:
+        (mapping of collection name to source ID, names that failed)
+    """
+    collections = (config.get("skills") or {}).get("collections") or {}
+    mapping: dict[str, str] = {}
+    failed: list[str] = []
+
+    ordered = sorted(collections.items(), key=lambda item: item[1].get("priority", 999))
+    for name, entry in ordered:
+        existing = sources.load()
+        url = entry.get("url") or ""
+        match = next(
+            (s for s in existing if _normalize_url(s.url) == _normalize_url(url)),
+            None,
+        )
+        if match is not None:
+            mapping[name] = match.id
+            continue
+
+        source_id = name
+        if any(s.id == source_id for s in existing):
+            source_id = f"{name}-collection"
+        try:
+            source = SkillSource(
+                id=source_id,
+                type="git",
+                url=url,
+                priority=entry.get("priority", 99),
+                enabled=entry.get("enabled", True),
+            )
+            sources.add_source(source)
+        except ValueError as exc:
+            logger.error("Could not migrate skill collection %s: %s", name, exc)
+            failed.append(name)
+            continue
+        mapping[name] = source_id
+        logger.info("Migrated skill collection %s to skill source %s", name, source_id)
+
+    return mapping, failed
+
+
+def _remove_collection_clones(names: list[str]) -> None:
+    skills_dir = Path.home() / ".claude" / "skills"
+    for name in names:
+        clone = skills_dir / name
+        if (clone / ".git").is_dir():
+            shutil.rmtree(clone, ignore_errors=True)
+            logger.info("Removed skill collection clone %s", clone)
+
+
+def run_migration() -> bool:
+    """Convert ``skills.collections`` into skill sources.
+
+    Returns:
+        True when every collection was migrated (or there were none)
+    """
+    path = _config_path()
+    try:
+        if not path.exists():
+            return True
+        config = json.loads(path.read_text(encoding="utf-8"))
+        skills = config.get("skills")
+        if not isinstance(skills, dict) or "collections" not in skills:
+            return True
+
+        mapping, failed = migrate_collections(config, SkillSourceConfiguration())
+
+        stamp = datetime.now(UTC).strftime("%Y%m%d_%H%M%S")
+        shutil.copy2(path, path.with_name(f"{path.name}.backup_{stamp}"))
+
+        default = skills.get("default_collection")
+        if default in mapping:
+            skills["default_collection"] = mapping[default]
+        if failed:
+            skills["collections"] = {
+                name: skills["collections"][name] for name in failed
+            }
+        else:
+            del skills["collections"]
+        path.write_text(json.dumps(config, indent=2) + "\n", encoding="utf-8")
+
+        _remove_collection_clones(list(mapping))
+        logger.info(
+            "Migrated %d skill collections to skill sources (%d failed)",
+            len(mapping),
+            len(failed),
+        )
+        return not failed
+
+    except Exception as exc:
+        logger.error("Unexpected error in skill_collections_to_sources: %s", exc)
+        return False
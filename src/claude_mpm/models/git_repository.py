@@ -1,10 +1,13 @@
 """Git repository model for agent sources."""
 
+import os
 from dataclasses import dataclass
 from datetime import datetime
 from pathlib import Path
 from urllib.parse import urlparse
 
+from claude_mpm.config.skill_sources import PROVIDERS, detect_provider, parse_ssh_url
+
 
 @dataclass
 class GitRepository:
@@ -13,8 +16,13 @@ class GitRepository:
     This model tracks Git repositories that contain agent markdown files.
     Repositories are cached locally and synced using ETag-based HTTP caching.
 
+    Repositories on GitLab or Bitbucket, or with an SSH URL, are synced the
+    way skill sources are instead (see services/agents/sources/hosted_agent_sync.py).
+
     Attributes:
-        url: Full GitHub repository URL (e.g., https://github.com/owner/repo)
+        url: Full repository URL: GitHub, GitLab or Bitbucket over HTTPS
+            (e.g., https://github.com/owner/repo), or an SSH URL
+            (e.g., git@gitlab.com:group/agents.git)
         subdirectory: Optional subdirectory within repository (e.g., "agents/backend")
         branch: Git branch to use (default: "main"). Tags also work (e.g., "v2.0.0").
                 Branch names containing '/' are not supported (they break raw GitHub URL
@@ -23,6 +31,11 @@ class GitRepository:
         priority: Priority for agent resolution (lower = higher precedence)
         last_synced: Timestamp of last successful sync
         etag: HTTP ETag from last sync for incremental updates
+        token: Optional access token or env var reference (e.g., "$MY_TOKEN");
+            without one GITHUB_TOKEN, GITLAB_TOKEN or BITBUCKET_TOKEN applies
+        ssh_key: Optional private key for SSH URLs (e.g., "~/.ssh/agents_deploy")
+        provider: Hosting service of an HTTPS URL ("github", "gitlab" or
+            "bitbucket"); detected from the host when unset
     """
 
     url: str
@@ -32,6 +45,36 @@ class GitRepository:
     priority: int = 100
     last_synced: datetime | None = None
     etag: str | None = None
+    token: str | None = None
+    ssh_key: str | None = None
+    provider: str | None = None
+
+    @property
+    def is_ssh(self) -> bool:
+        """Whether this repository is cloned over SSH."""
+        return parse_ssh_url(self.url or "") is not None
+
+    @property
+    def ssh_key_path(self) -> Path | None:
+        """The configured SSH key with "~" and environment variables expanded."""
+        if not self.ssh_key:
+            return None
+        return Path(os.path.expandvars(self.ssh_key)).expanduser()
+
+    @property
+    def resolved_token(self) -> str | None:
+        """The configured token, resolving a "$VAR" reference."""
+        if self.token and self.token.startswith("$"):
+            return os.environ.get(self.token[1:])
+        return self.token
+
+    @property
+    def hosting(self) -> str | None:
+        """The hosting service of an HTTPS repository: the configured provider,
+        else the one detected from the URL (None for SSH or unknown hosts)."""
+        if self.is_ssh:
+            return None
+        return self.provider or detect_provider(self.url or "")
 
     @property
     def cache_path(self) -> Path:
@@ -107,8 +150,8 @@ class GitRepository:
 
         Validation checks:
             - URL is not empty
-            - URL is valid HTTP/HTTPS format
-            - URL is a GitHub repository URL
+            - URL is valid HTTP/HTTPS format on GitHub, GitLab or Bitbucket,
+              or an SSH URL
             - Priority is non-negative
             - Priority is reasonable (<= 1000, warning only)
             - Subdirectory is relative path (not absolute)
@@ -121,26 +164,52 @@ class GitRepository:
             return errors  # Can't continue validation without URL
 
         # Check URL format
-        try:
-            parsed = urlparse(self.url)
-
-            # Must be HTTP or HTTPS
-            if parsed.scheme not in ("http", "https"):
-                errors.append(
-                    f"URL must use http:// or https:// protocol, got: {parsed.scheme}"
-                )
-
-            # Must be GitHub (for now)
-            if not parsed.netloc.endswith("github.com"):
-                errors.append(f"URL must be a GitHub repository, got: {parsed.netloc}")
-
-            # Should have owner/repo path structure
-            path_parts = [p for p in parsed.path.strip("/").split("/") if p]
+        ssh = parse_ssh_url(self.url)
+        if ssh is not None:
+            host, path = ssh
+            if not host:
+                errors.append(f"SSH URL must include a host, got: {self.url}")
+            path_parts = [p for p in path.strip("/").split("/") if p]
             if len(path_parts) < 2:
-                errors.append(f"URL must include owner/repo path, got: {parsed.path}")
-
-        except Exception as e:
-            errors.append(f"Invalid URL format: {e}")
+                errors.append(f"URL must include owner/repo path, got: {path}")
+        else:
+            try:
+                parsed = urlparse(self.url)
+
+                # Must be HTTP or HTTPS
+                if parsed.scheme not in ("http", "https"):
+                    errors.append(
+                        "URL must use http:// or https:// protocol, or be an SSH "
+                        f"URL (git@host:owner/repo.git), got: {parsed.scheme}"
+                    )
+
+                if self.provider is not None and self.provider not in PROVIDERS:
+                    errors.append(
+                        f"Provider must be one of {', '.join(PROVIDERS)}, "
+                        f"got: {self.provider}"
+                    )
+                elif self.hosting is None:
+                    errors.append(
+                        "URL must be a GitHub, GitLab or Bitbucket repository "
+                        "(set provider for a self-hosted GitLab), "
+                        f"got: {parsed.netloc}"
+                    )
+
+                # Should have owner/repo path structure
+                path_parts = [p for p in parsed.path.strip("/").split("/") if p]
+                if len(path_parts) < 2:
+                    errors.append(
+                        f"URL must include owner/repo path, got: {parsed.path}"
+                    )
+
+            except Exception as e:
+                errors.append(f"Invalid URL format: {e}")
+
+        if self.ssh_key and ssh is None:
+            errors.append(
+                "ssh_key requires an SSH URL (git@host:owner/repo.git), "
+                f"got: {self.url}"
+            )
 
         # Validate branch
         if not self.branch or not self.branch.strip():
@@ -179,10 +248,13 @@ class GitRepository:
         return errors
 
     def _parse_github_url(self, url: str) -> tuple[str, str]:
-        """Parse GitHub URL to extract owner and repository name.
+        """Parse repository URL to extract owner and repository name.
+
+        The owner of a GitLab project in a subgroup is the whole group path
+        (e.g., "group/subgroup"); SSH URLs are parsed the same way.
 
         Args:
-            url: GitHub repository URL
+            url: HTTPS or SSH repository URL
 
         Returns:
             Tuple of (owner, repository_name)
@@ -198,9 +270,12 @@ class GitRepository:
             url = url[:-4]
 
         # Parse URL
-        parsed = urlparse(url)
-        path_parts = [p for p in parsed.path.strip("/").split("/") if p]
+        ssh = parse_ssh_url(url)
+        path = ssh[1] if ssh is not None else urlparse(url).path
+        path_parts = [p for p in path.split("/-/")[0].strip("/").split("/") if p]
 
+        if len(path_parts) > 2 and self.hosting == "gitlab":
+            return "/".join(path_parts[:-1]), path_parts[-1]
         if len(path_parts) >= 2:
             owner = path_parts[0]
             repo = path_parts[1]
@@ -0,0 +1,394 @@
+"""Opt-in team adoption metrics and the usage leaderboard.
+
+WHAT: Users who turn on the ``adoption_metrics`` flag have each session
+counted: turns, accepted outcomes (commits made during the session) and the
+verification commands it ran (tests, linters, type checks and builds, see
+FailureTracker.VERIFICATION_COMMAND_PATTERNS) with whether they passed.
+``claude-mpm adoption report`` aggregates the records of every user into a
+leaderboard of sessions, outcomes and verification pass rate per user.
+
+Records are kept per user in ``~/.claude-mpm/adoption/<user>.json``, or in
+``CLAUDE_MPM_ADOPTION_DIR``. A team deployment where sessions of several
+people run on one host points that variable at a shared directory; otherwise
+a lead collects the files and reports over them with ``--dir``.
+
+WHY: Leads rolling claude-mpm out to a team had no way to tell who used it,
+whether sessions ended in work that was kept, or whether agent changes
+passed verification, short of asking everyone.
+
+DESIGN DECISIONS:
+- Off unless the user turns it on, with their own flag or
+  CLAUDE_MPM_FLAG_ADOPTION_METRICS: a project or organization flag cannot
+  opt a person in, and the user's ``flags disable`` wins over everything.
+  ``adoption forget`` deletes the user's records
+- Only counts are stored: no prompts, commands, file names or output
+- The user is ``CLAUDE_MPM_USER``, else git's user.email, else the login
+  name, so one person is one row however many projects they work in
+- Recording is fail-open and happens only on Stop and on Bash commands, so
+  a broken or locked record file never affects a session
+"""
+
+from __future__ import annotations
+
+import getpass
+import os
+import re
+import subprocess
+from dataclasses import dataclass, field
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json
+from .page_capture import safe_path_component
+
+logger = get_logger(__name__)
+
+FLAG = "adoption_metrics"
+DIR_ENV_VAR = "CLAUDE_MPM_ADOPTION_DIR"
+USER_ENV_VAR = "CLAUDE_MPM_USER"
+DEFAULT_DAYS = 30
+
+SORT_KEYS = ("sessions", "outcomes", "pass-rate", "last-active")
+
+_COMMIT_RE = re.compile(r"\bgit\s+(-C\s+\S+\s+)?commit\b")
+
+
+def adoption_dir() -> Path:
+    configured = os.environ.get(DIR_ENV_VAR)
+    if configured:
+        return Path(configured).expanduser()
+    return Path.home() / ".claude-mpm" / "adoption"
+
+
+def current_user(project_dir: Path | None = None) -> str:
+    """Who sessions in *project_dir* are counted for."""
+    user = os.environ.get(USER_ENV_VAR, "").strip()
+    if user:
+        return user
+    try:
+        result = subprocess.run(
+            ["git", "config", "user.email"],
+            cwd=project_dir or Path.cwd(),
+            capture_output=True,
+            text=True,
+            timeout=5,
+            check=False,
+        )
+        if result.returncode == 0 and result.stdout.strip():
+            return result.stdout.strip()
+    except (OSError, subprocess.SubprocessError) as e:
+        logger.debug(f"Could not read git user.email: {e}")
+    try:
+        return getpass.getuser()
+    except Exception:
+        return "unknown"
+
+
+def is_commit_command(command: str) -> bool:
+    return bool(_COMMIT_RE.search(command))
+
+
+class AdoptionLog:
+    """The session records of one user."""
+
+    def __init__(self, user: str, directory: Path | None = None):
+        self.user = user
+        self.directory = Path(directory or adoption_dir())
+        self.path = self.directory / f"{safe_path_component(user)}.json"
+
+    def _update_session(self, session_id: str, project: Path, **counts: int) -> None:
+        now = datetime.now(UTC).isoformat()
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            data["user"] = self.user
+            sessions = data.setdefault("sessions", {})
+            session = sessions.setdefault(
+                session_id, {"project": str(project.resolve()), "started": now}
+            )
+            session["last_active"] = now
+            for key, value in counts.items():
+                session[key] = session.get(key, 0) + value
+
+        update_json(self.path, record)
+
+    def record_turn(self, session_id: str, project: Path) -> None:
+        self._update_session(session_id, project, turns=1)
+
+    def record_command(
+        self, session_id: str, project: Path, command: str, success: bool
+    ) -> bool:
+        """Count *command* if it is a commit or a verification command.
+
+        Returns whether it was counted.
+        """
+        from .memory.failure_tracker import FailureTracker
+
+        if success and is_commit_command(command):
+            self._update_session(session_id, project, commits=1)
+        elif FailureTracker.is_verification_command(command):
+            key = "verification_passed" if success else "verification_failed"
+            self._update_session(session_id, project, **{key: 1})
+        else:
+            return False
+        return True
+
+    def sessions(self) -> dict[str, dict[str, Any]]:
+        data = read_json(self.path, {})
+        sessions = data.get("sessions") if isinstance(data, dict) else None
+        return sessions if isinstance(sessions, dict) else {}
+
+    def forget(self) -> bool:
+        """Delete this user's records; False if there were none."""
+        if not self.path.exists():
+            return False
+        self.path.unlink()
+        return True
+
+
+def recording_state(project: Path | None = None) -> tuple[bool, str]:
+    """Whether the current user's sessions are recorded, and the flag source.
+
+    Unlike other flags, the project and organization layers are ignored: the
+    records describe a person, so only that person can opt in.
+    """
+    from .feature_flags import DEFAULT, ENV, USER, FeatureFlags, env_value
+
+    user = FeatureFlags(project).user.get(FLAG)
+    if user is False:
+        return False, USER
+    env = env_value(FLAG)
+    if env is not None:
+        return env, ENV
+    return (True, USER) if user else (False, DEFAULT)
+
+
+def _enabled_log(event: dict[str, Any]) -> tuple[AdoptionLog, Path, str] | None:
+    session_id = event.get("session_id") or ""
+    project = Path(event.get("cwd") or Path.cwd())
+    if not session_id or not recording_state(project)[0]:
+        return None
+    return AdoptionLog(current_user(project)), project, session_id
+
+
+def record_stop_event(event: dict[str, Any]) -> None:
+    """Count a finished turn for the Stop hook; fail-open."""
+    try:
+        enabled = _enabled_log(event)
+        if enabled:
+            log, project, session_id = enabled
+            log.record_turn(session_id, project)
+    except Exception as e:
+        logger.debug(f"Could not record adoption metrics: {e}")
+
+
+def record_tool_event(event: dict[str, Any]) -> None:
+    """Count a commit or verification run for the PostToolUse hook; fail-open."""
+    if event.get("tool_name") != "Bash":
+        return
+    tool_input = event.get("tool_input") or {}
+    command = tool_input.get("command") if isinstance(tool_input, dict) else None
+    if not isinstance(command, str) or not command:
+        return
+    from .memory.failure_tracker import FailureTracker
+
+    if not (
+        is_commit_command(command) or FailureTracker.is_verification_command(command)
+    ):
+        return
+    try:
+        enabled = _enabled_log(event)
+        if enabled:
+            log, project, session_id = enabled
+            success = event.get("exit_code", 0) == 0
+            log.record_command(session_id, project, command, success)
+    except Exception as e:
+        logger.debug(f"Could not record adoption metrics: {e}")
+
+
+def _parse_time(value: Any) -> datetime | None:
+    try:
+        parsed = datetime.fromisoformat(str(value))
+    except ValueError:
+        return None
+    return parsed if parsed.tzinfo else parsed.replace(tzinfo=UTC)
+
+
+@dataclass
+class UserAdoption:
+    """One user's row of the leaderboard."""
+
+    user: str
+    sessions: int = 0
+    turns: int = 0
+    outcomes: int = 0
+    verification_passed: int = 0
+    verification_failed: int = 0
+    active_days: set[str] = field(default_factory=set)
+    projects: set[str] = field(default_factory=set)
+    last_active: datetime | None = None
+
+    @property
+    def verification_runs(self) -> int:
+        return self.verification_passed + self.verification_failed
+
+    @property
+    def pass_rate(self) -> float | None:
+        if not self.verification_runs:
+            return None
+        return self.verification_passed / self.verification_runs
+
+    def add(self, session: dict[str, Any]) -> None:
+        self.sessions += 1
+        self.turns += int(session.get("turns", 0))
+        self.outcomes += int(session.get("commits", 0))
+        self.verification_passed += int(session.get("verification_passed", 0))
+        self.verification_failed += int(session.get("verification_failed", 0))
+        if session.get("project"):
+            self.projects.add(str(session["project"]))
+        last_active = _parse_time(session.get("last_active"))
+        if last_active:
+            self.active_days.add(last_active.date().isoformat())
+            if self.last_active is None or last_active > self.last_active:
+                self.last_active = last_active
+
+    def sort_key(self, sort: str) -> tuple:
+        if sort == "outcomes":
+            return (self.outcomes, self.sessions)
+        if sort == "pass-rate":
+            return (self.pass_rate or 0.0, self.verification_runs)
+        if sort == "last-active":
+            return (self.last_active or datetime.min.replace(tzinfo=UTC),)
+        return (self.sessions, self.outcomes)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "user": self.user,
+            "sessions": self.sessions,
+            "active_days": len(self.active_days),
+            "turns": self.turns,
+            "outcomes": self.outcomes,
+            "verification_runs": self.verification_runs,
+            "verification_passed": self.verification_passed,
+            "pass_rate": self.pass_rate,
+            "projects": sorted(self.projects),
+            "last_active": self.last_active.isoformat() if self.last_active else None,
+        }
+
+
+@dataclass
+class AdoptionReport:
+    """Per-user adoption over a period, most active users first."""
+
+    since: datetime
+    days: int
+    users: list[UserAdoption]
+
+    @property
+    def active(self) -> list[UserAdoption]:
+        return [u for u in self.users if u.sessions]
+
+    @property
+    def inactive(self) -> list[UserAdoption]:
+        """Users who opted in but had no session in the period."""
+        return [u for u in self.users if not u.sessions]
+
+    def to_dict(self) -> dict[str, Any]:
+        runs = sum(u.verification_runs for u in self.users)
+        passed = sum(u.verification_passed for u in self.users)
+        return {
+            "since": self.since.isoformat(),
+            "days": self.days,
+            "active_users": len(self.active),
+            "sessions": sum(u.sessions for u in self.users),
+            "outcomes": sum(u.outcomes for u in self.users),
+            "verification_runs": runs,
+            "pass_rate": passed / runs if runs else None,
+            "users": [u.to_dict() for u in self.users],
+        }
+
+    def render(self) -> str:
+        if not self.users:
+            return (
+                "No adoption metrics recorded. Users opt in with "
+                f"'claude-mpm flags enable {FLAG}'."
+            )
+        totals = self.to_dict()
+        lines = [
+            f"Adoption over the last {self.days} days: "
+            f"{totals['active_users']} of {len(self.users)} users active, "
+            f"{totals['sessions']} sessions, {totals['outcomes']} outcomes",
+            "",
+        ]
+        rows = [
+            ("#", "USER", "SESSIONS", "DAYS", "OUTCOMES", "VERIFIED", "LAST ACTIVE")
+        ]
+        for rank, user in enumerate(self.active, 1):
+            rate = f"{user.pass_rate:.0%}" if user.pass_rate is not None else "-"
+            rows.append(
+                (
+                    str(rank),
+                    user.user,
+                    str(user.sessions),
+                    str(len(user.active_days)),
+                    str(user.outcomes),
+                    f"{rate} of {user.verification_runs}",
+                    user.last_active.strftime("%Y-%m-%d") if user.last_active else "-",
+                )
+            )
+        widths = [max(len(row[i]) for row in rows) for i in range(len(rows[0]))]
+        lines += [
+            "  ".join(cell.ljust(width) for cell, width in zip(row, widths)).rstrip()
+            for row in rows
+        ]
+        if self.inactive:
+            lines += [
+                "",
+                "No sessions in this period: "
+                + ", ".join(u.user for u in self.inactive),
+            ]
+        return "\n".join(lines)
+
+
+def build_report(
+    directories: list[Path] | None = None,
+    days: int = DEFAULT_DAYS,
+    project: Path | None = None,
+    sort: str = "sessions",
+    now: datetime | None = None,
+) -> AdoptionReport:
+    """Aggregate the records in *directories* over the last *days* days.
+
+    With *project*, only sessions in that project are counted.
+    """
+    if days <= 0:
+        raise ValueError("--days must be positive")
+    if sort not in SORT_KEYS:
+        raise ValueError(f"Unknown sort '{sort}' (choose from {', '.join(SORT_KEYS)})")
+    since = (now or datetime.now(UTC)) - timedelta(days=days)
+    project_filter = str(Path(project).resolve()) if project else None
+    users: dict[str, UserAdoption] = {}
+    for directory in directories or [adoption_dir()]:
+        for path in sorted(Path(directory).glob("*.json")):
+            data = read_json(path, {})
+            if not isinstance(data, dict) or not isinstance(data.get("sessions"), dict):
+                logger.debug(f"Skipping {path}: not an adoption record file")
+                continue
+            name = str(data.get("user") or path.stem)
+            row = users.setdefault(name, UserAdoption(name))
+            for session in data["sessions"].values():
+                if not isinstance(session, dict):
+                    continue
+                if project_filter and session.get("project") != project_filter:
+                    continue
+                last_active = _parse_time(session.get("last_active"))
+                if last_active and last_active >= since:
+                    row.add(session)
+                elif row.last_active is None or (
+                    last_active and last_active > row.last_active
+                ):
+                    row.last_active = last_active
+    ranked = sorted(users.values(), key=lambda u: u.sort_key(sort), reverse=True)
+    return AdoptionReport(since, days, ranked)
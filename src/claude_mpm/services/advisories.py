@@ -0,0 +1,704 @@
+"""Watch security advisories for the versions a project pins.
+
+WHAT: The project's lockfiles are read for exact dependency versions and
+checked against OSV (https://osv.dev), which carries the GitHub Advisory
+Database alongside PyPA, RustSec, Go and the other ecosystem databases. Each
+advisory that is new for the project is acted on once:
+
+- ``ticket``: a GitHub issue is opened with ``gh``, unless one whose title
+  names the advisory already exists (open or closed)
+- ``queue``: an upgrade task is put on the work queue, for a worker to bump
+  the package to a fixed version and run the tests
+- ``report``: nothing beyond the startup notice and ``advisories list``
+
+Startup launches a detached check at most once per ``interval_hours``;
+``claude-mpm advisories check`` runs one on demand.
+
+WHY: Vulnerabilities in pinned dependencies were found when someone happened
+to run an audit. A check that runs on its own and files the work where the
+team already tracks it means a new advisory is handled within a day.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    advisories:
+      enabled: true           # off by default; the check uses the network
+      interval_hours: 24
+      action: ticket          # ticket | queue | report
+      min_severity: moderate  # low | moderate | high | critical
+      ignore: [GHSA-xxxx-xxxx-xxxx, CVE-2024-0001]  # IDs or aliases
+      labels: [security]      # labels for opened issues
+
+Lockfiles read from the project root: requirements*.txt (``==`` pins),
+poetry.lock, uv.lock, Pipfile.lock, package-lock.json, Cargo.lock, go.mod and
+Gemfile.lock.
+
+DESIGN DECISIONS:
+- The background check is a detached process (``start_new_session``), not a
+  thread, because ``claude-mpm run`` replaces itself with Claude Code
+- "New" means not yet acted on for this project; the record is kept per
+  user under ~/.claude-mpm/cache, and the issue search keeps several users
+  of one repository from filing the same advisory twice
+- A failed action is not recorded, so it is retried on the next check
+- Advisories without a severity label are never filtered out by
+  ``min_severity``; deciding they are harmless is left to ``ignore``
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import subprocess  # nosec B404 - runs gh and this module only
+import sys
+import time
+import tomllib
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field, fields
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "advisories"
+OSV_API = "https://api.osv.dev/v1"
+STATE_FILE = "advisories.json"
+BATCH_SIZE = 1000  # OSV's querybatch limit
+SEVERITIES = ("low", "moderate", "high", "critical")
+UNKNOWN = "unknown"
+ACTIONS = ("ticket", "queue", "report")
+
+_REQUIREMENT_RE = re.compile(
+    r"^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*===?\s*([^\s;#,]+)"
+)
+_GO_REQUIRE_RE = re.compile(r"^(?:require\s+)?(\S+)\s+(v\S+)")
+_GEM_SPEC_RE = re.compile(r"^    (\S+) \(([^)]+)\)$")
+
+
+class AdvisoryError(Exception):
+    """An advisory could not be acted on."""
+
+
+@dataclass
+class AdvisoryConfig:
+    """Whether and how often to check, and what to do about new advisories."""
+
+    enabled: bool = False
+    interval_hours: float = 24.0
+    action: str = "ticket"
+    min_severity: str = "low"
+    ignore: list[str] = field(default_factory=list)
+    labels: list[str] = field(default_factory=lambda: ["security"])
+
+    def __post_init__(self) -> None:
+        if self.action not in ACTIONS:
+            logger.warning(f"Unknown advisories.action {self.action!r}; using report")
+            self.action = "report"
+        self.min_severity = str(self.min_severity).lower()
+        if self.min_severity not in SEVERITIES:
+            self.min_severity = "low"
+
+    @classmethod
+    def load(cls, config: Any = None) -> AdvisoryConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in section.items() if k in known and v is not None})
+
+
+# ---------------------------------------------------------------------------
+# Dependencies
+# ---------------------------------------------------------------------------
+
+
+@dataclass(frozen=True)
+class Dependency:
+    """A package pinned to an exact version."""
+
+    ecosystem: str  # OSV ecosystem name
+    name: str
+    version: str
+    manifest: str  # The file the pin was read from
+
+
+def _requirements(path: Path) -> list[tuple[str, str]]:
+    pins = []
+    for line in path.read_text(encoding="utf-8").splitlines():
+        match = _REQUIREMENT_RE.match(line.strip())
+        if match:
+            pins.append((match.group(1), match.group(2)))
+    return pins
+
+
+def _toml_packages(keep: Callable[[dict[str, Any]], bool]):
+    def parse(path: Path) -> list[tuple[str, str]]:
+        data = tomllib.loads(path.read_text(encoding="utf-8"))
+        return [
+            (package["name"], str(package["version"]))
+            for package in data.get("package", [])
+            if "name" in package and "version" in package and keep(package)
+        ]
+
+    return parse
+
+
+def _pipfile_lock(path: Path) -> list[tuple[str, str]]:
+    data = json.loads(path.read_text(encoding="utf-8"))
+    pins = []
+    for section in ("default", "develop"):
+        for name, info in (data.get(section) or {}).items():
+            version = str((info or {}).get("version", ""))
+            if version.startswith("=="):
+                pins.append((name, version[2:]))
+    return pins
+
+
+def _package_lock(path: Path) -> list[tuple[str, str]]:
+    data = json.loads(path.read_text(encoding="utf-8"))
+    pins = []
+    packages = data.get("packages")
+    if isinstance(packages, dict):  # lockfileVersion 2 and 3
+        for key, info in packages.items():
+            if "node_modules/" not in key or info.get("link") or "version" not in info:
+                continue
+            name = info.get("name") or key.rsplit("node_modules/", 1)[1]
+            pins.append((name, info["version"]))
+        return pins
+
+    def walk(dependencies: dict[str, Any]) -> None:  # lockfileVersion 1
+        for name, info in dependencies.items():
+            if "version" in info and not str(info["version"]).startswith("file:"):
+                pins.append((name, info["version"]))
+            walk(info.get("dependencies") or {})
+
+    walk(data.get("dependencies") or {})
+    return pins
+
+
+def _go_mod(path: Path) -> list[tuple[str, str]]:
+    pins = []
+    in_block = False
+    for raw in path.read_text(encoding="utf-8").splitlines():
+        line = raw.split("//", 1)[0].strip()
+        if line.startswith("require ("):
+            in_block = True
+            continue
+        if in_block and line == ")":
+            in_block = False
+            continue
+        if in_block or line.startswith("require "):
+            match = _GO_REQUIRE_RE.match(line)
+            if match:
+                version = match.group(2).removesuffix("+incompatible")
+                pins.append((match.group(1), version.removeprefix("v")))
+    return pins
+
+
+def _gemfile_lock(path: Path) -> list[tuple[str, str]]:
+    pins = []
+    section = None
+    for line in path.read_text(encoding="utf-8").splitlines():
+        if line and not line.startswith(" "):
+            section = line.strip()
+        elif section == "GEM":
+            match = _GEM_SPEC_RE.match(line)
+            if match:
+                pins.append((match.group(1), match.group(2)))
+    return pins
+
+
+# lockfile name -> (OSV ecosystem, parser returning (name, version) pairs)
+LOCKFILES: dict[str, tuple[str, Callable[[Path], list[tuple[str, str]]]]] = {
+    "poetry.lock": ("PyPI", _toml_packages(lambda p: True)),
+    "uv.lock": ("PyPI", _toml_packages(lambda p: "registry" in p.get("source", {}))),
+    "Pipfile.lock": ("PyPI", _pipfile_lock),
+    "package-lock.json": ("npm", _package_lock),
+    "Cargo.lock": (
+        "crates.io",
+        _toml_packages(lambda p: str(p.get("source", "")).startswith("registry+")),
+    ),
+    "go.mod": ("Go", _go_mod),
+    "Gemfile.lock": ("RubyGems", _gemfile_lock),
+}
+
+
+def collect_dependencies(project_dir: Path) -> list[Dependency]:
+    """Every exact pin in the lockfiles at the root of *project_dir*.
+
+    A package pinned in two files is listed once, from the first file.
+    """
+    project_dir = Path(project_dir)
+    sources = [
+        (path, "PyPI", _requirements)
+        for path in sorted(project_dir.glob("requirements*.txt"))
+    ]
+    sources += [
+        (project_dir / name, ecosystem, parse)
+        for name, (ecosystem, parse) in LOCKFILES.items()
+    ]
+    found: dict[tuple[str, str, str], Dependency] = {}
+    for path, ecosystem, parse in sources:
+        if not path.is_file():
+            continue
+        try:
+            pins = parse(path)
+        except (OSError, ValueError, KeyError, TypeError) as e:
+            logger.warning(f"Could not read dependencies from {path.name}: {e}")
+            continue
+        for name, version in pins:
+            key = (ecosystem, name.lower(), version)
+            found.setdefault(key, Dependency(ecosystem, name, version, path.name))
+    return list(found.values())
+
+
+# ---------------------------------------------------------------------------
+# OSV
+# ---------------------------------------------------------------------------
+
+
+def _http(url: str, body: dict[str, Any] | None) -> dict[str, Any]:
+    import requests
+
+    if body is None:
+        response = requests.get(url, timeout=30)
+    else:
+        response = requests.post(url, json=body, timeout=60)
+    response.raise_for_status()
+    return response.json()
+
+
+class OsvClient:
+    """Looks pinned versions up in OSV."""
+
+    def __init__(
+        self,
+        api: str = OSV_API,
+        transport: Callable[[str, dict[str, Any] | None], dict[str, Any]] = _http,
+    ):
+        """
+        Args:
+            api: OSV API base URL
+            transport: POSTs a JSON body (GETs when it is None) and returns
+                the JSON response (injected for tests)
+        """
+        self.api = api.rstrip("/")
+        self.transport = transport
+        self._vulns: dict[str, dict[str, Any]] = {}
+
+    def query(self, dependencies: list[Dependency]) -> dict[Dependency, list[str]]:
+        """The IDs of the advisories affecting each affected dependency."""
+        affected: dict[Dependency, list[str]] = {}
+        for start in range(0, len(dependencies), BATCH_SIZE):
+            batch = dependencies[start : start + BATCH_SIZE]
+            body = {
+                "queries": [
+                    {
+                        "package": {"ecosystem": dep.ecosystem, "name": dep.name},
+                        "version": dep.version,
+                    }
+                    for dep in batch
+                ]
+            }
+            results = self.transport(f"{self.api}/querybatch", body).get("results")
+            for dep, result in zip(batch, results or [], strict=False):
+                ids = [vuln["id"] for vuln in (result or {}).get("vulns") or []]
+                if ids:
+                    affected[dep] = ids
+        return affected
+
+    def vulnerability(self, vuln_id: str) -> dict[str, Any]:
+        """The full OSV record of one advisory."""
+        if vuln_id not in self._vulns:
+            self._vulns[vuln_id] = self.transport(f"{self.api}/vulns/{vuln_id}", None)
+        return self._vulns[vuln_id]
+
+
+@dataclass
+class Advisory:
+    """An advisory that affects a pinned dependency."""
+
+    id: str
+    package: str
+    ecosystem: str
+    version: str
+    manifest: str
+    summary: str = ""
+    severity: str = UNKNOWN
+    aliases: list[str] = field(default_factory=list)
+    fixed: list[str] = field(default_factory=list)
+    url: str = ""
+
+    @property
+    def key(self) -> str:
+        """Identifies the advisory for this package, whatever version is pinned."""
+        return f"{self.id} {self.ecosystem}/{self.package}"
+
+    def matches(self, ids: set[str]) -> bool:
+        return self.id in ids or any(alias in ids for alias in self.aliases)
+
+    def severity_rank(self) -> int:
+        if self.severity in SEVERITIES:
+            return SEVERITIES.index(self.severity)
+        return len(SEVERITIES)  # Unknown sorts first: nobody has judged it
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> Advisory:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in data.items() if k in known})
+
+    @classmethod
+    def from_osv(cls, vuln: dict[str, Any], dep: Dependency) -> Advisory:
+        severity = str((vuln.get("database_specific") or {}).get("severity", ""))
+        severity = {"medium": "moderate"}.get(severity.lower(), severity.lower())
+        fixed: list[str] = []
+        for affected in vuln.get("affected") or []:
+            package = affected.get("package") or {}
+            if (
+                package.get("ecosystem") != dep.ecosystem
+                or str(package.get("name", "")).lower() != dep.name.lower()
+            ):
+                continue
+            for range_ in affected.get("ranges") or []:
+                for event in range_.get("events") or []:
+                    if "fixed" in event and event["fixed"] not in fixed:
+                        fixed.append(event["fixed"])
+        summary = vuln.get("summary") or (vuln.get("details") or "").split("\n")[0]
+        return cls(
+            id=vuln["id"],
+            package=dep.name,
+            ecosystem=dep.ecosystem,
+            version=dep.version,
+            manifest=dep.manifest,
+            summary=summary[:200],
+            severity=severity if severity in SEVERITIES else UNKNOWN,
+            aliases=list(vuln.get("aliases") or []),
+            fixed=fixed,
+            url=f"https://osv.dev/vulnerability/{vuln['id']}",
+        )
+
+
+# ---------------------------------------------------------------------------
+# Checking and acting
+# ---------------------------------------------------------------------------
+
+
+@dataclass
+class AdvisoryReport:
+    """The outcome of one check."""
+
+    checked_at: str
+    dependencies: int
+    advisories: list[Advisory] = field(default_factory=list)
+    new: list[str] = field(default_factory=list)  # Keys of new advisories
+    # key -> what was done ("https://github.com/o/r/issues/7") or the error
+    actions: dict[str, str] = field(default_factory=dict)
+    errors: dict[str, str] = field(default_factory=dict)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "checked_at": self.checked_at,
+            "dependencies": self.dependencies,
+            "advisories": [a.to_dict() for a in self.advisories],
+            "new": self.new,
+            "actions": self.actions,
+            "errors": self.errors,
+        }
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> AdvisoryReport:
+        return cls(
+            checked_at=data["checked_at"],
+            dependencies=data["dependencies"],
+            advisories=[Advisory.from_dict(a) for a in data.get("advisories", [])],
+            new=list(data.get("new", [])),
+            actions=dict(data.get("actions", {})),
+            errors=dict(data.get("errors", {})),
+        )
+
+
+def state_path() -> Path:
+    return Path.home() / ".claude-mpm" / "cache" / STATE_FILE
+
+
+def load_project_state(project_dir: Path) -> dict[str, Any]:
+    """What is recorded for *project_dir*: last run, last report, handled."""
+    state = read_json(state_path(), {})
+    if not isinstance(state, dict):
+        return {}
+    return state.get(str(Path(project_dir).resolve())) or {}
+
+
+def _update_project_state(project_dir: Path, mutate: Callable[[dict], None]) -> None:
+    key = str(Path(project_dir).resolve())
+
+    def apply(state: Any) -> Any:
+        if not isinstance(state, dict):
+            state = {}
+        mutate(state.setdefault(key, {}))
+        return state
+
+    update_json(state_path(), apply)
+
+
+def load_report(project_dir: Path) -> AdvisoryReport | None:
+    data = load_project_state(project_dir).get("report")
+    try:
+        return AdvisoryReport.from_dict(data) if data else None
+    except (KeyError, TypeError) as e:
+        logger.debug(f"Ignoring unreadable advisory report: {e}")
+        return None
+
+
+def ticket_title(advisory: Advisory) -> str:
+    return f"{advisory.id}: {advisory.package} {advisory.version} is vulnerable"
+
+
+def ticket_body(advisory: Advisory) -> str:
+    fixed = ", ".join(advisory.fixed) or "no fixed version yet"
+    lines = [
+        advisory.summary or "(no summary)",
+        "",
+        f"- Package: {advisory.package} {advisory.version} ({advisory.ecosystem}), "
+        f"pinned in `{advisory.manifest}`",
+        f"- Severity: {advisory.severity}",
+        f"- Fixed in: {fixed}",
+    ]
+    if advisory.aliases:
+        lines.append(f"- Aliases: {', '.join(advisory.aliases)}")
+    lines += [f"- Details: {advisory.url}", "", "Found by claude-mpm advisories."]
+    return "\n".join(lines)
+
+
+def upgrade_prompt(advisory: Advisory) -> str:
+    target = (
+        f"a fixed version ({', '.join(advisory.fixed)})"
+        if advisory.fixed
+        else "a version that is not affected"
+    )
+    return (
+        f"Security advisory {advisory.id} affects {advisory.package} "
+        f"{advisory.version}, pinned in {advisory.manifest}: {advisory.summary}\n\n"
+        f"Upgrade {advisory.package} to {target} with the project's package "
+        "manager so the lockfile is regenerated, run the tests, and fix what "
+        "the upgrade breaks. Leave unrelated dependencies alone. "
+        f"Details: {advisory.url}"
+    )
+
+
+class AdvisoryMonitor:
+    """Checks one project's pins and acts on new advisories."""
+
+    def __init__(
+        self,
+        project_dir: Path | None = None,
+        config: AdvisoryConfig | None = None,
+        client: OsvClient | None = None,
+        runner: Callable[..., subprocess.CompletedProcess] = subprocess.run,
+        broker_factory: Callable[[], Any] | None = None,
+    ):
+        """
+        Args:
+            project_dir: The project (default: the current directory)
+            config: Monitoring settings (default: the ``advisories`` config)
+            client: OSV client (injected for tests)
+            runner: Runs ``gh`` (injected for tests)
+            broker_factory: Opens the work queue broker (injected for tests)
+        """
+        self.project_dir = Path(project_dir or Path.cwd()).resolve()
+        self.config = config or AdvisoryConfig.load()
+        self.client = client or OsvClient()
+        self.runner = runner
+        self.broker_factory = broker_factory
+
+    def check(self) -> tuple[list[Dependency], list[Advisory]]:
+        """The project's pins and the advisories that affect them."""
+        dependencies = collect_dependencies(self.project_dir)
+        ignored = set(self.config.ignore)
+        floor = SEVERITIES.index(self.config.min_severity)
+        advisories = []
+        for dep, ids in self.client.query(dependencies).items():
+            for vuln_id in ids:
+                advisory = Advisory.from_osv(self.client.vulnerability(vuln_id), dep)
+                if advisory.matches(ignored) or advisory.severity_rank() < floor:
+                    continue
+                advisories.append(advisory)
+        advisories.sort(key=lambda a: (-a.severity_rank(), a.package, a.id))
+        return dependencies, advisories
+
+    def run(self, act: bool = True) -> AdvisoryReport:
+        """Check, act on new advisories unless *act* is False, and record it."""
+        dependencies, advisories = self.check()
+        handled = load_project_state(self.project_dir).get("handled") or {}
+        report = AdvisoryReport(
+            checked_at=datetime.now(UTC).isoformat(),
+            dependencies=len(dependencies),
+            advisories=advisories,
+            new=[a.key for a in advisories if a.key not in handled],
+        )
+        if not act:
+            return report
+
+        for advisory in advisories:
+            if advisory.key not in report.new:
+                continue
+            try:
+                report.actions[advisory.key] = self.act(advisory)
+            except AdvisoryError as e:
+                logger.warning(f"Could not act on {advisory.key}: {e}")
+                report.errors[advisory.key] = str(e)
+
+        def record(state: dict[str, Any]) -> None:
+            done = state.setdefault("handled", {})
+            for key, outcome in report.actions.items():
+                done[key] = {
+                    "action": self.config.action,
+                    "outcome": outcome,
+                    "at": report.checked_at,
+                }
+            state["report"] = report.to_dict()
+
+        _update_project_state(self.project_dir, record)
+        return report
+
+    def act(self, advisory: Advisory) -> str:
+        """Do the configured action; returns what was done."""
+        if self.config.action == "ticket":
+            return self.open_ticket(advisory)
+        if self.config.action == "queue":
+            return self.queue_upgrade(advisory)
+        return "reported"
+
+    def _gh(self, *args: str) -> str:
+        try:
+            result = self.runner(  # nosec B603 B607 - fixed gh arguments
+                ["gh", *args],
+                cwd=self.project_dir,
+                capture_output=True,
+                text=True,
+                timeout=60,
+            )
+        except FileNotFoundError:
+            raise AdvisoryError("the gh CLI is not installed") from None
+        except subprocess.TimeoutExpired:
+            raise AdvisoryError(f"gh {args[0]} {args[1]} timed out") from None
+        if result.returncode != 0:
+            detail = result.stderr.strip() or f"gh exited {result.returncode}"
+            raise AdvisoryError(detail)
+        return result.stdout.strip()
+
+    def open_ticket(self, advisory: Advisory) -> str:
+        """Open a GitHub issue, or find the one already open for it."""
+        search = ["--search", f"{advisory.id} in:title", "--state", "all"]
+        first_url = ["--json", "url", "--jq", ".[0].url // empty"]
+        existing = self._gh("issue", "list", *search, *first_url)
+        if existing:
+            return existing
+        args = ["issue", "create", "--title", ticket_title(advisory)]
+        args += ["--body", ticket_body(advisory)]
+        labels = [arg for label in self.config.labels for arg in ("--label", label)]
+        try:
+            return self._gh(*args, *labels)
+        except AdvisoryError:
+            if not labels:
+                raise
+            # The repository may not have the labels; file the issue anyway
+            return self._gh(*args)
+
+    def queue_upgrade(self, advisory: Advisory) -> str:
+        """Put an upgrade task for the package on the work queue."""
+        from .work_queue import QueueTask, WorkQueueConfig, create_broker
+
+        try:
+            broker = (
+                self.broker_factory()
+                if self.broker_factory
+                else create_broker(WorkQueueConfig.load())
+            )
+        except Exception as e:
+            raise AdvisoryError(f"work queue unavailable: {e}") from e
+        try:
+            task = broker.enqueue(
+                QueueTask(prompt=upgrade_prompt(advisory), cwd=str(self.project_dir))
+            )
+        finally:
+            broker.close()
+        return f"work-queue task {task.id}"
+
+
+# ---------------------------------------------------------------------------
+# Scheduling
+# ---------------------------------------------------------------------------
+
+
+def is_due(project_dir: Path, config: AdvisoryConfig) -> bool:
+    last_run = load_project_state(project_dir).get("last_run", 0)
+    return time.time() - last_run >= config.interval_hours * 3600
+
+
+def check_in_background(
+    project_dir: Path | None = None,
+    config: AdvisoryConfig | None = None,
+    launch: Callable[..., Any] = subprocess.Popen,
+) -> bool:
+    """Start a detached check if monitoring is on and one is due.
+
+    Returns:
+        True if a check was started
+    """
+    project_dir = Path(project_dir or Path.cwd()).resolve()
+    config = config or AdvisoryConfig.load()
+    if not config.enabled or not is_due(project_dir, config):
+        return False
+    _update_project_state(project_dir, lambda s: s.update(last_run=time.time()))
+    launch(  # nosec B603 - runs this module with the project directory
+        [sys.executable, "-m", __name__, str(project_dir)],
+        cwd=project_dir,
+        stdin=subprocess.DEVNULL,
+        stdout=subprocess.DEVNULL,
+        stderr=subprocess.DEVNULL,
+        start_new_session=True,
+    )
+    return True
+
+
+def advisory_notice(project_dir: Path) -> str | None:
+    """One line for the startup banner, or None when nothing is affected."""
+    report = load_report(project_dir)
+    if report is None or not report.advisories:
+        return None
+    count = len(report.advisories)
+    noun = "advisory affects" if count == 1 else "advisories affect"
+    return (
+        f"{count} security {noun} pinned dependencies; "
+        "run 'claude-mpm advisories list' for details"
+    )
+
+
+def main(argv: list[str] | None = None) -> int:
+    """Background check: ``python -m claude_mpm.services.advisories DIR``."""
+    argv = sys.argv[1:] if argv is None else argv
+    if len(argv) != 1:
+        print("usage: python -m claude_mpm.services.advisories PROJECT_DIR")
+        return 2
+    try:
+        report = AdvisoryMonitor(Path(argv[0])).run()
+    except Exception as e:
+        logger.warning(f"Advisory check failed: {e}")
+        return 1
+    return 1 if report.errors else 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
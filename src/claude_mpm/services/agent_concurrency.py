@@ -0,0 +1,184 @@
+"""Per-agent concurrency limits for delegations.
+
+WHAT: Caps how many delegations to one agent type may run at once. Each
+      delegation takes a slot when the PM starts it (PreToolUse on the Agent
+      tool) and gives it back when it returns (PostToolUse); a delegation
+      past its agent's limit is refused with a reason telling the PM to wait.
+WHY: Some agents hammer shared resources (databases, rate-limited APIs)
+     when the PM fans out to them freely.
+
+CONFIGURATION (configuration.yaml, user file then project file)::
+
+    agents:
+      max_concurrent:
+        engineer: 3
+        research: 1
+
+Agent names are normalized (``Research``, ``research-agent`` and
+``research`` are the same agent). Agents without an entry are unlimited.
+
+DESIGN DECISIONS:
+- Slots live in ``<project>/.claude-mpm/state/agent-slots.json``, keyed by
+  the tool_use_id, so every session in a project shares the limits; updates
+  go through ``update_json`` because parallel Agent calls run their hooks at
+  the same time
+- A slot whose release never arrived (a crashed or killed session) expires
+  after STALE_AFTER
+"""
+
+from __future__ import annotations
+
+import uuid
+from dataclasses import dataclass
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.core.state_files import read_json, update_json
+from claude_mpm.utils.agent_filters import normalize_agent_id
+
+CONFIG_SECTION = "agents"
+STATE_FILE = Path(".claude-mpm") / "state" / "agent-slots.json"
+STALE_AFTER = timedelta(hours=2)
+
+
+def load_limits(project_dir: str | Path | None) -> dict[str, int]:
+    """``agents.max_concurrent`` for a project: user file, then project file.
+
+    Returns:
+        Normalized agent name -> limit; entries that are not positive
+        integers are ignored
+    """
+    paths = [Path.home() / ".claude-mpm" / "config" / "configuration.yaml"]
+    if project_dir:
+        paths.append(Path(project_dir) / ".claude-mpm" / "configuration.yaml")
+    limits: dict[str, int] = {}
+    for path in paths:
+        try:
+            data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+        except (OSError, yaml.YAMLError):
+            continue
+        section = data.get(CONFIG_SECTION) if isinstance(data, dict) else None
+        if not isinstance(section, dict):
+            continue
+        configured = section.get("max_concurrent")
+        if not isinstance(configured, dict):
+            continue
+        for agent, limit in configured.items():
+            name = normalize_agent_id(str(agent))
+            if name and type(limit) is int and limit > 0:
+                limits[name] = limit
+    return limits
+
+
+@dataclass
+class SlotDecision:
+    """Outcome of asking for a slot."""
+
+    granted: bool
+    agent: str
+    slot_id: str
+    running: int
+    limit: int | None
+
+    def reason(self) -> str:
+        return (
+            f"{self.agent} already has {self.running} delegation(s) running, "
+            f"its limit (agents.max_concurrent.{self.agent} = {self.limit}). "
+            f"Wait for one to finish before delegating more work to {self.agent}."
+        )
+
+
+class AgentSlots:
+    """The running delegations of one project."""
+
+    def __init__(self, project_dir: str | Path, limits: dict[str, int] | None = None):
+        self.project_dir = Path(project_dir)
+        self.path = self.project_dir / STATE_FILE
+        self.limits = load_limits(project_dir) if limits is None else limits
+
+    def acquire(
+        self,
+        agent_type: str,
+        slot_id: str | None = None,
+        session_id: str = "",
+        now: datetime | None = None,
+    ) -> SlotDecision:
+        """Take a slot for a delegation to *agent_type* if its limit allows.
+
+        Agents without a limit are not tracked.
+        """
+        agent = normalize_agent_id(agent_type)
+        limit = self.limits.get(agent)
+        slot_id = slot_id or str(uuid.uuid4())
+        if limit is None:
+            return SlotDecision(True, agent, slot_id, 0, None)
+        now = now or datetime.now(UTC)
+        decision = SlotDecision(False, agent, slot_id, 0, limit)
+
+        def take(slots: dict[str, Any]) -> dict[str, Any]:
+            slots = _live(slots, now)
+            others = [
+                key
+                for key, slot in slots.items()
+                if slot["agent"] == agent and key != slot_id
+            ]
+            decision.running = len(others)
+            if slot_id in slots or decision.running < limit:
+                decision.granted = True
+                slots[slot_id] = {
+                    "agent": agent,
+                    "session_id": session_id,
+                    "started_at": now.isoformat(),
+                }
+            return slots
+
+        update_json(self.path, take)
+        return decision
+
+    def release(self, slot_id: str | None = None, agent_type: str = "") -> bool:
+        """Give back a slot: by ID, or without one the oldest of *agent_type*.
+
+        Returns:
+            Whether a slot was released
+        """
+        if not self.path.exists():
+            return False
+        agent = normalize_agent_id(agent_type)
+        released = []
+
+        def give_back(slots: dict[str, Any]) -> dict[str, Any]:
+            key = slot_id if slot_id in slots else None
+            if slot_id is None and agent:
+                owned = [k for k, slot in slots.items() if slot["agent"] == agent]
+                key = min(owned, key=lambda k: slots[k]["started_at"], default=None)
+            if key is not None:
+                released.append(slots.pop(key))
+            return slots
+
+        update_json(self.path, give_back)
+        return bool(released)
+
+    def running(self, now: datetime | None = None) -> dict[str, int]:
+        """Agent name -> delegations running now."""
+        counts: dict[str, int] = {}
+        slots = _live(read_json(self.path, {}), now or datetime.now(UTC))
+        for slot in slots.values():
+            counts[slot["agent"]] = counts.get(slot["agent"], 0) + 1
+        return counts
+
+
+def _live(slots: Any, now: datetime) -> dict[str, Any]:
+    if not isinstance(slots, dict):
+        return {}
+    live = {}
+    for key, slot in slots.items():
+        try:
+            started = datetime.fromisoformat(slot["started_at"])
+        except (KeyError, TypeError, ValueError):
+            continue
+        if now - started < STALE_AFTER:
+            live[key] = slot
+    return live
@@ -0,0 +1,182 @@
+"""Inventory of what each deployed agent can do, from its deployed file.
+
+WHAT: ``agent_capabilities`` reads every agent Claude Code would load for a
+project (``.claude/agents/*.md``, then ``~/.claude/agents/*.md`` for names
+the project does not define) and reports per agent its model, tools,
+memory settings, referenced skills and schema version::
+
+    {
+      "version": 1,
+      "project": "/work/api",
+      "agents": [
+        {
+          "name": "engineer",
+          "file": "/work/api/.claude/agents/engineer.md",
+          "scope": "project",
+          "version": "3.9.1",
+          "schema_version": "1.3.0",
+          "model": "sonnet",
+          "effort": null,
+          "tools": ["Read", "Edit", "Bash"],
+          "disallowed_tools": [],
+          "permissions": false,
+          "skills": ["test-driven-development"],
+          "missing_skills": [],
+          "memory": {"scope": null, "file": null, "routing": null},
+          "error": null
+        }
+      ]
+    }
+
+``tools`` is null when the agent may use every tool. An agent whose
+frontmatter cannot be parsed is still listed, with ``error`` set.
+
+WHY: External tooling (dashboards, CI checks, other orchestrators) needs a
+machine-readable inventory that matches what is deployed, not what the
+templates say: overrides, composition and local edits all change the
+deployed file.
+
+DESIGN DECISIONS:
+- Only the deployed files are read, parsed as deploys parse them
+  (agent_overrides.parse_agent), so the report never disagrees with
+  Claude Code about an agent
+- The top-level ``version`` is bumped only for incompatible changes; new
+  fields may be added without one
+"""
+
+from __future__ import annotations
+
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.services.agents.agent_overrides import parse_agent
+from claude_mpm.services.agents.tool_permissions import PERMISSIONS_KEY
+from claude_mpm.utils.agent_filters import normalize_agent_id
+
+FORMAT_VERSION = 1
+
+
+@dataclass
+class AgentCapabilities:
+    """What one deployed agent is configured with."""
+
+    name: str
+    file: str
+    scope: str  # project or user
+    version: str | None = None
+    schema_version: str | None = None
+    model: str | None = None
+    effort: str | None = None
+    # None: every tool
+    tools: list[str] | None = None
+    disallowed_tools: list[str] = field(default_factory=list)
+    permissions: bool = False
+    skills: list[str] = field(default_factory=list)
+    missing_skills: list[str] = field(default_factory=list)
+    memory: dict[str, Any] = field(default_factory=dict)
+    error: str | None = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+def _tool_list(value: Any) -> list[str] | None:
+    """Frontmatter ``tools: Read, Edit`` or ``tools: [Read, Edit]`` as a list."""
+    if value is None:
+        return None
+    items = value.split(",") if isinstance(value, str) else value
+    if not isinstance(items, list):
+        return None
+    return [str(item).strip() for item in items if str(item).strip()]
+
+
+def _optional_str(value: Any) -> str | None:
+    return None if value is None else str(value)
+
+
+def read_capabilities(
+    path: Path, scope: str, project_dir: Path, home: Path
+) -> AgentCapabilities:
+    """The capabilities declared by the deployed agent file at *path*."""
+    from claude_mpm.services.skills.selective_skill_deployer import (
+        get_skills_from_agent,
+    )
+
+    agent = AgentCapabilities(name=path.stem, file=str(path), scope=scope)
+    try:
+        frontmatter, _, _ = parse_agent(path.read_text(encoding="utf-8"))
+    except (OSError, UnicodeDecodeError, ValueError) as e:
+        agent.error = str(e)
+        return agent
+
+    agent.name = str(frontmatter.get("name") or path.stem)
+    agent.version = _optional_str(
+        frontmatter.get("version") or frontmatter.get("agent_version")
+    )
+    agent.schema_version = _optional_str(frontmatter.get("schema_version"))
+    agent.model = _optional_str(frontmatter.get("model"))
+    agent.effort = _optional_str(frontmatter.get("effort"))
+    agent.tools = _tool_list(frontmatter.get("tools"))
+    agent.disallowed_tools = _tool_list(frontmatter.get("disallowedTools")) or []
+    agent.permissions = bool(frontmatter.get(PERMISSIONS_KEY))
+
+    agent.skills = sorted(get_skills_from_agent(frontmatter))
+    skill_dirs = [root / ".claude" / "skills" for root in (project_dir, home)]
+    agent.missing_skills = [
+        skill
+        for skill in agent.skills
+        if not any((skills_dir / skill).is_dir() for skills_dir in skill_dirs)
+    ]
+
+    memory_file = (
+        project_dir
+        / ".claude-mpm"
+        / "memories"
+        / f"{normalize_agent_id(agent.name)}_memories.md"
+    )
+    routing = frontmatter.get("memory_routing")
+    agent.memory = {
+        # Claude Code's native agent memory: user, project or local
+        "scope": _optional_str(frontmatter.get("memory")),
+        "file": str(memory_file) if memory_file.is_file() else None,
+        "routing": routing if isinstance(routing, dict) else None,
+    }
+    return agent
+
+
+def agent_capabilities(
+    project_dir: str | Path, home: Path | None = None
+) -> list[AgentCapabilities]:
+    """Every agent deployed for *project_dir*, project agents first.
+
+    A user-level agent is left out when the project deploys one with the
+    same file name, since Claude Code loads the project's.
+    """
+    project_dir = Path(project_dir)
+    home = Path(home or Path.home())
+    agents: list[AgentCapabilities] = []
+    seen: set[str] = set()
+    for scope, root in (("project", project_dir), ("user", home)):
+        agents_dir = root / ".claude" / "agents"
+        if not agents_dir.is_dir():
+            continue
+        for path in sorted(agents_dir.glob("*.md")):
+            if path.name in seen:
+                continue
+            seen.add(path.name)
+            agents.append(read_capabilities(path, scope, project_dir, home))
+    return agents
+
+
+def capabilities_report(
+    project_dir: str | Path, home: Path | None = None
+) -> dict[str, Any]:
+    """The versioned ``agents capabilities --json`` document."""
+    return {
+        "version": FORMAT_VERSION,
+        "project": str(Path(project_dir)),
+        "agents": [
+            agent.to_dict() for agent in agent_capabilities(project_dir, home)
+        ],
+    }
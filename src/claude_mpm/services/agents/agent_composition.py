@@ -0,0 +1,286 @@
+"""Agents composed from a base template and mixins.
+
+WHAT: An agent can build on a shared base and add reusable mixins instead of
+repeating their text::
+
+    ---
+    name: python-engineer
+    description: Python specialist
+    extends: base-engineer
+    mixins: [testing-discipline, git-hygiene]
+    tools: Read,Edit,Write,Bash
+    ---
+
+    You are the Python engineer.
+
+    ## Python Rules
+
+    Use type hints everywhere.
+
+Bases and mixins are markdown files with the same shape as an agent (or an
+override). They are looked up by name in, first match wins::
+
+    <project>/.claude-mpm/agent-bases/<name>.md   (agent-mixins/ for mixins)
+    ~/.claude-mpm/agent-bases/<name>.md
+    <template dir>/agent-bases/<name>.md
+
+A base can also be a complete agent: ``extends: engineer`` falls back to the
+template next to the agent and then to the agent's usual sources. Bases may
+themselves extend a base and list mixins.
+
+At deploy time (see deployment_utils.render_agent_content) the agent is
+flattened, before overrides apply, in the order base -> mixins -> agent.
+
+MERGE RULES: as for overrides (see agent_overrides), except that
+- ``tools`` from mixins are added to the base's tools; tools the agent lists
+  itself replace the combined list
+- Text before a mixin's first heading is appended to the preamble instead of
+  replacing it
+- ``extends`` and ``mixins`` are removed from the deployed agent
+
+WHY: Teams kept many nearly identical agents, so a change to a shared
+instruction meant editing every one of them.
+
+DESIGN DECISIONS:
+- Bases and mixins live in their own directories, so agent discovery never
+  deploys a partial file as an agent
+- A base or mixin that cannot be found, or a cycle of bases, fails the
+  deployment rather than deploying an agent missing half its instructions
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.core.logging_utils import get_logger
+from claude_mpm.services.agents.agent_overrides import (
+    PREAMBLE,
+    Layer,
+    merge_layers,
+    parse_agent,
+    split_sections,
+)
+
+logger = get_logger(__name__)
+
+BASES_DIR = "agent-bases"
+MIXINS_DIR = "agent-mixins"
+EXTENDS_KEY = "extends"
+MIXINS_KEY = "mixins"
+# Deepest chain of bases, far beyond any real hierarchy
+MAX_DEPTH = 10
+
+
+@dataclass
+class Composition:
+    """A flattened agent and the files it was built from."""
+
+    content: str
+    # (layer name, path): "base:<name>" and "mixin:<name>", in merge order
+    files: list[tuple[str, Path]] = field(default_factory=list)
+    # Dotted frontmatter field or section heading -> layer name ("agent" for
+    # the agent's own file), as in agent_overrides.MergedAgent
+    origins: dict[str, str] = field(default_factory=dict)
+
+
+def _names(value: Any, key: str) -> list[str]:
+    if value is None:
+        return []
+    if isinstance(value, str):
+        value = [part for part in value.split(",") if part.strip()]
+    if not isinstance(value, list) or not all(isinstance(v, str) for v in value):
+        raise ValueError(f"{key} must be a name or a list of names, got {value!r}")
+    names = [v.strip() for v in value]
+    for name in names:
+        if not name or "/" in name or "\\" in name or name.startswith("."):
+            raise ValueError(f"Invalid {key} entry '{name}'")
+    return names
+
+
+def _tools(value: Any) -> list[str] | None:
+    from claude_mpm.services.agents.tool_permissions import parse_tools
+
+    return parse_tools(value)
+
+
+class AgentComposer:
+    """Resolves ``extends`` and ``mixins`` for one project."""
+
+    def __init__(self, project_root: Path | None = None, home: Path | None = None):
+        self.home = Path(home or Path.home())
+        self.project_root = Path(project_root or Path.cwd())
+
+    @classmethod
+    def for_deployment(cls, deployment_dir: Path) -> AgentComposer:
+        """Composer for a ``.claude/agents`` dir of a project or the home dir."""
+        deployment_dir = Path(deployment_dir).absolute()
+        if (deployment_dir.parent.name, deployment_dir.name) == (".claude", "agents"):
+            return cls(deployment_dir.parent.parent)
+        return cls()
+
+    def search_dirs(self, kind: str, source_dir: Path | None = None) -> list[Path]:
+        """Where bases (``agent-bases``) or mixins (``agent-mixins``) are found."""
+        dirs = [
+            self.project_root / ".claude-mpm" / kind,
+            self.home / ".claude-mpm" / kind,
+        ]
+        if source_dir is not None:
+            dirs.append(Path(source_dir) / kind)
+        return list(dict.fromkeys(dirs))
+
+    def find(self, kind: str, name: str, source_dir: Path | None = None) -> Path:
+        """The file of base or mixin *name*.
+
+        Raises:
+            ValueError: If there is none.
+        """
+        for directory in self.search_dirs(kind, source_dir):
+            path = directory / f"{name}.md"
+            if path.is_file():
+                return path
+        if kind == BASES_DIR:
+            if source_dir is not None and (Path(source_dir) / f"{name}.md").is_file():
+                return Path(source_dir) / f"{name}.md"
+            from claude_mpm.services.deployment_diff import agent_source
+
+            source = agent_source(name, self.project_root, self.home)
+            if source is not None:
+                return source
+        what = "Base" if kind == BASES_DIR else "Mixin"
+        searched = ", ".join(str(d) for d in self.search_dirs(kind, source_dir))
+        raise ValueError(f"{what} '{name}' not found (searched {searched})")
+
+    def compose(
+        self,
+        content: str,
+        source_path: Path | None = None,
+        _chain: tuple[str, ...] = (),
+    ) -> Composition:
+        """Flatten agent *content* into a standalone agent.
+
+        Agents without ``extends`` or ``mixins`` are returned unchanged.
+
+        Raises:
+            ValueError: If a base or mixin is missing or invalid, or bases
+                form a cycle.
+        """
+        frontmatter, _, body = parse_agent(content)
+        if not _chain and source_path is not None:
+            _chain = (Path(source_path).stem,)
+        base_name = frontmatter.get(EXTENDS_KEY)
+        mixins = _names(frontmatter.get(MIXINS_KEY), MIXINS_KEY)
+        if not base_name and not mixins:
+            return Composition(content)
+        source_dir = Path(source_path).parent if source_path else None
+        files: list[tuple[str, Path]] = []
+
+        layers: list[Layer] = []
+        base_origins: dict[str, str] = {}
+        if base_name:
+            if not isinstance(base_name, str):
+                raise ValueError(f"extends must be a name, got {base_name!r}")
+            base_name = _names(base_name, EXTENDS_KEY)[0]
+            chain = (*_chain, base_name)
+            if base_name in _chain or len(chain) > MAX_DEPTH:
+                raise ValueError(f"Cycle in agent bases: {' -> '.join(chain)}")
+            path = self.find(BASES_DIR, base_name, source_dir)
+            base = self.compose(path.read_text(encoding="utf-8"), path, chain)
+            files.extend(base.files)
+            files.append((f"base:{base_name}", path))
+            base_origins = {
+                key: f"base:{base_name}" if layer == "agent" else layer
+                for key, layer in base.origins.items()
+            }
+            base_frontmatter, _, base_body = parse_agent(base.content)
+            layers.append(
+                Layer(f"base:{base_name}", path, base_frontmatter, base_body)
+            )
+        else:
+            layers.append(Layer("agent", source_path, {}, ""))
+
+        for mixin in mixins:
+            path = self.find(MIXINS_DIR, mixin, source_dir)
+            files.append((f"mixin:{mixin}", path))
+            mixin_frontmatter, _, mixin_body = parse_agent(
+                path.read_text(encoding="utf-8")
+            )
+            layers.append(Layer(f"mixin:{mixin}", path, mixin_frontmatter, mixin_body))
+
+        own = {
+            k: v for k, v in frontmatter.items() if k not in (EXTENDS_KEY, MIXINS_KEY)
+        }
+        layers.append(Layer("agent", source_path, own, body))
+        content, origins = _flatten(layers, base_origins)
+        return Composition(content, files, origins)
+
+    def inputs(self, content: str, source_path: Path | None = None) -> list[Path]:
+        """The base and mixin files agent *content* is built from."""
+        return [path for _, path in self.compose(content, source_path).files]
+
+
+def _flatten(
+    layers: list[Layer], seed: dict[str, str]
+) -> tuple[str, dict[str, str]]:
+    """The flattened agent, and the layer each field and section came from."""
+    current, *rest = layers
+    declared = current.frontmatter.get("tools")
+    tools = _tools(declared)
+    tools_origin = current.name
+    as_string = isinstance(declared, str)
+    origins = dict(seed)
+    mixin_preambles: list[str] = []
+    for layer in rest:
+        frontmatter = dict(layer.frontmatter)
+        declared = frontmatter.pop("tools", None)
+        sections = split_sections(layer.body)
+        if layer.name.startswith("mixin:"):
+            if declared is not None:
+                if tools is None:
+                    as_string = isinstance(declared, str)
+                tools = list(dict.fromkeys([*(tools or []), *_tools(declared)]))
+                tools_origin = layer.name
+            # A mixin's preamble adds to the preamble instead of replacing it
+            preamble = dict(sections)[PREAMBLE]
+            if preamble:
+                mixin_preambles.append(preamble)
+                before = dict(split_sections(current.body))[PREAMBLE]
+                combined = "\n\n".join(text for text in (before, preamble) if text)
+                sections = [(PREAMBLE, combined), *sections[1:]]
+        else:
+            if declared is not None:
+                as_string = isinstance(declared, str)
+                tools = _tools(declared)
+                tools_origin = layer.name
+            # The agent's own preamble replaces the base's, not the mixins'
+            preamble = dict(sections)[PREAMBLE]
+            if preamble and mixin_preambles:
+                combined = "\n\n".join([preamble, *mixin_preambles])
+                sections = [(PREAMBLE, combined), *sections[1:]]
+        body = "\n\n".join(text for _, text in sections if text)
+        merged = merge_layers(
+            [current, Layer(layer.name, layer.path, frontmatter, body)]
+        )
+        origins = {
+            key: name if name == layer.name else origins.get(key, name)
+            for key, name in merged.origins.items()
+        }
+        body = "\n\n".join(text for _, text in merged.sections if text)
+        current = Layer(layer.name, layer.path, merged.frontmatter, body)
+
+    frontmatter = dict(current.frontmatter)
+    for key in (EXTENDS_KEY, MIXINS_KEY):
+        frontmatter.pop(key, None)
+        origins.pop(key, None)
+    if tools is not None:
+        frontmatter["tools"] = ",".join(tools) if as_string else tools
+        origins["tools"] = tools_origin
+    if not frontmatter:
+        return f"{current.body}\n", origins
+    frontmatter_text = yaml.safe_dump(
+        frontmatter, sort_keys=False, allow_unicode=True, width=1_000_000
+    ).rstrip()
+    return f"---\n{frontmatter_text}\n---\n\n{current.body}\n", origins
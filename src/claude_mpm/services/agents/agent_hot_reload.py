@@ -0,0 +1,266 @@
+"""Hot-reload edited agents into running sessions.
+
+WHAT: ``AgentReloader`` redeploys a project's agents when their template,
+its base or mixins (see agent_composition) or one of their overrides (see
+agent_overrides) changes, and records when each agent was reloaded in
+``.claude-mpm/agent-reloads.json``::
+
+    {
+      "version": 1,
+      "agents": {
+        "qa": {"reloaded_at": "2026-10-17T09:30:00+00:00", "checked_at": 1792...}
+      }
+    }
+
+Reloads happen two ways:
+
+- ``claude-mpm agents watch`` watches the templates and override directories
+  and redeploys after each burst of changes; the shared daemon's ``watchers``
+  component does the same for every attached project
+- On every delegation, the PreToolUse hook redeploys the delegated agent if
+  its inputs are newer than the last check (see hooks/agent_reload_hook.py)
+
+Claude Code reads agent definitions once, when a session starts. For an
+agent reloaded after that, the hook puts the agent's current instructions at
+the top of the delegation prompt and tells the PM the agent was reloaded, so
+the edit takes effect on the next delegation without restarting the session.
+
+WHY: Editing an agent meant ending the session and starting a new one, which
+lost the session's context.
+
+DESIGN DECISIONS:
+- Staleness is decided from modification times, so the per-delegation check
+  only stats a few files; ``checked_at`` keeps an unchanged template from
+  being redeployed on every delegation
+- Redeploying goes through deploy_agent_file, so local edits to deployed
+  agents are merged rather than overwritten, and pins, overrides and
+  permissions apply as on any deploy
+- Model and permission changes need no prompt injection: both are read from
+  the deployed file on every call
+"""
+
+from __future__ import annotations
+
+import threading
+import time
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.core.state_files import read_json, update_json
+from claude_mpm.services.agents.agent_composition import (
+    BASES_DIR,
+    MIXINS_DIR,
+    AgentComposer,
+)
+from claude_mpm.services.agents.agent_overrides import AgentOverrides, parse_agent
+
+logger = get_logger(__name__)
+
+RELOADS_FILE = "agent-reloads.json"
+DEFAULT_DEBOUNCE = 0.5
+# deploy_agent_file actions that changed the deployed agent
+CHANGED_ACTIONS = ("deployed", "updated", "merged")
+
+
+class AgentReloader:
+    """Keeps a project's deployed agents in step with their templates."""
+
+    def __init__(
+        self,
+        project_dir: Path | None = None,
+        home: Path | None = None,
+        debounce: float = DEFAULT_DEBOUNCE,
+    ) -> None:
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.home = Path(home or Path.home())
+        self.agents_dir = self.project_dir / ".claude" / "agents"
+        self.state_path = self.project_dir / ".claude-mpm" / RELOADS_FILE
+        self.debounce = debounce
+        self._changed_at: float | None = None
+        self._lock = threading.Lock()
+
+    def deployed(self) -> list[str]:
+        """Names of the agents deployed to the project."""
+        if not self.agents_dir.is_dir():
+            return []
+        return sorted(path.stem for path in self.agents_dir.glob("*.md"))
+
+    def inputs(self, name: str) -> list[Path]:
+        """The files agent *name* is rendered from.
+
+        The template comes first, then its bases and mixins (see
+        agent_composition), then overrides.
+        """
+        from claude_mpm.services.deployment_diff import agent_source
+
+        source = agent_source(name, self.project_dir, self.home)
+        if source is None:
+            return []
+        try:
+            composed = AgentComposer(self.project_dir, self.home).inputs(
+                source.read_text(encoding="utf-8"), source
+            )
+        except (OSError, ValueError):
+            # Redeploying reports the error; the template alone is still watched
+            composed = []
+        overrides = AgentOverrides(self.project_dir, self.home).override_paths(name)
+        return [source, *composed, *(path for _, path in overrides if path.is_file())]
+
+    def _agents(self) -> dict[str, Any]:
+        data = read_json(self.state_path, {})
+        agents = data.get("agents") if isinstance(data, dict) else None
+        return agents if isinstance(agents, dict) else {}
+
+    def reloaded_at(self, name: str) -> datetime | None:
+        """When agent *name* was last reloaded, if ever."""
+        value = (self._agents().get(name) or {}).get("reloaded_at")
+        try:
+            return datetime.fromisoformat(value) if value else None
+        except ValueError:
+            return None
+
+    def is_stale(self, name: str) -> bool:
+        """Whether agent *name*'s inputs changed since it was last deployed."""
+        deployed = self.agents_dir / f"{name}.md"
+        inputs = self.inputs(name)
+        if not deployed.is_file() or not inputs:
+            return False
+        checked_at = (self._agents().get(name) or {}).get("checked_at") or 0
+        since = max(deployed.stat().st_mtime, float(checked_at))
+        return any(path.stat().st_mtime > since for path in inputs)
+
+    def reload(self, name: str):
+        """Redeploy agent *name* from its template.
+
+        Returns:
+            The DeploymentResult, or None if the agent has no template.
+        """
+        from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+
+        inputs = self.inputs(name)
+        if not inputs:
+            return None
+        # Inputs as of now; a clock-skewed mtime must not look newer forever
+        checked_at = max([time.time(), *(path.stat().st_mtime for path in inputs)])
+        result = deploy_agent_file(inputs[0], self.agents_dir)
+        changed = result.success and result.action in CHANGED_ACTIONS
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            entry = data.setdefault("agents", {}).setdefault(name, {})
+            entry["checked_at"] = checked_at
+            if changed:
+                entry["reloaded_at"] = datetime.now(UTC).isoformat()
+
+        update_json(self.state_path, record)
+        if changed:
+            logger.info(f"Reloaded agent {name}")
+        elif not result.success:
+            logger.warning(f"Could not reload agent {name}: {result.error}")
+        return result
+
+    def reload_changed(self) -> dict[str, Any]:
+        """Redeploy every stale agent.
+
+        Returns:
+            Dict with "reloaded", "conflicts" and "errors" lists
+        """
+        summary: dict[str, Any] = {"reloaded": [], "conflicts": [], "errors": []}
+        for name in self.deployed():
+            if not self.is_stale(name):
+                continue
+            result = self.reload(name)
+            if result is None:
+                continue
+            if not result.success:
+                summary["errors"].append(f"{name}: {result.error}")
+            elif result.action == "conflict":
+                summary["conflicts"].append(name)
+            elif result.action in CHANGED_ACTIONS:
+                summary["reloaded"].append(name)
+        return summary
+
+    def watched_dirs(self) -> list[Path]:
+        """Directories whose changes can change a deployed agent."""
+        dirs = [
+            root / ".claude-mpm" / kind
+            for root in (self.project_dir, self.home)
+            for kind in ("agent-overrides", BASES_DIR, MIXINS_DIR)
+        ]
+        for name in self.deployed():
+            dirs.extend(path.parent for path in self.inputs(name))
+        return [d for d in dict.fromkeys(d.resolve() for d in dirs) if d.is_dir()]
+
+    def notify(self, path: str | Path, now: float | None = None) -> bool:
+        """Record a change to *path*; returns whether it can trigger a reload."""
+        if Path(path).suffix != ".md":
+            return False
+        with self._lock:
+            self._changed_at = time.monotonic() if now is None else now
+        return True
+
+    def poll(self, now: float | None = None) -> dict[str, Any] | None:
+        """Reload stale agents if changes have settled for the debounce period."""
+        now = time.monotonic() if now is None else now
+        with self._lock:
+            if self._changed_at is None or now - self._changed_at < self.debounce:
+                return None
+            self._changed_at = None
+        return self.reload_changed()
+
+    def event_handler(self):
+        """A watchdog handler feeding file events to ``notify``."""
+        from watchdog.events import FileSystemEventHandler
+
+        reloader = self
+
+        class _Handler(FileSystemEventHandler):
+            def on_any_event(self, event):
+                if event.is_directory:
+                    return
+                reloader.notify(event.src_path)
+                dest = getattr(event, "dest_path", None)
+                if dest:
+                    reloader.notify(dest)
+
+        return _Handler()
+
+    def run(self, on_reload=None, interval: float = 0.1) -> None:
+        """Reload stale agents, then watch their inputs until interrupted.
+
+        Args:
+            on_reload: Optional callback(summary) called after each check
+                that reloaded, or failed to reload, an agent
+            interval: Seconds between debounce checks
+        """
+        from watchdog.observers import Observer
+
+        def report(summary):
+            if on_reload is not None and any(summary.values()):
+                on_reload(summary)
+
+        report(self.reload_changed())
+        observer = Observer()
+        for directory in self.watched_dirs():
+            observer.schedule(self.event_handler(), str(directory), recursive=True)
+        observer.start()
+        try:
+            while True:
+                time.sleep(interval)
+                summary = self.poll()
+                if summary is not None:
+                    report(summary)
+        except KeyboardInterrupt:
+            pass
+        finally:
+            observer.stop()
+            observer.join()
+
+
+def current_instructions(project_dir: Path, name: str) -> str:
+    """The instructions of deployed agent *name*, without frontmatter."""
+    path = Path(project_dir) / ".claude" / "agents" / f"{name}.md"
+    _, _, body = parse_agent(path.read_text(encoding="utf-8"))
+    return body.strip()
@@ -0,0 +1,257 @@
+"""Partial agent overrides layered over the deployed template.
+
+WHAT: An override file changes only what it names in an agent::
+
+    ~/.claude-mpm/agent-overrides/<agent>.md          (user layer)
+    <project>/.claude-mpm/agent-overrides/<agent>.md  (project layer)
+
+Layers apply in order system template -> user -> project when an agent is
+deployed. ``claude-mpm agents explain <agent>`` shows which layer each
+frontmatter field and instructions section came from.
+
+MERGE RULES:
+- Frontmatter is deep-merged: mappings merge key by key, any other value
+  (lists included) replaces the template's; ``null`` removes the key
+- Instructions are merged by ``#`` / ``##`` section: a section replaces the
+  template's section with the same heading, a heading with no text removes
+  it, and new sections are appended. Text before the first heading replaces
+  the template's preamble
+
+Example, giving the engineer a different tool list and one extra rule::
+
+    ---
+    tools: [Read, Edit, Bash]
+    ---
+
+    ## Project Rules
+
+    Run ``make check`` before reporting back.
+
+WHY: Changing one paragraph of an agent meant copying the whole template
+into .claude-mpm/agents/, which then silently stopped receiving upstream
+improvements to everything else.
+
+DESIGN DECISIONS:
+- Overrides live in their own ``agent-overrides`` directories, so agent
+  discovery never mistakes a partial file for a complete agent
+- They are applied at render time (see deployment_utils.render_agent_content),
+  after agents.lock has picked the template, so pins cover the template only
+  and editing an override takes effect on the next deploy
+- The template's frontmatter text is kept verbatim unless an override
+  changes frontmatter
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.core.logging_utils import get_logger
+
+logger = get_logger(__name__)
+
+OVERRIDES_DIR = "agent-overrides"
+SYSTEM = "system"
+USER = "user"
+PROJECT = "project"
+PREAMBLE = "(preamble)"
+
+_FRONTMATTER_RE = re.compile(r"^---\n(.*?)\n---[ \t]*\n?", re.DOTALL)
+_SECTION_RE = re.compile(r"^#{1,2}\s+\S")
+_FENCE_RE = re.compile(r"^\s*(```|~~~)")
+
+
+def parse_agent(text: str) -> tuple[dict[str, Any], str, str]:
+    """Split agent markdown into (frontmatter, frontmatter text, body)."""
+    match = _FRONTMATTER_RE.match(text)
+    if not match:
+        return {}, "", text
+    try:
+        frontmatter = yaml.safe_load(match.group(1)) or {}
+    except yaml.YAMLError as e:
+        raise ValueError(f"Invalid frontmatter: {e}") from e
+    if not isinstance(frontmatter, dict):
+        raise ValueError("Frontmatter is not a mapping")
+    return frontmatter, match.group(1), text[match.end() :]
+
+
+def split_sections(body: str) -> list[tuple[str, str]]:
+    """Split *body* into (heading, text) pairs at ``#`` and ``##`` headings.
+
+    Text before the first heading is returned under PREAMBLE; headings
+    inside code fences are not section breaks.
+    """
+    sections: list[tuple[str, list[str]]] = [(PREAMBLE, [])]
+    in_fence = False
+    for line in body.splitlines():
+        if _FENCE_RE.match(line):
+            in_fence = not in_fence
+        elif not in_fence and _SECTION_RE.match(line):
+            sections.append((" ".join(line.split()), [line]))
+            continue
+        sections[-1][1].append(line)
+    return [(heading, "\n".join(lines).strip()) for heading, lines in sections]
+
+
+@dataclass(frozen=True)
+class Layer:
+    """One source of an agent's definition."""
+
+    name: str
+    path: Path | None
+    frontmatter: dict[str, Any]
+    body: str
+
+
+@dataclass
+class MergedAgent:
+    """An agent after all layers are applied, and where each part came from."""
+
+    frontmatter: dict[str, Any]
+    frontmatter_text: str
+    sections: list[tuple[str, str]]
+    layers: list[Layer]
+    # Dotted frontmatter field or section heading -> layer name
+    origins: dict[str, str] = field(default_factory=dict)
+
+    def render(self) -> str:
+        body = "\n\n".join(text for _, text in self.sections if text)
+        if not self.frontmatter_text:
+            return f"{body}\n"
+        return f"---\n{self.frontmatter_text}\n---\n\n{body}\n"
+
+
+def _record_origins(
+    value: Any, layer: str, origins: dict[str, str], prefix: str
+) -> None:
+    origins[prefix] = layer
+    if isinstance(value, dict):
+        for key, child in value.items():
+            _record_origins(child, layer, origins, f"{prefix}.{key}")
+
+
+def _forget_origins(origins: dict[str, str], prefix: str) -> None:
+    for key in [k for k in origins if k == prefix or k.startswith(f"{prefix}.")]:
+        del origins[key]
+
+
+def deep_merge(
+    base: dict[str, Any],
+    override: dict[str, Any],
+    layer: str,
+    origins: dict[str, str],
+    prefix: str = "",
+) -> dict[str, Any]:
+    """*override* merged over *base*, noting changed fields in *origins*."""
+    merged = dict(base)
+    for key, value in override.items():
+        path = f"{prefix}{key}"
+        if value is None:
+            merged.pop(key, None)
+            _forget_origins(origins, path)
+        elif isinstance(value, dict) and isinstance(merged.get(key), dict):
+            origins[path] = layer
+            merged[key] = deep_merge(merged[key], value, layer, origins, f"{path}.")
+        else:
+            merged[key] = value
+            _forget_origins(origins, path)
+            _record_origins(value, layer, origins, path)
+    return merged
+
+
+def merge_layers(layers: list[Layer], frontmatter_text: str = "") -> MergedAgent:
+    """Apply *layers* in order; the first is the complete template."""
+    base, *overrides = layers
+    origins: dict[str, str] = {}
+    for key, value in base.frontmatter.items():
+        _record_origins(value, base.name, origins, key)
+    frontmatter = base.frontmatter
+    sections = split_sections(base.body)
+    for heading, _ in sections:
+        origins[heading] = base.name
+
+    for layer in overrides:
+        if layer.frontmatter:
+            frontmatter = deep_merge(
+                frontmatter, layer.frontmatter, layer.name, origins
+            )
+            frontmatter_text = ""
+        by_heading = dict(sections)
+        for heading, text in split_sections(layer.body):
+            content_lines = text.splitlines()[0 if heading == PREAMBLE else 1 :]
+            if heading == PREAMBLE and not text:
+                continue
+            if not "\n".join(content_lines).strip():
+                by_heading.pop(heading, None)
+                origins.pop(heading, None)
+            else:
+                by_heading[heading] = text
+                origins[heading] = layer.name
+        order = [h for h, _ in sections if h in by_heading]
+        order += [h for h in by_heading if h not in order]
+        sections = [(h, by_heading[h]) for h in order]
+
+    if frontmatter and not frontmatter_text:
+        frontmatter_text = yaml.safe_dump(
+            frontmatter, sort_keys=False, allow_unicode=True, width=1_000_000
+        ).rstrip()
+    return MergedAgent(frontmatter, frontmatter_text, sections, layers, origins)
+
+
+class AgentOverrides:
+    """The user and project override layers that apply to one deployment."""
+
+    def __init__(self, project_root: Path | None = None, home: Path | None = None):
+        self.home = Path(home or Path.home())
+        self.project_root = Path(project_root or Path.cwd())
+
+    @classmethod
+    def for_deployment(cls, deployment_dir: Path) -> AgentOverrides | None:
+        """Overrides for a ``.claude/agents`` dir of a project or the home dir."""
+        deployment_dir = Path(deployment_dir).absolute()
+        if (deployment_dir.parent.name, deployment_dir.name) != (".claude", "agents"):
+            return None
+        return cls(deployment_dir.parent.parent)
+
+    def override_paths(self, name: str) -> list[tuple[str, Path]]:
+        user = self.home / ".claude-mpm" / OVERRIDES_DIR / f"{name}.md"
+        project = self.project_root / ".claude-mpm" / OVERRIDES_DIR / f"{name}.md"
+        paths = [(USER, user)]
+        if project.resolve() != user.resolve():
+            paths.append((PROJECT, project))
+        return paths
+
+    def layers(self, name: str) -> list[Layer]:
+        """The override layers of agent *name* that exist, user first."""
+        layers = []
+        for layer_name, path in self.override_paths(name):
+            if not path.is_file():
+                continue
+            frontmatter, _, body = parse_agent(path.read_text(encoding="utf-8"))
+            layers.append(Layer(layer_name, path, frontmatter, body))
+        return layers
+
+    def merge(
+        self, name: str, template: str, template_path: Path | None = None
+    ) -> MergedAgent:
+        frontmatter, frontmatter_text, body = parse_agent(template)
+        base = Layer(SYSTEM, template_path, frontmatter, body)
+        return merge_layers([base, *self.layers(name)], frontmatter_text)
+
+    def apply(self, name: str, template: str) -> str:
+        """*template* with the overrides of agent *name* applied.
+
+        Invalid override files are logged and skipped rather than failing
+        the deployment.
+        """
+        try:
+            if not self.layers(name):
+                return template
+            return self.merge(name, template).render()
+        except (OSError, ValueError) as e:
+            logger.warning(f"Ignoring overrides of agent '{name}': {e}")
+            return template
@@ -0,0 +1,250 @@
+"""Agent lockfile pinning each deployed agent to an exact template.
+
+WHAT: ``.claude-mpm/agents.lock`` records, per deployed agent, the template
+it was deployed from: its version, the SHA-256 of the template file and the
+cache file it came from. A copy of the pinned template is kept next to it in
+``.claude-mpm/pinned-agents/<agent>.md``::
+
+    {
+      "version": 1,
+      "agents": {
+        "engineer": {
+          "version": "3.9.1",
+          "sha256": "5be1c0...",
+          "source": "bobmatnyc/claude-mpm-agents/agents/engineer.md",
+          "locked_at": "2026-10-17T09:30:00+00:00"
+        }
+      }
+    }
+
+Once a project has a lock, every deployment into its ``.claude/agents``
+deploys the pinned copy, whatever the synced cache holds; an agent without
+an entry is pinned to the template it is first deployed from. The first
+``claude-mpm run`` pins the agents that are already deployed, before the
+startup sync fetches newer templates. Only ``claude-mpm agents update``
+moves a pin forward.
+
+WHY: Agent templates were upgraded on every ``claude-mpm run``, which
+changed how agents behaved mid-sprint without warning.
+
+DESIGN DECISIONS:
+- The lock and pinned copies are per project, next to the project's other
+  ``.claude-mpm`` state, so they can be committed; teammates then deploy
+  the same agents without having synced the same cache
+- Deployments consult the lock only when it exists, so deploying into a
+  project that never ran ``claude-mpm run`` behaves as before
+- Writes go through ``update_json``, so parallel deployments do not drop
+  each other's pins
+"""
+
+from __future__ import annotations
+
+import hashlib
+import re
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.core.logging_utils import get_logger
+from claude_mpm.core.state_files import read_json, update_json, write_atomic
+
+logger = get_logger(__name__)
+
+LOCK_FILE = "agents.lock"
+PINS_DIR = "pinned-agents"
+LOCK_VERSION = 1
+
+
+@dataclass(frozen=True)
+class LockedAgent:
+    """The template an agent is pinned to."""
+
+    version: str
+    sha256: str
+    source: str
+    locked_at: str
+
+
+@dataclass(frozen=True)
+class AgentUpdate:
+    """A pinned agent whose template in the cache has changed."""
+
+    name: str
+    pinned: LockedAgent
+    source: Path
+    version: str
+
+    def describe(self) -> str:
+        current = self.pinned.version or self.pinned.sha256[:12]
+        available = self.version or _sha256(self.source)[:12]
+        return f"{self.name}: {current} -> {available}"
+
+
+def _sha256(path: Path) -> str:
+    return hashlib.sha256(Path(path).read_bytes()).hexdigest()
+
+
+def template_version(path: Path) -> str:
+    """The ``version`` in a template's frontmatter, or "" without one."""
+    try:
+        content = Path(path).read_text(encoding="utf-8")
+    except (OSError, UnicodeDecodeError):
+        return ""
+    match = re.match(r"^---\n(.*?)\n---", content, re.DOTALL)
+    if not match:
+        return ""
+    try:
+        frontmatter = yaml.safe_load(match.group(1))
+    except yaml.YAMLError:
+        return ""
+    if not isinstance(frontmatter, dict) or frontmatter.get("version") is None:
+        return ""
+    return str(frontmatter["version"])
+
+
+def find_cached(name: str, cache_dir: Path) -> Path | None:
+    """The template for agent *name* in the agent cache, if any."""
+    exact = cache_dir / f"{name}.md"
+    if exact.is_file():
+        return exact
+    return next(cache_dir.glob(f"**/{name}.md"), None)
+
+
+def lock_path(project_root: Path | None = None) -> Path:
+    return Path(project_root or Path.cwd()) / ".claude-mpm" / LOCK_FILE
+
+
+class AgentsLock:
+    """Reads and writes one project's agents.lock and pinned copies."""
+
+    def __init__(self, project_root: Path | None = None):
+        self.path = lock_path(project_root)
+        self.pins_dir = self.path.parent / PINS_DIR
+
+    @classmethod
+    def for_deployment(cls, deployment_dir: Path) -> AgentsLock | None:
+        """The lock governing a project ``.claude/agents`` dir, if it has one."""
+        deployment_dir = Path(deployment_dir).absolute()
+        if (deployment_dir.parent.name, deployment_dir.name) != (".claude", "agents"):
+            return None
+        lock = cls(deployment_dir.parent.parent)
+        return lock if lock.exists() else None
+
+    def exists(self) -> bool:
+        return self.path.exists()
+
+    def entries(self) -> dict[str, LockedAgent]:
+        data = read_json(self.path, {})
+        agents = data.get("agents") if isinstance(data, dict) else None
+        if not isinstance(agents, dict):
+            return {}
+        entries = {}
+        for name, entry in agents.items():
+            try:
+                entries[name] = LockedAgent(**entry)
+            except TypeError:
+                continue
+        return entries
+
+    def get(self, name: str) -> LockedAgent | None:
+        return self.entries().get(name)
+
+    def pinned_file(self, name: str) -> Path:
+        return self.pins_dir / f"{name}.md"
+
+    def pin(self, name: str, template: Path) -> LockedAgent:
+        """Pin *name* to *template* and keep a copy of it."""
+        content = Path(template).read_bytes()
+        entry = LockedAgent(
+            version=template_version(template),
+            sha256=hashlib.sha256(content).hexdigest(),
+            source=_source_label(Path(template)),
+            locked_at=datetime.now(UTC).isoformat(),
+        )
+        if Path(template).absolute() != self.pinned_file(name).absolute():
+            write_atomic(self.pinned_file(name), content.decode("utf-8"))
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = LOCK_VERSION
+            if not isinstance(data.get("agents"), dict):
+                data["agents"] = {}
+            data["agents"][name] = asdict(entry)
+            data["agents"] = dict(sorted(data["agents"].items()))
+
+        update_json(self.path, record)
+        return entry
+
+    def unpin(self, name: str) -> bool:
+        removed = []
+
+        def drop(data: dict[str, Any]) -> None:
+            agents = data.get("agents")
+            if isinstance(agents, dict) and agents.pop(name, None):
+                removed.append(name)
+
+        if self.exists():
+            update_json(self.path, drop)
+        self.pinned_file(name).unlink(missing_ok=True)
+        return bool(removed)
+
+    def resolve(self, name: str, template: Path) -> Path:
+        """The file to deploy for *name* when *template* is on offer.
+
+        That is the pinned copy when the template differs from the pin. An
+        unpinned agent is pinned to *template*, as is one whose pinned copy
+        has gone missing.
+        """
+        entry = self.get(name)
+        if entry is not None and _sha256(template) != entry.sha256:
+            pinned = self.pinned_file(name)
+            if pinned.is_file():
+                logger.debug(
+                    f"Keeping pinned {name} {entry.version}; "
+                    "run 'claude-mpm agents update' to upgrade"
+                )
+                return pinned
+            logger.warning(f"Pinned copy of {name} is missing; re-pinning it")
+        if entry is None or _sha256(template) != entry.sha256:
+            self.pin(name, template)
+        return template
+
+    def pin_deployed(self, deploy_dir: Path, cache_dir: Path) -> list[str]:
+        """Pin the deployed agents that have a template in the cache.
+
+        Returns the names that were pinned. Agents already pinned and agents
+        without a cached template (local agents) are left alone.
+        """
+        pinned = []
+        entries = self.entries()
+        for deployed in sorted(Path(deploy_dir).glob("*.md")):
+            name = deployed.stem
+            if name in entries:
+                continue
+            template = find_cached(name, cache_dir)
+            if template is not None:
+                self.pin(name, template)
+                pinned.append(name)
+        return pinned
+
+    def updates(self, cache_dir: Path) -> list[AgentUpdate]:
+        """Pinned agents whose cached template differs from the pin."""
+        found = []
+        for name, entry in self.entries().items():
+            template = find_cached(name, cache_dir)
+            if template is not None and _sha256(template) != entry.sha256:
+                found.append(
+                    AgentUpdate(name, entry, template, template_version(template))
+                )
+        return found
+
+
+def _source_label(template: Path) -> str:
+    """*template* relative to the agent cache when it lives there."""
+    parts = template.absolute().parts
+    for i in range(len(parts) - 2):
+        if parts[i : i + 2] == ("cache", "agents"):
+            return "/".join(parts[i + 2 :])
+    return str(template)
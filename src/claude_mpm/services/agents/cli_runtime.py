@@ -45,11 +45,13 @@ class CLIAgentRunner(AgentRuntime):
         model: str | None = None,
         cwd: str | None = None,
         max_turns: int | None = None,
+        permission_mode: str | None = None,
     ) -> None:
         self._system_prompt = system_prompt
         self._model = model
         self._cwd = cwd
         self._max_turns = max_turns
+        self._permission_mode = permission_mode
 
     # -- class constructors ---------------------------------------------------
 
@@ -61,6 +63,7 @@ class CLIAgentRunner(AgentRuntime):
             model=config.model,
             cwd=config.cwd,
             max_turns=config.max_turns,
+            permission_mode=config.permission_mode,
         )
 
     # -- properties -----------------------------------------------------------
@@ -92,6 +95,9 @@ class CLIAgentRunner(AgentRuntime):
         if self._max_turns is not None:
             args.extend(["--max-turns", str(self._max_turns)])
 
+        if self._permission_mode:
+            args.extend(["--permission-mode", self._permission_mode])
+
         if resume_session:
             args.extend(["--resume", resume_session])
             if fork:
@@ -113,7 +119,13 @@ class CLIAgentRunner(AgentRuntime):
     ) -> AgentResult:
         """Run the CLI subprocess and convert output to ``AgentResult``."""
         # Apply per-call config overrides
-        original = (self._system_prompt, self._model, self._cwd, self._max_turns)
+        original = (
+            self._system_prompt,
+            self._model,
+            self._cwd,
+            self._max_turns,
+            self._permission_mode,
+        )
         if config is not None:
             if config.system_prompt is not None:
                 self._system_prompt = config.system_prompt
@@ -123,6 +135,8 @@ class CLIAgentRunner(AgentRuntime):
                 self._cwd = config.cwd
             if config.max_turns is not None:
                 self._max_turns = config.max_turns
+            if config.permission_mode is not None:
+                self._permission_mode = config.permission_mode
 
         args = self._build_cli_args(
             prompt,
@@ -142,7 +156,13 @@ class CLIAgentRunner(AgentRuntime):
             stdout_bytes, stderr_bytes = await process.communicate()
         finally:
             # Restore originals
-            self._system_prompt, self._model, self._cwd, self._max_turns = original
+            (
+                self._system_prompt,
+                self._model,
+                self._cwd,
+                self._max_turns,
+                self._permission_mode,
+            ) = original
 
         duration_ms = int((time.monotonic() - start) * 1000)
         stdout = stdout_bytes.decode() if stdout_bytes else ""
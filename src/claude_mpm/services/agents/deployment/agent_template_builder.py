@@ -22,6 +22,10 @@ import yaml
 
 from claude_mpm.config.sld_config import get_sld_instruction_for_agent
 from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.agents.tool_permissions import (
+    PERMISSIONS_KEY,
+    ToolPermissions,
+)
 
 if TYPE_CHECKING:
     from claude_mpm.core.config import Config
@@ -744,6 +748,26 @@ class AgentTemplateBuilder:
             for skill in skills:
                 frontmatter_lines.append(f"- {skill}")
 
+        # Least-privilege permissions: narrow the tools to what they allow and
+        # keep the block for the runtime checks (see agents/tool_permissions)
+        permissions_data = template_data.get("permissions") or (
+            capabilities.get("permissions") if isinstance(capabilities, dict) else None
+        )
+        if permissions_data:
+            permissions = ToolPermissions.from_frontmatter(
+                {PERMISSIONS_KEY: permissions_data}
+            )
+            allowed_tools = permissions.allowed_tools(tools if raw_tools else None)
+            if allowed_tools is not None:
+                frontmatter_lines.append(f"tools: {','.join(allowed_tools)}")
+            frontmatter_lines.append(
+                yaml.safe_dump(
+                    {PERMISSIONS_KEY: permissions.to_dict()},
+                    sort_keys=False,
+                    default_flow_style=None,
+                ).rstrip()
+            )
+
         # Add initialPrompt for self-starting delegation (issue #418)
         # Check template data first (explicit override), then use type/name mapping
         initial_prompt = template_data.get("initialPrompt")
@@ -32,6 +32,19 @@ from typing import TYPE_CHECKING
 
 import yaml
 
+from claude_mpm.services.agents.agent_composition import AgentComposer
+from claude_mpm.services.agents.agent_overrides import AgentOverrides
+from claude_mpm.services.agents.agents_lock import AgentsLock
+from claude_mpm.services.agents.tool_permissions import apply_to_agent
+from claude_mpm.services.deployment_integrity import record_deployment
+from claude_mpm.services.deployment_merge import (
+    CONFLICT,
+    KEPT,
+    MERGED,
+    UNCHANGED,
+    apply_update,
+)
+
 if TYPE_CHECKING:
     from claude_mpm.core.config import Config
 
@@ -261,7 +274,8 @@ class DeploymentResult:
     Attributes:
         success: Whether deployment succeeded
         deployed_path: Path to deployed file (if successful)
-        action: What action was taken ("deployed", "updated", "skipped", "failed")
+        action: What action was taken ("deployed", "updated", "merged",
+            "conflict", "skipped", "failed")
         error: Error message (if failed)
         cleaned_legacy: List of legacy filenames that were cleaned up
     """
@@ -401,6 +415,126 @@ def _extract_agent_type_from_content(content: str) -> str:
     return ""
 
 
+def render_agent_content(
+    source_content: str,
+    normalized_filename: str,
+    *,
+    ensure_frontmatter: bool = True,
+    config: Config | None = None,
+    overrides: AgentOverrides | None = None,
+    composer: AgentComposer | None = None,
+    source_path: Path | None = None,
+) -> str:
+    """Return the content ``deploy_agent_file`` writes for a source agent.
+
+    Args:
+        source_content: Text of the source agent file
+        normalized_filename: Deployed filename (see normalize_deployment_filename)
+        ensure_frontmatter: Ensure agent_id and model in frontmatter
+        config: Optional Config instance for the SLD block (see deploy_agent_file)
+        overrides: User and project overrides merged over the source
+            (see agent_overrides)
+        composer: Resolves the source's ``extends`` and ``mixins`` (see
+            agent_composition); defaults to the current project's
+        source_path: Path of the source agent, where bases and mixins
+            shipped with the template are looked up
+
+    Returns:
+        The content to deploy
+
+    Raises:
+        ValueError: If the agent's permissions block is invalid, or its
+            base or mixins cannot be resolved
+    """
+    # Flatten base and mixins first, so overrides apply to the whole agent
+    source_content = (
+        (composer or AgentComposer()).compose(source_content, source_path).content
+    )
+    if overrides is not None:
+        source_content = overrides.apply(
+            Path(normalized_filename).stem, source_content
+        )
+    # Narrow the tools to the agent's permissions (see tool_permissions)
+    source_content = apply_to_agent(source_content)
+    deploy_content = source_content
+    if ensure_frontmatter:
+        deploy_content = ensure_agent_id_in_frontmatter(
+            source_content, normalized_filename
+        )
+        # Derive agent name from filename stem (e.g. "python-engineer" from
+        # "python-engineer.md") and inject a default model when missing.
+        agent_name = Path(normalized_filename).stem
+        deploy_content = ensure_model_in_frontmatter(deploy_content, agent_name)
+
+    # SLD block injection (Bug 1 fix).
+    # The cache-copy path bypasses AgentTemplateBuilder.build_agent_markdown(),
+    # so we post-process the content here when a Config is supplied and the
+    # feature flag is on.  inject_sld_block_into_content() is idempotent —
+    # it checks for SLD_BLOCK_MARKER before appending, so running deploy
+    # twice never duplicates the block.
+    if config is not None:
+        from claude_mpm.config.sld_config import inject_sld_block_into_content
+
+        agent_type = _extract_agent_type_from_content(deploy_content)
+        deploy_content = inject_sld_block_into_content(
+            deploy_content, agent_type, config=config
+        )
+    return deploy_content
+
+
+@dataclass
+class RenderedAgent:
+    """Content ``deploy_agent_file`` would write, and where it comes from.
+
+    Attributes:
+        filename: Deployed filename (see normalize_deployment_filename)
+        source: Template rendered; the pinned one when the project has an
+            agents.lock
+        content: The content to deploy
+    """
+
+    filename: str
+    source: Path
+    content: str
+
+
+def render_agent_file(
+    source_file: Path,
+    deployment_dir: Path,
+    *,
+    ensure_frontmatter: bool = True,
+    config: Config | None = None,
+) -> RenderedAgent:
+    """Render a source agent exactly as ``deploy_agent_file`` would, without
+    writing anything (used by ``agents deploy --dry-run``).
+
+    Raises:
+        OSError: If the source cannot be read
+        ValueError: If the source is empty or cannot be rendered (see
+            render_agent_content)
+    """
+    normalized_filename = normalize_deployment_filename(source_file.name)
+    lock = AgentsLock.for_deployment(deployment_dir)
+    if lock is not None:
+        source_file = lock.resolve(Path(normalized_filename).stem, source_file)
+
+    source_content = source_file.read_text(encoding="utf-8")
+    # Phase 1c: Content validation - reject empty/whitespace-only files
+    if not source_content.strip():
+        raise ValueError(f"Agent file is empty: {source_file.name}")
+
+    content = render_agent_content(
+        source_content,
+        normalized_filename,
+        ensure_frontmatter=ensure_frontmatter,
+        config=config,
+        overrides=AgentOverrides.for_deployment(deployment_dir),
+        composer=AgentComposer.for_deployment(deployment_dir),
+        source_path=source_file,
+    )
+    return RenderedAgent(normalized_filename, source_file, content)
+
+
 def deploy_agent_file(
     source_file: Path,
     deployment_dir: Path,
@@ -425,19 +559,25 @@ def deploy_agent_file(
 
     Algorithm:
     1. Validate source file exists
-    2. Normalize filename to dash-based convention
+    2. Normalize filename to dash-based convention, and swap in the pinned
+       template when the project has an agents.lock (see agents_lock)
     3. Clean up legacy underscore variants (if cleanup_legacy=True)
-    4. Check if deployment needed (content comparison unless force=True)
-    5. Ensure agent_id in frontmatter (if ensure_frontmatter=True)
-    6. Inject SLD block when enabled and agent type qualifies (Step 6a)
-    7. Write content to deployment location
+    4. Ensure agent_id in frontmatter (if ensure_frontmatter=True)
+    5. Flatten the agent's base and mixins (see agent_composition), merge
+       user and project agent overrides (see agent_overrides), narrow
+       the tools to the agent's permissions (see tool_permissions), then
+       inject SLD block when enabled and agent type qualifies
+    6. Write content only if it differs from the deployed file
 
     Args:
         source_file: Path to source agent file (in cache)
         deployment_dir: Target deployment directory (.claude/agents/)
         cleanup_legacy: Remove underscore-variant files (default: True)
         ensure_frontmatter: Ensure agent_id in frontmatter (default: True)
-        force: Force deployment even if content matches (default: False)
+        force: Deploy the upstream content even over local edits. Without it,
+            local edits are merged with the update (see deployment_merge).
+            Content is always compared, so a file whose deployed content
+            already matches is never rewritten
         config: Optional Config instance for SLD feature-flag check.
             When provided and ``workflow.spec_linked_docs.enabled`` is True,
             engineer and documentation agents receive the SLD instruction block.
@@ -471,22 +611,19 @@ def deploy_agent_file(
         )
 
     try:
-        # Step 2: Normalize filename to dash-based convention
-        normalized_filename = normalize_deployment_filename(source_file.name)
-        target_file = deployment_dir / normalized_filename
-
-        # Step 3: Read and validate source content BEFORE legacy cleanup
+        # Steps 2-3 and 5: Resolve the pinned template, read and validate it
+        # and build the content to deploy. This runs BEFORE legacy cleanup
         # (prevents data loss if source is empty but underscore variant exists)
-        source_content = source_file.read_text(encoding="utf-8")
-
-        # Phase 1c: Content validation - reject empty/whitespace-only files
-        if not source_content.strip():
-            logger.warning(f"Skipping empty agent file: {source_file.name}")
-            return DeploymentResult(
-                success=False,
-                error=f"Agent file is empty: {source_file.name}",
-                cleaned_legacy=cleaned_legacy,
-            )
+        rendered = render_agent_file(
+            source_file,
+            deployment_dir,
+            ensure_frontmatter=ensure_frontmatter,
+            config=config,
+        )
+        normalized_filename = rendered.filename
+        target_file = deployment_dir / normalized_filename
+        source_file = rendered.source
+        deploy_content = rendered.content
 
         # Step 4: Clean up legacy underscore variants (safe — source validated above)
         if cleanup_legacy:
@@ -508,68 +645,50 @@ def deploy_agent_file(
                         )
                         # Don't fail deployment just because cleanup failed
 
-        # Step 5: Check if deployment needed (content comparison)
+        # Step 6: Write only if the deployed bytes would change. This compares
+        # the final content (SLD block included), so identical agents are
+        # never rewritten, even with force=True: rewriting them only churns
+        # mtimes and wakes file watchers. Without force, local edits are
+        # three-way merged with the update instead of overwritten.
         was_existing = target_file.exists()
-        should_deploy = force
-
-        if not force and was_existing:
-            existing_content = target_file.read_text(encoding="utf-8")
-            # Compare after potential frontmatter modification
-            modified_content = source_content
-            if ensure_frontmatter:
-                modified_content = ensure_agent_id_in_frontmatter(
-                    source_content, normalized_filename
-                )
-                _agent_name = Path(normalized_filename).stem
-                modified_content = ensure_model_in_frontmatter(
-                    modified_content, _agent_name
-                )
-            should_deploy = modified_content != existing_content
-        else:
-            should_deploy = True
+        status = apply_update(
+            target_file, deploy_content, source=source_file, force=force
+        )
 
-        if not should_deploy and was_existing:
-            logger.debug(f"Skipped (up-to-date): {normalized_filename}")
+        if status == CONFLICT:
+            # Deployed file left as is until the conflict is resolved
             return DeploymentResult(
                 success=True,
                 deployed_path=target_file,
-                action="skipped",
+                action="conflict",
                 cleaned_legacy=cleaned_legacy,
             )
-
-        # Step 6: Ensure frontmatter if requested
-        deploy_content = source_content
-        if ensure_frontmatter:
-            deploy_content = ensure_agent_id_in_frontmatter(
-                source_content, normalized_filename
-            )
-            # Derive agent name from filename stem (e.g. "python-engineer" from
-            # "python-engineer.md") and inject a default model when missing.
-            agent_name = Path(normalized_filename).stem
-            deploy_content = ensure_model_in_frontmatter(deploy_content, agent_name)
-
-        # Step 6a: SLD block injection (Bug 1 fix).
-        # The cache-copy path bypasses AgentTemplateBuilder.build_agent_markdown(),
-        # so we post-process the content here when a Config is supplied and the
-        # feature flag is on.  inject_sld_block_into_content() is idempotent —
-        # it checks for SLD_BLOCK_MARKER before appending, so running deploy
-        # twice never duplicates the block.
-        if config is not None:
-            from claude_mpm.config.sld_config import inject_sld_block_into_content
-
-            agent_type = _extract_agent_type_from_content(deploy_content)
-            deploy_content = inject_sld_block_into_content(
-                deploy_content, agent_type, config=config
+        if status == KEPT:
+            logger.debug(f"Kept local edits: {normalized_filename}")
+            return DeploymentResult(
+                success=True,
+                deployed_path=target_file,
+                action="skipped",
+                cleaned_legacy=cleaned_legacy,
             )
 
-        # Step 7: Ensure deployment directory exists
-        deployment_dir.mkdir(parents=True, exist_ok=True)
+        # Step 7: Record content hashes for supply-chain verification
+        record_deployment(target_file, source_file)
 
-        # Step 8: Write content to deployment location
-        target_file.write_text(deploy_content, encoding="utf-8")
+        if status == UNCHANGED:
+            logger.debug(f"Skipped (up-to-date): {normalized_filename}")
+            return DeploymentResult(
+                success=True,
+                deployed_path=target_file,
+                action="skipped",
+                cleaned_legacy=cleaned_legacy,
+            )
 
         # Determine action
-        action = "updated" if was_existing else "deployed"
+        if status == MERGED:
+            action = "merged"
+        else:
+            action = "updated" if was_existing else "deployed"
         logger.info(f"{action.capitalize()}: {normalized_filename}")
 
         return DeploymentResult(
@@ -591,6 +710,11 @@ def deploy_agent_file(
         return DeploymentResult(
             success=False, error=f"IO error: {e}", cleaned_legacy=cleaned_legacy
         )
+    except ValueError as e:
+        logger.error(f"Invalid agent {source_file.name}: {e}")
+        return DeploymentResult(
+            success=False, error=f"Invalid agent: {e}", cleaned_legacy=cleaned_legacy
+        )
     except Exception as e:
         logger.error(f"Unexpected error deploying {source_file.name}: {e}")
         return DeploymentResult(
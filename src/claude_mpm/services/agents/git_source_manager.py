@@ -76,6 +76,9 @@ class GitSourceManager:
         3. Discovers agents in the cached directory
         4. Returns sync results with metadata
 
+        GitLab, Bitbucket and SSH repositories are synced from an archive or
+        a shallow clone instead (see sources/hosted_agent_sync.py).
+
         Args:
             repo: GitRepository to sync
             force: Force sync even if cache is fresh (bypasses ETag)
@@ -112,30 +115,16 @@ class GitSourceManager:
         }
 
         try:
-            # Build source URL for raw GitHub content
-            # Format: https://raw.githubusercontent.com/owner/repo/{branch}/subdirectory
-            owner, repo_name = repo._parse_github_url(repo.url)
-            branch = repo.branch
-
-            if repo.subdirectory:
-                subdirectory = repo.subdirectory.strip("/")
-                source_url = f"https://raw.githubusercontent.com/{owner}/{repo_name}/{branch}/{subdirectory}"
-            else:
-                source_url = (
-                    f"https://raw.githubusercontent.com/{owner}/{repo_name}/{branch}"
-                )
-
-            # Initialize sync service
-            sync_service = GitSourceSyncService(
-                source_url=source_url,
-                cache_dir=repo.cache_path,
-                source_id=repo.identifier,
-            )
+            from claude_mpm.services.agents.sources import hosted_agent_sync
 
-            # Sync agents with progress bar
-            sync_results = sync_service.sync_agents(
-                force_refresh=force, show_progress=show_progress
-            )
+            if hosted_agent_sync.is_hosted(repo):
+                updated, cached, commit = hosted_agent_sync.sync_hosted_repository(
+                    repo, force=force
+                )
+                sync_results = {"total_downloaded": updated, "cache_hits": cached}
+            else:
+                sync_results = self._sync_github(repo, force, show_progress)
+                commit = None
 
             # Discover agents in cache
             discovery_service = RemoteAgentDiscoveryService(repo.cache_path)
@@ -156,6 +145,8 @@ class GitSourceManager:
                 ],
                 "timestamp": datetime.now(UTC).isoformat(),
             }
+            if commit:
+                result["commit"] = commit
 
             logger.info(
                 f"Sync complete: {result['files_updated']} updated, "
@@ -173,6 +164,36 @@ class GitSourceManager:
                 "timestamp": datetime.now(UTC).isoformat(),
             }
 
+    def _sync_github(
+        self, repo: GitRepository, force: bool, show_progress: bool
+    ) -> dict[str, Any]:
+        """Sync a GitHub repository through raw.githubusercontent.com."""
+        # Build source URL for raw GitHub content
+        # Format: https://raw.githubusercontent.com/owner/repo/{branch}/subdirectory
+        owner, repo_name = repo._parse_github_url(repo.url)
+        branch = repo.branch
+
+        if repo.subdirectory:
+            subdirectory = repo.subdirectory.strip("/")
+            source_url = f"https://raw.githubusercontent.com/{owner}/{repo_name}/{branch}/{subdirectory}"
+        else:
+            source_url = (
+                f"https://raw.githubusercontent.com/{owner}/{repo_name}/{branch}"
+            )
+
+        # Initialize sync service
+        sync_service = GitSourceSyncService(
+            source_url=source_url,
+            cache_dir=repo.cache_path,
+            source_id=repo.identifier,
+            token=repo.resolved_token,
+        )
+
+        # Sync agents with progress bar
+        return sync_service.sync_agents(
+            force_refresh=force, show_progress=show_progress
+        )
+
     def sync_all_repositories(
         self,
         repos: list[GitRepository],
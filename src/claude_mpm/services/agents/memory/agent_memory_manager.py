@@ -28,6 +28,7 @@ from claude_mpm.core.config import Config
 from claude_mpm.core.enums import OperationResult
 from claude_mpm.core.interfaces import MemoryServiceInterface
 from claude_mpm.core.unified_paths import get_path_manager
+from claude_mpm.services import data_encryption
 from claude_mpm.utils.agent_filters import normalize_agent_id
 
 from .content_manager import MemoryContentManager
@@ -147,7 +148,9 @@ class AgentMemoryManager(MemoryServiceInterface):
         # Load project-level memory if exists
         if project_memory_file.exists():
             try:
-                project_memory = project_memory_file.read_text(encoding="utf-8")
+                project_memory = data_encryption.reveal(
+                    project_memory_file.read_text(encoding="utf-8")
+                )
                 project_memory = self.content_manager.validate_and_repair(
                     project_memory, agent_id
                 )
@@ -221,7 +224,10 @@ class AgentMemoryManager(MemoryServiceInterface):
         try:
             target_dir = self.memories_dir
             memory_file = target_dir / f"{normalize_agent_id(agent_id)}_memories.md"
-            memory_file.write_text(template, encoding="utf-8")
+            memory_file.write_text(
+                data_encryption.protect(template, data_encryption.MEMORIES),
+                encoding="utf-8",
+            )
             self.logger.info(f"Created project-specific memory file for {agent_id}")
 
         except Exception as e:
@@ -686,7 +692,10 @@ class AgentMemoryManager(MemoryServiceInterface):
                 return False
 
             # Write the content
-            memory_path.write_text(content, encoding="utf-8")
+            memory_path.write_text(
+                data_encryption.protect(content, data_encryption.MEMORIES),
+                encoding="utf-8",
+            )
             self.logger.info(f"Saved memory for agent {agent_id}")
             return True
 
@@ -738,7 +747,7 @@ class AgentMemoryManager(MemoryServiceInterface):
                 ),
             }
 
-        content = memory_file.read_text(encoding="utf-8")
+        content = data_encryption.reveal(memory_file.read_text(encoding="utf-8"))
         entry_count = sum(
             1 for line in content.splitlines() if line.strip().startswith("-")
         )
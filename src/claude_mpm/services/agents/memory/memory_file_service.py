@@ -3,6 +3,7 @@
 from pathlib import Path
 
 from claude_mpm.core.logging_utils import get_logger
+from claude_mpm.services import data_encryption
 
 logger = get_logger(__name__)
 
@@ -165,7 +166,9 @@ class MemoryFileService:
             file_path.parent.mkdir(parents=True, exist_ok=True)
 
             # Write content
-            file_path.write_text(content)
+            file_path.write_text(
+                data_encryption.protect(content, data_encryption.MEMORIES)
+            )
 
             self.logger.debug(f"Saved memory file: {file_path}")
             return True
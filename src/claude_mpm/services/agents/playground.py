@@ -0,0 +1,297 @@
+"""
+Prompt engineering playground for agent instructions.
+
+WHY: Tuning an agent's instructions used to mean edit template, redeploy,
+start a session, try a prompt, repeat. The playground keeps a draft of the
+instructions in memory, shows the effective system prompt Claude Code would
+receive, runs single-turn prompts against each revision and only writes the
+draft back to a template once the user accepts it.
+
+DESIGN DECISIONS:
+- The draft replaces only the agent-specific instructions; whatever the
+  deployment builder appends (BASE-AGENT.md layers, memory instructions) is
+  kept, so the shown prompt matches what would actually be deployed
+- Turns run through an AgentRuntime (the claude CLI by default) with
+  max_turns=1; ``ReplayResponder`` answers from a recording instead, for
+  offline or deterministic iteration
+- Accepted drafts are written to the project-local template
+  (``.claude-mpm/agents/<name>.md``), which takes priority over cached and
+  bundled agents at deploy time and never touches the package or git cache
+"""
+
+from __future__ import annotations
+
+import difflib
+import time
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Protocol
+
+from ...core.logger import get_logger
+from .agent_runtime import AgentConfig, AgentResult
+
+logger = get_logger(__name__)
+
+# Source kinds in lookup priority order
+PROJECT_TEMPLATE = "project-template"
+USER_TEMPLATE = "user-template"
+CACHED = "cache"
+BUNDLED = "bundled"
+DEPLOYED = "deployed"
+
+
+def split_frontmatter(text: str) -> tuple[str, str]:
+    """Split an agent markdown file into (frontmatter block, body)."""
+    if not text.startswith("---"):
+        return "", text.strip()
+    end = text.find("\n---", 3)
+    if end == -1:
+        return "", text.strip()
+    close = text.find("\n", end + 4)
+    close = len(text) if close == -1 else close + 1
+    return text[:close], text[close:].strip()
+
+
+@dataclass
+class AgentSource:
+    """An agent markdown file the playground reads instructions from."""
+
+    name: str
+    path: Path
+    kind: str
+    frontmatter: str
+    body: str
+
+    @classmethod
+    def load(cls, name: str, path: Path, kind: str) -> AgentSource:
+        frontmatter, body = split_frontmatter(path.read_text(encoding="utf-8"))
+        return cls(name, path, kind, frontmatter, body)
+
+
+def _candidate_paths(
+    name: str, project_dir: Path, home: Path
+) -> list[tuple[str, Path]]:
+    from ... import __file__ as package_init
+
+    filename = f"{name}.md"
+    candidates = [
+        (PROJECT_TEMPLATE, project_dir / ".claude-mpm" / "agents" / filename),
+        (USER_TEMPLATE, home / ".claude-mpm" / "agents" / filename),
+    ]
+    cache_root = home / ".claude-mpm" / "cache" / "agents"
+    if cache_root.is_dir():
+        candidates.extend((CACHED, p) for p in sorted(cache_root.rglob(filename)))
+    candidates.append(
+        (BUNDLED, Path(package_init).parent / "agents" / "bundled" / filename)
+    )
+    candidates.append((DEPLOYED, project_dir / ".claude" / "agents" / filename))
+    return candidates
+
+
+def locate_agent_source(
+    name: str, project_dir: Path | None = None, home: Path | None = None
+) -> AgentSource | None:
+    """Find the template an agent is built from (deployed file as last resort)."""
+    project_dir = project_dir or Path.cwd()
+    home = home or Path.home()
+    for kind, path in _candidate_paths(name, project_dir, home):
+        if path.is_file():
+            return AgentSource.load(name, path, kind)
+    return None
+
+
+def deployment_suffix(source: AgentSource) -> str:
+    """What deployment appends after an agent's own instructions."""
+    if source.kind == DEPLOYED:
+        return ""
+    try:
+        from .deployment.agent_template_builder import AgentTemplateBuilder
+
+        built = AgentTemplateBuilder().build_agent_markdown(
+            source.name, source.path, {}
+        )
+    except Exception as e:
+        logger.debug(f"Could not compose prompt for {source.name}: {e}")
+        return ""
+    _, built_body = split_frontmatter(built)
+    if built_body.startswith(source.body):
+        return built_body[len(source.body) :]
+    return ""
+
+
+def effective_prompt(source: AgentSource) -> str:
+    """System prompt Claude Code receives for the agent as it stands."""
+    return source.body + deployment_suffix(source)
+
+
+class Responder(Protocol):
+    async def run(
+        self, prompt: str, config: AgentConfig | None = None
+    ) -> AgentResult: ...
+
+
+class ReplayResponder:
+    """Answers from a recorded session instead of calling a model."""
+
+    def __init__(self, recording: Path):
+        from ..model.recordings import ReplayIndex, load_recording
+
+        self.index = ReplayIndex(load_recording(recording))
+
+    async def run(self, prompt: str, config: AgentConfig | None = None) -> AgentResult:
+        turn, _how = self.index.match(prompt)
+        return AgentResult(text=turn.reply, num_turns=1, duration_ms=0)
+
+
+@dataclass
+class PlaygroundTurn:
+    revision: int
+    prompt: str
+    reply: str
+    is_error: bool = False
+    duration_ms: int | None = None
+
+
+@dataclass
+class PromptPlayground:
+    """Draft instructions for one agent plus the turns run against them."""
+
+    source: AgentSource
+    responder: Responder
+    model: str | None = None
+    draft: str = ""
+    revision: int = 0
+    history: list[PlaygroundTurn] = field(default_factory=list)
+    _suffix: str | None = field(default=None, repr=False)
+
+    def __post_init__(self):
+        self.original = self.source.body
+        if not self.draft:
+            self.draft = self.original
+
+    # ------------------------------------------------------------------
+    # Prompt assembly
+    # ------------------------------------------------------------------
+
+    def _deployment_suffix(self) -> str:
+        if self._suffix is None:
+            self._suffix = deployment_suffix(self.source)
+        return self._suffix
+
+    def effective_prompt(self) -> str:
+        """System prompt Claude Code would receive with the current draft."""
+        return self.draft + self._deployment_suffix()
+
+    # ------------------------------------------------------------------
+    # Editing
+    # ------------------------------------------------------------------
+
+    @property
+    def dirty(self) -> bool:
+        return self.draft != self.original
+
+    def set_draft(self, text: str) -> bool:
+        """Replace the draft; returns True (and bumps the revision) if it changed."""
+        text = text.strip()
+        if text == self.draft:
+            return False
+        self.draft = text
+        self.revision += 1
+        return True
+
+    def reset(self) -> bool:
+        return self.set_draft(self.original)
+
+    def diff(self) -> str:
+        """Unified diff of the draft against the template's instructions."""
+        label = str(self.source.path)
+        return "".join(
+            difflib.unified_diff(
+                f"{self.original}\n".splitlines(keepends=True),
+                f"{self.draft}\n".splitlines(keepends=True),
+                fromfile=f"{label} (template)",
+                tofile=f"{label} (draft r{self.revision})",
+            )
+        )
+
+    # ------------------------------------------------------------------
+    # Turns
+    # ------------------------------------------------------------------
+
+    async def ask(self, prompt: str) -> PlaygroundTurn:
+        """Run one single-turn prompt against the current draft."""
+        config = AgentConfig(
+            system_prompt=self.effective_prompt(), model=self.model, max_turns=1
+        )
+        start = time.monotonic()
+        try:
+            result = await self.responder.run(prompt, config)
+            reply, is_error = result.text, result.is_error
+            duration = result.duration_ms
+        except Exception as e:
+            reply, is_error, duration = f"{type(e).__name__}: {e}", True, None
+        if duration is None:
+            duration = int((time.monotonic() - start) * 1000)
+
+        turn = PlaygroundTurn(self.revision, prompt, reply, is_error, duration)
+        self.history.append(turn)
+        return turn
+
+    def compare(self, prompt: str | None = None) -> list[PlaygroundTurn]:
+        """Latest reply to ``prompt`` (default: last prompt) for each revision."""
+        if prompt is None:
+            if not self.history:
+                return []
+            prompt = self.history[-1].prompt
+        latest: dict[int, PlaygroundTurn] = {}
+        for turn in self.history:
+            if turn.prompt == prompt:
+                latest[turn.revision] = turn
+        return [latest[rev] for rev in sorted(latest)]
+
+    # ------------------------------------------------------------------
+    # Accepting
+    # ------------------------------------------------------------------
+
+    def target_path(self, project_dir: Path | None = None) -> Path:
+        """Where an accepted draft is written."""
+        if self.source.kind == PROJECT_TEMPLATE:
+            return self.source.path
+        base = project_dir or Path.cwd()
+        return base / ".claude-mpm" / "agents" / f"{self.source.name}.md"
+
+    def accept(self, project_dir: Path | None = None) -> Path:
+        """Write the draft to the project template and make it the new baseline."""
+        target = self.target_path(project_dir)
+        target.parent.mkdir(parents=True, exist_ok=True)
+        content = f"{self.source.frontmatter}\n{self.draft}\n"
+        target.write_text(content, encoding="utf-8")
+
+        self.source = AgentSource.load(self.source.name, target, PROJECT_TEMPLATE)
+        self.original = self.source.body
+        self._suffix = None
+        self.draft = self.original
+        logger.info(f"Saved playground draft for {self.source.name} to {target}")
+        return target
+
+    def summary(self) -> dict[str, Any]:
+        return {
+            "agent": self.source.name,
+            "source": str(self.source.path),
+            "kind": self.source.kind,
+            "revision": self.revision,
+            "dirty": self.dirty,
+            "turns": len(self.history),
+        }
+
+
+__all__ = [
+    "AgentSource",
+    "PlaygroundTurn",
+    "PromptPlayground",
+    "ReplayResponder",
+    "deployment_suffix",
+    "effective_prompt",
+    "locate_agent_source",
+    "split_frontmatter",
+]
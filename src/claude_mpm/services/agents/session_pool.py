@@ -0,0 +1,320 @@
+"""
+Warm agent session pool.
+
+WHAT: Keeps a small number of SDK sessions open and ready: the Claude Code
+process is started, MCP servers are connected and, with a warm-up prompt,
+the repository context has already been read. A delegated task takes one of
+these sessions instead of starting from nothing, and a replacement is warmed
+in the background.
+
+WHY: A cold session takes tens of seconds before it does any work. For
+high-frequency small tasks (a lint fix, a one-file review) that start-up
+dominates the total time.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    session_pool:
+      enabled: false          # work-queue workers use the pool when true
+      size: 2                 # sessions kept warm
+      max_uses: 1             # tasks per session before it is replaced
+      max_idle_seconds: 900   # idle sessions older than this are replaced
+      warmup_prompt: null     # default: read CLAUDE.md and the layout
+
+DESIGN DECISIONS:
+- Sessions are ``InterruptibleSession`` objects from sdk_runtime, opened
+  ahead of time; the pool adds no second way of talking to the SDK
+- ``max_uses`` defaults to 1 so a task never sees the conversation of the
+  one before it; raising it trades that isolation for skipping the warm-up
+- A pool serves one (model, cwd, options) combination; callers that need
+  different options keep one pool per combination
+- An empty pool never blocks: the caller gets a cold session (counted as a
+  miss) rather than waiting on a warm-up already in flight
+"""
+
+from __future__ import annotations
+
+import asyncio
+import threading
+import time
+from dataclasses import asdict, dataclass
+from pathlib import Path
+from typing import TYPE_CHECKING, Any
+
+from ...core.logging_config import get_logger
+from .agent_runtime import AgentResult
+
+if TYPE_CHECKING:
+    from ..work_queue import QueueTask
+    from .sdk_runtime import InterruptibleSession, SDKAgentRunner
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "session_pool"
+DEFAULT_WARMUP_PROMPT = (
+    "You will be given a series of small tasks in this repository. Before "
+    "they arrive, read CLAUDE.md (if present) and list the top-level layout "
+    "so you know where things are. Do not change any files. Reply with READY."
+)
+
+
+@dataclass
+class SessionPoolConfig:
+    """How many sessions to keep warm and when to replace them."""
+
+    enabled: bool = False
+    size: int = 2
+    max_uses: int = 1
+    max_idle_seconds: float = 900
+    warmup_prompt: str | None = DEFAULT_WARMUP_PROMPT
+
+    @classmethod
+    def load(cls, config: Any = None) -> SessionPoolConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ...core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        defaults = cls()
+        return cls(
+            enabled=bool(section.get("enabled", defaults.enabled)),
+            size=int(section.get("size", defaults.size)),
+            max_uses=max(1, int(section.get("max_uses", defaults.max_uses))),
+            max_idle_seconds=float(
+                section.get("max_idle_seconds", defaults.max_idle_seconds)
+            ),
+            warmup_prompt=section.get("warmup_prompt") or defaults.warmup_prompt,
+        )
+
+
+@dataclass
+class PoolStats:
+    """Counters for judging whether the pool is sized right."""
+
+    hits: int = 0  # tasks that got a warm session
+    misses: int = 0  # tasks that had to open a cold one
+    opened: int = 0
+    retired: int = 0
+    warmup_failures: int = 0
+
+
+class PooledSession:
+    """An open session plus the bookkeeping the pool needs."""
+
+    def __init__(self, session: InterruptibleSession, now: float):
+        self.session = session
+        self.opened_at = now
+        self.last_used = now
+        self.uses = 0
+
+    async def close(self) -> None:
+        try:
+            await self.session.__aexit__(None, None, None)
+        except Exception as e:
+            logger.debug(f"Error closing pooled session: {e}")
+
+
+class WarmSessionPool:
+    """Keeps ``config.size`` sessions of one runner open and warmed up.
+
+    Usage::
+
+        pool = WarmSessionPool(SDKAgentRunner(cwd="/repo"), config)
+        await pool.start()
+        result = await pool.run("Fix the typo in README.md")
+        await pool.close()
+    """
+
+    def __init__(
+        self,
+        runner: SDKAgentRunner,
+        config: SessionPoolConfig | None = None,
+        clock=time.monotonic,
+    ):
+        self.runner = runner
+        self.config = config or SessionPoolConfig()
+        self.stats = PoolStats()
+        self._clock = clock
+        self._idle: list[PooledSession] = []
+        self._opening = 0
+        self._refill: asyncio.Task | None = None
+        self._closed = False
+
+    @property
+    def idle(self) -> int:
+        return len(self._idle)
+
+    async def start(self) -> None:
+        """Open and warm the initial sessions."""
+        await self._fill()
+
+    async def acquire(self) -> PooledSession:
+        """Take a warm session, or open a cold one when none is ready."""
+        if self._closed:
+            raise RuntimeError("Session pool is closed")
+        await self._retire_stale()
+        if self._idle:
+            pooled = self._idle.pop(0)
+            self.stats.hits += 1
+        else:
+            self.stats.misses += 1
+            pooled = await self._open(warm=False)
+        self._schedule_refill()
+        return pooled
+
+    async def release(self, pooled: PooledSession, healthy: bool = True) -> None:
+        """Return *pooled* to the pool, or close it if it is used up."""
+        pooled.uses += 1
+        pooled.last_used = self._clock()
+        if self._closed or not healthy or pooled.uses >= self.config.max_uses:
+            await self._retire(pooled)
+        else:
+            self._idle.append(pooled)
+        self._schedule_refill()
+
+    async def run(self, prompt: str, timeout: float | None = None) -> AgentResult:
+        """Run *prompt* on a pooled session and return its result."""
+        pooled = await self.acquire()
+        try:
+            result = await asyncio.wait_for(pooled.session.query(prompt), timeout)
+        except asyncio.TimeoutError:
+            await pooled.session.interrupt()
+            await self.release(pooled, healthy=False)
+            return AgentResult(text=f"Timed out after {timeout:.0f}s", is_error=True)
+        except BaseException:
+            await self.release(pooled, healthy=False)
+            raise
+        await self.release(pooled, healthy=not result.is_error)
+        return result
+
+    async def close(self) -> None:
+        """Close every idle session; sessions in use close on release."""
+        self._closed = True
+        if self._refill is not None:
+            self._refill.cancel()
+            await asyncio.gather(self._refill, return_exceptions=True)
+        idle, self._idle = self._idle, []
+        for pooled in idle:
+            await self._retire(pooled)
+
+    def status(self) -> dict[str, Any]:
+        return {
+            "size": self.config.size,
+            "idle": len(self._idle),
+            "opening": self._opening,
+            **asdict(self.stats),
+        }
+
+    # ------------------------------------------------------------------
+    # Opening, warming and retiring sessions
+    # ------------------------------------------------------------------
+
+    async def _open(self, warm: bool = True) -> PooledSession:
+        session = self.runner.interruptible()
+        await session.__aenter__()
+        pooled = PooledSession(session, self._clock())
+        self.stats.opened += 1
+        if warm and self.config.warmup_prompt:
+            try:
+                result = await session.query(self.config.warmup_prompt)
+            except Exception:
+                await pooled.close()
+                raise
+            if result.is_error:
+                await pooled.close()
+                raise RuntimeError(f"Warm-up failed: {result.text[:200]}")
+        return pooled
+
+    async def _fill(self) -> None:
+        missing = self.config.size - len(self._idle) - self._opening
+        if missing <= 0 or self._closed:
+            return
+        self._opening += missing
+        try:
+            opened = await asyncio.gather(
+                *(self._open() for _ in range(missing)), return_exceptions=True
+            )
+        finally:
+            self._opening -= missing
+        for pooled in opened:
+            if isinstance(pooled, BaseException):
+                self.stats.warmup_failures += 1
+                logger.warning(f"Could not warm a pooled session: {pooled}")
+            elif self._closed:
+                await pooled.close()
+            else:
+                self._idle.append(pooled)
+
+    def _schedule_refill(self) -> None:
+        if self._closed or (self._refill is not None and not self._refill.done()):
+            return
+        if len(self._idle) + self._opening < self.config.size:
+            self._refill = asyncio.get_running_loop().create_task(self._fill())
+
+    async def _retire_stale(self) -> None:
+        cutoff = self._clock() - self.config.max_idle_seconds
+        stale = [p for p in self._idle if p.last_used < cutoff]
+        for pooled in stale:
+            self._idle.remove(pooled)
+            await self._retire(pooled)
+
+    async def _retire(self, pooled: PooledSession) -> None:
+        self.stats.retired += 1
+        await pooled.close()
+
+
+class PooledTaskRunner:
+    """Runs work-queue tasks on a warm pool from a synchronous worker.
+
+    The pool lives on its own event loop thread. Tasks that need something
+    the pooled sessions were not opened with (another directory, a cloned
+    repo, a model or turn limit) go to *fallback* instead.
+    """
+
+    def __init__(
+        self,
+        cwd: Path,
+        config: SessionPoolConfig,
+        fallback,
+        runner: SDKAgentRunner | None = None,
+    ):
+        self.cwd = Path(cwd).resolve()
+        self.fallback = fallback
+        if runner is None:
+            from ...core.constants import skip_permissions_disabled
+            from .sdk_runtime import SDKAgentRunner
+
+            runner = SDKAgentRunner(
+                cwd=str(self.cwd),
+                permission_mode=(
+                    None if skip_permissions_disabled() else "bypassPermissions"
+                ),
+            )
+        self._loop = asyncio.new_event_loop()
+        self._thread = threading.Thread(target=self._loop.run_forever, daemon=True)
+        self._thread.start()
+        self.pool = WarmSessionPool(runner, config)
+        self._call(self.pool.start())
+
+    def __call__(self, task: QueueTask, cwd: Path, timeout: float) -> AgentResult:
+        if (
+            task.repo
+            or task.model
+            or task.max_turns
+            or Path(cwd).resolve() != self.cwd
+        ):
+            return self.fallback(task, cwd, timeout)
+        return self._call(self.pool.run(task.prompt, timeout=timeout))
+
+    def close(self) -> None:
+        try:
+            self._call(self.pool.close())
+        finally:
+            self._loop.call_soon_threadsafe(self._loop.stop)
+            self._thread.join(timeout=5)
+
+    def _call(self, coro):
+        return asyncio.run_coroutine_threadsafe(coro, self._loop).result()
@@ -19,6 +19,7 @@ from typing import Any
 import requests
 
 from claude_mpm.core.file_utils import get_file_hash
+from claude_mpm.services import http_client
 from claude_mpm.services.agents.compatibility import (
     CompatibilityResult,
     ManifestChecker,
@@ -34,8 +35,10 @@ from claude_mpm.services.agents.deployment.multi_source_deployment_service impor
 from claude_mpm.services.agents.deployment_utils import (
     deploy_agent_file,
     normalize_deployment_filename,
+    render_agent_file,
 )
 from claude_mpm.services.agents.sources.agent_sync_state import AgentSyncState
+from claude_mpm.utils.bulk_operations import FAILED, BulkItem, run_bulk
 from claude_mpm.utils.progress import create_progress_bar
 
 logger = logging.getLogger(__name__)
@@ -214,6 +217,7 @@ class GitSourceSyncService:
         source_url: str = "https://raw.githubusercontent.com/bobmatnyc/claude-mpm-agents/main/agents",
         cache_dir: Path | None = None,
         source_id: str = "github-remote",
+        token: str | None = None,
     ):
         """Initialize Git source sync service.
 
@@ -221,6 +225,8 @@ class GitSourceSyncService:
             source_url: Base URL for raw files (without trailing slash)
             cache_dir: Local cache directory (defaults to ~/.claude-mpm/cache/agents/)
             source_id: Unique identifier for this source (for multi-source support)
+            token: GitHub token of this source; defaults to GITHUB_TOKEN or
+                GH_TOKEN
 
         Design Decision: Cache to ~/.claude-mpm/cache/agents/ (canonical location)
 
@@ -249,12 +255,15 @@ class GitSourceSyncService:
         self.cache_dir.mkdir(parents=True, exist_ok=True)
 
         # Setup HTTP session with connection pooling
-        self.session = requests.Session()
+        # Through the configured proxy and CA bundle (network settings)
+        self.session = http_client.session()
         self.session.headers["Accept"] = "text/plain"
         # Inject GitHub token for private repo access
-        _token = os.environ.get("GITHUB_TOKEN") or os.environ.get("GH_TOKEN")
-        if _token:
-            self.session.headers["Authorization"] = f"token {_token}"
+        self._token = (
+            token or os.environ.get("GITHUB_TOKEN") or os.environ.get("GH_TOKEN")
+        )
+        if self._token:
+            self.session.headers["Authorization"] = f"token {self._token}"
 
         # Initialize SQLite state tracking (NEW)
         self.sync_state = AgentSyncState()
@@ -956,7 +965,7 @@ class GitSourceSyncService:
         logger.debug(f"Fetching commit SHA from {refs_url}")
 
         api_headers: dict[str, str] = {"Accept": "application/vnd.github+json"}
-        _token = os.environ.get("GITHUB_TOKEN") or os.environ.get("GH_TOKEN")
+        _token = self._token
         if _token:
             api_headers["Authorization"] = f"token {_token}"
 
@@ -1159,6 +1168,8 @@ class GitSourceSyncService:
         project_dir: Path,
         agent_list: list[str] | None = None,
         force: bool = False,
+        max_workers: int = 1,
+        progress_callback=None,
     ) -> dict[str, Any]:
         """Deploy agents from cache to project directory (Phase 1 deployment).
 
@@ -1184,6 +1195,8 @@ class GitSourceSyncService:
             project_dir: Project root directory (e.g., /path/to/project)
             agent_list: Optional list of agent paths to deploy (uses all if None)
             force: Force redeployment even if up-to-date
+            max_workers: Agents to deploy in parallel
+            progress_callback: Optional callback(completed: int) per agent
 
         Returns:
             Dictionary with deployment results:
@@ -1192,6 +1205,8 @@ class GitSourceSyncService:
                 "updated": ["research.md"],       # Updated existing
                 "skipped": ["qa.md"],             # Already up-to-date
                 "failed": ["broken.md"],          # Copy failures
+                "conflicts": ["ops.md"],          # Merge conflict, file left as is
+                "errors": {"broken.md": "..."},   # Why each one failed
                 "deployment_dir": "/path/.claude-mpm/agents"
             }
 
@@ -1207,13 +1222,6 @@ class GitSourceSyncService:
             >>> print(f"Deployed {len(result['deployed'])} agents")
         """
 
-        from claude_mpm.core.config import Config
-        from claude_mpm.utils.agent_filters import (
-            is_local_only,
-            load_local_only_agents,
-            warn_missing_local_only_agents,
-        )
-
         # Deploy to .claude/agents/ where Claude Code expects them
         deployment_dir = project_dir / ".claude" / "agents"
         deployment_dir.mkdir(parents=True, exist_ok=True)
@@ -1226,6 +1234,100 @@ class GitSourceSyncService:
             "deployment_dir": str(deployment_dir),
         }
 
+        agent_list, project_config, excluded_set, local_only_list = (
+            self._select_project_agents(project_dir, agent_list)
+        )
+
+        # Clean up any previously deployed excluded agents
+        if excluded_set:
+            cleanup_results = self._cleanup_excluded_agents(
+                deployment_dir, excluded_set, local_only_list=local_only_list
+            )
+            if cleanup_results["removed"]:
+                logger.info(
+                    f"Cleaned up {len(cleanup_results['removed'])} excluded agents: "
+                    f"{', '.join(cleanup_results['removed'])}"
+                )
+
+        logger.info(
+            f"Deploying {len(agent_list)} agents from cache to {deployment_dir}"
+        )
+
+        def deploy_one(agent_path: str) -> BulkItem:
+            # Resolve normalized agent path to actual cache file
+            cache_file = self._resolve_cache_path(agent_path)
+
+            if not cache_file or not cache_file.exists():
+                logger.warning(f"Agent not found in cache: {agent_path}")
+                return BulkItem(agent_path, FAILED, "not found in cache")
+
+            # Phase 3 Fix (Issue #299): Use unified deploy_agent_file() function
+            # This ensures identical behavior between GitSourceSyncService
+            # and SingleTierDeploymentService.
+            # Pass project_config so deploy_agent_file can inject the SLD
+            # instruction block when workflow.spec_linked_docs.enabled is True
+            # (Bug 1 fix: cache-copy path previously bypassed SLD injection).
+            result = deploy_agent_file(
+                source_file=cache_file,
+                deployment_dir=deployment_dir,
+                cleanup_legacy=True,
+                ensure_frontmatter=True,
+                force=force,
+                config=project_config,
+            )
+
+            # Get normalized filename for tracking
+            deploy_filename = normalize_deployment_filename(Path(agent_path).name)
+
+            if not result.success:
+                logger.error(f"Failed to deploy: {deploy_filename}: {result.error}")
+                return BulkItem(deploy_filename, FAILED, result.error or "")
+            if result.action == "merged":
+                return BulkItem(deploy_filename, "updated", "merged with local edits")
+            return BulkItem(deploy_filename, result.action)
+
+        report = run_bulk(
+            agent_list,
+            deploy_one,
+            operation_name="Deploy agents",
+            name=lambda agent_path: Path(agent_path).name,
+            max_workers=max_workers,
+            progress_callback=progress_callback,
+        )
+        for status in ("deployed", "updated", "skipped", FAILED):
+            results[status] = report.names(status)
+        results["conflicts"] = report.names("conflict")
+        results["errors"] = {item.name: item.detail for item in report.failed}
+
+        # Log summary
+        total_success = len(results["deployed"]) + len(results["updated"])
+        logger.info(
+            f"Deployment complete: {total_success} deployed/updated, "
+            f"{len(results['skipped'])} skipped, {len(results['failed'])} failed"
+        )
+
+        return results
+
+    def _select_project_agents(
+        self, project_dir: Path, agent_list: list[str] | None
+    ) -> tuple[list[str], Any, set[str], list[str]]:
+        """Cached agents to deploy to *project_dir*, without touching it.
+
+        Applies the project's ``excluded_agents`` and ``agents.local_only``
+        settings to *agent_list* (all cached agents if None).
+
+        Returns:
+            The agent paths to deploy, the project Config (None without a
+            project configuration), the normalized excluded names and the
+            local_only entries
+        """
+        from claude_mpm.core.config import Config
+        from claude_mpm.utils.agent_filters import (
+            is_local_only,
+            load_local_only_agents,
+            warn_missing_local_only_agents,
+        )
+
         # Load project config to get exclusion list (and SLD flag for injection).
         config_file = project_dir / ".claude-mpm" / "configuration.yaml"
         if config_file.exists():
@@ -1292,71 +1394,51 @@ class GitSourceSyncService:
                     ", ".join(sorted(protected)),
                 )
 
-        # Clean up any previously deployed excluded agents
-        if excluded_set:
-            cleanup_results = self._cleanup_excluded_agents(
-                deployment_dir, excluded_set, local_only_list=local_only_list
-            )
-            if cleanup_results["removed"]:
-                logger.info(
-                    f"Cleaned up {len(cleanup_results['removed'])} excluded agents: "
-                    f"{', '.join(cleanup_results['removed'])}"
-                )
+        return agent_list, project_config, excluded_set, local_only_list
 
-        logger.info(
-            f"Deploying {len(agent_list)} agents from cache to {deployment_dir}"
-        )
+    def render_agents_for_project(
+        self, project_dir: Path, agent_list: list[str] | None = None
+    ) -> dict[str, Any]:
+        """Render the agents ``deploy_agents_to_project`` would deploy.
+
+        Nothing is written: the content each agent would get (base and
+        mixins, overrides, permissions and SLD block applied) is returned
+        instead, so template bugs show up before they replace live agents.
 
+        Returns:
+            Dictionary with:
+            {
+                "rendered": [RenderedAgent, ...],  # By deployed filename
+                "failed": ["broken.md"],
+                "errors": {"broken.md": "..."},
+                "deployment_dir": "/path/.claude/agents"
+            }
+        """
+        deployment_dir = project_dir / ".claude" / "agents"
+        agent_list, project_config, _, _ = self._select_project_agents(
+            project_dir, agent_list
+        )
+        results: dict[str, Any] = {
+            "rendered": [],
+            "failed": [],
+            "errors": {},
+            "deployment_dir": str(deployment_dir),
+        }
         for agent_path in agent_list:
+            name = normalize_deployment_filename(Path(agent_path).name)
+            cache_file = self._resolve_cache_path(agent_path)
             try:
-                # Resolve normalized agent path to actual cache file
-                cache_file = self._resolve_cache_path(agent_path)
-
                 if not cache_file or not cache_file.exists():
-                    logger.warning(f"Agent not found in cache: {agent_path}")
-                    results["failed"].append(agent_path)
-                    continue
-
-                # Phase 3 Fix (Issue #299): Use unified deploy_agent_file() function
-                # This ensures identical behavior between GitSourceSyncService
-                # and SingleTierDeploymentService.
-                # Pass project_config so deploy_agent_file can inject the SLD
-                # instruction block when workflow.spec_linked_docs.enabled is True
-                # (Bug 1 fix: cache-copy path previously bypassed SLD injection).
-                result = deploy_agent_file(
-                    source_file=cache_file,
-                    deployment_dir=deployment_dir,
-                    cleanup_legacy=True,
-                    ensure_frontmatter=True,
-                    force=force,
-                    config=project_config,
+                    raise FileNotFoundError("not found in cache")
+                results["rendered"].append(
+                    render_agent_file(
+                        cache_file, deployment_dir, config=project_config
+                    )
                 )
-
-                # Get normalized filename for tracking
-                deploy_filename = normalize_deployment_filename(Path(agent_path).name)
-
-                if result.success:
-                    if result.action == "deployed":
-                        results["deployed"].append(deploy_filename)
-                    elif result.action == "updated":
-                        results["updated"].append(deploy_filename)
-                    elif result.action == "skipped":
-                        results["skipped"].append(deploy_filename)
-                else:
-                    results["failed"].append(deploy_filename)
-                    logger.error(f"Failed to deploy: {deploy_filename}: {result.error}")
-
-            except Exception as e:
-                logger.error(f"Unexpected error deploying {agent_path}: {e}")
-                results["failed"].append(Path(agent_path).name)
-
-        # Log summary
-        total_success = len(results["deployed"]) + len(results["updated"])
-        logger.info(
-            f"Deployment complete: {total_success} deployed/updated, "
-            f"{len(results['skipped'])} skipped, {len(results['failed'])} failed"
-        )
-
+            except (OSError, ValueError) as e:
+                results["failed"].append(name)
+                results["errors"][name] = str(e)
+        results["rendered"].sort(key=lambda rendered: rendered.filename)
         return results
 
     def _resolve_cache_path(self, agent_path: str) -> Path | None:
@@ -0,0 +1,197 @@
+"""Agent sources on GitLab, Bitbucket or behind an SSH URL.
+
+WHAT: Syncs a GitRepository the raw.githubusercontent.com path of
+GitSourceSyncService cannot serve: an SSH URL is shallow-cloned with the
+repository's deploy key, a GitLab or Bitbucket repository is downloaded as one
+archive of its branch head. The agent files under the repository's
+subdirectory then replace the cache at ``repo.cache_path``, so discovery,
+deployment and agents.lock treat them like any other source.
+
+WHY: Agent sources were GitHub-only and had no per-source credentials, so
+teams with agents in a private GitLab, Bitbucket or deploy-key repository
+copied them into each machine's templates directory by hand. Skill sources
+already handled all three.
+
+DESIGN DECISIONS:
+- Authentication, URL handling and access checks are the skill sources'
+  (services/skills/git_hosts.py and git_skill_source_manager), which only
+  read the url, branch, token and ssh_key a GitRepository has too
+- The commit a cache was synced to is recorded next to it; a sync whose
+  branch head has not moved is a single request and leaves the cache alone
+  unless forced
+- Agent files are picked as the GitHub Tree API sync picks them: .md and
+  .json files, except README.md, hidden files and dist/build/.cache output
+"""
+
+from __future__ import annotations
+
+import shutil
+import tarfile
+import tempfile
+from pathlib import Path, PurePosixPath
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.models.git_repository import GitRepository
+from claude_mpm.services.deployment_delta import sync_directory
+from claude_mpm.services.skills.git_hosts import archive_member_path, get_host
+from claude_mpm.services.skills.git_skill_source_manager import (
+    _git_ssh_env,
+    _run_git,
+    check_ssh_source_access,
+)
+
+logger = get_logger(__name__)
+
+_EXCLUDED_DIRS = ("dist", "build", ".cache")
+
+
+def is_hosted(repo: GitRepository) -> bool:
+    """Whether *repo* is synced here rather than through raw GitHub URLs."""
+    return repo.is_ssh or repo.hosting not in (None, "github")
+
+
+def is_agent_file(path: str) -> bool:
+    """Whether *path*, relative to the agents subdirectory, is synced."""
+    parts = PurePosixPath(path).parts
+    if not parts or any(p.startswith(".") or p in _EXCLUDED_DIRS for p in parts):
+        return False
+    return path.endswith((".md", ".json")) and path != "README.md"
+
+
+def commit_marker(repo: GitRepository) -> Path:
+    """File recording the commit *repo*'s cache was synced to."""
+    cache_path = repo.cache_path
+    return cache_path.parent / f".{cache_path.name}.commit"
+
+
+def check_access(repo: GitRepository) -> str | None:
+    """Check the repository can be read; return an error or None."""
+    if repo.is_ssh:
+        return check_ssh_source_access(repo)
+    return get_host(repo).check_access()
+
+
+def resolve_commit(repo: GitRepository) -> str:
+    """The commit at the head of *repo*'s branch.
+
+    Raises:
+        RuntimeError: If the branch cannot be read
+    """
+    import requests
+
+    if repo.is_ssh:
+        error = check_ssh_source_access(repo)
+        if error:
+            raise RuntimeError(error)
+        heads = _run_git(
+            ["ls-remote", "--heads", repo.url, repo.branch],
+            _git_ssh_env(repo),
+            timeout=30,
+        )
+        return heads.split()[0]
+    try:
+        return get_host(repo).resolve_branch(repo.branch)
+    except (requests.RequestException, KeyError, ValueError) as e:
+        raise RuntimeError(f"Could not resolve {repo.url}@{repo.branch}: {e}") from e
+
+
+def _subpath(repo: GitRepository, path: str) -> str | None:
+    """*path* relative to the repository's agents subdirectory, if under it."""
+    prefix = (repo.subdirectory or "").strip("/")
+    if not prefix:
+        return path
+    if not path.startswith(f"{prefix}/"):
+        return None
+    return path[len(prefix) + 1 :]
+
+
+def _clone_tree(repo: GitRepository, commit: str, staging: Path, tree: Path) -> None:
+    env = _git_ssh_env(repo)
+    clone = staging / "repo"
+    _run_git(
+        [
+            "clone",
+            "--depth",
+            "1",
+            "--single-branch",
+            "--branch",
+            repo.branch,
+            repo.url,
+            str(clone),
+        ],
+        env,
+    )
+    head = _run_git(["rev-parse", "HEAD"], env, clone).strip()
+    if head != commit:
+        _run_git(["fetch", "--depth", "1", "origin", commit], env, clone)
+        _run_git(["reset", "--hard", "FETCH_HEAD"], env, clone)
+    for path in _run_git(["ls-files"], env, clone).splitlines():
+        relative = _subpath(repo, path)
+        if relative is not None and is_agent_file(relative):
+            target = tree / relative
+            target.parent.mkdir(parents=True, exist_ok=True)
+            shutil.copyfile(clone / path, target)
+
+
+def _archive_tree(repo: GitRepository, commit: str, staging: Path, tree: Path) -> None:
+    archive = staging / "archive.tar.gz"
+    get_host(repo).download_archive(commit, archive)
+    with tarfile.open(archive, "r:gz") as tar:
+        for member in tar:
+            path = archive_member_path(member.name)
+            if not member.isfile() or path is None:
+                continue
+            relative = _subpath(repo, path)
+            if relative is None or not is_agent_file(relative):
+                continue
+            target = tree / relative
+            target.parent.mkdir(parents=True, exist_ok=True)
+            with tar.extractfile(member) as data:
+                target.write_bytes(data.read())
+
+
+def sync_hosted_repository(
+    repo: GitRepository, force: bool = False
+) -> tuple[int, int, str]:
+    """Sync *repo*'s agent files into its cache.
+
+    Returns:
+        Tuple of (files_updated, files_cached, commit)
+
+    Raises:
+        RuntimeError: If git or the hosting service fails
+        requests.RequestException: If the archive cannot be downloaded
+    """
+    commit = resolve_commit(repo)
+    cache_path = repo.cache_path
+    marker = commit_marker(repo)
+    if (
+        not force
+        and marker.is_file()
+        and marker.read_text(encoding="utf-8").strip() == commit
+        and cache_path.is_dir()
+    ):
+        cached = sum(1 for p in cache_path.rglob("*") if p.is_file())
+        logger.info(f"{repo.identifier} is already at {commit[:8]}")
+        return 0, cached, commit
+
+    cache_path.parent.mkdir(parents=True, exist_ok=True)
+    staging = Path(tempfile.mkdtemp(prefix=".agents-", dir=cache_path.parent))
+    try:
+        tree = staging / "tree"
+        tree.mkdir()
+        if repo.is_ssh:
+            _clone_tree(repo, commit, staging, tree)
+        else:
+            _archive_tree(repo, commit, staging, tree)
+        delta = sync_directory(tree, cache_path)
+    finally:
+        shutil.rmtree(staging, ignore_errors=True)
+
+    marker.write_text(f"{commit}\n", encoding="utf-8")
+    files_updated = len(delta.added) + len(delta.changed)
+    logger.info(
+        f"Synced {repo.identifier} at {commit[:8]}: {files_updated} updated, "
+        f"{len(delta.removed)} removed"
+    )
+    return files_updated, len(delta.unchanged), commit
@@ -0,0 +1,296 @@
+"""Least-privilege tool permissions declared by an agent.
+
+WHAT: An agent's frontmatter (or the ``permissions`` field of a JSON
+template) can narrow what the agent may do beyond its tool list::
+
+    permissions:
+      tools: [Read, Grep, Glob, Edit, Bash]   # the only tools it may use
+      bash: ["pytest*", "git diff*", "git status"]
+      write_paths: ["tests/**", "docs/**"]
+      network: false
+
+- ``bash``: glob patterns every Bash command must match. Each command of a
+  compound command (``&&``, ``||``, ``;``, ``|``) is checked on its own;
+  ``[]`` allows no Bash at all
+- ``write_paths``: globs, relative to the project root, that Write, Edit,
+  MultiEdit and NotebookEdit may touch; ``[]`` makes the agent read-only
+- ``network: false`` denies WebFetch, WebSearch and Bash commands that reach
+  the network (curl, ssh, ``git push``, package installs, ...)
+
+A key that is left out does not restrict anything.
+
+The permissions are enforced twice:
+
+- At deploy time the block is validated, so an invalid block fails the
+  agent's deployment, and the deployed ``tools`` list is narrowed to what
+  the permissions allow, so Claude Code never offers the agent the rest
+- At runtime the PreToolUse dispatcher checks each call of a subagent
+  against the permissions of its deployed agent file (see
+  hooks/tool_permissions_hook.py)
+
+WHY: Compliance-sensitive repositories need least-privilege agents, and a
+tool list alone cannot say which commands an agent may run or which files
+it may change.
+
+DESIGN DECISIONS:
+- Runtime checks read the deployed agent, so user and project overrides of
+  the permissions (see agent_overrides) are what gets enforced
+- The main session is not an agent and is never restricted
+- Command substitution (``$(...)`` and backticks) never matches a Bash
+  allowlist, since the command inside it would go unchecked
+- An agent whose deployed permissions block is invalid is denied every
+  tool rather than run unrestricted
+"""
+
+from __future__ import annotations
+
+import fnmatch
+import re
+import shlex
+from dataclasses import dataclass
+from pathlib import Path, PurePosixPath
+from typing import Any
+
+import yaml
+
+from claude_mpm.services.agents.agent_overrides import parse_agent
+from claude_mpm.services.workspace_trust import NETWORK_TOOLS, SHELL_TOOLS
+
+PERMISSIONS_KEY = "permissions"
+WRITE_TOOLS = ("Write", "Edit", "MultiEdit", "NotebookEdit")
+
+# Commands that always reach the network, and those that do for some
+# subcommands (None: every subcommand)
+NETWORK_COMMANDS = frozenset(
+    {"curl", "wget", "ssh", "scp", "sftp", "rsync", "nc", "ncat", "telnet", "ftp"}
+)
+NETWORK_SUBCOMMANDS: dict[str, frozenset[str] | None] = {
+    "git": frozenset({"push", "pull", "fetch", "clone", "ls-remote", "submodule"}),
+    "gh": None,
+    "pip": frozenset({"install", "download"}),
+    "uv": frozenset({"pip", "add", "sync", "lock"}),
+    "npm": frozenset({"install", "i", "ci", "add", "publish"}),
+    "yarn": frozenset({"install", "add", "publish"}),
+    "pnpm": frozenset({"install", "i", "add", "publish"}),
+    "docker": frozenset({"pull", "push", "login"}),
+}
+
+_KEYS = ("tools", "bash", "write_paths", "network")
+# &&, ||, ;, |, newlines and a lone & (but not the & of 2>&1 or &>)
+_SEPARATOR_RE = re.compile(r"&&|\|\||[;|\n]|(?<![<>&])&(?!>)")
+_SUBSTITUTION_RE = re.compile(r"\$\(|`")
+_ASSIGNMENT_RE = re.compile(r"^[A-Za-z_][A-Za-z0-9_]*=")
+
+
+def parse_tools(value: Any) -> list[str] | None:
+    """A frontmatter tool list, given as a list or a comma-separated string."""
+    if value is None:
+        return None
+    if isinstance(value, str):
+        return [tool.strip() for tool in value.split(",") if tool.strip()]
+    if isinstance(value, list):
+        return [str(tool).strip() for tool in value if str(tool).strip()]
+    raise ValueError(f"tools must be a list of tool names, got {value!r}")
+
+
+def split_commands(command: str) -> list[str]:
+    """The simple commands of a compound shell command."""
+    return [part.strip() for part in _SEPARATOR_RE.split(command) if part.strip()]
+
+
+def _words(command: str) -> list[str]:
+    try:
+        words = shlex.split(command)
+    except ValueError:
+        words = command.split()
+    while words and _ASSIGNMENT_RE.match(words[0]):
+        words = words[1:]
+    return words
+
+
+def is_network_command(command: str) -> bool:
+    """Whether a simple shell command reaches the network."""
+    words = _words(command)
+    if not words:
+        return False
+    program = Path(words[0]).name
+    if program in NETWORK_COMMANDS:
+        return True
+    if program not in NETWORK_SUBCOMMANDS:
+        return False
+    subcommands = NETWORK_SUBCOMMANDS[program]
+    if subcommands is None:
+        return True
+    return any(word in subcommands for word in words[1:] if not word.startswith("-"))
+
+
+def _string_list(permissions: dict[str, Any], key: str) -> list[str] | None:
+    value = permissions.get(key)
+    if value is None:
+        return None
+    if not isinstance(value, list) or not all(isinstance(v, str) for v in value):
+        raise ValueError(f"permissions.{key} must be a list of strings")
+    return list(value)
+
+
+@dataclass
+class ToolPermissions:
+    """What an agent may do; ``None`` fields do not restrict."""
+
+    tools: list[str] | None = None
+    bash: list[str] | None = None
+    write_paths: list[str] | None = None
+    network: bool = True
+
+    @classmethod
+    def from_frontmatter(cls, frontmatter: dict[str, Any]) -> ToolPermissions | None:
+        """The permissions an agent declares, or None if it declares none.
+
+        Raises:
+            ValueError: If the permissions block is invalid.
+        """
+        permissions = frontmatter.get(PERMISSIONS_KEY)
+        if permissions is None:
+            return None
+        if not isinstance(permissions, dict):
+            raise ValueError("permissions must be a mapping")
+        unknown = sorted(set(permissions) - set(_KEYS))
+        if unknown:
+            raise ValueError(
+                f"Unknown permissions key(s) {', '.join(unknown)}; "
+                f"expected {', '.join(_KEYS)}"
+            )
+        network = permissions.get("network", True)
+        if not isinstance(network, bool):
+            raise ValueError("permissions.network must be true or false")
+        write_paths = _string_list(permissions, "write_paths")
+        for pattern in write_paths or []:
+            if pattern.startswith("/") or ".." in PurePosixPath(pattern).parts:
+                raise ValueError(
+                    f"permissions.write_paths '{pattern}' must be relative to "
+                    "the project root"
+                )
+        return cls(
+            tools=_string_list(permissions, "tools"),
+            bash=_string_list(permissions, "bash"),
+            write_paths=write_paths,
+            network=network,
+        )
+
+    def to_dict(self) -> dict[str, Any]:
+        data: dict[str, Any] = {}
+        for key in ("tools", "bash", "write_paths"):
+            if getattr(self, key) is not None:
+                data[key] = getattr(self, key)
+        if not self.network:
+            data["network"] = False
+        return data
+
+    def _tool_allowed(self, tool_name: str) -> bool:
+        if self.tools is not None and tool_name not in self.tools:
+            return False
+        if not self.network and tool_name in NETWORK_TOOLS:
+            return False
+        if self.bash == [] and tool_name in SHELL_TOOLS:
+            return False
+        return not (self.write_paths == [] and tool_name in WRITE_TOOLS)
+
+    def allowed_tools(self, declared: list[str] | None) -> list[str] | None:
+        """*declared* narrowed to these permissions.
+
+        None when neither lists any tools, meaning every tool that the
+        runtime checks allow.
+        """
+        if declared is None:
+            declared = self.tools
+        if declared is None:
+            return None
+        return [tool for tool in declared if self._tool_allowed(tool)]
+
+    def check(
+        self, tool_name: str, tool_input: dict[str, Any], project_root: Path
+    ) -> str | None:
+        """Why a tool call is not allowed, or None if it is."""
+        if not self._tool_allowed(tool_name):
+            return f"{tool_name} is not allowed"
+        if tool_name == "Bash":
+            return self._check_command(str(tool_input.get("command") or ""))
+        if tool_name in WRITE_TOOLS and self.write_paths is not None:
+            path = tool_input.get("file_path") or tool_input.get("notebook_path")
+            return self._check_write(str(path or ""), project_root)
+        return None
+
+    def _check_command(self, command: str) -> str | None:
+        commands = split_commands(command)
+        if not self.network:
+            for part in commands:
+                if is_network_command(part):
+                    return f"'{part}' reaches the network, which is not allowed"
+        if self.bash is None:
+            return None
+        if _SUBSTITUTION_RE.search(command):
+            return "Command substitution is not allowed with a Bash allowlist"
+        for part in commands:
+            if not any(fnmatch.fnmatchcase(part, p) for p in self.bash):
+                return f"'{part}' does not match the allowed commands"
+        return None
+
+    def _check_write(self, path: str, project_root: Path) -> str | None:
+        if not path:
+            return None
+        root = Path(project_root).resolve()
+        target = Path(path)
+        if not target.is_absolute():
+            target = root / target
+        try:
+            relative = target.resolve().relative_to(root).as_posix()
+        except ValueError:
+            return f"{path} is outside the project"
+        if any(fnmatch.fnmatchcase(relative, p) for p in self.write_paths or []):
+            return None
+        return f"{relative} is not in the writable paths"
+
+
+def apply_to_agent(content: str) -> str:
+    """*content* with its ``tools`` narrowed to its permissions.
+
+    Agents without permissions are returned unchanged.
+
+    Raises:
+        ValueError: If the agent's permissions block is invalid.
+    """
+    frontmatter, _, body = parse_agent(content)
+    permissions = ToolPermissions.from_frontmatter(frontmatter)
+    if permissions is None:
+        return content
+    declared = frontmatter.get("tools")
+    allowed = permissions.allowed_tools(parse_tools(declared))
+    if allowed is None or allowed == parse_tools(declared):
+        return content
+    frontmatter["tools"] = ",".join(allowed) if isinstance(declared, str) else allowed
+    frontmatter_text = yaml.safe_dump(
+        frontmatter, sort_keys=False, allow_unicode=True, width=1_000_000
+    ).rstrip()
+    return f"---\n{frontmatter_text}\n---\n{body}"
+
+
+def agent_permissions(
+    agent_type: str, project_root: Path, home: Path | None = None
+) -> ToolPermissions | None:
+    """Permissions of the deployed agent *agent_type*, project before user.
+
+    Raises:
+        ValueError: If the deployed agent's permissions block is invalid.
+    """
+    name = agent_type.rsplit(":", 1)[-1].strip().lower().replace("_", "-")
+    if not name or "/" in name:
+        return None
+    for agents_dir in (
+        Path(project_root) / ".claude" / "agents",
+        Path(home or Path.home()) / ".claude" / "agents",
+    ):
+        path = agents_dir / f"{name}.md"
+        if path.is_file():
+            frontmatter, _, _ = parse_agent(path.read_text(encoding="utf-8"))
+            return ToolPermissions.from_frontmatter(frontmatter)
+    return None
@@ -0,0 +1,370 @@
+"""Session analytics export for BI tools.
+
+WHAT: ``claude-mpm export analytics`` writes a project's sessions from their
+Claude Code transcripts as flat tables, one file per table, that Metabase,
+Looker or a warehouse load job can read directly::
+
+    claude-mpm-analytics/
+      sessions.csv   one row per session: times, turns, calls, cost, labels
+      events.csv     one row per timeline event: actor, type, model, tokens
+      costs.csv      one row per session, agent and model: turns, tokens, cost
+      outcomes.csv   one row per session: completed delegations, and commits
+                     and verification runs where adoption metrics recorded them
+
+Columns and types are fixed (see TABLES) and every table carries
+``session_id`` and ``project``, so tables join on them and exports from
+several projects can be appended to each other. Parquet files carry the same
+columns with typed timestamps.
+
+WHY: The cost, label and adoption reports answer fixed questions; teams that
+want their own dashboards had to parse transcripts themselves.
+
+DESIGN DECISIONS:
+- Prompt and response text stays out of the export unless asked for with
+  ``include_text``: only counts, names, models and times leave the machine
+- Parquet needs pyarrow (in the ``data-processing`` extra); CSV has no
+  dependencies and is the default
+- Costs are the same public list price estimates as ``session-report``
+"""
+
+from __future__ import annotations
+
+import csv
+from dataclasses import dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+FORMATS = ("csv", "parquet")
+DEFAULT_SINCE = "30d"
+DEFAULT_OUTPUT = "claude-mpm-analytics"
+
+# Column name -> type ("string", "int", "float", "bool", "timestamp")
+TABLES: dict[str, dict[str, str]] = {
+    "sessions": {
+        "session_id": "string",
+        "project": "string",
+        "started_at": "timestamp",
+        "ended_at": "timestamp",
+        "duration_seconds": "float",
+        "turns": "int",
+        "agent_calls": "int",
+        "skill_calls": "int",
+        "mcp_calls": "int",
+        "cost_usd": "float",
+        "pm_cost_usd": "float",
+        "subagent_cost_usd": "float",
+        "pricing_fallback": "bool",
+        "labels": "string",
+        "title": "string",
+    },
+    "events": {
+        "session_id": "string",
+        "project": "string",
+        "event_id": "string",
+        "timestamp": "timestamp",
+        "actor": "string",
+        "event_type": "string",
+        "model": "string",
+        "input_tokens": "int",
+        "output_tokens": "int",
+        "cache_creation_input_tokens": "int",
+        "cache_read_input_tokens": "int",
+        "cost_usd": "float",
+        "tool_calls": "int",
+        "subagent_type": "string",
+        "title": "string",
+    },
+    "costs": {
+        "session_id": "string",
+        "project": "string",
+        "date": "string",
+        "agent": "string",
+        "model": "string",
+        "turns": "int",
+        "input_tokens": "int",
+        "output_tokens": "int",
+        "cache_creation_input_tokens": "int",
+        "cache_read_input_tokens": "int",
+        "cost_usd": "float",
+    },
+    "outcomes": {
+        "session_id": "string",
+        "project": "string",
+        "user": "string",
+        "delegations_completed": "int",
+        "commits": "int",
+        "verification_passed": "int",
+        "verification_failed": "int",
+        "adoption_recorded": "bool",
+    },
+}
+_TOKENS = (
+    "input_tokens",
+    "output_tokens",
+    "cache_creation_input_tokens",
+    "cache_read_input_tokens",
+)
+
+
+def parse_since(value: str | None, now: datetime | None = None) -> datetime | None:
+    """``30d``/``12h`` ago or an ISO date, as aware UTC.
+
+    Raises:
+        ValueError: If *value* is neither.
+    """
+    from .log_sources import LogSourceError, parse_time
+
+    try:
+        return parse_time(value, now)
+    except LogSourceError as e:
+        raise ValueError(str(e)) from e
+
+
+def project_sessions(project_dir: Path) -> list[Path]:
+    """Transcripts Claude Code keeps for *project_dir*, oldest first."""
+    from .session_analysis.transcript_parser import (
+        _claude_projects_root,
+        _encode_cwd,
+    )
+
+    directory = _claude_projects_root() / _encode_cwd(str(project_dir))
+    if not directory.is_dir():
+        return []
+    return sorted(directory.glob("*.jsonl"), key=lambda p: p.stat().st_mtime)
+
+
+def _iso(value: datetime | None) -> str:
+    return value.astimezone(UTC).isoformat() if value else ""
+
+
+def _adoption_sessions() -> dict[str, tuple[str, dict[str, Any]]]:
+    """Adoption records of every user, by session id."""
+    from ..core.state_files import read_json
+    from .adoption import adoption_dir
+
+    records: dict[str, tuple[str, dict[str, Any]]] = {}
+    directory = adoption_dir()
+    if not directory.is_dir():
+        return records
+    for path in sorted(directory.glob("*.json")):
+        data = read_json(path, {})
+        sessions = data.get("sessions") if isinstance(data, dict) else None
+        if not isinstance(sessions, dict):
+            continue
+        user = str(data.get("user") or path.stem)
+        for session_id, session in sessions.items():
+            if isinstance(session, dict):
+                records[session_id] = (user, session)
+    return records
+
+
+@dataclass
+class AnalyticsExport:
+    """Rows of every table, built from a set of projects' transcripts."""
+
+    since: datetime | None = None
+    include_text: bool = False
+    rows: dict[str, list[dict[str, Any]]] = field(
+        default_factory=lambda: {table: [] for table in TABLES}
+    )
+    # Transcripts that could not be parsed: (path, error)
+    skipped: list[tuple[str, str]] = field(default_factory=list)
+
+    def add_project(self, project_dir: Path) -> int:
+        """Add the sessions of *project_dir* active since ``since``.
+
+        Returns:
+            The number of sessions added
+        """
+        from .session_analysis.transcript_parser import parse_session
+        from .session_labels import SessionLabels
+
+        project_dir = Path(project_dir).resolve()
+        labels = SessionLabels(project_dir).all()
+        adoption = _adoption_sessions()
+        added = 0
+        for transcript in project_sessions(project_dir):
+            modified = datetime.fromtimestamp(transcript.stat().st_mtime, UTC)
+            if self.since is not None and modified < self.since:
+                continue
+            try:
+                report = parse_session(transcript.stem, str(project_dir))
+            except Exception as e:
+                logger.warning(f"Skipping transcript {transcript}: {e}")
+                self.skipped.append((str(transcript), str(e)))
+                continue
+            last = report.ended_at or report.started_at
+            if self.since is not None and last is not None and last < self.since:
+                continue
+            self._add_session(
+                report, str(project_dir), labels.get(report.session_id, []), adoption
+            )
+            added += 1
+        return added
+
+    def _add_session(
+        self,
+        report,
+        project: str,
+        labels: list[str],
+        adoption: dict[str, tuple[str, dict[str, Any]]],
+    ) -> None:
+        session_id = report.session_id
+        duration = None
+        if report.started_at and report.ended_at:
+            duration = (report.ended_at - report.started_at).total_seconds()
+        self.rows["sessions"].append(
+            {
+                "session_id": session_id,
+                "project": project,
+                "started_at": report.started_at,
+                "ended_at": report.ended_at,
+                "duration_seconds": duration,
+                "turns": report.total_turns,
+                "agent_calls": report.agent_call_count,
+                "skill_calls": report.skill_call_count,
+                "mcp_calls": report.mcp_call_count,
+                "cost_usd": report.grand_total_cost_usd,
+                "pm_cost_usd": report.pm_cost_usd,
+                "subagent_cost_usd": report.subagent_cost_usd,
+                "pricing_fallback": report.has_pricing_fallback,
+                "labels": ",".join(labels),
+                "title": report.title if self.include_text else "",
+            }
+        )
+
+        costs: dict[tuple[str, str, str], dict[str, Any]] = {}
+        delegations = 0
+        for event in report.events:
+            if event.event_type == "outcome":
+                delegations += 1
+            subagent_types = [c.subagent_type for c in event.calls if c.subagent_type]
+            self.rows["events"].append(
+                {
+                    "session_id": session_id,
+                    "project": project,
+                    "event_id": event.uuid,
+                    "timestamp": event.timestamp,
+                    "actor": event.actor,
+                    "event_type": event.event_type,
+                    "model": event.model,
+                    **{key: int(event.usage.get(key, 0)) for key in _TOKENS},
+                    "cost_usd": event.cost_usd,
+                    "tool_calls": len(event.calls),
+                    "subagent_type": ",".join(subagent_types),
+                    "title": event.title if self.include_text else "",
+                }
+            )
+            if not event.model or event.event_type == "outcome":
+                continue
+            date = event.timestamp.astimezone(UTC).date().isoformat()
+            key = (date, event.actor, event.model)
+            row = costs.setdefault(
+                key,
+                {
+                    "session_id": session_id,
+                    "project": project,
+                    "date": date,
+                    "agent": event.actor,
+                    "model": event.model,
+                    "turns": 0,
+                    **dict.fromkeys(_TOKENS, 0),
+                    "cost_usd": 0.0,
+                },
+            )
+            row["turns"] += 1
+            for token in _TOKENS:
+                row[token] += int(event.usage.get(token, 0))
+            row["cost_usd"] += event.cost_usd
+        self.rows["costs"].extend(costs.values())
+
+        user, record = adoption.get(session_id, ("", {}))
+        self.rows["outcomes"].append(
+            {
+                "session_id": session_id,
+                "project": project,
+                "user": user,
+                "delegations_completed": delegations,
+                "commits": int(record.get("commits", 0)),
+                "verification_passed": int(record.get("verification_passed", 0)),
+                "verification_failed": int(record.get("verification_failed", 0)),
+                "adoption_recorded": bool(record),
+            }
+        )
+
+    def write(
+        self, output_dir: Path, fmt: str = "csv", tables: list[str] | None = None
+    ) -> dict[str, Path]:
+        """Write each table to ``<output_dir>/<table>.<fmt>``.
+
+        Returns:
+            Table name -> written file
+
+        Raises:
+            ValueError: If the format or a table is unknown, or parquet is
+                asked for without pyarrow installed.
+        """
+        if fmt not in FORMATS:
+            raise ValueError(f"Unknown format '{fmt}': use {' or '.join(FORMATS)}")
+        tables = tables or list(TABLES)
+        unknown = [table for table in tables if table not in TABLES]
+        if unknown:
+            raise ValueError(
+                f"Unknown table {', '.join(unknown)}: choose from {', '.join(TABLES)}"
+            )
+        writer = _write_parquet if fmt == "parquet" else _write_csv
+        output_dir = Path(output_dir)
+        output_dir.mkdir(parents=True, exist_ok=True)
+        written = {}
+        for table in tables:
+            path = output_dir / f"{table}.{fmt}"
+            writer(path, TABLES[table], self.rows[table])
+            written[table] = path
+        return written
+
+
+def _csv_value(value: Any) -> Any:
+    if value is None:
+        return ""
+    if isinstance(value, datetime):
+        return _iso(value)
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, float):
+        return round(value, 6)
+    return value
+
+
+def _write_csv(path: Path, columns: dict[str, str], rows: list[dict]) -> None:
+    with path.open("w", encoding="utf-8", newline="") as handle:
+        writer = csv.DictWriter(handle, fieldnames=list(columns))
+        writer.writeheader()
+        for row in rows:
+            writer.writerow({name: _csv_value(row.get(name)) for name in columns})
+
+
+def _write_parquet(path: Path, columns: dict[str, str], rows: list[dict]) -> None:
+    try:
+        import pyarrow as pa
+        import pyarrow.parquet as pq
+    except ImportError as e:
+        raise ValueError(
+            "Parquet export needs pyarrow: pip install 'claude-mpm[data-processing]' "
+            "or use --format csv"
+        ) from e
+
+    types = {
+        "string": pa.string(),
+        "int": pa.int64(),
+        "float": pa.float64(),
+        "bool": pa.bool_(),
+        "timestamp": pa.timestamp("us", tz="UTC"),
+    }
+    schema = pa.schema([(name, types[kind]) for name, kind in columns.items()])
+    data = [{name: row.get(name) for name in columns} for row in rows]
+    pq.write_table(pa.Table.from_pylist(data, schema=schema), path)
@@ -0,0 +1,176 @@
+"""Review comments attached to session events and transcript lines.
+
+WHAT: A comment targets one event of a session: a dashboard event (tool call,
+prompt, delegation) or a line of the session's Claude Code transcript. They
+are stored with the project in .claude-mpm/annotations/<session_id>.json and
+shown next to their event in the dashboard and in ``claude-mpm
+session-report``.
+
+WHY: Reviews of agent work happened in external docs that quoted the
+transcript, and went stale as soon as the session was re-run or moved.
+
+TARGETS:
+- ``transcript:<uuid>``  a transcript entry; ``--line N`` resolves the uuid of
+  line N of the transcript, which is also what session reports key events by
+- ``tool:<correlation_id>``  a dashboard tool call (pre and post events share
+  the correlation id)
+- ``event:<subtype>@<timestamp>``  any other dashboard event
+"""
+
+from __future__ import annotations
+
+import getpass
+import json
+import uuid
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.state_files import read_json, update_json
+from .page_capture import safe_path_component
+
+TRANSCRIPT_PREFIX = "transcript:"
+MAX_TEXT_LENGTH = 10_000
+
+
+def annotations_dir(project_dir: Path) -> Path:
+    return Path(project_dir) / ".claude-mpm" / "annotations"
+
+
+def transcript_target(entry_uuid: str) -> str:
+    return f"{TRANSCRIPT_PREFIX}{entry_uuid}"
+
+
+def _default_author() -> str:
+    try:
+        return getpass.getuser()
+    except Exception:
+        return "unknown"
+
+
+@dataclass(frozen=True)
+class Annotation:
+    """One comment on one event of a session."""
+
+    id: str
+    session_id: str
+    target: str
+    text: str
+    author: str
+    created_at: str
+    line: int | None = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+class AnnotationStore:
+    """Adds, lists and removes the comments of one project's sessions."""
+
+    def __init__(self, project_dir: Path | None = None):
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.root = annotations_dir(self.project_dir)
+
+    def _path(self, session_id: str) -> Path:
+        return self.root / f"{safe_path_component(session_id)}.json"
+
+    def add(
+        self,
+        session_id: str,
+        text: str,
+        target: str | None = None,
+        line: int | None = None,
+        author: str | None = None,
+    ) -> Annotation:
+        """Attach *text* to *target*, or to line *line* of the transcript."""
+        text = text.strip()
+        if not session_id:
+            raise ValueError("A session id is required")
+        if not text:
+            raise ValueError("Comment text is empty")
+        if len(text) > MAX_TEXT_LENGTH:
+            raise ValueError(f"Comment is longer than {MAX_TEXT_LENGTH} characters")
+        if target is None:
+            if line is None:
+                raise ValueError("Either a target or a transcript line is required")
+            target = transcript_target(self.transcript_uuid(session_id, line))
+
+        annotation = Annotation(
+            id=uuid.uuid4().hex[:8],
+            session_id=session_id,
+            target=target,
+            text=text,
+            author=author or _default_author(),
+            created_at=datetime.now(UTC).isoformat(),
+            line=line,
+        )
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            data["annotations"] = [
+                *(data.get("annotations") or []),
+                annotation.to_dict(),
+            ]
+
+        update_json(self._path(session_id), record)
+        return annotation
+
+    def annotations(
+        self, session_id: str | None = None, target: str | None = None
+    ) -> list[Annotation]:
+        """Comments of *session_id* (or of every session), oldest first."""
+        if session_id:
+            paths = [self._path(session_id)]
+        else:
+            paths = sorted(self.root.glob("*.json")) if self.root.is_dir() else []
+        found = []
+        for path in paths:
+            data = read_json(path, {})
+            entries = data.get("annotations") if isinstance(data, dict) else None
+            for entry in entries if isinstance(entries, list) else []:
+                try:
+                    annotation = Annotation(**entry)
+                except TypeError:
+                    continue
+                if target is None or annotation.target == target:
+                    found.append(annotation)
+        return sorted(found, key=lambda a: a.created_at)
+
+    def remove(self, annotation_id: str) -> bool:
+        for annotation in self.annotations():
+            if annotation.id != annotation_id:
+                continue
+
+            def drop(data: dict[str, Any]) -> None:
+                data["annotations"] = [
+                    entry
+                    for entry in data.get("annotations") or []
+                    if entry.get("id") != annotation_id
+                ]
+
+            update_json(self._path(annotation.session_id), drop)
+            return True
+        return False
+
+    def transcript_uuid(self, session_id: str, line: int) -> str:
+        """The uuid of line *line* (1-based) of the session's transcript."""
+        from .session_analysis.transcript_parser import locate_transcript
+
+        path = locate_transcript(session_id, str(self.project_dir.resolve()))
+        if not path.is_file():
+            raise FileNotFoundError(f"Transcript not found: {path}")
+        if line < 1:
+            raise ValueError("Transcript lines are numbered from 1")
+        with path.open(encoding="utf-8") as transcript:
+            for number, raw in enumerate(transcript, start=1):
+                if number < line:
+                    continue
+                try:
+                    entry_uuid = json.loads(raw).get("uuid")
+                except (json.JSONDecodeError, AttributeError):
+                    entry_uuid = None
+                if not entry_uuid:
+                    raise ValueError(f"Line {line} of {path.name} has no uuid")
+                return entry_uuid
+        raise ValueError(f"{path.name} has fewer than {line} lines")
@@ -0,0 +1,274 @@
+"""Per-session store for files a session produces.
+
+WHAT: Reports, generated images and build outputs are copied into
+.claude-mpm/artifacts/<session_id>/ with a manifest recording what each file
+is. ``claude-mpm artifacts list/get`` and the dashboard's Artifacts tab find
+them there, and a retention policy keeps the store from growing forever.
+
+WHY: Agents used to leave generated files in /tmp or wherever the task
+happened to run, where nobody could find them after the session ended and
+nothing ever cleaned them up.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    artifacts:
+      retention:
+        max_age_days: 30     # drop sessions older than this
+        max_sessions: 50     # keep at most this many sessions
+        max_total_mb: 1024   # then drop oldest sessions until under this
+
+DESIGN DECISIONS:
+- One manifest.json per session directory, so pruning a session is a single
+  directory removal and never leaves stale index entries behind
+- Retention works on whole sessions, oldest first; the current session is
+  never pruned
+- Artifact ids are short random hex, unique across sessions, so ``get`` does
+  not need the session id
+"""
+
+from __future__ import annotations
+
+import json
+import mimetypes
+import shutil
+import uuid
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from .page_capture import current_session_id, safe_path_component
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "artifacts"
+MANIFEST = "manifest.json"
+
+REPORT = "report"
+IMAGE = "image"
+BUILD = "build"
+OTHER = "other"
+ARTIFACT_KINDS = (REPORT, IMAGE, BUILD, OTHER)
+
+_KIND_SUFFIXES = {
+    IMAGE: {".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".bmp"},
+    REPORT: {".md", ".html", ".pdf", ".txt", ".json", ".csv", ".xml", ".log"},
+    BUILD: {".zip", ".tar", ".gz", ".tgz", ".whl", ".jar", ".so", ".dmg", ".exe"},
+}
+
+
+def artifacts_dir(project_dir: Path) -> Path:
+    return Path(project_dir) / ".claude-mpm" / "artifacts"
+
+
+def infer_kind(name: str) -> str:
+    suffix = Path(name).suffix.lower()
+    for kind, suffixes in _KIND_SUFFIXES.items():
+        if suffix in suffixes:
+            return kind
+    return OTHER
+
+
+@dataclass
+class RetentionPolicy:
+    """Limits applied by ``ArtifactStore.prune``; ``None`` means no limit."""
+
+    max_age_days: int | None = 30
+    max_sessions: int | None = 50
+    max_total_mb: int | None = 1024
+
+    @classmethod
+    def load(cls, config: Any = None) -> RetentionPolicy:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = (config.get(CONFIG_KEY, {}) or {}).get("retention", {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        defaults = cls()
+        return cls(
+            max_age_days=section.get("max_age_days", defaults.max_age_days),
+            max_sessions=section.get("max_sessions", defaults.max_sessions),
+            max_total_mb=section.get("max_total_mb", defaults.max_total_mb),
+        )
+
+
+@dataclass
+class Artifact:
+    """One stored file and what it is."""
+
+    id: str
+    session_id: str
+    name: str
+    kind: str
+    size: int
+    created_at: str
+    description: str = ""
+    source: str = ""
+
+    @property
+    def media_type(self) -> str:
+        return mimetypes.guess_type(self.name)[0] or "application/octet-stream"
+
+    def to_dict(self) -> dict[str, Any]:
+        return {**asdict(self), "media_type": self.media_type}
+
+
+class ArtifactStore:
+    """Adds, finds and prunes session artifacts under one project."""
+
+    def __init__(self, project_dir: Path, retention: RetentionPolicy | None = None):
+        self.project_dir = Path(project_dir)
+        self.root = artifacts_dir(self.project_dir)
+        self.retention = retention or RetentionPolicy.load()
+
+    def add(
+        self,
+        source: Path,
+        session_id: str | None = None,
+        name: str | None = None,
+        kind: str | None = None,
+        description: str = "",
+        move: bool = False,
+    ) -> Artifact:
+        """Copy (or move) ``source`` into the session's artifact directory."""
+        source = Path(source).expanduser()
+        if not source.is_file():
+            raise FileNotFoundError(f"Not a file: {source}")
+        if kind is not None and kind not in ARTIFACT_KINDS:
+            raise ValueError(
+                f"Unknown artifact kind '{kind}' "
+                f"(expected one of {', '.join(ARTIFACT_KINDS)})"
+            )
+        session = safe_path_component(session_id or current_session_id())
+        artifact_id = uuid.uuid4().hex[:8]
+        name = safe_path_component(name or source.name)
+        artifact = Artifact(
+            id=artifact_id,
+            session_id=session,
+            name=name,
+            kind=kind or infer_kind(name),
+            size=source.stat().st_size,
+            created_at=datetime.now(UTC).isoformat(),
+            description=description,
+            source=str(source.resolve()),
+        )
+        target = self.root / session / artifact_id / name
+        target.parent.mkdir(parents=True, exist_ok=True)
+        if move:
+            shutil.move(str(source), target)
+        else:
+            shutil.copy2(source, target)
+
+        manifest = self._read_manifest(session)
+        manifest.append(artifact)
+        self._write_manifest(session, manifest)
+        self.prune(keep_session=session)
+        return artifact
+
+    def artifacts(
+        self, session_id: str | None = None, kind: str | None = None
+    ) -> list[Artifact]:
+        """Artifacts newest first, optionally for one session or of one kind."""
+        sessions = [safe_path_component(session_id)] if session_id else self.sessions()
+        artifacts = [
+            artifact
+            for session in sessions
+            for artifact in self._read_manifest(session)
+            if kind is None or artifact.kind == kind
+        ]
+        return sorted(artifacts, key=lambda a: a.created_at, reverse=True)
+
+    def get(self, artifact_id: str) -> Artifact | None:
+        for artifact in self.artifacts():
+            if artifact.id == artifact_id:
+                return artifact
+        return None
+
+    def path(self, artifact: Artifact) -> Path:
+        return self.root / artifact.session_id / artifact.id / artifact.name
+
+    def sessions(self) -> list[str]:
+        if not self.root.is_dir():
+            return []
+        return sorted(d.name for d in self.root.iterdir() if (d / MANIFEST).is_file())
+
+    # ------------------------------------------------------------------
+    # Retention
+    # ------------------------------------------------------------------
+
+    def prune(
+        self, now: datetime | None = None, keep_session: str | None = None
+    ) -> list[str]:
+        """Apply the retention policy; returns the session ids removed."""
+        now = now or datetime.now(UTC)
+        policy = self.retention
+        # Oldest first, by the newest artifact in each session
+        sessions = sorted(
+            (max(a.created_at for a in manifest), session, manifest)
+            for session in self.sessions()
+            if (manifest := self._read_manifest(session))
+        )
+        cutoff = (
+            (now - timedelta(days=policy.max_age_days)).isoformat()
+            if policy.max_age_days is not None
+            else None
+        )
+        removed: list[str] = []
+
+        def drop(session: str) -> None:
+            shutil.rmtree(self.root / session, ignore_errors=True)
+            removed.append(session)
+
+        remaining = []
+        for newest, session, manifest in sessions:
+            if cutoff and newest < cutoff and session != keep_session:
+                drop(session)
+            else:
+                remaining.append((session, sum(a.size for a in manifest)))
+
+        if policy.max_sessions is not None:
+            while len(remaining) > policy.max_sessions:
+                victim = next((r for r in remaining if r[0] != keep_session), None)
+                if victim is None:
+                    break
+                remaining.remove(victim)
+                drop(victim[0])
+
+        if policy.max_total_mb is not None:
+            limit = policy.max_total_mb * 1024 * 1024
+            while sum(size for _, size in remaining) > limit:
+                victim = next((r for r in remaining if r[0] != keep_session), None)
+                if victim is None:
+                    break
+                remaining.remove(victim)
+                drop(victim[0])
+
+        if removed:
+            logger.info(f"Pruned artifacts of {len(removed)} session(s)")
+        return removed
+
+    # ------------------------------------------------------------------
+    # Manifest
+    # ------------------------------------------------------------------
+
+    def _read_manifest(self, session: str) -> list[Artifact]:
+        path = self.root / session / MANIFEST
+        if not path.is_file():
+            return []
+        try:
+            entries = json.loads(path.read_text(encoding="utf-8"))
+            return [Artifact(**entry) for entry in entries]
+        except (OSError, json.JSONDecodeError, TypeError) as e:
+            logger.warning(f"Ignoring unreadable artifact manifest {path}: {e}")
+            return []
+
+    def _write_manifest(self, session: str, artifacts: list[Artifact]) -> None:
+        path = self.root / session / MANIFEST
+        path.parent.mkdir(parents=True, exist_ok=True)
+        data = [asdict(artifact) for artifact in artifacts]
+        path.write_text(json.dumps(data, indent=2) + "\n", encoding="utf-8")
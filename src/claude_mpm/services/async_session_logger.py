@@ -31,6 +31,7 @@ from typing import Any
 
 from claude_mpm.core.constants import PerformanceConfig, SystemLimits, TimeoutConfig
 from claude_mpm.core.logging_utils import get_logger
+from claude_mpm.services import data_encryption
 
 # Import centralized session manager
 from claude_mpm.services.session_manager import get_session_manager
@@ -337,8 +338,11 @@ class AsyncSessionLogger:
             with gzip.open(file_path, "wt", encoding="utf-8") as f:
                 json.dump(data, f, indent=2, ensure_ascii=False)
         else:
-            with file_path.open("w", encoding="utf-8") as f:
-                json.dump(data, f, indent=2, ensure_ascii=False)
+            content = json.dumps(data, indent=2, ensure_ascii=False)
+            file_path.write_text(
+                data_encryption.protect(content, data_encryption.TRANSCRIPTS),
+                encoding="utf-8",
+            )
 
         logger.debug(f"Wrote log entry to {file_path}")
 
@@ -0,0 +1,437 @@
+"""Canary rollouts of agent and PM instruction changes.
+
+WHAT: ``claude-mpm canary start <agent> FILE --percent 10`` makes FILE the
+*candidate* version of an agent (or, with the name ``instructions``, of the
+PM instructions). Each new session is assigned to the candidate or the
+stable arm: sessions in the listed ``--project`` directories always get the
+candidate, other sessions with the given probability. The assignment is
+recorded with the session id passed to Claude Code, so ``canary status``
+can compare the two arms' outcomes from their transcripts: tool errors per
+turn, the share of prompts that correct or interrupt the previous turn, and
+cost per session. ``canary promote`` gives every session the candidate,
+``canary stop`` returns every session to the stable version.
+
+WHY: Agent and instruction edits were deployed everywhere at once, and a
+regression only showed up as a vague feeling that sessions got worse.
+
+DESIGN DECISIONS:
+- Canaries are user-level (~/.claude-mpm/canary) since they span projects;
+  the candidate file is copied there when the canary starts
+- Assignment hashes the canary and session id, so it is reproducible and
+  independent across canaries
+- Agents are switched by rewriting the project's deployed agent file at
+  session start. The stable arm redeploys the agent from its source (the one
+  recorded in the integrity manifest, else the template lookup), or from a
+  copy of the deployed file taken before the candidate first replaced it
+- Concurrent sessions in one project share the deployed agent file, so the
+  last one to start decides what both use; run canaries on sequential
+  sessions or use ``--project`` for a cleaner comparison
+- PM instruction candidates go through the same override as
+  ``--instructions-override``; an explicit override wins and that session
+  is not counted
+- Resumed sessions keep what they started with and are not assigned
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import os
+import re
+import uuid
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, state_lock, update_json, write_atomic
+from .deployment_delta import write_if_changed
+from .session_analysis.pricing import compute_cost
+
+logger = get_logger(__name__)
+
+INSTRUCTIONS = "instructions"
+INSTRUCTIONS_ENV_VAR = "CLAUDE_MPM_INSTRUCTIONS_OVERRIDE"
+
+ACTIVE = "active"
+PROMOTED = "promoted"
+STOPPED = "stopped"
+
+STABLE = "stable"
+CANDIDATE = "candidate"
+ARMS = (STABLE, CANDIDATE)
+
+DEFAULT_PERCENT = 10
+# Fewer measured sessions per arm than this and status says so
+MIN_SESSIONS = 5
+
+_NAME_RE = re.compile(r"^[a-z0-9][a-z0-9_-]*$")
+
+
+@dataclass
+class Canary:
+    """One candidate version being rolled out."""
+
+    name: str  # agent name, or INSTRUCTIONS
+    percent: int
+    projects: list[str] = field(default_factory=list)
+    status: str = ACTIVE
+    started_at: str = ""
+    promoted_at: str | None = None
+    stopped_at: str | None = None
+
+    @property
+    def kind(self) -> str:
+        return INSTRUCTIONS if self.name == INSTRUCTIONS else "agent"
+
+    def arm_for(self, session_id: str, project: Path) -> str:
+        if self.status == PROMOTED:
+            return CANDIDATE
+        if self.status == STOPPED:
+            return STABLE
+        if str(Path(project).resolve()) in self.projects:
+            return CANDIDATE
+        digest = hashlib.sha256(f"{self.name}:{session_id}".encode()).hexdigest()
+        return CANDIDATE if int(digest[:8], 16) % 100 < self.percent else STABLE
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class SessionOutcome:
+    """What happened in one session, from its transcript."""
+
+    turns: int = 0
+    tool_errors: int = 0
+    corrections: int = 0
+    cost_usd: float = 0.0
+
+
+@dataclass
+class ArmStats:
+    """Outcomes of the sessions assigned to one arm."""
+
+    arm: str
+    sessions: int = 0  # assigned
+    measured: int = 0  # with a transcript
+    turns: int = 0
+    tool_errors: int = 0
+    corrections: int = 0
+    cost_usd: float = 0.0
+
+    def add(self, outcome: SessionOutcome) -> None:
+        self.measured += 1
+        self.turns += outcome.turns
+        self.tool_errors += outcome.tool_errors
+        self.corrections += outcome.corrections
+        self.cost_usd += outcome.cost_usd
+
+    @property
+    def errors_per_turn(self) -> float:
+        return self.tool_errors / self.turns if self.turns else 0.0
+
+    @property
+    def correction_rate(self) -> float:
+        return self.corrections / self.turns if self.turns else 0.0
+
+    @property
+    def cost_per_session(self) -> float:
+        return self.cost_usd / self.measured if self.measured else 0.0
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            **asdict(self),
+            "errors_per_turn": round(self.errors_per_turn, 4),
+            "correction_rate": round(self.correction_rate, 4),
+            "cost_per_session": round(self.cost_per_session, 4),
+        }
+
+
+def session_outcome(lines: list[dict[str, Any]]) -> SessionOutcome:
+    """Turns, tool errors, corrections and cost of one session transcript.
+
+    A turn starts with a user prompt; a prompt is a correction when it reads
+    like one or interrupts the previous turn, as in ``skills stats``.
+    """
+    from .skills.skill_effectiveness import _RETRY_RE, _user_text
+
+    outcome = SessionOutcome()
+    seen_messages: set[str] = set()
+    for entry in lines:
+        message = entry.get("message") or {}
+        content = message.get("content")
+        blocks = content if isinstance(content, list) else []
+        if entry.get("type") == "user":
+            results = [b for b in blocks if b.get("type") == "tool_result"]
+            outcome.tool_errors += sum(1 for b in results if b.get("is_error"))
+            text = _user_text(content).strip()
+            if text and not results:
+                if outcome.turns and _RETRY_RE.search(text):
+                    outcome.corrections += 1
+                outcome.turns += 1
+        elif entry.get("type") == "assistant":
+            message_id = message.get("id")
+            usage = message.get("usage")
+            if usage and message_id not in seen_messages:
+                if message_id:
+                    seen_messages.add(message_id)
+                model = message.get("model") or "claude-sonnet"
+                outcome.cost_usd += compute_cost(model, usage)
+    return outcome
+
+
+def _now() -> str:
+    return datetime.now(UTC).isoformat()
+
+
+class CanaryStore:
+    """Canaries, their candidate files and session assignments."""
+
+    def __init__(self, root: Path | None = None):
+        self.root = Path(root or Path.home() / ".claude-mpm" / "canary")
+        self.path = self.root / "canaries.json"
+        self.assignments_path = self.root / "assignments.jsonl"
+
+    # ------------------------------------------------------------------
+    # Canary records
+    # ------------------------------------------------------------------
+
+    def canaries(self) -> dict[str, Canary]:
+        data = read_json(self.path, {})
+        canaries = {}
+        for name, record in (data if isinstance(data, dict) else {}).items():
+            try:
+                canaries[name] = Canary(**record)
+            except TypeError as e:
+                logger.warning(f"Ignoring unreadable canary {name}: {e}")
+        return canaries
+
+    def get(self, name: str) -> Canary:
+        canary = self.canaries().get(name)
+        if canary is None:
+            raise KeyError(f"No canary for '{name}'")
+        return canary
+
+    def _save(self, canary: Canary) -> None:
+        def put(records: dict[str, Any]) -> None:
+            records[canary.name] = canary.to_dict()
+
+        update_json(self.path, put)
+
+    def candidate_path(self, name: str) -> Path:
+        return self.root / name / "candidate.md"
+
+    def start(
+        self,
+        name: str,
+        candidate: Path,
+        percent: int | None = None,
+        projects: list[Path] | None = None,
+    ) -> Canary:
+        """Start rolling out *candidate* as the new version of *name*.
+
+        Raises:
+            ValueError: Invalid name, percentage or candidate, or a canary for
+                *name* is already running.
+        """
+        if not _NAME_RE.match(name):
+            raise ValueError(f"Invalid agent name '{name}'")
+        existing = self.canaries().get(name)
+        if existing and existing.status == ACTIVE:
+            raise ValueError(
+                f"A canary for '{name}' is already running; promote or stop it first"
+            )
+        if percent is None:
+            percent = 0 if projects else DEFAULT_PERCENT
+        if not 0 <= percent <= 100:
+            raise ValueError(f"Percentage must be 0-100, got {percent}")
+        content = Path(candidate).read_text(encoding="utf-8")
+        if not content.strip():
+            raise ValueError(f"Candidate file is empty: {candidate}")
+        if name != INSTRUCTIONS:
+            from .agents.deployment_utils import (
+                normalize_deployment_filename,
+                render_agent_content,
+            )
+
+            content = render_agent_content(
+                content,
+                normalize_deployment_filename(f"{name}.md"),
+                source_path=Path(candidate),
+            )
+        write_atomic(self.candidate_path(name), content)
+        canary = Canary(
+            name=name,
+            percent=percent,
+            projects=[str(Path(p).resolve()) for p in projects or []],
+            started_at=_now(),
+        )
+        self._save(canary)
+        return canary
+
+    def promote(self, name: str) -> Canary:
+        canary = self.get(name)
+        if canary.status != ACTIVE:
+            raise ValueError(f"Canary for '{name}' is {canary.status}, not active")
+        canary.status, canary.promoted_at = PROMOTED, _now()
+        self._save(canary)
+        return canary
+
+    def stop(self, name: str, project: Path | None = None) -> Canary:
+        """Return every session to the stable version, this project now."""
+        canary = self.get(name)
+        canary.status, canary.stopped_at = STOPPED, _now()
+        self._save(canary)
+        if canary.kind != INSTRUCTIONS:
+            self._deploy_stable(canary, Path(project or Path.cwd()))
+        return canary
+
+    # ------------------------------------------------------------------
+    # Sessions
+    # ------------------------------------------------------------------
+
+    def start_session(self, project: Path) -> str | None:
+        """Assign a new session in *project* to every canary's arms.
+
+        Deploys each arm and records the assignment. Returns the session id
+        to start Claude Code with, or None when no canary is running.
+        """
+        project = Path(project).resolve()
+        canaries = self.canaries()
+        if not canaries:
+            return None
+        session_id = str(uuid.uuid4())
+        arms: dict[str, str] = {}
+        for canary in canaries.values():
+            arm = canary.arm_for(session_id, project)
+            try:
+                if self._apply(canary, arm, project) and canary.status == ACTIVE:
+                    arms[canary.name] = arm
+            except OSError as e:
+                logger.warning(f"Could not apply canary '{canary.name}': {e}")
+        if not arms:
+            return None  # only promoted or stopped canaries: nothing to compare
+        record = {
+            "session_id": session_id,
+            "project": str(project),
+            "arms": arms,
+            "started": {name: canaries[name].started_at for name in arms},
+            "at": _now(),
+        }
+        with state_lock(self.assignments_path):
+            self.assignments_path.parent.mkdir(parents=True, exist_ok=True)
+            with self.assignments_path.open("a", encoding="utf-8") as fh:
+                fh.write(json.dumps(record) + "\n")
+        return session_id
+
+    def _apply(self, canary: Canary, arm: str, project: Path) -> bool:
+        """Deploy *arm* for this session; False if the session doesn't count."""
+        if canary.kind == INSTRUCTIONS:
+            if os.environ.get(INSTRUCTIONS_ENV_VAR):
+                return False  # an explicit override wins
+            if arm == CANDIDATE:
+                candidate = self.candidate_path(INSTRUCTIONS)
+                os.environ[INSTRUCTIONS_ENV_VAR] = str(candidate)
+            return True
+        if arm == CANDIDATE:
+            self._deploy_candidate(canary, project)
+        else:
+            self._deploy_stable(canary, project)
+        return True
+
+    def _deployed(self, canary: Canary, project: Path) -> tuple[Path, Path]:
+        from .agents.deployment_utils import normalize_deployment_filename
+
+        filename = normalize_deployment_filename(f"{canary.name}.md")
+        deployed = project / ".claude" / "agents" / filename
+        stable_copy = project / ".claude-mpm" / "canary" / f"{canary.name}.stable.md"
+        return deployed, stable_copy
+
+    def _deploy_candidate(self, canary: Canary, project: Path) -> None:
+        deployed, stable_copy = self._deployed(canary, project)
+        candidate = self.candidate_path(canary.name).read_text(encoding="utf-8")
+        if deployed.is_file() and not stable_copy.exists():
+            current = deployed.read_text(encoding="utf-8")
+            if current != candidate:
+                write_atomic(stable_copy, current)
+        write_if_changed(deployed, candidate)
+
+    def _deploy_stable(self, canary: Canary, project: Path) -> None:
+        deployed, stable_copy = self._deployed(canary, project)
+        candidate_path = self.candidate_path(canary.name)
+        if not deployed.is_file() or not candidate_path.is_file():
+            return
+        if deployed.read_text(encoding="utf-8") != candidate_path.read_text(
+            encoding="utf-8"
+        ):
+            return  # never replaced, or edited since
+        source = _agent_source(deployed, canary.name, project)
+        if source is not None:
+            from .agents.deployment_utils import deploy_agent_file
+
+            result = deploy_agent_file(source, deployed.parent, force=True)
+            if result.success:
+                return
+            logger.warning(f"Could not redeploy {canary.name}: {result.error}")
+        if stable_copy.is_file():
+            write_if_changed(deployed, stable_copy.read_text(encoding="utf-8"))
+        else:
+            deployed.unlink()  # the candidate added the agent
+
+    # ------------------------------------------------------------------
+    # Comparison
+    # ------------------------------------------------------------------
+
+    def assignments(self, canary: Canary) -> list[dict[str, Any]]:
+        """Sessions assigned since *canary* started."""
+        if not self.assignments_path.is_file():
+            return []
+        found = []
+        with self.assignments_path.open(encoding="utf-8") as fh:
+            for raw in fh:
+                try:
+                    record = json.loads(raw)
+                except json.JSONDecodeError:
+                    continue
+                started = record.get("started", {}).get(canary.name)
+                if canary.name in record.get("arms", {}) and (
+                    started == canary.started_at
+                ):
+                    found.append(record)
+        return found
+
+    def compare(self, name: str) -> dict[str, ArmStats]:
+        """Outcomes of each arm's sessions, from their transcripts."""
+        from .session_analysis.transcript_parser import locate_transcript
+        from .skills.skill_effectiveness import _read_jsonl
+
+        canary = self.get(name)
+        stats = {arm: ArmStats(arm) for arm in ARMS}
+        for record in self.assignments(canary):
+            arm = stats[record["arms"][name]]
+            arm.sessions += 1
+            transcript = locate_transcript(record["session_id"], record["project"])
+            if not transcript.is_file():
+                continue
+            try:
+                arm.add(session_outcome(_read_jsonl(transcript)))
+            except OSError as e:
+                logger.debug(f"Skipping unreadable transcript {transcript}: {e}")
+        return stats
+
+
+def _agent_source(deployed: Path, name: str, project: Path) -> Path | None:
+    from .deployment_integrity import IntegrityManifest
+
+    key = deployed.relative_to(project).as_posix()
+    entry = IntegrityManifest(project).entries().get(key)
+    if entry is not None and Path(entry.source).is_file():
+        return Path(entry.source)
+    from .agents.playground import DEPLOYED, locate_agent_source
+
+    located = locate_agent_source(name, project)
+    if located is not None and located.kind != DEPLOYED:
+        return located.path
+    return None
@@ -0,0 +1,203 @@
+"""Chaos mode: inject faults to validate error handling.
+
+WHAT: While chaos mode is on, claude-mpm makes things go wrong on purpose:
+
+- ``tool_failure_rate``: tool calls are refused as if the tool failed
+- ``rate_limit_rate``: tool calls are refused with a simulated 429 rate
+  limit error
+- ``event_delay_rate``: hook events reach the dashboard up to
+  ``max_event_delay`` seconds late
+- ``drop_rate``: hook event deliveries fail as if the connection dropped,
+  so they go through the offline spool
+
+Each rate is the chance per tool call or event, from 0 to 1.
+
+CONFIGURATION (configuration.yaml, user file then project file)::
+
+    chaos:
+      enabled: true
+      tool_failure_rate: 0.1
+      rate_limit_rate: 0.05
+      event_delay_rate: 0.2
+      max_event_delay: 3
+      drop_rate: 0.1
+      tools: [Bash, WebFetch]    # only these tools fail (default: all)
+      seed: 42                   # same faults for the same calls
+
+``claude-mpm run --chaos`` or ``CLAUDE_MPM_CHAOS=1`` turns chaos mode on
+for one session; ``CLAUDE_MPM_CHAOS=0`` turns it off whatever the
+configuration says.
+
+WHY: Custom hooks and channel adapters are written against the happy path.
+Their error handling only runs when something breaks in production, which
+is the worst time to find out it does not work.
+
+DESIGN DECISIONS:
+- Off unless asked for; with nothing but ``enabled`` set, every fault has
+  a DEFAULT_RATE chance
+- Every injected fault says so (``[chaos]`` in the reason and the log), so
+  a simulated failure is never mistaken for a real one
+- With a seed, whether a call fails depends only on the seed and the
+  call's ID; hooks run in a new process per call, so a shared random
+  stream would make every call roll the same number
+"""
+
+from __future__ import annotations
+
+import os
+import random
+from dataclasses import dataclass, field, fields
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_SECTION = "chaos"
+ENV_VAR = "CLAUDE_MPM_CHAOS"
+DEFAULT_RATE = 0.1
+RATES = ("tool_failure_rate", "rate_limit_rate", "event_delay_rate", "drop_rate")
+
+TOOL_FAILURE = "tool_failure"
+RATE_LIMIT = "rate_limit"
+
+
+@dataclass
+class ChaosConfig:
+    """Which faults chaos mode injects, and how often."""
+
+    enabled: bool = False
+    tool_failure_rate: float = DEFAULT_RATE
+    rate_limit_rate: float = DEFAULT_RATE
+    event_delay_rate: float = DEFAULT_RATE
+    max_event_delay: float = 2.0
+    drop_rate: float = DEFAULT_RATE
+    retry_after: int = 30
+    tools: list[str] = field(default_factory=list)
+    seed: int | str | None = None
+
+    @classmethod
+    def load(cls, project_dir: str | Path | None = None) -> ChaosConfig:
+        """``chaos`` for a project: user file, then project file, then the
+        CLAUDE_MPM_CHAOS override. Invalid values are ignored.
+        """
+        paths = [Path.home() / ".claude-mpm" / "config" / "configuration.yaml"]
+        if project_dir:
+            paths.append(Path(project_dir) / ".claude-mpm" / "configuration.yaml")
+        known = {f.name for f in fields(cls)}
+        values: dict[str, Any] = {}
+        for path in paths:
+            try:
+                data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+            except (OSError, yaml.YAMLError):
+                continue
+            section = data.get(CONFIG_SECTION) if isinstance(data, dict) else None
+            if isinstance(section, dict):
+                values.update({k: v for k, v in section.items() if k in known})
+
+        config = cls()
+        for name, value in values.items():
+            default = getattr(config, name)
+            try:
+                if name in RATES:
+                    value = min(max(float(value), 0.0), 1.0)
+                elif isinstance(default, bool):
+                    value = bool(value)
+                elif isinstance(default, (int, float)):
+                    value = max(type(default)(value), 0)
+                elif name == "tools":
+                    value = [value] if isinstance(value, str) else list(value)
+            except (TypeError, ValueError):
+                logger.warning(f"Ignoring invalid chaos.{name}: {value!r}")
+                continue
+            setattr(config, name, value)
+
+        override = os.environ.get(ENV_VAR, "").strip().lower()
+        if override in ("1", "true", "yes", "on"):
+            config.enabled = True
+        elif override in ("0", "false", "no", "off"):
+            config.enabled = False
+        return config
+
+
+@dataclass
+class ToolFault:
+    """A fault injected into one tool call."""
+
+    kind: str  # TOOL_FAILURE or RATE_LIMIT
+    tool_name: str
+    retry_after: int = 0
+
+    def reason(self) -> str:
+        if self.kind == RATE_LIMIT:
+            return (
+                "[chaos] Simulated rate limit: 429 Too Many Requests for "
+                f"{self.tool_name}; retry after {self.retry_after}s"
+            )
+        return f"[chaos] Simulated failure of {self.tool_name}"
+
+
+class FaultInjector:
+    """Decides, call by call, whether chaos mode injects a fault."""
+
+    def __init__(self, config: ChaosConfig):
+        self.config = config
+
+    @property
+    def enabled(self) -> bool:
+        return self.config.enabled
+
+    def _roll(self, key: str | None) -> float:
+        if self.config.seed is None or not key:
+            return random.random()  # nosec B311 - not used for security
+        return random.Random(f"{self.config.seed}:{key}").random()  # nosec B311
+
+    def tool_fault(
+        self, tool_name: str, call_id: str | None = None
+    ) -> ToolFault | None:
+        """The fault to inject into a tool call, if any."""
+        config = self.config
+        if not config.enabled or (config.tools and tool_name not in config.tools):
+            return None
+        roll = self._roll(call_id)
+        if roll < config.tool_failure_rate:
+            fault = ToolFault(TOOL_FAILURE, tool_name)
+        elif roll < config.tool_failure_rate + config.rate_limit_rate:
+            fault = ToolFault(RATE_LIMIT, tool_name, config.retry_after)
+        else:
+            return None
+        logger.info(f"Chaos: injected {fault.kind} into {tool_name}")
+        return fault
+
+    def event_delay(self, event_id: str | None = None) -> float:
+        """Seconds to hold back an event before delivering it."""
+        if not self.enabled:
+            return 0.0
+        roll = self._roll(f"delay:{event_id}" if event_id else None)
+        if roll >= self.config.event_delay_rate:
+            return 0.0
+        # Reuse the roll so the delay is as reproducible as the decision
+        rate = self.config.event_delay_rate
+        delay = self.config.max_event_delay * (roll / rate if rate else 0.0)
+        logger.info(f"Chaos: delaying event by {delay:.1f}s")
+        return delay
+
+    def drop_event(self, event_id: str | None = None) -> bool:
+        """Whether to fail an event delivery as if the connection dropped."""
+        if not self.enabled:
+            return False
+        dropped = (
+            self._roll(f"drop:{event_id}" if event_id else None)
+            < self.config.drop_rate
+        )
+        if dropped:
+            logger.info("Chaos: dropped an event delivery")
+        return dropped
+
+
+def load_injector(project_dir: str | Path | None = None) -> FaultInjector:
+    """The fault injector for a project."""
+    return FaultInjector(ChaosConfig.load(project_dir))
@@ -0,0 +1,389 @@
+"""Per-project onboarding checklist.
+
+WHAT: A checklist is a list of steps, each checked against the repository:
+agents deployed, hooks installed, CI wired up, configuration committed, or
+steps someone ticks off by hand. Teams define their own steps in
+``.claude-mpm/checklist.yaml``; without one the default steps below apply.
+``claude-mpm checklist status`` reports them for one or many repositories,
+and the dashboard's Checklist tab shows them for the project it serves.
+
+    steps:
+      - id: agents
+        title: Agents deployed
+        check: agents_deployed
+        agents: [engineer, qa]       # default: at least one agent
+      - id: ci
+        title: CI runs claude-mpm
+        check: file
+        path: .github/workflows/*.yml
+        contains: claude-mpm         # regular expression
+      - id: policies
+        title: Concurrency limits set
+        check: config
+        key: agents.max_concurrent   # in .claude-mpm/configuration.yaml
+      - id: owner
+        title: Team owner agreed
+        check: manual                # claude-mpm checklist done owner
+
+CHECKS: agents_deployed (``agents``, ``min``), skills_deployed (``skills``,
+``min``), hooks_installed (``events``), mcp_configured (``servers``), file
+(``path``, ``contains``), config (``key``, ``equals``) and manual.
+
+WHY: Platform teams rolling claude-mpm out to many repositories had no way
+to see how far each one had got short of checking out every repo.
+
+DESIGN DECISIONS:
+- Checks only read committed project files (through the same collectors as
+  ``claude-mpm status``), so the result describes the repository, not the
+  machine it is checked on
+- Manual steps are recorded, with who and when, in
+  ``.claude-mpm/checklist-state.json`` so they can be committed too; only
+  manual steps can be marked done, automated ones always reflect the repo
+- An unknown check type fails its step instead of the whole checklist
+"""
+
+from __future__ import annotations
+
+import getpass
+import re
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json
+from .project_status import ProjectStatus, collect_project_status
+
+logger = get_logger(__name__)
+
+CHECKLIST_FILE = Path(".claude-mpm") / "checklist.yaml"
+STATE_FILE = Path(".claude-mpm") / "checklist-state.json"
+MANUAL = "manual"
+
+DEFAULT_STEPS: list[dict[str, Any]] = [
+    {"id": "agents", "title": "Agents deployed", "check": "agents_deployed"},
+    {
+        "id": "hooks",
+        "title": "Claude Code hooks installed",
+        "check": "hooks_installed",
+    },
+    {
+        "id": "ci",
+        "title": "CI runs claude-mpm",
+        "check": "file",
+        "path": ".github/workflows/*.y*ml",
+        "contains": "claude-mpm",
+    },
+    {
+        "id": "config",
+        "title": "Project configuration committed",
+        "check": "file",
+        "path": ".claude-mpm/configuration.yaml",
+    },
+]
+
+_HINTS = {
+    "agents_deployed": "Run 'claude-mpm agents deploy'",
+    "skills_deployed": "Run 'claude-mpm skills deploy'",
+    "hooks_installed": "Run 'claude-mpm configure --install-hooks'",
+    "mcp_configured": "Add the MCP servers to .mcp.json",
+    "file": "Add the file to the repository",
+    "config": "Set the key in .claude-mpm/configuration.yaml",
+    MANUAL: "Run 'claude-mpm checklist done <step>' once it is done",
+}
+
+
+@dataclass(frozen=True)
+class Step:
+    """One checklist step and the parameters of its check."""
+
+    id: str
+    title: str
+    check: str
+    params: dict[str, Any] = field(default_factory=dict)
+    hint: str = ""
+
+    @classmethod
+    def from_dict(cls, data: Any) -> Step:
+        if not isinstance(data, dict) or not data.get("id") or not data.get("check"):
+            raise ValueError(f"Checklist steps need an id and a check: {data!r}")
+        params = {
+            k: v for k, v in data.items() if k not in ("id", "title", "check", "hint")
+        }
+        return cls(
+            id=str(data["id"]),
+            title=str(data.get("title") or data["id"]),
+            check=str(data["check"]),
+            params=params,
+            hint=str(data.get("hint") or _HINTS.get(str(data["check"]), "")),
+        )
+
+
+@dataclass
+class StepResult:
+    """Whether a step is done in a project, and why."""
+
+    id: str
+    title: str
+    check: str
+    done: bool
+    detail: str
+    hint: str = ""
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class ChecklistStatus:
+    """The checklist results of one project."""
+
+    project_root: Path
+    source: str
+    results: list[StepResult]
+
+    @property
+    def done(self) -> int:
+        return sum(result.done for result in self.results)
+
+    @property
+    def complete(self) -> bool:
+        return self.done == len(self.results)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "project_root": str(self.project_root),
+            "source": self.source,
+            "done": self.done,
+            "total": len(self.results),
+            "complete": self.complete,
+            "steps": [result.to_dict() for result in self.results],
+        }
+
+    def render(self) -> str:
+        lines = [
+            f"{self.project_root.name}: {self.done}/{len(self.results)} steps done"
+            f" ({self.source})"
+        ]
+        for result in self.results:
+            mark = "✓" if result.done else "✗"
+            lines.append(f"  {mark} {result.title} - {result.detail}")
+            if not result.done and result.hint:
+                lines.append(f"      {result.hint}")
+        return "\n".join(lines)
+
+
+class _Context:
+    """What checks read from one project, collected once."""
+
+    def __init__(self, root: Path, manual: dict[str, Any]):
+        self.root = root
+        self.manual = manual
+        self._status: ProjectStatus | None = None
+        self._config: dict[str, Any] | None = None
+
+    @property
+    def status(self) -> ProjectStatus:
+        if self._status is None:
+            self._status = collect_project_status(self.root, session_limit=0)
+        return self._status
+
+    @property
+    def config(self) -> dict[str, Any]:
+        if self._config is None:
+            path = self.root / ".claude-mpm" / "configuration.yaml"
+            try:
+                loaded = yaml.safe_load(path.read_text(encoding="utf-8"))
+            except (OSError, yaml.YAMLError):
+                loaded = None
+            self._config = loaded if isinstance(loaded, dict) else {}
+        return self._config
+
+
+def _require(
+    names: list[str], present: set[str], kind: str, minimum: int, count: int
+) -> tuple[bool, str]:
+    if names:
+        missing = [name for name in names if name not in present]
+        if missing:
+            return False, f"missing {kind}: {', '.join(missing)}"
+        return True, f"{len(names)} required {kind} present"
+    if count < minimum:
+        return False, f"{count} {kind} (need {minimum})"
+    return True, f"{count} {kind}"
+
+
+def _agents_deployed(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    agents = ctx.status.agents
+    # Deployed files are named after the agent id, frontmatter may differ
+    names = {agent["name"] for agent in agents}
+    names |= {p.stem for p in (ctx.root / ".claude" / "agents").glob("*.md")}
+    required = list(params.get("agents") or [])
+    return _require(required, names, "agents", int(params.get("min", 1)), len(agents))
+
+
+def _skills_deployed(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    names = {skill["name"] for skill in ctx.status.skills}
+    required = list(params.get("skills") or [])
+    minimum = int(params.get("min", 1))
+    return _require(required, names, "skills", minimum, len(names))
+
+
+def _hooks_installed(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    events = {hook["event"] for hook in ctx.status.hooks if hook["mpm"]}
+    required = list(params.get("events") or [])
+    return _require(required, events, "hook events", 1, len(events))
+
+
+def _mcp_configured(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    names = {server["name"] for server in ctx.status.mcp_servers}
+    required = list(params.get("servers") or [])
+    return _require(required, names, "MCP servers", 1, len(names))
+
+
+def _file(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    pattern = str(params.get("path") or "")
+    if not pattern:
+        return False, "no path given"
+    matches = sorted(p for p in ctx.root.glob(pattern) if p.is_file())
+    if not matches:
+        return False, f"no {pattern}"
+    contains = params.get("contains")
+    if not contains:
+        return True, matches[0].relative_to(ctx.root).as_posix()
+    regex = re.compile(str(contains))
+    for path in matches:
+        try:
+            if regex.search(path.read_text(encoding="utf-8", errors="replace")):
+                return True, f"{path.relative_to(ctx.root).as_posix()} matches"
+        except OSError:
+            continue
+    return False, f"no {pattern} mentions {contains}"
+
+
+def _config(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    key = str(params.get("key") or "")
+    value: Any = ctx.config
+    for part in key.split("."):
+        if not isinstance(value, dict) or value.get(part) is None:
+            return False, f"{key} not set"
+        value = value[part]
+    if "equals" in params and value != params["equals"]:
+        return False, f"{key} is {value!r}, expected {params['equals']!r}"
+    return True, f"{key} set"
+
+
+def _manual(ctx: _Context, params: dict[str, Any]) -> tuple[bool, str]:
+    record = ctx.manual.get(params["_id"])
+    if not isinstance(record, dict):
+        return False, "not marked done"
+    return True, f"marked done by {record.get('by')} on {record.get('at', '')[:10]}"
+
+
+CHECKS: dict[str, Callable[[_Context, dict[str, Any]], tuple[bool, str]]] = {
+    "agents_deployed": _agents_deployed,
+    "skills_deployed": _skills_deployed,
+    "hooks_installed": _hooks_installed,
+    "mcp_configured": _mcp_configured,
+    "file": _file,
+    "config": _config,
+    MANUAL: _manual,
+}
+
+
+class Checklist:
+    """The checklist of one project: its steps and manual step records."""
+
+    def __init__(self, project_root: Path | None = None):
+        self.project_root = Path(project_root or Path.cwd()).resolve()
+        self.path = self.project_root / CHECKLIST_FILE
+        self.state_path = self.project_root / STATE_FILE
+
+    def steps(self) -> list[Step]:
+        """The project's steps, or the default ones without a checklist.yaml."""
+        if not self.path.is_file():
+            return [Step.from_dict(step) for step in DEFAULT_STEPS]
+        try:
+            data = yaml.safe_load(self.path.read_text(encoding="utf-8")) or {}
+        except yaml.YAMLError as e:
+            raise ValueError(f"Invalid {CHECKLIST_FILE}: {e}") from e
+        entries = data.get("steps") if isinstance(data, dict) else None
+        if not isinstance(entries, list):
+            raise ValueError(f"{CHECKLIST_FILE} needs a list of steps")
+        steps = [Step.from_dict(entry) for entry in entries]
+        ids = [step.id for step in steps]
+        duplicates = sorted({i for i in ids if ids.count(i) > 1})
+        if duplicates:
+            raise ValueError(f"Duplicate checklist steps: {', '.join(duplicates)}")
+        return steps
+
+    def _manual_records(self) -> dict[str, Any]:
+        data = read_json(self.state_path, {})
+        steps = data.get("steps") if isinstance(data, dict) else None
+        return steps if isinstance(steps, dict) else {}
+
+    def status(self) -> ChecklistStatus:
+        steps = self.steps()
+        ctx = _Context(self.project_root, self._manual_records())
+        results = []
+        for step in steps:
+            check = CHECKS.get(step.check)
+            if check is None:
+                done, detail = False, f"unknown check '{step.check}'"
+            else:
+                try:
+                    done, detail = check(ctx, {**step.params, "_id": step.id})
+                except Exception as e:
+                    logger.debug(f"Checklist step {step.id} failed: {e}")
+                    done, detail = False, f"check failed: {e}"
+            results.append(
+                StepResult(step.id, step.title, step.check, done, detail, step.hint)
+            )
+        source = CHECKLIST_FILE.as_posix() if self.path.is_file() else "default steps"
+        return ChecklistStatus(self.project_root, source, results)
+
+    def mark(self, step_id: str, done: bool = True, note: str = "") -> Step:
+        """Record a manual step as done (or not done any more)."""
+        step = next((s for s in self.steps() if s.id == step_id), None)
+        if step is None:
+            raise ValueError(f"No checklist step '{step_id}'")
+        if step.check != MANUAL:
+            raise ValueError(
+                f"Step '{step_id}' is checked automatically ({step.check}); "
+                "only manual steps can be marked"
+            )
+        try:
+            user = getpass.getuser()
+        except Exception:
+            user = "unknown"
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            steps = data.setdefault("steps", {})
+            if done:
+                steps[step_id] = {
+                    "by": user,
+                    "at": datetime.now(UTC).isoformat(),
+                    "note": note,
+                }
+            else:
+                steps.pop(step_id, None)
+
+        update_json(self.state_path, record)
+        return step
+
+    def write_default(self) -> Path:
+        """Write the default steps to checklist.yaml as a starting point."""
+        if self.path.exists():
+            raise FileExistsError(f"{self.path} already exists")
+        self.path.parent.mkdir(parents=True, exist_ok=True)
+        self.path.write_text(
+            "# Onboarding checklist: see 'claude-mpm checklist --help'\n"
+            + yaml.safe_dump({"steps": DEFAULT_STEPS}, sort_keys=False),
+            encoding="utf-8",
+        )
+        return self.path
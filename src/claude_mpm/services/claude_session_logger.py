@@ -21,6 +21,8 @@ from typing import Any
 # Import configuration manager
 from claude_mpm.core.config import Config
 
+from claude_mpm.services import data_encryption
+
 # Import centralized session manager
 from claude_mpm.services.session_manager import get_session_manager
 
@@ -221,8 +223,11 @@ class ClaudeSessionLogger:
 
         # Save response
         try:
-            with file_path.open("w", encoding="utf-8") as f:
-                json.dump(response_data, f, indent=2, ensure_ascii=False)
+            content = json.dumps(response_data, indent=2, ensure_ascii=False)
+            file_path.write_text(
+                data_encryption.protect(content, data_encryption.TRANSCRIPTS),
+                encoding="utf-8",
+            )
 
             logger.debug(f"Logged response to {filename} for session {self.session_id}")
             return file_path
@@ -23,6 +23,7 @@ from datetime import UTC, datetime
 from pathlib import Path
 
 from claude_mpm.core.logger import get_logger
+from claude_mpm.services import data_encryption
 
 logger = get_logger(__name__)
 
@@ -96,8 +97,7 @@ class ResumeService:
 
         for response_file in self.responses_dir.glob("*.json"):
             try:
-                with response_file.open("r") as f:
-                    data = json.load(f)
+                data = json.loads(data_encryption.reveal(response_file.read_text()))
 
                 session_id = data.get("session_id", "unknown")
                 if session_id not in sessions_map:
@@ -116,8 +116,7 @@ class ResumeService:
                 files.sort(key=lambda p: p.stat().st_mtime, reverse=True)
                 latest_file = files[0]
 
-                with latest_file.open("r") as f:
-                    data = json.load(f)
+                data = json.loads(data_encryption.reveal(latest_file.read_text()))
 
                 metadata = data.get("metadata", {})
                 timestamp_str = data.get("timestamp") or metadata.get("timestamp")
@@ -215,8 +214,7 @@ class ResumeService:
         response_files = []
         for response_file in self.responses_dir.glob("*.json"):
             try:
-                with response_file.open("r") as f:
-                    data = json.load(f)
+                data = json.loads(data_encryption.reveal(response_file.read_text()))
                 if data.get("session_id") == session_id:
                     response_files.append(response_file)
             except Exception as e:
@@ -249,8 +247,7 @@ class ResumeService:
             # Use the last (most recent) file for primary data
             latest_file = response_files[-1]
 
-            with latest_file.open("r") as f:
-                latest_data = json.load(f)
+            latest_data = json.loads(data_encryption.reveal(latest_file.read_text()))
 
             metadata = latest_data.get("metadata", {})
             timestamp_str = latest_data.get("timestamp") or metadata.get("timestamp")
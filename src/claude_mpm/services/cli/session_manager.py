@@ -25,6 +25,7 @@ from pathlib import Path
 from typing import Any, Optional
 
 from claude_mpm.core.logger import get_logger
+from claude_mpm.core.state_files import merge_records
 
 
 # Interface Definition
@@ -215,6 +216,8 @@ class SessionManager(ISessionManager):
         self.config_service = config_service
         self.logger = get_logger("SessionManager")
         self._sessions_cache: dict[str, SessionInfo] = {}
+        # Sessions as last read from or written to disk (see _save_sessions)
+        self._baseline: dict[str, dict[str, Any]] = {}
         self._auto_save_task: asyncio.Task | None = None
         self._running = False
         self._load_sessions()
@@ -478,8 +481,14 @@ class SessionManager(ISessionManager):
             sessions_dict = {
                 sid: session.to_dict() for sid, session in self._sessions_cache.items()
             }
-            with session_file.open("w") as f:
-                json.dump(sessions_dict, f, indent=2)
+            # Write only what changed here; keep other processes' sessions
+            merged = merge_records(session_file, sessions_dict, self._baseline)
+            self._baseline = json.loads(json.dumps(merged))
+            for sid in self._sessions_cache.keys() - merged.keys():
+                del self._sessions_cache[sid]
+            for sid, data in merged.items():
+                if sid not in self._sessions_cache or sessions_dict.get(sid) != data:
+                    self._sessions_cache[sid] = SessionInfo.from_dict(data)
         except Exception as e:
             self.logger.error(f"Failed to save sessions: {e}")
 
@@ -495,6 +504,7 @@ class SessionManager(ISessionManager):
         try:
             with session_file.open() as f:
                 sessions_dict = json.load(f)
+            self._baseline = sessions_dict
 
             self._sessions_cache = {
                 sid: SessionInfo.from_dict(data) for sid, data in sessions_dict.items()
@@ -12,6 +12,8 @@ DESIGN DECISIONS:
 - Handles browser opening, process management, and status checking
 - Integrates with PortManager for port allocation
 - Thread-safe daemon management
+- start_server lets the shared daemon serve the project while it runs, so
+  ``run --monitor`` does not start a server of its own
 """
 
 import threading
@@ -19,6 +21,7 @@ import time
 import webbrowser
 from abc import ABC, abstractmethod
 from dataclasses import dataclass
+from pathlib import Path
 
 import requests
 
@@ -385,8 +388,20 @@ class UnifiedDashboardManager(IUnifiedDashboardManager):
             force_restart: If True, restart existing service if it's ours
 
         Returns:
-            Tuple of (success, DashboardInfo)
+            Tuple of (success, DashboardInfo); its port is the shared
+            daemon's server for this project when the daemon is running
         """
+        from ..shared_daemon import SharedDaemon
+
+        entry = SharedDaemon().serve_project(Path.cwd())
+        if entry:
+            self.logger.info(
+                f"Shared daemon serves this project on port {entry['port']}"
+            )
+            return True, DashboardInfo(
+                url=self.get_dashboard_url(entry["port"]), port=entry["port"]
+            )
+
         if port is None:
             port = self.find_available_port()
 
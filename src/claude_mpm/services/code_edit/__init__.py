@@ -0,0 +1,43 @@
+"""Syntax-aware code edits.
+
+WHAT: Public surface of the code edit tool: ``CodeEditor`` renames symbols,
+inserts methods and wraps functions by locating tree-sitter nodes (or Python
+``ast`` nodes when the tree-sitter grammar is missing), and refuses edits
+that would not parse.
+WHY:  String replacements break on repeated or near-identical text; agents
+need edits that land in the right definition or fail loudly.
+"""
+
+from __future__ import annotations
+
+from claude_mpm.services.code_edit.editor import (
+    BODY,
+    FUNCTION,
+    WRAP_TARGETS,
+    CodeEditor,
+    EditResult,
+)
+from claude_mpm.services.code_edit.syntax import (
+    LANGUAGES,
+    CodeEditError,
+    Definition,
+    LanguageSpec,
+    ParsedFile,
+    language_for,
+    parse_source,
+)
+
+__all__ = [
+    "BODY",
+    "FUNCTION",
+    "LANGUAGES",
+    "WRAP_TARGETS",
+    "CodeEditError",
+    "CodeEditor",
+    "Definition",
+    "EditResult",
+    "LanguageSpec",
+    "ParsedFile",
+    "language_for",
+    "parse_source",
+]
@@ -0,0 +1,413 @@
+"""Structural code edits: rename a symbol, insert a method, wrap a function.
+
+WHAT: ``CodeEditor`` makes edits located by syntax nodes rather than by
+matching text:
+
+- ``rename_symbol``: renames every identifier node with a name, in the file
+  or inside one definition, never touching strings or comments
+- ``insert_method``: adds a method to a class, interface, trait or impl
+  block (after a given member or at the end), re-indented to fit; for Go,
+  after the type's existing methods
+- ``wrap_function``: wraps a function's body (or the whole function) in a
+  template such as a try/except, re-indenting the wrapped code
+
+Every edit is re-parsed before anything is written and refused if it would
+leave the file with syntax errors. Results carry a unified diff, and
+``dry_run`` returns the diff without writing.
+
+WHY: String replacement edits fail when the old text is not unique, and
+succeed when they should not (renaming ``id`` inside ``valid``, dropping a
+method outside its class). Edits that know where definitions start and end
+are either structurally valid or not made.
+
+DESIGN DECISIONS:
+- Renames are by name, not by binding: an attribute of another object with
+  the same name is renamed too. ``within`` narrows the rename to one
+  definition, and a name that is already in use there is refused
+- Definitions are found by name or by ``Parent.name``; an ambiguous name is
+  an error listing the candidates' lines rather than a guess
+- Files that do not parse are not edited, since their structure is unknown
+- Paths are confined to the editor's root, like the patch engine's
+"""
+
+from __future__ import annotations
+
+import difflib
+import re
+import textwrap
+from dataclasses import asdict, dataclass
+from pathlib import Path
+from typing import Any
+
+from ...core.logger import get_logger
+from ...core.state_files import write_atomic
+from .syntax import (
+    FUNCTION_KINDS,
+    CodeEditError,
+    Definition,
+    LanguageSpec,
+    ParsedFile,
+    language_for,
+    parse_source,
+)
+
+logger = get_logger(__name__)
+
+IDENTIFIER_RE = re.compile(r"^[A-Za-z_$][A-Za-z0-9_$]*$")
+PLACEHOLDER = "{code}"
+BODY = "body"
+FUNCTION = "function"
+WRAP_TARGETS = (BODY, FUNCTION)
+
+
+@dataclass
+class EditResult:
+    path: str
+    operation: str
+    language: str
+    # "tree-sitter" or "ast"
+    backend: str
+    # Identifiers renamed, or 1 for an insert or wrap
+    changes: int
+    diff: str
+    dry_run: bool = False
+    written: bool = False
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+def _line_start(source: bytes, offset: int) -> int:
+    return source.rfind(b"\n", 0, offset) + 1
+
+
+def _line_end(source: bytes, offset: int) -> int:
+    """Offset just past the newline ending the line of *offset*."""
+    end = source.find(b"\n", offset)
+    return len(source) if end == -1 else end + 1
+
+
+def _indent_at(source: bytes, offset: int) -> str:
+    line = source[_line_start(source, offset) : _line_end(source, offset)]
+    text = line.decode("utf-8")
+    return text[: len(text) - len(text.lstrip())].rstrip("\r\n")
+
+
+def _indent_lines(code: str, indent: str, newline: str) -> str:
+    lines = code.split("\n")
+    return newline.join(indent + line if line.strip() else "" for line in lines)
+
+
+class CodeEditor:
+    """Makes syntax-aware edits to files under *root*."""
+
+    def __init__(self, root: Path | None = None):
+        self.root = Path(root or Path.cwd()).resolve()
+
+    # ------------------------------------------------------------------
+    # Operations
+    # ------------------------------------------------------------------
+
+    def definitions(self, path: str) -> list[dict[str, Any]]:
+        """The definitions in *path*, with their 1-based line ranges."""
+        _, parsed = self._load(path)
+        return [
+            {
+                "kind": d.kind,
+                "name": d.qualified_name,
+                "line": parsed.line_of(d.start),
+                "end_line": parsed.line_of(max(d.end - 1, d.start)),
+            }
+            for d in parsed.definitions
+        ]
+
+    def rename_symbol(
+        self,
+        path: str,
+        old_name: str,
+        new_name: str,
+        within: str | None = None,
+        dry_run: bool = False,
+    ) -> EditResult:
+        """Rename the identifiers called *old_name*, optionally only inside the
+        definition *within*.
+
+        Raises:
+            CodeEditError: If the new name is invalid or taken, or there is
+                nothing to rename
+        """
+        target, parsed = self._load(path)
+        if not IDENTIFIER_RE.match(new_name):
+            raise CodeEditError(f"Not a valid identifier: {new_name!r}")
+        if old_name == new_name:
+            raise CodeEditError("The new name is the same as the old one")
+        first, last = 0, len(parsed.source)
+        scope = "the file"
+        if within:
+            definition = self._find(parsed, within)
+            first, last = definition.start, definition.end
+            scope = definition.qualified_name
+        in_scope = [i for i in parsed.identifiers if first <= i.start < last]
+        if any(i.name == new_name for i in in_scope):
+            raise CodeEditError(f"{new_name} is already used in {scope}")
+        matches = [i for i in in_scope if i.name == old_name]
+        if not matches:
+            raise CodeEditError(f"No identifier named {old_name} in {scope}")
+        source = parsed.source
+        replacement = new_name.encode("utf-8")
+        for identifier in reversed(matches):
+            source = (
+                source[: identifier.start] + replacement + source[identifier.end :]
+            )
+        return self._finish(
+            target, parsed, source, "rename_symbol", len(matches), dry_run
+        )
+
+    def insert_method(
+        self,
+        path: str,
+        container: str,
+        code: str,
+        after: str | None = None,
+        dry_run: bool = False,
+    ) -> EditResult:
+        """Insert *code* into *container*, after its member *after* or last.
+
+        Raises:
+            CodeEditError: If the container or member is not found, or the
+                container's body cannot take a new member
+        """
+        target, parsed = self._load(path)
+        spec = language_for(path)
+        source = parsed.source
+        newline = "\r\n" if b"\r\n" in source else "\n"
+        holder = self._find(parsed, container, kinds=spec.containers, first=True)
+        members = [
+            d
+            for d in parsed.definitions
+            if d.parent == holder.name
+            and (spec.members_outside or holder.start <= d.start < holder.end)
+        ]
+        code = textwrap.dedent(code.replace("\r\n", "\n")).strip("\n")
+
+        anchor = None
+        if after:
+            anchor = next((m for m in members if m.name == after), None)
+            if anchor is None:
+                raise CodeEditError(f"{holder.qualified_name} has no member {after}")
+        elif spec.members_outside:
+            anchor = members[-1] if members else holder
+
+        if anchor is not None:
+            indent = _indent_at(source, anchor.start)
+            at = _line_end(source, anchor.end)
+            insertion = newline + _indent_lines(code, indent, newline) + newline
+            if at == len(source) and not source.endswith(b"\n"):
+                insertion = newline + insertion
+            source = source[:at] + insertion.encode("utf-8") + source[at:]
+        elif holder.block is None:
+            raise CodeEditError(f"{holder.qualified_name} has no body")
+        else:
+            source = self._append_member(source, spec, holder, code, newline)
+        return self._finish(target, parsed, source, "insert_method", 1, dry_run)
+
+    def wrap_function(
+        self,
+        path: str,
+        function: str,
+        template: str,
+        target: str = BODY,
+        dry_run: bool = False,
+    ) -> EditResult:
+        """Wrap *function*'s body, or the whole function, in *template*.
+
+        The template is code with a line holding only ``{code}`` where the
+        wrapped lines go, indented as that line is.
+
+        Raises:
+            CodeEditError: If the template has no ``{code}`` line, or the
+                function is not found or its body shares a line with code
+                outside it
+        """
+        if target not in WRAP_TARGETS:
+            raise CodeEditError(f"Target must be one of {', '.join(WRAP_TARGETS)}")
+        template = textwrap.dedent(template).strip("\r\n").replace("\r\n", "\n")
+        placeholders = [
+            line for line in template.split("\n") if line.strip() == PLACEHOLDER
+        ]
+        if len(placeholders) != 1:
+            raise CodeEditError(
+                f"The template needs exactly one line holding only {PLACEHOLDER}"
+            )
+        path_obj, parsed = self._load(path)
+        definition = self._find(parsed, function, kinds=FUNCTION_KINDS)
+        if target == FUNCTION:
+            region = (definition.start, definition.end)
+        elif definition.statements is None:
+            raise CodeEditError(f"{definition.qualified_name} has an empty body")
+        else:
+            region = definition.statements
+
+        source = parsed.source
+        newline = "\r\n" if b"\r\n" in source else "\n"
+        first = _line_start(source, region[0])
+        last = _line_end(source, region[1])
+        if source[first : region[0]].strip() or source[region[1] : last].strip():
+            raise CodeEditError(
+                f"The {target} of {definition.qualified_name} shares a line with "
+                "other code; put it on its own lines first"
+            )
+        indent = _indent_at(source, region[0])
+        wrapped = textwrap.dedent(
+            source[first:last].decode("utf-8").replace("\r\n", "\n")
+        ).rstrip("\n")
+        lines = []
+        for line in template.split("\n"):
+            if line.strip() == PLACEHOLDER:
+                inner = indent + line[: len(line) - len(line.lstrip())]
+                lines.append(_indent_lines(wrapped, inner, newline))
+            else:
+                lines.append(indent + line if line.strip() else "")
+        text = newline.join(lines)
+        if source[first:last].endswith(b"\n"):
+            text += newline
+        source = source[:first] + text.encode("utf-8") + source[last:]
+        return self._finish(path_obj, parsed, source, "wrap_function", 1, dry_run)
+
+    # ------------------------------------------------------------------
+    # Helpers
+    # ------------------------------------------------------------------
+
+    def _load(self, path: str) -> tuple[Path, ParsedFile]:
+        target = (self.root / path).resolve()
+        if not target.is_relative_to(self.root):
+            raise CodeEditError(f"{path} is outside {self.root}")
+        spec = language_for(path)
+        if not target.is_file():
+            raise CodeEditError(f"File not found: {path}")
+        source = target.read_bytes()
+        try:
+            source.decode("utf-8")
+        except UnicodeDecodeError as e:
+            raise CodeEditError(f"{path} is not a UTF-8 text file") from e
+        parsed = parse_source(source, spec)
+        if parsed.has_error:
+            raise CodeEditError(
+                f"{path} has syntax errors; fix them before editing it structurally"
+            )
+        return target, parsed
+
+    def _find(
+        self,
+        parsed: ParsedFile,
+        name: str,
+        kinds: frozenset[str] | None = None,
+        first: bool = False,
+    ) -> Definition:
+        """The definition called *name* (or ``Parent.name``) of one of *kinds*.
+
+        With *first*, the first of several matches is used (a Rust type's
+        first impl block); otherwise several matches are an error.
+        """
+        parent, _, short = name.rpartition(".")
+        found = [
+            d
+            for d in parsed.definitions
+            if d.name == short
+            and (not parent or d.parent == parent)
+            and (kinds is None or d.kind in kinds)
+        ]
+        if not found:
+            wanted = " or ".join(sorted(kinds)) if kinds else "definition"
+            raise CodeEditError(f"No {wanted} named {name}")
+        if len(found) > 1 and not first:
+            where = ", ".join(
+                f"{d.qualified_name} (line {parsed.line_of(d.start)})" for d in found
+            )
+            raise CodeEditError(
+                f"{name} is ambiguous: {where}; qualify it as Parent.name"
+            )
+        return found[0]
+
+    def _append_member(
+        self,
+        source: bytes,
+        spec: LanguageSpec,
+        holder: Definition,
+            code: str,
+        newline: str,
+    ) -> bytes:
+        """Insert *code* as the last member of *holder*'s body."""
+        assert holder.block is not None
+        start, end = holder.block
+        braced = source[end - 1 : end] == b"}"
+        if holder.statements is not None:
+            indent = _indent_at(source, holder.statements[0])
+        else:
+            outer = _indent_at(source, holder.start)
+            indent = outer + ("\t" if "\t" in outer else spec.indent)
+        body = _indent_lines(code, indent, newline)
+
+        if not braced:
+            # An indented block ends with its last statement
+            at = _line_end(source, end)
+            insertion = newline + body + newline
+            if at == len(source) and not source.endswith(b"\n"):
+                insertion = newline + insertion
+            return source[:at] + insertion.encode("utf-8") + source[at:]
+
+        close = end - 1
+        if not source[start + 1 : close].strip():
+            outer = _indent_at(source, holder.start)
+            text = newline + body + newline + outer
+            return source[: start + 1] + text.encode("utf-8") + source[close:]
+        line = _line_start(source, close)
+        if source[line:close].strip():
+            raise CodeEditError(
+                f"The closing brace of {holder.qualified_name} shares a line with "
+                "other code; put it on its own line first"
+            )
+        text = newline + body + newline
+        return source[:line] + text.encode("utf-8") + source[line:]
+
+    def _finish(
+        self,
+        target: Path,
+        parsed: ParsedFile,
+        source: bytes,
+        operation: str,
+        changes: int,
+        dry_run: bool,
+    ) -> EditResult:
+        """Check the edited source parses, then write it unless *dry_run*."""
+        relative = str(target.relative_to(self.root))
+        edited = parse_source(source, language_for(relative))
+        if edited.has_error:
+            raise CodeEditError(
+                f"The edit would leave {relative} with syntax errors; nothing "
+                "was written"
+            )
+        old_text = parsed.source.decode("utf-8")
+        new_text = source.decode("utf-8")
+        diff = "".join(
+            difflib.unified_diff(
+                old_text.splitlines(keepends=True),
+                new_text.splitlines(keepends=True),
+                fromfile=f"a/{relative}",
+                tofile=f"b/{relative}",
+            )
+        )
+        result = EditResult(
+            path=relative,
+            operation=operation,
+            language=parsed.language,
+            backend=parsed.backend,
+            changes=changes,
+            diff=diff,
+            dry_run=dry_run,
+        )
+        if not dry_run:
+            write_atomic(target, new_text)
+            result.written = True
+            logger.info(f"{operation}: edited {relative}")
+        return result
@@ -0,0 +1,436 @@
+"""Syntax trees for the code edit tool.
+
+WHAT: ``parse_source`` turns a file into a ``ParsedFile``: its definitions
+(functions, methods, classes, structs, impl blocks, ...) with their byte
+ranges and bodies, and its identifier nodes. That is all the edit
+operations need, whichever parser produced it.
+
+Trees come from tree-sitter. Each language's grammar is its own package
+(tree-sitter-python, tree-sitter-go, ...); Python files fall back to the
+standard library's ``ast`` and ``tokenize`` when tree-sitter-python is not
+installed, so the most common case works without extra packages.
+
+DESIGN DECISIONS:
+- Offsets are byte offsets into the UTF-8 source, as tree-sitter reports
+  them; the ``ast`` fallback converts its positions to match
+- Definition kinds are per language ("class", "impl", "type", ...); each
+  ``LanguageSpec`` says which kinds methods can be inserted into
+- A leading Python docstring is not part of a body, so wrapping a body
+  keeps the docstring where tools look for it
+"""
+
+from __future__ import annotations
+
+import ast
+import importlib
+import importlib.util
+import io
+import keyword
+import token
+import tokenize
+from dataclasses import dataclass, field
+from typing import Any
+
+from ...core.logger import get_logger
+
+logger = get_logger(__name__)
+
+FUNCTION_KINDS = frozenset({"function", "method"})
+
+
+class CodeEditError(Exception):
+    """An edit that cannot be made; nothing was written."""
+
+
+@dataclass(frozen=True)
+class LanguageSpec:
+    """How one language's tree-sitter grammar names the nodes edits use."""
+
+    name: str
+    # Grammar package, and the function in it returning the language
+    module: str
+    suffixes: tuple[str, ...]
+    # Node type -> definition kind
+    definitions: dict[str, str]
+    # Kinds methods are inserted into
+    containers: frozenset[str]
+    identifiers: frozenset[str]
+    language_function: str = "language"
+    indent: str = "    "
+    # Field holding the name, where it is not "name"
+    name_fields: dict[str, str] = field(default_factory=dict)
+    # Parent node types a definition's range extends to (decorators, export)
+    wrappers: frozenset[str] = frozenset()
+    # Go declares methods after the type rather than inside it
+    members_outside: bool = False
+
+
+_JS_DEFINITIONS = {
+    "function_declaration": "function",
+    "generator_function_declaration": "function",
+    "method_definition": "method",
+    "class_declaration": "class",
+}
+_JS_IDENTIFIERS = frozenset(
+    {
+        "identifier",
+        "property_identifier",
+        "shorthand_property_identifier",
+        "shorthand_property_identifier_pattern",
+    }
+)
+_TYPED_IDENTIFIERS = frozenset({"identifier", "field_identifier", "type_identifier"})
+_TS_DEFINITIONS = {
+    **_JS_DEFINITIONS,
+    "abstract_class_declaration": "class",
+    "interface_declaration": "interface",
+}
+
+LANGUAGES: dict[str, LanguageSpec] = {
+    spec.name: spec
+    for spec in (
+        LanguageSpec(
+            name="python",
+            module="tree_sitter_python",
+            suffixes=(".py", ".pyi"),
+            definitions={
+                "function_definition": "function",
+                "class_definition": "class",
+            },
+            containers=frozenset({"class"}),
+            identifiers=frozenset({"identifier"}),
+            wrappers=frozenset({"decorated_definition"}),
+        ),
+        LanguageSpec(
+            name="javascript",
+            module="tree_sitter_javascript",
+            suffixes=(".js", ".mjs", ".cjs", ".jsx"),
+            definitions=_JS_DEFINITIONS,
+            containers=frozenset({"class"}),
+            identifiers=_JS_IDENTIFIERS,
+            wrappers=frozenset({"export_statement"}),
+        ),
+        LanguageSpec(
+            name="typescript",
+            module="tree_sitter_typescript",
+            language_function="language_typescript",
+            suffixes=(".ts", ".mts", ".cts"),
+            definitions=_TS_DEFINITIONS,
+            containers=frozenset({"class", "interface"}),
+            identifiers=_JS_IDENTIFIERS | {"type_identifier"},
+            wrappers=frozenset({"export_statement"}),
+        ),
+        LanguageSpec(
+            name="tsx",
+            module="tree_sitter_typescript",
+            language_function="language_tsx",
+            suffixes=(".tsx",),
+            definitions=_TS_DEFINITIONS,
+            containers=frozenset({"class", "interface"}),
+            identifiers=_JS_IDENTIFIERS | {"type_identifier"},
+            wrappers=frozenset({"export_statement"}),
+        ),
+        LanguageSpec(
+            name="go",
+            module="tree_sitter_go",
+            suffixes=(".go",),
+            definitions={
+                "function_declaration": "function",
+                "method_declaration": "method",
+                "type_spec": "type",
+            },
+            containers=frozenset({"type"}),
+            identifiers=_TYPED_IDENTIFIERS,
+            indent="\t",
+            wrappers=frozenset({"type_declaration"}),
+            members_outside=True,
+        ),
+        LanguageSpec(
+            name="rust",
+            module="tree_sitter_rust",
+            suffixes=(".rs",),
+            definitions={
+                "function_item": "function",
+                "function_signature_item": "function",
+                "struct_item": "struct",
+                "enum_item": "enum",
+                "trait_item": "trait",
+                "impl_item": "impl",
+            },
+            containers=frozenset({"impl", "trait"}),
+            identifiers=_TYPED_IDENTIFIERS,
+            name_fields={"impl_item": "type"},
+        ),
+    )
+}
+
+
+def language_for(path: str) -> LanguageSpec:
+    """The language of *path*, from its suffix.
+
+    Raises:
+        CodeEditError: If no supported language uses the suffix
+    """
+    for spec in LANGUAGES.values():
+        if path.endswith(spec.suffixes):
+            return spec
+    suffixes = sorted(s for spec in LANGUAGES.values() for s in spec.suffixes)
+    raise CodeEditError(
+        f"Unsupported file type: {path} (supported: {', '.join(suffixes)})"
+    )
+
+
+@dataclass
+class Definition:
+    """A function, class or other named definition; offsets are bytes."""
+
+    kind: str
+    name: str
+    # The whole definition, including decorators and ``export``
+    start: int
+    end: int
+    # Enclosing definition's name; for Go methods, the receiver type
+    parent: str | None = None
+    # The body node (a Python block, or braces and all)
+    block: tuple[int, int] | None = None
+    # From the first to the last statement or member of the body, None when
+    # the body is empty
+    statements: tuple[int, int] | None = None
+
+    @property
+    def qualified_name(self) -> str:
+        return f"{self.parent}.{self.name}" if self.parent else self.name
+
+
+@dataclass
+class Identifier:
+    name: str
+    start: int
+    end: int
+
+
+@dataclass
+class ParsedFile:
+    source: bytes
+    language: str
+    # "tree-sitter" or "ast"
+    backend: str
+    has_error: bool = False
+    definitions: list[Definition] = field(default_factory=list)
+    identifiers: list[Identifier] = field(default_factory=list)
+
+    def line_of(self, offset: int) -> int:
+        """1-based line number of byte *offset*."""
+        return self.source.count(b"\n", 0, offset) + 1
+
+
+_PARSERS: dict[str, Any] = {}
+
+
+def _tree_sitter_parser(spec: LanguageSpec) -> Any | None:
+    """A parser for *spec*, or None when tree-sitter or its grammar is missing."""
+    if spec.name in _PARSERS:
+        return _PARSERS[spec.name]
+    parser = None
+    if importlib.util.find_spec("tree_sitter") and importlib.util.find_spec(
+        spec.module
+    ):
+        try:
+            import tree_sitter
+
+            module = importlib.import_module(spec.module)
+            language = tree_sitter.Language(getattr(module, spec.language_function)())
+            parser = tree_sitter.Parser()
+            # tree-sitter < 0.22 sets the language after construction
+            if hasattr(parser, "set_language"):
+                parser.set_language(language)
+            else:
+                parser = tree_sitter.Parser(language)
+        except Exception as e:
+            logger.debug(f"tree-sitter grammar for {spec.name} unusable: {e}")
+            parser = None
+    _PARSERS[spec.name] = parser
+    return parser
+
+
+def parse_source(source: bytes, spec: LanguageSpec) -> ParsedFile:
+    """Parse *source* with tree-sitter, or ``ast`` for Python without it.
+
+    Raises:
+        CodeEditError: If no parser is available for the language
+    """
+    parser = _tree_sitter_parser(spec)
+    if parser is not None:
+        return _parse_tree_sitter(parser, spec, source)
+    if spec.name == "python":
+        return _parse_python(source)
+    package = spec.module.replace("_", "-")
+    raise CodeEditError(
+        f"No tree-sitter grammar for {spec.name}; install it with "
+        f"'pip install {package}' (or 'claude-mpm[code-edit]' for all of them)"
+    )
+
+
+def _text(source: bytes, node: Any) -> str:
+    return source[node.start_byte : node.end_byte].decode("utf-8")
+
+
+def _first_of_type(node: Any, types: frozenset[str]) -> Any | None:
+    """*node* or its first descendant whose type is in *types*."""
+    stack = [node]
+    while stack:
+        current = stack.pop()
+        if current.type in types:
+            return current
+        stack.extend(reversed(current.children))
+    return None
+
+
+def _is_docstring(spec: LanguageSpec, node: Any) -> bool:
+    return (
+        spec.name == "python"
+        and node.type == "expression_statement"
+        and node.named_child_count == 1
+        and node.named_children[0].type == "string"
+    )
+
+
+def _body_ranges(
+    spec: LanguageSpec, node: Any
+) -> tuple[tuple[int, int] | None, tuple[int, int] | None]:
+    body = node.child_by_field_name("body")
+    if body is None:
+        return None, None
+    members = list(body.named_children)
+    # Newer Go grammars hold a block's statements in a statement_list
+    if len(members) == 1 and members[0].type == "statement_list":
+        members = list(members[0].named_children)
+    if members and _is_docstring(spec, members[0]):
+        members = members[1:]
+    statements = (members[0].start_byte, members[-1].end_byte) if members else None
+    return (body.start_byte, body.end_byte), statements
+
+
+def _definition(
+    spec: LanguageSpec, node: Any, kind: str, parent: str | None, source: bytes
+) -> Definition | None:
+    name_node = node.child_by_field_name(spec.name_fields.get(node.type, "name"))
+    if name_node is None:
+        return None
+    if name_node.type not in spec.identifiers:
+        # impl<T> Stack<T>: the type's own name
+        name_node = _first_of_type(name_node, spec.identifiers)
+        if name_node is None:
+            return None
+    if node.type == "impl_item" and node.child_by_field_name("trait") is not None:
+        kind = "trait_impl"
+    if node.type == "method_declaration":
+        receiver = node.child_by_field_name("receiver")
+        found = receiver and _first_of_type(receiver, frozenset({"type_identifier"}))
+        parent = _text(source, found) if found else parent
+    outer = node
+    while outer.parent is not None and outer.parent.type in spec.wrappers:
+        outer = outer.parent
+    block, statements = _body_ranges(spec, node)
+    return Definition(
+        kind=kind,
+        name=_text(source, name_node),
+        start=outer.start_byte,
+        end=outer.end_byte,
+        parent=parent,
+        block=block,
+        statements=statements,
+    )
+
+
+def _parse_tree_sitter(parser: Any, spec: LanguageSpec, source: bytes) -> ParsedFile:
+    tree = parser.parse(source)
+    parsed = ParsedFile(
+        source=source,
+        language=spec.name,
+        backend="tree-sitter",
+        has_error=tree.root_node.has_error,
+    )
+    stack: list[tuple[Any, str | None]] = [(tree.root_node, None)]
+    while stack:
+        node, parent = stack.pop()
+        kind = spec.definitions.get(node.type)
+        if kind:
+            definition = _definition(spec, node, kind, parent, source)
+            if definition is not None:
+                parsed.definitions.append(definition)
+                parent = definition.name
+        elif node.type in spec.identifiers:
+            parsed.identifiers.append(
+                Identifier(_text(source, node), node.start_byte, node.end_byte)
+            )
+        stack.extend((child, parent) for child in reversed(node.children))
+    parsed.definitions.sort(key=lambda d: d.start)
+    return parsed
+
+
+def _parse_python(source: bytes) -> ParsedFile:
+    """The ``ast`` fallback: definitions from ``ast``, identifiers from the
+    NAME tokens (which leaves out strings and comments, as tree-sitter does)."""
+    parsed = ParsedFile(source=source, language="python", backend="ast")
+    try:
+        tree = ast.parse(source)
+    except (SyntaxError, ValueError):
+        parsed.has_error = True
+        return parsed
+
+    line_starts = [0]
+    for line in source.split(b"\n")[:-1]:
+        line_starts.append(line_starts[-1] + len(line) + 1)
+
+    def offset(lineno: int, col: int) -> int:
+        # ast columns are already UTF-8 byte offsets
+        return line_starts[lineno - 1] + col
+
+    def visit(node: ast.AST, parent: str | None) -> None:
+        for child in ast.iter_child_nodes(node):
+            if not isinstance(
+                child, (ast.FunctionDef, ast.AsyncFunctionDef, ast.ClassDef)
+            ):
+                visit(child, parent)
+                continue
+            first = child.decorator_list[0] if child.decorator_list else child
+            start = offset(first.lineno, first.col_offset)
+            if child.decorator_list:
+                start -= 1  # The "@"
+            body = child.body
+            members = body[1:] if ast.get_docstring(child, clean=False) else body
+            parsed.definitions.append(
+                Definition(
+                    kind="class" if isinstance(child, ast.ClassDef) else "function",
+                    name=child.name,
+                    start=start,
+                    end=offset(child.end_lineno, child.end_col_offset),
+                    parent=parent,
+                    block=(
+                        offset(body[0].lineno, body[0].col_offset),
+                        offset(body[-1].end_lineno, body[-1].end_col_offset),
+                    ),
+                    statements=(
+                        offset(members[0].lineno, members[0].col_offset),
+                        offset(members[-1].end_lineno, members[-1].end_col_offset),
+                    )
+                    if members
+                    else None,
+                )
+            )
+            visit(child, child.name)
+
+    visit(tree, None)
+    parsed.definitions.sort(key=lambda d: d.start)
+
+    lines = source.decode("utf-8").split("\n")
+    for tok in tokenize.tokenize(io.BytesIO(source).readline):
+        if tok.type != token.NAME or keyword.iskeyword(tok.string):
+            continue
+        row, col = tok.start
+        start = line_starts[row - 1] + len(lines[row - 1][:col].encode("utf-8"))
+        parsed.identifiers.append(
+            Identifier(tok.string, start, start + len(tok.string.encode("utf-8")))
+        )
+    return parsed
@@ -0,0 +1,438 @@
+"""Propose resolutions for merge conflicts with one agent per conflict.
+
+WHAT: ``claude-mpm resolve-conflicts`` finds the files git reports as
+conflicted, splits each into its ``<<<<<<<`` / ``>>>>>>>`` regions and asks
+an agent to resolve every region. The agent sees both sides (and the base,
+with ``merge.conflictStyle=diff3``), the rest of the file, and the commits
+that touched the file on each side since the merge base. Its answer is a
+proposed replacement for the region, shown to the user as a diff; nothing is
+written until the user accepts it. The diff shown is against our side of
+the conflict, i.e. what the resolution changes on the current branch.
+
+WHY: Most conflicts are two intentions that both need to survive (a rename on
+one side, a new call on the other). Reading the history of both sides is
+what a reviewer does to untangle them, and is tedious by hand.
+
+DESIGN DECISIONS:
+- One agent per conflict region, not per file: regions are independent and
+  small prompts keep answers focused; regions run concurrently
+- Agents run in plan permission mode in the repository, so they can read
+  code for context but cannot edit it
+- The agent answers with a fenced ``resolution`` block; anything else (or a
+  block that still has conflict markers) is reported as a failed proposal
+- Applying rewrites only accepted regions; rejected regions keep their
+  markers, and a file is staged only when no markers are left
+- A file that changed since the proposals were made is not touched
+"""
+
+from __future__ import annotations
+
+import asyncio
+import difflib
+import re
+import subprocess  # nosec B404 - runs git only
+from collections.abc import Callable
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from .agents.agent_runtime import AgentConfig
+
+logger = get_logger(__name__)
+
+# Refs git leaves while a merge-like operation stops on conflicts, in the
+# order they are checked
+OPERATION_HEADS = ("MERGE_HEAD", "CHERRY_PICK_HEAD", "REVERT_HEAD", "REBASE_HEAD")
+HISTORY_LIMIT = 5
+
+SYSTEM_PROMPT = """\
+You resolve git merge conflicts. You are given one conflict region of a file:
+both sides, the common base when available, the whole file, and the recent
+commits on each side. Keep the intent of both sides unless one side clearly
+supersedes the other. You may read other files in the repository for context
+but must not edit anything.
+
+Reply with one short paragraph explaining the resolution, followed by the
+exact text that replaces the whole conflict region (markers included) in a
+fenced block tagged resolution:
+
+```resolution
+<replacement lines>
+```
+
+The block must not contain conflict markers. Leave it empty to delete the
+region."""
+
+_RESOLUTION_RE = re.compile(r"```resolution[^\n]*\n(.*?)^```", re.DOTALL | re.MULTILINE)
+_MARKER_RE = re.compile(r"^(<{7}|={7}|>{7}|\|{7})(?: |$)", re.MULTILINE)
+
+
+class ConflictError(Exception):
+    """Raised when conflicts cannot be read or resolutions cannot be applied."""
+
+
+@dataclass
+class ConflictHunk:
+    """One ``<<<<<<< ... >>>>>>>`` region; lines are 0-based, end exclusive."""
+
+    index: int
+    start: int
+    end: int
+    ours: list[str]
+    theirs: list[str]
+    base: list[str] | None = None
+    ours_label: str = ""
+    theirs_label: str = ""
+
+    @property
+    def header(self) -> str:
+        return f"conflict {self.index + 1} (lines {self.start + 1}-{self.end})"
+
+
+@dataclass
+class ConflictedFile:
+    path: str
+    text: str
+    hunks: list[ConflictHunk]
+
+
+@dataclass
+class SideHistory:
+    """Commits that touched a file on one side since the merge base."""
+
+    ref: str
+    commits: list[str] = field(default_factory=list)
+
+
+@dataclass
+class Proposal:
+    """An agent's proposed replacement for one conflict region."""
+
+    path: str
+    hunk: ConflictHunk
+    resolution: str | None
+    explanation: str = ""
+    error: str | None = None
+
+    @property
+    def ok(self) -> bool:
+        return self.error is None and self.resolution is not None
+
+    def diff(self) -> str:
+        """Unified diff from our side of the conflict to the proposed text."""
+        if not self.ok:
+            return ""
+        lines = difflib.unified_diff(
+            self.hunk.ours,
+            self.resolution.splitlines(keepends=True),
+            fromfile=f"a/{self.path} ({self.hunk.ours_label or 'ours'})",
+            tofile=f"b/{self.path} (resolved)",
+        )
+        return "".join(line if line.endswith("\n") else f"{line}\n" for line in lines)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "path": self.path,
+            "conflict": self.hunk.index + 1,
+            "start_line": self.hunk.start + 1,
+            "end_line": self.hunk.end,
+            "resolution": self.resolution,
+            "explanation": self.explanation,
+            "error": self.error,
+        }
+
+
+def parse_conflicts(text: str) -> list[ConflictHunk]:
+    """Find the conflict regions in a file's text.
+
+    Raises:
+        ConflictError: If a region is not terminated or is malformed
+    """
+    hunks: list[ConflictHunk] = []
+    lines = text.splitlines(keepends=True)
+    i = 0
+    while i < len(lines):
+        if not lines[i].startswith("<<<<<<<"):
+            i += 1
+            continue
+        start = i
+        ours_label = lines[i][7:].strip()
+        sections: dict[str, list[str]] = {"ours": []}
+        current = "ours"
+        theirs_label = None
+        i += 1
+        while i < len(lines):
+            line = lines[i]
+            if line.startswith("|||||||") and current == "ours":
+                current = "base"
+                sections[current] = []
+            elif line.startswith("=======") and current in ("ours", "base"):
+                current = "theirs"
+                sections[current] = []
+            elif line.startswith(">>>>>>>") and current == "theirs":
+                theirs_label = line[7:].strip()
+                break
+            elif line.startswith("<<<<<<<"):
+                raise ConflictError(f"nested conflict marker at line {i + 1}")
+            else:
+                sections[current].append(line)
+            i += 1
+        if theirs_label is None:
+            raise ConflictError(f"conflict at line {start + 1} is not terminated")
+        i += 1
+        hunks.append(
+            ConflictHunk(
+                index=len(hunks),
+                start=start,
+                end=i,
+                ours=sections["ours"],
+                theirs=sections["theirs"],
+                base=sections.get("base"),
+                ours_label=ours_label,
+                theirs_label=theirs_label,
+            )
+        )
+    return hunks
+
+
+def parse_resolution(reply: str) -> tuple[str, str]:
+    """Split an agent reply into (resolution text, explanation).
+
+    Raises:
+        ConflictError: If there is no resolution block or it has markers
+    """
+    match = _RESOLUTION_RE.search(reply)
+    if match is None:
+        raise ConflictError("reply has no ```resolution block")
+    resolution = match.group(1)
+    if _MARKER_RE.search(resolution):
+        raise ConflictError("proposed resolution still contains conflict markers")
+    explanation = (reply[: match.start()] + reply[match.end() :]).strip()
+    return resolution, explanation
+
+
+def apply_resolutions(text: str, resolutions: dict[int, str]) -> str:
+    """Replace the conflict regions named by index; others keep their markers."""
+    lines = text.splitlines(keepends=True)
+    for hunk in reversed(parse_conflicts(text)):
+        if hunk.index in resolutions:
+            replacement = resolutions[hunk.index]
+            if replacement and not replacement.endswith("\n"):
+                replacement += "\n"
+            lines[hunk.start : hunk.end] = replacement.splitlines(keepends=True)
+    return "".join(lines)
+
+
+def _git(repo: Path, *args: str, check: bool = True) -> str:
+    try:
+        result = subprocess.run(  # nosec B603 B607 - Safe: fixed git executable
+            ["git", *args],
+            cwd=repo,
+            capture_output=True,
+            text=True,
+            check=check,
+            timeout=30,
+        )
+    except subprocess.CalledProcessError as e:
+        raise ConflictError(f"git {args[0]} failed: {e.stderr.strip()}") from e
+    except (OSError, subprocess.TimeoutExpired) as e:
+        raise ConflictError(f"git {args[0]} failed: {e}") from e
+    return result.stdout if result.returncode == 0 else ""
+
+
+def _default_runtime_factory(config: AgentConfig):
+    from .agents.runtime_config import get_runtime
+
+    return get_runtime(config)
+
+
+class ConflictResolver:
+    """Finds conflicts in a repository and asks agents to resolve them."""
+
+    def __init__(
+        self,
+        repo: Path | None = None,
+        runtime_factory: Callable[[AgentConfig], Any] | None = None,
+        model: str | None = None,
+        max_parallel: int = 4,
+    ):
+        self.runtime_factory = runtime_factory or _default_runtime_factory
+        self.model = model
+        self.max_parallel = max(1, max_parallel)
+        start = Path(repo or Path.cwd())
+        self.repo = Path(_git(start, "rev-parse", "--show-toplevel").strip())
+
+    # ------------------------------------------------------------------
+    # Discovery
+    # ------------------------------------------------------------------
+
+    def conflicted_paths(self) -> list[str]:
+        """Paths git reports as unmerged, relative to the repository root."""
+        output = _git(self.repo, "diff", "--name-only", "--diff-filter=U")
+        return sorted({line for line in output.splitlines() if line})
+
+    def load(self, paths: list[str] | None = None) -> list[ConflictedFile]:
+        """Read conflicted files; paths without markers (e.g. binary) are skipped."""
+        unmerged = self.conflicted_paths()
+        if paths:
+            wanted = {self._relative(p) for p in paths}
+            missing = sorted(wanted - set(unmerged))
+            if missing:
+                raise ConflictError(f"not in conflict: {', '.join(missing)}")
+            unmerged = [p for p in unmerged if p in wanted]
+
+        files = []
+        for path in unmerged:
+            try:
+                text = (self.repo / path).read_text(encoding="utf-8")
+            except (OSError, UnicodeDecodeError) as e:
+                logger.info(f"Skipping {path}: {e}")
+                continue
+            hunks = parse_conflicts(text)
+            if hunks:
+                files.append(ConflictedFile(path=path, text=text, hunks=hunks))
+        return files
+
+    def _relative(self, path: str) -> str:
+        resolved = (Path.cwd() / path).resolve()
+        if not resolved.is_relative_to(self.repo.resolve()):
+            raise ConflictError(f"{path} is outside the repository")
+        return resolved.relative_to(self.repo.resolve()).as_posix()
+
+    def operation_head(self) -> str | None:
+        """The ref being merged in (MERGE_HEAD, REBASE_HEAD, ...), if any."""
+        for ref in OPERATION_HEADS:
+            if _git(self.repo, "rev-parse", "-q", "--verify", ref, check=False):
+                return ref
+        return None
+
+    def history(self, path: str) -> list[SideHistory]:
+        """Recent commits touching ``path`` on each side since the merge base."""
+        other = self.operation_head()
+        if other is None:
+            return []
+        base = _git(self.repo, "merge-base", "HEAD", other, check=False).strip()
+        sides = []
+        for ref in ("HEAD", other):
+            span = f"{base}..{ref}" if base else ref
+            log = _git(
+                self.repo,
+                "log",
+                f"-n{HISTORY_LIMIT}",
+                "--format=%h %s (%an)",
+                span,
+                "--",
+                path,
+                check=False,
+            )
+            sides.append(SideHistory(ref=ref, commits=log.splitlines()))
+        return sides
+
+    # ------------------------------------------------------------------
+    # Proposals
+    # ------------------------------------------------------------------
+
+    def build_prompt(
+        self, file: ConflictedFile, hunk: ConflictHunk, history: list[SideHistory]
+    ) -> str:
+        parts = [
+            f"File: {file.path}",
+            f"Resolve {hunk.header} of {len(file.hunks)}.",
+            "",
+            f"Ours ({hunk.ours_label or 'HEAD'}):",
+            _fence(hunk.ours),
+        ]
+        if hunk.base is not None:
+            parts += ["Base (common ancestor):", _fence(hunk.base)]
+        parts += [f"Theirs ({hunk.theirs_label or 'incoming'}):", _fence(hunk.theirs)]
+        for side in history:
+            commits = "\n".join(f"  {c}" for c in side.commits) or "  (none)"
+            parts += [f"Commits on {side.ref} touching this file:", commits, ""]
+        parts += ["Whole file with conflict markers:", _fence([file.text])]
+        return "\n".join(parts)
+
+    def propose(
+        self,
+        files: list[ConflictedFile],
+        on_proposal: Callable[[Proposal], None] | None = None,
+    ) -> list[Proposal]:
+        """Ask one agent per conflict region for a resolution."""
+        return asyncio.run(self._propose_all(files, on_proposal))
+
+    async def _propose_all(
+        self,
+        files: list[ConflictedFile],
+        on_proposal: Callable[[Proposal], None] | None,
+    ) -> list[Proposal]:
+        semaphore = asyncio.Semaphore(self.max_parallel)
+
+        async def one(file: ConflictedFile, hunk: ConflictHunk, history) -> Proposal:
+            async with semaphore:
+                proposal = await self._propose_one(file, hunk, history)
+            if on_proposal:
+                on_proposal(proposal)
+            return proposal
+
+        tasks = []
+        for file in files:
+            history = self.history(file.path)
+            tasks.extend(one(file, hunk, history) for hunk in file.hunks)
+        return list(await asyncio.gather(*tasks))
+
+    async def _propose_one(
+        self, file: ConflictedFile, hunk: ConflictHunk, history: list[SideHistory]
+    ) -> Proposal:
+        config = AgentConfig(
+            system_prompt=SYSTEM_PROMPT,
+            model=self.model,
+            cwd=str(self.repo),
+            permission_mode="plan",
+            max_turns=10,
+        )
+        try:
+            runtime = self.runtime_factory(config)
+            result = await runtime.run(self.build_prompt(file, hunk, history), config)
+            if result.is_error:
+                raise ConflictError(f"agent error: {(result.text or '')[:200]}")
+            resolution, explanation = parse_resolution(result.text or "")
+        except Exception as e:
+            logger.debug(f"No proposal for {file.path} {hunk.header}", exc_info=True)
+            return Proposal(file.path, hunk, None, error=str(e))
+        return Proposal(file.path, hunk, resolution, explanation)
+
+    # ------------------------------------------------------------------
+    # Applying
+    # ------------------------------------------------------------------
+
+    def apply(
+        self, file: ConflictedFile, accepted: list[Proposal], stage: bool = True
+    ) -> bool:
+        """Write accepted proposals for one file.
+
+        Returns:
+            True when the file has no conflicts left (and was staged if asked)
+
+        Raises:
+            ConflictError: If the file changed since it was loaded
+        """
+        target = self.repo / file.path
+        current = target.read_text(encoding="utf-8")
+        if current != file.text:
+            raise ConflictError(f"{file.path} changed since the proposals were made")
+        resolutions = {p.hunk.index: p.resolution for p in accepted if p.ok}
+        if not resolutions:
+            return False
+        updated = apply_resolutions(current, resolutions)
+        target.write_text(updated, encoding="utf-8")
+        resolved = not parse_conflicts(updated)
+        if resolved and stage:
+            _git(self.repo, "add", "--", file.path)
+        return resolved
+
+
+def _fence(lines: list[str]) -> str:
+    body = "".join(lines)
+    if body and not body.endswith("\n"):
+        body += "\n"
+    return f"```\n{body}```\n"
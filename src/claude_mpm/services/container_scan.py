@@ -0,0 +1,645 @@
+"""Scan a project's Dockerfiles and container images.
+
+WHAT: ``ContainerScanner`` finds the project's Dockerfiles (``Dockerfile``,
+``Dockerfile.*``, ``*.Dockerfile``, ``Containerfile``) and reports:
+
+- ``latest-tag``: a base image without a tag, or tagged ``latest``
+- ``root-user``: the final stage runs as root (no ``USER``, or ``USER root``)
+- ``secret-in-env``: an ``ENV`` or ``ARG`` with a secret-looking name and a
+  literal value, which every layer after it carries
+- ``secret-file``: ``COPY``/``ADD`` brings a secret file (``.env``, SSH or
+  TLS keys, ``.npmrc``, cloud credentials) into the image, named directly or
+  inside a copied directory that ``.dockerignore`` does not exclude
+- ``vulnerable-package`` and ``secret-in-layer``: from scanning the image
+  with trivy, when image scanning is on
+
+``claude-mpm doctor --checks containers`` reports the findings.
+
+WHY: Agents write and edit Dockerfiles as readily as code, and the mistakes
+that matter (a floating base image, a root process, a token baked into a
+layer) pass every build and test. Checking them where the rest of the
+project's health is checked catches them before the image ships.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    container_scan:
+      images: none     # none | base | build (see below)
+      ignore: []       # rule names or vulnerability IDs to leave out
+
+``images: base`` scans the final stage's base image with trivy;
+``images: build`` builds each Dockerfile with docker and scans the result,
+which covers the packages the Dockerfile installs but takes as long as the
+build.
+
+DESIGN DECISIONS:
+- Dockerfile checks are a parser, not a build: they run in milliseconds
+  and need neither docker nor network, so they are always on
+- Image scanning is opt-in and delegated to trivy, which knows every
+  distribution's package database; a missing docker or trivy is reported
+  once, not as a failure per Dockerfile
+- Secret values are never copied into findings, only the name and location
+- Base images referenced through build arguments (``FROM $BASE``) and
+  earlier stages (``FROM builder``) are not tag-checked
+"""
+
+from __future__ import annotations
+
+import fnmatch
+import hashlib
+import json
+import re
+import shlex
+import subprocess  # nosec B404 - runs docker and trivy
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field, fields
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "container_scan"
+IMAGE_MODES = ("none", "base", "build")
+SEVERITIES = ("low", "medium", "high", "critical")
+BUILD_TIMEOUT = 1800
+SCAN_TIMEOUT = 600
+SKIP_DIRS = {"node_modules", "vendor", "dist", "build", "target", "__pycache__"}
+
+LATEST_TAG = "latest-tag"
+ROOT_USER = "root-user"
+SECRET_IN_ENV = "secret-in-env"
+SECRET_FILE = "secret-file"
+VULNERABLE_PACKAGE = "vulnerable-package"
+SECRET_IN_LAYER = "secret-in-layer"
+
+_SECRET_NAME = re.compile(
+    r"(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIALS)",
+    re.IGNORECASE,
+)
+# Files that hold credentials, by name or by path suffix
+_SECRET_FILES = (
+    ".env",
+    ".env.*",
+    "id_rsa",
+    "id_dsa",
+    "id_ecdsa",
+    "id_ed25519",
+    "*.pem",
+    "*.key",
+    "*.p12",
+    "*.pfx",
+    ".npmrc",
+    ".pypirc",
+    ".netrc",
+    ".git-credentials",
+    "credentials.json",
+    ".aws/credentials",
+    ".docker/config.json",
+)
+_SECRET_FILE_EXAMPLES = (".env.example", ".env.sample", ".env.template")
+
+Runner = Callable[..., subprocess.CompletedProcess]
+
+
+class ContainerScanError(Exception):
+    """docker or trivy is missing or failed."""
+
+
+@dataclass
+class ContainerScanConfig:
+    """Whether to scan images as well as Dockerfiles, and what to leave out."""
+
+    images: str = "none"
+    ignore: list[str] = field(default_factory=list)
+
+    def __post_init__(self) -> None:
+        if self.images not in IMAGE_MODES:
+            logger.warning(f"Unknown container_scan.images {self.images!r}; using none")
+            self.images = "none"
+
+    @classmethod
+    def load(cls, config: Any = None) -> ContainerScanConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in section.items() if k in known and v is not None})
+
+
+@dataclass
+class Finding:
+    """One problem in a Dockerfile or the image built from it."""
+
+    rule: str
+    severity: str  # low | medium | high | critical
+    message: str
+    file: str
+    line: int = 0
+    remediation: str = ""
+    id: str = ""  # vulnerability or secret rule ID from the image scanner
+
+    @property
+    def location(self) -> str:
+        return f"{self.file}:{self.line}" if self.line else self.file
+
+    @property
+    def severity_rank(self) -> int:
+        return SEVERITIES.index(self.severity) if self.severity in SEVERITIES else 0
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class ScanReport:
+    """Findings for every Dockerfile, and what could not be scanned."""
+
+    dockerfiles: list[str] = field(default_factory=list)
+    findings: list[Finding] = field(default_factory=list)
+    errors: list[str] = field(default_factory=list)
+
+    def count(self, *severities: str) -> int:
+        return sum(1 for f in self.findings if f.severity in severities)
+
+    def summary(self) -> str:
+        files = len(self.dockerfiles)
+        where = f"{files} Dockerfile{'s' if files != 1 else ''}"
+        if not self.findings:
+            return f"No problems found in {where}"
+        counts = [
+            f"{n} {severity}"
+            for severity in reversed(SEVERITIES)
+            if (n := self.count(severity))
+        ]
+        return f"{', '.join(counts)} in {where}"
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "dockerfiles": self.dockerfiles,
+            "findings": [f.to_dict() for f in self.findings],
+            "errors": self.errors,
+        }
+
+
+# ---------------------------------------------------------------------------
+# Dockerfile parsing
+# ---------------------------------------------------------------------------
+
+
+@dataclass
+class Instruction:
+    """One Dockerfile instruction, continuation lines joined."""
+
+    keyword: str  # upper case
+    args: str
+    line: int
+
+
+def parse_dockerfile(text: str) -> list[Instruction]:
+    """Split a Dockerfile into instructions.
+
+    Backslash continuations are joined, comments and parser directives are
+    dropped, and heredoc bodies (``RUN <<EOF``) are skipped.
+    """
+    instructions = []
+    lines = text.splitlines()
+    i = 0
+    while i < len(lines):
+        start = i
+        line = lines[i].strip()
+        i += 1
+        if not line or line.startswith("#"):
+            continue
+        parts = [line]
+        while parts[-1].endswith("\\") and i < len(lines):
+            parts[-1] = parts[-1][:-1].rstrip()
+            following = lines[i].strip()
+            i += 1
+            # Comments inside a continuation are dropped, not ended on
+            while following.startswith("#") and i < len(lines):
+                following = lines[i].strip()
+                i += 1
+            parts.append(following)
+        joined = " ".join(p for p in parts if p)
+        keyword, _, args = joined.partition(" ")
+        heredoc = re.search(r"<<-?[\"']?(\w+)[\"']?", args)
+        if heredoc:
+            while i < len(lines) and lines[i].strip() != heredoc.group(1):
+                i += 1
+            i += 1
+        instructions.append(Instruction(keyword.upper(), args.strip(), start + 1))
+    return instructions
+
+
+def _words(args: str) -> list[str]:
+    """Arguments of an instruction in shell or JSON (exec) form."""
+    if args.startswith("["):
+        try:
+            words = json.loads(args)
+            if isinstance(words, list):
+                return [str(w) for w in words]
+        except json.JSONDecodeError:
+            pass
+    try:
+        return shlex.split(args)
+    except ValueError:
+        return args.split()
+
+
+def _key_values(args: str) -> list[tuple[str, str | None]]:
+    """``ENV``/``ARG`` pairs: ``A=1 B=2``, legacy ``ENV A 1``, or ``ARG A``."""
+    words = _words(args)
+    if words and "=" not in words[0] and len(words) > 1:
+        return [(words[0], " ".join(words[1:]))]
+    pairs = []
+    for word in words:
+        name, sep, value = word.partition("=")
+        pairs.append((name, value if sep else None))
+    return pairs
+
+
+def _image_tag(image: str) -> str | None:
+    """The tag of an image reference, or None when it has none."""
+    if "@" in image:
+        return "digest"
+    name = image.rsplit("/", 1)[-1]
+    return name.rsplit(":", 1)[1] if ":" in name else None
+
+
+# ---------------------------------------------------------------------------
+# Scanner
+# ---------------------------------------------------------------------------
+
+
+def find_dockerfiles(project_dir: Path) -> list[Path]:
+    """The project's Dockerfiles, skipping hidden and dependency directories."""
+    found = []
+    for path in sorted(project_dir.rglob("*")):
+        relative = path.relative_to(project_dir)
+        if any(p.startswith(".") or p in SKIP_DIRS for p in relative.parts[:-1]):
+            continue
+        name = path.name
+        if not path.is_file():
+            continue
+        if (
+            name in ("Dockerfile", "Containerfile")
+            or name.startswith("Dockerfile.")
+            or name.endswith(".Dockerfile")
+        ):
+            found.append(path)
+    return found
+
+
+class ContainerScanner:
+    """Check Dockerfiles, and optionally the images they build, for problems."""
+
+    def __init__(
+        self,
+        project_dir: Path,
+        config: ContainerScanConfig | None = None,
+        runner: Runner = subprocess.run,
+    ):
+        self.project_dir = Path(project_dir).resolve()
+        self.config = config or ContainerScanConfig.load()
+        self.runner = runner
+
+    def scan(self) -> ScanReport:
+        """Check every Dockerfile, and scan images if configured."""
+        report = ScanReport()
+        scan_images = self.config.images != "none"
+        for dockerfile in find_dockerfiles(self.project_dir):
+            relative = dockerfile.relative_to(self.project_dir).as_posix()
+            report.dockerfiles.append(relative)
+            try:
+                instructions = parse_dockerfile(
+                    dockerfile.read_text(encoding="utf-8", errors="replace")
+                )
+            except OSError as e:
+                report.errors.append(f"{relative}: {e}")
+                continue
+            report.findings += self.check_dockerfile(dockerfile, instructions)
+            if not scan_images:
+                continue
+            try:
+                report.findings += self.scan_image(dockerfile, instructions)
+            except ContainerScanError as e:
+                report.errors.append(f"{relative}: {e}")
+                # A missing tool is missing for every Dockerfile
+                scan_images = "not installed" not in str(e)
+
+        ignored = set(self.config.ignore)
+        kept = [f for f in report.findings if not {f.rule, f.id} & ignored]
+        report.findings = sorted(
+            kept,
+            key=lambda f: (-f.severity_rank, f.file, f.line),
+        )
+        return report
+
+    # -- Dockerfile checks -------------------------------------------------
+
+    def check_dockerfile(
+        self, dockerfile: Path, instructions: list[Instruction]
+    ) -> list[Finding]:
+        relative = dockerfile.relative_to(self.project_dir).as_posix()
+        findings: list[Finding] = []
+        stages: set[str] = set()
+        user: Instruction | None = None
+        final_from: Instruction | None = None
+
+        for instruction in instructions:
+            if instruction.keyword == "FROM":
+                words = [w for w in _words(instruction.args) if not w.startswith("--")]
+                image = words[0] if words else ""
+                if len(words) >= 3 and words[1].lower() == "as":
+                    stages.add(words[2].lower())
+                final_from, user = instruction, None
+                if image and image.lower() not in stages | {"scratch"}:
+                    if "$" not in image and _image_tag(image) in (None, "latest"):
+                        findings.append(
+                            Finding(
+                                LATEST_TAG,
+                                "medium",
+                                f"Base image {image} is not pinned to a version; "
+                                "builds change whenever it is republished",
+                                relative,
+                                instruction.line,
+                                "Use a version tag, or a digest (image@sha256:...)",
+                            )
+                        )
+            elif instruction.keyword == "USER":
+                user = instruction
+            elif instruction.keyword in ("ENV", "ARG"):
+                findings += self._check_secret_vars(relative, instruction)
+            elif instruction.keyword in ("COPY", "ADD"):
+                findings += self._check_copied_secrets(
+                    dockerfile, relative, instruction
+                )
+
+        if final_from is not None and self._runs_as_root(user):
+            line = user.line if user else final_from.line
+            findings.append(
+                Finding(
+                    ROOT_USER,
+                    "medium",
+                    "The final stage runs as root"
+                    + ("" if user else " (no USER instruction)"),
+                    relative,
+                    line,
+                    "Add a non-root user and switch to it with USER before CMD",
+                )
+            )
+        return findings
+
+    @staticmethod
+    def _runs_as_root(user: Instruction | None) -> bool:
+        if user is None:
+            return True
+        name = user.args.split(":", 1)[0].strip()
+        return name in ("root", "0")
+
+    @staticmethod
+    def _check_secret_vars(relative: str, instruction: Instruction) -> list[Finding]:
+        findings = []
+        for name, value in _key_values(instruction.args):
+            if not value or "$" in value or not _SECRET_NAME.search(name):
+                continue
+            findings.append(
+                Finding(
+                    SECRET_IN_ENV,
+                    "high",
+                    f"{instruction.keyword} {name} sets a secret-looking value "
+                    "that stays in the image layers",
+                    relative,
+                    instruction.line,
+                    "Pass secrets at build time with RUN --mount=type=secret, "
+                    "or at run time through the environment",
+                )
+            )
+        return findings
+
+    def _check_copied_secrets(
+        self, dockerfile: Path, relative: str, instruction: Instruction
+    ) -> list[Finding]:
+        words = _words(instruction.args)
+        if any(w.startswith("--from") for w in words):
+            return []  # copies from another stage or image, not the context
+        sources = [w for w in words if not w.startswith("--")][:-1]
+        context = dockerfile.parent
+        ignore = self._dockerignore(context)
+        findings = []
+        for source in sources:
+            if "://" in source or "$" in source:
+                continue
+            for path in self._secret_files(context, source, ignore):
+                findings.append(
+                    Finding(
+                        SECRET_FILE,
+                        "high",
+                        f"{instruction.keyword} {source} puts {path} in the image",
+                        relative,
+                        instruction.line,
+                        f"Add {path} to .dockerignore, or mount it with "
+                        "RUN --mount=type=secret",
+                    )
+                )
+        return findings
+
+    @staticmethod
+    def _dockerignore(context: Path) -> list[str]:
+        path = context / ".dockerignore"
+        if not path.is_file():
+            return []
+        lines = path.read_text(encoding="utf-8", errors="replace").splitlines()
+        return [line.strip() for line in lines if line.strip()[:1] not in ("", "#")]
+
+    @staticmethod
+    def _secret_files(context: Path, source: str, ignore: list[str]) -> list[str]:
+        """Secret files under *source* in the build context, minus ignored ones."""
+        pattern = source.lstrip("/").rstrip("/") or "."
+        matches = (
+            list(context.glob(pattern))
+            if any(c in pattern for c in "*?[")
+            else [context / pattern]
+        )
+        found = []
+        for match in matches:
+            candidates = [match] if match.is_file() else []
+            if match.is_dir():
+                candidates = [p for p in match.rglob("*") if p.is_file()]
+            for path in candidates:
+                name = path.relative_to(context).as_posix()
+                parents = Path(name).parts[:-1]
+                if any(part in SKIP_DIRS or part == ".git" for part in parents):
+                    continue
+                if _is_secret_file(name) and not _dockerignored(name, ignore):
+                    found.append(name)
+        return sorted(set(found))
+
+    # -- Image scanning ----------------------------------------------------
+
+    def scan_image(
+        self, dockerfile: Path, instructions: list[Instruction]
+    ) -> list[Finding]:
+        """Scan the image of *dockerfile* (built, or its base) with trivy.
+
+        Raises:
+            ContainerScanError: If docker or trivy is missing or fails
+        """
+        relative = dockerfile.relative_to(self.project_dir).as_posix()
+        if self.config.images == "build":
+            digest = hashlib.sha256(relative.encode()).hexdigest()[:12]
+            image = f"claude-mpm-scan/{dockerfile.parent.name.lower()}:{digest}"
+            self._run(
+                [
+                    "docker",
+                    "build",
+                    "--quiet",
+                    "-f",
+                    str(dockerfile),
+                    "-t",
+                    image,
+                    str(dockerfile.parent),
+                ],
+                BUILD_TIMEOUT,
+            )
+        else:
+            image = self._base_image(instructions)
+            if image is None:
+                return []
+        output = self._run(
+            [
+                "trivy",
+                "image",
+                "--quiet",
+                "--format",
+                "json",
+                "--scanners",
+                "vuln,secret",
+                image,
+            ],
+            SCAN_TIMEOUT,
+        )
+        try:
+            results = json.loads(output or "{}").get("Results") or []
+        except json.JSONDecodeError as e:
+            raise ContainerScanError(f"trivy printed invalid JSON: {e}") from None
+        return parse_trivy_results(results, relative, image)
+
+    @staticmethod
+    def _base_image(instructions: list[Instruction]) -> str | None:
+        """The final stage's base image, unless it is a stage, scratch or $ARG."""
+        stages: set[str] = set()
+        image = None
+        for instruction in instructions:
+            if instruction.keyword != "FROM":
+                continue
+            words = [w for w in _words(instruction.args) if not w.startswith("--")]
+            image = words[0] if words else None
+            if len(words) >= 3 and words[1].lower() == "as":
+                stages.add(words[2].lower())
+        if not image or "$" in image or image.lower() in stages | {"scratch"}:
+            return None
+        return image
+
+    def _run(self, cmd: list[str], timeout: int) -> str:
+        try:
+            result = self.runner(cmd, capture_output=True, text=True, timeout=timeout)
+        except FileNotFoundError:
+            raise ContainerScanError(f"{cmd[0]} is not installed") from None
+        except subprocess.TimeoutExpired:
+            raise ContainerScanError(f"{cmd[0]} {cmd[1]} timed out") from None
+        if result.returncode != 0:
+            lines = (result.stderr or result.stdout or "").strip().splitlines()
+            detail = lines[-1] if lines else f"exit status {result.returncode}"
+            raise ContainerScanError(f"{cmd[0]} {cmd[1]} failed: {detail}")
+        return result.stdout
+
+
+def _is_secret_file(name: str) -> bool:
+    basename = name.rsplit("/", 1)[-1]
+    if basename in _SECRET_FILE_EXAMPLES:
+        return False
+    return any(
+        fnmatch.fnmatch(name, f"*{pattern}" if "/" in pattern else pattern)
+        or fnmatch.fnmatch(basename, pattern)
+        for pattern in _SECRET_FILES
+    )
+
+
+_GLOB_REGEX = {"**/": "(.*/)?", "**": ".*", "*": "[^/]*", "?": "[^/]"}
+
+
+def _dockerignore_regex(pattern: str) -> re.Pattern:
+    """Compile a ``.dockerignore`` pattern; ``**`` spans directories."""
+    parts = re.split(r"(\*\*/?|\*|\?)", pattern.strip("/"))
+    return re.compile(
+        "".join(_GLOB_REGEX.get(part, re.escape(part)) for part in parts)
+    )
+
+
+def _dockerignored(name: str, patterns: list[str]) -> bool:
+    """Whether *name* is left out of the build context; the last matching
+    pattern wins, and ``!`` patterns add files back."""
+    parents = name.split("/")
+    prefixes = ["/".join(parents[:i]) for i in range(1, len(parents) + 1)]
+    ignored = False
+    for pattern in patterns:
+        negated = pattern.startswith("!")
+        regex = _dockerignore_regex(pattern.lstrip("!").strip())
+        if any(regex.fullmatch(prefix) for prefix in prefixes):
+            ignored = not negated
+    return ignored
+
+
+def _severity(value: str | None) -> str:
+    value = (value or "").lower()
+    return value if value in SEVERITIES else "low"
+
+
+def parse_trivy_results(
+    results: list[dict[str, Any]], dockerfile: str, image: str
+) -> list[Finding]:
+    """Findings from the ``Results`` of ``trivy image --format json``."""
+    findings = []
+    for result in results:
+        target = result.get("Target", image)
+        for vuln in result.get("Vulnerabilities") or []:
+            fixed = vuln.get("FixedVersion")
+            title = vuln.get("Title") or vuln.get("Description", "").split("\n")[0]
+            findings.append(
+                Finding(
+                    VULNERABLE_PACKAGE,
+                    _severity(vuln.get("Severity")),
+                    f"{vuln.get('PkgName')} {vuln.get('InstalledVersion')} in "
+                    f"{image}: {vuln.get('VulnerabilityID')}"
+                    + (f" ({title})" if title else ""),
+                    dockerfile,
+                    remediation=(
+                        f"Upgrade {vuln.get('PkgName')} to {fixed}, or a base "
+                        "image that has it"
+                        if fixed
+                        else "No fixed version yet; consider another base image"
+                    ),
+                    id=vuln.get("VulnerabilityID", ""),
+                )
+            )
+        for secret in result.get("Secrets") or []:
+            findings.append(
+                Finding(
+                    SECRET_IN_LAYER,
+                    _severity(secret.get("Severity")),
+                    f"{secret.get('Title') or secret.get('RuleID')} in {target} "
+                    f"(line {secret.get('StartLine', '?')}) of {image}",
+                    dockerfile,
+                    remediation="Remove the secret from the image and rotate it",
+                    id=secret.get("RuleID", ""),
+                )
+            )
+    return findings
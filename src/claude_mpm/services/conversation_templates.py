@@ -0,0 +1,359 @@
+"""Conversation templates: launchable, parameterized starts for common work.
+
+WHAT: A template pre-fills the PM's first prompt, picks the agents the
+session may delegate to and sets session policies, from a YAML file::
+
+    # .claude-mpm/conversation-templates/bug-triage.yaml
+    description: Reproduce and triage a reported bug
+    variables:
+      issue:
+        description: Issue number
+        required: true
+      area:
+        default: the whole project
+    prompt: |
+      Triage issue #{{ issue }} in {{ area }}: reproduce it, find the cause
+      and propose a fix. Do not change code yet.
+    agents: [research, qa]
+    policies:
+      labels: [bugfix]
+      env: {TRIAGE_MODE: "1"}
+      model: opus
+      max_turns: 40                   # headless sessions only
+      disallowed_tools: [WebFetch]
+      skip_permissions: false         # ask before each tool use
+      chaos: false
+
+``claude-mpm start --template bug-triage --var issue=123`` launches it.
+Templates are looked up by name, first match wins, in::
+
+    <project>/.claude-mpm/conversation-templates/<name>.yaml
+    ~/.claude-mpm/conversation-templates/<name>.yaml
+    <skill source cache>/conversation-templates/<name>.yaml   (by priority)
+
+so a team shares templates by committing them or by adding them to a skill
+source repository (``claude-mpm skill-source add``).
+
+WHY: Recurring workflows (bug triage, release prep, dependency upgrades)
+started with a long prompt each person wrote differently, and with whatever
+agents and settings happened to be active.
+
+DESIGN DECISIONS:
+- Agent selection uses Claude Code's own permission rules: every deployed
+  agent not in ``agents`` is denied as ``Task(<name>)``, so the restriction
+  holds for the whole session, not just the first prompt
+- Policies map onto existing ``run`` options (--label, --env, --model, ...);
+  values given on the command line are added to or win over the template's
+- A placeholder that is not a declared variable fails when the template is
+  loaded, not halfway through a launch
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+TEMPLATES_DIR = "conversation-templates"
+_VARIABLE_RE = re.compile(r"^[A-Za-z_][A-Za-z0-9_-]*$")
+_PLACEHOLDER_RE = re.compile(r"\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}")
+_NAME_RE = re.compile(r"^[a-z0-9][a-z0-9_.-]*$")
+
+
+@dataclass
+class TemplateVariable:
+    """A ``{{ name }}`` placeholder of a template."""
+
+    name: str
+    description: str = ""
+    default: str | None = None
+    required: bool = False
+
+
+@dataclass
+class TemplatePolicies:
+    """Session settings a template applies."""
+
+    labels: list[str] = field(default_factory=list)
+    env: dict[str, str] = field(default_factory=dict)
+    model: str | None = None
+    max_turns: int | None = None
+    disallowed_tools: list[str] = field(default_factory=list)
+    # None: keep the default (skip permission prompts)
+    skip_permissions: bool | None = None
+    chaos: bool = False
+
+
+@dataclass
+class ConversationTemplate:
+    """A parsed template file."""
+
+    name: str
+    path: Path
+    source: str  # project, user or a skill source id
+    prompt: str
+    description: str = ""
+    variables: dict[str, TemplateVariable] = field(default_factory=dict)
+    agents: list[str] = field(default_factory=list)
+    policies: TemplatePolicies = field(default_factory=TemplatePolicies)
+
+    def render(self, values: dict[str, str], partial: bool = False) -> str:
+        """The prompt with *values* (and defaults) filled in.
+
+        With *partial*, variables without a value keep their placeholder
+        instead of failing, for previews.
+
+        Raises:
+            ValueError: For an unknown variable or a missing required one.
+        """
+        unknown = sorted(set(values) - set(self.variables))
+        if unknown:
+            raise ValueError(
+                f"Template '{self.name}' has no variable(s) {', '.join(unknown)}"
+                f" (it takes: {', '.join(self.variables) or 'none'})"
+            )
+        resolved = {
+            name: values.get(name, variable.default)
+            for name, variable in self.variables.items()
+        }
+        missing = [name for name, value in resolved.items() if value is None]
+        if missing and not partial:
+            raise ValueError(
+                f"Template '{self.name}' needs "
+                + ", ".join(f"--var {name}=..." for name in missing)
+            )
+
+        def fill(match: re.Match[str]) -> str:
+            value = resolved[match.group(1)]
+            return match.group(0) if value is None else value
+
+        return _PLACEHOLDER_RE.sub(fill, self.prompt).strip()
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.name,
+            "source": self.source,
+            "path": str(self.path),
+            "description": self.description,
+            "variables": [asdict(v) for v in self.variables.values()],
+            "agents": self.agents,
+            "policies": asdict(self.policies),
+        }
+
+
+def _string_list(value: Any, what: str) -> list[str]:
+    if value is None:
+        return []
+    if isinstance(value, str):
+        value = value.split(",")
+    if not isinstance(value, list):
+        raise ValueError(f"{what} must be a list")
+    return [str(item).strip() for item in value if str(item).strip()]
+
+
+def _parse_variables(spec: Any) -> dict[str, TemplateVariable]:
+    if spec is None:
+        return {}
+    if isinstance(spec, list):
+        spec = {str(name): {} for name in spec}
+    if not isinstance(spec, dict):
+        raise ValueError("variables must be a mapping")
+    variables = {}
+    for name, entry in spec.items():
+        name = str(name)
+        if not _VARIABLE_RE.match(name):
+            raise ValueError(f"Invalid variable name '{name}'")
+        if not isinstance(entry, dict):
+            # ``issue: 42`` is shorthand for a default
+            entry = {"default": entry}
+        default = entry.get("default")
+        variables[name] = TemplateVariable(
+            name=name,
+            description=str(entry.get("description") or ""),
+            default=None if default is None else str(default),
+            required=bool(entry.get("required", default is None)),
+        )
+    return variables
+
+
+def _parse_policies(spec: Any) -> TemplatePolicies:
+    if spec is None:
+        return TemplatePolicies()
+    if not isinstance(spec, dict):
+        raise ValueError("policies must be a mapping")
+    env = spec.get("env") or {}
+    if not isinstance(env, dict):
+        raise ValueError("policies.env must be a mapping")
+    max_turns = spec.get("max_turns")
+    if max_turns is not None and (type(max_turns) is not int or max_turns < 1):
+        raise ValueError("policies.max_turns must be a positive whole number")
+    skip = spec.get("skip_permissions")
+    return TemplatePolicies(
+        labels=_string_list(spec.get("labels"), "policies.labels"),
+        env={str(k): str(v) for k, v in env.items()},
+        model=str(spec["model"]) if spec.get("model") else None,
+        max_turns=max_turns,
+        disallowed_tools=_string_list(
+            spec.get("disallowed_tools"), "policies.disallowed_tools"
+        ),
+        skip_permissions=None if skip is None else bool(skip),
+        chaos=bool(spec.get("chaos", False)),
+    )
+
+
+def parse_template(path: Path, source: str) -> ConversationTemplate:
+    """Load the template file at *path*.
+
+    Raises:
+        ValueError: If the file is not a valid template.
+    """
+    try:
+        data = yaml.safe_load(path.read_text(encoding="utf-8"))
+    except (OSError, yaml.YAMLError) as e:
+        raise ValueError(f"Cannot read template {path}: {e}") from e
+    if not isinstance(data, dict):
+        raise ValueError(f"Template {path} is not a mapping")
+    prompt = data.get("prompt")
+    if not isinstance(prompt, str) or not prompt.strip():
+        raise ValueError(f"Template {path} has no prompt")
+    try:
+        variables = _parse_variables(data.get("variables"))
+        template = ConversationTemplate(
+            name=str(data.get("name") or path.stem),
+            path=path,
+            source=source,
+            prompt=prompt,
+            description=str(data.get("description") or ""),
+            variables=variables,
+            agents=_string_list(data.get("agents"), "agents"),
+            policies=_parse_policies(data.get("policies")),
+        )
+    except ValueError as e:
+        raise ValueError(f"Template {path}: {e}") from e
+    undeclared = sorted(set(_PLACEHOLDER_RE.findall(prompt)) - set(variables))
+    if undeclared:
+        raise ValueError(
+            f"Template {path} uses undeclared variable(s): {', '.join(undeclared)}"
+        )
+    return template
+
+
+def template_dirs(
+    project_dir: str | Path, home: Path | None = None
+) -> list[tuple[str, Path]]:
+    """(source, directory) pairs to look for templates in, first wins."""
+    from ..config.skill_sources import SkillSourceConfiguration
+
+    home = Path(home or Path.home())
+    dirs = [
+        ("project", Path(project_dir) / ".claude-mpm" / TEMPLATES_DIR),
+        ("user", home / ".claude-mpm" / TEMPLATES_DIR),
+    ]
+    try:
+        sources = SkillSourceConfiguration(
+            home / ".claude-mpm" / "config" / "skill_sources.yaml"
+        ).get_enabled_sources()
+    except Exception as e:
+        logger.debug(f"Skill sources unavailable for templates: {e}")
+        sources = []
+    cache = home / ".claude-mpm" / "cache" / "skills"
+    dirs += [(source.id, cache / source.id / TEMPLATES_DIR) for source in sources]
+    return dirs
+
+
+def list_templates(
+    project_dir: str | Path, home: Path | None = None
+) -> list[ConversationTemplate]:
+    """Every template by name, as ``find_template`` would pick it.
+
+    Invalid template files are logged and skipped.
+    """
+    found: dict[str, ConversationTemplate] = {}
+    for source, directory in template_dirs(project_dir, home):
+        if not directory.is_dir():
+            continue
+        for path in sorted(directory.iterdir()):
+            if path.suffix not in (".yaml", ".yml") or path.stem in found:
+                continue
+            try:
+                found[path.stem] = parse_template(path, source)
+            except ValueError as e:
+                logger.warning(str(e))
+    return sorted(found.values(), key=lambda template: template.name)
+
+
+def find_template(
+    name: str, project_dir: str | Path, home: Path | None = None
+) -> ConversationTemplate:
+    """The template called *name*.
+
+    Raises:
+        ValueError: If there is none or it is invalid.
+    """
+    if not _NAME_RE.match(name):
+        raise ValueError(f"Invalid template name '{name}'")
+    for source, directory in template_dirs(project_dir, home):
+        for suffix in (".yaml", ".yml"):
+            path = directory / f"{name}{suffix}"
+            if path.is_file():
+                return parse_template(path, source)
+    raise ValueError(
+        f"No conversation template '{name}' "
+        "(see 'claude-mpm templates list')"
+    )
+
+
+def parse_vars(items: list[str] | None) -> dict[str, str]:
+    """``--var name=value`` arguments as a mapping.
+
+    Raises:
+        ValueError: For an item without ``=``.
+    """
+    values = {}
+    for item in items or []:
+        name, sep, value = item.partition("=")
+        if not sep or not name.strip():
+            raise ValueError(f"--var expects NAME=VALUE, got {item!r}")
+        values[name.strip()] = value
+    return values
+
+
+def agent_denials(
+    agents: list[str], project_dir: str | Path, home: Path | None = None
+) -> list[str]:
+    """``Task(<name>)`` rules denying every deployed agent not in *agents*.
+
+    Raises:
+        ValueError: If an agent in *agents* is not deployed.
+    """
+    from ..utils.agent_filters import normalize_agent_id
+    from .agents.agent_capabilities import agent_capabilities
+
+    if not agents:
+        return []
+    deployed = {
+        normalize_agent_id(agent.name): agent.name
+        for agent in agent_capabilities(project_dir, home)
+        if not agent.error
+    }
+    wanted = {normalize_agent_id(name) for name in agents}
+    missing = sorted(
+        name for name in agents if normalize_agent_id(name) not in deployed
+    )
+    if missing:
+        raise ValueError(
+            f"Template agent(s) not deployed: {', '.join(missing)} "
+            "(deploy them with 'claude-mpm agents deploy')"
+        )
+    return [
+        f"Task({name})"
+        for key, name in sorted(deployed.items())
+        if key not in wanted
+    ]
@@ -61,11 +61,13 @@ class ModelProvider(Enum):
         CLAUDE: Cloud-based Claude API (always available)
         OLLAMA: Local Ollama installation (requires local setup)
         AUTO: Intelligent routing with Ollama-first, Claude fallback
+        MOCK: Replays recorded responses for deterministic local testing
     """
 
     CLAUDE = "claude"
     OLLAMA = "ollama"
     AUTO = "auto"
+    MOCK = "mock"
 
 
 @dataclass
@@ -31,6 +31,7 @@ from claude_mpm.utils.agent_filters import normalize_agent_id
 
 from ...core.logger import get_logger
 from ...utils.agent_filters import get_deployed_agent_ids
+from .. import data_encryption
 from .service_interfaces import ICacheManager, IMemoryManager, IPathResolver
 
 # Default ports for MCP memory backends
@@ -244,7 +245,7 @@ class MemoryManager(IMemoryManager):
 
         # Load existing content or create new
         if memory_file.exists():
-            content = memory_file.read_text(encoding="utf-8")
+            content = data_encryption.reveal(memory_file.read_text(encoding="utf-8"))
             lines = content.split("\n")
         else:
             lines = [
@@ -257,7 +258,10 @@ class MemoryManager(IMemoryManager):
         lines.append(f"- [{timestamp}] {key}: {value}")
 
         # Write back
-        memory_file.write_text("\n".join(lines), encoding="utf-8")
+        memory_file.write_text(
+            data_encryption.protect("\n".join(lines), data_encryption.MEMORIES),
+            encoding="utf-8",
+        )
 
         # Clear cache to force reload on next access
         self._cache_manager.clear_memory_caches()
@@ -490,7 +494,9 @@ class MemoryManager(IMemoryManager):
 
         if pm_memory_path.exists():
             try:
-                loaded_content = pm_memory_path.read_text(encoding="utf-8")
+                loaded_content = data_encryption.reveal(
+                    pm_memory_path.read_text(encoding="utf-8")
+                )
                 if loaded_content:
                     # Cap PM_memories.md to prevent unbounded token growth.
                     # Files over 4,000 chars (~1,000 tokens) are trimmed to their
@@ -564,7 +570,9 @@ class MemoryManager(IMemoryManager):
             # Check if agent is deployed
             if agent_name in deployed_agents:
                 try:
-                    loaded_content = memory_file.read_text(encoding="utf-8")
+                    loaded_content = data_encryption.reveal(
+                        memory_file.read_text(encoding="utf-8")
+                    )
                     if loaded_content:
                         # Store or merge agent memories
                         if agent_name not in agent_memories_dict:
@@ -603,7 +611,9 @@ class MemoryManager(IMemoryManager):
             else:
                 # Log skipped memories only if they contain actual items
                 try:
-                    loaded_content = memory_file.read_text(encoding="utf-8")
+                    loaded_content = data_encryption.reveal(
+                        memory_file.read_text(encoding="utf-8")
+                    )
                     memory_items = [
                         line.strip()
                         for line in loaded_content.split("\n")
@@ -731,9 +741,12 @@ class MemoryManager(IMemoryManager):
         if old_path.exists() and not new_path.exists():
             try:
                 # Read content from old file
-                content = old_path.read_text(encoding="utf-8")
+                content = data_encryption.reveal(old_path.read_text(encoding="utf-8"))
                 # Write to new file
-                new_path.write_text(content, encoding="utf-8")
+                new_path.write_text(
+                    data_encryption.protect(content, data_encryption.MEMORIES),
+                    encoding="utf-8",
+                )
                 # Remove old file
                 old_path.unlink()
                 self.logger.info(
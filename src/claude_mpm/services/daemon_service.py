@@ -0,0 +1,388 @@
+"""
+Foreground Service Mode for the Shared Daemon
+=============================================
+
+WHAT: ``claude-mpm daemon --config /etc/claude-mpm`` (or ``daemon run``)
+runs the shared daemon's components as one long-lived foreground process,
+the shape container runtimes and systemd expect:
+
+- starts the configured components and attaches the configured projects
+- serves ``GET /healthz`` (200 when every component is running, else 503)
+  and ``GET /status`` on a separate health port
+- restarts components that die, checking every ``check_interval`` seconds
+- reloads its configuration on SIGHUP, ``claude-mpm config reload`` or a
+  change to the configuration file, without stopping what is running
+- stops everything on SIGTERM/SIGINT and exits
+
+WHY: Teams hosting one shared instance for several people ran
+``daemon start`` under ad-hoc wrappers, which return immediately and leave
+nothing for a container runtime to supervise or health-check.
+
+CONFIGURATION: ``<config dir>/configuration.yaml``, section ``daemon``, with
+every key overridable by an environment variable (env wins):
+
+    daemon:
+      host: 0.0.0.0              # CLAUDE_MPM_DAEMON_HOST
+      health_port: 8080          # CLAUDE_MPM_DAEMON_HEALTH_PORT
+      components: [event_server] # CLAUDE_MPM_DAEMON_COMPONENTS (comma-separated)
+      projects: [/workspace/app] # CLAUDE_MPM_DAEMON_PROJECTS (os.pathsep-separated)
+      check_interval: 30         # CLAUDE_MPM_DAEMON_CHECK_INTERVAL
+
+DESIGN DECISIONS:
+- Persistent state stays under ``~/.claude-mpm`` as for ``daemon start``;
+  the container image points HOME at its data volume instead of adding a
+  second state location
+- The health server is plain ``http.server`` on its own port so probes keep
+  answering while a component is down, which is exactly when they matter
+- A reload applies what can change in place: components are started or
+  stopped, projects attached or detached and the check interval changed,
+  while components that stay configured keep running with their sessions.
+  ``host`` and ``health_port`` cannot move under a bound socket, so a change
+  to either is reported as needing a restart and ignored. A file that no
+  longer parses keeps the running configuration
+- ``config reload`` finds the service through the PID it records in the
+  shared daemon's state and sends SIGHUP; the service records each reload
+  there too, so the command can report what changed
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import signal
+import threading
+import time
+from dataclasses import asdict, dataclass, field
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+from pathlib import Path
+from typing import Any
+
+from ..core.logging_config import get_logger
+from .shared_daemon import SharedDaemon
+
+logger = get_logger(__name__)
+
+CONFIG_FILE = "configuration.yaml"
+CONFIG_KEY = "daemon"
+ENV_PREFIX = "CLAUDE_MPM_DAEMON_"
+DEFAULT_HEALTH_PORT = 8080
+# How often the configuration file is checked for changes, in seconds
+WATCH_INTERVAL = 2.0
+# Settings a reload cannot apply to a running service
+RESTART_SETTINGS = ("host", "health_port")
+
+
+class DaemonServiceError(RuntimeError):
+    """The foreground service is not running or did not answer a reload."""
+
+
+@dataclass
+class DaemonServiceConfig:
+    """How the foreground service binds, what it runs and what it serves."""
+
+    host: str = "localhost"
+    health_port: int = DEFAULT_HEALTH_PORT
+    components: list[str] | None = None  # None: all registered components
+    projects: list[str] = field(default_factory=list)
+    check_interval: float = 30.0
+
+    @classmethod
+    def load(
+        cls, config_dir: Path | None = None, environ: dict[str, str] | None = None
+    ) -> DaemonServiceConfig:
+        """Read ``<config_dir>/configuration.yaml`` then apply env overrides."""
+        environ = os.environ if environ is None else environ
+        section: dict[str, Any] = {}
+        if config_dir is not None:
+            config_file = Path(config_dir) / CONFIG_FILE
+            if config_file.is_file():
+                import yaml
+
+                data = yaml.safe_load(config_file.read_text(encoding="utf-8")) or {}
+                section = data.get(CONFIG_KEY, {}) or {}
+            elif not Path(config_dir).is_dir():
+                raise FileNotFoundError(f"Config directory not found: {config_dir}")
+
+        def setting(key: str, default: Any) -> Any:
+            return environ.get(ENV_PREFIX + key.upper(), section.get(key, default))
+
+        components = setting("components", None)
+        if isinstance(components, str):
+            components = [c.strip() for c in components.split(",") if c.strip()]
+        projects = setting("projects", [])
+        if isinstance(projects, str):
+            projects = [p for p in projects.split(os.pathsep) if p]
+        return cls(
+            host=str(setting("host", cls.host)),
+            health_port=int(setting("health_port", DEFAULT_HEALTH_PORT)),
+            components=list(components) if components else None,
+            projects=[str(p) for p in projects],
+            check_interval=float(setting("check_interval", cls.check_interval)),
+        )
+
+
+@dataclass
+class ReloadResult:
+    """What a configuration reload changed, or why it changed nothing."""
+
+    changes: list[str] = field(default_factory=list)
+    restart_required: list[str] = field(default_factory=list)
+    error: str | None = None
+    reloaded_at: float = field(default_factory=time.time)
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> ReloadResult:
+        return cls(**{k: data[k] for k in cls.__dataclass_fields__ if k in data})
+
+    def summary(self) -> str:
+        if self.error:
+            return f"kept the running configuration: {self.error}"
+        return "; ".join(self.changes) or "no changes"
+
+
+def _config_mtime(config_dir: Path | None) -> int | None:
+    if config_dir is None:
+        return None
+    try:
+        return (Path(config_dir) / CONFIG_FILE).stat().st_mtime_ns
+    except OSError:
+        return None
+
+
+class DaemonService:
+    """Runs the shared daemon in the foreground with a health endpoint."""
+
+    def __init__(
+        self,
+        config: DaemonServiceConfig,
+        daemon: SharedDaemon | None = None,
+        config_dir: Path | None = None,
+        environ: dict[str, str] | None = None,
+    ):
+        self.config = config
+        self.daemon = daemon or SharedDaemon(host=config.host)
+        # Where the configuration came from, so a reload reads it again
+        self.config_dir = config_dir
+        self.environ = environ
+        self._stopping = threading.Event()
+        self._reload_requested = threading.Event()
+        self._wake = threading.Event()
+        self._config_mtime = _config_mtime(config_dir)
+        self._server: ThreadingHTTPServer | None = None
+
+    def health(self) -> tuple[int, dict[str, Any]]:
+        """HTTP status and body for ``/healthz``."""
+        status = self.daemon.status()
+        # A per-project component with no attached projects has nothing to run
+        components = {
+            name: bool(info.get("running")) or info.get("instances") == {}
+            for name, info in status["components"].items()
+            if not self.config.components or name in self.config.components
+        }
+        healthy = bool(components) and all(components.values())
+        body = {"status": "ok" if healthy else "unhealthy", "components": components}
+        return (200 if healthy else 503), body
+
+    def start_health_server(self) -> ThreadingHTTPServer:
+        service = self
+
+        class Handler(BaseHTTPRequestHandler):
+            def do_GET(self) -> None:
+                if self.path == "/healthz":
+                    code, body = service.health()
+                elif self.path == "/status":
+                    code, body = 200, service.daemon.status()
+                else:
+                    code, body = 404, {"error": "not found"}
+                payload = json.dumps(body, default=str).encode()
+                self.send_response(code)
+                self.send_header("Content-Type", "application/json")
+                self.send_header("Content-Length", str(len(payload)))
+                self.end_headers()
+                self.wfile.write(payload)
+
+            def log_message(self, format: str, *args: Any) -> None:
+                pass  # probes hit this every few seconds
+
+        self._server = ThreadingHTTPServer(
+            (self.config.host, self.config.health_port), Handler
+        )
+        threading.Thread(target=self._server.serve_forever, daemon=True).start()
+        logger.info(
+            f"Health endpoint on http://{self.config.host}:"
+            f"{self._server.server_address[1]}/healthz"
+        )
+        return self._server
+
+    def run(self) -> int:
+        """Start everything and block until stopped; returns an exit code."""
+        if threading.current_thread() is threading.main_thread():
+            for signum in (signal.SIGTERM, signal.SIGINT):
+                signal.signal(signum, lambda *_: self.stop())
+            if hasattr(signal, "SIGHUP"):
+                signal.signal(signal.SIGHUP, lambda *_: self.request_reload())
+
+        results = self.daemon.start(only=self.config.components)
+        for project in self.config.projects:
+            self.daemon.attach(Path(project))
+        logger.info(f"Daemon service started: {results}")
+        self.start_health_server()
+        self.daemon.record_service(
+            pid=os.getpid(),
+            config_dir=str(self.config_dir) if self.config_dir else None,
+            started_at=time.time(),
+        )
+        next_check = time.monotonic() + self.config.check_interval
+        try:
+            while not self._stopping.is_set():
+                timeout = max(0.0, next_check - time.monotonic())
+                if self.config_dir is not None:
+                    timeout = min(timeout, WATCH_INTERVAL)
+                self._wake.wait(timeout)
+                self._wake.clear()
+                if self._stopping.is_set():
+                    break
+                if self._reload_requested.is_set() or self._config_changed():
+                    self._reload_requested.clear()
+                    self.reload()
+                if time.monotonic() >= next_check:
+                    self._restart_dead_components()
+                    next_check = time.monotonic() + self.config.check_interval
+        finally:
+            if self._server is not None:
+                self._server.shutdown()
+                self._server.server_close()
+            self.daemon.stop()
+            self.daemon.clear_service()
+            logger.info("Daemon service stopped")
+        return 0
+
+    def stop(self) -> None:
+        self._stopping.set()
+        self._wake.set()
+
+    def request_reload(self) -> None:
+        """Ask the run loop to reload; safe to call from a signal handler."""
+        self._reload_requested.set()
+        self._wake.set()
+
+    def _config_changed(self) -> bool:
+        mtime = _config_mtime(self.config_dir)
+        if mtime == self._config_mtime:
+            return False
+        self._config_mtime = mtime
+        return True
+
+    def reload(self) -> ReloadResult:
+        """Read the configuration again and apply what changed.
+
+        Components that stay configured keep running. The outcome is
+        logged and recorded in the shared daemon's state.
+        """
+        self._config_mtime = _config_mtime(self.config_dir)
+        result = ReloadResult()
+        try:
+            new = DaemonServiceConfig.load(self.config_dir, self.environ)
+        except Exception as e:
+            result.error = f"could not read the configuration: {e}"
+            logger.error(f"Daemon service reload failed; {result.summary()}")
+            self.daemon.record_service(last_reload=result.to_dict())
+            return result
+
+        old = self.config
+        for key in RESTART_SETTINGS:
+            if getattr(new, key) != getattr(old, key):
+                result.restart_required.append(
+                    f"{key} {getattr(old, key)} -> {getattr(new, key)}"
+                )
+                setattr(new, key, getattr(old, key))
+
+        names = self.daemon.component_names()
+        before = [n for n in names if not old.components or n in old.components]
+        after = [n for n in names if not new.components or n in new.components]
+        removed = [n for n in before if n not in after]
+        added = [n for n in after if n not in before]
+        if removed:
+            for name, ok in self.daemon.stop(only=removed).items():
+                result.changes.append(f"{'stopped' if ok else 'failed to stop'} {name}")
+        if added:
+            for name, ok in self.daemon.start(only=added).items():
+                result.changes.append(
+                    f"{'started' if ok else 'failed to start'} {name}"
+                )
+
+        old_projects = {str(Path(p).resolve()) for p in old.projects}
+        new_projects = {str(Path(p).resolve()) for p in new.projects}
+        for project in sorted(old_projects - new_projects):
+            self.daemon.detach(Path(project))
+            result.changes.append(f"detached {project}")
+        for project in sorted(new_projects - old_projects):
+            self.daemon.attach(Path(project))
+            result.changes.append(f"attached {project}")
+
+        if new.check_interval != old.check_interval:
+            result.changes.append(
+                f"check_interval {old.check_interval:g}s -> {new.check_interval:g}s"
+            )
+        self.config = new
+
+        # Settings other code reads through Config take effect on next use
+        from ..core.config import Config
+
+        Config.reset_singleton()
+
+        logger.info(f"Daemon service reloaded: {result.summary()}")
+        for setting in result.restart_required:
+            logger.warning(f"Restart the daemon service to apply {setting}")
+        self.daemon.record_service(last_reload=result.to_dict())
+        return result
+
+    def _restart_dead_components(self) -> None:
+        _, body = self.health()
+        dead = [name for name, running in body["components"].items() if not running]
+        if dead:
+            logger.warning(f"Restarting stopped components: {', '.join(dead)}")
+            self.daemon.start(only=dead)
+
+
+def request_service_reload(
+    daemon: SharedDaemon | None = None, timeout: float = 10.0
+) -> tuple[int, ReloadResult]:
+    """Signal the running foreground service to reload and wait for it.
+
+    Returns:
+        The service's PID and the outcome of its reload
+
+    Raises:
+        DaemonServiceError: If no service is running, the platform has no
+            SIGHUP, or the service does not reload within *timeout* seconds
+    """
+    daemon = daemon or SharedDaemon()
+    service = daemon.service() or {}
+    pid = service.get("pid")
+    if not pid:
+        raise DaemonServiceError(
+            "No daemon service is running (start one with 'claude-mpm daemon run')"
+        )
+    if not hasattr(signal, "SIGHUP"):
+        raise DaemonServiceError("Reloading needs SIGHUP, which this platform lacks")
+    previous = (service.get("last_reload") or {}).get("reloaded_at")
+    try:
+        os.kill(pid, signal.SIGHUP)
+    except ProcessLookupError:
+        daemon.clear_service()
+        raise DaemonServiceError(
+            f"The daemon service (PID {pid}) is no longer running"
+        ) from None
+    deadline = time.monotonic() + timeout
+    while time.monotonic() < deadline:
+        last = (daemon.service() or {}).get("last_reload") or {}
+        if last.get("reloaded_at") != previous:
+            return pid, ReloadResult.from_dict(last)
+        time.sleep(0.1)
+    raise DaemonServiceError(
+        f"The daemon service (PID {pid}) did not reload within {timeout:g}s"
+    )
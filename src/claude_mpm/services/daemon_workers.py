@@ -0,0 +1,307 @@
+"""
+Scheduler and watcher processes of the shared daemon.
+
+WHAT: The shared daemon's ``scheduler`` and ``watchers`` components are each
+one background process serving every attached project:
+
+- ``DaemonScheduler`` runs ``SCHEDULED_JOBS`` (advisory checks, storage
+  prune) for each project when they are due
+- ``AgentWatchers`` hot-reloads edited agents in each project, as
+  ``claude-mpm agents watch`` does for one
+
+``WorkerProcess`` is the component wrapper that starts, stops and reports
+on such a process; ``python -m claude_mpm.services.daemon_workers NAME``
+is what it runs.
+
+WHY: These used to run only while a project's CLI was open (startup checks)
+or in a terminal the user kept around (``agents watch``), one copy per
+project.
+
+DESIGN DECISIONS:
+- The attached projects are re-read from the daemon's state on every pass,
+  so attach and detach take effect without restarting the workers
+- Per-project state stays in the project's namespace: jobs read the
+  project's own ``.claude-mpm/configuration.yaml`` (``Config`` would read
+  the worker's CWD) and record their runs under
+  ``~/.claude-mpm/daemon/projects/<namespace>/``
+- A failing job or project is logged and recorded, and never stops the
+  worker from serving the other projects
+"""
+
+from __future__ import annotations
+
+import os
+import signal
+import subprocess  # nosec B404
+import sys
+import threading
+import time
+from collections.abc import Callable
+from pathlib import Path
+from typing import Any
+
+from ..core.logging_config import get_logger
+from ..core.state_files import read_json, update_json, write_atomic
+from .shared_daemon import (
+    DEFAULT_STATE_DIR,
+    SHARED_DAEMON_ENV,
+    SharedDaemon,
+    _pid_alive,
+    _read_pid,
+)
+
+logger = get_logger(__name__)
+
+SCHEDULER_FILE = "scheduler.json"
+WATCHERS_FILE = "watchers.json"
+# Seconds between scheduler passes
+SCHEDULER_INTERVAL = 60.0
+# Seconds between checks of which projects are attached
+SYNC_INTERVAL = 5.0
+# Seconds a stopping worker gets before stop() reports failure
+STOP_TIMEOUT = 10.0
+
+
+def project_config(project_root: Path) -> dict[str, Any]:
+    """The project's own configuration, or {} if it has none."""
+    import yaml
+
+    for name in ("configuration.yaml", "configuration.yml"):
+        path = Path(project_root) / ".claude-mpm" / name
+        if not path.is_file():
+            continue
+        try:
+            data = yaml.safe_load(path.read_text(encoding="utf-8"))
+        except (OSError, yaml.YAMLError) as e:
+            logger.warning(f"Ignoring unreadable configuration {path}: {e}")
+            return {}
+        return data if isinstance(data, dict) else {}
+    return {}
+
+
+def _check_advisories(project_root: Path) -> None:
+    from .advisories import AdvisoryConfig, check_in_background
+
+    config = AdvisoryConfig.load(project_config(project_root))
+    check_in_background(project_root, config)
+
+
+def _prune_storage(project_root: Path) -> None:
+    from .storage_retention import StorageManager, StoragePolicy
+
+    policy = StoragePolicy.load(project_config(project_root))
+    if policy.auto_prune:
+        StorageManager(project_dir=project_root, policy=policy).prune()
+
+
+# name -> (seconds between runs, job(project_root)). Jobs with their own
+# schedule (advisories' interval_hours) are checked hourly and decide.
+SCHEDULED_JOBS: dict[str, tuple[float, Callable[[Path], None]]] = {
+    "advisories": (3600.0, _check_advisories),
+    "storage_prune": (24 * 3600.0, _prune_storage),
+}
+
+
+class DaemonScheduler:
+    """Runs scheduled jobs for every attached project when they are due."""
+
+    def __init__(
+        self,
+        daemon: SharedDaemon,
+        jobs: dict[str, tuple[float, Callable[[Path], None]]] | None = None,
+    ):
+        self.daemon = daemon
+        self.jobs = SCHEDULED_JOBS if jobs is None else jobs
+
+    def tick(self, now: float | None = None) -> list[tuple[str, str]]:
+        """Run every due job once.
+
+        Returns:
+            (namespace, job name) of each job that ran
+        """
+        now = time.time() if now is None else now
+        ran: list[tuple[str, str]] = []
+        for root, entry in self.daemon.list_projects().items():
+            namespace = entry["namespace"]
+            path = self.daemon.namespace_dir(namespace) / SCHEDULER_FILE
+            runs = (read_json(path, {}) or {}).get("jobs", {})
+            for name, (interval, job) in self.jobs.items():
+                if now - runs.get(name, {}).get("last_run", 0) < interval:
+                    continue
+                error = None
+                try:
+                    job(Path(root))
+                except Exception as e:
+                    error = str(e)
+                    logger.warning(f"Scheduled {name} failed for {namespace}: {e}")
+
+                def record(data: dict[str, Any], name=name, error=error) -> None:
+                    data.setdefault("jobs", {})[name] = {
+                        "last_run": now,
+                        "error": error,
+                    }
+
+                update_json(path, record)
+                ran.append((namespace, name))
+        return ran
+
+    def run(self, stop: threading.Event) -> None:
+        while not stop.is_set():
+            self.tick()
+            stop.wait(SCHEDULER_INTERVAL)
+
+
+class AgentWatchers:
+    """Hot-reloads edited agents in every attached project."""
+
+    def __init__(self, daemon: SharedDaemon, observer: Any = None):
+        self.daemon = daemon
+        self.observer = observer
+        self.reloaders: dict[str, Any] = {}  # project root -> AgentReloader
+        self._watches: dict[str, list[Any]] = {}
+
+    def sync(self) -> None:
+        """Watch newly attached projects and stop watching detached ones."""
+        from .agents.agent_hot_reload import AgentReloader
+
+        projects = self.daemon.list_projects()
+        for root in [r for r in self.reloaders if r not in projects]:
+            for watch in self._watches.pop(root, []):
+                self.observer.unschedule(watch)
+            del self.reloaders[root]
+
+        for root in projects:
+            if root in self.reloaders:
+                continue
+            reloader = AgentReloader(Path(root))
+            self.reloaders[root] = reloader
+            self._watches[root] = [
+                self.observer.schedule(
+                    reloader.event_handler(), str(directory), recursive=True
+                )
+                for directory in reloader.watched_dirs()
+            ]
+            self._report(root, reloader.reload_changed())
+
+    def poll(self, now: float | None = None) -> None:
+        """Reload agents in projects whose changes have settled."""
+        for root, reloader in list(self.reloaders.items()):
+            summary = reloader.poll(now)
+            if summary is not None:
+                self._report(root, summary)
+
+    def _report(self, root: str, summary: dict[str, Any]) -> None:
+        entry = self.daemon.list_projects().get(root)
+        if entry is None or not any(summary.values()):
+            return
+        logger.info(f"Agents reloaded in {entry['namespace']}: {summary}")
+
+        def record(data: dict[str, Any]) -> None:
+            data["last_reload"] = dict(summary, at=time.time())
+
+        path = self.daemon.namespace_dir(entry["namespace"]) / WATCHERS_FILE
+        update_json(path, record)
+
+    def run(self, stop: threading.Event, interval: float = 0.1) -> None:
+        if self.observer is None:
+            from watchdog.observers import Observer
+
+            self.observer = Observer()
+        self.observer.start()
+        next_sync = 0.0
+        try:
+            while not stop.is_set():
+                if time.monotonic() >= next_sync:
+                    self.sync()
+                    next_sync = time.monotonic() + SYNC_INTERVAL
+                self.poll()
+                stop.wait(interval)
+        finally:
+            self.observer.stop()
+            self.observer.join()
+
+
+# Component name -> worker class run in its process
+WORKERS: dict[str, Callable[[SharedDaemon], Any]] = {
+    "scheduler": DaemonScheduler,
+    "watchers": AgentWatchers,
+}
+
+
+def _alive(pid: int | None) -> bool:
+    """Like _pid_alive, but an exited child of this process is not alive."""
+    if not pid:
+        return False
+    try:
+        if os.waitpid(pid, os.WNOHANG)[0] == pid:
+            return False
+    except ChildProcessError:
+        pass  # Not our child: started by an earlier CLI process
+    return _pid_alive(pid)
+
+
+class WorkerProcess:
+    """A daemon component running one of ``WORKERS`` in its own process."""
+
+    def __init__(self, name: str, state_dir: Path | None = None):
+        if name not in WORKERS:
+            raise ValueError(f"Unknown daemon worker '{name}'")
+        self.name = name
+        self.state_dir = Path(state_dir or DEFAULT_STATE_DIR)
+        self.pid_file = self.state_dir / f"{name}.pid"
+        self.log_file = self.state_dir / "logs" / f"{name}.log"
+
+    def status(self) -> dict[str, Any]:
+        pid = _read_pid(self.pid_file)
+        running = _alive(pid)
+        return {"running": running, "pid": pid if running else None}
+
+    def start(self) -> bool:
+        self.log_file.parent.mkdir(parents=True, exist_ok=True)
+        with self.log_file.open("a") as log:
+            process = subprocess.Popen(  # nosec B603 - runs this module
+                [sys.executable, "-m", __name__, self.name, str(self.state_dir)],
+                stdin=subprocess.DEVNULL,
+                stdout=log,
+                stderr=subprocess.STDOUT,
+                start_new_session=True,
+                env={**os.environ, SHARED_DAEMON_ENV: "1"},
+            )
+        write_atomic(self.pid_file, str(process.pid))
+        try:
+            process.wait(timeout=0.5)
+        except subprocess.TimeoutExpired:
+            return True
+        logger.error(f"Daemon worker {self.name} exited; see {self.log_file}")
+        return False
+
+    def stop(self) -> bool:
+        pid = _read_pid(self.pid_file)
+        if _alive(pid):
+            os.kill(pid, signal.SIGTERM)
+            deadline = time.monotonic() + STOP_TIMEOUT
+            while _alive(pid):
+                if time.monotonic() >= deadline:
+                    return False
+                time.sleep(0.1)
+        self.pid_file.unlink(missing_ok=True)
+        return True
+
+
+def main(argv: list[str] | None = None) -> int:
+    """Worker process: ``python -m claude_mpm.services.daemon_workers NAME``."""
+    argv = sys.argv[1:] if argv is None else argv
+    if not argv or argv[0] not in WORKERS:
+        print(f"usage: python -m {__name__} {{{','.join(WORKERS)}}} [STATE_DIR]")
+        return 2
+    daemon = SharedDaemon(state_dir=Path(argv[1]) if len(argv) > 1 else None)
+    stop = threading.Event()
+    for signum in (signal.SIGTERM, signal.SIGINT):
+        signal.signal(signum, lambda *_: stop.set())
+    logger.info(f"Daemon worker {argv[0]} started (PID {os.getpid()})")
+    WORKERS[argv[0]](daemon).run(stop)
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
@@ -0,0 +1,218 @@
+"""Saved dashboard views.
+
+WHAT: A view is a named combination of dashboard filters and layout, such as
+"blocked sessions across all projects" (project ``all``, label ``blocked``)
+or "today's agent activity" (the Agents tab, events since midnight). Views
+are kept for the user in ``~/.claude-mpm/dashboard-views.json``::
+
+    {
+      "version": 1,
+      "views": {
+        "blocked": {"name": "blocked", "description": "Blocked everywhere",
+                    "tab": "events", "project": "all", "label": "blocked",
+                    "stream": "", "since": "", "left_width": null,
+                    "updated_at": "2026-10-17T09:30:00+00:00"}
+      }
+    }
+
+They are saved from the dashboard's View menu or with ``claude-mpm view
+save``, picked from the same menu, and opened in the browser with
+``claude-mpm view open <name>``, which loads the dashboard at ``/?view=<name>``.
+
+WHY: Every visit to the dashboard started from the same defaults, and
+getting back to a useful slice meant setting the project, label, stream and
+tab filters again by hand.
+
+DESIGN DECISIONS:
+- One file per user, not per project: a single monitor serves every
+  project's sessions, and views like "all projects" are not tied to one
+- Views are validated against the tabs and filter values the dashboard
+  knows, so a typo fails when saving rather than silently showing nothing
+- Saving an existing name replaces that view
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+from urllib.parse import quote
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json
+
+logger = get_logger(__name__)
+
+VIEWS_FILE = "dashboard-views.json"
+# The dashboard's tabs (ViewMode in routes/+page.svelte)
+TABS = (
+    "events",
+    "tools",
+    "files",
+    "agents",
+    "config",
+    "graph",
+    "captures",
+    "artifacts",
+    "checklist",
+)
+PROJECT_SCOPES = ("current", "all")
+# Event time windows; "" shows every event the dashboard holds
+SINCE = ("", "1h", "today")
+
+_NAME_RE = re.compile(r"^[a-z0-9][a-z0-9_-]{0,63}$")
+
+
+def user_views_path() -> Path:
+    return Path.home() / ".claude-mpm" / VIEWS_FILE
+
+
+def view_url(base_url: str, name: str) -> str:
+    """Dashboard URL that opens view *name*."""
+    return f"{base_url.rstrip('/')}/?view={quote(name)}"
+
+
+@dataclass
+class DashboardView:
+    """A named set of dashboard filters and layout."""
+
+    name: str
+    description: str = ""
+    tab: str = "events"
+    project: str = "current"
+    label: str = ""
+    stream: str = ""
+    since: str = ""
+    # Width of the left panel in percent; None keeps the dashboard's default
+    left_width: int | None = None
+    updated_at: str = ""
+
+    def validate(self) -> DashboardView:
+        """Normalize this view in place and return it.
+
+        Raises:
+            ValueError: If a field has a value the dashboard does not know.
+        """
+        self.name = self.name.strip().lower()
+        if not _NAME_RE.match(self.name):
+            raise ValueError(
+                f"Invalid view name '{self.name}': use up to 64 letters, digits, "
+                "'_' and '-' starting with a letter or digit"
+            )
+        for field, value, allowed in (
+            ("tab", self.tab, TABS),
+            ("project", self.project, PROJECT_SCOPES),
+            ("since", self.since, SINCE),
+        ):
+            if value not in allowed:
+                choices = ", ".join(repr(choice) for choice in allowed)
+                raise ValueError(
+                    f"Invalid {field} '{value}': expected one of {choices}"
+                )
+        if self.label:
+            from .session_labels import normalize_label
+
+            self.label = normalize_label(self.label)
+        if self.left_width is not None:
+            if not isinstance(self.left_width, int) or not 10 <= self.left_width <= 90:
+                raise ValueError("left_width must be a whole percentage from 10 to 90")
+        return self
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> DashboardView:
+        """Build a validated view from JSON (the API body or the views file).
+
+        Raises:
+            ValueError: If the data is not a valid view.
+        """
+        if not isinstance(data, dict):
+            raise ValueError("A view must be a JSON object")
+        left_width = data.get("left_width")
+        if isinstance(left_width, float) and left_width.is_integer():
+            left_width = int(left_width)
+        return cls(
+            name=str(data.get("name") or ""),
+            description=str(data.get("description") or ""),
+            tab=str(data.get("tab") or "events"),
+            project=str(data.get("project") or "current"),
+            label=str(data.get("label") or ""),
+            stream=str(data.get("stream") or ""),
+            since=str(data.get("since") or ""),
+            left_width=left_width,
+            updated_at=str(data.get("updated_at") or ""),
+        ).validate()
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+    def summary(self) -> str:
+        """The view's filters in one line, e.g. ``tab=agents project=all``."""
+        parts = [f"tab={self.tab}", f"project={self.project}"]
+        parts.extend(
+            f"{field}={value}"
+            for field, value in (
+                ("label", self.label),
+                ("stream", self.stream),
+                ("since", self.since),
+            )
+            if value
+        )
+        if self.left_width is not None:
+            parts.append(f"left_width={self.left_width}")
+        return " ".join(parts)
+
+
+class DashboardViews:
+    """The user's saved dashboard views."""
+
+    def __init__(self, path: Path | None = None) -> None:
+        self.path = Path(path or user_views_path())
+
+    def _raw(self) -> dict[str, Any]:
+        data = read_json(self.path, {})
+        views = data.get("views") if isinstance(data, dict) else None
+        return views if isinstance(views, dict) else {}
+
+    def all(self) -> list[DashboardView]:
+        """Saved views sorted by name; invalid entries are skipped."""
+        views = []
+        for name, data in sorted(self._raw().items()):
+            try:
+                views.append(DashboardView.from_dict({**data, "name": name}))
+            except (ValueError, TypeError) as e:
+                logger.warning(f"Ignoring invalid dashboard view '{name}': {e}")
+        return views
+
+    def get(self, name: str) -> DashboardView | None:
+        name = name.strip().lower()
+        return next((view for view in self.all() if view.name == name), None)
+
+    def save(self, view: DashboardView) -> DashboardView:
+        """Save *view*, replacing a view of the same name.
+
+        Raises:
+            ValueError: If the view is invalid.
+        """
+        view.validate()
+        view.updated_at = datetime.now(UTC).isoformat()
+
+        def write(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            data.setdefault("views", {})[view.name] = view.to_dict()
+
+        update_json(self.path, write)
+        return view
+
+    def delete(self, name: str) -> bool:
+        """Delete view *name*; returns whether it existed."""
+        name = name.strip().lower()
+        found = False
+
+        def write(data: dict[str, Any]) -> None:
+            nonlocal found
+            found = data.get("views", {}).pop(name, None) is not None
+
+        update_json(self.path, write)
+        return found
@@ -0,0 +1,363 @@
+"""Optional at-rest encryption for transcripts, memories and the event log.
+
+WHAT: When enabled, files in the configured scopes are written encrypted and
+read back transparently by claude-mpm. Two backends are supported:
+
+- ``keyring``: Fernet with a data key kept in the system keyring (or in
+  ``CLAUDE_MPM_DATA_KEY`` for headless machines), as OAuth tokens already are
+- ``age``: the ``age`` CLI, encrypting to configured recipients and
+  decrypting with an identity file, for users who manage keys with age
+
+WHY: On shared or corporate-managed machines, plaintext session responses,
+agent memories and event payloads under .claude-mpm are readable by anyone
+with access to the disk or its backups.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    encryption:
+      enabled: true
+      backend: keyring              # or: age
+      scopes: [transcripts, memories, events]
+      age_recipients: ["age1..."]   # age backend only
+      age_identity: ~/.config/age/key.txt
+
+DESIGN DECISIONS:
+- Encrypted files start with a marker line naming the backend, so readers
+  detect them by content: plaintext files keep working after encryption is
+  turned on, and encrypted files stay readable after it is turned off
+- Encryption is opt-in and off by default; reading an encrypted file without
+  the key fails loudly instead of returning ciphertext
+- ``claude-mpm storage encrypt/decrypt`` converts existing files in place and
+  ``claude-mpm storage cat`` prints a decrypted file for authorized use
+"""
+
+from __future__ import annotations
+
+import os
+import subprocess  # nosec B404
+from dataclasses import dataclass, field
+from functools import cache
+from pathlib import Path
+from typing import Any, Protocol
+
+from ..core.logger import get_logger
+from ..core.state_files import write_atomic
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "encryption"
+KEY_ENV_VAR = "CLAUDE_MPM_DATA_KEY"
+KEYRING_SERVICE = "claude-mpm-data"
+KEYRING_USERNAME = "data-key"
+
+TRANSCRIPTS = "transcripts"
+MEMORIES = "memories"
+EVENTS = "events"
+SCOPES = (TRANSCRIPTS, MEMORIES, EVENTS)
+
+KEYRING = "keyring"
+AGE = "age"
+BACKENDS = (KEYRING, AGE)
+
+MARKER = b"CLAUDE-MPM-ENCRYPTED v1 "
+COMMAND_TIMEOUT = 30
+
+
+class DataEncryptionError(Exception):
+    """Raised when data cannot be encrypted or decrypted."""
+
+
+class EncryptionBackend(Protocol):
+    name: str
+
+    def encrypt(self, data: bytes) -> bytes: ...
+
+    def decrypt(self, data: bytes) -> bytes: ...
+
+
+@dataclass
+class EncryptionConfig:
+    """Whether encryption is on, with which backend, for which scopes."""
+
+    enabled: bool = False
+    backend: str = KEYRING
+    scopes: list[str] = field(default_factory=lambda: list(SCOPES))
+    age_recipients: list[str] = field(default_factory=list)
+    age_identity: str | None = None
+
+    @classmethod
+    def load(cls, config: Any = None) -> EncryptionConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        defaults = cls()
+        return cls(
+            enabled=bool(section.get("enabled", defaults.enabled)),
+            backend=section.get("backend", defaults.backend),
+            scopes=list(section.get("scopes") or defaults.scopes),
+            age_recipients=list(section.get("age_recipients") or []),
+            age_identity=section.get("age_identity"),
+        )
+
+
+class FernetKeyringBackend:
+    """Fernet with a data key from ``CLAUDE_MPM_DATA_KEY`` or the keyring."""
+
+    name = KEYRING
+
+    def __init__(self, key: bytes | None = None):
+        self._key = key
+
+    def _fernet(self, create: bool):
+        try:
+            from cryptography.fernet import Fernet
+        except ImportError as e:
+            raise DataEncryptionError(
+                "The keyring backend needs the 'cryptography' package"
+            ) from e
+        if self._key is None:
+            self._key = self._load_key(create, Fernet)
+        return Fernet(self._key)
+
+    @staticmethod
+    def _load_key(create: bool, fernet_cls) -> bytes:
+        env_key = os.environ.get(KEY_ENV_VAR)
+        if env_key:
+            return env_key.encode()
+        try:
+            import keyring
+
+            stored = keyring.get_password(KEYRING_SERVICE, KEYRING_USERNAME)
+            if stored:
+                return stored.encode()
+            if not create:
+                raise DataEncryptionError(
+                    f"No data key in the keyring ({KEYRING_SERVICE}) or "
+                    f"{KEY_ENV_VAR}; cannot decrypt"
+                )
+            key = fernet_cls.generate_key()
+            keyring.set_password(KEYRING_SERVICE, KEYRING_USERNAME, key.decode())
+            logger.info(f"Created a data key in the keyring ({KEYRING_SERVICE})")
+            return key
+        except DataEncryptionError:
+            raise
+        except Exception as e:
+            raise DataEncryptionError(
+                f"Keyring unavailable ({e}); set {KEY_ENV_VAR} to a Fernet key"
+            ) from e
+
+    def encrypt(self, data: bytes) -> bytes:
+        return self._fernet(create=True).encrypt(data)
+
+    def decrypt(self, data: bytes) -> bytes:
+        from cryptography.fernet import InvalidToken
+
+        try:
+            return self._fernet(create=False).decrypt(data)
+        except InvalidToken as e:
+            raise DataEncryptionError("Data key does not match this file") from e
+
+
+class AgeBackend:
+    """The ``age`` CLI, ASCII-armored so encrypted files stay text."""
+
+    name = AGE
+
+    def __init__(self, recipients: list[str], identity: str | None):
+        self.recipients = recipients
+        self.identity = identity
+
+    def encrypt(self, data: bytes) -> bytes:
+        if not self.recipients:
+            raise DataEncryptionError(f"Set {CONFIG_KEY}.age_recipients to use age")
+        command = ["age", "--encrypt", "--armor"]
+        for recipient in self.recipients:
+            command += ["--recipient", recipient]
+        return self._run(command, data)
+
+    def decrypt(self, data: bytes) -> bytes:
+        if not self.identity:
+            raise DataEncryptionError(f"Set {CONFIG_KEY}.age_identity to use age")
+        identity = str(Path(self.identity).expanduser())
+        return self._run(["age", "--decrypt", "--identity", identity], data)
+
+    def _run(self, command: list[str], data: bytes) -> bytes:
+        try:
+            result = subprocess.run(  # nosec B603
+                command,
+                input=data,
+                capture_output=True,
+                timeout=COMMAND_TIMEOUT,
+                check=False,
+            )
+        except FileNotFoundError as e:
+            raise DataEncryptionError("'age' is not installed") from e
+        except subprocess.TimeoutExpired as e:
+            raise DataEncryptionError(f"age timed out after {COMMAND_TIMEOUT}s") from e
+        if result.returncode != 0:
+            raise DataEncryptionError(
+                f"age failed: {result.stderr.decode(errors='replace').strip()}"
+            )
+        return result.stdout
+
+
+def is_encrypted(data: bytes | str) -> bool:
+    if isinstance(data, str):
+        return data.startswith(MARKER.decode())
+    return data.startswith(MARKER)
+
+
+class DataEncryption:
+    """Encrypts writes in enabled scopes and decrypts any encrypted read."""
+
+    def __init__(
+        self,
+        config: EncryptionConfig | None = None,
+        backends: dict[str, EncryptionBackend] | None = None,
+    ):
+        self.config = config or EncryptionConfig.load()
+        if self.config.backend not in BACKENDS and not backends:
+            raise DataEncryptionError(
+                f"Unknown encryption backend '{self.config.backend}' "
+                f"(expected one of {', '.join(BACKENDS)})"
+            )
+        self._backends = backends or {}
+
+    def backend(self, name: str | None = None) -> EncryptionBackend:
+        name = name or self.config.backend
+        if name not in self._backends:
+            if name == KEYRING:
+                self._backends[name] = FernetKeyringBackend()
+            elif name == AGE:
+                self._backends[name] = AgeBackend(
+                    self.config.age_recipients, self.config.age_identity
+                )
+            else:
+                raise DataEncryptionError(f"Unknown encryption backend '{name}'")
+        return self._backends[name]
+
+    def enabled_for(self, scope: str) -> bool:
+        return self.config.enabled and scope in self.config.scopes
+
+    def encrypt(self, data: bytes) -> bytes:
+        backend = self.backend()
+        return MARKER + backend.name.encode() + b"\n" + backend.encrypt(data)
+
+    def decrypt(self, data: bytes) -> bytes:
+        if not is_encrypted(data):
+            return data
+        header, _, payload = data.partition(b"\n")
+        name = header[len(MARKER) :].decode()
+        return self.backend(name).decrypt(payload)
+
+    # Both backends produce ASCII, so encrypted content is still valid text
+    # and callers keep using Path.read_text/write_text around these two.
+
+    def protect(self, text: str, scope: str) -> str:
+        """``text`` as it should be stored for ``scope``."""
+        if not self.enabled_for(scope):
+            return text
+        return self.encrypt(text.encode("utf-8")).decode("ascii")
+
+    def reveal(self, text: str) -> str:
+        """Stored ``text`` as plaintext, decrypting it if it is encrypted."""
+        if not is_encrypted(text):
+            return text
+        return self.decrypt(text.encode("ascii")).decode("utf-8")
+
+    # ------------------------------------------------------------------
+    # Files
+    # ------------------------------------------------------------------
+
+    def read_text(self, path: Path) -> str:
+        return self.reveal(Path(path).read_text(encoding="utf-8"))
+
+    def encrypt_file(self, path: Path) -> bool:
+        """Encrypt ``path`` in place; False if it already was."""
+        data = Path(path).read_bytes()
+        if is_encrypted(data):
+            return False
+        write_atomic(path, self.encrypt(data).decode("ascii"))
+        return True
+
+    def decrypt_file(self, path: Path) -> bool:
+        """Decrypt ``path`` in place; False if it was not encrypted."""
+        data = Path(path).read_bytes()
+        if not is_encrypted(data):
+            return False
+        write_atomic(path, self.decrypt(data).decode("utf-8"))
+        return True
+
+
+def scope_files(
+    scope: str, project_dir: Path, home_dir: Path | None = None
+) -> list[Path]:
+    """Files that belong to ``scope`` for one project (and the user)."""
+    project = Path(project_dir) / ".claude-mpm"
+    home = Path(home_dir or Path.home() / ".claude-mpm")
+    if scope == MEMORIES:
+        roots = [(project / "memories", "*.md"), (home / "memories", "*.md")]
+    elif scope == TRANSCRIPTS:
+        roots = [(project / "responses", "**/*.json")]
+    elif scope == EVENTS:
+        return [p for p in [project / "event_log.json"] if p.is_file()]
+    else:
+        raise ValueError(
+            f"Unknown encryption scope '{scope}' (expected one of {', '.join(SCOPES)})"
+        )
+    return sorted(
+        path
+        for root, pattern in roots
+        if root.is_dir()
+        for path in root.glob(pattern)
+        if path.is_file() and path.name != "README.md"
+    )
+
+
+@cache
+def _default() -> DataEncryption:
+    return DataEncryption()
+
+
+def default_encryption() -> DataEncryption | None:
+    """The configured encryption, or None if encryption is not configured.
+
+    Raises:
+        DataEncryptionError: If encryption is enabled but cannot be set up,
+            so data is never written in plaintext by mistake.
+    """
+    try:
+        return _default()
+    except DataEncryptionError as e:
+        if EncryptionConfig.load().enabled:
+            raise DataEncryptionError(
+                f"At-rest encryption is enabled but cannot be set up: {e}"
+            ) from e
+        logger.warning(f"At-rest encryption disabled: {e}")
+        return None
+
+
+def protect(text: str, scope: str) -> str:
+    """``text`` encrypted if encryption is enabled for ``scope``, else as is.
+
+    Raises:
+        DataEncryptionError: If encryption is enabled but unusable.
+    """
+    encryption = default_encryption()
+    return encryption.protect(text, scope) if encryption else text
+
+
+def reveal(text: str) -> str:
+    """Decrypt stored ``text`` if it is encrypted; plaintext passes through."""
+    if not is_encrypted(text):
+        return text
+    encryption = default_encryption()
+    if encryption is None:
+        raise DataEncryptionError("File is encrypted and encryption is not set up")
+    return encryption.reveal(text)
@@ -0,0 +1,207 @@
+"""Content-hash aware writes for agent and skill deployment.
+
+WHAT: Deploys a file or a directory by comparing content, not by replacing
+it: a file is written only when its bytes differ from what is deployed,
+files that disappeared from the source are removed, and everything else is
+left untouched. Each sync returns a ``DeploymentDelta`` listing exactly which
+files were added, changed, removed or left unchanged.
+
+WHY: Deployment used to delete and re-copy every skill directory on each
+run. Rewriting identical files churns mtimes, which wakes file watchers
+(Claude Code reloads skills and agents on change) and makes "updated" in the
+deployment summary meaningless.
+
+DESIGN DECISIONS:
+- Sizes are compared before hashing, so the common unchanged case reads each
+  file once on each side
+- Files are written through a temporary file and ``os.replace`` so a watcher
+  never sees a half-written SKILL.md
+- Copies keep the source mtime (``shutil.copy2``), matching the previous
+  ``copytree`` behaviour that the mtime-based freshness checks rely on
+- With ``merge=True``, text files that already exist go through
+  ``deployment_merge.apply_update`` so local edits are merged rather than
+  overwritten
+"""
+
+from __future__ import annotations
+
+import hashlib
+import os
+import shutil
+import tempfile
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+
+from .deployment_integrity import SKILL
+
+ADDED = "added"
+CHANGED = "changed"
+REMOVED = "removed"
+UNCHANGED = "unchanged"
+
+
+@dataclass
+class DeploymentDelta:
+    """Files touched by one deployment, as paths relative to its target."""
+
+    added: list[str] = field(default_factory=list)
+    changed: list[str] = field(default_factory=list)
+    removed: list[str] = field(default_factory=list)
+    unchanged: list[str] = field(default_factory=list)
+    # Only with merge=True: upstream and local edits merged, local edits kept
+    # (upstream unchanged), and conflicting files left as they were
+    merged: list[str] = field(default_factory=list)
+    kept: list[str] = field(default_factory=list)
+    conflicts: list[str] = field(default_factory=list)
+
+    @property
+    def has_changes(self) -> bool:
+        return bool(self.added or self.changed or self.removed or self.merged)
+
+    def summary(self) -> str:
+        """E.g. ``"1 changed, 2 added"``, or ``"no changes"``."""
+        parts = [
+            f"{len(paths)} {label}"
+            for label, paths in (
+                (CHANGED, self.changed),
+                (ADDED, self.added),
+                (REMOVED, self.removed),
+                ("merged", self.merged),
+                ("conflicting", self.conflicts),
+            )
+            if paths
+        ]
+        return ", ".join(parts) or "no changes"
+
+    def to_dict(self) -> dict[str, list[str]]:
+        return asdict(self)
+
+
+def _digest(path: Path) -> str:
+    return hashlib.sha256(path.read_bytes()).hexdigest()
+
+
+def _same_content(source: Path, target: Path) -> bool:
+    if not target.is_file() or target.is_symlink():
+        return False
+    if source.stat().st_size != target.stat().st_size:
+        return False
+    return _digest(source) == _digest(target)
+
+
+def _atomic_copy(source: Path, target: Path) -> None:
+    target.parent.mkdir(parents=True, exist_ok=True)
+    fd, tmp = tempfile.mkstemp(prefix=f".{target.name}.", dir=target.parent)
+    os.close(fd)
+    try:
+        shutil.copy2(source, tmp)
+        if target.is_symlink() or target.is_dir():
+            _remove(target)
+        os.replace(tmp, target)
+    except BaseException:
+        Path(tmp).unlink(missing_ok=True)
+        raise
+
+
+def _text(path: Path) -> str | None:
+    try:
+        return path.read_text(encoding="utf-8")
+    except UnicodeDecodeError:
+        return None
+
+
+def _remove(path: Path) -> None:
+    if path.is_dir() and not path.is_symlink():
+        shutil.rmtree(path)
+    else:
+        path.unlink()
+
+
+def write_if_changed(target: Path, content: str) -> bool:
+    """Write *content* to *target* unless it already holds exactly that.
+
+    Returns:
+        True if the file was written.
+    """
+    data = content.encode("utf-8")
+    if target.is_file() and not target.is_symlink():
+        if target.stat().st_size == len(data) and target.read_bytes() == data:
+            return False
+    target.parent.mkdir(parents=True, exist_ok=True)
+    fd, tmp = tempfile.mkstemp(prefix=f".{target.name}.", dir=target.parent)
+    try:
+        with os.fdopen(fd, "wb") as f:
+            f.write(data)
+        if target.is_symlink():
+            target.unlink()
+        os.replace(tmp, target)
+    except BaseException:
+        Path(tmp).unlink(missing_ok=True)
+        raise
+    return True
+
+
+def sync_directory(
+    source_dir: Path, target_dir: Path, merge: bool = False
+) -> DeploymentDelta:
+    """Make *target_dir* hold the same files as *source_dir*, writing only
+    what differs.
+
+    A symlinked *target_dir* is replaced by a real directory, as the
+    previous delete-and-copy did. Hidden files are synced like any other.
+    With *merge*, local edits to existing text files are merged with the
+    source instead of overwritten (see ``deployment_merge``).
+    """
+    from .deployment_merge import (
+        CONFLICT,
+        KEPT,
+        MERGED,
+        UNCHANGED as MERGE_UNCHANGED,
+        WRITTEN,
+        apply_update,
+        save_base,
+    )
+
+    source_dir, target_dir = Path(source_dir), Path(target_dir)
+    delta = DeploymentDelta()
+    if target_dir.is_symlink() or target_dir.is_file():
+        target_dir.unlink()
+    existed = target_dir.is_dir()
+
+    wanted: set[str] = set()
+    for source in sorted(p for p in source_dir.rglob("*") if p.is_file()):
+        rel = source.relative_to(source_dir).as_posix()
+        wanted.add(rel)
+        target = target_dir / rel
+        upstream = _text(source) if merge else None
+        if upstream is not None and target.is_file() and not target.is_symlink():
+            status = apply_update(target, upstream, source=source, kind=SKILL)
+            paths = {
+                WRITTEN: delta.changed,
+                MERGE_UNCHANGED: delta.unchanged,
+                KEPT: delta.kept,
+                MERGED: delta.merged,
+                CONFLICT: delta.conflicts,
+            }
+            paths[status].append(rel)
+            continue
+        if _same_content(source, target):
+            delta.unchanged.append(rel)
+        else:
+            (delta.changed if target.exists() else delta.added).append(rel)
+            _atomic_copy(source, target)
+        if upstream is not None:
+            save_base(target, upstream)
+
+    if existed:
+        for target in sorted(target_dir.rglob("*"), reverse=True):
+            rel = target.relative_to(target_dir).as_posix()
+            if target.is_dir() and not target.is_symlink():
+                if not any(target.iterdir()) and not (source_dir / rel).is_dir():
+                    target.rmdir()
+            elif rel not in wanted:
+                _remove(target)
+                delta.removed.append(rel)
+        delta.removed.sort()
+    target_dir.mkdir(parents=True, exist_ok=True)
+    return delta
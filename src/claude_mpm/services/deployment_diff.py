@@ -0,0 +1,336 @@
+"""Differences between deployed agents/skills and their sources.
+
+WHAT: ``agent_diff`` and ``skill_diff`` compare what is deployed in
+``.claude/agents`` or ``.claude/skills`` (the project's, else the user's)
+with what a redeploy from its source would write, and return unified diffs.
+``claude-mpm agents diff <name>`` and ``claude-mpm skills diff <name>`` print
+them. An agent can also be compared with the newest template in the agent
+cache (``--latest``, what ``agents update`` would deploy) or with its
+deployed file as committed at a git revision (``--rev``).
+
+WHY: Syncs and updates overwrite deployed files. Local edits to a deployed
+agent or skill were only noticed after they were gone.
+
+DESIGN DECISIONS:
+- The source is the one recorded in the integrity manifest at deployment
+  time, which is what the next sync deploys from, or for an agent pinned in
+  agents.lock the pinned copy. Without a record, agents
+  fall back to the template lookup used by the playground (project, user,
+  cache, bundled) and skills to the skills cache and bundled skills
+- Agents are compared with the source as deploy_agent_file renders it
+  (agent_id/model frontmatter, SLD block), so deployment's own edits never
+  show up as local modifications
+- Diffs read source (or revision) -> deployed: ``+`` lines are what the
+  deployed copy has that the other side does not
+- Skill files that are not UTF-8 text are reported as changed without a diff
+"""
+
+from __future__ import annotations
+
+import difflib
+import subprocess
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from .deployment_integrity import AGENT, SKILL, IntegrityManifest, project_root_for
+
+logger = get_logger(__name__)
+
+ADDED = "added"  # only in the deployed copy
+REMOVED = "removed"  # only in the source
+CHANGED = "changed"
+
+
+@dataclass
+class FileDiff:
+    path: str  # relative to the deployed agent file or skill directory
+    status: str
+    diff: str = ""
+
+
+@dataclass
+class DeploymentDiff:
+    """How one deployed agent or skill differs from its source."""
+
+    name: str
+    kind: str
+    deployed: Path
+    source: Path | None
+    files: list[FileDiff] = field(default_factory=list)
+    # Git revision compared with instead of a source
+    revision: str | None = None
+
+    @property
+    def modified(self) -> bool:
+        return bool(self.files)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.name,
+            "kind": self.kind,
+            "deployed": str(self.deployed),
+            "source": str(self.source) if self.source else None,
+            "revision": self.revision,
+            "modified": self.modified,
+            "files": [f.__dict__ for f in self.files],
+        }
+
+    def render(self) -> str:
+        """Unified diffs of every changed file, plus added/removed files."""
+        parts = []
+        for f in self.files:
+            if f.diff:
+                parts.append(f.diff)
+            elif f.status == CHANGED:
+                parts.append(f"Binary file {f.path} differs\n")
+            else:
+                where = "deployed copy" if f.status == ADDED else "source"
+                parts.append(f"Only in {where}: {f.path}\n")
+        return "".join(parts)
+
+
+def _unified(path: str, expected: str, actual: str, before: str = "source") -> str:
+    lines = difflib.unified_diff(
+        expected.splitlines(keepends=True),
+        actual.splitlines(keepends=True),
+        fromfile=f"{before}/{path}",
+        tofile=f"deployed/{path}",
+    )
+    return "".join(line if line.endswith("\n") else line + "\n" for line in lines)
+
+
+def _deployed_path(relative: Path, project_dir: Path, home: Path) -> Path | None:
+    for root in dict.fromkeys([project_dir, home]):
+        path = root / ".claude" / relative
+        if path.exists():
+            return path
+    return None
+
+
+def _recorded_sources(deployed: Path) -> dict[str, Path]:
+    """Manifest sources of a deployed file or directory's files, by path."""
+    root = project_root_for(deployed / "x" if deployed.is_dir() else deployed)
+    if root is None:
+        return {}
+    prefix = deployed.absolute().relative_to(root).as_posix()
+    sources = {}
+    for key, entry in IntegrityManifest(root).entries().items():
+        if key == prefix:
+            sources[""] = Path(entry.source)
+        elif key.startswith(prefix + "/"):
+            sources[key[len(prefix) + 1 :]] = Path(entry.source)
+    return sources
+
+
+def _project_config(project_dir: Path) -> Any:
+    config_file = project_dir / ".claude-mpm" / "configuration.yaml"
+    if not config_file.exists():
+        return None
+    try:
+        from ..core.config import Config
+
+        return Config(config_file=config_file)
+    except Exception as e:
+        logger.debug(f"Could not load {config_file}: {e}")
+        return None
+
+
+def agent_source(
+    name: str, project_dir: Path | None = None, home: Path | None = None
+) -> Path | None:
+    """The template a deployed agent was deployed from, or would be."""
+    from .agents.agents_lock import AgentsLock
+    from .agents.deployment_utils import normalize_deployment_filename
+    from .agents.playground import DEPLOYED, locate_agent_source
+
+    project_dir = Path(project_dir or Path.cwd())
+    home = Path(home or Path.home())
+    filename = normalize_deployment_filename(f"{name}.md")
+    deployed = _deployed_path(Path("agents") / filename, project_dir, home)
+    lock = AgentsLock.for_deployment(deployed.parent) if deployed else None
+    if lock is not None and lock.get(Path(filename).stem):
+        pinned = lock.pinned_file(Path(filename).stem)
+        if pinned.is_file():
+            return pinned
+    source = _recorded_sources(deployed).get("") if deployed else None
+    if source is not None and source.is_file():
+        return source
+    located = locate_agent_source(Path(filename).stem, project_dir, home)
+    return located.path if located and located.kind != DEPLOYED else None
+
+
+def _deployed_agent(name: str, project_dir: Path, home: Path) -> tuple[str, Path]:
+    from .agents.deployment_utils import normalize_deployment_filename
+
+    filename = normalize_deployment_filename(f"{name}.md")
+    deployed = _deployed_path(Path("agents") / filename, project_dir, home)
+    if deployed is None:
+        raise FileNotFoundError(f"Agent '{name}' is not deployed")
+    return filename, deployed
+
+
+def agent_diff(
+    name: str,
+    project_dir: Path | None = None,
+    home: Path | None = None,
+    latest: bool = False,
+) -> DeploymentDiff:
+    """Diff a deployed agent against its source.
+
+    With *latest*, the source is the agent's newest template in the agent
+    cache rather than the template it was deployed (or is pinned) from.
+
+    Raises:
+        FileNotFoundError: The agent is not deployed or has no source.
+    """
+    from .agents.agent_composition import AgentComposer
+    from .agents.agent_overrides import AgentOverrides
+    from .agents.agents_lock import find_cached
+    from .agents.deployment_utils import render_agent_content
+
+    project_dir = Path(project_dir or Path.cwd())
+    home = Path(home or Path.home())
+    filename, deployed = _deployed_agent(name, project_dir, home)
+
+    if latest:
+        cache_dir = home / ".claude-mpm" / "cache" / "agents"
+        source = find_cached(Path(filename).stem, cache_dir)
+        if source is None:
+            raise FileNotFoundError(f"Agent '{name}' has no template in {cache_dir}")
+    else:
+        source = agent_source(name, project_dir, home)
+    if source is None:
+        raise FileNotFoundError(f"No source found for agent '{name}'")
+
+    expected = render_agent_content(
+        source.read_text(encoding="utf-8"),
+        filename,
+        config=_project_config(project_dir),
+        overrides=AgentOverrides(deployed.parent.parent.parent, home),
+        composer=AgentComposer(deployed.parent.parent.parent, home),
+        source_path=source,
+    )
+    actual = deployed.read_text(encoding="utf-8")
+    result = DeploymentDiff(Path(filename).stem, AGENT, deployed, source)
+    if expected != actual:
+        before = "latest" if latest else "source"
+        diff = _unified(filename, expected, actual, before=before)
+        result.files.append(FileDiff(filename, CHANGED, diff))
+    return result
+
+
+def _git(args: list[str], cwd: Path) -> subprocess.CompletedProcess:
+    return subprocess.run(
+        ["git", *args],
+        cwd=cwd,
+        capture_output=True,
+        text=True,
+        timeout=30,
+        check=False,
+    )
+
+
+def agent_revision_diff(
+    name: str,
+    revision: str,
+    project_dir: Path | None = None,
+    home: Path | None = None,
+) -> DeploymentDiff:
+    """Diff a deployed agent against its file as committed at *revision*.
+
+    Raises:
+        FileNotFoundError: The agent is not deployed, is not in a git
+            repository, or did not exist at *revision*.
+    """
+    project_dir = Path(project_dir or Path.cwd())
+    home = Path(home or Path.home())
+    filename, deployed = _deployed_agent(name, project_dir, home)
+
+    toplevel = _git(["rev-parse", "--show-toplevel"], deployed.parent)
+    if toplevel.returncode != 0:
+        raise FileNotFoundError(f"{deployed} is not in a git repository")
+    repo = Path(toplevel.stdout.strip())
+    relative = deployed.resolve().relative_to(repo.resolve()).as_posix()
+    committed = _git(["show", f"{revision}:{relative}"], repo)
+    if committed.returncode != 0:
+        raise FileNotFoundError(
+            f"{relative} is not in revision '{revision}': "
+            f"{committed.stderr.strip()}"
+        )
+
+    actual = deployed.read_text(encoding="utf-8")
+    result = DeploymentDiff(Path(filename).stem, AGENT, deployed, None)
+    result.revision = revision
+    if committed.stdout != actual:
+        diff = _unified(filename, committed.stdout, actual, before=revision)
+        result.files.append(FileDiff(filename, CHANGED, diff))
+    return result
+
+
+def _skill_source_dir(name: str, deployed: Path, home: Path) -> Path | None:
+    for relative, source in _recorded_sources(deployed).items():
+        parts = len(Path(relative).parts)
+        source_dir = source.parents[parts - 1] if parts else source
+        if source_dir.is_dir():
+            return source_dir
+    from .. import __file__ as package_init
+
+    for base in (
+        home / ".claude-mpm" / "cache" / "skills",
+        Path(package_init).parent / "skills" / "bundled",
+    ):
+        if base.is_dir():
+            for skill_md in sorted(base.rglob(f"{name}/SKILL.md")):
+                return skill_md.parent
+    return None
+
+
+def _read_text(path: Path) -> str | None:
+    try:
+        return path.read_text(encoding="utf-8")
+    except UnicodeDecodeError:
+        return None
+
+
+def _files(directory: Path) -> set[str]:
+    return {
+        p.relative_to(directory).as_posix()
+        for p in directory.rglob("*")
+        if p.is_file() and "__pycache__" not in p.parts
+    }
+
+
+def skill_diff(
+    name: str, project_dir: Path | None = None, home: Path | None = None
+) -> DeploymentDiff:
+    """Diff a deployed skill directory against its source directory.
+
+    Raises:
+        FileNotFoundError: The skill is not deployed or has no source.
+    """
+    project_dir = Path(project_dir or Path.cwd())
+    home = Path(home or Path.home())
+    deployed = _deployed_path(Path("skills") / name, project_dir, home)
+    if deployed is None or not deployed.is_dir():
+        raise FileNotFoundError(f"Skill '{name}' is not deployed")
+    source = _skill_source_dir(name, deployed, home)
+    if source is None:
+        raise FileNotFoundError(f"No source found for skill '{name}'")
+
+    result = DeploymentDiff(name, SKILL, deployed, source)
+    deployed_files = _files(deployed)
+    source_files = _files(source)
+    for path in sorted(deployed_files | source_files):
+        if path not in source_files:
+            result.files.append(FileDiff(path, ADDED))
+        elif path not in deployed_files:
+            result.files.append(FileDiff(path, REMOVED))
+        elif (source / path).read_bytes() != (deployed / path).read_bytes():
+            expected = _read_text(source / path)
+            actual = _read_text(deployed / path)
+            text = expected is not None and actual is not None
+            diff = _unified(path, expected, actual) if text else ""
+            result.files.append(FileDiff(path, CHANGED, diff))
+    return result
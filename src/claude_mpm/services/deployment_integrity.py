@@ -0,0 +1,358 @@
+"""Supply-chain verification of deployed agents and skills.
+
+WHAT: Every agent or skill file claude-mpm deploys is recorded in an
+integrity manifest with the SHA-256 of the deployed content, the cache file it
+came from, that file's SHA-256 and the commit of the source repository. On
+every run the deployed files and their sources are checked against the
+manifest:
+
+- ``modified``: the deployed file no longer matches what was deployed
+- ``source_modified``: the cached source changed without its repository
+  moving to a new commit (the cache was edited locally)
+- ``outdated``: the source moved to a new commit since deployment (benign;
+  the next sync or a re-deploy picks it up)
+- ``missing`` / ``source_missing``: the file or its source is gone
+
+``claude-mpm integrity verify`` shows the report and
+``claude-mpm integrity redeploy`` restores modified files from their sources.
+
+WHY: Agents and skills are instructions the model follows with the user's
+permissions. A deployed file changed out-of-band, by another tool, a
+compromised dependency or a malicious commit to the working tree, silently
+changes what the agents do.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    integrity:
+      verify_on_startup: true
+      auto_redeploy: false     # restore modified files without asking
+
+DESIGN DECISIONS:
+- The manifest lives in the .claude-mpm directory of the project that owns
+  the deployment (~/.claude-mpm for user-level deployments), and only
+  deployments under a .claude or .claude-mpm directory are recorded
+- Recording never fails a deployment; a file that could not be recorded is
+  simply not verified
+- The manifest is not signed: it detects out-of-band edits, not an attacker
+  who can also rewrite the manifest
+- Missing files are reported but not alerted on at startup, since the
+  deployment cleanups remove agents and skills that are no longer configured
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import shutil
+from dataclasses import dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import state_lock, write_atomic
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "integrity"
+MANIFEST_NAME = "deployment-integrity.json"
+
+AGENT = "agent"
+SKILL = "skill"
+
+MODIFIED = "modified"
+MISSING = "missing"
+SOURCE_MODIFIED = "source_modified"
+SOURCE_MISSING = "source_missing"
+OUTDATED = "outdated"
+# Issues that suggest tampering rather than normal churn
+ALERTS = (MODIFIED, SOURCE_MODIFIED)
+
+
+@dataclass
+class IntegrityConfig:
+    verify_on_startup: bool = True
+    auto_redeploy: bool = False
+
+    @classmethod
+    def load(cls, config: Any = None) -> IntegrityConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return cls(
+            verify_on_startup=bool(section.get("verify_on_startup", True)),
+            auto_redeploy=bool(section.get("auto_redeploy", False)),
+        )
+
+
+@dataclass
+class IntegrityEntry:
+    """One deployed file and where it came from."""
+
+    path: str  # relative to the project root
+    kind: str
+    sha256: str
+    source: str
+    source_sha256: str
+    commit: str | None
+    deployed_at: str
+
+    def to_dict(self) -> dict[str, Any]:
+        return dict(self.__dict__)
+
+
+@dataclass
+class IntegrityIssue:
+    path: str
+    kind: str
+    issue: str
+    source: str
+    detail: str = ""
+
+    def to_dict(self) -> dict[str, Any]:
+        return dict(self.__dict__)
+
+
+def sha256_file(path: Path) -> str:
+    return hashlib.sha256(Path(path).read_bytes()).hexdigest()
+
+
+def git_commit(path: Path) -> str | None:
+    """HEAD commit of the git repository containing ``path``, if any.
+
+    Reads .git directly rather than running git, since this runs once per
+    deployed file.
+    """
+    for candidate in Path(path).resolve().parents:
+        git_dir = candidate / ".git"
+        if not git_dir.is_dir():
+            continue
+        try:
+            head = (git_dir / "HEAD").read_text(encoding="utf-8").strip()
+            if not head.startswith("ref: "):
+                return head or None
+            ref = head[len("ref: ") :]
+            ref_file = git_dir / ref
+            if ref_file.is_file():
+                return ref_file.read_text(encoding="utf-8").strip() or None
+            packed = git_dir / "packed-refs"
+            if packed.is_file():
+                for line in packed.read_text(encoding="utf-8").splitlines():
+                    sha, _, name = line.partition(" ")
+                    if name == ref:
+                        return sha
+        except OSError as e:
+            logger.debug(f"Could not read git HEAD in {candidate}: {e}")
+        return None
+    return None
+
+
+def project_root_for(deployed: Path) -> Path | None:
+    """Project that owns a deployed file: the parent of its .claude dir."""
+    for parent in Path(deployed).absolute().parents:
+        if parent.name in (".claude", ".claude-mpm"):
+            return parent.parent
+    return None
+
+
+class IntegrityManifest:
+    """Recorded deployments of one project, keyed by relative path."""
+
+    def __init__(self, project_root: Path):
+        self.project_root = Path(project_root)
+        self.path = self.project_root / ".claude-mpm" / MANIFEST_NAME
+
+    def entries(self) -> dict[str, IntegrityEntry]:
+        if not self.path.is_file():
+            return {}
+        try:
+            data = json.loads(self.path.read_text(encoding="utf-8"))
+            return {
+                entry["path"]: IntegrityEntry(**entry)
+                for entry in data.get("files", [])
+            }
+        except (OSError, json.JSONDecodeError, KeyError, TypeError) as e:
+            logger.warning(f"Ignoring unreadable manifest {self.path}: {e}")
+            return {}
+
+    def save(self, entries: dict[str, IntegrityEntry]) -> None:
+        files = [entries[key].to_dict() for key in sorted(entries)]
+        write_atomic(
+            self.path, json.dumps({"version": 1, "files": files}, indent=2) + "\n"
+        )
+
+    def record(self, pairs: list[tuple[Path, Path]], kind: str) -> None:
+        """Record (deployed, source) file pairs as they are right now."""
+        now = datetime.now(UTC).isoformat()
+        # Parallel deployments record into the same manifest
+        with state_lock(self.path):
+            entries = self.entries()
+            for deployed, source in pairs:
+                key = (
+                    Path(deployed).absolute().relative_to(self.project_root).as_posix()
+                )
+                entries[key] = IntegrityEntry(
+                    path=key,
+                    kind=kind,
+                    sha256=sha256_file(deployed),
+                    source=str(Path(source).absolute()),
+                    source_sha256=sha256_file(source),
+                    commit=git_commit(source),
+                    deployed_at=now,
+                )
+            self.save(entries)
+
+    def verify(self) -> list[IntegrityIssue]:
+        """Check every recorded file and its source against the manifest."""
+        issues = []
+        for entry in self.entries().values():
+            issues += [
+                IntegrityIssue(entry.path, entry.kind, kind, entry.source, detail)
+                for kind, detail in self._check(entry)
+            ]
+        return issues
+
+    def _check(self, entry: IntegrityEntry) -> list[tuple[str, str]]:
+        deployed = self.project_root / entry.path
+        source = Path(entry.source)
+        if not deployed.is_file():
+            return [(MISSING, "")]
+        found = []
+        if sha256_file(deployed) != entry.sha256:
+            found.append((MODIFIED, "changed since it was deployed"))
+        if not source.is_file():
+            found.append((SOURCE_MISSING, ""))
+        elif sha256_file(source) != entry.source_sha256:
+            commit = git_commit(source)
+            if commit and commit != entry.commit:
+                found.append((OUTDATED, f"source moved to {commit[:12]}"))
+            else:
+                found.append(
+                    (SOURCE_MODIFIED, "cached source changed without a new commit")
+                )
+        return found
+
+    def forget(self, paths: list[str]) -> None:
+        with state_lock(self.path):
+            entries = self.entries()
+            for key in paths:
+                entries.pop(key, None)
+            self.save(entries)
+
+    def redeploy(self, issues: list[IntegrityIssue]) -> list[str]:
+        """Restore modified files from their sources; returns restored paths.
+
+        Files whose source is itself modified or missing are left alone, since
+        re-deploying would copy the tampered content.
+        """
+        bad_sources = {
+            i.path for i in issues if i.issue in (SOURCE_MODIFIED, SOURCE_MISSING)
+        }
+        restored = []
+        for item in issues:
+            if item.issue != MODIFIED or item.path in bad_sources:
+                continue
+            deployed = self.project_root / item.path
+            source = Path(item.source)
+            if item.kind == AGENT:
+                from .agents.deployment_utils import deploy_agent_file
+
+                result = deploy_agent_file(source, deployed.parent, force=True)
+                if not result.success:
+                    logger.warning(f"Could not redeploy {item.path}: {result.error}")
+                    continue
+                # deploy_agent_file recorded the new content
+            else:
+                shutil.copy2(source, deployed)
+                self.record([(deployed, source)], item.kind)
+            restored.append(item.path)
+        return restored
+
+
+def record_deployment(deployed: Path, source: Path, kind: str = AGENT) -> None:
+    """Record one deployed file; never raises."""
+    try:
+        root = project_root_for(deployed)
+        if root is not None:
+            IntegrityManifest(root).record([(deployed, source)], kind)
+    except Exception as e:
+        logger.debug(f"Could not record integrity of {deployed}: {e}")
+
+
+def record_directory(
+    deployed_dir: Path,
+    source_dir: Path,
+    kind: str = SKILL,
+    exclude: list[str] | None = None,
+) -> None:
+    """Record every file of a deployed directory (a skill); never raises.
+
+    *exclude* lists relative paths that were not deployed from the source
+    (local edits kept by a merge), which keep their previous record.
+    """
+    try:
+        root = project_root_for(deployed_dir / "x")
+        if root is None:
+            return
+        skip = set(exclude or ())
+        pairs = [
+            (deployed_dir / path.relative_to(source_dir), path)
+            for path in sorted(Path(source_dir).rglob("*"))
+            if path.is_file()
+            and path.relative_to(source_dir).as_posix() not in skip
+            and (deployed_dir / path.relative_to(source_dir)).is_file()
+        ]
+        IntegrityManifest(root).record(pairs, kind)
+    except Exception as e:
+        logger.debug(f"Could not record integrity of {deployed_dir}: {e}")
+
+
+def verify_on_startup(project_dir: Path | None = None) -> list[IntegrityIssue]:
+    """Verify the project and user deployments and print an alert.
+
+    Runs before the startup sync, which would otherwise quietly overwrite
+    modified agents. Returns the alerting issues.
+    """
+    config = IntegrityConfig.load()
+    if not config.verify_on_startup:
+        return []
+    alerts: list[IntegrityIssue] = []
+    roots = dict.fromkeys([Path(project_dir or Path.cwd()).resolve(), Path.home()])
+    for root in roots:
+        manifest = IntegrityManifest(root)
+        if not manifest.path.is_file():
+            continue
+        issues = manifest.verify()
+        # Cleanups delete unconfigured agents and skills; stop tracking them
+        missing = [i.path for i in issues if i.issue == MISSING]
+        if missing:
+            manifest.forget(missing)
+        found = [i for i in issues if i.issue in ALERTS]
+        if found and config.auto_redeploy:
+            restored = manifest.redeploy(issues)
+            found = [i for i in found if i.path not in restored]
+        alerts += found
+    if alerts:
+        import sys
+
+        print(
+            f"⚠️  {len(alerts)} deployed agent/skill file(s) changed outside "
+            "claude-mpm (possible tampering):",
+            file=sys.stderr,
+        )
+        for item in alerts[:10]:
+            print(f"   {item.issue:<16} {item.path}", file=sys.stderr)
+        if len(alerts) > 10:
+            print(f"   ... and {len(alerts) - 10} more", file=sys.stderr)
+        print(
+            "   Review with 'claude-mpm integrity verify'; restore with "
+            "'claude-mpm integrity redeploy'",
+            file=sys.stderr,
+        )
+    return alerts
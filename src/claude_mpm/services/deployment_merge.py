@@ -0,0 +1,318 @@
+"""Three-way merge of local edits with upstream agent and skill updates.
+
+WHAT: Every agent or skill file claude-mpm deploys into a project keeps a
+copy of what it deployed (the merge base) under
+``.claude-mpm/deployment-base/``. When an update arrives, ``apply_update``
+compares the base with the deployed file and the new upstream content:
+
+- only upstream changed: the update is written
+- only the deployed file changed: the local edits are kept
+- both changed: the two sides are merged line by line; a clean merge is
+  written, overlapping edits are recorded as a conflict in
+  ``.claude-mpm/merge-conflicts.json`` and the deployed file is left as is
+
+Conflicts are resolved interactively after startup, or with
+``claude-mpm integrity resolve``.
+
+WHY: Syncs overwrote deployed files with the upstream version, silently
+throwing away local edits to agents and skills. Keeping the deployed copy
+instead would silently drop upstream fixes.
+
+DESIGN DECISIONS:
+- The base is the rendered content (for agents: with deployment's
+  frontmatter and SLD block), so deployment's own edits never look like
+  local changes
+- Files deployed before bases were kept have no base. The integrity
+  manifest's hash of what was deployed still tells which side changed; only
+  when both did is the whole file treated as one conflict
+- A file with a pending conflict is not re-recorded in the integrity
+  manifest, so it keeps showing as modified until it is resolved
+- Files outside a .claude or .claude-mpm directory, binary files and forced
+  deployments are overwritten as before
+"""
+
+from __future__ import annotations
+
+import difflib
+import hashlib
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json
+from .deployment_delta import write_if_changed
+from .deployment_integrity import (
+    AGENT,
+    IntegrityManifest,
+    project_root_for,
+    record_deployment,
+)
+
+logger = get_logger(__name__)
+
+BASE_DIR = Path(".claude-mpm") / "deployment-base"
+CONFLICTS_FILE = Path(".claude-mpm") / "merge-conflicts.json"
+
+# apply_update outcomes
+WRITTEN = "written"  # deployed file replaced with the upstream content
+UNCHANGED = "unchanged"  # deployed file already matches upstream
+KEPT = "kept"  # only the deployed file changed; local edits kept
+MERGED = "merged"  # both changed; merged cleanly and written
+CONFLICT = "conflict"  # both changed in the same place; nothing written
+
+LOCAL_LABEL = "deployed"
+UPSTREAM_LABEL = "upstream"
+_MARKERS = ("<<<<<<< ", "=======", ">>>>>>> ")
+
+
+@dataclass
+class MergeResult:
+    text: str
+    conflicts: int = 0
+
+    @property
+    def clean(self) -> bool:
+        return self.conflicts == 0
+
+
+def _matches(base: list[str], other: list[str]) -> dict[int, int]:
+    """Base line index -> index of the same line in *other*."""
+    matcher = difflib.SequenceMatcher(None, base, other, autojunk=False)
+    return {
+        a + offset: b + offset
+        for a, b, size in matcher.get_matching_blocks()
+        for offset in range(size)
+    }
+
+
+def _terminated(lines: list[str]) -> list[str]:
+    if lines and not lines[-1].endswith("\n"):
+        return [*lines[:-1], lines[-1] + "\n"]
+    return lines
+
+
+def merge3(base: str, local: str, upstream: str) -> MergeResult:
+    """Merge the changes *local* and *upstream* made to *base*.
+
+    Lines unchanged on both sides anchor the merge. Between anchors, a side
+    that did not change takes the other side's version; if both changed
+    differently the region is emitted between conflict markers, deployed
+    side first.
+    """
+    b, lo, up = (s.splitlines(keepends=True) for s in (base, local, upstream))
+    in_local, in_upstream = _matches(b, lo), _matches(b, up)
+    out: list[str] = []
+    conflicts = 0
+    i = j = k = 0
+    while True:
+        n = i
+        while n < len(b) and not (n in in_local and n in in_upstream):
+            n += 1
+        local_end = in_local[n] if n < len(b) else len(lo)
+        upstream_end = in_upstream[n] if n < len(b) else len(up)
+        base_chunk, ours, theirs = b[i:n], lo[j:local_end], up[k:upstream_end]
+        if ours == theirs or theirs == base_chunk:
+            out += ours
+        elif ours == base_chunk:
+            out += theirs
+        else:
+            conflicts += 1
+            out += [f"<<<<<<< {LOCAL_LABEL}\n", *_terminated(ours), "=======\n"]
+            out += [*_terminated(theirs), f">>>>>>> {UPSTREAM_LABEL}\n"]
+        if n == len(b):
+            break
+        out.append(b[n])
+        i, j, k = n + 1, local_end + 1, upstream_end + 1
+    return MergeResult("".join(out), conflicts)
+
+
+def has_conflict_markers(text: str) -> bool:
+    return any(line.startswith(_MARKERS) for line in text.splitlines())
+
+
+def _key(root: Path, target: Path) -> str:
+    return Path(target).absolute().relative_to(root).as_posix()
+
+
+def _read_text(path: Path) -> str | None:
+    try:
+        return path.read_text(encoding="utf-8")
+    except UnicodeDecodeError:
+        return None
+
+
+def load_base(target: Path) -> str | None:
+    """The content last deployed to *target*, if it was kept."""
+    root = project_root_for(target)
+    if root is None:
+        return None
+    base = root / BASE_DIR / _key(root, target)
+    return _read_text(base) if base.is_file() else None
+
+
+def save_base(target: Path, content: str) -> None:
+    """Keep *content* as the merge base of *target*; never raises."""
+    try:
+        root = project_root_for(target)
+        if root is not None:
+            write_if_changed(root / BASE_DIR / _key(root, target), content)
+    except Exception as e:
+        logger.debug(f"Could not save merge base of {target}: {e}")
+
+
+def _recorded_base(root: Path, key: str, local: str, upstream: str) -> str:
+    """Stand-in base for files deployed before bases were kept."""
+    entry = IntegrityManifest(root).entries().get(key)
+    if entry is None:
+        return local  # nothing known: take upstream, as before
+    for side in (local, upstream):
+        if hashlib.sha256(side.encode("utf-8")).hexdigest() == entry.sha256:
+            return side
+    return ""  # both changed since deployment: one whole-file conflict
+
+
+@dataclass
+class MergeConflict:
+    """A deployed file whose local edits overlap an upstream update."""
+
+    project_root: Path
+    path: str  # relative to project_root
+    kind: str
+    source: str | None
+    base: str
+    upstream: str
+    detected_at: str
+
+    @property
+    def target(self) -> Path:
+        return self.project_root / self.path
+
+    def local(self) -> str:
+        return self.target.read_text(encoding="utf-8")
+
+    def merged(self) -> MergeResult:
+        return merge3(self.base, self.local(), self.upstream)
+
+    def to_dict(self) -> dict[str, Any]:
+        data = asdict(self)
+        del data["project_root"]
+        return data
+
+
+def _conflicts_path(root: Path) -> Path:
+    return Path(root) / CONFLICTS_FILE
+
+
+def _record_conflict(
+    root: Path, key: str, kind: str, source: Path | None, base: str, upstream: str
+) -> None:
+    conflict = MergeConflict(
+        project_root=root,
+        path=key,
+        kind=kind,
+        source=str(Path(source).absolute()) if source else None,
+        base=base,
+        upstream=upstream,
+        detected_at=datetime.now(UTC).isoformat(),
+    )
+
+    def add(records: dict[str, Any]) -> None:
+        records[key] = conflict.to_dict()
+
+    # Parallel deployments record into the same file
+    update_json(_conflicts_path(root), add)
+
+
+def _forget_conflict(root: Path, key: str) -> None:
+    path = _conflicts_path(root)
+    if not path.is_file():
+        return
+
+    def drop(records: dict[str, Any]) -> None:
+        records.pop(key, None)
+
+    update_json(path, drop)
+
+
+def pending_conflicts(project_root: Path) -> list[MergeConflict]:
+    """Unresolved conflicts of one project whose files still exist."""
+    root = Path(project_root)
+    records = read_json(_conflicts_path(root), {})
+    conflicts = []
+    for key in sorted(records if isinstance(records, dict) else {}):
+        try:
+            conflict = MergeConflict(project_root=root, **records[key])
+        except TypeError as e:
+            logger.warning(f"Ignoring unreadable merge conflict {key}: {e}")
+            continue
+        if conflict.target.is_file():
+            conflicts.append(conflict)
+    return conflicts
+
+
+def resolve_conflict(conflict: MergeConflict, content: str) -> None:
+    """Deploy *content* as the resolution of *conflict*."""
+    write_if_changed(conflict.target, content)
+    save_base(conflict.target, conflict.upstream)
+    _forget_conflict(conflict.project_root, conflict.path)
+    if conflict.source and Path(conflict.source).is_file():
+        record_deployment(conflict.target, Path(conflict.source), conflict.kind)
+
+
+def apply_update(
+    target: Path,
+    upstream: str,
+    *,
+    source: Path | None = None,
+    kind: str = AGENT,
+    force: bool = False,
+) -> str:
+    """Deploy *upstream* to *target* without losing local edits.
+
+    With *force* the upstream content replaces any local edits.
+
+    Returns one of WRITTEN, UNCHANGED, KEPT, MERGED or CONFLICT.
+    """
+    target = Path(target)
+    root = project_root_for(target)
+    local = None
+    if target.is_file() and not target.is_symlink():
+        local = _read_text(target)
+    if force and root is not None:
+        written = write_if_changed(target, upstream)
+        save_base(target, upstream)
+        _forget_conflict(root, _key(root, target))
+        return WRITTEN if written else UNCHANGED
+    if root is None or local is None:
+        written = write_if_changed(target, upstream)
+        save_base(target, upstream)
+        return WRITTEN if written else UNCHANGED
+
+    key = _key(root, target)
+    if local == upstream:
+        status = UNCHANGED
+    else:
+        base = load_base(target)
+        if base is None:
+            base = _recorded_base(root, key, local, upstream)
+        if base == upstream:
+            return KEPT
+        if base == local:
+            write_if_changed(target, upstream)
+            status = WRITTEN
+        else:
+            result = merge3(base, local, upstream)
+            if not result.clean:
+                logger.warning(
+                    f"Local edits to {target} conflict with the update from "
+                    f"{source or 'upstream'}; kept the deployed file"
+                )
+                _record_conflict(root, key, kind, source, base, upstream)
+                return CONFLICT
+            write_if_changed(target, result.text)
+            status = MERGED
+    save_base(target, upstream)
+    _forget_conflict(root, key)
+    return status
@@ -0,0 +1,334 @@
+"""Declarative development environment applied at session start.
+
+WHAT: Projects declare the tools (and versions) their agents need in
+``.claude-mpm/configuration.yaml``::
+
+    dev_environment:
+      tools:
+        node: "20"          # any 20.x
+        go: "1.22"
+        pnpm: latest        # any version, as long as it is installed
+      manager: auto         # mise | asdf | none; auto picks what is installed
+      provision: true       # install missing versions at session start
+      strict: false         # refuse to start while a tool is missing
+      env:                  # extra variables for the session
+        NODE_ENV: development
+
+When ``claude-mpm run`` starts, the tools are installed through mise or asdf
+if needed, the manager's environment for those versions (PATH and friends)
+is exported to Claude Code, and each tool's version is checked. ``claude-mpm
+doctor`` runs the same check without installing anything.
+
+WHY: Agents inherit whatever PATH the user's shell happened to have, so a
+build that works in the user's terminal (where a shell hook selected node 20)
+fails in the agent's (which got the system node). Declaring the environment
+once makes every session, and every teammate's session, see the same tools.
+
+DESIGN DECISIONS:
+- A version matches when it starts with the declared components: "20" takes
+  20.11.1, "1.22" takes go1.22.3; "latest" or "*" takes any version
+- The manager's environment replaces PATH; ``env`` entries only fill in
+  variables the user has not set themselves
+- Workspaces that are not trusted are only checked: a repository cannot get
+  tools installed or variables exported just by being opened
+- Without mise or asdf the tools are looked up on the current PATH, so the
+  spec still reports what is missing
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import re
+import shutil
+import subprocess  # nosec B404 - runs the version manager and tool probes
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "dev_environment"
+MANAGERS = ("mise", "asdf")
+ANY_VERSION = frozenset({"", "*", "latest"})
+
+# How to ask a tool for its version, where "<tool> --version" does not work
+VERSION_COMMANDS: dict[str, list[str]] = {
+    "go": ["go", "version"],
+    "golang": ["go", "version"],
+    "nodejs": ["node", "--version"],
+    "java": ["java", "-version"],
+    "rust": ["rustc", "--version"],
+}
+
+_VERSION_RE = re.compile(r"\d+(?:\.\d+)*")
+
+
+@dataclass
+class EnvironmentSpec:
+    """The tools and variables a project declares for its sessions."""
+
+    tools: dict[str, str]
+    manager: str = "auto"
+    provision: bool = True
+    strict: bool = False
+    env: dict[str, str] = field(default_factory=dict)
+
+    @classmethod
+    def from_dict(cls, data: Any) -> EnvironmentSpec | None:
+        """Build the spec from the config section; None if nothing is declared."""
+        if not isinstance(data, dict):
+            return None
+        tools = data.get("tools") or {}
+        if not isinstance(tools, dict):
+            raise ValueError(f"{CONFIG_KEY}.tools must map tool names to versions")
+        manager = str(data.get("manager", "auto"))
+        if manager not in ("auto", "none", *MANAGERS):
+            raise ValueError(
+                f"{CONFIG_KEY}.manager must be auto, none, mise or asdf "
+                f"(got {manager!r})"
+            )
+        env = data.get("env") or {}
+        if not isinstance(env, dict):
+            raise ValueError(f"{CONFIG_KEY}.env must map names to values")
+        if not tools and not env:
+            return None
+        return cls(
+            tools={str(name): str(version) for name, version in tools.items()},
+            manager=manager,
+            provision=bool(data.get("provision", True)),
+            strict=bool(data.get("strict", False)),
+            env={str(name): str(value) for name, value in env.items()},
+        )
+
+    @classmethod
+    def from_config(cls, config: Any = None) -> EnvironmentSpec | None:
+        if config is None:
+            from ..core.config import Config
+
+            config = Config()
+        return cls.from_dict(config.get(CONFIG_KEY))
+
+
+@dataclass
+class ToolStatus:
+    """One declared tool and what was found for it."""
+
+    name: str
+    required: str
+    version: str | None = None
+    path: str | None = None
+
+    @property
+    def ok(self) -> bool:
+        return self.version is not None and version_matches(self.required, self.version)
+
+    def describe(self) -> str:
+        if self.version is None:
+            return f"{self.name} {self.required}: not found"
+        if not self.ok:
+            return f"{self.name} {self.required}: found {self.version}"
+        return f"{self.name} {self.version}"
+
+
+@dataclass
+class EnvironmentReport:
+    """The outcome of applying (or checking) an environment spec."""
+
+    manager: str | None
+    tools: list[ToolStatus]
+    env: dict[str, str] = field(default_factory=dict)
+    provisioned: list[str] = field(default_factory=list)
+    errors: list[str] = field(default_factory=list)
+
+    @property
+    def failed(self) -> list[ToolStatus]:
+        return [tool for tool in self.tools if not tool.ok]
+
+    @property
+    def ok(self) -> bool:
+        return not self.failed and not self.errors
+
+    def summary(self) -> str:
+        if self.ok:
+            return ", ".join(tool.describe() for tool in self.tools)
+        problems = [tool.describe() for tool in self.failed] + self.errors
+        return "; ".join(problems)
+
+
+def version_matches(required: str, found: str) -> bool:
+    if required.strip().lower() in ANY_VERSION:
+        return True
+    wanted = required.strip().lstrip("v").split(".")
+    return found.split(".")[: len(wanted)] == wanted
+
+
+def _run(args: list[str], env: dict[str, str], cwd: Path, timeout: int) -> str:
+    """Run a command and return stdout; failures raise RuntimeError."""
+    try:
+        result = subprocess.run(  # nosec B603 - args are built from the spec
+            args,
+            cwd=cwd,
+            env=env,
+            capture_output=True,
+            text=True,
+            check=True,
+            timeout=timeout,
+        )
+    except subprocess.CalledProcessError as e:
+        detail = (e.stderr or e.stdout or "").strip().splitlines()
+        raise RuntimeError(
+            f"{' '.join(args[:2])} failed: {detail[-1] if detail else e}"
+        ) from e
+    except subprocess.TimeoutExpired as e:
+        raise RuntimeError(f"{' '.join(args[:2])} timed out after {timeout}s") from e
+    except OSError as e:
+        raise RuntimeError(f"{args[0]}: {e}") from e
+    return result.stdout
+
+
+def probe_tool(name: str, required: str, env: dict[str, str], cwd: Path) -> ToolStatus:
+    """Find *name* on env's PATH and read its version."""
+    command = VERSION_COMMANDS.get(name, [name, "--version"])
+    status = ToolStatus(name, required)
+    executable = shutil.which(command[0], path=env.get("PATH"))
+    if executable is None:
+        return status
+    status.path = executable
+    try:
+        result = subprocess.run(  # nosec B603 - executable found on PATH
+            [executable, *command[1:]],
+            cwd=cwd,
+            env=env,
+            capture_output=True,
+            text=True,
+            timeout=15,
+        )
+    except (OSError, subprocess.TimeoutExpired) as e:
+        logger.debug(f"Could not run {executable}: {e}")
+        return status
+    match = _VERSION_RE.search(result.stdout or result.stderr or "")
+    status.version = match.group(0) if match else None
+    return status
+
+
+def detect_manager(spec: EnvironmentSpec) -> str | None:
+    if spec.manager == "none":
+        return None
+    candidates = MANAGERS if spec.manager == "auto" else (spec.manager,)
+    for manager in candidates:
+        if shutil.which(manager):
+            return manager
+    return None
+
+
+def _tool_specs(spec: EnvironmentSpec) -> list[tuple[str, str]]:
+    """(tool, version) pairs as the managers take them; "latest" for any."""
+    return [
+        (name, "latest" if version.strip().lower() in ANY_VERSION else version)
+        for name, version in spec.tools.items()
+    ]
+
+
+def _mise_install(spec: EnvironmentSpec, env: dict[str, str], cwd: Path) -> None:
+    pins = [f"{name}@{version}" for name, version in _tool_specs(spec)]
+    _run(["mise", "install", *pins], env, cwd, timeout=900)
+
+
+def _mise_env(spec: EnvironmentSpec, env: dict[str, str], cwd: Path) -> dict[str, str]:
+    pins = [f"{name}@{version}" for name, version in _tool_specs(spec)]
+    data = json.loads(_run(["mise", "env", "--json", *pins], env, cwd, timeout=60))
+    return {str(key): str(value) for key, value in data.items()}
+
+
+def _asdf_install(spec: EnvironmentSpec, env: dict[str, str], cwd: Path) -> None:
+    installed_plugins = _run(["asdf", "plugin", "list"], env, cwd, 60).split()
+    for name, version in _tool_specs(spec):
+        if name not in installed_plugins:
+            _run(["asdf", "plugin", "add", name], env, cwd, timeout=300)
+        target = version if version == "latest" else f"latest:{version}"
+        _run(["asdf", "install", name, target], env, cwd, timeout=900)
+
+
+def _asdf_env(spec: EnvironmentSpec, env: dict[str, str], cwd: Path) -> dict[str, str]:
+    """PATH with the bin directory of each tool's newest matching install."""
+    bins = []
+    for name, version in _tool_specs(spec):
+        filter_args = [] if version == "latest" else [version]
+        try:
+            listed = _run(["asdf", "list", name, *filter_args], env, cwd, 60)
+        except RuntimeError:
+            continue
+        installed = [line.strip().lstrip("*").strip() for line in listed.splitlines()]
+        installed = [v for v in installed if v and version_matches(version, v)]
+        if not installed:
+            continue
+        where = _run(["asdf", "where", name, installed[-1]], env, cwd, 60).strip()
+        bins.append(str(Path(where) / "bin"))
+    if not bins:
+        return {}
+    return {"PATH": os.pathsep.join([*bins, env.get("PATH", "")])}
+
+
+_INSTALLERS = {"mise": _mise_install, "asdf": _asdf_install}
+_ENVIRONMENTS = {"mise": _mise_env, "asdf": _asdf_env}
+
+
+def resolve_environment(
+    spec: EnvironmentSpec,
+    project_root: Path | None = None,
+    provision: bool = True,
+    base_env: dict[str, str] | None = None,
+) -> EnvironmentReport:
+    """Install (if *provision*), resolve and check the spec's tools.
+
+    Nothing is exported here; ``report.env`` holds the variables to apply.
+    """
+    cwd = Path(project_root or Path.cwd())
+    base = dict(os.environ if base_env is None else base_env)
+    manager = detect_manager(spec)
+    report = EnvironmentReport(manager=manager, tools=[])
+
+    if spec.manager in MANAGERS and manager is None:
+        report.errors.append(f"{spec.manager} is not installed")
+
+    if manager and spec.tools:
+        env_for = _ENVIRONMENTS[manager]
+        try:
+            report.env = env_for(spec, base, cwd)
+        except (RuntimeError, ValueError) as e:
+            report.errors.append(str(e))
+        statuses = _probe_all(spec, {**base, **report.env}, cwd)
+        missing = [status.name for status in statuses if not status.ok]
+        if missing and provision and spec.provision:
+            try:
+                _INSTALLERS[manager](spec, base, cwd)
+                report.provisioned = missing
+                report.env = env_for(spec, base, cwd)
+                report.errors.clear()
+            except (RuntimeError, ValueError) as e:
+                report.errors.append(str(e))
+            statuses = _probe_all(spec, {**base, **report.env}, cwd)
+        report.tools = statuses
+    else:
+        report.tools = _probe_all(spec, base, cwd)
+
+    for name, value in spec.env.items():
+        if name not in base:
+            report.env.setdefault(name, value)
+    return report
+
+
+def _probe_all(
+    spec: EnvironmentSpec, env: dict[str, str], cwd: Path
+) -> list[ToolStatus]:
+    return [
+        probe_tool(name, version, env, cwd) for name, version in spec.tools.items()
+    ]
+
+
+def apply_environment(report: EnvironmentReport) -> None:
+    """Export a report's variables into this process (and so to Claude Code)."""
+    os.environ.update(report.env)
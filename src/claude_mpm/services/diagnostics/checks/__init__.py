@@ -11,6 +11,8 @@ from .base_check import BaseDiagnosticCheck
 from .claude_code_check import ClaudeCodeCheck
 from .common_issues_check import CommonIssuesCheck
 from .configuration_check import ConfigurationCheck
+from .container_images_check import ContainerImagesCheck
+from .dev_environment_check import DevEnvironmentCheck
 from .filesystem_check import FilesystemCheck
 from .installation_check import InstallationCheck
 from .instructions_check import InstructionsCheck
@@ -29,6 +31,8 @@ __all__ = [
     "ClaudeCodeCheck",
     "CommonIssuesCheck",
     "ConfigurationCheck",
+    "ContainerImagesCheck",
+    "DevEnvironmentCheck",
     "FilesystemCheck",
     "InstallationCheck",
     "InstructionsCheck",
@@ -0,0 +1,90 @@
+"""Diagnostic check for the project's Dockerfiles and container images.
+
+Reports unpinned base images, root final stages and secrets in ``ENV``,
+``ARG`` or copied files, plus vulnerable packages when
+``container_scan.images`` turns on image scanning with trivy.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+from ....core.enums import OperationResult, ValidationSeverity
+from ...container_scan import ContainerScanner, find_dockerfiles
+from ..models import DiagnosticResult
+from .base_check import BaseDiagnosticCheck
+
+
+class ContainerImagesCheck(BaseDiagnosticCheck):
+    """Check the project's Dockerfiles for vulnerable packages and bad practices."""
+
+    def __init__(self, verbose: bool = False, project_dir: Path | None = None):
+        super().__init__(verbose)
+        self.project_dir = project_dir or Path.cwd()
+
+    @property
+    def name(self) -> str:
+        return "container_images_check"
+
+    @property
+    def category(self) -> str:
+        return "Container Images"
+
+    def should_run(self) -> bool:
+        return bool(find_dockerfiles(self.project_dir))
+
+    def run(self) -> DiagnosticResult:
+        report = ContainerScanner(self.project_dir).scan()
+        details: dict = {"dockerfiles": report.dockerfiles}
+        if report.findings:
+            details["findings"] = {
+                severity: n
+                for severity in ("critical", "high", "medium", "low")
+                if (n := report.count(severity))
+            }
+        if report.errors:
+            details["errors"] = report.errors
+        if not report.dockerfiles:
+            return DiagnosticResult(
+                category=self.category,
+                status=OperationResult.SKIPPED,
+                message="No Dockerfiles found",
+            )
+
+        sub_results = [
+            DiagnosticResult(
+                category=f"{finding.rule} ({finding.location})",
+                status=(
+                    ValidationSeverity.ERROR
+                    if finding.severity in ("high", "critical")
+                    else ValidationSeverity.WARNING
+                ),
+                message=finding.message,
+                fix_description=finding.remediation or None,
+                severity=finding.severity,
+            )
+            for finding in report.findings
+        ]
+        message = report.summary()
+        if report.errors:
+            message += f"; could not scan images ({report.errors[0]})"
+
+        if report.count("high", "critical"):
+            status = ValidationSeverity.ERROR
+        elif report.findings or report.errors:
+            status = ValidationSeverity.WARNING
+        else:
+            status = OperationResult.SUCCESS
+        return DiagnosticResult(
+            category=self.category,
+            status=status,
+            message=message,
+            details=details,
+            fix_description=(
+                "Run 'claude-mpm doctor --checks containers --verbose' to see "
+                "each finding and its fix"
+                if report.findings and not self.verbose
+                else None
+            ),
+            sub_results=sub_results if self.verbose else [],
+        )
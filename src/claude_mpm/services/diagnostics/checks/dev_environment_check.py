@@ -0,0 +1,84 @@
+"""Diagnostic check for the project's declared dev environment.
+
+Reports whether the tools listed under ``dev_environment`` in the project's
+configuration resolve to the declared versions, through mise or asdf when
+one is installed. Nothing is installed; ``claude-mpm run`` does that.
+"""
+
+from __future__ import annotations
+
+from ....core.enums import OperationResult, ValidationSeverity
+from ...dev_environment import EnvironmentSpec, resolve_environment
+from ..models import DiagnosticResult
+from .base_check import BaseDiagnosticCheck
+
+
+class DevEnvironmentCheck(BaseDiagnosticCheck):
+    """Check the tools a project declares for its sessions."""
+
+    @property
+    def name(self) -> str:
+        return "dev_environment_check"
+
+    @property
+    def category(self) -> str:
+        return "Dev Environment"
+
+    def should_run(self) -> bool:
+        try:
+            return EnvironmentSpec.from_config() is not None
+        except ValueError:
+            return True
+
+    def run(self) -> DiagnosticResult:
+        try:
+            spec = EnvironmentSpec.from_config()
+        except ValueError as e:
+            return DiagnosticResult(
+                category=self.category,
+                status=ValidationSeverity.ERROR,
+                message=str(e),
+                fix_description="Fix dev_environment in .claude-mpm/configuration.yaml",
+            )
+        if spec is None:
+            return DiagnosticResult(
+                category=self.category,
+                status=OperationResult.SKIPPED,
+                message="No dev_environment declared",
+            )
+
+        report = resolve_environment(spec, provision=False)
+        details = {
+            "manager": report.manager or "none",
+            "tools": {
+                tool.name: {
+                    "required": tool.required,
+                    "found": tool.version,
+                    "path": tool.path,
+                }
+                for tool in report.tools
+            },
+        }
+        if report.ok:
+            return DiagnosticResult(
+                category=self.category,
+                status=OperationResult.SUCCESS,
+                message=report.summary(),
+                details=details,
+            )
+
+        fix_command = None
+        if report.manager == "mise":
+            pins = [f"{tool.name}@{tool.required}" for tool in report.failed]
+            fix_command = f"mise install {' '.join(pins)}"
+        return DiagnosticResult(
+            category=self.category,
+            status=ValidationSeverity.WARNING,
+            message=report.summary(),
+            details=details,
+            fix_command=fix_command,
+            fix_description=(
+                "Install the missing versions, or start a session with "
+                "dev_environment.provision enabled to install them"
+            ),
+        )
@@ -0,0 +1,239 @@
+"""
+Debug bundle generator for bug reports.
+
+WHY: Bug reports usually arrive as "it crashed" with no context, and the
+back-and-forth to collect logs, config and versions takes longer than the
+fix. ``claude-mpm debug bundle`` packs everything a maintainer needs into one
+zip that can be attached to an issue.
+
+DESIGN DECISIONS:
+- Redact before writing: config values under secret-looking keys are masked
+  structurally, and every text file is additionally passed through the
+  transcript secret scrubber so tokens in logs never leave the machine
+- Bounded: only the tail of the newest log files and the most recent events
+  are included so bundles stay small enough to attach
+- The failing command's traceback is captured by ``record_crash`` from the
+  CLI's top-level exception handler, so the bundle can include it even when
+  generated from a later, successful invocation
+- Every section is best-effort; a missing or unreadable source is noted in
+  the manifest instead of aborting the bundle
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import platform
+import re
+import sys
+import traceback
+import zipfile
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from ...core.logger import get_logger
+from .. import data_encryption
+from ..session_analysis.transcript_parser import _redact_secrets
+
+logger = get_logger(__name__)
+
+CRASH_FILE_NAME = "last_crash.json"
+REDACTED = "[REDACTED]"
+
+# Config keys whose values are always masked, regardless of content. Names
+# ending in _KEY cover data keys such as data_encryption.KEY_ENV_VAR.
+_SECRET_KEY_RE = re.compile(
+    r"(?i)(token|secret|password|passwd|api[_\-]?key|credential|private[_\-]?key"
+    r"|authorization|_key$)"
+)
+
+# Environment variables worth including (values still pass through redaction)
+_ENV_PREFIXES = ("CLAUDE_MPM_", "CLAUDE_CODE_", "MPM_")
+_ENV_NAMES = ("SHELL", "TERM", "LANG", "VIRTUAL_ENV", "CONDA_DEFAULT_ENV")
+
+CONFIG_FILE_NAMES = ("configuration.yaml", "config.yaml", "settings.json")
+
+
+def _crash_file(user_dir: Path | None = None) -> Path:
+    return (user_dir or Path.home() / ".claude-mpm") / "crashes" / CRASH_FILE_NAME
+
+
+def record_crash(
+    exc: BaseException, argv: list[str] | None = None, user_dir: Path | None = None
+) -> Path | None:
+    """Persist the traceback of a failed command for later bundling.
+
+    Never raises: crash recording must not mask the original error.
+    """
+    path = _crash_file(user_dir)
+    try:
+        path.parent.mkdir(parents=True, exist_ok=True)
+        record = {
+            "timestamp": datetime.now(UTC).isoformat(),
+            "argv": list(sys.argv if argv is None else argv),
+            "cwd": os.getcwd(),
+            "exception": f"{type(exc).__name__}: {exc}",
+            "traceback": "".join(
+                traceback.format_exception(type(exc), exc, exc.__traceback__)
+            ),
+        }
+        path.write_text(_redact_secrets(json.dumps(record, indent=2)))
+        return path
+    except Exception as e:
+        logger.debug(f"Could not record crash: {e}")
+        return None
+
+
+def redact_config(data: Any) -> Any:
+    """Mask values stored under secret-looking keys, recursively."""
+    if isinstance(data, dict):
+        return {
+            key: (
+                REDACTED
+                if _SECRET_KEY_RE.search(str(key))
+                and not isinstance(value, (dict, list))
+                else redact_config(value)
+            )
+            for key, value in data.items()
+        }
+    if isinstance(data, list):
+        return [redact_config(item) for item in data]
+    if isinstance(data, str):
+        return _redact_secrets(data)
+    return data
+
+
+def _tail(path: Path, max_lines: int) -> str:
+    with path.open("r", encoding="utf-8", errors="replace") as f:
+        lines = f.readlines()
+    return "".join(lines[-max_lines:])
+
+
+class DebugBundleBuilder:
+    """Collects diagnostics into a single redacted zip archive."""
+
+    def __init__(
+        self,
+        project_dir: Path | None = None,
+        user_dir: Path | None = None,
+        max_log_files: int = 5,
+        max_log_lines: int = 500,
+        max_events: int = 200,
+    ):
+        self.project_dir = project_dir or Path.cwd() / ".claude-mpm"
+        self.user_dir = user_dir or Path.home() / ".claude-mpm"
+        self.max_log_files = max_log_files
+        self.max_log_lines = max_log_lines
+        self.max_events = max_events
+
+    def build(self, output: Path | None = None) -> tuple[Path, dict[str, Any]]:
+        """Write the bundle and return its path plus the manifest."""
+        stamp = datetime.now(UTC).strftime("%Y%m%d-%H%M%S")
+        output = output or Path.cwd() / f"claude-mpm-debug-{stamp}.zip"
+        if output.is_dir():
+            output = output / f"claude-mpm-debug-{stamp}.zip"
+        output.parent.mkdir(parents=True, exist_ok=True)
+
+        manifest: dict[str, Any] = {
+            "created_at": datetime.now(UTC).isoformat(),
+            "files": [],
+            "skipped": [],
+        }
+        sections = (
+            ("environment", self._collect_environment),
+            ("config", self._collect_config),
+            ("logs", self._collect_logs),
+            ("events", self._collect_events),
+            ("crash", self._collect_crash),
+        )
+
+        with zipfile.ZipFile(output, "w", zipfile.ZIP_DEFLATED) as archive:
+            for section, collector in sections:
+                try:
+                    for name, content in collector():
+                        arcname = f"{section}/{name}"
+                        archive.writestr(arcname, _redact_secrets(content))
+                        manifest["files"].append(arcname)
+                except Exception as e:
+                    manifest["skipped"].append(f"{section}: {e}")
+            archive.writestr("manifest.json", json.dumps(manifest, indent=2))
+
+        return output, manifest
+
+    # ------------------------------------------------------------------
+    # Collectors: each yields (archive name, text content)
+    # ------------------------------------------------------------------
+
+    def _collect_environment(self):
+        from ... import __version__
+
+        env = {
+            key: value
+            for key, value in sorted(os.environ.items())
+            if key.startswith(_ENV_PREFIXES) or key in _ENV_NAMES
+        }
+        info = {
+            "claude_mpm_version": __version__,
+            "python": sys.version,
+            "executable": sys.executable,
+            "platform": platform.platform(),
+            "machine": platform.machine(),
+            "cwd": os.getcwd(),
+            "environment": redact_config(env),
+        }
+        yield "environment.json", json.dumps(info, indent=2)
+
+    def _collect_config(self):
+        for scope, base in (("project", self.project_dir), ("user", self.user_dir)):
+            for name in CONFIG_FILE_NAMES:
+                path = base / name
+                if not path.is_file():
+                    continue
+                text = path.read_text(encoding="utf-8", errors="replace")
+                try:
+                    if path.suffix == ".json":
+                        data = redact_config(json.loads(text))
+                        text = json.dumps(data, indent=2)
+                    else:
+                        data = redact_config(yaml.safe_load(text))
+                        text = yaml.safe_dump(data, sort_keys=False)
+                except (ValueError, yaml.YAMLError):
+                    pass  # Unparseable: fall back to text-level redaction
+                yield f"{scope}-{name}", text
+
+    def _collect_logs(self):
+        for scope, base in (("project", self.project_dir), ("user", self.user_dir)):
+            logs_dir = base / "logs"
+            if not logs_dir.is_dir():
+                continue
+            files = [p for p in logs_dir.rglob("*") if p.is_file()]
+            files.sort(key=lambda p: p.stat().st_mtime, reverse=True)
+            for path in files[: self.max_log_files]:
+                relative = path.relative_to(logs_dir).as_posix().replace("/", "_")
+                yield f"{scope}-{relative}", _tail(path, self.max_log_lines)
+
+    def _collect_events(self):
+        event_log = self.project_dir / "event_log.json"
+        if event_log.is_file():
+            text = data_encryption.reveal(event_log.read_text())
+            events = json.loads(text or "[]")
+            yield "event_log.json", json.dumps(events[-self.max_events :], indent=2)
+
+        spool_dir = self.user_dir / "event_spool"
+        if spool_dir.is_dir():
+            spooled = sorted(spool_dir.glob("*.json"))[-self.max_events :]
+            payloads = []
+            for path in spooled:
+                try:
+                    payloads.append(json.loads(path.read_text()))
+                except (OSError, ValueError):
+                    continue
+            yield "spooled_events.json", json.dumps(payloads, indent=2)
+
+    def _collect_crash(self):
+        path = _crash_file(self.user_dir)
+        if path.is_file():
+            yield CRASH_FILE_NAME, path.read_text()
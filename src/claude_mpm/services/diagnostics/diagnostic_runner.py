@@ -24,6 +24,8 @@ from .checks import (
     ClaudeCodeCheck,
     CommonIssuesCheck,
     ConfigurationCheck,
+    ContainerImagesCheck,
+    DevEnvironmentCheck,
     FilesystemCheck,
     InstallationCheck,
     InstructionsCheck,
@@ -72,6 +74,8 @@ class DiagnosticRunner:
             AgentCheck,
             AgentSourcesCheck,  # Check agent sources configuration
             SkillSourcesCheck,  # Check skill sources configuration
+            DevEnvironmentCheck,  # Declared tool versions (dev_environment)
+            ContainerImagesCheck,  # Dockerfiles and images (container_scan)
             MCPCheck,
             MCPServicesCheck,  # Check external MCP services
             MemoryCaptureCheck,  # Memory auto-capture backend (#536/#537)
@@ -144,6 +148,8 @@ class DiagnosticRunner:
             AgentCheck,
             AgentSourcesCheck,
             SkillSourcesCheck,
+            DevEnvironmentCheck,
+            ContainerImagesCheck,
             MCPCheck,
             MCPServicesCheck,
             MemoryCaptureCheck,
@@ -239,6 +245,12 @@ class DiagnosticRunner:
             "agent-sources": AgentSourcesCheck,
             "agent_sources": AgentSourcesCheck,
             "sources": AgentSourcesCheck,
+            "dev_environment": DevEnvironmentCheck,
+            "dev-environment": DevEnvironmentCheck,
+            "tools": DevEnvironmentCheck,
+            "containers": ContainerImagesCheck,
+            "container_images": ContainerImagesCheck,
+            "container-images": ContainerImagesCheck,
             "mcp": MCPCheck,
             "mcp_services": MCPServicesCheck,
             "mcp-services": MCPServicesCheck,
@@ -9,6 +9,7 @@ import json
 import sys
 
 from claude_mpm.core.enums import OperationResult, ValidationSeverity
+from claude_mpm.utils.theme import get_theme
 
 from .models import DiagnosticResult, DiagnosticSummary
 
@@ -37,25 +38,34 @@ class DoctorReporter:
         "info": "🔵",
     }
 
-    # ANSI color codes
-    COLORS = {
-        "reset": "\033[0m",
-        "bold": "\033[1m",
-        "red": "\033[91m",
-        "green": "\033[92m",
-        "yellow": "\033[93m",
-        "blue": "\033[94m",
-        "gray": "\033[90m",
+    # Reporter color names -> theme roles (see utils/theme.py)
+    COLOR_ROLES = {
+        "bold": "heading",
+        "red": "error",
+        "green": "success",
+        "yellow": "warning",
+        "blue": "info",
+        "gray": "muted",
+    }
+
+    # Status -> badge level for the OK/Warning/Error/Skipped label
+    BADGE_LEVELS = {
+        OperationResult.SUCCESS: "ok",
+        ValidationSeverity.WARNING: "warning",
+        ValidationSeverity.ERROR: "error",
+        OperationResult.SKIPPED: "skipped",
     }
 
     def __init__(self, use_color: bool = True, verbose: bool = False):
         """Initialize reporter.
 
         Args:
-            use_color: Whether to use ANSI color codes
+            use_color: Whether to use ANSI color codes (also off for
+                NO_COLOR, the no-color theme and non-terminal output)
             verbose: Whether to include detailed information
         """
-        self.use_color = use_color and sys.stdout.isatty()
+        self.theme = get_theme()
+        self.use_color = use_color and self.theme.color
         self.verbose = verbose
 
     def report(self, summary: DiagnosticSummary, format: str = "terminal"):
@@ -100,7 +110,6 @@ class DoctorReporter:
 
         # Status symbol and category
         symbol = self.STATUS_SYMBOLS.get(result.status, "?")
-        color = self._get_status_color(result.status)
 
         # Add severity indicator if present (issue #125)
         severity_prefix = ""
@@ -113,13 +122,13 @@ class DoctorReporter:
         line = f"{indent_str}{severity_prefix}{symbol} {result.category}: "
 
         if result.status == OperationResult.SUCCESS:
-            line += self._color("OK", color)
+            line += self._badge("OK", result.status)
         elif result.status == ValidationSeverity.WARNING:
-            line += self._color("Warning", color)
+            line += self._badge("Warning", result.status)
         elif result.status == ValidationSeverity.ERROR:
-            line += self._color("Error", color)
+            line += self._badge("Error", result.status)
         else:
-            line += self._color("Skipped", color)
+            line += self._badge("Skipped", result.status)
 
         print(line)
 
@@ -401,22 +410,17 @@ class DoctorReporter:
 
     def _color(self, text: str, color: str) -> str:
         """Apply color to text if colors are enabled."""
-        if not self.use_color:
+        if not self.use_color or color not in self.COLOR_ROLES:
             return text
 
-        color_code = self.COLORS.get(color, "")
-        reset_code = self.COLORS["reset"]
-        return f"{color_code}{text}{reset_code}"
-
-    def _get_status_color(self, status) -> str:
-        """Get color for a status."""
-        color_map = {
-            OperationResult.SUCCESS: "green",
-            ValidationSeverity.WARNING: "yellow",
-            ValidationSeverity.ERROR: "red",
-            OperationResult.SKIPPED: "gray",
-        }
-        return color_map.get(status, "reset")
+        return self.theme.ansi(self.COLOR_ROLES[color], text)
+
+    def _badge(self, label: str, status) -> str:
+        """Status label as a themed badge; the plain label without color."""
+        if not self.use_color or status not in self.BADGE_LEVELS:
+            return label
+
+        return self.theme.badge(label, self.BADGE_LEVELS[status])
 
     def _get_version(self) -> str:
         """Get claude-mpm version."""
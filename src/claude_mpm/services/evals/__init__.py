@@ -0,0 +1,43 @@
+"""
+Agent evaluation suites.
+
+WHY: Prompt changes silently change agent behaviour. Suites pin down what an
+agent must do for a set of prompts (output contents, files written, tests
+passing, rubric grades) and the scoring history flags regressions between
+agent versions. Golden transcripts do the same for the tool-call sequence of
+a recorded session.
+"""
+
+from .assertions import ASSERTION_CHECKS, AssertionResult, RubricGrader
+from .golden import (
+    GoldenDiff,
+    GoldenReplayer,
+    GoldenStore,
+    GoldenTranscript,
+    diff_turns,
+    record_golden,
+)
+from .history import EvalHistory, find_regressions
+from .runner import CaseResult, EvalRunner, SuiteResult
+from .suite import EvalCase, EvalSuite, EvalSuiteError, load_suite
+
+__all__ = [
+    "ASSERTION_CHECKS",
+    "AssertionResult",
+    "CaseResult",
+    "EvalCase",
+    "EvalHistory",
+    "EvalRunner",
+    "EvalSuite",
+    "EvalSuiteError",
+    "GoldenDiff",
+    "GoldenReplayer",
+    "GoldenStore",
+    "GoldenTranscript",
+    "RubricGrader",
+    "SuiteResult",
+    "diff_turns",
+    "find_regressions",
+    "load_suite",
+    "record_golden",
+]
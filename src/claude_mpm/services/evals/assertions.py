@@ -0,0 +1,188 @@
+"""
+Assertion checks for evaluation cases.
+
+Each check takes the assertion mapping from the suite and a
+:class:`CaseContext` and returns an :class:`AssertionResult` with a score in
+[0, 1]. Deterministic checks score 0 or 1; the rubric check scores whatever
+the grading model returns and passes at its ``threshold``.
+"""
+
+from __future__ import annotations
+
+import asyncio
+import json
+import re
+import subprocess  # nosec B404
+from collections.abc import Callable
+from dataclasses import asdict, dataclass
+from pathlib import Path
+from typing import Any
+
+from ..agents.agent_runtime import AgentConfig
+
+GRADER_SYSTEM_PROMPT = (
+    "You are a strict evaluator grading an AI agent's output against a rubric. "
+    'Reply with a single JSON object: {"score": <number 0-1>, "reason": "<one '
+    'sentence>"}. Do not include anything else.'
+)
+
+_JSON_OBJECT_RE = re.compile(r"\{.*\}", re.DOTALL)
+
+
+@dataclass
+class AssertionResult:
+    type: str
+    passed: bool
+    score: float
+    detail: str
+    weight: float = 1.0
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class CaseContext:
+    """What an assertion can inspect after a case ran."""
+
+    output: str
+    workspace: Path
+    grader: RubricGrader | None = None
+
+
+def _binary(assertion: dict, passed: bool, detail: str) -> AssertionResult:
+    return AssertionResult(
+        type=assertion["type"],
+        passed=passed,
+        score=1.0 if passed else 0.0,
+        detail=detail,
+        weight=float(assertion.get("weight", 1.0)),
+    )
+
+
+def check_contains(assertion: dict, ctx: CaseContext) -> AssertionResult:
+    value = str(assertion["value"])
+    output = ctx.output
+    if assertion.get("ignore_case"):
+        value, output = value.lower(), output.lower()
+    passed = value in output
+    return _binary(
+        assertion, passed, f"{'found' if passed else 'missing'}: {assertion['value']!r}"
+    )
+
+
+def check_regex(assertion: dict, ctx: CaseContext) -> AssertionResult:
+    flags = re.IGNORECASE if assertion.get("ignore_case") else 0
+    try:
+        match = re.search(assertion["pattern"], ctx.output, flags | re.MULTILINE)
+    except re.error as e:
+        return _binary(assertion, False, f"invalid pattern: {e}")
+    detail = f"matched {match.group(0)!r}" if match else "no match"
+    return _binary(assertion, match is not None, detail)
+
+
+def check_file_created(assertion: dict, ctx: CaseContext) -> AssertionResult:
+    path = (ctx.workspace / assertion["path"]).resolve()
+    if not path.is_relative_to(ctx.workspace.resolve()):
+        return _binary(assertion, False, "path escapes the workspace")
+    if not path.is_file():
+        return _binary(assertion, False, f"{assertion['path']} was not created")
+
+    expected = assertion.get("contains")
+    if expected and expected not in path.read_text(encoding="utf-8", errors="replace"):
+        return _binary(
+            assertion, False, f"{assertion['path']} does not contain {expected!r}"
+        )
+    return _binary(assertion, True, f"{assertion['path']} created")
+
+
+def check_tests_pass(assertion: dict, ctx: CaseContext) -> AssertionResult:
+    command = assertion["command"]
+    timeout = float(assertion.get("timeout", 300))
+    try:
+        result = subprocess.run(  # nosec B602 - command comes from the suite
+            command,
+            shell=True,
+            cwd=ctx.workspace,
+            capture_output=True,
+            text=True,
+            timeout=timeout,
+            check=False,
+        )
+    except subprocess.TimeoutExpired:
+        return _binary(assertion, False, f"'{command}' timed out after {timeout:g}s")
+
+    if result.returncode == 0:
+        return _binary(assertion, True, f"'{command}' passed")
+    tail = (result.stdout + result.stderr).strip().splitlines()[-3:]
+    return _binary(
+        assertion,
+        False,
+        f"'{command}' exited {result.returncode}: {' | '.join(tail)}",
+    )
+
+
+def check_rubric(assertion: dict, ctx: CaseContext) -> AssertionResult:
+    threshold = float(assertion.get("threshold", 0.7))
+    weight = float(assertion.get("weight", 1.0))
+    if ctx.grader is None:
+        return AssertionResult("rubric", False, 0.0, "no grader configured", weight)
+
+    score, reason = ctx.grader.grade(assertion["rubric"], ctx.output)
+    return AssertionResult(
+        type="rubric",
+        passed=score >= threshold,
+        score=score,
+        detail=f"{score:.2f} (threshold {threshold:.2f}): {reason}",
+        weight=weight,
+    )
+
+
+ASSERTION_CHECKS: dict[str, Callable[[dict, CaseContext], AssertionResult]] = {
+    "contains": check_contains,
+    "regex": check_regex,
+    "file-created": check_file_created,
+    "tests-pass": check_tests_pass,
+    "rubric": check_rubric,
+}
+
+
+def run_assertion(assertion: dict, ctx: CaseContext) -> AssertionResult:
+    """Run one assertion; a crashing check fails rather than aborting the case."""
+    check = ASSERTION_CHECKS[assertion["type"]]
+    try:
+        return check(assertion, ctx)
+    except Exception as e:
+        return _binary(assertion, False, f"{type(e).__name__}: {e}")
+
+
+class RubricGrader:
+    """Scores output against a rubric with a grading model."""
+
+    def __init__(self, runtime_factory: Callable[[AgentConfig], Any], model=None):
+        self.runtime_factory = runtime_factory
+        self.model = model
+
+    def grade(self, rubric: str, output: str) -> tuple[float, str]:
+        config = AgentConfig(
+            system_prompt=GRADER_SYSTEM_PROMPT, model=self.model, max_turns=1
+        )
+        prompt = f"Rubric:\n{rubric}\n\nAgent output:\n{output}"
+        runtime = self.runtime_factory(config)
+        result = asyncio.run(runtime.run(prompt, config))
+        if result.is_error:
+            return 0.0, f"grader error: {result.text[:200]}"
+        return parse_grade(result.text)
+
+
+def parse_grade(text: str) -> tuple[float, str]:
+    """Extract (score, reason) from a grader reply; unparseable replies score 0."""
+    match = _JSON_OBJECT_RE.search(text or "")
+    if match:
+        try:
+            data = json.loads(match.group(0))
+            score = min(max(float(data.get("score", 0)), 0.0), 1.0)
+            return score, str(data.get("reason", "")).strip()
+        except (ValueError, TypeError, AttributeError):
+            pass
+    return 0.0, f"unparseable grade: {(text or '').strip()[:120]}"
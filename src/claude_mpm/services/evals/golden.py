@@ -0,0 +1,375 @@
+"""
+Golden transcripts: behavioural regression tests built from real sessions.
+
+WHY: Eval suites check outcomes someone thought to write down. A golden
+transcript pins down *how* a known-good session went - which tools were
+called, in which order, on which files - so a framework or agent upgrade that
+quietly changes behaviour shows up as a diff instead of a surprise.
+
+DESIGN DECISIONS:
+- A golden is a recording (see ``services.model.recordings``) plus metadata:
+  the cwd it was recorded in and the claude-mpm version, stored as
+  ``.claude-mpm/golden/<name>.json``
+- Replays send the recorded user prompts through the configured AgentRuntime
+  in one session (run, then resume) inside a copy of the project, so a
+  behavioural diff never edits the real tree
+- Tool calls compare by name by default; ``strict`` also compares the key
+  argument (file path, command, pattern) made relative to each session's cwd
+- Replies compare by text similarity since wording drifts between runs even
+  when behaviour does not
+"""
+
+from __future__ import annotations
+
+import asyncio
+import difflib
+import json
+import re
+import shutil
+import tempfile
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ...core.logger import get_logger
+from ..agents.agent_runtime import AgentConfig, AgentResult
+from ..model.recordings import (
+    RecordedToolCall,
+    RecordedTurn,
+    load_recording,
+)
+
+logger = get_logger(__name__)
+
+GOLDEN_FORMAT_VERSION = 1
+REPLY_SIMILARITY_THRESHOLD = 0.5
+
+_NAME_RE = re.compile(r"^[A-Za-z0-9][A-Za-z0-9._-]*$")
+
+# The input field that identifies what a tool call acted on.
+_KEY_ARGUMENTS = {
+    "Read": "file_path",
+    "Write": "file_path",
+    "Edit": "file_path",
+    "MultiEdit": "file_path",
+    "NotebookEdit": "notebook_path",
+    "Bash": "command",
+    "Grep": "pattern",
+    "Glob": "pattern",
+    "Task": "subagent_type",
+    "Agent": "subagent_type",
+    "WebFetch": "url",
+}
+
+# Copying these into the replay workspace is slow and never needed.
+_WORKSPACE_IGNORE = shutil.ignore_patterns(
+    ".git", "node_modules", ".venv", "venv", "__pycache__", ".mypy_cache"
+)
+
+
+def default_golden_dir(project_dir: Path | None = None) -> Path:
+    return (project_dir or Path.cwd()) / ".claude-mpm" / "golden"
+
+
+def _framework_version() -> str:
+    try:
+        from ... import __version__
+
+        return __version__
+    except ImportError:
+        return "unknown"
+
+
+@dataclass
+class GoldenTranscript:
+    name: str
+    turns: list[RecordedTurn]
+    cwd: str
+    source: str = ""
+    framework_version: str = field(default_factory=_framework_version)
+    created_at: str = field(default_factory=lambda: datetime.now(UTC).isoformat())
+
+    @property
+    def tool_call_count(self) -> int:
+        return sum(len(turn.tool_calls) for turn in self.turns)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "version": GOLDEN_FORMAT_VERSION,
+            "name": self.name,
+            "cwd": self.cwd,
+            "source": self.source,
+            "framework_version": self.framework_version,
+            "created_at": self.created_at,
+            "turns": [asdict(turn) for turn in self.turns],
+        }
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> GoldenTranscript:
+        return cls(
+            name=data["name"],
+            turns=[RecordedTurn.from_dict(t) for t in data.get("turns", [])],
+            cwd=data.get("cwd", ""),
+            source=data.get("source", ""),
+            framework_version=data.get("framework_version", "unknown"),
+            created_at=data.get("created_at", ""),
+        )
+
+
+class GoldenStore:
+    """Reads and writes golden transcripts under ``.claude-mpm/golden``."""
+
+    def __init__(self, root: Path | None = None):
+        self.root = Path(root) if root else default_golden_dir()
+
+    def path(self, name: str) -> Path:
+        if not _NAME_RE.match(name):
+            raise ValueError(
+                f"invalid golden name {name!r} (letters, digits, '.', '_', '-')"
+            )
+        return self.root / f"{name}.json"
+
+    def exists(self, name: str) -> bool:
+        return self.path(name).is_file()
+
+    def save(self, golden: GoldenTranscript) -> Path:
+        path = self.path(golden.name)
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(json.dumps(golden.to_dict(), indent=2), encoding="utf-8")
+        return path
+
+    def load(self, name: str) -> GoldenTranscript:
+        path = self.path(name)
+        if not path.is_file():
+            raise FileNotFoundError(f"golden transcript '{name}' not found")
+        return GoldenTranscript.from_dict(json.loads(path.read_text(encoding="utf-8")))
+
+    def load_all(self) -> list[GoldenTranscript]:
+        if not self.root.is_dir():
+            return []
+        goldens = []
+        for path in sorted(self.root.glob("*.json")):
+            try:
+                goldens.append(
+                    GoldenTranscript.from_dict(json.loads(path.read_text("utf-8")))
+                )
+            except (OSError, ValueError, KeyError) as e:
+                logger.warning(f"Skipping unreadable golden {path}: {e}")
+        return goldens
+
+
+def record_golden(name: str, transcript: Path, cwd: str) -> GoldenTranscript:
+    """Build a golden from a session transcript or recording file."""
+    turns = load_recording(transcript)
+    if not turns:
+        raise ValueError(f"{transcript} contains no user turns")
+    return GoldenTranscript(name=name, turns=turns, cwd=cwd, source=str(transcript))
+
+
+# ----------------------------------------------------------------------
+# Diffing
+# ----------------------------------------------------------------------
+
+
+def tool_signature(call: RecordedToolCall, cwd: str = "", strict: bool = False) -> str:
+    """``Name`` or, when strict, ``Name(key argument)`` relative to *cwd*."""
+    if not strict:
+        return call.name
+    key = _KEY_ARGUMENTS.get(call.name)
+    value = str(call.input.get(key, "")) if key else ""
+    if cwd and value:
+        value = value.replace(cwd.rstrip("/") + "/", "")
+    value = " ".join(value.split())
+    return f"{call.name}({value[:80]})" if value else call.name
+
+
+@dataclass
+class TurnDiff:
+    index: int
+    prompt: str
+    tool_changes: list[str] = field(default_factory=list)
+    reply_similarity: float = 1.0
+    missing: bool = False
+
+    @property
+    def reply_changed(self) -> bool:
+        return self.reply_similarity < REPLY_SIMILARITY_THRESHOLD
+
+    @property
+    def changed(self) -> bool:
+        return self.missing or bool(self.tool_changes) or self.reply_changed
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "index": self.index,
+            "prompt": self.prompt,
+            "missing": self.missing,
+            "tool_changes": self.tool_changes,
+            "reply_similarity": round(self.reply_similarity, 3),
+            "reply_changed": self.reply_changed,
+        }
+
+
+@dataclass
+class GoldenDiff:
+    name: str
+    turns: list[TurnDiff]
+    extra_turns: int = 0
+    error: str | None = None
+
+    @property
+    def regressed(self) -> bool:
+        return (
+            self.error is not None
+            or self.extra_turns > 0
+            or any(turn.changed for turn in self.turns)
+        )
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.name,
+            "regressed": self.regressed,
+            "error": self.error,
+            "extra_turns": self.extra_turns,
+            "turns": [turn.to_dict() for turn in self.turns],
+        }
+
+
+def diff_turns(
+    name: str,
+    golden: list[RecordedTurn],
+    actual: list[RecordedTurn],
+    golden_cwd: str = "",
+    actual_cwd: str = "",
+    strict: bool = False,
+) -> GoldenDiff:
+    """Compare a replay against its golden, turn by turn."""
+    diffs = []
+    for index, expected in enumerate(golden):
+        diff = TurnDiff(index=index, prompt=expected.prompt)
+        if index >= len(actual):
+            diff.missing = True
+            diff.reply_similarity = 0.0
+            diffs.append(diff)
+            continue
+
+        got = actual[index]
+        before = [tool_signature(c, golden_cwd, strict) for c in expected.tool_calls]
+        after = [tool_signature(c, actual_cwd, strict) for c in got.tool_calls]
+        matcher = difflib.SequenceMatcher(None, before, after, autojunk=False)
+        for tag, i1, i2, j1, j2 in matcher.get_opcodes():
+            if tag != "equal":
+                diff.tool_changes.extend(f"- {sig}" for sig in before[i1:i2])
+                diff.tool_changes.extend(f"+ {sig}" for sig in after[j1:j2])
+        diff.reply_similarity = difflib.SequenceMatcher(
+            None, expected.reply, got.reply
+        ).ratio()
+        diffs.append(diff)
+
+    return GoldenDiff(
+        name=name, turns=diffs, extra_turns=max(0, len(actual) - len(golden))
+    )
+
+
+# ----------------------------------------------------------------------
+# Replay
+# ----------------------------------------------------------------------
+
+
+def _default_runtime_factory(config: AgentConfig):
+    from ..agents.runtime_config import get_runtime
+
+    return get_runtime(config)
+
+
+def _turn_from_result(prompt: str, result: AgentResult) -> RecordedTurn:
+    return RecordedTurn(
+        prompt=prompt,
+        reply=result.text or "",
+        tool_calls=[
+            RecordedToolCall(
+                name=call.get("tool_name") or call.get("name", ""),
+                input=call.get("input") or {},
+                result=str(call.get("output") or ""),
+            )
+            for call in result.tool_calls
+        ],
+    )
+
+
+class GoldenReplayer:
+    """Re-runs a golden's prompts against the current framework and agents."""
+
+    def __init__(
+        self,
+        runtime_factory: Callable[[AgentConfig], Any] | None = None,
+        project_dir: Path | None = None,
+        in_place: bool = False,
+        keep_workspace: bool = False,
+        transcript_locator: Callable[[str, str], Path] | None = None,
+    ):
+        self.runtime_factory = runtime_factory or _default_runtime_factory
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.in_place = in_place
+        self.keep_workspace = keep_workspace
+        self.transcript_locator = transcript_locator or _locate_transcript
+        self.workspace: Path | None = None
+
+    def replay(
+        self,
+        golden: GoldenTranscript,
+        model: str | None = None,
+        permission_mode: str = "acceptEdits",
+    ) -> list[RecordedTurn]:
+        """Send each recorded prompt in one session and return what happened."""
+        workspace = self._prepare_workspace(golden.name)
+        config = AgentConfig(
+            model=model, cwd=str(workspace), permission_mode=permission_mode
+        )
+        runtime = self.runtime_factory(config)
+        turns: list[RecordedTurn] = []
+        session_id = None
+        try:
+            for turn in golden.turns:
+                if session_id:
+                    call = runtime.resume(session_id, turn.prompt, config)
+                else:
+                    call = runtime.run(turn.prompt, config)
+                result = asyncio.run(call)
+                session_id = result.session_id or session_id
+                turns.append(_turn_from_result(turn.prompt, result))
+                if result.is_error:
+                    break
+        finally:
+            if not self.in_place and not self.keep_workspace:
+                shutil.rmtree(workspace.parent, ignore_errors=True)
+
+        # The session transcript also has tool calls the CLI runtime does not
+        # report, so prefer it when it was written.
+        if session_id:
+            transcript = self.transcript_locator(session_id, str(workspace))
+            if transcript.is_file():
+                recorded = load_recording(transcript)
+                if recorded:
+                    return recorded
+        return turns
+
+    def _prepare_workspace(self, name: str) -> Path:
+        if self.in_place:
+            self.workspace = self.project_dir
+            return self.project_dir
+        root = Path(tempfile.mkdtemp(prefix=f"claude-mpm-golden-{name}-"))
+        workspace = root / self.project_dir.name
+        shutil.copytree(
+            self.project_dir, workspace, ignore=_WORKSPACE_IGNORE, symlinks=True
+        )
+        self.workspace = workspace
+        return workspace
+
+
+def _locate_transcript(session_id: str, cwd: str) -> Path:
+    from ..session_analysis.transcript_parser import locate_transcript
+
+    return locate_transcript(session_id, cwd)
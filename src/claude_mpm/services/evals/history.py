@@ -0,0 +1,87 @@
+"""
+Scoring history for evaluation runs.
+
+WHY: A single score says little; what matters after a prompt change is
+whether cases that used to pass now fail. Every run is appended to a
+per-project JSONL file keyed by suite, agent version and prompt hash, so a
+run can be compared with the previous one for the same suite and agent.
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+from typing import Any
+
+from ...core.logger import get_logger
+
+logger = get_logger(__name__)
+
+# A case whose score drops by more than this counts as regressed even if it
+# still passes (rubric scores drift slightly between runs)
+SCORE_REGRESSION_TOLERANCE = 0.1
+
+
+def default_history_path(project_dir: Path | None = None) -> Path:
+    return (project_dir or Path.cwd()) / ".claude-mpm" / "evals" / "history.jsonl"
+
+
+class EvalHistory:
+    """Append-only log of suite results."""
+
+    def __init__(self, path: Path | None = None):
+        self.path = path or default_history_path()
+
+    def record(self, run: dict[str, Any]) -> None:
+        self.path.parent.mkdir(parents=True, exist_ok=True)
+        with self.path.open("a", encoding="utf-8") as handle:
+            handle.write(json.dumps(run) + "\n")
+
+    def runs(
+        self, suite: str | None = None, agent: str | None = None
+    ) -> list[dict[str, Any]]:
+        """Recorded runs, oldest first, optionally filtered."""
+        if not self.path.exists():
+            return []
+        runs = []
+        for line in self.path.read_text(encoding="utf-8").splitlines():
+            try:
+                run = json.loads(line)
+            except ValueError:
+                logger.debug(f"Skipping corrupt eval history line in {self.path}")
+                continue
+            if suite and run.get("suite") != suite:
+                continue
+            if agent and run.get("agent") != agent:
+                continue
+            runs.append(run)
+        return runs
+
+    def previous(self, suite: str, agent: str) -> dict[str, Any] | None:
+        """Most recent recorded run of ``suite`` against ``agent``."""
+        runs = self.runs(suite=suite, agent=agent)
+        return runs[-1] if runs else None
+
+
+def find_regressions(
+    previous: dict[str, Any], current: dict[str, Any]
+) -> list[dict[str, Any]]:
+    """Cases that passed before and fail now, or whose score dropped."""
+    before = {case["id"]: case for case in previous.get("cases", [])}
+    regressions = []
+    for case in current.get("cases", []):
+        old = before.get(case["id"])
+        if old is None:
+            continue
+        newly_failing = old["passed"] and not case["passed"]
+        dropped = old["score"] - case["score"] > SCORE_REGRESSION_TOLERANCE
+        if newly_failing or dropped:
+            regressions.append(
+                {
+                    "id": case["id"],
+                    "before": old["score"],
+                    "after": case["score"],
+                    "now_failing": newly_failing,
+                }
+            )
+    return regressions
@@ -0,0 +1,214 @@
+"""
+Evaluation runner: executes a suite's cases against an agent.
+
+DESIGN DECISIONS:
+- Each case runs in its own temp workspace seeded with the case's files, so
+  file-created and tests-pass assertions see only what the agent did
+- The agent runs with the same effective system prompt deployment would
+  produce, through the configured AgentRuntime (SDK or CLI)
+- Results carry the agent's frontmatter version and a hash of the effective
+  prompt, so history can tell "same version, edited prompt" apart
+"""
+
+from __future__ import annotations
+
+import asyncio
+import hashlib
+import shutil
+import tempfile
+import time
+from collections.abc import Callable
+from dataclasses import dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from ...core.logger import get_logger
+from ..agents.agent_runtime import AgentConfig
+from ..agents.playground import AgentSource, effective_prompt, locate_agent_source
+from .assertions import AssertionResult, CaseContext, RubricGrader, run_assertion
+from .suite import EvalCase, EvalSuite, EvalSuiteError
+
+logger = get_logger(__name__)
+
+
+@dataclass
+class CaseResult:
+    id: str
+    passed: bool
+    score: float
+    output: str
+    assertions: list[AssertionResult]
+    duration: float
+    error: str | None = None
+    workspace: str | None = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "id": self.id,
+            "passed": self.passed,
+            "score": round(self.score, 4),
+            "duration": round(self.duration, 2),
+            "error": self.error,
+            "workspace": self.workspace,
+            "assertions": [a.to_dict() for a in self.assertions],
+            "output": self.output,
+        }
+
+
+@dataclass
+class SuiteResult:
+    suite: str
+    agent: str
+    agent_version: str
+    prompt_hash: str
+    model: str | None
+    cases: list[CaseResult] = field(default_factory=list)
+    timestamp: str = field(default_factory=lambda: datetime.now(UTC).isoformat())
+
+    @property
+    def score(self) -> float:
+        if not self.cases:
+            return 0.0
+        return sum(case.score for case in self.cases) / len(self.cases)
+
+    @property
+    def passed(self) -> int:
+        return sum(1 for case in self.cases if case.passed)
+
+    def to_record(self) -> dict[str, Any]:
+        """Compact form stored in the scoring history."""
+        return {
+            "suite": self.suite,
+            "agent": self.agent,
+            "agent_version": self.agent_version,
+            "prompt_hash": self.prompt_hash,
+            "model": self.model,
+            "timestamp": self.timestamp,
+            "score": round(self.score, 4),
+            "passed": self.passed,
+            "total": len(self.cases),
+            "cases": [
+                {"id": c.id, "passed": c.passed, "score": round(c.score, 4)}
+                for c in self.cases
+            ],
+        }
+
+    def to_dict(self) -> dict[str, Any]:
+        return {**self.to_record(), "cases": [c.to_dict() for c in self.cases]}
+
+
+def _agent_version(source: AgentSource) -> str:
+    try:
+        meta = yaml.safe_load(source.frontmatter.strip().strip("-")) or {}
+    except yaml.YAMLError:
+        meta = {}
+    return str(meta.get("version", "unversioned"))
+
+
+def _default_runtime_factory(config: AgentConfig):
+    from ..agents.runtime_config import get_runtime
+
+    return get_runtime(config)
+
+
+class EvalRunner:
+    """Runs evaluation suites and scores the outcomes."""
+
+    def __init__(
+        self,
+        runtime_factory: Callable[[AgentConfig], Any] | None = None,
+        grader: RubricGrader | None = None,
+        project_dir: Path | None = None,
+        keep_workspaces: bool = False,
+    ):
+        self.runtime_factory = runtime_factory or _default_runtime_factory
+        self.grader = grader or RubricGrader(self.runtime_factory)
+        self.project_dir = project_dir or Path.cwd()
+        self.keep_workspaces = keep_workspaces
+
+    def run(
+        self,
+        suite: EvalSuite,
+        agent: str | None = None,
+        model: str | None = None,
+        on_case: Callable[[CaseResult], None] | None = None,
+    ) -> SuiteResult:
+        agent = agent or suite.agent
+        source = locate_agent_source(agent, self.project_dir)
+        if source is None:
+            raise EvalSuiteError(f"agent '{agent}' not found")
+
+        system_prompt = effective_prompt(source)
+        result = SuiteResult(
+            suite=suite.name,
+            agent=agent,
+            agent_version=_agent_version(source),
+            prompt_hash=hashlib.sha256(system_prompt.encode()).hexdigest()[:12],
+            model=model or suite.model,
+        )
+        for case in suite.cases:
+            case_result = self._run_case(suite, case, system_prompt, result.model)
+            result.cases.append(case_result)
+            if on_case:
+                on_case(case_result)
+        return result
+
+    def _run_case(
+        self, suite: EvalSuite, case: EvalCase, system_prompt: str, model: str | None
+    ) -> CaseResult:
+        workspace = Path(tempfile.mkdtemp(prefix=f"claude-mpm-eval-{case.id}-"))
+        start = time.monotonic()
+        output, error = "", None
+        try:
+            self._seed(workspace, case.files)
+            config = AgentConfig(
+                system_prompt=system_prompt,
+                model=model,
+                cwd=str(workspace),
+                max_turns=suite.max_turns,
+                permission_mode=suite.permission_mode,
+            )
+            runtime = self.runtime_factory(config)
+            run = runtime.run(case.prompt, config)
+            if case.timeout:
+                run = asyncio.wait_for(run, timeout=case.timeout)
+            agent_result = asyncio.run(run)
+            output = agent_result.text or ""
+            if agent_result.is_error:
+                error = f"agent error: {output[:200]}"
+        except TimeoutError:
+            error = f"agent timed out after {case.timeout:g}s"
+        except Exception as e:
+            logger.debug(f"Eval case {case.id} crashed", exc_info=True)
+            error = f"{type(e).__name__}: {e}"
+
+        ctx = CaseContext(output=output, workspace=workspace, grader=self.grader)
+        assertions = [run_assertion(a, ctx) for a in case.assertions]
+        total_weight = sum(a.weight for a in assertions) or 1.0
+        score = sum(a.score * a.weight for a in assertions) / total_weight
+        passed = error is None and all(a.passed for a in assertions)
+
+        if not self.keep_workspaces:
+            shutil.rmtree(workspace, ignore_errors=True)
+        return CaseResult(
+            id=case.id,
+            passed=passed,
+            score=score,
+            output=output,
+            assertions=assertions,
+            duration=time.monotonic() - start,
+            error=error,
+            workspace=str(workspace) if self.keep_workspaces else None,
+        )
+
+    @staticmethod
+    def _seed(workspace: Path, files: dict[str, str]) -> None:
+        for name, content in files.items():
+            target = (workspace / name).resolve()
+            if not target.is_relative_to(workspace.resolve()):
+                raise EvalSuiteError(f"seed file {name!r} escapes the workspace")
+            target.parent.mkdir(parents=True, exist_ok=True)
+            target.write_text(content, encoding="utf-8")
@@ -0,0 +1,134 @@
+"""
+Evaluation suite definitions.
+
+A suite is a YAML file naming the agent under test and a list of cases. Each
+case is a prompt run in a fresh workspace plus the assertions its outcome
+must satisfy::
+
+    name: engineer-basics          # default: file name
+    agent: engineer
+    model: sonnet                  # optional
+    max_turns: 10                  # optional, per case
+    permission_mode: acceptEdits   # optional
+    cases:
+      - id: create-module
+        prompt: Create calc.py with an add(a, b) function and a test for it
+        files:                     # optional files seeded into the workspace
+          README.md: "# calc"
+        assertions:
+          - {type: contains, value: calc.py}
+          - {type: regex, pattern: "def add\\\\("}
+          - {type: file-created, path: calc.py, contains: "def add"}
+          - {type: tests-pass, command: python -m pytest -q}
+          - {type: rubric, rubric: "Explains what was created", threshold: 0.7}
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+ASSERTION_TYPES = ("contains", "regex", "file-created", "tests-pass", "rubric")
+
+# Required key per assertion type (beyond ``type``)
+_REQUIRED_KEYS = {
+    "contains": "value",
+    "regex": "pattern",
+    "file-created": "path",
+    "tests-pass": "command",
+    "rubric": "rubric",
+}
+
+
+class EvalSuiteError(ValueError):
+    """A suite file is missing or malformed."""
+
+
+@dataclass
+class EvalCase:
+    id: str
+    prompt: str
+    assertions: list[dict[str, Any]]
+    files: dict[str, str] = field(default_factory=dict)
+    timeout: float | None = None
+
+
+@dataclass
+class EvalSuite:
+    name: str
+    agent: str
+    cases: list[EvalCase]
+    path: Path | None = None
+    model: str | None = None
+    max_turns: int | None = None
+    permission_mode: str | None = "acceptEdits"
+
+
+def _parse_case(index: int, raw: Any) -> EvalCase:
+    if not isinstance(raw, dict):
+        raise EvalSuiteError(f"case #{index + 1} must be a mapping")
+    case_id = str(raw.get("id") or f"case-{index + 1}")
+    prompt = raw.get("prompt")
+    if not prompt:
+        raise EvalSuiteError(f"case '{case_id}' has no prompt")
+
+    assertions = raw.get("assertions") or []
+    if not assertions:
+        raise EvalSuiteError(f"case '{case_id}' has no assertions")
+    for assertion in assertions:
+        kind = assertion.get("type") if isinstance(assertion, dict) else None
+        if kind not in ASSERTION_TYPES:
+            raise EvalSuiteError(
+                f"case '{case_id}': unknown assertion type {kind!r} "
+                f"(expected one of {', '.join(ASSERTION_TYPES)})"
+            )
+        if not assertion.get(_REQUIRED_KEYS[kind]):
+            raise EvalSuiteError(
+                f"case '{case_id}': {kind} assertion needs '{_REQUIRED_KEYS[kind]}'"
+            )
+
+    return EvalCase(
+        id=case_id,
+        prompt=str(prompt),
+        assertions=list(assertions),
+        files={str(k): str(v) for k, v in (raw.get("files") or {}).items()},
+        timeout=raw.get("timeout"),
+    )
+
+
+def load_suite(path: Path) -> EvalSuite:
+    """Parse and validate a suite file."""
+    path = Path(path)
+    try:
+        data = yaml.safe_load(path.read_text(encoding="utf-8"))
+    except OSError as e:
+        raise EvalSuiteError(f"cannot read suite {path}: {e}") from e
+    except yaml.YAMLError as e:
+        raise EvalSuiteError(f"invalid YAML in {path}: {e}") from e
+
+    if not isinstance(data, dict):
+        raise EvalSuiteError(f"{path} must contain a mapping")
+    if not data.get("agent"):
+        raise EvalSuiteError(f"{path} does not name an 'agent'")
+    raw_cases = data.get("cases") or []
+    if not raw_cases:
+        raise EvalSuiteError(f"{path} has no cases")
+
+    cases = [_parse_case(i, raw) for i, raw in enumerate(raw_cases)]
+    ids = [case.id for case in cases]
+    duplicates = sorted({i for i in ids if ids.count(i) > 1})
+    if duplicates:
+        raise EvalSuiteError(f"duplicate case ids: {', '.join(duplicates)}")
+
+    return EvalSuite(
+        name=str(data.get("name") or path.stem),
+        agent=str(data["agent"]),
+        cases=cases,
+        path=path,
+        model=data.get("model"),
+        max_turns=data.get("max_turns"),
+        permission_mode=data.get("permission_mode", "acceptEdits"),
+    )
@@ -491,11 +491,11 @@ class EventAggregator:
             "active_session_ids": [sid[:8] + "..." for sid in self.active_sessions],
         }
 
-    def list_sessions(self, limit: int = 10) -> list[dict[str, Any]]:
-        """List captured sessions.
+    def list_sessions(self, limit: int | None = 10) -> list[dict[str, Any]]:
+        """List captured sessions, most recent first.
 
         Args:
-            limit: Maximum number of sessions to return
+            limit: Maximum number of sessions to return, or None for all
 
         Returns:
             List of session summaries
@@ -507,7 +507,7 @@ class EventAggregator:
             self.save_dir.glob("session_*.json"),
             key=lambda p: p.stat().st_mtime,
             reverse=True,
-        )[:limit]
+        )[:limit]  # a None limit keeps every file
 
         for filepath in session_files:
             try:
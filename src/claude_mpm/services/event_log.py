@@ -20,6 +20,7 @@ from pathlib import Path
 from typing import Any, Literal
 
 from ..core.logger import get_logger
+from . import data_encryption
 
 # Event status types
 EventStatus = Literal["pending", "resolved", "archived"]
@@ -64,7 +65,7 @@ class EventLog:
             return []
 
         try:
-            content = self.log_file.read_text()
+            content = data_encryption.reveal(self.log_file.read_text())
             if not content.strip():
                 return []
             data = json.loads(content)
@@ -89,7 +90,10 @@ class EventLog:
             self.log_file.parent.mkdir(parents=True, exist_ok=True)
 
             # Write with pretty formatting for human readability
-            self.log_file.write_text(json.dumps(self.events, indent=2))
+            content = json.dumps(self.events, indent=2)
+            self.log_file.write_text(
+                data_encryption.protect(content, data_encryption.EVENTS)
+            )
         except Exception as e:
             self.logger.error(f"Failed to save event log: {e}")
 
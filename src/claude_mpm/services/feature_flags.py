@@ -0,0 +1,299 @@
+"""Feature flags for experimental subsystems.
+
+WHAT: A registry of preview features and the value of each flag, resolved
+from these layers (later ones win):
+
+1. the flag's default (off)
+2. the organization's flag config, a JSON document fetched from
+   ``feature_flags.org_url`` in configuration.yaml (or
+   ``CLAUDE_MPM_ORG_FLAGS_URL``) and cached in
+   ``~/.claude-mpm/cache/org-flags.json``
+3. the user's choices in ``~/.claude-mpm/feature-flags.json``
+4. the project's choices in ``.claude-mpm/feature-flags.json``
+5. ``CLAUDE_MPM_FLAG_<NAME>=1|0`` in the environment
+
+Flags the organization lists under ``locked`` keep the organization's value
+whatever the other layers say. ``claude-mpm flags list`` shows each flag and
+the layer that decided it; ``flags enable``/``flags disable`` write layer 3
+(or 4 with ``--project``).
+
+Organization config format::
+
+    {"flags": {"llmlingua": true}, "locked": ["agent_teams"]}
+
+WHY: Previews were switched on by undocumented environment variables, or
+by patching code. Flags give users one place to opt in and see what is on,
+and give organizations a way to roll previews out, or keep them off.
+
+DESIGN DECISIONS:
+- Reading flags never touches the network, so hooks can consult them; the
+  org config is refreshed in the background on startup (once per
+  CLAUDE_MPM_SYNC_TTL, a day by default) and by ``flags list``, and the
+  last fetched copy is used in between
+- A failed fetch keeps the cached copy; with no org URL configured the
+  cache is removed, so a dropped org config stops applying
+- Unknown flag names in any layer are ignored, so configs survive flags
+  being retired
+"""
+
+from __future__ import annotations
+
+import difflib
+import json
+import os
+import threading
+import urllib.request
+from dataclasses import dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json, write_atomic
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "feature_flags"
+ORG_URL_ENV_VAR = "CLAUDE_MPM_ORG_FLAGS_URL"
+ENV_PREFIX = "CLAUDE_MPM_FLAG_"
+FLAGS_FILE = "feature-flags.json"
+
+# Where a flag's value came from
+DEFAULT = "default"
+ORG = "org"
+USER = "user"
+PROJECT = "project"
+ENV = "env"
+
+_TRUE = frozenset({"1", "true", "yes", "on"})
+_FALSE = frozenset({"0", "false", "no", "off"})
+
+
+@dataclass(frozen=True)
+class Flag:
+    """A preview feature that can be switched on."""
+
+    name: str
+    description: str
+    # Environment variable set to "1" for Claude Code when the flag is on
+    exports: str | None = None
+
+
+FLAGS: dict[str, Flag] = {
+    flag.name: flag
+    for flag in (
+        Flag(
+            "agent_teams",
+            "Start Claude Code with Agent Teams enabled",
+            exports="CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS",
+        ),
+        Flag(
+            "adoption_metrics",
+            "Count your sessions, commits and verification runs for the team "
+            "adoption report",
+        ),
+        Flag(
+            "llmlingua",
+            "Compress long Bash output with LLMLingua-2 before Claude reads it",
+        ),
+    )
+}
+
+
+@dataclass
+class FlagState:
+    """The resolved value of one flag."""
+
+    flag: Flag
+    enabled: bool
+    source: str
+    locked: bool = False
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.flag.name,
+            "enabled": self.enabled,
+            "source": self.source,
+            "locked": self.locked,
+            "description": self.flag.description,
+        }
+
+
+def user_flags_path() -> Path:
+    return Path.home() / ".claude-mpm" / FLAGS_FILE
+
+
+def project_flags_path(project_root: Path | None = None) -> Path:
+    return Path(project_root or Path.cwd()) / ".claude-mpm" / FLAGS_FILE
+
+
+def org_cache_path() -> Path:
+    return Path.home() / ".claude-mpm" / "cache" / "org-flags.json"
+
+
+def _parse_bool(value: Any) -> bool | None:
+    if isinstance(value, bool):
+        return value
+    if isinstance(value, str):
+        text = value.strip().lower()
+        if text in _TRUE:
+            return True
+        if text in _FALSE:
+            return False
+    return None
+
+
+def _layer(data: Any) -> dict[str, bool]:
+    """Known flags with a boolean value from a ``{"flags": {...}}`` document."""
+    flags = data.get("flags") if isinstance(data, dict) else None
+    if not isinstance(flags, dict):
+        return {}
+    values = {}
+    for name, value in flags.items():
+        parsed = _parse_bool(value)
+        if name in FLAGS and parsed is not None:
+            values[name] = parsed
+    return values
+
+
+def env_value(name: str) -> bool | None:
+    """The value ``CLAUDE_MPM_FLAG_<NAME>`` sets for flag *name*, if any."""
+    return _parse_bool(os.environ.get(f"{ENV_PREFIX}{name.upper()}"))
+
+
+def unknown_flag_error(name: str) -> str:
+    message = f"Unknown flag '{name}'"
+    close = difflib.get_close_matches(name, FLAGS, n=1)
+    if close:
+        message += f"; did you mean '{close[0]}'?"
+    return message + " (see 'claude-mpm flags list')"
+
+
+class FeatureFlags:
+    """Resolves feature flags for one project."""
+
+    def __init__(self, project_root: Path | None = None):
+        self.project_root = Path(project_root or Path.cwd())
+        org = read_json(org_cache_path(), {})
+        self.org = _layer(org)
+        locked = org.get("locked") if isinstance(org, dict) else None
+        if not isinstance(locked, list):
+            locked = []
+        self.locked = {name for name in locked if name in FLAGS}
+        self.user = _layer(read_json(user_flags_path(), {}))
+        self.project = _layer(read_json(project_flags_path(self.project_root), {}))
+
+    def state(self, name: str) -> FlagState:
+        if name not in FLAGS:
+            raise KeyError(unknown_flag_error(name))
+        flag = FLAGS[name]
+        if name in self.locked:
+            return FlagState(flag, self.org.get(name, False), ORG, locked=True)
+        env = env_value(name)
+        if env is not None:
+            return FlagState(flag, env, ENV)
+        layers = ((PROJECT, self.project), (USER, self.user), (ORG, self.org))
+        for source, layer in layers:
+            if name in layer:
+                return FlagState(flag, layer[name], source)
+        return FlagState(flag, False, DEFAULT)
+
+    def states(self) -> list[FlagState]:
+        return [self.state(name) for name in sorted(FLAGS)]
+
+    def is_enabled(self, name: str) -> bool:
+        return self.state(name).enabled
+
+    def set(self, name: str, enabled: bool, project: bool = False) -> Path:
+        """Record the user's (or with *project*, the project's) choice."""
+        if name not in FLAGS:
+            raise KeyError(unknown_flag_error(name))
+        if name in self.locked:
+            raise ValueError(f"'{name}' is locked by your organization's flag config")
+        path = project_flags_path(self.project_root) if project else user_flags_path()
+
+        def record(data: dict[str, Any]) -> None:
+            if not isinstance(data.get("flags"), dict):
+                data["flags"] = {}
+            data["flags"][name] = enabled
+
+        update_json(path, record)
+        (self.project if project else self.user)[name] = enabled
+        return path
+
+    def exported_env(self) -> dict[str, str]:
+        """Environment variables Claude Code needs for the enabled flags."""
+        return {
+            flag.exports: "1"
+            for flag in FLAGS.values()
+            if flag.exports and self.is_enabled(flag.name)
+        }
+
+
+def is_enabled(name: str, project_root: Path | None = None) -> bool:
+    """Whether flag *name* is on; False if the flags cannot be read."""
+    try:
+        return FeatureFlags(project_root).is_enabled(name)
+    except Exception as e:
+        logger.debug(f"Could not read feature flag {name}: {e}")
+        return False
+
+
+def org_flags_url(config: Any = None) -> str | None:
+    url = os.environ.get(ORG_URL_ENV_VAR)
+    if url:
+        return url
+    try:
+        if config is None:
+            from ..core.config import Config
+
+            config = Config()
+        return (config.get(CONFIG_KEY, {}) or {}).get("org_url") or None
+    except Exception as e:
+        logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return None
+
+
+def _fetch(url: str, timeout: float) -> Any:
+    if "://" not in url or url.startswith("file://"):
+        path = Path(url.removeprefix("file://")).expanduser()
+        return json.loads(path.read_text(encoding="utf-8"))
+    request = urllib.request.Request(url, headers={"Accept": "application/json"})
+    with urllib.request.urlopen(request, timeout=timeout) as response:  # nosec B310
+        return json.loads(response.read().decode("utf-8"))
+
+
+def refresh_org_flags(url: str | None = None, timeout: float = 5.0) -> dict | None:
+    """Fetch the organization's flag config into the cache.
+
+    Returns the cached document, or None when no org URL is configured. A
+    failed fetch is logged and leaves the previous copy in place.
+    """
+    url = url or org_flags_url()
+    cache = org_cache_path()
+    if not url:
+        cache.unlink(missing_ok=True)
+        return None
+    try:
+        data = _fetch(url, timeout)
+        if not isinstance(data, dict):
+            raise ValueError("expected a JSON object")
+    except Exception as e:
+        logger.warning(f"Could not fetch organization flag config from {url}: {e}")
+        return read_json(cache, None)
+    document = {
+        "url": url,
+        "fetched_at": datetime.now(UTC).isoformat(),
+        "flags": data.get("flags", {}),
+        "locked": data.get("locked", []),
+    }
+    write_atomic(cache, json.dumps(document, indent=2))
+    return document
+
+
+def refresh_org_flags_in_background() -> threading.Thread:
+    thread = threading.Thread(
+        target=refresh_org_flags, name="org-flags-refresh", daemon=True
+    )
+    thread.start()
+    return thread
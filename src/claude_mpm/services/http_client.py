@@ -0,0 +1,136 @@
+"""HTTP settings shared by everything that fetches skills and agents.
+
+WHAT: ``get`` and ``session`` make requests through the configured proxy
+and trust the configured CA bundle. Skill sources (GitHub, GitLab and
+Bitbucket APIs, raw files, archives, HTTP registries, update checks and
+search) and agent template downloads all fetch through them.
+
+CONFIGURATION (.claude-mpm/configuration.yaml)::
+
+    network:
+      https_proxy: http://proxy.corp.example:8080
+      http_proxy: http://proxy.corp.example:8080
+      no_proxy: localhost,.corp.example
+      ca_bundle: ~/certs/corp-root-ca.pem
+
+Unset values fall back to the environment: ``HTTPS_PROXY``, ``HTTP_PROXY``
+and ``NO_PROXY`` as usual, and for the CA bundle ``REQUESTS_CA_BUNDLE``,
+``CURL_CA_BUNDLE`` or ``SSL_CERT_FILE``.
+
+WHY: Behind a TLS-intercepting proxy every HTTPS response is signed by the
+company's root CA. git trusts it through the system store, but requests
+only trusts its bundled certifi roots, so skill and agent fetches failed
+where ``git clone`` worked.
+
+DESIGN DECISIONS:
+- The bundle is used instead of certifi's roots, not added to them; a
+  corporate bundle normally includes the public roots as well
+- There is no setting to turn verification off
+- ``no_proxy`` applies to configured proxies too; requests only honours
+  it for proxies from the environment
+"""
+
+from __future__ import annotations
+
+import os
+from dataclasses import dataclass, fields
+from pathlib import Path
+from typing import Any
+
+import requests
+from requests.utils import should_bypass_proxies
+
+from claude_mpm.core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "network"
+# requests reads the first two itself but not SSL_CERT_FILE, which git and
+# other OpenSSL-based tools use
+CA_BUNDLE_ENV = ("REQUESTS_CA_BUNDLE", "CURL_CA_BUNDLE", "SSL_CERT_FILE")
+
+
+@dataclass
+class NetworkConfig:
+    """Proxy and CA bundle for outgoing HTTP requests."""
+
+    https_proxy: str = ""
+    http_proxy: str = ""
+    no_proxy: str = ""
+    ca_bundle: str = ""
+
+    @classmethod
+    def load(cls, config: Any = None) -> NetworkConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from claude_mpm.core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: str(v) for k, v in section.items() if k in known and v})
+
+    def ca_path(self) -> str | None:
+        """The CA bundle to verify against, or None for requests' default."""
+        configured = self.ca_bundle or next(
+            (os.environ[name] for name in CA_BUNDLE_ENV if os.environ.get(name)),
+            "",
+        )
+        if not configured:
+            return None
+        path = Path(configured).expanduser()
+        if not path.exists():
+            logger.warning(f"CA bundle not found: {path}")
+        return str(path)
+
+    def proxies(self, url: str) -> dict[str, str]:
+        """Configured proxies for *url*; none when ``no_proxy`` matches it."""
+        configured = {"https": self.https_proxy, "http": self.http_proxy}
+        proxies = {scheme: proxy for scheme, proxy in configured.items() if proxy}
+        if proxies and self.no_proxy:
+            if should_bypass_proxies(url, no_proxy=self.no_proxy):
+                return {}
+        return proxies
+
+    def request_kwargs(self, url: str) -> dict[str, Any]:
+        """``requests`` keyword arguments applying these settings to *url*."""
+        kwargs: dict[str, Any] = {}
+        if proxies := self.proxies(url):
+            kwargs["proxies"] = proxies
+        if ca := self.ca_path():
+            kwargs["verify"] = ca
+        return kwargs
+
+
+class ConfiguredSession(requests.Session):
+    """A session that applies the network settings to every request.
+
+    Explicit ``proxies`` or ``verify`` arguments win over the settings.
+    """
+
+    def __init__(self, config: NetworkConfig):
+        super().__init__()
+        self.network = config
+
+    def request(self, method, url, *args, **kwargs):
+        for key, value in self.network.request_kwargs(str(url)).items():
+            if kwargs.get(key) is None:
+                kwargs[key] = value
+        return super().request(method, url, *args, **kwargs)
+
+
+def get(url: str, config: NetworkConfig | None = None, **kwargs: Any):
+    """``requests.get`` through the configured proxy and CA bundle.
+
+    Explicit ``proxies`` or ``verify`` arguments win over the settings.
+    """
+    settings = (config or NetworkConfig.load()).request_kwargs(url)
+    return requests.get(url, **{**settings, **kwargs})
+
+
+def session(config: NetworkConfig | None = None) -> ConfiguredSession:
+    """A ``requests.Session`` using the configured proxy and CA bundle."""
+    return ConfiguredSession(config or NetworkConfig.load())
@@ -0,0 +1,309 @@
+"""Policy-controlled HTTP requests for agents, recorded as test fixtures.
+
+WHAT: ``HttpRequestTool`` sends a request only to allowlisted hosts, caps
+the response size, and can save the request/response pair as a JSON
+fixture. ``FixtureSet`` loads those fixtures back so the project's tests can
+replay the exact exchange the agent observed.
+
+WHY: API-integration work needs real responses, but a general-purpose fetch
+lets agents reach anything and leaves nothing behind to test against. This
+keeps calls inside policy and turns each one into a reusable fixture.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    http_tool:
+      allowed_hosts: [localhost, api.staging.example.com, "*.internal.example"]
+      max_response_bytes: 1048576
+      timeout: 30
+      fixtures_dir: tests/fixtures/http
+
+DESIGN DECISIONS:
+- Only localhost is allowed until a project lists more hosts
+- Redirects are followed by hand so every hop is checked against the
+  allowlist
+- Credentials are never written to fixtures: sensitive headers are redacted
+  on both the request and the response
+"""
+
+from __future__ import annotations
+
+import base64
+import fnmatch
+import json
+import re
+import time
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+from urllib.parse import urljoin, urlsplit
+
+import requests
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "http_tool"
+DEFAULT_ALLOWED_HOSTS = ("localhost", "127.0.0.1", "::1")
+DEFAULT_MAX_RESPONSE_BYTES = 1024 * 1024
+DEFAULT_TIMEOUT = 30
+DEFAULT_FIXTURES_DIR = "tests/fixtures/http"
+MAX_REDIRECTS = 5
+METHODS = ("GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS")
+
+REDACTED = "[REDACTED]"
+SENSITIVE_HEADERS = frozenset(
+    {
+        "authorization",
+        "proxy-authorization",
+        "cookie",
+        "set-cookie",
+        "x-api-key",
+        "x-auth-token",
+    }
+)
+
+_FIXTURE_NAME = re.compile(r"[^A-Za-z0-9_.-]")
+_TEXT_TYPES = ("text/", "json", "xml", "javascript", "x-www-form-urlencoded")
+
+
+class HttpPolicyError(Exception):
+    """Raised when a request is outside the configured policy."""
+
+
+def redact_headers(headers: dict[str, str]) -> dict[str, str]:
+    return {
+        name: REDACTED if name.lower() in SENSITIVE_HEADERS else value
+        for name, value in headers.items()
+    }
+
+
+@dataclass
+class HttpToolConfig:
+    """Which hosts agents may call and how much they may read."""
+
+    allowed_hosts: list[str] = field(
+        default_factory=lambda: list(DEFAULT_ALLOWED_HOSTS)
+    )
+    max_response_bytes: int = DEFAULT_MAX_RESPONSE_BYTES
+    timeout: float = DEFAULT_TIMEOUT
+    fixtures_dir: str = DEFAULT_FIXTURES_DIR
+
+    @classmethod
+    def load(cls, config: Any = None) -> HttpToolConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        defaults = cls()
+        return cls(
+            allowed_hosts=list(section.get("allowed_hosts") or defaults.allowed_hosts),
+            max_response_bytes=int(
+                section.get("max_response_bytes", defaults.max_response_bytes)
+            ),
+            timeout=float(section.get("timeout", defaults.timeout)),
+            fixtures_dir=section.get("fixtures_dir", defaults.fixtures_dir),
+        )
+
+    def allows(self, url: str) -> bool:
+        parts = urlsplit(url)
+        if parts.scheme not in ("http", "https"):
+            return False
+        host = (parts.hostname or "").lower()
+        return any(
+            fnmatch.fnmatch(host, pattern.lower()) for pattern in self.allowed_hosts
+        )
+
+
+@dataclass
+class HttpExchange:
+    """A request and the response it got, in fixture form."""
+
+    request: dict[str, Any]
+    response: dict[str, Any]
+    elapsed_ms: int = 0
+    recorded_at: str = ""
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+    def body_text(self) -> str:
+        """The response body as text (decoding base64 bodies as UTF-8)."""
+        body = self.response.get("body", "")
+        if self.response.get("body_encoding") == "base64":
+            return base64.b64decode(body).decode("utf-8", errors="replace")
+        return body
+
+    def json(self) -> Any:
+        return json.loads(self.body_text())
+
+
+class HttpRequestTool:
+    """Sends allowlisted requests and records them as fixtures."""
+
+    def __init__(self, project_dir: Path, config: HttpToolConfig | None = None):
+        self.project_dir = Path(project_dir)
+        self.config = config or HttpToolConfig.load()
+        self.fixtures = FixtureSet(self.project_dir / self.config.fixtures_dir)
+
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: dict[str, str] | None = None,
+        body: str | None = None,
+        json_body: Any = None,
+        record_as: str | None = None,
+    ) -> HttpExchange:
+        """Send one request; save it as fixture ``record_as`` when given.
+
+        Raises:
+            HttpPolicyError: Method or host not allowed, or the response is
+                larger than ``max_response_bytes``.
+        """
+        method = method.upper()
+        if method not in METHODS:
+            raise HttpPolicyError(f"Unsupported HTTP method: {method}")
+        headers = dict(headers or {})
+        if json_body is not None:
+            body = json.dumps(json_body)
+            headers.setdefault("Content-Type", "application/json")
+
+        started = time.monotonic()
+        response = self._send(method, url, headers, body)
+        try:
+            content = self._read_limited(response)
+        finally:
+            response.close()
+
+        exchange = HttpExchange(
+            request={
+                "method": method,
+                "url": url,
+                "headers": redact_headers(headers),
+                "body": body,
+            },
+            response={
+                "status": response.status_code,
+                "url": response.url,
+                "headers": redact_headers(dict(response.headers)),
+                **_encode_body(content, response.headers.get("Content-Type", "")),
+            },
+            elapsed_ms=int((time.monotonic() - started) * 1000),
+            recorded_at=datetime.now(UTC).isoformat(),
+        )
+        if record_as:
+            self.fixtures.save(record_as, exchange)
+        return exchange
+
+    def _send(
+        self, method: str, url: str, headers: dict[str, str], body: str | None
+    ) -> requests.Response:
+        for _ in range(MAX_REDIRECTS + 1):
+            if not self.config.allows(url):
+                raise HttpPolicyError(
+                    f"Host not allowed: {urlsplit(url).hostname or url}. Add it to "
+                    f"{CONFIG_KEY}.allowed_hosts to permit requests."
+                )
+            response = requests.request(
+                method,
+                url,
+                headers=headers,
+                data=body,
+                timeout=self.config.timeout,
+                allow_redirects=False,
+                stream=True,
+            )
+            if not response.is_redirect:
+                return response
+            response.close()
+            target = urljoin(url, response.headers["Location"])
+            if urlsplit(target).hostname != urlsplit(url).hostname:
+                # Never forward credentials to a different host
+                headers = {
+                    name: value
+                    for name, value in headers.items()
+                    if name.lower() not in SENSITIVE_HEADERS
+                }
+            url = target
+            if response.status_code in (301, 302, 303) and method != "HEAD":
+                method, body = "GET", None
+        raise HttpPolicyError(f"More than {MAX_REDIRECTS} redirects")
+
+    def _read_limited(self, response: requests.Response) -> bytes:
+        limit = self.config.max_response_bytes
+        declared = response.headers.get("Content-Length")
+        if declared and declared.isdigit() and int(declared) > limit:
+            raise HttpPolicyError(
+                f"Response is {declared} bytes; the limit is {limit} "
+                f"({CONFIG_KEY}.max_response_bytes)"
+            )
+        content = b""
+        for chunk in response.iter_content(chunk_size=65536):
+            content += chunk
+            if len(content) > limit:
+                raise HttpPolicyError(
+                    f"Response exceeds the {limit} byte limit "
+                    f"({CONFIG_KEY}.max_response_bytes)"
+                )
+        return content
+
+
+class FixtureSet:
+    """Recorded exchanges in a directory, one JSON file each.
+
+    Tests can replay them without the network::
+
+        fixtures = FixtureSet(Path("tests/fixtures/http"))
+        user = fixtures.load("get_user").json()
+    """
+
+    def __init__(self, directory: Path):
+        self.directory = Path(directory)
+
+    def path(self, name: str) -> Path:
+        return self.directory / f"{_FIXTURE_NAME.sub('_', name).lstrip('.')}.json"
+
+    def save(self, name: str, exchange: HttpExchange) -> Path:
+        path = self.path(name)
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(json.dumps(exchange.to_dict(), indent=2) + "\n")
+        return path
+
+    def load(self, name: str) -> HttpExchange:
+        path = self.path(name)
+        if not path.is_file():
+            raise FileNotFoundError(
+                f"No HTTP fixture named '{name}' in {self.directory}"
+            )
+        return HttpExchange(**json.loads(path.read_text()))
+
+    def names(self) -> list[str]:
+        if not self.directory.is_dir():
+            return []
+        return sorted(p.stem for p in self.directory.glob("*.json"))
+
+    def find(self, method: str, url: str) -> HttpExchange | None:
+        """The recorded exchange for ``method url``, if any."""
+        for name in self.names():
+            exchange = self.load(name)
+            request = exchange.request
+            if request["method"] == method.upper() and request["url"] == url:
+                return exchange
+        return None
+
+
+def _encode_body(content: bytes, content_type: str) -> dict[str, Any]:
+    if any(kind in content_type for kind in _TEXT_TYPES) or not content_type:
+        try:
+            return {"body": content.decode("utf-8"), "body_encoding": "utf-8"}
+        except UnicodeDecodeError:
+            pass
+    encoded = base64.b64encode(content).decode("ascii")
+    return {"body": encoded, "body_encoding": "base64"}
@@ -15,6 +15,8 @@ Design Principles:
 - Non-blocking generation
 - Graceful degradation if generation fails
 - Integration with existing session state
+- A mission summary over its budget is shortened by the summarizer of the
+  ``compaction`` use case (see services/summarizers.py)
 """
 
 from datetime import UTC, datetime
@@ -27,6 +29,9 @@ from claude_mpm.storage.state_storage import StateStorage
 
 logger = get_logger(__name__)
 
+# The mission summary's 1,000-token budget (see ResumeLog), in words
+MISSION_SUMMARY_WORDS = 750
+
 
 class ResumeLogGenerator:
     """Service for generating session resume logs."""
@@ -165,7 +170,9 @@ class ResumeLogGenerator:
             )
 
             # Extract content from session state
-            mission_summary = session_state.get("mission_summary", "")
+            mission_summary = self._compact(
+                session_state.get("mission_summary", ""), MISSION_SUMMARY_WORDS
+            )
             accomplishments = session_state.get("accomplishments", [])
             key_findings = session_state.get("key_findings", [])
             decisions_made = session_state.get("decisions_made", [])
@@ -204,6 +211,20 @@ class ResumeLogGenerator:
             )
             return None
 
+    def _compact(self, text: str, max_words: int) -> str:
+        """*text*, summarized when it has more than *max_words* words."""
+        if len(text.split()) <= max_words:
+            return text
+        try:
+            from claude_mpm.services.summarizers import summarizer_for
+
+            summary = summarizer_for("compaction").summarize(text, max_words)
+            logger.info(f"Compacted mission summary with {summary.backend}")
+            return summary.text
+        except Exception as e:
+            logger.warning(f"Could not compact mission summary: {e}")
+            return text
+
     def generate_from_todo_list(
         self,
         session_id: str,
@@ -0,0 +1,34 @@
+"""Knowledge graph of project services, endpoints, schemas, owners and contracts."""
+
+from .contracts import ContractAnalyzer, ContractReport, DriftIssue
+from .extractor import KnowledgeGraphBuilder, load_or_build
+from .graph import (
+    CONTRACT,
+    ENDPOINT,
+    ENTITY_TYPES,
+    OWNER,
+    SCHEMA,
+    SERVICE,
+    Entity,
+    KnowledgeGraph,
+    Relation,
+    default_graph_path,
+)
+
+__all__ = [
+    "CONTRACT",
+    "ENDPOINT",
+    "ENTITY_TYPES",
+    "OWNER",
+    "SCHEMA",
+    "SERVICE",
+    "ContractAnalyzer",
+    "ContractReport",
+    "DriftIssue",
+    "Entity",
+    "KnowledgeGraph",
+    "KnowledgeGraphBuilder",
+    "Relation",
+    "default_graph_path",
+    "load_or_build",
+]
@@ -0,0 +1,460 @@
+"""OpenAPI and protobuf contract awareness for the knowledge graph.
+
+WHAT: Parses OpenAPI/Swagger specs and ``.proto`` files, links each contract
+operation to the endpoint or RPC method that implements it, and reports
+drift between contract and code:
+
+- ``unimplemented``: an operation or RPC in the contract has no handler
+- ``undocumented``: a service that implements part of a spec exposes an
+  endpoint the spec does not describe
+- ``schema_missing_field`` / ``schema_extra_field``: a spec schema and the
+  code model of the same name disagree on their fields
+
+WHY: Agents change handlers and models without touching the spec (or the
+reverse) and nothing fails until a client does. Running the check after a
+session catches the drift while the change is still in review.
+
+DESIGN DECISIONS:
+- The code side comes from the knowledge graph's endpoint and schema
+  entities, so contract checks see exactly what agents query
+- Paths are compared after normalising parameters ({id}, :id, <int:id>)
+  and may differ by a prefix, since routers are usually mounted under a
+  base path the handler decorator does not repeat
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+import re
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from .graph import CONTRACT, ENDPOINT, IMPLEMENTS, SCHEMA, Entity, KnowledgeGraph
+
+logger = logging.getLogger(__name__)
+
+HTTP_METHODS = ("get", "put", "post", "delete", "options", "head", "patch")
+SPEC_SUFFIXES = {".yaml", ".yml", ".json"}
+MAX_SPEC_BYTES = 2 * 1024 * 1024
+
+UNIMPLEMENTED = "unimplemented"
+UNDOCUMENTED = "undocumented"
+SCHEMA_MISSING_FIELD = "schema_missing_field"
+SCHEMA_EXTRA_FIELD = "schema_extra_field"
+
+_SPEC_MARKER = re.compile(r"^\s*[\"']?(openapi|swagger)[\"']?\s*:", re.MULTILINE)
+_PATH_PARAM = re.compile(r"\{[^}]*\}|:\w+|<[^>]*>")
+_PROTO_PACKAGE = re.compile(r"^\s*package\s+([\w.]+)\s*;", re.MULTILINE)
+_PROTO_SERVICE = re.compile(r"^\s*service\s+(\w+)\s*\{", re.MULTILINE)
+_PROTO_RPC = re.compile(
+    r"rpc\s+(\w+)\s*\(\s*(?:stream\s+)?([\w.]+)\s*\)\s*returns\s*"
+    r"\(\s*(?:stream\s+)?([\w.]+)\s*\)"
+)
+_PROTO_MESSAGE = re.compile(r"^\s*message\s+(\w+)\s*\{", re.MULTILINE)
+_PROTO_FIELD = re.compile(
+    r"^\s*(?:repeated\s+|optional\s+)?[\w.<>, ]+\s+(\w+)\s*=\s*\d+", re.MULTILINE
+)
+_PY_RPC_IMPL = re.compile(r"def\s+(\w+)\s*\(\s*self\s*,\s*request", re.MULTILINE)
+_GO_RPC_IMPL = re.compile(r"^func\s*\(\s*\w+\s+\*?\w+\s*\)\s*(\w+)\(", re.MULTILINE)
+_GENERATED = ("_pb2.py", "_pb2_grpc.py", ".pb.go", "_grpc.pb.go")
+
+
+def _line_of(text: str, needle: str) -> int:
+    offset = text.find(needle)
+    return text.count("\n", 0, offset) + 1 if offset >= 0 else 1
+
+
+def normalize_path(path: str) -> str:
+    """``/users/{user_id}/`` and ``/users/:id`` both become ``/users/{}``."""
+    normalized = _PATH_PARAM.sub("{}", path.strip()).rstrip("/")
+    return normalized or "/"
+
+
+def paths_match(contract_path: str, code_path: str) -> bool:
+    """Equal after normalising, or equal up to a mount prefix on either side."""
+    a, b = normalize_path(contract_path), normalize_path(code_path)
+    if a == b:
+        return True
+    longer, shorter = (a, b) if len(a) > len(b) else (b, a)
+    return shorter != "/" and longer.endswith(shorter)
+
+
+@dataclass
+class ContractOperation:
+    """One HTTP operation or RPC declared by a contract."""
+
+    kind: str  # "http" or "rpc"
+    name: str  # "GET /users/{id}" or "UserService.GetUser"
+    spec: str
+    line: int
+    method: str = ""
+    path: str = ""
+    rpc: str = ""
+    operation_id: str = ""
+    request: str = ""
+    response: str = ""
+    implemented_by: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class ContractSchema:
+    """A schema or message declared by a contract, with its field names."""
+
+    name: str
+    spec: str
+    line: int
+    fields: list[str] = field(default_factory=list)
+
+
+@dataclass
+class DriftIssue:
+    """A disagreement between a contract and the code."""
+
+    kind: str
+    subject: str
+    detail: str
+    spec: str = ""
+    file: str = ""
+    line: int | None = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class ContractReport:
+    """Contracts found in a project and how the code drifts from them."""
+
+    operations: list[ContractOperation] = field(default_factory=list)
+    schemas: list[ContractSchema] = field(default_factory=list)
+    issues: list[DriftIssue] = field(default_factory=list)
+
+    @property
+    def drifted(self) -> bool:
+        return bool(self.issues)
+
+    @property
+    def specs(self) -> list[str]:
+        found = {op.spec for op in self.operations}
+        return sorted(found | {schema.spec for schema in self.schemas})
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "specs": self.specs,
+            "operations": [op.to_dict() for op in self.operations],
+            "issues": [issue.to_dict() for issue in self.issues],
+        }
+
+
+# ---------------------------------------------------------------------------
+# Contract parsing
+# ---------------------------------------------------------------------------
+
+
+def _ref_name(node: Any) -> str:
+    """Schema name behind a ``$ref`` (directly, or as an array's items)."""
+    if not isinstance(node, dict):
+        return ""
+    ref = node.get("$ref") or (node.get("items") or {}).get("$ref", "")
+    return ref.rsplit("/", 1)[-1] if ref else ""
+
+
+def parse_openapi(
+    text: str, spec: str
+) -> tuple[list[ContractOperation], list[ContractSchema]]:
+    """Operations and component schemas of an OpenAPI 3 or Swagger 2 document."""
+    document = yaml.safe_load(text)
+    if not isinstance(document, dict):
+        return [], []
+    operations = []
+    for path, item in (document.get("paths") or {}).items():
+        if not isinstance(item, dict):
+            continue
+        for method, op in item.items():
+            if method not in HTTP_METHODS or not isinstance(op, dict):
+                continue
+            body = (op.get("requestBody") or {}).get("content") or {}
+            request = next((_ref_name(c.get("schema")) for c in body.values()), "")
+            for param in op.get("parameters") or []:
+                if isinstance(param, dict) and param.get("in") == "body":
+                    request = request or _ref_name(param.get("schema"))
+            response = ""
+            for status, resp in (op.get("responses") or {}).items():
+                if not str(status).startswith("2") or not isinstance(resp, dict):
+                    continue
+                content = resp.get("content") or {}
+                response = next(
+                    (_ref_name(c.get("schema")) for c in content.values()), ""
+                ) or _ref_name(resp.get("schema"))
+                break
+            operations.append(
+                ContractOperation(
+                    kind="http",
+                    name=f"{method.upper()} {path}",
+                    spec=spec,
+                    line=_line_of(text, str(path)),
+                    method=method.upper(),
+                    path=str(path),
+                    operation_id=op.get("operationId", ""),
+                    request=request,
+                    response=response,
+                )
+            )
+    components = (document.get("components") or {}).get("schemas") or document.get(
+        "definitions"
+    ) or {}
+    schemas = [
+        ContractSchema(
+            name=name,
+            spec=spec,
+            line=_line_of(text, f'"{name}"' if spec.endswith(".json") else f"{name}:"),
+            fields=sorted((body.get("properties") or {}).keys()),
+        )
+        for name, body in components.items()
+        if isinstance(body, dict)
+    ]
+    return operations, schemas
+
+
+def _braced_block(text: str, start: int) -> str:
+    """Text of the ``{...}`` block opening at or after ``start``."""
+    open_at = text.find("{", start)
+    depth = 0
+    for index in range(open_at, len(text)):
+        if text[index] == "{":
+            depth += 1
+        elif text[index] == "}":
+            depth -= 1
+            if depth == 0:
+                return text[open_at + 1 : index]
+    return text[open_at + 1 :]
+
+
+def parse_proto(
+    text: str, spec: str
+) -> tuple[list[ContractOperation], list[ContractSchema]]:
+    """RPCs and messages of a ``.proto`` file."""
+    text = re.sub(r"//[^\n]*", "", text)
+    package = _PROTO_PACKAGE.search(text)
+    prefix = f"{package.group(1)}." if package else ""
+    operations = []
+    for service in _PROTO_SERVICE.finditer(text):
+        body = _braced_block(text, service.start())
+        for rpc in _PROTO_RPC.finditer(body):
+            operations.append(
+                ContractOperation(
+                    kind="rpc",
+                    name=f"{prefix}{service.group(1)}.{rpc.group(1)}",
+                    spec=spec,
+                    line=_line_of(text, f"rpc {rpc.group(1)}"),
+                    rpc=rpc.group(1),
+                    request=rpc.group(2).rsplit(".", 1)[-1],
+                    response=rpc.group(3).rsplit(".", 1)[-1],
+                )
+            )
+    schemas = []
+    for message in _PROTO_MESSAGE.finditer(text):
+        body = _braced_block(text, message.start())
+        # Nested messages are listed on their own; only direct fields count.
+        direct = re.sub(r"\{[^{}]*\}", "", body)
+        schemas.append(
+            ContractSchema(
+                name=message.group(1),
+                spec=spec,
+                line=_line_of(text, f"message {message.group(1)}"),
+                fields=sorted(_PROTO_FIELD.findall(direct)),
+            )
+        )
+    return operations, schemas
+
+
+# ---------------------------------------------------------------------------
+# Analysis
+# ---------------------------------------------------------------------------
+
+
+class ContractAnalyzer:
+    """Finds a project's contracts and checks the code against them."""
+
+    def __init__(self, project_dir: Path | None = None):
+        self.project_dir = Path(project_dir or Path.cwd()).resolve()
+
+    def _files(self, suffixes: set[str]):
+        from .extractor import SKIP_DIRS
+
+        for root, dirs, names in os.walk(self.project_dir):
+            dirs[:] = sorted(d for d in dirs if d not in SKIP_DIRS)
+            for name in sorted(names):
+                if Path(name).suffix in suffixes and not name.endswith(_GENERATED):
+                    yield Path(root) / name
+
+    def contracts(self) -> tuple[list[ContractOperation], list[ContractSchema]]:
+        operations: list[ContractOperation] = []
+        schemas: list[ContractSchema] = []
+        for path in self._files(SPEC_SUFFIXES | {".proto"}):
+            try:
+                if path.stat().st_size > MAX_SPEC_BYTES:
+                    continue
+                text = path.read_text(encoding="utf-8", errors="replace")
+                rel = path.relative_to(self.project_dir).as_posix()
+                if path.suffix == ".proto":
+                    ops, found = parse_proto(text, rel)
+                elif _SPEC_MARKER.search(text[:4096]):
+                    ops, found = parse_openapi(text, rel)
+                else:
+                    continue
+            except (OSError, yaml.YAMLError) as e:
+                logger.warning(f"Could not parse contract {path}: {e}")
+                continue
+            operations.extend(ops)
+            schemas.extend(found)
+        return operations, schemas
+
+    def _rpc_implementations(self, names: set[str]) -> dict[str, list[str]]:
+        """``file:line`` of methods named like an RPC in Python or Go code."""
+        found: dict[str, list[str]] = {}
+        if not names:
+            return found
+        for path in self._files({".py", ".go"}):
+            try:
+                text = path.read_text(encoding="utf-8", errors="replace")
+            except OSError:
+                continue
+            pattern = _PY_RPC_IMPL if path.suffix == ".py" else _GO_RPC_IMPL
+            rel = path.relative_to(self.project_dir).as_posix()
+            for match in pattern.finditer(text):
+                if match.group(1) in names:
+                    line = text.count("\n", 0, match.start()) + 1
+                    found.setdefault(match.group(1), []).append(f"{rel}:{line}")
+        return found
+
+    def analyze(self, graph: KnowledgeGraph) -> ContractReport:
+        """Link contract operations to code and collect drift issues."""
+        operations, schemas = self.contracts()
+        report = ContractReport(operations=operations, schemas=schemas)
+        endpoints = [e for e in graph.entities.values() if e.type == ENDPOINT]
+
+        documented: set[str] = set()
+        covered_services: set[str] = set()
+        for op in (op for op in operations if op.kind == "http"):
+            for endpoint in endpoints:
+                method = endpoint.attributes.get("method", "")
+                if method not in (op.method, "ANY"):
+                    continue
+                if paths_match(op.path, endpoint.attributes.get("path", "")):
+                    op.implemented_by.append(endpoint.id)
+                    documented.add(endpoint.id)
+                    covered_services.add(endpoint.id.split(":")[1])
+            if not op.implemented_by:
+                report.issues.append(
+                    DriftIssue(
+                        UNIMPLEMENTED,
+                        op.name,
+                        f"{op.spec} declares {op.name} but no handler matches",
+                        spec=op.spec,
+                    )
+                )
+
+        for endpoint in endpoints:
+            service = endpoint.id.split(":")[1]
+            if service in covered_services and endpoint.id not in documented:
+                report.issues.append(
+                    DriftIssue(
+                        UNDOCUMENTED,
+                        endpoint.name,
+                        f"{endpoint.name} is served but missing from the contract",
+                        file=endpoint.attributes.get("file", ""),
+                        line=endpoint.attributes.get("line"),
+                    )
+                )
+
+        rpcs = [op for op in operations if op.kind == "rpc"]
+        implementations = self._rpc_implementations({op.rpc for op in rpcs})
+        for op in rpcs:
+            op.implemented_by = implementations.get(op.rpc, [])
+            if not op.implemented_by:
+                report.issues.append(
+                    DriftIssue(
+                        UNIMPLEMENTED,
+                        op.name,
+                        f"{op.spec} declares rpc {op.rpc} but no method implements it",
+                        spec=op.spec,
+                    )
+                )
+
+        report.issues.extend(self._schema_drift(schemas, graph))
+        return report
+
+    @staticmethod
+    def _schema_drift(
+        schemas: list[ContractSchema], graph: KnowledgeGraph
+    ) -> list[DriftIssue]:
+        """Field differences between contract schemas and same-named models."""
+        models: dict[str, list[Entity]] = {}
+        for entity in graph.entities.values():
+            if entity.type == SCHEMA and entity.attributes.get("fields"):
+                models.setdefault(entity.name, []).append(entity)
+        issues = []
+        for schema in schemas:
+            if not schema.fields:
+                continue
+            for model in models.get(schema.name, []):
+                code_fields = set(model.attributes["fields"])
+                file = model.attributes.get("file", "")
+                line = model.attributes.get("line")
+                for name in sorted(set(schema.fields) - code_fields):
+                    issues.append(
+                        DriftIssue(
+                            SCHEMA_MISSING_FIELD,
+                            schema.name,
+                            f"{schema.name}.{name} is in {schema.spec} but not in code",
+                            spec=schema.spec,
+                            file=file,
+                            line=line,
+                        )
+                    )
+                for name in sorted(code_fields - set(schema.fields)):
+                    issues.append(
+                        DriftIssue(
+                            SCHEMA_EXTRA_FIELD,
+                            schema.name,
+                            f"{schema.name}.{name} is in code but not in {schema.spec}",
+                            spec=schema.spec,
+                            file=file,
+                            line=line,
+                        )
+                    )
+        return issues
+
+    def link(self, graph: KnowledgeGraph) -> ContractReport:
+        """Add contract entities and ``implements`` relations to the graph."""
+        report = self.analyze(graph)
+        for op in report.operations:
+            contract_id = f"{CONTRACT}:{op.spec}:{op.name}"
+            attributes = {
+                k: v
+                for k, v in op.to_dict().items()
+                if v and k not in ("name", "implemented_by")
+            }
+            if op.kind == "rpc":
+                attributes["implemented_by"] = op.implemented_by
+            graph.add_entity(
+                Entity(
+                    id=contract_id,
+                    type=CONTRACT,
+                    name=op.name,
+                    attributes=attributes,
+                    files=[op.spec],
+                )
+            )
+            if op.kind == "http":
+                for endpoint_id in op.implemented_by:
+                    graph.add_relation(endpoint_id, contract_id, IMPLEMENTS)
+        return report
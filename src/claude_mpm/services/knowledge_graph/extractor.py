@@ -0,0 +1,527 @@
+"""Extract knowledge graph entities from a codebase and from session outputs.
+
+WHAT: Walks the project and pattern-matches services (directories with a
+package manifest), HTTP endpoints (FastAPI/Flask, Express, Go routers),
+schemas (pydantic/ORM models, dataclasses, TypedDicts, zod schemas, TypeScript
+model interfaces, SQL tables) and owners (CODEOWNERS, falling back to the top
+git committers of each service).
+
+WHY regexes instead of ASTs: the graph has to cover Python, TypeScript and Go
+projects without language toolchains installed. A missed decorator costs one
+node; a parser dependency per language costs every user an install.
+
+Session outputs are folded in by ``update_from_session``: files a session
+wrote or edited are re-extracted, so the graph follows the code agents
+change without a full rebuild.
+"""
+
+from __future__ import annotations
+
+import fnmatch
+import json
+import logging
+import os
+import re
+import subprocess  # nosec B404
+import tomllib
+from pathlib import Path
+
+from .contracts import ContractAnalyzer
+from .graph import (
+    DEFINES,
+    ENDPOINT,
+    EXPOSES,
+    OWNER,
+    OWNS,
+    SCHEMA,
+    SERVICE,
+    USES,
+    Entity,
+    KnowledgeGraph,
+    default_graph_path,
+)
+
+logger = logging.getLogger(__name__)
+
+MANIFESTS = (
+    "pyproject.toml",
+    "setup.py",
+    "package.json",
+    "go.mod",
+    "Cargo.toml",
+    "pom.xml",
+    "Dockerfile",
+)
+SKIP_DIRS = {
+    ".git",
+    ".hg",
+    ".claude",
+    ".claude-mpm",
+    ".venv",
+    "venv",
+    "env",
+    "node_modules",
+    "__pycache__",
+    "dist",
+    "build",
+    ".next",
+    ".tox",
+    ".mypy_cache",
+    ".pytest_cache",
+    "site-packages",
+    "vendor",
+}
+SOURCE_SUFFIXES = {".py", ".js", ".jsx", ".mjs", ".ts", ".tsx", ".go", ".sql"}
+MAX_FILE_BYTES = 512 * 1024
+EDIT_TOOLS = {"Edit", "MultiEdit", "Write", "NotebookEdit"}
+
+_PY_ROUTE = re.compile(
+    r"@\w+(?:\.\w+)*\.(get|post|put|patch|delete|route|api_route)\(\s*[rf]?"
+    r"[\"']([^\"']*)[\"']([^\n]*)"
+)
+_PY_METHODS = re.compile(r"methods\s*=\s*[\[(]([^\])]*)")
+_PY_DEF = re.compile(
+    r"^\s*(?:async\s+)?def\s+(\w+)\s*(\(.*?\)(?:\s*->[^:]*)?):\s*$",
+    re.MULTILINE | re.DOTALL,
+)
+_JS_ROUTE = re.compile(
+    r"\b(?:app|router|server|api|routes)\.(get|post|put|patch|delete|all)"
+    r"\(\s*[\"'`]([^\"'`]+)"
+)
+_GO_ROUTE = re.compile(
+    r"\.(GET|POST|PUT|PATCH|DELETE|HandleFunc|Handle)\(\s*\"([^\"]+)\""
+)
+
+_PY_CLASS = re.compile(r"^class\s+(\w+)\s*(?:\(([^)]*)\))?\s*:", re.MULTILINE)
+_PY_FIELD = re.compile(r"^\s+(\w+)\s*:\s*[^=\n]")
+_PY_SCHEMA_BASES = {
+    "BaseModel": "pydantic",
+    "SQLModel": "orm",
+    "Base": "orm",
+    "DeclarativeBase": "orm",
+    "Model": "orm",
+    "Schema": "schema",
+    "TypedDict": "typeddict",
+}
+_TS_INTERFACE = re.compile(r"^export\s+(?:interface|type)\s+(\w+)", re.MULTILINE)
+_TS_ZOD = re.compile(r"^export\s+const\s+(\w+)\s*=\s*z\.object\(", re.MULTILINE)
+_GO_STRUCT = re.compile(r"^type\s+(\w+)\s+struct\s*\{", re.MULTILINE)
+_SQL_TABLE = re.compile(
+    r"create\s+table\s+(?:if\s+not\s+exists\s+)?[`\"\[]?(?:\w+\.)?(\w+)",
+    re.IGNORECASE,
+)
+_MODEL_PATH = re.compile(r"(^|/)(models?|schemas?|types|entities|dto)(/|\.|$)")
+
+CODEOWNERS_LOCATIONS = ("CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS")
+GIT_OWNER_LIMIT = 2
+
+
+def _line_of(text: str, offset: int) -> int:
+    return text.count("\n", 0, offset) + 1
+
+
+def _manifest_name(directory: Path, manifest: str) -> str | None:
+    """Project name declared in a manifest, if it is cheap to read."""
+    path = directory / manifest
+    try:
+        if manifest == "package.json":
+            return json.loads(path.read_text(encoding="utf-8")).get("name")
+        if manifest == "pyproject.toml":
+            data = tomllib.loads(path.read_text(encoding="utf-8"))
+            return data.get("project", {}).get("name") or (
+                data.get("tool", {}).get("poetry", {}).get("name")
+            )
+        if manifest == "go.mod":
+            first = path.read_text(encoding="utf-8").splitlines()[0]
+            return first.split()[-1].rsplit("/", 1)[-1]
+    except (OSError, ValueError, IndexError, AttributeError):
+        pass
+    return None
+
+
+class KnowledgeGraphBuilder:
+    """Builds and incrementally updates a project's knowledge graph."""
+
+    def __init__(self, project_dir: Path | None = None, use_git: bool = True):
+        self.project_dir = Path(project_dir or Path.cwd()).resolve()
+        self.use_git = use_git
+
+    # ------------------------------------------------------------------
+    # Entry points
+    # ------------------------------------------------------------------
+
+    def build(self) -> KnowledgeGraph:
+        """Extract a fresh graph from the whole project."""
+        graph = KnowledgeGraph()
+        files = []
+        for root, dirs, names in os.walk(self.project_dir):
+            dirs[:] = sorted(
+                d for d in dirs if d not in SKIP_DIRS and not d.endswith(".egg-info")
+            )
+            directory = Path(root)
+            manifests = [m for m in MANIFESTS if m in names]
+            if manifests:
+                self._add_service(graph, directory, manifests)
+            files.extend(
+                directory / name
+                for name in sorted(names)
+                if Path(name).suffix in SOURCE_SUFFIXES
+            )
+        if f"{SERVICE}:." not in graph.entities:
+            # The project root anchors files outside any manifest directory.
+            self._add_service(graph, self.project_dir, [])
+        # Schemas first, so endpoints anywhere can link to the schemas they use.
+        for path in files:
+            self._extract_file(graph, path, kinds=(SCHEMA,))
+        for path in files:
+            self._extract_file(graph, path, kinds=(ENDPOINT,))
+        self._link_owners(graph)
+        ContractAnalyzer(self.project_dir).link(graph)
+        return graph
+
+    def update_from_session(self, graph: KnowledgeGraph, transcript: Path) -> list[str]:
+        """Re-extract the project files a session wrote or edited.
+
+        Returns:
+            Project-relative paths of the files that were re-extracted
+        """
+        touched = sorted(self._session_files(transcript))
+        graph.remove_files(set(touched))
+        if f"{SERVICE}:." not in graph.entities:
+            self._add_service(graph, self.project_dir, [])
+        for rel in touched:
+            path = self.project_dir / rel
+            if path.is_file():
+                self._extract_file(graph, path, session_id=transcript.stem)
+        if touched:
+            ContractAnalyzer(self.project_dir).link(graph)
+        return touched
+
+    # ------------------------------------------------------------------
+    # Services and owners
+    # ------------------------------------------------------------------
+
+    def _relative(self, path: Path) -> str:
+        return path.relative_to(self.project_dir).as_posix()
+
+    def _add_service(
+        self, graph: KnowledgeGraph, directory: Path, manifests: list[str]
+    ) -> None:
+        rel = self._relative(directory)
+        rel = "" if rel == "." else rel
+        name = next(
+            (n for n in (_manifest_name(directory, m) for m in manifests) if n),
+            directory.name,
+        )
+        graph.add_entity(
+            Entity(
+                id=f"{SERVICE}:{rel or '.'}",
+                type=SERVICE,
+                name=name,
+                attributes={"path": rel or ".", "manifests": manifests},
+                files=[f"{rel}/{m}" if rel else m for m in manifests],
+            )
+        )
+
+    def _service_for(self, graph: KnowledgeGraph, rel: str) -> str:
+        """Id of the innermost service containing ``rel``."""
+        best, best_len = f"{SERVICE}:.", 0
+        for entity in graph.entities.values():
+            path = entity.attributes.get("path", ".")
+            if entity.type != SERVICE or path == ".":
+                continue
+            if rel.startswith(path + "/") and len(path) > best_len:
+                best, best_len = entity.id, len(path)
+        return best
+
+    def _codeowners(self) -> list[tuple[str, list[str]]]:
+        for location in CODEOWNERS_LOCATIONS:
+            path = self.project_dir / location
+            if not path.is_file():
+                continue
+            rules = []
+            for line in path.read_text(encoding="utf-8").splitlines():
+                parts = line.split("#", 1)[0].split()
+                if len(parts) >= 2:
+                    rules.append((parts[0], parts[1:]))
+            return rules
+        return []
+
+    @staticmethod
+    def _pattern_matches(pattern: str, path: str) -> bool:
+        pattern = pattern.strip("/").removesuffix("/**").removesuffix("/*")
+        if pattern in ("*", "**", ""):
+            return True
+        if path == ".":
+            return False
+        return (
+            path == pattern
+            or path.startswith(pattern + "/")
+            or fnmatch.fnmatch(path, pattern)
+        )
+
+    def _git_owners(self, path: str) -> list[str]:
+        try:
+            result = subprocess.run(  # nosec B603 B607
+                ["git", "shortlog", "-sne", "HEAD", "--", path],
+                cwd=self.project_dir,
+                capture_output=True,
+                text=True,
+                timeout=10,
+                check=False,
+            )
+        except (OSError, subprocess.SubprocessError):
+            return []
+        if result.returncode != 0:
+            return []
+        owners = []
+        for line in result.stdout.splitlines()[:GIT_OWNER_LIMIT]:
+            match = re.search(r"<([^>]+)>", line)
+            if match:
+                owners.append(match.group(1))
+        return owners
+
+    def _link_owners(self, graph: KnowledgeGraph) -> None:
+        rules = self._codeowners()
+        services = [e for e in graph.entities.values() if e.type == SERVICE]
+        for service in services:
+            path = service.attributes["path"]
+            owners, source = [], "CODEOWNERS"
+            # Last matching rule wins, as in GitHub's CODEOWNERS semantics.
+            for pattern, rule_owners in rules:
+                if self._pattern_matches(pattern, path):
+                    owners = rule_owners
+            if not rules and self.use_git:
+                owners, source = self._git_owners(path), "git"
+            for owner in owners:
+                owner_id = f"{OWNER}:{owner}"
+                graph.add_entity(
+                    Entity(
+                        id=owner_id,
+                        type=OWNER,
+                        name=owner,
+                        attributes={"source": source},
+                    )
+                )
+                graph.add_relation(owner_id, service.id, OWNS)
+
+    # ------------------------------------------------------------------
+    # Endpoints and schemas
+    # ------------------------------------------------------------------
+
+    def _extract_file(
+        self,
+        graph: KnowledgeGraph,
+        path: Path,
+        session_id: str | None = None,
+        kinds: tuple[str, ...] = (SCHEMA, ENDPOINT),
+    ) -> None:
+        try:
+            if path.stat().st_size > MAX_FILE_BYTES:
+                return
+            text = path.read_text(encoding="utf-8", errors="replace")
+        except OSError as e:
+            logger.debug(f"Skipping unreadable file {path}: {e}")
+            return
+        rel = self._relative(path)
+        service = self._service_for(graph, rel)
+        extra = {"last_session": session_id} if session_id else {}
+
+        schemas = self._schemas(path.suffix, rel, text) if SCHEMA in kinds else []
+        for name, line, attrs in schemas:
+            schema_id = f"{SCHEMA}:{service.split(':', 1)[1]}:{name}"
+            graph.add_entity(
+                Entity(
+                    id=schema_id,
+                    type=SCHEMA,
+                    name=name,
+                    attributes={"file": rel, "line": line, **attrs, **extra},
+                    files=[rel],
+                )
+            )
+            graph.add_relation(service, schema_id, DEFINES)
+
+        endpoints = self._endpoints(path.suffix, text) if ENDPOINT in kinds else []
+        for method, route, line, handler, signature in endpoints:
+            endpoint_id = f"{ENDPOINT}:{service.split(':', 1)[1]}:{method} {route}"
+            attrs = {"method": method, "path": route, "file": rel, "line": line}
+            if handler:
+                attrs["handler"] = handler
+            graph.add_entity(
+                Entity(
+                    id=endpoint_id,
+                    type=ENDPOINT,
+                    name=f"{method} {route}",
+                    attributes={**attrs, **extra},
+                    files=[rel],
+                )
+            )
+            graph.add_relation(service, endpoint_id, EXPOSES)
+            for schema in graph.entities.values():
+                if schema.type == SCHEMA and re.search(
+                    rf"\b{re.escape(schema.name)}\b", signature
+                ):
+                    graph.add_relation(endpoint_id, schema.id, USES)
+
+    def _endpoints(
+        self, suffix: str, text: str
+    ) -> list[tuple[str, str, int, str, str]]:
+        """(method, path, line, handler, handler signature) per route."""
+        found = []
+        if suffix == ".py":
+            for match in _PY_ROUTE.finditer(text):
+                verb, route, rest = match.groups()
+                if verb in ("route", "api_route"):
+                    methods = _PY_METHODS.search(rest)
+                    verbs = (
+                        re.findall(r"\w+", methods.group(1)) if methods else ["GET"]
+                    )
+                else:
+                    verbs = [verb]
+                handler = _PY_DEF.search(text, match.end())
+                name, signature = handler.groups() if handler else ("", "")
+                for method in verbs:
+                    found.append(
+                        (
+                            method.upper(),
+                            route or "/",
+                            _line_of(text, match.start()),
+                            name,
+                            signature,
+                        )
+                    )
+        elif suffix in (".js", ".jsx", ".mjs", ".ts", ".tsx"):
+            for match in _JS_ROUTE.finditer(text):
+                end = text.find("\n", match.end())
+                signature = text[match.end() : end if end >= 0 else None]
+                found.append(
+                    (
+                        match.group(1).upper(),
+                        match.group(2),
+                        _line_of(text, match.start()),
+                        "",
+                        signature,
+                    )
+                )
+        elif suffix == ".go":
+            for match in _GO_ROUTE.finditer(text):
+                verb = match.group(1)
+                method = "ANY" if verb.startswith("Handle") else verb
+                found.append(
+                    (method, match.group(2), _line_of(text, match.start()), "", "")
+                )
+        return found
+
+    def _schemas(
+        self, suffix: str, rel: str, text: str
+    ) -> list[tuple[str, int, dict[str, object]]]:
+        """(name, line, attributes) per schema definition."""
+        found: list[tuple[str, int, dict[str, object]]] = []
+        if suffix == ".py":
+            for match in _PY_CLASS.finditer(text):
+                name, bases = match.group(1), match.group(2) or ""
+                base_names = {b.strip().rsplit(".", 1)[-1] for b in bases.split(",")}
+                kind = next(
+                    (_PY_SCHEMA_BASES[b] for b in base_names if b in _PY_SCHEMA_BASES),
+                    None,
+                )
+                preceding = text[max(0, match.start() - 80) : match.start()]
+                if kind is None and "@dataclass" in preceding.rsplit("\n\n", 1)[-1]:
+                    kind = "dataclass"
+                if kind is None:
+                    continue
+                attrs = {"kind": kind, "fields": self._py_fields(text, match.end())}
+                found.append((name, _line_of(text, match.start()), attrs))
+        elif suffix in (".ts", ".tsx", ".js", ".mjs"):
+            for match in _TS_ZOD.finditer(text):
+                found.append(
+                    (match.group(1), _line_of(text, match.start()), {"kind": "zod"})
+                )
+            if _MODEL_PATH.search(rel):
+                for match in _TS_INTERFACE.finditer(text):
+                    found.append(
+                        (
+                            match.group(1),
+                            _line_of(text, match.start()),
+                            {"kind": "typescript"},
+                        )
+                    )
+        elif suffix == ".go" and _MODEL_PATH.search(rel):
+            for match in _GO_STRUCT.finditer(text):
+                found.append(
+                    (match.group(1), _line_of(text, match.start()), {"kind": "struct"})
+                )
+        elif suffix == ".sql":
+            for match in _SQL_TABLE.finditer(text):
+                found.append(
+                    (match.group(1), _line_of(text, match.start()), {"kind": "table"})
+                )
+        return found
+
+    @staticmethod
+    def _py_fields(text: str, body_start: int, limit: int = 30) -> list[str]:
+        """Annotated attribute names in the class body starting at body_start."""
+        fields = []
+        for line in text[body_start:].splitlines()[1:]:
+            if line.strip() and not line[0].isspace():
+                break
+            match = _PY_FIELD.match(line)
+            if match and not line.strip().startswith(("def ", "async ")):
+                fields.append(match.group(1))
+                if len(fields) >= limit:
+                    break
+        return fields
+
+    # ------------------------------------------------------------------
+    # Session outputs
+    # ------------------------------------------------------------------
+
+    def _session_files(self, transcript: Path) -> set[str]:
+        """Project files written or edited by tool calls in a transcript."""
+        touched: set[str] = set()
+        try:
+            lines = transcript.read_text(encoding="utf-8", errors="replace")
+        except OSError as e:
+            logger.warning(f"Could not read transcript {transcript}: {e}")
+            return touched
+        for raw in lines.splitlines():
+            try:
+                entry = json.loads(raw)
+            except json.JSONDecodeError:
+                continue
+            content = (entry.get("message") or {}).get("content")
+            if entry.get("type") != "assistant" or not isinstance(content, list):
+                continue
+            for block in content:
+                if block.get("type") != "tool_use" or block.get("name") not in (
+                    EDIT_TOOLS
+                ):
+                    continue
+                tool_input = block.get("input") or {}
+                file_path = tool_input.get("file_path") or tool_input.get(
+                    "notebook_path"
+                )
+                if not file_path:
+                    continue
+                path = Path(file_path)
+                if not path.is_absolute():
+                    path = self.project_dir / path
+                try:
+                    rel = self._relative(path.resolve())
+                except ValueError:
+                    continue
+                if Path(rel).suffix in SOURCE_SUFFIXES:
+                    touched.add(rel)
+        return touched
+
+
+def load_or_build(
+    project_dir: Path | None = None, rebuild: bool = False
+) -> KnowledgeGraph:
+    """Load the project's saved graph, building and saving it when missing."""
+    path = default_graph_path(project_dir)
+    if not rebuild and path.exists():
+        return KnowledgeGraph.load(path)
+    graph = KnowledgeGraphBuilder(project_dir).build()
+    graph.save(path)
+    return graph
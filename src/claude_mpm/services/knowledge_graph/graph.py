@@ -0,0 +1,241 @@
+"""In-memory knowledge graph of project entities with JSON persistence.
+
+WHAT: Entities (services, endpoints, schemas, owners, API contracts)
+connected by typed relations, stored in ``.claude-mpm/knowledge_graph.json``.
+
+WHY: Agents repeatedly grep the codebase to answer "which service exposes
+/users?" or "who owns billing?". A small persisted graph answers those
+questions in one MCP call and gives the dashboard something to browse.
+
+DESIGN DECISIONS:
+- Entity ids are stable strings (``service:api``, ``schema:api:User``) so
+  re-extraction merges into existing entities instead of duplicating them
+- Every entity records the files it was extracted from, which lets a session
+  update replace exactly the entities of the files it touched
+- Plain JSON, no graph database: project graphs are hundreds of nodes
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+SERVICE = "service"
+ENDPOINT = "endpoint"
+SCHEMA = "schema"
+OWNER = "owner"
+CONTRACT = "contract"
+ENTITY_TYPES = (SERVICE, ENDPOINT, SCHEMA, OWNER, CONTRACT)
+
+EXPOSES = "exposes"
+DEFINES = "defines"
+OWNS = "owns"
+USES = "uses"
+IMPLEMENTS = "implements"
+
+
+def default_graph_path(project_dir: Path | None = None) -> Path:
+    """Location of the project's knowledge graph file."""
+    return Path(project_dir or Path.cwd()) / ".claude-mpm" / "knowledge_graph.json"
+
+
+@dataclass
+class Entity:
+    """A node in the knowledge graph."""
+
+    id: str
+    type: str
+    name: str
+    attributes: dict[str, Any] = field(default_factory=dict)
+    files: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass(frozen=True)
+class Relation:
+    """A directed, typed edge between two entities."""
+
+    source: str
+    target: str
+    type: str
+
+    def to_dict(self) -> dict[str, str]:
+        return asdict(self)
+
+
+class KnowledgeGraph:
+    """Entities and relations with merge, search and neighbourhood queries."""
+
+    def __init__(self) -> None:
+        self.entities: dict[str, Entity] = {}
+        self.relations: set[Relation] = set()
+        self.updated_at: str | None = None
+
+    def __len__(self) -> int:
+        return len(self.entities)
+
+    # ------------------------------------------------------------------
+    # Mutation
+    # ------------------------------------------------------------------
+
+    def add_entity(self, entity: Entity) -> Entity:
+        """Add an entity, merging attributes and files into an existing one."""
+        existing = self.entities.get(entity.id)
+        if existing is None:
+            self.entities[entity.id] = entity
+            return entity
+        existing.attributes.update(entity.attributes)
+        existing.files.extend(f for f in entity.files if f not in existing.files)
+        return existing
+
+    def add_relation(self, source: str, target: str, type: str) -> None:
+        self.relations.add(Relation(source, target, type))
+
+    def remove_files(self, files: set[str]) -> int:
+        """Drop entities extracted only from ``files`` and their relations.
+
+        Services and owners are kept: they are anchored by manifests and
+        CODEOWNERS rather than by individual source files.
+
+        Returns:
+            Number of entities removed
+        """
+        removed = set()
+        for entity in list(self.entities.values()):
+            if not entity.files or entity.type in (SERVICE, OWNER):
+                continue
+            entity.files = [f for f in entity.files if f not in files]
+            if not entity.files:
+                removed.add(entity.id)
+                del self.entities[entity.id]
+        self.relations = {
+            r
+            for r in self.relations
+            if r.source not in removed and r.target not in removed
+        }
+        return len(removed)
+
+    # ------------------------------------------------------------------
+    # Queries
+    # ------------------------------------------------------------------
+
+    def get(self, entity_id: str) -> Entity | None:
+        return self.entities.get(entity_id)
+
+    def search(
+        self, text: str = "", type: str | None = None, limit: int = 50
+    ) -> list[Entity]:
+        """Entities whose id, name or attributes contain ``text``.
+
+        Name matches rank before attribute-only matches.
+        """
+        needle = text.lower()
+        ranked: list[tuple[int, str, Entity]] = []
+        for entity in self.entities.values():
+            if type and entity.type != type:
+                continue
+            if not needle:
+                rank = 0
+            elif needle in entity.name.lower():
+                rank = 0 if entity.name.lower() == needle else 1
+            elif needle in entity.id.lower():
+                rank = 2
+            elif needle in json.dumps(entity.attributes).lower():
+                rank = 3
+            else:
+                continue
+            ranked.append((rank, entity.id, entity))
+        ranked.sort(key=lambda item: item[:2])
+        return [entity for _, _, entity in ranked[:limit]]
+
+    def neighbors(self, entity_id: str) -> list[dict[str, Any]]:
+        """Relations touching ``entity_id`` with the entity on the other end."""
+        found = []
+        for relation in sorted(
+            self.relations, key=lambda r: (r.type, r.source, r.target)
+        ):
+            if relation.source == entity_id:
+                direction, other = "out", relation.target
+            elif relation.target == entity_id:
+                direction, other = "in", relation.source
+            else:
+                continue
+            entity = self.entities.get(other)
+            found.append(
+                {
+                    "relation": relation.type,
+                    "direction": direction,
+                    "entity": entity.to_dict() if entity else {"id": other},
+                }
+            )
+        return found
+
+    def describe(self, entity_id: str) -> dict[str, Any] | None:
+        """An entity with its relations, as returned to agents."""
+        entity = self.get(entity_id)
+        if entity is None:
+            return None
+        return {**entity.to_dict(), "relations": self.neighbors(entity_id)}
+
+    def summary(self) -> dict[str, Any]:
+        counts = dict.fromkeys(ENTITY_TYPES, 0)
+        for entity in self.entities.values():
+            counts[entity.type] = counts.get(entity.type, 0) + 1
+        return {
+            "entities": len(self.entities),
+            "relations": len(self.relations),
+            "by_type": counts,
+            "updated_at": self.updated_at,
+        }
+
+    # ------------------------------------------------------------------
+    # Persistence
+    # ------------------------------------------------------------------
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "updated_at": self.updated_at,
+            "entities": [
+                e.to_dict() for e in sorted(self.entities.values(), key=lambda e: e.id)
+            ],
+            "relations": [
+                r.to_dict()
+                for r in sorted(
+                    self.relations, key=lambda r: (r.source, r.target, r.type)
+                )
+            ],
+        }
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> KnowledgeGraph:
+        graph = cls()
+        graph.updated_at = data.get("updated_at")
+        for item in data.get("entities", []):
+            graph.add_entity(Entity(**item))
+        for item in data.get("relations", []):
+            graph.add_relation(item["source"], item["target"], item["type"])
+        return graph
+
+    def save(self, path: Path) -> None:
+        self.updated_at = datetime.now(UTC).isoformat()
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(json.dumps(self.to_dict(), indent=2), encoding="utf-8")
+
+    @classmethod
+    def load(cls, path: Path) -> KnowledgeGraph:
+        """Load a graph, returning an empty one if the file is missing or bad."""
+        if not path.exists():
+            return cls()
+        try:
+            return cls.from_dict(json.loads(path.read_text(encoding="utf-8")))
+        except (OSError, ValueError, TypeError, KeyError) as e:
+            logger.warning(f"Could not read knowledge graph {path}: {e}")
+            return cls()
@@ -0,0 +1,544 @@
+"""Batch agent runs as Kubernetes Jobs.
+
+WHAT: Schedules non-interactive agent runs (a prompt, optionally against a
+git repository) as Kubernetes Jobs, one Job per run, with per-run CPU and
+memory limits. A batch is driven from the machine that submitted it:
+``claude-mpm batch run runs.jsonl`` keeps at most ``parallelism`` Jobs
+active, and as Jobs finish their logs are parsed into an ``AgentResult``,
+saved under ``.claude-mpm/batches/<batch>/`` and appended to the project's
+event log (``batch.run_completed`` / ``batch.run_failed``).
+
+WHY: Analysing hundreds of repositories one headless session at a time on a
+laptop takes days; a cluster runs them side by side, with each run isolated
+in its own pod.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    kubernetes:
+      namespace: default
+      context: null                  # kubectl context, null for the current one
+      image: claude-mpm:latest       # built from docker/Dockerfile
+      api_key_secret: claude-mpm     # Secret with an ANTHROPIC_API_KEY key
+      service_account: null
+      cpu: "1"                       # default per-run request and limit
+      memory: 2Gi
+      parallelism: 10                # Jobs active at once per batch
+      active_deadline_seconds: 3600
+      ttl_seconds_after_finished: 86400
+
+Runs file (JSON Lines, or a JSON list), only ``prompt`` is required:
+
+    {"id": "api", "prompt": "Summarise the auth flow", "repo":
+     "https://github.com/org/api.git", "ref": "main", "model": "sonnet",
+     "max_turns": 20, "cpu": "2", "memory": "4Gi"}
+
+DESIGN DECISIONS:
+- Talks to the cluster through ``kubectl`` so there is no client library to
+  install and the user's kubeconfig, contexts and auth plugins just work
+- Batch state lives in a JSON file per batch, so ``batch status`` and
+  ``batch resume`` pick up where an interrupted driver left off
+- Each pod clones its repository into an emptyDir and runs
+  ``claude-mpm run --headless`` there; the workspace is trusted, since the
+  pod is the sandbox
+- Results are read back from the Job's logs (the headless ``result``
+  message), which needs no shared storage between pods and the driver
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import re
+import subprocess
+import time
+from collections.abc import Callable, Iterable
+from dataclasses import asdict, dataclass, fields
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from .agents.agent_runtime import AgentResult
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "kubernetes"
+
+EVENT_COMPLETED = "batch.run_completed"
+EVENT_FAILED = "batch.run_failed"
+
+PENDING = "pending"
+ACTIVE = "active"
+SUCCEEDED = "succeeded"
+FAILED = "failed"
+FINISHED = (SUCCEEDED, FAILED)
+
+BATCH_LABEL = "claude-mpm/batch"
+RUN_LABEL = "claude-mpm/run"
+WORKSPACE = "/workspace"
+# Batch ids are used in label values and Job names (63 characters at most)
+MAX_BATCH_ID = 40
+
+
+class KubectlError(RuntimeError):
+    """kubectl exited non-zero."""
+
+
+def default_state_dir() -> Path:
+    return Path.cwd() / ".claude-mpm" / "batches"
+
+
+def _now() -> str:
+    return datetime.now(UTC).isoformat()
+
+
+def _slug(value: str) -> str:
+    return re.sub(r"[^a-z0-9-]+", "-", value.lower()).strip("-")
+
+
+@dataclass
+class KubernetesConfig:
+    """Where and how batch Jobs are scheduled."""
+
+    namespace: str = "default"
+    context: str | None = None
+    image: str = "claude-mpm:latest"
+    api_key_secret: str = "claude-mpm"
+    service_account: str | None = None
+    cpu: str = "1"
+    memory: str = "2Gi"
+    parallelism: int = 10
+    active_deadline_seconds: int = 3600
+    ttl_seconds_after_finished: int = 86400
+
+    @classmethod
+    def load(cls, config: Any = None) -> KubernetesConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return cls.from_dict(section)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> KubernetesConfig:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in data.items() if k in known and v is not None})
+
+
+@dataclass
+class BatchRun:
+    """One agent run in a batch and what became of it."""
+
+    id: str
+    prompt: str
+    repo: str | None = None
+    ref: str | None = None
+    model: str | None = None
+    max_turns: int | None = None
+    cpu: str | None = None
+    memory: str | None = None
+    status: str = PENDING
+    job: str | None = None
+    submitted_at: str | None = None
+    finished_at: str | None = None
+    detail: str | None = None
+    result: dict[str, Any] | None = None
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> BatchRun:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in data.items() if k in known})
+
+
+def load_runs(path: Path) -> list[BatchRun]:
+    """Read runs from a JSON Lines file or a JSON list.
+
+    Raises:
+        ValueError: If an entry has no prompt or two entries share an id.
+    """
+    text = Path(path).read_text(encoding="utf-8")
+    stripped = text.lstrip()
+    if stripped.startswith("["):
+        entries = json.loads(stripped)
+    else:
+        entries = [json.loads(line) for line in text.splitlines() if line.strip()]
+    runs = []
+    for index, entry in enumerate(entries, start=1):
+        if not entry.get("prompt"):
+            raise ValueError(f"Run {index} in {path} has no prompt")
+        entry = {**entry, "id": str(entry.get("id") or f"run-{index}")}
+        runs.append(BatchRun.from_dict(entry))
+    ids = [run.id for run in runs]
+    duplicates = sorted({i for i in ids if ids.count(i) > 1})
+    if duplicates:
+        raise ValueError(f"Duplicate run ids in {path}: {', '.join(duplicates)}")
+    return runs
+
+
+def parse_result(log: str) -> AgentResult:
+    """Extract the headless ``result`` message from a run's output."""
+    for line in reversed(log.splitlines()):
+        line = line.strip()
+        if not line.startswith("{"):
+            continue
+        try:
+            message = json.loads(line)
+        except json.JSONDecodeError:
+            continue
+        if message.get("type") != "result":
+            continue
+        return AgentResult(
+            text=str(message.get("result") or ""),
+            session_id=message.get("session_id"),
+            cost_usd=message.get("total_cost_usd"),
+            num_turns=message.get("num_turns"),
+            duration_ms=message.get("duration_ms"),
+            is_error=bool(message.get("is_error"))
+            or message.get("subtype", "success") != "success",
+        )
+    return AgentResult(text=log[-2000:], is_error=True)
+
+
+class KubernetesBatch:
+    """A batch of runs and the Kubernetes Jobs executing them."""
+
+    def __init__(
+        self,
+        batch_id: str,
+        runs: list[BatchRun],
+        config: KubernetesConfig | None = None,
+        state_dir: Path | None = None,
+        kubectl: Callable[..., subprocess.CompletedProcess] | None = None,
+        event_log: Any = None,
+        created_at: str | None = None,
+    ):
+        self.batch_id = batch_id
+        self.runs = runs
+        self.config = config or KubernetesConfig.load()
+        self.state_dir = Path(state_dir or default_state_dir())
+        self.created_at = created_at or _now()
+        self._kubectl_runner = kubectl or subprocess.run
+        self._event_log = event_log
+
+    # ------------------------------------------------------------------
+    # State
+    # ------------------------------------------------------------------
+
+    @classmethod
+    def create(
+        cls, runs: list[BatchRun], batch_id: str | None = None, **kwargs: Any
+    ) -> KubernetesBatch:
+        batch_id = _slug(batch_id or datetime.now(UTC).strftime("%Y%m%d-%H%M%S"))
+        batch_id = batch_id[:MAX_BATCH_ID].strip("-")
+        if not batch_id:
+            raise ValueError("Batch id must contain letters or digits")
+        batch = cls(batch_id, runs, **kwargs)
+        if batch.path.exists():
+            raise FileExistsError(f"Batch already exists: {batch_id}")
+        batch.save()
+        return batch
+
+    @classmethod
+    def open(
+        cls, batch_id: str, state_dir: Path | None = None, **kwargs: Any
+    ) -> KubernetesBatch:
+        path = Path(state_dir or default_state_dir()) / f"{batch_id}.json"
+        if not path.is_file():
+            raise FileNotFoundError(f"No batch named {batch_id}")
+        data = json.loads(path.read_text(encoding="utf-8"))
+        return cls(
+            data["batch_id"],
+            [BatchRun.from_dict(run) for run in data.get("runs", [])],
+            config=KubernetesConfig.from_dict(data.get("config", {})),
+            state_dir=path.parent,
+            created_at=data.get("created_at"),
+            **kwargs,
+        )
+
+    @staticmethod
+    def list_ids(state_dir: Path | None = None) -> list[str]:
+        directory = Path(state_dir or default_state_dir())
+        if not directory.is_dir():
+            return []
+        return sorted(p.stem for p in directory.glob("*.json"))
+
+    @property
+    def path(self) -> Path:
+        return self.state_dir / f"{self.batch_id}.json"
+
+    @property
+    def logs_dir(self) -> Path:
+        return self.state_dir / self.batch_id
+
+    def save(self) -> None:
+        self.state_dir.mkdir(parents=True, exist_ok=True)
+        data = {
+            "batch_id": self.batch_id,
+            "created_at": self.created_at,
+            "config": asdict(self.config),
+            "runs": [asdict(run) for run in self.runs],
+        }
+        tmp = self.path.with_suffix(".tmp")
+        tmp.write_text(json.dumps(data, indent=2), encoding="utf-8")
+        tmp.replace(self.path)
+
+    def summary(self) -> dict[str, int]:
+        counts = dict.fromkeys((PENDING, ACTIVE, SUCCEEDED, FAILED), 0)
+        for run in self.runs:
+            counts[run.status] = counts.get(run.status, 0) + 1
+        return counts
+
+    @property
+    def done(self) -> bool:
+        return all(run.status in FINISHED for run in self.runs)
+
+    # ------------------------------------------------------------------
+    # Manifests
+    # ------------------------------------------------------------------
+
+    def job_name(self, run: BatchRun) -> str:
+        digest = hashlib.sha1(
+            f"{self.batch_id}/{run.id}".encode(), usedforsecurity=False
+        ).hexdigest()[:8]
+        return f"mpm-{_slug(f'{self.batch_id}-{run.id}')[:49].strip('-')}-{digest}"
+
+    def manifest(self, run: BatchRun) -> dict[str, Any]:
+        """The Job for *run*, as a dict ready for ``kubectl apply``."""
+        config = self.config
+        labels = {
+            "app.kubernetes.io/managed-by": "claude-mpm",
+            BATCH_LABEL: self.batch_id,
+            RUN_LABEL: _slug(run.id)[:63].strip("-") or "run",
+        }
+        resources = {
+            "cpu": run.cpu or config.cpu,
+            "memory": run.memory or config.memory,
+        }
+        volume_mounts = [{"name": "workspace", "mountPath": WORKSPACE}]
+        command = ["claude-mpm", "run", "--headless", "-i", run.prompt]
+        if run.max_turns:
+            command += ["--max-turns", str(run.max_turns)]
+        if run.model:
+            command += ["--model", run.model]
+
+        pod_spec: dict[str, Any] = {
+            "restartPolicy": "Never",
+            "volumes": [{"name": "workspace", "emptyDir": {}}],
+            "containers": [
+                {
+                    "name": "agent",
+                    "image": config.image,
+                    "command": command,
+                    "workingDir": f"{WORKSPACE}/repo" if run.repo else WORKSPACE,
+                    "env": [
+                        {
+                            "name": "ANTHROPIC_API_KEY",
+                            "valueFrom": {
+                                "secretKeyRef": {
+                                    "name": config.api_key_secret,
+                                    "key": "ANTHROPIC_API_KEY",
+                                }
+                            },
+                        },
+                        {"name": "CLAUDE_MPM_TRUST_WORKSPACE", "value": "1"},
+                        {"name": "CLAUDE_MPM_BATCH_ID", "value": self.batch_id},
+                        {"name": "CLAUDE_MPM_BATCH_RUN", "value": run.id},
+                    ],
+                    "resources": {"requests": resources, "limits": resources},
+                    "volumeMounts": volume_mounts,
+                }
+            ],
+        }
+        if run.repo:
+            clone = ["git", "clone", "--depth", "1"]
+            if run.ref:
+                clone += ["--branch", run.ref]
+            pod_spec["initContainers"] = [
+                {
+                    "name": "clone",
+                    "image": config.image,
+                    "command": [*clone, run.repo, f"{WORKSPACE}/repo"],
+                    "volumeMounts": volume_mounts,
+                }
+            ]
+        if config.service_account:
+            pod_spec["serviceAccountName"] = config.service_account
+
+        return {
+            "apiVersion": "batch/v1",
+            "kind": "Job",
+            "metadata": {
+                "name": self.job_name(run),
+                "namespace": config.namespace,
+                "labels": labels,
+            },
+            "spec": {
+                "backoffLimit": 0,
+                "activeDeadlineSeconds": config.active_deadline_seconds,
+                "ttlSecondsAfterFinished": config.ttl_seconds_after_finished,
+                "template": {"metadata": {"labels": labels}, "spec": pod_spec},
+            },
+        }
+
+    # ------------------------------------------------------------------
+    # Cluster operations
+    # ------------------------------------------------------------------
+
+    def _kubectl(self, *args: str, input: str | None = None) -> str:
+        command = ["kubectl"]
+        if self.config.context:
+            command += ["--context", self.config.context]
+        command += ["--namespace", self.config.namespace, *args]
+        try:
+            result = self._kubectl_runner(
+                command, input=input, capture_output=True, text=True, check=False
+            )
+        except FileNotFoundError as e:
+            raise KubectlError("kubectl not found on PATH") from e
+        if result.returncode != 0:
+            raise KubectlError(
+                (result.stderr or result.stdout or "").strip()
+                or f"kubectl {args[0]} failed"
+            )
+        return result.stdout
+
+    def submit(self, run: BatchRun) -> None:
+        self._kubectl("apply", "-f", "-", input=json.dumps(self.manifest(run)))
+        run.job = self.job_name(run)
+        run.status = ACTIVE
+        run.submitted_at = _now()
+        logger.info(f"Submitted {run.job} for run {run.id}")
+
+    def refresh(self) -> list[BatchRun]:
+        """Update active runs from their Jobs; returns the runs that finished."""
+        active = [run for run in self.runs if run.status == ACTIVE]
+        if not active:
+            return []
+        selector = f"{BATCH_LABEL}={self.batch_id}"
+        listing = json.loads(self._kubectl("get", "jobs", "-l", selector, "-o", "json"))
+        jobs = {item["metadata"]["name"]: item for item in listing.get("items", [])}
+        finished = []
+        for run in active:
+            job = jobs.get(run.job or "")
+            if job is None:
+                run.status, run.detail = FAILED, "Job no longer exists"
+            else:
+                conditions = {
+                    c.get("type"): c
+                    for c in job.get("status", {}).get("conditions", []) or []
+                    if c.get("status") == "True"
+                }
+                if "Complete" in conditions:
+                    run.status = SUCCEEDED
+                elif "Failed" in conditions:
+                    run.status = FAILED
+                    run.detail = conditions["Failed"].get("reason")
+                else:
+                    continue
+            run.finished_at = _now()
+            finished.append(run)
+        return finished
+
+    def collect(self, run: BatchRun) -> AgentResult:
+        """Read *run*'s Job logs into a result and record it in the event log."""
+        try:
+            log = self._kubectl("logs", f"job/{run.job}", "-c", "agent")
+        except KubectlError as e:
+            log = ""
+            result = AgentResult(text=f"Could not read logs: {e}", is_error=True)
+        else:
+            result = parse_result(log)
+        if result.is_error and run.status == SUCCEEDED:
+            run.status = FAILED
+            run.detail = run.detail or "Run reported an error"
+        run.result = asdict(result)
+
+        self.logs_dir.mkdir(parents=True, exist_ok=True)
+        log_file = self.logs_dir / f"{_slug(run.id) or 'run'}.log"
+        log_file.write_text(log, encoding="utf-8")
+
+        succeeded = run.status == SUCCEEDED
+        self._events().append_event(
+            EVENT_COMPLETED if succeeded else EVENT_FAILED,
+            {
+                "batch_id": self.batch_id,
+                "run_id": run.id,
+                "job": run.job,
+                "repo": run.repo,
+                "message": result.text,
+                "detail": run.detail,
+                "session_id": result.session_id,
+                "cost_usd": result.cost_usd,
+                "num_turns": result.num_turns,
+                "log": str(log_file),
+            },
+            status="resolved" if succeeded else "pending",
+        )
+        return result
+
+    def step(self) -> dict[str, int]:
+        """Collect finished runs and submit pending ones up to ``parallelism``."""
+        for run in self.refresh():
+            self.collect(run)
+        active = sum(run.status == ACTIVE for run in self.runs)
+        for run in self.runs:
+            if active >= self.config.parallelism:
+                break
+            if run.status == PENDING:
+                try:
+                    self.submit(run)
+                except KubectlError as e:
+                    run.status, run.detail = FAILED, str(e)
+                    run.finished_at = _now()
+                    continue
+                active += 1
+        self.save()
+        return self.summary()
+
+    def run(
+        self,
+        poll_interval: float = 15.0,
+        sleep: Callable[[float], None] = time.sleep,
+        on_progress: Callable[[dict[str, int]], None] | None = None,
+    ) -> dict[str, int]:
+        """Step until every run has finished."""
+        while True:
+            summary = self.step()
+            if on_progress:
+                on_progress(summary)
+            if self.done:
+                return summary
+            sleep(poll_interval)
+
+    def cancel(self) -> int:
+        """Delete the batch's Jobs and mark unfinished runs failed."""
+        self._kubectl("delete", "jobs", "-l", f"{BATCH_LABEL}={self.batch_id}")
+        cancelled = 0
+        for run in self.runs:
+            if run.status not in FINISHED:
+                run.status, run.detail = FAILED, "Cancelled"
+                run.finished_at = _now()
+                cancelled += 1
+        self.save()
+        return cancelled
+
+    def _events(self) -> Any:
+        if self._event_log is None:
+            from .event_log import get_event_log
+
+            self._event_log = get_event_log()
+        return self._event_log
+
+
+def manifest_list(batch: KubernetesBatch, runs: Iterable[BatchRun]) -> dict[str, Any]:
+    """A ``v1/List`` of the Jobs for *runs*, for ``--dry-run`` output."""
+    return {
+        "apiVersion": "v1",
+        "kind": "List",
+        "items": [batch.manifest(run) for run in runs],
+    }
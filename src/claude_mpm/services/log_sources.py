@@ -0,0 +1,297 @@
+"""Per-project log sources that debugging agents can query.
+
+WHAT: A project registers where its runtime logs live (a file, a Docker
+container, a systemd unit) in .claude-mpm/log_sources.json. ``query_logs``
+reads them with time-range and regex filters and returns the matching
+lines, newest last.
+
+WHY: Debugging agents otherwise reason from static code alone and ask the
+user to paste logs. The logs MCP server lets them look at what the program
+actually did.
+
+DESIGN DECISIONS:
+- Docker and journald are read through their CLIs (``docker logs``,
+  ``journalctl``), which already filter by time, rather than through APIs
+  that would add dependencies
+- Timestamps are parsed from each line where present; lines without one
+  (stack traces, continuation lines) inherit the previous line's time so a
+  time filter keeps a traceback together
+- Reads are bounded (file tail, line caps, subprocess timeout) so a huge log
+  cannot flood an agent's context
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import subprocess  # nosec B404
+from dataclasses import asdict, dataclass
+from datetime import datetime, timedelta, timezone
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+FILE = "file"
+DOCKER = "docker"
+JOURNALD = "journald"
+SOURCE_KINDS = (FILE, DOCKER, JOURNALD)
+
+DEFAULT_LIMIT = 200
+MAX_FILE_BYTES = 5 * 1024 * 1024
+MAX_TOOL_LINES = 5000
+COMMAND_TIMEOUT = 30
+
+_TIMESTAMP = re.compile(
+    r"(\d{4}-\d{2}-\d{2})[T ](\d{2}:\d{2}:\d{2})(?:[.,](\d+))?"
+    r"(Z|[+-]\d{2}:?\d{2})?"
+)
+_RELATIVE = re.compile(r"^(\d+)\s*([smhd])$")
+_UNITS = {"s": "seconds", "m": "minutes", "h": "hours", "d": "days"}
+_EPOCH = datetime.min.replace(tzinfo=timezone.utc)
+
+
+class LogSourceError(Exception):
+    """Raised when a log source is unknown, misconfigured or unreadable."""
+
+
+def default_registry_path(project_dir: Path) -> Path:
+    return Path(project_dir) / ".claude-mpm" / "log_sources.json"
+
+
+def parse_time(value: str | None, now: datetime | None = None) -> datetime | None:
+    """Parse ``15m``/``2h``/``1d`` (ago) or an ISO timestamp into aware UTC."""
+    if not value:
+        return None
+    now = now or datetime.now(timezone.utc)
+    relative = _RELATIVE.match(value.strip())
+    if relative:
+        amount, unit = relative.groups()
+        return now - timedelta(**{_UNITS[unit]: int(amount)})
+    try:
+        parsed = datetime.fromisoformat(value.strip())
+    except ValueError as e:
+        raise LogSourceError(
+            f"Invalid time {value!r}: use e.g. 15m, 2h, 1d or an ISO timestamp"
+        ) from e
+    return parsed.astimezone(timezone.utc)
+
+
+def line_timestamp(line: str) -> datetime | None:
+    """The first ISO-like timestamp in a log line, as aware UTC."""
+    match = _TIMESTAMP.search(line)
+    if not match:
+        return None
+    date, clock, fraction, offset = match.groups()
+    text = f"{date}T{clock}"
+    if fraction:
+        # Docker emits nanoseconds; datetime only holds microseconds
+        text += "." + fraction[:6].ljust(6, "0")
+    if offset:
+        text += "+00:00" if offset == "Z" else offset
+    try:
+        return datetime.fromisoformat(text).astimezone(timezone.utc)
+    except ValueError:
+        return None
+
+
+@dataclass
+class LogSource:
+    """A named place to read logs from.
+
+    ``target`` is a file path (relative to the project), a container name or
+    id, or a systemd unit, depending on ``kind``.
+    """
+
+    name: str
+    kind: str
+    target: str
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class LogLine:
+    source: str
+    text: str
+    timestamp: str | None = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+class LogSourceRegistry:
+    """Reads and writes a project's log source registry."""
+
+    def __init__(self, project_dir: Path):
+        self.project_dir = Path(project_dir)
+        self.path = default_registry_path(self.project_dir)
+
+    def sources(self) -> list[LogSource]:
+        if not self.path.exists():
+            return []
+        try:
+            data = json.loads(self.path.read_text(encoding="utf-8"))
+        except (OSError, json.JSONDecodeError) as e:
+            raise LogSourceError(f"Could not read {self.path}: {e}") from e
+        return [LogSource(**entry) for entry in data.get("sources", [])]
+
+    def get(self, name: str) -> LogSource:
+        for source in self.sources():
+            if source.name == name:
+                return source
+        known = ", ".join(s.name for s in self.sources()) or "none registered"
+        raise LogSourceError(f"Unknown log source '{name}' ({known})")
+
+    def add(self, source: LogSource) -> None:
+        if source.kind not in SOURCE_KINDS:
+            raise LogSourceError(
+                f"Unknown log source kind '{source.kind}' "
+                f"(expected one of {', '.join(SOURCE_KINDS)})"
+            )
+        sources = [s for s in self.sources() if s.name != source.name]
+        sources.append(source)
+        self._save(sources)
+
+    def remove(self, name: str) -> bool:
+        sources = self.sources()
+        remaining = [s for s in sources if s.name != name]
+        if len(remaining) == len(sources):
+            return False
+        self._save(remaining)
+        return True
+
+    def _save(self, sources: list[LogSource]) -> None:
+        self.path.parent.mkdir(parents=True, exist_ok=True)
+        data = {"sources": [s.to_dict() for s in sources]}
+        self.path.write_text(json.dumps(data, indent=2) + "\n", encoding="utf-8")
+
+
+class LogQuery:
+    """Reads registered sources and filters their lines."""
+
+    def __init__(self, project_dir: Path):
+        self.project_dir = Path(project_dir)
+        self.registry = LogSourceRegistry(self.project_dir)
+
+    def query(
+        self,
+        source: str | None = None,
+        since: str | None = None,
+        until: str | None = None,
+        grep: str | None = None,
+        ignore_case: bool = True,
+        limit: int = DEFAULT_LIMIT,
+    ) -> list[LogLine]:
+        """Matching lines from one source, or all sources when none is named.
+
+        Returns at most ``limit`` lines: the most recent matches, oldest first.
+        """
+        sources = [self.registry.get(source)] if source else self.registry.sources()
+        if not sources:
+            raise LogSourceError(
+                "No log sources registered. Add one with "
+                "'claude-mpm logs add NAME --file PATH|--docker NAME|--journald UNIT'"
+            )
+        start, end = parse_time(since), parse_time(until)
+        flags = re.IGNORECASE if ignore_case else 0
+        try:
+            pattern = re.compile(grep, flags) if grep else None
+        except re.error as e:
+            raise LogSourceError(f"Invalid grep pattern {grep!r}: {e}") from e
+
+        matches: list[tuple[datetime | None, LogLine]] = []
+        for entry in sources:
+            current: datetime | None = None
+            for text in self._read(entry, start, end):
+                current = line_timestamp(text) or current
+                if start and current and current < start:
+                    continue
+                if end and current and current > end:
+                    continue
+                if pattern and not pattern.search(text):
+                    continue
+                stamp = current.isoformat() if current else None
+                matches.append((current, LogLine(entry.name, text, stamp)))
+
+        if len(sources) > 1:
+            # Interleave sources by time; undated lines keep their position
+            matches.sort(key=lambda pair: pair[0] or _EPOCH)
+        return [line for _, line in matches[-limit:]] if limit > 0 else []
+
+    # ------------------------------------------------------------------
+    # Readers
+    # ------------------------------------------------------------------
+
+    def _read(
+        self, source: LogSource, start: datetime | None, end: datetime | None
+    ) -> list[str]:
+        if source.kind == FILE:
+            return self._read_file(source)
+        if source.kind == DOCKER:
+            command = ["docker", "logs", "--timestamps"]
+            if start:
+                command += ["--since", start.isoformat()]
+            else:
+                command += ["--tail", str(MAX_TOOL_LINES)]
+            if end:
+                command += ["--until", end.isoformat()]
+            return self._run([*command, source.target])
+        if source.kind == JOURNALD:
+            command = ["journalctl", "--no-pager", "-o", "short-iso-precise"]
+            command += ["-u", source.target]
+            if start:
+                command += ["--since", _journal_time(start)]
+            else:
+                command += ["-n", str(MAX_TOOL_LINES)]
+            if end:
+                command += ["--until", _journal_time(end)]
+            return self._run(command)
+        raise LogSourceError(f"Unknown log source kind '{source.kind}'")
+
+    def _read_file(self, source: LogSource) -> list[str]:
+        path = Path(source.target).expanduser()
+        if not path.is_absolute():
+            path = self.project_dir / path
+        if not path.is_file():
+            raise LogSourceError(f"Log file not found for '{source.name}': {path}")
+        with path.open("rb") as handle:
+            size = handle.seek(0, 2)
+            handle.seek(max(0, size - MAX_FILE_BYTES))
+            data = handle.read()
+        lines = data.decode("utf-8", errors="replace").splitlines()
+        if size > MAX_FILE_BYTES and lines:
+            lines = lines[1:]  # first line is probably cut in half
+        return lines
+
+    def _run(self, command: list[str]) -> list[str]:
+        try:
+            result = subprocess.run(  # nosec B603
+                command,
+                capture_output=True,
+                text=True,
+                timeout=COMMAND_TIMEOUT,
+                check=False,
+            )
+        except FileNotFoundError as e:
+            raise LogSourceError(f"'{command[0]}' is not installed") from e
+        except subprocess.TimeoutExpired as e:
+            raise LogSourceError(
+                f"'{' '.join(command)}' timed out after {COMMAND_TIMEOUT}s"
+            ) from e
+        if result.returncode != 0:
+            raise LogSourceError(
+                f"'{' '.join(command)}' failed: {result.stderr.strip()}"
+            )
+        # docker logs replays the container's stderr on our stderr
+        lines = result.stdout.splitlines() + result.stderr.splitlines()
+        if command[0] == "docker":
+            lines.sort(key=lambda line: line_timestamp(line) or _EPOCH)
+        return lines
+
+
+def _journal_time(moment: datetime) -> str:
+    return moment.astimezone().strftime("%Y-%m-%d %H:%M:%S")
@@ -0,0 +1,534 @@
+"""Detached tracking of long-running commands.
+
+WHAT: Commands that run for a long time (training jobs, large builds, data
+migrations) are started through a tracker instead of a blocking tool call:
+
+    claude-mpm tasks start --name train -- python train.py --epochs 50
+    claude-mpm tasks list                   # status, progress, GPU use
+    claude-mpm tasks logs train-1a2b3c      # tail of the output
+    claude-mpm tasks stop train-1a2b3c
+
+``start`` returns as soon as the command is running. A detached watcher
+process runs the command with its output going to a log file, polls the log
+every ``poll_interval`` seconds, parses progress out of it and keeps the
+task's state in ``.claude-mpm/tasks/<task>/task.json``. Progress, completion
+and failure are appended to the project's event log (``task.started``,
+``task.progress``, ``task.completed``, ``task.failed``).
+
+WHY: A tool call that waits an hour on a training run blocks the whole
+session; the agent should be able to start it, keep working and check back.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    long_tasks:
+      poll_interval: 5      # seconds between looks at the output
+      progress_step: 10     # percentage points between task.progress events
+      gpu: true             # sample nvidia-smi while tasks run
+
+DESIGN DECISIONS:
+- The watcher is its own session leader (``start_new_session``), so it and
+  the command outlive the claude-mpm invocation and the Claude Code session
+  that started them
+- Progress is recognised in common forms: "45%", "epoch 3/10", "step 120 of
+  500" and "[12/340]" (build tools); a task can pass its own regex with a
+  ``percent`` group or ``current`` and ``total`` groups
+- Only the watcher writes task.json; stopping a task drops a ``stop`` file
+  and signals the command's process group, and the watcher records the stop
+- A running task whose watcher is gone (machine reboot, killed watcher) is
+  reported as ``lost`` rather than running forever
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import re
+import shlex
+import shutil
+import signal
+import subprocess
+import sys
+import time
+import uuid
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field, fields
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, state_lock, update_json, write_atomic
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "long_tasks"
+
+EVENT_STARTED = "task.started"
+EVENT_PROGRESS = "task.progress"
+EVENT_COMPLETED = "task.completed"
+EVENT_FAILED = "task.failed"
+
+RUNNING = "running"
+SUCCEEDED = "succeeded"
+FAILED = "failed"
+STOPPED = "stopped"
+LOST = "lost"
+FINISHED = (SUCCEEDED, FAILED, STOPPED, LOST)
+
+TASK_FILE = "task.json"
+LOG_FILE = "output.log"
+STOP_FILE = "stop"
+
+PROGRESS_PATTERNS = (
+    re.compile(r"(?P<percent>\d{1,3}(?:\.\d+)?)\s?%"),
+    re.compile(
+        r"\b(?:epoch|step|iter(?:ation)?|batch|chunk|file|shard)s?\s*[:#]?\s*"
+        r"(?P<current>\d+)\s*(?:/|of)\s*(?P<total>\d+)",
+        re.IGNORECASE,
+    ),
+    re.compile(r"\[\s*(?P<current>\d+)\s*/\s*(?P<total>\d+)\s*\]"),
+)
+# Only the end of new output is searched for progress
+PROGRESS_WINDOW = 64 * 1024
+
+
+def default_state_dir() -> Path:
+    return Path.cwd() / ".claude-mpm" / "tasks"
+
+
+def _now() -> str:
+    return datetime.now(UTC).isoformat()
+
+
+def _slug(value: str) -> str:
+    return re.sub(r"[^a-z0-9-]+", "-", value.lower()).strip("-")
+
+
+def _pid_alive(pid: int | None) -> bool:
+    if not pid:
+        return False
+    try:
+        os.kill(pid, 0)
+    except ProcessLookupError:
+        return False
+    except PermissionError:
+        return True
+    except OSError:
+        return False
+    return True
+
+
+@dataclass
+class LongTaskConfig:
+    """How running tasks are watched."""
+
+    poll_interval: float = 5.0
+    progress_step: int = 10
+    gpu: bool = True
+
+    @classmethod
+    def load(cls, config: Any = None) -> LongTaskConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return cls.from_dict(section)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> LongTaskConfig:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in data.items() if k in known and v is not None})
+
+
+@dataclass
+class LongTask:
+    """A tracked command and what is known about its progress."""
+
+    id: str
+    command: list[str]
+    cwd: str
+    name: str | None = None
+    progress_pattern: str | None = None
+    status: str = RUNNING
+    pid: int | None = None
+    watcher_pid: int | None = None
+    started_at: str | None = None
+    updated_at: str | None = None
+    finished_at: str | None = None
+    exit_code: int | None = None
+    percent: float | None = None
+    # Last line of output, or the line progress was read from
+    last_line: str | None = None
+    # Latest nvidia-smi sample, one entry per GPU
+    gpu: list[dict[str, Any]] = field(default_factory=list)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> LongTask:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in data.items() if k in known})
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+    @property
+    def command_line(self) -> str:
+        return shlex.join(self.command)
+
+
+def parse_progress(text: str, pattern: str | None = None) -> tuple[float, str] | None:
+    """Latest progress reported in *text*, as (percent, line it was read from).
+
+    Lines are split on carriage returns too, so progress bars that redraw
+    themselves (tqdm, pip, cargo) are read at their latest state.
+    """
+    patterns = (re.compile(pattern),) if pattern else PROGRESS_PATTERNS
+    for line in reversed(re.split(r"[\r\n]+", text)):
+        line = line.strip()
+        if not line:
+            continue
+        for regex in patterns:
+            match = regex.search(line)
+            if not match:
+                continue
+            groups = match.groupdict()
+            if groups.get("percent") is not None:
+                percent = float(groups["percent"])
+            elif groups.get("current") is not None and groups.get("total"):
+                total = float(groups["total"])
+                if not total:
+                    continue
+                percent = float(groups["current"]) / total * 100
+            else:
+                continue
+            if 0 <= percent <= 100:
+                return round(percent, 1), line
+    return None
+
+
+def sample_gpus() -> list[dict[str, Any]]:
+    """Utilization and memory of each NVIDIA GPU, or [] without nvidia-smi."""
+    nvidia_smi = shutil.which("nvidia-smi")
+    if not nvidia_smi:
+        return []
+    try:
+        output = subprocess.run(  # nosec B603 - fixed arguments
+            [
+                nvidia_smi,
+                "--query-gpu=index,name,utilization.gpu,memory.used,memory.total",
+                "--format=csv,noheader,nounits",
+            ],
+            capture_output=True,
+            text=True,
+            timeout=10,
+            check=True,
+        ).stdout
+    except (OSError, subprocess.SubprocessError) as e:
+        logger.debug(f"nvidia-smi failed: {e}")
+        return []
+    gpus = []
+    for line in output.splitlines():
+        parts = [part.strip() for part in line.split(",")]
+        if len(parts) != 5:
+            continue
+        try:
+            gpus.append(
+                {
+                    "index": int(parts[0]),
+                    "name": parts[1],
+                    "utilization": int(parts[2]),
+                    "memory_used_mb": int(parts[3]),
+                    "memory_total_mb": int(parts[4]),
+                }
+            )
+        except ValueError:
+            continue
+    return gpus
+
+
+class TaskTracker:
+    """Starts tracked tasks and reads and updates their state."""
+
+    def __init__(
+        self,
+        state_dir: Path | None = None,
+        config: LongTaskConfig | None = None,
+        event_log: Any = None,
+        gpu_sampler: Callable[[], list[dict[str, Any]]] = sample_gpus,
+        launch: Callable[..., Any] = subprocess.Popen,
+    ):
+        """
+        Args:
+            state_dir: Where tasks are kept (default: .claude-mpm/tasks)
+            config: Watch settings (default: the ``long_tasks`` config)
+            event_log: Event log to record task events in (injected for tests)
+            gpu_sampler: Samples GPU use (injected for tests)
+            launch: Starts the watcher process (injected for tests)
+        """
+        self.state_dir = Path(state_dir) if state_dir else default_state_dir()
+        self.config = config or LongTaskConfig.load()
+        self._event_log = event_log
+        self.gpu_sampler = gpu_sampler
+        self.launch = launch
+
+    def _events(self):
+        if self._event_log is None:
+            from .event_log import EventLog
+
+            # .claude-mpm/tasks -> .claude-mpm/event_log.json, whatever the
+            # working directory of the watcher
+            self._event_log = EventLog(self.state_dir.parent / "event_log.json")
+        return self._event_log
+
+    def _task_dir(self, task_id: str) -> Path:
+        return self.state_dir / task_id
+
+    def _save(self, task: LongTask) -> None:
+        task.updated_at = _now()
+        path = self._task_dir(task.id) / TASK_FILE
+        with state_lock(path):
+            write_atomic(path, json.dumps(task.to_dict(), indent=2))
+
+    def _emit(self, event_type: str, task: LongTask, **extra: Any) -> None:
+        payload = {
+            "task_id": task.id,
+            "name": task.name,
+            "command": task.command_line,
+            "status": task.status,
+            "percent": task.percent,
+            "message": task.last_line or "",
+            **extra,
+        }
+        # Failures stay pending so they surface like other unresolved events
+        status = "pending" if event_type == EVENT_FAILED else "resolved"
+        try:
+            self._events().append_event(event_type, payload, status=status)
+        except Exception as e:
+            logger.warning(f"Could not record {event_type} for {task.id}: {e}")
+
+    # ------------------------------------------------------------------
+    # Reading tasks
+    # ------------------------------------------------------------------
+
+    def get(self, task_id: str) -> LongTask:
+        """Load a task, marking it lost when its watcher has gone.
+
+        Raises:
+            FileNotFoundError: If there is no such task.
+        """
+        path = self._task_dir(task_id) / TASK_FILE
+        if not path.exists():
+            raise FileNotFoundError(f"No task '{task_id}' in {self.state_dir}")
+        task = LongTask.from_dict(json.loads(path.read_text(encoding="utf-8")))
+        if task.status == RUNNING and task.watcher_pid and not _pid_alive(
+            task.watcher_pid
+        ):
+            # Re-read: the watcher may have finished between the two reads
+            with state_lock(path):
+                task = LongTask.from_dict(read_json(path, {}))
+                lost = task.status == RUNNING
+                if lost:
+                    task.status = LOST
+                    task.finished_at = _now()
+                    self._save(task)
+            if lost:
+                self._emit(EVENT_FAILED, task, detail="watcher is no longer running")
+        return task
+
+    def list_tasks(self) -> list[LongTask]:
+        """Every task, most recently started first."""
+        if not self.state_dir.is_dir():
+            return []
+        tasks = [
+            self.get(path.parent.name)
+            for path in self.state_dir.glob(f"*/{TASK_FILE}")
+        ]
+        return sorted(tasks, key=lambda t: t.started_at or "", reverse=True)
+
+    def log_path(self, task_id: str) -> Path:
+        return self._task_dir(task_id) / LOG_FILE
+
+    def tail(self, task_id: str, lines: int = 50) -> str:
+        """The last *lines* lines of a task's output."""
+        self.get(task_id)
+        path = self.log_path(task_id)
+        if not path.exists():
+            return ""
+        with open(path, "rb") as f:
+            f.seek(0, os.SEEK_END)
+            f.seek(max(0, f.tell() - PROGRESS_WINDOW))
+            text = f.read().decode("utf-8", errors="replace")
+        # Show progress bars at their latest state, not every redraw
+        text = "\n".join(line.rsplit("\r", 1)[-1] for line in text.split("\n"))
+        return "\n".join(text.splitlines()[-lines:])
+
+    # ------------------------------------------------------------------
+    # Starting and stopping
+    # ------------------------------------------------------------------
+
+    def start(
+        self,
+        command: list[str],
+        name: str | None = None,
+        cwd: Path | None = None,
+        progress_pattern: str | None = None,
+    ) -> LongTask:
+        """Start *command* under a detached watcher and return at once.
+
+        Raises:
+            ValueError: If the command is empty, the directory does not exist
+                or the progress pattern is not a valid regex.
+        """
+        if not command:
+            raise ValueError("No command given")
+        cwd = Path(cwd or Path.cwd()).resolve()
+        if not cwd.is_dir():
+            raise ValueError(f"Not a directory: {cwd}")
+        if progress_pattern:
+            try:
+                re.compile(progress_pattern)
+            except re.error as e:
+                raise ValueError(f"Invalid progress pattern: {e}") from None
+
+        base = _slug(name or Path(command[0]).name) or "task"
+        task = LongTask(
+            id=f"{base}-{uuid.uuid4().hex[:6]}",
+            command=list(command),
+            cwd=str(cwd),
+            name=name,
+            progress_pattern=progress_pattern,
+            started_at=_now(),
+        )
+        task_dir = self._task_dir(task.id)
+        task_dir.mkdir(parents=True)
+        self._save(task)
+
+        watcher = self.launch(  # nosec B603 - runs this module with the task dir
+            [sys.executable, "-m", __name__, str(task_dir)],
+            stdin=subprocess.DEVNULL,
+            stdout=subprocess.DEVNULL,
+            stderr=subprocess.DEVNULL,
+            start_new_session=True,
+        )
+
+        # The watcher may already be saving the task (its pid, first output):
+        # add the watcher's pid to what is on disk rather than overwrite it
+        def record(data: dict[str, Any]) -> None:
+            data["watcher_pid"] = watcher.pid
+            data["updated_at"] = _now()
+
+        task = LongTask.from_dict(update_json(task_dir / TASK_FILE, record))
+        self._emit(EVENT_STARTED, task)
+        return task
+
+    def stop(self, task_id: str) -> LongTask:
+        """Ask a running task to stop; its watcher records the outcome."""
+        task = self.get(task_id)
+        if task.status in FINISHED:
+            return task
+        (self._task_dir(task_id) / STOP_FILE).touch()
+        if task.pid:
+            try:
+                os.killpg(task.pid, signal.SIGTERM)
+            except ProcessLookupError:
+                pass
+        return task
+
+    # ------------------------------------------------------------------
+    # Watching (runs in the detached watcher process)
+    # ------------------------------------------------------------------
+
+    def watch(self, task_id: str) -> LongTask:
+        """Run a task's command to completion, tracking its progress."""
+        task = self.get(task_id)
+        task_dir = self._task_dir(task_id)
+        task.watcher_pid = os.getpid()
+
+        with open(task_dir / LOG_FILE, "ab") as log:
+            try:
+                process = subprocess.Popen(  # nosec B603 - the user's command
+                    task.command,
+                    cwd=task.cwd,
+                    stdin=subprocess.DEVNULL,
+                    stdout=log,
+                    stderr=subprocess.STDOUT,
+                    start_new_session=True,
+                )
+            except OSError as e:
+                task.status = FAILED
+                task.last_line = f"Could not start {task.command[0]}: {e}"
+                task.finished_at = _now()
+                self._save(task)
+                self._emit(EVENT_FAILED, task)
+                return task
+        task.pid = process.pid
+        self._save(task)
+
+        offset = 0
+        next_event = self.config.progress_step
+        while process.poll() is None:
+            time.sleep(self.config.poll_interval)
+            offset = self._poll(task, offset)
+            if task.percent is not None and self.config.progress_step > 0:
+                if task.percent >= next_event and task.percent < 100:
+                    self._emit(EVENT_PROGRESS, task)
+                    step = self.config.progress_step
+                    next_event = (task.percent // step + 1) * step
+
+        self._poll(task, offset)
+        task.exit_code = process.returncode
+        task.finished_at = _now()
+        if (task_dir / STOP_FILE).exists():
+            task.status = STOPPED
+        elif process.returncode == 0:
+            task.status = SUCCEEDED
+            task.percent = 100.0
+        else:
+            task.status = FAILED
+        self._save(task)
+        self._emit(
+            EVENT_COMPLETED if task.status == SUCCEEDED else EVENT_FAILED,
+            task,
+            exit_code=task.exit_code,
+            log=str(task_dir / LOG_FILE),
+        )
+        return task
+
+    def _poll(self, task: LongTask, offset: int) -> int:
+        """Read output written since *offset*, update the task, save it."""
+        path = self._task_dir(task.id) / LOG_FILE
+        size = path.stat().st_size if path.exists() else 0
+        if size > offset:
+            with open(path, "rb") as f:
+                f.seek(max(offset, size - PROGRESS_WINDOW))
+                text = f.read().decode("utf-8", errors="replace")
+            progress = parse_progress(text, task.progress_pattern)
+            if progress:
+                task.percent, task.last_line = progress
+            else:
+                lines = [line for line in re.split(r"[\r\n]+", text) if line.strip()]
+                if lines:
+                    task.last_line = lines[-1].strip()
+        if self.config.gpu:
+            task.gpu = self.gpu_sampler()
+        self._save(task)
+        return size
+
+
+def main(argv: list[str] | None = None) -> int:
+    """Watcher entry point: ``python -m claude_mpm.services.long_tasks DIR``."""
+    argv = sys.argv[1:] if argv is None else argv
+    if len(argv) != 1:
+        print("usage: python -m claude_mpm.services.long_tasks TASK_DIR")
+        return 2
+    task_dir = Path(argv[0])
+    task = TaskTracker(state_dir=task_dir.parent).watch(task_dir.name)
+    return 0 if task.status == SUCCEEDED else 1
+
+
+if __name__ == "__main__":
+    sys.exit(main())
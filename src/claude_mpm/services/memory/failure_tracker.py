@@ -25,6 +25,11 @@ Example flow:
 2. User or agent makes changes
 3. Bash tool succeeds → Fix detected, matched with failure
 4. Learning extracted and written to agent memory
+
+Verification gate failures (tests, linters, type checks, builds) are handled
+differently in step 4: files edited in between are recorded as the fix and the
+cause → fix lesson is proposed for user approval (see lesson_proposals.py)
+rather than written to memory directly.
 """
 
 import logging
@@ -32,6 +37,7 @@ import re
 import threading
 from dataclasses import dataclass, field
 from datetime import UTC, datetime
+from pathlib import Path
 
 logger = logging.getLogger(__name__)
 
@@ -49,6 +55,7 @@ class FailureEvent:
         timestamp: When the failure occurred
         fixed: Whether this failure has been fixed
         fix_timestamp: When the fix occurred (if fixed)
+        changed_files: Files edited between the failure and its fix
     """
 
     task_id: str
@@ -59,6 +66,7 @@ class FailureEvent:
     timestamp: datetime = field(default_factory=lambda: datetime.now(UTC))
     fixed: bool = False
     fix_timestamp: datetime | None = None
+    changed_files: list[str] = field(default_factory=list)
 
     def mark_fixed(self) -> None:
         """Mark this failure as fixed."""
@@ -125,6 +133,10 @@ class Learning:
             f"- **Date**: {self.timestamp.strftime('%Y-%m-%d')}\n"
         )
 
+    def to_lesson(self) -> str:
+        """Format learning as a single cause → fix memory item."""
+        return f"{self.category}: {self.problem} → {self.solution}"
+
 
 class FailureTracker:
     """Session-level tracker for failures, fixes, and learnings.
@@ -163,6 +175,20 @@ class FailureTracker:
         "script": [r"\.sh", r"\.py", r"\.js", r"script"],
     }
 
+    # Commands that act as a verification gate. A failure of one of these
+    # followed by a passing re-run is proposed as a lesson for user approval
+    # instead of being written to memory directly.
+    VERIFICATION_COMMAND_PATTERNS = [
+        r"\bpytest\b",
+        r"\bpython[\d.]* -m (pytest|unittest|mypy)\b",
+        r"\b(npm|pnpm|yarn|bun)( run)? (test|lint|typecheck|type-check|build)\b",
+        r"\b(jest|vitest|mocha|tsc|eslint|ruff|mypy|pyright|flake8)\b",
+        r"\bgo (test|vet|build)\b",
+        r"\bcargo (test|clippy|build|check)\b",
+        r"\bmake (test|check|lint|build)\b",
+        r"(\bmvn|\bgradle|gradlew) (test|check|build|verify)\b",
+    ]
+
     def __init__(self):
         """Initialize the failure tracker."""
         self.failures: list[FailureEvent] = []
@@ -329,6 +355,33 @@ class FailureTracker:
         logger.info(f"Extracted learning for {target_agent}: {category}")
         return learning
 
+    def record_change(self, file_path: str) -> None:
+        """Attribute a file edit to every failure that is still open.
+
+        WHY: The files touched between a failure and its fix are the fix. They
+        turn "fixed after 40s" into a lesson someone can act on.
+
+        Args:
+            file_path: Path of the edited file
+        """
+        for failure in self.get_unfixed_failures():
+            if file_path not in failure.changed_files:
+                failure.changed_files.append(file_path)
+
+    @classmethod
+    def is_verification_command(cls, command: str | None) -> bool:
+        """Check whether a shell command runs tests, linters or a build."""
+        if not command:
+            return False
+        return any(
+            re.search(pattern, command, re.IGNORECASE)
+            for pattern in cls.VERIFICATION_COMMAND_PATTERNS
+        )
+
+    def is_verification_failure(self, failure_event: FailureEvent) -> bool:
+        """Check whether a failure came from a verification gate command."""
+        return self.is_verification_command(failure_event.context.get("command"))
+
     def get_unfixed_failures(self) -> list[FailureEvent]:
         """Get all failures that haven't been fixed yet.
 
@@ -525,6 +578,21 @@ class FailureTracker:
         Returns:
             Solution description string
         """
+        if failure_event.changed_files:
+            names = [Path(f).name for f in failure_event.changed_files]
+            changed = ", ".join(names[:3])
+            if len(names) > 3:
+                changed += f" (+{len(names) - 3} more)"
+            command = fix_event.context.get("command") or failure_event.context.get(
+                "command"
+            )
+            if command:
+                command = " ".join(command.split())
+                if len(command) > 60:
+                    command = command[:57] + "..."
+                return f"Changed {changed}; `{command}` passes again"
+            return f"Changed {changed}; {fix_event.task_type} passes again"
+
         # Calculate time between failure and fix
         time_delta = fix_event.timestamp - failure_event.timestamp
         time_str = f"{int(time_delta.total_seconds())}s"
@@ -0,0 +1,160 @@
+#!/usr/bin/env python3
+"""
+Lesson Proposals
+================
+
+Pending memory entries learned from verification gate failures.
+
+WHY: A lesson extracted automatically from "tests failed, files changed, tests
+passed" is usually right but sometimes noise. Writing it straight into agent
+memory would let noise accumulate in every future prompt, so verification
+lessons wait here until the user approves or rejects them with
+``claude-mpm memory lessons``.
+
+DESIGN DECISION: Proposals live in one JSON file next to the memory files
+(.claude-mpm/memories/pending_lessons.json). Proposing the same lesson twice
+is a no-op, so a failure that recurs every session does not pile up.
+"""
+
+import hashlib
+import json
+import logging
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+PENDING = "pending"
+APPROVED = "approved"
+REJECTED = "rejected"
+
+
+@dataclass
+class LessonProposal:
+    """A cause → fix lesson waiting for user approval.
+
+    Attributes:
+        id: Short stable identifier derived from agent, cause and fix
+        agent: Agent whose memory receives the lesson when approved
+        category: Learning category (Testing, Code Quality, ...)
+        cause: What made the verification gate fail
+        fix: What made it pass again
+        context: Command, session and changed files
+        created_at: When the lesson was proposed
+        status: pending, approved or rejected
+    """
+
+    id: str
+    agent: str
+    category: str
+    cause: str
+    fix: str
+    context: dict[str, Any] = field(default_factory=dict)
+    created_at: str = field(default_factory=lambda: datetime.now(UTC).isoformat())
+    status: str = PENDING
+
+    @property
+    def memory_item(self) -> str:
+        """The line written to agent memory on approval."""
+        return f"{self.category}: {self.cause} → {self.fix}"
+
+    @classmethod
+    def from_learning(cls, learning) -> "LessonProposal":
+        """Build a proposal from a failure-tracker Learning."""
+        digest = hashlib.sha256(
+            f"{learning.target_agent}|{learning.problem}|{learning.solution}".encode()
+        ).hexdigest()[:8]
+        failure = learning.failure_event
+        context = {
+            "command": failure.context.get("command", ""),
+            "session_id": failure.context.get("session_id", ""),
+            "changed_files": list(failure.changed_files),
+        }
+        return cls(
+            id=digest,
+            agent=learning.target_agent,
+            category=learning.category,
+            cause=learning.problem,
+            fix=learning.solution,
+            context=context,
+        )
+
+
+class LessonProposalStore:
+    """Persists lesson proposals and applies approved ones to agent memory."""
+
+    FILENAME = "pending_lessons.json"
+
+    def __init__(self, memories_dir: Path | None = None):
+        memories_dir = memories_dir or Path.cwd() / ".claude-mpm" / "memories"
+        self.path = memories_dir / self.FILENAME
+
+    def _load(self) -> list[LessonProposal]:
+        if not self.path.exists():
+            return []
+        try:
+            data = json.loads(self.path.read_text(encoding="utf-8"))
+            return [LessonProposal(**item) for item in data.get("lessons", [])]
+        except (OSError, ValueError, TypeError) as e:
+            logger.warning(f"Could not read lesson proposals {self.path}: {e}")
+            return []
+
+    def _save(self, lessons: list[LessonProposal]) -> None:
+        self.path.parent.mkdir(parents=True, exist_ok=True)
+        payload = {"lessons": [asdict(lesson) for lesson in lessons]}
+        self.path.write_text(json.dumps(payload, indent=2), encoding="utf-8")
+
+    def all(self) -> list[LessonProposal]:
+        return self._load()
+
+    def pending(self) -> list[LessonProposal]:
+        return [lesson for lesson in self._load() if lesson.status == PENDING]
+
+    def propose(self, proposal: LessonProposal) -> bool:
+        """Store a proposal. Returns False if the same lesson already exists."""
+        lessons = self._load()
+        if any(lesson.id == proposal.id for lesson in lessons):
+            return False
+        lessons.append(proposal)
+        self._save(lessons)
+        logger.info(f"Proposed lesson {proposal.id} for {proposal.agent}")
+        return True
+
+    def approve(
+        self, ids: list[str], memory_manager, agent: str | None = None
+    ) -> list[LessonProposal]:
+        """Write the given pending lessons to agent memory.
+
+        Args:
+            ids: Proposal ids to approve
+            memory_manager: AgentMemoryManager used to write the lessons
+            agent: Optional agent overriding each proposal's target
+
+        Returns:
+            The proposals that were written to memory
+        """
+        lessons = self._load()
+        approved = []
+        for lesson in lessons:
+            if lesson.id not in ids or lesson.status != PENDING:
+                continue
+            if agent:
+                lesson.agent = agent
+            if memory_manager.add_learning(lesson.agent, lesson.memory_item):
+                lesson.status = APPROVED
+                approved.append(lesson)
+        self._save(lessons)
+        return approved
+
+    def reject(self, ids: list[str]) -> list[LessonProposal]:
+        """Mark the given pending lessons as rejected."""
+        lessons = self._load()
+        rejected = []
+        for lesson in lessons:
+            if lesson.id in ids and lesson.status == PENDING:
+                lesson.status = REJECTED
+                rejected.append(lesson)
+        self._save(lessons)
+        return rejected
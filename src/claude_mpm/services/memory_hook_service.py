@@ -256,9 +256,13 @@ class MemoryHookService(BaseService, MemoryHookInterface):
 
             if isinstance(failure_learning_config, dict):
                 enabled = failure_learning_config.get("enabled", True)
+                require_approval = failure_learning_config.get(
+                    "require_approval", True
+                )
             else:
                 # Default to enabled if config section doesn't exist
                 enabled = True
+                require_approval = True
 
             if not enabled:
                 self.logger.debug("Failure-learning disabled in configuration")
@@ -274,7 +278,9 @@ class MemoryHookService(BaseService, MemoryHookInterface):
             # Get hook instances
             failure_hook = get_failure_detection_hook()
             fix_hook = get_fix_detection_hook()
-            learning_hook = get_learning_extraction_hook()
+            learning_hook = get_learning_extraction_hook(
+                require_approval=require_approval
+            )
 
             # Register hooks in priority order
             success1 = self.hook_service.register_hook(failure_hook)
@@ -70,6 +70,7 @@ PROVIDER STRATEGIES:
 - OLLAMA: Local-only, fail if unavailable (privacy mode)
 - CLAUDE: Cloud-only, always use Claude
 - PRIVACY: Like OLLAMA with privacy-focused messages
+- MOCK: Replay responses recorded from prior sessions (no tokens, deterministic)
 
 RECOMMENDED MODELS (Ollama):
 - SEO Analysis: llama3.3:70b - Comprehensive SEO insights
@@ -120,6 +121,7 @@ from claude_mpm.services.core.interfaces.model import (
 )
 from claude_mpm.services.model.base_provider import BaseModelProvider
 from claude_mpm.services.model.claude_provider import ClaudeProvider
+from claude_mpm.services.model.mock_provider import MockProvider
 from claude_mpm.services.model.model_router import ModelRouter, RoutingStrategy
 from claude_mpm.services.model.ollama_provider import OllamaProvider
 
@@ -128,6 +130,7 @@ __all__ = [  # noqa: RUF022 - Semantic grouping preferred over alphabetical
     "BaseModelProvider",
     # Providers
     "ClaudeProvider",
+    "MockProvider",
     "OllamaProvider",
     # Router
     "ModelRouter",
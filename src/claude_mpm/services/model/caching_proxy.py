@@ -0,0 +1,392 @@
+"""
+Response Caching Proxy for Development Iterations
+=================================================
+
+WHY: Iterating on agent instructions re-sends the same requests over and
+over. A local proxy between Claude Code and the model API records each
+request/response pair once and serves it back afterwards, so unchanged
+requests cost nothing and replay runs are fully deterministic.
+
+USAGE: Start the proxy and point Claude Code at it::
+
+    claude-mpm cache-proxy start --mode auto
+    export ANTHROPIC_BASE_URL=http://127.0.0.1:8787
+
+MODES:
+- auto: serve cache hits, forward and record misses
+- record: always forward, overwrite the cached entry
+- replay: cache only; misses return a 404 error, never touching the API
+
+DESIGN DECISIONS:
+- Standard library only (http.server + urllib) so the proxy starts anywhere
+  claude-mpm is installed, without the monitor's async stack
+- The cache key hashes method, path and the JSON body with volatile fields
+  (``metadata``) removed and keys sorted, so cosmetic differences still hit
+- Request headers are never stored: API keys stay out of the cache
+- Streaming (SSE) responses are buffered and replayed as one body; clients
+  parse the same event stream either way
+"""
+
+from __future__ import annotations
+
+import base64
+import hashlib
+import json
+import threading
+import time
+import urllib.error
+import urllib.request
+from dataclasses import dataclass, field
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.core.logger import get_logger
+
+logger = get_logger(__name__)
+
+DEFAULT_UPSTREAM = "https://api.anthropic.com"
+DEFAULT_PORT = 8787
+PROXY_MODES = ("auto", "record", "replay")
+
+# Body fields that change between otherwise identical requests
+VOLATILE_FIELDS = ("metadata",)
+
+# Hop-by-hop or length-dependent headers that must not be copied verbatim
+_SKIP_REQUEST_HEADERS = {"host", "content-length", "accept-encoding", "connection"}
+_SKIP_RESPONSE_HEADERS = {
+    "content-length",
+    "content-encoding",
+    "transfer-encoding",
+    "connection",
+    "keep-alive",
+}
+
+
+def default_cache_dir(project_dir: Path | None = None) -> Path:
+    """Per-project response cache directory."""
+    return (project_dir or Path.cwd()) / ".claude-mpm" / "cache" / "responses"
+
+
+def cache_key(method: str, path: str, body: bytes) -> str:
+    """Stable key for a request, ignoring volatile body fields."""
+    try:
+        payload = json.loads(body) if body else None
+    except (ValueError, UnicodeDecodeError):
+        payload = None
+
+    if isinstance(payload, dict):
+        for name in VOLATILE_FIELDS:
+            payload.pop(name, None)
+        canonical = json.dumps(payload, sort_keys=True, separators=(",", ":"))
+        body = canonical.encode("utf-8")
+
+    digest = hashlib.sha256()
+    digest.update(f"{method.upper()} {path}\n".encode())
+    digest.update(body)
+    return digest.hexdigest()
+
+
+@dataclass
+class CachedResponse:
+    status: int
+    headers: dict[str, str]
+    body: bytes
+
+    def to_dict(self) -> dict[str, Any]:
+        try:
+            body = {"body": self.body.decode("utf-8")}
+        except UnicodeDecodeError:
+            body = {"body_b64": base64.b64encode(self.body).decode("ascii")}
+        return {"status": self.status, "headers": self.headers, **body}
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> CachedResponse:
+        if "body_b64" in data:
+            body = base64.b64decode(data["body_b64"])
+        else:
+            body = data.get("body", "").encode("utf-8")
+        return cls(
+            status=int(data.get("status", 200)),
+            headers=dict(data.get("headers", {})),
+            body=body,
+        )
+
+
+class ResponseCache:
+    """Request/response pairs stored as one JSON file per cache key."""
+
+    def __init__(self, cache_dir: Path):
+        self.cache_dir = Path(cache_dir)
+
+    def _entry_path(self, key: str) -> Path:
+        return self.cache_dir / f"{key}.json"
+
+    def get(self, key: str) -> CachedResponse | None:
+        path = self._entry_path(key)
+        try:
+            data = json.loads(path.read_text(encoding="utf-8"))
+        except FileNotFoundError:
+            return None
+        except (OSError, ValueError) as e:
+            logger.warning(f"Ignoring unreadable cache entry {path.name}: {e}")
+            return None
+        return CachedResponse.from_dict(data["response"])
+
+    def put(self, key: str, request: dict[str, Any], response: CachedResponse) -> Path:
+        self.cache_dir.mkdir(parents=True, exist_ok=True)
+        path = self._entry_path(key)
+        entry = {
+            "key": key,
+            "recorded_at": time.time(),
+            "request": request,
+            "response": response.to_dict(),
+        }
+        tmp = path.with_suffix(".tmp")
+        tmp.write_text(json.dumps(entry, indent=2), encoding="utf-8")
+        tmp.replace(path)
+        return path
+
+    def entries(self) -> list[Path]:
+        if not self.cache_dir.is_dir():
+            return []
+        return sorted(self.cache_dir.glob("*.json"))
+
+    def stats(self) -> dict[str, Any]:
+        entries = self.entries()
+        return {
+            "cache_dir": str(self.cache_dir),
+            "entries": len(entries),
+            "bytes": sum(p.stat().st_size for p in entries),
+        }
+
+    def clear(self) -> int:
+        removed = 0
+        for path in self.entries():
+            path.unlink(missing_ok=True)
+            removed += 1
+        return removed
+
+
+@dataclass
+class ProxyStats:
+    hits: int = 0
+    misses: int = 0
+    forwarded: int = 0
+    recorded: int = 0
+    errors: int = 0
+    _lock: threading.Lock = field(default_factory=threading.Lock, repr=False)
+
+    def bump(self, name: str) -> None:
+        with self._lock:
+            setattr(self, name, getattr(self, name) + 1)
+
+    def to_dict(self) -> dict[str, int]:
+        return {
+            "hits": self.hits,
+            "misses": self.misses,
+            "forwarded": self.forwarded,
+            "recorded": self.recorded,
+            "errors": self.errors,
+        }
+
+
+def _error_body(error_type: str, message: str) -> bytes:
+    """Error payload in the Anthropic API's own format."""
+    payload = {"type": "error", "error": {"type": error_type, "message": message}}
+    return json.dumps(payload).encode("utf-8")
+
+
+class CachingProxy:
+    """
+    Local HTTP proxy that caches model API responses.
+
+    Usage:
+        proxy = CachingProxy(ResponseCache(default_cache_dir()), mode="auto")
+        proxy.start()           # background thread
+        print(proxy.base_url)   # use as ANTHROPIC_BASE_URL
+        proxy.stop()
+    """
+
+    def __init__(
+        self,
+        cache: ResponseCache,
+        mode: str = "auto",
+        upstream: str = DEFAULT_UPSTREAM,
+        host: str = "127.0.0.1",
+        port: int = DEFAULT_PORT,
+        timeout: float = 600.0,
+    ):
+        if mode not in PROXY_MODES:
+            raise ValueError(
+                f"Unknown proxy mode '{mode}' (expected {', '.join(PROXY_MODES)})"
+            )
+        self.cache = cache
+        self.mode = mode
+        self.upstream = upstream.rstrip("/")
+        self.host = host
+        self.port = port
+        self.timeout = timeout
+        self.stats = ProxyStats()
+        self._server: ThreadingHTTPServer | None = None
+        self._thread: threading.Thread | None = None
+
+    @property
+    def base_url(self) -> str:
+        return f"http://{self.host}:{self.port}"
+
+    # ------------------------------------------------------------------
+    # Request handling
+    # ------------------------------------------------------------------
+
+    def handle(
+        self, method: str, path: str, headers: dict[str, str], body: bytes
+    ) -> tuple[CachedResponse, str]:
+        """Answer one request. Returns (response, outcome) for logging."""
+        key = cache_key(method, path, body)
+
+        if self.mode != "record":
+            cached = self.cache.get(key)
+            if cached is not None:
+                self.stats.bump("hits")
+                return cached, "hit"
+            self.stats.bump("misses")
+
+        if self.mode == "replay":
+            message = f"No cached response for {method} {path} (replay mode)"
+            return (
+                CachedResponse(
+                    404,
+                    {"content-type": "application/json"},
+                    _error_body("not_found_error", message),
+                ),
+                "miss",
+            )
+
+        response = self._forward(method, path, headers, body)
+        if 200 <= response.status < 300:
+            self.cache.put(key, self._describe(method, path, body), response)
+            self.stats.bump("recorded")
+            return response, "recorded"
+        return response, "forwarded"
+
+    def _forward(
+        self, method: str, path: str, headers: dict[str, str], body: bytes
+    ) -> CachedResponse:
+        self.stats.bump("forwarded")
+        request = urllib.request.Request(  # nosec B310 - upstream is configured
+            f"{self.upstream}{path}",
+            data=body or None,
+            method=method,
+            headers={
+                name: value
+                for name, value in headers.items()
+                if name.lower() not in _SKIP_REQUEST_HEADERS
+            },
+        )
+        try:
+            with urllib.request.urlopen(  # nosec B310
+                request, timeout=self.timeout
+            ) as upstream:
+                return CachedResponse(
+                    upstream.status,
+                    self._keep_headers(upstream.headers),
+                    upstream.read(),
+                )
+        except urllib.error.HTTPError as e:
+            return CachedResponse(e.code, self._keep_headers(e.headers), e.read())
+        except OSError as e:
+            self.stats.bump("errors")
+            logger.warning(f"Upstream request failed: {e}")
+            return CachedResponse(
+                502,
+                {"content-type": "application/json"},
+                _error_body("api_error", f"Caching proxy cannot reach upstream: {e}"),
+            )
+
+    @staticmethod
+    def _keep_headers(headers) -> dict[str, str]:
+        return {
+            name.lower(): value
+            for name, value in headers.items()
+            if name.lower() not in _SKIP_RESPONSE_HEADERS
+        }
+
+    @staticmethod
+    def _describe(method: str, path: str, body: bytes) -> dict[str, Any]:
+        """Human-readable request summary stored next to the response."""
+        summary: dict[str, Any] = {"method": method, "path": path}
+        try:
+            payload = json.loads(body) if body else {}
+        except (ValueError, UnicodeDecodeError):
+            return summary
+        if isinstance(payload, dict):
+            summary["model"] = payload.get("model")
+            summary["stream"] = bool(payload.get("stream"))
+            summary["messages"] = len(payload.get("messages") or [])
+        return summary
+
+    # ------------------------------------------------------------------
+    # Server lifecycle
+    # ------------------------------------------------------------------
+
+    def _make_handler(self):
+        proxy = self
+
+        class _Handler(BaseHTTPRequestHandler):
+            protocol_version = "HTTP/1.1"
+
+            def _proxy(self):
+                length = int(self.headers.get("Content-Length") or 0)
+                body = self.rfile.read(length) if length else b""
+                response, outcome = proxy.handle(
+                    self.command, self.path, dict(self.headers.items()), body
+                )
+                logger.debug(f"{self.command} {self.path} -> {outcome}")
+
+                self.send_response(response.status)
+                for name, value in response.headers.items():
+                    self.send_header(name, value)
+                self.send_header("Content-Length", str(len(response.body)))
+                self.send_header("X-Claude-MPM-Cache", outcome)
+                self.end_headers()
+                self.wfile.write(response.body)
+
+            do_GET = do_POST = do_PUT = do_DELETE = _proxy
+
+            def log_message(self, format, *args):  # noqa: A002
+                logger.debug(format % args)
+
+        return _Handler
+
+    def start(self) -> None:
+        """Bind and serve in a background thread."""
+        self._server = ThreadingHTTPServer((self.host, self.port), self._make_handler())
+        self._server.daemon_threads = True
+        self.port = self._server.server_address[1]
+        self._thread = threading.Thread(
+            target=self._server.serve_forever, name="caching-proxy", daemon=True
+        )
+        self._thread.start()
+        logger.info(f"Caching proxy ({self.mode}) listening on {self.base_url}")
+
+    def stop(self) -> None:
+        if self._server is not None:
+            self._server.shutdown()
+            self._server.server_close()
+            self._server = None
+        if self._thread is not None:
+            self._thread.join(timeout=5)
+            self._thread = None
+
+
+__all__ = [
+    "DEFAULT_PORT",
+    "DEFAULT_UPSTREAM",
+    "PROXY_MODES",
+    "CachedResponse",
+    "CachingProxy",
+    "ResponseCache",
+    "cache_key",
+    "default_cache_dir",
+]
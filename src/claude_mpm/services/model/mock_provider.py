@@ -0,0 +1,136 @@
+"""
+Mock Model Provider Implementation for Claude MPM Framework
+===========================================================
+
+WHY: Hooks, policies, adapters and dashboards all need a model response to
+react to, but testing them against a live API costs tokens and is never
+repeatable. The mock provider replays canned responses recorded from prior
+sessions so the same input always produces the same output, offline.
+
+DESIGN DECISION: Recordings are matched on the raw content, not the
+task-specific prompt, so a recording taken from a normal Claude Code session
+can answer any capability. Without a recording the provider still answers
+with a fixed, clearly-labelled placeholder so pipelines never stall.
+
+Configuration:
+    recording: Path to a fixture file or Claude Code transcript (.jsonl)
+    model: Reported model name (default: "mock")
+    latency: Artificial delay in seconds, for timeout/UX testing (default: 0)
+"""
+
+import asyncio
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.services.core.interfaces.model import ModelCapability, ModelResponse
+from claude_mpm.services.model.base_provider import BaseModelProvider
+from claude_mpm.services.model.recordings import (
+    RecordedTurn,
+    ReplayIndex,
+    load_recording,
+)
+
+PLACEHOLDER_REPLY = "[mock] No recording loaded; this is a deterministic placeholder."
+
+
+class MockProvider(BaseModelProvider):
+    """
+    Replays recorded responses instead of calling a model.
+
+    Usage:
+        provider = MockProvider(config={"recording": "fixtures/session.json"})
+        response = await provider.analyze_content(
+            content="Summarise the changes", task=ModelCapability.SUMMARIZATION
+        )
+        assert response.metadata["replay_match"] in ("exact", "similar")
+    """
+
+    def __init__(
+        self,
+        config: dict[str, Any] | None = None,
+        turns: list[RecordedTurn] | None = None,
+    ):
+        super().__init__(provider_name="mock", config=config or {})
+        self.default_model = self.get_config("model", "mock")
+        self.latency = float(self.get_config("latency", 0) or 0)
+        self._turns = turns
+        self._index: ReplayIndex | None = None
+
+    async def initialize(self) -> bool:
+        recording = self.get_config("recording", None)
+        try:
+            if self._turns is None and recording:
+                self._turns = load_recording(Path(recording).expanduser())
+            if self._turns:
+                self._index = ReplayIndex(self._turns)
+        except (OSError, ValueError) as e:
+            self.log_error(f"Failed to load mock recording {recording}: {e}")
+            return False
+
+        count = len(self._turns or [])
+        self.log_info(f"Mock provider initialized with {count} recorded turns")
+        self._initialized = True
+        return True
+
+    async def is_available(self) -> bool:
+        return True
+
+    async def get_available_models(self) -> list[str]:
+        return [self.default_model]
+
+    def get_supported_capabilities(self) -> list[ModelCapability]:
+        return list(ModelCapability)
+
+    async def analyze_content(
+        self,
+        content: str,
+        task: ModelCapability,
+        model: str | None = None,
+        **kwargs,
+    ) -> ModelResponse:
+        """Return the recorded reply that best matches ``content``."""
+        if not self._initialized and not await self.initialize():
+            return self.create_response(
+                success=False,
+                model=model or self.default_model,
+                task=task,
+                error="Mock provider could not load its recording",
+            )
+
+        if self.latency:
+            await asyncio.sleep(self.latency)
+
+        self._request_count += 1
+        if self._index is None:
+            return self.create_response(
+                success=True,
+                model=model or self.default_model,
+                task=task,
+                result=PLACEHOLDER_REPLY,
+                metadata={"replayed": False},
+            )
+
+        turn, how = self._index.match(content)
+        return self.create_response(
+            success=True,
+            model=model or self.default_model,
+            task=task,
+            result=turn.reply,
+            metadata={
+                "replayed": True,
+                "replay_match": how,
+                "recorded_prompt": turn.prompt,
+                "tool_calls": [call.name for call in turn.tool_calls],
+            },
+        )
+
+    async def get_model_info(self, model: str) -> dict[str, Any]:
+        return {
+            "name": model,
+            "provider": "mock",
+            "recorded_turns": len(self._turns or []),
+            "cost": "free",
+        }
+
+
+__all__ = ["MockProvider"]
@@ -15,6 +15,7 @@ ROUTING STRATEGIES:
 - OLLAMA: Local-only, fail if unavailable (privacy mode)
 - CLAUDE: Cloud-only, always use Claude
 - PRIVACY: Like OLLAMA but with better error messages
+- MOCK: Replay recorded responses, never call a model (testing)
 
 ARCHITECTURE:
 - Manages provider lifecycle (initialization, shutdown)
@@ -34,6 +35,7 @@ from claude_mpm.services.core.interfaces.model import (
     ModelResponse,
 )
 from claude_mpm.services.model.claude_provider import ClaudeProvider
+from claude_mpm.services.model.mock_provider import MockProvider
 from claude_mpm.services.model.ollama_provider import OllamaProvider
 from claude_mpm.services.model.openrouter_provider import OpenRouterProvider
 
@@ -50,6 +52,7 @@ class RoutingStrategy(Enum):
     OLLAMA_ONLY = "ollama"  # Local only, fail if unavailable
     CLAUDE_ONLY = "claude"  # Cloud only, always use Claude
     PRIVACY_FIRST = "privacy"  # Like OLLAMA_ONLY but explicit about privacy
+    MOCK = "mock"  # Replay recorded responses; deterministic, no tokens
 
 
 class ModelRouter(BaseService, IModelRouter):
@@ -117,10 +120,12 @@ class ModelRouter(BaseService, IModelRouter):
         ollama_config = self.get_config("ollama_config", {})
         claude_config = self.get_config("claude_config", {})
         openrouter_config = self.get_config("openrouter_config", {})
+        mock_config = self.get_config("mock_config", {})
 
         self.ollama_provider = OllamaProvider(config=ollama_config)
         self.claude_provider = ClaudeProvider(config=claude_config)
         self.openrouter_provider = OpenRouterProvider(config=openrouter_config)
+        self.mock_provider = MockProvider(config=mock_config)
 
         # Provider registry for direct lookup by name (e.g., "openrouter")
         # WHY: Enables agents that need a specific provider (independent code
@@ -129,6 +134,7 @@ class ModelRouter(BaseService, IModelRouter):
             "ollama": self.ollama_provider,
             "claude": self.claude_provider,
             "openrouter": self.openrouter_provider,
+            "mock": self.mock_provider,
         }
 
         # Routing metrics
@@ -136,6 +142,7 @@ class ModelRouter(BaseService, IModelRouter):
             "ollama": 0,
             "claude": 0,
             "openrouter": 0,
+            "mock": 0,
         }
         self._fallback_count = 0
         self._active_provider: str | None = None
@@ -172,6 +179,10 @@ class ModelRouter(BaseService, IModelRouter):
             else:
                 self.log_warning("Claude provider initialization failed")
 
+        if self.strategy == RoutingStrategy.MOCK:
+            self.log_info("Initializing mock provider...")
+            success = await self.mock_provider.initialize()
+
         if not success:
             self.log_error("No providers initialized successfully")
             return False
@@ -193,6 +204,9 @@ class ModelRouter(BaseService, IModelRouter):
         if self.openrouter_provider:
             await self.openrouter_provider.shutdown()
 
+        if self.mock_provider:
+            await self.mock_provider.shutdown()
+
         self._shutdown = True
 
     def get_provider(self, name: str) -> Any | None:
@@ -258,6 +272,13 @@ class ModelRouter(BaseService, IModelRouter):
                 "metrics": self.claude_provider.get_metrics(),
             }
 
+        if self.strategy == RoutingStrategy.MOCK:
+            status["mock"] = {
+                "available": True,
+                "initialized": self.mock_provider.is_initialized,
+                "metrics": self.mock_provider.get_metrics(),
+            }
+
         # Add routing metrics
         status["router"] = {
             "strategy": self.strategy.value,
@@ -299,6 +320,13 @@ class ModelRouter(BaseService, IModelRouter):
             )
 
         # Route based on strategy
+        if self.strategy == RoutingStrategy.MOCK:
+            self._active_provider = "mock"
+            self._route_count["mock"] += 1
+            return await self.mock_provider.analyze_content(
+                content, task, model=model, **kwargs
+            )
+
         if self.strategy == RoutingStrategy.CLAUDE_ONLY:
             return await self._route_to_claude(content, task, model=model, **kwargs)
 
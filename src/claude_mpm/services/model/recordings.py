@@ -0,0 +1,166 @@
+"""
+Recorded Model Responses for Deterministic Replay
+=================================================
+
+WHY: Testing hooks, policies, adapters and dashboards against a live model
+costs tokens and gives different output every run. A recording captures the
+prompt/response pairs of a prior session once; the mock provider and the
+selftest backend then replay them exactly.
+
+SOURCES:
+- Fixture files written by :func:`save_recording` (JSON with a ``turns`` list)
+- Claude Code session transcripts (``~/.claude/projects/<cwd>/<id>.jsonl``):
+  each user text message starts a turn, assistant text becomes the reply and
+  ``tool_use`` / ``tool_result`` blocks become the turn's tool calls
+
+MATCHING: exact prompt first, then the recorded prompt sharing the most
+words with the request, then recorded order. Matching never fails, so a
+replay always returns *something* deterministic.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any
+
+RECORDING_FORMAT_VERSION = 1
+
+
+@dataclass
+class RecordedToolCall:
+    name: str
+    input: dict[str, Any] = field(default_factory=dict)
+    result: str = ""
+
+
+@dataclass
+class RecordedTurn:
+    prompt: str
+    reply: str
+    tool_calls: list[RecordedToolCall] = field(default_factory=list)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> RecordedTurn:
+        return cls(
+            prompt=data.get("prompt", ""),
+            reply=data.get("reply", ""),
+            tool_calls=[RecordedToolCall(**c) for c in data.get("tool_calls", [])],
+        )
+
+
+def _text_of(content: Any) -> str:
+    if isinstance(content, str):
+        return content
+    if isinstance(content, list):
+        return "\n".join(
+            block.get("text", "")
+            for block in content
+            if isinstance(block, dict) and block.get("type") == "text"
+        )
+    return ""
+
+
+def _tool_result_text(block: dict[str, Any]) -> str:
+    content = block.get("content", "")
+    return content if isinstance(content, str) else _text_of(content)
+
+
+def turns_from_transcript(lines: list[dict[str, Any]]) -> list[RecordedTurn]:
+    """Convert parsed Claude Code transcript lines into replayable turns."""
+    turns: list[RecordedTurn] = []
+    pending_tools: dict[str, RecordedToolCall] = {}
+
+    for entry in lines:
+        message = entry.get("message") or {}
+        content = message.get("content")
+        kind = entry.get("type")
+
+        if kind == "user":
+            blocks = content if isinstance(content, list) else []
+            results = [b for b in blocks if b.get("type") == "tool_result"]
+            for block in results:
+                call = pending_tools.pop(block.get("tool_use_id", ""), None)
+                if call is not None:
+                    call.result = _tool_result_text(block)
+            text = _text_of(content).strip()
+            if text and not results:
+                turns.append(RecordedTurn(prompt=text, reply=""))
+
+        elif kind == "assistant" and turns:
+            turn = turns[-1]
+            for block in content if isinstance(content, list) else []:
+                if block.get("type") == "text" and block.get("text"):
+                    turn.reply = f"{turn.reply}\n{block['text']}".strip()
+                elif block.get("type") == "tool_use":
+                    call = RecordedToolCall(
+                        name=block.get("name", ""), input=block.get("input") or {}
+                    )
+                    turn.tool_calls.append(call)
+                    pending_tools[block.get("id", "")] = call
+
+    return turns
+
+
+def load_recording(path: Path) -> list[RecordedTurn]:
+    """Load turns from a fixture file or a Claude Code transcript."""
+    text = Path(path).read_text(encoding="utf-8")
+    stripped = text.lstrip()
+    if stripped.startswith("{") and '"turns"' in stripped[:200]:
+        data = json.loads(text)
+        return [RecordedTurn.from_dict(t) for t in data.get("turns", [])]
+
+    lines = [json.loads(line) for line in text.splitlines() if line.strip()]
+    if lines and all("prompt" in line for line in lines):
+        return [RecordedTurn.from_dict(line) for line in lines]
+    return turns_from_transcript(lines)
+
+
+def save_recording(turns: list[RecordedTurn], path: Path) -> Path:
+    """Write turns as a fixture file that :func:`load_recording` reads back."""
+    path = Path(path)
+    path.parent.mkdir(parents=True, exist_ok=True)
+    payload = {
+        "version": RECORDING_FORMAT_VERSION,
+        "turns": [asdict(turn) for turn in turns],
+    }
+    path.write_text(json.dumps(payload, indent=2), encoding="utf-8")
+    return path
+
+
+_WORD_RE = re.compile(r"\w+")
+
+
+def _words(text: str) -> set[str]:
+    return set(_WORD_RE.findall(text.lower()))
+
+
+class ReplayIndex:
+    """Finds the recorded turn that best answers a prompt."""
+
+    def __init__(self, turns: list[RecordedTurn]):
+        if not turns:
+            raise ValueError("Recording contains no turns")
+        self.turns = turns
+        self._exact = {t.prompt.strip(): t for t in reversed(turns)}
+        self._cursor = 0
+
+    def match(self, prompt: str) -> tuple[RecordedTurn, str]:
+        """Return (turn, how) where how is exact, similar or sequential."""
+        exact = self._exact.get(prompt.strip())
+        if exact is not None:
+            return exact, "exact"
+
+        wanted = _words(prompt)
+        if wanted:
+            scored = [(len(wanted & _words(t.prompt)), t) for t in self.turns]
+            best_score = max(score for score, _ in scored)
+            if best_score:
+                best = next(t for score, t in scored if score == best_score)
+                return best, "similar"
+
+        turn = self.turns[self._cursor % len(self.turns)]
+        self._cursor += 1
+        return turn, "sequential"
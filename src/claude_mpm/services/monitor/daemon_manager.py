@@ -144,7 +144,7 @@ class DaemonManager:
         self.startup_status_file = None
 
     @staticmethod
-    def get_pid_file_for_port(port: int) -> Path:
+    def get_pid_file_for_port(port: int, start: Path | None = None) -> Path:
         """Return the canonical PID-file path for a given port.
 
         WHAT: Single, importable resolver used by both DaemonManager and the
@@ -162,9 +162,12 @@ class DaemonManager:
               (issue #701).  Directory creation is the responsibility of WRITE
               paths only (see ``write_pid_file`` and ``start_daemon_subprocess``).
 
+              ``start`` resolves the path for a server run from another
+              directory (the shared daemon starts one per attached project).
+
         :spec: none
         """
-        project_root = _find_project_root()
+        project_root = _find_project_root(start)
         return project_root / ".claude-mpm" / f"monitor-daemon-{port}.pid"
 
     def _get_default_pid_file(self) -> Path:
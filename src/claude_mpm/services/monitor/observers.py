@@ -0,0 +1,220 @@
+"""
+Read-only observer links for pairing on a session.
+
+WHAT: ``claude-mpm dashboard share <session>`` (or ``POST /api/observers``)
+creates a link of the form ``http://<dashboard>/observe/<token>``. Whoever
+opens it sees that session's events live (prompts, tool calls and their
+output, agent delegations), and nothing else: no other sessions, no files,
+no configuration, no way to act on the session.
+
+WHY: Pair-debugging and demos meant screen sharing or handing out the full
+dashboard, which can also edit configuration and deploy agents.
+
+DESIGN DECISIONS:
+- Observers connect to a Socket.IO server of their own, served under
+  ``/observe/socket.io/`` on the ``/observe`` namespace. The dashboard's
+  server at ``/socket.io/`` broadcasts every session's events without
+  authentication, so it must never be reachable through a shared link; the
+  observer server has no handlers an observer could send to and only ever
+  sends copies of the observer's session's events
+- Only a SHA-256 of each token is stored (``.claude-mpm/observer-links.json``),
+  so the links file does not grant access by itself
+- Links expire (8 hours by default). Revoking or expiring a link also
+  disconnects its open observers within a few seconds
+- The rest of the dashboard still has no authentication: share the link
+  through a tunnel or proxy that only forwards ``/observe/``, not by
+  binding the dashboard to a public address
+"""
+
+from __future__ import annotations
+
+import hashlib
+import secrets
+import time
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+from urllib.parse import parse_qs
+
+from ...core.logging_config import get_logger
+from ...core.state_files import read_json, update_json
+
+logger = get_logger(__name__)
+
+NAMESPACE = "/observe"
+# Kept apart from the dashboard's /socket.io/ so proxies can forward it alone
+SOCKETIO_PATH = "observe/socket.io"
+LINKS_FILE = "observer-links.json"
+DEFAULT_HOURS = 8.0
+# How often open observers are checked against revoked and expired links
+RECHECK_SECONDS = 5.0
+
+
+def _hash(token: str) -> str:
+    return hashlib.sha256(token.encode()).hexdigest()
+
+
+def session_room(session_id: str) -> str:
+    return f"session:{session_id}"
+
+
+@dataclass(frozen=True)
+class ObserverLink:
+    """Read-only access to one session's event stream."""
+
+    id: str
+    session_id: str
+    token_sha256: str
+    created_at: str
+    expires_at: str
+    label: str = ""
+
+    def expired(self, now: datetime | None = None) -> bool:
+        now = now or datetime.now(UTC)
+        return datetime.fromisoformat(self.expires_at) <= now
+
+    def to_dict(self) -> dict[str, Any]:
+        data = asdict(self)
+        del data["token_sha256"]
+        return data
+
+
+class ObserverLinks:
+    """The observer links of one project."""
+
+    def __init__(self, project_root: Path | None = None):
+        self.path = Path(project_root or Path.cwd()) / ".claude-mpm" / LINKS_FILE
+
+    def links(self) -> list[ObserverLink]:
+        data = read_json(self.path, {})
+        entries = data.get("links") if isinstance(data, dict) else None
+        links = []
+        for entry in entries if isinstance(entries, list) else []:
+            try:
+                links.append(ObserverLink(**entry))
+            except TypeError:
+                continue
+        return links
+
+    def active(self) -> list[ObserverLink]:
+        return [link for link in self.links() if not link.expired()]
+
+    def create(
+        self, session_id: str, hours: float = DEFAULT_HOURS, label: str = ""
+    ) -> tuple[ObserverLink, str]:
+        """Create a link to *session_id*; returns it and its secret token.
+
+        The token is not stored and cannot be shown again.
+        """
+        if not session_id:
+            raise ValueError("A session id is required")
+        if hours <= 0:
+            raise ValueError("Link lifetime must be positive")
+        token = secrets.token_urlsafe(24)
+        now = datetime.now(UTC)
+        link = ObserverLink(
+            id=secrets.token_hex(4),
+            session_id=session_id,
+            token_sha256=_hash(token),
+            created_at=now.isoformat(),
+            expires_at=(now + timedelta(hours=hours)).isoformat(),
+            label=label,
+        )
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            kept = [
+                entry
+                for entry in data.get("links") or []
+                if isinstance(entry, dict)
+                and entry.get("expires_at", "") > now.isoformat()
+            ]
+            data["links"] = [*kept, asdict(link)]
+
+        update_json(self.path, record)
+        return link, token
+
+    def verify(self, token: str | None) -> ObserverLink | None:
+        """The unexpired link *token* opens, if any."""
+        if not token:
+            return None
+        digest = _hash(token)
+        for link in self.active():
+            if secrets.compare_digest(link.token_sha256, digest):
+                return link
+        return None
+
+    def revoke(self, link_id: str) -> bool:
+        removed = []
+
+        def drop(data: dict[str, Any]) -> None:
+            links = data.get("links") or []
+            data["links"] = [e for e in links if e.get("id") != link_id]
+            removed.extend(e for e in links if e.get("id") == link_id)
+
+        if self.path.exists():
+            update_json(self.path, drop)
+        return bool(removed)
+
+
+class ObserverHub:
+    """Serves observers on the ``/observe`` Socket.IO namespace.
+
+    *sio* must be the observer server attached at :data:`SOCKETIO_PATH`,
+    not the dashboard's.
+    """
+
+    def __init__(self, sio: Any, links: ObserverLinks | None = None):
+        self.sio = sio
+        self.links = links or ObserverLinks()
+        self.observers: dict[str, ObserverLink] = {}  # sid -> link
+        self._checked = 0.0
+
+    def register(self) -> None:
+        self.sio.on("connect", self.connect, namespace=NAMESPACE)
+        self.sio.on("disconnect", self.disconnect, namespace=NAMESPACE)
+
+    async def connect(self, sid: str, environ: dict, auth: Any = None) -> bool:
+        token = auth.get("token") if isinstance(auth, dict) else None
+        if not token:
+            query = parse_qs(environ.get("QUERY_STRING", ""))
+            token = (query.get("token") or [None])[0]
+        link = self.links.verify(token)
+        if link is None:
+            logger.info("Refused observer with an invalid or expired link")
+            return False
+        self.observers[sid] = link
+        await self.sio.enter_room(sid, session_room(link.session_id), NAMESPACE)
+        await self.sio.emit(
+            "observer:session", link.to_dict(), to=sid, namespace=NAMESPACE
+        )
+        logger.info(f"Observer joined session {link.session_id} (link {link.id})")
+        return True
+
+    async def disconnect(self, sid: str, *args: Any) -> None:
+        self.observers.pop(sid, None)
+
+    async def relay(self, event_type: str, event: dict[str, Any]) -> None:
+        """Send a copy of a dashboard event to its session's observers."""
+        session_id = event.get("session_id")
+        if not session_id:
+            return
+        if self.observers:
+            await self._drop_revoked()
+        # Emitted even without local observers: with several event server
+        # processes the observer may be connected to another one
+        await self.sio.emit(
+            event_type, event, room=session_room(session_id), namespace=NAMESPACE
+        )
+
+    async def _drop_revoked(self) -> None:
+        if time.monotonic() - self._checked < RECHECK_SECONDS:
+            return
+        self._checked = time.monotonic()
+        active = {link.id for link in self.links.active()}
+        for sid, link in list(self.observers.items()):
+            if link.id not in active:
+                self.observers.pop(sid, None)
+                await self.sio.disconnect(sid, namespace=NAMESPACE)
+                logger.info(f"Disconnected observer of revoked link {link.id}")
@@ -0,0 +1,85 @@
+"""Session comment API routes for the Claude MPM Dashboard.
+
+Lets the dashboard show, add and delete the review comments attached to a
+session's events (see services/annotations.py).
+
+Comments are kept in the project the monitor was started in, matching
+/api/working-directory.
+"""
+
+import asyncio
+from pathlib import Path
+from urllib.parse import urlparse
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.annotations import AnnotationStore
+
+logger = get_logger(__name__)
+
+
+def register_annotation_routes(app: web.Application) -> None:
+    """Register annotation routes on the aiohttp app."""
+    app.router.add_get("/api/annotations", handle_list)
+    app.router.add_post("/api/annotations", handle_add)
+    app.router.add_delete("/api/annotations/{annotation_id}", handle_remove)
+    logger.info("Registered 3 annotation routes under /api/annotations")
+
+
+def _cross_origin(request: web.Request) -> bool:
+    origin = request.headers.get("Origin")
+    return bool(origin) and urlparse(origin).netloc != request.host
+
+
+async def handle_list(request: web.Request) -> web.Response:
+    """GET /api/annotations?session=&target= - Comments, oldest first."""
+    store = AnnotationStore(Path.cwd())
+    annotations = await asyncio.to_thread(
+        store.annotations,
+        request.query.get("session") or None,
+        request.query.get("target") or None,
+    )
+    return web.json_response(
+        {"success": True, "annotations": [a.to_dict() for a in annotations]}
+    )
+
+
+async def handle_add(request: web.Request) -> web.Response:
+    """POST /api/annotations {session_id, target, text, author?} - New comment."""
+    if _cross_origin(request):
+        return web.json_response(
+            {"success": False, "error": "Cross-origin request refused"}, status=403
+        )
+    try:
+        body = await request.json()
+        target = str(body.get("target") or "")
+        if not target:
+            raise ValueError("A target is required")
+        annotation = await asyncio.to_thread(
+            AnnotationStore(Path.cwd()).add,
+            str(body.get("session_id") or ""),
+            str(body.get("text") or ""),
+            target,
+            None,
+            body.get("author") or None,
+        )
+    except (ValueError, TypeError, AttributeError) as e:
+        return web.json_response({"success": False, "error": str(e)}, status=400)
+    return web.json_response(
+        {"success": True, "annotation": annotation.to_dict()}, status=201
+    )
+
+
+async def handle_remove(request: web.Request) -> web.Response:
+    """DELETE /api/annotations/{annotation_id} - Delete a comment."""
+    if _cross_origin(request):
+        return web.json_response(
+            {"success": False, "error": "Cross-origin request refused"}, status=403
+        )
+    store = AnnotationStore(Path.cwd())
+    if not await asyncio.to_thread(store.remove, request.match_info["annotation_id"]):
+        return web.json_response(
+            {"success": False, "error": "Comment not found"}, status=404
+        )
+    return web.json_response({"success": True})
@@ -0,0 +1,73 @@
+"""Session artifact API routes for the Claude MPM Dashboard.
+
+Lists the files sessions stored with 'claude-mpm artifacts add' and serves
+them as downloads for the dashboard's Artifacts tab.
+
+Artifacts are read from the project the monitor was started in, matching
+/api/working-directory.
+"""
+
+import asyncio
+from pathlib import Path
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.artifact_store import ARTIFACT_KINDS, ArtifactStore
+
+logger = get_logger(__name__)
+
+
+def register_artifact_routes(app: web.Application) -> None:
+    """Register artifact routes on the aiohttp app."""
+    app.router.add_get("/api/artifacts", handle_list)
+    app.router.add_get("/api/artifacts/{artifact_id}/download", handle_download)
+    logger.info("Registered 2 artifact routes under /api/artifacts")
+
+
+async def handle_list(request: web.Request) -> web.Response:
+    """GET /api/artifacts?session=&kind= - Stored artifacts, newest first."""
+    kind = request.query.get("kind") or None
+    if kind and kind not in ARTIFACT_KINDS:
+        return web.json_response(
+            {"success": False, "error": f"Unknown artifact kind: {kind}"}, status=400
+        )
+    store = ArtifactStore(Path.cwd())
+    try:
+        artifacts = await asyncio.to_thread(
+            store.artifacts, request.query.get("session") or None, kind
+        )
+    except Exception as e:
+        logger.error(f"Error listing artifacts: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
+    return web.json_response(
+        {"success": True, "artifacts": [a.to_dict() for a in artifacts]}
+    )
+
+
+async def handle_download(request: web.Request) -> web.StreamResponse:
+    """GET /api/artifacts/{artifact_id}/download - The stored file.
+
+    ``?inline=1`` serves raster images for previews; everything else
+    downloads so stored HTML or SVG never runs in the dashboard's origin.
+    """
+    store = ArtifactStore(Path.cwd())
+    artifact = await asyncio.to_thread(store.get, request.match_info["artifact_id"])
+    path = store.path(artifact) if artifact else None
+    if path is None or not path.is_file():
+        return web.json_response(
+            {"success": False, "error": "Artifact not found"}, status=404
+        )
+    inline = (
+        request.query.get("inline") == "1"
+        and artifact.media_type.startswith("image/")
+        and artifact.media_type != "image/svg+xml"
+    )
+    disposition = "inline" if inline else "attachment"
+    return web.FileResponse(
+        path,
+        headers={
+            "Content-Type": artifact.media_type,
+            "Content-Disposition": f'{disposition}; filename="{artifact.name}"',
+        },
+    )
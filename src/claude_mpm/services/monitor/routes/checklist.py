@@ -0,0 +1,36 @@
+"""Onboarding checklist API routes for the Claude MPM Dashboard.
+
+Serves the checklist results shown in the dashboard's Checklist tab (see
+services/checklist.py).
+
+The checklist is evaluated for the project the monitor was started in,
+matching /api/working-directory.
+"""
+
+import asyncio
+from pathlib import Path
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.checklist import Checklist
+
+logger = get_logger(__name__)
+
+
+def register_checklist_routes(app: web.Application) -> None:
+    """Register checklist routes on the aiohttp app."""
+    app.router.add_get("/api/checklist", handle_status)
+    logger.info("Registered 1 checklist route under /api/checklist")
+
+
+async def handle_status(request: web.Request) -> web.Response:
+    """GET /api/checklist - Each step and whether it is done."""
+    try:
+        status = await asyncio.to_thread(Checklist(Path.cwd()).status)
+    except ValueError as e:
+        return web.json_response({"success": False, "error": str(e)}, status=400)
+    except Exception as e:
+        logger.error(f"Error checking the checklist: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
+    return web.json_response({"success": True, **status.to_dict()})
@@ -365,12 +365,16 @@ def register_source_routes(app, config_event_handler, config_file_watcher):
                             raise ValueError(f"Source '{source_id}' not found")
                         config.save(config_path)
 
-                    return config_path
+                    return config_path, []
 
                 # skill
                 from claude_mpm.config.skill_sources import (
                     SkillSourceConfiguration,
                 )
+                from claude_mpm.services.skills.git_skill_source_manager import (
+                    GitSkillSourceManager,
+                )
+                from claude_mpm.services.skills.skills_lock import SkillsLock
 
                 config_path = (
                     Path.home() / ".claude-mpm" / "config" / "skill_sources.yaml"
@@ -378,14 +382,16 @@ def register_source_routes(app, config_event_handler, config_file_watcher):
 
                 with config_file_lock(config_path):
                     ssc = SkillSourceConfiguration(config_path)
-                    removed = ssc.remove_source(source_id)
-                    if not removed:
+                    if ssc.get_source(source_id) is None:
                         raise ValueError(f"Source '{source_id}' not found")
-                    # remove_source calls save() internally
+                    # Same removal as 'skill-source remove': config, pin,
+                    # cache and the deployed skills only this source provided
+                    manager = GitSkillSourceManager(ssc, lock=SkillsLock())
+                    result = manager.remove_source(source_id)
 
-                return config_path
+                return config_path, result["skills_removed"]
 
-            written_config_path = await asyncio.to_thread(_remove)
+            written_config_path, removed_skills = await asyncio.to_thread(_remove)
 
             # Update mtime
             _watcher.update_mtime(written_config_path)
@@ -403,6 +409,7 @@ def register_source_routes(app, config_event_handler, config_file_watcher):
                     "success": True,
                     "message": f"Source '{source_id}' removed",
                     "orphaned_items": [],
+                    "removed_skills": removed_skills,
                 }
             )
 
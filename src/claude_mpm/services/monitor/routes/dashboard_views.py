@@ -0,0 +1,71 @@
+"""Saved view API routes for the Claude MPM Dashboard.
+
+Lets the dashboard list, save and delete the user's saved views (see
+services/dashboard_views.py).
+
+Views belong to the user rather than the project the monitor was started
+in, so every project's dashboard offers the same views.
+"""
+
+import asyncio
+from urllib.parse import urlparse
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.dashboard_views import DashboardView, DashboardViews
+
+logger = get_logger(__name__)
+
+
+def register_dashboard_view_routes(app: web.Application) -> None:
+    """Register saved view routes on the aiohttp app."""
+    app.router.add_get("/api/views", handle_list)
+    app.router.add_put("/api/views/{name}", handle_save)
+    app.router.add_delete("/api/views/{name}", handle_delete)
+    logger.info("Registered 3 saved view routes under /api/views")
+
+
+def _cross_origin(request: web.Request) -> bool:
+    origin = request.headers.get("Origin")
+    return bool(origin) and urlparse(origin).netloc != request.host
+
+
+def _refused() -> web.Response:
+    return web.json_response(
+        {"success": False, "error": "Cross-origin request refused"}, status=403
+    )
+
+
+async def handle_list(request: web.Request) -> web.Response:
+    """GET /api/views - Saved views, by name."""
+    views = await asyncio.to_thread(DashboardViews().all)
+    return web.json_response(
+        {"success": True, "views": [view.to_dict() for view in views]}
+    )
+
+
+async def handle_save(request: web.Request) -> web.Response:
+    """PUT /api/views/{name} {tab, project, label, ...} - Save a view."""
+    if _cross_origin(request):
+        return _refused()
+    try:
+        body = await request.json()
+        if not isinstance(body, dict):
+            raise ValueError("A view must be a JSON object")
+        view = DashboardView.from_dict({**body, "name": request.match_info["name"]})
+        view = await asyncio.to_thread(DashboardViews().save, view)
+    except (ValueError, TypeError) as e:
+        return web.json_response({"success": False, "error": str(e)}, status=400)
+    return web.json_response({"success": True, "view": view.to_dict()})
+
+
+async def handle_delete(request: web.Request) -> web.Response:
+    """DELETE /api/views/{name} - Delete a saved view."""
+    if _cross_origin(request):
+        return _refused()
+    if not await asyncio.to_thread(DashboardViews().delete, request.match_info["name"]):
+        return web.json_response(
+            {"success": False, "error": "View not found"}, status=404
+        )
+    return web.json_response({"success": True})
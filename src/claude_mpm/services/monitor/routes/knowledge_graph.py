@@ -0,0 +1,84 @@
+"""Knowledge graph API routes for the Claude MPM Dashboard.
+
+Lets the dashboard browse the project knowledge graph (services, endpoints,
+schemas, owners) that agents query through the knowledge-graph MCP server.
+
+Graph loading and extraction are blocking filesystem work and run in
+asyncio.to_thread(). The graph belongs to the directory the monitor was
+started in, matching /api/working-directory.
+"""
+
+import asyncio
+from pathlib import Path
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.knowledge_graph import ENTITY_TYPES, load_or_build
+
+logger = get_logger(__name__)
+
+MAX_LIMIT = 500
+
+
+def register_knowledge_graph_routes(app: web.Application) -> None:
+    """Register knowledge graph routes on the aiohttp app."""
+    app.router.add_get("/api/knowledge-graph", handle_graph)
+    app.router.add_get("/api/knowledge-graph/entity", handle_entity)
+    app.router.add_post("/api/knowledge-graph/rebuild", handle_rebuild)
+    logger.info("Registered 3 knowledge graph routes under /api/knowledge-graph")
+
+
+async def handle_graph(request: web.Request) -> web.Response:
+    """GET /api/knowledge-graph?q=&type=&limit= - Summary and matching entities."""
+    entity_type = request.query.get("type") or None
+    if entity_type and entity_type not in ENTITY_TYPES:
+        return web.json_response(
+            {"success": False, "error": f"Unknown entity type: {entity_type}"},
+            status=400,
+        )
+    try:
+        limit = min(int(request.query.get("limit", "200")), MAX_LIMIT)
+    except ValueError:
+        return web.json_response(
+            {"success": False, "error": "limit must be an integer"}, status=400
+        )
+    try:
+        graph = await asyncio.to_thread(load_or_build, Path.cwd())
+        entities = graph.search(request.query.get("q", ""), entity_type, limit)
+        return web.json_response(
+            {
+                "success": True,
+                "summary": graph.summary(),
+                "entities": [e.to_dict() for e in entities],
+            }
+        )
+    except Exception as e:
+        logger.error(f"Error loading knowledge graph: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
+
+
+async def handle_entity(request: web.Request) -> web.Response:
+    """GET /api/knowledge-graph/entity?id= - One entity with its relations."""
+    entity_id = request.query.get("id", "")
+    try:
+        graph = await asyncio.to_thread(load_or_build, Path.cwd())
+    except Exception as e:
+        logger.error(f"Error loading knowledge graph: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
+    entity = graph.describe(entity_id)
+    if entity is None:
+        return web.json_response(
+            {"success": False, "error": f"Entity not found: {entity_id}"}, status=404
+        )
+    return web.json_response({"success": True, "entity": entity})
+
+
+async def handle_rebuild(request: web.Request) -> web.Response:
+    """POST /api/knowledge-graph/rebuild - Re-extract the graph from the code."""
+    try:
+        graph = await asyncio.to_thread(load_or_build, Path.cwd(), True)
+        return web.json_response({"success": True, "summary": graph.summary()})
+    except Exception as e:
+        logger.error(f"Error rebuilding knowledge graph: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
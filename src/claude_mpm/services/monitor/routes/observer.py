@@ -0,0 +1,170 @@
+"""Observer link routes for the Claude MPM Dashboard.
+
+``/observe/{token}`` serves the read-only observer page; the page itself
+receives events from the observer Socket.IO server under
+``/observe/socket.io/`` (see services/monitor/observers.py).
+``/api/observers`` lets the dashboard create, list and revoke links.
+
+Links are kept in the project the monitor was started in, matching
+/api/working-directory.
+"""
+
+from pathlib import Path
+from urllib.parse import urlparse
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.monitor.observers import DEFAULT_HOURS, ObserverLinks
+
+logger = get_logger(__name__)
+
+
+def register_observer_routes(app: web.Application) -> None:
+    """Register observer link routes on the aiohttp app."""
+    app.router.add_get("/observe/{token}", handle_page)
+    app.router.add_get("/api/observers", handle_list)
+    app.router.add_post("/api/observers", handle_create)
+    app.router.add_delete("/api/observers/{link_id}", handle_revoke)
+    logger.info("Registered 4 observer routes under /observe and /api/observers")
+
+
+async def handle_page(request: web.Request) -> web.Response:
+    """GET /observe/{token} - The observer page, if the link is valid."""
+    if ObserverLinks(Path.cwd()).verify(request.match_info["token"]) is None:
+        return web.Response(
+            text="This observer link is invalid or has expired.", status=404
+        )
+    return web.Response(
+        text=OBSERVER_PAGE,
+        content_type="text/html",
+        headers={"Cache-Control": "no-store", "Referrer-Policy": "no-referrer"},
+    )
+
+
+def _same_origin(request: web.Request) -> bool:
+    """Whether the request comes from the dashboard itself (or no browser).
+
+    The dashboard allows any origin, so link management checks it here.
+    """
+    origin = request.headers.get("Origin")
+    return not origin or urlparse(origin).netloc == request.host
+
+
+def _cross_origin_refused() -> web.Response:
+    return web.json_response(
+        {"success": False, "error": "Cross-origin request refused"}, status=403
+    )
+
+
+async def handle_list(request: web.Request) -> web.Response:
+    """GET /api/observers - Unexpired links (without their tokens)."""
+    if not _same_origin(request):
+        return _cross_origin_refused()
+    links = ObserverLinks(Path.cwd()).active()
+    return web.json_response(
+        {"success": True, "links": [link.to_dict() for link in links]}
+    )
+
+
+async def handle_create(request: web.Request) -> web.Response:
+    """POST /api/observers {session_id, hours?, label?} - A new link."""
+    if not _same_origin(request):
+        return _cross_origin_refused()
+    try:
+        body = await request.json()
+        link, token = ObserverLinks(Path.cwd()).create(
+            str(body.get("session_id") or ""),
+            hours=float(body.get("hours") or DEFAULT_HOURS),
+            label=str(body.get("label") or ""),
+        )
+    except (ValueError, TypeError, AttributeError) as e:
+        return web.json_response({"success": False, "error": str(e)}, status=400)
+    url = f"{request.scheme}://{request.host}/observe/{token}"
+    return web.json_response(
+        {"success": True, "link": link.to_dict(), "url": url}, status=201
+    )
+
+
+async def handle_revoke(request: web.Request) -> web.Response:
+    """DELETE /api/observers/{link_id} - Revoke a link."""
+    if not _same_origin(request):
+        return _cross_origin_refused()
+    if not ObserverLinks(Path.cwd()).revoke(request.match_info["link_id"]):
+        return web.json_response(
+            {"success": False, "error": "Link not found"}, status=404
+        )
+    return web.json_response({"success": True})
+
+
+# Rendered with textContent only: event data never becomes markup
+OBSERVER_PAGE = """<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="referrer" content="no-referrer">
+<title>Claude MPM - Observing session</title>
+<script src="https://cdn.socket.io/4.7.2/socket.io.min.js"
+  integrity="sha384-mZLF4UVrpi/QTWPA7BjNPEnkIfRFn4ZEO3Qt/HFklTJBj/gBOV8G3HcKn4NfQblz"
+  crossorigin="anonymous"></script>
+<style>
+  body { font: 13px/1.5 ui-monospace, Menlo, monospace; margin: 0;
+         background: #0f172a; color: #e2e8f0; }
+  header { padding: 10px 16px; background: #1e293b; position: sticky; top: 0; }
+  #status { color: #94a3b8; margin-left: 12px; }
+  #events { padding: 8px 16px; }
+  .event { border-bottom: 1px solid #1e293b; padding: 4px 0;
+           white-space: pre-wrap; word-break: break-word; }
+  .time { color: #64748b; margin-right: 8px; }
+  .kind { color: #38bdf8; margin-right: 8px; }
+</style>
+</head>
+<body>
+<header>Observing <strong id="session">session</strong>
+  <span id="status">connecting...</span></header>
+<div id="events"></div>
+<script>
+  const token = location.pathname.split("/").pop();
+  const list = document.getElementById("events");
+  const status = document.getElementById("status");
+  const socket = io("/observe", { path: "/observe/socket.io", auth: { token } });
+
+  function summary(data) {
+    const input = data.tool_input || {};
+    const text = data.prompt || input.command || input.file_path ||
+      input.description || data.output || data.response || data.message || "";
+    return typeof text === "string" ? text : JSON.stringify(text);
+  }
+
+  function show(event) {
+    const data = event.data || {};
+    const row = document.createElement("div");
+    row.className = "event";
+    const time = document.createElement("span");
+    time.className = "time";
+    time.textContent = new Date(event.timestamp || Date.now()).toLocaleTimeString();
+    const kind = document.createElement("span");
+    kind.className = "kind";
+    kind.textContent = [event.subtype || event.type, data.tool_name]
+      .filter(Boolean).join(" ");
+    row.append(time, kind, document.createTextNode(summary(data).slice(0, 2000)));
+    const atBottom = innerHeight + scrollY >= document.body.scrollHeight - 40;
+    list.append(row);
+    if (atBottom) scrollTo(0, document.body.scrollHeight);
+  }
+
+  socket.on("connect", () => { status.textContent = "live (read-only)"; });
+  socket.on("disconnect", () => { status.textContent = "disconnected"; });
+  socket.on("connect_error", () => {
+    status.textContent = "this link is invalid, expired or revoked";
+  });
+  socket.on("observer:session", (link) => {
+    document.getElementById("session").textContent = link.label || link.session_id;
+  });
+  socket.onAny((name, event) => {
+    if (name !== "observer:session" && event && typeof event === "object") show(event);
+  });
+</script>
+</body>
+</html>
+"""
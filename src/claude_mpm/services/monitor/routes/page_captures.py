@@ -0,0 +1,64 @@
+"""Page capture API routes for the Claude MPM Dashboard.
+
+Serves the screenshots and DOM snapshots agents take through the
+page-capture MCP server so the dashboard's Captures tab can show them.
+
+Captures are read from the project the monitor was started in, matching
+/api/working-directory. Session and capture ids from the URL are sanitised
+by PageCaptureService before touching the filesystem.
+"""
+
+import asyncio
+from pathlib import Path
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.page_capture import DOM, SCREENSHOT, PageCaptureService
+
+logger = get_logger(__name__)
+
+
+def register_page_capture_routes(app: web.Application) -> None:
+    """Register page capture routes on the aiohttp app."""
+    app.router.add_get("/api/captures", handle_list)
+    app.router.add_get(
+        "/api/captures/{session_id}/{capture_id}/{kind}", handle_artifact
+    )
+    logger.info("Registered 2 page capture routes under /api/captures")
+
+
+async def handle_list(request: web.Request) -> web.Response:
+    """GET /api/captures?session= - Stored captures, newest first."""
+    service = PageCaptureService(Path.cwd())
+    try:
+        captures = await asyncio.to_thread(
+            service.captures, request.query.get("session") or None
+        )
+    except Exception as e:
+        logger.error(f"Error listing page captures: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
+    return web.json_response(
+        {"success": True, "captures": [c.to_dict() for c in captures]}
+    )
+
+
+async def handle_artifact(request: web.Request) -> web.StreamResponse:
+    """GET /api/captures/{session_id}/{capture_id}/{screenshot|dom} - The file."""
+    kind = request.match_info["kind"]
+    if kind not in (SCREENSHOT, DOM):
+        return web.json_response(
+            {"success": False, "error": f"Unknown artifact: {kind}"}, status=400
+        )
+    service = PageCaptureService(Path.cwd())
+    capture = service.get(
+        request.match_info["session_id"], request.match_info["capture_id"]
+    )
+    path = service.artifact_path(capture, kind) if capture else None
+    if path is None:
+        return web.json_response(
+            {"success": False, "error": "Capture not found"}, status=404
+        )
+    # DOM snapshots are served as text so the page is never rendered here
+    content_type = "image/png" if kind == SCREENSHOT else "text/plain"
+    return web.FileResponse(path, headers={"Content-Type": content_type})
@@ -0,0 +1,45 @@
+"""Session label API routes for the Claude MPM Dashboard.
+
+Serves the labels the dashboard filters its session list by (see
+services/session_labels.py).
+
+Labels are read from the project the monitor was started in, matching
+/api/working-directory.
+"""
+
+import asyncio
+from pathlib import Path
+
+from aiohttp import web
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.session_labels import SessionLabels
+
+logger = get_logger(__name__)
+
+
+def register_session_label_routes(app: web.Application) -> None:
+    """Register session label routes on the aiohttp app."""
+    app.router.add_get("/api/session-labels", handle_list)
+    logger.info("Registered 1 session label route under /api/session-labels")
+
+
+def _labels() -> dict:
+    store = SessionLabels(Path.cwd())
+    return {
+        "sessions": store.all(),
+        "counts": store.counts(),
+        "taxonomy": store.taxonomy(),
+    }
+
+
+async def handle_list(request: web.Request) -> web.Response:
+    """GET /api/session-labels - Labels by session id, and label counts."""
+    try:
+        data = await asyncio.to_thread(_labels)
+    except ValueError as e:
+        return web.json_response({"success": False, "error": str(e)}, status=400)
+    except Exception as e:
+        logger.error(f"Error reading session labels: {e}")
+        return web.json_response({"success": False, "error": str(e)}, status=500)
+    return web.json_response({"success": True, **data})
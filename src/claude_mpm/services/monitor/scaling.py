@@ -0,0 +1,126 @@
+"""
+Multi-process scaling for the event server.
+
+WHAT: Lets several event server processes (on one host or many) act as one
+behind a load balancer. With ``event_server.redis_url`` set, every process
+attaches a Redis client manager to its Socket.IO server, so an event that
+arrives at any process (a hook POST to ``/api/events``, an emit from a
+handler) reaches dashboard clients connected to every other process.
+
+WHY: A team deployment with dozens of simultaneous sessions overwhelms one
+event server process; extra processes only help if each one sees all events.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    event_server:
+      redis_url: redis://redis.internal:6379/0   # or CLAUDE_MPM_EVENT_REDIS_URL
+      channel: claude-mpm-events                 # pub/sub channel name
+      node_id: null                              # default: <hostname>-<pid>
+
+DESIGN DECISIONS:
+- Uses python-socketio's own ``AsyncRedisManager`` rather than a custom
+  relay: rooms, broadcasts and emits to a single sid all work across
+  processes without changes to the handlers
+- Sticky sessions are the load balancer's job (Socket.IO's HTTP long-polling
+  needs every request of a session on the same process); each response
+  carries ``X-MPM-Node`` so affinity can be checked from the browser
+- A configured Redis URL that cannot be used is an error, not a silent fall
+  back to one process, which would drop events without any sign
+"""
+
+from __future__ import annotations
+
+import os
+import socket
+from dataclasses import dataclass, field
+from typing import Any
+
+from ...core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "event_server"
+REDIS_ENV_VAR = "CLAUDE_MPM_EVENT_REDIS_URL"
+NODE_HEADER = "X-MPM-Node"
+DEFAULT_CHANNEL = "claude-mpm-events"
+
+
+def _default_node_id() -> str:
+    return f"{socket.gethostname()}-{os.getpid()}"
+
+
+@dataclass
+class ScalingConfig:
+    """How this event server process shares events with its peers."""
+
+    redis_url: str | None = None
+    channel: str = DEFAULT_CHANNEL
+    node_id: str = field(default_factory=_default_node_id)
+
+    @property
+    def enabled(self) -> bool:
+        return bool(self.redis_url)
+
+    @classmethod
+    def load(
+        cls, config: Any = None, environ: dict[str, str] | None = None
+    ) -> ScalingConfig:
+        environ = os.environ if environ is None else environ
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ...core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return cls(
+            redis_url=environ.get(REDIS_ENV_VAR) or section.get("redis_url"),
+            channel=section.get("channel") or DEFAULT_CHANNEL,
+            node_id=section.get("node_id") or _default_node_id(),
+        )
+
+    def client_manager(self, channel: str | None = None) -> Any:
+        """The Socket.IO client manager for this process, or None for local.
+
+        Each Socket.IO server needs its own *channel* (``channel`` from the
+        config by default) so their events are not mixed.
+
+        Raises:
+            RuntimeError: If a Redis URL is configured but the redis package
+                is missing.
+        """
+        if not self.enabled:
+            return None
+        import socketio
+
+        try:
+            manager = socketio.AsyncRedisManager(
+                self.redis_url, channel=channel or self.channel
+            )
+        except RuntimeError as e:
+            raise RuntimeError(
+                f"event_server.redis_url is set but Redis is unavailable ({e}); "
+                "install it with: pip install 'claude-mpm[queue]'"
+            ) from e
+        logger.info(
+            f"Event server node {self.node_id} sharing events over "
+            f"{self.redis_url} ({channel or self.channel})"
+        )
+        return manager
+
+    def middleware(self) -> Any:
+        """aiohttp middleware that tags responses with this node's id."""
+        from aiohttp import web
+
+        node_id = self.node_id
+
+        @web.middleware
+        async def node_header(request, handler):
+            response = await handler(request)
+            if not response.prepared:  # websocket responses are already sent
+                response.headers[NODE_HEADER] = node_id
+            return response
+
+        return node_header
@@ -33,6 +33,8 @@ from .handlers.code_analysis import CodeAnalysisHandler
 from .handlers.dashboard import DashboardHandler
 from .handlers.file import FileHandler
 from .handlers.hooks import HookHandler
+from .observers import SOCKETIO_PATH, ObserverHub
+from .scaling import ScalingConfig
 
 # EventBus integration
 try:
@@ -170,6 +172,10 @@ class UnifiedMonitorServer:
         # High-performance event emitter
         self.event_emitter = None
 
+        # Read-only observers of single sessions
+        self.observer_sio = None
+        self.observer_hub: ObserverHub | None = None
+
         # File watching (optional for dev mode)
         self.file_observer: Observer | None = None
         self.file_watcher: SvelteBuildWatcher | None = None
@@ -179,6 +185,7 @@ class UnifiedMonitorServer:
         self.loop = None
         self.server_thread = None
         self.startup_error = None  # Track startup errors
+        self.scaling = ScalingConfig()
 
         # Heartbeat tracking
         self.heartbeat_task: asyncio.Task | None = None
@@ -299,8 +306,12 @@ class UnifiedMonitorServer:
     async def _start_async_server(self):
         """Start the async server components."""
         try:
+            # Share events with peer processes when running behind a balancer
+            self.scaling = ScalingConfig.load()
+
             # Create Socket.IO server with proper ping configuration
             self.sio = socketio.AsyncServer(
+                client_manager=self.scaling.client_manager(),
                 cors_allowed_origins="*",
                 logger=True,  # Enable to see Socket.IO events and connection lifecycle
                 engineio_logger=True,  # Enable to see Engine.IO protocol handshake details
@@ -324,13 +335,28 @@ class UnifiedMonitorServer:
                 return response
 
             # Create aiohttp application with CORS middleware
-            self.app = web.Application(middlewares=[cors_middleware])
+            self.app = web.Application(
+                middlewares=[cors_middleware, self.scaling.middleware()]
+            )
 
             # Attach Socket.IO to the app
             self.sio.attach(self.app)
 
             # Setup event handlers
             self._setup_event_handlers()
+            # Observers get a server of their own: the default one above
+            # broadcasts every session to any client, without authentication
+            self.observer_sio = socketio.AsyncServer(
+                client_manager=self.scaling.client_manager(
+                    f"{self.scaling.channel}-observe"
+                ),
+                cors_allowed_origins="*",
+                ping_interval=30,
+                ping_timeout=60,
+            )
+            self.observer_sio.attach(self.app, socketio_path=SOCKETIO_PATH)
+            self.observer_hub = ObserverHub(self.observer_sio)
+            self.observer_hub.register()
 
             # Setup high-performance event emitter
             await self._setup_event_emitter()
@@ -371,6 +397,14 @@ class UnifiedMonitorServer:
 
                 self.running = True
                 self.logger.info(f"Server running on http://{self.host}:{self.port}")
+
+                # Publish this server so `dashboard open` and other projects'
+                # clients can find it without guessing the port
+                from ..server_discovery import ServerDiscoveryRegistry
+
+                ServerDiscoveryRegistry().register(
+                    Path.cwd(), self.port, pid=os.getpid(), host=self.host
+                )
             except OSError as e:
                 # Port binding error - make sure it's reported clearly
                 # Check for common port binding errors
@@ -591,6 +625,7 @@ class UnifiedMonitorServer:
                         "version": version,
                         "port": self.port,
                         "pid": os.getpid(),
+                        "node": self.scaling.node_id,
                         "uptime": int(time.time() - self.server_start_time),
                     }
                 )
@@ -697,6 +732,8 @@ class UnifiedMonitorServer:
                     # Emit to Socket.IO clients via the categorized event type
                     if self.sio:
                         await self.sio.emit(event_type, wrapped_event)
+                        if self.observer_hub:
+                            await self.observer_hub.relay(event_type, wrapped_event)
                         self.logger.debug(
                             f"HTTP event forwarded to Socket.IO: {event} -> {event_type}"
                         )
@@ -1021,6 +1058,19 @@ class UnifiedMonitorServer:
                     )
                 raise web.HTTPNotFound()
 
+            # Local server discovery endpoint (lists every project's server)
+            async def servers_handler(request):
+                """List all live claude-mpm servers on this machine."""
+                from ..server_discovery import ServerDiscoveryRegistry
+
+                registry = ServerDiscoveryRegistry()
+                current = str(Path.cwd().resolve())
+                servers = [
+                    {**info, "current": info.get("project_root") == current}
+                    for info in registry.list_servers()
+                ]
+                return web.json_response({"servers": servers})
+
             # Version endpoint for dashboard build tracker
             async def version_handler(request):
                 """Serve version information for dashboard build tracker."""
@@ -1339,6 +1389,7 @@ class UnifiedMonitorServer:
             self.app.router.add_get("/favicon.svg", favicon_handler)
             self.app.router.add_get("/health", health_check)
             self.app.router.add_get("/version.json", version_handler)
+            self.app.router.add_get("/api/servers", servers_handler)
             self.app.router.add_get("/api/config", config_handler)
             self.app.router.add_get("/api/working-directory", working_directory_handler)
             self.app.router.add_get("/api/files", api_files_handler)
@@ -1461,6 +1512,62 @@ class UnifiedMonitorServer:
                 self.app, self.config_event_handler, self.config_file_watcher
             )
 
+            # Register knowledge graph browsing routes
+            from claude_mpm.services.monitor.routes.knowledge_graph import (
+                register_knowledge_graph_routes,
+            )
+
+            register_knowledge_graph_routes(self.app)
+
+            # Register page capture (screenshot/DOM) routes
+            from claude_mpm.services.monitor.routes.page_captures import (
+                register_page_capture_routes,
+            )
+
+            register_page_capture_routes(self.app)
+
+            # Register session artifact listing/download routes
+            from claude_mpm.services.monitor.routes.artifacts import (
+                register_artifact_routes,
+            )
+
+            register_artifact_routes(self.app)
+
+            # Register read-only observer link routes
+            from claude_mpm.services.monitor.routes.observer import (
+                register_observer_routes,
+            )
+
+            register_observer_routes(self.app)
+
+            # Register session comment routes
+            from claude_mpm.services.monitor.routes.annotations import (
+                register_annotation_routes,
+            )
+
+            register_annotation_routes(self.app)
+
+            # Register onboarding checklist routes
+            from claude_mpm.services.monitor.routes.checklist import (
+                register_checklist_routes,
+            )
+
+            register_checklist_routes(self.app)
+
+            # Register session label routes
+            from claude_mpm.services.monitor.routes.session_labels import (
+                register_session_label_routes,
+            )
+
+            register_session_label_routes(self.app)
+
+            # Register saved dashboard view routes
+            from claude_mpm.services.monitor.routes.dashboard_views import (
+                register_dashboard_view_routes,
+            )
+
+            register_dashboard_view_routes(self.app)
+
             self.logger.info("HTTP routes registered successfully")
 
         except Exception as e:
@@ -1571,6 +1678,12 @@ class UnifiedMonitorServer:
     async def _cleanup_async(self):
         """Cleanup async resources."""
         try:
+            # Withdraw from local discovery before tearing anything down
+            if self.running or self.site:
+                from ..server_discovery import ServerDiscoveryRegistry
+
+                ServerDiscoveryRegistry().unregister(Path.cwd(), pid=os.getpid())
+
             # Stop file observer if running
             # STABILITY FIX: Ensure watcher is stopped and verify observer termination
             if self.file_observer:
@@ -1613,6 +1726,13 @@ class UnifiedMonitorServer:
                     self.logger.debug(f"Error shutting down Socket.IO: {e}")
                 finally:
                     self.sio = None
+            if self.observer_sio:
+                try:
+                    await self.observer_sio.shutdown()
+                except Exception as e:
+                    self.logger.debug(f"Error shutting down observer Socket.IO: {e}")
+                finally:
+                    self.observer_sio = None
 
             # Cleanup event emitter
             if self.event_emitter:
@@ -0,0 +1,379 @@
+"""
+Ownership routing: who owns a file, a line range or a change.
+
+WHAT: Resolves the owners of repository paths from, in order:
+
+- the ``paths`` rules of the ownership mapping file (.claude-mpm/owners.yaml)
+- CODEOWNERS (``CODEOWNERS``, ``.github/CODEOWNERS``, ``docs/CODEOWNERS``)
+- ``git blame`` of the file, or of just the referenced lines, with author
+  emails mapped to handles through the mapping file's ``authors`` table
+
+It also finds the files a piece of text refers to (``src/app.py:42``) and the
+files a branch changes, which is what the ownership hook routes tickets,
+findings and review requests with.
+
+WHY: Tickets and review requests that agents open land unassigned, and
+someone has to triage each one. The repository already records who owns
+what; routing from it is cheaper and more consistent than asking the agent.
+
+CONFIGURATION (``ownership`` in ~/.claude-mpm/config/configuration.yaml,
+overridden per key by <project>/.claude-mpm/configuration.yaml)::
+
+    ownership:
+      enabled: true
+      mapping_file: .claude-mpm/owners.yaml
+      blame: true             # fall back to git blame when nothing matches
+      assign_tickets: true    # gh issue create, GitHub and mcp-ticketer tools
+      request_reviews: true   # gh pr create
+      max_assignees: 1
+      max_reviewers: 3
+
+Mapping file::
+
+    paths:              # checked before CODEOWNERS; the last match wins
+      "src/billing/": ["@org/payments"]
+    authors:            # git author email -> owner
+      alice@example.com: "@alice"
+    assignees:          # owner -> ticket assignee (teams cannot be assigned)
+      "@org/payments": "@bob"
+
+DESIGN DECISIONS:
+- Patterns follow GitHub's CODEOWNERS rules: a pattern without a slash
+  matches at any depth, a leading slash anchors it at the root, and a
+  matching rule with no owners leaves the path explicitly unowned
+- Blame picks the author of most of the lines; authors missing from
+  ``authors`` stay as their email, which ticket systems accept but GitHub
+  reviewer and assignee lists do not
+"""
+
+from __future__ import annotations
+
+import logging
+import re
+import subprocess  # nosec B404
+from collections import Counter
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+logger = logging.getLogger(__name__)
+
+CONFIG_SECTION = "ownership"
+DEFAULT_MAPPING_FILE = ".claude-mpm/owners.yaml"
+CODEOWNERS_LOCATIONS = ("CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS")
+BASE_CANDIDATES = ("origin/HEAD", "origin/main", "origin/master", "main", "master")
+GIT_TIMEOUT = 10
+
+# "src/app.py", "src/app.py:42", "src/app.py:40-55", "src/app.py#L40-L55"
+_FILE_REF_RE = re.compile(
+    r"(?<![\w/.-])((?:[\w.-]+/)*[\w-][\w.-]*\.[A-Za-z]\w*)"
+    r"(?:(?::|#L)(\d+)(?:-L?(\d+))?)?"
+)
+
+
+@dataclass
+class OwnershipConfig:
+    enabled: bool = False
+    mapping_file: str = DEFAULT_MAPPING_FILE
+    blame: bool = True
+    assign_tickets: bool = True
+    request_reviews: bool = True
+    max_assignees: int = 1
+    max_reviewers: int = 3
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> OwnershipConfig:
+        config = cls()
+        for key, value in data.items():
+            if hasattr(config, key):
+                setattr(config, key, value)
+        return config
+
+
+@dataclass
+class OwnerRule:
+    pattern: str
+    owners: list[str]
+
+
+@dataclass
+class FileRef:
+    """A file mentioned in text, optionally narrowed to lines."""
+
+    path: str
+    start: int | None = None
+    end: int | None = None
+
+
+@dataclass
+class Ownership:
+    """The owners of one path and where they came from."""
+
+    path: str
+    owners: list[str] = field(default_factory=list)
+    source: str = ""  # "mapping", "CODEOWNERS", "blame" or "" when unowned
+    rule: str | None = None  # the matching pattern (None for blame)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "path": self.path,
+            "owners": self.owners,
+            "source": self.source,
+            "rule": self.rule,
+        }
+
+
+@dataclass
+class Route:
+    """An owner picked for a set of files."""
+
+    owner: str
+    paths: list[str]
+    source: str
+
+
+# ---------------------------------------------------------------------------
+# Configuration
+# ---------------------------------------------------------------------------
+
+_CONFIG_CACHE: dict[str, OwnershipConfig] = {}
+
+
+def _load_yaml(path: Path) -> dict[str, Any]:
+    if not path.is_file():
+        return {}
+    try:
+        data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+    except Exception as e:
+        logger.debug("Could not read %s: %s", path, e)
+        return {}
+    return data if isinstance(data, dict) else {}
+
+
+def load_ownership_config(project_dir: str | Path | None) -> OwnershipConfig:
+    """The ownership configuration for a project: user file, then project file."""
+    key = str(project_dir or "")
+    if key not in _CONFIG_CACHE:
+        paths = [Path.home() / ".claude-mpm" / "config" / "configuration.yaml"]
+        if project_dir:
+            paths.append(Path(project_dir) / ".claude-mpm" / "configuration.yaml")
+        merged: dict[str, Any] = {}
+        for path in paths:
+            section = _load_yaml(path).get(CONFIG_SECTION)
+            merged.update(section if isinstance(section, dict) else {})
+        _CONFIG_CACHE[key] = OwnershipConfig.from_dict(merged)
+    return _CONFIG_CACHE[key]
+
+
+def find_project_root(path: str | Path) -> Path:
+    """The repository containing *path* (*path* itself outside a repository)."""
+    path = Path(path).resolve()
+    for directory in (path, *path.parents):
+        if (directory / ".git").exists():
+            return directory
+    return path
+
+
+# ---------------------------------------------------------------------------
+# Patterns and references
+# ---------------------------------------------------------------------------
+
+
+def _translate(pattern: str) -> str:
+    out, i = [], 0
+    while i < len(pattern):
+        if pattern.startswith("**/", i):
+            out.append("(?:.*/)?")
+            i += 3
+        elif pattern.startswith("**", i):
+            out.append(".*")
+            i += 2
+        elif pattern[i] == "*":
+            out.append("[^/]*")
+            i += 1
+        elif pattern[i] == "?":
+            out.append("[^/]")
+            i += 1
+        else:
+            out.append(re.escape(pattern[i]))
+            i += 1
+    return "".join(out)
+
+
+def pattern_matches(pattern: str, path: str) -> bool:
+    """Whether a CODEOWNERS pattern covers *path* (relative, with slashes)."""
+    path = path.strip("/")
+    anchored = "/" in pattern.rstrip("/")
+    body = pattern.strip("/")
+    if not body:
+        return False
+    if pattern.endswith("/"):
+        suffix = "/.*"  # a directory: everything beneath it
+    elif body.endswith("/*"):
+        suffix = ""  # "docs/*": direct children only
+    else:
+        suffix = "(?:/.*)?"
+    prefix = "" if anchored else "(?:.*/)?"
+    return re.fullmatch(prefix + _translate(body) + suffix, path) is not None
+
+
+def parse_codeowners(text: str) -> list[OwnerRule]:
+    """The rules of a CODEOWNERS file, in file order."""
+    rules = []
+    for line in text.splitlines():
+        parts = line.split("#", 1)[0].split()
+        if parts:
+            rules.append(OwnerRule(parts[0], parts[1:]))
+    return rules
+
+
+def find_file_refs(text: str, project_dir: str | Path) -> list[FileRef]:
+    """Files of the project that *text* mentions, in order, without repeats."""
+    root = Path(project_dir)
+    refs: dict[tuple[str, int | None, int | None], FileRef] = {}
+    for match in _FILE_REF_RE.finditer(text or ""):
+        path = match.group(1).removeprefix("./")
+        if not (root / path).is_file():
+            continue
+        start = int(match.group(2)) if match.group(2) else None
+        end = int(match.group(3)) if match.group(3) else start
+        refs.setdefault((path, start, end), FileRef(path, start, end))
+    return list(refs.values())
+
+
+# ---------------------------------------------------------------------------
+# Resolver
+# ---------------------------------------------------------------------------
+
+
+class OwnershipResolver:
+    """Resolve and route owners for the files of one repository."""
+
+    def __init__(self, project_dir: str | Path, config: OwnershipConfig | None = None):
+        self.project_dir = Path(project_dir)
+        self.config = config or load_ownership_config(self.project_dir)
+        self._mapping: dict[str, Any] | None = None
+        self._codeowners: list[OwnerRule] | None = None
+
+    # -- sources -----------------------------------------------------------
+
+    @property
+    def mapping(self) -> dict[str, Any]:
+        if self._mapping is None:
+            self._mapping = _load_yaml(self.project_dir / self.config.mapping_file)
+        return self._mapping
+
+    def path_rules(self) -> list[OwnerRule]:
+        rules = []
+        for pattern, owners in (self.mapping.get("paths") or {}).items():
+            owners = [owners] if isinstance(owners, str) else list(owners or [])
+            rules.append(OwnerRule(str(pattern), [str(o) for o in owners]))
+        return rules
+
+    def codeowners(self) -> list[OwnerRule]:
+        if self._codeowners is None:
+            self._codeowners = []
+            for location in CODEOWNERS_LOCATIONS:
+                path = self.project_dir / location
+                if path.is_file():
+                    self._codeowners = parse_codeowners(
+                        path.read_text(encoding="utf-8")
+                    )
+                    break
+        return self._codeowners
+
+    def _git(self, *args: str) -> str | None:
+        try:
+            result = subprocess.run(  # nosec B603 B607
+                ["git", *args],
+                cwd=self.project_dir,
+                capture_output=True,
+                text=True,
+                timeout=GIT_TIMEOUT,
+                check=False,
+            )
+        except (OSError, subprocess.SubprocessError):
+            return None
+        return result.stdout if result.returncode == 0 else None
+
+    def blame_authors(
+        self, path: str, start: int | None = None, end: int | None = None
+    ) -> list[str]:
+        """Author emails of *path* (or its lines), most lines first."""
+        args = ["blame", "--line-porcelain"]
+        if start:
+            args += ["-L", f"{start},{end or start}"]
+        output = self._git(*args, "HEAD", "--", path)
+        if output is None:
+            return []
+        counts = Counter(
+            line[len("author-mail ") :].strip("<>")
+            for line in output.splitlines()
+            if line.startswith("author-mail ")
+        )
+        counts.pop("not.committed.yet", None)
+        return [author for author, _ in counts.most_common()]
+
+    # -- resolution --------------------------------------------------------
+
+    def owner_of_author(self, email: str) -> str:
+        """The owner an author email maps to (the email when unmapped)."""
+        authors = self.mapping.get("authors") or {}
+        return str(authors.get(email, email))
+
+    def owners_for(
+        self, path: str, start: int | None = None, end: int | None = None
+    ) -> Ownership:
+        """Owners of *path*, optionally narrowed to lines *start*-*end*."""
+        path = path.removeprefix("./")
+        for source, rules in (
+            ("mapping", self.path_rules()),
+            ("CODEOWNERS", self.codeowners()),
+        ):
+            matched = None
+            for rule in rules:
+                if pattern_matches(rule.pattern, path):
+                    matched = rule  # last match wins
+            if matched is not None:
+                return Ownership(path, matched.owners, source, matched.pattern)
+        if self.config.blame:
+            authors = self.blame_authors(path, start, end)
+            if authors:
+                return Ownership(path, [self.owner_of_author(authors[0])], "blame")
+        return Ownership(path)
+
+    def route(self, refs: list[FileRef]) -> list[Route]:
+        """Owners of *refs*, the owner of the most files first."""
+        routes: dict[str, Route] = {}
+        for ref in refs:
+            ownership = self.owners_for(ref.path, ref.start, ref.end)
+            for owner in ownership.owners:
+                route = routes.setdefault(owner, Route(owner, [], ownership.source))
+                if ref.path not in route.paths:
+                    route.paths.append(ref.path)
+        return sorted(routes.values(), key=lambda r: -len(r.paths))
+
+    def assignee_for(self, owner: str) -> str:
+        """The ticket assignee for *owner* (teams map to a person)."""
+        assignees = self.mapping.get("assignees") or {}
+        return str(assignees.get(owner, owner))
+
+    def current_owner(self) -> str | None:
+        """The owner the local git user maps to, if any."""
+        email = (self._git("config", "user.email") or "").strip()
+        return self.owner_of_author(email) if email else None
+
+    # -- changes -----------------------------------------------------------
+
+    def changed_files(self, base: str | None = None) -> list[str]:
+        """Files changed on this branch since it left *base*."""
+        candidates = (base, f"origin/{base}") if base else BASE_CANDIDATES
+        for candidate in candidates:
+            if self._git("rev-parse", "--verify", "--quiet", candidate) is None:
+                continue
+            output = self._git("diff", "--name-only", f"{candidate}...HEAD")
+            if output is not None:
+                return [line for line in output.splitlines() if line]
+        return []
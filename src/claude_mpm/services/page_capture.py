@@ -0,0 +1,237 @@
+"""Screenshots and DOM snapshots of local dev servers for frontend work.
+
+WHAT: Opens a local dev URL in headless Chromium (Playwright), waits for it
+to settle, and stores a PNG screenshot and/or the rendered HTML under
+.claude-mpm/captures/<session_id>/, with a JSON record of what was captured
+and any console errors the page logged.
+
+WHY: Agents changing UI code otherwise declare success without ever looking
+at the result. The page-capture MCP server lets them check their work
+visually, and the dashboard's Captures tab shows the user the same images.
+
+DESIGN DECISIONS:
+- Local URLs only (localhost, loopback, *.localhost): this is for checking a
+  dev server, not a general-purpose browser for fetching the internet
+- Playwright is an optional dependency imported on first capture; without it
+  the tool reports how to install it
+- Uses the sync Playwright API; async callers run captures in a worker
+  thread, as the MCP server does
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import re
+import uuid
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+from urllib.parse import urlsplit
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+SCREENSHOT = "screenshot"
+DOM = "dom"
+BOTH = "both"
+CAPTURE_MODES = (SCREENSHOT, DOM, BOTH)
+
+DEFAULT_VIEWPORT = (1280, 800)
+DEFAULT_TIMEOUT_MS = 30_000
+LOCAL_HOSTS = ("localhost", "127.0.0.1", "::1", "0.0.0.0")  # nosec B104
+
+_UNSAFE_CHARS = re.compile(r"[^A-Za-z0-9_.-]")
+
+
+class PageCaptureError(Exception):
+    """Raised when a URL is not allowed or the page cannot be captured."""
+
+
+def safe_path_component(value: str) -> str:
+    """``value`` usable as a single path component (no separators, no ``..``)."""
+    return _UNSAFE_CHARS.sub("_", value).lstrip(".") or "default"
+
+
+def captures_dir(project_dir: Path) -> Path:
+    return Path(project_dir) / ".claude-mpm" / "captures"
+
+
+def current_session_id() -> str:
+    """The Claude session the capture belongs to, or ``default``."""
+    return os.environ.get("CLAUDE_SESSION_ID") or "default"
+
+
+def is_local_url(url: str) -> bool:
+    parts = urlsplit(url)
+    if parts.scheme not in ("http", "https"):
+        return False
+    host = (parts.hostname or "").lower()
+    return host in LOCAL_HOSTS or host.endswith(".localhost")
+
+
+@dataclass
+class Capture:
+    """One screenshot and/or DOM snapshot of a page."""
+
+    id: str
+    session_id: str
+    url: str
+    created_at: str
+    mode: str
+    title: str = ""
+    status: int | None = None
+    viewport: list[int] = field(default_factory=lambda: list(DEFAULT_VIEWPORT))
+    selector: str | None = None
+    screenshot: str | None = None
+    dom: str | None = None
+    console_errors: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+class PageCaptureService:
+    """Captures pages and keeps the artifacts per session."""
+
+    def __init__(self, project_dir: Path):
+        self.project_dir = Path(project_dir)
+        self.root = captures_dir(self.project_dir)
+
+    def capture(
+        self,
+        url: str,
+        mode: str = BOTH,
+        session_id: str | None = None,
+        selector: str | None = None,
+        full_page: bool = False,
+        viewport: tuple[int, int] = DEFAULT_VIEWPORT,
+        wait_for: str | None = None,
+    ) -> Capture:
+        """Load ``url`` and store what ``mode`` asks for.
+
+        ``selector`` narrows both the screenshot and the DOM snapshot to one
+        element; ``wait_for`` is a selector that must appear before capturing.
+        """
+        if mode not in CAPTURE_MODES:
+            raise PageCaptureError(
+                f"Unknown mode '{mode}' (expected one of {', '.join(CAPTURE_MODES)})"
+            )
+        if not is_local_url(url):
+            raise PageCaptureError(
+                f"Only local dev URLs can be captured (http://localhost:PORT/...), "
+                f"got {url}"
+            )
+        try:
+            from playwright.sync_api import Error as PlaywrightError, sync_playwright
+        except ImportError as e:
+            raise PageCaptureError(
+                "Playwright is not installed. Run: pip install playwright && "
+                "playwright install chromium"
+            ) from e
+
+        session = safe_path_component(session_id or current_session_id())
+        capture = Capture(
+            id=f"{datetime.now(UTC):%Y%m%dT%H%M%S}-{uuid.uuid4().hex[:6]}",
+            session_id=session,
+            url=url,
+            created_at=datetime.now(UTC).isoformat(),
+            mode=mode,
+            viewport=list(viewport),
+            selector=selector,
+        )
+        directory = self.root / session
+        directory.mkdir(parents=True, exist_ok=True)
+        errors = capture.console_errors
+
+        def on_console(message) -> None:
+            if message.type == "error":
+                errors.append(message.text)
+
+        try:
+            with sync_playwright() as playwright:
+                browser = playwright.chromium.launch(headless=True)
+                try:
+                    page = browser.new_page(
+                        viewport={"width": viewport[0], "height": viewport[1]}
+                    )
+                    page.on("console", on_console)
+                    page.on("pageerror", lambda e: errors.append(str(e)))
+                    response = page.goto(
+                        url, wait_until="networkidle", timeout=DEFAULT_TIMEOUT_MS
+                    )
+                    capture.status = response.status if response else None
+                    if wait_for:
+                        page.wait_for_selector(wait_for, timeout=DEFAULT_TIMEOUT_MS)
+                    capture.title = page.title()
+                    target = page.locator(selector).first if selector else None
+
+                    if mode in (SCREENSHOT, BOTH):
+                        path = directory / f"{capture.id}.png"
+                        if target is not None:
+                            target.screenshot(path=str(path))
+                        else:
+                            page.screenshot(path=str(path), full_page=full_page)
+                        capture.screenshot = path.name
+                    if mode in (DOM, BOTH):
+                        html = (
+                            target.evaluate("el => el.outerHTML")
+                            if target is not None
+                            else page.content()
+                        )
+                        path = directory / f"{capture.id}.html"
+                        path.write_text(html, encoding="utf-8")
+                        capture.dom = path.name
+                finally:
+                    browser.close()
+        except PlaywrightError as e:
+            raise PageCaptureError(f"Could not capture {url}: {e}") from e
+
+        self._record_path(session, capture.id).write_text(
+            json.dumps(capture.to_dict(), indent=2), encoding="utf-8"
+        )
+        return capture
+
+    # ------------------------------------------------------------------
+    # Stored captures
+    # ------------------------------------------------------------------
+
+    def captures(self, session_id: str | None = None) -> list[Capture]:
+        """Stored captures, newest first, for one session or all of them."""
+        if not self.root.is_dir():
+            return []
+        sessions = (
+            [self.root / safe_path_component(session_id)]
+            if session_id
+            else [d for d in self.root.iterdir() if d.is_dir()]
+        )
+        captures = []
+        for directory in sessions:
+            for record in directory.glob("*.json"):
+                try:
+                    data = json.loads(record.read_text(encoding="utf-8"))
+                    captures.append(Capture(**data))
+                except (OSError, json.JSONDecodeError, TypeError) as e:
+                    logger.debug(f"Skipping unreadable capture {record}: {e}")
+        return sorted(captures, key=lambda c: c.created_at, reverse=True)
+
+    def get(self, session_id: str, capture_id: str) -> Capture | None:
+        record = self._record_path(session_id, capture_id)
+        if not record.is_file():
+            return None
+        return Capture(**json.loads(record.read_text(encoding="utf-8")))
+
+    def artifact_path(self, capture: Capture, kind: str) -> Path | None:
+        """Absolute path of a capture's screenshot or DOM file, if it has one."""
+        name = capture.screenshot if kind == SCREENSHOT else capture.dom
+        if not name:
+            return None
+        path = self.root / capture.session_id / name
+        return path if path.is_file() else None
+
+    def _record_path(self, session_id: str, capture_id: str) -> Path:
+        # Both parts come from dashboard URLs; keep them inside root
+        session = safe_path_component(session_id)
+        return self.root / session / f"{safe_path_component(capture_id)}.json"
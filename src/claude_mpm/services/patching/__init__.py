@@ -0,0 +1,47 @@
+"""Fuzz-tolerant application of unified diffs.
+
+WHAT: Public surface of the patch engine: ``PatchEngine`` applies or checks a
+patch, placing each hunk with the configured strategies (``exact``,
+``fuzzy``, ``ast``) and explaining hunks that do not apply.
+WHY:  Patches produced by agents often drift from the file by whitespace or a
+stale context line; ``patch`` and ``git apply`` reject them with little
+indication of what differed.
+"""
+
+from __future__ import annotations
+
+from claude_mpm.services.patching.diff import (
+    FilePatch,
+    Hunk,
+    PatchParseError,
+    parse_patch,
+)
+from claude_mpm.services.patching.engine import (
+    FileResult,
+    HunkResult,
+    PatchConfig,
+    PatchEngine,
+    PatchResult,
+)
+from claude_mpm.services.patching.strategies import (
+    STRATEGIES,
+    Match,
+    PatchStrategy,
+    register_strategy,
+)
+
+__all__ = [
+    "STRATEGIES",
+    "FilePatch",
+    "FileResult",
+    "Hunk",
+    "HunkResult",
+    "Match",
+    "PatchConfig",
+    "PatchEngine",
+    "PatchParseError",
+    "PatchResult",
+    "PatchStrategy",
+    "parse_patch",
+    "register_strategy",
+]
@@ -0,0 +1,217 @@
+"""Unified diff parsing for the patch engine.
+
+WHAT: ``parse_patch`` turns a unified diff (``git diff`` output or a plain
+``diff -u``) into one ``FilePatch`` per file, each holding its hunks.
+
+WHY: Patches written by agents are often slightly off: hunk headers whose
+line counts do not add up, blank context lines whose leading space an editor
+stripped, CRLF line endings. The parser accepts these and records a warning
+on the hunk instead of rejecting the whole patch; whether the hunk still
+applies is for the strategies to decide.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field
+
+HUNK_RE = re.compile(r"^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$")
+CONTEXT, REMOVED, ADDED = " ", "-", "+"
+DEV_NULL = "/dev/null"
+
+
+class PatchParseError(ValueError):
+    """The text is not a unified diff the engine can apply."""
+
+    def __init__(self, message: str, line: int | None = None):
+        super().__init__(f"line {line}: {message}" if line else message)
+        self.line = line
+
+
+@dataclass
+class Hunk:
+    """One ``@@`` block: lines as (tag, text) pairs, tag being " ", "-" or "+"."""
+
+    old_start: int
+    old_count: int
+    new_start: int
+    new_count: int
+    # Function context git prints after the header ("def load(self):")
+    section: str = ""
+    lines: list[tuple[str, str]] = field(default_factory=list)
+    # False when "\ No newline at end of file" follows the old/new side
+    old_eof_newline: bool = True
+    new_eof_newline: bool = True
+    # Line of the header in the patch text, for diagnostics
+    patch_line: int = 0
+    warnings: list[str] = field(default_factory=list)
+
+    @property
+    def before(self) -> list[str]:
+        """Lines the hunk expects to find (context and removed lines)."""
+        return [text for tag, text in self.lines if tag != ADDED]
+
+    @property
+    def after(self) -> list[str]:
+        """Lines the hunk leaves in their place (context and added lines)."""
+        return [text for tag, text in self.lines if tag != REMOVED]
+
+    @property
+    def header(self) -> str:
+        return (
+            f"@@ -{self.old_start},{self.old_count} "
+            f"+{self.new_start},{self.new_count} @@"
+        )
+
+
+@dataclass
+class FilePatch:
+    """The hunks for one file; a None path is /dev/null (created or deleted)."""
+
+    old_path: str | None
+    new_path: str | None
+    hunks: list[Hunk] = field(default_factory=list)
+
+    @property
+    def path(self) -> str:
+        return self.new_path or self.old_path or ""
+
+    @property
+    def is_new(self) -> bool:
+        return self.old_path is None
+
+    @property
+    def is_deleted(self) -> bool:
+        return self.new_path is None
+
+    @property
+    def is_rename(self) -> bool:
+        return None not in (self.old_path, self.new_path) and (
+            self.old_path != self.new_path
+        )
+
+
+def _header_path(value: str) -> str | None:
+    """Path from a ---/+++ line, dropping a tab-separated timestamp."""
+    path = value.split("\t")[0].strip()
+    if path.startswith('"') and path.endswith('"'):
+        path = path[1:-1]
+    return None if path == DEV_NULL else path
+
+
+def _strip_path(path: str | None, strip: int) -> str | None:
+    if path is None:
+        return None
+    parts = path.split("/")
+    return "/".join(parts[strip:]) if len(parts) > strip else parts[-1]
+
+
+def _auto_strip(old: str | None, new: str | None) -> int:
+    """1 for git's a/ and b/ prefixes, else 0."""
+    prefixes = [p[:2] for p in (old, new) if p is not None]
+    return 1 if prefixes and set(prefixes) <= {"a/", "b/"} else 0
+
+
+def _is_file_header(lines: list[str], i: int) -> bool:
+    return (
+        lines[i].startswith("--- ")
+        and i + 1 < len(lines)
+        and lines[i + 1].startswith("+++ ")
+    )
+
+
+def _parse_hunk(lines: list[str], i: int) -> tuple[Hunk, int]:
+    """Parse the hunk whose header is lines[i]; return it and the next index."""
+    match = HUNK_RE.match(lines[i])
+    if not match:
+        raise PatchParseError(f"malformed hunk header: {lines[i]!r}", i + 1)
+    old_start, old_count, new_start, new_count, section = match.groups()
+    hunk = Hunk(
+        old_start=int(old_start),
+        old_count=1 if old_count is None else int(old_count),
+        new_start=int(new_start),
+        new_count=1 if new_count is None else int(new_count),
+        section=section.strip(),
+        patch_line=i + 1,
+    )
+    old_seen = new_seen = 0
+    i += 1
+    while i < len(lines):
+        line = lines[i]
+        complete = old_seen >= hunk.old_count and new_seen >= hunk.new_count
+        if line.startswith("\\"):
+            # "\ No newline at end of file" applies to the line before it
+            if hunk.lines and hunk.lines[-1][0] != ADDED:
+                hunk.old_eof_newline = False
+            if hunk.lines and hunk.lines[-1][0] != REMOVED:
+                hunk.new_eof_newline = False
+            i += 1
+            continue
+        if line.startswith("@@") or _is_file_header(lines, i):
+            break
+        if line == "" and not complete:
+            # A blank context line whose leading space was stripped
+            tag, text = CONTEXT, ""
+        elif line[:1] in (CONTEXT, REMOVED, ADDED):
+            tag, text = line[0], line[1:]
+        else:
+            break
+        hunk.lines.append((tag, text))
+        old_seen += tag != ADDED
+        new_seen += tag != REMOVED
+        i += 1
+
+    if not hunk.lines:
+        raise PatchParseError("hunk has no lines", hunk.patch_line)
+    if (old_seen, new_seen) != (hunk.old_count, hunk.new_count):
+        hunk.warnings.append(
+            f"header counts -{hunk.old_count},+{hunk.new_count} but the hunk "
+            f"has -{old_seen},+{new_seen}"
+        )
+        hunk.old_count, hunk.new_count = old_seen, new_seen
+    return hunk, i
+
+
+def parse_patch(text: str, strip: int | None = None) -> list[FilePatch]:
+    """Parse a unified diff into per-file patches.
+
+    Args:
+        text: The diff
+        strip: Leading path components to drop (like ``patch -p``); by
+            default git's ``a/`` and ``b/`` prefixes are dropped and other
+            paths are kept as they are
+
+    Raises:
+        PatchParseError: If the text holds no file changes, or a binary or
+            malformed hunk
+    """
+    lines = [line.removesuffix("\r") for line in text.splitlines()]
+    patches: list[FilePatch] = []
+    current: FilePatch | None = None
+    i = 0
+    while i < len(lines):
+        line = lines[i]
+        if line.startswith("Binary files ") or line == "GIT binary patch":
+            raise PatchParseError("binary patches are not supported", i + 1)
+        if _is_file_header(lines, i):
+            old = _header_path(line[4:])
+            new = _header_path(lines[i + 1][4:])
+            count = _auto_strip(old, new) if strip is None else strip
+            current = FilePatch(_strip_path(old, count), _strip_path(new, count))
+            patches.append(current)
+            i += 2
+            continue
+        if line.startswith("@@"):
+            if current is None:
+                raise PatchParseError("hunk before any ---/+++ file header", i + 1)
+            hunk, i = _parse_hunk(lines, i)
+            current.hunks.append(hunk)
+            continue
+        i += 1
+
+    if not patches:
+        raise PatchParseError("no file changes found (expected ---/+++ headers)")
+    for patch in patches:
+        if not patch.path:
+            raise PatchParseError("file header with neither an old nor a new path")
+    return patches
@@ -0,0 +1,305 @@
+"""Apply unified diffs with configurable matching strategies.
+
+WHAT: ``PatchEngine.apply`` parses a patch, works out every change in memory
+(the dry-run step), and writes files only when every hunk of every file found
+its place and every patched file passed validation. ``check`` stops after
+the dry run. Results record, per hunk, which strategy placed it, at which
+line, how far it moved and what was ignored; a hunk that did not apply gets
+a diagnostic naming the closest region of the file and the first line that
+differs.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    patching:
+      strategies: [exact, ast, fuzzy]   # tried in order for each hunk
+      max_fuzz: 2                       # context lines fuzzy may ignore
+
+DESIGN DECISIONS:
+- All or nothing: a patch that fails anywhere changes no file, so a failed
+  apply never leaves a half-patched tree to untangle
+- Line endings and the final newline of each file are kept as they were
+- Every configured strategy validates each patched file, whichever strategy
+  placed its hunks, so a syntax check applies to fuzzily placed hunks too
+"""
+
+from __future__ import annotations
+
+import difflib
+from dataclasses import asdict, dataclass, field, fields
+from pathlib import Path
+from typing import Any
+
+from ...core.logger import get_logger
+from ...core.state_files import write_atomic
+from .diff import FilePatch, Hunk, parse_patch
+from .strategies import STRATEGIES, PatchStrategy
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "patching"
+DEFAULT_STRATEGIES = ("exact", "ast", "fuzzy")
+
+CREATE = "create"
+MODIFY = "modify"
+DELETE = "delete"
+RENAME = "rename"
+
+
+@dataclass
+class PatchConfig:
+    """Which strategies place hunks, and how much fuzz they allow."""
+
+    strategies: list[str] = field(default_factory=lambda: list(DEFAULT_STRATEGIES))
+    max_fuzz: int = 2
+
+    @classmethod
+    def load(cls, config: Any = None) -> PatchConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ...core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return cls.from_dict(section)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> PatchConfig:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in data.items() if k in known and v is not None})
+
+
+@dataclass
+class HunkResult:
+    header: str
+    applied: bool
+    strategy: str | None = None
+    # 1-based line in the file as patched so far
+    line: int | None = None
+    offset: int = 0
+    fuzz: int = 0
+    notes: list[str] = field(default_factory=list)
+    diagnostic: str | None = None
+
+
+@dataclass
+class FileResult:
+    path: str
+    action: str
+    hunks: list[HunkResult] = field(default_factory=list)
+    error: str | None = None
+
+    @property
+    def ok(self) -> bool:
+        return self.error is None
+
+
+@dataclass
+class PatchResult:
+    files: list[FileResult]
+    dry_run: bool
+    written: bool = False
+
+    @property
+    def ok(self) -> bool:
+        return all(f.ok for f in self.files)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {**asdict(self), "ok": self.ok}
+
+
+def _split_lines(text: str) -> tuple[list[str], str, bool]:
+    """Lines without endings, the newline used, and whether the last line
+    ends with one."""
+    newline = "\r\n" if "\r\n" in text else "\n"
+    lines = text.split(newline)
+    eof_newline = lines[-1] == ""
+    if eof_newline:
+        lines.pop()
+    return lines, newline, eof_newline
+
+
+def _join_lines(lines: list[str], newline: str, eof_newline: bool) -> str:
+    text = newline.join(lines)
+    return text + newline if lines and eof_newline else text
+
+
+def diagnose(lines: list[str], hunk: Hunk, expected: int) -> str:
+    """Explain why *hunk* did not apply: the closest region and how it differs."""
+    before = hunk.before
+    if not lines:
+        return "the file is empty"
+    size = min(len(before), len(lines))
+    wanted = "\n".join(before[:size])
+    best, best_ratio = 0, -1.0
+    for start in range(len(lines) - size + 1):
+        ratio = difflib.SequenceMatcher(
+            None, wanted, "\n".join(lines[start : start + size]), autojunk=False
+        ).ratio()
+        # Prefer the region nearest the expected line among equals
+        if ratio > best_ratio or (
+            ratio == best_ratio and abs(start - expected) < abs(best - expected)
+        ):
+            best, best_ratio = start, ratio
+    message = [
+        f"expected {len(before)} line(s) near line {expected + 1}; closest "
+        f"match is at line {best + 1} ({best_ratio:.0%} similar)"
+    ]
+    if len(before) > len(lines) - best:
+        message.append(f"the hunk runs past the end of the file ({len(lines)} lines)")
+    for i in range(size):
+        if lines[best + i] != before[i]:
+            message.append(f"first difference at line {best + i + 1}:")
+            message.append(f"  expected {before[i]!r}")
+            message.append(f"  found    {lines[best + i]!r}")
+            break
+    return "\n".join(message)
+
+
+class PatchEngine:
+    """Applies patches under *root* using the configured strategies."""
+
+    def __init__(
+        self,
+        root: Path | None = None,
+        config: PatchConfig | None = None,
+        strategies: list[str] | None = None,
+    ):
+        self.root = Path(root or Path.cwd()).resolve()
+        self.config = config or PatchConfig.load()
+        names = strategies or self.config.strategies
+        unknown = [name for name in names if name not in STRATEGIES]
+        if unknown:
+            raise ValueError(
+                f"Unknown patch strategy: {', '.join(unknown)} "
+                f"(available: {', '.join(STRATEGIES)})"
+            )
+        self.strategies: list[PatchStrategy] = [
+            STRATEGIES[name](max_fuzz=self.config.max_fuzz) for name in names
+        ]
+
+    def check(self, patch: str, strip: int | None = None) -> PatchResult:
+        """Dry run: work out whether *patch* applies without writing anything."""
+        return self.apply(patch, strip=strip, dry_run=True)
+
+    def apply(
+        self, patch: str, strip: int | None = None, dry_run: bool = False
+    ) -> PatchResult:
+        """Apply *patch*, changing files only if all of it applies.
+
+        Raises:
+            PatchParseError: If *patch* is not a usable unified diff
+        """
+        planned = [self._plan(file_patch) for file_patch in parse_patch(patch, strip)]
+        result = PatchResult(files=[r for r, _ in planned], dry_run=dry_run)
+        if dry_run or not result.ok:
+            return result
+        for _, writes in planned:
+            for path, content in writes:
+                if content is None:
+                    path.unlink()
+                else:
+                    write_atomic(path, content)
+        result.written = True
+        return result
+
+    def _resolve(self, relative: str) -> Path:
+        path = (self.root / relative).resolve()
+        if not path.is_relative_to(self.root):
+            raise ValueError(f"path is outside {self.root}")
+        return path
+
+    def _plan(
+        self, file_patch: FilePatch
+    ) -> tuple[FileResult, list[tuple[Path, str | None]]]:
+        """Work out one file's new content; writes are (path, content) pairs,
+        None content meaning delete."""
+        action = (
+            CREATE
+            if file_patch.is_new
+            else DELETE
+            if file_patch.is_deleted
+            else RENAME
+            if file_patch.is_rename
+            else MODIFY
+        )
+        result = FileResult(path=file_patch.path, action=action)
+        try:
+            source = self._resolve(file_patch.old_path or file_patch.path)
+            target = self._resolve(file_patch.path)
+        except ValueError as e:
+            result.error = str(e)
+            return result, []
+
+        if file_patch.is_new:
+            if target.exists() and target.stat().st_size:
+                result.error = "file already exists"
+                return result, []
+            original = ""
+        elif not source.is_file():
+            result.error = "file not found"
+            return result, []
+        else:
+            try:
+                original = source.read_text(encoding="utf-8", newline="")
+            except UnicodeDecodeError:
+                result.error = "not a UTF-8 text file"
+                return result, []
+
+        lines, newline, eof_newline = _split_lines(original)
+        shift = 0
+        floor = 0
+        for hunk in file_patch.hunks:
+            # A hunk adding lines after line N has no lines to find there
+            expected = max(hunk.old_start - (1 if hunk.before else 0), 0) + shift
+            hunk_result = HunkResult(header=hunk.header, applied=False)
+            hunk_result.notes.extend(hunk.warnings)
+            result.hunks.append(hunk_result)
+            match = None
+            for strategy in self.strategies:
+                if strategy.supports(file_patch.path):
+                    match = strategy.locate(
+                        lines, hunk, expected, floor, file_patch.path
+                    )
+                if match is not None:
+                    hunk_result.strategy = strategy.name
+                    break
+            if match is None:
+                hunk_result.diagnostic = diagnose(lines, hunk, expected)
+                continue
+
+            at_end = match.start + match.length == len(lines)
+            lines[match.start : match.start + match.length] = match.lines
+            if at_end and match.lines:
+                eof_newline = hunk.new_eof_newline
+            hunk_result.applied = True
+            hunk_result.line = match.start + 1
+            hunk_result.offset = match.start - expected
+            hunk_result.fuzz = match.fuzz
+            hunk_result.notes.extend(match.notes)
+            shift += hunk_result.offset + len(match.lines) - match.length
+            floor = match.start + len(match.lines)
+
+        failed = sum(not h.applied for h in result.hunks)
+        if failed:
+            result.error = f"{failed} of {len(result.hunks)} hunk(s) did not apply"
+            return result, []
+
+        if file_patch.is_deleted:
+            if lines:
+                result.error = "file still has content the patch does not remove"
+                return result, []
+            return result, [(source, None)]
+
+        patched = _join_lines(lines, newline, eof_newline)
+        for strategy in self.strategies:
+            problem = strategy.validate(file_patch.path, original, patched)
+            if problem:
+                result.error = f"{strategy.name}: {problem}"
+                return result, []
+        writes: list[tuple[Path, str | None]] = [(target, patched)]
+        if file_patch.is_rename:
+            writes.append((source, None))
+        return result, writes
@@ -0,0 +1,317 @@
+"""Strategies for finding where a hunk applies.
+
+WHAT: A strategy looks for the lines a hunk expects (its context and removed
+lines) in the current file and returns a ``Match``: where the hunk applies
+and the lines that replace that region. The engine tries its configured
+strategies in order for each hunk and keeps the first match.
+
+- ``exact``: the expected lines, character for character, as near as
+  possible to the line the header names (the same offset search as
+  ``patch``)
+- ``fuzzy``: ignores differences in whitespace, then also ignores up to
+  ``max_fuzz`` context lines at either end of the hunk, like ``patch``'s
+  fuzz factor
+- ``ast``: for Python files, fuzzy matching limited to the function or class
+  git names in the hunk header, so a hunk whose context also appears in
+  another function lands in the right one; the patched file must still parse
+
+WHY: Agents often produce patches that drift from the file by a few spaces or
+a stale context line. Rejecting them outright wastes a round trip; applying
+them blindly risks landing a hunk in the wrong place.
+
+DESIGN DECISIONS:
+- Context lines are always kept as the file has them; only removed lines go
+  and only added lines come from the patch. When whitespace differs, added
+  lines are re-indented by the indentation difference seen in the matched
+  lines
+- Strategies register themselves in ``STRATEGIES`` with ``register_strategy``
+  so others can be added without touching the engine
+"""
+
+from __future__ import annotations
+
+import ast
+import re
+from collections.abc import Iterator
+from dataclasses import dataclass, field
+
+from .diff import ADDED, CONTEXT, REMOVED, Hunk
+
+PYTHON_SUFFIXES = (".py", ".pyi")
+DEFINITION_RE = re.compile(r"\b(?:def|class)\s+(\w+)")
+
+
+@dataclass
+class Match:
+    """Where a hunk applies: ``length`` file lines from ``start`` (0-based)
+    are replaced by ``lines``."""
+
+    start: int
+    length: int
+    lines: list[str]
+    # Context lines left out of the comparison
+    fuzz: int = 0
+    notes: list[str] = field(default_factory=list)
+
+
+class PatchStrategy:
+    """Finds where a hunk applies; subclasses register with register_strategy."""
+
+    name = ""
+    description = ""
+
+    def __init__(self, max_fuzz: int = 2):
+        self.max_fuzz = max_fuzz
+
+    def supports(self, path: str) -> bool:
+        """Whether the strategy handles files like *path*."""
+        return True
+
+    def locate(
+        self,
+        lines: list[str],
+        hunk: Hunk,
+        expected: int,
+        floor: int = 0,
+        path: str = "",
+    ) -> Match | None:
+        """Find where *hunk* applies in *lines*, nearest to *expected*.
+
+        Matches may not start before *floor* (the end of the previous hunk).
+        """
+        raise NotImplementedError
+
+    def validate(self, path: str, original: str, patched: str) -> str | None:
+        """Check a fully patched file; return a problem or None."""
+        return None
+
+
+STRATEGIES: dict[str, type[PatchStrategy]] = {}
+
+
+def register_strategy(cls: type[PatchStrategy]) -> type[PatchStrategy]:
+    STRATEGIES[cls.name] = cls
+    return cls
+
+
+def _positions(expected: int, floor: int, ceiling: int, size: int) -> Iterator[int]:
+    """Start positions in [floor, ceiling - size], nearest *expected* first."""
+    last = ceiling - size
+    if last < floor:
+        return
+    expected = min(max(expected, floor), last)
+    yield expected
+    for distance in range(1, max(expected - floor, last - expected) + 1):
+        if expected - distance >= floor:
+            yield expected - distance
+        if expected + distance <= last:
+            yield expected + distance
+
+
+def _normalize(line: str) -> str:
+    return " ".join(line.split())
+
+
+def _indent(line: str) -> str:
+    return line[: len(line) - len(line.lstrip())]
+
+
+def _tabs_for_spaces(width: int):
+    return lambda ws: ws.replace(" " * width, "\t")
+
+
+def _spaces_for_tabs(width: int):
+    return lambda ws: ws.replace("\t", " " * width)
+
+
+def _reindenter(found: list[str], wanted: list[str]):
+    """Map patch indentation onto the file's, from the matched line pairs.
+
+    Tries tabs for spaces and spaces for tabs, then a fixed indentation
+    difference (the file indents everything one level deeper). Returns None
+    unless one mapping explains every non-blank pair.
+    """
+    pairs = [
+        (_indent(w), _indent(f))
+        for f, w in zip(found, wanted, strict=False)
+        if f.strip() and w.strip()
+    ]
+    if all(patch_ws == file_ws for patch_ws, file_ws in pairs):
+        return None
+    patch_ws, file_ws = next((p, f) for p, f in pairs if p != f)
+    common = 0
+    while common < min(len(patch_ws), len(file_ws)) and (
+        patch_ws[common] == file_ws[common]
+    ):
+        common += 1
+    old, new = patch_ws[common:], file_ws[common:]
+
+    def shift(ws: str) -> str:
+        return ws[: len(ws) - len(old)] + new if ws.endswith(old) else ws
+
+    candidates = []
+    for width in (4, 2, 8):
+        candidates += [_tabs_for_spaces(width), _spaces_for_tabs(width)]
+    mapping = next(
+        (m for m in [*candidates, shift] if all(m(p) == f for p, f in pairs)), None
+    )
+    if mapping is None:
+        return None
+    return lambda line: mapping(_indent(line)) + line.lstrip()
+
+
+def _replacement(
+    tagged: list[tuple[str, str]], region: list[str], reindent=None
+) -> list[str]:
+    """The lines replacing *region*: its own context lines plus added lines."""
+    result = []
+    index = 0
+    for tag, text in tagged:
+        if tag == CONTEXT:
+            result.append(region[index])
+            index += 1
+        elif tag == REMOVED:
+            index += 1
+        else:
+            result.append(reindent(text) if reindent else text)
+    return result
+
+
+def _trim_context(
+    tagged: list[tuple[str, str]], fuzz: int
+) -> tuple[list[tuple[str, str]], int, int]:
+    """Drop up to *fuzz* context lines from each end; return the remaining
+    lines and how many were dropped from the front and from the back."""
+    start = 0
+    while start < fuzz and start < len(tagged) and tagged[start][0] == CONTEXT:
+        start += 1
+    end = len(tagged)
+    while len(tagged) - end < fuzz and end > start and tagged[end - 1][0] == CONTEXT:
+        end -= 1
+    return tagged[start:end], start, len(tagged) - end
+
+
+def _insertion_match(lines: list[str], hunk: Hunk, expected: int, floor: int):
+    """A hunk with nothing to find (e.g. lines added to an empty file)."""
+    start = min(max(expected, floor), len(lines))
+    return Match(start=start, length=0, lines=hunk.after)
+
+
+@register_strategy
+class ExactStrategy(PatchStrategy):
+    name = "exact"
+    description = "Expected lines must match exactly; the hunk may move"
+
+    def locate(self, lines, hunk, expected, floor=0, path=""):
+        before = hunk.before
+        if not before:
+            return _insertion_match(lines, hunk, expected, floor)
+        for start in _positions(expected, floor, len(lines), len(before)):
+            if lines[start : start + len(before)] == before:
+                return Match(start=start, length=len(before), lines=hunk.after)
+        return None
+
+
+@register_strategy
+class FuzzyStrategy(PatchStrategy):
+    name = "fuzzy"
+    description = (
+        "Ignores whitespace differences and up to max_fuzz context lines at "
+        "each end of a hunk"
+    )
+
+    def locate(self, lines, hunk, expected, floor=0, path=""):
+        return self._locate(lines, hunk, expected, floor, len(lines))
+
+    def _locate(
+        self,
+        lines: list[str],
+        hunk: Hunk,
+        expected: int,
+        floor: int,
+        ceiling: int,
+    ) -> Match | None:
+        if not hunk.before:
+            return _insertion_match(lines, hunk, expected, floor)
+        normalized = [_normalize(line) for line in lines]
+        for fuzz in range(self.max_fuzz + 1):
+            tagged, front, back = _trim_context(hunk.lines, fuzz)
+            dropped = max(front, back)
+            if fuzz and dropped < fuzz:
+                break  # No more context to ignore
+            wanted = [text for tag, text in tagged if tag != ADDED]
+            if not wanted:
+                break
+            target = [_normalize(line) for line in wanted]
+            # Context dropped from the front moves where the region starts
+            for start in _positions(expected + front, floor, ceiling, len(wanted)):
+                if normalized[start : start + len(wanted)] != target:
+                    continue
+                region = lines[start : start + len(wanted)]
+                notes = []
+                reindent = None
+                if region != wanted:
+                    notes.append("whitespace differs")
+                    reindent = _reindenter(region, wanted)
+                if dropped:
+                    notes.append(f"ignored {dropped} context line(s) at an end")
+                return Match(
+                    start=start,
+                    length=len(wanted),
+                    lines=_replacement(tagged, region, reindent),
+                    fuzz=dropped,
+                    notes=notes,
+                )
+        return None
+
+
+@register_strategy
+class AstStrategy(FuzzyStrategy):
+    name = "ast"
+    description = (
+        "Python only: fuzzy matching inside the function or class named in "
+        "the hunk header; the patched file must parse"
+    )
+
+    def supports(self, path: str) -> bool:
+        return path.endswith(PYTHON_SUFFIXES)
+
+    def locate(self, lines, hunk, expected, floor=0, path=""):
+        if not self.supports(path):
+            return None
+        found = DEFINITION_RE.search(hunk.section)
+        if not found:
+            return None
+        try:
+            tree = ast.parse("\n".join(lines))
+        except SyntaxError:
+            return None
+        scopes = [
+            (
+                min([node.lineno, *(d.lineno for d in node.decorator_list)]) - 1,
+                node.end_lineno or len(lines),
+            )
+            for node in ast.walk(tree)
+            if isinstance(node, (ast.FunctionDef, ast.AsyncFunctionDef, ast.ClassDef))
+            and node.name == found[1]
+        ]
+        # The scope nearest the expected line first
+        for first, last in sorted(scopes, key=lambda s: abs(s[0] - expected)):
+            match = self._locate(lines, hunk, expected, max(floor, first), last)
+            if match:
+                match.notes.append(f"matched inside {found[1]}")
+                return match
+        return None
+
+    def validate(self, path: str, original: str, patched: str) -> str | None:
+        if not self.supports(path):
+            return None
+        try:
+            ast.parse(original)
+        except SyntaxError:
+            return None  # It did not parse before the patch either
+        try:
+            ast.parse(patched)
+        except SyntaxError as e:
+            return f"patched file does not parse: {e.msg} (line {e.lineno})"
+        return None
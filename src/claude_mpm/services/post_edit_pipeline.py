@@ -0,0 +1,350 @@
+"""Format and organize imports in files agents edit.
+
+WHAT: Projects turn the pipeline on in ``.claude-mpm/configuration.yaml``::
+
+    post_edit:
+      enabled: true
+      timeout: 8               # seconds for all steps on one file
+      exclude: ["vendor/*", "*.pb.go"]
+      languages:
+        python:                # override a built-in language's steps
+          imports: ruff check --select I --fix --quiet {file}
+          formatter: ruff format --quiet {file}
+        go:
+          imports: false       # gofmt only
+        proto:                 # or add a language
+          suffixes: [.proto]
+          formatter: clang-format -i {file}
+
+After each Write, Edit or MultiEdit, the hook runs the file's import
+organizer and then its formatter, and tells the agent when the file was
+rewritten. ``claude-mpm post-edit show`` lists the steps and whether their
+tools are installed; ``claude-mpm post-edit run FILE...`` runs them by hand.
+
+WHY: Agents write code that is almost, but not quite, in the project's
+style, so every diff they produce carries formatting noise (reordered
+imports, rewrapped lines) that reviewers have to wade through.
+
+DESIGN DECISIONS:
+- Off by default: a formatter rewriting files must be a project's choice
+- Built-in steps use each ecosystem's usual tools (isort and black, goimports
+  and gofmt, prettier, rustfmt); a project overrides any step, or disables
+  it with ``false``
+- Tools are looked up in the project first (``node_modules/.bin``,
+  ``.venv/bin``) so the project's pinned version runs; a step whose tool is
+  not installed is skipped, not failed
+- A failing step never undoes the edit and never stops the next step
+- The whole pipeline for a file shares one timeout, which stays below the
+  hook handler's own deadline
+"""
+
+from __future__ import annotations
+
+import fnmatch
+import hashlib
+import shlex
+import shutil
+import subprocess  # nosec B404 - runs the project's formatters
+import time
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "post_edit"
+PROJECT_CONFIG = Path(".claude-mpm") / "configuration.yaml"
+USER_CONFIG = Path(".claude-mpm") / "config" / "configuration.yaml"
+
+# Steps run in this order: organizing imports can leave lines to reformat
+STEPS = ("imports", "formatter")
+DEFAULT_TIMEOUT = 8.0
+
+# Tools that modify files and should trigger the pipeline
+EDIT_TOOLS = frozenset({"Write", "Edit", "MultiEdit"})
+
+# Where a project keeps its own copies of tools, checked before PATH
+LOCAL_BIN_DIRS = (
+    Path("node_modules") / ".bin",
+    Path(".venv") / "bin",
+    Path("venv") / "bin",
+)
+
+_PRETTIER = "prettier --write --log-level warn {file}"
+
+DEFAULT_LANGUAGES: dict[str, dict[str, Any]] = {
+    "python": {
+        "suffixes": [".py", ".pyi"],
+        "imports": "isort --quiet {file}",
+        "formatter": "black --quiet {file}",
+    },
+    "go": {
+        "suffixes": [".go"],
+        "imports": "goimports -w {file}",
+        "formatter": "gofmt -w {file}",
+    },
+    "javascript": {
+        "suffixes": [".js", ".jsx", ".mjs", ".cjs"],
+        "formatter": _PRETTIER,
+    },
+    "typescript": {
+        "suffixes": [".ts", ".tsx", ".mts", ".cts"],
+        "formatter": _PRETTIER,
+    },
+    "css": {
+        "suffixes": [".css", ".scss", ".less"],
+        "formatter": _PRETTIER,
+    },
+    "rust": {
+        "suffixes": [".rs"],
+        "formatter": "rustfmt --edition 2021 {file}",
+    },
+}
+
+# Step outcomes
+CHANGED = "changed"
+UNCHANGED = "unchanged"
+SKIPPED = "skipped"
+FAILED = "failed"
+
+
+@dataclass
+class Step:
+    """One command of a language's pipeline; "{file}" is the edited file."""
+
+    name: str
+    command: list[str]
+
+    @property
+    def executable(self) -> str:
+        return self.command[0]
+
+
+@dataclass
+class StepResult:
+    name: str
+    tool: str
+    status: str
+    detail: str = ""
+
+
+@dataclass
+class FileResult:
+    """What the pipeline did to one file."""
+
+    path: str
+    language: str
+    steps: list[StepResult] = field(default_factory=list)
+
+    @property
+    def changed(self) -> bool:
+        return any(step.status == CHANGED for step in self.steps)
+
+    @property
+    def failed(self) -> bool:
+        return any(step.status == FAILED for step in self.steps)
+
+    def summary(self) -> str:
+        parts = []
+        for step in self.steps:
+            if step.status == CHANGED:
+                parts.append(f"{step.tool} rewrote it")
+            elif step.status == FAILED:
+                parts.append(f"{step.tool} failed: {step.detail}")
+        return f"{self.path}: " + ("; ".join(parts) or "already formatted")
+
+    def to_dict(self) -> dict[str, Any]:
+        return {**asdict(self), "changed": self.changed}
+
+
+def _parse_command(value: Any) -> list[str] | None:
+    """A step command from config: a string, an argv list, or false/None."""
+    if not value:
+        return None
+    if isinstance(value, str):
+        return shlex.split(value)
+    if isinstance(value, list) and all(isinstance(v, str) for v in value):
+        return list(value)
+    raise ValueError(f"A post_edit step must be a command or list, got: {value!r}")
+
+
+def _digest(path: Path) -> str:
+    return hashlib.sha256(path.read_bytes()).hexdigest()
+
+
+def _load_section(path: Path) -> dict[str, Any]:
+    """The ``post_edit`` mapping of a YAML config file ({} when absent)."""
+    if not path.is_file():
+        return {}
+    import yaml
+
+    try:
+        data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+    except (OSError, yaml.YAMLError) as e:
+        logger.warning(f"Could not read {path}: {e}")
+        return {}
+    section = data.get(CONFIG_KEY) if isinstance(data, dict) else None
+    return section if isinstance(section, dict) else {}
+
+
+class PostEditPipeline:
+    """Runs the configured import organizer and formatter on edited files."""
+
+    def __init__(self, project_root: Path, config: dict[str, Any] | None = None):
+        self.project_root = Path(project_root).resolve()
+        config = config or {}
+        self.enabled = bool(config.get("enabled", False))
+        self.timeout = float(config.get("timeout", DEFAULT_TIMEOUT))
+        self.exclude = [str(p) for p in config.get("exclude") or []]
+
+        self.languages: dict[str, dict[str, Any]] = {
+            name: dict(spec) for name, spec in DEFAULT_LANGUAGES.items()
+        }
+        for name, spec in (config.get("languages") or {}).items():
+            if spec is False:
+                self.languages.pop(name, None)
+                continue
+            if not isinstance(spec, dict):
+                raise ValueError(f"post_edit.languages.{name} must be a mapping")
+            self.languages.setdefault(name, {"suffixes": []}).update(spec)
+
+    @classmethod
+    def for_project(cls, project_root: Path) -> PostEditPipeline:
+        """Load the user's settings, then the project's on top of them."""
+        user = _load_section(Path.home() / USER_CONFIG)
+        project = _load_section(Path(project_root) / PROJECT_CONFIG)
+        languages = {**(user.get("languages") or {})}
+        for name, spec in (project.get("languages") or {}).items():
+            base = languages.get(name)
+            if isinstance(base, dict) and isinstance(spec, dict):
+                spec = {**base, **spec}
+            languages[name] = spec
+        return cls(project_root, {**user, **project, "languages": languages})
+
+    def language_for(self, path: Path) -> str | None:
+        suffix = path.suffix.lower()
+        for name, spec in self.languages.items():
+            if suffix in [s.lower() for s in spec.get("suffixes") or []]:
+                return name
+        return None
+
+    def steps_for(self, language: str) -> list[Step]:
+        spec = self.languages.get(language, {})
+        steps = []
+        for name in STEPS:
+            command = _parse_command(spec.get(name))
+            if command:
+                steps.append(Step(name, command))
+        return steps
+
+    def find_tool(self, executable: str) -> str | None:
+        """Path of *executable*, preferring the project's own copy."""
+        for directory in LOCAL_BIN_DIRS:
+            candidate = self.project_root / directory / executable
+            if candidate.is_file():
+                return str(candidate)
+        return shutil.which(executable)
+
+    def describe(self) -> list[dict[str, Any]]:
+        """Each language's steps, with the tool each would run (or None)."""
+        rows = []
+        for language, spec in self.languages.items():
+            for step in self.steps_for(language):
+                rows.append(
+                    {
+                        "language": language,
+                        "suffixes": " ".join(spec.get("suffixes") or []),
+                        "step": step.name,
+                        "command": " ".join(step.command),
+                        "tool": self.find_tool(step.executable),
+                    }
+                )
+        return rows
+
+    def _relative(self, path: Path) -> str | None:
+        try:
+            return path.resolve().relative_to(self.project_root).as_posix()
+        except ValueError:
+            return None
+
+    def is_excluded(self, relative: str) -> bool:
+        return any(fnmatch.fnmatch(relative, pattern) for pattern in self.exclude)
+
+    def run(self, path: str | Path) -> FileResult | None:
+        """Run the pipeline on one file.
+
+        Returns None when the file is outside the project, excluded, missing,
+        or of a language without steps.
+        """
+        path = Path(path)
+        if not path.is_absolute():
+            path = self.project_root / path
+        relative = self._relative(path)
+        if relative is None or self.is_excluded(relative) or not path.is_file():
+            return None
+        language = self.language_for(path)
+        steps = self.steps_for(language) if language else []
+        if not steps:
+            return None
+
+        result = FileResult(relative, language)
+        deadline = time.monotonic() + self.timeout
+        for step in steps:
+            result.steps.append(self._run_step(step, path, deadline))
+        return result
+
+    def _run_step(self, step: Step, path: Path, deadline: float) -> StepResult:
+        tool = Path(step.executable).name
+        executable = self.find_tool(step.executable)
+        if executable is None:
+            return StepResult(step.name, tool, SKIPPED, f"{tool} is not installed")
+        remaining = deadline - time.monotonic()
+        if remaining <= 0:
+            return StepResult(step.name, tool, SKIPPED, "out of time")
+
+        argv = [executable]
+        argv += [arg.replace("{file}", str(path)) for arg in step.command[1:]]
+        before = _digest(path)
+        try:
+            completed = subprocess.run(  # nosec B603 - argv from project config
+                argv,
+                cwd=self.project_root,
+                capture_output=True,
+                text=True,
+                timeout=remaining,
+                check=False,
+            )
+        except subprocess.TimeoutExpired:
+            detail = f"timed out after {remaining:.0f}s"
+            return StepResult(step.name, tool, FAILED, detail)
+        except OSError as e:
+            return StepResult(step.name, tool, FAILED, str(e))
+
+        changed = _digest(path) != before
+        if completed.returncode != 0:
+            lines = (completed.stderr or completed.stdout).strip().splitlines()
+            detail = lines[-1] if lines else f"exit code {completed.returncode}"
+            # A tool that failed part-way may still have rewritten the file
+            return StepResult(step.name, tool, CHANGED if changed else FAILED, detail)
+        return StepResult(step.name, tool, CHANGED if changed else UNCHANGED)
+
+
+def run_for_tool_event(event: dict[str, Any]) -> FileResult | None:
+    """Run the pipeline for a PostToolUse event of an edit tool.
+
+    Returns None unless the event edited a file and the project has the
+    pipeline enabled.
+    """
+    if event.get("tool_name") not in EDIT_TOOLS:
+        return None
+    tool_input = event.get("tool_input") or {}
+    file_path = tool_input.get("file_path") if isinstance(tool_input, dict) else None
+    if not isinstance(file_path, str) or not file_path:
+        return None
+    cwd = event.get("cwd") or "."
+    pipeline = PostEditPipeline.for_project(Path(cwd))
+    if not pipeline.enabled:
+        return None
+    return pipeline.run(file_path)
@@ -22,6 +22,8 @@ Profile Structure:
         - dart-engineer
 
     skills:
+      sources:
+        - system
       enabled:
         - flask
         - pytest
@@ -29,6 +31,10 @@ Profile Structure:
         - wordpress-*
         - react-*
 
+A project selects its profile in .claude-mpm/configuration.yaml with
+``active_profile: <name>`` (``profile: <name>`` is accepted as shorthand);
+``claude-mpm skills deploy`` then deploys only the skills the profile allows.
+
 Usage:
     # Auto-detect project directory (searches for .claude-mpm in cwd and parents)
     profile_manager = ProfileManager()
@@ -38,6 +44,9 @@ Usage:
 
     profile_manager.load_profile("framework-development")
 
+    # Or load whichever profile the project's configuration selects
+    profile_manager = ProfileManager.for_project(Path("/path/to/project"))
+
     if profile_manager.is_agent_enabled("python-engineer"):
         # Deploy agent
         pass
@@ -57,6 +66,8 @@ from ..core.logger import get_logger
 
 logger = get_logger(__name__)
 
+PROJECT_CONFIG = Path(".claude-mpm") / "configuration.yaml"
+
 
 class ProfileManager:
     """
@@ -97,6 +108,53 @@ class ProfileManager:
         self._disabled_agents: set[str] = set()
         self._enabled_skills: set[str] = set()
         self._disabled_skill_patterns: list[str] = []
+        self._skill_sources: set[str] = set()
+
+    @staticmethod
+    def configured_profile(project_dir: Path | None = None) -> str | None:
+        """
+        Get the profile selected in the project's configuration.
+
+        Reads ``active_profile`` (written by ``claude-mpm profile set``) or its
+        shorthand ``profile`` from .claude-mpm/configuration.yaml.
+
+        Args:
+            project_dir: Project root directory (defaults to cwd)
+
+        Returns:
+            Optional[str]: Profile name, or None if the project selects none
+        """
+        config_path = (project_dir or Path.cwd()) / PROJECT_CONFIG
+        try:
+            data = yaml.safe_load(config_path.read_text()) or {}
+        except FileNotFoundError:
+            return None
+        except Exception as e:
+            logger.warning(f"Could not read {config_path}: {e}")
+            return None
+        if not isinstance(data, dict):
+            return None
+        name = data.get("active_profile") or data.get("profile")
+        return name if isinstance(name, str) and name else None
+
+    @classmethod
+    def for_project(cls, project_dir: Path | None = None) -> "ProfileManager | None":
+        """
+        Create a manager with the project's configured profile loaded.
+
+        Args:
+            project_dir: Project root directory (defaults to cwd)
+
+        Returns:
+            Optional[ProfileManager]: Loaded manager, or None when the project
+            selects no profile or the profile cannot be loaded
+        """
+        project_dir = project_dir or Path.cwd()
+        profile_name = cls.configured_profile(project_dir)
+        if not profile_name:
+            return None
+        manager = cls(project_dir=project_dir)
+        return manager if manager.load_profile(profile_name) else None
 
     def _find_profiles_dir(self) -> Path:
         """Find profiles directory by searching for .claude-mpm in cwd and parents.
@@ -156,6 +214,7 @@ class ProfileManager:
             skills_config = self._profile_data.get("skills", {})
             self._enabled_skills = set(skills_config.get("enabled", []))
             self._disabled_skill_patterns = skills_config.get("disabled_categories", [])
+            self._skill_sources = set(skills_config.get("sources", []))
 
             logger.info(
                 f"Loaded profile '{self.active_profile}': "
@@ -241,6 +300,44 @@ class ProfileManager:
         # No enabled list and didn't match disabled pattern - allow it
         return True
 
+    def is_skill_source_enabled(self, source_id: str) -> bool:
+        """
+        Check if skills from a source may be deployed under the active profile.
+
+        If the profile lists no sources, every source is allowed.
+
+        Args:
+            source_id: Skill source ID (e.g., "system", "anthropic-official")
+
+        Returns:
+            bool: True if the source's skills should be deployed
+        """
+        if not self.active_profile or not self._skill_sources:
+            return True
+        return source_id in self._skill_sources
+
+    def filter_skills(self, skills: list[dict[str, Any]]) -> list[dict[str, Any]]:
+        """
+        Select the skills the active profile allows.
+
+        Args:
+            skills: Skill dicts as returned by GitSkillSourceManager.get_all_skills()
+
+        Returns:
+            list[dict]: Skills from an enabled source whose name or deployment
+            name is enabled
+        """
+        return [
+            skill
+            for skill in skills
+            if self.is_skill_source_enabled(skill.get("source_id", ""))
+            and any(
+                self.is_skill_enabled(name)
+                for name in (skill.get("name"), skill.get("deployment_name"))
+                if name
+            )
+        ]
+
     def get_enabled_agents(self) -> set[str]:
         """
         Get set of enabled agent names.
@@ -277,6 +374,15 @@ class ProfileManager:
         """
         return self._disabled_skill_patterns.copy()
 
+    def get_skill_sources(self) -> set[str]:
+        """
+        Get set of skill source IDs the profile deploys from.
+
+        Returns:
+            Set[str]: Source IDs (empty means every source)
+        """
+        return self._skill_sources.copy()
+
     def get_filtering_summary(self) -> dict[str, Any]:
         """
         Get summary of current profile filtering.
@@ -288,6 +394,7 @@ class ProfileManager:
             - disabled_agents_count: Number of explicitly disabled agents
             - enabled_skills_count: Number of explicitly enabled skills
             - disabled_patterns_count: Number of disabled skill patterns
+            - skill_sources_count: Number of skill sources the profile lists
         """
         return {
             "active_profile": self.active_profile,
@@ -295,6 +402,7 @@ class ProfileManager:
             "disabled_agents_count": len(self._disabled_agents),
             "enabled_skills_count": len(self._enabled_skills),
             "disabled_patterns_count": len(self._disabled_skill_patterns),
+            "skill_sources_count": len(self._skill_sources),
         }
 
     def list_available_profiles(self) -> list[str]:
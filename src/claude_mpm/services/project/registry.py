@@ -28,6 +28,7 @@ from typing import Any
 import yaml
 
 from claude_mpm.core.logger import get_logger
+from claude_mpm.core.state_files import state_lock, write_atomic
 
 
 class ProjectRegistryError(Exception):
@@ -493,10 +494,12 @@ class ProjectRegistry:
             # Remove internal fields before saving
             save_data = {k: v for k, v in data.items() if not k.startswith("_")}
 
-            with registry_file.open("w", encoding="utf-8") as f:
-                yaml.dump(
-                    save_data, f, default_flow_style=False, sort_keys=False, indent=2
-                )
+            content = yaml.dump(
+                save_data, default_flow_style=False, sort_keys=False, indent=2
+            )
+            # Two sessions starting in one project must not interleave writes
+            with state_lock(registry_file):
+                write_atomic(registry_file, content)
 
             self.logger.debug(f"Saved registry data to {registry_file}")
 
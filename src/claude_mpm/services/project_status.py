@@ -0,0 +1,284 @@
+"""Summary of everything claude-mpm manages in a project.
+
+WHAT: Collects, for one project, the deployed agents and their versions, the
+deployed skills, the Claude Code hooks, the project's MCP servers and the
+most recent sessions into a ``ProjectStatus``. ``claude-mpm status`` prints
+the counts, ``claude-mpm status --deep`` the full summary, and
+``claude-mpm status --deep --write`` saves it as ``.claude-mpm/README.md`` so
+it can be committed for the rest of the team.
+
+WHY: Answering "what does claude-mpm do in this repo?" meant running
+``agents list``, ``skills list``, reading ``.claude/settings.json`` and
+``.mcp.json`` by hand. ``.claude/`` and ``.mcp.json`` are usually ignored by
+git, so teammates could not see any of it without checking out and running
+claude-mpm themselves.
+
+DESIGN DECISIONS:
+- Read-only and project-level only: nothing from ~/.claude is included, so
+  the summary describes the repository rather than whoever generated it
+- Agent versions come from the deployed file's frontmatter; the source commit
+  and any out-of-band modification come from the integrity manifest when one
+  was recorded
+- Hooks are grouped by event and split into claude-mpm's own and others,
+  using the same ``is_our_hook`` check as the hook installers
+- The written file has no generation timestamp, so re-running it only
+  produces a diff when something actually changed
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+from urllib.parse import urlparse
+
+from ..core.logger import get_logger
+from ..core.state_files import write_atomic
+from ..hooks.hook_identity import is_our_hook
+from .deployment_integrity import IntegrityManifest
+from .skills.selective_skill_deployer import parse_agent_frontmatter
+
+logger = get_logger(__name__)
+
+STATUS_FILE = Path(".claude-mpm") / "README.md"
+DEFAULT_SESSION_LIMIT = 5
+
+
+@dataclass
+class ProjectStatus:
+    """What claude-mpm manages in ``project_root``."""
+
+    project_root: Path
+    agents: list[dict[str, Any]] = field(default_factory=list)
+    skills: list[dict[str, Any]] = field(default_factory=list)
+    hooks: list[dict[str, Any]] = field(default_factory=list)
+    mcp_servers: list[dict[str, Any]] = field(default_factory=list)
+    sessions: list[dict[str, Any]] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "project_root": str(self.project_root),
+            "agents": self.agents,
+            "skills": self.skills,
+            "hooks": self.hooks,
+            "mcp_servers": self.mcp_servers,
+            "sessions": self.sessions,
+        }
+
+    def counts(self) -> dict[str, int]:
+        return {
+            "agents": len(self.agents),
+            "skills": len(self.skills),
+            "hooks": sum(hook["mpm"] + hook["other"] for hook in self.hooks),
+            "mcp_servers": len(self.mcp_servers),
+            "sessions": len(self.sessions),
+        }
+
+    def render_summary(self) -> str:
+        """One line per category with its count."""
+        counts = self.counts()
+        lines = [f"claude-mpm in {self.project_root}"]
+        lines.append(f"  Agents:      {counts['agents']} deployed")
+        lines.append(f"  Skills:      {counts['skills']} deployed")
+        lines.append(f"  Hooks:       {counts['hooks']} on {len(self.hooks)} event(s)")
+        lines.append(f"  MCP servers: {counts['mcp_servers']}")
+        if self.sessions:
+            lines.append(f"  Last session: {self.sessions[0]['timestamp']}")
+        else:
+            lines.append("  Last session: none recorded")
+        return "\n".join(lines)
+
+    def render_markdown(self) -> str:
+        """The full summary as markdown, as written to ``STATUS_FILE``."""
+        lines = [
+            "# claude-mpm in this repository",
+            "",
+            "Generated by `claude-mpm status --deep --write`. Re-run it after "
+            "changing agents, skills, hooks or MCP servers.",
+            "",
+        ]
+        lines += _section(
+            "Agents",
+            ["Agent", "Version", "Source commit", "State"],
+            [
+                [a["name"], a["version"], a["commit"] or "-", a["state"]]
+                for a in self.agents
+            ],
+        )
+        lines += _section(
+            "Skills",
+            ["Skill", "Version", "Description"],
+            [[s["name"], s["version"], s["description"]] for s in self.skills],
+        )
+        lines += _section(
+            "Hooks",
+            ["Event", "claude-mpm", "Other"],
+            [[h["event"], str(h["mpm"]), str(h["other"])] for h in self.hooks],
+        )
+        lines += _section(
+            "MCP servers",
+            ["Server", "Command"],
+            [[m["name"], m["command"]] for m in self.mcp_servers],
+        )
+        lines += _section(
+            "Recent sessions",
+            ["Session", "When", "Branch", "Last agent", "Labels"],
+            [
+                [
+                    s["session_id"],
+                    s["timestamp"],
+                    s["git_branch"],
+                    s["last_agent"],
+                    ", ".join(s.get("labels", [])),
+                ]
+                for s in self.sessions
+            ],
+        )
+        return "\n".join(lines).rstrip() + "\n"
+
+
+def _cell(value: Any) -> str:
+    return str(value).replace("|", "\\|").replace("\n", " ")
+
+
+def _section(title: str, header: list[str], rows: list[list[str]]) -> list[str]:
+    lines = [f"## {title}", ""]
+    if not rows:
+        return [*lines, "None.", ""]
+    lines.append("| " + " | ".join(header) + " |")
+    lines.append("|" + "---|" * len(header))
+    lines += ["| " + " | ".join(_cell(v) for v in row) + " |" for row in rows]
+    return [*lines, ""]
+
+
+def _read_json(path: Path) -> dict[str, Any]:
+    if not path.is_file():
+        return {}
+    try:
+        data = json.loads(path.read_text(encoding="utf-8"))
+    except (OSError, json.JSONDecodeError) as e:
+        logger.warning(f"Ignoring unreadable {path}: {e}")
+        return {}
+    return data if isinstance(data, dict) else {}
+
+
+def _agents(root: Path) -> list[dict[str, Any]]:
+    manifest = IntegrityManifest(root)
+    entries = manifest.entries()
+    issues = {issue.path: issue.issue for issue in manifest.verify()}
+    agents = []
+    for path in sorted((root / ".claude" / "agents").glob("*.md")):
+        frontmatter = parse_agent_frontmatter(path)
+        key = path.relative_to(root).as_posix()
+        entry = entries.get(key)
+        agents.append(
+            {
+                "name": str(frontmatter.get("name") or path.stem),
+                "version": str(frontmatter.get("version") or "-"),
+                "commit": entry.commit[:7] if entry and entry.commit else None,
+                "state": issues.get(key, "ok" if entry else "unrecorded"),
+            }
+        )
+    return agents
+
+
+def _skills(root: Path) -> list[dict[str, Any]]:
+    skills = []
+    for path in sorted((root / ".claude" / "skills").glob("*/SKILL.md")):
+        frontmatter = parse_agent_frontmatter(path)
+        metadata = frontmatter.get("metadata")
+        version = frontmatter.get("version") or (
+            metadata.get("version") if isinstance(metadata, dict) else None
+        )
+        description = " ".join(str(frontmatter.get("description") or "").split())
+        skills.append(
+            {
+                "name": str(frontmatter.get("name") or path.parent.name),
+                "version": str(version or "-"),
+                "description": (
+                    description[:77] + "..." if len(description) > 80 else description
+                ),
+            }
+        )
+    return skills
+
+
+def _hooks(root: Path) -> list[dict[str, Any]]:
+    counts: dict[str, dict[str, int]] = {}
+    for name in ("settings.json", "settings.local.json"):
+        hooks = _read_json(root / ".claude" / name).get("hooks")
+        if not isinstance(hooks, dict):
+            continue
+        for event, matchers in hooks.items():
+            entry = counts.setdefault(event, {"mpm": 0, "other": 0})
+            for matcher in matchers if isinstance(matchers, list) else []:
+                commands = matcher.get("hooks", []) if isinstance(matcher, dict) else []
+                for hook in commands:
+                    entry["mpm" if is_our_hook(hook) else "other"] += 1
+    return [{"event": event, **counts[event]} for event in sorted(counts)]
+
+
+def _mcp_servers(root: Path) -> list[dict[str, Any]]:
+    servers = _read_json(root / ".mcp.json").get("mcpServers")
+    if not isinstance(servers, dict):
+        return []
+    result = []
+    for name in sorted(servers):
+        config = servers[name] if isinstance(servers[name], dict) else {}
+        # Only the executable or host: arguments, env and URLs may carry tokens
+        if config.get("command"):
+            command = Path(str(config["command"])).name
+        elif config.get("url"):
+            command = urlparse(str(config["url"])).hostname or "-"
+        else:
+            command = "-"
+        result.append({"name": name, "command": command})
+    return result
+
+
+def _sessions(root: Path, limit: int) -> list[dict[str, Any]]:
+    if limit <= 0:
+        return []
+    from .cli.resume_service import ResumeService
+    from .session_labels import SessionLabels
+
+    try:
+        summaries = ResumeService(root).list_sessions()[:limit]
+        labels = SessionLabels(root).all()
+    except Exception as e:
+        logger.debug(f"Could not list sessions in {root}: {e}")
+        return []
+    return [
+        {
+            "session_id": s.session_id[:8],
+            "timestamp": s.timestamp.strftime("%Y-%m-%d %H:%M"),
+            "git_branch": s.git_branch,
+            "last_agent": s.last_agent,
+            "stop_reason": s.stop_reason,
+            "labels": labels.get(s.session_id, []),
+        }
+        for s in summaries
+    ]
+
+
+def collect_project_status(
+    project_root: Path, session_limit: int = DEFAULT_SESSION_LIMIT
+) -> ProjectStatus:
+    """Collect what claude-mpm manages in ``project_root``."""
+    root = Path(project_root).resolve()
+    return ProjectStatus(
+        project_root=root,
+        agents=_agents(root),
+        skills=_skills(root),
+        hooks=_hooks(root),
+        mcp_servers=_mcp_servers(root),
+        sessions=_sessions(root, session_limit),
+    )
+
+
+def write_status_file(status: ProjectStatus, path: Path | None = None) -> Path:
+    """Write the markdown summary; defaults to ``.claude-mpm/README.md``."""
+    target = Path(path) if path else status.project_root / STATUS_FILE
+    write_atomic(target, status.render_markdown())
+    return target
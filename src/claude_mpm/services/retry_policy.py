@@ -0,0 +1,172 @@
+"""Retry policies for delegated tasks.
+
+WHAT: A ``RetryPolicy`` says how often a failed task is tried again and
+when: the attempt limit, an exponential backoff between attempts, and which
+kinds of failure are worth retrying. ``classify_failure`` sorts a failed
+run's output into one of FAILURE_CLASSES; ``RetryPolicies`` picks the policy
+for a task from its task type or agent, falling back to the default.
+
+CONFIGURATION (.claude-mpm/configuration.yaml, under the scheduler's own
+section, e.g. ``work_queue.retry``)::
+
+    retry:
+      max_attempts: 3          # default: the scheduler's max_attempts
+      backoff: 30              # seconds before the second attempt
+      multiplier: 2            # each later wait is this much longer
+      max_backoff: 600
+      retry_on: [timeout, rate_limit, network, worker_lost]
+      agents:
+        research: {max_attempts: 5}
+      task_types:
+        migration: {max_attempts: 1}
+
+Entries under ``agents`` and ``task_types`` only name what differs from the
+default policy. A task type takes precedence over an agent.
+
+WHY: A delegated task that hit a rate limit or a network blip failed for
+good, while one that failed on its merits was worth nothing more than a
+report; the scheduler could not tell the two apart.
+
+DESIGN DECISIONS:
+- Plain ``error`` failures (the agent finished and reported failure) are
+  not retried by default: running the same prompt again rarely helps
+- ``dependency`` failures (a task this one depends on failed) are never
+  retried; the retrying happens on the dependency
+- Classification is by pattern over the run's output, most specific first
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field, fields, replace
+from typing import Any
+
+from ..core.logger import get_logger
+from ..utils.agent_filters import normalize_agent_id
+
+logger = get_logger(__name__)
+
+TIMEOUT = "timeout"
+RATE_LIMIT = "rate_limit"
+NETWORK = "network"
+WORKER_LOST = "worker_lost"
+DEPENDENCY = "dependency"
+ERROR = "error"
+FAILURE_CLASSES = (TIMEOUT, RATE_LIMIT, NETWORK, WORKER_LOST, DEPENDENCY, ERROR)
+TRANSIENT = (TIMEOUT, RATE_LIMIT, NETWORK, WORKER_LOST)
+
+_PATTERNS = (
+    (RATE_LIMIT, re.compile(r"rate.?limit|\b429\b|overloaded|quota", re.I)),
+    (TIMEOUT, re.compile(r"timed? ?out|timeout|deadline exceeded", re.I)),
+    (
+        NETWORK,
+        re.compile(
+            r"connection (?:reset|refused|error|aborted)|could not resolve|"
+            r"network is unreachable|temporary failure in name resolution|"
+            r"bad gateway|service unavailable",
+            re.I,
+        ),
+    ),
+)
+
+
+def classify_failure(text: str | None) -> str:
+    """The failure class of a failed run, from its output."""
+    for failure, pattern in _PATTERNS:
+        if pattern.search(text or ""):
+            return failure
+    return ERROR
+
+
+@dataclass(frozen=True)
+class RetryPolicy:
+    """How a failed task is retried."""
+
+    max_attempts: int = 3
+    backoff: float = 30.0
+    multiplier: float = 2.0
+    max_backoff: float = 600.0
+    retry_on: tuple[str, ...] = TRANSIENT
+
+    def should_retry(self, failure: str, attempts: int) -> bool:
+        """Whether a task that failed with *failure* gets another attempt."""
+        return (
+            failure != DEPENDENCY
+            and failure in self.retry_on
+            and attempts < self.max_attempts
+        )
+
+    def delay(self, attempts: int) -> float:
+        """Seconds to wait before the attempt after attempt *attempts*."""
+        wait = self.backoff * self.multiplier ** max(attempts - 1, 0)
+        return max(0.0, min(wait, self.max_backoff))
+
+    def updated(self, overrides: dict[str, Any]) -> RetryPolicy:
+        """This policy with the known keys of *overrides* applied."""
+        known = {f.name for f in fields(self)}
+        values = {k: v for k, v in overrides.items() if k in known and v is not None}
+        if "retry_on" in values:
+            retry_on = values["retry_on"]
+            if isinstance(retry_on, str):
+                retry_on = [retry_on]
+            unknown = ", ".join(sorted(set(retry_on) - set(FAILURE_CLASSES)))
+            if unknown:
+                logger.warning(f"Ignoring unknown retry_on classes: {unknown}")
+            values["retry_on"] = tuple(r for r in retry_on if r in FAILURE_CLASSES)
+        try:
+            return replace(self, **values)
+        except TypeError as e:
+            logger.warning(f"Ignoring invalid retry policy {overrides}: {e}")
+            return self
+
+
+@dataclass
+class RetryPolicies:
+    """The default policy and the per agent and per task type ones."""
+
+    default: RetryPolicy = field(default_factory=RetryPolicy)
+    agents: dict[str, RetryPolicy] = field(default_factory=dict)
+    task_types: dict[str, RetryPolicy] = field(default_factory=dict)
+
+    @classmethod
+    def from_config(
+        cls, section: dict[str, Any] | None, max_attempts: int | None = None
+    ) -> RetryPolicies:
+        """Policies from a ``retry`` config section.
+
+        Args:
+            section: The section; keys other than the policy fields,
+                ``agents`` and ``task_types`` are ignored
+            max_attempts: Attempt limit when the section sets none
+        """
+        section = section if isinstance(section, dict) else {}
+        default = RetryPolicy()
+        if max_attempts:
+            default = replace(default, max_attempts=max_attempts)
+        default = default.updated(section)
+
+        def overrides(key: str, normalize=lambda name: name) -> dict:
+            entries = section.get(key)
+            if not isinstance(entries, dict):
+                return {}
+            return {
+                normalize(str(name)): default.updated(values)
+                for name, values in entries.items()
+                if isinstance(values, dict)
+            }
+
+        return cls(
+            default=default,
+            agents=overrides("agents", normalize_agent_id),
+            task_types=overrides("task_types"),
+        )
+
+    def for_task(
+        self, agent: str | None = None, task_type: str | None = None
+    ) -> RetryPolicy:
+        """The policy for a task: its task type's, its agent's, or the default."""
+        if task_type and task_type in self.task_types:
+            return self.task_types[task_type]
+        if agent and normalize_agent_id(agent) in self.agents:
+            return self.agents[normalize_agent_id(agent)]
+        return self.default
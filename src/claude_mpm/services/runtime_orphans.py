@@ -0,0 +1,272 @@
+"""
+Runtime Orphan Scanner
+======================
+
+Finds and reaps the runtime debris claude-mpm leaves behind after a crash or
+a killed terminal: stale PID files, dead Unix sockets, dashboard servers
+whose project was deleted, and detached tmux sessions.
+
+WHY: ``OrphanDetectionService`` covers *deployments* made by local-ops
+(PM2, Docker, project ports 3000-3999). claude-mpm's own runtime state is a
+different problem: the files are ours, so we can reason about them precisely
+and clean them up without guessing.
+
+SAFETY PHILOSOPHY:
+- Files are only removed when the owning process is provably gone (PID file
+  points at a dead process, socket refuses connections)
+- Lock files are never removed: the kernel drops a dead process's flock(),
+  so they are never stale, and unlinking one while a writer takes it would
+  let the next writer lock a new inode and break mutual exclusion
+- Processes are only signalled when their command line identifies them as
+  claude-mpm *and* the project they serve no longer exists
+- Never touch the current process or anything younger than ``min_age``
+- tmux sessions are opt-in because a detached session may be intentional
+- ``scan()`` never modifies anything, so dry runs are just scan + print
+"""
+
+import os
+import shutil
+import signal
+import socket
+import subprocess
+import time
+from dataclasses import dataclass
+from pathlib import Path
+
+from ..core.logging_config import get_logger
+from .server_discovery import ServerDiscoveryRegistry
+
+logger = get_logger(__name__)
+
+TMUX_SESSION_PREFIXES = ("claude-mpm", "mpm-")
+
+
+@dataclass
+class RuntimeOrphan:
+    """A single leftover resource."""
+
+    kind: str  # pid_file | socket_file | server | tmux_session
+    target: str  # file path, session name, or "pid:<n>"
+    reason: str
+    pid: int | None = None
+
+    def describe(self) -> str:
+        return f"[{self.kind}] {self.target} - {self.reason}"
+
+
+def _pid_alive(pid: int) -> bool:
+    try:
+        os.kill(pid, 0)
+    except ProcessLookupError:
+        return False
+    except PermissionError:
+        return True
+    except OSError:
+        return False
+    return True
+
+
+def _read_pid(path: Path) -> int | None:
+    try:
+        text = path.read_text().strip().split()
+    except OSError:
+        return None
+    if not text or not text[0].isdigit():
+        return None
+    return int(text[0])
+
+
+def _process_cmdline(pid: int) -> str:
+    try:
+        result = subprocess.run(  # nosec B603 B607
+            ["ps", "-p", str(pid), "-o", "command="],
+            capture_output=True,
+            text=True,
+            timeout=2,
+            check=False,
+        )
+    except (OSError, subprocess.TimeoutExpired):
+        return ""
+    return result.stdout.strip()
+
+
+class RuntimeOrphanScanner:
+    """Scans user and project state directories for leftovers."""
+
+    def __init__(
+        self,
+        user_dir: Path | None = None,
+        project_dir: Path | None = None,
+        discovery: ServerDiscoveryRegistry | None = None,
+        min_age: float = 60.0,
+    ):
+        self.user_dir = user_dir or Path.home() / ".claude-mpm"
+        self.project_dir = project_dir or Path.cwd() / ".claude-mpm"
+        self.discovery = discovery or ServerDiscoveryRegistry(
+            self.user_dir / "servers.json"
+        )
+        self.min_age = min_age
+
+    # ------------------------------------------------------------------
+    # Scanning
+    # ------------------------------------------------------------------
+
+    def scan(self, include_tmux: bool = False) -> list[RuntimeOrphan]:
+        """Return every orphan found. Never modifies anything."""
+        orphans: list[RuntimeOrphan] = []
+        orphans.extend(self.scan_pid_files())
+        orphans.extend(self.scan_socket_files())
+        orphans.extend(self.scan_servers())
+        if include_tmux:
+            orphans.extend(self.scan_tmux_sessions())
+        return orphans
+
+    def _state_dirs(self) -> list[Path]:
+        dirs = [self.user_dir, self.user_dir / "daemon", self.project_dir]
+        return [d for d in dict.fromkeys(dirs) if d.is_dir()]
+
+    def _old_enough(self, path: Path) -> bool:
+        try:
+            return time.time() - path.stat().st_mtime >= self.min_age
+        except OSError:
+            return False
+
+    def scan_pid_files(self) -> list[RuntimeOrphan]:
+        orphans = []
+        for state_dir in self._state_dirs():
+            for path in sorted(state_dir.glob("*.pid")):
+                pid = _read_pid(path)
+                if pid is None:
+                    if self._old_enough(path):
+                        orphans.append(
+                            RuntimeOrphan("pid_file", str(path), "unreadable PID")
+                        )
+                elif not _pid_alive(pid):
+                    orphans.append(
+                        RuntimeOrphan(
+                            "pid_file", str(path), f"process {pid} is gone", pid
+                        )
+                    )
+        return orphans
+
+    def scan_socket_files(self) -> list[RuntimeOrphan]:
+        orphans = []
+        for state_dir in self._state_dirs():
+            for path in sorted(state_dir.glob("*.sock")):
+                if not path.is_socket() or self._socket_listening(path):
+                    continue
+                orphans.append(
+                    RuntimeOrphan("socket_file", str(path), "no process listening")
+                )
+        return orphans
+
+    @staticmethod
+    def _socket_listening(path: Path) -> bool:
+        try:
+            with socket.socket(socket.AF_UNIX, socket.SOCK_STREAM) as sock:
+                sock.settimeout(0.5)
+                sock.connect(str(path))
+        except OSError:
+            return False
+        return True
+
+    def scan_servers(self) -> list[RuntimeOrphan]:
+        """Dashboard servers still running for projects that were deleted."""
+        orphans = []
+        for info in self.discovery.list_servers():
+            pid = info.get("pid")
+            root = info.get("project_root", "")
+            if not pid or pid == os.getpid() or Path(root).exists():
+                continue
+            cmdline = _process_cmdline(pid)
+            if "claude-mpm" not in cmdline and "claude_mpm" not in cmdline:
+                continue
+            orphans.append(
+                RuntimeOrphan(
+                    "server",
+                    f"pid:{pid}",
+                    f"serving deleted project {root} on port {info.get('port')}",
+                    pid,
+                )
+            )
+        return orphans
+
+    def scan_tmux_sessions(self) -> list[RuntimeOrphan]:
+        """Detached tmux sessions created by claude-mpm."""
+        if not shutil.which("tmux"):
+            return []
+        try:
+            result = subprocess.run(  # nosec B603 B607
+                [
+                    "tmux",
+                    "list-sessions",
+                    "-F",
+                    "#{session_name}\t#{session_attached}",
+                ],
+                capture_output=True,
+                text=True,
+                timeout=2,
+                check=False,
+            )
+        except (OSError, subprocess.TimeoutExpired):
+            return []
+        if result.returncode != 0:
+            return []
+
+        orphans = []
+        for line in result.stdout.splitlines():
+            name, _, attached = line.partition("\t")
+            if name.startswith(TMUX_SESSION_PREFIXES) and attached.strip() == "0":
+                orphans.append(
+                    RuntimeOrphan("tmux_session", name, "detached, no clients")
+                )
+        return orphans
+
+    # ------------------------------------------------------------------
+    # Reaping
+    # ------------------------------------------------------------------
+
+    def reap(
+        self, orphans: list[RuntimeOrphan]
+    ) -> list[tuple[RuntimeOrphan, bool, str]]:
+        """Clean up the given orphans.
+
+        Returns:
+            (orphan, success, message) for each orphan
+        """
+        results = []
+        for orphan in orphans:
+            try:
+                ok, message = self._reap_one(orphan)
+            except Exception as e:
+                ok, message = False, str(e)
+            results.append((orphan, ok, message))
+
+        # Discovery entries for dead servers are dropped as a side effect
+        self.discovery.prune_dead()
+        return results
+
+    def _reap_one(self, orphan: RuntimeOrphan) -> tuple[bool, str]:
+        if orphan.kind in ("pid_file", "socket_file"):
+            Path(orphan.target).unlink(missing_ok=True)
+            return True, "removed"
+
+        if orphan.kind == "server":
+            if orphan.pid is None or orphan.pid == os.getpid():
+                return False, "refusing to signal this process"
+            os.kill(orphan.pid, signal.SIGTERM)
+            return True, "sent SIGTERM"
+
+        if orphan.kind == "tmux_session":
+            result = subprocess.run(  # nosec B603 B607
+                ["tmux", "kill-session", "-t", orphan.target],
+                capture_output=True,
+                text=True,
+                timeout=5,
+                check=False,
+            )
+            if result.returncode == 0:
+                return True, "killed session"
+            return False, result.stderr.strip() or "tmux kill-session failed"
+
+        return False, f"unknown orphan kind {orphan.kind}"
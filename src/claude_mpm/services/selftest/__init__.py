@@ -0,0 +1,23 @@
+"""
+End-to-end selftest for claude-mpm installations.
+
+WHY: Users need a way to confirm an installation works (agents deploy, hooks
+fire, the dashboard receives events) before relying on it for real work,
+without spending tokens or touching their own state.
+"""
+
+from .mock_backend import MockModelBackend, MockToolCall, MockTurn
+from .runner import ScenarioResult, SelftestRunner
+from .scenarios import SCENARIOS, Scenario, ScenarioContext, SelftestFailure
+
+__all__ = [
+    "SCENARIOS",
+    "MockModelBackend",
+    "MockToolCall",
+    "MockTurn",
+    "Scenario",
+    "ScenarioContext",
+    "ScenarioResult",
+    "SelftestFailure",
+    "SelftestRunner",
+]
@@ -0,0 +1,127 @@
+"""
+Scripted mock model backend for self-tests.
+
+WHY: ``claude-mpm selftest`` must exercise the real hook pipeline and
+dashboard without a network connection or API key. Instead of calling a
+model, this backend produces the exact hook payloads Claude Code would feed
+to ``claude-hook`` for a short, fixed session.
+
+DESIGN DECISION: Fully deterministic. Given the same prompt, session id and
+working directory, the same events are produced every time, so scenario
+assertions can be exact.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+
+@dataclass
+class MockToolCall:
+    """One tool invocation in a scripted turn."""
+
+    tool_name: str
+    tool_input: dict[str, Any]
+    tool_response: dict[str, Any] = field(default_factory=dict)
+
+
+@dataclass
+class MockTurn:
+    """A canned reply selected when ``match`` appears in the prompt."""
+
+    match: str
+    reply: str
+    tool_calls: list[MockToolCall] = field(default_factory=list)
+
+
+DEFAULT_SCRIPT = [
+    MockTurn(
+        match="",
+        reply="Selftest complete: listed the project directory.",
+        tool_calls=[
+            MockToolCall(
+                tool_name="Bash",
+                tool_input={"command": "ls", "description": "List project files"},
+                tool_response={"stdout": ".claude\n", "stderr": "", "exit_code": 0},
+            )
+        ],
+    )
+]
+
+
+class MockModelBackend:
+    """Replays scripted turns as Claude Code hook events."""
+
+    def __init__(self, script: list[MockTurn] | None = None):
+        self.script = script or DEFAULT_SCRIPT
+
+    @classmethod
+    def from_recording(cls, path: Path) -> MockModelBackend:
+        """Script built from a recorded session (fixture or transcript)."""
+        from ..model.recordings import load_recording
+
+        turns = load_recording(path)
+        if not turns:
+            raise ValueError(f"No replayable turns in {path}")
+        return cls(
+            [
+                MockTurn(
+                    match=turn.prompt,
+                    reply=turn.reply,
+                    tool_calls=[
+                        MockToolCall(
+                            tool_name=call.name,
+                            tool_input=call.input,
+                            tool_response={"output": call.result},
+                        )
+                        for call in turn.tool_calls
+                    ],
+                )
+                for turn in turns
+            ]
+        )
+
+    @property
+    def default_prompt(self) -> str:
+        """Prompt that selects the first scripted turn."""
+        return self.script[0].match
+
+    def select_turn(self, prompt: str) -> MockTurn:
+        """First turn whose ``match`` occurs in the prompt ('' matches all)."""
+        for turn in self.script:
+            if turn.match in prompt:
+                return turn
+        return self.script[-1]
+
+    def session_events(
+        self, prompt: str, session_id: str, cwd: str
+    ) -> list[dict[str, Any]]:
+        """Hook payloads for one prompt/response cycle, in emission order."""
+        turn = self.select_turn(prompt)
+        base = {"session_id": session_id, "cwd": cwd}
+        events: list[dict[str, Any]] = [
+            {**base, "hook_event_name": "SessionStart", "source": "startup"},
+            {**base, "hook_event_name": "UserPromptSubmit", "prompt": prompt},
+        ]
+        for call in turn.tool_calls:
+            tool = {"tool_name": call.tool_name, "tool_input": call.tool_input}
+            events.append({**base, "hook_event_name": "PreToolUse", **tool})
+            events.append(
+                {
+                    **base,
+                    "hook_event_name": "PostToolUse",
+                    **tool,
+                    "tool_response": call.tool_response,
+                }
+            )
+        events.append(
+            {
+                **base,
+                "hook_event_name": "Stop",
+                "stop_hook_active": False,
+                "last_assistant_message": turn.reply,
+            }
+        )
+        return events
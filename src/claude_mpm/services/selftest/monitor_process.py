@@ -0,0 +1,38 @@
+"""
+Standalone monitor server process used by ``claude-mpm selftest``.
+
+WHY: The selftest must run the real dashboard server, but inside an isolated
+HOME so it never touches the user's discovery registry or state. Running it
+as ``python -m claude_mpm.services.selftest.monitor_process`` lets the
+runner control the environment and kill it cleanly.
+"""
+
+import argparse
+import signal
+import sys
+import threading
+
+
+def main(argv: list[str] | None = None) -> int:
+    parser = argparse.ArgumentParser(description=__doc__)
+    parser.add_argument("--host", default="localhost")
+    parser.add_argument("--port", type=int, required=True)
+    args = parser.parse_args(argv)
+
+    from ..monitor.server import UnifiedMonitorServer
+
+    server = UnifiedMonitorServer(host=args.host, port=args.port)
+    if not server.start():
+        print(f"monitor failed to start: {server.startup_error}", file=sys.stderr)
+        return 1
+
+    stop = threading.Event()
+    signal.signal(signal.SIGTERM, lambda *_: stop.set())
+    signal.signal(signal.SIGINT, lambda *_: stop.set())
+    stop.wait()
+    server.stop()
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
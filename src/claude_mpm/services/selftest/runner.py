@@ -0,0 +1,118 @@
+"""
+Selftest runner: executes scenarios in an isolated sandbox workspace.
+
+DESIGN DECISIONS:
+- Every run gets a fresh temp directory with its own HOME and project, so a
+  selftest never reads or modifies the user's real state
+- Scenarios run sequentially; one whose ``requires`` did not pass is
+  reported as skipped rather than failed
+- Teardown (server processes, socket clients) always runs, even on failure
+"""
+
+from __future__ import annotations
+
+import shutil
+import tempfile
+import time
+from collections.abc import Callable
+from dataclasses import asdict, dataclass
+from pathlib import Path
+
+from ...core.logger import get_logger
+from .mock_backend import MockModelBackend
+from .scenarios import SCENARIOS, Scenario, ScenarioContext, SelftestFailure
+
+logger = get_logger(__name__)
+
+
+@dataclass
+class ScenarioResult:
+    name: str
+    status: str  # passed | failed | skipped
+    detail: str
+    duration: float
+
+    def to_dict(self) -> dict:
+        return asdict(self)
+
+
+class SelftestRunner:
+    """Runs selftest scenarios and collects their results."""
+
+    def __init__(
+        self,
+        scenarios: list[Scenario] | None = None,
+        backend: MockModelBackend | None = None,
+        keep_workspace: bool = False,
+    ):
+        self.scenarios = SCENARIOS if scenarios is None else scenarios
+        self.backend = backend or MockModelBackend()
+        self.keep_workspace = keep_workspace
+        self.workspace: Path | None = None
+
+    def select(self, names: list[str] | None) -> list[Scenario]:
+        """Scenarios to run, pulling in prerequisites of the named ones."""
+        if not names:
+            return list(self.scenarios)
+
+        by_name = {s.name: s for s in self.scenarios}
+        unknown = [n for n in names if n not in by_name]
+        if unknown:
+            raise ValueError(f"Unknown scenario(s): {', '.join(unknown)}")
+
+        wanted: set[str] = set()
+        stack = list(names)
+        while stack:
+            name = stack.pop()
+            if name not in wanted:
+                wanted.add(name)
+                stack.extend(by_name[name].requires)
+        return [s for s in self.scenarios if s.name in wanted]
+
+    def run(
+        self,
+        names: list[str] | None = None,
+        on_result: Callable[[ScenarioResult], None] | None = None,
+    ) -> list[ScenarioResult]:
+        scenarios = self.select(names)
+        self.workspace = Path(tempfile.mkdtemp(prefix="claude-mpm-selftest-"))
+        ctx = ScenarioContext(root=self.workspace, backend=self.backend)
+        ctx.home.mkdir()
+        ctx.project.mkdir()
+
+        results: list[ScenarioResult] = []
+        passed: set[str] = set()
+        try:
+            for scenario in scenarios:
+                result = self._run_one(scenario, ctx, passed)
+                if result.status == "passed":
+                    passed.add(scenario.name)
+                results.append(result)
+                if on_result:
+                    on_result(result)
+        finally:
+            ctx.cleanup()
+            if not self.keep_workspace:
+                shutil.rmtree(self.workspace, ignore_errors=True)
+        return results
+
+    @staticmethod
+    def _run_one(
+        scenario: Scenario, ctx: ScenarioContext, passed: set[str]
+    ) -> ScenarioResult:
+        missing = [r for r in scenario.requires if r not in passed]
+        if missing:
+            return ScenarioResult(
+                scenario.name, "skipped", f"requires {', '.join(missing)}", 0.0
+            )
+
+        start = time.monotonic()
+        try:
+            detail = scenario.run(ctx) or ""
+            status = "passed"
+        except SelftestFailure as e:
+            detail, status = str(e), "failed"
+        except Exception as e:
+            logger.debug(f"Selftest scenario {scenario.name} crashed", exc_info=True)
+            detail, status = f"{type(e).__name__}: {e}", "failed"
+        return ScenarioResult(scenario.name, status, detail, time.monotonic() - start)
@@ -0,0 +1,294 @@
+"""
+Built-in end-to-end scenarios for ``claude-mpm selftest``.
+
+Each scenario is a plain function taking a :class:`ScenarioContext`. It
+returns a short detail string on success and raises :class:`SelftestFailure`
+(or any exception) on failure. Scenarios run in order and may hand results to
+later ones through ``ctx.shared``; ``requires`` lets the runner skip a
+scenario whose prerequisite failed instead of reporting a cascade of errors.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import socket
+import subprocess  # nosec B404
+import sys
+import threading
+import time
+import urllib.request
+from collections.abc import Callable, Iterator
+from contextlib import contextmanager
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+from .mock_backend import MockModelBackend
+
+SELFTEST_PROMPT = "claude-mpm selftest: list the project files"
+
+
+class SelftestFailure(Exception):
+    """A scenario check did not hold."""
+
+
+@dataclass
+class ScenarioContext:
+    """Isolated workspace shared by all scenarios in one run."""
+
+    root: Path
+    backend: MockModelBackend = field(default_factory=MockModelBackend)
+    host: str = "localhost"
+    shared: dict[str, Any] = field(default_factory=dict)
+    cleanups: list[Callable[[], None]] = field(default_factory=list)
+
+    @property
+    def home(self) -> Path:
+        return self.root / "home"
+
+    @property
+    def project(self) -> Path:
+        return self.root / "project"
+
+    def _home_env(self) -> dict[str, str]:
+        return {
+            "HOME": str(self.home),
+            "USERPROFILE": str(self.home),
+            "CLAUDE_MPM_EVENT_SPOOL_DIR": str(self.home / "event_spool"),
+        }
+
+    def env(self, **extra: str) -> dict[str, str]:
+        """Subprocess environment pointing HOME at the sandbox."""
+        env = dict(os.environ)
+        env.update(self._home_env())
+        env.update(extra)
+        return env
+
+    @contextmanager
+    def sandbox_home(self) -> Iterator[None]:
+        """Point HOME at the sandbox while a scenario works in-process."""
+        sandbox = self._home_env()
+        saved = {name: os.environ.get(name) for name in sandbox}
+        os.environ.update(sandbox)
+        try:
+            yield
+        finally:
+            for name, value in saved.items():
+                if value is None:
+                    os.environ.pop(name, None)
+                else:
+                    os.environ[name] = value
+
+    def cleanup(self) -> None:
+        while self.cleanups:
+            try:
+                self.cleanups.pop()()
+            except Exception:  # nosec B110 - best-effort teardown
+                pass
+
+
+@dataclass
+class Scenario:
+    name: str
+    description: str
+    run: Callable[[ScenarioContext], str]
+    requires: tuple[str, ...] = ()
+
+
+# ----------------------------------------------------------------------
+# Scenario implementations
+# ----------------------------------------------------------------------
+
+
+def deploy_agent(ctx: ScenarioContext) -> str:
+    """Deploy a bundled agent into the sandbox project."""
+    from ... import __file__ as package_init
+    from ..agents.deployment.agent_deployment import AgentDeploymentService
+
+    templates_dir = Path(package_init).parent / "agents" / "bundled"
+    target_dir = ctx.project / ".claude" / "agents"
+    with ctx.sandbox_home():
+        service = AgentDeploymentService(
+            templates_dir=templates_dir, working_directory=ctx.project
+        )
+        if not service.deploy_agent("ticketing", target_dir, force_rebuild=True):
+            raise SelftestFailure("deployment service reported failure")
+
+    deployed = target_dir / "ticketing.md"
+    if not deployed.exists():
+        raise SelftestFailure(f"{deployed} was not written")
+    if "name: ticketing" not in deployed.read_text():
+        raise SelftestFailure("deployed agent is missing its frontmatter name")
+    return f"deployed {deployed.relative_to(ctx.root)}"
+
+
+def mock_session(ctx: ScenarioContext) -> str:
+    """Run a scripted session against the mock backend."""
+    session_id = f"selftest-{os.getpid()}-{int(time.time())}"
+    prompt = ctx.backend.default_prompt or SELFTEST_PROMPT
+    events = ctx.backend.session_events(prompt, session_id, str(ctx.project))
+    replay = ctx.backend.session_events(prompt, session_id, str(ctx.project))
+    if events != replay:
+        raise SelftestFailure("mock backend is not deterministic")
+
+    names = [e["hook_event_name"] for e in events]
+    if names[0] != "SessionStart" or names[-1] != "Stop":
+        raise SelftestFailure(f"unexpected event sequence: {names}")
+
+    ctx.shared["session_id"] = session_id
+    ctx.shared["events"] = events
+    return f"{len(events)} hook events scripted ({', '.join(names)})"
+
+
+def _free_port(host: str) -> int:
+    with socket.socket(socket.AF_INET, socket.SOCK_STREAM) as sock:
+        sock.bind((host, 0))
+        return sock.getsockname()[1]
+
+
+def _wait_healthy(url: str, proc: subprocess.Popen, timeout: float) -> None:
+    deadline = time.monotonic() + timeout
+    while time.monotonic() < deadline:
+        if proc.poll() is not None:
+            raise SelftestFailure(f"monitor exited with code {proc.returncode}")
+        try:
+            with urllib.request.urlopen(url, timeout=1) as response:  # nosec B310
+                if response.status == 200:
+                    return
+        except OSError:
+            pass
+        time.sleep(0.2)
+    raise SelftestFailure(f"monitor did not become healthy at {url}")
+
+
+def start_monitor(ctx: ScenarioContext) -> str:
+    """Start the dashboard server in the sandbox and subscribe to it."""
+    import socketio
+
+    port = _free_port(ctx.host)
+    proc = subprocess.Popen(  # nosec B603
+        [
+            sys.executable,
+            "-m",
+            "claude_mpm.services.selftest.monitor_process",
+            "--host",
+            ctx.host,
+            "--port",
+            str(port),
+        ],
+        cwd=ctx.project,
+        env=ctx.env(),
+        stdout=subprocess.DEVNULL,
+        stderr=subprocess.PIPE,
+    )
+
+    def stop_monitor():
+        proc.terminate()
+        try:
+            proc.wait(timeout=10)
+        except subprocess.TimeoutExpired:
+            proc.kill()
+
+    ctx.cleanups.append(stop_monitor)
+    base_url = f"http://{ctx.host}:{port}"
+    _wait_healthy(f"{base_url}/health", proc, timeout=20)
+
+    received: list[dict] = []
+    lock = threading.Lock()
+    client = socketio.Client(reconnection=False)
+
+    @client.on("*")
+    def _collect(event, data=None):
+        if isinstance(data, dict):
+            with lock:
+                received.append(data)
+
+    client.connect(base_url, wait_timeout=10)
+    ctx.cleanups.append(client.disconnect)
+
+    ctx.shared.update(port=port, received=received, received_lock=lock)
+    return f"monitor healthy on port {port}"
+
+
+def emit_events(ctx: ScenarioContext) -> str:
+    """Feed the scripted events through the real hook handler."""
+    env = ctx.env(CLAUDE_MPM_SERVER_PORT=str(ctx.shared["port"]))
+    for event in ctx.shared["events"]:
+        result = subprocess.run(  # nosec B603
+            [sys.executable, "-m", "claude_mpm.hooks.claude_hooks.hook_handler"],
+            input=json.dumps(event),
+            capture_output=True,
+            text=True,
+            cwd=ctx.project,
+            env=env,
+            timeout=30,
+            check=False,
+        )
+        if result.returncode != 0:
+            raise SelftestFailure(
+                f"hook handler exited {result.returncode} on "
+                f"{event['hook_event_name']}: {result.stderr.strip()[-200:]}"
+            )
+        if '"continue"' not in result.stdout:
+            raise SelftestFailure(
+                f"hook handler gave no continue response for "
+                f"{event['hook_event_name']}"
+            )
+    return f"{len(ctx.shared['events'])} events accepted by claude-hook"
+
+
+def verify_dashboard(ctx: ScenarioContext) -> str:
+    """Check the dashboard received the session's events."""
+    session_id = ctx.shared["session_id"]
+    expected = len(ctx.shared["events"])
+    deadline = time.monotonic() + 15
+    matched: list[dict] = []
+    while time.monotonic() < deadline:
+        with ctx.shared["received_lock"]:
+            matched = [
+                e for e in ctx.shared["received"] if e.get("session_id") == session_id
+            ]
+        if len(matched) >= expected:
+            break
+        time.sleep(0.25)
+
+    if not matched:
+        raise SelftestFailure("dashboard received no events for the session")
+    if len(matched) < expected:
+        raise SelftestFailure(
+            f"dashboard received {len(matched)} of {expected} session events"
+        )
+    subtypes = sorted({str(e.get("subtype")) for e in matched})
+    return f"dashboard received {len(matched)} events ({', '.join(subtypes)})"
+
+
+SCENARIOS: list[Scenario] = [
+    Scenario(
+        "deploy_agent",
+        "Deploy a bundled agent into a sandbox project",
+        deploy_agent,
+    ),
+    Scenario(
+        "mock_session",
+        "Run a scripted session against the mock model backend",
+        mock_session,
+    ),
+    Scenario(
+        "start_monitor",
+        "Start the dashboard server and connect a Socket.IO client",
+        start_monitor,
+    ),
+    Scenario(
+        "emit_events",
+        "Send the session's hook events through claude-hook",
+        emit_events,
+        requires=("mock_session", "start_monitor"),
+    ),
+    Scenario(
+        "verify_dashboard",
+        "Verify the dashboard received every session event",
+        verify_dashboard,
+        requires=("emit_events",),
+    ),
+]
@@ -0,0 +1,245 @@
+"""
+Local Server Discovery Registry
+===============================
+
+User-level registry of running monitor/dashboard servers, keyed by project.
+
+WHY: Multiple projects on one machine used to fight over the default dashboard
+port (8765). The per-project ``socketio-instances.json`` tracked by
+``PortManager`` cannot see servers started from other projects, so a second
+project either collided on 8765 or silently picked a random port that
+``claude-mpm dashboard open`` could not find again.
+
+DESIGN DECISIONS:
+- Single JSON file in ``~/.claude-mpm/servers.json`` shared by all projects
+- One entry per project root; re-registering a project replaces its entry.
+  Several projects may share a port when the same process (the shared
+  daemon) serves all of them
+- Liveness is checked with ``os.kill(pid, 0)`` so readers never need psutil
+- Writes are serialized with ``config_file_lock`` and land atomically
+- Port negotiation prefers the project's previous port, then the requested
+  port, then the first free port in ``PortManager.PORT_RANGE`` that no other
+  live project has claimed
+"""
+
+import json
+import os
+import socket
+import time
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from ..core.config_file_lock import ConfigFileLockError, config_file_lock
+from ..core.logging_config import get_logger
+
+# Mirrors PortManager.PORT_RANGE without importing psutil on read paths
+DISCOVERY_PORT_RANGE = range(8765, 8786)
+DEFAULT_REGISTRY_PATH = Path.home() / ".claude-mpm" / "servers.json"
+
+
+class ServerDiscoveryRegistry:
+    """Tracks which local port each project's dashboard server is bound to."""
+
+    def __init__(self, registry_path: Path | None = None):
+        self.logger = get_logger(__name__)
+        self.registry_path = registry_path or DEFAULT_REGISTRY_PATH
+
+    # ------------------------------------------------------------------
+    # Persistence
+    # ------------------------------------------------------------------
+
+    def _load(self) -> dict[str, dict[str, Any]]:
+        if not self.registry_path.exists():
+            return {}
+        try:
+            data = json.loads(self.registry_path.read_text())
+        except (OSError, json.JSONDecodeError) as e:
+            self.logger.warning(f"Ignoring unreadable server registry: {e}")
+            return {}
+        return data if isinstance(data, dict) else {}
+
+    def _save(self, servers: dict[str, dict[str, Any]]) -> None:
+        self.registry_path.parent.mkdir(parents=True, exist_ok=True)
+        tmp = self.registry_path.with_suffix(".json.tmp")
+        tmp.write_text(json.dumps(servers, indent=2))
+        tmp.replace(self.registry_path)
+
+    @staticmethod
+    def _is_alive(pid: int | None) -> bool:
+        if not pid:
+            return False
+        try:
+            os.kill(pid, 0)
+        except ProcessLookupError:
+            return False
+        except PermissionError:
+            # Process exists but belongs to another user
+            return True
+        except OSError:
+            return False
+        return True
+
+    @staticmethod
+    def _key(project_root: Path) -> str:
+        return str(Path(project_root).resolve())
+
+    # ------------------------------------------------------------------
+    # Registration
+    # ------------------------------------------------------------------
+
+    def register(
+        self,
+        project_root: Path,
+        port: int,
+        pid: int | None = None,
+        host: str = "localhost",
+    ) -> dict[str, Any]:
+        """Record that ``project_root`` is served on ``host:port``."""
+        entry = {
+            "project_root": self._key(project_root),
+            "port": port,
+            "host": host,
+            "pid": pid or os.getpid(),
+            "started_at": time.time(),
+        }
+        try:
+            with config_file_lock(self.registry_path):
+                servers = self._load()
+                # A port belongs to one server process; entries for the same
+                # port from a different (stale) process are evicted, while a
+                # shared daemon may serve the port for several projects.
+                servers = {
+                    key: info
+                    for key, info in servers.items()
+                    if info.get("port") != port or info.get("pid") == entry["pid"]
+                }
+                servers[entry["project_root"]] = entry
+                self._save(servers)
+        except (ConfigFileLockError, OSError) as e:
+            self.logger.warning(f"Could not register server for discovery: {e}")
+        return entry
+
+    def unregister(self, project_root: Path, pid: int | None = None) -> bool:
+        """Remove a project's entry.
+
+        Args:
+            project_root: Project whose server is going away
+            pid: When given, only remove the entry if it still belongs to this
+                process (another server may have re-registered the project)
+        """
+        key = self._key(project_root)
+        try:
+            with config_file_lock(self.registry_path):
+                servers = self._load()
+                entry = servers.get(key)
+                if entry is None or (pid is not None and entry.get("pid") != pid):
+                    return False
+                del servers[key]
+                self._save(servers)
+                return True
+        except (ConfigFileLockError, OSError) as e:
+            self.logger.warning(f"Could not unregister server: {e}")
+            return False
+
+    def prune_dead(self) -> int:
+        """Drop entries whose server process has exited."""
+        try:
+            with config_file_lock(self.registry_path):
+                servers = self._load()
+                alive = {
+                    key: info
+                    for key, info in servers.items()
+                    if self._is_alive(info.get("pid"))
+                }
+                removed = len(servers) - len(alive)
+                if removed:
+                    self._save(alive)
+                return removed
+        except (ConfigFileLockError, OSError) as e:
+            self.logger.debug(f"Could not prune server registry: {e}")
+            return 0
+
+    # ------------------------------------------------------------------
+    # Queries
+    # ------------------------------------------------------------------
+
+    def list_servers(self) -> list[dict[str, Any]]:
+        """Return all live servers, sorted by port."""
+        servers = [
+            info for info in self._load().values() if self._is_alive(info.get("pid"))
+        ]
+        return sorted(servers, key=lambda info: info.get("port", 0))
+
+    def lookup(self, project_root: Path) -> dict[str, Any] | None:
+        """Return the live server entry for ``project_root``, if any."""
+        entry = self._load().get(self._key(project_root))
+        if entry and self._is_alive(entry.get("pid")):
+            return entry
+        return None
+
+    def lookup_nearest(self, path: Path) -> dict[str, Any] | None:
+        """Return the live server entry for *path* or its closest parent.
+
+        For callers that run somewhere inside a project, such as hooks.
+        """
+        resolved = Path(path).resolve()
+        for candidate in (resolved, *resolved.parents):
+            entry = self.lookup(candidate)
+            if entry:
+                return entry
+        return None
+
+    # ------------------------------------------------------------------
+    # Port negotiation
+    # ------------------------------------------------------------------
+
+    @staticmethod
+    def _port_free(port: int, host: str = "localhost") -> bool:
+        try:
+            with socket.socket(socket.AF_INET, socket.SOCK_STREAM) as sock:
+                sock.setsockopt(socket.SOL_SOCKET, socket.SO_REUSEADDR, 1)
+                sock.bind((host, port))
+                return True
+        except OSError:
+            return False
+
+    def negotiate_port(
+        self,
+        project_root: Path,
+        preferred_port: int | None = None,
+        host: str = "localhost",
+        exclude: Iterable[int] = (),
+    ) -> int | None:
+        """Pick the port a project's server should use.
+
+        Returns the port of an already-running server for this project so
+        callers can reuse it; otherwise the first free, unclaimed port not in
+        *exclude* (ports reserved for servers that are not up yet).
+
+        Returns:
+            A port number, or None if the whole range is exhausted
+        """
+        existing = self.lookup(project_root)
+        if existing:
+            return existing["port"]
+
+        key = self._key(project_root)
+        claimed = {
+            info.get("port")
+            for info in self._load().values()
+            if info.get("project_root") != key and self._is_alive(info.get("pid"))
+        }
+        claimed.update(exclude)
+
+        candidates = [preferred_port] if preferred_port else []
+        candidates.extend(p for p in DISCOVERY_PORT_RANGE if p != preferred_port)
+        for port in candidates:
+            if port not in claimed and self._port_free(port, host):
+                return port
+
+        self.logger.error(
+            f"No free dashboard port in {DISCOVERY_PORT_RANGE.start}-"
+            f"{DISCOVERY_PORT_RANGE.stop - 1}"
+        )
+        return None
@@ -43,6 +43,13 @@ Timeline entry format::
     **Outcome:** {response_summary}
 
     **Links:** {url1} {url2}
+
+    **Comments:**
+
+    > **{author}** ({YYYY-MM-DD HH:MM}): {text}
+
+Comments are the review annotations attached to the entry's transcript uuid
+(see services/annotations.py); entries without comments omit the section.
 """
 
 from __future__ import annotations
@@ -51,7 +58,7 @@ import re
 import warnings
 from datetime import UTC, datetime
 from pathlib import Path
-from typing import Any
+from typing import TYPE_CHECKING, Any
 
 try:
     import yaml  # PyYAML — already a project dep via other modules
@@ -62,6 +69,9 @@ except ImportError as _yaml_import_err:  # pragma: no cover
 
 from .transcript_parser import SessionReport, TimelineEvent  # noqa: TC001
 
+if TYPE_CHECKING:
+    from ..annotations import Annotation
+
 # ---------------------------------------------------------------------------
 # Rendering helpers
 # ---------------------------------------------------------------------------
@@ -190,15 +200,24 @@ def _model_breakdown(report: SessionReport) -> list[dict[str, Any]]:
 # ---------------------------------------------------------------------------
 
 
-def render_markdown(report: SessionReport) -> str:
+def render_markdown(
+    report: SessionReport,
+    annotations: list[Annotation] | None = None,
+    labels: list[str] | None = None,
+) -> str:
     """Render *report* to the canonical Markdown string.
 
-    WHAT: Produces YAML frontmatter + Timeline section from a SessionReport.
+    WHAT: Produces YAML frontmatter + Timeline section from a SessionReport,
+          with *annotations* shown under the entries they comment on and the
+          session's *labels* in the frontmatter.
     WHY:  Centralises all rendering logic; callers and tests work only with
           the report dataclass and never with raw JSONL.
     """
     now = datetime.now(tz=UTC)
     project_path = Path(report.project_path)
+    comments: dict[str, list[Annotation]] = {}
+    for annotation in annotations or []:
+        comments.setdefault(annotation.target, []).append(annotation)
 
     # -- Frontmatter ----------------------------------------------------------
     frontmatter: dict[str, Any] = {
@@ -216,6 +235,8 @@ def render_markdown(report: SessionReport) -> str:
         "stat_cards": _stat_cards(report),
         "has_pricing_fallback": report.has_pricing_fallback,
     }
+    if labels:
+        frontmatter["labels"] = list(labels)
 
     lines: list[str] = []
     lines.append("---")
@@ -312,16 +333,36 @@ def render_markdown(report: SessionReport) -> str:
             lines.append(f"**Links:** {links_str}")
             lines.append("")
 
+        # Review comments
+        event_comments = comments.get(f"transcript:{event.uuid}")
+        if event.uuid and event_comments:
+            lines.append("**Comments:**")
+            lines.append("")
+            for annotation in event_comments:
+                text = annotation.text.replace("\n", "\n> ")
+                lines.append(
+                    f"> **{annotation.author}** "
+                    f"({annotation.created_at[:16].replace('T', ' ')}): {text}"
+                )
+                lines.append("")
+
         lines.append("---")
         lines.append("")
 
     return "\n".join(lines)
 
 
-def write_report(report: SessionReport, output_path: Path) -> None:
+def write_report(
+    report: SessionReport,
+    output_path: Path,
+    annotations: list[Annotation] | None = None,
+    labels: list[str] | None = None,
+) -> None:
     """Render *report* and write it to *output_path*, creating parent dirs."""
     output_path.parent.mkdir(parents=True, exist_ok=True)
-    output_path.write_text(render_markdown(report), encoding="utf-8")
+    output_path.write_text(
+        render_markdown(report, annotations, labels), encoding="utf-8"
+    )
 
 
 # ---------------------------------------------------------------------------
@@ -358,8 +399,8 @@ def read_markdown(path: Path) -> dict[str, Any]:
           scanned line-by-line with a stateful parser: each ``#### HH:MM · actor
           · title`` heading starts a new event dict, an inline ``<!-- meta: ... -->``
           comment is parsed into key/value pairs, ``**Calls:**`` / ``**Outcome:**``
-          / ``**Links:**`` markers route subsequent lines into their respective
-          accumulator lists, and ``---`` horizontal-rule separators are silently
+          / ``**Links:**`` / ``**Comments:**`` markers route subsequent lines
+          into their respective accumulator lists, and ``---`` horizontal-rule separators are silently
           skipped. When the next heading (or end-of-file) is reached, the
           accumulated state is flushed into the events list. Returns a dict with
           keys ``frontmatter`` and ``events``.
@@ -373,7 +414,7 @@ def read_markdown(path: Path) -> dict[str, Any]:
 
     Each event dict has:
     ``time``, ``actor``, ``title``, ``detail``, ``meta`` (from HTML comment),
-    ``calls_text``, ``outcome_text``, ``links_text``.
+    ``calls_text``, ``outcome_text``, ``links_text``, ``comments_text``.
     """
     text = path.read_text(encoding="utf-8")
 
@@ -402,10 +443,13 @@ def read_markdown(path: Path) -> dict[str, Any]:
     calls_lines: list[str] = []
     outcome_line = ""
     links_line = ""
+    comments_lines: list[str] = []
     in_calls = False
+    in_comments = False
 
     def _flush() -> None:
-        nonlocal current, detail_lines, calls_lines, outcome_line, links_line, in_calls
+        nonlocal current, detail_lines, calls_lines, outcome_line, links_line
+        nonlocal comments_lines, in_calls, in_comments
         if current is None:
             return
         # Strip leading/trailing blank lines; collapse runs of 2+ blank lines
@@ -415,13 +459,16 @@ def read_markdown(path: Path) -> dict[str, Any]:
         current["calls_text"] = "\n".join(calls_lines).strip()
         current["outcome_text"] = outcome_line
         current["links_text"] = links_line
+        current["comments_text"] = "\n".join(comments_lines).strip()
         events.append(current)
         current = None
         detail_lines = []
         calls_lines = []
         outcome_line = ""
         links_line = ""
+        comments_lines = []
         in_calls = False
+        in_comments = False
 
     for line in body.splitlines():
         # New heading?
@@ -465,7 +512,15 @@ def read_markdown(path: Path) -> dict[str, Any]:
             links_line = line[len("**Links:**") :].strip()
             continue
 
-        if in_calls:
+        if line.startswith("**Comments:**"):
+            in_calls = False
+            in_comments = True
+            continue
+
+        if in_comments:
+            if line.startswith("> "):
+                comments_lines.append(line[2:])
+        elif in_calls:
             if line.startswith("- "):
                 calls_lines.append(line)
         else:
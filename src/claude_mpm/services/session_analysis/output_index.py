@@ -0,0 +1,320 @@
+"""
+Full-text index of session output for ``claude-mpm grep-output``.
+
+WHAT: Extracts every tool result (terminal output, file reads, search hits),
+      tool input and message from a project's Claude Code transcripts,
+      subagents included, into a SQLite index at
+      ``.claude-mpm/output_index.db``, and greps it with a Python regex the
+      way ripgrep greps files.
+WHY:  "Where did this wrong constant come from?" is answered by the first
+      tool output it appeared in, but transcripts are JSONL with output
+      nested inside content blocks, so grepping them directly finds escaped
+      JSON rather than the lines a user saw.
+
+DESIGN DECISIONS
+----------------
+- Incremental: a transcript is re-extracted only when its size or mtime
+  changed since it was last indexed
+- Regex matching runs inside SQLite via a registered REGEXP function, so
+  only matching records are loaded into Python
+- Secrets are redacted before indexing, as in session reports, so the index
+  never becomes a second copy of leaked credentials
+- With at-rest encryption on for transcripts the index lives in memory and
+  is rebuilt per search: a plaintext SQLite file would defeat the encryption
+
+References
+----------
+LINK: none  (grep-output command)
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import sqlite3
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+
+from .transcript_parser import (
+    _claude_projects_root,
+    _encode_cwd,
+    _extract_text_from_content,
+    _parse_jsonl,
+    _redact_secrets,
+)
+
+TOOL_RESULT = "tool_result"
+TOOL_INPUT = "tool_input"
+ASSISTANT = "assistant"
+USER = "user"
+RECORD_KINDS = (TOOL_RESULT, TOOL_INPUT, ASSISTANT, USER)
+
+_SCHEMA = """
+CREATE TABLE IF NOT EXISTS transcripts (
+    path TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    mtime REAL NOT NULL
+);
+CREATE TABLE IF NOT EXISTS records (
+    path TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    timestamp TEXT,
+    kind TEXT NOT NULL,
+    tool TEXT,
+    agent TEXT,
+    text TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS records_session ON records (session_id);
+"""
+
+
+def default_index_path(project_dir: Path) -> Path:
+    return Path(project_dir) / ".claude-mpm" / "output_index.db"
+
+
+@dataclass
+class OutputMatch:
+    """A record with at least one matching line, ripgrep-style."""
+
+    session_id: str
+    seq: int
+    timestamp: str | None
+    kind: str
+    tool: str | None
+    agent: str | None
+    # (line number, text, is_match); non-matches are context lines
+    lines: list[tuple[int, str, bool]] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "session_id": self.session_id,
+            "seq": self.seq,
+            "timestamp": self.timestamp,
+            "kind": self.kind,
+            "tool": self.tool,
+            "agent": self.agent,
+            "lines": [
+                {"line": number, "text": text, "match": is_match}
+                for number, text, is_match in self.lines
+            ],
+        }
+
+
+def extract_records(path: Path) -> list[dict[str, Any]]:
+    """Searchable records from one transcript, in transcript order."""
+    tool_names: dict[str, str] = {}
+    agent = path.stem if path.parent.name == "subagents" else None
+    records: list[dict[str, Any]] = []
+
+    def add(raw: dict[str, Any], kind: str, text: str, tool: str | None) -> None:
+        if text.strip():
+            records.append(
+                {
+                    "seq": len(records),
+                    "timestamp": raw.get("timestamp"),
+                    "kind": kind,
+                    "tool": tool,
+                    "agent": agent,
+                    "text": _redact_secrets(text),
+                }
+            )
+
+    for raw in _parse_jsonl(path):
+        message = raw.get("message")
+        if not isinstance(message, dict):
+            continue
+        role = message.get("role") or raw.get("type")
+        content = message.get("content")
+        if isinstance(content, str):
+            add(raw, ASSISTANT if role == "assistant" else USER, content, None)
+            continue
+        if not isinstance(content, list):
+            continue
+        for block in content:
+            if not isinstance(block, dict):
+                continue
+            block_type = block.get("type")
+            if block_type == "text":
+                kind = ASSISTANT if role == "assistant" else USER
+                add(raw, kind, block.get("text", ""), None)
+            elif block_type == "tool_use":
+                tool_names[block.get("id", "")] = block.get("name", "")
+                tool_input = block.get("input", {})
+                text = (
+                    tool_input
+                    if isinstance(tool_input, str)
+                    else json.dumps(tool_input, indent=1, ensure_ascii=False)
+                )
+                add(raw, TOOL_INPUT, text, block.get("name"))
+            elif block_type == "tool_result":
+                tool = tool_names.get(block.get("tool_use_id", ""))
+                text = _extract_text_from_content(block.get("content", []))
+                add(raw, TOOL_RESULT, text, tool)
+    return records
+
+
+class OutputIndex:
+    """SQLite index over one project's session transcripts."""
+
+    def __init__(
+        self,
+        project_dir: Path,
+        index_path: Path | None = None,
+        transcripts_dir: Path | None = None,
+        persist: bool | None = None,
+    ):
+        self.project_dir = Path(project_dir)
+        self.index_path = index_path or default_index_path(self.project_dir)
+        self.transcripts_dir = transcripts_dir or (
+            _claude_projects_root() / _encode_cwd(str(self.project_dir.resolve()))
+        )
+        self.persist = not _transcripts_encrypted() if persist is None else persist
+        self._memory: sqlite3.Connection | None = None
+
+    def _connect(self) -> sqlite3.Connection:
+        if not self.persist:
+            if self._memory is None:
+                # An index written before encryption was turned on
+                self.index_path.unlink(missing_ok=True)
+                self._memory = sqlite3.connect(":memory:")
+                self._memory.executescript(_SCHEMA)
+            return self._memory
+        self.index_path.parent.mkdir(parents=True, exist_ok=True)
+        connection = sqlite3.connect(self.index_path)
+        connection.executescript(_SCHEMA)
+        return connection
+
+    def _release(self, connection: sqlite3.Connection) -> None:
+        if connection is not self._memory:
+            connection.close()
+
+    def transcripts(self, session_id: str | None = None) -> list[tuple[str, Path]]:
+        """(session id, path) for main and subagent transcripts."""
+        if not self.transcripts_dir.is_dir():
+            return []
+        found = []
+        for path in sorted(self.transcripts_dir.glob("*.jsonl")):
+            if session_id and not path.stem.startswith(session_id):
+                continue
+            found.append((path.stem, path))
+            subagents = self.transcripts_dir / path.stem / "subagents"
+            found.extend((path.stem, sub) for sub in sorted(subagents.glob("*.jsonl")))
+        return found
+
+    def update(self, session_id: str | None = None, rebuild: bool = False) -> int:
+        """Index new or changed transcripts; returns how many were (re)indexed."""
+        updated = 0
+        with self._connect() as connection:
+            if rebuild:
+                connection.execute("DELETE FROM records")
+                connection.execute("DELETE FROM transcripts")
+            known = {
+                path: (size, mtime)
+                for path, size, mtime in connection.execute(
+                    "SELECT path, size, mtime FROM transcripts"
+                )
+            }
+            for session, path in self.transcripts(session_id):
+                stat = path.stat()
+                if known.get(str(path)) == (stat.st_size, stat.st_mtime):
+                    continue
+                connection.execute("DELETE FROM records WHERE path = ?", (str(path),))
+                connection.executemany(
+                    "INSERT INTO records (path, session_id, seq, timestamp, kind, "
+                    "tool, agent, text) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+                    [
+                        (
+                            str(path),
+                            session,
+                            r["seq"],
+                            r["timestamp"],
+                            r["kind"],
+                            r["tool"],
+                            r["agent"],
+                            r["text"],
+                        )
+                        for r in extract_records(path)
+                    ],
+                )
+                connection.execute(
+                    "INSERT OR REPLACE INTO transcripts VALUES (?, ?, ?, ?)",
+                    (str(path), session, stat.st_size, stat.st_mtime),
+                )
+                updated += 1
+        self._release(connection)
+        return updated
+
+    def grep(
+        self,
+        pattern: str,
+        session_id: str | None = None,
+        ignore_case: bool = False,
+        fixed_strings: bool = False,
+        kinds: tuple[str, ...] | None = None,
+        tool: str | None = None,
+        context: int = 0,
+        max_count: int | None = None,
+    ) -> list[OutputMatch]:
+        """Records containing ``pattern``, oldest first.
+
+        ``session_id`` may be a prefix, as session ids are long UUIDs.
+        ``max_count`` caps the number of matching records returned.
+        """
+        regex = re.compile(
+            re.escape(pattern) if fixed_strings else pattern,
+            re.IGNORECASE if ignore_case else 0,
+        )
+        where = ["text REGEXP ?"]
+        params: list[Any] = [pattern]
+        if session_id:
+            where.append("session_id LIKE ?")
+            params.append(f"{session_id}%")
+        if kinds:
+            where.append(f"kind IN ({', '.join('?' for _ in kinds)})")
+            params.extend(kinds)
+        if tool:
+            where.append("tool = ?")
+            params.append(tool)
+        sql = (
+            "SELECT session_id, seq, timestamp, kind, tool, agent, text FROM records "
+            f"WHERE {' AND '.join(where)} ORDER BY timestamp, path, seq"
+        )
+        if max_count:
+            sql += f" LIMIT {int(max_count)}"
+
+        connection = self._connect()
+        connection.create_function(
+            "REGEXP", 2, lambda _, text: regex.search(text) is not None
+        )
+        try:
+            rows = connection.execute(sql, params).fetchall()
+        finally:
+            self._release(connection)
+        return [_match_lines(row, regex, context) for row in rows]
+
+
+def _transcripts_encrypted() -> bool:
+    from ..data_encryption import TRANSCRIPTS, EncryptionConfig
+
+    config = EncryptionConfig.load()
+    return config.enabled and TRANSCRIPTS in config.scopes
+
+
+def _match_lines(row: tuple, regex: re.Pattern[str], context: int) -> OutputMatch:
+    session_id, seq, timestamp, kind, tool, agent, text = row
+    match = OutputMatch(session_id, seq, timestamp, kind, tool, agent)
+    lines = text.split("\n")
+    # Line of each match start, so patterns spanning lines still show up
+    hits = {text.count("\n", 0, m.start()) for m in regex.finditer(text)}
+    shown = sorted(
+        {
+            j
+            for i in hits
+            for j in range(max(0, i - context), min(len(lines), i + context + 1))
+        }
+    )
+    match.lines = [(i + 1, lines[i], i in hits) for i in shown]
+    return match
@@ -0,0 +1,299 @@
+"""Labels on sessions, for slicing work by type.
+
+WHAT: A session can carry any number of labels (``bugfix``, ``spike``,
+``customer-x``), given at launch with ``claude-mpm run --label bugfix`` or
+later with ``claude-mpm labels add <session-id> bugfix``. They are stored
+with the project in ``.claude-mpm/session-labels.json``::
+
+    {
+      "version": 1,
+      "sessions": {
+        "2f6a...": {"labels": ["bugfix", "customer-x"],
+                    "updated_at": "2026-10-17T09:30:00+00:00"}
+      }
+    }
+
+``claude-mpm labels sessions --label bugfix`` lists a label's sessions,
+``labels costs`` totals estimated cost per label, ``status`` and
+``session-report`` show them, and the dashboard filters its session list
+by label.
+
+An optional taxonomy in ``.claude-mpm/labels.yaml`` documents the labels a
+team uses; with ``strict: true`` no other labels are accepted::
+
+    strict: true
+    labels:
+      bugfix: Fixing a reported defect
+      spike: Time-boxed exploration
+
+WHY: Every session looked alike in status, reports and the dashboard, so
+there was no way to tell how much time or money went into bug fixing as
+opposed to feature work or one customer's requests.
+
+DESIGN DECISIONS:
+- Labels given at launch reach the session through CLAUDE_MPM_SESSION_LABELS,
+  since Claude Code picks the session id only once it starts; the
+  SessionStart hook records them under that id
+- Labels are normalized to lowercase and limited to letters, digits and
+  ``.:/_-``, so ``Bugfix`` and ``bugfix`` are one label
+- The file sits with the project's other ``.claude-mpm`` state and can be
+  committed to share the labels with the team
+"""
+
+from __future__ import annotations
+
+import os
+import re
+from dataclasses import dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from ..core.logger import get_logger
+from ..core.state_files import read_json, update_json
+
+logger = get_logger(__name__)
+
+LABELS_FILE = "session-labels.json"
+TAXONOMY_FILE = "labels.yaml"
+ENV_VAR = "CLAUDE_MPM_SESSION_LABELS"
+
+_LABEL_RE = re.compile(r"^[a-z0-9][a-z0-9.:/_-]{0,63}$")
+
+
+def normalize_label(label: str) -> str:
+    """*label* in its stored form.
+
+    Raises:
+        ValueError: If it is not a valid label.
+    """
+    normalized = label.strip().lower()
+    if not _LABEL_RE.match(normalized):
+        raise ValueError(
+            f"Invalid label '{label}': use up to 64 letters, digits and .:/_- "
+            "starting with a letter or digit"
+        )
+    return normalized
+
+
+def parse_labels(values: list[str] | None) -> list[str]:
+    """Labels from repeated and comma-separated arguments, normalized."""
+    labels: list[str] = []
+    for value in values or []:
+        for part in value.split(","):
+            if part.strip():
+                label = normalize_label(part)
+                if label not in labels:
+                    labels.append(label)
+    return labels
+
+
+class SessionLabels:
+    """The labels of one project's sessions, and its label taxonomy."""
+
+    def __init__(self, project_dir: Path | None = None):
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.path = self.project_dir / ".claude-mpm" / LABELS_FILE
+        self.taxonomy_path = self.project_dir / ".claude-mpm" / TAXONOMY_FILE
+
+    def _taxonomy(self) -> dict[str, Any]:
+        if not self.taxonomy_path.is_file():
+            return {}
+        try:
+            data = yaml.safe_load(self.taxonomy_path.read_text(encoding="utf-8"))
+        except (OSError, yaml.YAMLError) as e:
+            raise ValueError(f"Could not read {self.taxonomy_path}: {e}") from e
+        return data if isinstance(data, dict) else {}
+
+    def taxonomy(self) -> dict[str, str]:
+        """Declared labels and their descriptions."""
+        labels = self._taxonomy().get("labels") or {}
+        if isinstance(labels, list):
+            labels = dict.fromkeys(labels, "")
+        if not isinstance(labels, dict):
+            return {}
+        return {
+            normalize_label(str(name)): str(description or "")
+            for name, description in labels.items()
+        }
+
+    @property
+    def strict(self) -> bool:
+        return bool(self._taxonomy().get("strict", False))
+
+    def validate(self, labels: list[str]) -> list[str]:
+        """*labels* normalized and checked against a strict taxonomy.
+
+        Raises:
+            ValueError: If a label is invalid or not in a strict taxonomy.
+        """
+        labels = parse_labels(labels)
+        if self.strict:
+            known = self.taxonomy()
+            unknown = [label for label in labels if label not in known]
+            if unknown:
+                raise ValueError(
+                    f"Unknown label(s) {', '.join(unknown)}; {self.taxonomy_path} "
+                    f"allows: {', '.join(sorted(known)) or 'none'}"
+                )
+        return labels
+
+    def all(self) -> dict[str, list[str]]:
+        """Labels by session id, for every labeled session."""
+        data = read_json(self.path, {})
+        sessions = data.get("sessions") if isinstance(data, dict) else None
+        if not isinstance(sessions, dict):
+            return {}
+        return {
+            session_id: list(entry.get("labels") or [])
+            for session_id, entry in sessions.items()
+            if isinstance(entry, dict) and entry.get("labels")
+        }
+
+    def labels(self, session_id: str) -> list[str]:
+        return self.all().get(session_id, [])
+
+    def _set(self, session_id: str, change) -> list[str]:
+        if not session_id:
+            raise ValueError("A session id is required")
+        result: list[str] = []
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = 1
+            sessions = data.setdefault("sessions", {})
+            entry = sessions.get(session_id) or {}
+            labels = change(list(entry.get("labels") or []))
+            if labels:
+                sessions[session_id] = {
+                    "labels": labels,
+                    "updated_at": datetime.now(UTC).isoformat(),
+                }
+            else:
+                sessions.pop(session_id, None)
+            result.extend(labels)
+
+        update_json(self.path, record)
+        return result
+
+    def add(self, session_id: str, labels: list[str]) -> list[str]:
+        """Add *labels* to a session; returns its labels."""
+        labels = self.validate(labels)
+        if not labels:
+            raise ValueError("No labels given")
+
+        def extend(current: list[str]) -> list[str]:
+            return current + [label for label in labels if label not in current]
+
+        return self._set(session_id, extend)
+
+    def remove(self, session_id: str, labels: list[str] | None = None) -> list[str]:
+        """Remove *labels* (all labels if None) from a session; returns the rest."""
+        drop = set(parse_labels(labels)) if labels else None
+
+        def keep(current: list[str]) -> list[str]:
+            if drop is None:
+                return []
+            return [label for label in current if label not in drop]
+
+        return self._set(session_id, keep)
+
+    def sessions(self, labels: list[str] | None = None) -> dict[str, list[str]]:
+        """Labeled sessions that carry every one of *labels*."""
+        wanted = set(parse_labels(labels))
+        return {
+            session_id: session_labels
+            for session_id, session_labels in self.all().items()
+            if wanted <= set(session_labels)
+        }
+
+    def counts(self) -> dict[str, int]:
+        """Sessions per label, including declared labels nobody used yet."""
+        counts = dict.fromkeys(self.taxonomy(), 0)
+        for session_labels in self.all().values():
+            for label in session_labels:
+                counts[label] = counts.get(label, 0) + 1
+        return dict(sorted(counts.items()))
+
+
+@dataclass
+class LabelCost:
+    """Estimated cost of the sessions carrying one label."""
+
+    label: str
+    sessions: int = 0
+    turns: int = 0
+    cost_usd: float = 0.0
+    # Labeled sessions whose transcript is gone
+    missing: int = 0
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "label": self.label,
+            "sessions": self.sessions,
+            "turns": self.turns,
+            "cost_usd": round(self.cost_usd, 6),
+            "missing": self.missing,
+        }
+
+
+def label_costs(
+    project_dir: Path | None = None, labels: list[str] | None = None
+) -> list[LabelCost]:
+    """Estimated cost per label from the sessions' transcripts, highest first.
+
+    With *labels*, only sessions carrying all of them are counted, and only
+    those labels are reported.
+    """
+    from .session_analysis.transcript_parser import locate_transcript, parse_session
+
+    project_dir = Path(project_dir or Path.cwd()).resolve()
+    wanted = parse_labels(labels)
+    costs: dict[str, LabelCost] = {}
+    sessions = SessionLabels(project_dir).sessions(wanted)
+    for session_id, session_labels in sessions.items():
+        report = None
+        if locate_transcript(session_id, str(project_dir)).exists():
+            try:
+                report = parse_session(session_id, str(project_dir))
+            except Exception as e:
+                logger.warning(f"Could not read the transcript of {session_id}: {e}")
+        for label in wanted or session_labels:
+            row = costs.setdefault(label, LabelCost(label))
+            if report is None:
+                row.missing += 1
+                continue
+            row.sessions += 1
+            row.turns += report.total_turns
+            row.cost_usd += report.grand_total_cost_usd
+    return sorted(costs.values(), key=lambda c: c.cost_usd, reverse=True)
+
+
+def launch_labels() -> list[str]:
+    """Labels ``claude-mpm run --label`` handed to this session."""
+    value = os.environ.get(ENV_VAR, "")
+    try:
+        return parse_labels([value])
+    except ValueError as e:
+        logger.warning(f"Ignoring {ENV_VAR}: {e}")
+        return []
+
+
+def record_session_start(event: dict[str, Any]) -> list[str]:
+    """Label a starting session with its launch labels; fail-open.
+
+    Returns the session's labels, launch labels included.
+    """
+    session_id = event.get("session_id") or ""
+    if not session_id:
+        return []
+    store = SessionLabels(Path(event.get("cwd") or Path.cwd()))
+    try:
+        labels = launch_labels()
+        if labels:
+            return store.add(session_id, labels)
+        return store.labels(session_id)
+    except Exception as e:
+        logger.debug(f"Could not record session labels: {e}")
+        return []
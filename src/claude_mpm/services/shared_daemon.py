@@ -0,0 +1,485 @@
+"""
+Shared User-Level Daemon
+========================
+
+WHY: Every project used to start its own monitor server, serve daemon and
+watchers, each with its own PID file. Users ended up with a dozen
+``claude-mpm`` processes, several of them orphaned, and no single place to
+see or stop them. The shared daemon is one user-level supervisor for all of
+them, shared by every attached project:
+
+- ``event_server``: each attached project's monitor/event server
+- ``scheduler``: periodic per-project jobs (advisory checks, storage prune)
+- ``watchers``: agent hot-reload for every attached project
+- ``commander``: the session API (``claude-mpm serve``) that replaced the
+  tmux-based Commander, shared by all projects
+
+DESIGN DECISIONS:
+- State lives in ``~/.claude-mpm/daemon/state.json`` (never CWD-relative),
+  written atomically under its lock since several CLI processes update it
+- Components are registered by name; each wraps a lifecycle object exposing
+  ``start()`` / ``stop()`` / ``status()``. User-level ones (``COMPONENTS``)
+  run once; per-project ones (``PROJECT_COMPONENTS``) run once per attached
+  project, so new hosted services only need a factory entry
+- Projects *attach* to the daemon rather than starting their own servers;
+  ``run --monitor`` and ``monitor start`` attach instead of starting a server
+  while the daemon is running (see ``serve_project``)
+- Each attached project is a namespace (``<dirname>-<hash8>``) with its own
+  state: its event server runs from the project root on a port of its own
+  (the monitor keeps observer links, the reported working directory and its
+  discovery entry relative to the directory it starts in), and the
+  scheduler and watchers keep their per-project records under
+  ``~/.claude-mpm/daemon/projects/<namespace>/``
+- Components keep running in the background after ``daemon start`` returns;
+  ``daemon stop`` tears them down in reverse start order
+"""
+
+from __future__ import annotations
+
+import hashlib
+import os
+import subprocess  # nosec B404
+import sys
+import time
+from collections.abc import Callable
+from pathlib import Path
+from typing import Any
+
+from ..core.logging_config import get_logger
+from ..core.state_files import read_json, update_json
+from .server_discovery import ServerDiscoveryRegistry
+
+logger = get_logger(__name__)
+
+DEFAULT_STATE_DIR = Path.home() / ".claude-mpm" / "daemon"
+PROJECTS_DIR_NAME = "projects"
+
+# Set in processes the daemon starts, so they never route back through it
+SHARED_DAEMON_ENV = "CLAUDE_MPM_SHARED_DAEMON"
+
+# Default ports for hosted components
+EVENT_SERVER_PORT = 8765  # Preferred; each project negotiates its own
+COMMANDER_PORT = 7777
+
+# Seconds to wait for ``monitor start`` / ``monitor stop`` in a project
+MONITOR_COMMAND_TIMEOUT = 60
+
+
+def _read_pid(path: Path) -> int | None:
+    try:
+        return int(path.read_text().strip())
+    except (OSError, ValueError):
+        return None
+
+
+def _pid_alive(pid: int | None) -> bool:
+    if not pid:
+        return False
+    try:
+        os.kill(pid, 0)
+    except ProcessLookupError:
+        return False
+    except PermissionError:
+        return True
+    return True
+
+
+class ProjectEventServer:
+    """One attached project's event server, run from the project root."""
+
+    def __init__(self, host: str, project_root: Path, port: int | None):
+        self.host = host
+        self.project_root = Path(project_root)
+        self.port = port
+
+    def _pid_file(self) -> Path:
+        from .monitor.daemon_manager import DaemonManager
+
+        return DaemonManager.get_pid_file_for_port(self.port, start=self.project_root)
+
+    def _monitor(self, *args: str) -> bool:
+        env = {**os.environ, SHARED_DAEMON_ENV: "1"}
+        result = subprocess.run(  # nosec B603 - runs this package's CLI
+            [sys.executable, "-m", "claude_mpm.cli", "monitor", *args],
+            cwd=self.project_root,
+            env=env,
+            stdin=subprocess.DEVNULL,
+            stdout=subprocess.DEVNULL,
+            stderr=subprocess.DEVNULL,
+            timeout=MONITOR_COMMAND_TIMEOUT,
+            check=False,
+        )
+        return result.returncode == 0
+
+    def status(self) -> dict[str, Any]:
+        pid = _read_pid(self._pid_file()) if self.port else None
+        running = _pid_alive(pid)
+        return {
+            "running": running,
+            "port": self.port,
+            "pid": pid if running else None,
+            "project": str(self.project_root),
+        }
+
+    def start(self) -> bool:
+        if not self.port:
+            return False
+        started = self._monitor(
+            "start", "--background", "--port", str(self.port), "--host", self.host
+        )
+        return started and self.status()["running"]
+
+    def stop(self) -> bool:
+        return bool(self.port) and self._monitor("stop", "--port", str(self.port))
+
+
+def _event_server(host: str, project_root: Path, entry: dict[str, Any]) -> Any:
+    return ProjectEventServer(host, project_root, entry.get("port"))
+
+
+def _scheduler(host: str) -> Any:
+    from .daemon_workers import WorkerProcess
+
+    return WorkerProcess("scheduler")
+
+
+def _watchers(host: str) -> Any:
+    from .daemon_workers import WorkerProcess
+
+    return WorkerProcess("watchers")
+
+
+def _commander(host: str) -> Any:
+    from .ui_service.serve_daemon import ServeDaemon
+
+    return ServeDaemon(host="127.0.0.1", port=COMMANDER_PORT, daemon_mode=True)
+
+
+# name -> factory(host, project_root, project_entry), run per attached project.
+# These start before the user-level components and stop after them.
+PROJECT_COMPONENTS: dict[str, Callable[[str, Path, dict[str, Any]], Any]] = {
+    "event_server": _event_server,
+}
+
+# name -> factory(host) returning an object with start/stop/status.
+# Order matters: components start top-to-bottom and stop bottom-to-top.
+COMPONENTS: dict[str, Callable[[str], Any]] = {
+    "scheduler": _scheduler,
+    "watchers": _watchers,
+    "commander": _commander,
+}
+
+
+def project_namespace(project_root: Path) -> str:
+    """Return the stable namespace of an attached project.
+
+    Human-readable prefix plus a short path hash so two checkouts with the
+    same directory name never share a namespace (or its state).
+    """
+    resolved = Path(project_root).resolve()
+    digest = hashlib.sha256(str(resolved).encode()).hexdigest()[:8]
+    return f"{resolved.name or 'root'}-{digest}"
+
+
+class SharedDaemon:
+    """Supervises the user-level components and the projects attached to them."""
+
+    def __init__(
+        self,
+        state_dir: Path | None = None,
+        host: str = "localhost",
+        components: dict[str, Callable[[str], Any]] | None = None,
+        discovery: ServerDiscoveryRegistry | None = None,
+        project_components: (
+            dict[str, Callable[[str, Path, dict[str, Any]], Any]] | None
+        ) = None,
+    ):
+        self.state_dir = state_dir or DEFAULT_STATE_DIR
+        self.state_file = self.state_dir / "state.json"
+        self.host = host
+        self.components = components if components is not None else COMPONENTS
+        self.project_components = (
+            project_components
+            if project_components is not None
+            else PROJECT_COMPONENTS
+        )
+        self.discovery = discovery or ServerDiscoveryRegistry()
+
+    def component_names(self) -> list[str]:
+        """Every component, in start order."""
+        return [*self.project_components, *self.components]
+
+    def namespace_dir(self, namespace: str) -> Path:
+        """Where hosted components keep one namespace's state."""
+        return self.state_dir / PROJECTS_DIR_NAME / namespace
+
+    # ------------------------------------------------------------------
+    # State persistence
+    # ------------------------------------------------------------------
+
+    def _load_state(self) -> dict[str, Any]:
+        state = read_json(self.state_file, None)
+        if not isinstance(state, dict):
+            return {"projects": {}}
+        state.setdefault("projects", {})
+        return state
+
+    def _update_state(
+        self, mutate: Callable[[dict[str, Any]], Any]
+    ) -> dict[str, Any]:
+        """Locked read-modify-write of the state; returns the new state."""
+
+        def apply(data: Any) -> dict[str, Any]:
+            state = data if isinstance(data, dict) else {}
+            state.setdefault("projects", {})
+            mutate(state)
+            return state
+
+        return update_json(self.state_file, apply)
+
+    # ------------------------------------------------------------------
+    # Lifecycle
+    # ------------------------------------------------------------------
+
+    def is_running(self) -> bool:
+        """Whether ``daemon start`` (or ``daemon run``) is in effect."""
+        return bool(self._load_state().get("started_at"))
+
+    def start(self, only: list[str] | None = None) -> dict[str, bool]:
+        """Start hosted components.
+
+        Args:
+            only: Restrict to these component names (default: all)
+
+        Returns:
+            Mapping of component name to whether it is running afterwards
+            (for per-project components: for every attached project)
+        """
+        state = self._update_state(lambda s: s.update(started_at=time.time()))
+        results: dict[str, bool] = {}
+        for name in self.project_components:
+            if only and name not in only:
+                continue
+            started = [self._start_project(root, name) for root in state["projects"]]
+            results[name] = all(started)
+
+        for name, factory in self.components.items():
+            if only and name not in only:
+                continue
+            try:
+                component = factory(self.host)
+                if component.status().get("running"):
+                    results[name] = True
+                    continue
+                results[name] = bool(component.start())
+            except Exception as e:
+                logger.error(f"Failed to start daemon component {name}: {e}")
+                results[name] = False
+        return results
+
+    def stop(self, only: list[str] | None = None) -> dict[str, bool]:
+        """Stop hosted components in reverse start order.
+
+        Args:
+            only: Restrict to these component names (default: all, which
+                also unregisters every attached project's discovery entry)
+        """
+        results: dict[str, bool] = {}
+        for name in reversed(list(self.components)):
+            if only and name not in only:
+                continue
+            try:
+                component = self.components[name](self.host)
+                if not component.status().get("running"):
+                    results[name] = True
+                    continue
+                results[name] = bool(component.stop())
+            except Exception as e:
+                logger.error(f"Failed to stop daemon component {name}: {e}")
+                results[name] = False
+
+        projects = self.list_projects()
+        for name in reversed(list(self.project_components)):
+            if only and name not in only:
+                continue
+            stopped = [self._stop_project(root, name) for root in projects]
+            results[name] = all(stopped)
+
+        if only:
+            return results
+        state = self._update_state(lambda s: s.pop("started_at", None))
+        for root in state["projects"]:
+            self.discovery.unregister(Path(root))
+        return results
+
+    def status(self) -> dict[str, Any]:
+        """Describe every component and attached project.
+
+        Per-project components list their ``instances`` by namespace and are
+        running when every attached project's instance is.
+        """
+        state = self._load_state()
+        components: dict[str, Any] = {}
+        for name, factory in self.project_components.items():
+            instances: dict[str, Any] = {}
+            for root, entry in state["projects"].items():
+                try:
+                    info = factory(self.host, Path(root), entry).status()
+                except Exception as e:
+                    info = {"running": False, "error": str(e)}
+                instances[entry["namespace"]] = info
+            components[name] = {
+                "running": bool(instances)
+                and all(i.get("running") for i in instances.values()),
+                "instances": instances,
+            }
+        for name, factory in self.components.items():
+            try:
+                components[name] = factory(self.host).status()
+            except Exception as e:
+                components[name] = {"running": False, "error": str(e)}
+
+        return {
+            "running": any(c.get("running") for c in components.values()),
+            "started_at": state.get("started_at"),
+            "components": components,
+            "projects": state["projects"],
+        }
+
+    def record_service(self, **fields: Any) -> None:
+        """Merge *fields* into the record of the foreground service
+        (``daemon run``) that ``config reload`` signals."""
+        self._update_state(lambda s: s.setdefault("service", {}).update(fields))
+
+    def clear_service(self) -> None:
+        if self._load_state().get("service") is not None:
+            self._update_state(lambda s: s.pop("service", None))
+
+    def service(self) -> dict[str, Any] | None:
+        """The record of the foreground service, if one has run."""
+        return self._load_state().get("service")
+
+    # ------------------------------------------------------------------
+    # Attached projects
+    # ------------------------------------------------------------------
+
+    def attach(self, project_root: Path) -> dict[str, Any]:
+        """Attach a project so it is served by the shared daemon.
+
+        While the daemon is running, the project's components start at once
+        and the returned entry maps each to whether it started (``started``).
+        """
+        resolved = Path(project_root).resolve()
+        key = str(resolved)
+        namespace = project_namespace(resolved)
+
+        def record(state: dict[str, Any]) -> None:
+            entry = state["projects"].setdefault(key, {})
+            entry.setdefault("namespace", namespace)
+            entry.setdefault("attached_at", time.time())
+
+        state = self._update_state(record)
+        self.namespace_dir(namespace).mkdir(parents=True, exist_ok=True)
+        if not state.get("started_at"):
+            return dict(state["projects"][key])
+
+        started = {
+            name: self._start_project(key, name) for name in self.project_components
+        }
+        return dict(self.list_projects().get(key, {}), started=started)
+
+    def detach(self, project_root: Path) -> bool:
+        """Detach a project and stop its components; the others keep running."""
+        key = str(Path(project_root).resolve())
+        if key not in self.list_projects():
+            return False
+        for name in reversed(list(self.project_components)):
+            self._stop_project(key, name)
+
+        removed = []
+
+        def drop(state: dict[str, Any]) -> None:
+            if state["projects"].pop(key, None) is not None:
+                removed.append(key)
+
+        self._update_state(drop)
+        if not removed:
+            return False
+        self.discovery.unregister(Path(key))
+        return True
+
+    def list_projects(self) -> dict[str, dict[str, Any]]:
+        return self._load_state()["projects"]
+
+    def serve_project(self, project_root: Path) -> dict[str, Any] | None:
+        """Serve a project that is starting up from the shared daemon.
+
+        Returns:
+            The project's entry (with its event server ``port``) once its
+            components run, or None when the daemon is not running, or this
+            process was started by it, so the caller starts its own server
+        """
+        if os.environ.get(SHARED_DAEMON_ENV) or not self.is_running():
+            return None
+        entry = self.attach(project_root)
+        started = entry.get("started") or {}
+        if not started or not all(started.values()):
+            logger.warning(f"Shared daemon could not serve {project_root}: {started}")
+            return None
+        return entry
+
+    def _claim_port(self, root: str) -> int | None:
+        """The event server port of project *root*, negotiated on first use.
+
+        A project keeps its port across restarts while nothing else took it,
+        and never gets a port recorded for another attached project.
+        """
+        projects = self.list_projects()
+        previous = projects.get(root, {}).get("port")
+        reserved = {
+            entry["port"]
+            for other, entry in projects.items()
+            if other != root and entry.get("port")
+        }
+        port = self.discovery.negotiate_port(
+            Path(root),
+            preferred_port=previous or EVENT_SERVER_PORT,
+            host=self.host,
+            exclude=reserved,
+        )
+        if port is not None and port != previous:
+
+            def record(state: dict[str, Any]) -> None:
+                if root in state["projects"]:
+                    state["projects"][root]["port"] = port
+
+            self._update_state(record)
+        return port
+
+    def _start_project(self, root: str, name: str) -> bool:
+        try:
+            if self._claim_port(root) is None:
+                return False
+            entry = self.list_projects().get(root)
+            if entry is None:
+                return False
+            component = self.project_components[name](self.host, Path(root), entry)
+            if component.status().get("running"):
+                return True
+            return bool(component.start())
+        except Exception as e:
+            logger.error(f"Failed to start {name} for {root}: {e}")
+            return False
+
+    def _stop_project(self, root: str, name: str) -> bool:
+        entry = self.list_projects().get(root)
+        if entry is None:
+            return True
+        try:
+            component = self.project_components[name](self.host, Path(root), entry)
+            if not component.status().get("running"):
+                return True
+            return bool(component.stop())
+        except Exception as e:
+            logger.error(f"Failed to stop {name} for {root}: {e}")
+            return False
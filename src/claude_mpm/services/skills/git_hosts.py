@@ -0,0 +1,313 @@
+"""GitLab and Bitbucket hosting for HTTPS skill sources.
+
+WHAT: ``get_host`` returns the adapter for a GitLab or Bitbucket source, which
+knows the service's URLs and token scheme: resolving a branch to a commit,
+the archive of a commit, single raw files, the files and commits between two
+commits, and checking the repository can be read.
+
+WHY: Skill sources were assumed to live on GitHub. Teams keep their skills on
+GitLab (often self-hosted) and Bitbucket too, and neither serves the GitHub
+Tree API or raw.githubusercontent.com.
+
+CONFIGURATION:
+- GitLab: ``source.token``, else ``GITLAB_TOKEN``, sent as ``PRIVATE-TOKEN``
+  (personal, project or group access tokens). Inside GitLab CI with no token,
+  ``CI_JOB_TOKEN`` is sent as ``JOB-TOKEN``. The API is served from the
+  source's own host, so self-hosted instances need no extra setting.
+- Bitbucket Cloud: ``source.token``, else ``BITBUCKET_TOKEN``, sent as a
+  Bearer token (repository, project or workspace access tokens). A token of
+  the form ``user:secret`` (an app password or API token), or
+  ``BITBUCKET_USERNAME`` with ``BITBUCKET_APP_PASSWORD``, is sent as Basic
+  auth instead.
+
+DESIGN DECISIONS:
+- A source is synced from one tar.gz archive of its commit rather than file
+  by file: both services serve archives for any commit, and it is a single
+  request however large the repository is
+- GitHub keeps its Tree API and raw downloads (see git_skill_source_manager),
+  so ``get_host`` returns None for it
+"""
+
+from __future__ import annotations
+
+import os
+from pathlib import Path, PurePosixPath
+from urllib.parse import quote, urlparse
+
+from claude_mpm.config.skill_sources import SkillSource
+
+TIMEOUT = 30
+ARCHIVE_TIMEOUT = 120
+
+
+def _source_token(source: SkillSource) -> str | None:
+    """The source's own token, resolving a "$VAR" reference."""
+    if not source.token:
+        return None
+    if source.token.startswith("$"):
+        return os.environ.get(source.token[1:])
+    return source.token
+
+
+def _repo_path(url: str) -> str:
+    """Repository path of an HTTPS URL, without ".git" or a "/-/..." page."""
+    path = urlparse(url).path.split("/-/")[0].strip("/")
+    return path.removesuffix(".git")
+
+
+class GitHost:
+    """URLs and authentication for one source on a hosting service."""
+
+    name = ""
+    token_env = ""
+
+    def __init__(self, source: SkillSource):
+        self.source = source
+
+    def auth_headers(self) -> dict[str, str]:
+        raise NotImplementedError
+
+    def branch_url(self, branch: str) -> str:
+        raise NotImplementedError
+
+    def commit_from_branch(self, data: dict) -> str:
+        raise NotImplementedError
+
+    def repository_url(self) -> str:
+        raise NotImplementedError
+
+    def archive_url(self, ref: str) -> str:
+        raise NotImplementedError
+
+    def raw_url(self, ref: str, path: str) -> str:
+        raise NotImplementedError
+
+    def _get(self, url: str, **kwargs):
+        from claude_mpm.services import http_client
+
+        kwargs.setdefault("timeout", TIMEOUT)
+        return http_client.get(url, headers=self.auth_headers(), **kwargs)
+
+    def resolve_branch(self, branch: str) -> str:
+        """Commit SHA at the head of *branch*.
+
+        Raises:
+            requests.RequestException: If the branch cannot be read
+        """
+        response = self._get(self.branch_url(branch))
+        response.raise_for_status()
+        return self.commit_from_branch(response.json())
+
+    def check_access(self) -> str | None:
+        """Check the repository can be read; return an error or None."""
+        import requests
+
+        try:
+            response = self._get(self.repository_url())
+        except requests.RequestException as e:
+            return str(e)
+        if response.status_code == 200:
+            return None
+        if response.status_code in (401, 403, 404):
+            error = f"Repository not found or not accessible: {self.source.url}"
+            if not self.auth_headers():
+                error += f". Set {self.token_env} (or --token) for private repos"
+            return error
+        return f"HTTP {response.status_code}: {response.reason}"
+
+    def download_archive(self, ref: str, dest: Path) -> None:
+        """Stream the tar.gz archive of *ref* to *dest*.
+
+        Raises:
+            requests.RequestException: If the archive cannot be downloaded
+        """
+        with self._get(
+            self.archive_url(ref), stream=True, timeout=ARCHIVE_TIMEOUT
+        ) as response:
+            response.raise_for_status()
+            with open(dest, "wb") as f:
+                for chunk in response.iter_content(chunk_size=1 << 16):
+                    f.write(chunk)
+
+    def fetch_file(self, ref: str, path: str) -> bytes:
+        """Contents of *path* at *ref*.
+
+        Raises:
+            requests.RequestException: If the file cannot be downloaded
+        """
+        response = self._get(self.raw_url(ref, path))
+        response.raise_for_status()
+        return response.content
+
+    def changed_files(self, base: str, head: str) -> list[str]:
+        """Paths added, changed or removed between *base* and *head*.
+
+        Raises:
+            requests.RequestException: If the comparison cannot be read
+        """
+        raise NotImplementedError
+
+    def commits(
+        self, base: str, head: str, path: str, limit: int = 20
+    ) -> list[tuple[str, str]]:
+        """(sha, subject) of the commits after *base* up to *head* that touch
+        *path*, newest first.
+
+        Raises:
+            requests.RequestException: If the history cannot be read
+        """
+        raise NotImplementedError
+
+
+class GitLabHost(GitHost):
+    """gitlab.com or a self-hosted GitLab, through the v4 REST API."""
+
+    name = "gitlab"
+    token_env = "GITLAB_TOKEN"
+
+    @property
+    def api_url(self) -> str:
+        parsed = urlparse(self.source.url)
+        project = quote(_repo_path(self.source.url), safe="")
+        return f"{parsed.scheme}://{parsed.netloc}/api/v4/projects/{project}"
+
+    def auth_headers(self) -> dict[str, str]:
+        token = _source_token(self.source) or os.environ.get(self.token_env)
+        if token:
+            return {"PRIVATE-TOKEN": token}
+        if job_token := os.environ.get("CI_JOB_TOKEN"):
+            return {"JOB-TOKEN": job_token}
+        return {}
+
+    def branch_url(self, branch: str) -> str:
+        return f"{self.api_url}/repository/branches/{quote(branch, safe='')}"
+
+    def commit_from_branch(self, data: dict) -> str:
+        return data["commit"]["id"]
+
+    def repository_url(self) -> str:
+        return self.api_url
+
+    def archive_url(self, ref: str) -> str:
+        return f"{self.api_url}/repository/archive.tar.gz?sha={quote(ref, safe='')}"
+
+    def raw_url(self, ref: str, path: str) -> str:
+        return (
+            f"{self.api_url}/repository/files/{quote(path, safe='')}/raw"
+            f"?ref={quote(ref, safe='')}"
+        )
+
+    def changed_files(self, base: str, head: str) -> list[str]:
+        response = self._get(
+            f"{self.api_url}/repository/compare", params={"from": base, "to": head}
+        )
+        response.raise_for_status()
+        files = set()
+        for diff in response.json().get("diffs", []):
+            files.update(p for p in (diff.get("old_path"), diff.get("new_path")) if p)
+        return sorted(files)
+
+    def commits(
+        self, base: str, head: str, path: str, limit: int = 20
+    ) -> list[tuple[str, str]]:
+        response = self._get(
+            f"{self.api_url}/repository/commits",
+            params={"ref_name": f"{base}..{head}", "path": path, "per_page": limit},
+        )
+        response.raise_for_status()
+        return [(c["id"], c["title"]) for c in response.json()]
+
+
+class BitbucketHost(GitHost):
+    """Bitbucket Cloud, through the 2.0 REST API."""
+
+    name = "bitbucket"
+    token_env = "BITBUCKET_TOKEN"
+
+    @property
+    def workspace_repo(self) -> str:
+        return "/".join(_repo_path(self.source.url).split("/")[:2])
+
+    @property
+    def api_url(self) -> str:
+        return f"https://api.bitbucket.org/2.0/repositories/{self.workspace_repo}"
+
+    def auth_headers(self) -> dict[str, str]:
+        import base64
+
+        token = _source_token(self.source) or os.environ.get(self.token_env)
+        if not token and os.environ.get("BITBUCKET_APP_PASSWORD"):
+            token = (
+                f"{os.environ.get('BITBUCKET_USERNAME', '')}:"
+                f"{os.environ['BITBUCKET_APP_PASSWORD']}"
+            )
+        if not token:
+            return {}
+        if ":" in token:
+            basic = base64.b64encode(token.encode()).decode()
+            return {"Authorization": f"Basic {basic}"}
+        return {"Authorization": f"Bearer {token}"}
+
+    def branch_url(self, branch: str) -> str:
+        return f"{self.api_url}/refs/branches/{quote(branch, safe='')}"
+
+    def commit_from_branch(self, data: dict) -> str:
+        return data["target"]["hash"]
+
+    def repository_url(self) -> str:
+        return self.api_url
+
+    def archive_url(self, ref: str) -> str:
+        return f"https://bitbucket.org/{self.workspace_repo}/get/{quote(ref)}.tar.gz"
+
+    def raw_url(self, ref: str, path: str) -> str:
+        return f"{self.api_url}/src/{quote(ref, safe='')}/{quote(path)}"
+
+    def changed_files(self, base: str, head: str) -> list[str]:
+        # "head..base" is what head has that base does not
+        url = f"{self.api_url}/diffstat/{quote(head, safe='')}..{quote(base, safe='')}"
+        files = set()
+        while url:
+            response = self._get(url)
+            response.raise_for_status()
+            data = response.json()
+            for entry in data.get("values", []):
+                files.update(
+                    entry[side]["path"] for side in ("old", "new") if entry.get(side)
+                )
+            url = data.get("next")
+        return sorted(files)
+
+    def commits(
+        self, base: str, head: str, path: str, limit: int = 20
+    ) -> list[tuple[str, str]]:
+        response = self._get(
+            f"{self.api_url}/commits/{quote(head, safe='')}",
+            params={"exclude": base, "path": path, "pagelen": limit},
+        )
+        response.raise_for_status()
+        return [
+            (c["hash"], next(iter((c.get("message") or "").splitlines()), ""))
+            for c in response.json().get("values", [])
+        ]
+
+
+HOSTS: dict[str, type[GitHost]] = {"gitlab": GitLabHost, "bitbucket": BitbucketHost}
+
+
+def get_host(source: SkillSource) -> GitHost | None:
+    """The adapter for a GitLab or Bitbucket source; None for GitHub and SSH."""
+    host = HOSTS.get(source.hosting or "")
+    return host(source) if host else None
+
+
+def archive_member_path(name: str) -> str | None:
+    """Repository path of an archive member, dropping the top-level folder.
+
+    Both services wrap the tree in one folder named after the repository and
+    commit. Returns None for that folder itself and for unsafe paths.
+    """
+    parts = PurePosixPath(name).parts[1:]
+    if not parts or ".." in parts or PurePosixPath(name).is_absolute():
+        return None
+    return "/".join(parts)
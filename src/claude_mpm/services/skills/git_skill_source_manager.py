@@ -38,7 +38,12 @@ References
 SPEC-SKILLS-03~1 : docs/specs/skills.md#SPEC-SKILLS-03~1
 """
 
+import json
 import os
+import shlex
+import shutil
+import subprocess  # nosec B404 - subprocess needed for git over SSH
+import tempfile
 from concurrent.futures import ThreadPoolExecutor, as_completed
 from datetime import UTC, datetime
 from pathlib import Path
@@ -47,16 +52,85 @@ from typing import Any
 
 from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
 from claude_mpm.core.logging_config import get_logger
+from claude_mpm.core.state_files import read_json, write_atomic
 from claude_mpm.services.agents.sources.git_source_sync_service import (
     GitSourceSyncService,
 )
+from claude_mpm.services import http_client
+from claude_mpm.services.deployment_delta import sync_directory
+from claude_mpm.services.deployment_integrity import git_commit, record_directory
+from claude_mpm.services.skills.git_hosts import (
+    GitHost,
+    archive_member_path,
+    get_host,
+)
+from claude_mpm.services.skills.http_registry import (
+    download_artifact,
+    extract_skill_archive,
+    fetch_index,
+)
 from claude_mpm.services.skills.selective_skill_deployer import (
     sanitize_skill_name_for_deployment,
 )
+from claude_mpm.services.skills.skill_conflicts import (
+    ConflictConfig,
+    SkillConflict,
+    pick_sources,
+    resolve_conflicts,
+)
+from claude_mpm.services.skills.skill_dependencies import resolve_dependencies
+from claude_mpm.services.skills.skill_history import SkillHistory
 from claude_mpm.services.skills.skill_discovery_service import SkillDiscoveryService
+from claude_mpm.services.skills.skill_signing import (
+    SIGNATURE_FILES,
+    SignatureConfig,
+    SignatureError,
+    Verification,
+    verify_collection,
+)
+from claude_mpm.services.skills.skills_lock import SkillsLock
+from claude_mpm.utils.bulk_operations import FAILED, BulkItem, run_bulk
 
 logger = get_logger(__name__)
 
+# Files synced into the cache: the full skill directory structure, SKILL.md,
+# scripts/ and references/
+RELEVANT_EXTENSIONS = (
+    # Documentation
+    ".md",
+    ".json",
+    ".yaml",
+    ".yml",
+    ".txt",
+    # Scripts
+    ".sh",
+    ".py",
+    ".js",
+    ".ts",
+    ".mjs",
+    ".cjs",
+    # Assets
+    ".png",
+    ".jpg",
+    ".jpeg",
+    ".gif",
+    ".svg",
+    ".webp",
+)
+
+
+def _is_relevant_file(path: str) -> bool:
+    return path.endswith(RELEVANT_EXTENSIONS) or path in (
+        ".gitignore",
+        ".env.example",
+        *SIGNATURE_FILES,
+    )
+
+
+def is_hidden_path(path: Path) -> bool:
+    """Whether any part of a relative path is hidden (.git, .DS_Store, ...)."""
+    return any(part.startswith(".") for part in path.parts)
+
 
 def _get_github_token(source: SkillSource | None = None) -> str | None:
     """Get GitHub token with source-specific override support.
@@ -98,6 +172,139 @@ def _get_github_token(source: SkillSource | None = None) -> str | None:
     return os.environ.get("GITHUB_TOKEN") or os.environ.get("GH_TOKEN")
 
 
+def _git_ssh_env(source: SkillSource) -> dict[str, str]:
+    """Environment for running git against an SSH source without prompts.
+
+    With a per-source key, ssh offers only that key (IdentitiesOnly), so a
+    deploy key is used even when the agent holds other keys for the host.
+    Without one, ssh's own configuration and agent apply.
+    """
+    env = {**os.environ, "GIT_TERMINAL_PROMPT": "0"}
+    key = source.ssh_key_path
+    if key is not None:
+        env["GIT_SSH_COMMAND"] = shlex.join(
+            ["ssh", "-i", str(key), "-o", "IdentitiesOnly=yes", "-o", "BatchMode=yes"]
+        )
+    elif "GIT_SSH_COMMAND" not in env:
+        env["GIT_SSH_COMMAND"] = "ssh -o BatchMode=yes"
+    return env
+
+
+def _run_git(
+    args: list[str], env: dict[str, str], cwd: Path | None = None, timeout: int = 120
+) -> str:
+    """Run git and return its stdout; failures raise RuntimeError with stderr."""
+    try:
+        result = subprocess.run(  # nosec B603 B607 - Safe: fixed git executable
+            ["git", *args],
+            cwd=cwd,
+            env=env,
+            capture_output=True,
+            text=True,
+            check=True,
+            timeout=timeout,
+        )
+    except subprocess.CalledProcessError as e:
+        raise RuntimeError(f"git {args[0]} failed: {e.stderr.strip()}") from e
+    except subprocess.TimeoutExpired as e:
+        raise RuntimeError(f"git {args[0]} timed out after {timeout}s") from e
+    return result.stdout
+
+
+def check_ssh_source_access(source: SkillSource) -> str | None:
+    """Check that an SSH source's branch can be read; return an error or None."""
+    key = source.ssh_key_path
+    if key is not None and not key.is_file():
+        return f"SSH key not found: {key}"
+    try:
+        heads = _run_git(
+            ["ls-remote", "--heads", source.url, source.branch],
+            _git_ssh_env(source),
+            timeout=30,
+        )
+    except RuntimeError as e:
+        return str(e)
+    if not heads.strip():
+        return f"Branch not found: {source.branch}"
+    return None
+
+
+def _github_owner_repo(url: str) -> str:
+    url_parts = url.rstrip("/").replace(".git", "").split("github.com/")
+    if len(url_parts) != 2:
+        raise ValueError(f"Invalid GitHub URL: {url}")
+    return "/".join(url_parts[1].strip("/").split("/")[:2])
+
+
+def _resolve_github_branch(owner_repo: str, branch: str, headers: dict) -> str:
+    """GET /repos/{owner}/{repo}/git/refs/heads/{branch} → commit SHA."""
+    import requests
+
+    refs_url = f"https://api.github.com/repos/{owner_repo}/git/refs/heads/{branch}"
+    logger.debug(f"Fetching commit SHA from {refs_url}")
+    refs_response = http_client.get(refs_url, headers=headers, timeout=30)
+
+    # Check for rate limiting
+    if refs_response.status_code == 403:
+        logger.warning(
+            "GitHub API rate limit exceeded (HTTP 403). "
+            "Consider setting GITHUB_TOKEN environment variable for higher limits."
+        )
+        raise requests.RequestException("Rate limit exceeded")
+
+    refs_response.raise_for_status()
+    commit_sha = refs_response.json()["object"]["sha"]
+    logger.debug(f"Resolved {branch} to commit {commit_sha[:8]}")
+    return commit_sha
+
+
+def resolve_source_commit(source: SkillSource) -> str:
+    """Resolve the commit at the head of a source's branch.
+
+    Raises:
+        RuntimeError: If the branch cannot be read
+        ValueError: If the URL is not SSH, GitHub, GitLab or Bitbucket, or
+            the source is a local directory or registry (which have no
+            commits to pin)
+    """
+    if not source.is_git:
+        kind = "local directory" if source.is_local else "registry"
+        raise ValueError(f"{source.id} is a {kind} and cannot be pinned")
+    if source.is_ssh:
+        error = check_ssh_source_access(source)
+        if error:
+            raise RuntimeError(error)
+        heads = _run_git(
+            ["ls-remote", "--heads", source.url, source.branch],
+            _git_ssh_env(source),
+            timeout=30,
+        )
+        return heads.split()[0]
+
+    import requests
+
+    host = get_host(source)
+    if host is not None:
+        try:
+            return host.resolve_branch(source.branch)
+        except (requests.RequestException, KeyError, ValueError) as e:
+            raise RuntimeError(
+                f"Could not resolve {source.url}@{source.branch}: {e}"
+            ) from e
+
+    owner_repo = _github_owner_repo(source.url)
+    headers = {"Accept": "application/vnd.github+json"}
+    token = _get_github_token(source)
+    if token:
+        headers["Authorization"] = f"token {token}"
+    try:
+        return _resolve_github_branch(owner_repo, source.branch, headers)
+    except (requests.RequestException, KeyError, ValueError) as e:
+        raise RuntimeError(
+            f"Could not resolve {owner_repo}@{source.branch}: {e}"
+        ) from e
+
+
 class GitSkillSourceManager:
     """Manages multiple Git-based skill sources with priority resolution.
 
@@ -131,6 +338,10 @@ class GitSkillSourceManager:
         config: SkillSourceConfiguration,
         cache_dir: Path | None = None,
         sync_service: GitSourceSyncService | None = None,
+        lock: SkillsLock | None = None,
+        signatures: SignatureConfig | None = None,
+        history: SkillHistory | None = None,
+        conflict_config: ConflictConfig | None = None,
     ):
         """Initialize skill source manager.
 
@@ -138,6 +349,15 @@ class GitSkillSourceManager:
             config: Skill source configuration
             cache_dir: Cache directory (defaults to ~/.claude-mpm/cache/skills/)
             sync_service: Git sync service (injected for testing)
+            lock: Project skills.lock; when given, syncs fetch each source's
+                locked commit (pinning unlocked sources to their branch head)
+                instead of the branch head
+            signatures: Keys trusted for every source and whether syncs
+                require a verified signature (defaults to skills.signatures)
+            history: Where deployed skill versions are kept and rollbacks
+                held (defaults to skill-history/ next to the cache)
+            conflict_config: Precedence and aliases for skills several
+                sources provide (defaults to skills.conflicts)
         """
         if cache_dir is None:
             cache_dir = Path.home() / ".claude-mpm" / "cache" / "skills"
@@ -153,6 +373,16 @@ class GitSkillSourceManager:
         self.etag_dir.mkdir(parents=True, exist_ok=True)
 
         self.sync_service = sync_service  # Use injected if provided
+        self.lock = lock
+        self._signatures = signatures
+        self._conflict_config = conflict_config
+        # Every skill found by the last get_all_skills(), and the conflicts
+        # resolving them turned up
+        self._discovered: list[dict[str, Any]] = []
+        self.conflicts: list[SkillConflict] = []
+        self.history = history or SkillHistory(self.cache_dir.parent / "skill-history")
+        # Commits the GitHub Tree API resolved branches to, by source ID
+        self._tree_commits: dict[str, str] = {}
         self.logger = get_logger(__name__)
         self._etag_cache_lock = Lock()  # Thread-safe ETag cache operations
 
@@ -161,16 +391,24 @@ class GitSkillSourceManager:
         )
 
     def sync_all_sources(
-        self, force: bool = False, progress_callback=None
+        self,
+        force: bool = False,
+        progress_callback=None,
+        max_workers: int = 1,
+        source_progress_callback=None,
     ) -> dict[str, Any]:
         """Sync all enabled skill sources.
 
-        Syncs sources in priority order (lower priority first). Individual
-        failures don't stop overall sync.
+        Syncs sources in priority order (lower priority first), or up to
+        ``max_workers`` at a time. Individual failures don't stop overall sync.
 
         Args:
             force: Force re-download even if cached
             progress_callback: Optional callback(increment: int) called for each file synced
+                (from worker threads when max_workers > 1)
+            max_workers: Sources to sync in parallel
+            source_progress_callback: Optional callback(completed: int) called as
+                each source finishes
 
         Returns:
             Dict with sync results for each source:
@@ -207,24 +445,37 @@ class GitSkillSourceManager:
             "timestamp": datetime.now(UTC).isoformat(),
         }
 
-        for source in sources:
+        def sync_one(source: SkillSource) -> BulkItem:
             try:
                 result = self.sync_source(
                     source.id, force=force, progress_callback=progress_callback
                 )
-                results["sources"][source.id] = result
-
-                if result.get("synced"):
-                    results["synced_count"] += 1
-                    results["total_files_updated"] += result.get("files_updated", 0)
-                    results["total_files_cached"] += result.get("files_cached", 0)
-                else:
-                    results["failed_count"] += 1
-
             except Exception as e:
                 self.logger.error(f"Exception syncing source {source.id}: {e}")
-                results["sources"][source.id] = {"synced": False, "error": str(e)}
-                results["failed_count"] += 1
+                result = {"synced": False, "error": str(e)}
+            results["sources"][source.id] = result
+            if result.get("synced"):
+                return BulkItem(source.id)
+            return BulkItem(source.id, FAILED, result.get("error", ""))
+
+        report = run_bulk(
+            sources,
+            sync_one,
+            operation_name="Sync skill sources",
+            name=lambda source: source.id,
+            max_workers=max_workers,
+            progress_callback=source_progress_callback,
+        )
+        # Report in priority order whatever order the sources finished in
+        results["sources"] = {
+            source.id: results["sources"][source.id] for source in sources
+        }
+        for source_result in results["sources"].values():
+            if source_result.get("synced"):
+                results["synced_count"] += 1
+                results["total_files_updated"] += source_result.get("files_updated", 0)
+                results["total_files_cached"] += source_result.get("files_cached", 0)
+        results["failed_count"] = len(report.failed)
 
         self.logger.info(
             f"Sync complete: {results['synced_count']} succeeded, "
@@ -292,10 +543,13 @@ class GitSkillSourceManager:
             self._migrate_legacy_etag_cache(source.id, cache_path)
 
             # Recursively sync repository structure
+            commit = self._pinned_commit(source)
             files_updated, files_cached = self._recursive_sync_repository(
-                source, cache_path, force, progress_callback
+                source, cache_path, force, progress_callback, commit=commit
             )
 
+            verification = self.verify_source(source)
+
             # Discover skills in cache
             self.logger.debug(f"Scanning cache path for skills: {cache_path}")
             discovery_service = SkillDiscoveryService(cache_path)
@@ -320,6 +574,10 @@ class GitSkillSourceManager:
                 "skills_discovered": len(discovered_skills),
                 "timestamp": datetime.now(UTC).isoformat(),
             }
+            if commit:
+                result["commit"] = commit
+            if verification is not None:
+                result["signature"] = verification.to_dict()
 
             self.logger.info(
                 f"Sync complete for {source_id}: {result['files_updated']} updated, "
@@ -357,8 +615,12 @@ class GitSkillSourceManager:
         Priority Resolution Algorithm:
             1. Load skills from all enabled sources
             2. Group by skill ID (name converted to ID)
-            3. For each group, select skill with lowest priority
-            4. Return deduplicated skill list
+            3. For each group, select the skill from the source given
+               precedence, else the one with lowest priority
+            4. Add aliased copies, and settle skills from different
+               sources that deploy to the same directory
+            5. Return deduplicated skill list; conflicts are kept in
+               ``self.conflicts``
 
         Example:
             >>> manager = GitSkillSourceManager(config)
@@ -457,8 +719,11 @@ class GitSkillSourceManager:
 
         Resolution Strategy:
             - Group skills by skill_id
-            - For each group, select skill from source with lowest priority
+            - For each group, select skill from the source given precedence
+              in skills.conflicts, else the source with lowest priority
             - If multiple skills have same priority, use first encountered
+            - See skill_conflicts.resolve_conflicts for aliases and
+              directory conflicts
 
         Example:
             skills_by_source = {
@@ -472,37 +737,100 @@ class GitSkillSourceManager:
         for skills in skills_by_source.values():
             all_skills.extend(skills)
 
-        if not all_skills:
-            return []
+        self._discovered = all_skills
+        resolved_skills, self.conflicts = resolve_conflicts(
+            all_skills, self.conflict_config
+        )
+        return resolved_skills
+
+    @property
+    def conflict_config(self) -> ConflictConfig:
+        if self._conflict_config is None:
+            self._conflict_config = ConflictConfig.load()
+        return self._conflict_config
+
+    @property
+    def signatures(self) -> SignatureConfig:
+        if self._signatures is None:
+            self._signatures = SignatureConfig.load()
+        return self._signatures
+
+    def verify_source(self, source: SkillSource) -> Verification | None:
+        """Check *source*'s synced cache against the keys trusted for it.
 
-        # Group by skill_id
-        skills_by_id: dict[str, list[dict[str, Any]]] = {}
-        for skill in all_skills:
-            skill_id = skill.get("skill_id", skill.get("name", "unknown"))
-            if skill_id not in skills_by_id:
-                skills_by_id[skill_id] = []
-            skills_by_id[skill_id].append(skill)
-
-        # Select skill with lowest priority for each group
-        resolved_skills = []
-        for skill_id, skill_group in skills_by_id.items():
-            # Sort by priority (ascending), take first
-            skill_group_sorted = sorted(
-                skill_group, key=lambda s: s.get("source_priority", 999)
+        Returns None when there is nothing to check: a local source, or a
+        source with no trusted keys while signatures are not required. A
+        failed check is logged as a warning unless signatures are required,
+        in which case the cache is cleared so its files cannot deploy.
+
+        Raises:
+            SignatureError: If signatures are required and the check fails
+        """
+        trusted_keys = [*source.trusted_keys, *self.signatures.trusted_keys]
+        if source.is_local or not (trusted_keys or self.signatures.require):
+            return None
+        verification = verify_collection(
+            self._get_source_cache_path(source), trusted_keys
+        )
+        if verification.verified:
+            self.logger.info(f"{source.id} is signed by {verification.signer}")
+        elif self.signatures.require:
+            self._clear_cache(source)
+            raise SignatureError(
+                f"{source.id} failed signature verification: {verification.error}"
             )
-            selected_skill = skill_group_sorted[0]
+        else:
+            self.logger.warning(
+                f"{source.id} failed signature verification: {verification.error}"
+            )
+        return verification
 
-            # Log if multiple versions exist
-            if len(skill_group) > 1:
-                sources = [s.get("source_id") for s in skill_group]
-                self.logger.debug(
-                    f"Skill '{skill_id}' found in multiple sources {sources}, "
-                    f"using source '{selected_skill.get('source_id')}'"
-                )
+    def _clear_cache(self, source: SkillSource) -> None:
+        """Remove *source*'s cache and what records it, so the next sync
+        downloads every file again."""
+        shutil.rmtree(self._get_source_cache_path(source), ignore_errors=True)
+        self._commit_marker(source.id).unlink(missing_ok=True)
+        self._get_etag_cache_file(source.id).unlink(missing_ok=True)
 
-            resolved_skills.append(selected_skill)
+    def _held_or_record(
+        self, skill: dict[str, Any], name: str, target_skill_dir: Path
+    ) -> str | None:
+        """Record the version of *skill* about to be deployed to
+        *target_skill_dir*, unless a rollback holds it there.
 
-        return resolved_skills
+        Returns:
+            The version ID the skill is held at, or None if it may deploy
+        """
+        held = self.history.held(name, target_skill_dir)
+        if held:
+            self.logger.info(f"Skipped {name} (held at {held} by skills rollback)")
+            return held
+        source_id = skill.get("source_id")
+        source = self.config.get_source(source_id) if source_id else None
+        self.history.record_deployment(
+            name,
+            Path(skill["source_file"]).parent,
+            target_skill_dir,
+            source_id,
+            self.synced_commit(source) if source else None,
+        )
+        return None
+
+    def _pinned_commit(self, source: SkillSource) -> str | None:
+        """The locked commit for *source*, pinning its branch head if unlocked.
+
+        Returns None when the manager has no lock (sync the branch head) or
+        the source is a local directory or registry.
+        """
+        if self.lock is None or not source.is_git:
+            return None
+        entry = self.lock.get(source)
+        if entry is not None:
+            return entry.commit
+        commit = resolve_source_commit(source)
+        self.lock.pin(source, commit)
+        self.logger.info(f"Pinned {source.id} to {commit[:8]} in {self.lock.path}")
+        return commit
 
     def _recursive_sync_repository(
         self,
@@ -510,6 +838,7 @@ class GitSkillSourceManager:
         cache_path: Path,
         force: bool = False,
         progress_callback=None,
+        commit: str | None = None,
     ) -> tuple[int, int]:
         """Recursively sync entire GitHub repository structure to cache.
 
@@ -533,6 +862,7 @@ class GitSkillSourceManager:
             cache_path: Local cache directory (structure preserved)
             force: Force re-download even if ETag cached
             progress_callback: Optional callback(absolute_position: int) for progress tracking
+            commit: Commit to sync instead of the branch head (from skills.lock)
 
         Returns:
             Tuple of (files_updated, files_cached)
@@ -549,19 +879,35 @@ class GitSkillSourceManager:
         - Invalid GitHub URL: Raises ValueError
         - Tree API failure: Returns 0, 0 (logged as warning)
         - Individual file failures: Logged but don't stop sync
+
+        SSH sources (git@host:owner/repo.git) are cloned with git instead;
+        see _sync_via_git. GitLab and Bitbucket sources are synced from an
+        archive; see _sync_via_archive. Local sources are copied from disk;
+        see _sync_local. Registries are synced from their index; see
+        _sync_via_registry.
         """
-        # Parse GitHub URL
-        url_parts = source.url.rstrip("/").replace(".git", "").split("github.com/")
-        if len(url_parts) != 2:
-            raise ValueError(f"Invalid GitHub URL: {source.url}")
+        if source.is_local:
+            return self._sync_local(source, cache_path, progress_callback)
+        if source.is_http:
+            return self._sync_via_registry(
+                source, cache_path, force, progress_callback
+            )
+        if source.is_ssh:
+            return self._sync_via_git(source, cache_path, progress_callback, commit)
+        host = get_host(source)
+        if host is not None:
+            return self._sync_via_archive(
+                host, cache_path, force, progress_callback, commit
+            )
 
-        repo_path = url_parts[1].strip("/")
-        owner_repo = "/".join(repo_path.split("/")[:2])
+        # Parse GitHub URL
+        owner_repo = _github_owner_repo(source.url)
+        ref = commit or source.branch
 
         # Step 1: Discover all files via GitHub Tree API (single request)
         # This discovers the COMPLETE repository structure (272 files for skills)
         all_files = self._discover_repository_files_via_tree_api(
-            owner_repo, source.branch, source
+            owner_repo, source.branch, source, commit=commit
         )
 
         if not all_files:
@@ -569,43 +915,11 @@ class GitSkillSourceManager:
             return 0, 0
 
         self.logger.info(
-            f"Discovered {len(all_files)} files in {owner_repo}/{source.branch} via Tree API"
+            f"Discovered {len(all_files)} files in {owner_repo}/{ref} via Tree API"
         )
 
         # Step 2: Filter to download relevant files
-        # Include full skill directory structure: SKILL.md, scripts/, references/
-        # Supported extensions:
-        #   - Documentation: .md, .json, .yaml, .yml, .txt
-        #   - Scripts: .sh, .py, .js, .ts, .mjs, .cjs
-        #   - Assets: .png, .jpg, .jpeg, .gif, .svg, .webp
-        #   - Config: .gitignore, .env.example
-        relevant_extensions = (
-            # Documentation
-            ".md",
-            ".json",
-            ".yaml",
-            ".yml",
-            ".txt",
-            # Scripts
-            ".sh",
-            ".py",
-            ".js",
-            ".ts",
-            ".mjs",
-            ".cjs",
-            # Assets
-            ".png",
-            ".jpg",
-            ".jpeg",
-            ".gif",
-            ".svg",
-            ".webp",
-        )
-        relevant_files = [
-            f
-            for f in all_files
-            if f.endswith(relevant_extensions) or f in (".gitignore", ".env.example")
-        ]
+        relevant_files = [f for f in all_files if _is_relevant_file(f)]
 
         self.logger.info(
             f"Filtered to {len(relevant_files)} relevant files (docs, scripts, assets)"
@@ -626,7 +940,7 @@ class GitSkillSourceManager:
             # Submit all download tasks
             future_to_file = {}
             for file_path in relevant_files:
-                raw_url = f"https://raw.githubusercontent.com/{owner_repo}/{source.branch}/{file_path}"
+                raw_url = f"https://raw.githubusercontent.com/{owner_repo}/{ref}/{file_path}"
                 cache_file = cache_path / file_path
                 future = executor.submit(
                     self._download_file_with_etag,
@@ -656,14 +970,271 @@ class GitSkillSourceManager:
                 if progress_callback:
                     progress_callback(completed)
 
+        synced = commit or self._tree_commits.pop(source.id, None)
+        if synced:
+            self._commit_marker(source.id).write_text(f"{synced}\n", encoding="utf-8")
+
         self.logger.info(
             f"Repository sync complete: {files_updated} updated, "
             f"{files_cached} cached from {len(relevant_files)} files"
         )
         return files_updated, files_cached
 
+    def _sync_via_git(
+        self,
+        source: SkillSource,
+        cache_path: Path,
+        progress_callback=None,
+        commit: str | None = None,
+    ) -> tuple[int, int]:
+        """Sync an SSH source into its cache as a shallow git clone.
+
+        The GitHub API and raw downloads cannot use SSH deploy keys, so SSH
+        sources are cloned (depth 1, one branch) and then fetched and reset
+        on later syncs. A cache left by an HTTPS sync of the same source ID is
+        replaced by the clone. With a locked *commit*, that commit is fetched
+        instead of the branch head, and a cache already at it is left alone.
+
+        Returns:
+            Tuple of (files_updated, files_cached), counted from the files
+            the fetch changed
+        """
+        key = source.ssh_key_path
+        if key is not None and not key.is_file():
+            raise ValueError(f"SSH key not found: {key}")
+        env = _git_ssh_env(source)
+        ref = commit or source.branch
+
+        if (cache_path / ".git").is_dir():
+            before = _run_git(["rev-parse", "HEAD"], env, cache_path).strip()
+            if before != commit:
+                _run_git(["remote", "set-url", "origin", source.url], env, cache_path)
+                _run_git(["fetch", "--depth", "1", "origin", ref], env, cache_path)
+                _run_git(["reset", "--hard", "FETCH_HEAD"], env, cache_path)
+                _run_git(["clean", "-fd"], env, cache_path)
+            changed = _run_git(
+                ["diff", "--name-only", before, "HEAD"], env, cache_path
+            ).splitlines()
+        else:
+            staging = Path(
+                tempfile.mkdtemp(prefix=f".{source.id}-", dir=cache_path.parent)
+            )
+            try:
+                clone = staging / "repo"
+                _run_git(
+                    [
+                        "clone",
+                        "--depth",
+                        "1",
+                        "--single-branch",
+                        "--branch",
+                        source.branch,
+                        source.url,
+                        str(clone),
+                    ],
+                    env,
+                )
+                if commit:
+                    _run_git(["fetch", "--depth", "1", "origin", commit], env, clone)
+                    _run_git(["reset", "--hard", "FETCH_HEAD"], env, clone)
+                shutil.rmtree(cache_path, ignore_errors=True)
+                clone.rename(cache_path)
+            finally:
+                shutil.rmtree(staging, ignore_errors=True)
+            changed = None
+
+        files = _run_git(["ls-files"], env, cache_path).splitlines()
+        files_updated = len(files) if changed is None else len(changed)
+        if progress_callback:
+            progress_callback(len(files))
+
+        self.logger.info(
+            f"Git sync complete for {source.id}: {files_updated} updated, "
+            f"{len(files)} files in {ref}"
+        )
+        return files_updated, max(len(files) - files_updated, 0)
+
+    def _sync_local(
+        self, source: SkillSource, cache_path: Path, progress_callback=None
+    ) -> tuple[int, int]:
+        """Sync a local directory source into its cache.
+
+        The directory's relevant files are synced into the cache, removing
+        files deleted from the directory. Hidden files and directories
+        (.git, editor swap files) are skipped.
+
+        Returns:
+            Tuple of (files_updated, files_cached)
+        """
+        root = source.local_path
+        if not root.is_dir():
+            raise ValueError(f"Local skill directory not found: {root}")
+
+        staging = Path(tempfile.mkdtemp(prefix=f".{source.id}-", dir=cache_path.parent))
+        try:
+            for path in root.rglob("*"):
+                relative = path.relative_to(root)
+                if not path.is_file() or is_hidden_path(relative):
+                    continue
+                if not _is_relevant_file(relative.as_posix()):
+                    continue
+                target = staging / relative
+                target.parent.mkdir(parents=True, exist_ok=True)
+                shutil.copy2(path, target)
+            delta = sync_directory(staging, cache_path)
+        finally:
+            shutil.rmtree(staging, ignore_errors=True)
+
+        files_updated = len(delta.added) + len(delta.changed)
+        if progress_callback:
+            progress_callback(files_updated + len(delta.unchanged))
+
+        self.logger.info(
+            f"Local sync complete for {source.id}: {files_updated} updated, "
+            f"{len(delta.removed)} removed from {root}"
+        )
+        return files_updated, len(delta.unchanged)
+
+    def _sync_via_registry(
+        self,
+        source: SkillSource,
+        cache_path: Path,
+        force: bool = False,
+        progress_callback=None,
+    ) -> tuple[int, int]:
+        """Sync a static HTTP registry into its cache.
+
+        The index is downloaded on every sync; each skill archive whose
+        SHA-256 is unchanged since the last sync, and whose files are still
+        cached, is kept without downloading it again unless *force* is set.
+        Every download is checked against the index before the cache is
+        touched. The SHA-256 of the index is recorded as the synced commit.
+
+        Returns:
+            Tuple of (files_updated, files_cached)
+        """
+        index = fetch_index(source)
+        state_file = self.etag_dir / f"{source.id}.registry.json"
+        synced = {} if force else (read_json(state_file) or {})
+
+        staging = Path(tempfile.mkdtemp(prefix=f".{source.id}-", dir=cache_path.parent))
+        try:
+            tree = staging / "tree"
+            tree.mkdir()
+            downloaded = 0
+            for skill in index.skills:
+                cached = cache_path / skill.path
+                if synced.get(skill.path) == skill.sha256 and cached.is_dir():
+                    shutil.copytree(cached, tree / skill.path)
+                    continue
+                extract_skill_archive(
+                    download_artifact(source, skill),
+                    tree / skill.path,
+                    lambda path: _is_relevant_file(path)
+                    and not is_hidden_path(Path(path)),
+                )
+                downloaded += 1
+            for file in index.files:
+                target = tree / file.path
+                target.parent.mkdir(parents=True, exist_ok=True)
+                target.write_bytes(download_artifact(source, file))
+            delta = sync_directory(tree, cache_path)
+        finally:
+            shutil.rmtree(staging, ignore_errors=True)
+
+        write_atomic(
+            state_file,
+            json.dumps({skill.path: skill.sha256 for skill in index.skills}, indent=2),
+        )
+        self._commit_marker(source.id).write_text(
+            f"{index.sha256}\n", encoding="utf-8"
+        )
+        files_updated = len(delta.added) + len(delta.changed)
+        if progress_callback:
+            progress_callback(files_updated + len(delta.unchanged))
+
+        self.logger.info(
+            f"Registry sync complete for {source.id}: {downloaded} of "
+            f"{len(index.skills)} skills downloaded, {len(delta.removed)} files "
+            f"removed"
+        )
+        return files_updated, len(delta.unchanged)
+
+    def _sync_via_archive(
+        self,
+        host: GitHost,
+        cache_path: Path,
+        force: bool = False,
+        progress_callback=None,
+        commit: str | None = None,
+    ) -> tuple[int, int]:
+        """Sync a GitLab or Bitbucket source into its cache from an archive.
+
+        The branch head (or the locked *commit*) is downloaded as one tar.gz
+        archive and its relevant files are synced into the cache, removing
+        files the commit no longer has. The synced commit is recorded next to
+        the ETag cache, so a cache already at that commit is left alone
+        unless *force* is set.
+
+        Returns:
+            Tuple of (files_updated, files_cached)
+        """
+        import tarfile
+
+        source = host.source
+        ref = commit or host.resolve_branch(source.branch)
+        marker = self._commit_marker(source.id)
+        if (
+            not force
+            and marker.is_file()
+            and marker.read_text(encoding="utf-8").strip() == ref
+        ):
+            files = [p for p in cache_path.rglob("*") if p.is_file()]
+            if files:
+                if progress_callback:
+                    progress_callback(len(files))
+                self.logger.info(f"{source.id} is already at {ref[:8]}")
+                return 0, len(files)
+
+        staging = Path(tempfile.mkdtemp(prefix=f".{source.id}-", dir=cache_path.parent))
+        try:
+            archive = staging / "archive.tar.gz"
+            host.download_archive(ref, archive)
+            tree = staging / "tree"
+            tree.mkdir()
+            with tarfile.open(archive, "r:gz") as tar:
+                for member in tar:
+                    path = archive_member_path(member.name)
+                    if not member.isfile() or path is None:
+                        continue
+                    if not _is_relevant_file(path):
+                        continue
+                    target = tree / path
+                    target.parent.mkdir(parents=True, exist_ok=True)
+                    with tar.extractfile(member) as data:
+                        target.write_bytes(data.read())
+            delta = sync_directory(tree, cache_path)
+        finally:
+            shutil.rmtree(staging, ignore_errors=True)
+
+        marker.parent.mkdir(parents=True, exist_ok=True)
+        marker.write_text(f"{ref}\n", encoding="utf-8")
+        files_updated = len(delta.added) + len(delta.changed)
+        if progress_callback:
+            progress_callback(files_updated + len(delta.unchanged))
+
+        self.logger.info(
+            f"Archive sync complete for {source.id}: {files_updated} updated, "
+            f"{len(delta.removed)} removed from {host.name} at {ref[:8]}"
+        )
+        return files_updated, len(delta.unchanged)
+
     def _discover_repository_files_via_tree_api(
-        self, owner_repo: str, branch: str, source: SkillSource | None = None
+        self,
+        owner_repo: str,
+        branch: str,
+        source: SkillSource | None = None,
+        commit: str | None = None,
     ) -> list[str]:
         """Discover all files in repository using GitHub Git Tree API.
 
@@ -692,6 +1263,7 @@ class GitSkillSourceManager:
         Args:
             owner_repo: GitHub owner/repo (e.g., "bobmatnyc/claude-mpm-skills")
             branch: Branch name (e.g., "main")
+            commit: Locked commit; skips step 1 and lists that commit's tree
 
         Returns:
             List of all file paths in repository
@@ -720,12 +1292,6 @@ class GitSkillSourceManager:
         all_files = []
 
         try:
-            # Step 1: Get the latest commit SHA for the branch
-            refs_url = (
-                f"https://api.github.com/repos/{owner_repo}/git/refs/heads/{branch}"
-            )
-            self.logger.debug(f"Fetching commit SHA from {refs_url}")
-
             # Build headers with authentication if token available
             headers = {"Accept": "application/vnd.github+json"}
             token = _get_github_token(source)
@@ -736,19 +1302,13 @@ class GitSkillSourceManager:
                 else:
                     self.logger.debug("Using GitHub token for authentication")
 
-            refs_response = requests.get(refs_url, headers=headers, timeout=30)
-
-            # Check for rate limiting
-            if refs_response.status_code == 403:
-                self.logger.warning(
-                    "GitHub API rate limit exceeded (HTTP 403). "
-                    "Consider setting GITHUB_TOKEN environment variable for higher limits."
-                )
-                raise requests.RequestException("Rate limit exceeded")
-
-            refs_response.raise_for_status()
-            commit_sha = refs_response.json()["object"]["sha"]
-            self.logger.debug(f"Resolved {branch} to commit {commit_sha[:8]}")
+            # Step 1: Get the latest commit SHA for the branch (unless locked)
+            if commit:
+                commit_sha = commit
+            else:
+                commit_sha = _resolve_github_branch(owner_repo, branch, headers)
+            if source is not None:
+                self._tree_commits[source.id] = commit_sha
 
             # Step 2: Get the tree for that commit (recursive=1 gets ALL files)
             tree_url = (
@@ -757,7 +1317,7 @@ class GitSkillSourceManager:
             params = {"recursive": "1"}  # Recursively get entire tree
 
             self.logger.debug(f"Fetching recursive tree from {tree_url}")
-            tree_response = requests.get(
+            tree_response = http_client.get(
                 tree_url,
                 headers=headers,  # Reuse headers with auth from Step 1
                 params=params,
@@ -789,6 +1349,28 @@ class GitSkillSourceManager:
 
         return all_files
 
+    def _commit_marker(self, source_id: str) -> Path:
+        """File recording the commit an HTTPS source's cache was synced to."""
+        return self.etag_dir / f"{source_id}.commit"
+
+    def synced_commit(self, source: SkillSource) -> str | None:
+        """The commit *source*'s cache was last synced to, if known.
+
+        SSH caches are git clones and report their HEAD; GitHub, GitLab and
+        Bitbucket syncs record the commit next to the ETag cache, and
+        registry syncs the SHA-256 of their index. Local directories have no
+        commits.
+        """
+        if source.is_local:
+            return None
+        cache_path = self._get_source_cache_path(source)
+        if (cache_path / ".git").is_dir():
+            return git_commit(cache_path / ".git")
+        marker = self._commit_marker(source.id)
+        if not marker.is_file():
+            return None
+        return marker.read_text(encoding="utf-8").strip() or None
+
     def _get_etag_cache_file(self, source_id: str) -> Path:
         """Return the external ETag cache path for *source_id*.
 
@@ -1019,7 +1601,7 @@ class GitSkillSourceManager:
             headers["Authorization"] = f"token {token}"
 
         try:
-            response = requests.get(url, headers=headers, timeout=30)
+            response = http_client.get(url, headers=headers, timeout=30)
 
             # 304 Not Modified - use cached version
             if response.status_code == 304:
@@ -1116,6 +1698,8 @@ class GitSkillSourceManager:
         project_dir: Path,
         skill_list: list[str] | None = None,
         force: bool = False,
+        max_workers: int = 1,
+        progress_callback=None,
     ) -> dict[str, Any]:
         """Deploy skills from cache to project directory (Phase 2 deployment).
 
@@ -1134,14 +1718,17 @@ class GitSkillSourceManager:
 
         Trade-offs:
         - Storage: 2x disk (cache + project deployments)
-        - Performance: Copy ~10ms for 50 skills (negligible)
+        - Performance: Unchanged files are hashed, not rewritten
         - Flexibility: Project-specific skill sets from shared cache
         - Isolation: Projects don't affect each other
 
         Args:
             project_dir: Project root directory (e.g., /path/to/myproject)
             skill_list: Optional list of skill names to deploy (deploys all if None)
+                (``<source>:<skill>`` picks that source's copy)
             force: Force redeployment even if up-to-date
+            max_workers: Skills to deploy in parallel
+            progress_callback: Optional callback(completed: int) per skill
 
         Returns:
             Dictionary with deployment results:
@@ -1149,7 +1736,9 @@ class GitSkillSourceManager:
                 "deployed": ["skill1"],      # Newly deployed
                 "updated": ["skill2"],        # Updated existing
                 "skipped": ["skill3"],        # Already up-to-date
-                "failed": [],                 # Copy failures
+                "failed": ["skill4"],         # Copy failures
+                "changes": {"skill2": {"changed": ["SKILL.md"], ...}},
+                "errors": {"skill4": "not found in cache"},
                 "deployment_dir": "/path/.claude-mpm/skills"
             }
 
@@ -1160,7 +1749,7 @@ class GitSkillSourceManager:
            a. Check if cache file exists
            b. Flatten nested path to deployment name
            c. Compare modification times (skip if up-to-date)
-           d. Copy from cache to project
+           d. Write only the files whose content differs from the cache
            e. Track result (deployed/updated/skipped/failed)
         4. Return deployment statistics
 
@@ -1175,8 +1764,6 @@ class GitSkillSourceManager:
             >>> result = manager.deploy_skills_to_project(Path("/my/project"))
             >>> print(f"Deployed {len(result['deployed'])} skills")
         """
-        import shutil
-
         deployment_dir = project_dir / ".claude-mpm" / "skills"
 
         # Try to create deployment directory
@@ -1201,23 +1788,31 @@ class GitSkillSourceManager:
             "updated": [],
             "skipped": [],
             "failed": [],
+            "changes": {},
             "deployment_dir": str(deployment_dir),
         }
 
-        # Get all skills from cache or use provided list
+        # Get all skills from cache or use provided list, plus the skills
+        # they require
+        catalog = self.get_all_skills()
         if skill_list is None:
-            all_skills = self.get_all_skills()
+            selected = catalog
         else:
-            # Filter skills by provided list
-            all_skills = [
-                s for s in self.get_all_skills() if s.get("name") in skill_list
-            ]
+            # <source>:<skill> names pick that source's copy
+            catalog, skill_list = pick_sources(catalog, self._discovered, skill_list)
+            selected = [s for s in catalog if s.get("name") in skill_list]
+        resolution = resolve_dependencies(selected, catalog)
+        all_skills = resolution.skills
+        if resolution.added:
+            self.logger.info(
+                f"Including required skills: {', '.join(resolution.added)}"
+            )
 
         self.logger.info(
             f"Deploying {len(all_skills)} skills from cache to {deployment_dir}"
         )
 
-        for skill in all_skills:
+        def deploy_one(skill: dict[str, Any]) -> BulkItem:
             skill_name = skill.get("name", "unknown")
             deployment_name = skill.get("deployment_name")
             source_file = skill.get("source_file")
@@ -1226,15 +1821,13 @@ class GitSkillSourceManager:
                 self.logger.warning(
                     f"Skill {skill_name} missing deployment_name or source_file, skipping"
                 )
-                results["failed"].append(skill_name)
-                continue
+                return BulkItem(skill_name, FAILED, "no deployment name or source")
 
             try:
                 source_path = Path(source_file)
                 if not source_path.exists():
                     self.logger.warning(f"Cache file not found: {source_file}")
-                    results["failed"].append(skill_name)
-                    continue
+                    return BulkItem(skill_name, FAILED, "not found in cache")
 
                 # Source is the entire skill directory (not just SKILL.md)
                 source_dir = source_path.parent
@@ -1256,44 +1849,62 @@ class GitSkillSourceManager:
                         should_deploy = True
 
                 if not should_deploy and was_existing:
-                    results["skipped"].append(sanitized_name)
                     self.logger.debug(f"Skipped (up-to-date): {sanitized_name}")
-                    continue
+                    return BulkItem(sanitized_name, "skipped")
 
                 # Security: Validate paths
                 if not self._validate_safe_path(deployment_dir, target_skill_dir):
                     self.logger.error(f"Invalid target path: {target_skill_dir}")
-                    results["failed"].append(skill_name)
-                    continue
+                    return BulkItem(skill_name, FAILED, "invalid target path")
 
-                # Remove existing if force or updating
-                if target_skill_dir.exists():
-                    if target_skill_dir.is_symlink():
-                        self.logger.warning(f"Removing symlink: {target_skill_dir}")
-                        target_skill_dir.unlink()
-                    else:
-                        shutil.rmtree(target_skill_dir)
+                if self._held_or_record(skill, sanitized_name, target_skill_dir):
+                    return BulkItem(sanitized_name, "skipped")
+
+                if target_skill_dir.is_symlink():
+                    self.logger.warning(f"Replacing symlink: {target_skill_dir}")
 
-                # Copy entire skill directory from cache
-                shutil.copytree(source_dir, target_skill_dir)
+                # Write only the files whose content differs from the cache,
+                # merging in local edits unless forced
+                delta = sync_directory(source_dir, target_skill_dir, merge=not force)
+                record_directory(
+                    target_skill_dir, source_dir, exclude=delta.kept + delta.conflicts
+                )
 
                 # Track result
+                if delta.conflicts:
+                    results["changes"][sanitized_name] = delta.to_dict()
+                    return BulkItem(sanitized_name, "conflict", delta.summary())
+                if was_existing and not delta.has_changes:
+                    self.logger.debug(f"Unchanged: {sanitized_name}")
+                    return BulkItem(sanitized_name, "skipped")
+                results["changes"][sanitized_name] = delta.to_dict()
                 if was_existing:
-                    results["updated"].append(sanitized_name)
-                    self.logger.info(f"Updated: {sanitized_name}")
-                else:
-                    results["deployed"].append(sanitized_name)
-                    self.logger.info(f"Deployed: {sanitized_name}")
+                    self.logger.info(f"Updated: {sanitized_name} ({delta.summary()})")
+                    return BulkItem(sanitized_name, "updated", delta.summary())
+                self.logger.info(f"Deployed: {sanitized_name}")
+                return BulkItem(sanitized_name, "deployed")
 
             except PermissionError as e:
                 self.logger.error(f"Permission denied deploying {skill_name}: {e}")
-                results["failed"].append(skill_name)
+                return BulkItem(skill_name, FAILED, f"permission denied: {e}")
             except OSError as e:
                 self.logger.error(f"IO error deploying {skill_name}: {e}")
-                results["failed"].append(skill_name)
-            except Exception as e:
-                self.logger.error(f"Unexpected error deploying {skill_name}: {e}")
-                results["failed"].append(skill_name)
+                return BulkItem(skill_name, FAILED, str(e))
+
+        report = run_bulk(
+            all_skills,
+            deploy_one,
+            operation_name="Deploy skills",
+            name=lambda skill: skill.get("name", "unknown"),
+            max_workers=max_workers,
+            progress_callback=progress_callback,
+        )
+        for status in ("deployed", "updated", "skipped", FAILED):
+            results[status] = report.names(status)
+        results["conflicts"] = report.names("conflict")
+        results["failed"].extend(resolution.errors)
+        errors = {item.name: item.detail for item in report.failed}
+        errors.update(resolution.errors)
 
         # Log summary
         total_success = len(results["deployed"]) + len(results["updated"])
@@ -1312,6 +1923,10 @@ class GitSkillSourceManager:
             "skipped_count": len(results["skipped"]),
             "failed": results["failed"],
             "failed_count": len(results["failed"]),
+            "conflicts": results["conflicts"],
+            "changes": results["changes"],
+            "errors": errors,
+            "dependencies": resolution.added,
             "deployment_dir": results["deployment_dir"],
         }
 
@@ -1321,6 +1936,7 @@ class GitSkillSourceManager:
         force: bool = False,
         progress_callback=None,
         skill_filter: set[str] | None = None,
+        max_workers: int = 1,
     ) -> dict[str, Any]:
         """Deploy skills from cache to target directory with flat structure and automatic cleanup.
 
@@ -1344,6 +1960,8 @@ class GitSkillSourceManager:
             skill_filter: Optional set of skill names to deploy (selective deployment).
                          If None, deploys ALL skills WITHOUT cleanup.
                          If provided, deploys ONLY filtered skills AND removes orphans.
+                         ``<source>:<skill>`` names pick that source's copy.
+            max_workers: Skills to deploy in parallel
 
         Returns:
             Dict with deployment results:
@@ -1356,7 +1974,8 @@ class GitSkillSourceManager:
                 "errors": List[str],
                 "filtered_count": int,  # Number of skills filtered out
                 "removed_count": int,   # Number of orphaned skills removed
-                "removed_skills": List[str]  # Names of removed orphaned skills
+                "removed_skills": List[str],  # Names of removed orphaned skills
+                "changes": Dict[str, Dict[str, List[str]]]  # Files written per skill
             }
 
         Example:
@@ -1374,16 +1993,22 @@ class GitSkillSourceManager:
 
         target_dir.mkdir(parents=True, exist_ok=True)
 
-        deployed = []
-        skipped = []
-        errors = []
         filtered_count = 0
         removed_skills = []  # Track removed orphaned skills
+        changes: dict[str, dict[str, list[str]]] = {}  # Files written per skill
 
         # Get all skills from all sources
-        all_skills = self.get_all_skills()
+        catalog = self.get_all_skills()
 
         # Apply skill filter if provided (selective deployment)
+        if skill_filter is not None:
+            # <source>:<skill> names pick that source's copy
+            catalog, picked = pick_sources(
+                catalog, self._discovered, skill_filter, "deployment_name"
+            )
+            skill_filter = set(picked)
+        all_skills = catalog
+
         if skill_filter is not None:
             original_count = len(all_skills)
             # Normalize filter to lowercase for case-insensitive matching
@@ -1420,6 +2045,16 @@ class GitSkillSourceManager:
                 f"match agent requirements ({filtered_count} filtered out)"
             )
 
+        # Add the skills the selection requires, so cleanup keeps them too
+        resolution = resolve_dependencies(all_skills, catalog)
+        all_skills = resolution.skills
+        if resolution.added:
+            filtered_count -= len(resolution.added)
+            self.logger.info(
+                f"Including required skills: {', '.join(resolution.added)}"
+            )
+
+        if skill_filter is not None:
             # Cleanup: Remove skills from target directory that aren't in the filtered set
             # This ensures only agent-referenced skills remain deployed
             removed_skills = self._cleanup_unfiltered_skills(target_dir, all_skills)
@@ -1437,7 +2072,7 @@ class GitSkillSourceManager:
             f"Deploying {len(all_skills)} skills to {target_dir} (force={force})"
         )
 
-        for idx, skill in enumerate(all_skills, start=1):
+        def deploy_one(skill: dict[str, Any]) -> BulkItem:
             skill_name = skill.get("name", "unknown")
             raw_deployment_name = skill.get("deployment_name")
 
@@ -1445,10 +2080,9 @@ class GitSkillSourceManager:
                 self.logger.warning(
                     f"Skill {skill_name} missing deployment_name, skipping"
                 )
-                errors.append(f"{skill_name}: Missing deployment_name")
-                if progress_callback:
-                    progress_callback(idx)
-                continue
+                return BulkItem(
+                    skill_name, FAILED, f"{skill_name}: Missing deployment_name"
+                )
 
             deployment_name = sanitize_skill_name_for_deployment(
                 str(raw_deployment_name)
@@ -1458,22 +2092,29 @@ class GitSkillSourceManager:
                 result = self._deploy_single_skill(
                     skill, target_dir, deployment_name, force
                 )
-
-                if result["deployed"]:
-                    deployed.append(deployment_name)
-                elif result["skipped"]:
-                    skipped.append(deployment_name)
-
-                if result["error"]:
-                    errors.append(result["error"])
-
             except Exception as e:
                 self.logger.error(f"Failed to deploy {skill_name}: {e}")
-                errors.append(f"{skill_name}: {e}")
-
-            # Call progress callback for each skill processed
-            if progress_callback:
-                progress_callback(idx)
+                return BulkItem(skill_name, FAILED, f"{skill_name}: {e}")
+
+            if result["error"]:
+                return BulkItem(deployment_name, FAILED, result["error"])
+            if result["deployed"]:
+                changes[deployment_name] = result["delta"].to_dict()
+                return BulkItem(deployment_name, "deployed")
+            return BulkItem(deployment_name, "skipped")
+
+        report = run_bulk(
+            all_skills,
+            deploy_one,
+            operation_name="Deploy skills",
+            name=lambda skill: skill.get("name", "unknown"),
+            max_workers=max_workers,
+            progress_callback=progress_callback,
+        )
+        deployed = report.names("deployed")
+        skipped = report.names("skipped")
+        errors = [item.detail for item in report.failed]
+        errors.extend(f"{name}: {why}" for name, why in resolution.errors.items())
 
         self.logger.info(
             f"Deployment complete: {len(deployed)} deployed, "
@@ -1491,6 +2132,106 @@ class GitSkillSourceManager:
             "filtered_count": filtered_count,
             "removed_count": len(removed_skills),
             "removed_skills": removed_skills,
+            "changes": changes,
+            "dependencies": resolution.added,
+        }
+
+    def deploy_source(
+        self, source_id: str, target_dir: Path | None = None
+    ) -> dict[str, Any]:
+        """Deploy the skills one source provides, overwriting changed files.
+
+        Only skills the source wins priority resolution for are deployed, so
+        a skill shadowed by a higher-priority source is left alone. Used by
+        the local source watcher to push edits without touching other
+        sources' skills.
+
+        Returns:
+            Dict with "deployed", "skipped" and "errors" lists, and "skills"
+            (the deployment names the source currently provides)
+        """
+        if target_dir is None:
+            target_dir = Path.home() / ".claude" / "skills"
+        target_dir.mkdir(parents=True, exist_ok=True)
+
+        result: dict[str, Any] = {
+            "deployed": [],
+            "skipped": [],
+            "errors": [],
+            "skills": [],
+        }
+        for skill in self.get_all_skills():
+            if skill.get("source_id") != source_id:
+                continue
+            raw_name = skill.get("deployment_name")
+            if not raw_name:
+                continue
+            name = sanitize_skill_name_for_deployment(str(raw_name))
+            result["skills"].append(name)
+            outcome = self._deploy_single_skill(skill, target_dir, name, force=True)
+            if outcome["error"]:
+                result["errors"].append(outcome["error"])
+            elif outcome["deployed"]:
+                result["deployed"].append(name)
+            else:
+                result["skipped"].append(name)
+        return result
+
+    def _provided_skill_names(self, source_id: str | None = None) -> set[str]:
+        """Deployment names of the skills that win priority resolution."""
+        names = set()
+        for skill in self.get_all_skills():
+            raw_name = skill.get("deployment_name")
+            if raw_name and source_id in (None, skill.get("source_id")):
+                names.add(sanitize_skill_name_for_deployment(str(raw_name)))
+        return names
+
+    def remove_source(
+        self, source_id: str, target_dir: Path | None = None
+    ) -> dict[str, Any]:
+        """Remove a source with everything it left behind.
+
+        Drops the source from the configuration, its skills.lock pin (when
+        the manager has a lock), its cache, and the deployed copies of the
+        skills it provided that no remaining source provides.
+
+        Returns:
+            Dict with "cache_removed" and "skills_removed" (the deployment
+            names deleted from target_dir)
+
+        Raises:
+            ValueError: If the source does not exist
+        """
+        source = self.config.get_source(source_id)
+        if source is None:
+            raise ValueError(f"Source not found: {source_id}")
+        if target_dir is None:
+            target_dir = Path.home() / ".claude" / "skills"
+
+        provided = self._provided_skill_names(source_id)
+        self.config.remove_source(source_id)
+        if self.lock is not None:
+            self.lock.unpin(source_id)
+
+        cache_path = self._get_source_cache_path(source)
+        cache_removed = cache_path.exists()
+        shutil.rmtree(cache_path, ignore_errors=True)
+        self._get_etag_cache_file(source_id).unlink(missing_ok=True)
+        self._commit_marker(source_id).unlink(missing_ok=True)
+
+        skills_removed = []
+        for name in sorted(provided - self._provided_skill_names()):
+            skill_dir = target_dir / name
+            if skill_dir.is_dir() and self._validate_safe_path(target_dir, skill_dir):
+                shutil.rmtree(skill_dir)
+                skills_removed.append(name)
+
+        self.logger.info(
+            f"Removed source {source_id}: {len(skills_removed)} deployed skills"
+        )
+        return {
+            "cache_removed": cache_removed,
+            "skills_removed": skills_removed,
         }
 
     def _cleanup_unfiltered_skills(
@@ -1654,8 +2395,6 @@ class GitSkillSourceManager:
         Returns:
             Dict with deployed, skipped, error flags
         """
-        import shutil
-
         source_file = Path(skill["source_file"])
         source_dir = source_file.parent
 
@@ -1675,21 +2414,31 @@ class GitSkillSourceManager:
             }
 
         try:
-            # Remove existing if force
-            if target_skill_dir.exists():
-                if target_skill_dir.is_symlink():
-                    self.logger.warning(f"Removing symlink: {target_skill_dir}")
-                    target_skill_dir.unlink()
-                else:
-                    shutil.rmtree(target_skill_dir)
+            if self._held_or_record(skill, deployment_name, target_skill_dir):
+                return {"deployed": False, "skipped": True, "error": None}
+
+            was_existing = target_skill_dir.exists()
+            if target_skill_dir.is_symlink():
+                self.logger.warning(f"Replacing symlink: {target_skill_dir}")
+
+            # Write only the files whose content differs, with all resources
+            delta = sync_directory(source_dir, target_skill_dir)
+            record_directory(target_skill_dir, source_dir)
 
-            # Copy entire skill directory with all resources
-            shutil.copytree(source_dir, target_skill_dir)
+            if was_existing and not delta.has_changes:
+                self.logger.debug(f"Skipped {deployment_name} (unchanged)")
+                return {"deployed": False, "skipped": True, "error": None}
 
             self.logger.debug(
-                f"Deployed {deployment_name} from {source_dir} to {target_skill_dir}"
+                f"Deployed {deployment_name} from {source_dir} to "
+                f"{target_skill_dir} ({delta.summary()})"
             )
-            return {"deployed": True, "skipped": False, "error": None}
+            return {
+                "deployed": True,
+                "skipped": False,
+                "error": None,
+                "delta": delta,
+            }
 
         except Exception as e:
             return {
@@ -0,0 +1,372 @@
+"""Static HTTP registries for skill sources.
+
+WHAT: A registry is an ``index.json`` served over HTTP(S) that lists skills,
+each packed as a tar.gz archive with its SHA-256::
+
+    {
+      "format": 1,
+      "skills": [
+        {
+          "name": "tdd",
+          "path": "universal/testing/tdd",
+          "version": "1.2.0",
+          "description": "Test-driven development workflow",
+          "tags": ["testing"],
+          "url": "universal-testing-tdd-1.2.0.tar.gz",
+          "sha256": "9f2c..."
+        }
+      ],
+      "files": [
+        {"path": "skills.manifest", "url": "skills.manifest", "sha256": "..."}
+      ]
+    }
+
+``url`` is relative to the index unless absolute, ``path`` is the skill's
+directory in the collection (its name when unset), and an archive holds that
+directory's files, at its root or under one top-level folder. ``files`` are
+the collection's other files (a README, or the manifest and signature of a
+signed collection), fetched as they are. ``build_registry`` packs a skill
+collection into a registry directory ready to upload.
+
+WHY: Organizations that cannot expose a git host to developer machines
+still run artifact servers (Artifactory, Nexus, S3 behind SSO). A registry
+is plain files, so any of them can serve one.
+
+CONFIGURATION:
+- ``source.token`` (or a "$VAR" reference) is sent as a Bearer token; a
+  token of the form ``user:secret`` is sent as Basic auth. Without a token,
+  credentials for the host in ``~/.netrc`` apply.
+
+DESIGN DECISIONS:
+- Every download is checked against its SHA-256 before anything is written
+  to the cache; a mismatch fails the sync
+- An archive whose SHA-256 is unchanged since the last sync is not
+  downloaded again
+- The SHA-256 of the index stands in for a commit, so deployed versions are
+  recorded against the index they came from. Registries are not pinned in
+  skills.lock: a static server only serves its current index
+- Archives are packed reproducibly (sorted members, no timestamps or
+  owners), so repacking an unchanged skill keeps its SHA-256
+"""
+
+from __future__ import annotations
+
+import base64
+import gzip
+import hashlib
+import io
+import json
+import re
+import tarfile
+from collections.abc import Callable
+from dataclasses import dataclass, field
+from pathlib import Path, PurePosixPath
+from typing import Any
+from urllib.parse import urljoin
+
+import yaml
+
+from claude_mpm.config.skill_sources import SkillSource
+from claude_mpm.services.skills.git_hosts import ARCHIVE_TIMEOUT, TIMEOUT, _source_token
+
+INDEX_FORMAT = 1
+INDEX_FILE = "index.json"
+
+_SHA256_RE = re.compile(r"^[0-9a-f]{64}$")
+
+
+class RegistryError(ValueError):
+    """An index that cannot be read, or a download that does not match it."""
+
+
+def sha256_bytes(data: bytes) -> str:
+    return hashlib.sha256(data).hexdigest()
+
+
+def _safe_path(path: str) -> str | None:
+    """*path* as a relative POSIX path, or None if it could leave its root."""
+    pure = PurePosixPath(path)
+    if not path or pure.is_absolute() or ".." in pure.parts or "\\" in path:
+        return None
+    parts = [part for part in pure.parts if part != "."]
+    return "/".join(parts) or None
+
+
+@dataclass(frozen=True)
+class RegistryArtifact:
+    """A skill archive or collection file listed in an index."""
+
+    path: str
+    url: str
+    sha256: str
+    name: str = ""
+    version: str | None = None
+
+
+@dataclass
+class RegistryIndex:
+    """The skills and files an index lists, with URLs made absolute."""
+
+    url: str
+    sha256: str
+    skills: list[RegistryArtifact] = field(default_factory=list)
+    files: list[RegistryArtifact] = field(default_factory=list)
+    data: dict[str, Any] = field(default_factory=dict)
+
+
+def _artifact(entry: Any, index_url: str, kind: str) -> RegistryArtifact:
+    if not isinstance(entry, dict):
+        raise RegistryError(f"Each of the index's {kind} must be an object")
+    name = str(entry.get("name") or "")
+    label = name or entry.get("path") or "?"
+    path = _safe_path(str(entry.get("path") or name))
+    if path is None:
+        raise RegistryError(f"{label} has no path, or one outside the registry")
+    if not entry.get("url"):
+        raise RegistryError(f"{label} has no url")
+    digest = str(entry.get("sha256") or "").lower()
+    if not _SHA256_RE.match(digest):
+        raise RegistryError(f"{label} has no valid sha256")
+    version = entry.get("version")
+    return RegistryArtifact(
+        path=path,
+        url=urljoin(index_url, str(entry["url"])),
+        sha256=digest,
+        name=name or PurePosixPath(path).name,
+        version=str(version) if version is not None else None,
+    )
+
+
+def parse_index(content: bytes, url: str) -> RegistryIndex:
+    """Parse the index downloaded from *url*.
+
+    Raises:
+        RegistryError: If it is not a registry index this version can read
+    """
+    try:
+        data = json.loads(content)
+    except ValueError as e:
+        raise RegistryError(f"{url} is not JSON: {e}") from e
+    if not isinstance(data, dict) or not isinstance(data.get("skills"), list):
+        raise RegistryError(f"{url} is not a skill registry index (no skills list)")
+    if data.get("format", INDEX_FORMAT) != INDEX_FORMAT:
+        raise RegistryError(
+            f"{url} uses registry format {data['format']}; "
+            f"this version reads format {INDEX_FORMAT}"
+        )
+    index = RegistryIndex(url=url, sha256=sha256_bytes(content), data=data)
+    index.skills = [_artifact(e, url, "skills") for e in data["skills"]]
+    index.files = [_artifact(e, url, "files") for e in data.get("files") or []]
+    paths = [a.path for a in (*index.skills, *index.files)]
+    duplicates = sorted({p for p in paths if paths.count(p) > 1})
+    if duplicates:
+        raise RegistryError(f"{url} lists {', '.join(duplicates)} more than once")
+    return index
+
+
+def auth_headers(source: SkillSource) -> dict[str, str]:
+    token = _source_token(source)
+    if not token:
+        return {}
+    if ":" in token:
+        basic = base64.b64encode(token.encode()).decode()
+        return {"Authorization": f"Basic {basic}"}
+    return {"Authorization": f"Bearer {token}"}
+
+
+def _get(source: SkillSource, url: str, timeout: int = TIMEOUT) -> bytes:
+    from claude_mpm.services import http_client
+
+    response = http_client.get(url, headers=auth_headers(source), timeout=timeout)
+    response.raise_for_status()
+    return response.content
+
+
+def fetch_index(source: SkillSource) -> RegistryIndex:
+    """Download and parse *source*'s index.
+
+    Raises:
+        requests.RequestException: If the index cannot be downloaded
+        RegistryError: If it is not a registry index
+    """
+    return parse_index(_get(source, source.url), source.url)
+
+
+def check_registry_access(source: SkillSource) -> str | None:
+    """Check *source*'s index can be read; return an error or None."""
+    import requests
+
+    try:
+        index = fetch_index(source)
+    except requests.HTTPError as e:
+        status = e.response.status_code if e.response is not None else None
+        if status in (401, 403) and not auth_headers(source):
+            return f"{e}. Set --token (or ~/.netrc) for a private registry"
+        return str(e)
+    except (requests.RequestException, RegistryError) as e:
+        return str(e)
+    if not index.skills:
+        return f"{source.url} lists no skills"
+    return None
+
+
+def download_artifact(source: SkillSource, artifact: RegistryArtifact) -> bytes:
+    """Download *artifact*, checked against the SHA-256 the index gives.
+
+    Raises:
+        requests.RequestException: If it cannot be downloaded
+        RegistryError: If its SHA-256 does not match
+    """
+    data = _get(source, artifact.url, timeout=ARCHIVE_TIMEOUT)
+    digest = sha256_bytes(data)
+    if digest != artifact.sha256:
+        raise RegistryError(
+            f"{artifact.url} does not match its sha256 in the index "
+            f"(expected {artifact.sha256[:12]}, got {digest[:12]})"
+        )
+    return data
+
+
+def extract_skill_archive(
+    data: bytes, dest: Path, keep: Callable[[str], bool] = lambda path: True
+) -> int:
+    """Extract the regular files of a skill archive into *dest*.
+
+    A single top-level folder wrapping every file is dropped. Links, devices
+    and members whose paths could leave *dest* are skipped, as are files
+    *keep* rejects.
+
+    Returns:
+        The number of files extracted
+
+    Raises:
+        RegistryError: If *data* is not a tar.gz archive
+    """
+    try:
+        tar = tarfile.open(fileobj=io.BytesIO(data), mode="r:gz")
+    except tarfile.TarError as e:
+        raise RegistryError(f"Not a tar.gz archive: {e}") from e
+    with tar:
+        members = []
+        for member in tar:
+            path = _safe_path(member.name)
+            if member.isfile() and path is not None:
+                members.append((path.split("/"), member))
+        tops = {parts[0] for parts, _ in members}
+        strip = len(tops) == 1 and all(len(parts) > 1 for parts, _ in members)
+        count = 0
+        for parts, member in members:
+            path = "/".join(parts[1:] if strip else parts)
+            if not keep(path):
+                continue
+            target = dest / path
+            target.parent.mkdir(parents=True, exist_ok=True)
+            with tar.extractfile(member) as source:
+                target.write_bytes(source.read())
+            count += 1
+    return count
+
+
+# ---------------------------------------------------------------------------
+# Publishing
+# ---------------------------------------------------------------------------
+
+
+def _pack(files: list[tuple[str, Path]]) -> bytes:
+    """A reproducible tar.gz of (archive path, file) pairs."""
+    buffer = io.BytesIO()
+    with gzip.GzipFile(fileobj=buffer, mode="wb", mtime=0) as gz:
+        with tarfile.open(fileobj=gz, mode="w", format=tarfile.PAX_FORMAT) as tar:
+            for name, path in sorted(files):
+                info = tarfile.TarInfo(name)
+                data = path.read_bytes()
+                info.size = len(data)
+                info.mode = 0o755 if path.stat().st_mode & 0o111 else 0o644
+                tar.addfile(info, io.BytesIO(data))
+    return buffer.getvalue()
+
+
+def _metadata(skill_md: Path) -> dict[str, Any]:
+    from claude_mpm.services.agents.playground import split_frontmatter
+
+    frontmatter, _ = split_frontmatter(skill_md.read_text(encoding="utf-8"))
+    try:
+        meta = yaml.safe_load(frontmatter.strip().strip("-")) or {}
+    except yaml.YAMLError:
+        return {}
+    return meta if isinstance(meta, dict) else {}
+
+
+def build_registry(root: Path, output: Path) -> RegistryIndex:
+    """Pack the skill collection at *root* into a registry in *output*.
+
+    Each directory holding a SKILL.md becomes one archive; the collection's
+    other files (including a signed manifest) are copied as they are. Files
+    are chosen as a sync would choose them, so a registry synced from
+    *output* holds the same files as a sync of the collection.
+
+    Returns:
+        The index written to ``output/index.json``
+
+    Raises:
+        RegistryError: If *root* holds no skills
+    """
+    # The manager imports this module, so its helpers are imported late
+    from claude_mpm.services.skills.git_skill_source_manager import (
+        _is_relevant_file,
+        is_hidden_path,
+    )
+
+    root, output = Path(root), Path(output)
+    files = sorted(
+        path.relative_to(root).as_posix()
+        for path in root.rglob("*")
+        if path.is_file()
+        and not is_hidden_path(path.relative_to(root))
+        and _is_relevant_file(path.relative_to(root).as_posix())
+        and output not in path.parents
+    )
+    skill_dirs = sorted(
+        {str(PurePosixPath(f).parent) for f in files if f.endswith("/SKILL.md")}
+    )
+    if "SKILL.md" in files:
+        raise RegistryError(f"{root} is a single skill; pack the folder above it")
+    if not skill_dirs:
+        raise RegistryError(f"No SKILL.md files found in {root}")
+
+    output.mkdir(parents=True, exist_ok=True)
+    skills, rest = [], list(files)
+    for skill_dir in skill_dirs:
+        prefix = f"{skill_dir}/"
+        members = [f for f in files if f.startswith(prefix)]
+        rest = [f for f in rest if not f.startswith(prefix)]
+        meta = _metadata(root / skill_dir / "SKILL.md")
+        version = meta.get("version")
+        name = str(meta.get("name") or PurePosixPath(skill_dir).name)
+        archive = skill_dir.replace("/", "-")
+        archive += f"-{version}.tar.gz" if version is not None else ".tar.gz"
+        data = _pack([(f[len(prefix) :], root / f) for f in members])
+        (output / archive).write_bytes(data)
+        entry = {"name": name, "path": skill_dir}
+        if version is not None:
+            entry["version"] = str(version)
+        for key in ("description", "tags"):
+            if meta.get(key):
+                entry[key] = meta[key]
+        skills.append({**entry, "url": archive, "sha256": sha256_bytes(data)})
+
+    extra = []
+    for path in rest:
+        target = output / path
+        target.parent.mkdir(parents=True, exist_ok=True)
+        data = (root / path).read_bytes()
+        target.write_bytes(data)
+        extra.append({"path": path, "url": path, "sha256": sha256_bytes(data)})
+
+    data = {"format": INDEX_FORMAT, "skills": skills}
+    if extra:
+        data["files"] = extra
+    content = json.dumps(data, indent=2) + "\n"
+    (output / INDEX_FILE).write_text(content, encoding="utf-8")
+    index_path = (output / INDEX_FILE).resolve()
+    return parse_index(content.encode(), index_path.as_uri())
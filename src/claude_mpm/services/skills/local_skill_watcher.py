@@ -0,0 +1,157 @@
+"""Hot-sync a local skill source into the deployed skills directory.
+
+WHAT: ``LocalSkillWatcher`` watches the directory of a ``type: local`` skill
+source and, after each burst of changes, syncs it into the source cache and
+redeploys its skills into ``~/.claude/skills``, removing skills that were
+deleted from the directory.
+
+WHY: Developing a skill meant copying it by hand after every edit, or
+committing to a throwaway git repository to sync it as a source.
+
+DESIGN DECISIONS:
+- Changes are debounced: an editor saving a file writes several events, and
+  a sync runs once the directory has been quiet for ``debounce`` seconds
+- Event handling (``notify``) and syncing (``poll``) are separate from the
+  watchdog observer, so the watcher can be driven without one
+- Only the skills this source wins priority resolution for are deployed;
+  skills from other sources are never touched
+"""
+
+from __future__ import annotations
+
+import shutil
+import threading
+import time
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.config.skill_sources import SkillSource
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.services.skills.git_skill_source_manager import (
+    GitSkillSourceManager,
+    _is_relevant_file,
+    is_hidden_path,
+)
+
+logger = get_logger(__name__)
+
+DEFAULT_DEBOUNCE = 0.5
+
+
+class LocalSkillWatcher:
+    """Keeps the deployed skills of one local source in step with its directory."""
+
+    def __init__(
+        self,
+        manager: GitSkillSourceManager,
+        source: SkillSource,
+        target_dir: Path | None = None,
+        debounce: float = DEFAULT_DEBOUNCE,
+    ) -> None:
+        if not source.is_local:
+            raise ValueError(f"{source.id} is not a local skill source")
+        self.manager = manager
+        self.source = source
+        self.target_dir = target_dir or Path.home() / ".claude" / "skills"
+        self.debounce = debounce
+        self._deployed: set[str] = set()
+        self._changed_at: float | None = None
+        self._lock = threading.Lock()
+
+    @property
+    def root(self) -> Path:
+        return self.source.local_path
+
+    def sync(self) -> dict[str, Any]:
+        """Sync the directory and redeploy its skills.
+
+        Returns:
+            Dict with "deployed", "skipped", "errors" and "removed" lists
+
+        Raises:
+            RuntimeError: If the directory cannot be synced
+        """
+        synced = self.manager.sync_source(self.source.id, force=True)
+        if not synced.get("synced"):
+            raise RuntimeError(synced.get("error", "sync failed"))
+
+        result = self.manager.deploy_source(self.source.id, self.target_dir)
+        current = set(result["skills"])
+        removed = sorted(self._deployed - current)
+        for name in removed:
+            shutil.rmtree(self.target_dir / name, ignore_errors=True)
+        self._deployed = current
+        result["removed"] = removed
+
+        logger.info(
+            f"Synced {self.source.id}: {len(result['deployed'])} deployed, "
+            f"{len(removed)} removed"
+        )
+        return result
+
+    def notify(self, path: str | Path, now: float | None = None) -> bool:
+        """Record a change to *path*; returns whether it triggers a sync."""
+        try:
+            relative = Path(path).resolve().relative_to(self.root.resolve())
+        except ValueError:
+            return False
+        if is_hidden_path(relative) or not _is_relevant_file(relative.as_posix()):
+            return False
+        with self._lock:
+            self._changed_at = time.monotonic() if now is None else now
+        return True
+
+    def poll(self, now: float | None = None) -> dict[str, Any] | None:
+        """Sync if changes have settled for the debounce period."""
+        now = time.monotonic() if now is None else now
+        with self._lock:
+            if self._changed_at is None or now - self._changed_at < self.debounce:
+                return None
+            self._changed_at = None
+        return self.sync()
+
+    def run(self, on_sync=None, interval: float = 0.1) -> None:
+        """Sync once, then watch the directory until interrupted.
+
+        Args:
+            on_sync: Optional callback(result) called after each sync, with
+                an "error" key instead when the sync failed
+            interval: Seconds between debounce checks
+        """
+        from watchdog.events import FileSystemEventHandler
+        from watchdog.observers import Observer
+
+        watcher = self
+
+        class _Handler(FileSystemEventHandler):
+            def on_any_event(self, event):
+                if event.is_directory:
+                    return
+                watcher.notify(event.src_path)
+                dest = getattr(event, "dest_path", None)
+                if dest:
+                    watcher.notify(dest)
+
+        def report(result):
+            if on_sync is not None:
+                on_sync(result)
+
+        report(self.sync())
+        observer = Observer()
+        observer.schedule(_Handler(), str(self.root), recursive=True)
+        observer.start()
+        try:
+            while True:
+                time.sleep(interval)
+                try:
+                    result = self.poll()
+                except RuntimeError as e:
+                    report({"error": str(e)})
+                    continue
+                if result is not None:
+                    report(result)
+        except KeyboardInterrupt:
+            pass
+        finally:
+            observer.stop()
+            observer.join()
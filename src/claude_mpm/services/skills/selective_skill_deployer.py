@@ -14,6 +14,12 @@ DESIGN DECISIONS:
 - Return set of unique skill names for filtering
 - Track deployed skills in .mpm-deployed-skills.json index
 - Remove orphaned skills (deployed by mpm but no longer referenced)
+- Skills can also be selected by name or glob (select_skills), so a skill
+  no agent references yet can be deployed; such skills are recorded as
+  user-requested and kept by orphan cleanup
+- Skills can be selected by tag (select_skills_by_tag): frontmatter tags,
+  plus the folders a skill sits in within its source, which deployment
+  otherwise flattens away
 
 FORMATS SUPPORTED:
 1. Legacy: skills: [skill-a, skill-b, ...]
@@ -915,6 +921,99 @@ def get_skills_to_deploy(config_path: Path) -> tuple[list[str], str]:
         return ([], "agent_referenced")
 
 
+def skill_deploy_name(skill: dict[str, Any]) -> str:
+    """Directory name a manifest skill deploys to.
+
+    The normalized source path ("universal/web/api-design/SKILL.md" deploys
+    as "universal-web-api-design"), or the skill's name without one.
+    """
+    source_path = skill.get("source_path", "")
+    if source_path:
+        return source_path.replace("/SKILL.md", "").replace("/", "-")
+    return skill.get("name", "")
+
+
+def _is_glob(selector: str) -> bool:
+    return any(c in selector for c in "*?[")
+
+
+def skill_matches(skill: dict[str, Any], selectors: set[str] | list[str]) -> bool:
+    """Whether a manifest skill is named by one of *selectors*.
+
+    A selector matches the skill's name, skill_id or deployed name exactly,
+    or as a glob ("toolchains-python-*").
+    """
+    return any(_selector_matches(skill, selector) for selector in selectors)
+
+
+def _selector_matches(skill: dict[str, Any], selector: str) -> bool:
+    from fnmatch import fnmatchcase
+
+    names = {skill.get("name"), skill.get("skill_id"), skill_deploy_name(skill)}
+    names.discard(None)
+    names.discard("")
+    if _is_glob(selector):
+        return any(fnmatchcase(name, selector) for name in names)
+    return selector in names
+
+
+def select_skills(
+    skills: list[dict[str, Any]], selectors: list[str]
+) -> tuple[list[dict[str, Any]], list[str]]:
+    """Pick the skills named by *selectors* (names or globs).
+
+    Returns:
+        The selected skills in manifest order, and the selectors that
+        matched no skill
+
+    Example:
+        >>> selected, unmatched = select_skills(skills, ["tdd", "*-pytest"])
+    """
+    selected = [
+        skill
+        for skill in skills
+        if isinstance(skill, dict) and skill_matches(skill, selectors)
+    ]
+    unmatched = [
+        selector
+        for selector in selectors
+        if not any(_selector_matches(skill, selector) for skill in selected)
+    ]
+    return selected, unmatched
+
+
+def skill_tags(skill: dict[str, Any]) -> set[str]:
+    """Lowercased tags of a discovered skill.
+
+    Frontmatter tags, plus the folders the skill sits in within its source:
+    "toolchains/golang/testing/SKILL.md" is also tagged "toolchains" and
+    "golang".
+    """
+    tags = skill.get("tags") or []
+    if isinstance(tags, str):
+        tags = [tags]
+    found = {str(tag).strip().lower() for tag in tags if str(tag).strip()}
+    relative_path = skill.get("relative_path") or ""
+    found.update(part.lower() for part in Path(relative_path).parts[:-2])
+    return found
+
+
+def select_skills_by_tag(
+    skills: list[dict[str, Any]], tags: list[str]
+) -> list[dict[str, Any]]:
+    """Pick the skills carrying any of *tags* (case-insensitive).
+
+    Example:
+        >>> select_skills_by_tag(skills, ["security", "golang"])
+    """
+    wanted = {tag.strip().lower() for tag in tags}
+    return [
+        skill
+        for skill in skills
+        if isinstance(skill, dict) and skill_tags(skill) & wanted
+    ]
+
+
 # === User-Requested Skills Management ===
 
 
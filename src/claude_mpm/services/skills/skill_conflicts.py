@@ -0,0 +1,304 @@
+"""Detect and resolve skills that several sources provide under one name.
+
+WHAT: ``resolve_conflicts`` takes the skills discovered in every enabled
+source and decides which copy is deployed under each name. Two kinds of
+conflict are found:
+
+- ``name``: two sources have a skill with the same skill ID
+- ``directory``: skills with different IDs would deploy to the same
+  directory (the flattened path, e.g. ``toolchains-python-pytest``)
+
+The winner is the copy from the source named in ``precedence`` for that
+skill, else the source with the lowest priority, else the source listed
+first. Every conflict is kept as a ``SkillConflict`` so ``skills list`` and
+``skills deploy`` can show what was shadowed.
+
+CONFIGURATION (.claude-mpm/configuration.yaml)::
+
+    skills:
+      conflicts:
+        precedence:                   # skill -> source whose copy wins
+          code-review: team-b
+        aliases:                      # deploy a shadowed copy under a new name
+          team-a:code-review: team-a-code-review
+
+A qualified name ``<source>:<skill>`` picks one source's copy wherever
+skills are named for deployment from sources (``skills deploy --skill``,
+agent-referenced skill filters), without changing configuration.
+
+WHY: With two sources providing a skill of the same name, deploy silently
+kept whichever won the priority sort, and skills from different sources
+that flattened to the same directory overwrote each other in the order
+they happened to deploy.
+
+DESIGN DECISIONS:
+- Resolution never fails a deploy; a conflict is reported, not an error
+- An alias deploys the shadowed copy next to the winner rather than
+  replacing it; precedence is the way to replace
+- Directory conflicts are only checked across sources; collisions inside
+  one source are reported by skill discovery
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field, fields
+from typing import Any
+
+from claude_mpm.core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "skills.conflicts"
+SEPARATOR = ":"
+
+NAME = "name"
+DIRECTORY = "directory"
+
+PRECEDENCE = "precedence"
+PRIORITY = "priority"
+ORDER = "order"
+
+
+def skill_key(skill: dict[str, Any]) -> str:
+    """The ID a skill is grouped by: its skill_id, else its name."""
+    return str(skill.get("skill_id", skill.get("name", "unknown")))
+
+
+def qualified_name(skill: dict[str, Any]) -> str:
+    """``<source>:<skill>`` for a discovered skill."""
+    return f"{skill.get('source_id')}{SEPARATOR}{skill_key(skill)}"
+
+
+def split_qualified(name: str) -> tuple[str | None, str]:
+    """The source and skill of a possibly qualified name."""
+    source_id, sep, skill = name.partition(SEPARATOR)
+    if not sep or not source_id or not skill:
+        return None, name
+    return source_id, skill
+
+
+@dataclass
+class ConflictConfig:
+    """Which source wins a conflict, and aliases for shadowed copies."""
+
+    precedence: dict[str, str] = field(default_factory=dict)
+    aliases: dict[str, str] = field(default_factory=dict)
+
+    @classmethod
+    def load(cls, config: Any = None) -> ConflictConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from claude_mpm.core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        known = {f.name for f in fields(cls)}
+        return cls(
+            **{
+                k: {str(n): str(v) for n, v in section[k].items()}
+                for k in known
+                if isinstance(section.get(k), dict)
+            }
+        )
+
+
+@dataclass
+class SkillConflict:
+    """Skills from several sources competing for one name."""
+
+    name: str  # the skill ID, or the directory for a directory conflict
+    kind: str  # NAME or DIRECTORY
+    winner: str  # qualified name of the copy deployed under the name
+    shadowed: list[str]  # qualified names of the other copies
+    resolved_by: str  # PRECEDENCE, PRIORITY or ORDER (equal priorities)
+    aliases: dict[str, str] = field(default_factory=dict)  # shadowed -> alias
+
+    def describe(self) -> str:
+        what = "directory " if self.kind == DIRECTORY else ""
+        how = {
+            PRECEDENCE: "by precedence",
+            PRIORITY: "by source priority",
+            ORDER: "first listed, equal priority",
+        }[self.resolved_by]
+        shadowed = ", ".join(
+            f"{q} (as {self.aliases[q]})" if q in self.aliases else q
+            for q in self.shadowed
+        )
+        return f"{what}{self.name}: {self.winner} wins ({how}); shadows {shadowed}"
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.name,
+            "kind": self.kind,
+            "winner": self.winner,
+            "shadowed": list(self.shadowed),
+            "resolved_by": self.resolved_by,
+            "aliases": dict(self.aliases),
+        }
+
+
+def _pick(
+    name: str, group: list[dict[str, Any]], config: ConflictConfig
+) -> tuple[dict[str, Any], str]:
+    """The winning skill of *group* and how it was chosen."""
+    preferred = config.precedence.get(name)
+    for skill in group:
+        if preferred and skill.get("source_id") == preferred:
+            return skill, PRECEDENCE
+    if preferred:
+        logger.warning(
+            f"Precedence for skill '{name}' names source '{preferred}', "
+            "which does not provide it"
+        )
+    ranked = sorted(group, key=lambda s: s.get("source_priority", 999))
+    first, second = ranked[0], ranked[1]
+    tied = first.get("source_priority", 999) == second.get("source_priority", 999)
+    return first, ORDER if tied else PRIORITY
+
+
+def _group(skills: list[dict[str, Any]], key) -> dict[str, list[dict[str, Any]]]:
+    groups: dict[str, list[dict[str, Any]]] = {}
+    for skill in skills:
+        groups.setdefault(key(skill), []).append(skill)
+    return groups
+
+
+def resolve_conflicts(
+    skills: list[dict[str, Any]], config: ConflictConfig | None = None
+) -> tuple[list[dict[str, Any]], list[SkillConflict]]:
+    """Pick one skill per name and directory.
+
+    Args:
+        skills: Skills from every source, in source order, each tagged with
+            ``source_id`` and ``source_priority``
+        config: Precedence and aliases (none by default)
+
+    Returns:
+        The skills to deploy, in the order first discovered, and the conflicts found.
+        An aliased copy is included with ``deployment_name`` and
+        ``skill_id`` set to its alias and ``alias_of`` to its qualified name
+    """
+    config = config or ConflictConfig()
+    conflicts: list[SkillConflict] = []
+    resolved: list[dict[str, Any]] = []
+
+    for name, group in _group(skills, skill_key).items():
+        if len(group) == 1:
+            resolved.append(group[0])
+            continue
+        winner, how = _pick(name, group, config)
+        resolved.append(winner)
+        conflicts.append(
+            SkillConflict(
+                name=name,
+                kind=NAME,
+                winner=qualified_name(winner),
+                shadowed=[qualified_name(s) for s in group if s is not winner],
+                resolved_by=how,
+            )
+        )
+
+    # Aliased copies deploy under their own name, shadowed or not
+    by_qualified = {qualified_name(s): s for s in skills}
+    for qualified, alias in config.aliases.items():
+        skill = by_qualified.get(qualified)
+        if skill is None:
+            logger.warning(f"Skill alias '{alias}' names unknown skill {qualified}")
+            continue
+        resolved.append(
+            {
+                **skill,
+                "skill_id": alias,
+                "deployment_name": alias,
+                "alias_of": qualified,
+            }
+        )
+        for conflict in conflicts:
+            if qualified in conflict.shadowed:
+                conflict.aliases[qualified] = alias
+
+    # Different skills from different sources flattening to one directory
+    directories = _group(
+        [s for s in resolved if s.get("deployment_name")],
+        lambda s: str(s["deployment_name"]).lower(),
+    )
+    losers: set[int] = set()
+    for directory, group in directories.items():
+        if len(group) == 1 or len({s.get("source_id") for s in group}) == 1:
+            continue
+        winner, how = _pick(directory, group, config)
+        losers.update(id(s) for s in group if s is not winner)
+        conflicts.append(
+            SkillConflict(
+                name=directory,
+                kind=DIRECTORY,
+                winner=qualified_name(winner),
+                shadowed=[qualified_name(s) for s in group if s is not winner],
+                resolved_by=how,
+            )
+        )
+    kept = [s for s in resolved if id(s) not in losers]
+
+    for conflict in conflicts:
+        # Only a tie is left to chance; the rest were configured
+        log = logger.warning if conflict.resolved_by == ORDER else logger.info
+        log(f"Skill conflict: {conflict.describe()}")
+    return kept, conflicts
+
+
+def pick_sources(
+    catalog: list[dict[str, Any]],
+    discovered: list[dict[str, Any]],
+    names,
+    field_name: str = "name",
+) -> tuple[list[dict[str, Any]], list[str]]:
+    """Honour ``<source>:<skill>`` names in a deployment selection.
+
+    Each qualified name swaps the named source's copy into *catalog* in
+    place of the copy that won its name or directory.
+
+    Args:
+        catalog: The resolved skills
+        discovered: Every skill discovered, before resolution
+        names: The names selected for deployment
+        field_name: The skill field the caller matches plain names against
+
+    Returns:
+        The catalog with the picked copies, and *names* with each qualified
+        name replaced by the picked copy's *field_name*
+    """
+    plain: list[str] = []
+    for name in names:
+        source_id, skill_name = split_qualified(name)
+        if source_id is None:
+            plain.append(name)
+            continue
+        pick = next(
+            (
+                s
+                for s in discovered
+                if s.get("source_id") == source_id
+                and skill_name
+                in (s.get("skill_id"), s.get("name"), s.get("deployment_name"))
+            ),
+            None,
+        )
+        if pick is None:
+            logger.warning(f"No skill '{skill_name}' in source '{source_id}'")
+            continue
+        replaced = [
+            s
+            for s in catalog
+            if skill_key(s) == skill_key(pick)
+            or (
+                s.get("deployment_name")
+                and s.get("deployment_name") == pick.get("deployment_name")
+            )
+        ]
+        catalog = [s for s in catalog if not any(s is r for r in replaced)]
+        catalog.append(pick)
+        plain.append(str(pick.get(field_name, skill_name)))
+    return catalog, plain
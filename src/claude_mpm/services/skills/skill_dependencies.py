@@ -0,0 +1,126 @@
+"""Dependency resolution for skills that require other skills.
+
+WHAT: A skill's frontmatter may list the skills it builds on::
+
+    ---
+    name: release-checklist
+    description: Steps for cutting a release
+    requires: [changelog-writing, semver]
+    ---
+
+``resolve_dependencies`` expands a selection of skills to its dependency
+closure, in dependency order, and reports the skills that cannot be deployed
+because a requirement is missing or part of a cycle.
+
+WHY: Composed skills reference helper skills that had to be deployed by hand,
+in the right order, and a missing helper went unnoticed until an agent tried
+to use it.
+
+DESIGN DECISIONS:
+- Requirements are matched against a skill's name, skill_id or deployment
+  name, case-insensitively, over every skill the sources provide (after
+  priority resolution), not just the selection
+- A skill that cannot be resolved is reported with the reason instead of
+  raising, so one broken skill does not stop the rest from deploying;
+  skills that require it are reported too
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from typing import Any
+
+Skill = dict[str, Any]
+
+
+@dataclass
+class DependencyResolution:
+    """A selection expanded with its dependencies."""
+
+    # Skills to deploy, each after the skills it requires
+    skills: list[Skill] = field(default_factory=list)
+    # Names of skills pulled in only as dependencies
+    added: list[str] = field(default_factory=list)
+    # Skill name -> why it cannot be deployed
+    errors: dict[str, str] = field(default_factory=dict)
+
+
+def skill_name(skill: Skill) -> str:
+    return str(skill.get("name", "unknown"))
+
+
+def _keys(skill: Skill) -> set[str]:
+    keys = (skill.get("name"), skill.get("skill_id"), skill.get("deployment_name"))
+    return {str(key).lower() for key in keys if key}
+
+
+def resolve_dependencies(
+    selected: list[Skill], catalog: list[Skill]
+) -> DependencyResolution:
+    """Expand *selected* with the skills they require from *catalog*.
+
+    Args:
+        selected: Skills chosen for deployment
+        catalog: Every skill available to satisfy requirements
+
+    Returns:
+        The skills to deploy in dependency order, the names added as
+        dependencies, and the skills that cannot be deployed with why
+    """
+    index: dict[str, Skill] = {}
+    for skill in [*selected, *catalog]:
+        for key in _keys(skill):
+            index.setdefault(key, skill)
+
+    resolution = DependencyResolution()
+    selected_ids = {id(skill) for skill in selected}
+    state: dict[int, str] = {}  # id -> visiting | done | failed
+    path: list[Skill] = []
+
+    def fail(skill: Skill, reason: str) -> bool:
+        state[id(skill)] = "failed"
+        resolution.errors.setdefault(skill_name(skill), reason)
+        return False
+
+    def visit(skill: Skill) -> bool:
+        status = state.get(id(skill))
+        if status == "visiting":
+            start = next(i for i, s in enumerate(path) if s is skill)
+            cycle = [*path[start:], skill]
+            chain = " → ".join(skill_name(s) for s in cycle)
+            for member in cycle:
+                resolution.errors.setdefault(
+                    skill_name(member), f"dependency cycle: {chain}"
+                )
+            return False
+        if status is not None:
+            return status == "done"
+
+        state[id(skill)] = "visiting"
+        path.append(skill)
+        try:
+            for required in skill.get("requires") or []:
+                dependency = index.get(str(required).lower())
+                if dependency is None:
+                    return fail(
+                        skill,
+                        f"requires '{required}', which no skill source provides",
+                    )
+                if not visit(dependency):
+                    return fail(
+                        skill,
+                        f"requires '{required}', which cannot be deployed: "
+                        f"{resolution.errors.get(skill_name(dependency), '')}",
+                    )
+        finally:
+            path.pop()
+
+        state[id(skill)] = "done"
+        resolution.skills.append(skill)
+        if id(skill) not in selected_ids:
+            resolution.added.append(skill_name(skill))
+        return True
+
+    for skill in selected:
+        visit(skill)
+    return resolution
@@ -262,6 +262,7 @@ class SkillDiscoveryService:
             skill_version: 1.0.0
             tags: [tag1, tag2]
             agent_types: [engineer, qa]  # Optional
+            requires: [other-skill]      # Optional, deployed with it
             ---
 
             # Skill Content
@@ -336,6 +337,7 @@ class SkillDiscoveryService:
         skill_version = frontmatter.get("skill_version", "1.0.0")
         tags = frontmatter.get("tags", [])
         agent_types = frontmatter.get("agent_types", None)
+        requires = frontmatter.get("requires", [])
 
         # Ensure tags is a list
         if isinstance(tags, str):
@@ -343,6 +345,13 @@ class SkillDiscoveryService:
         elif not isinstance(tags, list):
             tags = []
 
+        # Ensure requires is a list of skill names
+        if isinstance(requires, str):
+            requires = [requires]
+        elif not isinstance(requires, list):
+            requires = []
+        requires = [str(name) for name in requires if name]
+
         # Ensure agent_types is a list (if present)
         if agent_types is not None:
             if isinstance(agent_types, str):
@@ -374,6 +383,9 @@ class SkillDiscoveryService:
         if resources:
             skill_dict["resources"] = [str(r) for r in resources]
 
+        if requires:
+            skill_dict["requires"] = requires
+
         return skill_dict
 
     def _extract_frontmatter(self, content: str) -> tuple[dict[str, Any], str]:
@@ -0,0 +1,328 @@
+"""Per-skill effectiveness scoring from Claude Code session transcripts.
+
+WHAT: Finds every ``Skill`` tool invocation in the session transcripts under
+~/.claude/projects/, correlates it with what happened in the rest of that turn
+(tool errors, cost) and in the next user prompt (retries, interruptions), and
+aggregates the outcomes into a 0-100 effectiveness score per skill.
+
+WHY: Skills accumulate faster than anyone reviews them. Some are invoked and
+then fought with; others are deployed and never used. ``claude-mpm skills
+stats`` surfaces both so the worst skills get rewritten or retired.
+
+SCORING:
+- An invocation *succeeds* when the skill loaded, the turn stayed under
+  ``ERROR_TOLERANCE`` tool errors and the user did not retry
+- An invocation is *retried* when the next prompt re-invokes the same skill,
+  interrupts the turn or reads like a correction ("didn't work", "try again")
+- score = 100 * (0.7 * success rate + 0.2 * (1 - retry rate)
+  + 0.1 * cost efficiency), where cost efficiency compares the skill's
+  average turn cost with the median across all skills
+
+References
+----------
+LINK: none
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+import re
+import statistics
+from collections.abc import Iterable, Iterator
+from dataclasses import dataclass, field
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.services.session_analysis.pricing import compute_cost
+
+logger = logging.getLogger(__name__)
+
+ERROR_TOLERANCE = 3
+MIN_INVOCATIONS = 3
+
+_RETRY_RE = re.compile(
+    r"\[request interrupted|didn'?t work|doesn'?t work|not working|still (broken"
+    r"|failing|wrong|not)|try again|that'?s wrong|that is wrong|wrong (file|answer"
+    r"|approach)|revert that",
+    re.IGNORECASE,
+)
+
+
+@dataclass
+class SkillInvocation:
+    """One ``Skill`` tool call and the outcome of the turn it ran in."""
+
+    skill: str
+    session_id: str
+    timestamp: datetime | None
+    loaded: bool = True
+    tool_errors: int = 0
+    cost_usd: float = 0.0
+    retried: bool = False
+
+    @property
+    def succeeded(self) -> bool:
+        return self.loaded and not self.retried and self.tool_errors < ERROR_TOLERANCE
+
+
+@dataclass
+class SkillStats:
+    """Aggregated outcomes for one skill."""
+
+    name: str
+    invocations: int = 0
+    successes: int = 0
+    retries: int = 0
+    total_cost_usd: float = 0.0
+    sessions: set[str] = field(default_factory=set)
+    last_used: datetime | None = None
+    deployed: bool = False
+    score: float | None = None
+
+    @property
+    def success_rate(self) -> float:
+        return self.successes / self.invocations if self.invocations else 0.0
+
+    @property
+    def retry_rate(self) -> float:
+        return self.retries / self.invocations if self.invocations else 0.0
+
+    @property
+    def avg_cost_usd(self) -> float:
+        return self.total_cost_usd / self.invocations if self.invocations else 0.0
+
+    @property
+    def recommendation(self) -> str:
+        if self.invocations == 0:
+            return "retire (unused)"
+        if self.invocations < MIN_INVOCATIONS or self.score is None:
+            return "needs more data"
+        if self.score >= 75:
+            return "keep"
+        if self.score >= 40:
+            return "rewrite"
+        return "retire"
+
+    def add(self, invocation: SkillInvocation) -> None:
+        self.invocations += 1
+        self.successes += invocation.succeeded
+        self.retries += invocation.retried
+        self.total_cost_usd += invocation.cost_usd
+        self.sessions.add(invocation.session_id)
+        if invocation.timestamp and (
+            self.last_used is None or invocation.timestamp > self.last_used
+        ):
+            self.last_used = invocation.timestamp
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.name,
+            "score": None if self.score is None else round(self.score, 1),
+            "recommendation": self.recommendation,
+            "invocations": self.invocations,
+            "sessions": len(self.sessions),
+            "success_rate": round(self.success_rate, 3),
+            "retry_rate": round(self.retry_rate, 3),
+            "avg_cost_usd": round(self.avg_cost_usd, 4),
+            "total_cost_usd": round(self.total_cost_usd, 4),
+            "last_used": self.last_used.isoformat() if self.last_used else None,
+            "deployed": self.deployed,
+        }
+
+
+# ---------------------------------------------------------------------------
+# Transcript scanning
+# ---------------------------------------------------------------------------
+
+
+def _timestamp(entry: dict[str, Any]) -> datetime | None:
+    raw = str(entry.get("timestamp", "")).replace("Z", "+00:00")
+    try:
+        ts = datetime.fromisoformat(raw)
+    except ValueError:
+        return None
+    return ts if ts.tzinfo else ts.replace(tzinfo=UTC)
+
+
+def _user_text(content: Any) -> str:
+    if isinstance(content, str):
+        return content
+    if isinstance(content, list):
+        return "\n".join(
+            b.get("text", "")
+            for b in content
+            if isinstance(b, dict) and b.get("type") == "text"
+        )
+    return ""
+
+
+def scan_session(lines: list[dict[str, Any]], session_id: str) -> list[SkillInvocation]:
+    """Extract skill invocations and their outcomes from one transcript."""
+    invocations: list[SkillInvocation] = []
+    turn: list[SkillInvocation] = []
+    previous_turn: list[SkillInvocation] = []
+    pending: dict[str, SkillInvocation] = {}
+    seen_messages: set[str] = set()
+
+    for entry in lines:
+        message = entry.get("message") or {}
+        content = message.get("content")
+        blocks = content if isinstance(content, list) else []
+
+        if entry.get("type") == "user":
+            results = [b for b in blocks if b.get("type") == "tool_result"]
+            for block in results:
+                skill_call = pending.pop(block.get("tool_use_id", ""), None)
+                if block.get("is_error"):
+                    if skill_call is not None:
+                        skill_call.loaded = False
+                    for invocation in turn:
+                        invocation.tool_errors += 1
+            text = _user_text(content).strip()
+            if text and not results:
+                # A new prompt closes the turn; it decides whether the
+                # skills used in the previous one were retried.
+                if _RETRY_RE.search(text):
+                    for invocation in turn:
+                        invocation.retried = True
+                previous_turn, turn = turn, []
+
+        elif entry.get("type") == "assistant":
+            message_id = message.get("id")
+            usage = message.get("usage")
+            if usage and message_id not in seen_messages:
+                if message_id:
+                    seen_messages.add(message_id)
+                cost = compute_cost(message.get("model") or "claude-sonnet", usage)
+                for invocation in turn:
+                    invocation.cost_usd += cost
+            for block in blocks:
+                if block.get("type") != "tool_use" or block.get("name") != "Skill":
+                    continue
+                name = (block.get("input") or {}).get("skill", "")
+                if not name:
+                    continue
+                for earlier in previous_turn:
+                    if earlier.skill == name:
+                        earlier.retried = True
+                invocation = SkillInvocation(
+                    skill=name, session_id=session_id, timestamp=_timestamp(entry)
+                )
+                invocations.append(invocation)
+                turn.append(invocation)
+                pending[block.get("id", "")] = invocation
+
+    return invocations
+
+
+def _read_jsonl(path: Path) -> list[dict[str, Any]]:
+    lines = []
+    with path.open("r", encoding="utf-8", errors="replace") as fh:
+        for raw in fh:
+            raw = raw.strip()
+            if not raw:
+                continue
+            try:
+                obj = json.loads(raw)
+            except json.JSONDecodeError:
+                continue
+            if isinstance(obj, dict):
+                lines.append(obj)
+    return lines
+
+
+# ---------------------------------------------------------------------------
+# Aggregation
+# ---------------------------------------------------------------------------
+
+
+def score_skills(stats: Iterable[SkillStats]) -> list[SkillStats]:
+    """Fill in each skill's score relative to the median turn cost."""
+    stats = list(stats)
+    costs = [s.avg_cost_usd for s in stats if s.invocations and s.avg_cost_usd > 0]
+    median_cost = statistics.median(costs) if costs else 0.0
+    for s in stats:
+        if not s.invocations:
+            s.score = None
+            continue
+        efficiency = 1.0
+        if median_cost and s.avg_cost_usd > median_cost:
+            efficiency = median_cost / s.avg_cost_usd
+        s.score = 100 * (
+            0.7 * s.success_rate + 0.2 * (1 - s.retry_rate) + 0.1 * efficiency
+        )
+    return stats
+
+
+def deployed_skill_names(project_dir: Path, home: Path | None = None) -> set[str]:
+    """Names of skills deployed to the project or user ``.claude/skills``."""
+    names: set[str] = set()
+    roots = (project_dir, home or Path.home())
+    for root in (base / ".claude" / "skills" for base in roots):
+        if root.is_dir():
+            names.update(p.name for p in root.iterdir() if (p / "SKILL.md").is_file())
+    return names
+
+
+class SkillEffectivenessAnalyzer:
+    """Scores skills from the transcripts of one project or all projects."""
+
+    def __init__(
+        self,
+        project_dir: Path | None = None,
+        all_projects: bool = False,
+        days: int | None = None,
+        projects_root: Path | None = None,
+    ):
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.all_projects = all_projects
+        self.days = days
+        self.projects_root = projects_root or Path.home() / ".claude" / "projects"
+
+    def transcripts(self) -> Iterator[Path]:
+        if self.all_projects:
+            roots = [p for p in self.projects_root.glob("*") if p.is_dir()]
+        else:
+            from claude_mpm.services.session_analysis.transcript_parser import (
+                locate_transcript,
+            )
+
+            roots = [locate_transcript("-", str(self.project_dir)).parent]
+        cutoff = None
+        if self.days:
+            cutoff = (datetime.now(UTC) - timedelta(days=self.days)).timestamp()
+        for root in roots:
+            if not root.is_dir():
+                continue
+            for path in sorted(root.glob("*.jsonl")):
+                if cutoff is None or path.stat().st_mtime >= cutoff:
+                    yield path
+
+    def invocations(self) -> list[SkillInvocation]:
+        found: list[SkillInvocation] = []
+        for path in self.transcripts():
+            try:
+                found.extend(scan_session(_read_jsonl(path), path.stem))
+            except OSError as e:
+                logger.debug(f"Skipping unreadable transcript {path}: {e}")
+        return found
+
+    def stats(self, deployed: Iterable[str] | None = None) -> list[SkillStats]:
+        """Per-skill stats, including deployed skills that were never used."""
+        if deployed is None:
+            deployed = deployed_skill_names(self.project_dir)
+        deployed = set(deployed)
+        by_name: dict[str, SkillStats] = {
+            name: SkillStats(name=name, deployed=True) for name in deployed
+        }
+        for invocation in self.invocations():
+            stat = by_name.setdefault(
+                invocation.skill,
+                SkillStats(
+                    name=invocation.skill, deployed=invocation.skill in deployed
+                ),
+            )
+            stat.add(invocation)
+        return score_skills(by_name.values())
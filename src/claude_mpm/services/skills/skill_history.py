@@ -0,0 +1,356 @@
+"""Version history of deployed skills, and rolling a skill back.
+
+WHAT: Every time a skill is deployed with new content, that content is kept
+in ``~/.claude-mpm/cache/skill-history/<skill>/<id>/``, where the ID is a
+hash of the skill's files, and listed in the skill's ``index.json`` with the
+skill's version, its source and the commit the source was synced to::
+
+    {
+      "versions": [
+        {"id": "3f9c2e1a7b04", "version": "1.2.0", "source_id": "system",
+         "commit": "8d1e...", "recorded_at": "2026-10-17T09:30:00+00:00"}
+      ],
+      "holds": {"/home/me/project/.claude/skills/tdd": "3f9c2e1a7b04"}
+    }
+
+``claude-mpm skills rollback <name>`` restores the version deployed before
+the current one (``--to`` picks a version by ID, source commit or version
+number) and holds the skill there: deployments skip a held skill until
+``skills rollback <name> --release``.
+
+WHY: A bad upstream skill update is deployed to every project on the next
+sync, and getting the old version back meant digging through the source
+repository's history by hand.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    skills:
+      history:
+        keep: 5        # versions kept per skill (held versions always stay)
+
+DESIGN DECISIONS:
+- The history is in the user's cache, shared by every project, since the
+  skill cache it records is too; holds are per deployed directory
+- The first deployment over a skill that has no history keeps the copy
+  already deployed as well, so the first bad update can be rolled back
+- Recording never fails a deployment
+- A hold, not the source's skills.lock pin, stops updates: the pin moves
+  every skill of the source, a rollback is about one
+"""
+
+from __future__ import annotations
+
+import hashlib
+import shutil
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.core.state_files import read_json, state_lock, update_json
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "skills.history"
+DEFAULT_KEEP = 5
+INDEX_FILE = "index.json"
+ID_LENGTH = 12
+
+
+class SkillHistoryError(Exception):
+    """A skill cannot be rolled back as asked."""
+
+
+@dataclass(frozen=True)
+class SkillVersion:
+    """One recorded version of a skill."""
+
+    id: str
+    version: str | None
+    source_id: str | None
+    commit: str | None
+    recorded_at: str
+
+    def matches(self, ref: str) -> bool:
+        """Whether *ref* names this version: ID or commit prefix, or version."""
+        if ref == self.version:
+            return True
+        return len(ref) >= 4 and (
+            self.id.startswith(ref) or bool(self.commit and self.commit.startswith(ref))
+        )
+
+    def describe(self) -> str:
+        parts = [self.id]
+        if self.version:
+            parts.append(f"v{self.version}")
+        if self.source_id:
+            commit = f"@{self.commit[:7]}" if self.commit else ""
+            parts.append(f"from {self.source_id}{commit}")
+        parts.append(self.recorded_at[:16].replace("T", " "))
+        return "  ".join(parts)
+
+
+def content_id(skill_dir: Path) -> str:
+    """Hash of a skill directory's files and their paths."""
+    digest = hashlib.sha256()
+    for path in sorted(p for p in Path(skill_dir).rglob("*") if p.is_file()):
+        if "__pycache__" in path.parts:
+            continue
+        digest.update(path.relative_to(skill_dir).as_posix().encode() + b"\0")
+        digest.update(hashlib.sha256(path.read_bytes()).digest())
+    return digest.hexdigest()[:ID_LENGTH]
+
+
+def history_dir() -> Path:
+    return Path.home() / ".claude-mpm" / "cache" / "skill-history"
+
+
+def _keep(config: Any = None) -> int:
+    try:
+        if config is None:
+            from claude_mpm.core.config import Config
+
+            config = Config()
+        keep = (config.get(CONFIG_KEY, {}) or {}).get("keep", DEFAULT_KEEP)
+        return max(1, int(keep))
+    except Exception as e:
+        logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return DEFAULT_KEEP
+
+
+class SkillHistory:
+    """The recorded versions and holds of deployed skills."""
+
+    def __init__(self, root: Path | None = None, keep: int | None = None):
+        self.root = Path(root or history_dir())
+        self._keep = keep
+
+    @property
+    def keep(self) -> int:
+        if self._keep is None:
+            self._keep = _keep()
+        return self._keep
+
+    def _index(self, name: str) -> Path:
+        return self.root / name / INDEX_FILE
+
+    def _read(self, name: str) -> dict[str, Any]:
+        data = read_json(self._index(name), {})
+        return data if isinstance(data, dict) else {}
+
+    def versions(self, name: str) -> list[SkillVersion]:
+        """Recorded versions of *name*, oldest first."""
+        versions = []
+        for entry in self._read(name).get("versions") or []:
+            try:
+                versions.append(SkillVersion(**entry))
+            except TypeError:
+                logger.debug(f"Ignoring malformed history entry of {name}: {entry}")
+        return versions
+
+    def version_dir(self, name: str, version: SkillVersion) -> Path:
+        return self.root / name / version.id
+
+    def held(self, name: str, deployed_dir: Path) -> str | None:
+        """The version ID *deployed_dir* is held at, if any."""
+        holds = self._read(name).get("holds") or {}
+        return holds.get(str(Path(deployed_dir).absolute()))
+
+    def record(
+        self,
+        name: str,
+        skill_dir: Path,
+        source_id: str | None = None,
+        commit: str | None = None,
+    ) -> SkillVersion:
+        """Keep the content of *skill_dir* as a version of *name*.
+
+        Content that is already recorded is not copied again.
+        """
+        from .skill_updates import _version
+
+        version_id = content_id(skill_dir)
+        with state_lock(self._index(name)):
+            existing = {v.id: v for v in self.versions(name)}
+            if version_id in existing:
+                return existing[version_id]
+            target = self.root / name / version_id
+            shutil.rmtree(target, ignore_errors=True)
+            shutil.copytree(
+                skill_dir, target, ignore=shutil.ignore_patterns("__pycache__")
+            )
+            skill_md = target / "SKILL.md"
+            version = SkillVersion(
+                id=version_id,
+                version=(
+                    _version(skill_md.read_text(encoding="utf-8", errors="replace"))
+                    if skill_md.is_file()
+                    else None
+                ),
+                source_id=source_id,
+                commit=commit,
+                recorded_at=datetime.now(UTC).isoformat(),
+            )
+            pruned: list[str] = []
+
+            def append(data: dict[str, Any]) -> None:
+                versions = [*data.get("versions", []), asdict(version)]
+                held = set((data.get("holds") or {}).values())
+                while len(versions) > self.keep:
+                    oldest = next(
+                        (v for v in versions if v["id"] not in held), None
+                    )
+                    if oldest is None:
+                        break
+                    versions.remove(oldest)
+                    pruned.append(oldest["id"])
+                data["versions"] = versions
+
+            update_json(self._index(name), append)
+        for old in pruned:
+            shutil.rmtree(self.root / name / old, ignore_errors=True)
+        return version
+
+    def record_deployment(
+        self,
+        name: str,
+        source_dir: Path,
+        deployed_dir: Path,
+        source_id: str | None = None,
+        commit: str | None = None,
+    ) -> None:
+        """Record a skill about to be deployed from *source_dir*; never raises.
+
+        A skill deployed before it had a history keeps its deployed copy too,
+        as the version to roll back to.
+        """
+        try:
+            if deployed_dir.is_dir() and not self.versions(name):
+                self.record(name, deployed_dir)
+            self.record(name, source_dir, source_id, commit)
+        except Exception as e:
+            logger.debug(f"Could not record history of skill {name}: {e}")
+
+    def set_hold(self, name: str, deployed_dir: Path, version_id: str | None) -> None:
+        key = str(Path(deployed_dir).absolute())
+
+        def hold(data: dict[str, Any]) -> None:
+            holds = data.setdefault("holds", {})
+            if version_id is None:
+                holds.pop(key, None)
+            else:
+                holds[key] = version_id
+
+        update_json(self._index(name), hold)
+
+
+# ---------------------------------------------------------------------------
+# Rollback
+# ---------------------------------------------------------------------------
+
+
+def find_deployed_skill(
+    name: str, project_dir: Path | None = None, home: Path | None = None
+) -> Path | None:
+    """The deployed directory of skill *name*; project deployments first."""
+    project_dir = Path(project_dir or Path.cwd())
+    home = Path(home or Path.home())
+    for skills_dir in (
+        project_dir / ".claude" / "skills",
+        project_dir / ".claude-mpm" / "skills",
+        home / ".claude" / "skills",
+    ):
+        if (skills_dir / name / "SKILL.md").is_file():
+            return skills_dir / name
+    return None
+
+
+@dataclass
+class Rollback:
+    """A skill restored to a recorded version."""
+
+    name: str
+    deployed: Path
+    version: SkillVersion
+    previous: str  # content ID of the copy that was replaced
+
+
+def rollback_skill(
+    name: str,
+    to: str | None = None,
+    history: SkillHistory | None = None,
+    project_dir: Path | None = None,
+    home: Path | None = None,
+) -> Rollback:
+    """Restore deployed skill *name* to a recorded version and hold it there.
+
+    Without *to*, the version recorded before the deployed one is restored.
+
+    Raises:
+        SkillHistoryError: The skill is not deployed, has no such version, or
+            its deployed copy is not a recorded version and *to* is not given
+    """
+    from claude_mpm.services.deployment_delta import sync_directory
+    from claude_mpm.services.deployment_integrity import record_directory
+
+    history = history or SkillHistory()
+    deployed = find_deployed_skill(name, project_dir, home)
+    if deployed is None:
+        raise SkillHistoryError(f"Skill '{name}' is not deployed")
+    versions = history.versions(name)
+    if not versions:
+        raise SkillHistoryError(
+            f"No versions of '{name}' are recorded; history starts with the "
+            "next deployment that changes it"
+        )
+
+    current = content_id(deployed)
+    if to:
+        matches = [v for v in versions if v.matches(to)]
+        if not matches:
+            raise SkillHistoryError(f"No recorded version of '{name}' matches {to}")
+        if len({v.id for v in matches}) > 1 and to not in [v.version for v in matches]:
+            ids = ", ".join(v.id for v in matches)
+            raise SkillHistoryError(f"{to} matches several versions: {ids}")
+        target = matches[-1]
+    else:
+        ids = [v.id for v in versions]
+        if current not in ids:
+            raise SkillHistoryError(
+                f"The deployed '{name}' is not a recorded version (edited "
+                "locally?); choose one with --to"
+            )
+        index = ids.index(current)
+        if index == 0:
+            raise SkillHistoryError(f"No version of '{name}' before {current}")
+        target = versions[index - 1]
+
+    source = history.version_dir(name, target)
+    if not source.is_dir():
+        raise SkillHistoryError(f"The files of version {target.id} are missing")
+    sync_directory(source, deployed)
+    record_directory(deployed, source)
+    history.set_hold(name, deployed, target.id)
+    return Rollback(name, deployed, target, current)
+
+
+def release_skill(
+    name: str,
+    history: SkillHistory | None = None,
+    project_dir: Path | None = None,
+    home: Path | None = None,
+) -> Path:
+    """Let deployments update a rolled-back skill again.
+
+    Raises:
+        SkillHistoryError: The skill is not deployed or not held
+    """
+    history = history or SkillHistory()
+    deployed = find_deployed_skill(name, project_dir, home)
+    if deployed is None:
+        raise SkillHistoryError(f"Skill '{name}' is not deployed")
+    if history.held(name, deployed) is None:
+        raise SkillHistoryError(f"'{name}' is not held at a rolled-back version")
+    history.set_hold(name, deployed, None)
+    return deployed
@@ -0,0 +1,513 @@
+"""Lint skill directories before they are published or deployed.
+
+WHAT: ``lint_skill`` checks one skill directory the way deployment reads it:
+
+- frontmatter: present and parseable, with a usable ``name`` and
+  ``description`` and list-valued ``tags``, ``requires`` and ``agent_types``
+- sections: a title heading and the required sections ("When to Use" by
+  default, also satisfied by a ``when_to_use`` frontmatter field)
+- links: relative Markdown links and ``progressive_disclosure.references``
+  point at files inside the skill directory
+- budgets: SKILL.md stays within its line and token budgets
+
+With ``fix=True`` the mechanical problems are corrected in SKILL.md and the
+skill is linted again; the report lists what was fixed and what is left.
+
+WHY: Skill discovery skips a SKILL.md it cannot parse with nothing more than
+a log warning, so a malformed skill published to a source simply went
+missing at deploy time.
+
+DESIGN DECISIONS:
+- Frontmatter is parsed by the discovery service's own parser, so lint and
+  deploy agree on what is valid
+- Links that leave the skill directory are errors even when the file exists
+  in the source repository: only the skill directory is deployed
+- Fixes edit the affected lines instead of re-serialising the YAML, so
+  comments and key order survive
+- Size limits come from the SKILL.md format spec (200 lines) and a 5000
+  token budget; a skill's ``context_limit`` replaces the token budget
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Callable
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+from urllib.parse import unquote
+
+from ...core.enums import ValidationSeverity
+from .skill_discovery_service import SkillDiscoveryService
+
+MAX_LINES = 200
+MAX_TOKENS = 5000
+MAX_NAME_LENGTH = 64
+MAX_DESCRIPTION_LENGTH = 1024
+REQUIRED_SECTIONS = ("When to Use",)
+LIST_FIELDS = ("tags", "requires", "agent_types")
+
+ERROR = ValidationSeverity.ERROR
+WARNING = ValidationSeverity.WARNING
+
+_NAME_RE = re.compile(r"^[a-z0-9]+(?:-[a-z0-9]+)*$")
+_FRONTMATTER_RE = re.compile(r"^(---[ \t]*\n)(.*?)(\n---[ \t]*\n)", re.DOTALL)
+_LINK_RE = re.compile(r"!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+[\"'][^)]*)?\)")
+_INLINE_CODE_RE = re.compile(r"`[^`]*`")
+_FENCE_RE = re.compile(r"^\s*(```|~~~)")
+
+Fix = Callable[[str], str]
+
+
+@dataclass
+class LintIssue:
+    """One problem found in a skill."""
+
+    rule: str
+    severity: ValidationSeverity
+    message: str
+    path: str = "SKILL.md"  # Relative to the skill directory
+    line: int | None = None
+    # Rewrites SKILL.md's text to correct the issue, when that is mechanical
+    fix: Fix | None = field(default=None, repr=False, compare=False)
+
+    @property
+    def fixable(self) -> bool:
+        return self.fix is not None
+
+    def location(self) -> str:
+        return f"{self.path}:{self.line}" if self.line else self.path
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "rule": self.rule,
+            "severity": str(self.severity),
+            "message": self.message,
+            "path": self.path,
+            "line": self.line,
+            "fixable": self.fixable,
+        }
+
+
+@dataclass
+class LintReport:
+    """The issues found in one skill directory."""
+
+    skill_dir: Path
+    name: str
+    issues: list[LintIssue] = field(default_factory=list)
+    fixed: list[LintIssue] = field(default_factory=list)
+
+    @property
+    def errors(self) -> list[LintIssue]:
+        return [issue for issue in self.issues if issue.severity == ERROR]
+
+    @property
+    def warnings(self) -> list[LintIssue]:
+        return [issue for issue in self.issues if issue.severity == WARNING]
+
+    def passed(self, strict: bool = False) -> bool:
+        return not (self.issues if strict else self.errors)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "skill": self.name,
+            "path": str(self.skill_dir),
+            "issues": [issue.to_dict() for issue in self.issues],
+            "fixed": [issue.to_dict() for issue in self.fixed],
+        }
+
+
+def estimate_tokens(text: str) -> int:
+    """Approximate token count (words * 1.3, as elsewhere in claude-mpm)."""
+    return int(len(text.split()) * 1.3)
+
+
+def find_skill_dirs(path: Path) -> list[Path]:
+    """The skill directory at *path*, or every skill directory below it."""
+    if path.is_file():
+        return [path.parent]
+    if (path / "SKILL.md").is_file():
+        return [path]
+    return sorted(
+        skill_md.parent
+        for skill_md in path.rglob("SKILL.md")
+        if not any(part.startswith(".") for part in skill_md.relative_to(path).parts)
+    )
+
+
+def locate_skill(
+    name: str, project_dir: Path | None = None, home: Path | None = None
+) -> Path | None:
+    """Find a skill by name: deployed copies first, then sources and bundled."""
+    from ... import __file__ as package_init
+
+    project_dir = Path(project_dir or Path.cwd())
+    home = Path(home or Path.home())
+    for deployed in (project_dir / ".claude" / "skills", home / ".claude" / "skills"):
+        if (deployed / name / "SKILL.md").is_file():
+            return deployed / name
+    for base in (
+        home / ".claude-mpm" / "cache" / "skills",
+        Path(package_init).parent / "skills" / "bundled",
+    ):
+        if base.is_dir():
+            for skill_md in sorted(base.rglob(f"{name}/SKILL.md")):
+                return skill_md.parent
+    return None
+
+
+def _skill_id(name: str) -> str:
+    return SkillDiscoveryService(Path())._generate_skill_id(name)
+
+
+def _edit_frontmatter(text: str, edit: Callable[[str], str]) -> str:
+    match = _FRONTMATTER_RE.match(text)
+    if not match:
+        return text
+    frontmatter = edit(match.group(2))
+    return match.group(1) + frontmatter + match.group(3) + text[match.end() :]
+
+
+def _set_field(key: str, value: str) -> Fix:
+    """A fix that sets a one-line frontmatter field, adding it if missing."""
+    line = f"{key}: {value}"
+    pattern = re.compile(rf"^{key}:[^\n]*$", re.MULTILINE)
+
+    def edit(frontmatter: str) -> str:
+        if pattern.search(frontmatter):
+            return pattern.sub(lambda _: line, frontmatter, count=1)
+        return f"{line}\n{frontmatter}" if frontmatter else line
+
+    return lambda text: _edit_frontmatter(text, edit)
+
+
+def _as_list(key: str, value: str) -> Fix:
+    items = [item.strip() for item in value.split(",") if item.strip()]
+    return _set_field(key, f"[{', '.join(items)}]")
+
+
+def _add_title(title: str) -> Fix:
+    def fix(text: str) -> str:
+        match = _FRONTMATTER_RE.match(text)
+        start = match.end() if match else 0
+        body = text[start:].lstrip("\n")
+        return f"{text[:start]}\n# {title}\n\n{body}"
+
+    return fix
+
+
+def _body_lines(body: str, first_line: int) -> list[tuple[int, str]]:
+    """(line number, text) for the lines of *body* outside code fences."""
+    lines = []
+    in_fence = False
+    for offset, line in enumerate(body.splitlines()):
+        if _FENCE_RE.match(line):
+            in_fence = not in_fence
+        elif not in_fence:
+            lines.append((first_line + offset, line))
+    return lines
+
+
+def _check_encoding(raw: str) -> list[LintIssue]:
+    issues = []
+    if raw.startswith("\ufeff"):
+        issues.append(
+            LintIssue(
+                "encoding",
+                ERROR,
+                "starts with a byte order mark, so the frontmatter is not found",
+                fix=lambda text: text.lstrip("\ufeff"),
+            )
+        )
+    if "\r\n" in raw:
+        issues.append(
+            LintIssue(
+                "encoding",
+                WARNING,
+                "uses CRLF line endings",
+                fix=lambda text: text.replace("\r\n", "\n"),
+            )
+        )
+    stripped = raw.lstrip("\ufeff")
+    if stripped != stripped.lstrip() and stripped.lstrip().startswith("---"):
+        issues.append(
+            LintIssue(
+                "frontmatter",
+                ERROR,
+                "frontmatter must start on the first line",
+                fix=lambda text: text.lstrip(),
+            )
+        )
+    return issues
+
+
+def _check_frontmatter(meta: dict[str, Any], skill_dir: Path) -> list[LintIssue]:
+    issues = []
+    fallback = _skill_id(skill_dir.name)
+
+    name = meta.get("name")
+    if name is None or str(name).strip() == "":
+        issues.append(
+            LintIssue(
+                "name",
+                ERROR,
+                "missing 'name'; the skill is skipped at deploy time",
+                fix=_set_field("name", fallback) if fallback else None,
+            )
+        )
+    else:
+        name = str(name)
+        if len(name) > MAX_NAME_LENGTH:
+            issues.append(
+                LintIssue(
+                    "name",
+                    ERROR,
+                    f"name is {len(name)} characters (max {MAX_NAME_LENGTH})",
+                )
+            )
+        if not _NAME_RE.match(name):
+            fixed = _skill_id(name)
+            issues.append(
+                LintIssue(
+                    "name",
+                    ERROR,
+                    f"name {name!r} must be lowercase letters, digits and hyphens",
+                    fix=_set_field("name", fixed) if fixed else None,
+                )
+            )
+        # Nested skills deploy as <parent>-<dir>, so only the tail must match
+        elif not name.endswith(skill_dir.name) and _NAME_RE.match(skill_dir.name):
+            issues.append(
+                LintIssue(
+                    "name",
+                    WARNING,
+                    f"name {name!r} does not match directory {skill_dir.name!r}",
+                )
+            )
+
+    description = meta.get("description")
+    if description is None:
+        issues.append(
+            LintIssue(
+                "description",
+                ERROR,
+                "missing 'description'; the skill is skipped at deploy time",
+            )
+        )
+    elif not isinstance(description, str) or not description.strip():
+        issues.append(
+            LintIssue("description", ERROR, "description must be non-empty text")
+        )
+    elif len(description) > MAX_DESCRIPTION_LENGTH:
+        issues.append(
+            LintIssue(
+                "description",
+                ERROR,
+                f"description is {len(description)} characters "
+                f"(max {MAX_DESCRIPTION_LENGTH})",
+            )
+        )
+
+    for key in LIST_FIELDS:
+        value = meta.get(key)
+        if value is None or isinstance(value, list):
+            continue
+        if isinstance(value, str):
+            issues.append(
+                LintIssue(
+                    "field-type",
+                    WARNING,
+                    f"'{key}' should be a list, not {value!r}",
+                    fix=_as_list(key, value),
+                )
+            )
+        else:
+            issues.append(
+                LintIssue(
+                    "field-type",
+                    ERROR,
+                    f"'{key}' must be a list; it is ignored at deploy time",
+                )
+            )
+    return issues
+
+
+def _check_sections(
+    meta: dict[str, Any],
+    lines: list[tuple[int, str]],
+    required: tuple[str, ...],
+) -> list[LintIssue]:
+    issues = []
+    headings = [
+        text.lstrip("#").strip().lower() for _, text in lines if text.startswith("#")
+    ]
+    if not any(text.startswith("# ") for _, text in lines):
+        title = str(meta.get("name") or "").replace("-", " ").strip().title()
+        issues.append(
+            LintIssue(
+                "section",
+                WARNING,
+                "no '# Title' heading",
+                fix=_add_title(title) if title else None,
+            )
+        )
+    entry_point = (meta.get("progressive_disclosure") or {}).get("entry_point")
+    has_when_to_use = bool(meta.get("when_to_use")) or (
+        isinstance(entry_point, dict) and bool(entry_point.get("when_to_use"))
+    )
+    for section in required:
+        wanted = section.lower()
+        if wanted == "when to use" and has_when_to_use:
+            continue
+        if not any(heading.startswith(wanted) for heading in headings):
+            issues.append(
+                LintIssue("section", WARNING, f"missing a '{section}' section")
+            )
+    return issues
+
+
+def _check_links(skill_dir: Path) -> list[LintIssue]:
+    issues = []
+    root = skill_dir.resolve()
+    for doc in sorted(skill_dir.rglob("*.md")):
+        relative = doc.relative_to(skill_dir).as_posix()
+        try:
+            text = doc.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        for number, line in _body_lines(text, 1):
+            for target in _LINK_RE.findall(_INLINE_CODE_RE.sub("", line)):
+                if "://" in target or target.startswith(("#", "/", "mailto:")):
+                    continue
+                path = unquote(target.split("#", 1)[0].split("?", 1)[0])
+                if not path:
+                    continue
+                resolved = (doc.parent / path).resolve()
+                if not resolved.is_relative_to(root):
+                    message = f"link to {target} leaves the skill directory"
+                elif not resolved.exists():
+                    message = f"broken link to {target}"
+                else:
+                    continue
+                issues.append(LintIssue("link", ERROR, message, relative, number))
+    return issues
+
+
+def _check_references(meta: dict[str, Any], skill_dir: Path) -> list[LintIssue]:
+    disclosure = meta.get("progressive_disclosure")
+    references = disclosure.get("references") if isinstance(disclosure, dict) else []
+    issues = []
+    for reference in references or []:
+        if isinstance(reference, dict):  # {path: ..., purpose: ...}
+            reference = reference.get("path")
+        if not reference:
+            continue
+        reference = str(reference)
+        if not any(
+            (skill_dir / folder / reference).is_file()
+            for folder in ("", "references", "reference")
+        ):
+            issues.append(
+                LintIssue(
+                    "link",
+                    ERROR,
+                    f"progressive_disclosure reference {reference} does not exist",
+                )
+            )
+    return issues
+
+
+def _check_budgets(
+    text: str, meta: dict[str, Any], max_lines: int, max_tokens: int
+) -> list[LintIssue]:
+    issues = []
+    line_count = len(text.splitlines())
+    if line_count > max_lines:
+        issues.append(
+            LintIssue(
+                "size", WARNING, f"SKILL.md is {line_count} lines (budget {max_lines})"
+            )
+        )
+    limit = meta.get("context_limit")
+    budget = limit if isinstance(limit, int) and limit > 0 else max_tokens
+    tokens = estimate_tokens(text)
+    if tokens > budget:
+        issues.append(
+            LintIssue(
+                "size", WARNING, f"SKILL.md is ~{tokens} tokens (budget {budget})"
+            )
+        )
+    return issues
+
+
+def _lint_once(
+    skill_dir: Path,
+    max_lines: int,
+    max_tokens: int,
+    required_sections: tuple[str, ...],
+) -> LintReport:
+    report = LintReport(skill_dir, skill_dir.name)
+    skill_md = skill_dir / "SKILL.md"
+    if not skill_md.is_file():
+        report.issues.append(LintIssue("skill-md", ERROR, "SKILL.md not found"))
+        return report
+    raw = skill_md.read_bytes().decode("utf-8", errors="replace")
+
+    report.issues.extend(_check_encoding(raw))
+    try:
+        meta, body = SkillDiscoveryService(skill_dir)._extract_frontmatter(raw)
+    except ValueError as e:
+        # An encoding error (byte order mark, leading blank lines) explains it
+        if not any(issue.severity == ERROR for issue in report.issues):
+            report.issues.append(LintIssue("frontmatter", ERROR, str(e)))
+        return report
+    if not isinstance(meta, dict):
+        report.issues.append(
+            LintIssue("frontmatter", ERROR, "frontmatter must be a YAML mapping")
+        )
+        return report
+    report.name = str(meta.get("name") or skill_dir.name)
+
+    report.issues.extend(_check_frontmatter(meta, skill_dir))
+    body_start = raw.count("\n", 0, len(raw) - len(body)) + 1
+    lines = _body_lines(body, body_start)
+    if not body.strip():
+        report.issues.append(LintIssue("section", ERROR, "SKILL.md has no content"))
+    else:
+        report.issues.extend(_check_sections(meta, lines, required_sections))
+    report.issues.extend(_check_links(skill_dir))
+    report.issues.extend(_check_references(meta, skill_dir))
+    report.issues.extend(_check_budgets(raw, meta, max_lines, max_tokens))
+    return report
+
+
+def lint_skill(
+    skill_dir: Path,
+    fix: bool = False,
+    max_lines: int = MAX_LINES,
+    max_tokens: int = MAX_TOKENS,
+    required_sections: tuple[str, ...] = REQUIRED_SECTIONS,
+) -> LintReport:
+    """Lint one skill directory, correcting what can be corrected if *fix*.
+
+    Fixing runs in rounds, because some issues (a byte order mark) hide
+    others (the frontmatter behind it) until they are fixed.
+    """
+    skill_dir = Path(skill_dir)
+    fixed: list[LintIssue] = []
+    for _ in range(3):
+        report = _lint_once(skill_dir, max_lines, max_tokens, required_sections)
+        fixable = [issue for issue in report.issues if issue.fixable]
+        if not fix or not fixable:
+            break
+        skill_md = skill_dir / "SKILL.md"
+        text = original = skill_md.read_bytes().decode("utf-8", errors="replace")
+        for issue in fixable:
+            text = issue.fix(text)
+        if text == original:
+            break
+        skill_md.write_text(text, encoding="utf-8", newline="")
+        fixed.extend(fixable)
+    report.fixed = fixed
+    return report
@@ -0,0 +1,216 @@
+"""Scaffold a new skill directory.
+
+WHAT: ``scaffold_skill`` writes a skill directory that deploys, lints and
+tests cleanly from the start:
+
+- ``SKILL.md`` with complete frontmatter (name, skill_id, versions,
+  category, description, when_to_use, tags, agent_types, updated_at and a
+  ``progressive_disclosure`` entry point) and the usual sections
+- ``references/examples.md``, linked from SKILL.md, for worked examples
+- optionally ``tests.yaml`` with one case and the fixture project it runs
+  in (``fixtures/example-project``), for ``claude-mpm skills test``
+
+``claude-mpm skills new`` collects the details from flags or, on a
+terminal, interactive prompts.
+
+WHY: New skills were made by copying an old one, and half of the copied
+metadata (skill_id, version, tags, when_to_use) kept describing the old
+skill.
+
+DESIGN DECISIONS:
+- The name is checked with the linter's rules, and the frontmatter is
+  written with ``yaml.safe_dump`` so descriptions with colons or quotes stay
+  valid YAML
+- The body keeps TODO markers where the author has to write; everything
+  derived from the details given is filled in
+- An existing non-empty directory is never overwritten unless ``force``
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+
+import yaml
+
+from .skill_linter import _NAME_RE, MAX_DESCRIPTION_LENGTH, MAX_NAME_LENGTH
+from .skill_testing import TESTS_FILE
+
+INITIAL_VERSION = "0.1.0"
+DEFAULT_CATEGORY = "universal"
+EXAMPLES_FILE = "references/examples.md"
+FIXTURE_DIR = "fixtures/example-project"
+
+BODY = """\
+# {title}
+
+{description}
+
+## When to Use
+
+- {when_to_use}
+
+## Instructions
+
+1. TODO: the first thing the agent should do
+2. TODO: how to carry the work through
+
+## Examples
+
+See [worked examples]({examples}) for inputs and the results to aim for.
+
+## Pitfalls
+
+- TODO: mistakes this skill should prevent
+"""
+
+EXAMPLES = """\
+# {title} Examples
+
+## Example: TODO short name
+
+**Request:** TODO what the user asks for
+
+**Approach:** TODO how the skill applies
+
+```text
+TODO the result
+```
+"""
+
+TESTS = """\
+# Example prompts run by `claude-mpm skills test`
+cases:
+  - name: follows {name}
+    prompt: TODO a request this skill should handle
+    fixture: {fixture}
+    expect:
+      tools: [Read]
+      output: ["TODO pattern the answer must contain"]
+"""
+
+FIXTURE_README = """\
+# Example project
+
+Files the {name} test case works on. Replace with a minimal project that
+shows the situation the skill handles.
+"""
+
+
+class SkillScaffoldError(Exception):
+    """Details that cannot make a valid skill, or a directory in the way."""
+
+
+@dataclass
+class SkillSpec:
+    """What the author says about the new skill."""
+
+    name: str
+    description: str
+    when_to_use: str = ""
+    category: str = DEFAULT_CATEGORY
+    tags: list[str] = field(default_factory=list)
+    agent_types: list[str] = field(default_factory=list)
+
+    @property
+    def title(self) -> str:
+        return self.name.replace("-", " ").title()
+
+    def validate(self) -> None:
+        """Raise SkillScaffoldError unless the spec makes a valid skill."""
+        if not _NAME_RE.match(self.name):
+            raise SkillScaffoldError(
+                f"skill name {self.name!r} must be lowercase letters, digits "
+                "and hyphens"
+            )
+        if len(self.name) > MAX_NAME_LENGTH:
+            raise SkillScaffoldError(
+                f"skill name is {len(self.name)} characters (max {MAX_NAME_LENGTH})"
+            )
+        if not self.description.strip():
+            raise SkillScaffoldError("a description is required")
+        if len(self.description) > MAX_DESCRIPTION_LENGTH:
+            raise SkillScaffoldError(
+                f"description is {len(self.description)} characters "
+                f"(max {MAX_DESCRIPTION_LENGTH})"
+            )
+
+    def frontmatter(self, now: datetime | None = None) -> dict:
+        when_to_use = (self.when_to_use or self.description).strip().rstrip(".")
+        meta = {
+            "name": self.name,
+            "skill_id": self.name,
+            "skill_version": INITIAL_VERSION,
+            "version": INITIAL_VERSION,
+            "category": self.category or DEFAULT_CATEGORY,
+            "description": self.description.strip(),
+            "when_to_use": when_to_use,
+            "updated_at": (now or datetime.now(UTC)).strftime("%Y-%m-%dT%H:%M:%SZ"),
+            "tags": list(self.tags),
+        }
+        if self.agent_types:
+            meta["agent_types"] = list(self.agent_types)
+        meta["progressive_disclosure"] = {
+            "entry_point": {
+                "summary": self.description.strip(),
+                "when_to_use": when_to_use,
+            },
+            "references": [EXAMPLES_FILE],
+        }
+        return meta
+
+
+def scaffold_skill(
+    spec: SkillSpec,
+    parent: Path,
+    with_tests: bool = False,
+    force: bool = False,
+    now: datetime | None = None,
+) -> list[Path]:
+    """Write the skill described by *spec* to ``<parent>/<name>``.
+
+    Returns:
+        The files written, SKILL.md first
+
+    Raises:
+        SkillScaffoldError: If the spec is invalid, or the directory exists
+            and is not empty and *force* is not set
+    """
+    spec.validate()
+    skill_dir = Path(parent) / spec.name
+    if skill_dir.exists() and any(skill_dir.iterdir()) and not force:
+        raise SkillScaffoldError(
+            f"{skill_dir} already exists and is not empty (use --force)"
+        )
+
+    meta = spec.frontmatter(now)
+    files = {
+        "SKILL.md": "---\n"
+        + yaml.safe_dump(
+            meta,
+            sort_keys=False,
+            allow_unicode=True,
+            default_flow_style=None,
+            width=1000,
+        )
+        + "---\n\n"
+        + BODY.format(
+            title=spec.title,
+            description=meta["description"],
+            when_to_use=meta["when_to_use"],
+            examples=EXAMPLES_FILE,
+        ),
+        EXAMPLES_FILE: EXAMPLES.format(title=spec.title),
+    }
+    if with_tests:
+        files[TESTS_FILE] = TESTS.format(name=spec.name, fixture=FIXTURE_DIR)
+        files[f"{FIXTURE_DIR}/README.md"] = FIXTURE_README.format(name=spec.name)
+
+    written = []
+    for relative, content in files.items():
+        path = skill_dir / relative
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(content, encoding="utf-8")
+        written.append(path)
+    return written
@@ -0,0 +1,258 @@
+"""Search skills across every configured skill source.
+
+WHAT: ``search_skills`` matches a query against the name, description and
+tags of the skills each enabled source provides and returns one match per
+source that provides a skill, with the skill's version, so the same skill
+offered by two sources shows up twice.
+
+WHY: Finding which source has a skill meant cloning every source and
+grepping it.
+
+DESIGN DECISIONS:
+- A source is searched in its local cache when it has been synced; sources
+  that have not are searched through the ``manifest.json`` index at the root
+  of their repository (a registry's own index.json), without cloning them
+- Every query word must match somewhere; a word matching the name ranks above
+  one matching a tag, which ranks above one matching the description
+- Matches are not priority-resolved, but a match that a higher-priority
+  source overrides records which source wins
+- A source that can be searched neither way is reported as skipped instead
+  of failing the search
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+INDEX_FILE = "manifest.json"
+
+# Score per query word, by where it matched
+NAME_SCORE = 3
+TAG_SCORE = 2
+DESCRIPTION_SCORE = 1
+
+
+@dataclass
+class SkillMatch:
+    """A skill matching the query, as provided by one source."""
+
+    name: str
+    description: str
+    source_id: str
+    source_priority: int
+    version: str | None = None
+    tags: list[str] = field(default_factory=list)
+    score: int = 0
+    # "cache" when found in the synced source, "index" for its manifest.json
+    origin: str = "cache"
+    # Source whose copy of this skill is deployed instead, if any
+    shadowed_by: str | None = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class SearchResult:
+    """Matches for a query, best first."""
+
+    query: str
+    matches: list[SkillMatch] = field(default_factory=list)
+    # Source id -> why it could not be searched
+    skipped: dict[str, str] = field(default_factory=dict)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "query": self.query,
+            "matches": [match.to_dict() for match in self.matches],
+            "skipped": self.skipped,
+        }
+
+
+def _tags(value: Any) -> list[str]:
+    if isinstance(value, str):
+        return [tag.strip() for tag in value.split(",") if tag.strip()]
+    if isinstance(value, list):
+        return [str(tag) for tag in value]
+    return []
+
+
+def _score(terms: list[str], name: str, tags: list[str], description: str) -> int:
+    """Total score of *terms* against a skill, 0 unless every term matches."""
+    name = name.lower()
+    tags = [tag.lower() for tag in tags]
+    description = description.lower()
+    total = 0
+    for term in terms:
+        if term in name:
+            total += NAME_SCORE
+        elif any(term in tag for tag in tags):
+            total += TAG_SCORE
+        elif term in description:
+            total += DESCRIPTION_SCORE
+        else:
+            return 0
+    return total
+
+
+def _index_entries(manifest: dict[str, Any]) -> list[dict[str, Any]]:
+    """Skill entries of a manifest, flat or grouped by universal/toolchains."""
+    skills = manifest.get("skills", [])
+    if isinstance(skills, list):
+        return [entry for entry in skills if isinstance(entry, dict)]
+    if not isinstance(skills, dict):
+        return []
+    entries = list(skills.get("universal") or [])
+    for toolchain_skills in (skills.get("toolchains") or {}).values():
+        entries.extend(toolchain_skills or [])
+    return [entry for entry in entries if isinstance(entry, dict)]
+
+
+def _from_index(entry: dict[str, Any]) -> dict[str, Any]:
+    """Normalize a manifest entry to the fields of a discovered skill."""
+    metadata = entry.get("metadata") or {}
+    return {
+        "name": entry.get("name", ""),
+        "description": entry.get("description") or metadata.get("description", ""),
+        "version": entry.get("version") or metadata.get("version"),
+        "tags": entry.get("tags") or metadata.get("tags") or [],
+    }
+
+
+def fetch_source_index(source: SkillSource) -> dict[str, Any]:
+    """Download the ``manifest.json`` index of a GitHub, GitLab or Bitbucket
+    source, or the index.json of a registry.
+
+    Raises:
+        ValueError: If the source is cloned over SSH or is a local directory
+        requests.RequestException: If the index cannot be downloaded
+    """
+    import json
+
+    from claude_mpm.services import http_client
+    from claude_mpm.services.skills.git_hosts import get_host
+    from claude_mpm.services.skills.git_skill_source_manager import (
+        _get_github_token,
+        _github_owner_repo,
+    )
+
+    if source.is_http:
+        from claude_mpm.services.skills.http_registry import fetch_index
+
+        return fetch_index(source).data
+    if source.is_ssh or source.is_local:
+        kind = "Local" if source.is_local else "SSH"
+        raise ValueError(
+            f"{kind} sources have no index; run 'claude-mpm skill-source update' first"
+        )
+    host = get_host(source)
+    if host is not None:
+        return json.loads(host.fetch_file(source.branch, INDEX_FILE))
+    owner_repo = _github_owner_repo(source.url)
+    url = f"https://raw.githubusercontent.com/{owner_repo}/{source.branch}"
+    headers = {}
+    if token := _get_github_token(source):
+        headers["Authorization"] = f"token {token}"
+    response = http_client.get(f"{url}/{INDEX_FILE}", headers=headers, timeout=30)
+    response.raise_for_status()
+    return response.json()
+
+
+def _source_skills(
+    source: SkillSource,
+    cache_dir: Path,
+    fetch_index: Callable[[SkillSource], dict[str, Any]],
+) -> tuple[list[dict[str, Any]], str]:
+    """Skills *source* provides and where they came from ("cache" or "index")."""
+    from claude_mpm.services.skills.skill_discovery_service import (
+        SkillDiscoveryService,
+    )
+
+    cache_path = cache_dir / source.id
+    if cache_path.exists():
+        skills = SkillDiscoveryService(cache_path).discover_skills()
+        for skill in skills:
+            skill["version"] = skill.get("version") or skill.get("skill_version")
+        return skills, "cache"
+    entries = _index_entries(fetch_index(source))
+    return [_from_index(entry) for entry in entries], "index"
+
+
+def search_skills(
+    query: str,
+    config: SkillSourceConfiguration | None = None,
+    cache_dir: Path | None = None,
+    source_id: str | None = None,
+    fetch_index: Callable[[SkillSource], dict[str, Any]] = fetch_source_index,
+) -> SearchResult:
+    """Find skills matching *query* in every enabled source.
+
+    Args:
+        query: Words to look for in skill names, descriptions and tags
+        config: Skill source configuration (defaults to the user's)
+        cache_dir: Skill cache (defaults to ~/.claude-mpm/cache/skills/)
+        source_id: Search only this source
+        fetch_index: Downloads a source's index (injected for testing)
+
+    Returns:
+        SearchResult with matches ordered by score, then name, then priority
+    """
+    config = config or SkillSourceConfiguration()
+    cache_dir = cache_dir or Path.home() / ".claude-mpm" / "cache" / "skills"
+    terms = query.lower().split()
+    result = SearchResult(query=query)
+
+    sources = config.get_enabled_sources()
+    if source_id:
+        sources = [source for source in sources if source.id == source_id]
+        if not sources:
+            result.skipped[source_id] = "not an enabled skill source"
+
+    for source in sorted(sources, key=lambda s: s.priority):
+        try:
+            skills, origin = _source_skills(source, cache_dir, fetch_index)
+        except Exception as e:
+            logger.debug(f"Cannot search skill source {source.id}: {e}")
+            result.skipped[source.id] = str(e)
+            continue
+        for skill in skills:
+            name = str(skill.get("name", ""))
+            tags = _tags(skill.get("tags"))
+            description = str(skill.get("description") or "")
+            score = _score(terms, name, tags, description)
+            if not score:
+                continue
+            version = skill.get("version")
+            result.matches.append(
+                SkillMatch(
+                    name=name,
+                    description=description,
+                    source_id=source.id,
+                    source_priority=source.priority,
+                    version=str(version) if version else None,
+                    tags=tags,
+                    score=score,
+                    origin=origin,
+                )
+            )
+
+    # Sources are searched in priority order, so the first match for a name
+    # is the copy that gets deployed.
+    winners: dict[str, str] = {}
+    for match in result.matches:
+        key = match.name.lower()
+        if key in winners:
+            match.shadowed_by = winners[key]
+        else:
+            winners[key] = match.source_id
+
+    result.matches.sort(key=lambda m: (-m.score, m.name.lower(), m.source_priority))
+    return result
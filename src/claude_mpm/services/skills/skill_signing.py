@@ -0,0 +1,407 @@
+"""Sign skill collections and verify them when a source is synced.
+
+WHAT: A signed collection has a ``skills.manifest`` at its root listing the
+SHA-256 of every file that syncs (SKILL.md, scripts, references, assets) in
+``sha256sum`` format, and a signature of that manifest:
+``skills.manifest.minisig`` (minisign) or ``skills.manifest.sigstore.json``
+(a Sigstore bundle from cosign). ``sign_collection`` writes both;
+``verify_collection`` checks a synced cache against the keys trusted for its
+source.
+
+WHY: Skills run in developer environments with the developer's permissions.
+Security needs to know that what was deployed is what the publisher signed,
+not something changed on the hosting service or on the way.
+
+CONFIGURATION:
+
+    # ~/.claude-mpm/config/skill_sources.yaml
+    sources:
+      - id: org-skills
+        url: https://github.com/org/skills
+        trusted_keys:
+          - RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3
+
+    # .claude-mpm/configuration.yaml
+    skills:
+      signatures:
+        trusted_keys: []   # trusted for every source
+        require: false     # true: a source that does not verify fails to sync
+
+A trusted key is a minisign public key (the second line of its .pub file) or
+``sigstore:ISSUER IDENTITY``, the OIDC issuer and certificate identity a
+keyless Sigstore signature must carry (for a GitHub Actions signer, the
+issuer is https://token.actions.githubusercontent.com and the identity is the
+workflow URL with its ref).
+
+DESIGN DECISIONS:
+- minisign signatures are checked in-process (Ed25519 from ``cryptography``);
+  Sigstore bundles are checked with ``cosign verify-blob``. Signing runs the
+  ``minisign`` or ``cosign`` CLI, so secret keys never pass through claude-mpm
+- Files in the cache that the manifest does not list (stale downloads, files
+  outside the collection) are removed once the signature checks out, so
+  only signed files deploy
+- Without ``require`` a failed check is a warning and the source still
+  syncs; with it the source's cache is cleared and the sync fails
+- Local directory sources are never verified: they are the user's own files
+"""
+
+from __future__ import annotations
+
+import base64
+import binascii
+import hashlib
+import subprocess  # nosec B404 - runs minisign and cosign
+from collections.abc import Callable
+from dataclasses import dataclass, field, fields
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "skills.signatures"
+MANIFEST_FILE = "skills.manifest"
+MINISIGN_FILE = f"{MANIFEST_FILE}.minisig"
+SIGSTORE_FILE = f"{MANIFEST_FILE}.sigstore.json"
+SIGNATURE_FILES = (MANIFEST_FILE, MINISIGN_FILE, SIGSTORE_FILE)
+SIGSTORE_PREFIX = "sigstore:"
+COSIGN_TIMEOUT = 120
+
+Runner = Callable[..., subprocess.CompletedProcess]
+
+
+class SignatureError(Exception):
+    """A collection is unsigned, or its signature or files do not check out."""
+
+
+@dataclass
+class SignatureConfig:
+    """Keys trusted for every source, and whether verification is required."""
+
+    trusted_keys: list[str] = field(default_factory=list)
+    require: bool = False
+
+    @classmethod
+    def load(cls, config: Any = None) -> SignatureConfig:
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from claude_mpm.core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in section.items() if k in known and v is not None})
+
+
+@dataclass(frozen=True)
+class TrustedKey:
+    """A minisign public key, or the issuer and identity of a Sigstore signer."""
+
+    kind: str  # "minisign" or "sigstore"
+    key_id: bytes = b""
+    public_key: bytes = b""
+    issuer: str = ""
+    identity: str = ""
+
+    @classmethod
+    def parse(cls, text: str) -> TrustedKey:
+        """Parse a ``trusted_keys`` entry.
+
+        Raises:
+            SignatureError: If the entry is neither form
+        """
+        text = text.strip()
+        if text.startswith(SIGSTORE_PREFIX):
+            issuer, _, identity = text[len(SIGSTORE_PREFIX) :].strip().partition(" ")
+            if not issuer or not identity.strip():
+                raise SignatureError(
+                    f"Sigstore trusted key must be 'sigstore:ISSUER IDENTITY', "
+                    f"got: {text}"
+                )
+            return cls("sigstore", issuer=issuer, identity=identity.strip())
+        try:
+            blob = base64.b64decode(text, validate=True)
+        except (binascii.Error, ValueError):
+            blob = b""
+        if len(blob) != 42 or blob[:2] != b"Ed":
+            raise SignatureError(f"Not a minisign public key: {text}")
+        return cls("minisign", key_id=blob[2:10], public_key=blob[10:])
+
+    def __str__(self) -> str:
+        if self.kind == "sigstore":
+            return f"sigstore {self.identity}"
+        return f"minisign {int.from_bytes(self.key_id, 'little'):016X}"
+
+    def verify(self, root: Path, runner: Runner = subprocess.run) -> None:
+        """Check the signature of *root*'s manifest against this key.
+
+        Raises:
+            SignatureError: If the signature is missing or does not verify
+        """
+        if self.kind == "sigstore":
+            self._verify_sigstore(root, runner)
+            return
+        path = root / MINISIGN_FILE
+        if not path.is_file():
+            raise SignatureError(f"no {MINISIGN_FILE}")
+        verify_minisign(
+            (root / MANIFEST_FILE).read_bytes(),
+            path.read_text(encoding="utf-8"),
+            self,
+        )
+
+    def _verify_sigstore(self, root: Path, runner: Runner) -> None:
+        bundle = root / SIGSTORE_FILE
+        if not bundle.is_file():
+            raise SignatureError(f"no {SIGSTORE_FILE}")
+        cmd = [
+            "cosign",
+            "verify-blob",
+            "--bundle",
+            str(bundle),
+            "--certificate-identity",
+            self.identity,
+            "--certificate-oidc-issuer",
+            self.issuer,
+            str(root / MANIFEST_FILE),
+        ]
+        try:
+            result = runner(
+                cmd, capture_output=True, text=True, timeout=COSIGN_TIMEOUT
+            )
+        except FileNotFoundError:
+            raise SignatureError("cosign is not installed") from None
+        except subprocess.TimeoutExpired:
+            raise SignatureError("cosign timed out") from None
+        if result.returncode != 0:
+            lines = (result.stderr or result.stdout or "").strip().splitlines()
+            raise SignatureError(lines[-1] if lines else "cosign verify-blob failed")
+
+
+def verify_minisign(data: bytes, signature: str, key: TrustedKey) -> None:
+    """Check a minisign *signature* of *data*, and its trusted comment.
+
+    Both the legacy (``Ed``) and the default prehashed (``ED``, BLAKE2b-512)
+    signature algorithms are accepted.
+
+    Raises:
+        SignatureError: If the signature is malformed, by another key or bad
+    """
+    from cryptography.exceptions import InvalidSignature
+    from cryptography.hazmat.primitives.asymmetric.ed25519 import Ed25519PublicKey
+
+    lines = signature.splitlines()
+    prefix = "trusted comment: "
+    try:
+        blob = base64.b64decode(lines[1], validate=True)
+        global_signature = base64.b64decode(lines[3], validate=True)
+    except (IndexError, binascii.Error, ValueError):
+        raise SignatureError("malformed minisign signature") from None
+    if len(blob) != 74 or not lines[2].startswith(prefix):
+        raise SignatureError("malformed minisign signature")
+    algorithm, key_id, sig = blob[:2], blob[2:10], blob[10:]
+    if key_id != key.key_id:
+        raise SignatureError("signed by another key")
+    if algorithm == b"ED":
+        message = hashlib.blake2b(data, digest_size=64).digest()
+    elif algorithm == b"Ed":
+        message = data
+    else:
+        raise SignatureError(f"unsupported signature algorithm {algorithm!r}")
+    public_key = Ed25519PublicKey.from_public_bytes(key.public_key)
+    try:
+        public_key.verify(sig, message)
+        public_key.verify(global_signature, sig + lines[2][len(prefix) :].encode())
+    except InvalidSignature:
+        raise SignatureError("signature does not match") from None
+
+
+# ---------------------------------------------------------------------------
+# Manifest
+# ---------------------------------------------------------------------------
+
+
+def _sha256(path: Path) -> str:
+    digest = hashlib.sha256()
+    with path.open("rb") as f:
+        for chunk in iter(lambda: f.read(1 << 16), b""):
+            digest.update(chunk)
+    return digest.hexdigest()
+
+
+def _files(root: Path) -> dict[str, str]:
+    """Every file under *root* except .git and the signature files, hashed."""
+    files = {}
+    for path in root.rglob("*"):
+        relative = path.relative_to(root)
+        if not path.is_file() or relative.parts[0] == ".git":
+            continue
+        if relative.as_posix() in SIGNATURE_FILES:
+            continue
+        files[relative.as_posix()] = _sha256(path)
+    return files
+
+
+def build_manifest(root: Path) -> str:
+    """The manifest of a collection: the files a sync would fetch, hashed.
+
+    Hidden files are left out, as local syncs leave them out.
+    """
+    # The manager imports this module, so its helpers are imported late
+    from claude_mpm.services.skills.git_skill_source_manager import (
+        _is_relevant_file,
+        is_hidden_path,
+    )
+
+    lines = [
+        f"{digest}  {path}\n"
+        for path, digest in sorted(_files(root).items())
+        if _is_relevant_file(path) and not is_hidden_path(Path(path))
+    ]
+    return "".join(lines)
+
+
+def parse_manifest(text: str) -> dict[str, str]:
+    """Map each path in a manifest to its SHA-256.
+
+    Raises:
+        SignatureError: If a line is not ``<sha256>  <relative path>``
+    """
+    entries = {}
+    for number, line in enumerate(text.splitlines(), 1):
+        if not line.strip():
+            continue
+        digest, _, path = line.partition("  ")
+        parts = Path(path).parts
+        if (
+            len(digest) != 64
+            or not path
+            or path.startswith("/")
+            or ".." in parts
+            or any(c not in "0123456789abcdef" for c in digest)
+        ):
+            raise SignatureError(f"malformed {MANIFEST_FILE} line {number}")
+        entries[path] = digest
+    return entries
+
+
+# ---------------------------------------------------------------------------
+# Signing and verification
+# ---------------------------------------------------------------------------
+
+
+@dataclass
+class Verification:
+    """The result of checking one collection."""
+
+    verified: bool
+    signer: str | None = None
+    error: str | None = None
+    removed: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        data: dict[str, Any] = {"verified": self.verified}
+        if self.signer:
+            data["signer"] = self.signer
+        if self.error:
+            data["error"] = self.error
+        if self.removed:
+            data["removed"] = self.removed
+        return data
+
+
+def verify_collection(
+    root: Path, trusted_keys: list[str], runner: Runner = subprocess.run
+) -> Verification:
+    """Check a synced collection against *trusted_keys*.
+
+    The manifest must be signed by one of the keys and every file it lists
+    must be present and unchanged. Files it does not list are then removed.
+    """
+    if not trusted_keys:
+        return Verification(False, error="no trusted keys are configured for it")
+    if not (root / MANIFEST_FILE).is_file():
+        return Verification(False, error=f"not signed (no {MANIFEST_FILE})")
+    if not any((root / name).is_file() for name in SIGNATURE_FILES[1:]):
+        return Verification(False, error=f"{MANIFEST_FILE} is not signed")
+
+    failures = []
+    signer = None
+    for text in trusted_keys:
+        try:
+            key = TrustedKey.parse(text)
+            key.verify(root, runner)
+        except SignatureError as e:
+            failures.append(f"{text[:24]}: {e}")
+            continue
+        signer = str(key)
+        break
+    if signer is None:
+        return Verification(
+            False, error="no trusted key signed it (" + "; ".join(failures) + ")"
+        )
+
+    try:
+        expected = parse_manifest(
+            (root / MANIFEST_FILE).read_text(encoding="utf-8")
+        )
+    except (SignatureError, UnicodeDecodeError) as e:
+        return Verification(False, signer, error=str(e))
+    actual = _files(root)
+    changed = sorted(p for p, digest in expected.items() if actual.get(p) != digest)
+    if changed:
+        shown = ", ".join(changed[:5]) + (", ..." if len(changed) > 5 else "")
+        return Verification(
+            False,
+            signer,
+            error=f"{len(changed)} files differ from the signed manifest: {shown}",
+        )
+
+    removed = sorted(set(actual) - set(expected))
+    for path in removed:
+        (root / path).unlink()
+    if removed:
+        logger.info(f"Removed {len(removed)} unsigned files from {root}")
+    return Verification(True, signer, removed=removed)
+
+
+def sign_collection(
+    root: Path,
+    minisign_key: Path | None = None,
+    sigstore: bool = False,
+    runner: Runner = subprocess.run,
+) -> Path:
+    """Write *root*'s manifest and sign it with minisign or cosign.
+
+    The signing tool runs attached to the terminal, so it can prompt for the
+    key's password or open the browser for a Sigstore login.
+
+    Returns:
+        The signature file
+
+    Raises:
+        SignatureError: If no signing method is given or the tool fails
+    """
+    if (minisign_key is None) == (not sigstore):
+        raise SignatureError("Sign with exactly one of a minisign key or Sigstore")
+    manifest = root / MANIFEST_FILE
+    manifest.write_text(build_manifest(root), encoding="utf-8")
+    if minisign_key is not None:
+        signature = root / MINISIGN_FILE
+        cmd = ["minisign", "-S", "-s", str(minisign_key), "-m", str(manifest)]
+        cmd += ["-x", str(signature)]
+    else:
+        signature = root / SIGSTORE_FILE
+        cmd = ["cosign", "sign-blob", "--yes", "--bundle", str(signature)]
+        cmd.append(str(manifest))
+    try:
+        result = runner(cmd, check=False)
+    except FileNotFoundError:
+        raise SignatureError(f"{cmd[0]} is not installed") from None
+    if result.returncode != 0:
+        raise SignatureError(f"{cmd[0]} exited with status {result.returncode}")
+    return signature
@@ -0,0 +1,390 @@
+"""Run a skill's example prompts against a headless agent and check the answers.
+
+WHAT: A skill ships test cases in a ``tests.yaml`` next to its SKILL.md.
+Each case is a prompt and what the agent should do with the skill loaded:
+
+    model: haiku            # optional defaults for every case
+    max_turns: 6
+    allow_tools: [Read, Glob, Grep]
+    cases:
+      - name: picks a fixture over setUp
+        prompt: Share the database setup between these tests
+        fixture: fixtures/unittest-project   # copied into the working dir
+        expect:
+          tools: [Read, {tool: Edit, input: "@pytest\\.fixture"}]
+          no_tools: [Bash]
+          output: ["pytest\\.fixture"]
+          no_output: ["setUp\\("]
+
+``SkillTester`` runs every case in its own temporary working directory
+with SKILL.md in the system prompt, then checks the tool calls and the final
+answer against the expectations. ``claude-mpm skills test`` reports the
+results, optionally as JSON or JUnit XML, and exits non-zero on failure.
+
+WHY: Skills in a shared repository were only checked by hand, so a change
+that stopped a skill from steering the agent went unnoticed until someone
+hit it. Example prompts with assertions make that a CI failure.
+
+DESIGN DECISIONS:
+- Tool calls outside ``allow_tools`` are denied but still recorded, so a
+  case can assert that the agent reaches for Edit or Bash without the run
+  changing anything; the working directory is thrown away either way
+- Tool expectations name a tool, optionally with a regex matched against
+  the JSON of its input; output expectations are regexes (case-sensitive,
+  multiline) matched against the final answer
+- Runs go through the SDK runtime, the only one that reports tool calls;
+  the runtime factory is injectable so the harness itself is testable
+- Cases run concurrently, bounded by ``jobs``
+"""
+
+from __future__ import annotations
+
+import asyncio
+import json
+import re
+import shutil
+import tempfile
+from collections.abc import Callable
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any
+from xml.etree import ElementTree as ET
+
+import yaml
+
+from ...core.logger import get_logger
+from ..agents.agent_runtime import AgentConfig, AgentResult
+from .skill_discovery_service import SkillDiscoveryService
+
+logger = get_logger(__name__)
+
+TESTS_FILE = "tests.yaml"
+DEFAULT_ALLOW_TOOLS = ("Read", "Glob", "Grep", "LS")
+DEFAULT_MAX_TURNS = 8
+
+SYSTEM_PROMPT = """\
+You are working in a project directory with the following skill loaded.
+Follow it whenever it applies to the request. Files the skill refers to
+are in {skill_dir}.
+
+<skill name="{name}">
+{content}
+</skill>
+"""
+
+
+class SkillTestError(Exception):
+    """A tests.yaml that cannot be used."""
+
+
+@dataclass
+class ToolExpectation:
+    """A tool the agent must (or must not) call, optionally with some input."""
+
+    tool: str
+    input: str | None = None  # Regex over the JSON of the tool input
+
+    def matches(self, call: dict[str, Any]) -> bool:
+        if call.get("tool_name") != self.tool:
+            return False
+        if self.input is None:
+            return True
+        text = json.dumps(call.get("input") or {}, sort_keys=True)
+        return re.search(self.input, text) is not None
+
+    def __str__(self) -> str:
+        return self.tool if self.input is None else f"{self.tool} /{self.input}/"
+
+
+@dataclass
+class SkillTestCase:
+    """One example prompt and what should come of it."""
+
+    name: str
+    prompt: str
+    tools: list[ToolExpectation] = field(default_factory=list)
+    no_tools: list[ToolExpectation] = field(default_factory=list)
+    output: list[str] = field(default_factory=list)
+    no_output: list[str] = field(default_factory=list)
+    fixture: Path | None = None
+    allow_tools: tuple[str, ...] = DEFAULT_ALLOW_TOOLS
+    model: str | None = None
+    max_turns: int = DEFAULT_MAX_TURNS
+
+    def check(self, result: AgentResult) -> list[str]:
+        """The expectations *result* does not meet."""
+        failures = []
+        calls = result.tool_calls or []
+        for expected in self.tools:
+            if not any(expected.matches(call) for call in calls):
+                failures.append(f"expected a {expected} call")
+        for unwanted in self.no_tools:
+            if any(unwanted.matches(call) for call in calls):
+                failures.append(f"unexpected {unwanted} call")
+        text = result.text or ""
+        for pattern in self.output:
+            if not re.search(pattern, text, re.MULTILINE):
+                failures.append(f"output does not match /{pattern}/")
+        for pattern in self.no_output:
+            if re.search(pattern, text, re.MULTILINE):
+                failures.append(f"output matches /{pattern}/")
+        return failures
+
+
+@dataclass
+class CaseResult:
+    """How one case went."""
+
+    name: str
+    failures: list[str] = field(default_factory=list)
+    error: str | None = None
+    tools_called: list[str] = field(default_factory=list)
+    output: str = ""
+    cost_usd: float | None = None
+    duration_ms: int | None = None
+
+    @property
+    def passed(self) -> bool:
+        return self.error is None and not self.failures
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "name": self.name,
+            "passed": self.passed,
+            "failures": self.failures,
+            "error": self.error,
+            "tools_called": self.tools_called,
+            "output": self.output,
+            "cost_usd": self.cost_usd,
+            "duration_ms": self.duration_ms,
+        }
+
+
+@dataclass
+class SkillTestReport:
+    """The results of one skill's tests."""
+
+    skill: str
+    skill_dir: Path
+    results: list[CaseResult] = field(default_factory=list)
+
+    @property
+    def failed(self) -> list[CaseResult]:
+        return [result for result in self.results if not result.passed]
+
+    @property
+    def passed(self) -> bool:
+        return not self.failed
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "skill": self.skill,
+            "path": str(self.skill_dir),
+            "passed": self.passed,
+            "cases": [result.to_dict() for result in self.results],
+        }
+
+
+def _tool_expectations(value: Any, where: str) -> list[ToolExpectation]:
+    expectations = []
+    for item in value or []:
+        if isinstance(item, str):
+            expectations.append(ToolExpectation(item))
+        elif isinstance(item, dict) and isinstance(item.get("tool"), str):
+            pattern = item.get("input")
+            if pattern is not None:
+                pattern = _patterns(str(pattern), where)[0]
+            expectations.append(ToolExpectation(item["tool"], pattern))
+        else:
+            raise SkillTestError(f"{where}: expected a tool name or {{tool: ...}}")
+    return expectations
+
+
+def _patterns(value: Any, where: str) -> list[str]:
+    patterns = [value] if isinstance(value, str) else list(value or [])
+    for pattern in patterns:
+        try:
+            re.compile(pattern)
+        except (re.error, TypeError) as e:
+            raise SkillTestError(f"{where}: bad pattern {pattern!r}: {e}") from None
+    return patterns
+
+
+def load_test_cases(skill_dir: Path) -> list[SkillTestCase]:
+    """Read the cases in *skill_dir*'s tests.yaml.
+
+    Raises:
+        SkillTestError: If the file is missing or malformed
+    """
+    path = Path(skill_dir) / TESTS_FILE
+    if not path.is_file():
+        raise SkillTestError(f"No {TESTS_FILE} in {skill_dir}")
+    try:
+        data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+    except yaml.YAMLError as e:
+        raise SkillTestError(f"{path}: {e}") from None
+    if isinstance(data, list):
+        data = {"cases": data}
+    if not isinstance(data, dict) or not isinstance(data.get("cases"), list):
+        raise SkillTestError(f"{path}: expected a list of cases")
+
+    defaults = {
+        "allow_tools": tuple(data.get("allow_tools") or DEFAULT_ALLOW_TOOLS),
+        "model": data.get("model"),
+        "max_turns": int(data.get("max_turns") or DEFAULT_MAX_TURNS),
+    }
+    cases = []
+    for index, raw in enumerate(data["cases"], 1):
+        if not isinstance(raw, dict) or not raw.get("prompt"):
+            raise SkillTestError(f"{path}: case {index} has no prompt")
+        name = str(raw.get("name") or f"case {index}")
+        where = f"{path.name}: {name}"
+        expect = raw.get("expect") or {}
+        fixture = None
+        if raw.get("fixture"):
+            fixture = (Path(skill_dir) / raw["fixture"]).resolve()
+            if not fixture.is_dir():
+                raise SkillTestError(f"{where}: no fixture directory {fixture}")
+        cases.append(
+            SkillTestCase(
+                name=name,
+                prompt=str(raw["prompt"]),
+                tools=_tool_expectations(expect.get("tools"), where),
+                no_tools=_tool_expectations(expect.get("no_tools"), where),
+                output=_patterns(expect.get("output"), where),
+                no_output=_patterns(expect.get("no_output"), where),
+                fixture=fixture,
+                allow_tools=tuple(raw.get("allow_tools") or defaults["allow_tools"]),
+                model=raw.get("model") or defaults["model"],
+                max_turns=int(raw.get("max_turns") or defaults["max_turns"]),
+            )
+        )
+    return cases
+
+
+def find_tested_skills(path: Path) -> list[Path]:
+    """Skill directories at or below *path* that have a tests.yaml."""
+    from .skill_linter import find_skill_dirs
+
+    return [d for d in find_skill_dirs(Path(path)) if (d / TESTS_FILE).is_file()]
+
+
+def _default_runtime_factory(config: AgentConfig):
+    from ..agents.agent_runtime import create_runtime
+
+    return create_runtime("sdk", config)
+
+
+class SkillTester:
+    """Runs the cases of one skill."""
+
+    def __init__(
+        self,
+        skill_dir: Path,
+        runtime_factory: Callable[[AgentConfig], Any] | None = None,
+        model: str | None = None,
+        jobs: int = 4,
+    ):
+        self.skill_dir = Path(skill_dir).resolve()
+        self.runtime_factory = runtime_factory or _default_runtime_factory
+        self.model = model
+        self.jobs = max(1, jobs)
+        raw = (self.skill_dir / "SKILL.md").read_text(encoding="utf-8")
+        parse = SkillDiscoveryService(self.skill_dir)._extract_frontmatter
+        try:
+            meta, _body = parse(raw)
+        except ValueError:
+            meta = {}
+        self.name = str((meta or {}).get("name") or self.skill_dir.name)
+        self.system_prompt = SYSTEM_PROMPT.format(
+            skill_dir=self.skill_dir, name=self.name, content=raw.strip()
+        )
+
+    def run(self, only: list[str] | None = None) -> SkillTestReport:
+        """Run the skill's cases, or those whose names contain one of *only*."""
+        cases = load_test_cases(self.skill_dir)
+        if only:
+            cases = [c for c in cases if any(o in c.name for o in only)]
+        results = asyncio.run(self._run_all(cases))
+        return SkillTestReport(self.name, self.skill_dir, results)
+
+    async def _run_all(self, cases: list[SkillTestCase]) -> list[CaseResult]:
+        semaphore = asyncio.Semaphore(self.jobs)
+
+        async def one(case: SkillTestCase) -> CaseResult:
+            async with semaphore:
+                return await self.run_case(case)
+
+        return list(await asyncio.gather(*(one(case) for case in cases)))
+
+    async def run_case(self, case: SkillTestCase) -> CaseResult:
+        """Run one case in a scratch copy of its fixture."""
+        with tempfile.TemporaryDirectory(prefix="skill-test-") as scratch:
+            workdir = Path(scratch) / "project"
+            if case.fixture:
+                shutil.copytree(case.fixture, workdir)
+            else:
+                workdir.mkdir()
+            config = AgentConfig(
+                system_prompt=self.system_prompt,
+                model=self.model or case.model,
+                cwd=str(workdir),
+                max_turns=case.max_turns,
+            )
+            allowed = set(case.allow_tools)
+
+            async def guard(tool_name: str, _tool_input: dict[str, Any]) -> bool:
+                return tool_name in allowed
+
+            try:
+                runtime = self.runtime_factory(config)
+                result = await runtime.run_with_hooks(
+                    case.prompt, tool_guard=guard, config=config
+                )
+            except Exception as e:
+                logger.debug(f"Skill test {case.name} did not run", exc_info=True)
+                return CaseResult(case.name, error=str(e) or type(e).__name__)
+
+        outcome = CaseResult(
+            case.name,
+            tools_called=[call.get("tool_name", "") for call in result.tool_calls],
+            output=result.text or "",
+            cost_usd=result.cost_usd,
+            duration_ms=result.duration_ms,
+        )
+        if result.is_error:
+            outcome.error = f"agent error: {(result.text or '')[:200]}"
+        else:
+            outcome.failures = case.check(result)
+        return outcome
+
+
+def junit_xml(reports: list[SkillTestReport]) -> str:
+    """The reports as JUnit XML, one test suite per skill."""
+    suites = ET.Element("testsuites")
+    for report in reports:
+        suite = ET.SubElement(
+            suites,
+            "testsuite",
+            name=report.skill,
+            tests=str(len(report.results)),
+            failures=str(sum(bool(r.failures) for r in report.results)),
+            errors=str(sum(r.error is not None for r in report.results)),
+        )
+        for result in report.results:
+            case = ET.SubElement(
+                suite,
+                "testcase",
+                classname=report.skill,
+                name=result.name,
+                time=f"{(result.duration_ms or 0) / 1000:.3f}",
+            )
+            if result.error is not None:
+                ET.SubElement(case, "error", message=result.error)
+            elif result.failures:
+                failure = ET.SubElement(case, "failure", message=result.failures[0])
+                failure.text = "\n".join(result.failures)
+            if result.output:
+                ET.SubElement(case, "system-out").text = result.output
+    ET.indent(suites)
+    return ET.tostring(suites, encoding="unicode", xml_declaration=True) + "\n"
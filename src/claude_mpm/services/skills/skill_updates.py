@@ -0,0 +1,454 @@
+"""Find deployed skills whose upstream source has moved.
+
+WHAT: ``check_skill_updates`` compares, for every enabled git skill source,
+the commit the deployed skills came from with the head of the source's
+branch. For each deployed skill whose directory changed in between it
+reports the deployed and upstream versions, the files that changed and the
+commits that touched the skill. ``claude-mpm skills outdated`` prints the
+report; the startup notice ("3 skills have updates") reads the last report
+saved by a background check.
+
+The commit the skills came from is the source's pin in the project's
+``skills.lock`` when there is one, else the commit its cache was last
+synced to.
+
+WHY: Sources move without telling anyone. With a skills.lock the project
+stays on its pinned commits, and without one the skills deployed yesterday
+are whatever the branch was then; either way there was no way to know that
+a skill had a fix upstream.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    skills:
+      update_notice: true    # check in the background, print a startup notice
+
+DESIGN DECISIONS:
+- Only skills that are deployed (to the project or ~/.claude/skills) and
+  that the source wins priority resolution for are reported
+- Upstream is read through the service's API (GitHub, GitLab, Bitbucket);
+  SSH sources fetch into their cached clone without touching its checkout
+- Local directory sources are skipped: they have no upstream
+- A source that cannot be checked is reported with its error; the others are
+  still checked
+- The startup check runs at most once a day, in the background, and the
+  notice shows the previous check's result, so startup never waits on the
+  network
+"""
+
+from __future__ import annotations
+
+import json
+import threading
+from collections.abc import Callable
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.core.state_files import read_json, write_atomic
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "skills.update_notice"
+REPORT_FILE = "skill-updates.json"
+COMMIT_LIMIT = 10
+GIT_FETCH_DEPTH = 200
+
+
+@dataclass
+class SkillUpdate:
+    """A deployed skill that changed upstream."""
+
+    name: str
+    source_id: str
+    path: str  # directory in the source repository
+    deployed_version: str | None = None
+    latest_version: str | None = None
+    files: list[str] = field(default_factory=list)
+    commits: list[str] = field(default_factory=list)  # "abc1234 subject"
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+@dataclass
+class SourceStatus:
+    """How far one source's upstream is ahead of the deployed commit."""
+
+    source_id: str
+    current: str | None = None
+    latest: str | None = None
+    updates: list[SkillUpdate] = field(default_factory=list)
+    error: str | None = None
+
+    @property
+    def moved(self) -> bool:
+        return bool(self.current and self.latest and self.current != self.latest)
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "source_id": self.source_id,
+            "current": self.current,
+            "latest": self.latest,
+            "updates": [update.to_dict() for update in self.updates],
+            "error": self.error,
+        }
+
+
+@dataclass
+class UpdateReport:
+    sources: list[SourceStatus] = field(default_factory=list)
+    checked_at: str = field(default_factory=lambda: datetime.now(UTC).isoformat())
+
+    @property
+    def updates(self) -> list[SkillUpdate]:
+        return [update for source in self.sources for update in source.updates]
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "checked_at": self.checked_at,
+            "sources": [source.to_dict() for source in self.sources],
+        }
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> UpdateReport:
+        sources = []
+        for entry in data.get("sources", []):
+            updates = [SkillUpdate(**update) for update in entry.get("updates", [])]
+            sources.append(
+                SourceStatus(
+                    source_id=entry["source_id"],
+                    current=entry.get("current"),
+                    latest=entry.get("latest"),
+                    updates=updates,
+                    error=entry.get("error"),
+                )
+            )
+        return cls(sources=sources, checked_at=data.get("checked_at", ""))
+
+
+# ---------------------------------------------------------------------------
+# Upstream access
+# ---------------------------------------------------------------------------
+
+
+class GitHubUpstream:
+    """Files and commits between two commits of a GitHub source."""
+
+    def __init__(self, source: SkillSource):
+        from .git_skill_source_manager import _get_github_token, _github_owner_repo
+
+        self.owner_repo = _github_owner_repo(source.url)
+        self.headers = {"Accept": "application/vnd.github+json"}
+        if token := _get_github_token(source):
+            self.headers["Authorization"] = f"token {token}"
+        self._compare: dict[tuple[str, str], dict[str, Any]] = {}
+
+    def _get(self, url: str, **kwargs):
+        from claude_mpm.services import http_client
+
+        response = http_client.get(url, headers=self.headers, timeout=30, **kwargs)
+        response.raise_for_status()
+        return response
+
+    def _comparison(self, base: str, head: str) -> dict[str, Any]:
+        if (base, head) not in self._compare:
+            url = (
+                f"https://api.github.com/repos/{self.owner_repo}/compare/"
+                f"{base}...{head}"
+            )
+            self._compare[base, head] = self._get(url).json()
+        return self._compare[base, head]
+
+    def changed_files(self, base: str, head: str) -> list[str]:
+        files = set()
+        for entry in self._comparison(base, head).get("files", []):
+            files.add(entry["filename"])
+            if entry.get("previous_filename"):
+                files.add(entry["previous_filename"])
+        return sorted(files)
+
+    def commits(
+        self, base: str, head: str, path: str, limit: int = COMMIT_LIMIT
+    ) -> list[tuple[str, str]]:
+        # The commits API filters by path but not by range; keep the ones
+        # the comparison says are after base
+        in_range = {c["sha"] for c in self._comparison(base, head).get("commits", [])}
+        response = self._get(
+            f"https://api.github.com/repos/{self.owner_repo}/commits",
+            params={"sha": head, "path": path, "per_page": limit},
+        )
+        return [
+            (c["sha"], next(iter(c["commit"]["message"].splitlines()), ""))
+            for c in response.json()
+            if c["sha"] in in_range
+        ]
+
+    def fetch_file(self, ref: str, path: str) -> bytes:
+        url = f"https://raw.githubusercontent.com/{self.owner_repo}/{ref}/{path}"
+        return self._get(url).content
+
+
+class GitCloneUpstream:
+    """Files and commits of an SSH source, read from its cached clone.
+
+    The branch is fetched into the clone without moving its checkout, so the
+    deployed commit stays what the cache holds.
+    """
+
+    def __init__(self, source: SkillSource, clone: Path):
+        from .git_skill_source_manager import _git_ssh_env
+
+        self.source = source
+        self.clone = clone
+        self.env = _git_ssh_env(source)
+        self._fetched = False
+
+    def _git(self, *args: str) -> str:
+        from .git_skill_source_manager import _run_git
+
+        if not self._fetched:
+            depth = str(GIT_FETCH_DEPTH)
+            fetch = ["fetch", "--depth", depth, "origin", self.source.branch]
+            _run_git(fetch, self.env, self.clone)
+            self._fetched = True
+        return _run_git(list(args), self.env, self.clone)
+
+    def changed_files(self, base: str, head: str) -> list[str]:
+        return sorted(set(self._git("diff", "--name-only", base, head).splitlines()))
+
+    def commits(
+        self, base: str, head: str, path: str, limit: int = COMMIT_LIMIT
+    ) -> list[tuple[str, str]]:
+        span = f"{base}..{head}"
+        log = self._git("log", f"-n{limit}", "--format=%H %s", span, "--", path)
+        return [tuple(line.split(" ", 1)) for line in log.splitlines() if line]
+
+    def fetch_file(self, ref: str, path: str) -> bytes:
+        return self._git("show", f"{ref}:{path}").encode()
+
+
+def upstream_for(source: SkillSource, cache_path: Path):
+    """The upstream reader for *source*, or None for local directories and
+    registries."""
+    from .git_hosts import get_host
+
+    if not source.is_git:
+        return None
+    if source.is_ssh:
+        return GitCloneUpstream(source, cache_path)
+    return get_host(source) or GitHubUpstream(source)
+
+
+# ---------------------------------------------------------------------------
+# Checking
+# ---------------------------------------------------------------------------
+
+
+def _version(skill_md: str) -> str | None:
+    from claude_mpm.services.agents.playground import split_frontmatter
+
+    frontmatter, _ = split_frontmatter(skill_md)
+    try:
+        meta = yaml.safe_load(frontmatter.strip().strip("-")) or {}
+    except yaml.YAMLError:
+        return None
+    if not isinstance(meta, dict):
+        return None
+    version = meta.get("version") or meta.get("skill_version")
+    return str(version) if version else None
+
+
+def deployed_skill_dirs(project_dir: Path | None = None) -> dict[str, Path]:
+    """Deployed skill directories by name; project skills shadow user ones."""
+    dirs: dict[str, Path] = {}
+    for root in (Path.home(), Path(project_dir or Path.cwd())):
+        skills_dir = root / ".claude" / "skills"
+        if not skills_dir.is_dir():
+            continue
+        for skill_dir in skills_dir.iterdir():
+            if (skill_dir / "SKILL.md").is_file():
+                dirs[skill_dir.name] = skill_dir
+    return dirs
+
+
+def check_skill_updates(
+    source_ids: list[str] | None = None,
+    config: SkillSourceConfiguration | None = None,
+    project_dir: Path | None = None,
+    cache_dir: Path | None = None,
+    resolve_head: Callable[[SkillSource], str] | None = None,
+    upstream_factory: Callable[[SkillSource, Path], Any] = upstream_for,
+) -> UpdateReport:
+    """Report deployed skills that changed upstream since they were synced.
+
+    Args:
+        source_ids: Check only these sources (default: all enabled)
+        config: Skill source configuration (defaults to the user's)
+        project_dir: Project whose skills.lock and deployed skills to use
+        cache_dir: Skill cache (defaults to ~/.claude-mpm/cache/skills/)
+        resolve_head: Resolves a source's branch head (injected for testing)
+        upstream_factory: Builds a source's upstream reader (injected for
+            testing)
+    """
+    from .git_skill_source_manager import GitSkillSourceManager, resolve_source_commit
+    from .skills_lock import SkillsLock
+
+    config = config or SkillSourceConfiguration()
+    project_dir = Path(project_dir or Path.cwd())
+    lock = SkillsLock(project_dir)
+    manager = GitSkillSourceManager(config, cache_dir=cache_dir)
+    resolve_head = resolve_head or resolve_source_commit
+
+    deployed = deployed_skill_dirs(project_dir)
+    winners = {}
+    for skill in manager.get_all_skills():
+        if skill.get("deployment_name"):
+            winners[_deployment_name(skill)] = skill["source_id"]
+
+    report = UpdateReport()
+    for source in sorted(config.get_enabled_sources(), key=lambda s: s.priority):
+        if not source.is_git or (source_ids and source.id not in source_ids):
+            continue
+        status = SourceStatus(source.id)
+        report.sources.append(status)
+        pinned = lock.get(source) if lock.exists() else None
+        status.current = pinned.commit if pinned else manager.synced_commit(source)
+        if status.current is None:
+            status.error = "not synced yet"
+            continue
+        try:
+            status.latest = resolve_head(source)
+            if not status.moved:
+                continue
+            cache_path = manager._get_source_cache_path(source)
+            upstream = upstream_factory(source, cache_path)
+            changed = upstream.changed_files(status.current, status.latest)
+            for skill in manager.get_skills_by_source(source.id):
+                name = _deployment_name(skill)
+                if name not in deployed or winners.get(name) != source.id:
+                    continue
+                update = _skill_update(
+                    upstream, status, skill, name, cache_path, changed, deployed[name]
+                )
+                if update is not None:
+                    status.updates.append(update)
+        except Exception as e:
+            logger.debug(f"Could not check skill source {source.id}", exc_info=True)
+            status.error = str(e)
+    return report
+
+
+def _deployment_name(skill: dict[str, Any]) -> str:
+    from .selective_skill_deployer import sanitize_skill_name_for_deployment
+
+    return sanitize_skill_name_for_deployment(str(skill.get("deployment_name", "")))
+
+
+def _skill_update(
+    upstream,
+    status: SourceStatus,
+    skill: dict[str, Any],
+    name: str,
+    cache_path: Path,
+    changed: list[str],
+    deployed_dir: Path,
+) -> SkillUpdate | None:
+    skill_dir = Path(skill["source_file"]).parent.relative_to(cache_path).as_posix()
+    prefix = "" if skill_dir == "." else f"{skill_dir}/"
+    files = [path[len(prefix) :] for path in changed if path.startswith(prefix)]
+    if not files:
+        return None
+
+    update = SkillUpdate(name=name, source_id=status.source_id, path=skill_dir)
+    update.files = files
+    update.deployed_version = _version(
+        (deployed_dir / "SKILL.md").read_text(encoding="utf-8", errors="replace")
+    )
+    try:
+        latest = upstream.fetch_file(status.latest, f"{prefix}SKILL.md")
+        update.latest_version = _version(latest.decode("utf-8", errors="replace"))
+    except Exception as e:
+        logger.debug(f"Could not read upstream SKILL.md of {name}: {e}")
+    try:
+        commits = upstream.commits(status.current, status.latest, skill_dir)
+        update.commits = [f"{sha[:7]} {subject}" for sha, subject in commits]
+    except Exception as e:
+        logger.debug(f"Could not read upstream commits of {name}: {e}")
+    return update
+
+
+# ---------------------------------------------------------------------------
+# Startup notice
+# ---------------------------------------------------------------------------
+
+
+def report_path() -> Path:
+    return Path.home() / ".claude-mpm" / "cache" / REPORT_FILE
+
+
+def save_report(report: UpdateReport, project_dir: Path | None = None) -> None:
+    """Store the report for *project_dir*, next to other projects' reports."""
+    path = report_path()
+    reports = read_json(path, {})
+    if not isinstance(reports, dict):
+        reports = {}
+    reports[str(Path(project_dir or Path.cwd()).resolve())] = report.to_dict()
+    write_atomic(path, json.dumps(reports, indent=2) + "\n")
+
+
+def load_report(project_dir: Path | None = None) -> UpdateReport | None:
+    """The last saved report for *project_dir*, if any."""
+    reports = read_json(report_path(), {})
+    if not isinstance(reports, dict):
+        return None
+    data = reports.get(str(Path(project_dir or Path.cwd()).resolve()))
+    try:
+        return UpdateReport.from_dict(data) if data else None
+    except (KeyError, TypeError) as e:
+        logger.debug(f"Ignoring unreadable skill update report: {e}")
+        return None
+
+
+def update_notice(report: UpdateReport | None) -> str | None:
+    """One line for the startup banner, or None when nothing has updates."""
+    if report is None or not report.updates:
+        return None
+    count = len(report.updates)
+    noun = "skill has" if count == 1 else "skills have"
+    return f"{count} {noun} updates; run 'claude-mpm skills outdated' for details"
+
+
+def notice_enabled(config: Any = None) -> bool:
+    try:
+        if config is None:
+            from claude_mpm.core.config import Config
+
+            config = Config()
+        return bool(config.get(CONFIG_KEY, True))
+    except Exception as e:
+        logger.debug(f"Could not read {CONFIG_KEY}: {e}")
+        return True
+
+
+def refresh_report(project_dir: Path | None = None) -> None:
+    """Check for updates and save the report; never raises."""
+    try:
+        save_report(check_skill_updates(project_dir=project_dir), project_dir)
+    except Exception as e:
+        logger.debug(f"Skill update check failed: {e}")
+
+
+def refresh_report_in_background(project_dir: Path | None = None) -> threading.Thread:
+    thread = threading.Thread(
+        target=refresh_report,
+        args=(project_dir,),
+        name="skill-updates-check",
+        daemon=True,
+    )
+    thread.start()
+    return thread
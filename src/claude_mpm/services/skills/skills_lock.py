@@ -0,0 +1,124 @@
+"""Skill source lockfile pinning each source to a commit.
+
+WHAT: ``.claude-mpm/skills.lock`` records, per skill source, the commit its
+branch resolved to when the source was added or last updated::
+
+    {
+      "version": 1,
+      "sources": {
+        "system": {
+          "url": "https://github.com/bobmatnyc/claude-mpm-skills",
+          "branch": "main",
+          "commit": "3f9c2e1...",
+          "locked_at": "2026-10-17T09:30:00+00:00"
+        }
+      }
+    }
+
+Syncs that are given the lock (``skills deploy``, ``skill-source update``,
+the startup sync) fetch the locked commit instead of the branch head; a
+source without an entry is pinned to its current head on first sync. Only
+``skills update`` moves a pin forward.
+
+WHY: Every sync pulled the branch head, so two machines deploying the same
+project a day apart could get different skills. Committing the lock makes
+deployments reproducible across a team.
+
+DESIGN DECISIONS:
+- The lock is per project, next to the project's other ``.claude-mpm``
+  state, so it can be committed with the code that relies on the skills
+- An entry whose URL or branch no longer matches the configured source is
+  ignored and re-pinned, so editing a source never syncs the old repository
+- Writes go through ``update_json``, so concurrent syncs of different
+  sources do not drop each other's pins
+"""
+
+from __future__ import annotations
+
+from dataclasses import asdict, dataclass
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.config.skill_sources import SkillSource
+from claude_mpm.core.state_files import read_json, update_json
+
+LOCK_FILE = "skills.lock"
+LOCK_VERSION = 1
+
+
+@dataclass(frozen=True)
+class LockedSource:
+    """The commit a skill source is pinned to."""
+
+    url: str
+    branch: str
+    commit: str
+    locked_at: str
+
+    def matches(self, source: SkillSource) -> bool:
+        return (self.url, self.branch) == (source.url, source.branch)
+
+
+def lock_path(project_root: Path | None = None) -> Path:
+    return Path(project_root or Path.cwd()) / ".claude-mpm" / LOCK_FILE
+
+
+class SkillsLock:
+    """Reads and writes one project's skills.lock."""
+
+    def __init__(self, project_root: Path | None = None):
+        self.path = lock_path(project_root)
+
+    def exists(self) -> bool:
+        return self.path.exists()
+
+    def entries(self) -> dict[str, LockedSource]:
+        data = read_json(self.path, {})
+        sources = data.get("sources") if isinstance(data, dict) else None
+        if not isinstance(sources, dict):
+            return {}
+        entries = {}
+        for source_id, entry in sources.items():
+            try:
+                entries[source_id] = LockedSource(**entry)
+            except TypeError:
+                continue
+        return entries
+
+    def get(self, source: SkillSource) -> LockedSource | None:
+        """The pin for *source*, or None if it is unpinned or stale."""
+        entry = self.entries().get(source.id)
+        if entry is None or not entry.matches(source):
+            return None
+        return entry
+
+    def pin(self, source: SkillSource, commit: str) -> LockedSource:
+        entry = LockedSource(
+            url=source.url,
+            branch=source.branch,
+            commit=commit,
+            locked_at=datetime.now(UTC).isoformat(),
+        )
+
+        def record(data: dict[str, Any]) -> None:
+            data["version"] = LOCK_VERSION
+            if not isinstance(data.get("sources"), dict):
+                data["sources"] = {}
+            data["sources"][source.id] = asdict(entry)
+            data["sources"] = dict(sorted(data["sources"].items()))
+
+        update_json(self.path, record)
+        return entry
+
+    def unpin(self, source_id: str) -> bool:
+        removed = []
+
+        def drop(data: dict[str, Any]) -> None:
+            sources = data.get("sources")
+            if isinstance(sources, dict) and sources.pop(source_id, None):
+                removed.append(source_id)
+
+        if self.exists():
+            update_json(self.path, drop)
+        return bool(removed)
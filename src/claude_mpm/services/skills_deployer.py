@@ -6,6 +6,8 @@ deploying them to Claude Code's skills directory with automatic restart warnings
 
 DESIGN DECISIONS:
 - Downloads from https://github.com/bobmatnyc/claude-mpm-skills by default
+- Collections are skill sources: they are synced into the skill source cache
+  (~/.claude-mpm/cache/skills/<id>) and managed in skill_sources.yaml
 - Deploys to ~/.claude/skills/ (Claude Code's directory), NOT project directory
 - Integrates with ToolchainAnalyzer for automatic language detection
 - Handles Claude Code restart requirement (skills only load at startup)
@@ -13,7 +15,7 @@ DESIGN DECISIONS:
 - Graceful error handling with actionable messages
 
 ARCHITECTURE:
-1. GitHub Download: Fetch ZIP archive from repository
+1. Source Sync: Sync the skill source into its cache
 2. Manifest Parsing: Read skill metadata from manifest.json
 3. Filtering: Apply toolchain and category filters
 4. Deployment: Copy skills to ~/.claude/skills/
@@ -28,10 +30,12 @@ References:
 import json
 import platform
 import shutil
-import subprocess  # nosec B404 - subprocess needed for safe git operations
+import subprocess  # nosec B404 - subprocess needed to detect Claude Code
+from datetime import UTC, datetime
 from pathlib import Path
 from typing import Any
 
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
 from claude_mpm.core.mixins import LoggerMixin
 from claude_mpm.services.skills_config import SkillsConfig
 
@@ -72,6 +76,7 @@ class SkillsDeployerService(LoggerMixin):
         self.repo_url = repo_url or self.DEFAULT_REPO_URL
         self.toolchain_analyzer = toolchain_analyzer
         self.skills_config = SkillsConfig()
+        self.source_config = SkillSourceConfiguration()
 
         # Ensure Claude skills directory exists
         self.CLAUDE_SKILLS_DIR.mkdir(parents=True, exist_ok=True)
@@ -86,6 +91,7 @@ class SkillsDeployerService(LoggerMixin):
         project_root: Path | None = None,
         skill_names: list[str] | None = None,
         skills_dir: Path | None = None,
+        mark_requested: bool = False,
     ) -> dict:
         """Deploy skills from GitHub repository.
 
@@ -105,8 +111,11 @@ class SkillsDeployerService(LoggerMixin):
             force: Overwrite existing skills
             selective: If True, only deploy skills referenced by agents (default)
             project_root: Project root directory (for finding agents, auto-detected if None)
-            skill_names: Specific skill names to deploy (overrides selective filtering)
+            skill_names: Skill names or globs to deploy (overrides selective
+                filtering; orphan cleanup is skipped)
             skills_dir: Target directory for deployed skills (default: ~/.claude/skills/)
+            mark_requested: Record the skills selected by skill_names as
+                user-requested, so later selective deployments keep them
 
         Returns:
             Dict containing:
@@ -119,6 +128,7 @@ class SkillsDeployerService(LoggerMixin):
             - collection: Collection name used for deployment
             - selective_mode: True if selective deployment was used
             - total_available: Total skills available before filtering
+            - unmatched: skill_names entries that matched no skill
 
         Example:
             >>> result = deployer.deploy_skills(collection="obra-superpowers")
@@ -127,6 +137,10 @@ class SkillsDeployerService(LoggerMixin):
             >>> result = deployer.deploy_skills(skills_dir=Path("project/.claude/skills"))
             >>> # Deploy all skills (not just agent-referenced)
             >>> result = deployer.deploy_skills(selective=False)
+            >>> # Deploy skills no agent references yet, and keep them
+            >>> result = deployer.deploy_skills(
+            ...     skill_names=["toolchains-python-*"], mark_requested=True
+            ... )
             >>> if result['restart_required']:
             >>>     print(result['restart_instructions'])
         """
@@ -180,15 +194,16 @@ class SkillsDeployerService(LoggerMixin):
             f" (toolchain={toolchain}, categories={categories})"
         )
 
-        # Step 3.5a: Filter by specific skill names if provided
+        # Step 3.5a: Filter by specific skill names (or globs) if provided
+        errors = []
+        unmatched: list[str] = []
         if skill_names:
-            skill_names_set = set(skill_names)
-            filtered_skills = [
-                skill
-                for skill in filtered_skills
-                if skill.get("name") in skill_names_set
-                or skill.get("skill_id") in skill_names_set
-            ]
+            from claude_mpm.services.skills.selective_skill_deployer import (
+                select_skills,
+            )
+
+            filtered_skills, unmatched = select_skills(filtered_skills, skill_names)
+            errors.extend(f"No skill matches '{name}'" for name in unmatched)
             self.logger.info(
                 f"After skill_names filtering: {len(filtered_skills)} skills to deploy"
             )
@@ -248,34 +263,18 @@ class SkillsDeployerService(LoggerMixin):
                     )
 
             if required_skill_names:
-                # Convert required_skill_names to a set for O(1) lookup
-                required_set = set(required_skill_names)
+                from claude_mpm.services.skills.selective_skill_deployer import (
+                    skill_matches,
+                )
 
                 # Filter to only required skills
                 # Match on: 'name', 'skill_id', or normalized 'source_path'
                 # source_path example: "universal/web/api-design-patterns/SKILL.md"
                 # normalized: "universal-web-api-design-patterns"
-                def skill_matches_requirement(skill):
-                    # Check basic name and skill_id
-                    if skill.get("name") in required_set:
-                        return True
-                    if skill.get("skill_id") in required_set:
-                        return True
-
-                    # Check normalized source_path
-                    source_path = skill.get("source_path", "")
-                    if source_path:
-                        # Remove /SKILL.md suffix and replace / with -
-                        normalized = source_path.replace("/SKILL.md", "").replace(
-                            "/", "-"
-                        )
-                        if normalized in required_set:
-                            return True
-
-                    return False
-
+                # Entries may also be globs ("toolchains-python-*")
+                required_set = set(required_skill_names)
                 filtered_skills = [
-                    s for s in filtered_skills if skill_matches_requirement(s)
+                    s for s in filtered_skills if skill_matches(s, required_set)
                 ]
 
                 self.logger.info(
@@ -295,7 +294,6 @@ class SkillsDeployerService(LoggerMixin):
         # Step 4: Deploy skills
         deployed = []
         skipped = []
-        errors = []
 
         # Create target directory if it doesn't exist
         target_skills_dir.mkdir(parents=True, exist_ok=True)
@@ -360,9 +358,21 @@ class SkillsDeployerService(LoggerMixin):
                 self.logger.error(f"Failed to deploy {skill_name}: {e}")
                 errors.append(f"{skill_name}: {e}")
 
-        # Step 5: Cleanup orphaned skills (always run in selective mode)
+        # Step 4.5: Keep explicitly selected skills through later selective runs
+        if skill_names and mark_requested:
+            from claude_mpm.services.skills.selective_skill_deployer import (
+                add_user_requested_skill,
+            )
+
+            for name in deployed + skipped:
+                try:
+                    add_user_requested_skill(name, target_skills_dir)
+                except Exception as e:
+                    self.logger.warning(f"Failed to record {name} as requested: {e}")
+
+        # Step 5: Cleanup orphaned skills (selective mode, nothing named)
         cleanup_result = {"removed_count": 0, "removed_skills": []}
-        if selective:
+        if selective and not skill_names:
             # Get the set of skills that should remain deployed
             # This is the union of what we just deployed and what was already there
             try:
@@ -426,6 +436,7 @@ class SkillsDeployerService(LoggerMixin):
             "collection": collection_name,
             "selective_mode": selective,
             "total_available": total_available,
+            "unmatched": unmatched,
             "cleanup": cleanup_result,
         }
 
@@ -637,119 +648,63 @@ class SkillsDeployerService(LoggerMixin):
             "errors": errors,
         }
 
+    def _source_manager(self, lock: Any | None = None):
+        from claude_mpm.services.skills.git_skill_source_manager import (
+            GitSkillSourceManager,
+        )
+
+        return GitSkillSourceManager(self.source_config, lock=lock)
+
     def _download_from_github(self, collection_name: str) -> dict:
-        """Download skills repository from GitHub using git clone/pull.
+        """Sync a skill source into its cache and read its manifest.
 
         Logic:
-        1. Get collection config from SkillsConfig
-        2. Determine target directory: ~/.claude/skills/{collection_name}/
-        3. Check if directory exists:
-           - Exists + is git repo → git pull (update)
-           - Exists + not git repo → error (manual cleanup needed)
-           - Not exists → git clone (first install)
-        4. Parse manifest.json from collection
-        5. Update last_update timestamp in config
-        6. Return skills data
+        1. Look up the source in skill_sources.yaml
+        2. Sync it into ~/.claude-mpm/cache/skills/{source_id}/, the same cache
+           ``skill-source update`` uses
+        3. Parse manifest.json from the cache
 
         Args:
-            collection_name: Name of collection to download
+            collection_name: ID of the skill source to download
 
         Returns:
             Dict containing:
-            - temp_dir: Path to collection directory (not temp, but kept for compatibility)
+            - temp_dir: Path to the source cache (not temp, kept for compatibility)
             - manifest: Parsed manifest.json
-            - repo_dir: Path to repository directory
+            - repo_dir: Path to the source cache
 
         Raises:
-            ValueError: If collection not found or disabled
-            Exception: If git operations fail
+            ValueError: If the source is not found or disabled
+            Exception: If the sync fails
         """
-        # Get collection configuration
-        collection_config = self.skills_config.get_collection(collection_name)
-        if not collection_config:
+        source = self.source_config.get_source(collection_name)
+        if source is None:
             raise ValueError(
-                f"Collection '{collection_name}' not found. "
-                f"Use 'claude-mpm skills collection add' to add it."
+                f"Skill source '{collection_name}' not found. "
+                f"Use 'claude-mpm skill-source add' to add it."
             )
 
-        if not collection_config.get("enabled", True):
+        if not source.enabled:
             raise ValueError(
-                f"Collection '{collection_name}' is disabled. "
-                f"Use 'claude-mpm skills collection enable {collection_name}' to enable it."
+                f"Skill source '{collection_name}' is disabled. "
+                f"Use 'claude-mpm skill-source enable {collection_name}' to enable it."
             )
 
-        repo_url = collection_config["url"]
-        target_dir = self.CLAUDE_SKILLS_DIR / collection_name
-
-        self.logger.info(f"Processing collection '{collection_name}' from {repo_url}")
-
-        # Check if directory exists and handle accordingly
-        if target_dir.exists():
-            git_dir = target_dir / ".git"
-
-            if git_dir.exists():
-                # Update existing: git pull
-                self.logger.info(
-                    f"Updating existing collection '{collection_name}' at {target_dir}"
-                )
-                try:
-                    result = subprocess.run(  # nosec B603 B607 - Safe: hardcoded git command
-                        ["git", "pull"],
-                        cwd=target_dir,
-                        capture_output=True,
-                        text=True,
-                        check=True,
-                        timeout=60,
-                    )
-                    self.logger.debug(f"Git pull output: {result.stdout}")
-
-                except subprocess.CalledProcessError as e:
-                    raise Exception(
-                        f"Failed to update collection '{collection_name}': {e.stderr}"
-                    ) from e
-                except subprocess.TimeoutExpired as e:
-                    raise Exception(
-                        f"Git pull timed out for collection '{collection_name}'"
-                    ) from e
-            else:
-                # Directory exists but not a git repo - error
-                raise ValueError(
-                    f"Directory {target_dir} exists but is not a git repository. "
-                    f"Please remove it manually and try again:\n"
-                    f"  rm -rf {target_dir}"
-                )
-        else:
-            # First install: git clone
-            self.logger.info(
-                f"Installing new collection '{collection_name}' to {target_dir}"
+        self.logger.info(f"Syncing skill source '{collection_name}' from {source.url}")
+        manager = self._source_manager()
+        result = manager.sync_source(collection_name)
+        if not result.get("synced"):
+            raise Exception(
+                f"Failed to sync skill source '{collection_name}': "
+                f"{result.get('error', 'unknown error')}"
             )
-            try:
-                result = subprocess.run(  # nosec B603 B607 - Safe: hardcoded git command
-                    ["git", "clone", repo_url, str(target_dir)],
-                    capture_output=True,
-                    text=True,
-                    check=True,
-                    timeout=120,
-                )
-                self.logger.debug(f"Git clone output: {result.stdout}")
 
-            except subprocess.CalledProcessError as e:
-                raise Exception(
-                    f"Failed to clone collection '{collection_name}': {e.stderr}"
-                ) from e
-            except subprocess.TimeoutExpired as e:
-                raise Exception(
-                    f"Git clone timed out for collection '{collection_name}'"
-                ) from e
-
-        # Update last_update timestamp
-        self.skills_config.update_collection_timestamp(collection_name)
-
-        # Parse manifest.json
+        target_dir = manager.cache_dir / collection_name
         manifest_path = target_dir / "manifest.json"
         if not manifest_path.exists():
             raise Exception(
-                f"manifest.json not found in collection '{collection_name}' at {target_dir}"
+                f"manifest.json not found in skill source '{collection_name}' "
+                f"at {target_dir}"
             )
 
         try:
@@ -757,15 +712,14 @@ class SkillsDeployerService(LoggerMixin):
                 manifest = json.load(f)
         except json.JSONDecodeError as e:
             raise Exception(
-                f"Invalid manifest.json in collection '{collection_name}': {e}"
+                f"Invalid manifest.json in skill source '{collection_name}': {e}"
             ) from e
 
         self.logger.info(
-            f"Successfully loaded collection '{collection_name}' from {target_dir}"
+            f"Successfully loaded skill source '{collection_name}' from {target_dir}"
         )
 
-        # Return data in same format as before for compatibility
-        # Note: temp_dir is now the persistent collection directory
+        # temp_dir is the persistent source cache
         return {"temp_dir": target_dir, "manifest": manifest, "repo_dir": target_dir}
 
     def _flatten_manifest_skills(self, manifest: dict) -> list[dict]:
@@ -1128,81 +1082,107 @@ class SkillsDeployerService(LoggerMixin):
         self.logger.debug(f"Collection directory preserved at {temp_dir} (not deleted)")
 
     # === Collection Management Methods ===
+    #
+    # Collections are skill sources: these methods keep the 'skills
+    # collection-*' commands working on skill_sources.yaml.
 
     def list_collections(self) -> dict[str, Any]:
-        """List all configured skill collections.
+        """List all configured skill sources as collections.
 
         Returns:
             Dict containing:
-            - collections: Dict of collection configurations
-            - default_collection: Name of default collection
-            - enabled_count: Number of enabled collections
+            - collections: Dict of source ID to url/enabled/priority/last_update
+            - default_collection: ID of the default source
+            - enabled_count: Number of enabled sources
+            - total_count: Number of sources
 
         Example:
             >>> result = deployer.list_collections()
             >>> for name, config in result['collections'].items():
-            >>>     print(f"{name}: {config['url']} (priority: {config['priority']})")
+            ...     print(f"{name}: {config['url']}")
         """
-        collections = self.skills_config.get_collections()
-        default = self.skills_config.get_default_collection()
-        enabled = self.skills_config.get_enabled_collections()
+        cache_dir = Path.home() / ".claude-mpm" / "cache" / "skills"
+        collections = {}
+        for source in self.source_config.load():
+            cache = cache_dir / source.id
+            last_update = None
+            if cache.exists():
+                mtime = datetime.fromtimestamp(cache.stat().st_mtime, UTC)
+                last_update = mtime.isoformat(timespec="seconds")
+            collections[source.id] = {
+                "url": source.url,
+                "enabled": source.enabled,
+                "priority": source.priority,
+                "last_update": last_update,
+            }
 
         return {
             "collections": collections,
-            "default_collection": default,
-            "enabled_count": len(enabled),
+            "default_collection": self.skills_config.get_default_collection(),
+            "enabled_count": sum(c["enabled"] for c in collections.values()),
             "total_count": len(collections),
         }
 
     def add_collection(self, name: str, url: str, priority: int = 99) -> dict[str, Any]:
-        """Add a new skill collection.
+        """Add a skill source.
 
         Args:
-            name: Collection name (must be unique)
-            url: GitHub repository URL
-            priority: Collection priority (lower = higher priority, default: 99)
+            name: Source ID
+            url: Repository URL
+            priority: Priority (lower = higher precedence)
 
         Returns:
             Dict with operation result
 
+        Raises:
+            ValueError: If the source is invalid or already exists
+
         Example:
             >>> deployer.add_collection("obra-superpowers", "https://github.com/obra/superpowers")
         """
-        return self.skills_config.add_collection(name, url, priority)
+        source = SkillSource(id=name, type="git", url=url, priority=priority)
+        self.source_config.add_source(source)
+        return {
+            "status": "success",
+            "message": f"Skill source '{name}' added successfully",
+        }
 
     def remove_collection(self, name: str) -> dict[str, Any]:
-        """Remove a skill collection.
+        """Remove a skill source, its cache and the skills only it provided.
 
         Args:
-            name: Collection name to remove
+            name: Source ID
 
         Returns:
-            Dict with operation result
+            Dict with operation result, "cache_removed" and "skills_removed"
+
+        Raises:
+            ValueError: If the source is not found or is the default
 
         Example:
             >>> deployer.remove_collection("obra-superpowers")
         """
-        result = self.skills_config.remove_collection(name)
+        if name == self.skills_config.get_default_collection():
+            raise ValueError(
+                f"Cannot remove default collection '{name}'. "
+                f"Set a different default collection first."
+            )
 
-        # Also remove the collection directory
-        collection_dir = self.CLAUDE_SKILLS_DIR / name
-        if collection_dir.exists():
-            try:
-                shutil.rmtree(collection_dir)
-                self.logger.info(f"Removed collection directory: {collection_dir}")
-                result["directory_removed"] = True
-            except Exception as e:
-                self.logger.warning(f"Failed to remove directory {collection_dir}: {e}")
-                result["directory_removed"] = False
-                result["directory_error"] = str(e)
+        from claude_mpm.services.skills.skills_lock import SkillsLock
 
+        manager = self._source_manager(lock=SkillsLock())
+        result = manager.remove_source(name, self.CLAUDE_SKILLS_DIR)
+        result.update(
+            status="success",
+            message=f"Skill source '{name}' removed successfully",
+        )
         return result
 
     def enable_collection(self, name: str) -> dict[str, Any]:
-        """Enable a disabled collection.
+        """Enable a disabled skill source.
 
         Args:
-            name: Collection name
+            name: Source ID
 
         Returns:
             Dict with operation result
@@ -1210,32 +1190,53 @@ class SkillsDeployerService(LoggerMixin):
         Example:
             >>> deployer.enable_collection("obra-superpowers")
         """
-        return self.skills_config.enable_collection(name)
+        self.source_config.update_source(name, enabled=True)
+        return {"status": "success", "message": f"Skill source '{name}' enabled"}
 
     def disable_collection(self, name: str) -> dict[str, Any]:
-        """Disable a collection without removing it.
+        """Disable a skill source without removing it.
 
         Args:
-            name: Collection name
+            name: Source ID
 
         Returns:
             Dict with operation result
 
+        Raises:
+            ValueError: If the source is not found or is the default
+
         Example:
             >>> deployer.disable_collection("obra-superpowers")
         """
-        return self.skills_config.disable_collection(name)
+        if name == self.skills_config.get_default_collection():
+            raise ValueError(
+                f"Cannot disable default collection '{name}'. "
+                f"Set a different default collection first."
+            )
+        self.source_config.update_source(name, enabled=False)
+        return {"status": "success", "message": f"Skill source '{name}' disabled"}
 
     def set_default_collection(self, name: str) -> dict[str, Any]:
-        """Set the default collection for deployments.
+        """Set the default skill source for deployments.
 
         Args:
-            name: Collection name to set as default
+            name: Source ID
 
         Returns:
             Dict with operation result
 
+        Raises:
+            ValueError: If the source is not found or disabled
+
         Example:
             >>> deployer.set_default_collection("obra-superpowers")
         """
+        source = self.source_config.get_source(name)
+        if source is None:
+            raise ValueError(f"Skill source '{name}' not found")
+        if not source.enabled:
+            raise ValueError(
+                f"Cannot set disabled skill source '{name}' as default. "
+                "Enable it first."
+            )
         return self.skills_config.set_default_collection(name)
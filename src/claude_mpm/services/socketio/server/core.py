@@ -36,6 +36,7 @@ from ....core.constants import SystemLimits, TimeoutConfig
 from ....core.logging_config import get_logger
 from ....core.unified_paths import get_project_root, get_scripts_dir
 from ...exceptions import SocketIOServerError as MPMConnectionError
+from ...monitor.scaling import ScalingConfig
 
 
 class SocketIOServerCore:
@@ -246,9 +247,13 @@ class SocketIOServerCore:
             assert socketio is not None, "socketio package is not available"
             assert web is not None, "aiohttp.web is not available"
 
+            # Share events with peer processes when running behind a balancer
+            scaling = ScalingConfig.load()
+
             # Create Socket.IO server with centralized configuration
             # CRITICAL: These values MUST match client settings to prevent disconnections
             self.sio = socketio.AsyncServer(
+                client_manager=scaling.client_manager(),
                 cors_allowed_origins="*",
                 logger=False,  # Disable Socket.IO's own logging
                 engineio_logger=False,
@@ -264,7 +269,7 @@ class SocketIOServerCore:
             )
 
             # Create aiohttp application
-            self.app = web.Application()
+            self.app = web.Application(middlewares=[scaling.middleware()])
             assert self.sio is not None
             assert self.app is not None
             self.sio.attach(self.app)
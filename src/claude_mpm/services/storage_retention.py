@@ -0,0 +1,354 @@
+"""Disk usage and retention for what claude-mpm stores on disk.
+
+WHAT: Groups the directories claude-mpm writes to into categories
+(transcripts, events, logs, caches, artifacts), reports how much each uses,
+and prunes them by age and total size. ``claude-mpm storage status`` shows
+the numbers; ``claude-mpm storage prune`` applies the policies. With
+``auto_prune`` on, startup also applies them once a day in the background.
+
+WHY: Session logs, spooled events and analysis caches were written and never
+removed, so ~/.claude-mpm grew without bound, into tens of gigabytes on
+machines that run many sessions.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    storage:
+      auto_prune: false           # true: prune once a day on startup
+      retention:
+        transcripts: {max_age_days: 30, max_size_mb: 2048}
+        events: {max_age_days: 14, max_size_mb: 512}
+        logs: {max_age_days: 14, max_size_mb: 512}
+        caches: {max_age_days: 30, max_size_mb: 1024}
+
+    Artifacts keep their own policy under ``artifacts.retention``.
+
+DESIGN DECISIONS:
+- Files are pruned individually, oldest first by mtime: first everything
+  past ``max_age_days``, then the oldest files until the category is under
+  ``max_size_mb``; emptied directories are removed afterwards
+- Files modified within the last hour are never pruned, so a log or spool
+  file that is being written is left alone
+- ~/.claude-mpm/cache holds the synced agent and skill repositories, which
+  are state rather than cache; only the analysis caches are managed
+- Artifacts are pruned through ArtifactStore so manifests stay consistent
+- Startup pruning is opt-in: it deletes transcripts, which users may want
+  to keep, and it logs what each category lost
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from .artifact_store import ArtifactStore, RetentionPolicy
+from .artifact_store import CONFIG_KEY as ARTIFACTS_CONFIG_KEY
+from .page_capture import captures_dir
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "storage"
+
+TRANSCRIPTS = "transcripts"
+EVENTS = "events"
+LOGS = "logs"
+CACHES = "caches"
+ARTIFACTS = "artifacts"
+CATEGORIES = (TRANSCRIPTS, EVENTS, LOGS, CACHES, ARTIFACTS)
+
+# Directories under ~/.claude-mpm that belong to each category
+_USER_DIRS = {
+    TRANSCRIPTS: ("sessions", "agent_sessions", "resume-logs", "archives"),
+    EVENTS: ("event_spool", "dead-letter"),
+    LOGS: ("logs",),
+    CACHES: ("code-cache", "tree-cache"),
+}
+# ... and under the project's .claude-mpm
+_PROJECT_DIRS = {
+    TRANSCRIPTS: ("responses", "resume-logs"),
+    LOGS: ("logs",),
+}
+
+_DEFAULT_LIMITS = {
+    TRANSCRIPTS: (30, 2048),
+    EVENTS: (14, 512),
+    LOGS: (14, 512),
+    CACHES: (30, 1024),
+}
+
+MIN_AGE = timedelta(hours=1)
+_MB = 1024 * 1024
+
+
+@dataclass
+class CategoryPolicy:
+    """Limits for one category; ``None`` means no limit."""
+
+    max_age_days: int | None
+    max_size_mb: int | None
+
+
+@dataclass
+class StoragePolicy:
+    """Per-category retention limits and whether startup prunes."""
+
+    categories: dict[str, CategoryPolicy] = field(
+        default_factory=lambda: {
+            name: CategoryPolicy(*limits) for name, limits in _DEFAULT_LIMITS.items()
+        }
+    )
+    artifacts: RetentionPolicy = field(default_factory=RetentionPolicy)
+    auto_prune: bool = False
+
+    @classmethod
+    def load(cls, config: Any = None) -> StoragePolicy:
+        section: dict[str, Any] = {}
+        artifacts: dict[str, Any] | None = None
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+            artifacts = config.get(ARTIFACTS_CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        retention = section.get("retention", {}) or {}
+        categories = {}
+        for name, (max_age_days, max_size_mb) in _DEFAULT_LIMITS.items():
+            limits = retention.get(name, {}) or {}
+            categories[name] = CategoryPolicy(
+                max_age_days=limits.get("max_age_days", max_age_days),
+                max_size_mb=limits.get("max_size_mb", max_size_mb),
+            )
+        return cls(
+            categories=categories,
+            artifacts=RetentionPolicy.load({ARTIFACTS_CONFIG_KEY: artifacts or {}}),
+            auto_prune=bool(section.get("auto_prune", False)),
+        )
+
+
+@dataclass
+class CategoryUsage:
+    """Disk usage of one category and what its policy would remove."""
+
+    name: str
+    paths: list[str]
+    files: int = 0
+    size: int = 0
+    oldest: str | None = None
+    max_age_days: int | None = None
+    max_size_mb: int | None = None
+    prunable_files: int = 0
+    prunable_size: int = 0
+
+    def to_dict(self) -> dict[str, Any]:
+        return dict(self.__dict__)
+
+
+@dataclass
+class PruneResult:
+    """What pruning removed from one category."""
+
+    name: str
+    files: int = 0
+    size: int = 0
+    sessions: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> dict[str, Any]:
+        return dict(self.__dict__)
+
+
+class StorageManager:
+    """Reports on and prunes claude-mpm's on-disk storage."""
+
+    def __init__(
+        self,
+        home_dir: Path | None = None,
+        project_dir: Path | None = None,
+        policy: StoragePolicy | None = None,
+    ):
+        self.home_dir = Path(home_dir or Path.home() / ".claude-mpm")
+        self.project_dir = Path(project_dir or Path.cwd())
+        self.policy = policy or StoragePolicy.load()
+
+    def paths(self, category: str) -> list[Path]:
+        """Existing directories that make up ``category``."""
+        if category == ARTIFACTS:
+            candidates = [
+                ArtifactStore(self.project_dir, self.policy.artifacts).root,
+                captures_dir(self.project_dir),
+            ]
+        else:
+            project = self.project_dir / ".claude-mpm"
+            candidates = [self.home_dir / name for name in _USER_DIRS[category]]
+            candidates += [project / name for name in _PROJECT_DIRS.get(category, ())]
+        seen: list[Path] = []
+        for path in candidates:
+            if path.is_dir() and path.resolve() not in {p.resolve() for p in seen}:
+                seen.append(path)
+        return seen
+
+    def status(self, now: datetime | None = None) -> list[CategoryUsage]:
+        """Usage per category, with what a prune would remove right now."""
+        now = now or datetime.now(UTC)
+        usages = []
+        for name in CATEGORIES:
+            paths = self.paths(name)
+            files = _files(paths)
+            limits = self._limits(name)
+            usage = CategoryUsage(
+                name=name,
+                paths=[str(p) for p in paths],
+                files=len(files),
+                size=sum(size for _, size, _ in files),
+                max_age_days=limits.max_age_days,
+                max_size_mb=limits.max_size_mb,
+            )
+            if files:
+                oldest = min(mtime for _, _, mtime in files)
+                usage.oldest = oldest.isoformat()
+            victims = _select_victims(files, limits, now)
+            usage.prunable_files = len(victims)
+            usage.prunable_size = sum(size for _, size, _ in victims)
+            usages.append(usage)
+        return usages
+
+    def prune(
+        self,
+        categories: list[str] | None = None,
+        dry_run: bool = False,
+        now: datetime | None = None,
+    ) -> list[PruneResult]:
+        """Apply the policies; with ``dry_run`` only report what would go."""
+        now = now or datetime.now(UTC)
+        results = []
+        for name in categories or CATEGORIES:
+            if name not in CATEGORIES:
+                raise ValueError(
+                    f"Unknown storage category '{name}' "
+                    f"(expected one of {', '.join(CATEGORIES)})"
+                )
+            result = PruneResult(name)
+            paths = self.paths(name)
+            if name == ARTIFACTS and not dry_run:
+                store = ArtifactStore(self.project_dir, self.policy.artifacts)
+                before = sum(size for _, size, _ in _files([store.root]))
+                result.sessions = store.prune(now=now)
+                result.size = before - sum(size for _, size, _ in _files([store.root]))
+                # Captures have no manifest; prune them file by file
+                paths = [p for p in paths if p != store.root]
+            victims = _select_victims(_files(paths), self._limits(name), now)
+            for path, size, _ in victims:
+                if not dry_run:
+                    try:
+                        path.unlink()
+                    except OSError as e:
+                        logger.debug(f"Could not remove {path}: {e}")
+                        continue
+                result.files += 1
+                result.size += size
+            if not dry_run:
+                for root in paths:
+                    _remove_empty_dirs(root)
+            results.append(result)
+        removed = sum(r.size for r in results)
+        if removed and not dry_run:
+            logger.info(f"Pruned {removed / _MB:.1f} MB of claude-mpm storage")
+        return results
+
+    def _limits(self, category: str) -> CategoryPolicy:
+        if category == ARTIFACTS:
+            artifacts = self.policy.artifacts
+            return CategoryPolicy(artifacts.max_age_days, artifacts.max_total_mb)
+        return self.policy.categories[category]
+
+
+def _files(roots: list[Path]) -> list[tuple[Path, int, datetime]]:
+    """(path, size, mtime) of every regular file under ``roots``."""
+    found = []
+    for root in roots:
+        if not root.is_dir():
+            continue
+        for path in root.rglob("*"):
+            try:
+                if path.is_symlink() or not path.is_file():
+                    continue
+                stat = path.stat()
+            except OSError:
+                continue
+            mtime = datetime.fromtimestamp(stat.st_mtime, UTC)
+            found.append((path, stat.st_size, mtime))
+    return found
+
+
+def _select_victims(
+    files: list[tuple[Path, int, datetime]], limits: CategoryPolicy, now: datetime
+) -> list[tuple[Path, int, datetime]]:
+    """Files the policy removes: past the age limit, then oldest over size."""
+    candidates = sorted(
+        (entry for entry in files if now - entry[2] >= MIN_AGE), key=lambda e: e[2]
+    )
+    victims = []
+    if limits.max_age_days is not None:
+        cutoff = now - timedelta(days=limits.max_age_days)
+        victims = [entry for entry in candidates if entry[2] < cutoff]
+    if limits.max_size_mb is not None:
+        total = sum(size for _, size, _ in files) - sum(e[1] for e in victims)
+        limit = limits.max_size_mb * _MB
+        for entry in candidates:
+            if total <= limit:
+                break
+            if entry not in victims:
+                victims.append(entry)
+                total -= entry[1]
+    return victims
+
+
+def _remove_empty_dirs(root: Path) -> None:
+    # Deepest first so parents empty out before they are checked
+    for directory in sorted(
+        (p for p in root.rglob("*") if p.is_dir() and not p.is_symlink()),
+        key=lambda p: len(p.parts),
+        reverse=True,
+    ):
+        try:
+            directory.rmdir()
+        except OSError:
+            pass  # not empty
+
+
+def total_size(path: Path) -> int:
+    """Bytes used by everything under ``path``."""
+    return sum(size for _, size, _ in _files([path]))
+
+
+def format_size(size: float) -> str:
+    for unit in ("B", "KB", "MB", "GB"):
+        if size < 1024 or unit == "GB":
+            return f"{size:.0f}{unit}" if unit == "B" else f"{size:.1f}{unit}"
+        size /= 1024
+    return f"{size:.1f}GB"
+
+
+def prune_in_background() -> None:
+    """Prune on a daemon thread; used by startup, which must not block."""
+    import threading
+
+    def task() -> None:
+        try:
+            manager = StorageManager()
+            if not manager.policy.auto_prune:
+                return
+            for result in manager.prune():
+                if result.files:
+                    logger.info(
+                        f"Storage prune removed {result.files} {result.name} "
+                        f"files ({format_size(result.size)})"
+                    )
+        except Exception as e:
+            logger.debug(f"Scheduled storage prune failed: {e}")
+
+    threading.Thread(target=task, daemon=True).start()
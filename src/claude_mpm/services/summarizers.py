@@ -0,0 +1,347 @@
+"""Pluggable summarizer backends.
+
+WHAT: Code that needs a summary asks for the backend of its use case instead
+of calling a model directly::
+
+    summary = summarizer_for("compaction", project_root).summarize(text, 300)
+    summary.text, summary.backend, summary.fell_back
+
+Backends:
+
+- ``extractive``: picks key sentences with no model (offline, free, default)
+- ``claude``: ``claude -p`` with a configurable, by default cheap, model
+- ``local``: a local model served by Ollama
+
+Each use case picks its backend in ``.claude-mpm/configuration.yaml`` (the
+user's ``~/.claude-mpm/config/configuration.yaml`` applies underneath)::
+
+    summarization:
+      default: extractive          # use cases without a setting of their own
+      fallback: extractive         # when a backend fails; false to fail instead
+      claude:
+        model: haiku
+      local:
+        host: http://localhost:11434
+        model: mistral:7b
+      use_cases:
+        compaction: claude         # resume logs written near the context limit
+        document:                  # claude-mpm summarize
+          backend: local
+          model: llama3.2:3b
+
+Use cases in this tree are ``document`` and ``compaction``; any other name
+(e.g. ``digest``) is accepted and gets the default backend.
+
+WHY: Summaries that only need to be good enough were either mechanical or
+paid for at premium-model prices; teams want to choose per use case.
+
+DESIGN DECISIONS:
+- Backends are small sync classes rather than services/model providers: those
+  are async, analysis-oriented and need aiohttp, while summaries are asked
+  for from hooks and CLI commands
+- A failing or unavailable backend falls back to the extractive one (and the
+  Summary says so) unless ``fallback: false``, so a stopped Ollama never
+  loses a resume log
+"""
+
+from __future__ import annotations
+
+import json
+import shutil
+import subprocess  # nosec B404 - runs the claude CLI
+import urllib.request
+from abc import ABC, abstractmethod
+from dataclasses import dataclass
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "summarization"
+PROJECT_CONFIG = Path(".claude-mpm") / "configuration.yaml"
+USER_CONFIG = Path(".claude-mpm") / "config" / "configuration.yaml"
+
+DEFAULT_BACKEND = "extractive"
+DEFAULT_CLAUDE_MODEL = "haiku"
+DEFAULT_LOCAL_MODEL = "mistral:7b"
+DEFAULT_LOCAL_HOST = "http://localhost:11434"
+DEFAULT_TIMEOUT = 120.0
+
+PROMPT = (
+    "Summarize the text {where} in at most {max_words} words. "
+    "Keep names, decisions and open work; leave out pleasantries. "
+    "Reply with the summary only.{instructions}"
+)
+
+
+class SummarizerError(Exception):
+    """A backend could not produce a summary."""
+
+
+@dataclass
+class Summary:
+    """A summary and the backend that wrote it."""
+
+    text: str
+    backend: str
+    # True when the configured backend failed and the fallback wrote it
+    fell_back: bool = False
+
+
+class SummarizerBackend(ABC):
+    """Turns text into a summary of at most roughly ``max_words`` words."""
+
+    name: str
+
+    def is_available(self) -> bool:
+        return True
+
+    @abstractmethod
+    def summarize(self, text: str, max_words: int, instructions: str = "") -> str:
+        """The summary of *text*.
+
+        Raises:
+            SummarizerError: If no summary could be produced.
+        """
+
+    def describe(self) -> str:
+        return self.name
+
+
+class ExtractiveSummarizer(SummarizerBackend):
+    """Key sentences picked by position and wording, no model involved."""
+
+    name = "extractive"
+
+    def __init__(self, style: str = "detailed"):
+        self.style = style
+
+    def summarize(self, text: str, max_words: int, instructions: str = "") -> str:
+        from ..cli.commands.summarize import DocumentSummarizer, SummaryStyle
+
+        return DocumentSummarizer(max_words=max_words).summarize(
+            text, SummaryStyle(self.style)
+        )
+
+
+class ClaudeSummarizer(SummarizerBackend):
+    """``claude -p`` with a chosen model, the text passed on stdin."""
+
+    name = "claude"
+
+    def __init__(
+        self, model: str = DEFAULT_CLAUDE_MODEL, timeout: float = DEFAULT_TIMEOUT
+    ):
+        self.model = model
+        self.timeout = timeout
+
+    def is_available(self) -> bool:
+        return shutil.which("claude") is not None
+
+    def summarize(self, text: str, max_words: int, instructions: str = "") -> str:
+        if not self.is_available():
+            raise SummarizerError("claude CLI not found on PATH")
+        prompt = _prompt(max_words, instructions, "on standard input")
+        try:
+            result = subprocess.run(  # nosec B603 B607 - fixed argv
+                ["claude", "-p", "--model", self.model, prompt],
+                input=text,
+                capture_output=True,
+                text=True,
+                timeout=self.timeout,
+                check=False,
+            )
+        except (OSError, subprocess.TimeoutExpired) as e:
+            raise SummarizerError(f"claude failed: {e}") from e
+        if result.returncode != 0 or not result.stdout.strip():
+            detail = result.stderr.strip() or f"exit code {result.returncode}"
+            raise SummarizerError(f"claude failed: {detail}")
+        return result.stdout.strip()
+
+    def describe(self) -> str:
+        return f"claude ({self.model})"
+
+
+class LocalSummarizer(SummarizerBackend):
+    """A model served by Ollama, through its generate API."""
+
+    name = "local"
+
+    def __init__(
+        self,
+        model: str = DEFAULT_LOCAL_MODEL,
+        host: str = DEFAULT_LOCAL_HOST,
+        timeout: float = DEFAULT_TIMEOUT,
+    ):
+        self.model = model
+        self.host = host.rstrip("/")
+        self.timeout = timeout
+
+    def is_available(self) -> bool:
+        try:
+            with urllib.request.urlopen(  # nosec B310 - configured host
+                f"{self.host}/api/tags", timeout=2
+            ):
+                return True
+        except (OSError, ValueError):
+            return False
+
+    def summarize(self, text: str, max_words: int, instructions: str = "") -> str:
+        body = json.dumps(
+            {
+                "model": self.model,
+                "prompt": f"{_prompt(max_words, instructions)}\n\n---\n\n{text}",
+                "stream": False,
+            }
+        ).encode()
+        request = urllib.request.Request(  # nosec B310 - configured host
+            f"{self.host}/api/generate",
+            data=body,
+            headers={"Content-Type": "application/json"},
+        )
+        try:
+            with urllib.request.urlopen(  # nosec B310 - configured host
+                request, timeout=self.timeout
+            ) as response:
+                data = json.loads(response.read().decode("utf-8"))
+        except (OSError, ValueError) as e:
+            raise SummarizerError(f"Ollama at {self.host} failed: {e}") from e
+        summary = str(data.get("response", "")).strip()
+        if not summary:
+            raise SummarizerError(f"Ollama model {self.model} returned no text")
+        return summary
+
+    def describe(self) -> str:
+        return f"local ({self.model} at {self.host})"
+
+
+BACKENDS: dict[str, type[SummarizerBackend]] = {
+    "extractive": ExtractiveSummarizer,
+    "claude": ClaudeSummarizer,
+    "local": LocalSummarizer,
+}
+
+
+def _prompt(max_words: int, instructions: str, where: str = "below") -> str:
+    extra = f" {instructions}" if instructions else ""
+    return PROMPT.format(where=where, max_words=max_words, instructions=extra)
+
+
+def _load_section(path: Path) -> dict[str, Any]:
+    """The ``summarization`` mapping of a YAML config file ({} when absent)."""
+    if not path.is_file():
+        return {}
+    import yaml
+
+    try:
+        data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+    except (OSError, yaml.YAMLError) as e:
+        logger.warning(f"Could not read {path}: {e}")
+        return {}
+    section = data.get(CONFIG_KEY) if isinstance(data, dict) else None
+    return section if isinstance(section, dict) else {}
+
+
+def load_config(project_root: Path | None = None) -> dict[str, Any]:
+    """The user's settings with the project's on top of them."""
+    user = _load_section(Path.home() / USER_CONFIG)
+    project = _load_section(Path(project_root or Path.cwd()) / PROJECT_CONFIG)
+    config = {**user, **project}
+    for key in ("claude", "local", "use_cases"):
+        config[key] = {**(user.get(key) or {}), **(project.get(key) or {})}
+    return config
+
+
+def create_backend(name: str, settings: dict[str, Any] | None = None):
+    """Backend *name* configured with *settings* (model, host, timeout).
+
+    Raises:
+        ValueError: If there is no such backend.
+    """
+    if name not in BACKENDS:
+        raise ValueError(
+            f"Unknown summarizer backend '{name}': choose from {', '.join(BACKENDS)}"
+        )
+    settings = settings or {}
+    timeout = float(settings.get("timeout", DEFAULT_TIMEOUT))
+    if name == "claude":
+        return ClaudeSummarizer(
+            str(settings.get("model") or DEFAULT_CLAUDE_MODEL), timeout
+        )
+    if name == "local":
+        return LocalSummarizer(
+            str(settings.get("model") or DEFAULT_LOCAL_MODEL),
+            str(settings.get("host") or DEFAULT_LOCAL_HOST),
+            timeout,
+        )
+    return ExtractiveSummarizer(str(settings.get("style") or "detailed"))
+
+
+class Summarizer:
+    """The backend chosen for one use case, with its fallback."""
+
+    def __init__(
+        self,
+        use_case: str,
+        backend: SummarizerBackend,
+        fallback: SummarizerBackend | None = None,
+    ):
+        self.use_case = use_case
+        self.backend = backend
+        self.fallback = fallback
+
+    def summarize(self, text: str, max_words: int, instructions: str = "") -> Summary:
+        """Summarize *text*, falling back when the backend fails.
+
+        Raises:
+            SummarizerError: If the backend fails and there is no fallback.
+        """
+        try:
+            summary = self.backend.summarize(text, max_words, instructions)
+            return Summary(summary, self.backend.name)
+        except SummarizerError as e:
+            if self.fallback is None:
+                raise
+            logger.warning(
+                f"{self.backend.describe()} summarizer failed for "
+                f"{self.use_case}, using {self.fallback.name}: {e}"
+            )
+        summary = self.fallback.summarize(text, max_words, instructions)
+        return Summary(summary, self.fallback.name, fell_back=True)
+
+
+def summarizer_for(
+    use_case: str,
+    project_root: Path | None = None,
+    backend: str | None = None,
+) -> Summarizer:
+    """The summarizer configured for *use_case*.
+
+    Args:
+        use_case: e.g. ``compaction`` or ``document``
+        project_root: Project whose configuration applies (default: cwd)
+        backend: Backend name overriding the configuration
+
+    Raises:
+        ValueError: If the configuration names an unknown backend.
+    """
+    config = load_config(project_root)
+    choice = config["use_cases"].get(use_case)
+    if isinstance(choice, str):
+        choice = {"backend": choice}
+    choice = choice if isinstance(choice, dict) else {}
+    name = backend or choice.get("backend") or config.get("default") or DEFAULT_BACKEND
+    name = str(name)
+    settings = {**(config.get(name) or {}), **choice}
+    settings.pop("backend", None)
+    selected = create_backend(name, settings)
+
+    fallback_name = config.get("fallback", DEFAULT_BACKEND)
+    fallback = None
+    if fallback_name and fallback_name != name:
+        fallback = create_backend(
+            str(fallback_name), config.get(str(fallback_name)) or {}
+        )
+    return Summarizer(use_case, selected, fallback)
@@ -0,0 +1,484 @@
+"""Task-type routing of delegations, with A/B splits between agent variants.
+
+WHAT: Sends delegations of a task category to the agent configured for it,
+      or splits them between agent variants and records which variant did
+      better. Routing happens in the PreToolUse hook on the Agent tool: the
+      delegation's description and prompt are matched against each
+      category's keywords and ``subagent_type`` is rewritten.
+WHY: Evaluating a rewritten agent meant replacing the old one everywhere at
+     once; routing lets a share of the matching work go to the new variant
+     while the rest keeps the current one.
+
+CONFIGURATION (configuration.yaml, user file then project file)::
+
+    agents:
+      routing:
+        refactor: python-engineer          # every refactor -> one agent
+        test-writing:
+          agents:                          # A/B split, by weight
+            engineer: 50
+            engineer-v2: 50
+          keywords: [hypothesis]           # added to the built-in keywords
+          from: [engineer]                 # only when the PM picked these
+
+Built-in categories (BUILTIN_KEYWORDS) need no keywords; other categories
+must list theirs. The first category in file order that matches wins.
+
+OUTCOMES: Every routed delegation is appended to
+``<project>/.claude-mpm/state/task-routing.jsonl``. ``claude-mpm routing
+report`` finds each one's result in the session transcript: whether it
+errored, whether the user's next prompt corrected it (as in ``canary
+status``), and its duration and tokens when Claude Code recorded them.
+
+DESIGN DECISIONS:
+- A split assigns by hashing the tool_use_id, so a retried hook run gives
+  the same variant
+- A route to an agent that is not deployed (in the project's or the user's
+  ``.claude/agents``) is skipped, leaving the PM's choice
+- Comparisons name a better variant only when each has MIN_MEASURED
+  measured delegations
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import uuid
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+from claude_mpm.core.logger import get_logger
+from claude_mpm.core.state_files import state_lock
+from claude_mpm.utils.agent_filters import normalize_agent_id
+
+logger = get_logger(__name__)
+
+CONFIG_SECTION = "agents"
+CONFIG_KEY = "routing"
+LOG_FILE = Path(".claude-mpm") / "state" / "task-routing.jsonl"
+# Fewer measured delegations per variant than this and no winner is named
+MIN_MEASURED = 5
+
+BUILTIN_KEYWORDS: dict[str, list[str]] = {
+    "refactor": [
+        "refactor",
+        "restructure",
+        "clean up",
+        "cleanup",
+        "extract function",
+        "extract method",
+        "rename",
+        "simplify",
+    ],
+    "test-writing": [
+        "write tests",
+        "write a test",
+        "add tests",
+        "add a test",
+        "unit test",
+        "test coverage",
+        "integration test",
+        "missing tests",
+    ],
+    "bug-fix": [
+        "fix bug",
+        "fix the bug",
+        "bug fix",
+        "bugfix",
+        "regression",
+        "crash",
+    ],
+    "documentation": [
+        "document",
+        "docstring",
+        "readme",
+        "changelog",
+        "write docs",
+        "update docs",
+    ],
+}
+
+
+@dataclass
+class Route:
+    """Where delegations of one task category go."""
+
+    category: str
+    # Agent name -> weight; one entry for a plain route, several for a split
+    agents: dict[str, int]
+    keywords: list[str] = field(default_factory=list)
+    # Normalized names of the agents the route applies to; empty: any
+    from_agents: list[str] = field(default_factory=list)
+
+    @property
+    def is_split(self) -> bool:
+        return len(self.agents) > 1
+
+    def matches(self, text: str, agent: str) -> bool:
+        if self.from_agents and normalize_agent_id(agent) not in self.from_agents:
+            return False
+        text = text.lower()
+        return any(keyword.lower() in text for keyword in self.keywords)
+
+    def pick(self, key: str) -> str:
+        """The agent for the delegation identified by *key*."""
+        names = list(self.agents)
+        if len(names) == 1:
+            return names[0]
+        total = sum(self.agents.values())
+        digest = hashlib.sha256(f"{self.category}:{key}".encode()).hexdigest()
+        point = int(digest[:8], 16) % total
+        for name in names:
+            point -= self.agents[name]
+            if point < 0:
+                return name
+        return names[-1]
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+def parse_route(category: str, spec: Any) -> Route:
+    """A route from its configuration entry.
+
+    Raises:
+        ValueError: If the entry is malformed or a custom category has no
+            keywords.
+    """
+    if isinstance(spec, str):
+        spec = {"agent": spec}
+    if not isinstance(spec, dict):
+        raise ValueError(f"Route '{category}' must be an agent name or a mapping")
+    if "agent" in spec:
+        agents: Any = {spec["agent"]: 1}
+    else:
+        agents = spec.get("agents")
+    if isinstance(agents, list):
+        agents = dict.fromkeys(agents, 1)
+    if not isinstance(agents, dict) or not agents:
+        raise ValueError(f"Route '{category}' needs 'agent' or 'agents'")
+    weights = {}
+    for name, weight in agents.items():
+        if not isinstance(name, str) or not name.strip():
+            raise ValueError(f"Route '{category}' has an invalid agent name")
+        if type(weight) is not int or weight < 0:
+            raise ValueError(
+                f"Route '{category}': weight of '{name}' must be a whole number >= 0"
+            )
+        weights[name.strip()] = weight
+    if not sum(weights.values()):
+        raise ValueError(f"Route '{category}': the weights add up to 0")
+    keywords = [str(k) for k in spec.get("keywords") or [] if str(k).strip()]
+    keywords = BUILTIN_KEYWORDS.get(category, []) + keywords
+    if not keywords:
+        raise ValueError(
+            f"Route '{category}' is not a built-in category "
+            f"({', '.join(BUILTIN_KEYWORDS)}) and lists no keywords"
+        )
+    from_agents = spec.get("from") or []
+    if isinstance(from_agents, str):
+        from_agents = [from_agents]
+    return Route(
+        category,
+        weights,
+        keywords,
+        [normalize_agent_id(str(a)) for a in from_agents],
+    )
+
+
+def load_routes(project_dir: str | Path | None) -> list[Route]:
+    """``agents.routing`` for a project: the user file, then the project file.
+
+    A category in the project file replaces the user's. Malformed routes are
+    logged and skipped.
+    """
+    paths = [Path.home() / ".claude-mpm" / "config" / "configuration.yaml"]
+    if project_dir:
+        paths.append(Path(project_dir) / ".claude-mpm" / "configuration.yaml")
+    specs: dict[str, Any] = {}
+    for path in paths:
+        try:
+            data = yaml.safe_load(path.read_text(encoding="utf-8")) or {}
+        except (OSError, yaml.YAMLError):
+            continue
+        section = data.get(CONFIG_SECTION) if isinstance(data, dict) else None
+        configured = section.get(CONFIG_KEY) if isinstance(section, dict) else None
+        if isinstance(configured, dict):
+            specs.update(configured)
+    routes = []
+    for category, spec in specs.items():
+        try:
+            routes.append(parse_route(str(category), spec))
+        except ValueError as e:
+            logger.warning(f"Ignoring task route: {e}")
+    return routes
+
+
+def is_deployed(agent: str, project_dir: Path, home: Path | None = None) -> bool:
+    """Whether Claude Code can delegate to *agent* in *project_dir*."""
+    from claude_mpm.services.agents.deployment_utils import (
+        normalize_deployment_filename,
+    )
+
+    filename = normalize_deployment_filename(f"{agent}.md")
+    home = Path(home or Path.home())
+    return any(
+        (root / ".claude" / "agents" / filename).is_file()
+        for root in (Path(project_dir), home)
+    )
+
+
+@dataclass
+class RoutingDecision:
+    """Where one delegation was sent."""
+
+    category: str
+    requested: str  # the agent the PM picked
+    agent: str  # the agent it goes to
+    split: bool
+    tool_use_id: str = ""
+    session_id: str = ""
+    at: str = ""
+
+    @property
+    def changed(self) -> bool:
+        return self.agent != self.requested
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+class TaskRouter:
+    """The routes of one project and the log of what they decided."""
+
+    def __init__(
+        self,
+        project_dir: str | Path,
+        routes: list[Route] | None = None,
+        home: Path | None = None,
+    ):
+        self.project_dir = Path(project_dir)
+        self.routes = load_routes(project_dir) if routes is None else routes
+        self.home = home
+        self.log_path = self.project_dir / LOG_FILE
+
+    def classify(self, text: str, agent: str = "") -> Route | None:
+        """The first route whose keywords appear in *text*."""
+        for route in self.routes:
+            if route.matches(text, agent):
+                return route
+        return None
+
+    def route(
+        self,
+        agent: str,
+        text: str,
+        tool_use_id: str = "",
+        session_id: str = "",
+    ) -> RoutingDecision | None:
+        """Decide where a delegation to *agent* about *text* goes.
+
+        Returns:
+            None when no route matches or the chosen agent is not deployed
+        """
+        route = self.classify(text, agent)
+        if route is None:
+            return None
+        key = tool_use_id or str(uuid.uuid4())
+        chosen = route.pick(key)
+        if normalize_agent_id(chosen) != normalize_agent_id(agent) and not (
+            is_deployed(chosen, self.project_dir, self.home)
+        ):
+            logger.warning(
+                f"Task route '{route.category}' names '{chosen}', which is not "
+                "deployed; keeping the PM's choice"
+            )
+            return None
+        return RoutingDecision(
+            category=route.category,
+            requested=agent,
+            agent=chosen,
+            split=route.is_split,
+            tool_use_id=tool_use_id,
+            session_id=session_id,
+            at=datetime.now(UTC).isoformat(),
+        )
+
+    def record(self, decision: RoutingDecision) -> None:
+        with state_lock(self.log_path):
+            self.log_path.parent.mkdir(parents=True, exist_ok=True)
+            with self.log_path.open("a", encoding="utf-8") as fh:
+                fh.write(json.dumps(decision.to_dict()) + "\n")
+
+    def decisions(self, since: datetime | None = None) -> list[RoutingDecision]:
+        if not self.log_path.is_file():
+            return []
+        found = []
+        with self.log_path.open(encoding="utf-8") as fh:
+            for raw in fh:
+                try:
+                    decision = RoutingDecision(**json.loads(raw))
+                except (json.JSONDecodeError, TypeError):
+                    continue
+                if since is not None and decision.at:
+                    try:
+                        if datetime.fromisoformat(decision.at) < since:
+                            continue
+                    except ValueError:
+                        continue
+                found.append(decision)
+        return found
+
+    def report(
+        self, category: str | None = None, since: datetime | None = None
+    ) -> dict[str, dict[str, VariantStats]]:
+        """Category -> agent -> outcomes of the delegations routed to it."""
+        from claude_mpm.services.session_analysis.transcript_parser import (
+            locate_transcript,
+        )
+        from claude_mpm.services.skills.skill_effectiveness import _read_jsonl
+
+        stats: dict[str, dict[str, VariantStats]] = {}
+        transcripts: dict[str, list[dict[str, Any]] | None] = {}
+        for decision in self.decisions(since):
+            if category and decision.category != category:
+                continue
+            variant = stats.setdefault(decision.category, {}).setdefault(
+                decision.agent, VariantStats(decision.agent)
+            )
+            variant.delegations += 1
+            if decision.session_id not in transcripts:
+                path = locate_transcript(decision.session_id, str(self.project_dir))
+                try:
+                    transcripts[decision.session_id] = (
+                        _read_jsonl(path) if path.is_file() else None
+                    )
+                except OSError:
+                    transcripts[decision.session_id] = None
+            lines = transcripts[decision.session_id]
+            outcome = delegation_outcome(lines or [], decision.tool_use_id)
+            if outcome is not None:
+                variant.add(outcome)
+        return stats
+
+
+@dataclass
+class DelegationOutcome:
+    """How one delegation went, from its session transcript."""
+
+    error: bool = False
+    corrected: bool = False
+    duration_ms: int | None = None
+    tokens: int | None = None
+
+
+@dataclass
+class VariantStats:
+    """Outcomes of the delegations routed to one agent."""
+
+    agent: str
+    delegations: int = 0  # routed
+    measured: int = 0  # with a result in the transcript
+    errors: int = 0
+    corrections: int = 0
+    duration_ms: int = 0
+    timed: int = 0
+    tokens: int = 0
+    counted: int = 0
+
+    def add(self, outcome: DelegationOutcome) -> None:
+        self.measured += 1
+        self.errors += outcome.error
+        self.corrections += outcome.corrected
+        if outcome.duration_ms is not None:
+            self.duration_ms += outcome.duration_ms
+            self.timed += 1
+        if outcome.tokens is not None:
+            self.tokens += outcome.tokens
+            self.counted += 1
+
+    @property
+    def success_rate(self) -> float:
+        if not self.measured:
+            return 0.0
+        return (self.measured - self.errors - self.corrections) / self.measured
+
+    @property
+    def avg_duration_s(self) -> float | None:
+        return self.duration_ms / self.timed / 1000 if self.timed else None
+
+    @property
+    def avg_tokens(self) -> float | None:
+        return self.tokens / self.counted if self.counted else None
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            **asdict(self),
+            "success_rate": round(self.success_rate, 4),
+            "avg_duration_s": self.avg_duration_s,
+            "avg_tokens": self.avg_tokens,
+        }
+
+
+def better_variant(variants: dict[str, VariantStats]) -> str | None:
+    """The variant with the best success rate, fewer tokens breaking ties.
+
+    None unless there are two or more variants, each with MIN_MEASURED
+    measured delegations, and one is strictly ahead.
+    """
+    if len(variants) < 2 or any(v.measured < MIN_MEASURED for v in variants.values()):
+        return None
+    ranked = sorted(
+        variants.values(),
+        key=lambda v: (-v.success_rate, v.avg_tokens or 0.0),
+    )
+    first, second = ranked[0], ranked[1]
+    if (first.success_rate, first.avg_tokens) == (
+        second.success_rate,
+        second.avg_tokens,
+    ):
+        return None
+    return first.agent
+
+
+def delegation_outcome(
+    lines: list[dict[str, Any]], tool_use_id: str
+) -> DelegationOutcome | None:
+    """The outcome of delegation *tool_use_id*, or None if it has no result."""
+    from claude_mpm.services.skills.skill_effectiveness import _RETRY_RE, _user_text
+
+    if not tool_use_id:
+        return None
+    outcome: DelegationOutcome | None = None
+    for entry in lines:
+        if entry.get("type") != "user":
+            continue
+        content = (entry.get("message") or {}).get("content")
+        blocks = content if isinstance(content, list) else []
+        if outcome is None:
+            for block in blocks:
+                if (
+                    isinstance(block, dict)
+                    and block.get("type") == "tool_result"
+                    and block.get("tool_use_id") == tool_use_id
+                ):
+                    outcome = DelegationOutcome(error=bool(block.get("is_error")))
+                    result = entry.get("toolUseResult")
+                    if isinstance(result, dict):
+                        if isinstance(result.get("totalDurationMs"), int):
+                            outcome.duration_ms = result["totalDurationMs"]
+                        if isinstance(result.get("totalTokens"), int):
+                            outcome.tokens = result["totalTokens"]
+            continue
+        if any(isinstance(b, dict) and b.get("type") == "tool_result" for b in blocks):
+            continue
+        text = _user_text(content).strip()
+        if text:
+            # The user's next prompt after the delegation returned
+            outcome.corrected = bool(_RETRY_RE.search(text))
+            break
+    return outcome
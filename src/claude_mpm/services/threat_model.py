@@ -0,0 +1,1103 @@
+"""Draft a STRIDE threat model of the project and keep it up to date.
+
+WHAT: ``claude-mpm analyze threat-model`` writes ``docs/threat-model.md``
+from what the code shows:
+
+- services, HTTP endpoints, schemas and owners from the knowledge graph
+- entry points: the HTTP endpoints, and the command-line scripts declared
+  in pyproject.toml and package.json
+- data-flow findings: files that take request input, and calls that are
+  dangerous with it (SQL built from strings, shell commands, eval, unsafe
+  deserialization, outbound requests and file responses to computed
+  targets, unescaped HTML)
+- pinned dependencies and the last ``claude-mpm advisories check``
+
+Each threat sits under its STRIDE category with a severity, the evidence it
+was drafted from and a suggested mitigation. Running the command again
+updates the document: only source files that changed since the last run are
+rescanned, threats keep their IDs, the **Status** and **Mitigation** lines
+people edited are kept, and threats no longer found move to "Resolved".
+``--check`` exits non-zero when the document is out of date, for CI.
+
+WHY: A threat model written once by hand is stale by the next feature. A
+draft regenerated from the code shows reviewers the new endpoint without
+authentication or the new shell call while the change is in review.
+
+DESIGN DECISIONS:
+- Heuristics, like the knowledge graph: regexes per language, no parsers.
+  The document is a draft for people to review, and a threat that does not
+  apply costs one "false-positive" status
+- Threat IDs hash the category, rule and subject (a service, schema or
+  file), never line numbers, so edits elsewhere do not renumber threats
+- The document is rewritten only when its content changes, so its date
+  marks the last real change
+- Test files are not scanned: eval in a test is not a threat
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import os
+import re
+import tomllib
+from dataclasses import asdict, dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from claude_mpm.core.logging_config import get_logger
+from claude_mpm.core.state_files import read_json, write_atomic
+
+from .knowledge_graph import (
+    ENDPOINT,
+    OWNER,
+    SCHEMA,
+    SERVICE,
+    KnowledgeGraph,
+    KnowledgeGraphBuilder,
+    default_graph_path,
+)
+from .knowledge_graph.extractor import MAX_FILE_BYTES, SKIP_DIRS, SOURCE_SUFFIXES
+
+logger = get_logger(__name__)
+
+DEFAULT_OUTPUT = Path("docs") / "threat-model.md"
+STATE_FILE = "threat_model.json"
+SEVERITIES = ("high", "medium", "low")
+
+SPOOFING = "spoofing"
+TAMPERING = "tampering"
+REPUDIATION = "repudiation"
+INFORMATION_DISCLOSURE = "information-disclosure"
+DENIAL_OF_SERVICE = "denial-of-service"
+ELEVATION_OF_PRIVILEGE = "elevation-of-privilege"
+STRIDE = {
+    SPOOFING: ("Spoofing", "Acting as another user or system"),
+    TAMPERING: ("Tampering", "Changing data or code without the right to"),
+    REPUDIATION: ("Repudiation", "Acting without a record of who did it"),
+    INFORMATION_DISCLOSURE: ("Information disclosure", "Reading data one should not"),
+    DENIAL_OF_SERVICE: ("Denial of service", "Making the system unavailable"),
+    ELEVATION_OF_PRIVILEGE: (
+        "Elevation of privilege",
+        "Gaining abilities one should not have",
+    ),
+}
+
+SQL_INJECTION = "sql-injection"
+COMMAND_INJECTION = "command-injection"
+CODE_INJECTION = "code-injection"
+UNSAFE_DESERIALIZATION = "unsafe-deserialization"
+SSRF = "ssrf"
+PATH_TRAVERSAL = "path-traversal"
+XSS = "xss"
+
+_LANGUAGES = {
+    ".py": "py",
+    ".js": "js",
+    ".jsx": "js",
+    ".mjs": "js",
+    ".ts": "js",
+    ".tsx": "js",
+    ".go": "go",
+}
+_TEST_PATH = re.compile(
+    r"(^|/)(tests?|__tests__|spec)/|(^|/)test_[^/]*\.py$|_test\.(py|go)$"
+    r"|\.(test|spec)\.[jt]sx?$"
+)
+# A template literal with an interpolation, or a string concatenated to
+_JS_BUILT = r"(?:`[^`]*\$\{|[\"'][^\"'\n]*[\"']\s*\+)"
+_PY_BUILT = r"(?:f[\"']|[\"'][^\"'\n]*[\"']\s*(?:%|\.format\(|\+))"
+
+
+@dataclass(frozen=True)
+class _Rule:
+    category: str
+    title: str
+    mitigation: str
+    patterns: dict[str, re.Pattern[str]]
+    severe: bool = True  # high when the file takes request input
+
+
+RULES = {
+    SQL_INJECTION: _Rule(
+        TAMPERING,
+        "SQL built from strings",
+        "Pass values as query parameters instead of formatting them into SQL",
+        {
+            "py": re.compile(rf"\.(?:execute|executemany|raw)\(\s*{_PY_BUILT}"),
+            "js": re.compile(rf"\.(?:query|raw|execute)\(\s*{_JS_BUILT}"),
+            "go": re.compile(
+                r"\.(?:Query|QueryRow|Exec)(?:Context)?\(\s*(?:ctx,\s*)?"
+                r"(?:fmt\.Sprintf\(|\"[^\"\n]*\"\s*\+)"
+            ),
+        },
+    ),
+    COMMAND_INJECTION: _Rule(
+        ELEVATION_OF_PRIVILEGE,
+        "Shell commands",
+        "Run the program with an argument list, without a shell, and "
+        "validate the arguments",
+        {
+            "py": re.compile(
+                r"subprocess\.\w+\([^)]*shell\s*=\s*True|\bos\.(?:system|popen)\("
+            ),
+            "js": re.compile(
+                rf"(?:child_process\.|(?<![.\w]))exec(?:Sync)?\(\s*"
+                rf"(?:{_JS_BUILT}|[a-z]\w*\s*[,)])"
+            ),
+            "go": re.compile(
+                r"exec\.Command(?:Context)?\([^)\n]*\"(?:sh|bash|cmd)\"\s*,"
+                r"\s*\"(?:-c|/c)\""
+            ),
+        },
+    ),
+    CODE_INJECTION: _Rule(
+        ELEVATION_OF_PRIVILEGE,
+        "Dynamic code evaluation",
+        "Replace eval with a parser for the data expected",
+        {
+            "py": re.compile(r"(?<![.\w])(?:eval|exec)\("),
+            "js": re.compile(r"(?<![.\w])eval\(|\bnew Function\("),
+        },
+    ),
+    UNSAFE_DESERIALIZATION: _Rule(
+        TAMPERING,
+        "Unsafe deserialization",
+        "Deserialize untrusted data only from safe formats (JSON, "
+        "yaml.safe_load)",
+        {
+            "py": re.compile(
+                r"\b(?:pickle|marshal|dill)\.loads?\(|\bjsonpickle\.decode\("
+                r"|\byaml\.load\((?![^)]*SafeLoader)"
+            ),
+        },
+    ),
+    SSRF: _Rule(
+        INFORMATION_DISCLOSURE,
+        "Requests to computed URLs",
+        "Allow-list the hosts outbound requests may reach",
+        {
+            "py": re.compile(
+                r"\b(?:requests|httpx)\.(?:get|post|put|patch|delete|head)\(\s*"
+                r"(?:f[\"']|[a-z]\w*\s*[,)])|\burlopen\(\s*(?:f[\"']|[a-z]\w*\s*[,)])"
+            ),
+            "js": re.compile(
+                rf"(?:(?<![.\w])fetch|\baxios(?:\.\w+)?)\(\s*"
+                rf"(?:{_JS_BUILT}|[a-z]\w*\s*[,)])"
+            ),
+            "go": re.compile(r"\bhttp\.(?:Get|Post|Head)\(\s*[a-z]\w*"),
+        },
+        severe=False,
+    ),
+    PATH_TRAVERSAL: _Rule(
+        INFORMATION_DISCLOSURE,
+        "Files served from computed paths",
+        "Resolve the path and check it stays inside the directory served",
+        {
+            "py": re.compile(
+                r"\b(?:send_file|FileResponse)\(\s*"
+                r"(?:f[\"']|[a-z]\w*\s*[,)]|os\.path\.join\()"
+            ),
+            "js": re.compile(
+                rf"\.(?:sendFile|download)\(\s*"
+                rf"(?:{_JS_BUILT}|[a-z]\w*\s*[,)]|path\.join\()"
+            ),
+            "go": re.compile(r"\bhttp\.ServeFile\("),
+        },
+        severe=False,
+    ),
+    XSS: _Rule(
+        TAMPERING,
+        "Unescaped HTML",
+        "Let the template engine escape output; sanitize HTML that must "
+        "be rendered raw",
+        {
+            "py": re.compile(r"\b(?:mark_safe|Markup)\("),
+            "js": re.compile(
+                r"dangerouslySetInnerHTML|\.innerHTML\s*=|\bdocument\.write\("
+            ),
+        },
+        severe=False,
+    ),
+}
+
+_REQUEST_INPUT = {
+    "py": re.compile(
+        r"\brequest\.(?:args|form|json|data|values|files|cookies|headers|GET"
+        r"|POST|body|query_params|path_params|get_json)\b"
+    ),
+    "js": re.compile(
+        r"\breq(?:uest)?\.(?:body|query|params|headers|cookies)\b"
+        r"|\bctx\.request\.|\blocation\.(?:hash|search)\b|\bURLSearchParams\b"
+    ),
+    "go": re.compile(
+        r"\br\.(?:URL\.Query|FormValue|PostFormValue|Body|Form|Header\.Get)\b"
+        r"|\bmux\.Vars\(|\bc\.(?:Param|Query|PostForm|Bind\w*)\("
+    ),
+}
+_AUTH = re.compile(
+    r"(?i)login_required|permission_required|requires?_auth|auth_required"
+    r"|authenticat|authoriz|\bjwt|bearer|oauth|current_user|passport\."
+    r"|api_?key|verify_token|\bSecurity\("
+)
+# Authentication applied to a whole app or router
+_AUTH_GLOBAL = re.compile(
+    r"(?i)\.use\([^)\n]*(?:auth|jwt|passport|session)|add_middleware\([^)\n]*auth"
+    r"|dependencies\s*=\s*\[[^\]\n]*(?:auth|current_user|verify)"
+)
+_RATE_LIMIT = re.compile(r"(?i)rate_?limit|ratelimit|limiter|throttl|slowapi")
+_LOGGING = re.compile(
+    r"(?i)\baudit|\b(?:logger|logging|log|console)\.(?:info|warn\w*|error|printf?"
+    r"|log)\("
+)
+_SENSITIVE_FIELD = re.compile(
+    r"(?i)passw|secret|token|api_?key|ssn|social_security|credit_?card"
+    r"|card_?number|cvv|private_?key|salt"
+)
+_ADMIN_PATH = re.compile(r"(?i)/(?:admin|internal|debug|manage(?:ment)?)\b")
+_WRITE_METHODS = {"POST", "PUT", "PATCH", "DELETE", "ANY"}
+_AUTH_WINDOW = (-2, 4)  # lines around an endpoint checked for authentication
+
+_THREAT_HEADING = re.compile(r"^#### (TM-[0-9a-f]{6}): ", re.MULTILINE)
+_EDITABLE = re.compile(r"^- \*\*(Status|Mitigation):\*\* (.*)$", re.MULTILINE)
+_UPDATED_LINE = re.compile(r"^Updated: .*$", re.MULTILINE)
+
+
+# ---------------------------------------------------------------------------
+# Scanning source files
+# ---------------------------------------------------------------------------
+
+
+@dataclass(frozen=True)
+class FlowFinding:
+    """A call that is dangerous with untrusted input."""
+
+    rule: str
+    file: str
+    line: int
+    code: str
+
+
+@dataclass
+class FileScan:
+    """What one source file contributes to the threat model."""
+
+    hash: str
+    findings: list[FlowFinding] = field(default_factory=list)
+    reads_input: bool = False
+    auth_lines: list[int] = field(default_factory=list)
+    auth_global: bool = False
+    rate_limited: bool = False
+    logs: bool = False
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> FileScan:
+        data = dict(data)
+        data["findings"] = [FlowFinding(**f) for f in data.get("findings", [])]
+        return cls(**data)
+
+    def authenticates(self, line: int) -> bool:
+        """Whether authentication shows around the endpoint at *line*."""
+        low, high = line + _AUTH_WINDOW[0], line + _AUTH_WINDOW[1]
+        return self.auth_global or any(low <= n <= high for n in self.auth_lines)
+
+
+def _line_of(text: str, offset: int) -> int:
+    return text.count("\n", 0, offset) + 1
+
+
+def _content_hash(text: str) -> str:
+    return hashlib.sha256(text.encode("utf-8", errors="replace")).hexdigest()[:16]
+
+
+def scan_source(rel: str, text: str) -> FileScan:
+    """Data-flow findings and security markers of one source file."""
+    scan = FileScan(hash=_content_hash(text))
+    language = _LANGUAGES.get(Path(rel).suffix)
+    if language is None:
+        return scan
+    lines = text.splitlines()
+    for rule_id, rule in RULES.items():
+        pattern = rule.patterns.get(language)
+        if pattern is None:
+            continue
+        for match in pattern.finditer(text):
+            line = _line_of(text, match.start())
+            code = lines[line - 1].strip() if line <= len(lines) else match.group()
+            if code.startswith(("#", "//")):
+                continue
+            scan.findings.append(FlowFinding(rule_id, rel, line, code[:100]))
+    request_input = _REQUEST_INPUT.get(language)
+    scan.reads_input = bool(request_input and request_input.search(text))
+    scan.auth_lines = [
+        number for number, line in enumerate(lines, 1) if _AUTH.search(line)
+    ]
+    scan.auth_global = bool(_AUTH_GLOBAL.search(text))
+    scan.rate_limited = bool(_RATE_LIMIT.search(text))
+    scan.logs = bool(_LOGGING.search(text))
+    return scan
+
+
+# ---------------------------------------------------------------------------
+# The model
+# ---------------------------------------------------------------------------
+
+
+@dataclass(frozen=True)
+class EntryPoint:
+    """A way into the system."""
+
+    kind: str  # "http" or "cli"
+    name: str
+    location: str
+    service: str  # Service entity ID
+    authenticated: bool | None = None  # None for command-line entry points
+
+    @property
+    def writes(self) -> bool:
+        return self.kind == "http" and self.name.split(" ", 1)[0] in _WRITE_METHODS
+
+
+@dataclass
+class Threat:
+    """One drafted threat."""
+
+    id: str
+    category: str
+    title: str
+    severity: str
+    description: str
+    mitigation: str
+    evidence: list[str] = field(default_factory=list)
+    status: str = "open"
+    first_seen: str = ""
+
+    def to_dict(self) -> dict[str, Any]:
+        return asdict(self)
+
+
+def threat_id(category: str, rule: str, subject: str) -> str:
+    digest = hashlib.sha256(f"{category}|{rule}|{subject}".encode()).hexdigest()
+    return f"TM-{digest[:6]}"
+
+
+def _cli_entry_points(project_dir: Path, service: Any) -> list[EntryPoint]:
+    path = service.attributes.get("path", ".")
+    directory = project_dir if path == "." else project_dir / path
+    found = []
+    manifests = service.attributes.get("manifests") or []
+    try:
+        if "pyproject.toml" in manifests:
+            data = tomllib.loads((directory / "pyproject.toml").read_text("utf-8"))
+            scripts = data.get("project", {}).get("scripts") or {}
+            manifest = (Path(path) / "pyproject.toml").as_posix().removeprefix("./")
+            found += [
+                EntryPoint("cli", name, f"{manifest} ({target})", service.id)
+                for name, target in sorted(scripts.items())
+            ]
+        if "package.json" in manifests:
+            data = json.loads((directory / "package.json").read_text("utf-8"))
+            bin_ = data.get("bin") or {}
+            if isinstance(bin_, str):
+                bin_ = {data.get("name", service.name): bin_}
+            manifest = (Path(path) / "package.json").as_posix().removeprefix("./")
+            found += [
+                EntryPoint("cli", name, f"{manifest} ({target})", service.id)
+                for name, target in sorted(bin_.items())
+            ]
+    except (OSError, ValueError, AttributeError) as e:
+        logger.debug(f"Could not read command-line entry points of {path}: {e}")
+    return found
+
+
+@dataclass
+class ThreatModel:
+    """Threats drafted from the graph, the scans and the dependencies."""
+
+    project: str
+    graph: KnowledgeGraph
+    scans: dict[str, FileScan]
+    entry_points: list[EntryPoint]
+    threats: list[Threat]
+    dependencies: dict[str, int]  # Pinned packages per ecosystem
+    advisories: Any = None  # AdvisoryReport of the last check
+
+    @property
+    def findings(self) -> list[FlowFinding]:
+        return [f for scan in self.scans.values() for f in scan.findings]
+
+    def services(self) -> list[Any]:
+        return sorted(
+            (e for e in self.graph.entities.values() if e.type == SERVICE),
+            key=lambda e: e.attributes.get("path", "."),
+        )
+
+
+class _Drafter:
+    """Builds the threats of a ThreatModel."""
+
+    def __init__(self, model: ThreatModel):
+        self.model = model
+        self.threats: dict[str, Threat] = {}
+
+    def add(
+        self,
+        category: str,
+        rule: str,
+        subject: str,
+        title: str,
+        severity: str,
+        description: str,
+        mitigation: str,
+        evidence: list[str],
+    ) -> None:
+        id_ = threat_id(category, rule, subject)
+        existing = self.threats.get(id_)
+        if existing:
+            existing.evidence += [e for e in evidence if e not in existing.evidence]
+            if SEVERITIES.index(severity) < SEVERITIES.index(existing.severity):
+                existing.severity = severity
+            return
+        self.threats[id_] = Threat(
+            id_, category, title, severity, description, mitigation, evidence
+        )
+
+    def draft(self) -> list[Threat]:
+        self._endpoints()
+        self._services()
+        self._schemas()
+        self._flows()
+        self._dependencies()
+        return sorted(
+            self.threats.values(),
+            key=lambda t: (
+                list(STRIDE).index(t.category),
+                SEVERITIES.index(t.severity),
+                t.title,
+            ),
+        )
+
+    def _by_service(self) -> dict[str, list[EntryPoint]]:
+        grouped: dict[str, list[EntryPoint]] = {}
+        for entry in self.model.entry_points:
+            if entry.kind == "http":
+                grouped.setdefault(entry.service, []).append(entry)
+        return grouped
+
+    def _name(self, service_id: str) -> str:
+        entity = self.model.graph.get(service_id)
+        return entity.name if entity else service_id
+
+    def _endpoints(self) -> None:
+        for service_id, entries in self._by_service().items():
+            name = self._name(service_id)
+            open_ = [e for e in entries if not e.authenticated]
+            if open_:
+                self.add(
+                    SPOOFING,
+                    "unauthenticated-endpoints",
+                    service_id,
+                    f"Endpoints of {name} without authentication",
+                    "high" if any(e.writes for e in open_) else "medium",
+                    f"{len(open_)} of {len(entries)} endpoints of {name} show no "
+                    "authentication check near their handler or on their app "
+                    "or router, so any caller can use them.",
+                    "Require authentication on these endpoints, or record here "
+                    "why they are public",
+                    [f"`{e.name}` ({e.location})" for e in open_],
+                )
+            admin = [e for e in open_ if _ADMIN_PATH.search(e.name)]
+            if admin:
+                self.add(
+                    ELEVATION_OF_PRIVILEGE,
+                    "unauthenticated-admin",
+                    service_id,
+                    f"Administrative endpoints of {name} without authentication",
+                    "high",
+                    "Endpoints under admin, internal or debug paths show no "
+                    "authentication check.",
+                    "Require an administrator role on these endpoints, or do "
+                    "not expose them",
+                    [f"`{e.name}` ({e.location})" for e in admin],
+                )
+
+    def _service_files(self, service_id: str) -> list[FileScan]:
+        return [
+            scan
+            for rel, scan in self.model.scans.items()
+            if _service_of(self.model.graph, rel) == service_id
+        ]
+
+    def _services(self) -> None:
+        for service_id, entries in self._by_service().items():
+            name = self._name(service_id)
+            scans = self._service_files(service_id)
+            writes = [e for e in entries if e.writes]
+            if writes and not any(scan.logs for scan in scans):
+                self.add(
+                    REPUDIATION,
+                    "no-audit-log",
+                    service_id,
+                    f"Changes through {name} are not logged",
+                    "medium",
+                    f"{name} has endpoints that change data, and none of its "
+                    "files log or audit anything, so a change cannot be "
+                    "traced to who made it.",
+                    "Log who changed what in the handlers that change data",
+                    [f"`{e.name}` ({e.location})" for e in writes],
+                )
+            if not any(scan.rate_limited for scan in scans):
+                self.add(
+                    DENIAL_OF_SERVICE,
+                    "no-rate-limit",
+                    service_id,
+                    f"No rate limiting in {name}",
+                    "medium" if any(not e.authenticated for e in entries) else "low",
+                    f"None of the files of {name} limit request rates, so one "
+                    "client can exhaust its workers.",
+                    "Rate limit requests per client, in the service or in front "
+                    "of it",
+                    [f"{len(entries)} endpoints"],
+                )
+
+    def _schemas(self) -> None:
+        graph = self.model.graph
+        users: dict[str, list[str]] = {}
+        for relation in graph.relations:
+            if relation.type == "uses":
+                users.setdefault(relation.target, []).append(relation.source)
+        entries = {
+            f"{e.service}:{e.name}": e
+            for e in self.model.entry_points
+            if e.kind == "http"
+        }
+        for schema in sorted(
+            (e for e in graph.entities.values() if e.type == SCHEMA),
+            key=lambda e: e.id,
+        ):
+            sensitive = [
+                f
+                for f in schema.attributes.get("fields") or []
+                if _SENSITIVE_FIELD.search(f)
+            ]
+            endpoints = sorted(users.get(schema.id, []))
+            if not sensitive or not endpoints:
+                continue
+            exposed = []
+            for endpoint_id in endpoints:
+                entity = graph.get(endpoint_id)
+                if entity is None:
+                    continue
+                service_id = f"{SERVICE}:{endpoint_id.split(':')[1]}"
+                entry = entries.get(f"{service_id}:{entity.name}")
+                exposed.append((entity.name, entry))
+            if not exposed:
+                continue
+            open_ = any(not (entry and entry.authenticated) for _, entry in exposed)
+            self.add(
+                INFORMATION_DISCLOSURE,
+                "sensitive-schema",
+                schema.id,
+                f"Sensitive fields of {schema.name} reach endpoints",
+                "high" if open_ else "medium",
+                f"{schema.name} ({schema.attributes.get('file')}) has the fields "
+                f"{', '.join(sensitive)} and is used by endpoints; returning it "
+                "as is would disclose them.",
+                "Return a response model without these fields, and never log "
+                "them",
+                [f"`{name}`" for name, _ in exposed],
+            )
+
+    def _flows(self) -> None:
+        for rel, scan in sorted(self.model.scans.items()):
+            tainted = scan.reads_input or _has_endpoint(self.model, rel)
+            by_rule: dict[str, list[FlowFinding]] = {}
+            for finding in scan.findings:
+                by_rule.setdefault(finding.rule, []).append(finding)
+            for rule_id, findings in by_rule.items():
+                rule = RULES[rule_id]
+                if tainted:
+                    severity = "high" if rule.severe else "medium"
+                    reach = f"{rel} takes request input, which may reach these calls."
+                else:
+                    severity = "medium" if rule.severe else "low"
+                    reach = (
+                        f"{rel} takes no request input itself; check what "
+                        "callers pass in."
+                    )
+                self.add(
+                    rule.category,
+                    rule_id,
+                    rel,
+                    f"{rule.title} in {rel}",
+                    severity,
+                    reach,
+                    rule.mitigation,
+                    [f"`{f.code}` ({f.file}:{f.line})" for f in findings],
+                )
+
+    def _dependencies(self) -> None:
+        report = self.model.advisories
+        if report and report.advisories:
+            severe = any(a.severity in ("high", "critical") for a in report.advisories)
+            self.add(
+                ELEVATION_OF_PRIVILEGE,
+                "vulnerable-dependencies",
+                "dependencies",
+                "Dependencies with known advisories",
+                "high" if severe else "medium",
+                f"The advisory check of {report.checked_at[:10]} found "
+                f"{len(report.advisories)} advisories affecting pinned "
+                "packages.",
+                "Upgrade the packages to fixed versions "
+                "('claude-mpm advisories check' queues the upgrades)",
+                [
+                    f"{a.id} {a.package} {a.version} ({a.severity})"
+                    for a in report.advisories
+                ],
+            )
+        manifests = {
+            m
+            for e in self.model.services()
+            for m in e.attributes.get("manifests") or []
+        }
+        declared = sorted(manifests & {"pyproject.toml", "package.json"})
+        if declared and not self.model.dependencies:
+            self.add(
+                TAMPERING,
+                "unpinned-dependencies",
+                "dependencies",
+                "Dependencies are not pinned",
+                "medium",
+                "No lockfile pins exact dependency versions, so a build can "
+                "pull in a compromised or broken release.",
+                "Commit a lockfile (uv.lock, poetry.lock, package-lock.json)",
+                declared,
+            )
+
+
+def _service_of(graph: KnowledgeGraph, rel: str) -> str:
+    """ID of the innermost service containing *rel*."""
+    best, best_len = f"{SERVICE}:.", 0
+    for entity in graph.entities.values():
+        path = entity.attributes.get("path", ".")
+        if entity.type != SERVICE or path == ".":
+            continue
+        if rel.startswith(path + "/") and len(path) > best_len:
+            best, best_len = entity.id, len(path)
+    return best
+
+
+def _has_endpoint(model: ThreatModel, rel: str) -> bool:
+    return any(
+        e.kind == "http" and e.location.rsplit(":", 1)[0] == rel
+        for e in model.entry_points
+    )
+
+
+# ---------------------------------------------------------------------------
+# The document
+# ---------------------------------------------------------------------------
+
+
+def read_edits(document: str) -> dict[str, dict[str, str]]:
+    """Status and Mitigation lines of each threat in an existing document."""
+    edits: dict[str, dict[str, str]] = {}
+    headings = list(_THREAT_HEADING.finditer(document))
+    for index, heading in enumerate(headings):
+        end = headings[index + 1].start() if index + 1 < len(headings) else None
+        section = document[heading.end() : end]
+        section = re.split(r"^#{1,4} ", section, maxsplit=1, flags=re.MULTILINE)[0]
+        edits[heading.group(1)] = {
+            key.lower(): value.strip() for key, value in _EDITABLE.findall(section)
+        }
+    return edits
+
+
+def _count(n: int, noun: str) -> str:
+    if n == 1:
+        return f"1 {noun}"
+    return f"{n} {noun[:-1]}ies" if noun.endswith("y") else f"{n} {noun}s"
+
+
+def _cell(text: str) -> str:
+    return str(text).replace("|", "\\|").replace("\n", " ")
+
+
+def render(
+    model: ThreatModel, resolved: list[dict[str, str]], updated: str
+) -> str:
+    """The threat model document."""
+    services = model.services()
+    http = [e for e in model.entry_points if e.kind == "http"]
+    lines = [
+        f"# Threat Model: {model.project}",
+        "",
+        "> Drafted by `claude-mpm analyze threat-model` from the code, and",
+        "> updated by running it again. Review each threat and edit its",
+        "> **Status** (open, mitigated, accepted, false-positive) and",
+        "> **Mitigation** lines; updates keep them.",
+        "",
+        f"Updated: {updated}",
+        "",
+        "## System",
+        "",
+        f"{_count(len(services), 'service')}, "
+        f"{_count(len(model.entry_points), 'entry point')}, "
+        f"{_count(len(model.findings), 'data-flow finding')}, "
+        f"{_count(sum(model.dependencies.values()), 'pinned dependency')}.",
+        "",
+        "```mermaid",
+        "flowchart LR",
+        "  client([Client])",
+    ]
+    for index, service in enumerate(services):
+        count = sum(1 for e in http if e.service == service.id)
+        lines.append(f'  s{index}["{service.name}"]')
+        if count:
+            lines.append(f'  client -->|"HTTP ({count})"| s{index}')
+        stores = [
+            e
+            for e in model.graph.entities.values()
+            if e.type == SCHEMA
+            and e.attributes.get("kind") in ("orm", "table")
+            and _service_of(model.graph, e.attributes.get("file", "")) == service.id
+        ]
+        if stores:
+            lines.append(f'  s{index} --> d{index}[("{service.name} data")]')
+    lines += ["```", "", "### Services", ""]
+    lines += ["| Service | Path | Endpoints | Owners |", "|---|---|---|---|"]
+    for service in services:
+        owners = sorted(
+            model.graph.get(r.source).name
+            for r in model.graph.relations
+            if r.target == service.id and r.source.startswith(f"{OWNER}:")
+        )
+        count = sum(1 for e in http if e.service == service.id)
+        lines.append(
+            f"| {_cell(service.name)} | `{service.attributes.get('path', '.')}` "
+            f"| {count} | {_cell(', '.join(owners)) or '-'} |"
+        )
+
+    lines += ["", "### Entry Points", ""]
+    if model.entry_points:
+        lines += ["| Entry point | Kind | Location | Authentication |"]
+        lines += ["|---|---|---|---|"]
+        for entry in model.entry_points:
+            auth = {True: "found", False: "none found", None: "local user"}[
+                entry.authenticated
+            ]
+            lines.append(
+                f"| `{_cell(entry.name)}` | {entry.kind} | {_cell(entry.location)} "
+                f"| {auth} |"
+            )
+    else:
+        lines.append("No entry points found.")
+
+    lines += ["", "### Data-Flow Findings", ""]
+    if model.findings:
+        lines += ["| Rule | Location | Call |", "|---|---|---|"]
+        for finding in sorted(model.findings, key=lambda f: (f.file, f.line)):
+            lines.append(
+                f"| {finding.rule} | {finding.file}:{finding.line} "
+                f"| `{_cell(finding.code)}` |"
+            )
+    else:
+        lines.append("No dangerous calls found.")
+
+    lines += ["", "### Dependencies", ""]
+    if model.dependencies:
+        counts = sorted(model.dependencies.items())
+        lines.append(f"Pinned: {', '.join(f'{n} {eco}' for eco, n in counts)}.")
+    else:
+        lines.append("No pinned dependencies found.")
+    report = model.advisories
+    if report is None:
+        lines.append(
+            "Not checked for advisories yet: run `claude-mpm advisories check`."
+        )
+    else:
+        lines.append(
+            f"{len(report.advisories)} known advisories at the check of "
+            f"{report.checked_at[:10]}."
+        )
+
+    lines += ["", "## Threats"]
+    for category, (title, meaning) in STRIDE.items():
+        lines += ["", f"### {title}", "", f"_{meaning}._"]
+        threats = [t for t in model.threats if t.category == category]
+        if not threats:
+            lines += ["", "No threats found."]
+        for threat in threats:
+            lines += [
+                "",
+                f"#### {threat.id}: {threat.title}",
+                "",
+                f"- **Severity:** {threat.severity}",
+                f"- **Status:** {threat.status}",
+                f"- **Mitigation:** {threat.mitigation}",
+                f"- **First seen:** {threat.first_seen}",
+                "",
+                threat.description,
+                "",
+            ]
+            lines += [f"- {item}" for item in threat.evidence]
+
+    if resolved:
+        lines += ["", "## Resolved", "", "Threats no longer found in the code.", ""]
+        lines += ["| Threat | Title | Resolved | Status |", "|---|---|---|---|"]
+        for item in resolved:
+            lines.append(
+                f"| {item['id']} | {_cell(item['title'])} | {item['resolved']} "
+                f"| {_cell(item['status'])} |"
+            )
+    return "\n".join(lines) + "\n"
+
+
+def _without_date(document: str) -> str:
+    return _UPDATED_LINE.sub("Updated:", document)
+
+
+# ---------------------------------------------------------------------------
+# Updating
+# ---------------------------------------------------------------------------
+
+
+@dataclass
+class ThreatModelUpdate:
+    """The outcome of one run."""
+
+    path: Path
+    model: ThreatModel
+    new: list[str]
+    resolved: list[str]
+    rescanned: int
+    changed: bool  # The document differs from what is on disk
+
+    def summary(self) -> str:
+        counts = ", ".join(
+            f"{n} {s}"
+            for s in SEVERITIES
+            if (n := sum(1 for t in self.model.threats if t.severity == s))
+        )
+        text = _count(len(self.model.threats), "threat")
+        if counts:
+            text += f" ({counts})"
+        changes = []
+        if self.new:
+            changes.append(f"{len(self.new)} new")
+        if self.resolved:
+            changes.append(f"{len(self.resolved)} resolved")
+        if changes:
+            text += f"; {', '.join(changes)}"
+        return text
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "path": str(self.path),
+            "threats": [t.to_dict() for t in self.model.threats],
+            "new": self.new,
+            "resolved": self.resolved,
+            "rescanned": self.rescanned,
+            "changed": self.changed,
+        }
+
+
+class ThreatModeler:
+    """Drafts the threat model document and updates it as the code changes."""
+
+    def __init__(
+        self,
+        project_dir: Path | None = None,
+        output: Path | None = None,
+        use_git: bool = True,
+    ):
+        self.project_dir = Path(project_dir or Path.cwd()).resolve()
+        output = Path(output or DEFAULT_OUTPUT)
+        self.output = output if output.is_absolute() else self.project_dir / output
+        self.use_git = use_git
+
+    @property
+    def state_path(self) -> Path:
+        return self.project_dir / ".claude-mpm" / STATE_FILE
+
+    def _source_files(self) -> dict[str, Path]:
+        files = {}
+        for root, dirs, names in os.walk(self.project_dir):
+            dirs[:] = sorted(
+                d for d in dirs if d not in SKIP_DIRS and not d.endswith(".egg-info")
+            )
+            for name in names:
+                if Path(name).suffix in SOURCE_SUFFIXES:
+                    path = Path(root) / name
+                    files[path.relative_to(self.project_dir).as_posix()] = path
+        return files
+
+    def _scan(
+        self, cached: dict[str, Any], rebuild: bool
+    ) -> tuple[dict[str, FileScan], int, bool]:
+        """Scans of every source file, reusing those whose content is unchanged.
+
+        Returns:
+            The scans, how many files were rescanned, and whether any file
+            was added, changed or removed
+        """
+        scans: dict[str, FileScan] = {}
+        rescanned = 0
+        for rel, path in sorted(self._source_files().items()):
+            try:
+                if path.stat().st_size > MAX_FILE_BYTES:
+                    continue
+                text = path.read_text(encoding="utf-8", errors="replace")
+            except OSError as e:
+                logger.debug(f"Skipping unreadable file {path}: {e}")
+                continue
+            previous = cached.get(rel)
+            if not rebuild and previous and previous.get("hash") == _content_hash(text):
+                try:
+                    scans[rel] = FileScan.from_dict(previous)
+                    continue
+                except TypeError:
+                    pass
+            rescanned += 1
+            scans[rel] = (
+                FileScan(hash=_content_hash(text))
+                if _TEST_PATH.search(rel)
+                else scan_source(rel, text)
+            )
+        changed = rescanned > 0 or set(cached) != set(scans)
+        return scans, rescanned, changed
+
+    def _graph(self, changed: bool, save: bool) -> KnowledgeGraph:
+        path = default_graph_path(self.project_dir)
+        if not changed and path.exists():
+            return KnowledgeGraph.load(path)
+        graph = KnowledgeGraphBuilder(self.project_dir, use_git=self.use_git).build()
+        if save:
+            graph.save(path)
+        return graph
+
+    def _entry_points(
+        self, graph: KnowledgeGraph, scans: dict[str, FileScan]
+    ) -> list[EntryPoint]:
+        entries = []
+        for entity in sorted(graph.entities.values(), key=lambda e: e.id):
+            if entity.type == SERVICE:
+                entries += _cli_entry_points(self.project_dir, entity)
+                continue
+            rel = entity.attributes.get("file", "")
+            if entity.type != ENDPOINT or _TEST_PATH.search(rel):
+                continue
+            line = int(entity.attributes.get("line") or 0)
+            scan = scans.get(rel)
+            entries.append(
+                EntryPoint(
+                    "http",
+                    entity.name,
+                    f"{rel}:{line}",
+                    _service_of(graph, rel),
+                    authenticated=bool(scan and scan.authenticates(line)),
+                )
+            )
+        return sorted(entries, key=lambda e: (e.kind != "http", e.location, e.name))
+
+    def _dependencies(self) -> tuple[dict[str, int], Any]:
+        from .advisories import collect_dependencies, load_report
+
+        counts: dict[str, int] = {}
+        for dep in collect_dependencies(self.project_dir):
+            counts[dep.ecosystem] = counts.get(dep.ecosystem, 0) + 1
+        try:
+            report = load_report(self.project_dir)
+        except Exception as e:
+            logger.debug(f"Could not read the advisory report: {e}")
+            report = None
+        return counts, report
+
+    def update(self, check: bool = False, rebuild: bool = False) -> ThreatModelUpdate:
+        """Draft the threat model, or bring the existing document up to date.
+
+        Args:
+            check: Only compare with the document on disk; write nothing
+            rebuild: Rescan every file, ignoring what the last run recorded
+        """
+        state = read_json(self.state_path, {})
+        state = state if isinstance(state, dict) else {}
+        scans, rescanned, changed = self._scan(state.get("files") or {}, rebuild)
+        graph = self._graph(changed or rebuild, save=not check)
+        dependencies, advisories = self._dependencies()
+        root = graph.get(f"{SERVICE}:.")
+        model = ThreatModel(
+            project=root.name if root else self.project_dir.name,
+            graph=graph,
+            scans=scans,
+            entry_points=self._entry_points(graph, scans),
+            threats=[],
+            dependencies=dependencies,
+            advisories=advisories,
+        )
+        model.threats = _Drafter(model).draft()
+
+        existing = self.output.read_text("utf-8") if self.output.is_file() else ""
+        edits = read_edits(existing)
+        today = datetime.now(UTC).date().isoformat()
+        known: dict[str, dict[str, Any]] = dict(state.get("threats") or {})
+        new = []
+        for threat in model.threats:
+            record = known.get(threat.id) or {}
+            if not record or record.get("resolved"):
+                new.append(threat.id)
+            threat.first_seen = record.get("first_seen") or today
+            edited = edits.get(threat.id, {})
+            threat.status = edited.get("status") or record.get("status") or "open"
+            threat.mitigation = (
+                edited.get("mitigation")
+                or record.get("mitigation")
+                or threat.mitigation
+            )
+            known[threat.id] = {
+                "title": threat.title,
+                "first_seen": threat.first_seen,
+                "status": threat.status,
+                "mitigation": threat.mitigation,
+                "resolved": None,
+            }
+        current = {t.id for t in model.threats}
+        resolved_now = []
+        for id_, record in known.items():
+            if id_ in current or record.get("resolved"):
+                continue
+            resolved_now.append(id_)
+            status = edits.get(id_, {}).get("status") or record.get("status", "open")
+            record.update(resolved=today, status=status)
+        resolved = [
+            {"id": id_, **record}
+            for id_, record in sorted(
+                known.items(), key=lambda item: (item[1]["resolved"] or "", item[0])
+            )
+            if record.get("resolved")
+        ]
+
+        document = render(model, resolved, today)
+        changed_doc = _without_date(document) != _without_date(existing)
+        if not check:
+            if changed_doc:
+                write_atomic(self.output, document)
+            write_atomic(
+                self.state_path,
+                json.dumps(
+                    {
+                        "output": str(self.output),
+                        "files": {rel: s.to_dict() for rel, s in scans.items()},
+                        "threats": known,
+                    },
+                    indent=2,
+                ),
+            )
+        return ThreatModelUpdate(
+            self.output, model, new, resolved_now, rescanned, changed_doc
+        )
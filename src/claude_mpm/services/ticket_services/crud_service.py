@@ -14,6 +14,7 @@ DESIGN DECISIONS:
 """
 
 import logging
+from collections.abc import Callable
 from typing import Any
 
 from ...core.logger import get_logger
@@ -104,16 +105,22 @@ class TicketCRUDService:
         page_size: int = 20,
         type_filter: str = "all",
         status_filter: str = "all",
+        offset: int | None = None,
+        match: Callable[[dict], bool] | None = None,
     ) -> dict[str, Any]:
         """
         List tickets with pagination and filtering.
 
+        Args:
+            offset: Tickets to skip after filtering; overrides ``page``
+            match: Extra filter a ticket must pass (e.g. ``--filter`` values)
+
         Returns:
             Dict with tickets list and pagination info
         """
         try:
             tickets = self._list_via_manager(
-                limit, page, page_size, type_filter, status_filter
+                limit, page, page_size, type_filter, status_filter, offset, match
             )
 
             return {
@@ -134,9 +141,15 @@ class TicketCRUDService:
         page_size: int,
         type_filter: str,
         status_filter: str,
+        offset: int | None = None,
+        match: Callable[[dict], bool] | None = None,
     ) -> list[dict]:
         """List tickets using TicketManager."""
-        all_tickets = self.ticket_manager.list_recent_tickets(limit=limit * 2)
+        if offset is None:
+            offset = (page - 1) * page_size
+        all_tickets = self.ticket_manager.list_recent_tickets(
+            limit=max(limit, offset + page_size) * 2
+        )
 
         # Apply filters
         filtered_tickets = []
@@ -150,10 +163,12 @@ class TicketCRUDService:
                 if ticket.get("status") != status_filter:
                     continue
 
+            if match is not None and not match(ticket):
+                continue
+
             filtered_tickets.append(ticket)
 
         # Apply pagination
-        offset = (page - 1) * page_size
         return filtered_tickets[offset : offset + page_size]
 
     def update_ticket(
@@ -9,10 +9,13 @@ DESIGN DECISIONS:
 - Uses emoji for visual status indicators
 - Handles pagination display
 - Provides consistent formatting patterns
+- Epics and issues render as a tree of their child tickets
 """
 
 from typing import Any
 
+from ...utils.table_view import TreeNode, render_tree
+
 
 class TicketFormatterService:
     """Service for formatting ticket output."""
@@ -111,6 +114,63 @@ class TicketFormatterService:
 
         return lines
 
+    def ticket_rows(self, tickets: list[dict[str, Any]]) -> list[dict[str, Any]]:
+        """Flatten tickets into table rows keyed by ticket column names."""
+        return [
+            {
+                "id": ticket.get("id"),
+                "title": ticket.get("title"),
+                "status": ticket.get("status"),
+                "priority": ticket.get("priority"),
+                "type": ticket.get("metadata", {}).get("ticket_type"),
+                "created_at": ticket.get("created_at"),
+            }
+            for ticket in tickets
+        ]
+
+    def format_ticket_tree(self, tickets: list[dict[str, Any]]) -> str:
+        """
+        Format tickets as a tree of epics, issues and their children.
+
+        Tickets whose parent is not in the list are shown at the top level,
+        so a filtered list still shows every ticket once.
+
+        Returns:
+            Rendered tree
+        """
+        if not tickets:
+            return "No tickets found matching criteria"
+
+        by_id = {ticket["id"]: ticket for ticket in tickets}
+        children: dict[str, list[dict[str, Any]]] = {}
+        roots = []
+        for ticket in tickets:
+            metadata = ticket.get("metadata", {})
+            parent = metadata.get("parent_issue") or metadata.get("parent_epic")
+            if parent in by_id and parent != ticket["id"]:
+                children.setdefault(parent, []).append(ticket)
+            else:
+                roots.append(ticket)
+
+        def node(ticket: dict[str, Any], seen: frozenset[str]) -> TreeNode:
+            status = ticket.get("status", "unknown")
+            emoji = self.STATUS_EMOJI.get(status, self.DEFAULT_EMOJI)
+            ticket_type = ticket.get("metadata", {}).get("ticket_type", "task")
+            label = f"{emoji} [{ticket['id']}] {ticket['title']} ({ticket_type})"
+            seen = seen | {ticket["id"]}
+            return TreeNode(
+                label,
+                [
+                    node(child, seen)
+                    for child in children.get(ticket["id"], [])
+                    if child["id"] not in seen
+                ],
+            )
+
+        return render_tree(
+            f"Tickets ({len(tickets)})", [node(ticket, frozenset()) for ticket in roots]
+        )
+
     def format_ticket_detail(
         self, ticket: dict[str, Any], verbose: bool = False
     ) -> list[str]:
@@ -85,6 +85,8 @@ def create_app(service_config: UIServiceConfig | None = None) -> FastAPI:
     app.state.process_manager = ProcessManager(
         max_sessions=cfg.max_sessions,
         session_timeout_minutes=cfg.session_timeout_minutes,
+        max_active_turns=cfg.max_active_turns,
+        hibernate_after_minutes=cfg.hibernate_after_minutes,
     )
 
     # CORS middleware
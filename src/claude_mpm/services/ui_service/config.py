@@ -25,6 +25,10 @@ class UIServiceConfig:
         anthropic_api_key: Anthropic API key (from env ANTHROPIC_API_KEY).
         max_sessions: Maximum number of concurrent managed sessions.
         session_timeout_minutes: Minutes of inactivity before session cleanup.
+        max_active_turns: Maximum number of turns in flight at once; waiting
+            turns are served by session priority (0 means no limit).
+        hibernate_after_minutes: Minutes of inactivity before a session's
+            subprocess is stopped until its next message (0 disables).
     """
 
     host: str = "127.0.0.1"
@@ -37,6 +41,8 @@ class UIServiceConfig:
     anthropic_api_key: str | None = None
     max_sessions: int = 10
     session_timeout_minutes: int = 60
+    max_active_turns: int = 0
+    hibernate_after_minutes: int = 15
     global_sessions_dir: Path = field(
         default_factory=lambda: Path.home() / ".claude-mpm" / "sessions"
     )
@@ -69,6 +75,10 @@ class UIServiceConfig:
             session_timeout_minutes=int(
                 os.getenv("CLAUDE_MPM_UI_SESSION_TIMEOUT", "60")
             ),
+            max_active_turns=int(os.getenv("CLAUDE_MPM_UI_MAX_ACTIVE_TURNS", "0")),
+            hibernate_after_minutes=int(
+                os.getenv("CLAUDE_MPM_UI_HIBERNATE_AFTER", "15")
+            ),
             global_sessions_dir=Path(
                 os.getenv(
                     "CLAUDE_MPM_UI_SESSIONS_DIR",
@@ -23,9 +23,28 @@ class SessionStatus(str, Enum):
     idle = "idle"
     busy = "busy"
     compacting = "compacting"
+    paused = "paused"
+    hibernated = "hibernated"
     terminated = "terminated"
 
 
+class SessionPriority(str, Enum):
+    """Scheduling class of a managed session.
+
+    Interactive sessions are served first when turns queue up and may pause
+    batch sessions to get a slot; background sessions queue behind them.
+    """
+
+    interactive = "interactive"
+    background = "background"
+    batch = "batch"
+
+    @property
+    def rank(self) -> int:
+        """Lower ranks are scheduled first."""
+        return list(SessionPriority).index(self)
+
+
 class SessionCreate(BaseModel):
     """Request body for creating a new session.
 
@@ -37,6 +56,7 @@ class SessionCreate(BaseModel):
         permission_mode: Initial permission mode (default, acceptEdits, etc.).
         project_root: Optional project root directory; used as cwd default when
             cwd is not explicitly set.
+        priority: Scheduling class (interactive, background or batch).
     """
 
     model_config = ConfigDict(from_attributes=True)
@@ -47,6 +67,9 @@ class SessionCreate(BaseModel):
     cwd: str | None = Field(None, description="Working directory for subprocess")
     permission_mode: str = Field("default", description="Initial permission mode")
     project_root: str | None = Field(None, description="Project root directory")
+    priority: SessionPriority = Field(
+        SessionPriority.interactive, description="Scheduling class"
+    )
 
 
 class SessionUpdate(BaseModel):
@@ -56,6 +79,7 @@ class SessionUpdate(BaseModel):
         model: New model to use (applies to next message).
         permission_mode: Updated permission mode.
         output_format: Output format override.
+        priority: New scheduling class (applies to the next turn).
     """
 
     model_config = ConfigDict(from_attributes=True)
@@ -63,6 +87,7 @@ class SessionUpdate(BaseModel):
     model: str | None = None
     permission_mode: str | None = None
     output_format: str | None = None
+    priority: SessionPriority | None = None
 
 
 class ManagedSessionState(BaseModel):
@@ -81,6 +106,8 @@ class ManagedSessionState(BaseModel):
         context_tokens_total: Total context window capacity.
         context_percent_used: Percentage of context window used.
         permission_mode: Active permission mode.
+        priority: Scheduling class.
+        preempted_by: ID of the session that paused this one to take its slot.
         schema_version: API schema version for forward-compatibility detection.
     """
 
@@ -98,6 +125,8 @@ class ManagedSessionState(BaseModel):
     context_tokens_total: int = 200000
     context_percent_used: float = 0.0
     permission_mode: str = "default"
+    priority: SessionPriority = SessionPriority.interactive
+    preempted_by: str | None = None
     schema_version: str = "1"
 
 
@@ -7,12 +7,28 @@ WHY: Centralising subprocess lifecycle and activity recording in one place
      keeps the router layer thin and lets the /status and /activity endpoints
      read consistent, thread-safe state without duplicating tracking logic.
 
+PRIORITIES: Each session has a priority class (interactive, background or
+batch). When max_sessions is reached, a new interactive session pauses the
+least recently active batch session (SIGSTOP) instead of being refused; the
+batch session is resumed (SIGCONT) as soon as a slot frees up. When
+max_active_turns is set, turns beyond the limit wait for a slot and are
+served interactive first, so interactive sessions are not stuck behind batch
+work competing for the same API rate limit.
+
+HIBERNATION: Sessions idle for hibernate_after_minutes are hibernated: their
+state is persisted and the subprocess is stopped, but the session keeps its
+ID and history. The next message respawns claude with --resume, so clients
+never notice apart from a slower first reply. Hibernated sessions do not
+count against max_sessions and are not removed by the inactivity timeout.
+
 References
 ----------
 SPEC-SESSIONS-09~1 : docs/specs/sessions.md#SPEC-SESSIONS-09~1
 """
 
 import asyncio
+import heapq
+import itertools
 import json
 import logging
 import signal
@@ -32,12 +48,19 @@ from claude_mpm.services.ui_service.models.message import StreamEvent
 from claude_mpm.services.ui_service.models.session import (
     ManagedSessionState,
     SessionCreate,
+    SessionPriority,
     SessionStatus,
 )
 
 logger = logging.getLogger(__name__)
 
 
+def _running_event() -> asyncio.Event:
+    event = asyncio.Event()
+    event.set()
+    return event
+
+
 @dataclass
 class ManagedSession:
     """Internal state for a single managed claude subprocess session.
@@ -57,7 +80,14 @@ class ManagedSession:
         message_history: Ordered list of user/assistant message dicts.
         output_queue: Parsed stream-json events for current turn.
         state_tracker: Per-session activity and state tracker (None for persisted/stub sessions).
+        priority: Scheduling class of the session.
+        preempted_by: ID of the session that paused this one, if any.
+        paused_at: When the session was paused.
+        paused_status: Status to restore when the session is resumed.
+        bare: Whether the subprocess runs with --bare (kept for rehydration).
+        hibernated_at: When the session was hibernated.
         _stdin_lock: Serialises writes to process stdin.
+        _running: Set while the session is not paused.
     """
 
     id: str
@@ -75,7 +105,14 @@ class ManagedSession:
     message_history: list[dict] = field(default_factory=list)
     output_queue: asyncio.Queue = field(default_factory=asyncio.Queue)
     state_tracker: SessionStateTracker | None = field(default=None)
+    priority: SessionPriority = SessionPriority.interactive
+    preempted_by: str | None = None
+    paused_at: datetime | None = None
+    paused_status: SessionStatus | None = None
+    bare: bool = False
+    hibernated_at: datetime | None = None
     _stdin_lock: asyncio.Lock = field(default_factory=asyncio.Lock)
+    _running: asyncio.Event = field(default_factory=_running_event)
 
     def to_state(self) -> ManagedSessionState:
         """Convert to the public-facing Pydantic state model."""
@@ -97,8 +134,64 @@ class ManagedSession:
             context_tokens_total=self.context_tokens_total,
             context_percent_used=round(pct, 2),
             permission_mode=self.permission_mode,
+            priority=self.priority,
+            preempted_by=self.preempted_by,
         )
 
+    def set_idle(self) -> None:
+        """Mark a finished turn, keeping a paused session paused."""
+        if self.status == SessionStatus.paused:
+            self.paused_status = SessionStatus.idle
+        else:
+            self.status = SessionStatus.idle
+
+
+class TurnScheduler:
+    """Limits concurrent turns, serving waiting turns by session priority.
+
+    Waiting turns get a slot interactive first, then background, then batch,
+    and in arrival order within a class. A limit of 0 means turns never wait.
+    """
+
+    def __init__(self, max_active: int = 0):
+        self.max_active = max_active
+        self.active = 0
+        self._waiting: list[tuple[int, int, asyncio.Future]] = []
+        self._order = itertools.count()
+
+    @property
+    def waiting(self) -> int:
+        """Number of turns waiting for a slot."""
+        return len(self._waiting)
+
+    async def acquire(self, priority: SessionPriority) -> None:
+        """Wait for a turn slot."""
+        if not self.max_active or (
+            self.active < self.max_active and not self._waiting
+        ):
+            self.active += 1
+            return
+        future = asyncio.get_running_loop().create_future()
+        heapq.heappush(self._waiting, (priority.rank, next(self._order), future))
+        try:
+            await future
+        except asyncio.CancelledError:
+            if future.cancelled():
+                self._waiting = [w for w in self._waiting if w[2] is not future]
+                heapq.heapify(self._waiting)
+            else:
+                # The slot was handed over just before the cancellation
+                self.release()
+            raise
+
+    def release(self) -> None:
+        """Free a turn slot and hand it to the best waiting turn."""
+        self.active -= 1
+        if self._waiting:
+            _, _, future = heapq.heappop(self._waiting)
+            self.active += 1
+            future.set_result(None)
+
 
 class ProcessManager:
     """Manages the lifecycle of claude CLI subprocesses.
@@ -111,15 +204,26 @@ class ProcessManager:
     All logic paths that would use a real process gracefully degrade.
 
     Attributes:
-        max_sessions: Maximum concurrent sessions.
+        max_sessions: Maximum concurrent sessions; paused sessions do not count.
         session_timeout_minutes: Inactivity timeout for cleanup.
+        hibernate_after_minutes: Idle minutes before a session is hibernated
+            (0 disables hibernation).
+        turns: Scheduler limiting concurrent turns (max_active_turns).
         _sessions: Mapping of session id -> ManagedSession.
         _cleanup_task: Background task for periodic cleanup.
     """
 
-    def __init__(self, max_sessions: int = 10, session_timeout_minutes: int = 60):
+    def __init__(
+        self,
+        max_sessions: int = 10,
+        session_timeout_minutes: int = 60,
+        max_active_turns: int = 0,
+        hibernate_after_minutes: int = 0,
+    ):
         self.max_sessions = max_sessions
         self.session_timeout_minutes = session_timeout_minutes
+        self.hibernate_after_minutes = hibernate_after_minutes
+        self.turns = TurnScheduler(max_active_turns)
         self._sessions: dict[str, ManagedSession] = {}
         self._cleanup_task: asyncio.Task | None = None
 
@@ -155,52 +259,34 @@ class ProcessManager:
         Args:
             config: Parameters for the new session.
 
+        An interactive session created at capacity pauses a batch session
+        to take its slot.
+
         Returns:
             The created ManagedSession.
 
         Raises:
-            RuntimeError: If the maximum number of sessions has been reached.
+            RuntimeError: If the maximum number of sessions has been reached
+                and no batch session can be paused.
         """
-        if len(self._sessions) >= self.max_sessions:
-            raise RuntimeError(
-                f"Maximum sessions ({self.max_sessions}) reached. "
-                "Terminate an existing session first."
-            )
-
         session_id = str(uuid.uuid4())
+        if self._running_count() >= self.max_sessions:
+            victim = self._preemption_victim(config.priority)
+            if victim is None:
+                raise RuntimeError(
+                    f"Maximum sessions ({self.max_sessions}) reached. "
+                    "Terminate an existing session first."
+                )
+            await self.pause(victim.id, preempted_by=session_id)
+
         now = datetime.now(tz=UTC)
         # Use project_root as cwd fallback when cwd is not explicitly provided.
         cwd = config.cwd or config.project_root or str(Path.cwd())
         model = config.model or "claude-opus-4-5"
 
-        # Build subprocess command
-        cmd = ["claude", "--output-format", "stream-json", "--print"]
-        if config.resume_id:
-            cmd += ["--resume", config.resume_id]
-        if config.bare:
-            cmd += ["--bare"]
-        if config.model:
-            cmd += ["--model", config.model]
-
-        process: asyncio.subprocess.Process | None = None
-        try:
-            process = await asyncio.create_subprocess_exec(
-                *cmd,
-                stdin=PIPE,
-                stdout=PIPE,
-                stderr=PIPE,
-                cwd=cwd,
-            )
-            logger.info(
-                "Spawned claude subprocess pid=%s session=%s", process.pid, session_id
-            )
-        except FileNotFoundError:
-            # claude CLI not installed — operate in stub mode
-            logger.warning(
-                "claude CLI not found; session %s will operate in stub mode", session_id
-            )
-        except Exception as exc:
-            logger.error("Failed to spawn claude process: %s", exc)
+        process = await self._spawn(
+            session_id, cwd, config.resume_id, config.bare, config.model
+        )
 
         tracker = SessionStateTracker()
         tracker.set_model(model)
@@ -219,6 +305,8 @@ class ProcessManager:
             context_tokens_total=200000,
             permission_mode=config.permission_mode,
             state_tracker=tracker,
+            priority=config.priority,
+            bare=config.bare,
         )
 
         self._sessions[session_id] = session
@@ -233,6 +321,45 @@ class ProcessManager:
 
         return session
 
+    async def _spawn(
+        self,
+        session_id: str,
+        cwd: str,
+        resume_id: str | None,
+        bare: bool,
+        model: str | None,
+    ) -> asyncio.subprocess.Process | None:
+        """Start a claude subprocess; None when it cannot be started (stub mode)."""
+        # Build subprocess command
+        cmd = ["claude", "--output-format", "stream-json", "--print"]
+        if resume_id:
+            cmd += ["--resume", resume_id]
+        if bare:
+            cmd += ["--bare"]
+        if model:
+            cmd += ["--model", model]
+
+        process: asyncio.subprocess.Process | None = None
+        try:
+            process = await asyncio.create_subprocess_exec(
+                *cmd,
+                stdin=PIPE,
+                stdout=PIPE,
+                stderr=PIPE,
+                cwd=cwd,
+            )
+            logger.info(
+                "Spawned claude subprocess pid=%s session=%s", process.pid, session_id
+            )
+        except FileNotFoundError:
+            # claude CLI not installed — operate in stub mode
+            logger.warning(
+                "claude CLI not found; session %s will operate in stub mode", session_id
+            )
+        except Exception as exc:
+            logger.error("Failed to spawn claude process: %s", exc)
+        return process
+
     def get_session(self, session_id: str) -> ManagedSession:
         """Retrieve a session by ID.
 
@@ -263,21 +390,269 @@ class ProcessManager:
         if not session:
             return
 
+        if session.status == SessionStatus.paused:
+            # A stopped process only handles SIGTERM once it is continued
+            self._signal(session, signal.SIGCONT)
         session.status = SessionStatus.terminated
+        session._running.set()
         if session.state_tracker is not None:
             session.state_tracker.record_stopped()
 
-        if session.process and session.process.returncode is None:
+        await self._stop_process(session.process)
+
+        del self._sessions[session_id]
+        logger.info("Terminated session %s", session_id)
+        self._resume_preempted()
+
+    @staticmethod
+    async def _stop_process(process: asyncio.subprocess.Process | None) -> None:
+        if process and process.returncode is None:
             try:
-                session.process.terminate()
-                await asyncio.wait_for(session.process.wait(), timeout=5.0)
+                process.terminate()
+                await asyncio.wait_for(process.wait(), timeout=5.0)
             except TimeoutError:
-                session.process.kill()
+                process.kill()
             except ProcessLookupError:
                 pass
 
-        del self._sessions[session_id]
-        logger.info("Terminated session %s", session_id)
+    # ------------------------------------------------------------------
+    # Hibernation
+    # ------------------------------------------------------------------
+
+    async def hibernate(self, session_id: str) -> ManagedSession:
+        """Persist an idle session and stop its subprocess.
+
+        The session keeps its ID and history; the next message rehydrates it
+        with ``claude --resume``.
+
+        Args:
+            session_id: The UI service session UUID.
+
+        Returns:
+            The hibernated session.
+
+        Raises:
+            KeyError: If no session with that ID exists.
+            RuntimeError: If the session is not idle or has no Claude session
+                ID to resume.
+        """
+        session = self.get_session(session_id)
+        if session.status == SessionStatus.hibernated:
+            return session
+        if session.status != SessionStatus.idle:
+            raise RuntimeError(
+                f"Session {session_id} is {session.status.value}; "
+                "only idle sessions can hibernate"
+            )
+        if not session.claude_session_id:
+            raise RuntimeError(
+                f"Session {session_id} has no Claude session ID to resume from"
+            )
+
+        process, session.process = session.process, None
+        session.status = SessionStatus.hibernated
+        session.hibernated_at = datetime.now(tz=UTC)
+        self._persist_session(session)
+        await self._stop_process(process)
+        logger.info("Hibernated session %s", session_id)
+        self._resume_preempted()
+        return session
+
+    async def _rehydrate(self, session: ManagedSession) -> None:
+        """Respawn a hibernated session's subprocess with --resume."""
+        if self._running_count() >= self.max_sessions:
+            victim = self._preemption_victim(session.priority)
+            if victim is None:
+                raise RuntimeError(
+                    f"Maximum sessions ({self.max_sessions}) running; "
+                    f"cannot wake hibernated session {session.id}"
+                )
+            await self.pause(victim.id, preempted_by=session.id)
+
+        session.process = await self._spawn(
+            session.id,
+            session.cwd,
+            session.claude_session_id,
+            session.bare,
+            session.model,
+        )
+        session.output_queue = asyncio.Queue()
+        session.status = (
+            SessionStatus.idle if session.process else SessionStatus.starting
+        )
+        session.hibernated_at = None
+        if session.state_tracker is not None:
+            session.state_tracker.set_state(SessionState.IDLE)
+        self._persist_session(session)
+        if session.process:
+            asyncio.create_task(
+                self._read_stdout(session),
+                name=f"stdout-reader-{session.id}",
+            )
+        logger.info("Rehydrated session %s", session.id)
+
+    async def reap_idle(self, now: datetime | None = None) -> None:
+        """Hibernate sessions past hibernate_after_minutes and remove
+        sessions past session_timeout_minutes.
+        """
+        now = now or datetime.now(tz=UTC)
+        for session_id, session in list(self._sessions.items()):
+            if session.preempted_by or session.status == SessionStatus.hibernated:
+                # Waiting for a slot is not inactivity; hibernated sessions
+                # hold no process
+                continue
+            idle_seconds = (now - session.last_activity).total_seconds()
+            if (
+                self.hibernate_after_minutes
+                and idle_seconds > self.hibernate_after_minutes * 60
+                and session.status == SessionStatus.idle
+                and session.process is not None
+                and session.claude_session_id
+            ):
+                await self.hibernate(session_id)
+            elif idle_seconds > self.session_timeout_minutes * 60:
+                logger.info(
+                    "Cleaning up idle session %s (idle %.0fs)",
+                    session_id,
+                    idle_seconds,
+                )
+                await self.terminate(session_id)
+
+    # ------------------------------------------------------------------
+    # Priorities and preemption
+    # ------------------------------------------------------------------
+
+    async def pause(
+        self, session_id: str, preempted_by: str | None = None
+    ) -> ManagedSession:
+        """Stop a session's subprocess (SIGSTOP) and free its slot.
+
+        New messages to a paused session wait until it is resumed.
+
+        Args:
+            session_id: The UI service session UUID.
+            preempted_by: Session that takes the slot; preempted sessions
+                are resumed automatically when a slot frees up.
+
+        Returns:
+            The paused session.
+
+        Raises:
+            KeyError: If no session with that ID exists.
+            RuntimeError: If the session has terminated.
+        """
+        session = self.get_session(session_id)
+        if session.status == SessionStatus.terminated:
+            raise RuntimeError(f"Session {session_id} has terminated")
+        if session.status in (SessionStatus.paused, SessionStatus.hibernated):
+            return session
+
+        self._signal(session, signal.SIGSTOP)
+        session.paused_status = session.status
+        session.status = SessionStatus.paused
+        session.paused_at = datetime.now(tz=UTC)
+        session.preempted_by = preempted_by
+        session._running.clear()
+        self._persist_session(session)
+        if preempted_by:
+            logger.info(
+                "Paused %s session %s for session %s",
+                session.priority.value,
+                session_id,
+                preempted_by,
+            )
+        else:
+            logger.info("Paused session %s", session_id)
+        return session
+
+    async def resume(self, session_id: str) -> ManagedSession:
+        """Continue a paused session (SIGCONT).
+
+        Args:
+            session_id: The UI service session UUID.
+
+        Returns:
+            The resumed session.
+
+        Raises:
+            KeyError: If no session with that ID exists.
+            RuntimeError: If every session slot is taken.
+        """
+        session = self.get_session(session_id)
+        if session.status != SessionStatus.paused:
+            return session
+        if self._running_count() >= self.max_sessions:
+            raise RuntimeError(
+                f"Maximum sessions ({self.max_sessions}) running. "
+                "Pause or terminate another session first."
+            )
+        self._resume(session)
+        return session
+
+    def _resume(self, session: ManagedSession) -> None:
+        self._signal(session, signal.SIGCONT)
+        session.status = session.paused_status or SessionStatus.idle
+        session.paused_status = None
+        session.paused_at = None
+        session.preempted_by = None
+        session._running.set()
+        self._persist_session(session)
+        logger.info("Resumed session %s", session.id)
+
+    def _resume_preempted(self) -> None:
+        """Resume preempted sessions, longest paused first, while slots are free."""
+        preempted = sorted(
+            (
+                s
+                for s in self._sessions.values()
+                if s.status == SessionStatus.paused and s.preempted_by
+            ),
+            key=lambda s: s.paused_at or s.created_at,
+        )
+        for session in preempted:
+            if self._running_count() >= self.max_sessions:
+                break
+            self._resume(session)
+
+    def _running_count(self) -> int:
+        return sum(
+            1
+            for s in self._sessions.values()
+            if s.status not in (SessionStatus.paused, SessionStatus.hibernated)
+        )
+
+    def _preemption_victim(self, priority: SessionPriority) -> ManagedSession | None:
+        """Pick the batch session an interactive session may pause.
+
+        Idle sessions go before busy ones, least recently active first.
+        """
+        if priority != SessionPriority.interactive:
+            return None
+        candidates = [
+            s
+            for s in self._sessions.values()
+            if s.priority == SessionPriority.batch
+            and s.status
+            not in (
+                SessionStatus.paused,
+                SessionStatus.hibernated,
+                SessionStatus.terminated,
+            )
+        ]
+        if not candidates:
+            return None
+        return min(
+            candidates,
+            key=lambda s: (s.status == SessionStatus.busy, s.last_activity),
+        )
+
+    @staticmethod
+    def _signal(session: ManagedSession, signum: int) -> None:
+        if session.process and session.process.returncode is None:
+            try:
+                session.process.send_signal(signum)
+            except ProcessLookupError:
+                pass
 
     # ------------------------------------------------------------------
     # Session persistence
@@ -316,6 +691,10 @@ class ProcessManager:
                 "context_tokens_used": session.context_tokens_used,
                 "context_tokens_total": session.context_tokens_total,
                 "permission_mode": session.permission_mode,
+                "priority": session.priority.value,
+                "preempted_by": session.preempted_by,
+                "bare": session.bare,
+                "message_history": session.message_history,
             }
             session_file.write_text(json.dumps(state, indent=2))
         except Exception as exc:
@@ -325,7 +704,8 @@ class ProcessManager:
         """Load previously persisted session metadata from disk on startup.
 
         Only metadata is loaded; subprocesses are not reattached.  Sessions
-        are marked as 'terminated' so the caller knows a restart occurred.
+        are marked as 'terminated' so the caller knows a restart occurred,
+        except hibernated ones, which wake up on their next message.
         """
         try:
             sessions_dir = self._get_global_sessions_dir()
@@ -337,11 +717,18 @@ class ProcessManager:
                         continue
 
                     now = datetime.now(tz=UTC)
+                    hibernated = data.get("status") == "hibernated" and data.get(
+                        "claude_session_id"
+                    )
                     session = ManagedSession(
                         id=session_id,
                         claude_session_id=data.get("claude_session_id"),
                         process=None,
-                        status=SessionStatus.terminated,
+                        status=(
+                            SessionStatus.hibernated
+                            if hibernated
+                            else SessionStatus.terminated
+                        ),
                         model=data.get("model", "claude-opus-4-5"),
                         cwd=data.get("cwd", str(Path.cwd())),
                         project_root=data.get("project_root"),
@@ -354,6 +741,11 @@ class ProcessManager:
                         context_tokens_used=data.get("context_tokens_used", 0),
                         context_tokens_total=data.get("context_tokens_total", 200000),
                         permission_mode=data.get("permission_mode", "default"),
+                        priority=SessionPriority(
+                            data.get("priority", SessionPriority.interactive)
+                        ),
+                        bare=data.get("bare", False),
+                        message_history=data.get("message_history", []),
                     )
                     self._sessions[session_id] = session
                     logger.debug("Loaded persisted session %s", session_id)
@@ -375,7 +767,8 @@ class ProcessManager:
 
         Writes the message to the subprocess stdin then yields StreamEvent
         objects as they arrive on stdout. Falls back to a stub response when
-        no live process is available.
+        no live process is available. The turn waits while the session is
+        paused and, when max_active_turns is set, for a turn slot.
 
         Args:
             session_id: The UI service session UUID.
@@ -385,6 +778,13 @@ class ProcessManager:
             StreamEvent objects parsed from the claude stream-json output.
         """
         session = self.get_session(session_id)
+        async with session._stdin_lock:
+            if session.status == SessionStatus.hibernated:
+                try:
+                    await self._rehydrate(session)
+                except RuntimeError as exc:
+                    yield StreamEvent(type="error", data={"message": str(exc)})
+                    return
         session.last_activity = datetime.now(tz=UTC)
 
         # Record in history and tracker
@@ -392,6 +792,19 @@ class ProcessManager:
         if session.state_tracker is not None:
             session.state_tracker.record_user_input(content)
 
+        await session._running.wait()
+        await self.turns.acquire(session.priority)
+        try:
+            async for event in self._run_turn(session, content):
+                yield event
+        finally:
+            self.turns.release()
+
+    async def _run_turn(
+        self, session: ManagedSession, content: str
+    ) -> AsyncIterator[StreamEvent]:
+        """Write one message to the subprocess and yield its events."""
+        session_id = session.id
         if not session.process or session.process.returncode is not None:
             # Stub mode: yield a synthetic response
             async for event in self._stub_response(session, content):
@@ -421,6 +834,9 @@ class ProcessManager:
                     session.output_queue.get(), timeout=120.0
                 )
             except TimeoutError:
+                if session.status == SessionStatus.paused:
+                    # A stopped process cannot answer; wait for the resume
+                    continue
                 session.status = SessionStatus.idle
                 if session.state_tracker is not None:
                     session.state_tracker.set_state(SessionState.IDLE)
@@ -432,7 +848,7 @@ class ProcessManager:
             yield event
 
             if event.type in ("result", "error"):
-                session.status = SessionStatus.idle
+                session.set_idle()
                 session.last_activity = datetime.now(tz=UTC)
                 break
 
@@ -484,11 +900,12 @@ class ProcessManager:
         Args:
             session: The session whose stdout to consume.
         """
-        if not session.process or not session.process.stdout:
+        process = session.process
+        if not process or not process.stdout:
             return
 
         try:
-            async for raw_line in session.process.stdout:
+            async for raw_line in process.stdout:
                 line = raw_line.decode("utf-8", errors="replace").strip()
                 if not line:
                     continue
@@ -504,9 +921,11 @@ class ProcessManager:
         except Exception as exc:
             logger.error("stdout reader error for session %s: %s", session.id, exc)
         finally:
-            session.status = SessionStatus.terminated
-            if session.state_tracker is not None:
-                session.state_tracker.record_stopped()
+            # A hibernated or rehydrated session no longer uses this process
+            if session.process is process:
+                session.status = SessionStatus.terminated
+                if session.state_tracker is not None:
+                    session.state_tracker.record_stopped()
 
     def _parse_stream_event(self, session: ManagedSession, data: dict) -> StreamEvent:
         """Parse a raw stream-json dict into a StreamEvent, updating session state.
@@ -573,7 +992,7 @@ class ProcessManager:
                 session.message_history.append(
                     {"role": "assistant", "content": result_text}
                 )
-            session.status = SessionStatus.idle
+            session.set_idle()
             usage_dict = (
                 data.get("usage") if isinstance(data.get("usage"), dict) else None
             )
@@ -624,7 +1043,7 @@ class ProcessManager:
             type="result",
             data={"type": "result", "result": stub_text, "stop_reason": "end_turn"},
         )
-        session.status = SessionStatus.idle
+        session.set_idle()
         session.last_activity = datetime.now(tz=UTC)
         if session.state_tracker is not None:
             session.state_tracker.record_result(
@@ -632,18 +1051,9 @@ class ProcessManager:
             )
 
     async def _cleanup_loop(self) -> None:
-        """Periodically remove sessions that have exceeded the timeout."""
+        """Periodically hibernate or remove idle sessions."""
         import asyncio as _asyncio
 
         while True:
             await _asyncio.sleep(60)
-            cutoff = datetime.now(tz=UTC)
-            for session_id, session in list(self._sessions.items()):
-                idle_seconds = (cutoff - session.last_activity).total_seconds()
-                if idle_seconds > self.session_timeout_minutes * 60:
-                    logger.info(
-                        "Cleaning up idle session %s (idle %.0fs)",
-                        session_id,
-                        idle_seconds,
-                    )
-                    await self.terminate(session_id)
+            await self.reap_idle()
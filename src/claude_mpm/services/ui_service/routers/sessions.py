@@ -11,9 +11,12 @@ Endpoints:
     POST /sessions                    — create/resume a session
     GET  /sessions/{id}               — get session state
     DELETE /sessions/{id}             — terminate session
-    PATCH /sessions/{id}              — update model/permission_mode/output_format
+    PATCH /sessions/{id}              — update model/permission_mode/priority
     POST /sessions/{id}/fork          — fork session (sends /fork to stdin)
     POST /sessions/{id}/interrupt     — send SIGINT
+    POST /sessions/{id}/pause         — pause the subprocess (SIGSTOP)
+    POST /sessions/{id}/resume        — resume a paused session (SIGCONT)
+    POST /sessions/{id}/hibernate     — stop an idle session until its next message
     PUT  /sessions/{id}/plan-mode     — toggle plan mode
     GET  /sessions/{id}/status        — minimal stable status (schema_version=1)
     GET  /sessions/{id}/activity      — recent activity events
@@ -53,6 +56,8 @@ async def create_session(request: Request, body: SessionCreate):
 
     Optionally pass ``resume_id`` to attach to an existing Claude session,
     ``model`` to override the model, and ``bare`` to suppress the system prompt.
+    ``priority`` sets the scheduling class; an interactive session created at
+    capacity pauses a batch session to take its slot.
     """
     pm = _get_pm(request)
     try:
@@ -86,7 +91,7 @@ async def delete_session(request: Request, session_id: str):
 
 @router.patch("/{session_id}", summary="Update session properties")
 async def update_session(request: Request, session_id: str, body: SessionUpdate):
-    """Update mutable session properties (model, permission_mode, output_format).
+    """Update mutable session properties (model, permission_mode, priority).
 
     Changes take effect on the next message sent to the session.
     """
@@ -100,6 +105,8 @@ async def update_session(request: Request, session_id: str, body: SessionUpdate)
         session.model = body.model
     if body.permission_mode is not None:
         session.permission_mode = body.permission_mode
+    if body.priority is not None:
+        session.priority = body.priority
 
     return session.to_state().model_dump()
 
@@ -131,6 +138,51 @@ async def interrupt_session(request: Request, session_id: str):
     return {"message": "Interrupt sent", "session_id": session_id}
 
 
+@router.post("/{session_id}/pause", summary="Pause a session")
+async def pause_session(request: Request, session_id: str):
+    """Stop the subprocess (SIGSTOP) and free its session slot.
+
+    Messages sent while the session is paused wait until it is resumed.
+    """
+    pm = _get_pm(request)
+    try:
+        session = await pm.pause(session_id)
+    except KeyError as exc:
+        raise HTTPException(status_code=404, detail=str(exc)) from exc
+    except RuntimeError as exc:
+        raise HTTPException(status_code=409, detail=str(exc)) from exc
+    return session.to_state().model_dump()
+
+
+@router.post("/{session_id}/resume", summary="Resume a paused session")
+async def resume_session(request: Request, session_id: str):
+    """Continue a paused subprocess (SIGCONT) when a session slot is free."""
+    pm = _get_pm(request)
+    try:
+        session = await pm.resume(session_id)
+    except KeyError as exc:
+        raise HTTPException(status_code=404, detail=str(exc)) from exc
+    except RuntimeError as exc:
+        raise HTTPException(status_code=409, detail=str(exc)) from exc
+    return session.to_state().model_dump()
+
+
+@router.post("/{session_id}/hibernate", summary="Hibernate an idle session")
+async def hibernate_session(request: Request, session_id: str):
+    """Persist the session and stop its subprocess to free memory.
+
+    The next message respawns the subprocess with ``--resume``.
+    """
+    pm = _get_pm(request)
+    try:
+        session = await pm.hibernate(session_id)
+    except KeyError as exc:
+        raise HTTPException(status_code=404, detail=str(exc)) from exc
+    except RuntimeError as exc:
+        raise HTTPException(status_code=409, detail=str(exc)) from exc
+    return session.to_state().model_dump()
+
+
 @router.put("/{session_id}/plan-mode", summary="Toggle plan mode")
 async def set_plan_mode(request: Request, session_id: str):
     """Send the plan mode toggle command to the session's stdin."""
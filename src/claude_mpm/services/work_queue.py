@@ -0,0 +1,799 @@
+"""Broker-backed task queue shared by several worker machines.
+
+WHAT: Delegated agent tasks (a prompt, plus a directory or repository to run
+it in) are put on a queue in a broker; any number of workers, on any number
+of machines, pull them one at a time and run them as headless sessions:
+
+    claude-mpm work-queue submit "Upgrade the tests to pytest 8" --repo URL
+    claude-mpm work-queue worker           # on each worker machine
+    claude-mpm work-queue status           # workers, heartbeats, task counts
+
+Workers register with the broker and heartbeat every ``heartbeat_interval``
+seconds. A worker that misses heartbeats for ``worker_timeout`` seconds is
+considered dead: every worker (and ``work-queue requeue``) reaps dead
+workers and puts their unfinished tasks back on the queue, up to
+``max_attempts`` times.
+
+A task that fails is retried according to its retry policy (see
+``claude_mpm.services.retry_policy``), chosen by its task type or agent: a
+timeout, rate limit or network failure goes back on the queue, held back by
+an exponential backoff, until the policy's attempts are used. A task can
+depend on others (``submit --after ID``); it waits while they run or are
+retried, and fails without running when one of them fails for good. Every
+attempt is recorded in the task's ``history``.
+
+WHY: One machine running sessions for a team saturates quickly; spreading
+the work across machines needs a queue they all see and a way to recover
+tasks from a machine that crashes or loses its network mid-task.
+
+CONFIGURATION (.claude-mpm/configuration.yaml):
+
+    work_queue:
+      broker: redis://queue.internal:6379/0   # or sqlite:///path/to/queue.db
+      queue: default
+      heartbeat_interval: 10
+      worker_timeout: 60
+      max_attempts: 3
+      task_timeout: 3600
+      retry:                  # failed tasks; see services.retry_policy
+        backoff: 30
+        retry_on: [timeout, rate_limit, network, worker_lost]
+        task_types:
+          migration: {max_attempts: 1}
+
+    ``CLAUDE_MPM_QUEUE_BROKER`` overrides ``broker``. The default is a SQLite
+    file under ~/.claude-mpm, which serves workers on one machine or on
+    machines sharing that file system.
+
+DESIGN DECISIONS:
+- One ``Broker`` interface with a Redis and a SQLite implementation; the
+  Redis client is an optional dependency (``pip install claude-mpm[queue]``)
+- Claiming is atomic in both brokers (``LMOVE`` into a per-worker list in
+  Redis, an immediate transaction in SQLite), so two workers never run the
+  same task
+- A worker only completes a task it still holds: a worker that was declared
+  dead and comes back cannot overwrite the result of the re-run
+- A task waiting for its backoff or its dependencies stays ``pending``;
+  SQLite skips it when claiming, Redis parks it in a ``waiting`` sorted set
+  (scored by when it may run) that claiming promotes from
+- NATS is not supported; its JetStream redelivery would replace the
+  heartbeat-based re-queue rather than sit behind this interface
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import shutil
+import socket
+import sqlite3
+import subprocess
+import tempfile
+import threading
+import time
+import uuid
+from abc import ABC, abstractmethod
+from collections.abc import Callable, Iterator
+from contextlib import contextmanager
+from dataclasses import asdict, dataclass, field, fields
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+from .agents.agent_runtime import AgentResult
+from .kubernetes_jobs import parse_result
+from .retry_policy import DEPENDENCY, WORKER_LOST, RetryPolicies, classify_failure
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "work_queue"
+BROKER_ENV_VAR = "CLAUDE_MPM_QUEUE_BROKER"
+
+PENDING = "pending"
+RUNNING = "running"
+SUCCEEDED = "succeeded"
+FAILED = "failed"
+STATUSES = (PENDING, RUNNING, SUCCEEDED, FAILED)
+
+# A worker that stops cleanly gives its tasks back without using an attempt
+_ALWAYS_REQUEUE = 1 << 30
+
+
+def default_broker_url() -> str:
+    return f"sqlite:///{Path.home() / '.claude-mpm' / 'work_queue.db'}"
+
+
+def _now() -> str:
+    return datetime.now(UTC).isoformat()
+
+
+@dataclass
+class WorkQueueConfig:
+    """Which broker to use and how workers keep in touch with it."""
+
+    broker: str = ""
+    queue: str = "default"
+    heartbeat_interval: float = 10.0
+    worker_timeout: float = 60.0
+    max_attempts: int = 3
+    task_timeout: float = 3600.0
+    retry: dict[str, Any] = field(default_factory=dict)
+
+    def __post_init__(self) -> None:
+        self.broker = self.broker or default_broker_url()
+
+    def retry_policies(self) -> RetryPolicies:
+        return RetryPolicies.from_config(self.retry, self.max_attempts)
+
+    @classmethod
+    def load(
+        cls, config: Any = None, environ: dict[str, str] | None = None
+    ) -> WorkQueueConfig:
+        environ = os.environ if environ is None else environ
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        known = {f.name for f in fields(cls)}
+        values = {k: v for k, v in section.items() if k in known and v is not None}
+        if environ.get(BROKER_ENV_VAR):
+            values["broker"] = environ[BROKER_ENV_VAR]
+        return cls(**values)
+
+
+@dataclass
+class QueueTask:
+    """A delegated task and, once run, its outcome."""
+
+    prompt: str
+    id: str = ""
+    cwd: str | None = None
+    repo: str | None = None
+    ref: str | None = None
+    model: str | None = None
+    max_turns: int | None = None
+    agent: str | None = None  # Selects the retry policy, with task_type
+    task_type: str | None = None
+    depends_on: list[str] = field(default_factory=list)
+    status: str = PENDING
+    worker: str | None = None
+    attempts: int = 0
+    enqueued_at: str = ""
+    started_at: str | None = None
+    finished_at: str | None = None
+    detail: str | None = None
+    result: dict[str, Any] | None = None
+    not_before: str | None = None  # Backoff: not claimed before this time
+    history: list[dict[str, Any]] = field(default_factory=list)
+
+    def __post_init__(self) -> None:
+        self.id = self.id or uuid.uuid4().hex[:12]
+        self.enqueued_at = self.enqueued_at or _now()
+
+    def record_attempt(self, failure: str | None, detail: str | None) -> None:
+        """Add the attempt that just ended to the task's history."""
+        self.history.append(
+            {
+                "attempt": self.attempts,
+                "worker": self.worker,
+                "started_at": self.started_at,
+                "finished_at": _now(),
+                "failure": failure,
+                "detail": detail,
+            }
+        )
+
+    def to_json(self) -> str:
+        return json.dumps(asdict(self))
+
+    @classmethod
+    def from_json(cls, data: str) -> QueueTask:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in json.loads(data).items() if k in known})
+
+
+@dataclass
+class WorkerInfo:
+    """A registered worker as the broker sees it."""
+
+    id: str
+    host: str
+    pid: int
+    started_at: str
+    last_heartbeat: float = 0.0
+    current_task: str | None = None
+
+    def to_json(self) -> str:
+        return json.dumps(asdict(self))
+
+    @classmethod
+    def from_json(cls, data: str) -> WorkerInfo:
+        known = {f.name for f in fields(cls)}
+        return cls(**{k: v for k, v in json.loads(data).items() if k in known})
+
+
+def _requeue(task: QueueTask, max_attempts: int, reason: str) -> QueueTask:
+    """Return *task* to the queue, or fail it once it has used its attempts."""
+    task.record_attempt(WORKER_LOST, reason)
+    if task.attempts >= max_attempts:
+        task.status = FAILED
+        task.finished_at = _now()
+        task.detail = f"{reason}; gave up after {task.attempts} attempt(s)"
+    else:
+        task.status = PENDING
+        task.detail = f"{reason}; re-queued"
+    task.worker = None
+    return task
+
+
+def _dependencies_met(
+    task: QueueTask, lookup: Callable[[str], QueueTask | None]
+) -> bool | None:
+    """Whether *task* may run: True once every dependency succeeded, None
+    while one is still to run, False (failing *task*) when one failed."""
+    for dependency_id in task.depends_on:
+        dependency = lookup(dependency_id)
+        if dependency is not None and dependency.status == SUCCEEDED:
+            continue
+        if dependency is not None and dependency.status != FAILED:
+            return None
+        reason = f"Dependency {dependency_id} failed"
+        if dependency is None:
+            reason = f"Dependency {dependency_id} does not exist"
+        task.record_attempt(DEPENDENCY, reason)
+        task.status, task.detail = FAILED, reason
+        task.finished_at = _now()
+        return False
+    return True
+
+
+# ---------------------------------------------------------------------------
+# Brokers
+# ---------------------------------------------------------------------------
+
+
+class Broker(ABC):
+    """Storage for one queue's tasks and workers."""
+
+    @abstractmethod
+    def enqueue(self, task: QueueTask) -> QueueTask: ...
+
+    @abstractmethod
+    def claim(self, worker_id: str) -> QueueTask | None:
+        """Atomically take the oldest pending task for *worker_id*."""
+
+    @abstractmethod
+    def complete(self, task: QueueTask, worker_id: str) -> bool:
+        """Store a finished task; False if *worker_id* no longer holds it."""
+
+    @abstractmethod
+    def get(self, task_id: str) -> QueueTask | None: ...
+
+    @abstractmethod
+    def tasks(self, status: str | None = None) -> list[QueueTask]: ...
+
+    @abstractmethod
+    def register(self, worker: WorkerInfo) -> None: ...
+
+    @abstractmethod
+    def heartbeat(self, worker_id: str, current_task: str | None = None) -> None: ...
+
+    @abstractmethod
+    def unregister(self, worker_id: str) -> None:
+        """Remove a worker that is shutting down, re-queueing what it holds."""
+
+    @abstractmethod
+    def workers(self) -> list[WorkerInfo]: ...
+
+    @abstractmethod
+    def requeue_dead(self, timeout: float, max_attempts: int) -> list[QueueTask]:
+        """Remove workers silent for *timeout* seconds and recover their tasks."""
+
+    @abstractmethod
+    def close(self) -> None: ...
+
+
+class SqliteBroker(Broker):
+    """Broker in a SQLite file, for one host or a shared file system."""
+
+    def __init__(self, path: Path, queue: str = "default"):
+        self.path = Path(path).expanduser()
+        self.path.parent.mkdir(parents=True, exist_ok=True)
+        self.queue = queue
+        self._lock = threading.Lock()
+        self._conn = sqlite3.connect(
+            str(self.path), timeout=30, isolation_level=None, check_same_thread=False
+        )
+        self._conn.executescript(
+            """
+            CREATE TABLE IF NOT EXISTS tasks (
+                id TEXT PRIMARY KEY, queue TEXT NOT NULL, status TEXT NOT NULL,
+                worker TEXT, seq INTEGER NOT NULL, data TEXT NOT NULL);
+            CREATE INDEX IF NOT EXISTS tasks_pending ON tasks (queue, status, seq);
+            CREATE TABLE IF NOT EXISTS workers (
+                id TEXT PRIMARY KEY, queue TEXT NOT NULL,
+                last_heartbeat REAL NOT NULL, data TEXT NOT NULL);
+            """
+        )
+
+    @contextmanager
+    def _transaction(self) -> Iterator[sqlite3.Connection]:
+        with self._lock:
+            self._conn.execute("BEGIN IMMEDIATE")
+            try:
+                yield self._conn
+            except BaseException:
+                self._conn.execute("ROLLBACK")
+                raise
+            self._conn.execute("COMMIT")
+
+    def _save(self, conn: sqlite3.Connection, task: QueueTask, seq: int) -> None:
+        conn.execute(
+            "INSERT OR REPLACE INTO tasks (id, queue, status, worker, seq, data) "
+            "VALUES (?, ?, ?, ?, ?, ?)",
+            (task.id, self.queue, task.status, task.worker, seq, task.to_json()),
+        )
+
+    def enqueue(self, task: QueueTask) -> QueueTask:
+        with self._transaction() as conn:
+            (seq,) = conn.execute(
+                "SELECT COALESCE(MAX(seq), 0) + 1 FROM tasks"
+            ).fetchone()
+            self._save(conn, task, seq)
+        return task
+
+    def claim(self, worker_id: str) -> QueueTask | None:
+        with self._transaction() as conn:
+
+            def lookup(task_id: str) -> QueueTask | None:
+                row = conn.execute(
+                    "SELECT data FROM tasks WHERE id = ?", (task_id,)
+                ).fetchone()
+                return QueueTask.from_json(row[0]) if row else None
+
+            rows = conn.execute(
+                "SELECT seq, data FROM tasks WHERE queue = ? AND status = ? "
+                "ORDER BY seq",
+                (self.queue, PENDING),
+            ).fetchall()
+            now = datetime.now(UTC)
+            for seq, data in rows:
+                task = QueueTask.from_json(data)
+                if task.not_before and datetime.fromisoformat(task.not_before) > now:
+                    continue
+                ready = _dependencies_met(task, lookup)
+                if ready is False:
+                    self._save(conn, task, seq)
+                if not ready:
+                    continue
+                task.status, task.worker = RUNNING, worker_id
+                task.started_at = _now()
+                task.attempts += 1
+                self._save(conn, task, seq)
+                return task
+        return None
+
+    def complete(self, task: QueueTask, worker_id: str) -> bool:
+        with self._transaction() as conn:
+            row = conn.execute(
+                "SELECT seq FROM tasks WHERE id = ? AND status = ? AND worker = ?",
+                (task.id, RUNNING, worker_id),
+            ).fetchone()
+            if row is None:
+                return False
+            self._save(conn, task, row[0])
+        return True
+
+    def get(self, task_id: str) -> QueueTask | None:
+        with self._lock:
+            row = self._conn.execute(
+                "SELECT data FROM tasks WHERE id = ? AND queue = ?",
+                (task_id, self.queue),
+            ).fetchone()
+        return QueueTask.from_json(row[0]) if row else None
+
+    def tasks(self, status: str | None = None) -> list[QueueTask]:
+        query = "SELECT data FROM tasks WHERE queue = ?"
+        params: tuple[Any, ...] = (self.queue,)
+        if status:
+            query += " AND status = ?"
+            params += (status,)
+        with self._lock:
+            rows = self._conn.execute(query + " ORDER BY seq", params).fetchall()
+        return [QueueTask.from_json(row[0]) for row in rows]
+
+    def register(self, worker: WorkerInfo) -> None:
+        worker.last_heartbeat = time.time()
+        with self._transaction() as conn:
+            conn.execute(
+                "INSERT OR REPLACE INTO workers (id, queue, last_heartbeat, data) "
+                "VALUES (?, ?, ?, ?)",
+                (worker.id, self.queue, worker.last_heartbeat, worker.to_json()),
+            )
+
+    def heartbeat(self, worker_id: str, current_task: str | None = None) -> None:
+        with self._transaction() as conn:
+            row = conn.execute(
+                "SELECT data FROM workers WHERE id = ?", (worker_id,)
+            ).fetchone()
+            if row is None:
+                return
+            worker = WorkerInfo.from_json(row[0])
+            worker.last_heartbeat, worker.current_task = time.time(), current_task
+            conn.execute(
+                "UPDATE workers SET last_heartbeat = ?, data = ? WHERE id = ?",
+                (worker.last_heartbeat, worker.to_json(), worker_id),
+            )
+
+    def _release(
+        self, conn: sqlite3.Connection, worker_id: str, max_attempts: int, reason: str
+    ) -> list[QueueTask]:
+        released = []
+        rows = conn.execute(
+            "SELECT seq, data FROM tasks WHERE queue = ? AND status = ? AND worker = ?",
+            (self.queue, RUNNING, worker_id),
+        ).fetchall()
+        for seq, data in rows:
+            task = _requeue(QueueTask.from_json(data), max_attempts, reason)
+            self._save(conn, task, seq)
+            released.append(task)
+        conn.execute("DELETE FROM workers WHERE id = ?", (worker_id,))
+        return released
+
+    def unregister(self, worker_id: str) -> None:
+        with self._transaction() as conn:
+            self._release(conn, worker_id, _ALWAYS_REQUEUE, "Worker stopped")
+
+    def workers(self) -> list[WorkerInfo]:
+        with self._lock:
+            rows = self._conn.execute(
+                "SELECT data FROM workers WHERE queue = ? ORDER BY id", (self.queue,)
+            ).fetchall()
+        return [WorkerInfo.from_json(row[0]) for row in rows]
+
+    def requeue_dead(self, timeout: float, max_attempts: int) -> list[QueueTask]:
+        cutoff = time.time() - timeout
+        recovered = []
+        with self._transaction() as conn:
+            dead = conn.execute(
+                "SELECT id FROM workers WHERE queue = ? AND last_heartbeat < ?",
+                (self.queue, cutoff),
+            ).fetchall()
+            for (worker_id,) in dead:
+                logger.warning(f"Worker {worker_id} missed its heartbeats")
+                recovered += self._release(
+                    conn, worker_id, max_attempts, f"Worker {worker_id} died"
+                )
+        return recovered
+
+    def close(self) -> None:
+        self._conn.close()
+
+
+class RedisBroker(Broker):
+    """Broker in Redis, for workers spread across machines."""
+
+    def __init__(self, url: str, queue: str = "default", client: Any = None):
+        if client is None:
+            try:
+                import redis
+            except ImportError as e:
+                raise RuntimeError(
+                    "The Redis broker needs the redis package: "
+                    "pip install 'claude-mpm[queue]'"
+                ) from e
+            client = redis.Redis.from_url(url, decode_responses=True)
+        self.redis = client
+        self.queue = queue
+        self._prefix = f"claude-mpm:queue:{queue}:"
+
+    def _key(self, *parts: str) -> str:
+        return self._prefix + ":".join(parts)
+
+    def _store(self, task: QueueTask) -> None:
+        self.redis.set(self._key("task", task.id), task.to_json())
+
+    def _wait(self, task: QueueTask) -> None:
+        """Park a pending task that must wait for its backoff or dependencies."""
+        ready_at = 0.0
+        if task.not_before:
+            ready_at = datetime.fromisoformat(task.not_before).timestamp()
+        self.redis.zadd(self._key("waiting"), {task.id: ready_at})
+
+    def _promote_waiting(self) -> None:
+        """Queue the waiting tasks that may run now; fail those that never can."""
+        waiting = self._key("waiting")
+        for task_id in self.redis.zrangebyscore(waiting, 0, time.time()):
+            task = self.get(task_id)
+            ready = None if task is None else _dependencies_met(task, self.get)
+            if task is not None and ready is None:
+                continue
+            # Whoever removes it from the waiting set moves it on
+            if not self.redis.zrem(waiting, task_id) or task is None:
+                continue
+            if ready:
+                self.redis.rpush(self._key("pending"), task_id)
+            else:
+                self._store(task)
+
+    def enqueue(self, task: QueueTask) -> QueueTask:
+        self._store(task)
+        self.redis.rpush(self._key("order"), task.id)
+        if task.depends_on or task.not_before:
+            self._wait(task)
+        else:
+            self.redis.lpush(self._key("pending"), task.id)
+        return task
+
+    def claim(self, worker_id: str) -> QueueTask | None:
+        self._promote_waiting()
+        # Oldest task is at the right end; LMOVE makes the hand-over atomic
+        task_id = self.redis.lmove(
+            self._key("pending"), self._key("running", worker_id), "RIGHT", "LEFT"
+        )
+        if task_id is None:
+            return None
+        task = self.get(task_id)
+        if task is None:
+            self.redis.lrem(self._key("running", worker_id), 0, task_id)
+            return None
+        task.status, task.worker = RUNNING, worker_id
+        task.started_at = _now()
+        task.attempts += 1
+        self._store(task)
+        return task
+
+    def complete(self, task: QueueTask, worker_id: str) -> bool:
+        if not self.redis.lrem(self._key("running", worker_id), 0, task.id):
+            return False
+        self._store(task)
+        if task.status == PENDING:
+            self._wait(task)
+        return True
+
+    def get(self, task_id: str) -> QueueTask | None:
+        data = self.redis.get(self._key("task", task_id))
+        return QueueTask.from_json(data) if data else None
+
+    def tasks(self, status: str | None = None) -> list[QueueTask]:
+        order = self.redis.lrange(self._key("order"), 0, -1)
+        tasks = [self.get(task_id) for task_id in order]
+        return [
+            t for t in tasks if t is not None and (not status or t.status == status)
+        ]
+
+    def register(self, worker: WorkerInfo) -> None:
+        worker.last_heartbeat = time.time()
+        self.redis.hset(self._key("workers"), worker.id, worker.to_json())
+        self.redis.zadd(self._key("heartbeats"), {worker.id: worker.last_heartbeat})
+
+    def heartbeat(self, worker_id: str, current_task: str | None = None) -> None:
+        data = self.redis.hget(self._key("workers"), worker_id)
+        if data is None:
+            return
+        worker = WorkerInfo.from_json(data)
+        worker.last_heartbeat, worker.current_task = time.time(), current_task
+        self.redis.hset(self._key("workers"), worker_id, worker.to_json())
+        self.redis.zadd(self._key("heartbeats"), {worker_id: worker.last_heartbeat})
+
+    def _release(
+        self, worker_id: str, max_attempts: int, reason: str
+    ) -> list[QueueTask]:
+        released = []
+        running = self._key("running", worker_id)
+        # Move back to the front of the queue first so a crash here loses nothing
+        pending = self._key("pending")
+        while (
+            task_id := self.redis.lmove(running, pending, "RIGHT", "RIGHT")
+        ) is not None:
+            task = self.get(task_id)
+            if task is None:
+                continue
+            task = _requeue(task, max_attempts, reason)
+            if task.status == FAILED:
+                self.redis.lrem(pending, 0, task_id)
+            self._store(task)
+            released.append(task)
+        self.redis.hdel(self._key("workers"), worker_id)
+        self.redis.zrem(self._key("heartbeats"), worker_id)
+        return released
+
+    def unregister(self, worker_id: str) -> None:
+        self._release(worker_id, _ALWAYS_REQUEUE, "Worker stopped")
+
+    def workers(self) -> list[WorkerInfo]:
+        values = self.redis.hgetall(self._key("workers")).values()
+        return sorted((WorkerInfo.from_json(v) for v in values), key=lambda w: w.id)
+
+    def requeue_dead(self, timeout: float, max_attempts: int) -> list[QueueTask]:
+        dead = self.redis.zrangebyscore(
+            self._key("heartbeats"), 0, time.time() - timeout
+        )
+        recovered = []
+        for worker_id in dead:
+            logger.warning(f"Worker {worker_id} missed its heartbeats")
+            recovered += self._release(
+                worker_id, max_attempts, f"Worker {worker_id} died"
+            )
+        return recovered
+
+    def close(self) -> None:
+        self.redis.close()
+
+
+def create_broker(config: WorkQueueConfig) -> Broker:
+    """Open the broker named by ``config.broker``.
+
+    Raises:
+        ValueError: If the URL scheme is not a supported broker.
+    """
+    url = config.broker
+    scheme = url.split("://", 1)[0] if "://" in url else "sqlite"
+    if scheme in ("redis", "rediss", "unix"):
+        return RedisBroker(url, config.queue)
+    if scheme == "sqlite":
+        return SqliteBroker(Path(url.removeprefix("sqlite:///")), config.queue)
+    raise ValueError(f"Unsupported broker {url!r}; use redis:// or sqlite:///")
+
+
+# ---------------------------------------------------------------------------
+# Worker
+# ---------------------------------------------------------------------------
+
+
+def run_headless(task: QueueTask, cwd: Path, timeout: float) -> AgentResult:
+    """Run *task* as a ``claude-mpm run --headless`` session in *cwd*."""
+    command = ["claude-mpm", "run", "--headless", "-i", task.prompt]
+    if task.max_turns:
+        command += ["--max-turns", str(task.max_turns)]
+    if task.model:
+        command += ["--model", task.model]
+    try:
+        completed = subprocess.run(
+            command,
+            cwd=cwd,
+            capture_output=True,
+            text=True,
+            timeout=timeout,
+            check=False,
+        )
+    except subprocess.TimeoutExpired:
+        return AgentResult(text=f"Timed out after {timeout:.0f}s", is_error=True)
+    return parse_result(completed.stdout + completed.stderr)
+
+
+class QueueWorker:
+    """Pulls tasks from a broker and runs them, one at a time."""
+
+    def __init__(
+        self,
+        broker: Broker,
+        config: WorkQueueConfig,
+        worker_id: str | None = None,
+        runner: Callable[[QueueTask, Path, float], AgentResult] = run_headless,
+        work_dir: Path | None = None,
+    ):
+        self.broker = broker
+        self.config = config
+        self.id = worker_id or f"{socket.gethostname()}-{os.getpid()}"
+        self.runner = runner
+        self.work_dir = Path(work_dir or Path.home() / ".claude-mpm" / "work_queue")
+        self.policies = config.retry_policies()
+        self.current: QueueTask | None = None
+        self._stopping = threading.Event()
+
+    def run(self, once: bool = False, poll_interval: float = 2.0) -> int:
+        """Process tasks until stopped (or the queue is empty, with *once*)."""
+        self.broker.register(
+            WorkerInfo(
+                id=self.id,
+                host=socket.gethostname(),
+                pid=os.getpid(),
+                started_at=_now(),
+            )
+        )
+        heartbeat = threading.Thread(target=self._heartbeat_loop, daemon=True)
+        heartbeat.start()
+        processed = 0
+        try:
+            while not self._stopping.is_set():
+                self.broker.requeue_dead(
+                    self.config.worker_timeout, self.config.max_attempts
+                )
+                task = self.broker.claim(self.id)
+                if task is None:
+                    if once:
+                        break
+                    self._stopping.wait(poll_interval)
+                    continue
+                self.current = task
+                self.broker.heartbeat(self.id, task.id)
+                self.execute(task)
+                if not self.broker.complete(task, self.id):
+                    logger.warning(
+                        f"Task {task.id} was reassigned while running; "
+                        "discarding this result"
+                    )
+                self.current = None
+                processed += 1
+        finally:
+            self._stopping.set()
+            heartbeat.join(timeout=5)
+            self.broker.unregister(self.id)
+        return processed
+
+    def execute(self, task: QueueTask) -> None:
+        """Run *task* and record its result on it."""
+        logger.info(f"Worker {self.id} running task {task.id}")
+        checkout = None
+        try:
+            if task.repo:
+                self.work_dir.mkdir(parents=True, exist_ok=True)
+                checkout = Path(
+                    tempfile.mkdtemp(prefix=f"{task.id}-", dir=self.work_dir)
+                )
+                clone = ["git", "clone", "--depth", "1"]
+                if task.ref:
+                    clone += ["--branch", task.ref]
+                subprocess.run(
+                    [*clone, task.repo, str(checkout)],
+                    capture_output=True,
+                    text=True,
+                    check=True,
+                )
+                cwd = checkout
+            else:
+                cwd = Path(task.cwd or Path.cwd())
+            result = self.runner(task, cwd, self.config.task_timeout)
+        except subprocess.CalledProcessError as e:
+            result = AgentResult(
+                text=f"Could not clone {task.repo}: {(e.stderr or '').strip()}",
+                is_error=True,
+            )
+        except Exception as e:
+            logger.error(f"Task {task.id} failed: {e}", exc_info=True)
+            result = AgentResult(text=str(e), is_error=True)
+        finally:
+            if checkout is not None:
+                shutil.rmtree(checkout, ignore_errors=True)
+        task.result = asdict(result)
+        if not result.is_error:
+            task.record_attempt(None, None)
+            task.status, task.detail, task.not_before = SUCCEEDED, None, None
+            task.finished_at = _now()
+            return
+        failure = classify_failure(result.text)
+        task.record_attempt(failure, (result.text or "")[:500])
+        policy = self.policies.for_task(task.agent, task.task_type)
+        if policy.should_retry(failure, task.attempts):
+            delay = policy.delay(task.attempts)
+            task.history[-1]["retry_in"] = delay
+            task.status, task.worker = PENDING, None
+            task.not_before = (datetime.now(UTC) + timedelta(seconds=delay)).isoformat()
+            task.detail = (
+                f"{failure} on attempt {task.attempts} of {policy.max_attempts}; "
+                f"retrying in {delay:.0f}s"
+            )
+            logger.info(f"Task {task.id}: {task.detail}")
+            return
+        task.status, task.not_before = FAILED, None
+        task.detail = f"{failure}; gave up after {task.attempts} attempt(s)"
+        task.finished_at = _now()
+
+    def stop(self) -> None:
+        self._stopping.set()
+
+    def _heartbeat_loop(self) -> None:
+        while not self._stopping.wait(self.config.heartbeat_interval):
+            try:
+                self.broker.heartbeat(
+                    self.id, self.current.id if self.current else None
+                )
+            except Exception as e:
+                logger.warning(f"Heartbeat failed: {e}")
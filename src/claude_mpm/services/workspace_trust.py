@@ -0,0 +1,266 @@
+"""Workspace trust: restricted sessions until a repository is trusted.
+
+WHAT: The first time ``claude-mpm run`` starts in a repository, the user is
+asked whether to trust it. The answer is remembered per repository root in
+~/.claude-mpm/trusted_workspaces.json. Sessions in a workspace that is not
+trusted run restricted:
+
+- no shell execution (Bash and background shell tools are disallowed)
+- no network tools (WebFetch, WebSearch)
+- no hooks, settings or MCP servers defined by the repository itself
+  (Claude Code only loads user-level settings and no MCP configuration)
+
+WHY: A cloned repository can ship .claude/settings.json hooks, .mcp.json
+servers and instructions that make an agent run arbitrary commands. Editors
+solved the same problem with a trust prompt; this mirrors that model.
+
+CONFIGURATION (.claude-mpm/configuration.yaml in the user's home config):
+
+    workspace_trust:
+      enabled: true
+      trusted_paths: [~/Projects]   # everything below is trusted
+
+``CLAUDE_MPM_TRUST_WORKSPACE=1`` trusts the current workspace for one run
+without prompting (CI, containers).
+
+DESIGN DECISIONS:
+- Trust is keyed by the git root, so trusting a repository covers all of its
+  subdirectories, and trusting a directory covers everything below it
+- Without a terminal to ask on, an undecided workspace runs restricted
+  rather than trusted, and says so on stderr along with how to trust it
+- Restricted sessions skip project settings entirely, including the hooks
+  claude-mpm installs there: a repository can ship that same file, so it
+  cannot be told apart from a tampered one
+- Restrictions are enforced twice: as Claude Code launch flags, and in the
+  PreToolUse dispatcher via CLAUDE_MPM_WORKSPACE_RESTRICTED for hooks
+  installed at user level
+"""
+
+from __future__ import annotations
+
+import json
+import os
+from dataclasses import dataclass, field
+from datetime import UTC, datetime
+from pathlib import Path
+from typing import Any
+
+from ..core.logger import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "workspace_trust"
+TRUST_ENV_VAR = "CLAUDE_MPM_TRUST_WORKSPACE"
+RESTRICTED_ENV_VAR = "CLAUDE_MPM_WORKSPACE_RESTRICTED"
+
+TRUSTED = "trusted"
+UNTRUSTED = "untrusted"
+UNKNOWN = "unknown"
+
+SHELL_TOOLS = ("Bash", "BashOutput", "KillShell", "KillBash")
+NETWORK_TOOLS = ("WebFetch", "WebSearch")
+RESTRICTED_TOOLS = SHELL_TOOLS + NETWORK_TOOLS
+
+
+def default_store_path() -> Path:
+    return Path.home() / ".claude-mpm" / "trusted_workspaces.json"
+
+
+def _user_config() -> dict[str, Any]:
+    """~/.claude-mpm/configuration.yaml (or .yml), or {} if there is none."""
+    import yaml
+
+    for name in ("configuration.yaml", "configuration.yml"):
+        path = Path.home() / ".claude-mpm" / name
+        if path.is_file():
+            data = yaml.safe_load(path.read_text(encoding="utf-8"))
+            return data if isinstance(data, dict) else {}
+    return {}
+
+
+def workspace_root(path: Path) -> Path:
+    """The git root containing ``path``, or ``path`` itself."""
+    path = Path(path).expanduser().resolve()
+    for candidate in (path, *path.parents):
+        if (candidate / ".git").exists():
+            return candidate
+    return path
+
+
+def restricted_claude_args() -> list[str]:
+    """Claude Code flags that apply the restricted policy."""
+    return [
+        "--disallowedTools",
+        ",".join(RESTRICTED_TOOLS),
+        "--setting-sources",
+        "user",
+        "--strict-mcp-config",
+    ]
+
+
+def is_restricted_session() -> bool:
+    return os.environ.get(RESTRICTED_ENV_VAR) == "1"
+
+
+@dataclass
+class TrustConfig:
+    enabled: bool = True
+    trusted_paths: list[str] = field(default_factory=list)
+
+    @classmethod
+    def load(cls, config: Any = None) -> TrustConfig:
+        """Trust settings from *config*, by default the user's own config.
+
+        Never the project configuration: the repository being judged could
+        otherwise disable the check or list itself as trusted.
+        """
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                config = _user_config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+        return cls(
+            enabled=bool(section.get("enabled", True)),
+            trusted_paths=list(section.get("trusted_paths") or []),
+        )
+
+
+@dataclass
+class TrustEntry:
+    path: str
+    decision: str
+    decided_at: str
+
+    def to_dict(self) -> dict[str, Any]:
+        return dict(self.__dict__)
+
+
+class WorkspaceTrustStore:
+    """Remembered trust decisions, one per workspace root."""
+
+    def __init__(
+        self, path: Path | None = None, config: TrustConfig | None = None
+    ):
+        self.path = Path(path or default_store_path())
+        self.config = config or TrustConfig.load()
+
+    def entries(self) -> list[TrustEntry]:
+        if not self.path.is_file():
+            return []
+        try:
+            data = json.loads(self.path.read_text(encoding="utf-8"))
+            return [TrustEntry(**entry) for entry in data.get("workspaces", [])]
+        except (OSError, json.JSONDecodeError, TypeError) as e:
+            # An unreadable store must not grant trust
+            logger.warning(f"Ignoring unreadable trust store {self.path}: {e}")
+            return []
+
+    def status(self, workspace: Path) -> str:
+        """TRUSTED, UNTRUSTED or UNKNOWN for the workspace containing a path."""
+        if not self.config.enabled:
+            return TRUSTED
+        root = workspace_root(workspace)
+        for trusted in self.config.trusted_paths:
+            if _is_within(root, Path(trusted).expanduser().resolve()):
+                return TRUSTED
+        # The most specific decision wins, so a distrusted repository inside a
+        # trusted directory stays distrusted
+        best: TrustEntry | None = None
+        for entry in self.entries():
+            if _is_within(root, Path(entry.path)) and (
+                best is None or len(entry.path) > len(best.path)
+            ):
+                best = entry
+        return best.decision if best else UNKNOWN
+
+    def trust(self, workspace: Path) -> Path:
+        return self._record(workspace, TRUSTED)
+
+    def distrust(self, workspace: Path) -> Path:
+        return self._record(workspace, UNTRUSTED)
+
+    def forget(self, workspace: Path) -> bool:
+        root = str(workspace_root(workspace))
+        entries = self.entries()
+        remaining = [e for e in entries if e.path != root]
+        if len(remaining) == len(entries):
+            return False
+        self._save(remaining)
+        return True
+
+    def _record(self, workspace: Path, decision: str) -> Path:
+        root = workspace_root(workspace)
+        entries = [e for e in self.entries() if e.path != str(root)]
+        decided_at = datetime.now(UTC).isoformat()
+        entries.append(TrustEntry(str(root), decision, decided_at))
+        self._save(entries)
+        return root
+
+    def _save(self, entries: list[TrustEntry]) -> None:
+        self.path.parent.mkdir(parents=True, exist_ok=True)
+        data = {"workspaces": [e.to_dict() for e in entries]}
+        self.path.write_text(json.dumps(data, indent=2) + "\n", encoding="utf-8")
+
+
+def _is_within(path: Path, parent: Path) -> bool:
+    return path == parent or parent in path.parents
+
+
+PROMPT = """\
+claude-mpm has not been run in {root} before.
+
+Sessions in untrusted workspaces run restricted: no shell commands, no
+network tools, and no hooks, settings or MCP servers from the repository.
+Only trust repositories whose contents you trust.
+
+Trust this workspace? [y/N] """
+
+# Shown on stderr, so headless NDJSON on stdout stays parseable
+RESTRICTED_NOTICE = """\
+⚠️  Workspace {root} is not trusted, so this non-interactive run is
+restricted: no shell commands (Bash), no network tools (WebFetch, WebSearch),
+and no hooks, settings or MCP servers from the repository.
+Trust it with 'claude-mpm trust add', or set {env}=1 for one run (CI)."""
+
+
+def resolve_workspace_trust(
+    workspace: Path,
+    store: WorkspaceTrustStore | None = None,
+    interactive: bool | None = None,
+    ask=input,
+) -> bool:
+    """Decide whether a session in ``workspace`` runs trusted.
+
+    Prompts once for workspaces with no recorded decision when a terminal is
+    available, and remembers the answer.
+    """
+    if os.environ.get(TRUST_ENV_VAR) == "1":
+        return True
+    store = store or WorkspaceTrustStore()
+    status = store.status(workspace)
+    if status != UNKNOWN:
+        return status == TRUSTED
+    if interactive is None:
+        import sys
+
+        interactive = sys.stdin.isatty() and sys.stdout.isatty()
+    if not interactive:
+        import sys
+
+        print(
+            RESTRICTED_NOTICE.format(root=workspace_root(workspace), env=TRUST_ENV_VAR),
+            file=sys.stderr,
+        )
+        return False
+    try:
+        answer = ask(PROMPT.format(root=workspace_root(workspace)))
+    except (EOFError, KeyboardInterrupt):
+        answer = ""
+    if answer.strip().lower() in ("y", "yes"):
+        store.trust(workspace)
+        return True
+    store.distrust(workspace)
+    print("Running restricted. Trust it later with 'claude-mpm trust add'.")
+    return False
@@ -21,7 +21,6 @@ SPEC-SKILLS-02~1 : docs/specs/skills.md#SPEC-SKILLS-02~1
 """
 
 import re
-import shutil
 from collections import Counter
 from pathlib import Path
 from typing import Any
@@ -30,6 +29,7 @@ import yaml
 
 from claude_mpm.core.config_scope import ConfigScope, resolve_skills_dir
 from claude_mpm.core.mixins import LoggerMixin
+from claude_mpm.services.deployment_delta import sync_directory
 
 # Security constants
 MAX_YAML_SIZE = 10 * 1024 * 1024  # 10MB limit to prevent YAML bombs
@@ -358,8 +358,9 @@ class SkillsService(LoggerMixin):
         Returns:
             Dict containing:
             - deployed: List of successfully deployed skill names
-            - skipped: List of skipped skill names (already deployed)
+            - skipped: List of skipped skill names (already deployed or unchanged)
             - errors: List of dicts with 'skill' and 'error' keys
+            - changes: Files added/changed/removed per deployed skill
 
         Example:
             >>> result = service.deploy_bundled_skills(force=True)
@@ -370,6 +371,7 @@ class SkillsService(LoggerMixin):
         deployed = []
         skipped = []
         errors = []
+        changes: dict[str, dict[str, list[str]]] = {}
 
         # Ensure deployment directory exists
         self.deployed_skills_path.mkdir(parents=True, exist_ok=True)
@@ -392,26 +394,22 @@ class SkillsService(LoggerMixin):
                     self.logger.debug(f"Skipped {skill['name']} (already deployed)")
                     continue
 
-                # Deploy skill
-                if target_dir.exists():
-                    # SECURITY: Verify again before deletion and check for symlinks
-                    if not self._validate_safe_path(
-                        self.deployed_skills_path, target_dir
-                    ):
-                        raise ValueError(
-                            "Refusing to delete path outside skills directory"
-                        )
+                # Deploy skill, writing only the files whose content differs
+                was_existing = target_dir.exists()
+                if target_dir.is_symlink():
+                    self.logger.warning(f"Replacing symlink: {target_dir}")
+                delta = sync_directory(Path(skill["path"]), target_dir)
 
-                    if target_dir.is_symlink():
-                        self.logger.warning(f"Refusing to delete symlink: {target_dir}")
-                        target_dir.unlink()
-                    else:
-                        shutil.rmtree(target_dir)
-
-                shutil.copytree(skill["path"], target_dir)
+                if was_existing and not delta.has_changes:
+                    skipped.append(skill["name"])
+                    self.logger.debug(f"Skipped {skill['name']} (unchanged)")
+                    continue
 
                 deployed.append(skill["name"])
-                self.logger.debug(f"Deployed skill: {skill['name']}")
+                changes[skill["name"]] = delta.to_dict()
+                self.logger.debug(
+                    f"Deployed skill: {skill['name']} ({delta.summary()})"
+                )
 
             except (ValueError, OSError) as e:
                 self.logger.error(f"Failed to deploy {skill['name']}: {e}")
@@ -422,7 +420,12 @@ class SkillsService(LoggerMixin):
             f"{len(skipped)} skipped, {len(errors)} errors"
         )
 
-        return {"deployed": deployed, "skipped": skipped, "errors": errors}
+        return {
+            "deployed": deployed,
+            "skipped": skipped,
+            "errors": errors,
+            "changes": changes,
+        }
 
     def get_skills_for_agent(self, agent_id: str) -> list[str]:
         """Get list of skills assigned to specific agent.
@@ -726,21 +729,22 @@ class SkillsService(LoggerMixin):
                 if not self._validate_safe_path(self.deployed_skills_path, target_dir):
                     raise ValueError(f"Path traversal attempt detected: {target_dir}")
 
-                # Remove old version
-                if target_dir.exists():
-                    # SECURITY: Check for symlinks before deletion
-                    if target_dir.is_symlink():
-                        self.logger.warning(f"Refusing to delete symlink: {target_dir}")
-                        target_dir.unlink()
-                    else:
-                        shutil.rmtree(target_dir)
-
-                # Deploy new version
-                target_dir.parent.mkdir(parents=True, exist_ok=True)
-                shutil.copytree(skill["path"], target_dir)
-
-                updated.append(skill_name)
-                self.logger.info(f"Updated skill: {skill_name}")
+                # Deploy new version, writing only the files that differ
+                if target_dir.is_symlink():
+                    self.logger.warning(f"Replacing symlink: {target_dir}")
+                delta = sync_directory(Path(skill["path"]), target_dir, merge=True)
+
+                if delta.conflicts:
+                    self.logger.warning(
+                        f"Kept local edits to {skill_name}: "
+                        f"{', '.join(delta.conflicts)} conflict with the update; "
+                        "resolve with 'claude-mpm integrity resolve'"
+                    )
+                if delta.has_changes:
+                    updated.append(skill_name)
+                    self.logger.info(f"Updated skill: {skill_name} ({delta.summary()})")
+                else:
+                    self.logger.debug(f"Skill already current: {skill_name}")
 
             except (ValueError, OSError) as e:
                 errors.append({"skill": skill_name, "error": str(e)})
@@ -0,0 +1,158 @@
+"""Run one operation over many items in parallel and report on each item.
+
+WHAT: ``run_bulk`` applies an operation to every item on a thread pool,
+reports each completion through a progress callback, turns an exception from
+one item into a failed result for that item instead of stopping the batch,
+and returns a ``BulkReport`` with one ``BulkItem`` per input.
+
+WHY: ``skills deploy``, ``agents deploy`` and ``skill-source update`` worked
+through their items one at a time, and a failed item was either buried in the
+log or reported with exit status 0.
+
+DESIGN DECISIONS:
+- Threads, not processes: the work is git/HTTP I/O and small file copies
+- Results keep input order whatever order items finish in, so summaries and
+  result lists are the same from run to run
+- ``max_workers=1`` runs inline in the calling thread; services default to it
+  so library callers see no change, and the CLI passes ``--jobs``
+- The operation picks the status (``deployed``, ``skipped``, ...); only
+  ``failed`` is special and makes ``exit_code`` nonzero
+"""
+
+from __future__ import annotations
+
+import argparse
+from collections.abc import Callable, Iterable
+from concurrent.futures import ThreadPoolExecutor, as_completed
+from dataclasses import asdict, dataclass, field
+from typing import Any, TypeVar
+
+from ..core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+OK = "ok"
+FAILED = "failed"
+DEFAULT_JOBS = 8
+
+T = TypeVar("T")
+
+
+@dataclass
+class BulkItem:
+    """Outcome of the operation for one item."""
+
+    name: str
+    status: str = OK
+    detail: str = ""
+
+    @property
+    def failed(self) -> bool:
+        return self.status == FAILED
+
+
+@dataclass
+class BulkReport:
+    """Per-item outcomes of one bulk operation, in input order."""
+
+    operation: str
+    items: list[BulkItem] = field(default_factory=list)
+
+    @property
+    def failed(self) -> list[BulkItem]:
+        return [item for item in self.items if item.failed]
+
+    @property
+    def exit_code(self) -> int:
+        return 1 if self.failed else 0
+
+    def names(self, status: str) -> list[str]:
+        return [item.name for item in self.items if item.status == status]
+
+    def counts(self) -> dict[str, int]:
+        counts: dict[str, int] = {}
+        for item in self.items:
+            counts[item.status] = counts.get(item.status, 0) + 1
+        return counts
+
+    def summary(self) -> str:
+        """E.g. ``"3 deployed, 1 skipped, 1 failed"``."""
+        if not self.items:
+            return "nothing to do"
+        return ", ".join(f"{n} {status}" for status, n in self.counts().items())
+
+    def render(self, emit: Callable[[str], Any] = print) -> None:
+        """Print one line per item, then the summary."""
+        for item in self.items:
+            mark = "✗" if item.failed else "✓"
+            line = f"  {mark} {item.name}: {item.status}"
+            emit(line + (f" ({item.detail})" if item.detail else ""))
+        emit(f"{self.operation}: {self.summary()}")
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "operation": self.operation,
+            "counts": self.counts(),
+            "failed": len(self.failed),
+            "items": [asdict(item) for item in self.items],
+        }
+
+
+def run_bulk(
+    items: Iterable[T],
+    operation: Callable[[T], BulkItem],
+    *,
+    operation_name: str = "",
+    name: Callable[[T], str] = str,
+    max_workers: int = 1,
+    progress_callback: Callable[[int], None] | None = None,
+) -> BulkReport:
+    """Run *operation* on every item and collect a report.
+
+    Args:
+        items: The items to process.
+        operation: Returns the ``BulkItem`` for one item. An exception marks
+            that item failed with the exception message.
+        operation_name: Label for the report summary (e.g. "Deploy skills").
+        name: Item name used when *operation* raises.
+        max_workers: Parallel workers; 1 runs in the calling thread.
+        progress_callback: Called with the number of items completed so far,
+            always from the calling thread.
+    """
+    items = list(items)
+    results: list[BulkItem | None] = [None] * len(items)
+
+    def run_one(index: int) -> None:
+        item = items[index]
+        try:
+            results[index] = operation(item)
+        except Exception as e:
+            label = operation_name or "Bulk operation"
+            logger.error(f"{label} failed for {name(item)}: {e}")
+            results[index] = BulkItem(name(item), FAILED, str(e))
+
+    if max_workers <= 1 or len(items) <= 1:
+        for index in range(len(items)):
+            run_one(index)
+            if progress_callback:
+                progress_callback(index + 1)
+    else:
+        with ThreadPoolExecutor(max_workers=min(max_workers, len(items))) as pool:
+            futures = [pool.submit(run_one, index) for index in range(len(items))]
+            for done, _ in enumerate(as_completed(futures), start=1):
+                if progress_callback:
+                    progress_callback(done)
+
+    return BulkReport(operation_name, [r for r in results if r is not None])
+
+
+def add_jobs_argument(parser: argparse.ArgumentParser) -> None:
+    """Add ``--jobs`` to a command that runs a bulk operation."""
+    parser.add_argument(
+        "--jobs",
+        "-j",
+        type=int,
+        default=DEFAULT_JOBS,
+        metavar="N",
+        help=f"Items to process in parallel (default: {DEFAULT_JOBS})",
+    )
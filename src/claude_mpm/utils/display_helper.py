@@ -10,7 +10,8 @@ from typing import Any
 
 from rich.console import Console
 from rich.panel import Panel
-from rich.table import Table
+
+from .theme import get_theme
 
 
 class DisplayHelper:
@@ -19,6 +20,7 @@ class DisplayHelper:
     def __init__(self, console: Console):
         """Initialize display helper with console instance."""
         self.console = console
+        self.theme = get_theme()
 
     def display_separator(self, char: str = "=", width: int = 60) -> None:
         """Display a separator line."""
@@ -34,21 +36,29 @@ class DisplayHelper:
     def display_section_title(self, title: str, emoji: str = "") -> None:
         """Display a section title with optional emoji."""
         if emoji:
-            self.console.print(f"[bold cyan]{emoji} {title}[/bold cyan]")
+            self.console.print(self.theme.markup("heading", f"{emoji} {title}"))
         else:
-            self.console.print(f"[bold cyan]{title}[/bold cyan]")
+            self.console.print(self.theme.markup("heading", title))
 
     def display_key_value_table(
         self,
         title: str,
         data: dict[str, Any],
-        key_style: str = "cyan",
-        value_style: str = "white",
+        key_style: str | None = None,
+        value_style: str | None = None,
     ) -> None:
-        """Display a two-column key-value table."""
-        table = Table(title=title, show_header=True)
-        table.add_column("Property", style=key_style)
-        table.add_column("Value", style=value_style)
+        """Display a two-column key-value table (theme styles by default)."""
+        table = self.theme.table(title=title, show_header=True)
+        table.add_column(
+            "Property",
+            style=self.theme.style("table.key") if key_style is None else key_style,
+        )
+        table.add_column(
+            "Value",
+            style=(
+                self.theme.style("table.value") if value_style is None else value_style
+            ),
+        )
 
         for key, value in data.items():
             # Handle various value types
@@ -66,19 +76,19 @@ class DisplayHelper:
         self, title: str, items: list[str], max_items: int = 10, color: str = "white"
     ) -> None:
         """Display a titled list of items."""
-        self.console.print(f"\n[bold cyan]{title}[/bold cyan]")
+        self.console.print("\n" + self.theme.markup("heading", title))
         for item in items[:max_items]:
             self.console.print(f"  [{color}]{item}[/{color}]")
 
     def display_warning_list(self, title: str, items: list[str]) -> None:
         """Display a list of warning items."""
-        self.console.print(f"\n[yellow]{title}[/yellow]")
+        self.console.print("\n" + self.theme.markup("warning", title))
         for item in items:
             self.console.print(f"  • {item}")
 
     def display_info_list(self, title: str, items: list[str]) -> None:
         """Display a list of info items."""
-        self.console.print(f"\n[blue]{title}[/blue]")
+        self.console.print("\n" + self.theme.markup("info", title))
         for item in items[:5]:
             self.console.print(f"  • {item}")
 
@@ -88,7 +98,9 @@ class DisplayHelper:
         """Display a single metric row with label and value."""
         indent_str = " " * indent
         if warning:
-            self.console.print(f"{indent_str}[yellow]{label}: {value}[/yellow]")
+            self.console.print(
+                indent_str + self.theme.markup("warning", f"{label}: {value}")
+            )
         else:
             self.console.print(f"{indent_str}{label}: {value}")
 
@@ -0,0 +1,221 @@
+"""Aligned tables and trees for list commands.
+
+WHAT: ``TableView`` renders rows (dicts) as an aligned rich table, sorted by
+any column and limited to chosen columns. ``add_table_arguments`` gives a list
+command the matching ``--sort`` and ``--columns`` flags, and ``render_tree``
+draws hierarchical data such as ticket epics or agent inheritance.
+
+WHY: Each list command printed its own layout (fixed-width f-strings, one
+field per line, emoji bullets), so columns drifted out of line and nothing
+could be sorted except by the one order the command picked.
+
+DESIGN DECISIONS:
+- Rows keep raw values and columns format them, so sorting compares numbers
+  as numbers and dates as ISO strings, not their display text
+- Empty values sort last in both directions
+- Tables and trees render to a string, so formatters that return text keep
+  their signatures and ``--quiet`` treats the table as the command's result
+- Styles come from the active theme (``utils/theme.py``); without color the
+  output is plain aligned text that is safe to grep
+"""
+
+from __future__ import annotations
+
+import argparse
+import io
+import shutil
+from collections.abc import Callable, Iterable, Sequence
+from dataclasses import dataclass, field
+from typing import Any
+
+from rich import box
+from rich.console import Console
+from rich.tree import Tree
+
+from .theme import get_theme
+
+
+@dataclass
+class Column:
+    """One table column.
+
+    Attributes:
+        key: Row dict key, also the name used by ``--sort`` and ``--columns``.
+        header: Column heading.
+        format: Turns the raw value into display text (default ``str``).
+        sort_key: Turns the raw value into a sort key (e.g. priority rank).
+        justify: ``left``, ``right`` or ``center``.
+    """
+
+    key: str
+    header: str
+    format: Callable[[Any], str] | None = None
+    sort_key: Callable[[Any], Any] | None = None
+    justify: str = "left"
+
+    def display(self, value: Any) -> str:
+        if value is None:
+            return "-"
+        if self.format is not None:
+            return self.format(value)
+        return str(value)
+
+
+@dataclass
+class TreeNode:
+    """A labelled node with children, for ``render_tree``."""
+
+    label: str
+    children: list[TreeNode] = field(default_factory=list)
+
+
+def _column_names(columns: Sequence[Column]) -> str:
+    return ", ".join(column.key for column in columns)
+
+
+def _find_column(columns: Sequence[Column], key: str) -> Column:
+    for column in columns:
+        if column.key == key:
+            return column
+    raise ValueError(f"Unknown column '{key}' (choose from: {_column_names(columns)})")
+
+
+def sort_rows(
+    rows: Iterable[dict[str, Any]], spec: str, columns: Sequence[Column]
+) -> list[dict[str, Any]]:
+    """Sort *rows* by ``--sort`` *spec*: a column key, ``-key`` for descending."""
+    reverse = spec.startswith("-")
+    column = _find_column(columns, spec.lstrip("-"))
+    rows = list(rows)
+    present = [row for row in rows if row.get(column.key) not in (None, "")]
+    empty = [row for row in rows if row.get(column.key) in (None, "")]
+
+    def key(row: dict[str, Any]) -> Any:
+        value = row[column.key]
+        if column.sort_key is not None:
+            return column.sort_key(value)
+        if isinstance(value, (int, float)):
+            return (0, value, "")
+        return (1, 0, str(value).lower())
+
+    return sorted(present, key=key, reverse=reverse) + empty
+
+
+def parse_columns(spec: str | Sequence[str], columns: Sequence[Column]) -> list[str]:
+    """Validate a ``--columns`` value (``"name,version"`` or a list of keys)."""
+    keys = spec.split(",") if isinstance(spec, str) else list(spec)
+    keys = [key.strip() for key in keys if key.strip()]
+    for key in keys:
+        _find_column(columns, key)
+    return keys
+
+
+class TableView:
+    """Rows rendered as an aligned table with sortable, selectable columns."""
+
+    def __init__(
+        self,
+        columns: Sequence[Column],
+        rows: Iterable[dict[str, Any]],
+        *,
+        title: str | None = None,
+        sort: str | None = None,
+        show: str | Sequence[str] | None = None,
+    ):
+        self.columns = list(columns)
+        self.rows = list(rows)
+        self.title = title
+        if sort:
+            self.rows = sort_rows(self.rows, sort, self.columns)
+        if show:
+            keys = parse_columns(show, self.columns)
+            self.visible = [_find_column(self.columns, key) for key in keys]
+        else:
+            self.visible = list(self.columns)
+
+    @classmethod
+    def from_args(
+        cls,
+        columns: Sequence[Column],
+        rows: Iterable[dict[str, Any]],
+        args: Any,
+        *,
+        title: str | None = None,
+        default_columns: Sequence[str] | None = None,
+    ) -> TableView:
+        """A view using the ``--sort`` and ``--columns`` values in *args*."""
+        sort, show = table_options(args)
+        return cls(columns, rows, title=title, sort=sort, show=show or default_columns)
+
+    def render(self) -> str:
+        theme = get_theme()
+        table = theme.table(
+            title=self.title, box=box.SIMPLE_HEAD, show_edge=False, pad_edge=False
+        )
+        for column in self.visible:
+            table.add_column(column.header, justify=column.justify, overflow="fold")
+        for row in self.rows:
+            table.add_row(*(col.display(row.get(col.key)) for col in self.visible))
+        return render_to_string(table)
+
+
+def render_tree(label: str, nodes: Iterable[TreeNode]) -> str:
+    """Draw *nodes* as a tree under a root *label*."""
+    theme = get_theme()
+    root = Tree(label, guide_style=theme.style("muted"))
+
+    def add(parent: Tree, node: TreeNode) -> None:
+        branch = parent.add(node.label)
+        for child in node.children:
+            add(branch, child)
+
+    for node in nodes:
+        add(root, node)
+    return render_to_string(root)
+
+
+def render_to_string(renderable: Any) -> str:
+    """Render a rich object as text sized to the terminal."""
+    color = get_theme().color
+    buffer = io.StringIO()
+    console = Console(
+        file=buffer,
+        width=shutil.get_terminal_size((100, 24)).columns,
+        force_terminal=color,
+        no_color=not color,
+        highlight=False,
+        markup=False,
+    )
+    console.print(renderable)
+    return buffer.getvalue().rstrip()
+
+
+def table_options(args: Any) -> tuple[str | None, str | None]:
+    """The ``--sort`` and ``--columns`` values in *args*, ``None`` when unset.
+
+    Only strings count, so handlers called with args objects that lack the
+    flags behave as if neither was given.
+    """
+    sort = getattr(args, "sort", None)
+    columns = getattr(args, "columns", None)
+    return (
+        sort if isinstance(sort, str) else None,
+        columns if isinstance(columns, str) else None,
+    )
+
+
+def add_table_arguments(
+    parser: argparse.ArgumentParser, columns: Sequence[Column]
+) -> None:
+    """Add ``--sort`` and ``--columns`` for a list command's table."""
+    names = _column_names(columns)
+    parser.add_argument(
+        "--sort",
+        metavar="COLUMN",
+        help=f"Sort by COLUMN; use --sort=-COLUMN for descending ({names})",
+    )
+    parser.add_argument(
+        "--columns",
+        metavar="COL,COL",
+        help=f"Columns to show, in order ({names})",
+    )
@@ -0,0 +1,248 @@
+"""Color themes for CLI output.
+
+WHAT: Output code asks the theme for a style by role (``success``,
+``table.header``, ``diff.added``, ``badge.error`` ...) instead of naming a
+color. ``get_theme()`` returns the active ``CliTheme``, which turns a role into
+a rich style string, rich markup or ANSI escape codes for plain ``print()``.
+
+WHY: Colors were hard-coded per command, assumed a dark terminal background,
+and ANSI-printing code ignored ``NO_COLOR`` and piped output.
+
+CONFIGURATION:
+    cli_theme:
+      name: light          # default | light | no-color
+      colors:              # optional overrides, any rich style
+        success: "bold green"
+        diff.removed: "magenta"
+
+The ``CLAUDE_MPM_THEME`` environment variable overrides ``name``.
+
+DESIGN DECISIONS:
+- Color is off when ``NO_COLOR`` is set (https://no-color.org), when the theme
+  is ``no-color``, or when stdout is not a terminal; ``FORCE_COLOR`` turns it
+  back on for CI logs that render ANSI
+- Roles resolve to plain style strings, so any ``Console`` can use them; no
+  console has to be created with a rich ``Theme``
+- Badges keep a visible ``[OK]`` shape when color is off so status is never
+  carried by color alone
+"""
+
+from __future__ import annotations
+
+import os
+import sys
+from dataclasses import dataclass, field
+from typing import Any, TextIO
+
+from rich.color import ColorSystem
+from rich.errors import StyleSyntaxError
+from rich.style import Style
+from rich.table import Table
+
+from ..core.logging_config import get_logger
+
+logger = get_logger(__name__)
+
+CONFIG_KEY = "cli_theme"
+THEME_ENV_VAR = "CLAUDE_MPM_THEME"
+DEFAULT_THEME = "default"
+NO_COLOR_THEME = "no-color"
+
+THEMES: dict[str, dict[str, str]] = {
+    # Tuned for dark terminal backgrounds
+    "default": {
+        "success": "green",
+        "warning": "yellow",
+        "error": "bold red",
+        "info": "cyan",
+        "muted": "dim",
+        "heading": "bold cyan",
+        "table.header": "bold cyan",
+        "table.border": "bright_black",
+        "table.key": "cyan",
+        "table.value": "",
+        "diff.header": "bold",
+        "diff.hunk": "cyan",
+        "diff.added": "green",
+        "diff.removed": "red",
+        "badge.ok": "bold black on green",
+        "badge.warning": "bold black on yellow",
+        "badge.error": "bold white on red",
+        "badge.skipped": "bold white on bright_black",
+    },
+    # Darker foregrounds that stay readable on white backgrounds
+    "light": {
+        "success": "green4",
+        "warning": "dark_orange3",
+        "error": "bold red3",
+        "info": "blue",
+        "muted": "grey42",
+        "heading": "bold blue",
+        "table.header": "bold blue",
+        "table.border": "grey50",
+        "table.key": "blue",
+        "table.value": "",
+        "diff.header": "bold",
+        "diff.hunk": "blue",
+        "diff.added": "green4",
+        "diff.removed": "red3",
+        "badge.ok": "bold white on green4",
+        "badge.warning": "bold black on yellow3",
+        "badge.error": "bold white on red3",
+        "badge.skipped": "bold white on grey50",
+    },
+}
+THEMES[NO_COLOR_THEME] = dict.fromkeys(THEMES[DEFAULT_THEME], "")
+
+ROLES = frozenset(THEMES[DEFAULT_THEME])
+
+
+def color_enabled(
+    environ: dict[str, str] | None = None, stream: TextIO | None = None
+) -> bool:
+    """Whether output to *stream* should carry color."""
+    environ = os.environ if environ is None else environ
+    if environ.get("NO_COLOR"):
+        return False
+    if environ.get("FORCE_COLOR"):
+        return True
+    stream = sys.stdout if stream is None else stream
+    try:
+        return stream.isatty()
+    except (AttributeError, ValueError):
+        return False
+
+
+@dataclass
+class CliTheme:
+    """A resolved palette plus whether color is on."""
+
+    name: str = DEFAULT_THEME
+    styles: dict[str, str] = field(
+        default_factory=lambda: dict(THEMES[DEFAULT_THEME])
+    )
+    color: bool = True
+
+    @classmethod
+    def load(
+        cls,
+        config: Any = None,
+        environ: dict[str, str] | None = None,
+        stream: TextIO | None = None,
+    ) -> CliTheme:
+        environ = os.environ if environ is None else environ
+        section: dict[str, Any] = {}
+        try:
+            if config is None:
+                from ..core.config import Config
+
+                config = Config()
+            section = config.get(CONFIG_KEY, {}) or {}
+        except Exception as e:
+            logger.debug(f"Could not read {CONFIG_KEY} config: {e}")
+
+        name = environ.get(THEME_ENV_VAR) or section.get("name") or DEFAULT_THEME
+        if name not in THEMES:
+            logger.warning(
+                f"Unknown theme '{name}' (choose from {', '.join(THEMES)}); "
+                f"using '{DEFAULT_THEME}'"
+            )
+            name = DEFAULT_THEME
+
+        styles = dict(THEMES[name])
+        for role, style in (section.get("colors") or {}).items():
+            if role not in ROLES:
+                logger.warning(f"Ignoring unknown {CONFIG_KEY} color role: {role}")
+                continue
+            try:
+                Style.parse(str(style))
+            except StyleSyntaxError as e:
+                logger.warning(f"Ignoring {CONFIG_KEY} color for {role}: {e}")
+                continue
+            styles[role] = str(style)
+
+        color = name != NO_COLOR_THEME and color_enabled(environ, stream)
+        return cls(name=name, styles=styles, color=color)
+
+    def style(self, role: str) -> str:
+        """The rich style string for *role*, or ``""`` when color is off."""
+        if not self.color:
+            return ""
+        return self.styles.get(role, "")
+
+    def markup(self, role: str, text: str) -> str:
+        """*text* (rich markup) wrapped in the markup for *role*."""
+        style = self.style(role)
+        return f"[{style}]{text}[/]" if style else text
+
+    def ansi(self, role: str, text: str) -> str:
+        """*text* with ANSI codes for *role*, for code that uses ``print()``."""
+        style = self.style(role)
+        if not style:
+            return text
+        return Style.parse(style).render(text, color_system=ColorSystem.EIGHT_BIT)
+
+    def badge(self, label: str, level: str) -> str:
+        """A status badge such as `` OK `` (``[OK]`` without color).
+
+        *level* is ``ok``, ``warning``, ``error`` or ``skipped``.
+        """
+        style = self.style(f"badge.{level}")
+        if not style:
+            return f"[{label}]"
+        return self.ansi(f"badge.{level}", f" {label} ")
+
+    def diff(self, text: str) -> str:
+        """Color a unified diff line by line."""
+        if not self.color:
+            return text
+        lines = []
+        for line in text.splitlines(keepends=True):
+            if line.startswith(("+++", "---")):
+                role = "diff.header"
+            elif line.startswith("@@"):
+                role = "diff.hunk"
+            elif line.startswith("+"):
+                role = "diff.added"
+            elif line.startswith("-"):
+                role = "diff.removed"
+            else:
+                lines.append(line)
+                continue
+            body = line.rstrip("\n")
+            lines.append(self.ansi(role, body) + line[len(body) :])
+        return "".join(lines)
+
+    def table(self, **kwargs: Any) -> Table:
+        """A rich ``Table`` with the theme's header and border styles."""
+        kwargs.setdefault("header_style", self.style("table.header"))
+        kwargs.setdefault("border_style", self.style("table.border"))
+        return Table(**kwargs)
+
+
+_theme: CliTheme | None = None
+
+
+def get_theme() -> CliTheme:
+    """The active theme, loaded from config and the environment on first use."""
+    global _theme
+    if _theme is None:
+        _theme = CliTheme.load()
+    return _theme
+
+
+def set_theme(theme: CliTheme | None) -> None:
+    """Replace the active theme; ``None`` reloads it on next use."""
+    global _theme
+    _theme = theme
+
+
+def export_no_color(theme: CliTheme | None = None) -> None:
+    """Set ``NO_COLOR`` when the ``no-color`` theme is selected.
+
+    Rich consoles read ``NO_COLOR`` when they are created, and child processes
+    inherit it, so the theme reaches output this module never sees.
+    """
+    theme = get_theme() if theme is None else theme
+    if theme.name == NO_COLOR_THEME:
+        os.environ.setdefault("NO_COLOR", "1")
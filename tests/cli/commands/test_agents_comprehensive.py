@@ -446,6 +446,7 @@ def mock_args():
     args.filter = None  # Explicitly set to None to avoid truthy MagicMock
     args.agent = None  # Explicitly set to None for dependency commands
     args.dry_run = False  # Explicitly set to False for dependency commands
+    args.stage_dir = None  # Explicitly set to None so deploy is not a dry run
     args.all = False  # Explicitly set to False for fix commands
     args.agent_name = None  # Explicitly set to None for view/fix commands
     return args
@@ -1376,6 +1377,8 @@ class TestOutputFormats:
         mock_args.format = format_type
         mock_args.preset = None  # Explicitly set to None to avoid MagicMock behavior
         mock_args.force = False
+        mock_args.dry_run = False
+        mock_args.stage_dir = None
         mock_args.verbose = False  # Explicitly set to False to avoid verbose mode
 
         with (
@@ -1574,6 +1577,8 @@ class TestIntegrationScenarios:
         deploy_args.format = "text"
         deploy_args.preset = None
         deploy_args.force = False
+        deploy_args.dry_run = False
+        deploy_args.stage_dir = None
         deploy_args.verbose = False
 
         with (
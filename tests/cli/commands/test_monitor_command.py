@@ -83,6 +83,48 @@ class TestMonitorCommand:
         assert result.success is True
         mock_daemon.start.assert_called_once()
 
+    @patch("claude_mpm.cli.commands.monitor.SharedDaemon")
+    @patch("claude_mpm.cli.commands.monitor.UnifiedMonitorDaemon")
+    def test_run_start_served_by_shared_daemon(
+        self, mock_daemon_class, mock_shared_class
+    ):
+        """Test start uses the shared daemon's server for the project."""
+        mock_shared_class.return_value.serve_project.return_value = {
+            "namespace": "app-1234abcd",
+            "port": 8766,
+        }
+
+        args = Namespace(monitor_command="start", port=None, host="localhost")
+
+        result = self.command.run(args)
+
+        assert result.success is True
+        assert result.data["port"] == 8766
+        mock_daemon_class.assert_not_called()
+
+    @patch("claude_mpm.cli.commands.monitor.SharedDaemon")
+    @patch("claude_mpm.cli.commands.monitor.UnifiedMonitorDaemon")
+    def test_run_start_without_shared_daemon(
+        self, mock_daemon_class, mock_shared_class
+    ):
+        """Test start runs its own server when the shared daemon is not up."""
+        mock_shared_class.return_value.serve_project.return_value = None
+        mock_daemon = Mock()
+        mock_daemon_class.return_value = mock_daemon
+        mock_daemon.lifecycle.is_running.return_value = False
+        mock_daemon.start.return_value = True
+
+        args = Namespace(
+            monitor_command="start", port=None, host="localhost", foreground=True
+        )
+
+        result = self.command.run(args)
+
+        assert result.success is True
+        mock_daemon_class.assert_called_once_with(
+            host="localhost", port=8765, daemon_mode=False
+        )
+
     @patch("claude_mpm.cli.commands.monitor.UnifiedMonitorDaemon")
     def test_run_start_already_running(self, mock_daemon_class):
         """Test starting when daemon is already running."""
@@ -28,6 +28,7 @@ from claude_mpm.cli.commands.run import (
     run_session_legacy,
 )
 from claude_mpm.cli.shared.base_command import CommandResult
+from claude_mpm.services.cli.unified_dashboard_manager import DashboardInfo
 from claude_mpm.constants import LogLevel
 
 
@@ -355,7 +356,10 @@ class TestMonitoringSetup:
 
         # Mock port finding and server start
         mock_dashboard.find_available_port.return_value = 8080
-        mock_dashboard.start_server.return_value = (True, {})
+        mock_dashboard.start_server.return_value = (
+            True,
+            DashboardInfo(url="http://localhost:8080", port=8080),
+        )
         mock_dashboard.get_dashboard_url.return_value = "http://localhost:8080"
         mock_dashboard.open_browser.return_value = True
 
@@ -572,7 +576,10 @@ class TestSocketIOServerManagement:
         # Test server running case
         mock_dashboard.ensure_dependencies.return_value = (True, None)
         mock_dashboard.find_available_port.return_value = 8765
-        mock_dashboard.start_server.return_value = (True, {})
+        mock_dashboard.start_server.return_value = (
+            True,
+            DashboardInfo(url="http://localhost:8765", port=8765),
+        )
         mock_dashboard.get_dashboard_url.return_value = "http://localhost:8765"
         mock_dashboard.open_browser.return_value = True
 
@@ -689,7 +696,10 @@ class TestIntegrationScenarios:
         mock_dashboard = Mock()
         mock_dashboard.ensure_dependencies.return_value = (True, None)
         mock_dashboard.find_available_port.return_value = 8080
-        mock_dashboard.start_server.return_value = (True, {})
+        mock_dashboard.start_server.return_value = (
+            True,
+            DashboardInfo(url="http://localhost:8080", port=8080),
+        )
         mock_dashboard.get_dashboard_url.return_value = "http://localhost:8080"
         mock_dashboard.open_browser.return_value = True
         mock_dashboard_class.return_value = mock_dashboard
@@ -0,0 +1,73 @@
+"""
+Tests for the per-session --env and --cwd overrides of claude-mpm run.
+
+COVERAGE:
+- Both flags parse on the run command and are kept away from Claude Code
+- Overrides are applied to this process only and returned for the session's
+  metadata; bad values stop the session
+- --mpm-resume restores the stored overrides, with new flags taking precedence
+"""
+
+import os
+
+import pytest
+
+from claude_mpm.cli.commands.run import (
+    _apply_session_overrides,
+    _session_overrides,
+    filter_claude_mpm_args,
+)
+from claude_mpm.cli.parsers.base_parser import create_parser
+from claude_mpm.services.cli.session_manager import SessionManager
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.chdir(tmp_path)
+    (tmp_path / "services" / "api").mkdir(parents=True)
+    return tmp_path
+
+
+def _parse(*argv):
+    return create_parser().parse_args(["run", *argv])
+
+
+def test_flags_parse_and_are_not_passed_to_claude():
+    args = _parse("--env", "API_URL=http://localhost:8001", "--env", "A=", "--cwd", "x")
+    assert args.session_env == ["API_URL=http://localhost:8001", "A="]
+    assert args.cwd == "x"
+
+    passthrough = ["--env", "A=1", "--cwd", "x", "--model", "opus"]
+    assert filter_claude_mpm_args(passthrough) == ["--model", "opus"]
+
+
+def test_overrides_apply_to_this_process(home, monkeypatch):
+    monkeypatch.delenv("API_URL", raising=False)
+    args = _parse("--env", "API_URL=http://localhost:8001", "--cwd", "services/api")
+
+    overrides = _apply_session_overrides(args)
+
+    target = str((home / "services" / "api").resolve())
+    assert overrides == {"env": {"API_URL": "http://localhost:8001"}, "cwd": target}
+    assert os.getcwd() == target
+    assert os.environ["API_URL"] == "http://localhost:8001"
+
+    with pytest.raises(ValueError, match="KEY=VALUE"):
+        _session_overrides(_parse("--env", "API_URL"))
+    with pytest.raises(SystemExit):
+        _apply_session_overrides(_parse("--cwd", str(home / "missing")))
+
+
+def test_resume_restores_stored_overrides(home):
+    stored = {"env": {"A": "1", "B": "2"}, "cwd": str(home / "services" / "api")}
+    session = SessionManager().create_session("default", stored)
+
+    assert _session_overrides(_parse("--mpm-resume", session.id)) == stored
+    assert _session_overrides(_parse("--mpm-resume")) == stored
+
+    args = _parse("--mpm-resume", session.id, "--env", "B=3", "--cwd", ".")
+    assert _session_overrides(args) == {
+        "env": {"A": "1", "B": "3"},
+        "cwd": str(home.resolve()),
+    }
@@ -596,8 +596,9 @@ class TestHandleUpdateSkillSources:
     @patch("claude_mpm.cli.commands.skill_source.GitSkillSourceManager")
     @patch("claude_mpm.cli.commands.skill_source.SkillSourceConfiguration")
     def test_update_all_sources(self, mock_config_class, mock_manager_class, capsys):
-        """Test updating all sources."""
+        """Test updating all sources; any failed source fails the command."""
         mock_config = Mock()
+        mock_config.get_enabled_sources.return_value = [Mock(), Mock(), Mock()]
         mock_config_class.return_value = mock_config
 
         mock_manager = Mock()
@@ -616,7 +617,7 @@ class TestHandleUpdateSkillSources:
 
         result = handle_update_skill_sources(args)
 
-        assert result == 0
+        assert result == 1
         captured = capsys.readouterr()
         assert "Updated 2/3 sources" in captured.out
         assert "repo1: 3 skills" in captured.out
@@ -0,0 +1,165 @@
+"""
+Tests for the shared --limit/--offset/--filter flags of list commands.
+
+COVERAGE:
+- Flags parse the same way on every list command; bad keys and values are
+  argparse errors that name the valid keys
+- Filters match case-insensitively with wildcards, reach nested fields and
+  list fields, AND across keys and OR within a key
+- Filtering happens before offset and limit; page summary and next-page hint
+- Args objects without the flags fall back to defaults
+- tickets list and skills list apply the flags
+"""
+
+import argparse
+from argparse import Namespace
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from claude_mpm.cli.shared.list_options import ListOptions, add_list_arguments
+
+FIELDS = {"status": "status", "type": "metadata.ticket_type", "tag": "tags"}
+
+ITEMS = [
+    {"id": 1, "status": "open", "metadata": {"ticket_type": "bug"}, "tags": ["ui"]},
+    {"id": 2, "status": "Closed", "metadata": {"ticket_type": "task"}, "tags": []},
+    {"id": 3, "status": "open", "metadata": {"ticket_type": "task"}, "tags": ["api"]},
+    {"id": 4, "status": "blocked", "metadata": {}, "tags": ["api", "ui"]},
+]
+
+
+def _parse(*argv, **kwargs):
+    parser = argparse.ArgumentParser()
+    add_list_arguments(parser, FIELDS, **kwargs)
+    return ListOptions.from_args(parser.parse_args(list(argv)))
+
+
+def _ids(page):
+    return [item["id"] for item in page.items]
+
+
+def test_parse_flags():
+    options = _parse(
+        "--limit", "5", "--offset", "2", "--filter", "type=bug", "--filter", "tag=ui"
+    )
+    assert options.limit == 5
+    assert options.offset == 2
+    assert options.filters == {"metadata.ticket_type": ["bug"], "tags": ["ui"]}
+    assert options.active
+
+    defaults = _parse(default_limit=10)
+    assert defaults.limit == 10
+    assert not defaults.active
+
+
+@pytest.mark.parametrize(
+    "argv",
+    [
+        ["--filter", "status"],
+        ["--filter", "owner=me"],
+        ["--limit", "0"],
+        ["--offset", "-1"],
+        ["--limit", "many"],
+    ],
+)
+def test_invalid_flags_are_usage_errors(argv, capsys):
+    with pytest.raises(SystemExit):
+        _parse(*argv)
+    assert "--" in capsys.readouterr().err
+
+
+def test_unknown_filter_key_lists_valid_keys(capsys):
+    with pytest.raises(SystemExit):
+        _parse("--filter", "owner=me")
+    assert "keys: status, type, tag" in capsys.readouterr().err
+
+
+def test_filter_semantics():
+    assert _ids(_parse("--filter", "status=OPEN").apply(ITEMS)) == [1, 3]
+    assert _ids(_parse("--filter", "status=c*").apply(ITEMS)) == [2]
+    assert _ids(_parse("--filter", "tag=api").apply(ITEMS)) == [3, 4]
+
+    both = _parse("--filter", "status=open", "--filter", "tag=api")
+    assert _ids(both.apply(ITEMS)) == [3]
+
+    either = _parse("--filter", "status=closed", "--filter", "status=blocked")
+    assert _ids(either.apply(ITEMS)) == [2, 4]
+
+    assert _ids(_parse("--filter", "type=task").apply(ITEMS)) == [2, 3]
+
+
+def test_filter_then_offset_then_limit():
+    page = _parse("--filter", "tag=*", "--offset", "1", "--limit", "1").apply(ITEMS)
+
+    assert _ids(page) == [3]
+    assert page.total == 3
+    assert page.has_more
+    assert page.summary("tickets") == "Showing 2-2 of 3 tickets"
+    assert page.next_hint("claude-mpm tickets list") == (
+        "Next page: claude-mpm tickets list --offset 2 --limit 1"
+    )
+
+    last = _parse("--offset", "2").apply(ITEMS)
+    assert _ids(last) == [3, 4]
+    assert last.next_hint("x") is None
+    assert _parse("--offset", "9").apply(ITEMS).summary() == "Showing 0 of 4 items"
+
+
+def test_from_args_ignores_missing_or_mock_values():
+    assert ListOptions.from_args(Namespace(), default_limit=7).limit == 7
+    options = ListOptions.from_args(MagicMock(), default_limit=3)
+    assert (options.limit, options.offset, options.filters) == (3, 0, {})
+
+
+def test_tickets_list_passes_offset_and_filter():
+    from claude_mpm.cli.commands.tickets import TicketsCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+    from claude_mpm.services.ticket_services import TicketCRUDService
+
+    args = create_parser().parse_args(
+        ["tickets", "list", "--offset", "1", "--limit", "2", "--filter", "tag=api"]
+    )
+    manager = MagicMock()
+    manager.list_recent_tickets.return_value = [
+        {**item, "title": f"T{item['id']}"} for item in ITEMS
+    ]
+    command = TicketsCommand()
+    command.crud_service = TicketCRUDService(ticket_manager=manager)
+    args.status = "all"
+
+    with patch("builtins.print") as mock_print:
+        result = command._list_tickets(args)
+
+    assert result.success
+    printed = "\n".join(str(call.args[0]) for call in mock_print.call_args_list)
+    assert "[4] T4" in printed
+    assert "[3] T3" not in printed
+
+
+def test_skills_list_pages_in_display_order():
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+
+    command = SkillsManagementCommand()
+    skills = [
+        {"name": name, "category": category, "metadata": {}}
+        for name, category in [("b", "web"), ("a", "web"), ("c", "core")]
+    ]
+    args = Namespace(verbose=False, limit=1, offset=1, filters=None)
+
+    with (
+        patch.object(
+            command.skills_service, "discover_bundled_skills", return_value=skills
+        ),
+        patch("claude_mpm.cli.commands.skills.console") as console,
+    ):
+        result = command._list_skills(args)
+
+    printed = "\n".join(
+        str(call.args[0]) for call in console.print.call_args_list if call.args
+    )
+    assert result.success
+    assert "[/green] a" in printed
+    assert "[/green] b" not in printed
+    assert "[/green] c" not in printed
+    assert "Showing 2-2 of 3 skills" in printed
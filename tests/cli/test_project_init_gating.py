@@ -194,6 +194,11 @@ class TestNeedsProjectWorkspace:
             needs_project_workspace(_args("monitor", monitor_command="port")) is False
         )
 
+    def test_agents_deploy_dry_run_skips_workspace(self):
+        # The startup deployment would run before the dry run it previews
+        args = _args("agents", agents_command="deploy", dry_run=True)
+        assert needs_project_workspace(args) is False
+
     def test_agents_list_no_workspace(self):
         assert needs_project_workspace(_args("agents", agents_command="list")) is False
 
@@ -132,3 +132,37 @@ def test_scope_user_passes_skill_filter():
 
     _, kwargs = manager.deploy_skills.call_args
     assert kwargs["skill_filter"] == {"alpha", "beta"}
+
+
+def test_scope_project_honors_project_profile(tmp_path, monkeypatch):
+    """The project's skill profile narrows a project deploy to its skills."""
+    monkeypatch.chdir(tmp_path)
+    profiles = tmp_path / ".claude-mpm" / "profiles"
+    profiles.mkdir(parents=True)
+    (profiles / "backend.yaml").write_text(
+        "skills:\n  sources: [system]\n  enabled: [flask]\n"
+    )
+    (tmp_path / ".claude-mpm" / "configuration.yaml").write_text("profile: backend\n")
+    manager = _patched_manager()
+    manager.get_all_skills.return_value = [
+        {"name": "flask", "source_id": "system"},
+        {"name": "flask", "source_id": "community"},
+        {"name": "react", "source_id": "system"},
+    ]
+
+    with (
+        patch(
+            "claude_mpm.services.skills.git_skill_source_manager.GitSkillSourceManager",
+            return_value=manager,
+        ),
+        patch("claude_mpm.config.skill_sources.SkillSourceConfiguration"),
+    ):
+        SkillsManagementCommand()._deploy_skills(_make_args(scope="project"))
+        _, kwargs = manager.deploy_skills_to_project.call_args
+        assert kwargs["skill_list"] == ["flask"]
+
+        args = _make_args(scope="project")
+        args.no_profile = True
+        SkillsManagementCommand()._deploy_skills(args)
+        _, kwargs = manager.deploy_skills_to_project.call_args
+        assert kwargs["skill_list"] is None
@@ -4,7 +4,8 @@ Tests the startup integration of GitSkillSourceManager to ensure
 skill templates are synchronized correctly on Claude MPM initialization.
 """
 
-from unittest.mock import MagicMock, patch
+from pathlib import Path
+from unittest.mock import ANY, MagicMock, patch
 
 import pytest
 
@@ -68,8 +69,10 @@ class TestSyncRemoteSkillsOnStartup:
         # Verify configuration was loaded
         mock_config_class.assert_called_once()
 
-        # Verify manager was created
-        mock_manager_class.assert_called_once_with(mock_config)
+        # Verify manager was created with the project's skills.lock
+        mock_manager_class.assert_called_once_with(mock_config, lock=ANY)
+        lock = mock_manager_class.call_args.kwargs["lock"]
+        assert lock.path == Path.cwd() / ".claude-mpm" / "skills.lock"
 
         # Verify sync was called with force=False and progress_callback
         call_args = mock_manager.sync_all_sources.call_args
@@ -0,0 +1,104 @@
+"""
+Tests for CLI output verbosity levels.
+
+COVERAGE:
+- -q, -v, -vv and --debug map to QUIET/NORMAL/VERBOSE/DEBUG
+- A main-level -q is not reset by the subcommand's parser
+- Quiet mode holds back ordinary output and keeps the result on stdout
+- Held-back output is replayed to stderr when the command fails
+- JSON output and interactive commands are never held back
+"""
+
+import io
+import sys
+from types import SimpleNamespace
+
+from claude_mpm.cli.parsers.base_parser import create_parser
+from claude_mpm.cli.verbosity import (
+    DEBUG,
+    NORMAL,
+    QUIET,
+    VERBOSE,
+    CommandOutput,
+    emit_quiet_result,
+    emit_result,
+    verbosity_from_args,
+)
+
+
+def _args(**kwargs):
+    defaults = {"command": "skill-source", "quiet": False, "verbose": 0}
+    return SimpleNamespace(**{**defaults, **kwargs})
+
+
+def test_levels_from_flags():
+    parser = create_parser()
+
+    def level(*argv):
+        return verbosity_from_args(parser.parse_args([*argv, "work-queue", "status"]))
+
+    assert level() == NORMAL
+    assert level("-q") == QUIET
+    assert level("-v") == VERBOSE
+    assert level("-vv") == DEBUG
+    assert level("-vvv") == DEBUG
+    assert level("--debug", "-q") == DEBUG
+
+
+def test_main_level_quiet_survives_subcommand_parser():
+    parser = create_parser()
+
+    assert parser.parse_args(["-q", "skill-source", "list"]).quiet is True
+    assert parser.parse_args(["skill-source", "-q", "list"]).quiet is True
+    assert parser.parse_args(["skill-source", "list"]).quiet is False
+
+
+def test_quiet_prints_only_the_result(monkeypatch):
+    stdout = io.StringIO()
+    monkeypatch.setattr(sys, "stdout", stdout)
+
+    output = CommandOutput.start(_args(quiet=True))
+    print("✅ Added skill source: my-skills")
+    emit_quiet_result("my-skills")
+    assert output.finish(0) == 0
+
+    assert stdout.getvalue() == "my-skills\n"
+    assert sys.stdout is stdout
+
+
+def test_quiet_failure_replays_output_to_stderr(monkeypatch):
+    stdout, stderr = io.StringIO(), io.StringIO()
+    monkeypatch.setattr(sys, "stdout", stdout)
+    monkeypatch.setattr(sys, "stderr", stderr)
+
+    output = CommandOutput.start(_args(quiet=True))
+    print("Syncing sources...")
+    print("Error: repository not found")
+    assert output.finish(1) == 1
+
+    assert stdout.getvalue() == ""
+    assert "Error: repository not found" in stderr.getvalue()
+
+
+def test_normal_mode_prints_everything_once(monkeypatch):
+    stdout = io.StringIO()
+    monkeypatch.setattr(sys, "stdout", stdout)
+
+    output = CommandOutput.start(_args())
+    print("✅ Added skill source: my-skills")
+    emit_quiet_result("my-skills")
+    emit_result("listing")
+    output.finish(0)
+
+    assert stdout.getvalue() == "✅ Added skill source: my-skills\nlisting\n"
+
+
+def test_machine_output_and_interactive_commands_are_not_held(monkeypatch):
+    stdout = io.StringIO()
+    monkeypatch.setattr(sys, "stdout", stdout)
+
+    for args in (_args(quiet=True, format="json"), _args(quiet=True, command="run")):
+        output = CommandOutput.start(args)
+        assert output.quiet
+        assert sys.stdout is stdout
+        output.finish(0)
@@ -0,0 +1,124 @@
+"""
+Tests for --watch on status commands.
+
+COVERAGE:
+- --watch takes an optional interval; too-small or non-numeric values are
+  rejected
+- Status subcommands accept --watch; other commands don't
+- Each refresh captures the command's output under a header; terminals get
+  an in-place redraw, pipes get appended refreshes
+- A failing refresh keeps watching; Ctrl+C stops with exit code 0
+"""
+
+import argparse
+import io
+from datetime import datetime
+
+import pytest
+
+from claude_mpm.cli.watch import DEFAULT_INTERVAL, add_watch_argument, watch
+
+
+class _Tty(io.StringIO):
+    def isatty(self):
+        return True
+
+
+def _fixed_now():
+    return datetime(2026, 1, 2, 3, 4, 5)
+
+
+def test_watch_argument_interval():
+    parser = argparse.ArgumentParser()
+    add_watch_argument(parser)
+
+    assert parser.parse_args([]).watch is None
+    assert parser.parse_args(["--watch"]).watch == DEFAULT_INTERVAL
+    assert parser.parse_args(["--watch", "5"]).watch == 5.0
+    for bad in ("0.1", "soon"):
+        with pytest.raises(SystemExit):
+            parser.parse_args(["--watch", bad])
+
+
+def test_status_commands_accept_watch():
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    parser = create_parser()
+    for argv in (
+        ["work-queue", "status"],
+        ["batch", "status"],
+        ["daemon", "status"],
+        ["storage", "status"],
+        ["aggregate", "sessions"],
+    ):
+        assert parser.parse_args([*argv, "--watch", "3"]).watch == 3.0
+
+    with pytest.raises(SystemExit):
+        parser.parse_args(["skills", "deploy", "--watch"])
+
+
+def test_refreshes_are_appended_when_not_a_terminal():
+    calls = []
+
+    def run():
+        calls.append(1)
+        print(f"refresh {len(calls)}")
+        return 0
+
+    stream = io.StringIO()
+    code = watch(
+        run,
+        2,
+        "claude-mpm batch status",
+        stream=stream,
+        sleep=lambda _: None,
+        now=_fixed_now,
+        refreshes=2,
+    )
+
+    output = stream.getvalue()
+    assert code == 0
+    assert output.count("Every 2s: claude-mpm batch status") == 2
+    assert "2026-01-02 03:04:05" in output
+    assert output.index("refresh 1") < output.index("refresh 2")
+    assert "\x1b[" not in output
+
+
+def test_terminal_is_redrawn_in_place():
+    stream = _Tty()
+    watch(
+        lambda: print("line"),
+        1,
+        "x",
+        stream=stream,
+        sleep=lambda _: None,
+        now=_fixed_now,
+        refreshes=2,
+    )
+
+    frames = stream.getvalue().split("\x1b[H")[1:]
+    assert len(frames) == 2
+    assert frames[0].endswith("\x1b[J")
+    assert "line\x1b[K\n" in frames[1]
+
+
+def test_failures_keep_watching_and_ctrl_c_stops():
+    results = iter([3, RuntimeError("boom")])
+
+    def run():
+        result = next(results)
+        if isinstance(result, Exception):
+            raise result
+        return result
+
+    def sleep(_):
+        if stream.getvalue().count("Every") == 2:
+            raise KeyboardInterrupt
+
+    stream = io.StringIO()
+    code = watch(run, 1, "x", stream=stream, sleep=sleep, now=_fixed_now)
+
+    output = stream.getvalue()
+    assert code == 0
+    assert "[exit 3]" in output
+    assert "Error: boom" in output
@@ -58,7 +58,7 @@ class TestSkillSource:
 
     def test_skill_source_validation_invalid_type(self):
         """Test validation fails for unsupported type."""
-        with pytest.raises(ValueError, match="Only 'git' type"):
+        with pytest.raises(ValueError, match="Type must be one of git, local"):
             SkillSource(id="test", type="svn", url="https://github.com/owner/repo")
 
     def test_skill_source_validation_empty_url(self):
@@ -71,10 +71,10 @@ class TestSkillSource:
         with pytest.raises(ValueError, match="must use http:// or https://"):
             SkillSource(id="test", type="git", url="ftp://github.com/owner/repo")
 
-    def test_skill_source_validation_non_github_url(self):
-        """Test validation fails for non-GitHub URL."""
-        with pytest.raises(ValueError, match="must be a GitHub repository"):
-            SkillSource(id="test", type="git", url="https://gitlab.com/owner/repo")
+    def test_skill_source_validation_unknown_host_url(self):
+        """Test validation fails for a URL on an unknown hosting service."""
+        with pytest.raises(ValueError, match="must be a GitHub, GitLab or Bitbucket"):
+            SkillSource(id="test", type="git", url="https://example.com/owner/repo")
 
     def test_skill_source_validation_invalid_url_path(self):
         """Test validation fails for URL without owner/repo."""
@@ -452,6 +452,17 @@ def test_env(monkeypatch, tmp_path):
     return monkeypatch
 
 
+@pytest.fixture(autouse=True)
+def trusted_workspace(monkeypatch):
+    """Run sessions as trusted and never prompt for workspace trust.
+
+    run_session_legacy exports CLAUDE_MPM_WORKSPACE_RESTRICTED for its child
+    process; without this it would leak into later hook tests.
+    """
+    monkeypatch.setenv("CLAUDE_MPM_TRUST_WORKSPACE", "1")
+    monkeypatch.delenv("CLAUDE_MPM_WORKSPACE_RESTRICTED", raising=False)
+
+
 # ===== Process and System Fixtures =====
 
 
@@ -0,0 +1,65 @@
+"""Tests for the supervised shutdown sequence."""
+
+import threading
+
+from claude_mpm.core.shutdown import ShutdownSupervisor
+
+
+class TestShutdownSupervisor:
+    def test_steps_run_in_priority_order(self):
+        supervisor = ShutdownSupervisor()
+        calls = []
+        supervisor.register("late", lambda: calls.append("late"), priority=90)
+        supervisor.register("early", lambda: calls.append("early"), priority=10)
+
+        supervisor.shutdown()
+
+        assert calls == ["early", "late"]
+        assert supervisor.step_names == ["early", "late"]
+
+    def test_sequence_runs_once(self):
+        supervisor = ShutdownSupervisor()
+        calls = []
+        supervisor.register("step", lambda: calls.append(1))
+
+        assert supervisor.shutdown() == {"step": "ok"}
+        assert supervisor.shutdown() == {}
+        assert calls == [1]
+
+    def test_failing_step_does_not_block_others(self):
+        supervisor = ShutdownSupervisor()
+        calls = []
+
+        def boom():
+            raise RuntimeError("boom")
+
+        supervisor.register("boom", boom, priority=1)
+        supervisor.register("after", lambda: calls.append("after"), priority=2)
+
+        outcomes = supervisor.shutdown()
+
+        assert outcomes["boom"] == "error: boom"
+        assert outcomes["after"] == "ok"
+        assert calls == ["after"]
+
+    def test_hung_step_times_out(self):
+        supervisor = ShutdownSupervisor()
+        release = threading.Event()
+        supervisor.register("hang", release.wait, timeout=0.05)
+
+        try:
+            assert supervisor.shutdown() == {"hang": "timeout"}
+        finally:
+            release.set()
+
+    def test_unregister_and_replace(self):
+        supervisor = ShutdownSupervisor()
+        calls = []
+        supervisor.register("a", lambda: calls.append("old"))
+        supervisor.register("a", lambda: calls.append("new"))
+        supervisor.register("b", lambda: calls.append("b"))
+        supervisor.unregister("b")
+
+        supervisor.shutdown()
+
+        assert calls == ["new"]
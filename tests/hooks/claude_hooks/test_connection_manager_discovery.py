@@ -0,0 +1,47 @@
+"""Tests for how the hook connection manager finds its dashboard server.
+
+COVERAGE:
+- The project's discovery registry entry wins over the environment
+- Subdirectories of a registered project use the project's server
+- The environment and default port apply only without a registry entry
+"""
+
+import pytest
+
+from claude_mpm.hooks.claude_hooks.services.connection_manager_http import (
+    ConnectionManagerService,
+)
+from claude_mpm.services import server_discovery
+from claude_mpm.services.server_discovery import ServerDiscoveryRegistry
+
+
+@pytest.fixture
+def registry(tmp_path, monkeypatch):
+    path = tmp_path / "servers.json"
+    monkeypatch.setattr(server_discovery, "DEFAULT_REGISTRY_PATH", path)
+    monkeypatch.setenv("CLAUDE_MPM_SERVER_PORT", "9999")
+    return ServerDiscoveryRegistry(registry_path=path)
+
+
+def test_registry_entry_overrides_env_port(registry, tmp_path):
+    project = tmp_path / "proj"
+    (project / "sub").mkdir(parents=True)
+    registry.register(project, 8771)
+
+    assert ConnectionManagerService._resolve_server(str(project)) == (
+        "localhost",
+        8771,
+    )
+    assert ConnectionManagerService._resolve_server(str(project / "sub"))[1] == 8771
+
+
+def test_env_port_used_without_registry_entry(registry, tmp_path):
+    assert ConnectionManagerService._resolve_server(str(tmp_path)) == (
+        "localhost",
+        9999,
+    )
+
+
+def test_default_port_without_registry_or_env(registry, tmp_path, monkeypatch):
+    monkeypatch.delenv("CLAUDE_MPM_SERVER_PORT")
+    assert ConnectionManagerService._resolve_server(str(tmp_path))[1] == 8765
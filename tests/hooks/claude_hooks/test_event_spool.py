@@ -0,0 +1,101 @@
+"""Tests for the offline event spool used by the HTTP connection manager."""
+
+from unittest.mock import Mock, patch
+
+import pytest
+
+from claude_mpm.hooks.claude_hooks.services.event_spool import EventSpool
+
+
+@pytest.fixture
+def spool(tmp_path):
+    return EventSpool(spool_dir=tmp_path / "spool", max_events=5)
+
+
+class TestEventSpool:
+    def test_enqueue_and_flush_preserves_order(self, spool):
+        for i in range(3):
+            assert spool.enqueue({"seq": i})
+
+        sent = []
+        delivered = spool.flush(lambda payload: sent.append(payload["seq"]) or True)
+
+        assert delivered == 3
+        assert sent == [0, 1, 2]
+        assert spool.pending_count() == 0
+
+    def test_flush_stops_at_first_failure(self, spool):
+        for i in range(3):
+            spool.enqueue({"seq": i})
+
+        send = Mock(side_effect=[True, False])
+        assert spool.flush(send) == 1
+        # The failed event and everything after it remain queued
+        assert spool.pending_count() == 2
+
+    def test_flush_respects_limit(self, spool):
+        for i in range(4):
+            spool.enqueue({"seq": i})
+
+        assert spool.flush(lambda _p: True, limit=2) == 2
+        assert spool.pending_count() == 2
+
+    def test_oldest_events_dropped_over_limit(self, spool):
+        for i in range(8):
+            spool.enqueue({"seq": i})
+
+        sent = []
+        spool.flush(lambda payload: sent.append(payload["seq"]) or True)
+        assert sent == [3, 4, 5, 6, 7]
+
+    def test_corrupt_entry_is_discarded(self, spool):
+        spool.enqueue({"seq": 0})
+        (spool.spool_dir / "00000000000000000000-bad.json").write_text("{not json")
+
+        sent = []
+        spool.flush(lambda payload: sent.append(payload["seq"]) or True)
+        assert sent == [0]
+        assert spool.pending_count() == 0
+
+    def test_env_var_overrides_default_dir(self, tmp_path, monkeypatch):
+        monkeypatch.setenv("CLAUDE_MPM_EVENT_SPOOL_DIR", str(tmp_path / "env"))
+        assert EventSpool().spool_dir == tmp_path / "env"
+
+    def test_clear(self, spool):
+        spool.enqueue({"seq": 0})
+        spool.enqueue({"seq": 1})
+        assert spool.clear() == 2
+        assert spool.pending_count() == 0
+
+
+class TestConnectionManagerSpooling:
+    @pytest.fixture
+    def manager(self, tmp_path, monkeypatch):
+        monkeypatch.setenv("CLAUDE_MPM_EVENT_SPOOL_DIR", str(tmp_path / "spool"))
+        from claude_mpm.hooks.claude_hooks.services.connection_manager_http import (
+            ConnectionManagerService,
+        )
+
+        mgr = ConnectionManagerService()
+        yield mgr
+        mgr.cleanup()
+
+    def test_failed_post_is_spooled(self, manager):
+        import requests
+
+        with patch(
+            "requests.post", side_effect=requests.exceptions.ConnectionError()
+        ):
+            manager._http_emit_blocking("hook", "pre_tool", {"n": 1})
+
+        assert manager.event_spool.pending_count() == 1
+
+    def test_successful_post_replays_backlog(self, manager):
+        manager.event_spool.enqueue({"namespace": "hook", "data": {"n": 0}})
+        ok = Mock(status_code=200)
+
+        with patch("requests.post", return_value=ok) as post:
+            manager._http_emit_blocking("hook", "pre_tool", {"n": 1})
+
+        assert post.call_count == 2
+        assert manager.event_spool.pending_count() == 0
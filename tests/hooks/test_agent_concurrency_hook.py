@@ -0,0 +1,100 @@
+"""Tests for per-agent concurrency limits.
+
+COVERAGE:
+- agents.max_concurrent is read from the user and project files, with agent
+  names normalized and invalid limits ignored
+- Delegations take slots up to their agent's limit; agents without a limit
+  are not tracked; a returned delegation frees its slot; stale slots expire
+- The PreToolUse dispatcher denies a delegation past the limit with a reason
+  and PostToolUse releases the slot
+"""
+
+from __future__ import annotations
+
+from datetime import UTC, datetime, timedelta
+
+import pytest
+
+from claude_mpm.hooks import pretooluse_dispatcher
+from claude_mpm.hooks.agent_concurrency_hook import (
+    build_concurrency_response,
+    release_for_event,
+)
+from claude_mpm.services.agent_concurrency import STALE_AFTER, AgentSlots, load_limits
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    home = tmp_path / "home"
+    monkeypatch.setenv("HOME", str(home))
+    (home / ".claude-mpm" / "config").mkdir(parents=True)
+    (home / ".claude-mpm" / "config" / "configuration.yaml").write_text(
+        "agents:\n  max_concurrent:\n    Engineer: 3\n    qa: 2\n"
+    )
+    root = tmp_path / "app"
+    (root / ".git").mkdir(parents=True)
+    (root / ".claude-mpm").mkdir()
+    (root / ".claude-mpm" / "configuration.yaml").write_text(
+        "agents:\n  max_concurrent:\n    research-agent: 1\n    qa: 0\n"
+        "    ops: many\n"
+    )
+    return root
+
+
+def delegate(project, agent, tool_use_id, tool_name="Agent"):
+    return {
+        "hook_event_name": "PreToolUse",
+        "tool_name": tool_name,
+        "tool_input": {"subagent_type": agent, "prompt": "work"},
+        "tool_use_id": tool_use_id,
+        "session_id": "s1",
+        "cwd": str(project / "src"),
+    }
+
+
+def test_load_limits(project):
+    assert load_limits(project) == {"engineer": 3, "qa": 2, "research": 1}
+    assert load_limits(None) == {"engineer": 3, "qa": 2}
+
+
+def test_slots(project):
+    slots = AgentSlots(project)
+    assert slots.acquire("Research", "t1").granted
+    denied = slots.acquire("research", "t2")
+    assert not denied.granted
+    assert "research already has 1 delegation(s) running" in denied.reason()
+    # Asking again for a slot already held is granted
+    assert slots.acquire("research", "t1").granted
+    assert slots.acquire("documentation", "t3").granted
+    assert slots.running() == {"research": 1}
+
+    assert slots.release("t1")
+    assert not slots.release("t1")
+    assert slots.acquire("research", "t2").granted
+
+    # Without an ID the oldest slot of the agent goes
+    slots.acquire("engineer")
+    assert slots.release(agent_type="engineer-agent")
+    assert slots.running() == {"research": 1}
+
+    later = datetime.now(UTC) + STALE_AFTER + timedelta(minutes=1)
+    assert slots.running(now=later) == {}
+    assert slots.acquire("research", "t4", now=later).granted
+
+
+def test_hook_denies_past_the_limit(project):
+    first = delegate(project, "research", "t1")
+    assert build_concurrency_response(first) == {"continue": True}
+
+    second = delegate(project, "research", "t2", tool_name="Task")
+    output = pretooluse_dispatcher.dispatch(second)["hookSpecificOutput"]
+    assert output["permissionDecision"] == "deny"
+    assert "agents.max_concurrent.research = 1" in output["permissionDecisionReason"]
+
+    # Unlimited agents go on to model tier injection
+    output = pretooluse_dispatcher.dispatch(delegate(project, "ops", "t3"))
+    assert output.get("hookSpecificOutput", {}).get("permissionDecision") != "deny"
+
+    assert release_for_event({**first, "hook_event_name": "PostToolUse"})
+    assert build_concurrency_response(second) == {"continue": True}
+    assert not release_for_event({"tool_name": "Bash", "cwd": str(project)})
@@ -0,0 +1,127 @@
+"""Tests for chaos mode fault injection.
+
+COVERAGE:
+- chaos is read from the user and project files, invalid values ignored,
+  and CLAUDE_MPM_CHAOS turns it on or off
+- Off by default; when on, tool calls fail or are rate limited at their
+  rates, only for the configured tools, reproducibly with a seed
+- The PreToolUse dispatcher denies a faulted call with a [chaos] reason
+- Delayed and dropped event deliveries end up in the offline spool
+"""
+
+from __future__ import annotations
+
+from unittest.mock import Mock, patch
+
+import pytest
+
+from claude_mpm.hooks import pretooluse_dispatcher
+from claude_mpm.services.chaos import (
+    ENV_VAR,
+    RATE_LIMIT,
+    TOOL_FAILURE,
+    ChaosConfig,
+    FaultInjector,
+)
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    home = tmp_path / "home"
+    monkeypatch.setenv("HOME", str(home))
+    monkeypatch.delenv(ENV_VAR, raising=False)
+    (home / ".claude-mpm" / "config").mkdir(parents=True)
+    (home / ".claude-mpm" / "config" / "configuration.yaml").write_text(
+        "chaos:\n  tool_failure_rate: 0.5\n  rate_limit_rate: 2\n"
+        "  max_event_delay: soon\n"
+    )
+    root = tmp_path / "app"
+    (root / ".git").mkdir(parents=True)
+    (root / ".claude-mpm").mkdir()
+    (root / ".claude-mpm" / "configuration.yaml").write_text(
+        "chaos:\n  enabled: true\n  tools: Bash\n  seed: 7\n"
+    )
+    return root
+
+
+def test_config(project, monkeypatch):
+    config = ChaosConfig.load(project)
+    assert config.enabled
+    assert (config.tool_failure_rate, config.rate_limit_rate) == (0.5, 1.0)
+    assert config.max_event_delay == 2.0
+    assert (config.tools, config.seed) == (["Bash"], 7)
+    assert not ChaosConfig.load(None).enabled
+
+    monkeypatch.setenv(ENV_VAR, "0")
+    assert not ChaosConfig.load(project).enabled
+    monkeypatch.setenv(ENV_VAR, "1")
+    assert ChaosConfig.load(None).enabled
+
+
+def test_injector():
+    assert FaultInjector(ChaosConfig()).tool_fault("Bash") is None
+
+    config = ChaosConfig(
+        enabled=True, tool_failure_rate=0.3, rate_limit_rate=0.3, seed="s"
+    )
+    injector = FaultInjector(config)
+    faults = [injector.tool_fault("Read", f"call-{n}") for n in range(200)]
+    kinds = [f.kind if f else None for f in faults]
+    assert 40 < kinds.count(TOOL_FAILURE) < 80
+    assert 40 < kinds.count(RATE_LIMIT) < 80
+    # Same seed, same calls: same faults
+    again = [injector.tool_fault("Read", f"call-{n}") for n in range(200)]
+    assert [f.kind if f else None for f in again] == kinds
+
+    rate_limited = next(f for f in faults if f and f.kind == RATE_LIMIT)
+    assert rate_limited.reason() == (
+        "[chaos] Simulated rate limit: 429 Too Many Requests for Read; "
+        "retry after 30s"
+    )
+
+    config.tools = ["Bash"]
+    assert all(injector.tool_fault("Read", f"call-{n}") is None for n in range(50))
+
+    config.event_delay_rate, config.max_event_delay = 1.0, 4.0
+    assert 0 <= injector.event_delay("e1") < 4.0
+    config.drop_rate = 0.0
+    assert not injector.drop_event("e1")
+
+
+def test_dispatcher_denies_faulted_calls(project):
+    (project / ".claude-mpm" / "configuration.yaml").write_text(
+        "chaos:\n  enabled: true\n  tool_failure_rate: 1\n  tools: [Bash]\n"
+    )
+    event = {
+        "hook_event_name": "PreToolUse",
+        "tool_name": "Bash",
+        "tool_input": {"command": "ls"},
+        "tool_use_id": "t1",
+        "cwd": str(project),
+    }
+    output = pretooluse_dispatcher.dispatch(event)["hookSpecificOutput"]
+    assert output["permissionDecision"] == "deny"
+    assert output["permissionDecisionReason"] == "[chaos] Simulated failure of Bash"
+
+    read = pretooluse_dispatcher.dispatch({**event, "tool_name": "Read"})
+    assert "hookSpecificOutput" not in read
+
+
+def test_dropped_events_are_spooled(project, monkeypatch):
+    monkeypatch.setenv("CLAUDE_MPM_EVENT_SPOOL_DIR", str(project / "spool"))
+    (project / ".claude-mpm" / "configuration.yaml").write_text(
+        "chaos:\n  enabled: true\n  drop_rate: 1\n  event_delay_rate: 0\n"
+    )
+    monkeypatch.chdir(project)
+    from claude_mpm.hooks.claude_hooks.services.connection_manager_http import (
+        ConnectionManagerService,
+    )
+
+    manager = ConnectionManagerService()
+    try:
+        with patch("requests.post", return_value=Mock(status_code=200)) as post:
+            manager._http_emit_blocking("hook", "pre_tool", {"n": 1})
+        assert post.call_count == 0
+        assert manager.event_spool.pending_count() == 1
+    finally:
+        manager.cleanup()
@@ -0,0 +1,177 @@
+"""Tests for the commit message / PR description gate.
+
+COVERAGE:
+- Rules: subject length, imperative mood, ticket reference (filled in from
+  the branch name), changelog type prefix
+- Reading messages from git commit -m/-am/heredoc/-F and gh pr create;
+  commands with shell expansions are let through
+- rewrite, block and warn actions; the gate is off unless enabled
+- The PreToolUse dispatcher denies before other hooks and passes the
+  rewritten command on
+"""
+
+from __future__ import annotations
+
+import subprocess
+
+import pytest
+
+from claude_mpm.hooks import message_gate_hook, pretooluse_dispatcher
+from claude_mpm.hooks.message_gate_hook import (
+    GateConfig,
+    build_message_gate_response,
+    decode_word,
+    imperative_form,
+    review_message,
+)
+
+HEREDOC = """git commit -m "$(cat <<'EOF'
+Added retries to the sync
+
+The first attempt often times out.
+EOF
+)\""""
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    """A git repository on branch feature/ABC-42-retries with the gate on."""
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.setattr(message_gate_hook, "_CONFIG_CACHE", {})
+    subprocess.run(
+        ["git", "init", "-q", "-b", "feature/ABC-42-retries", str(tmp_path)],
+        check=True,
+    )
+    return tmp_path
+
+
+def configure(project, **settings):
+    lines = ["message_gate:", "  enabled: true"]
+    lines += [f"  {key}: {value}" for key, value in settings.items()]
+    config = project / ".claude-mpm" / "configuration.yaml"
+    config.parent.mkdir(exist_ok=True)
+    config.write_text("\n".join(lines) + "\n")
+    message_gate_hook._CONFIG_CACHE.clear()
+
+
+def gate(project, command):
+    event = {"tool_name": "Bash", "tool_input": {"command": command}}
+    return build_message_gate_response({**event, "cwd": str(project)})
+
+
+def output(response):
+    return response.get("hookSpecificOutput", {})
+
+
+class TestRules:
+    def test_imperative_form(self):
+        assert imperative_form("Added") == "Add"
+        assert imperative_form("fixes") == "fix"
+        assert imperative_form("Applying") == "Apply"
+        assert imperative_form("Stopped") == "Stop"
+        assert imperative_form("Add") is None
+        assert imperative_form("Settings") is None
+
+    def test_review_fixes_and_problems(self):
+        config = GateConfig(require_ticket=True, subject_max_length=30)
+        review = review_message("feat: Added retries", "", config, "ABC-42")
+        assert review.subject == "feat: Add retries"
+        assert review.body == "Refs: ABC-42"
+        assert len(review.fixed) == 2 and review.problems == []
+
+        review = review_message("Add " + "x" * 40, "See #12", config)
+        assert review.problems == ["the subject is 44 characters (at most 30)"]
+
+        review = review_message("Add retries", "", GateConfig(require_ticket=True))
+        assert review.problems == ["no ticket reference"]
+
+    def test_changelog_prefix(self):
+        config = GateConfig(changelog=True)
+        assert review_message("fix(sync)!: Retry", "", config).problems == []
+        (problem,) = review_message("Retry the sync", "", config).problems
+        assert "changelog type" in problem
+
+    def test_decode_word(self):
+        assert decode_word("'a b'") == "a b"
+        assert decode_word('"say \\"hi\\""') == 'say "hi"'
+        assert decode_word('"$HOME"') is None
+        assert decode_word(HEREDOC.split("-m ", 1)[1]).startswith("Added retries")
+
+
+class TestBashCommands:
+    def test_disabled_by_default(self, project):
+        assert gate(project, "git commit -m 'Added x'") == {"continue": True}
+
+    def test_rewrite_commit(self, project):
+        configure(project, require_ticket="true")
+        response = gate(project, "git add -A && git commit -am 'Added x' && git push")
+        command = output(response)["updatedInput"]["command"]
+        assert command == (
+            "git add -A && git commit -a -m 'Add x' -m 'Refs: ABC-42' && git push"
+        )
+        assert output(response)["permissionDecision"] == "allow"
+
+        command = output(gate(project, HEREDOC))["updatedInput"]["command"]
+        assert command.startswith("git commit -m 'Add retries to the sync' -m ")
+        assert command.endswith("often times out.' -m 'Refs: ABC-42'")
+
+        assert gate(project, "git commit -m 'Fix x (#3)'") == {"continue": True}
+        assert gate(project, 'git commit -m "$(date)"') == {"continue": True}
+        assert gate(project, "git commit --amend --no-edit") == {"continue": True}
+
+    def test_rewrite_message_file(self, project):
+        configure(project)
+        (project / "msg.txt").write_text("Removed dead code\n\nBody\n")
+        response = gate(project, "git commit -F msg.txt")
+        assert output(response)["updatedInput"]["command"] == "git commit -F msg.txt"
+        assert (project / "msg.txt").read_text() == "Remove dead code\n\nBody\n"
+
+    def test_unfixable_problem_denies(self, project):
+        configure(project, subject_max_length=10, changelog="true")
+        response = gate(project, "git commit -m 'Added a long subject'")
+        reason = output(response)["permissionDecisionReason"]
+        assert output(response)["permissionDecision"] == "deny"
+        assert "(at most 10)" in reason and "changelog type" in reason
+        assert "updatedInput" not in output(response)
+
+    def test_block_and_warn(self, project):
+        configure(project, action="block")
+        response = gate(project, "git commit -m 'Added x'")
+        assert output(response)["permissionDecision"] == "deny"
+        assert "'Added' (use 'Add')" in output(response)["permissionDecisionReason"]
+
+        configure(project, action="warn")
+        response = gate(project, "git commit -m 'Added x'")
+        assert output(response)["permissionDecision"] == "allow"
+        assert "updatedInput" not in output(response)
+
+    def test_pull_request(self, project):
+        configure(project, require_ticket="true")
+        response = gate(project, 'gh pr create --title "Fixed retries" --body "Why"')
+        command = output(response)["updatedInput"]["command"]
+        assert command == (
+            "gh pr create --title 'Fix retries' --body \"Why\n\nRefs: ABC-42\""
+        )
+
+        event = {
+            "tool_name": "mcp__github__create_pull_request",
+            "tool_input": {"title": "Adds retries", "body": "", "head": "x"},
+            "cwd": str(project),
+        }
+        updated = output(build_message_gate_response(event))["updatedInput"]
+        assert updated == {"title": "Add retries", "body": "Refs: ABC-42", "head": "x"}
+
+
+def test_dispatcher(project, monkeypatch):
+    monkeypatch.setenv("CLAUDE_MPM_DISABLE_ZTK", "1")
+    configure(project, subject_max_length=20)
+    event = {"hook_event_name": "PreToolUse", "tool_name": "Bash", "cwd": str(project)}
+
+    command = "git commit -m 'Add a much longer subject'"
+    long = {**event, "tool_input": {"command": command}}
+    assert output(pretooluse_dispatcher.dispatch(long))["permissionDecision"] == "deny"
+
+    short = {**event, "tool_input": {"command": "git commit -m 'Added x'"}}
+    response = output(pretooluse_dispatcher.dispatch(short))
+    assert response["updatedInput"]["command"] == "git commit -m 'Add x'"
+    assert response["permissionDecisionReason"].startswith("Rewrote commit message")
@@ -0,0 +1,125 @@
+"""Tests for routing tickets and review requests to code owners.
+
+COVERAGE:
+- gh issue create gets --assignee from the files its title and body mention
+- GitHub MCP issues and mcp-ticketer tickets get assignees the same way
+- gh pr create gets --reviewer from the files the branch changes, without
+  the local git user
+- Calls that already name someone, and disabled routing, are left alone
+- The PreToolUse dispatcher passes the routed input on
+"""
+
+from __future__ import annotations
+
+import subprocess
+
+import pytest
+
+from claude_mpm.hooks import pretooluse_dispatcher
+from claude_mpm.hooks.ownership_hook import build_ownership_response
+from claude_mpm.services import ownership
+
+
+def git(repo, *args):
+    subprocess.run(["git", *args], cwd=repo, check=True, capture_output=True)
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    """A repository on a feature branch that changes billing/api.py."""
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.setattr(ownership, "_CONFIG_CACHE", {})
+    git(tmp_path, "init", "-q", "-b", "main")
+    git(tmp_path, "config", "user.email", "me@x.io")
+    git(tmp_path, "config", "user.name", "Me")
+    (tmp_path / "CODEOWNERS").write_text(
+        "* @me\nbilling/ @org/payments @carol\ndocs/ @dan\n"
+    )
+    (tmp_path / "billing").mkdir()
+    (tmp_path / "billing" / "api.py").write_text("x = 1\n")
+    git(tmp_path, "add", ".")
+    git(tmp_path, "commit", "-qm", "initial")
+    git(tmp_path, "checkout", "-qb", "feature")
+    (tmp_path / "billing" / "api.py").write_text("x = 2\n")
+    git(tmp_path, "commit", "-qam", "change")
+    (tmp_path / ".claude-mpm").mkdir()
+    (tmp_path / ".claude-mpm" / "configuration.yaml").write_text(
+        "ownership:\n  enabled: true\n"
+    )
+    (tmp_path / ".claude-mpm" / "owners.yaml").write_text(
+        "authors:\n  me@x.io: '@me'\nassignees:\n  '@org/payments': '@pat'\n"
+    )
+    return tmp_path
+
+
+def route(project, tool_name, tool_input):
+    event = {"tool_name": tool_name, "tool_input": tool_input, "cwd": str(project)}
+    return build_ownership_response(event).get("hookSpecificOutput", {})
+
+
+def bash(project, command):
+    return route(project, "Bash", {"command": command})
+
+
+def test_gh_issue_create(project):
+    output = bash(project, 'gh issue create --title "Crash in billing/api.py:1"')
+    assert output["updatedInput"]["command"] == (
+        'gh issue create --assignee pat --title "Crash in billing/api.py:1"'
+    )
+    assert output["permissionDecisionReason"] == (
+        "Assigned to pat: pat owns billing/api.py (CODEOWNERS)"
+    )
+
+    (project / "body.md").write_text("See `billing/api.py`.\n")
+    output = bash(project, "gh issue create -t Crash -F body.md && echo done")
+    assert output["updatedInput"]["command"].startswith(
+        "gh issue create --assignee pat -t"
+    )
+
+    assert bash(project, "gh issue create -t 'Crash' -a bob -b billing/api.py") == {}
+    assert bash(project, "gh issue create -t 'Nothing referenced'") == {}
+
+
+def test_mcp_tickets(project):
+    issue = {"title": "Bug", "body": "billing/api.py:1 fails"}
+    output = route(project, "mcp__github__create_issue", issue)
+    assert output["updatedInput"] == {**issue, "assignees": ["pat"]}
+
+    ticket = {"action": "create", "title": "billing/api.py fails"}
+    output = route(project, "mcp__mcp-ticketer__ticket", ticket)
+    assert output["updatedInput"] == {**ticket, "assignee": "pat"}
+    assert route(project, "mcp__mcp-ticketer__ticket_read", ticket) == {}
+    named = {"title": "billing/api.py fails", "assignee": "someone"}
+    assert route(project, "mcp__mcp-ticketer__ticket_create", named) == {}
+
+
+def test_gh_pr_create(project):
+    output = bash(project, "gh pr create --fill")
+    # @me owns everything but opened the PR, so only the billing owners
+    assert output["updatedInput"]["command"] == (
+        "gh pr create --reviewer org/payments,carol --fill"
+    )
+    assert "org/payments owns billing/api.py (CODEOWNERS)" in (
+        output["permissionDecisionReason"]
+    )
+    assert bash(project, "gh pr create --fill -r dan") == {}
+    assert bash(project, "gh pr create --fill --base nope") == {}
+
+
+def test_disabled(project):
+    (project / ".claude-mpm" / "configuration.yaml").write_text("ownership: {}\n")
+    ownership._CONFIG_CACHE.clear()
+    assert bash(project, "gh pr create --fill") == {}
+
+
+def test_dispatcher(project, monkeypatch):
+    monkeypatch.setenv("CLAUDE_MPM_DISABLE_ZTK", "1")
+    event = {
+        "hook_event_name": "PreToolUse",
+        "tool_name": "mcp__mcp-ticketer__ticket_create",
+        "tool_input": {"title": "billing/api.py is slow"},
+        "cwd": str(project),
+    }
+    output = pretooluse_dispatcher.dispatch(event)["hookSpecificOutput"]
+    assert output["updatedInput"]["assignee"] == "pat"
+    assert output["permissionDecisionReason"].startswith("Assigned to pat")
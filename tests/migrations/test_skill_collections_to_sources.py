@@ -0,0 +1,108 @@
+"""Tests for the skill_collections_to_sources migration.
+
+Coverage:
+1. Collections become skill sources with their priority and enabled state; a
+   collection of a repository that is already a source maps onto it.
+2. A name taken by a source with another URL gets a ``-collection`` suffix.
+3. ``default_collection`` follows the mapping, ``collections`` is removed and
+   ``config.json`` is backed up.
+4. Collection clones in ~/.claude/skills/ are deleted; deployed skills stay.
+5. Invalid entries stay in ``collections`` and the migration reports failure.
+6. No-op without config.json or without collections.
+"""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSourceConfiguration
+from claude_mpm.migrations.v6_5_75_skill_collections_to_sources import (
+    run_migration,
+)
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    return tmp_path
+
+
+def _write_config(home: Path, skills: dict) -> Path:
+    path = home / ".claude-mpm" / "config.json"
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(json.dumps({"version": "1.0", "skills": skills}))
+    return path
+
+
+def _collection(url: str, priority: int, enabled: bool = True) -> dict:
+    return {"url": url, "priority": priority, "enabled": enabled, "last_update": None}
+
+
+def test_collections_become_sources(home):
+    path = _write_config(
+        home,
+        {
+            "collections": {
+                "claude-mpm": _collection(
+                    "https://github.com/bobmatnyc/claude-mpm-skills", 1
+                ),
+                "obra": _collection("https://github.com/obra/superpowers.git", 2),
+                "system": _collection("https://github.com/org/system-skills", 3, False),
+            },
+            "default_collection": "claude-mpm",
+        },
+    )
+    clone = home / ".claude" / "skills" / "obra"
+    (clone / ".git").mkdir(parents=True)
+    deployed = home / ".claude" / "skills" / "tdd"
+    deployed.mkdir()
+
+    assert run_migration() is True
+
+    sources = {s.id: s for s in SkillSourceConfiguration().load()}
+    assert (sources["obra"].url, sources["obra"].priority) == (
+        "https://github.com/obra/superpowers.git",
+        2,
+    )
+    assert not sources["system-collection"].enabled
+    assert sources["system"].url == "https://github.com/bobmatnyc/claude-mpm-skills"
+    assert "claude-mpm" not in sources
+
+    config = json.loads(path.read_text())
+    assert config["skills"] == {"default_collection": "system"}
+    assert config["version"] == "1.0"
+    assert len(list(path.parent.glob("config.json.backup_*"))) == 1
+    assert not clone.exists() and deployed.is_dir()
+
+    # Nothing left to migrate
+    assert run_migration() is True
+    assert len(list(path.parent.glob("config.json.backup_*"))) == 1
+
+
+def test_invalid_collection_is_kept(home):
+    path = _write_config(
+        home,
+        {
+            "collections": {
+                "good": _collection("https://github.com/org/good", 5),
+                "bad": _collection("ftp://example.com/skills", 6),
+            },
+            "default_collection": "good",
+        },
+    )
+
+    assert run_migration() is False
+    assert SkillSourceConfiguration().get_source("good") is not None
+    skills = json.loads(path.read_text())["skills"]
+    assert list(skills["collections"]) == ["bad"]
+    assert skills["default_collection"] == "good"
+
+
+def test_noop_without_collections(home):
+    assert run_migration() is True
+    path = _write_config(home, {"default_collection": "system"})
+    assert run_migration() is True
+    assert not list(path.parent.glob("config.json.backup_*"))
@@ -0,0 +1,119 @@
+"""Tests for schema-versioned ~/.claude-mpm state migrations."""
+
+import json
+
+import pytest
+
+from claude_mpm.migrations.state import (
+    StateMigration,
+    StateMigrationError,
+    StateMigrator,
+)
+
+
+def _rename_memories(state_dir):
+    (state_dir / "memories").rename(state_dir / "agent-memories")
+
+
+def _boom(state_dir):
+    (state_dir / "config.yaml").write_text("half-written")
+    raise RuntimeError("disk full")
+
+
+@pytest.fixture
+def state_dir(tmp_path):
+    root = tmp_path / ".claude-mpm"
+    (root / "memories").mkdir(parents=True)
+    (root / "memories" / "pm.md").write_text("remember this")
+    (root / "config.yaml").write_text("port: 8765\n")
+    (root / "cache").mkdir()
+    (root / "cache" / "big.bin").write_text("rebuildable")
+    return root
+
+
+class TestStateMigrator:
+    def test_fresh_state_is_at_version_zero(self, state_dir):
+        migrator = StateMigrator(state_dir, migrations=[])
+        assert migrator.current_version() == 0
+        assert migrator.migrate() == []
+
+    def test_applies_pending_in_order_and_records_version(self, state_dir):
+        calls = []
+        migrations = [
+            StateMigration(2, "second", "", lambda d: calls.append(2)),
+            StateMigration(1, "first", "", lambda d: calls.append(1)),
+        ]
+        migrator = StateMigrator(state_dir, migrations)
+
+        applied = migrator.migrate(package_version="9.9.9")
+
+        assert [m.id for m in applied] == ["first", "second"]
+        assert calls == [1, 2]
+        schema = json.loads((state_dir / "state_schema.json").read_text())
+        assert schema["schema_version"] == 2
+        assert schema["package_version"] == "9.9.9"
+        assert migrator.migrate() == []
+
+    def test_only_newer_versions_run(self, state_dir):
+        calls = []
+        StateMigrator(state_dir, [StateMigration(1, "a", "", lambda d: None)]).migrate()
+
+        migrator = StateMigrator(
+            state_dir,
+            [
+                StateMigration(1, "a", "", lambda d: calls.append("a")),
+                StateMigration(2, "b", "", lambda d: calls.append("b")),
+            ],
+        )
+        migrator.migrate()
+
+        assert calls == ["b"]
+
+    def test_version_only_steps_take_no_snapshot(self, state_dir):
+        migrator = StateMigrator(state_dir, [StateMigration(1, "baseline", "")])
+
+        assert [m.id for m in migrator.migrate()] == ["baseline"]
+        assert migrator.current_version() == 1
+        assert migrator.list_backups() == []
+
+    def test_failure_restores_snapshot(self, state_dir):
+        migrator = StateMigrator(
+            state_dir,
+            [
+                StateMigration(1, "rename", "", _rename_memories),
+                StateMigration(2, "boom", "", _boom),
+            ],
+        )
+
+        with pytest.raises(StateMigrationError):
+            migrator.migrate()
+
+        assert (state_dir / "memories" / "pm.md").read_text() == "remember this"
+        assert not (state_dir / "agent-memories").exists()
+        assert (state_dir / "config.yaml").read_text() == "port: 8765\n"
+        assert (state_dir / "cache" / "big.bin").exists()
+        assert migrator.current_version() == 0
+
+    def test_manual_rollback_to_latest_backup(self, state_dir):
+        migrator = StateMigrator(
+            state_dir, [StateMigration(1, "rename", "", _rename_memories)]
+        )
+        migrator.migrate()
+        assert migrator.current_version() == 1
+
+        migrator.restore()
+
+        assert (state_dir / "memories" / "pm.md").exists()
+        assert not (state_dir / "agent-memories").exists()
+        assert migrator.current_version() == 0
+
+    def test_backups_exclude_rebuildable_dirs_and_are_pruned(self, state_dir):
+        migrator = StateMigrator(state_dir, migrations=[])
+        for _ in range(5):
+            migrator.backup()
+
+        assert migrator.prune_backups(keep=2) == 3
+        backups = migrator.list_backups()
+        assert len(backups) == 2
+        assert not (backups[0] / "cache").exists()
+        assert (backups[0] / "memories" / "pm.md").exists()
@@ -0,0 +1,53 @@
+"""Tests for sharing event server traffic across processes through Redis."""
+
+import pytest
+
+from claude_mpm.services.monitor.scaling import (
+    DEFAULT_CHANNEL,
+    REDIS_ENV_VAR,
+    ScalingConfig,
+)
+
+
+class FakeRedisManager:
+    def __init__(self, url, channel):
+        self.url = url
+        self.channel = channel
+
+
+def test_disabled_without_redis_url():
+    config = ScalingConfig.load(config={}, environ={})
+    assert not config.enabled
+    assert config.client_manager() is None
+    assert config.channel == DEFAULT_CHANNEL
+
+
+def test_env_overrides_config_and_builds_redis_manager(monkeypatch):
+    import socketio
+
+    monkeypatch.setattr(socketio, "AsyncRedisManager", FakeRedisManager, raising=False)
+    config = ScalingConfig.load(
+        config={
+            "event_server": {
+                "redis_url": "redis://from-config:6379/0",
+                "channel": "team-a",
+                "node_id": "node-1",
+            }
+        },
+        environ={REDIS_ENV_VAR: "redis://from-env:6379/1"},
+    )
+
+    manager = config.client_manager()
+    assert (manager.url, manager.channel) == ("redis://from-env:6379/1", "team-a")
+    assert config.node_id == "node-1"
+
+
+def test_missing_redis_package_is_an_error(monkeypatch):
+    import socketio
+
+    def unavailable(url, channel):
+        raise RuntimeError("Redis package is not installed")
+
+    monkeypatch.setattr(socketio, "AsyncRedisManager", unavailable, raising=False)
+    with pytest.raises(RuntimeError, match="claude-mpm\\[queue\\]"):
+        ScalingConfig(redis_url="redis://localhost").client_manager()
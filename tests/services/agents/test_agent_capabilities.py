@@ -0,0 +1,97 @@
+"""Tests for the deployed agent capabilities inventory.
+
+COVERAGE:
+- Model, tools, skills, memory settings and schema version come from the
+  deployed frontmatter; skills that are not deployed are flagged
+- Project agents shadow user agents of the same name, and an agent with
+  broken frontmatter is listed with its error
+- ``agents capabilities --json`` prints the versioned report
+"""
+
+import json
+from argparse import Namespace
+
+from claude_mpm.cli.commands.agents import AgentsCommand
+from claude_mpm.services.agents.agent_capabilities import (
+    FORMAT_VERSION,
+    agent_capabilities,
+)
+
+ENGINEER = """---
+name: engineer
+model: sonnet
+version: "3.1.0"
+schema_version: 1.3.0
+tools: Read, Edit, Bash
+disallowedTools: [WebFetch]
+skills:
+  required: [tdd]
+  optional: [git-workflow]
+memory: project
+---
+
+Engineer instructions
+"""
+
+
+def _write(path, text):
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(text)
+
+
+def test_capabilities_from_frontmatter(tmp_path):
+    project, home = tmp_path / "project", tmp_path / "home"
+    _write(project / ".claude" / "agents" / "engineer.md", ENGINEER)
+    (project / ".claude" / "skills" / "tdd").mkdir(parents=True)
+    _write(project / ".claude-mpm" / "memories" / "engineer_memories.md", "- x\n")
+
+    [engineer] = agent_capabilities(project, home)
+
+    assert engineer.scope == "project"
+    assert (engineer.model, engineer.version, engineer.schema_version) == (
+        "sonnet",
+        "3.1.0",
+        "1.3.0",
+    )
+    assert engineer.tools == ["Read", "Edit", "Bash"]
+    assert engineer.disallowed_tools == ["WebFetch"]
+    assert engineer.skills == ["git-workflow", "tdd"]
+    assert engineer.missing_skills == ["git-workflow"]
+    assert engineer.memory["scope"] == "project"
+    assert engineer.memory["file"].endswith("engineer_memories.md")
+
+
+def test_shadowing_and_broken_agents(tmp_path):
+    project, home = tmp_path / "project", tmp_path / "home"
+    _write(project / ".claude" / "agents" / "engineer.md", ENGINEER)
+    _write(project / ".claude" / "agents" / "broken.md", "---\nname: [bad\n---\n")
+    _write(home / ".claude" / "agents" / "engineer.md", "---\nmodel: opus\n---\n")
+    _write(home / ".claude" / "agents" / "qa.md", "---\nname: qa\n---\nQA\n")
+
+    agents = {agent.name: agent for agent in agent_capabilities(project, home)}
+
+    assert set(agents) == {"broken", "engineer", "qa"}
+    assert agents["engineer"].model == "sonnet"
+    assert agents["qa"].scope == "user"
+    assert agents["qa"].tools is None
+    assert agents["broken"].error.startswith("Invalid frontmatter")
+
+
+def test_capabilities_command_json(tmp_path, monkeypatch, capsys):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.chdir(tmp_path)
+    _write(tmp_path / ".claude" / "agents" / "engineer.md", ENGINEER)
+
+    result = AgentsCommand().run(
+        Namespace(agents_command="capabilities", json=True)
+    )
+
+    assert result.success
+    report = json.loads(capsys.readouterr().out)
+    assert report["version"] == FORMAT_VERSION
+    assert [agent["name"] for agent in report["agents"]] == ["engineer"]
+    assert report["agents"][0]["memory"] == {
+        "scope": "project",
+        "file": None,
+        "routing": None,
+    }
@@ -0,0 +1,146 @@
+"""Tests for agents composed from base templates and mixins.
+
+COVERAGE:
+- Deploying flattens base -> mixins -> agent: sections merge by heading,
+  mixin tools are added, mixin preambles are kept after the agent's own,
+  and extends/mixins are not deployed; each part's origin is recorded
+- Bases can extend bases and fall back to a template next to the agent;
+  missing bases and mixins and cycles fail the deployment
+- Editing a mixin makes every agent built on it stale for hot reload
+"""
+
+import os
+import time
+
+import pytest
+
+from claude_mpm.services.agents.agent_composition import AgentComposer
+from claude_mpm.services.agents.agent_hot_reload import AgentReloader
+from claude_mpm.services.agents.agent_overrides import parse_agent
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+
+BASE = """---
+name: base-engineer
+description: Writes code
+tools: Read,Edit
+model: sonnet
+---
+
+You are an engineer.
+
+## Workflow
+
+Read before you edit.
+
+## Reporting
+
+Summarize what changed.
+"""
+
+TESTING = """---
+tools: Bash
+---
+
+Tests come first.
+
+## Testing
+
+Run the tests before reporting back.
+"""
+
+AGENT = """---
+name: python-engineer
+description: Python specialist
+extends: base-engineer
+mixins: [testing]
+---
+
+You are the Python engineer.
+
+## Reporting
+
+List the files you changed.
+"""
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    project = tmp_path / "project"
+    mpm = project / ".claude-mpm"
+    for directory, name, content in (
+        ("agent-bases", "base-engineer", BASE),
+        ("agent-mixins", "testing", TESTING),
+        ("agents", "python-engineer", AGENT),
+    ):
+        (mpm / directory).mkdir(parents=True, exist_ok=True)
+        (mpm / directory / f"{name}.md").write_text(content)
+    return project
+
+
+def _deploy(project, name):
+    source = project / ".claude-mpm" / "agents" / f"{name}.md"
+    return deploy_agent_file(source, project / ".claude" / "agents")
+
+
+def test_deploy_flattens_base_and_mixins(project):
+    assert _deploy(project, "python-engineer").success
+    deployed = (project / ".claude" / "agents" / "python-engineer.md").read_text()
+    frontmatter, _, body = parse_agent(deployed)
+
+    assert frontmatter["name"] == "python-engineer"
+    assert frontmatter["tools"] == "Read,Edit,Bash"
+    assert frontmatter["model"] == "sonnet"
+    assert "extends" not in frontmatter and "mixins" not in frontmatter
+    assert body.index("You are the Python engineer.") < body.index("Tests come first.")
+    assert "You are an engineer." not in body
+    assert "Read before you edit." in body
+    assert "Run the tests before reporting back." in body
+    assert "List the files you changed." in body
+    assert "Summarize what changed." not in body
+
+    source = project / ".claude-mpm" / "agents" / "python-engineer.md"
+    origins = AgentComposer(project).compose(AGENT, source).origins
+    assert origins["## Workflow"] == "base:base-engineer"
+    assert origins["## Reporting"] == "agent"
+    assert origins["tools"] == "mixin:testing"
+
+
+def test_base_chains_and_errors(project, tmp_path):
+    composer = AgentComposer(project, tmp_path / "home")
+    templates = tmp_path / "templates"
+    templates.mkdir()
+    (templates / "engineer.md").write_text(BASE.replace("base-engineer", "engineer"))
+    chained = "---\nname: api\nextends: engineer\n---\n\n## API\n\nUse REST.\n"
+    composition = composer.compose(chained, templates / "api.md")
+    assert [layer for layer, _ in composition.files] == ["base:engineer"]
+    assert "Read before you edit." in composition.content
+
+    bases = project / ".claude-mpm" / "agent-bases"
+    (bases / "a.md").write_text("---\nextends: b\n---\n\nA\n")
+    (bases / "b.md").write_text("---\nextends: a\n---\n\nB\n")
+    with pytest.raises(ValueError, match="Cycle"):
+        composer.compose("---\nextends: a\n---\n\nX\n")
+    with pytest.raises(ValueError, match="Mixin 'nope' not found"):
+        composer.compose("---\nmixins: [nope]\n---\n\nX\n")
+
+    broken = project / ".claude-mpm" / "agents" / "broken.md"
+    broken.write_text("---\nname: broken\nextends: missing\n---\n\nX\n")
+    result = _deploy(project, "broken")
+    assert not result.success
+    assert "Base 'missing' not found" in result.error
+
+
+def test_mixin_edit_makes_agents_stale(project, tmp_path):
+    _deploy(project, "python-engineer")
+    reloader = AgentReloader(project, tmp_path / "home")
+    assert not reloader.is_stale("python-engineer")
+
+    mixin = project / ".claude-mpm" / "agent-mixins" / "testing.md"
+    mixin.write_text(TESTING.replace("Run the tests", "Run tox"))
+    later = time.time() + 5
+    os.utime(mixin, (later, later))
+    assert reloader.is_stale("python-engineer")
+    assert reloader.reload_changed()["reloaded"] == ["python-engineer"]
+    deployed = project / ".claude" / "agents" / "python-engineer.md"
+    assert "Run tox before reporting back." in deployed.read_text()
@@ -0,0 +1,119 @@
+"""Tests for hot-reloading edited agents.
+
+COVERAGE:
+- An agent is redeployed once after its template or an override changes,
+  and not again until the next change; watcher events are debounced
+- A delegation to an agent edited during the session is redeployed and
+  carries the current instructions, with a notice for the PM and the model
+  tier still injected
+- Sessions that started after the reload, and other tools, are untouched
+- ``agents watch`` reports why it exits when no agents are deployed
+"""
+
+import json
+import os
+import time
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.cli.commands.agents import AgentsCommand
+from claude_mpm.hooks import pretooluse_dispatcher
+from claude_mpm.services.agents.agent_hot_reload import AgentReloader
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+
+TEMPLATE = "---\nname: qa\ndescription: Runs the tests\nmodel: haiku\n---\n\n{}\n"
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.delenv("CLAUDE_MPM_WORKSPACE_RESTRICTED", raising=False)
+    monkeypatch.delenv("CLAUDE_MPM_CHAOS", raising=False)
+    project = tmp_path / "project"
+    (project / ".git").mkdir(parents=True)
+    template = project / ".claude-mpm" / "agents" / "qa.md"
+    template.parent.mkdir(parents=True)
+    template.write_text(TEMPLATE.format("Run pytest."))
+    deploy_agent_file(template, project / ".claude" / "agents")
+    return project
+
+
+def _edit(path, content):
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(content)
+    later = time.time() + 5
+    os.utime(path, (later, later))
+
+
+def test_reloads_changed_agents_once(project, tmp_path):
+    reloader = AgentReloader(project, tmp_path / "home", debounce=0.5)
+    deployed = project / ".claude" / "agents" / "qa.md"
+    assert reloader.reload_changed()["reloaded"] == []
+
+    _edit(project / ".claude-mpm" / "agents" / "qa.md", TEMPLATE.format("Run tox."))
+    assert reloader.is_stale("qa")
+    assert reloader.reload_changed()["reloaded"] == ["qa"]
+    assert "Run tox." in deployed.read_text()
+    assert reloader.reloaded_at("qa") is not None
+    assert not reloader.is_stale("qa")
+
+    override = project / ".claude-mpm" / "agent-overrides" / "qa.md"
+    _edit(override, "## Style\n\nBe brief.\n")
+    assert reloader.notify(override, 10.0)
+    assert not reloader.notify(project / "notes.txt", 10.0)
+    assert reloader.poll(now=10.2) is None
+    assert reloader.poll(now=10.6)["reloaded"] == ["qa"]
+    assert "Be brief." in deployed.read_text()
+
+
+def _delegate(project, transcript=None):
+    event = {
+        "hook_event_name": "PreToolUse",
+        "tool_name": "Agent",
+        "tool_input": {"subagent_type": "qa", "prompt": "Test the parser"},
+        "cwd": str(project),
+    }
+    if transcript is not None:
+        event["transcript_path"] = str(transcript)
+    return pretooluse_dispatcher.dispatch(event).get("hookSpecificOutput") or {}
+
+
+def test_delegation_gets_current_instructions(project, tmp_path):
+    transcript = tmp_path / "session.jsonl"
+    transcript.write_text(json.dumps({"timestamp": "2020-01-01T00:00:00Z"}) + "\n")
+    assert "Run pytest." not in _delegate(project, transcript)["updatedInput"]["prompt"]
+
+    _edit(project / ".claude-mpm" / "agents" / "qa.md", TEMPLATE.format("Run tox."))
+    output = _delegate(project, transcript)
+
+    prompt = output["updatedInput"]["prompt"]
+    assert "<agent-instructions>\nRun tox.\n</agent-instructions>" in prompt
+    assert prompt.endswith("Test the parser")
+    assert output["updatedInput"]["model"] == "haiku"
+    assert output["additionalContext"].startswith("Agent 'qa' was reloaded at")
+
+
+def test_sessions_started_after_reload_untouched(project, tmp_path):
+    _edit(project / ".claude-mpm" / "agents" / "qa.md", TEMPLATE.format("Run tox."))
+    AgentReloader(project, tmp_path / "home").reload_changed()
+
+    transcript = tmp_path / "session.jsonl"
+    transcript.write_text(json.dumps({"timestamp": "2999-01-01T00:00:00Z"}) + "\n")
+    output = _delegate(project, transcript)
+    assert output["updatedInput"]["prompt"] == "Test the parser"
+    assert "reloaded" not in output["additionalContext"]
+
+    read = pretooluse_dispatcher.dispatch(
+        {"tool_name": "Read", "tool_input": {"file_path": "x"}, "cwd": str(project)}
+    )
+    assert "updatedInput" not in (read.get("hookSpecificOutput") or {})
+
+
+def test_watch_without_deployed_agents_prints_error(tmp_path, monkeypatch, capsys):
+    monkeypatch.chdir(tmp_path)
+
+    result = AgentsCommand()._watch_agents(Namespace())
+
+    assert not result.success
+    assert "No agents deployed" in capsys.readouterr().out
@@ -0,0 +1,139 @@
+"""Tests for partial agent overrides.
+
+COVERAGE:
+- Frontmatter deep-merges (null removes a key, lists replace); instructions
+  merge by section, headings in code fences are not sections
+- Deploying applies user then project overrides; agents diff expects them
+- agents explain names the layer of each field and section
+"""
+
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.cli.commands.agents import AgentsCommand
+from claude_mpm.cli.commands.agents_explain import AgentExplainHandler
+from claude_mpm.services.agents.agent_overrides import (
+    PREAMBLE,
+    Layer,
+    merge_layers,
+    parse_agent,
+)
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+from claude_mpm.services.deployment_diff import agent_diff
+
+TEMPLATE = """---
+name: engineer
+description: Writes code
+tools: [Read, Write]
+settings:
+  temperature: 0.2
+  color: blue
+---
+
+You are an engineer.
+
+## Rules
+
+Be careful.
+
+```md
+## Not a section
+```
+
+## Style
+
+Use black.
+"""
+
+
+def layer(name: str, text: str) -> Layer:
+    frontmatter, _, body = parse_agent(text)
+    return Layer(name, None, frontmatter, body)
+
+
+@pytest.fixture
+def dirs(tmp_path, monkeypatch):
+    project = tmp_path / "project"
+    home = tmp_path / "home"
+    source = home / ".claude-mpm" / "cache" / "agents" / "engineer.md"
+    source.parent.mkdir(parents=True)
+    source.write_text(TEMPLATE)
+    (project / ".claude" / "agents").mkdir(parents=True)
+    for root in (home, project):
+        (root / ".claude-mpm" / "agent-overrides").mkdir(parents=True)
+    monkeypatch.setenv("HOME", str(home))
+    monkeypatch.chdir(project)
+    return project, home, source
+
+
+def write_override(root, text):
+    (root / ".claude-mpm" / "agent-overrides" / "engineer.md").write_text(text)
+
+
+def test_merge_layers():
+    merged = merge_layers(
+        [
+            layer("system", TEMPLATE),
+            layer(
+                "project",
+                "---\ntools: [Read]\ndescription: null\nsettings:\n"
+                "  color: red\n---\n\n## Style\n\nUse ruff.\n\n## Rules\n\n"
+                "## Testing\n\nRun pytest.\n",
+            ),
+        ]
+    )
+
+    assert merged.frontmatter == {
+        "name": "engineer",
+        "tools": ["Read"],
+        "settings": {"temperature": 0.2, "color": "red"},
+    }
+    assert [h for h, _ in merged.sections] == [PREAMBLE, "## Style", "## Testing"]
+    assert "Not a section" not in merged.render()
+    assert "Use ruff." in merged.render()
+    assert merged.origins["settings.temperature"] == "system"
+    assert merged.origins["settings.color"] == "project"
+    assert "description" not in merged.origins
+    assert merged.origins["## Testing"] == "project"
+
+    # Without frontmatter changes the template's text is kept as is
+    unchanged = merge_layers([layer("system", TEMPLATE), layer("user", "Hi.\n")])
+    assert unchanged.render().startswith("---\nname: engineer\n")
+    assert "You are an engineer." not in unchanged.render()
+
+
+def test_deploy_applies_overrides(dirs):
+    project, home, source = dirs
+    deployed = project / ".claude" / "agents" / "engineer.md"
+    write_override(home, "## Style\n\nUse ruff.\n")
+    write_override(project, "---\ntools: [Read]\n---\n\n## Style\n\nUse black 24.\n")
+
+    deploy_agent_file(source, deployed.parent)
+
+    content = deployed.read_text()
+    assert "Use black 24." in content
+    assert "Use ruff." not in content
+    assert "tools:\n- Read\n" in content
+    assert "Be careful." in content
+    assert not agent_diff("engineer", project, home).modified
+
+    write_override(project, "---\ntools: [Read, Bash]\n---\n")
+    assert agent_diff("engineer", project, home).modified
+
+
+def test_agents_explain(dirs, capsys):
+    project, home, _ = dirs
+    write_override(home, "## Style\n\nUse ruff.\n")
+    write_override(project, "---\nsettings:\n  color: red\n---\n")
+    handler = AgentExplainHandler(AgentsCommand())
+
+    result = handler.explain_agent(Namespace(agent_name="engineer"))
+
+    assert result.success
+    assert result.data["fields"]["settings.color"] == "project"
+    assert result.data["fields"]["tools"] == "system"
+    sections = {s["heading"]: s["layer"] for s in result.data["sections"]}
+    assert sections == {PREAMBLE: "system", "## Rules": "system", "## Style": "user"}
+    assert "settings.color" in capsys.readouterr().out
+    assert not handler.explain_agent(Namespace(agent_name="nope")).success
@@ -0,0 +1,119 @@
+"""Tests for pinning deployed agents in agents.lock.
+
+COVERAGE:
+- Without a lock, deployments pick up new templates as before
+- With a lock, an unpinned agent is pinned on deploy, and a changed template
+  deploys the pinned copy instead; the change is listed as an update
+- pin_deployed pins deployed agents that have a cached template only
+- agents update --check lists updates; agents update re-pins and redeploys,
+  optionally only the named agents
+"""
+
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.cli.commands.agents import AgentsCommand
+from claude_mpm.cli.commands.agents_update import AgentUpdateHandler
+from claude_mpm.services.agents.agents_lock import AgentsLock
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+
+
+def template(version: str, body: str) -> str:
+    return f"---\nname: engineer\nversion: {version}\n---\n\n{body}\n"
+
+
+@pytest.fixture
+def dirs(tmp_path, monkeypatch):
+    project = tmp_path / "project"
+    home = tmp_path / "home"
+    cache = home / ".claude-mpm" / "cache" / "agents" / "repo" / "agents"
+    cache.mkdir(parents=True)
+    (project / ".claude" / "agents").mkdir(parents=True)
+    monkeypatch.setenv("HOME", str(home))
+    monkeypatch.chdir(project)
+    return project, cache
+
+
+def test_deploy_respects_pins(dirs):
+    project, cache = dirs
+    source = cache / "engineer.md"
+    deployed = project / ".claude" / "agents" / "engineer.md"
+
+    source.write_text(template("1.0.0", "Write code."))
+    deploy_agent_file(source, deployed.parent)
+    source.write_text(template("1.1.0", "Write better code."))
+    deploy_agent_file(source, deployed.parent)
+    assert "Write better code." in deployed.read_text()
+    assert not AgentsLock(project).exists()
+
+    lock = AgentsLock(project)
+    assert lock.pin_deployed(deployed.parent, cache.parent.parent) == ["engineer"]
+    source.write_text(template("2.0.0", "Rewrite everything."))
+    result = deploy_agent_file(source, deployed.parent)
+
+    assert result.action == "skipped"
+    assert "Write better code." in deployed.read_text()
+    assert lock.get("engineer").version == "1.1.0"
+    assert lock.get("engineer").source == "repo/agents/engineer.md"
+    [update] = lock.updates(cache.parent.parent)
+    assert update.describe() == "engineer: 1.1.0 -> 2.0.0"
+
+    # A teammate without the deployed file gets the pinned copy too
+    deployed.unlink()
+    deploy_agent_file(source, deployed.parent)
+    assert "Write better code." in deployed.read_text()
+
+    # New agents are pinned on their first deploy
+    (cache / "qa.md").write_text("---\nname: qa\n---\n\nTest it.\n")
+    deploy_agent_file(cache / "qa.md", deployed.parent)
+    assert lock.get("qa").version == ""
+    assert lock.pinned_file("qa").read_text() == "---\nname: qa\n---\n\nTest it.\n"
+
+
+def test_pin_deployed_skips_local_agents(dirs):
+    project, cache = dirs
+    agents = project / ".claude" / "agents"
+    (agents / "my-helper.md").write_text("---\nname: my-helper\n---\n")
+    (agents / "engineer.md").write_text(template("1.0.0", "Write code."))
+    (cache / "engineer.md").write_text(template("1.0.0", "Write code."))
+
+    lock = AgentsLock(project)
+    assert lock.pin_deployed(agents, cache) == ["engineer"]
+    assert lock.pin_deployed(agents, cache) == []
+    assert set(lock.entries()) == {"engineer"}
+
+
+def test_agents_update(dirs, monkeypatch, capsys):
+    project, cache = dirs
+    agents = project / ".claude" / "agents"
+    for name in ("engineer", "qa"):
+        (cache / f"{name}.md").write_text(template("1.0.0", f"{name} v1"))
+    lock = AgentsLock(project)
+    for name in ("engineer", "qa"):
+        lock.pin(name, cache / f"{name}.md")
+        deploy_agent_file(cache / f"{name}.md", agents)
+    for name in ("engineer", "qa"):
+        (cache / f"{name}.md").write_text(template("1.2.0", f"{name} v2"))
+
+    synced = []
+    monkeypatch.setattr(
+        AgentUpdateHandler, "_sync_cache", lambda self: synced.append(True)
+    )
+    handler = AgentUpdateHandler(AgentsCommand())
+
+    result = handler.update_agents(Namespace(agent_names=[], check=True))
+    assert [u["agent"] for u in result.data["updates"]] == ["engineer", "qa"]
+    assert "engineer: 1.0.0 -> 1.2.0" in capsys.readouterr().out
+    assert "engineer v1" in (agents / "engineer.md").read_text()
+
+    result = handler.update_agents(Namespace(agent_names=["qa"], check=False))
+    assert result.success
+    assert "qa v2" in (agents / "qa.md").read_text()
+    assert "engineer v1" in (agents / "engineer.md").read_text()
+    assert lock.get("qa").version == "1.2.0"
+    assert [u.name for u in lock.updates(cache)] == ["engineer"]
+    assert len(synced) == 2
+
+    result = handler.update_agents(Namespace(agent_names=["ops"], check=False))
+    assert not result.success
@@ -122,7 +122,15 @@ class TestCharacterizationDeployAgentsToProject:
         with patch.object(svc, "_discover_cached_agents", return_value=[]):
             result = svc.deploy_agents_to_project(project_dir, agent_list=None)
 
-        expected_keys = {"deployed", "updated", "skipped", "failed", "deployment_dir"}
+        expected_keys = {
+            "deployed",
+            "updated",
+            "skipped",
+            "failed",
+            "conflicts",
+            "errors",
+            "deployment_dir",
+        }
         assert expected_keys == set(result.keys())
 
         # All list values should be lists
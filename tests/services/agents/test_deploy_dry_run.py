@@ -0,0 +1,84 @@
+"""Tests for rendering agents without deploying them (agents deploy --dry-run).
+
+COVERAGE:
+- Rendered content is exactly what a deploy then writes, and rendering
+  writes nothing to .claude/agents
+- Excluded agents are left out and agents that fail to render are reported
+- ``--stage-dir`` writes the rendered agents there, never to the deployment
+  directory
+"""
+
+from argparse import Namespace
+
+from claude_mpm.cli.commands.agents import AgentsCommand
+from claude_mpm.cli.commands.agents_deploy import AgentDeployHandler
+from claude_mpm.core.config import Config
+from claude_mpm.services.agents.sources.git_source_sync_service import (
+    GitSourceSyncService,
+)
+
+ENGINEER = "---\nname: engineer\ndescription: Writes code\n---\n\nEngineer body\n"
+
+
+def _cache(home, **agents):
+    cache_dir = home / ".claude-mpm" / "cache" / "agents"
+    for name, text in agents.items():
+        path = cache_dir / "repo" / "agents" / f"{name}.md"
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(text)
+    return cache_dir
+
+
+def test_render_matches_deploy(tmp_path, monkeypatch):
+    home, project = tmp_path / "home", tmp_path / "project"
+    monkeypatch.setenv("HOME", str(home))
+    project.mkdir()
+    service = GitSourceSyncService(cache_dir=_cache(home, engineer=ENGINEER))
+
+    result = service.render_agents_for_project(project)
+
+    assert [agent.filename for agent in result["rendered"]] == ["engineer.md"]
+    assert not (project / ".claude" / "agents").exists()
+
+    service.deploy_agents_to_project(project)
+    deployed = project / ".claude" / "agents" / "engineer.md"
+    assert deployed.read_text() == result["rendered"][0].content
+
+
+def test_render_exclusions_and_failures(tmp_path, monkeypatch):
+    home, project = tmp_path / "home", tmp_path / "project"
+    monkeypatch.setenv("HOME", str(home))
+    (project / ".claude-mpm").mkdir(parents=True)
+    (project / ".claude-mpm" / "configuration.yaml").write_text(
+        "excluded_agents: [qa]\n"
+    )
+    cache_dir = _cache(home, engineer=ENGINEER, qa=ENGINEER, broken="  \n")
+    service = GitSourceSyncService(cache_dir=cache_dir)
+    Config.reset_singleton()  # Load the project configuration written above
+
+    result = service.render_agents_for_project(project)
+
+    assert [agent.filename for agent in result["rendered"]] == ["engineer.md"]
+    assert result["failed"] == ["broken.md"]
+    assert "empty" in result["errors"]["broken.md"]
+
+
+def test_stage_dir(tmp_path, monkeypatch, capsys):
+    home, project = tmp_path / "home", tmp_path / "project"
+    monkeypatch.setenv("HOME", str(home))
+    project.mkdir()
+    _cache(home, engineer=ENGINEER)
+    handler = AgentDeployHandler(AgentsCommand())
+
+    stage = tmp_path / "stage"
+    result = handler.render_agents(Namespace(stage_dir=stage, format="text"), project)
+
+    assert result.success
+    assert "Engineer body" in (stage / "engineer.md").read_text()
+    assert not (project / ".claude" / "agents").exists()
+    assert str(stage) in capsys.readouterr().out
+
+    live = project / ".claude" / "agents"
+    result = handler.render_agents(Namespace(stage_dir=live, format="text"), project)
+    assert not result.success
+    assert not live.exists()
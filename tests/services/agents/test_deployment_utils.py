@@ -5,6 +5,7 @@ to ensure consistent agent naming across deployment services.
 (Issue #299 Phase 2 and Phase 3)
 """
 
+import os
 import tempfile
 from pathlib import Path
 
@@ -364,8 +365,8 @@ class TestDeployAgentFile:
         assert result2.action == "skipped"
         assert result2.success is True
 
-    def test_deploy_force_updates(self, tmp_path):
-        """Test that force=True always deploys."""
+    def test_deploy_force_does_not_rewrite_identical_content(self, tmp_path):
+        """Test that force=True only writes when the content differs."""
         source_dir = tmp_path / "source"
         source_dir.mkdir()
         deploy_dir = tmp_path / "deploy"
@@ -377,10 +378,19 @@ class TestDeployAgentFile:
 
         # First deployment
         deploy_agent_file(source_file, deploy_dir)
+        deployed = deploy_dir / "engineer.md"
+        os.utime(deployed, (0, 0))
 
-        # Force deployment
+        # Force deployment of identical content leaves the file alone
+        result = deploy_agent_file(source_file, deploy_dir, force=True)
+        assert result.action == "skipped"
+        assert deployed.stat().st_mtime == 0
+
+        # A real change is written
+        source_file.write_text(content + "\nNew rule.")
         result = deploy_agent_file(source_file, deploy_dir, force=True)
         assert result.action == "updated"
+        assert "New rule." in deployed.read_text()
 
     def test_deploy_nonexistent_source(self, tmp_path):
         """Test deployment of non-existent source file."""
@@ -0,0 +1,159 @@
+"""Tests for agent sources on GitLab, Bitbucket and SSH.
+
+COVERAGE:
+- GitLab, Bitbucket and SSH URLs validate, identify their repository and
+  persist token, ssh_key and provider in agent_sources.yaml
+- Hosted repositories sync the agent files under their subdirectory from an
+  archive, leave a cache at the same commit alone and drop removed agents
+- GitSourceManager reports the synced commit of hosted repositories and
+  passes a GitHub repository's own token to the raw-file sync
+"""
+
+import io
+import tarfile
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.config.agent_sources import AgentSourceConfiguration
+from claude_mpm.models.git_repository import GitRepository
+from claude_mpm.services.agents import git_source_manager
+from claude_mpm.services.agents.sources import hosted_agent_sync
+
+AGENT = "---\nname: {0}\ndescription: {0} agent\n---\n\n# {0}\n"
+
+
+@pytest.fixture(autouse=True)
+def home(tmp_path, monkeypatch):
+    monkeypatch.setattr(Path, "home", lambda: tmp_path)
+    monkeypatch.setenv("HOME", str(tmp_path))
+    return tmp_path
+
+
+class FakeHost:
+    """Serves archives of a repository whose files change between commits."""
+
+    name = "gitlab"
+
+    def __init__(self):
+        self.commit = "a" * 40
+        self.files: dict[str, str] = {}
+        self.downloads = 0
+
+    def resolve_branch(self, branch):
+        return self.commit
+
+    def download_archive(self, ref, dest):
+        self.downloads += 1
+        buffer = io.BytesIO()
+        with tarfile.open(fileobj=buffer, mode="w:gz") as tar:
+            for name, text in self.files.items():
+                data = text.encode()
+                info = tarfile.TarInfo(f"agents-{ref[:8]}/{name}")
+                info.size = len(data)
+                tar.addfile(info, io.BytesIO(data))
+        dest.write_bytes(buffer.getvalue())
+
+
+@pytest.fixture
+def host(monkeypatch):
+    host = FakeHost()
+    monkeypatch.setattr(hosted_agent_sync, "get_host", lambda repo: host)
+    return host
+
+
+def test_hosted_urls_validate_and_persist(home):
+    gitlab = GitRepository(
+        url="https://gitlab.example.com/org/platform/agents.git",
+        subdirectory="agents",
+        token="$TEAM_TOKEN",
+    )
+    assert gitlab.validate() == []
+    assert gitlab.hosting == "gitlab"
+    assert gitlab.identifier == "org/platform/agents/main/agents"
+
+    ssh = GitRepository(url="git@github.com:org/agents.git", ssh_key="~/.ssh/k")
+    assert ssh.validate() == []
+    assert ssh.is_ssh and ssh.hosting is None
+    assert ssh.identifier == "org/agents/main"
+    assert ssh.ssh_key_path == home / ".ssh" / "k"
+
+    assert GitRepository(url="https://bitbucket.org/ws/agents").validate() == []
+    errors = GitRepository(url="https://git.example.com/team/agents").validate()
+    assert any("provider" in e for e in errors)
+    custom = GitRepository(url="https://git.example.com/team/a", provider="gitlab")
+    assert custom.validate() == []
+    errors = GitRepository(url="https://github.com/o/r", ssh_key="~/k").validate()
+    assert any("ssh_key requires an SSH URL" in e for e in errors)
+
+    path = home / "agent_sources.yaml"
+    AgentSourceConfiguration(repositories=[gitlab, ssh, custom]).save(path)
+    loaded = AgentSourceConfiguration.load(path).repositories
+    assert [(r.token, r.ssh_key, r.provider) for r in loaded] == [
+        ("$TEAM_TOKEN", None, None),
+        (None, "~/.ssh/k", None),
+        (None, None, "gitlab"),
+    ]
+
+
+def test_archive_sync_of_agents_subdirectory(host):
+    repo = GitRepository(url="https://gitlab.com/org/agents", subdirectory="agents")
+    host.files = {
+        "agents/engineer.md": AGENT.format("engineer"),
+        "agents/ops/deploy.md": AGENT.format("deploy"),
+        "agents/README.md": "Read me",
+        "agents/.hidden.md": "x",
+        "agents/dist/engineer.md": "built",
+        "docs/guide.md": "not an agent",
+    }
+
+    updated, cached, commit = hosted_agent_sync.sync_hosted_repository(repo)
+    files = sorted(
+        p.relative_to(repo.cache_path).as_posix()
+        for p in repo.cache_path.rglob("*")
+        if p.is_file()
+    )
+    assert files == ["engineer.md", "ops/deploy.md"]
+    assert (updated, cached, commit) == (2, 0, host.commit)
+
+    assert hosted_agent_sync.sync_hosted_repository(repo) == (0, 2, host.commit)
+    assert host.downloads == 1
+
+    host.commit = "b" * 40
+    del host.files["agents/ops/deploy.md"]
+    hosted_agent_sync.sync_hosted_repository(repo)
+    assert not (repo.cache_path / "ops" / "deploy.md").exists()
+    assert hosted_agent_sync.commit_marker(repo).read_text().strip() == host.commit
+
+
+def test_manager_syncs_hosted_and_github_repositories(host, home, monkeypatch):
+    host.files = {"engineer/engineer.md": AGENT.format("engineer")}
+    manager = git_source_manager.GitSourceManager(home / "cache")
+
+    result = manager.sync_repository(
+        GitRepository(url="https://bitbucket.org/ws/agents"), show_progress=False
+    )
+    assert result["synced"], result
+    assert result["commit"] == host.commit
+    assert result["agents_discovered"] == ["engineer"]
+
+    seen = {}
+
+    class FakeSyncService:
+        def __init__(self, source_url, cache_dir, source_id, token=None):
+            seen.update(source_url=source_url, token=token)
+
+        def sync_agents(self, force_refresh=False, show_progress=True):
+            return {"total_downloaded": 0, "cache_hits": 0, "synced": []}
+
+    monkeypatch.setattr(git_source_manager, "GitSourceSyncService", FakeSyncService)
+    monkeypatch.setenv("TEAM_TOKEN", "secret")
+    result = manager.sync_repository(
+        GitRepository(url="https://github.com/org/agents", token="$TEAM_TOKEN"),
+        show_progress=False,
+    )
+    assert result["synced"], result
+    assert seen == {
+        "source_url": "https://raw.githubusercontent.com/org/agents/main",
+        "token": "secret",
+    }
@@ -0,0 +1,146 @@
+"""
+Tests for the agent prompt playground.
+
+COVERAGE:
+- Agent source lookup priority (project template over bundled)
+- Drafts bump revisions, diff against the template and reset
+- Single-turn runs receive the effective prompt and are compared per revision
+- Accepting writes the project template with the original frontmatter
+- The REPL wires prompts and commands to the playground
+"""
+
+import argparse
+
+import pytest
+
+from claude_mpm.cli.commands.playground import PlaygroundCommand
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.agents.playground import (
+    BUNDLED,
+    DEPLOYED,
+    PROJECT_TEMPLATE,
+    AgentSource,
+    PromptPlayground,
+    locate_agent_source,
+    split_frontmatter,
+)
+
+AGENT_MD = "---\nname: helper\nversion: 1.0.0\n---\n\nYou are a helpful agent.\n"
+
+
+class EchoResponder:
+    """Replies with the system prompt it was given."""
+
+    def __init__(self):
+        self.configs = []
+
+    async def run(self, prompt, config=None):
+        self.configs.append(config)
+        return AgentResult(text=f"{prompt} | {config.system_prompt}", duration_ms=1)
+
+
+@pytest.fixture
+def project(tmp_path):
+    agents = tmp_path / ".claude" / "agents"
+    agents.mkdir(parents=True)
+    (agents / "helper.md").write_text(AGENT_MD)
+    return tmp_path
+
+
+@pytest.fixture
+def playground(project):
+    source = locate_agent_source("helper", project, project / "home")
+    return PromptPlayground(source, EchoResponder())
+
+
+def test_split_frontmatter():
+    frontmatter, body = split_frontmatter(AGENT_MD)
+
+    assert frontmatter == "---\nname: helper\nversion: 1.0.0\n---\n"
+    assert body == "You are a helpful agent."
+    assert split_frontmatter("no frontmatter") == ("", "no frontmatter")
+
+
+def test_locate_prefers_project_template(project):
+    assert locate_agent_source("helper", project, project / "home").kind == DEPLOYED
+
+    template = project / ".claude-mpm" / "agents" / "helper.md"
+    template.parent.mkdir(parents=True)
+    template.write_text(AGENT_MD)
+    assert locate_agent_source("helper", project, project / "home").kind == (
+        PROJECT_TEMPLATE
+    )
+
+    assert locate_agent_source("ticketing", project, project / "home").kind == BUNDLED
+    assert locate_agent_source("missing", project, project / "home") is None
+
+
+def test_draft_revisions_diff_and_reset(playground):
+    assert not playground.set_draft("You are a helpful agent.")
+    assert playground.set_draft("You are a terse agent.")
+    assert playground.revision == 1
+    assert "-You are a helpful agent." in playground.diff()
+    assert "+You are a terse agent." in playground.diff()
+
+    assert playground.reset()
+    assert playground.revision == 2
+    assert not playground.dirty
+    assert playground.diff() == ""
+
+
+@pytest.mark.asyncio
+async def test_turns_use_effective_prompt_and_compare_across_revisions(playground):
+    first = await playground.ask("hi")
+    playground.set_draft("You are a terse agent.")
+    await playground.ask("other")
+    second = await playground.ask("hi")
+
+    assert first.reply == "hi | You are a helpful agent."
+    assert playground.responder.configs[-1].max_turns == 1
+    assert [t.revision for t in playground.compare("hi")] == [0, 1]
+    assert playground.compare()[-1] is second
+
+
+def test_accept_writes_project_template(project, playground):
+    playground.set_draft("You are a terse agent.")
+
+    path = playground.accept(project)
+
+    assert path == project / ".claude-mpm" / "agents" / "helper.md"
+    assert path.read_text() == (
+        "---\nname: helper\nversion: 1.0.0\n---\n\nYou are a terse agent.\n"
+    )
+    assert playground.source.kind == PROJECT_TEMPLATE
+    assert not playground.dirty
+    # The deployed copy is left for the next deploy to replace
+    assert "helpful" in (project / ".claude" / "agents" / "helper.md").read_text()
+
+
+def test_repl_runs_prompts_and_accepts(project, monkeypatch, capsys):
+    monkeypatch.chdir(project)
+    monkeypatch.setattr(
+        "claude_mpm.cli.commands.playground.locate_agent_source",
+        lambda name: AgentSource.load(
+            name, project / ".claude" / "agents" / "helper.md", DEPLOYED
+        ),
+    )
+    lines = iter(["hello", ":edit", "hello", ":compare", ":accept", "y", ":quit"])
+    command = PlaygroundCommand(
+        input_func=lambda _prompt: next(lines),
+        edit_func=lambda text: text.replace("helpful", "terse"),
+    )
+    command_args = argparse.Namespace(agent="helper", model=None, replay=None)
+    monkeypatch.setattr(
+        "claude_mpm.services.agents.cli_runtime.CLIAgentRunner",
+        lambda cwd=None: EchoResponder(),
+    )
+
+    result = command.run(command_args)
+
+    out = capsys.readouterr().out
+    assert result.success
+    assert "hello | You are a helpful agent." in out
+    assert "hello | You are a terse agent." in out
+    assert "reply diff r0 → r1" in out
+    assert "terse" in (project / ".claude-mpm" / "agents" / "helper.md").read_text()
+    assert result.data["revision"] == 1
@@ -0,0 +1,112 @@
+"""Tests for per-agent tool permissions.
+
+COVERAGE:
+- Deploying narrows the agent's tools to its permissions (network off and
+  an empty writable paths list remove those tools); an invalid permissions
+  block fails the deployment
+- Bash allowlists check every command of a compound command and reject
+  command substitution; network commands and writes outside the writable
+  paths are refused
+- The PreToolUse dispatcher denies a subagent's disallowed calls, and lets
+  the main session and agents without permissions through
+"""
+
+import pytest
+
+from claude_mpm.hooks import pretooluse_dispatcher
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+from claude_mpm.services.agents.tool_permissions import ToolPermissions
+
+AGENT = """---
+name: qa
+description: Runs the tests
+tools: Read,Grep,Edit,Write,Bash,WebFetch
+permissions:
+  bash: ["pytest*", "git diff*"]
+  write_paths: ["tests/**"]
+  network: false
+---
+
+You are QA.
+"""
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.delenv("CLAUDE_MPM_WORKSPACE_RESTRICTED", raising=False)
+    monkeypatch.delenv("CLAUDE_MPM_CHAOS", raising=False)
+    project = tmp_path / "project"
+    (project / ".git").mkdir(parents=True)
+    return project
+
+
+def _deploy(project, content, name="qa"):
+    source = project / "templates" / f"{name}.md"
+    source.parent.mkdir(exist_ok=True)
+    source.write_text(content)
+    return deploy_agent_file(source, project / ".claude" / "agents")
+
+
+def test_deploy_narrows_tools(project):
+    assert _deploy(project, AGENT).success
+    deployed = (project / ".claude" / "agents" / "qa.md").read_text()
+    assert "tools: Read,Grep,Edit,Write,Bash\n" in deployed
+    assert "You are QA." in deployed
+
+    read_only = AGENT.replace('["tests/**"]', "[]")
+    assert _deploy(project, read_only).success
+    deployed = (project / ".claude" / "agents" / "qa.md").read_text()
+    assert "tools: Read,Grep,Bash\n" in deployed
+
+    invalid = AGENT.replace("network: false", "network: sometimes")
+    result = _deploy(project, invalid, name="broken")
+    assert not result.success
+    assert "permissions.network" in result.error
+
+
+def test_bash_write_and_network_checks(tmp_path):
+    permissions = ToolPermissions(
+        bash=["pytest*", "git diff*", "git push*"],
+        write_paths=["tests/**"],
+        network=False,
+    )
+
+    def check(tool, **tool_input):
+        return permissions.check(tool, tool_input, tmp_path)
+
+    assert check("Bash", command="pytest -q tests/ 2>&1 | pytest --version") is None
+    assert "'rm -rf build'" in check("Bash", command="pytest && rm -rf build")
+    assert "substitution" in check("Bash", command="pytest $(cat args)")
+    assert "network" in check("Bash", command="git push origin main")
+    assert check("WebFetch", url="https://example.com") == "WebFetch is not allowed"
+    assert check("Edit", file_path=str(tmp_path / "tests" / "test_x.py")) is None
+    assert "not in the writable paths" in check("Write", file_path="src/app.py")
+    assert "outside the project" in check("Write", file_path="/etc/passwd")
+    assert check("Read", file_path="/etc/passwd") is None
+
+
+def test_dispatcher_enforces_subagent_permissions(project):
+    _deploy(project, AGENT)
+    engineer = "---\nname: engineer\ndescription: Codes\n---\n\nCode.\n"
+    _deploy(project, engineer, name="engineer")
+
+    def decide(agent_type, tool_name, **tool_input):
+        event = {
+            "hook_event_name": "PreToolUse",
+            "tool_name": tool_name,
+            "tool_input": tool_input,
+            "cwd": str(project),
+        }
+        if agent_type:
+            event["agent_type"] = agent_type
+        output = pretooluse_dispatcher.dispatch(event).get("hookSpecificOutput") or {}
+        return output.get("permissionDecision"), output.get("permissionDecisionReason")
+
+    decision, reason = decide("qa", "Bash", command="curl https://example.com")
+    assert decision == "deny"
+    assert reason.startswith("Agent 'qa' permissions:")
+    assert decide("qa", "Write", file_path="src/app.py")[0] == "deny"
+    assert decide("qa", "Write", file_path="tests/test_app.py")[0] != "deny"
+    assert decide(None, "Bash", command="curl https://example.com")[0] != "deny"
+    assert decide("engineer", "Write", file_path="src/app.py")[0] != "deny"
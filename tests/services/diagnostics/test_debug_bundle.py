@@ -0,0 +1,113 @@
+"""Tests for the ``claude-mpm debug bundle`` archive generator."""
+
+import json
+import zipfile
+
+import pytest
+
+from claude_mpm.services.diagnostics.debug_bundle import (
+    REDACTED,
+    DebugBundleBuilder,
+    record_crash,
+    redact_config,
+)
+
+
+@pytest.fixture
+def dirs(tmp_path):
+    project_dir = tmp_path / "project" / ".claude-mpm"
+    user_dir = tmp_path / "user"
+    project_dir.mkdir(parents=True)
+    user_dir.mkdir()
+    return project_dir, user_dir
+
+
+def _read(archive_path):
+    with zipfile.ZipFile(archive_path) as archive:
+        return {name: archive.read(name).decode() for name in archive.namelist()}
+
+
+class TestRedaction:
+    def test_masks_secret_keys_recursively(self):
+        data = {
+            "github": {"token": "abc", "owner": "me"},
+            "sources": [{"api_key": "xyz", "url": "https://example.com"}],
+            "port": 8765,
+        }
+
+        redacted = redact_config(data)
+
+        assert redacted["github"] == {"token": REDACTED, "owner": "me"}
+        assert redacted["sources"][0]["api_key"] == REDACTED
+        assert redacted["sources"][0]["url"] == "https://example.com"
+        assert redacted["port"] == 8765
+
+
+class TestBundle:
+    def test_bundle_contains_all_sections(self, dirs, tmp_path):
+        project_dir, user_dir = dirs
+        (project_dir / "configuration.yaml").write_text(
+            "monitor:\n  port: 8765\nanthropic:\n  api_key: sk-live-123\n"
+        )
+        logs = project_dir / "logs"
+        logs.mkdir()
+        (logs / "mpm.log").write_text("".join(f"line {i}\n" for i in range(50)))
+        (project_dir / "event_log.json").write_text(
+            json.dumps([{"id": str(i)} for i in range(10)])
+        )
+        try:
+            raise RuntimeError("boom")
+        except RuntimeError as e:
+            record_crash(e, argv=["claude-mpm", "run"], user_dir=user_dir)
+
+        builder = DebugBundleBuilder(
+            project_dir=project_dir, user_dir=user_dir, max_log_lines=5, max_events=3
+        )
+        out_dir = tmp_path / "out"
+        out_dir.mkdir()
+        path, manifest = builder.build(out_dir)
+
+        assert path.parent == out_dir
+        files = _read(path)
+        assert "environment/environment.json" in files
+        assert "sk-live-123" not in files["config/project-configuration.yaml"]
+        assert files["logs/project-mpm.log"].splitlines() == [
+            f"line {i}" for i in range(45, 50)
+        ]
+        assert len(json.loads(files["events/event_log.json"])) == 3
+        crash = json.loads(files["crash/last_crash.json"])
+        assert crash["argv"] == ["claude-mpm", "run"]
+        assert "RuntimeError: boom" in crash["traceback"]
+        assert json.loads(files["manifest.json"])["files"] == manifest["files"]
+
+    def test_unreadable_section_is_skipped(self, dirs, tmp_path):
+        project_dir, user_dir = dirs
+        (project_dir / "event_log.json").write_text("{not json")
+
+        builder = DebugBundleBuilder(project_dir=project_dir, user_dir=user_dir)
+        path, manifest = builder.build(tmp_path / "bundle.zip")
+
+        assert path == tmp_path / "bundle.zip"
+        assert any(entry.startswith("events:") for entry in manifest["skipped"])
+        assert "environment/environment.json" in _read(path)
+
+    def test_data_key_is_redacted_and_events_decrypted(
+        self, dirs, tmp_path, monkeypatch
+    ):
+        from claude_mpm.services import data_encryption
+
+        project_dir, user_dir = dirs
+        key = "dGhpcy1pcy1ub3QtYS1yZWFsLWtleS1idXQtbG9uZw"
+        monkeypatch.setenv(data_encryption.KEY_ENV_VAR, key)
+        monkeypatch.setattr(
+            data_encryption, "reveal", lambda text: text.removeprefix("ENC:")
+        )
+        (project_dir / "event_log.json").write_text('ENC:[{"id": "1"}]')
+
+        builder = DebugBundleBuilder(project_dir=project_dir, user_dir=user_dir)
+        files = _read(builder.build(tmp_path / "bundle.zip")[0])
+
+        environment = json.loads(files["environment/environment.json"])
+        assert environment["environment"][data_encryption.KEY_ENV_VAR] == REDACTED
+        assert key not in files["environment/environment.json"]
+        assert json.loads(files["events/event_log.json"]) == [{"id": "1"}]
@@ -0,0 +1,143 @@
+"""
+Tests for the response caching proxy.
+
+COVERAGE:
+- Cache keys ignore volatile fields and key order
+- auto mode records a miss and serves the repeat from cache
+- replay mode never forwards and answers misses with a 404 API error
+- record mode always forwards
+- Request headers (API keys) are not written to the cache
+"""
+
+import json
+import threading
+import urllib.error
+import urllib.request
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+
+import pytest
+
+from claude_mpm.services.model.caching_proxy import (
+    CachingProxy,
+    ResponseCache,
+    cache_key,
+)
+
+REQUEST = {"model": "sonnet", "messages": [{"role": "user", "content": "hi"}]}
+
+
+@pytest.fixture
+def upstream():
+    calls = []
+
+    class Handler(BaseHTTPRequestHandler):
+        def do_POST(self):
+            body = self.rfile.read(int(self.headers["Content-Length"]))
+            calls.append((self.path, self.headers.get("x-api-key"), body))
+            reply = json.dumps({"content": [{"text": f"reply {len(calls)}"}]})
+            self.send_response(200)
+            self.send_header("Content-Type", "application/json")
+            self.send_header("Content-Length", str(len(reply)))
+            self.end_headers()
+            self.wfile.write(reply.encode())
+
+        def log_message(self, *args):
+            pass
+
+    server = ThreadingHTTPServer(("127.0.0.1", 0), Handler)
+    thread = threading.Thread(target=server.serve_forever, daemon=True)
+    thread.start()
+    yield f"http://127.0.0.1:{server.server_address[1]}", calls
+    server.shutdown()
+    server.server_close()
+
+
+@pytest.fixture
+def start_proxy(tmp_path, upstream):
+    proxies = []
+
+    def start(mode):
+        proxy = CachingProxy(
+            ResponseCache(tmp_path / "cache"), mode=mode, upstream=upstream[0], port=0
+        )
+        proxy.start()
+        proxies.append(proxy)
+        return proxy
+
+    yield start
+    for proxy in proxies:
+        proxy.stop()
+
+
+def _post(proxy, payload):
+    request = urllib.request.Request(
+        f"{proxy.base_url}/v1/messages",
+        data=json.dumps(payload).encode(),
+        headers={"Content-Type": "application/json", "x-api-key": "sk-secret"},
+        method="POST",
+    )
+    try:
+        response = urllib.request.urlopen(request, timeout=10)
+    except urllib.error.HTTPError as e:
+        response = e
+    with response:
+        body = json.loads(response.read())
+        return response.status, response.headers["X-Claude-MPM-Cache"], body
+
+
+def test_cache_key_ignores_metadata_and_key_order():
+    a = json.dumps({**REQUEST, "metadata": {"user_id": "1"}}).encode()
+    b = json.dumps(dict(reversed(list(REQUEST.items())))).encode()
+
+    assert cache_key("POST", "/v1/messages", a) == cache_key("POST", "/v1/messages", b)
+    assert cache_key("POST", "/v1/messages", a) != cache_key("POST", "/v1/other", a)
+
+
+def test_auto_mode_records_then_hits(start_proxy, upstream):
+    proxy = start_proxy("auto")
+
+    first = _post(proxy, REQUEST)
+    second = _post(proxy, {**REQUEST, "metadata": {"user_id": "other"}})
+
+    assert first == (200, "recorded", {"content": [{"text": "reply 1"}]})
+    assert second == (200, "hit", {"content": [{"text": "reply 1"}]})
+    assert len(upstream[1]) == 1
+    assert upstream[1][0][1] == "sk-secret"
+    assert proxy.stats.to_dict()["hits"] == 1
+
+
+def test_replay_mode_serves_cache_and_never_forwards(start_proxy, upstream):
+    _post(start_proxy("auto"), REQUEST)
+    replay = start_proxy("replay")
+
+    assert _post(replay, REQUEST)[:2] == (200, "hit")
+    status, outcome, body = _post(replay, {**REQUEST, "model": "opus"})
+    assert (status, outcome) == (404, "miss")
+    assert body["error"]["type"] == "not_found_error"
+    assert len(upstream[1]) == 1
+
+
+def test_record_mode_always_forwards(start_proxy, upstream):
+    proxy = start_proxy("record")
+
+    _post(proxy, REQUEST)
+    status, outcome, body = _post(proxy, REQUEST)
+
+    assert (status, outcome) == (200, "recorded")
+    assert body == {"content": [{"text": "reply 2"}]}
+    assert proxy.cache.stats()["entries"] == 1
+
+
+def test_cache_entries_do_not_store_api_keys(tmp_path, start_proxy):
+    proxy = start_proxy("auto")
+    _post(proxy, REQUEST)
+
+    [entry] = proxy.cache.entries()
+    assert "sk-secret" not in entry.read_text()
+    assert json.loads(entry.read_text())["request"]["model"] == "sonnet"
+    assert proxy.cache.clear() == 1
+
+
+def test_unknown_mode_is_rejected(tmp_path):
+    with pytest.raises(ValueError):
+        CachingProxy(ResponseCache(tmp_path), mode="bogus")
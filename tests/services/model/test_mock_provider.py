@@ -0,0 +1,179 @@
+"""
+Tests for the Mock (replay) Model Provider
+==========================================
+
+WHY: The mock provider is what makes hook, policy and dashboard tests
+deterministic, so its parsing and matching must be exact and stable.
+
+COVERAGE:
+- Claude Code transcript parsing into turns and tool calls
+- Fixture round-trip through save_recording/load_recording
+- Replay matching (exact, similar, sequential)
+- MockProvider responses with and without a recording
+- ModelRouter MOCK strategy
+"""
+
+import json
+
+import pytest
+
+from claude_mpm.services.core.interfaces.model import ModelCapability
+from claude_mpm.services.model.mock_provider import PLACEHOLDER_REPLY, MockProvider
+from claude_mpm.services.model.model_router import ModelRouter, RoutingStrategy
+from claude_mpm.services.model.recordings import (
+    RecordedTurn,
+    ReplayIndex,
+    load_recording,
+    save_recording,
+    turns_from_transcript,
+)
+from claude_mpm.services.selftest.mock_backend import MockModelBackend
+
+TRANSCRIPT = [
+    {"type": "user", "message": {"role": "user", "content": "list the files"}},
+    {
+        "type": "assistant",
+        "message": {
+            "content": [
+                {"type": "text", "text": "Listing files."},
+                {
+                    "type": "tool_use",
+                    "id": "tu_1",
+                    "name": "Bash",
+                    "input": {"command": "ls"},
+                },
+            ]
+        },
+    },
+    {
+        "type": "user",
+        "message": {
+            "content": [
+                {"type": "tool_result", "tool_use_id": "tu_1", "content": "a.py"}
+            ]
+        },
+    },
+    {
+        "type": "assistant",
+        "message": {"content": [{"type": "text", "text": "Found a.py."}]},
+    },
+    {
+        "type": "user",
+        "message": {"content": [{"type": "text", "text": "summarise a.py"}]},
+    },
+    {
+        "type": "assistant",
+        "message": {"content": [{"type": "text", "text": "It is empty."}]},
+    },
+]
+
+
+@pytest.fixture
+def transcript_file(tmp_path):
+    path = tmp_path / "session.jsonl"
+    path.write_text("\n".join(json.dumps(line) for line in TRANSCRIPT))
+    return path
+
+
+def test_transcript_parsing_groups_replies_and_tool_calls():
+    turns = turns_from_transcript(TRANSCRIPT)
+
+    assert [t.prompt for t in turns] == ["list the files", "summarise a.py"]
+    assert turns[0].reply == "Listing files.\nFound a.py."
+    assert turns[0].tool_calls[0].name == "Bash"
+    assert turns[0].tool_calls[0].input == {"command": "ls"}
+    assert turns[0].tool_calls[0].result == "a.py"
+    assert turns[1].reply == "It is empty."
+
+
+def test_fixture_round_trip(tmp_path, transcript_file):
+    turns = load_recording(transcript_file)
+    fixture = save_recording(turns, tmp_path / "fixtures" / "session.json")
+
+    assert load_recording(fixture) == turns
+
+
+def test_replay_index_matching():
+    index = ReplayIndex(
+        [
+            RecordedTurn(prompt="list the files", reply="one"),
+            RecordedTurn(prompt="summarise a.py", reply="two"),
+        ]
+    )
+
+    assert index.match("summarise a.py") == (index.turns[1], "exact")
+    assert index.match("please summarise") == (index.turns[1], "similar")
+    assert index.match("???")[1] == "sequential"
+    assert index.match("???")[0] is index.turns[1]
+
+
+def test_replay_index_rejects_empty_recording():
+    with pytest.raises(ValueError):
+        ReplayIndex([])
+
+
+@pytest.mark.asyncio
+async def test_provider_replays_recording(transcript_file):
+    provider = MockProvider(config={"recording": str(transcript_file)})
+
+    response = await provider.analyze_content(
+        "list the files", ModelCapability.SUMMARIZATION
+    )
+
+    assert response.success
+    assert response.provider == "mock"
+    assert response.result == "Listing files.\nFound a.py."
+    assert response.metadata["replay_match"] == "exact"
+    assert response.metadata["tool_calls"] == ["Bash"]
+
+
+@pytest.mark.asyncio
+async def test_provider_without_recording_returns_placeholder():
+    provider = MockProvider()
+
+    first = await provider.analyze_content("anything", ModelCapability.GENERAL)
+    second = await provider.analyze_content("anything", ModelCapability.GENERAL)
+
+    assert first.result == second.result == PLACEHOLDER_REPLY
+    assert first.metadata["replayed"] is False
+
+
+@pytest.mark.asyncio
+async def test_provider_reports_unreadable_recording(tmp_path):
+    provider = MockProvider(config={"recording": str(tmp_path / "missing.json")})
+
+    response = await provider.analyze_content("x", ModelCapability.GENERAL)
+
+    assert not response.success
+    assert "recording" in response.error
+
+
+@pytest.mark.asyncio
+async def test_router_mock_strategy(transcript_file):
+    router = ModelRouter(
+        config={"strategy": "mock", "mock_config": {"recording": str(transcript_file)}}
+    )
+    assert router.strategy == RoutingStrategy.MOCK
+    assert await router.initialize()
+
+    response = await router.analyze_content("summarise a.py", ModelCapability.GENERAL)
+
+    assert response.result == "It is empty."
+    status = await router.get_provider_status()
+    assert status["router"]["route_count"]["mock"] == 1
+
+
+def test_selftest_backend_from_recording(transcript_file):
+    backend = MockModelBackend.from_recording(transcript_file)
+    events = backend.session_events(backend.default_prompt, "s1", "/tmp")
+
+    names = [e["hook_event_name"] for e in events]
+    assert names == [
+        "SessionStart",
+        "UserPromptSubmit",
+        "PreToolUse",
+        "PostToolUse",
+        "Stop",
+    ]
+    assert events[3]["tool_response"] == {"output": "a.py"}
+    assert events[-1]["last_assistant_message"] == "Listing files.\nFound a.py."
@@ -0,0 +1,145 @@
+"""Tests for the selftest runner and mock model backend."""
+
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.services.selftest import (
+    SCENARIOS,
+    MockModelBackend,
+    MockToolCall,
+    MockTurn,
+    Scenario,
+    SelftestFailure,
+    SelftestRunner,
+)
+
+
+def _passing(ctx):
+    return "ok"
+
+
+def _failing(ctx):
+    raise SelftestFailure("nope")
+
+
+class TestMockBackend:
+    def test_default_session_is_deterministic(self):
+        backend = MockModelBackend()
+        first = backend.session_events("hello", "s1", "/tmp/p")
+        second = backend.session_events("hello", "s1", "/tmp/p")
+
+        assert first == second
+        names = [e["hook_event_name"] for e in first]
+        assert names == [
+            "SessionStart",
+            "UserPromptSubmit",
+            "PreToolUse",
+            "PostToolUse",
+            "Stop",
+        ]
+        assert all(e["session_id"] == "s1" for e in first)
+
+    def test_script_selects_turn_by_prompt(self):
+        backend = MockModelBackend(
+            [
+                MockTurn("deploy", "deployed", [MockToolCall("Bash", {"cmd": "x"})]),
+                MockTurn("", "fallback"),
+            ]
+        )
+
+        deploy = backend.session_events("please deploy", "s", "/p")
+        other = backend.session_events("hi", "s", "/p")
+
+        assert deploy[-1]["last_assistant_message"] == "deployed"
+        assert len(deploy) == 5
+        assert other[-1]["last_assistant_message"] == "fallback"
+        assert len(other) == 3
+
+
+class TestRunner:
+    def test_failed_prerequisite_skips_dependents(self):
+        runner = SelftestRunner(
+            [
+                Scenario("a", "", _failing),
+                Scenario("b", "", _passing, requires=("a",)),
+                Scenario("c", "", _passing),
+            ]
+        )
+
+        results = {r.name: r for r in runner.run()}
+
+        assert results["a"].status == "failed"
+        assert results["a"].detail == "nope"
+        assert results["b"].status == "skipped"
+        assert results["c"].status == "passed"
+
+    def test_unexpected_exception_is_reported(self):
+        def boom(ctx):
+            raise KeyError("port")
+
+        [result] = SelftestRunner([Scenario("x", "", boom)]).run()
+
+        assert result.status == "failed"
+        assert "KeyError" in result.detail
+
+    def test_select_pulls_in_prerequisites(self):
+        runner = SelftestRunner()
+        names = [s.name for s in runner.select(["verify_dashboard"])]
+
+        assert names == [
+            "mock_session",
+            "start_monitor",
+            "emit_events",
+            "verify_dashboard",
+        ]
+        with pytest.raises(ValueError):
+            runner.select(["nope"])
+
+    def test_cleanups_run_and_workspace_removed(self):
+        calls = []
+
+        def register(ctx):
+            ctx.cleanups.append(lambda: calls.append("cleaned"))
+            assert ctx.home.is_dir() and ctx.project.is_dir()
+            return "registered"
+
+        runner = SelftestRunner([Scenario("r", "", register)])
+        runner.run()
+
+        assert calls == ["cleaned"]
+        assert not runner.workspace.exists()
+
+    def test_keep_workspace(self):
+        runner = SelftestRunner([Scenario("p", "", _passing)], keep_workspace=True)
+        runner.run()
+
+        assert runner.workspace.exists()
+
+
+class TestBuiltinScenarios:
+    def test_offline_scenarios_pass(self):
+        offline = [s for s in SCENARIOS if s.name in ("deploy_agent", "mock_session")]
+
+        results = SelftestRunner(offline).run()
+
+        assert [r.status for r in results] == ["passed", "passed"], results
+
+    def test_deploy_agent_leaves_real_home_alone(self, tmp_path, monkeypatch):
+        real_home = tmp_path / "real-home"
+        real_home.mkdir()
+        monkeypatch.setenv("HOME", str(real_home))
+        seen = []
+
+        def record_home(ctx):
+            with ctx.sandbox_home():
+                seen.append(Path.home())
+            return "ok"
+
+        deploy = [s for s in SCENARIOS if s.name == "deploy_agent"]
+        results = SelftestRunner([*deploy, Scenario("home", "", record_home)]).run()
+
+        assert [r.status for r in results] == ["passed", "passed"], results
+        assert seen[0].name == "home" and seen[0] != real_home
+        assert Path.home() == real_home
+        assert list(real_home.iterdir()) == []
@@ -710,7 +710,7 @@ Skill one
     def test_deployment_force_overwrite(
         self, manager_with_nested_skills, temp_deploy_dir
     ):
-        """Test that force flag overwrites existing skills."""
+        """Test that force redeploys only skills whose content changed."""
         # Deploy once
         result1 = manager_with_nested_skills.deploy_skills(
             target_dir=temp_deploy_dir, force=False
@@ -724,12 +724,25 @@ Skill one
         assert result2["skipped_count"] == 3
         assert result2["deployed_count"] == 0
 
-        # Deploy with force - should overwrite
+        # Deploy with force - identical skills are not rewritten
         result3 = manager_with_nested_skills.deploy_skills(
             target_dir=temp_deploy_dir, force=True
         )
-        assert result3["deployed_count"] == 3
-        assert result3["skipped_count"] == 0
+        assert result3["deployed_count"] == 0
+        assert result3["skipped_count"] == 3
+
+        # Deploy with force after a cache change - only that file is written
+        skill = manager_with_nested_skills.get_all_skills()[0]
+        source = Path(skill["source_file"])
+        source.write_text(source.read_text() + "\nUpdated.\n")
+        result4 = manager_with_nested_skills.deploy_skills(
+            target_dir=temp_deploy_dir, force=True
+        )
+        assert result4["deployed_count"] == 1
+        assert result4["skipped_count"] == 2
+        changes = result4["changes"][result4["deployed_skills"][0]]
+        assert changes["changed"] == ["SKILL.md"]
+        assert changes["added"] == changes["removed"] == []
 
     def test_deployment_metadata_in_skills(self, manager_with_nested_skills):
         """Test that skills include deployment metadata."""
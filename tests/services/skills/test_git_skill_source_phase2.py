@@ -567,7 +567,7 @@ Content
         assert any("skill1" in name.lower() for name in deployed_names)
 
     def test_deploy_skills_to_project_force_overwrite(self, manager, temp_project_dir):
-        """Test force flag overwrites existing deployments."""
+        """Test force redeploys only skills whose content changed."""
         source = manager.config.get_source("test-source")
         cache_path = manager._get_source_cache_path(source)
 
@@ -590,9 +590,17 @@ V1"""
         result2 = manager.deploy_skills_to_project(temp_project_dir, force=False)
         assert result2["skipped_count"] >= 1 or result2["deployed_count"] >= 0
 
-        # Deploy with force - should update
+        # Deploy with force - identical content is not rewritten
         result3 = manager.deploy_skills_to_project(temp_project_dir, force=True)
-        assert result3["deployed_count"] >= 1 or result3["updated_count"] >= 1
+        assert result3["updated_count"] == 0
+        assert result3["skipped_count"] >= 1
+
+        # Deploy with force after a cache change - reported as updated
+        (skill_dir / "SKILL.md").write_text(skill_content.replace("V1", "V2"))
+        result4 = manager.deploy_skills_to_project(temp_project_dir, force=True)
+        assert result4["updated_count"] == 1
+        (name,) = result4["changes"]
+        assert result4["changes"][name]["changed"] == ["SKILL.md"]
 
     @patch("requests.get")
     def test_integration_sync_and_deploy(self, mock_get, manager, temp_project_dir):
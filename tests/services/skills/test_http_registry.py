@@ -0,0 +1,278 @@
+"""Tests for skill sources served from a static HTTP registry.
+
+COVERAGE:
+- index.json URLs are added as "http" sources; git-only settings are
+  rejected and registries are never pinned in skills.lock
+- Indexes with unsafe paths, bad digests or another format are rejected;
+  archives drop a wrapping folder and skip links and unsafe members
+- Packing writes reproducible archives and the collection's other files;
+  syncing downloads only archives whose SHA-256 changed, removes skills the
+  index dropped, records the index digest, and fails without touching the
+  cache when a download does not match the index
+- Tokens are sent as Bearer (Basic for user:secret); a signed collection
+  verifies after a round trip through a registry
+- skill-source pack and add work end to end, and search reads the index
+"""
+
+import hashlib
+import io
+import json
+import tarfile
+from urllib.parse import urlparse
+
+import pytest
+import requests
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import (
+    GitSkillSourceManager,
+    resolve_source_commit,
+)
+from claude_mpm.services.skills.http_registry import (
+    RegistryError,
+    auth_headers,
+    build_registry,
+    extract_skill_archive,
+    parse_index,
+)
+from claude_mpm.services.skills.skills_lock import SkillsLock
+from tests.services.skills.test_skill_signing import MinisignKey, _collection
+
+INDEX_URL = "https://skills.example.com/corp/index.json"
+SKILL = "---\nname: {name}\ndescription: {name} skill\nversion: {version}\n---\n"
+
+
+class FakeRegistry:
+    """Serves a directory at https://skills.example.com/corp/."""
+
+    def __init__(self, root):
+        self.root = root
+        self.requests = []
+
+    def __call__(self, url, headers=None, **kwargs):
+        self.requests.append((url, headers))
+        path = self.root / urlparse(url).path.removeprefix("/corp/")
+        response = requests.Response()
+        response.url = url
+        if path.is_file():
+            response.status_code = 200
+            response._content = path.read_bytes()
+        else:
+            response.status_code = 404
+            response._content = b""
+        return response
+
+    def downloads(self):
+        urls = [url for url, _ in self.requests if url.endswith(".tar.gz")]
+        self.requests.clear()
+        return sorted(urlparse(url).path.rsplit("/", 1)[1] for url in urls)
+
+
+def _skill(root, path, name, version="1.0.0"):
+    (root / path).mkdir(parents=True, exist_ok=True)
+    (root / path / "SKILL.md").write_text(SKILL.format(name=name, version=version))
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    monkeypatch.chdir(tmp_path)
+    return tmp_path
+
+
+@pytest.fixture
+def registry(home, monkeypatch):
+    served = home / "served"
+    fake = FakeRegistry(served)
+    monkeypatch.setattr(requests, "get", fake)
+    return fake
+
+
+def _manager(home, **source):
+    config = SkillSourceConfiguration()
+    config.save([SkillSource(id="corp", type="http", url=INDEX_URL, **source)])
+    return GitSkillSourceManager(config, lock=SkillsLock(home))
+
+
+def test_registry_sources_validate(home):
+    source = SkillSource(id="corp", type="http", url=INDEX_URL, token="$TOKEN")
+    assert (source.is_http, source.is_git, source.is_ssh) == (True, False, False)
+    assert source.hosting is None
+    with pytest.raises(ValueError, match="ssh_key does not apply"):
+        SkillSource(id="c", type="http", url=INDEX_URL, ssh_key="~/.ssh/key")
+    with pytest.raises(ValueError, match="must be an http"):
+        SkillSource(id="c", type="http", url="ftp://example.com/index.json")
+    with pytest.raises(ValueError, match="cannot be pinned"):
+        resolve_source_commit(source)
+
+    config = SkillSourceConfiguration()
+    config.save([source])
+    assert config.load()[0].type == "http"
+
+    assert auth_headers(SkillSource(id="c", type="http", url=INDEX_URL)) == {}
+    bearer = SkillSource(id="c", type="http", url=INDEX_URL, token="abc")
+    assert auth_headers(bearer) == {"Authorization": "Bearer abc"}
+    basic = SkillSource(id="c", type="http", url=INDEX_URL, token="me:pw")
+    assert auth_headers(basic) == {"Authorization": "Basic bWU6cHc="}
+
+
+def test_parse_index():
+    digest = "a" * 64
+    index = parse_index(
+        json.dumps(
+            {
+                "skills": [
+                    {"name": "tdd", "url": "tdd.tar.gz", "sha256": digest},
+                    {
+                        "name": "pdf",
+                        "path": "docs/pdf",
+                        "url": "https://cdn.example.com/pdf.tar.gz",
+                        "sha256": digest.upper(),
+                    },
+                ]
+            }
+        ).encode(),
+        INDEX_URL,
+    )
+    assert [(s.path, s.url) for s in index.skills] == [
+        ("tdd", "https://skills.example.com/corp/tdd.tar.gz"),
+        ("docs/pdf", "https://cdn.example.com/pdf.tar.gz"),
+    ]
+    assert index.skills[1].sha256 == digest
+
+    def bad(entry, **extra):
+        data = {"skills": [{"url": "x.tar.gz", "sha256": digest, **entry}], **extra}
+        return lambda: parse_index(json.dumps(data).encode(), INDEX_URL)
+
+    for entry, match in [
+        ({"name": "../etc"}, "outside the registry"),
+        ({"name": "tdd", "sha256": "abc"}, "no valid sha256"),
+        ({"path": "/tmp/tdd"}, "outside the registry"),
+    ]:
+        with pytest.raises(RegistryError, match=match):
+            bad(entry)()
+    with pytest.raises(RegistryError, match="format 2"):
+        bad({"name": "tdd"}, format=2)()
+    with pytest.raises(RegistryError, match="not a skill registry"):
+        parse_index(b'{"name": "x"}', INDEX_URL)
+
+
+def test_extract_skill_archive(tmp_path):
+    buffer = io.BytesIO()
+    with tarfile.open(fileobj=buffer, mode="w:gz") as tar:
+        for name in ("tdd-1.0/SKILL.md", "tdd-1.0/scripts/run.sh", "../evil.md"):
+            info = tarfile.TarInfo(name)
+            info.size = 2
+            tar.addfile(info, io.BytesIO(b"hi"))
+        link = tarfile.TarInfo("tdd-1.0/link.md")
+        link.type, link.linkname = tarfile.SYMTYPE, "/etc/passwd"
+        tar.addfile(link)
+    dest = tmp_path / "tdd"
+
+    def keep(path):
+        return not path.endswith(".sh")
+
+    assert extract_skill_archive(buffer.getvalue(), dest, keep) == 1
+    assert sorted(p.name for p in dest.rglob("*") if p.is_file()) == ["SKILL.md"]
+    assert not (tmp_path / "evil.md").exists()
+    with pytest.raises(RegistryError, match="Not a tar.gz"):
+        extract_skill_archive(b"plain text", dest)
+
+
+def test_pack_and_sync(home, registry):
+    collection = home / "collection"
+    _skill(collection, "universal/tdd", "tdd")
+    _skill(collection, "toolchains/python/pytest", "pytest")
+    (collection / "universal" / "tdd" / "scripts").mkdir()
+    (collection / "universal" / "tdd" / "scripts" / "run.sh").write_text("echo\n")
+    (collection / "README.md").write_text("# Corp skills\n")
+    (collection / ".github").mkdir()
+    (collection / ".github" / "ci.yml").write_text("on: push\n")
+
+    index = build_registry(collection, registry.root)
+    assert [(s.name, s.path, s.version) for s in index.skills] == [
+        ("pytest", "toolchains/python/pytest", "1.0.0"),
+        ("tdd", "universal/tdd", "1.0.0"),
+    ]
+    assert [f.path for f in index.files] == ["README.md"]
+    assert (registry.root / "universal-tdd-1.0.0.tar.gz").is_file()
+    # Packing is reproducible
+    first = (registry.root / "index.json").read_bytes()
+    build_registry(collection, registry.root)
+    assert (registry.root / "index.json").read_bytes() == first
+
+    manager = _manager(home, token="secret")
+    result = manager.sync_source("corp")
+    assert result["synced"], result
+    assert result["skills_discovered"] == 2
+    assert "commit" not in result
+    cache = home / ".claude-mpm" / "cache" / "skills" / "corp"
+    assert (cache / "universal" / "tdd" / "scripts" / "run.sh").is_file()
+    assert (cache / "README.md").is_file()
+    assert not (cache / ".github").exists()
+    source = manager.config.get_source("corp")
+    assert manager.synced_commit(source) == hashlib.sha256(first).hexdigest()
+    assert not SkillsLock(home).path.exists()
+    assert registry.requests[0] == (INDEX_URL, {"Authorization": "Bearer secret"})
+    assert registry.downloads() == [
+        "toolchains-python-pytest-1.0.0.tar.gz",
+        "universal-tdd-1.0.0.tar.gz",
+    ]
+
+    # Unchanged archives are not downloaded again
+    assert manager.sync_source("corp")["files_updated"] == 0
+    assert registry.downloads() == []
+
+    # A new version downloads only that skill; a dropped skill goes
+    _skill(collection, "universal/tdd", "tdd", version="1.1.0")
+    (registry.root / "index.json").unlink()
+    build_registry(collection / "universal", registry.root)
+    result = manager.sync_source("corp")
+    assert result["synced"], result
+    assert registry.downloads() == ["tdd-1.1.0.tar.gz"]
+    assert sorted(p.name for p in cache.iterdir()) == ["tdd"]
+    assert "version: 1.1.0" in (cache / "tdd" / "SKILL.md").read_text()
+
+    # A download that does not match the index fails and leaves the cache
+    (registry.root / "tdd-1.1.0.tar.gz").write_bytes(b"tampered")
+    result = manager.sync_source("corp", force=True)
+    assert not result["synced"]
+    assert "does not match its sha256" in result["error"]
+    assert "version: 1.1.0" in (cache / "tdd" / "SKILL.md").read_text()
+
+
+def test_signed_registry(home, registry):
+    key = MinisignKey()
+    build_registry(_collection(home / "collection", key), registry.root)
+    manager = _manager(home, trusted_keys=[key.public])
+    result = manager.sync_source("corp")
+    assert result["synced"], result
+    assert result["signature"]["verified"]
+
+
+def test_pack_and_add_commands(home, registry, capsys):
+    from claude_mpm.cli.commands.skill_source import skill_source_command
+    from claude_mpm.cli.parsers.base_parser import create_parser
+    from claude_mpm.services.skills.skill_search import search_skills
+
+    _skill(home / "collection", "tdd", "tdd")
+    parse = create_parser().parse_args
+    args = parse(["skill-source", "pack", "collection", "-o", str(registry.root)])
+    assert skill_source_command(args) == 0
+    assert "Packed 1 skills" in capsys.readouterr().out
+
+    # Search reads the index of a registry that has not been synced
+    config = SkillSourceConfiguration()
+    config.save([SkillSource(id="corp", type="http", url=INDEX_URL)])
+    result = search_skills("tdd", config=config)
+    assert [(m.name, m.version, m.origin) for m in result.matches] == [
+        ("tdd", "1.0.0", "index")
+    ]
+    config.config_path.unlink()
+
+    assert skill_source_command(parse(["skill-source", "add", INDEX_URL])) == 0
+    out = capsys.readouterr().out
+    assert "Added skill source: corp" in out
+    assert "registry (not pinned)" in out
+    (source,) = [s for s in SkillSourceConfiguration().load() if s.id == "corp"]
+    assert source.type == "http"
@@ -0,0 +1,186 @@
+"""Tests for local directory skill sources and the watcher that hot-syncs them.
+
+COVERAGE:
+- Local sources validate (absolute path, no git-only options), persist and
+  are never pinned in skills.lock
+- ``skill-source add`` recognises directory arguments and stores their
+  absolute path; --watch is refused for repositories
+- Syncing copies relevant files from disk, skips hidden ones and drops files
+  deleted from the directory
+- The watcher ignores irrelevant changes, debounces bursts, redeploys only
+  the skills the source wins, and removes skills deleted from the directory
+"""
+
+from types import SimpleNamespace
+
+import pytest
+
+from claude_mpm.cli.commands import skill_source as commands
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import (
+    GitSkillSourceManager,
+    resolve_source_commit,
+)
+from claude_mpm.services.skills.local_skill_watcher import LocalSkillWatcher
+from claude_mpm.services.skills.skills_lock import SkillsLock
+
+SKILL = "---\nname: {}\ndescription: {}\n---\n\nBody\n"
+
+
+def _write_skill(root, name, description="A skill"):
+    (root / name).mkdir(parents=True, exist_ok=True)
+    (root / name / "SKILL.md").write_text(SKILL.format(name, description))
+
+
+@pytest.fixture
+def setup(tmp_path):
+    skills = tmp_path / "my-skills"
+    _write_skill(skills, "tdd")
+    _write_skill(skills, "debugging")
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    source = SkillSource(id="mine", type="local", url=str(skills), priority=10)
+    config.save([source])
+    manager = GitSkillSourceManager(config=config, cache_dir=tmp_path / "cache")
+    return SimpleNamespace(
+        skills=skills,
+        config=config,
+        source=config.get_source("mine"),
+        manager=manager,
+        target=tmp_path / "deployed",
+    )
+
+
+def test_local_source_validation(tmp_path, monkeypatch):
+    monkeypatch.setenv("SKILLS_HOME", str(tmp_path))
+    source = SkillSource(id="mine", type="local", url="$SKILLS_HOME/skills")
+    assert source.is_local and not source.is_ssh and source.hosting is None
+    assert source.local_path == tmp_path / "skills"
+
+    with pytest.raises(ValueError, match="must be absolute"):
+        SkillSource(id="mine", type="local", url="skills")
+    with pytest.raises(ValueError, match="ssh_key does not apply"):
+        SkillSource(id="mine", type="local", url="/skills", ssh_key="~/.ssh/id")
+    with pytest.raises(ValueError, match="cannot be pinned"):
+        resolve_source_commit(source)
+
+
+def test_local_source_persists_and_is_not_pinned(setup, tmp_path):
+    reloaded = SkillSourceConfiguration(config_path=setup.config.config_path)
+    source = reloaded.get_source("mine")
+    assert (source.type, source.url) == ("local", str(setup.skills))
+
+    lock = SkillsLock(tmp_path)
+    manager = GitSkillSourceManager(
+        config=reloaded, cache_dir=tmp_path / "cache", lock=lock
+    )
+    assert manager.sync_source("mine")["synced"]
+    assert lock.get(source) is None
+
+
+def test_sync_copies_relevant_files(setup, tmp_path):
+    (setup.skills / "tdd" / "scripts").mkdir()
+    (setup.skills / "tdd" / "scripts" / "run.sh").write_text("pytest\n")
+    (setup.skills / "tdd" / ".SKILL.md.swp").write_text("swap")
+    (setup.skills / ".git").mkdir()
+    (setup.skills / ".git" / "HEAD.md").write_text("ref")
+    (setup.skills / "notes.bin").write_text("ignored")
+    cache = tmp_path / "cache" / "mine"
+
+    result = setup.manager.sync_source("mine")
+    assert (result["files_updated"], result["skills_discovered"]) == (3, 2)
+    assert (cache / "tdd" / "scripts" / "run.sh").is_file()
+    assert not (cache / "tdd" / ".SKILL.md.swp").exists()
+    assert not (cache / ".git").exists()
+    assert not (cache / "notes.bin").exists()
+
+    (setup.skills / "tdd" / "scripts" / "run.sh").unlink()
+    result = setup.manager.sync_source("mine")
+    assert (result["files_updated"], result["files_cached"]) == (0, 2)
+    assert not (cache / "tdd" / "scripts").exists()
+
+    setup.skills.rename(tmp_path / "moved")
+    result = setup.manager.sync_source("mine")
+    assert not result["synced"] and "not found" in result["error"]
+
+
+def test_watcher_redeploys_changes(setup, tmp_path):
+    # A higher-priority source providing "debugging" wins it
+    other = tmp_path / "other"
+    _write_skill(other, "debugging", "The team's version")
+    sources = [setup.source, SkillSource(id="team", type="local", url=str(other))]
+    sources[1].priority = 0
+    setup.config.save(sources)
+    setup.manager.sync_source("team")
+
+    watcher = LocalSkillWatcher(setup.manager, setup.source, setup.target, 0.5)
+    result = watcher.sync()
+    assert (result["deployed"], result["removed"]) == (["tdd"], [])
+    assert not (setup.target / "debugging").exists()
+
+    assert not watcher.notify(setup.skills / "tdd" / ".SKILL.md.swp", now=0)
+    assert not watcher.notify(tmp_path / "elsewhere.md", now=0)
+    assert watcher.poll(now=10) is None
+
+    _write_skill(setup.skills, "tdd", "Edited")
+    assert watcher.notify(setup.skills / "tdd" / "SKILL.md", now=0)
+    assert watcher.poll(now=0.2) is None  # Still debouncing
+    result = watcher.poll(now=0.6)
+    assert result["deployed"] == ["tdd"]
+    assert "Edited" in (setup.target / "tdd" / "SKILL.md").read_text()
+    assert watcher.poll(now=5) is None
+
+    (setup.skills / "tdd" / "SKILL.md").unlink()
+    (setup.skills / "tdd").rmdir()
+    _write_skill(setup.skills, "review")
+    watcher.notify(setup.skills / "review" / "SKILL.md", now=1)
+    result = watcher.poll(now=2)
+    assert (result["deployed"], result["removed"]) == (["review"], ["tdd"])
+    assert not (setup.target / "tdd").exists()
+
+    with pytest.raises(ValueError, match="not a local skill source"):
+        LocalSkillWatcher(
+            setup.manager, SkillSource(id="x", type="git", url="https://github.com/o/r")
+        )
+
+
+def test_add_local_directory(tmp_path, monkeypatch, capsys):
+    skills = tmp_path / "my-skills"
+    _write_skill(skills, "tdd")
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    monkeypatch.setattr(
+        commands,
+        "SkillSourceConfiguration",
+        lambda **kw: SkillSourceConfiguration(**kw) if kw else config,
+    )
+    monkeypatch.chdir(tmp_path)
+
+    def args(url, **overrides):
+        values = {
+            "url": url,
+            "branch": "main",
+            "priority": 10,
+            "disabled": False,
+            "test": False,
+            "skip_test": False,
+            "watch": False,
+        }
+        values.update(overrides)
+        return SimpleNamespace(**values)
+
+    assert commands._is_local_path("./my-skills")
+    assert commands._is_local_path("my-skills")
+    assert not commands._is_local_path("git@github.com:owner/repo.git")
+    assert not commands._is_local_path("https://github.com/owner/repo")
+
+    assert commands.handle_add_skill_source(args("./my-skills")) == 0
+    source = config.get_source("my-skills")
+    assert (source.type, source.url) == ("local", str(skills.resolve()))
+    out = capsys.readouterr().out
+    assert "Path: " in out and "local directory (not pinned)" in out
+
+    assert commands.handle_add_skill_source(args("./missing")) == 1
+    assert "Not a directory" in capsys.readouterr().out
+
+    url = "https://github.com/owner/repo"
+    assert commands.handle_add_skill_source(args(url, watch=True)) == 1
+    assert "--watch only applies" in capsys.readouterr().out
@@ -0,0 +1,118 @@
+"""Tests for skill name conflicts between sources.
+
+COVERAGE:
+- A name two sources provide goes to the lower priority source, to the
+  first listed on equal priority, or to the source given precedence; each
+  is reported with the copies it shadows
+- An alias deploys a shadowed copy under its own name; different skills
+  from different sources deploying to one directory are reported too
+- Deploying <source>:<skill> deploys that source's copy in place of the
+  winner, and the manager keeps the conflicts for skills list
+"""
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skill_conflicts import (
+    DIRECTORY,
+    NAME,
+    ConflictConfig,
+    resolve_conflicts,
+    split_qualified,
+)
+
+
+def _skill(source_id, skill_id, priority, deployment_name=None):
+    return {
+        "skill_id": skill_id,
+        "name": skill_id,
+        "deployment_name": deployment_name or skill_id,
+        "source_id": source_id,
+        "source_priority": priority,
+    }
+
+
+def test_resolve_conflicts():
+    skills = [
+        _skill("team-a", "code-review", 10),
+        _skill("team-b", "code-review", 5),
+        _skill("team-a", "tdd", 10),
+        _skill("team-b", "tdd", 10),
+        _skill("team-a", "lint", 10, deployment_name="python-lint"),
+        _skill("team-b", "py-lint", 5, deployment_name="python-lint"),
+    ]
+    resolved, conflicts = resolve_conflicts(skills)
+    by_id = {s["skill_id"]: s["source_id"] for s in resolved}
+    assert by_id == {"code-review": "team-b", "tdd": "team-a", "py-lint": "team-b"}
+    assert [(c.kind, c.name, c.resolved_by) for c in conflicts] == [
+        (NAME, "code-review", "priority"),
+        (NAME, "tdd", "order"),
+        (DIRECTORY, "python-lint", "priority"),
+    ]
+    assert conflicts[0].describe() == (
+        "code-review: team-b:code-review wins (by source priority); "
+        "shadows team-a:code-review"
+    )
+
+    config = ConflictConfig.load(
+        {
+            "skills.conflicts": {
+                "precedence": {"code-review": "team-a"},
+                "aliases": {"team-b:code-review": "team-b-code-review"},
+                "unknown": {"x": "y"},
+            }
+        }
+    )
+    resolved, conflicts = resolve_conflicts(skills, config)
+    review = [s for s in resolved if s.get("alias_of") or s["name"] == "code-review"]
+    assert [(s["deployment_name"], s["source_id"]) for s in review] == [
+        ("code-review", "team-a"),
+        ("team-b-code-review", "team-b"),
+    ]
+    assert conflicts[0].resolved_by == "precedence"
+    assert conflicts[0].aliases == {"team-b:code-review": "team-b-code-review"}
+    assert "(as team-b-code-review)" in conflicts[0].describe()
+
+    assert split_qualified("team-a:tdd") == ("team-a", "tdd")
+    assert split_qualified("tdd") == (None, "tdd")
+
+
+def _write_skill(root, name, body):
+    skill_dir = root / name
+    skill_dir.mkdir(parents=True)
+    (skill_dir / "SKILL.md").write_text(
+        f"---\nname: {name}\ndescription: {body}\n---\n\n{body}\n",
+        encoding="utf-8",
+    )
+
+
+def test_deploy_qualified_name(tmp_path):
+    config = SkillSourceConfiguration(config_path=tmp_path / "sources.yaml")
+    config.save(
+        [
+            SkillSource(id=source_id, type="git", url=url, priority=priority)
+            for source_id, url, priority in (
+                ("team-a", "https://github.com/team-a/skills", 10),
+                ("team-b", "https://github.com/team-b/skills", 20),
+            )
+        ]
+    )
+    cache = tmp_path / "cache"
+    _write_skill(cache / "team-a", "code-review", "Review like team A")
+    _write_skill(cache / "team-b", "code-review", "Review like team B")
+    manager = GitSkillSourceManager(
+        config, cache_dir=cache, conflict_config=ConflictConfig()
+    )
+
+    project = tmp_path / "project"
+    result = manager.deploy_skills_to_project(project, ["code-review"])
+    deployed = project / ".claude-mpm" / "skills" / "code-review" / "SKILL.md"
+    assert result["deployed"] == ["code-review"]
+    assert "team A" in deployed.read_text()
+    [conflict] = manager.conflicts
+    assert (conflict.winner, conflict.shadowed) == (
+        "team-a:code-review",
+        ["team-b:code-review"],
+    )
+
+    manager.deploy_skills_to_project(project, ["team-b:code-review"])
+    assert "team B" in deployed.read_text()
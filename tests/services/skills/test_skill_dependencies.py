@@ -0,0 +1,100 @@
+"""Tests for skills that require other skills.
+
+COVERAGE:
+- The closure of a selection is deployed, each skill after its requirements,
+  matching requirements case-insensitively by name
+- Missing requirements and cycles are reported per skill, along with the
+  skills that depend on them, while the rest still resolve
+- Deploying to a project pulls in required skills from the cache and fails
+  the skills whose requirements cannot be met
+"""
+
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skill_dependencies import resolve_dependencies
+
+
+def _skill(name, *requires):
+    return {"name": name, "requires": list(requires)}
+
+
+def _names(skills):
+    return [skill["name"] for skill in skills]
+
+
+def test_closure_in_dependency_order():
+    catalog = [
+        _skill("release", "changelog", "Semver"),
+        _skill("changelog", "semver"),
+        _skill("semver"),
+        _skill("unrelated"),
+    ]
+
+    resolution = resolve_dependencies([catalog[0]], catalog)
+
+    assert _names(resolution.skills) == ["semver", "changelog", "release"]
+    assert resolution.added == ["semver", "changelog"]
+    assert resolution.errors == {}
+
+
+def test_missing_and_cyclic_requirements():
+    catalog = [
+        _skill("release", "changelog"),
+        _skill("changelog", "git-history"),
+        _skill("a", "b"),
+        _skill("b", "a"),
+        _skill("c", "a"),
+        _skill("ok"),
+    ]
+
+    resolution = resolve_dependencies([catalog[0], catalog[4], catalog[5]], catalog)
+
+    assert _names(resolution.skills) == ["ok"]
+    assert resolution.errors["changelog"] == (
+        "requires 'git-history', which no skill source provides"
+    )
+    assert resolution.errors["release"].startswith(
+        "requires 'changelog', which cannot be deployed: requires 'git-history'"
+    )
+    assert resolution.errors["a"] == "dependency cycle: a → b → a"
+    assert resolution.errors["b"] == "dependency cycle: a → b → a"
+    assert "cannot be deployed: dependency cycle" in resolution.errors["c"]
+
+
+@pytest.fixture
+def manager(tmp_path):
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save([SkillSource(id="org", type="git", url="https://github.com/o/s")])
+    skills = {
+        "release": "requires: [changelog]\n",
+        "changelog": "",
+        "broken": "requires: [missing-helper]\n",
+    }
+    for name, extra in skills.items():
+        skill_dir = tmp_path / "cache" / "org" / name
+        skill_dir.mkdir(parents=True)
+        (skill_dir / "SKILL.md").write_text(
+            f"---\nname: {name}\ndescription: The {name} skill\n{extra}---\n\n"
+            f"# {name}\n"
+        )
+    return GitSkillSourceManager(config=config, cache_dir=tmp_path / "cache")
+
+
+def test_deploy_to_project_includes_requirements(tmp_path, manager):
+    project = tmp_path / "project"
+
+    result = manager.deploy_skills_to_project(project, skill_list=["release"])
+
+    assert result["dependencies"] == ["changelog"]
+    assert sorted(result["deployed"]) == ["changelog", "release"]
+    deployed = Path(result["deployment_dir"])
+    assert (deployed / "changelog" / "SKILL.md").exists()
+
+    result = manager.deploy_skills_to_project(project, skill_list=["broken"])
+    assert result["failed"] == ["broken"]
+    assert "missing-helper" in result["errors"]["broken"]
+    assert not (deployed / "broken").exists()
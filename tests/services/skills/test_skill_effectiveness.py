@@ -0,0 +1,159 @@
+"""
+Tests for skill effectiveness scoring.
+
+COVERAGE:
+- Invocation outcomes: tool errors, retries by prompt and re-invocation, cost
+- Aggregated scores and recommendations, including unused deployed skills
+- Transcript discovery for the current project
+"""
+
+import json
+
+import pytest
+
+from claude_mpm.services.session_analysis.transcript_parser import locate_transcript
+from claude_mpm.services.skills.skill_effectiveness import (
+    SkillEffectivenessAnalyzer,
+    SkillStats,
+    scan_session,
+    score_skills,
+)
+
+USAGE = {"input_tokens": 1000, "output_tokens": 500}
+
+
+def _prompt(text):
+    return {"type": "user", "message": {"content": text}}
+
+
+def _skill(call_id, name, msg_id="m1"):
+    return {
+        "type": "assistant",
+        "timestamp": "2026-10-01T12:00:00Z",
+        "message": {
+            "id": msg_id,
+            "model": "claude-sonnet-4-6",
+            "usage": USAGE,
+            "content": [
+                {
+                    "type": "tool_use",
+                    "id": call_id,
+                    "name": "Skill",
+                    "input": {"skill": name},
+                }
+            ],
+        },
+    }
+
+
+def _result(call_id, is_error=False):
+    return {
+        "type": "user",
+        "message": {
+            "content": [
+                {"type": "tool_result", "tool_use_id": call_id, "is_error": is_error}
+            ]
+        },
+    }
+
+
+def _reply(msg_id):
+    return {
+        "type": "assistant",
+        "message": {
+            "id": msg_id,
+            "model": "claude-sonnet-4-6",
+            "usage": USAGE,
+            "content": [{"type": "text", "text": "done"}],
+        },
+    }
+
+
+def test_successful_invocation_collects_turn_cost():
+    lines = [
+        _prompt("add tests"),
+        _skill("s1", "tdd"),
+        _result("s1"),
+        _reply("m2"),
+        _reply("m2"),  # streamed chunk of the same message
+        _prompt("thanks, now docs"),
+    ]
+
+    [invocation] = scan_session(lines, "sess")
+
+    assert invocation.succeeded
+    assert invocation.timestamp is not None
+    assert invocation.cost_usd == pytest.approx(
+        scan_session(lines[:4], "sess")[0].cost_usd
+    )
+    assert invocation.cost_usd > 0
+
+
+@pytest.mark.parametrize(
+    "follow_up",
+    [
+        [_prompt("that didn't work, try again")],
+        [_prompt("[Request interrupted by user]")],
+        [_prompt("hmm"), _skill("s2", "tdd", "m3")],
+    ],
+)
+def test_retries_mark_invocation_unsuccessful(follow_up):
+    lines = [_prompt("add tests"), _skill("s1", "tdd"), _result("s1"), *follow_up]
+
+    first = scan_session(lines, "sess")[0]
+
+    assert first.retried
+    assert not first.succeeded
+
+
+def test_failed_load_and_tool_errors():
+    lines = [
+        _prompt("go"),
+        _skill("s1", "broken"),
+        _result("s1", is_error=True),
+        _skill("s2", "noisy", "m2"),
+        *[_result(f"t{i}", is_error=True) for i in range(3)],
+    ]
+
+    broken, noisy = scan_session(lines, "sess")
+
+    assert not broken.loaded
+    assert noisy.tool_errors == 3
+    assert not noisy.succeeded
+
+
+def test_scores_and_recommendations():
+    good = SkillStats("good", invocations=4, successes=4, total_cost_usd=0.4)
+    bad = SkillStats("bad", invocations=4, successes=1, retries=3, total_cost_usd=4)
+    new = SkillStats("new", invocations=1, successes=1)
+    unused = SkillStats("unused", deployed=True)
+
+    score_skills([good, bad, new, unused])
+
+    assert good.score == pytest.approx(100)
+    assert good.recommendation == "keep"
+    assert bad.score < 40
+    assert bad.recommendation == "retire"
+    assert new.recommendation == "needs more data"
+    assert unused.score is None
+    assert unused.recommendation == "retire (unused)"
+
+
+def test_analyzer_reads_project_transcripts(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    project = tmp_path / "proj"
+    (project / ".claude" / "skills" / "idle").mkdir(parents=True)
+    (project / ".claude" / "skills" / "idle" / "SKILL.md").write_text("# idle")
+
+    transcript = locate_transcript("sess", str(project))
+    transcript.parent.mkdir(parents=True)
+    lines = [_prompt("add tests"), _skill("s1", "tdd"), _result("s1")]
+    transcript.write_text("\n".join(json.dumps(line) for line in lines))
+
+    stats = {s.name: s for s in SkillEffectivenessAnalyzer(project).stats()}
+
+    assert stats["tdd"].invocations == 1
+    assert stats["tdd"].sessions == {"sess"}
+    assert not stats["tdd"].deployed
+    assert stats["idle"].deployed
+    assert stats["idle"].invocations == 0
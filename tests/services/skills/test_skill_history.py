@@ -0,0 +1,175 @@
+"""Tests for deployed skill version history and rollback.
+
+COVERAGE:
+- Deploying a skill records each new version with its source commit
+- Rollback restores the previous version (or one named by version, ID or
+  commit) and holds it; deployments skip a held skill until it is released
+- The first deployment over an untracked skill keeps the deployed copy;
+  old versions are pruned; locally edited copies need --to
+- skills rollback lists, rolls back and releases
+"""
+
+import subprocess
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skill_history import (
+    SkillHistory,
+    SkillHistoryError,
+    release_skill,
+    rollback_skill,
+)
+
+SSH_URL = "git@github.com:org/skills.git"
+
+
+def _git(cwd, *args):
+    return subprocess.run(
+        ["git", *args], cwd=cwd, check=True, capture_output=True, text=True
+    ).stdout.strip()
+
+
+def _commit(repo, version, body="Body"):
+    (repo / "tdd").mkdir(exist_ok=True)
+    (repo / "tdd" / "SKILL.md").write_text(
+        f"---\nname: tdd\ndescription: Test first\nversion: {version}\n---\n"
+        f"\n{body}\n"
+    )
+    _git(repo, "add", "-A")
+    _git(repo, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", version)
+    return _git(repo, "rev-parse", "HEAD")
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    monkeypatch.chdir(tmp_path)
+    return tmp_path
+
+
+@pytest.fixture
+def release(home, monkeypatch):
+    """Commit a tdd version to the org source, sync it and deploy it."""
+    repo = home / "remote" / "skills.git"
+    repo.mkdir(parents=True)
+    _git(repo, "init", "-q", "-b", "main")
+    monkeypatch.setenv("GIT_CONFIG_COUNT", "1")
+    monkeypatch.setenv("GIT_CONFIG_KEY_0", f"url.{repo.parent}/.insteadOf")
+    monkeypatch.setenv("GIT_CONFIG_VALUE_0", "git@github.com:org/")
+    config = SkillSourceConfiguration()
+    config.save([SkillSource(id="org", type="git", url=SSH_URL)])
+
+    def publish(version, body="Body", deploy=True):
+        commit = _commit(repo, version, body)
+        manager = GitSkillSourceManager(config)
+        manager.sync_source("org")
+        if deploy:
+            manager.deploy_source("org", home / ".claude" / "skills")
+        return commit
+
+    return publish
+
+
+def _deployed_version(home):
+    text = (home / ".claude" / "skills" / "tdd" / "SKILL.md").read_text()
+    return text.split("version: ")[1].split("\n")[0]
+
+
+def test_rollback_and_hold(home, release):
+    first = release("1.0.0")
+    release("1.1.0")
+    history = SkillHistory()
+    versions = history.versions("tdd")
+    assert [(v.version, v.source_id) for v in versions] == [
+        ("1.0.0", "org"),
+        ("1.1.0", "org"),
+    ]
+    assert versions[0].commit == first
+
+    rollback = rollback_skill("tdd")
+    assert rollback.version == versions[0]
+    assert rollback.previous == versions[1].id
+    assert _deployed_version(home) == "1.0.0"
+    deployed = home / ".claude" / "skills" / "tdd"
+    assert history.held("tdd", deployed) == versions[0].id
+
+    # Held skills are not updated by deployments
+    release("1.2.0")
+    assert _deployed_version(home) == "1.0.0"
+    with pytest.raises(SkillHistoryError, match="before"):
+        rollback_skill("tdd")
+
+    assert release_skill("tdd") == deployed
+    release("1.2.1")
+    assert _deployed_version(home) == "1.2.1"
+    with pytest.raises(SkillHistoryError, match="not held"):
+        release_skill("tdd")
+
+    # --to takes a version, a version ID or a source commit
+    assert rollback_skill("tdd", to="1.1.0").version.version == "1.1.0"
+    assert rollback_skill("tdd", to=first[:7]).version.version == "1.0.0"
+    assert rollback_skill("tdd", to=versions[1].id[:6]).version.version == "1.1.0"
+    with pytest.raises(SkillHistoryError, match="matches 9.9"):
+        rollback_skill("tdd", to="9.9")
+    with pytest.raises(SkillHistoryError, match="not deployed"):
+        rollback_skill("missing")
+
+
+def test_history_bootstrap_and_pruning(home, release):
+    # Deployed before there was a history
+    release("1.0.0")
+    SkillHistory().root.joinpath("tdd", "index.json").unlink()
+    release("1.1.0", deploy=False)
+
+    manager = GitSkillSourceManager(SkillSourceConfiguration())
+    manager.history._keep = 2
+    manager.deploy_source("org", home / ".claude" / "skills")
+    versions = SkillHistory().versions("tdd")
+    assert [v.version for v in versions] == ["1.0.0", "1.1.0"]
+    # The copy found deployed has no known source
+    assert versions[0].source_id is None
+
+    release("1.2.0", deploy=False)
+    manager.deploy_source("org", home / ".claude" / "skills")
+    versions = SkillHistory().versions("tdd")
+    assert [v.version for v in versions] == ["1.1.0", "1.2.0"]
+    # Pruned versions' files go too
+    kept = [p.name for p in (SkillHistory().root / "tdd").iterdir() if p.is_dir()]
+    assert sorted(kept) == sorted(v.id for v in versions)
+
+    skill_md = home / ".claude" / "skills" / "tdd" / "SKILL.md"
+    skill_md.write_text(skill_md.read_text() + "local edit\n")
+    with pytest.raises(SkillHistoryError, match="--to"):
+        rollback_skill("tdd")
+
+
+def test_rollback_command(home, release, capsys):
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    release("1.0.0")
+    release("1.1.0", body="Broken")
+    command = SkillsManagementCommand()
+    parse = create_parser().parse_args
+
+    assert command._rollback_skill(
+        parse(["skills", "rollback", "tdd", "--list"])
+    ).success
+    out = capsys.readouterr().out
+    assert out.index("v1.1.0") < out.index("v1.0.0")
+    assert "(deployed)" in out.splitlines()[0]
+
+    result = command._rollback_skill(parse(["skills", "rollback", "tdd"]))
+    assert result.success
+    assert "Rolled back tdd to" in capsys.readouterr().out
+    command._rollback_skill(parse(["skills", "rollback", "tdd", "--list"]))
+    assert "(deployed, held)" in capsys.readouterr().out.splitlines()[1]
+
+    assert command._rollback_skill(
+        parse(["skills", "rollback", "tdd", "--release"])
+    ).success
+    assert "will update again" in capsys.readouterr().out
+    result = command._rollback_skill(parse(["skills", "rollback", "tdd", "--to", "x"]))
+    assert not result.success
@@ -0,0 +1,178 @@
+"""Tests for skills lint.
+
+COVERAGE:
+- A well-formed skill passes; nested skills may prefix their directory name
+- Frontmatter, section and link problems are reported with their location,
+  including links that leave the skill directory
+- --fix corrects encoding, name format, scalar list fields and a missing
+  title in place, and reports what is left
+- Size budgets honour context_limit and only fail in strict mode
+- The command lints a directory of skills or a skill found by name
+"""
+
+import json
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.services.skills.skill_linter import (
+    find_skill_dirs,
+    lint_skill,
+    locate_skill,
+)
+
+GOOD = """---
+name: release-notes
+description: Write release notes from merged pull requests
+tags: [docs, release]
+---
+
+# Release Notes
+
+## When to Use
+
+When a release is tagged. See [the template](references/template.md).
+
+```markdown
+[not a link](missing.md)
+```
+"""
+TEMPLATE = {"references/template.md": "# Template"}
+
+
+def _skill(root, name, text, **files):
+    skill_dir = root / name
+    skill_dir.mkdir(parents=True)
+    (skill_dir / "SKILL.md").write_text(text, encoding="utf-8")
+    for relative, content in files.items():
+        path = skill_dir / relative
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(content)
+    return skill_dir
+
+
+def _rules(report):
+    return sorted((issue.rule, str(issue.severity)) for issue in report.issues)
+
+
+def test_well_formed_skill_passes(tmp_path):
+    skill_dir = _skill(tmp_path, "release-notes", GOOD, **TEMPLATE)
+    report = lint_skill(skill_dir)
+    assert report.issues == []
+    assert report.passed(strict=True)
+
+    nested = _skill(
+        tmp_path / "toolchains" / "python",
+        "core",
+        GOOD.replace("release-notes", "toolchains-python-core"),
+        **TEMPLATE,
+    )
+    assert lint_skill(nested).issues == []
+
+
+def test_reports_problems_with_locations(tmp_path):
+    (tmp_path / "shared.md").write_text("outside")
+    skill_dir = _skill(
+        tmp_path,
+        "notes",
+        "---\nname: notes\ntags: {a: 1}\n---\n\n# Notes\n\n"
+        "See [shared](../shared.md) and [gone](gone.md#top).\n",
+        **{"references/extra.md": "[Back](../SKILL.md) or [web](https://x.y)"},
+    )
+
+    report = lint_skill(skill_dir)
+
+    assert _rules(report) == [
+        ("description", "error"),
+        ("field-type", "error"),
+        ("link", "error"),
+        ("link", "error"),
+        ("section", "warning"),
+    ]
+    links = {issue.message: issue.location() for issue in report.issues}
+    assert links["link to ../shared.md leaves the skill directory"] == "SKILL.md:8"
+    assert links["broken link to gone.md#top"] == "SKILL.md:8"
+    assert not report.passed()
+
+    (skill_dir / "SKILL.md").write_text("# Notes\n")
+    report = lint_skill(skill_dir)
+    assert [issue.rule for issue in report.issues] == ["frontmatter"]
+
+
+def test_fix_corrects_mechanical_issues(tmp_path):
+    text = (
+        "\ufeff---\r\nname: Release Notes\r\n# kept comment\r\n"
+        "tags: docs, release\r\n---\r\n\r\n## When to use\r\n\r\nOn release.\r\n"
+    )
+    skill_dir = _skill(tmp_path, "release-notes", text)
+
+    report = lint_skill(skill_dir)
+    assert [issue.rule for issue in report.issues] == ["encoding", "encoding"]
+    assert all(issue.fixable for issue in report.issues)
+
+    report = lint_skill(skill_dir, fix=True)
+
+    assert _rules(report) == [("description", "error")]
+    assert {issue.rule for issue in report.fixed} == {
+        "encoding",
+        "name",
+        "field-type",
+        "section",
+    }
+    assert (skill_dir / "SKILL.md").read_bytes().decode() == (
+        "---\nname: release-notes\n# kept comment\ntags: [docs, release]\n---\n"
+        "\n# Release Notes\n\n## When to use\n\nOn release.\n"
+    )
+
+
+def test_size_budgets(tmp_path):
+    body = "\n".join(f"Step {i} of the checklist." for i in range(250))
+    skill_dir = _skill(tmp_path, "release-notes", GOOD + body, **TEMPLATE)
+
+    report = lint_skill(skill_dir, max_tokens=100_000)
+    assert [issue.message for issue in report.issues] == [
+        "SKILL.md is 265 lines (budget 200)"
+    ]
+    assert report.passed() and not report.passed(strict=True)
+
+    limited = GOOD.replace("tags:", "context_limit: 100\ntags:")
+    (skill_dir / "SKILL.md").write_text(limited + body)
+    report = lint_skill(skill_dir, max_lines=1000)
+    (message,) = [issue.message for issue in report.issues]
+    assert message.endswith("tokens (budget 100)")
+
+
+def test_lint_command(tmp_path, monkeypatch, capsys):
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+
+    monkeypatch.chdir(tmp_path)
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    skills = tmp_path / "skills"
+    _skill(skills, "release-notes", GOOD, **TEMPLATE)
+    _skill(skills / "nested", "broken", "---\nname: broken\n---\n\n# Broken\n")
+    assert find_skill_dirs(skills) == [
+        skills / "nested" / "broken",
+        skills / "release-notes",
+    ]
+
+    command = SkillsManagementCommand()
+    args = Namespace(target=str(skills), json=True, fix=False, strict=False)
+    result = command._lint_skills(args)
+    assert result.exit_code == 1
+    reports = json.loads(capsys.readouterr().out)
+    assert [report["skill"] for report in reports] == ["broken", "release-notes"]
+
+    deployed = _skill(tmp_path / ".claude" / "skills", "deployed-notes", GOOD)
+    assert locate_skill("deployed-notes") == deployed
+    args = Namespace(target="deployed-notes", json=False, fix=False, strict=True)
+    assert command._lint_skills(args).exit_code == 1
+    assert "broken link to references/template.md" in capsys.readouterr().out
+
+    args = Namespace(target="no-such-skill", json=False)
+    assert command._lint_skills(args).exit_code == 1
+
+
+@pytest.mark.parametrize("target", ["release-notes", "release-notes/SKILL.md"])
+def test_lint_single_skill_by_path(tmp_path, target):
+    _skill(tmp_path, "release-notes", GOOD, **TEMPLATE)
+    assert find_skill_dirs(tmp_path / target) == [tmp_path / "release-notes"]
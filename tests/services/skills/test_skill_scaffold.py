@@ -0,0 +1,134 @@
+"""Tests for scaffolding new skills.
+
+COVERAGE:
+- A scaffolded skill has complete frontmatter, links its examples, passes
+  skills lint without issues and, with tests, has a tests.yaml whose
+  fixture loads
+- Invalid names and descriptions are refused; an existing skill directory
+  is only written into with force
+- skills new takes its details from flags, prompts for missing ones on a
+  terminal, and fails without a name or description when it cannot prompt
+"""
+
+import io
+import sys
+from datetime import UTC, datetime
+
+import pytest
+import yaml
+from rich.prompt import Confirm, Prompt
+
+from claude_mpm.services.skills.skill_linter import lint_skill
+from claude_mpm.services.skills.skill_scaffold import (
+    SkillScaffoldError,
+    SkillSpec,
+    scaffold_skill,
+)
+from claude_mpm.services.skills.skill_testing import load_test_cases
+
+
+def _frontmatter(skill_dir):
+    return yaml.safe_load((skill_dir / "SKILL.md").read_text().split("---")[1])
+
+
+def test_scaffold(tmp_path):
+    spec = SkillSpec(
+        name="pytest-fixtures",
+        description="Share setup between tests: use fixtures, not setUp",
+        tags=["testing", "python"],
+        agent_types=["qa"],
+    )
+    written = scaffold_skill(
+        spec, tmp_path, with_tests=True, now=datetime(2026, 10, 1, tzinfo=UTC)
+    )
+    skill_dir = tmp_path / "pytest-fixtures"
+    assert [p.relative_to(skill_dir).as_posix() for p in written] == [
+        "SKILL.md",
+        "references/examples.md",
+        "tests.yaml",
+        "fixtures/example-project/README.md",
+    ]
+    meta = _frontmatter(skill_dir)
+    assert meta["skill_id"] == "pytest-fixtures"
+    assert meta["version"] == meta["skill_version"] == "0.1.0"
+    assert meta["category"] == "universal"
+    assert meta["when_to_use"] == spec.description
+    assert meta["updated_at"] == "2026-10-01T00:00:00Z"
+    assert (meta["tags"], meta["agent_types"]) == (["testing", "python"], ["qa"])
+    assert meta["progressive_disclosure"]["references"] == ["references/examples.md"]
+    body = (skill_dir / "SKILL.md").read_text()
+    assert "# Pytest Fixtures" in body
+    assert "## When to Use" in body
+
+    report = lint_skill(skill_dir)
+    assert report.issues == []
+    [case] = load_test_cases(skill_dir)
+    assert case.fixture == (skill_dir / "fixtures" / "example-project").resolve()
+
+    with pytest.raises(SkillScaffoldError, match="already exists"):
+        scaffold_skill(spec, tmp_path)
+    assert len(scaffold_skill(spec, tmp_path, force=True)) == 2
+
+    with pytest.raises(SkillScaffoldError, match="lowercase"):
+        scaffold_skill(SkillSpec("Pytest_Fixtures", "x"), tmp_path)
+    with pytest.raises(SkillScaffoldError, match="description is required"):
+        scaffold_skill(SkillSpec("empty", "  "), tmp_path)
+
+
+class Terminal(io.StringIO):
+    def isatty(self):
+        return True
+
+
+def test_new_command(tmp_path, monkeypatch, capsys):
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    parse = create_parser().parse_args
+    command = SkillsManagementCommand()
+    monkeypatch.setattr(sys, "stdin", io.StringIO())
+
+    args = parse(["skills", "new", "go-errors", "--dir", str(tmp_path)])
+    assert not command._new_skill(args).success
+    assert "--description are required" in capsys.readouterr().out
+
+    args = parse(
+        [
+            "skills",
+            "new",
+            "go-errors",
+            "--description",
+            "Wrap Go errors with context",
+            "--tag",
+            "go",
+            "--category",
+            "toolchains",
+            "--dir",
+            str(tmp_path),
+        ]
+    )
+    assert command._new_skill(args).success
+    out = capsys.readouterr().out
+    assert "Created skill go-errors" in out
+    assert "skills test" not in out
+    meta = _frontmatter(tmp_path / "go-errors")
+    assert (meta["category"], meta["tags"]) == ("toolchains", ["go"])
+    assert not (tmp_path / "go-errors" / "tests.yaml").exists()
+
+    # On a terminal, what the flags leave out is asked for
+    monkeypatch.setattr(sys, "stdin", Terminal())
+    answers = iter(["api-docs", "Document HTTP APIs", "", "docs, api", ""])
+
+    def answer(*args, default=None, **kwargs):
+        return next(answers) or default
+
+    monkeypatch.setattr(Prompt, "ask", answer)
+    monkeypatch.setattr(Confirm, "ask", lambda *args, **kwargs: True)
+    args = parse(["skills", "new", "--category", "docs", "--dir", str(tmp_path)])
+    assert command._new_skill(args).success
+    assert "skills test" in capsys.readouterr().out
+    meta = _frontmatter(tmp_path / "api-docs")
+    assert meta["when_to_use"] == "Document HTTP APIs"
+    assert meta["tags"] == ["docs", "api"]
+    assert "agent_types" not in meta
+    assert (tmp_path / "api-docs" / "tests.yaml").is_file()
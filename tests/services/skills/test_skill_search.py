@@ -0,0 +1,135 @@
+"""Tests for searching skills across skill sources.
+
+COVERAGE:
+- Synced sources are searched in the cache, others through their index
+- Every query word must match; name matches rank above tags and descriptions
+- A skill provided by several sources is listed once per source, marked
+  with the source that overrides it
+- Sources that cannot be searched are skipped with the reason
+- The command prints the matches as a table or JSON
+"""
+
+import json
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.skill_search import search_skills
+
+INDEX = {
+    "skills": {
+        "universal": [
+            {
+                "name": "release-notes",
+                "version": "2.1.0",
+                "description": "Write release notes",
+            }
+        ],
+        "toolchains": {
+            "python": [
+                {
+                    "name": "pytest-patterns",
+                    "metadata": {
+                        "version": "1.4.0",
+                        "description": "Fixtures and parametrization",
+                        "tags": ["testing", "release-gating"],
+                    },
+                }
+            ]
+        },
+    }
+}
+
+
+def _fetch_index(source):
+    if source.id == "private":
+        raise ValueError("SSH sources have no index")
+    return INDEX
+
+
+@pytest.fixture
+def config(tmp_path):
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save(
+        [
+            SkillSource(
+                id="system", type="git", url="https://github.com/o/s", priority=0
+            ),
+            SkillSource(
+                id="org", type="git", url="https://github.com/org/s", priority=50
+            ),
+            SkillSource(
+                id="private", type="git", url="git@github.com:org/s.git", priority=60
+            ),
+        ]
+    )
+    skill_dir = tmp_path / "cache" / "org" / "release-notes"
+    skill_dir.mkdir(parents=True)
+    (skill_dir / "SKILL.md").write_text(
+        "---\nname: release-notes\ndescription: Org release notes template\n"
+        "skill_version: 3.0.0\ntags: [docs]\n---\n\n# Release Notes\n"
+    )
+    return config
+
+
+def _search(tmp_path, config, query, **kwargs):
+    return search_skills(
+        query,
+        config=config,
+        cache_dir=tmp_path / "cache",
+        fetch_index=_fetch_index,
+        **kwargs,
+    )
+
+
+def test_search_across_cache_and_index(tmp_path, config):
+    result = _search(tmp_path, config, "release")
+
+    found = [(m.name, m.source_id, m.version, m.origin) for m in result.matches]
+    assert found == [
+        ("release-notes", "system", "2.1.0", "index"),
+        ("release-notes", "org", "3.0.0", "cache"),
+        ("pytest-patterns", "system", "1.4.0", "index"),
+    ]
+    assert result.matches[1].shadowed_by == "system"
+    assert result.matches[2].score < result.matches[0].score
+    assert result.skipped == {"private": "SSH sources have no index"}
+
+
+def test_every_word_must_match(tmp_path, config):
+    result = _search(tmp_path, config, "Release TEMPLATE")
+    assert [(m.name, m.source_id) for m in result.matches] == [
+        ("release-notes", "org")
+    ]
+    assert _search(tmp_path, config, "release kubernetes").matches == []
+
+    result = _search(tmp_path, config, "release", source_id="org")
+    assert [m.source_id for m in result.matches] == ["org"]
+    assert result.skipped == {}
+    result = _search(tmp_path, config, "release", source_id="nope")
+    assert result.skipped == {"nope": "not an enabled skill source"}
+
+
+def test_search_command(tmp_path, config, monkeypatch, capsys):
+    from claude_mpm.cli.commands import skills
+    from claude_mpm.services.skills import skill_search
+
+    def search(query, source_id=None):
+        return _search(tmp_path, config, query, source_id=source_id)
+
+    monkeypatch.setattr(skill_search, "search_skills", search)
+    command = skills.SkillsManagementCommand()
+
+    args = Namespace(query=["pytest"], source=None, json=True)
+    assert command._search_skills(args).exit_code == 0
+    output = json.loads(capsys.readouterr().out)
+    assert [m["name"] for m in output["matches"]] == ["pytest-patterns"]
+
+    monkeypatch.setattr(skills, "console", skills.Console(width=200))
+    args = Namespace(query=["release"], source=None, json=False)
+    assert command._search_skills(args).exit_code == 0
+    output = capsys.readouterr().out
+    assert "overridden by system" in output
+    assert "Skipped source 'private'" in output
+    assert "3 match(es)" in output
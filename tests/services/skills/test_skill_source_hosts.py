@@ -0,0 +1,217 @@
+"""Tests for skill sources hosted on GitLab and Bitbucket.
+
+COVERAGE:
+- GitLab (including self-hosted) and Bitbucket URLs validate, an explicit
+  provider is persisted, and unknown hosts are still rejected
+- Each host builds its API, archive and raw-file URLs and sends tokens the
+  way the service expects, falling back to its own environment variables
+- Sources sync from an archive of the branch head, skip unsafe and
+  irrelevant members, leave a cache at the same commit alone and drop files
+  a new commit removed
+- Branch heads resolve to commits for skills.lock
+"""
+
+import io
+import tarfile
+
+import pytest
+import requests
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_hosts import (
+    BitbucketHost,
+    GitLabHost,
+    archive_member_path,
+    get_host,
+)
+from claude_mpm.services.skills.git_skill_source_manager import (
+    GitSkillSourceManager,
+    resolve_source_commit,
+)
+
+GITLAB_URL = "https://gitlab.example.com/org/platform/skills.git"
+PROJECT_API = "https://gitlab.example.com/api/v4/projects/org%2Fplatform%2Fskills"
+SKILL = "---\nname: tdd\ndescription: Test first\n---\n\n{}\n"
+
+
+@pytest.fixture(autouse=True)
+def no_host_tokens(monkeypatch):
+    for name in (
+        "GITLAB_TOKEN",
+        "CI_JOB_TOKEN",
+        "BITBUCKET_TOKEN",
+        "BITBUCKET_USERNAME",
+        "BITBUCKET_APP_PASSWORD",
+    ):
+        monkeypatch.delenv(name, raising=False)
+
+
+def _archive(top: str, files: dict[str, str]) -> bytes:
+    buffer = io.BytesIO()
+    with tarfile.open(fileobj=buffer, mode="w:gz") as tar:
+        for name, text in files.items():
+            data = text.encode()
+            info = tarfile.TarInfo(f"{top}/{name}" if top else name)
+            info.size = len(data)
+            tar.addfile(info, io.BytesIO(data))
+    return buffer.getvalue()
+
+
+class FakeResponse:
+    def __init__(self, status_code=200, json_data=None, content=b""):
+        self.status_code = status_code
+        self.reason = "OK" if status_code == 200 else "Not Found"
+        self._json = json_data
+        self.content = content
+
+    def json(self):
+        return self._json
+
+    def raise_for_status(self):
+        if self.status_code >= 400:
+            raise requests.HTTPError(f"HTTP {self.status_code}")
+
+    def iter_content(self, chunk_size):
+        yield self.content
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        return False
+
+
+class FakeGitLab:
+    """Serves one GitLab project whose branch head can be moved."""
+
+    def __init__(self):
+        self.head = "a" * 40
+        self.archives = {}
+        self.requests = []
+
+    def __call__(self, url, headers=None, **kwargs):
+        self.requests.append((url, headers))
+        if url == f"{PROJECT_API}/repository/branches/main":
+            return FakeResponse(json_data={"commit": {"id": self.head}})
+        prefix = f"{PROJECT_API}/repository/archive.tar.gz?sha="
+        if url.startswith(prefix):
+            return FakeResponse(content=self.archives[url.removeprefix(prefix)])
+        return FakeResponse(404)
+
+
+def test_gitlab_and_bitbucket_urls_validate(tmp_path):
+    assert SkillSource(id="a", type="git", url=GITLAB_URL).hosting == "gitlab"
+    assert (
+        SkillSource(id="b", type="git", url="https://bitbucket.org/ws/skills").hosting
+        == "bitbucket"
+    )
+    with pytest.raises(ValueError, match="set provider for a self-hosted GitLab"):
+        SkillSource(id="c", type="git", url="https://git.example.com/org/skills")
+    with pytest.raises(ValueError, match="Provider must be one of"):
+        SkillSource(
+            id="c", type="git", url="https://git.example.com/org/s", provider="svn"
+        )
+
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save(
+        [
+            SkillSource(
+                id="c",
+                type="git",
+                url="https://git.example.com/org/skills",
+                provider="gitlab",
+            )
+        ]
+    )
+    (source,) = config.load()
+    assert (source.provider, source.hosting) == ("gitlab", "gitlab")
+    assert isinstance(get_host(source), GitLabHost)
+    github = SkillSource(id="d", type="git", url="https://github.com/o/r")
+    assert get_host(github) is None
+
+
+def test_host_urls_and_auth(monkeypatch):
+    gitlab = GitLabHost(SkillSource(id="a", type="git", url=GITLAB_URL))
+    assert gitlab.branch_url("feature/x") == (
+        f"{PROJECT_API}/repository/branches/feature%2Fx"
+    )
+    assert gitlab.archive_url("v1") == f"{PROJECT_API}/repository/archive.tar.gz?sha=v1"
+    assert gitlab.raw_url("main", "dir/manifest.json") == (
+        f"{PROJECT_API}/repository/files/dir%2Fmanifest.json/raw?ref=main"
+    )
+    assert gitlab.auth_headers() == {}
+    monkeypatch.setenv("CI_JOB_TOKEN", "job")
+    assert gitlab.auth_headers() == {"JOB-TOKEN": "job"}
+    monkeypatch.setenv("GITLAB_TOKEN", "glpat-env")
+    assert gitlab.auth_headers() == {"PRIVATE-TOKEN": "glpat-env"}
+    monkeypatch.setenv("SKILLS_TOKEN", "glpat-source")
+    gitlab.source.token = "$SKILLS_TOKEN"
+    assert gitlab.auth_headers() == {"PRIVATE-TOKEN": "glpat-source"}
+
+    bitbucket = BitbucketHost(
+        SkillSource(id="b", type="git", url="https://bitbucket.org/ws/skills.git")
+    )
+    api = "https://api.bitbucket.org/2.0/repositories/ws/skills"
+    assert bitbucket.branch_url("main") == f"{api}/refs/branches/main"
+    assert bitbucket.archive_url("abc") == (
+        "https://bitbucket.org/ws/skills/get/abc.tar.gz"
+    )
+    assert bitbucket.raw_url("main", "manifest.json") == f"{api}/src/main/manifest.json"
+    monkeypatch.setenv("BITBUCKET_USERNAME", "me")
+    monkeypatch.setenv("BITBUCKET_APP_PASSWORD", "secret")
+    assert bitbucket.auth_headers() == {"Authorization": "Basic bWU6c2VjcmV0"}
+    monkeypatch.setenv("BITBUCKET_TOKEN", "access")
+    assert bitbucket.auth_headers() == {"Authorization": "Bearer access"}
+    bitbucket.source.token = "me:secret"
+    assert bitbucket.auth_headers() == {"Authorization": "Basic bWU6c2VjcmV0"}
+
+
+def test_gitlab_source_syncs_from_archive(tmp_path, monkeypatch):
+    server = FakeGitLab()
+    monkeypatch.setattr(requests, "get", server)
+    monkeypatch.setenv("GITLAB_TOKEN", "glpat")
+    server.archives[server.head] = _archive(
+        "skills-aaaa",
+        {
+            "tdd/SKILL.md": SKILL.format("Write the test first."),
+            "tdd/scripts/run.sh": "pytest\n",
+            "notes.bin": "ignored",
+        },
+    )
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save([SkillSource(id="org", type="git", url=GITLAB_URL)])
+    manager = GitSkillSourceManager(config=config, cache_dir=tmp_path / "cache")
+    cache = tmp_path / "cache" / "org"
+
+    result = manager.sync_source("org")
+    assert result["synced"], result
+    assert (result["files_updated"], result["skills_discovered"]) == (2, 1)
+    assert (cache / "tdd" / "scripts" / "run.sh").read_text() == "pytest\n"
+    assert not (cache / "notes.bin").exists()
+    assert all(headers == {"PRIVATE-TOKEN": "glpat"} for _, headers in server.requests)
+
+    server.requests.clear()
+    result = manager.sync_source("org")
+    assert (result["files_updated"], result["files_cached"]) == (0, 2)
+    assert len(server.requests) == 1  # branch lookup only
+
+    server.head = "b" * 40
+    server.archives[server.head] = _archive(
+        "skills-bbbb", {"tdd/SKILL.md": SKILL.format("Red, green, refactor.")}
+    )
+    result = manager.sync_source("org")
+    assert (result["files_updated"], result["files_cached"]) == (1, 0)
+    assert "refactor" in (cache / "tdd" / "SKILL.md").read_text()
+    assert not (cache / "tdd" / "scripts").exists()
+
+    assert resolve_source_commit(config.get_source("org")) == "b" * 40
+    monkeypatch.setattr(requests, "get", lambda url, **kw: FakeResponse(404))
+    with pytest.raises(RuntimeError, match="Could not resolve"):
+        resolve_source_commit(config.get_source("org"))
+
+
+def test_archive_member_paths():
+    assert archive_member_path("skills-abc/tdd/SKILL.md") == "tdd/SKILL.md"
+    assert archive_member_path("skills-abc") is None
+    assert archive_member_path("skills-abc/../../etc/passwd") is None
+    assert archive_member_path("/skills-abc/tdd/SKILL.md") is None
@@ -0,0 +1,132 @@
+"""Tests for skill sources reached over SSH with deploy keys.
+
+COVERAGE:
+- SSH URLs (scp-like and ssh://) validate, persist with their ssh_key and
+  reject an ssh_key on HTTPS URLs
+- The per-source key is the only identity ssh offers
+- SSH sources sync as a git clone, pick up new commits on the next sync and
+  fail clearly when the key file is missing
+- skill-source add --ssh-key stores the key
+"""
+
+import subprocess
+
+import pytest
+
+from claude_mpm.config.skill_sources import (
+    SkillSource,
+    SkillSourceConfiguration,
+    parse_ssh_url,
+)
+from claude_mpm.services.skills.git_skill_source_manager import (
+    GitSkillSourceManager,
+    _git_ssh_env,
+    check_ssh_source_access,
+)
+
+SSH_URL = "git@github.com:org/skills.git"
+
+
+def _git(cwd, *args):
+    subprocess.run(["git", *args], cwd=cwd, check=True, capture_output=True)
+
+
+@pytest.fixture
+def remote(tmp_path, monkeypatch):
+    """A local repository that SSH_URL is rewritten to."""
+    repo = tmp_path / "remote" / "skills.git"
+    (repo / "tdd").mkdir(parents=True)
+    (repo / "tdd" / "SKILL.md").write_text(
+        "---\nname: tdd\ndescription: Test first\n---\n\nWrite the test first.\n"
+    )
+    _git(repo, "init", "-q", "-b", "main")
+    _git(repo, "add", "-A")
+    _git(repo, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "one")
+    monkeypatch.setenv("GIT_CONFIG_COUNT", "1")
+    monkeypatch.setenv("GIT_CONFIG_KEY_0", f"url.{repo.parent}/.insteadOf")
+    monkeypatch.setenv("GIT_CONFIG_VALUE_0", "git@github.com:org/")
+    return repo
+
+
+def test_ssh_urls_validate_and_persist(tmp_path):
+    assert parse_ssh_url(SSH_URL) == ("github.com", "org/skills.git")
+    assert parse_ssh_url("ssh://git@gitlab.example.com:2222/org/skills.git") == (
+        "gitlab.example.com",
+        "/org/skills.git",
+    )
+    assert parse_ssh_url("https://github.com/org/skills") is None
+
+    with pytest.raises(ValueError, match="owner/repo"):
+        SkillSource(id="s", type="git", url="git@github.com:skills.git")
+    with pytest.raises(ValueError, match="ssh_key requires an SSH URL"):
+        SkillSource(
+            id="s", type="git", url="https://github.com/org/skills", ssh_key="~/k"
+        )
+
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save([SkillSource(id="org", type="git", url=SSH_URL, ssh_key="~/.ssh/k")])
+    (source,) = config.load()
+    assert source.is_ssh
+    assert source.ssh_key == "~/.ssh/k"
+    assert not source.ssh_key_path.as_posix().startswith("~")
+
+
+def test_ssh_key_is_the_only_identity(tmp_path, monkeypatch):
+    monkeypatch.setenv("DEPLOY_KEYS", str(tmp_path))
+    source = SkillSource(
+        id="org", type="git", url=SSH_URL, ssh_key="$DEPLOY_KEYS/skills key"
+    )
+
+    command = _git_ssh_env(source)["GIT_SSH_COMMAND"]
+
+    assert f"-i '{tmp_path}/skills key'" in command
+    assert "IdentitiesOnly=yes" in command
+    assert "BatchMode=yes" in command
+
+
+def test_ssh_source_syncs_as_git_clone(tmp_path, remote):
+    key = tmp_path / "deploy_key"
+    key.write_text("not a real key\n")
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save([SkillSource(id="org", type="git", url=SSH_URL, ssh_key=str(key))])
+    manager = GitSkillSourceManager(config=config, cache_dir=tmp_path / "cache")
+    assert check_ssh_source_access(config.get_source("org")) is None
+
+    result = manager.sync_source("org")
+    assert result["synced"], result
+    assert (result["files_updated"], result["skills_discovered"]) == (1, 1)
+
+    (remote / "tdd" / "SKILL.md").write_text(
+        "---\nname: tdd\ndescription: Test first\n---\n\nRed, green, refactor.\n"
+    )
+    (remote / "README.md").write_text("skills\n")
+    _git(remote, "add", "-A")
+    _git(remote, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "two")
+
+    result = manager.sync_source("org")
+    assert (result["files_updated"], result["files_cached"]) == (2, 0)
+    cached = tmp_path / "cache" / "org" / "tdd" / "SKILL.md"
+    assert "refactor" in cached.read_text()
+
+    key.unlink()
+    result = manager.sync_source("org")
+    assert not result["synced"]
+    assert "SSH key not found" in result["error"]
+
+
+def test_add_stores_ssh_key(tmp_path, monkeypatch):
+    from claude_mpm.cli.commands.skill_source import handle_add_skill_source
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    config_path = tmp_path / "skill_sources.yaml"
+    monkeypatch.setattr(
+        "claude_mpm.cli.commands.skill_source.SkillSourceConfiguration",
+        lambda: SkillSourceConfiguration(config_path=config_path),
+    )
+    args = create_parser().parse_args(
+        ["skill-source", "add", SSH_URL, "--ssh-key", "~/.ssh/k", "--no-test"]
+    )
+
+    assert handle_add_skill_source(args) == 0
+    source = SkillSourceConfiguration(config_path=config_path).get_source("skills")
+    assert (source.url, source.ssh_key) == (SSH_URL, "~/.ssh/k")
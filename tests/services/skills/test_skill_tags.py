@@ -0,0 +1,86 @@
+"""Tests for deploying skills selected by tag.
+
+COVERAGE:
+- A skill's tags are its frontmatter tags and the folders it sits in within
+  its source, matched case-insensitively
+- skills deploy --tag deploys only the skills carrying any given tag, at
+  user and project scope, together with --skill names; no match deploys
+  nothing and fails
+"""
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.selective_skill_deployer import (
+    select_skills_by_tag,
+    skill_tags,
+)
+
+SKILL = "---\nname: {name}\ndescription: {name} skill\ntags: {tags}\n---\n\nBody\n"
+
+
+def _skill(root, path, tags="[]"):
+    name = path.rsplit("/", 1)[-1]
+    (root / path).mkdir(parents=True)
+    (root / path / "SKILL.md").write_text(SKILL.format(name=name, tags=tags))
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    monkeypatch.chdir(tmp_path)
+    skills = tmp_path / "skills"
+    _skill(skills, "universal/secrets-scan", "[Security]")
+    _skill(skills, "toolchains/golang/testing")
+    _skill(skills, "toolchains/python/pytest", "[testing]")
+    _skill(skills, "universal/tdd", "testing")
+    SkillSourceConfiguration().save(
+        [SkillSource(id="mine", type="local", url=str(skills))]
+    )
+    return tmp_path
+
+
+def test_skill_tags():
+    skill = {"tags": ["Security", " "], "relative_path": "a/b/scan/SKILL.md"}
+    assert skill_tags(skill) == {"security", "a", "b"}
+    assert skill_tags({"tags": "go"}) == {"go"}
+    skills = [
+        skill,
+        {"name": "tdd", "tags": ["testing"]},
+        {"name": "other", "relative_path": "other/SKILL.md"},
+    ]
+    assert select_skills_by_tag(skills, ["B", "testing"]) == skills[:2]
+    assert select_skills_by_tag(skills, ["other"]) == []
+
+
+def test_deploy_by_tag(home, capsys):
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    parse = create_parser().parse_args
+    command = SkillsManagementCommand()
+    args = parse(
+        ["skills", "deploy", "--scope", "user", "--tag", "security", "--tag", "golang"]
+    )
+    assert args.tags == ["security", "golang"]
+    assert command._deploy_skills(args).success
+    assert "2 of 4 skill(s) tagged security, golang" in capsys.readouterr().out
+    deployed = home / ".claude" / "skills"
+    assert sorted(p.name for p in deployed.iterdir() if p.is_dir()) == [
+        "toolchains-golang-testing",
+        "universal-secrets-scan",
+    ]
+
+    args = parse(["skills", "deploy", "--tag", "TESTING", "--skill", "secrets-scan"])
+    assert command._deploy_skills(args).success
+    assert "2 of 4 skill(s) tagged TESTING" in capsys.readouterr().out
+    project = home / ".claude-mpm" / "skills"
+    assert sorted(p.name for p in project.iterdir() if p.is_dir()) == [
+        "toolchains-python-pytest",
+        "universal-secrets-scan",
+        "universal-tdd",
+    ]
+
+    result = command._deploy_skills(parse(["skills", "deploy", "--tag", "rust"]))
+    assert not result.success
+    assert "No skill carries these tags" in capsys.readouterr().out
@@ -0,0 +1,226 @@
+"""Tests for the skill testing harness.
+
+COVERAGE:
+- tests.yaml parsing: defaults, tool expectations with input patterns,
+  fixtures, and malformed files
+- Cases run with the skill in the system prompt, in a scratch copy of their
+  fixture, with tools outside allow_tools denied
+- Tool and output expectations pass and fail as written; agent errors fail
+- The command tests a directory of skills, filters cases, writes JUnit XML
+  and exits non-zero on failure
+"""
+
+import json
+from argparse import Namespace
+from pathlib import Path
+from xml.etree import ElementTree as ET
+
+import pytest
+
+from claude_mpm.cli.commands.skills import SkillsManagementCommand
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.skills import skill_testing
+from claude_mpm.services.skills.skill_testing import (
+    SkillTestError,
+    SkillTester,
+    load_test_cases,
+)
+
+SKILL = """---
+name: pytest-fixtures
+description: Prefer pytest fixtures to setUp methods
+---
+
+# Pytest Fixtures
+
+Use `@pytest.fixture` for shared setup.
+"""
+
+TESTS = """\
+model: haiku
+allow_tools: [Read]
+cases:
+  - name: suggests a fixture
+    prompt: Share setup between my tests
+    fixture: fixtures/project
+    expect:
+      tools: [Read, {tool: Edit, input: "pytest\\\\.fixture"}]
+      no_tools: [Bash]
+      output: "pytest\\\\.fixture"
+      no_output: ["setUp\\\\("]
+  - name: answers without tools
+    prompt: What is a fixture?
+    max_turns: 2
+    expect:
+      output: ["fixture"]
+"""
+
+
+@pytest.fixture
+def skill_dir(tmp_path):
+    skill = tmp_path / "skills" / "pytest-fixtures"
+    (skill / "fixtures" / "project").mkdir(parents=True)
+    (skill / "fixtures" / "project" / "test_db.py").write_text("class T: ...\n")
+    (skill / "SKILL.md").write_text(SKILL)
+    (skill / "tests.yaml").write_text(TESTS)
+    return skill
+
+
+class FakeAgent:
+    """Answers by prompt and asks the tool guard about each scripted call."""
+
+    runs: list = []
+
+    def __init__(self, config, answers):
+        self.config = config
+        self.answers = answers
+
+    async def run_with_hooks(self, prompt, tool_guard=None, config=None):
+        text, calls = self.answers[prompt]
+        cwd = Path(config.cwd)
+        FakeAgent.runs.append(
+            {
+                "config": config,
+                "files": sorted(p.name for p in cwd.iterdir()),
+            }
+        )
+        tool_calls = [
+            {
+                "tool_name": name,
+                "input": tool_input,
+                "approved": await tool_guard(name, tool_input),
+            }
+            for name, tool_input in calls
+        ]
+        if isinstance(text, Exception):
+            raise text
+        return AgentResult(text=text, tool_calls=tool_calls, cost_usd=0.01)
+
+
+def factory(answers):
+    FakeAgent.runs = []
+    return lambda config: FakeAgent(config, answers)
+
+
+GOOD = {
+    "Share setup between my tests": (
+        "Added a @pytest.fixture for the database.",
+        [
+            ("Read", {"file_path": "test_db.py"}),
+            ("Edit", {"new_string": "@pytest.fixture\ndef db(): ..."}),
+        ],
+    ),
+    "What is a fixture?": ("A fixture is shared setup.", []),
+}
+
+
+def test_load_test_cases(skill_dir):
+    first, second = load_test_cases(skill_dir)
+    assert first.name == "suggests a fixture"
+    assert [str(t) for t in first.tools] == ["Read", r"Edit /pytest\.fixture/"]
+    assert first.output == [r"pytest\.fixture"]
+    assert first.fixture == (skill_dir / "fixtures" / "project").resolve()
+    assert (first.model, first.allow_tools, first.max_turns) == ("haiku", ("Read",), 8)
+    assert (second.fixture, second.max_turns) == (None, 2)
+
+    (skill_dir / "tests.yaml").write_text("- prompt: hi\n  expect: {output: '('}\n")
+    with pytest.raises(SkillTestError, match="bad pattern"):
+        load_test_cases(skill_dir)
+    (skill_dir / "tests.yaml").write_text("- name: no prompt\n")
+    with pytest.raises(SkillTestError, match="case 1 has no prompt"):
+        load_test_cases(skill_dir)
+    (skill_dir / "tests.yaml").write_text("- prompt: hi\n  fixture: gone\n")
+    with pytest.raises(SkillTestError, match="no fixture directory"):
+        load_test_cases(skill_dir)
+
+
+def test_cases_pass(skill_dir):
+    tester = SkillTester(skill_dir, runtime_factory=factory(GOOD))
+    report = tester.run()
+    assert report.skill == "pytest-fixtures"
+    assert report.passed
+    assert [r.tools_called for r in report.results] == [["Read", "Edit"], []]
+
+    first, second = FakeAgent.runs
+    assert "<skill name=\"pytest-fixtures\">" in first["config"].system_prompt
+    assert "Use `@pytest.fixture`" in first["config"].system_prompt
+    assert first["config"].model == "haiku"
+    # Each case runs in a scratch copy of its fixture
+    assert first["files"] == ["test_db.py"]
+    assert second["files"] == []
+    assert not Path(first["config"].cwd).exists()
+
+
+def test_tools_outside_allow_tools_are_denied(skill_dir):
+    approvals = []
+
+    class Recording(FakeAgent):
+        async def run_with_hooks(self, prompt, tool_guard=None, config=None):
+            result = await super().run_with_hooks(prompt, tool_guard, config)
+            approvals.extend(call["approved"] for call in result.tool_calls)
+            return result
+
+    tester = SkillTester(skill_dir, runtime_factory=lambda c: Recording(c, GOOD))
+    assert tester.run(["suggests"]).passed
+    assert approvals == [True, False]
+
+
+def test_cases_fail(skill_dir):
+    answers = {
+        "Share setup between my tests": (
+            "Override setUp() instead.",
+            [("Bash", {"command": "pytest"}), ("Edit", {"new_string": "setUp"})],
+        ),
+        "What is a fixture?": (RuntimeError("SDK not installed"), []),
+    }
+    report = SkillTester(
+        skill_dir, runtime_factory=factory(answers), model="sonnet"
+    ).run()
+    first, second = report.results
+    assert first.failures == [
+        "expected a Read call",
+        r"expected a Edit /pytest\.fixture/ call",
+        "unexpected Bash call",
+        r"output does not match /pytest\.fixture/",
+        r"output matches /setUp\(/",
+    ]
+    assert second.error == "SDK not installed"
+    assert FakeAgent.runs[0]["config"].model == "sonnet"
+
+
+def test_command(skill_dir, tmp_path, monkeypatch, capsys):
+    (tmp_path / "skills" / "untested").mkdir()
+    (tmp_path / "skills" / "untested" / "SKILL.md").write_text(SKILL)
+    monkeypatch.setattr(skill_testing, "_default_runtime_factory", factory(GOOD))
+    junit = tmp_path / "results.xml"
+    args = Namespace(
+        skills_command="test",
+        target=str(tmp_path / "skills"),
+        cases=None,
+        model=None,
+        junit=str(junit),
+        jobs=2,
+        json=True,
+    )
+    result = SkillsManagementCommand().run(args)
+    assert result.exit_code == 0
+    (report,) = json.loads(capsys.readouterr().out)
+    assert report["skill"] == "pytest-fixtures" and report["passed"]
+    suite = ET.parse(junit).getroot().find("testsuite")
+    assert (suite.get("tests"), suite.get("failures")) == ("2", "0")
+
+    args.cases = ["without tools"]
+    monkeypatch.setattr(
+        skill_testing,
+        "_default_runtime_factory",
+        factory({"What is a fixture?": ("No idea.", [])}),
+    )
+    assert SkillsManagementCommand().run(args).exit_code == 1
+    (report,) = json.loads(capsys.readouterr().out)
+    assert [case["failures"] for case in report["cases"]] == [
+        ["output does not match /fixture/"]
+    ]
+    assert ET.parse(junit).getroot().find("testsuite/testcase/failure") is not None
+
+    args.target = str(tmp_path / "skills" / "untested")
+    assert SkillsManagementCommand().run(args).exit_code == 1
@@ -0,0 +1,159 @@
+"""Tests for finding deployed skills that changed upstream.
+
+COVERAGE:
+- A synced SSH source whose branch moved reports each deployed skill that
+  changed, with versions, files and commits; undeployed skills are skipped
+- The skills.lock pin is the deployed commit when the project has one
+- Sources never synced are reported with an error
+- Reports are saved per project and turned into the startup notice
+- skills outdated prints the report
+"""
+
+import shutil
+import subprocess
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skill_updates import (
+    UpdateReport,
+    check_skill_updates,
+    load_report,
+    notice_enabled,
+    save_report,
+    update_notice,
+)
+from claude_mpm.services.skills.skills_lock import SkillsLock
+
+SSH_URL = "git@github.com:org/skills.git"
+
+
+def _git(cwd, *args):
+    return subprocess.run(
+        ["git", *args], cwd=cwd, check=True, capture_output=True, text=True
+    ).stdout.strip()
+
+
+def _commit(repo, name, version, message):
+    (repo / name).mkdir(exist_ok=True)
+    (repo / name / "SKILL.md").write_text(
+        f"---\nname: {name}\ndescription: The {name} skill\n"
+        f"version: {version}\n---\n\n{message}\n"
+    )
+    _git(repo, "add", "-A")
+    _git(repo, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", message)
+    return _git(repo, "rev-parse", "HEAD")
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    monkeypatch.chdir(tmp_path)
+    return tmp_path
+
+
+@pytest.fixture
+def remote(home, monkeypatch):
+    """A local repository that SSH_URL is rewritten to."""
+    repo = home / "remote" / "skills.git"
+    repo.mkdir(parents=True)
+    _git(repo, "init", "-q", "-b", "main")
+    monkeypatch.setenv("GIT_CONFIG_COUNT", "1")
+    monkeypatch.setenv("GIT_CONFIG_KEY_0", f"url.{repo.parent}/.insteadOf")
+    monkeypatch.setenv("GIT_CONFIG_VALUE_0", "git@github.com:org/")
+    return repo
+
+
+@pytest.fixture
+def deployed(home, remote):
+    """The org source synced at its first commit, with tdd deployed."""
+    first = _commit(remote, "tdd", "1.0.0", "Add tdd")
+    _commit(remote, "debugging", "1.0.0", "Add debugging")
+    config = SkillSourceConfiguration()
+    config.save([SkillSource(id="org", type="git", url=SSH_URL)])
+    manager = GitSkillSourceManager(config)
+    manager.sync_source("org")
+    target = home / ".claude" / "skills"
+    manager.deploy_source("org", target)
+    shutil.rmtree(target / "debugging")
+    return config, first
+
+
+def _check(home, config, **kwargs):
+    return check_skill_updates(config=config, project_dir=home, **kwargs)
+
+
+def test_reports_changed_deployed_skills(home, remote, deployed):
+    config, _ = deployed
+    synced = _git(remote, "rev-parse", "HEAD")
+
+    status = _check(home, config).sources[0]
+    assert (status.current, status.latest, status.updates) == (synced, synced, [])
+
+    _commit(remote, "tdd", "1.1.0", "Write the test first")
+    _commit(remote, "debugging", "1.1.0", "Bisect")
+    head = _git(remote, "rev-parse", "HEAD")
+
+    (status,) = _check(home, config).sources
+    assert status.moved and status.latest == head and status.error is None
+    (update,) = status.updates
+    assert (update.name, update.path, update.files) == ("tdd", "tdd", ["SKILL.md"])
+    assert (update.deployed_version, update.latest_version) == ("1.0.0", "1.1.0")
+    assert [c.split(" ", 1)[1] for c in update.commits] == ["Write the test first"]
+
+    # The cached clone's checkout is untouched by the check
+    cached = home / ".claude-mpm" / "cache" / "skills" / "org" / "tdd" / "SKILL.md"
+    assert "version: 1.0.0" in cached.read_text()
+
+
+def test_lock_pin_is_the_deployed_commit(home, remote, deployed):
+    config, first = deployed
+    SkillsLock(home).pin(config.get_source("org"), first)
+    head = _git(remote, "rev-parse", "HEAD")
+
+    (status,) = _check(home, config, resolve_head=lambda source: head).sources
+    assert status.current == first
+    # Only debugging changed after the pin, and it is not deployed
+    assert status.moved and status.updates == []
+
+
+def test_unsynced_source_is_an_error(home):
+    config = SkillSourceConfiguration()
+    config.save([SkillSource(id="org", type="git", url=SSH_URL)])
+
+    (status,) = _check(home, config, resolve_head=lambda source: "f" * 40).sources
+    assert status.error == "not synced yet" and not status.moved
+
+
+def test_saved_report_and_notice(home, remote, deployed):
+    config, _ = deployed
+    assert load_report(home) is None
+    assert update_notice(None) is None
+
+    _commit(remote, "tdd", "1.1.0", "Write the test first")
+    save_report(_check(home, config), home)
+    save_report(UpdateReport(), home / "elsewhere")
+
+    report = load_report(home)
+    assert [u.name for u in report.updates] == ["tdd"]
+    assert update_notice(report).startswith("1 skill has updates;")
+    assert update_notice(load_report(home / "elsewhere")) is None
+
+    assert notice_enabled({"skills.update_notice": True})
+    assert not notice_enabled({"skills.update_notice": False})
+
+
+def test_outdated_command(home, remote, deployed, capsys):
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    _commit(remote, "tdd", "1.1.0", "Write the test first")
+    args = create_parser().parse_args(["skills", "outdated", "--source", "org"])
+    assert args.sources == ["org"]
+
+    assert SkillsManagementCommand()._outdated_skills(args).success
+    out = capsys.readouterr().out
+    assert "tdd 1.0.0 → 1.1.0" in out and "Write the test first" in out
+    assert "1 skill(s) have updates" in out and "skill-source update" in out
+    assert [u.name for u in load_report(home).updates] == ["tdd"]
@@ -0,0 +1,159 @@
+"""Tests for the skills.lock commit pins.
+
+COVERAGE:
+- Pins are recorded per source, ignored once the source's URL or branch
+  changes, and dropped with the source
+- A locked sync keeps the pinned commit when the branch moves on, and pins
+  unlocked sources to their branch head
+- GitHub sources fetch the tree and raw files at the pinned commit
+- skill-source add records the commit; skills update moves the pin and
+  re-syncs
+"""
+
+import subprocess
+from argparse import Namespace
+from unittest.mock import patch
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skills_lock import SkillsLock
+
+SSH_URL = "git@github.com:org/skills.git"
+
+
+def _git(cwd, *args):
+    return subprocess.run(
+        ["git", *args], cwd=cwd, check=True, capture_output=True, text=True
+    ).stdout.strip()
+
+
+def _commit(repo, body):
+    (repo / "tdd" / "SKILL.md").write_text(
+        f"---\nname: tdd\ndescription: Test first\n---\n\n{body}\n"
+    )
+    _git(repo, "add", "-A")
+    _git(repo, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", body)
+    return _git(repo, "rev-parse", "HEAD")
+
+
+@pytest.fixture
+def remote(tmp_path, monkeypatch):
+    """A local repository that SSH_URL is rewritten to."""
+    repo = tmp_path / "remote" / "skills.git"
+    (repo / "tdd").mkdir(parents=True)
+    _git(repo, "init", "-q", "-b", "main")
+    monkeypatch.setenv("GIT_CONFIG_COUNT", "1")
+    monkeypatch.setenv("GIT_CONFIG_KEY_0", f"url.{repo.parent}/.insteadOf")
+    monkeypatch.setenv("GIT_CONFIG_VALUE_0", "git@github.com:org/")
+    return repo
+
+
+@pytest.fixture
+def config(tmp_path):
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    config.save([SkillSource(id="org", type="git", url=SSH_URL)])
+    return config
+
+
+def test_pins_follow_the_configured_source(tmp_path):
+    lock = SkillsLock(tmp_path)
+    source = SkillSource(id="org", type="git", url=SSH_URL)
+
+    lock.pin(source, "a" * 40)
+    assert lock.path == tmp_path / ".claude-mpm" / "skills.lock"
+    assert lock.get(source).commit == "a" * 40
+
+    moved = SkillSource(id="org", type="git", url=SSH_URL, branch="next")
+    assert lock.get(moved) is None
+
+    assert lock.unpin("org")
+    assert not lock.unpin("org")
+    assert lock.entries() == {}
+
+
+def test_locked_sync_keeps_pinned_commit(tmp_path, remote, config):
+    first = _commit(remote, "Write the test first.")
+    lock = SkillsLock(tmp_path / "project")
+    manager = GitSkillSourceManager(
+        config=config, cache_dir=tmp_path / "cache", lock=lock
+    )
+
+    result = manager.sync_source("org")
+    assert result["commit"] == first
+    assert lock.get(config.get_source("org")).commit == first
+
+    _commit(remote, "Red, green, refactor.")
+    result = manager.sync_source("org")
+    assert (result["commit"], result["files_updated"]) == (first, 0)
+    cached = tmp_path / "cache" / "org" / "tdd" / "SKILL.md"
+    assert "Write the test first." in cached.read_text()
+
+    # A fresh cache (another machine) gets the same commit
+    other = GitSkillSourceManager(
+        config=config, cache_dir=tmp_path / "other-cache", lock=lock
+    )
+    assert other.sync_source("org")["commit"] == first
+    assert "Write the test first." in (
+        tmp_path / "other-cache" / "org" / "tdd" / "SKILL.md"
+    ).read_text()
+
+
+def test_github_sync_fetches_pinned_commit(tmp_path):
+    config = SkillSourceConfiguration(config_path=tmp_path / "skill_sources.yaml")
+    source = SkillSource(id="gh", type="git", url="https://github.com/org/skills")
+    config.save([source])
+    lock = SkillsLock(tmp_path)
+    lock.pin(source, "c0ffee")
+    manager = GitSkillSourceManager(
+        config=config, cache_dir=tmp_path / "cache", lock=lock
+    )
+
+    with (
+        patch("requests.get") as get,
+        patch.object(manager, "_download_file_with_etag", return_value=True) as dl,
+    ):
+        get.return_value.json.return_value = {
+            "tree": [{"type": "blob", "path": "tdd/SKILL.md"}]
+        }
+        get.return_value.status_code = 200
+        result = manager.sync_source("gh")
+
+    assert result["commit"] == "c0ffee"
+    (tree_url,) = [call.args[0] for call in get.call_args_list]
+    assert tree_url.endswith("/repos/org/skills/git/trees/c0ffee")
+    assert dl.call_args.args[0] == (
+        "https://raw.githubusercontent.com/org/skills/c0ffee/tdd/SKILL.md"
+    )
+
+
+def test_add_pins_and_update_moves_pin(tmp_path, remote, monkeypatch):
+    from claude_mpm.cli.commands.skill_source import (
+        handle_add_skill_source,
+        handle_remove_skill_source,
+    )
+    from claude_mpm.cli.commands.skills import SkillsManagementCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    first = _commit(remote, "Write the test first.")
+    monkeypatch.chdir(tmp_path)
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    args = create_parser().parse_args(["skill-source", "add", SSH_URL])
+    assert handle_add_skill_source(args) == 0
+    lock = SkillsLock(tmp_path)
+    source = SkillSourceConfiguration().get_source("skills")
+    assert lock.get(source).commit == first
+
+    second = _commit(remote, "Red, green, refactor.")
+    command = SkillsManagementCommand()
+    assert command._update_source_pins(True, ["skills"])
+    assert lock.get(source).commit == first
+    assert command._update_source_pins(False, ["skills"])
+    assert lock.get(source).commit == second
+    cached = tmp_path / "home" / ".claude-mpm" / "cache" / "skills" / "skills"
+    assert "refactor" in (cached / "tdd" / "SKILL.md").read_text()
+
+    args = Namespace(source_id="skills", force=True)
+    assert handle_remove_skill_source(args) == 0
+    assert lock.entries() == {}
@@ -0,0 +1,143 @@
+"""Tests for skill collections managed as skill sources.
+
+COVERAGE:
+- Removing a source drops its config entry, pin and cache, and the deployed
+  skills no remaining source provides
+- The collection methods of SkillsDeployerService list, add, enable, disable
+  and remove skill sources; the default source cannot be removed or disabled
+- deploy-github reads manifest.json from the shared source cache
+- skill-source add accepts an explicit --id
+"""
+
+from types import SimpleNamespace
+
+import pytest
+
+from claude_mpm.config.skill_sources import SkillSource, SkillSourceConfiguration
+from claude_mpm.services.skills.git_skill_source_manager import GitSkillSourceManager
+from claude_mpm.services.skills.skills_lock import SkillsLock
+from claude_mpm.services.skills_deployer import SkillsDeployerService
+
+SKILL = "---\nname: {0}\ndescription: The {0} skill\n---\n\nBody\n"
+
+
+def _write_skill(root, name):
+    (root / name).mkdir(parents=True, exist_ok=True)
+    (root / name / "SKILL.md").write_text(SKILL.format(name))
+
+
+@pytest.fixture
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    monkeypatch.chdir(tmp_path)
+    return tmp_path
+
+
+@pytest.fixture
+def sources(home):
+    """Two local sources sharing "debugging", synced and deployed."""
+    config = SkillSourceConfiguration(
+        config_path=home / ".claude-mpm" / "config" / "skill_sources.yaml"
+    )
+    for source_id, names, priority in (
+        ("team", ["tdd", "debugging"], 10),
+        ("shared", ["debugging"], 20),
+    ):
+        for name in names:
+            _write_skill(home / source_id, name)
+        url = str(home / source_id)
+        config.add_source(SkillSource(source_id, "local", url, priority=priority))
+    manager = GitSkillSourceManager(config, lock=SkillsLock(home))
+    target = home / ".claude" / "skills"
+    for source_id in ("team", "shared"):
+        manager.sync_source(source_id)
+        manager.deploy_source(source_id, target)
+    return SimpleNamespace(config=config, manager=manager, target=target)
+
+
+def test_remove_source(sources):
+    cache = sources.manager.cache_dir / "team"
+    assert cache.is_dir()
+
+    result = sources.manager.remove_source("team", sources.target)
+    assert result == {"cache_removed": True, "skills_removed": ["tdd"]}
+    assert sources.config.get_source("team") is None
+    assert not cache.exists()
+    assert not (sources.target / "tdd").exists()
+    # Still provided by "shared"
+    assert (sources.target / "debugging" / "SKILL.md").is_file()
+
+    with pytest.raises(ValueError, match="Source not found"):
+        sources.manager.remove_source("team", sources.target)
+
+
+def test_deployer_collections_are_sources(sources, home):
+    deployer = SkillsDeployerService()
+    deployer.CLAUDE_SKILLS_DIR = sources.target
+
+    listed = deployer.list_collections()
+    assert listed["default_collection"] == "system"
+    assert listed["collections"]["team"]["priority"] == 10
+    assert listed["collections"]["team"]["last_update"] is not None
+    # The default system and anthropic-official sources, plus these two
+    assert listed["total_count"] == listed["enabled_count"] == 4
+
+    deployer.add_collection("superpowers", "https://github.com/obra/superpowers")
+    source = sources.config.get_source("superpowers")
+    assert (source.type, source.priority) == ("git", 99)
+    with pytest.raises(ValueError, match="already exists"):
+        deployer.add_collection("superpowers", "https://github.com/obra/superpowers")
+
+    deployer.disable_collection("superpowers")
+    assert not sources.config.get_source("superpowers").enabled
+    with pytest.raises(ValueError, match="disabled"):
+        deployer.set_default_collection("superpowers")
+    deployer.enable_collection("superpowers")
+
+    deployer.set_default_collection("team")
+    assert deployer.list_collections()["default_collection"] == "team"
+    with pytest.raises(ValueError, match="Cannot remove default"):
+        deployer.remove_collection("team")
+    with pytest.raises(ValueError, match="Cannot disable default"):
+        deployer.disable_collection("team")
+
+    result = deployer.remove_collection("shared")
+    assert result["skills_removed"] == []  # "team" wins "debugging"
+    assert sources.config.get_source("shared") is None
+
+
+def test_deploy_github_reads_source_cache(sources, home):
+    (home / "team" / "manifest.json").write_text(
+        '{"skills": [{"name": "tdd", "path": "tdd"}]}'
+    )
+    sources.config.update_source("shared", enabled=False)
+    deployer = SkillsDeployerService()
+    data = deployer._download_from_github("team")
+    assert data["temp_dir"] == sources.manager.cache_dir / "team"
+    assert data["manifest"]["skills"][0]["name"] == "tdd"
+
+    with pytest.raises(ValueError, match="is disabled"):
+        deployer._download_from_github("shared")
+    with pytest.raises(ValueError, match="not found"):
+        deployer._download_from_github("missing")
+    (home / "team" / "manifest.json").unlink()
+    with pytest.raises(Exception, match=r"manifest\.json not found"):
+        deployer._download_from_github("team")
+
+
+def test_add_with_explicit_id(home):
+    from claude_mpm.cli.commands.skill_source import handle_add_skill_source
+
+    _write_skill(home / "my-skills", "tdd")
+    args = SimpleNamespace(
+        url=str(home / "my-skills"),
+        id="mine",
+        branch="main",
+        priority=10,
+        disabled=False,
+        test=False,
+        skip_test=True,
+        watch=False,
+    )
+    assert handle_add_skill_source(args) == 0
+    assert SkillSourceConfiguration().get_source("mine").is_local
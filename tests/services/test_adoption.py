@@ -0,0 +1,144 @@
+"""
+Tests for opt-in team adoption metrics.
+
+COVERAGE:
+- Nothing is recorded unless the adoption_metrics flag is on; with it on,
+  turns, commits and verification runs are counted per session and other
+  commands are ignored
+- Only the user's own flag or the environment opts in, and the user's
+  "off" beats every other layer
+- The report ranks users by sessions, computes pass rates, drops sessions
+  outside the period and lists opted-in users with no recent session
+- adoption forget deletes only the current user's records
+"""
+
+import json
+from argparse import Namespace
+from datetime import UTC, datetime, timedelta
+
+import pytest
+
+from claude_mpm.cli.commands.adoption import AdoptionCommand
+from claude_mpm.services.adoption import (
+    AdoptionLog,
+    build_report,
+    record_stop_event,
+    record_tool_event,
+    recording_state,
+)
+from claude_mpm.services.feature_flags import FeatureFlags
+
+
+@pytest.fixture
+def env(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.setenv("CLAUDE_MPM_ADOPTION_DIR", str(tmp_path / "adoption"))
+    monkeypatch.setenv("CLAUDE_MPM_USER", "ada@example.com")
+    monkeypatch.delenv("CLAUDE_MPM_FLAG_ADOPTION_METRICS", raising=False)
+    project = tmp_path / "project"
+    project.mkdir()
+    return project
+
+
+def _bash(project, command, exit_code=0):
+    return {
+        "tool_name": "Bash",
+        "tool_input": {"command": command},
+        "exit_code": exit_code,
+        "session_id": "s1",
+        "cwd": str(project),
+    }
+
+
+def test_records_only_when_opted_in(env, tmp_path):
+    record_stop_event({"session_id": "s1", "cwd": str(env)})
+    record_tool_event(_bash(env, "pytest tests/"))
+    assert not (tmp_path / "adoption").exists()
+
+    FeatureFlags(env).set("adoption_metrics", True)
+    record_stop_event({"session_id": "s1", "cwd": str(env)})
+    record_stop_event({"session_id": "s1", "cwd": str(env)})
+    record_tool_event(_bash(env, "pytest tests/", exit_code=1))
+    record_tool_event(_bash(env, "pytest tests/"))
+    record_tool_event(_bash(env, 'git commit -m "Fix parser"'))
+    record_tool_event(_bash(env, "ls -la"))
+
+    sessions = AdoptionLog("ada@example.com").sessions()
+    assert list(sessions) == ["s1"]
+    session = sessions["s1"]
+    assert session["project"] == str(env.resolve())
+    assert (
+        session["turns"],
+        session["commits"],
+        session["verification_passed"],
+        session["verification_failed"],
+    ) == (2, 1, 1, 1)
+
+
+def test_only_the_user_can_opt_in(env, monkeypatch):
+    FeatureFlags(env).set("adoption_metrics", True, project=True)
+    org_cache = env.parent / "home" / ".claude-mpm" / "cache" / "org-flags.json"
+    org_cache.parent.mkdir(parents=True)
+    org_cache.write_text(json.dumps({"flags": {"adoption_metrics": True}}))
+    assert recording_state(env) == (False, "default")
+
+    monkeypatch.setenv("CLAUDE_MPM_FLAG_ADOPTION_METRICS", "1")
+    assert recording_state(env) == (True, "env")
+
+    FeatureFlags(env).set("adoption_metrics", False)
+    assert recording_state(env) == (False, "user")
+    record_stop_event({"session_id": "s1", "cwd": str(env)})
+    assert AdoptionLog("ada@example.com").sessions() == {}
+
+
+def test_report_ranks_users_and_lists_inactive(tmp_path):
+    now = datetime(2026, 5, 1, tzinfo=UTC)
+    recent = (now - timedelta(days=2)).isoformat()
+    old = (now - timedelta(days=60)).isoformat()
+    records = {
+        "ada": {
+            "a1": {"last_active": recent, "commits": 2, "verification_passed": 3},
+            "a2": {"last_active": recent, "verification_failed": 1},
+            "a3": {"last_active": old, "commits": 9},
+        },
+        "bob": {"b1": {"last_active": recent, "verification_passed": 1}},
+        "cy": {"c1": {"last_active": old, "turns": 4}},
+    }
+    directory = tmp_path / "collected"
+    directory.mkdir()
+    for user, sessions in records.items():
+        (directory / f"{user}.json").write_text(
+            json.dumps({"version": 1, "user": user, "sessions": sessions})
+        )
+    (directory / "notes.json").write_text("[]")
+
+    report = build_report([directory], days=30, now=now)
+
+    assert [u.user for u in report.active] == ["ada", "bob"]
+    ada = report.active[0]
+    assert (ada.sessions, ada.outcomes, ada.pass_rate) == (2, 2, 0.75)
+    assert [u.user for u in report.inactive] == ["cy"]
+    assert report.to_dict()["pass_rate"] == 0.8
+    assert "No sessions in this period: cy" in report.render()
+
+    by_rate = build_report([directory], days=30, sort="pass-rate", now=now)
+    assert by_rate.users[0].user == "bob"
+    with pytest.raises(ValueError):
+        build_report([directory], days=0)
+
+
+def test_forget_deletes_only_own_records(env, tmp_path):
+    AdoptionLog("ada@example.com").record_turn("s1", env)
+    AdoptionLog("bob@example.com").record_turn("s2", env)
+    command = AdoptionCommand(project_dir=env)
+
+    status = command.run(Namespace(adoption_command="status"))
+    assert status.data["sessions"] == 1
+    assert not status.data["enabled"]
+
+    result = command.run(Namespace(adoption_command="forget"))
+    assert result.success
+    assert not AdoptionLog("ada@example.com").sessions()
+    assert AdoptionLog("bob@example.com").sessions()
+    again = command.run(Namespace(adoption_command="forget"))
+    assert "No records" in again.message
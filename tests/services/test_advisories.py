@@ -0,0 +1,303 @@
+"""Tests for security advisory monitoring.
+
+COVERAGE:
+- Exact pins are read from Python, npm, Cargo, Go and Ruby lockfiles, and a
+  package pinned in two files is listed once
+- OSV lookups: batch query, advisory details, fixed versions, severity
+  filtering and ignored IDs or aliases
+- New advisories open a GitHub issue (or find the existing one) or queue an
+  upgrade task, once; failed actions are retried on the next check
+- The background check starts only when enabled and due
+- The advisories command
+"""
+
+import argparse
+import json
+import subprocess
+
+import pytest
+
+from claude_mpm.cli.commands.advisories import AdvisoriesCommand
+from claude_mpm.services import advisories
+from claude_mpm.services.advisories import (
+    AdvisoryConfig,
+    AdvisoryMonitor,
+    OsvClient,
+    check_in_background,
+    collect_dependencies,
+)
+from claude_mpm.services.work_queue import SqliteBroker
+
+VULNS = {
+    "GHSA-j8r2-6x86-q33q": {
+        "id": "GHSA-j8r2-6x86-q33q",
+        "summary": "Requests leaks Proxy-Authorization headers",
+        "aliases": ["CVE-2023-32681", "PYSEC-2023-74"],
+        "database_specific": {"severity": "MODERATE"},
+        "affected": [
+            {
+                "package": {"ecosystem": "PyPI", "name": "requests"},
+                "ranges": [{"events": [{"introduced": "2.3.0"}, {"fixed": "2.31.0"}]}],
+            }
+        ],
+    },
+    "PYSEC-2021-1": {
+        "id": "PYSEC-2021-1",
+        "details": "Unlabelled issue in requests\nMore detail.",
+        "affected": [],
+    },
+    "GHSA-low": {
+        "id": "GHSA-low",
+        "summary": "Minor",
+        "database_specific": {"severity": "LOW"},
+    },
+}
+
+
+class FakeOsv:
+    """Answers querybatch for requests 2.19.0 and lodash, and vulns by ID."""
+
+    def __init__(self):
+        self.queries = []
+
+    def __call__(self, url, body):
+        if body is None:
+            return VULNS[url.rsplit("/", 1)[1]]
+        self.queries.extend(body["queries"])
+        results = []
+        for query in body["queries"]:
+            name = query["package"]["name"]
+            ids = {
+                "requests": ["GHSA-j8r2-6x86-q33q", "PYSEC-2021-1"],
+                "lodash": ["GHSA-low"],
+            }.get(name, [])
+            results.append({"vulns": [{"id": i} for i in ids]} if ids else {})
+        return {"results": results}
+
+
+class FakeGh:
+    """Records gh calls; issue list finds nothing unless told otherwise."""
+
+    def __init__(self, existing="", fail_labels=False):
+        self.calls = []
+        self.existing = existing
+        self.fail_labels = fail_labels
+
+    def __call__(self, cmd, **kwargs):
+        self.calls.append(cmd[1:])
+        if cmd[1:3] == ["issue", "list"]:
+            return subprocess.CompletedProcess(cmd, 0, self.existing + "\n", "")
+        if self.fail_labels and "--label" in cmd:
+            return subprocess.CompletedProcess(cmd, 1, "", "could not add label")
+        url = f"https://github.com/o/r/issues/{len(self.calls)}"
+        return subprocess.CompletedProcess(cmd, 0, url + "\n", "")
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    project = tmp_path / "project"
+    project.mkdir()
+    (project / "requirements.txt").write_text(
+        "# pinned\nrequests[socks]==2.19.0 ; python_version >= '3'\nflask>=2\n"
+    )
+    (project / "package-lock.json").write_text(
+        json.dumps(
+            {
+                "lockfileVersion": 3,
+                "packages": {
+                    "": {"name": "app"},
+                    "node_modules/lodash": {"version": "4.17.20"},
+                    "node_modules/a/node_modules/b": {"version": "1.0.0"},
+                    "node_modules/local": {"link": True},
+                },
+            }
+        )
+    )
+    return project
+
+
+def monitor(project, gh=None, **config):
+    return AdvisoryMonitor(
+        project,
+        AdvisoryConfig(enabled=True, **config),
+        client=OsvClient(transport=FakeOsv()),
+        runner=gh or FakeGh(),
+    )
+
+
+def test_collect_dependencies(project):
+    (project / "poetry.lock").write_text(
+        '[[package]]\nname = "Requests"\nversion = "2.19.0"\n\n'
+        '[[package]]\nname = "idna"\nversion = "2.7"\n'
+    )
+    (project / "uv.lock").write_text(
+        '[[package]]\nname = "app"\nversion = "0.1.0"\nsource = { editable = "." }\n'
+        '\n[[package]]\nname = "attrs"\nversion = "23.1.0"\n'
+        'source = { registry = "https://pypi.org/simple" }\n'
+    )
+    (project / "Cargo.lock").write_text(
+        '[[package]]\nname = "serde"\nversion = "1.0.1"\n'
+        'source = "registry+https://github.com/rust-lang/crates.io-index"\n\n'
+        '[[package]]\nname = "mycrate"\nversion = "0.1.0"\n'
+    )
+    (project / "go.mod").write_text(
+        "module example.com/app\n\ngo 1.22\n\n"
+        "require golang.org/x/net v0.17.0\n"
+        "require (\n\tgithub.com/a/b v1.2.3+incompatible // indirect\n)\n"
+    )
+    (project / "Gemfile.lock").write_text(
+        "GEM\n  remote: https://rubygems.org/\n  specs:\n    rack (2.2.3)\n"
+        "      rack-test (>= 1)\n\nPLATFORMS\n  ruby\n"
+    )
+    pins = collect_dependencies(project)
+    assert {(d.ecosystem, d.name, d.version, d.manifest) for d in pins} == {
+        ("PyPI", "requests", "2.19.0", "requirements.txt"),
+        ("PyPI", "idna", "2.7", "poetry.lock"),
+        ("PyPI", "attrs", "23.1.0", "uv.lock"),
+        ("npm", "lodash", "4.17.20", "package-lock.json"),
+        ("npm", "b", "1.0.0", "package-lock.json"),
+        ("crates.io", "serde", "1.0.1", "Cargo.lock"),
+        ("Go", "golang.org/x/net", "0.17.0", "go.mod"),
+        ("Go", "github.com/a/b", "1.2.3", "go.mod"),
+        ("RubyGems", "rack", "2.2.3", "Gemfile.lock"),
+    }
+
+
+def test_check(project):
+    deps, found = monitor(project).check()
+    assert len(deps) == 3
+    assert [(a.id, a.severity) for a in found] == [
+        ("PYSEC-2021-1", "unknown"),
+        ("GHSA-j8r2-6x86-q33q", "moderate"),
+        ("GHSA-low", "low"),
+    ]
+    requests_advisory = found[1]
+    assert requests_advisory.fixed == ["2.31.0"]
+    assert requests_advisory.manifest == "requirements.txt"
+    assert found[0].summary == "Unlabelled issue in requests"
+
+    # Unlabelled advisories survive the severity floor; ignore matches aliases
+    _, found = monitor(
+        project, min_severity="moderate", ignore=["CVE-2023-32681"]
+    ).check()
+    assert [a.id for a in found] == ["PYSEC-2021-1"]
+
+
+def test_tickets_are_opened_once(project):
+    gh = FakeGh()
+    report = monitor(project, gh, ignore=["GHSA-low", "PYSEC-2021-1"]).run()
+    assert report.new == ["GHSA-j8r2-6x86-q33q PyPI/requests"]
+    listed, created = gh.calls
+    assert "GHSA-j8r2-6x86-q33q in:title" in listed
+    assert created[:4] == [
+        "issue",
+        "create",
+        "--title",
+        "GHSA-j8r2-6x86-q33q: requests 2.19.0 is vulnerable",
+    ]
+    assert "- Fixed in: 2.31.0" in created[5]
+    assert created[-2:] == ["--label", "security"]
+    assert report.actions == {
+        "GHSA-j8r2-6x86-q33q PyPI/requests": "https://github.com/o/r/issues/2"
+    }
+
+    gh.calls.clear()
+    report = monitor(project, gh, ignore=["GHSA-low", "PYSEC-2021-1"]).run()
+    assert (report.new, report.actions, gh.calls) == ([], {}, [])
+
+
+def test_existing_issue_and_missing_labels(project):
+    gh = FakeGh(existing="https://github.com/o/r/issues/99")
+    report = monitor(project, gh, ignore=["GHSA-low", "PYSEC-2021-1"]).run()
+    assert list(report.actions.values()) == ["https://github.com/o/r/issues/99"]
+    assert len(gh.calls) == 1
+
+    gh = FakeGh(fail_labels=True)
+    report = monitor(project, gh, ignore=["GHSA-j8r2-6x86-q33q"]).run()
+    assert len(report.actions) == 2 and not report.errors
+    # Each create is retried without the labels the repository lacks
+    creates = [call for call in gh.calls if call[:2] == ["issue", "create"]]
+    assert ["--label" in call for call in creates] == [True, False, True, False]
+
+
+def test_failed_actions_are_retried(project):
+    def no_gh(cmd, **kwargs):
+        raise FileNotFoundError("gh")
+
+    report = monitor(project, no_gh, ignore=["GHSA-low", "PYSEC-2021-1"]).run()
+    assert report.errors == {
+        "GHSA-j8r2-6x86-q33q PyPI/requests": "the gh CLI is not installed"
+    }
+    report = monitor(project, ignore=["GHSA-low", "PYSEC-2021-1"]).run()
+    assert report.new == ["GHSA-j8r2-6x86-q33q PyPI/requests"]
+    assert report.actions
+
+
+def test_queue_upgrade(project, tmp_path):
+    broker = SqliteBroker(tmp_path / "queue.db")
+    opened = AdvisoryMonitor(
+        project,
+        AdvisoryConfig(action="queue", min_severity="moderate"),
+        client=OsvClient(transport=FakeOsv()),
+        broker_factory=lambda: SqliteBroker(tmp_path / "queue.db"),
+    ).run()
+    assert sorted(opened.actions) == [
+        "GHSA-j8r2-6x86-q33q PyPI/requests",
+        "PYSEC-2021-1 PyPI/requests",
+    ]
+    tasks = {task.prompt.split()[2]: task for task in broker.tasks()}
+    task = tasks["GHSA-j8r2-6x86-q33q"]
+    assert task.cwd == str(project.resolve())
+    assert "Upgrade requests to a fixed version (2.31.0)" in task.prompt
+    assert "a version that is not affected" in tasks["PYSEC-2021-1"].prompt
+
+
+def test_check_in_background(project):
+    launched = []
+    config = AdvisoryConfig(enabled=True, interval_hours=24)
+
+    def launch(cmd, **kwargs):
+        launched.append(cmd)
+
+    assert check_in_background(project, config, launch=launch)
+    assert launched[0][1:] == [
+        "-m",
+        "claude_mpm.services.advisories",
+        str(project.resolve()),
+    ]
+    # Not due again until the interval passes, and never while disabled
+    assert not check_in_background(project, config, launch=launch)
+    assert not check_in_background(
+        project, AdvisoryConfig(interval_hours=0), launch=launch
+    )
+    assert len(launched) == 1
+
+    monitor(project).run(act=False)
+    assert advisories.advisory_notice(project) is None  # dry runs are not saved
+    monitor(project, action="report").run()
+    assert advisories.advisory_notice(project).startswith(
+        "3 security advisories affect pinned dependencies"
+    )
+
+
+def test_advisories_command(project):
+    command = AdvisoriesCommand(monitor(project, action="report"))
+    args = argparse.Namespace(advisories_command="list", json=False)
+    assert "No advisory check has run" in command.run(args).message
+
+    args = argparse.Namespace(advisories_command="check", dry_run=False, json=False)
+    result = command.run(args)
+    assert result.success
+    lines = result.message.splitlines()
+    assert lines[0] == (
+        "unknown   PYSEC-2021-1  requests 2.19.0 (requirements.txt), no fix  [new]"
+    )
+    assert "          -> reported" in lines
+    assert lines[-1].endswith("3 advisories (3 new)")
+
+    args = argparse.Namespace(advisories_command="list", json=True)
+    assert len(json.loads(command.run(args).message)["advisories"]) == 3
+    args = argparse.Namespace(advisories_command="deps", json=False)
+    assert command.run(args).message.endswith("3 pinned dependencies")
+    assert command.validate_args(argparse.Namespace(advisories_command=None))
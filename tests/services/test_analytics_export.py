@@ -0,0 +1,166 @@
+"""
+Tests for the session analytics export.
+
+COVERAGE:
+- Sessions, events, costs and outcomes are written as CSV with fixed
+  headers; labels and adoption records are joined in and prompt text is
+  left out unless asked for
+- --since drops sessions last active before the cutoff, and empty tables
+  still get their header
+- Parquet without pyarrow fails with an install hint; the command reports
+  the files written
+"""
+
+import builtins
+import csv
+import json
+import os
+from argparse import Namespace
+from datetime import UTC, datetime, timedelta
+
+import pytest
+
+from claude_mpm.cli.commands.export import ExportCommand
+from claude_mpm.services.analytics_export import (
+    TABLES,
+    AnalyticsExport,
+    parse_since,
+)
+from claude_mpm.services.session_analysis.transcript_parser import _encode_cwd
+from claude_mpm.services.session_labels import SessionLabels
+
+RECENT = "a1b2c3d4-0000-0000-0000-000000000001"
+OLD = "a1b2c3d4-0000-0000-0000-000000000002"
+
+
+def _transcript(directory, session_id, started, mtime):
+    later = started + timedelta(minutes=2)
+    lines = [
+        {
+            "type": "user",
+            "uuid": f"{session_id}-u",
+            "timestamp": started.isoformat(),
+            "message": {"role": "user", "content": "Fix the secret login bug"},
+        },
+        {
+            "type": "assistant",
+            "uuid": f"{session_id}-a",
+            "timestamp": later.isoformat(),
+            "message": {
+                "role": "assistant",
+                "model": "claude-sonnet-4-20250514",
+                "content": [{"type": "text", "text": "Done."}],
+                "usage": {"input_tokens": 1000, "output_tokens": 200},
+            },
+        },
+    ]
+    path = directory / f"{session_id}.jsonl"
+    path.write_text("\n".join(json.dumps(line) for line in lines) + "\n")
+    os.utime(path, (mtime.timestamp(), mtime.timestamp()))
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    project = tmp_path / "project"
+    project.mkdir()
+    transcripts = (
+        tmp_path / "home" / ".claude" / "projects" / _encode_cwd(str(project))
+    )
+    transcripts.mkdir(parents=True)
+    now = datetime.now(UTC)
+    _transcript(transcripts, RECENT, now - timedelta(days=1), now)
+    _transcript(
+        transcripts, OLD, now - timedelta(days=60), now - timedelta(days=60)
+    )
+    SessionLabels(project).add(RECENT, ["billing"])
+    adoption = tmp_path / "home" / ".claude-mpm" / "adoption"
+    adoption.mkdir(parents=True)
+    (adoption / "alice.json").write_text(
+        json.dumps(
+            {
+                "user": "alice",
+                "sessions": {RECENT: {"commits": 2, "verification_passed": 1}},
+            }
+        )
+    )
+    return project
+
+
+def _read(path):
+    with path.open(newline="") as handle:
+        return list(csv.DictReader(handle))
+
+
+def test_csv_export_joins_labels_and_adoption(project, tmp_path):
+    export = AnalyticsExport(since=parse_since("30d"))
+    assert export.add_project(project) == 1
+    written = export.write(tmp_path / "out")
+    assert set(written) == set(TABLES)
+
+    (session,) = _read(written["sessions"])
+    assert session["session_id"] == RECENT
+    assert session["labels"] == "billing"
+    assert session["turns"] == "1"
+    assert float(session["cost_usd"]) > 0
+    assert session["title"] == ""
+
+    events = _read(written["events"])
+    assert [e["event_type"] for e in events] == ["user_prompt", "pm_turn"]
+    assert events[1]["input_tokens"] == "1000"
+    assert "secret" not in written["events"].read_text()
+
+    (cost,) = _read(written["costs"])
+    assert cost["model"] == "claude-sonnet-4-20250514"
+    assert cost["output_tokens"] == "200"
+
+    (outcome,) = _read(written["outcomes"])
+    assert (outcome["user"], outcome["commits"]) == ("alice", "2")
+    assert outcome["adoption_recorded"] == "true"
+
+
+def test_since_filter_and_empty_tables(project, tmp_path):
+    export = AnalyticsExport(since=parse_since("90d"), include_text=True)
+    assert export.add_project(project) == 2
+    sessions = _read(export.write(tmp_path / "all", tables=["sessions"])["sessions"])
+    assert {s["session_id"] for s in sessions} == {RECENT, OLD}
+    assert all("login bug" in s["title"] for s in sessions)
+
+    empty = AnalyticsExport(since=datetime.now(UTC) + timedelta(days=1))
+    assert empty.add_project(project) == 0
+    path = empty.write(tmp_path / "none", tables=["costs"])["costs"]
+    assert path.read_text().strip() == ",".join(TABLES["costs"])
+
+    with pytest.raises(ValueError, match="Invalid time"):
+        parse_since("last week")
+
+
+def test_parquet_needs_pyarrow_and_command_reports(project, tmp_path, monkeypatch):
+    real_import = builtins.__import__
+
+    def no_pyarrow(name, *args, **kwargs):
+        if name.startswith("pyarrow"):
+            raise ImportError(name)
+        return real_import(name, *args, **kwargs)
+
+    args = Namespace(
+        export_command="analytics",
+        format="parquet",
+        since="30d",
+        output=str(tmp_path / "out"),
+        project=[str(project)],
+        table=None,
+        include_text=False,
+        json=False,
+    )
+    monkeypatch.setattr(builtins, "__import__", no_pyarrow)
+    result = ExportCommand().run(args)
+    assert not result.success
+    assert "pip install" in result.message
+
+    monkeypatch.setattr(builtins, "__import__", real_import)
+    args.format = "csv"
+    result = ExportCommand().run(args)
+    assert result.success
+    assert "Exported 1 session(s)" in result.message
+    assert result.data["files"]["events"]["rows"] == 2
@@ -0,0 +1,125 @@
+"""
+Tests for review comments on session events.
+
+COVERAGE:
+- Comments are added per session, listed oldest first and removed by id;
+  --line resolves the transcript entry's uuid
+- annotations add/list/remove through the CLI command
+- Session reports show comments under the entry they target and read back
+"""
+
+import json
+from argparse import Namespace
+from datetime import UTC, datetime
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.cli.commands.annotations import AnnotationsCommand
+from claude_mpm.services.annotations import AnnotationStore, transcript_target
+from claude_mpm.services.session_analysis.markdown_writer import (
+    read_markdown,
+    write_report,
+)
+from claude_mpm.services.session_analysis.transcript_parser import (
+    SessionReport,
+    TimelineEvent,
+    locate_transcript,
+)
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setattr(Path, "home", lambda: tmp_path / "home")
+    project = tmp_path / "project"
+    project.mkdir()
+    transcript = locate_transcript("sess-1", str(project.resolve()))
+    transcript.parent.mkdir(parents=True)
+    transcript.write_text(
+        "\n".join(
+            json.dumps({"uuid": f"u{n}", "type": "user"}) for n in range(1, 4)
+        )
+        + "\n"
+    )
+    return project
+
+
+def test_store(project):
+    store = AnnotationStore(project)
+    first = store.add("sess-1", "Why a retry here?", line=2, author="ana")
+    second = store.add("sess-1", "  Looks right  ", target="tool:abc")
+    store.add("sess-2", "Other session", target="event:stop@1")
+
+    assert first.target == transcript_target("u2")
+    assert first.line == 2
+    assert second.text == "Looks right"
+    assert [a.id for a in store.annotations("sess-1")] == [first.id, second.id]
+    assert [a.id for a in store.annotations("sess-1", "tool:abc")] == [second.id]
+    assert len(store.annotations()) == 3
+
+    with pytest.raises(ValueError):
+        store.add("sess-1", "   ", target="tool:abc")
+    with pytest.raises(ValueError):
+        store.add("sess-1", "Past the end", line=9)
+    with pytest.raises(FileNotFoundError):
+        store.add("sess-3", "No transcript", line=1)
+
+    assert store.remove(first.id)
+    assert not store.remove(first.id)
+    assert [a.id for a in store.annotations("sess-1")] == [second.id]
+
+
+def test_annotations_command(project):
+    command = AnnotationsCommand(project)
+
+    added = command.run(
+        Namespace(
+            annotations_command="add",
+            session="sess-1",
+            text="Should have run the tests first",
+            line=1,
+            target=None,
+            author="ana",
+        )
+    )
+    assert added.success
+    assert added.data["target"] == "transcript:u1"
+
+    listed = command.run(Namespace(annotations_command="list", session=None))
+    assert "line 1" in listed.message
+    assert "ana: Should have run the tests first" in listed.message
+
+    missing = command.run(Namespace(annotations_command="remove", id="nope"))
+    assert not missing.success
+    assert command.run(
+        Namespace(annotations_command="remove", id=added.data["id"])
+    ).success
+    assert command.validate_args(Namespace(annotations_command=None))
+
+
+def test_report_shows_comments(project, tmp_path):
+    store = AnnotationStore(project)
+    store.add("sess-1", "Good call\nbut slow", line=2, author="ana")
+    report = SessionReport(
+        session_id="sess-1", project_path=str(project), transcript_path=""
+    )
+    for n in (1, 2):
+        report.events.append(
+            TimelineEvent(
+                uuid=f"u{n}",
+                timestamp=datetime(2026, 1, 1, 12, n, tzinfo=UTC),
+                actor="mpm",
+                event_type="pm_turn",
+                title=f"Turn {n}",
+                detail="Did things.",
+            )
+        )
+
+    output = tmp_path / "report.md"
+    write_report(report, output, store.annotations("sess-1"))
+
+    first, second = read_markdown(output)["events"]
+    assert first["comments_text"] == ""
+    assert second["comments_text"].startswith("**ana** (")
+    assert second["comments_text"].endswith("Good call\nbut slow")
+    assert second["detail"] == "Did things."
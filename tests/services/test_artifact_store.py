@@ -0,0 +1,136 @@
+"""
+Tests for the per-session artifact store.
+
+COVERAGE:
+- Adding files with inferred kinds, per session, copy vs move
+- Listing, filtering and looking up artifacts
+- Retention by age, session count and total size; the current session is kept
+- The artifacts command
+"""
+
+import argparse
+import json
+from datetime import UTC, datetime, timedelta
+
+import pytest
+
+from claude_mpm.cli.commands.artifacts import ArtifactsCommand
+from claude_mpm.services.artifact_store import (
+    BUILD,
+    IMAGE,
+    REPORT,
+    ArtifactStore,
+    RetentionPolicy,
+    infer_kind,
+)
+
+
+@pytest.fixture
+def store(tmp_path):
+    return ArtifactStore(tmp_path / "proj", RetentionPolicy(None, None, None))
+
+
+def _file(tmp_path, name, content=b"data"):
+    path = tmp_path / name
+    path.write_bytes(content)
+    return path
+
+
+def _age_session(store, session, days):
+    manifest = store.root / session / "manifest.json"
+    entries = json.loads(manifest.read_text())
+    stamp = (datetime.now(UTC) - timedelta(days=days)).isoformat()
+    for entry in entries:
+        entry["created_at"] = stamp
+    manifest.write_text(json.dumps(entries))
+
+
+def test_infer_kind():
+    assert infer_kind("report.md") == REPORT
+    assert infer_kind("chart.PNG") == IMAGE
+    assert infer_kind("dist.whl") == BUILD
+    assert infer_kind("notes") == "other"
+
+
+def test_add_list_get(tmp_path, store):
+    report = store.add(_file(tmp_path, "summary.md"), session_id="s1")
+    image = store.add(
+        _file(tmp_path, "plot.png"), session_id="s2", description="latency", move=True
+    )
+
+    assert (tmp_path / "summary.md").exists()
+    assert not (tmp_path / "plot.png").exists()
+    assert store.path(image).read_bytes() == b"data"
+    assert image.media_type == "image/png"
+    assert [a.id for a in store.artifacts()] == [image.id, report.id]
+    assert [a.id for a in store.artifacts(session_id="s1")] == [report.id]
+    assert [a.id for a in store.artifacts(kind=IMAGE)] == [image.id]
+    assert store.get(image.id).description == "latency"
+    assert store.get("missing") is None
+    with pytest.raises(FileNotFoundError):
+        store.add(tmp_path / "nope.txt")
+    with pytest.raises(ValueError):
+        store.add(_file(tmp_path, "x.txt"), kind="video")
+
+
+def test_session_ids_stay_inside_store(tmp_path, store):
+    artifact = store.add(_file(tmp_path, "a.txt"), session_id="../../etc", name="../x")
+
+    assert store.path(artifact).is_relative_to(store.root)
+
+
+def test_retention(tmp_path, store):
+    for session in ("old", "mid", "new"):
+        store.add(_file(tmp_path, f"{session}.txt", b"x" * 1024), session_id=session)
+    _age_session(store, "old", 40)
+    _age_session(store, "mid", 10)
+
+    store.retention = RetentionPolicy(max_age_days=30, max_sessions=None)
+    assert store.prune() == ["old"]
+
+    store.retention = RetentionPolicy(max_age_days=None, max_sessions=1)
+    assert store.prune(keep_session="mid") == ["new"]
+    assert store.sessions() == ["mid"]
+
+    store.retention = RetentionPolicy(None, None, None)
+    store.add(_file(tmp_path, "big.bin", b"x" * 1024), session_id="current")
+    store.retention = RetentionPolicy(None, None, max_total_mb=0)
+    assert store.prune(keep_session="current") == ["mid"]
+    assert store.sessions() == ["current"]
+
+
+def test_artifacts_command(tmp_path):
+    project = tmp_path / "proj"
+    command = ArtifactsCommand(project_dir=project)
+    source = _file(tmp_path, "coverage.html")
+
+    added = command.run(
+        argparse.Namespace(
+            artifacts_command="add",
+            path=str(source),
+            session="s1",
+            name=None,
+            kind=None,
+            description="",
+            move=False,
+        )
+    )
+    artifact_id = added.data["id"]
+    listed = command.run(
+        argparse.Namespace(
+            artifacts_command="list", session=None, kind=None, json=False
+        )
+    )
+    out = tmp_path / "out"
+    out.mkdir()
+    copied = command.run(
+        argparse.Namespace(artifacts_command="get", id=artifact_id, output=str(out))
+    )
+    missing = command.run(
+        argparse.Namespace(artifacts_command="get", id="nope", output=None)
+    )
+
+    assert added.success and added.data["kind"] == REPORT
+    assert artifact_id in listed.message and "coverage.html" in listed.message
+    assert copied.success and (out / "coverage.html").read_bytes() == b"data"
+    assert not missing.success
@@ -0,0 +1,185 @@
+"""
+Tests for canary rollouts of agent and instruction changes.
+
+COVERAGE:
+- Sessions in listed projects always get the candidate; others by percentage,
+  reproducibly per session
+- Starting a session deploys the assigned arm of an agent canary, records
+  the assignment and returns the session id; the stable arm and stop restore
+  the previous agent
+- Instruction canaries use the instructions override unless one is set
+- Promoted canaries give every session the candidate without counting it
+- session_outcome counts turns, tool errors, corrections and cost
+- status compares the arms from the assigned sessions' transcripts
+- canary subcommands parse
+"""
+
+import argparse
+import json
+import os
+
+import pytest
+
+from claude_mpm.services.canary import (
+    CANDIDATE,
+    INSTRUCTIONS,
+    INSTRUCTIONS_ENV_VAR,
+    STABLE,
+    Canary,
+    CanaryStore,
+    session_outcome,
+)
+
+STABLE_AGENT = "---\nname: qa\n---\nRun the tests.\n"
+CANDIDATE_AGENT = "---\nname: qa\n---\nRun the tests, then the linters.\n"
+
+
+@pytest.fixture
+def setup(tmp_path):
+    store = CanaryStore(tmp_path / "canary")
+    project = tmp_path / "project"
+    agents = project / ".claude" / "agents"
+    agents.mkdir(parents=True)
+    (agents / "qa.md").write_text(STABLE_AGENT)
+    candidate = tmp_path / "qa-v2.md"
+    candidate.write_text(CANDIDATE_AGENT)
+    return store, project, candidate
+
+
+def test_assignment_by_project_and_percentage(tmp_path):
+    canary = Canary(name="qa", percent=30, projects=[str(tmp_path.resolve())])
+
+    assert canary.arm_for("any", tmp_path) == CANDIDATE
+    arms = [canary.arm_for(f"s{i}", tmp_path / "other") for i in range(1000)]
+    assert 200 < arms.count(CANDIDATE) < 400
+    assert arms == [canary.arm_for(f"s{i}", tmp_path / "other") for i in range(1000)]
+    assert Canary(name="qa", percent=0).arm_for("s", tmp_path) == STABLE
+
+
+def test_agent_arms_are_deployed_and_recorded(setup):
+    store, project, candidate = setup
+    deployed = project / ".claude" / "agents" / "qa.md"
+
+    assert store.start_session(project) is None
+    store.start("qa", candidate, projects=[project])
+
+    session_id = store.start_session(project)
+    assert session_id
+    assert "linters" in deployed.read_text()
+    (record,) = store.assignments(store.get("qa"))
+    assert (record["session_id"], record["arms"]) == (session_id, {"qa": CANDIDATE})
+
+    store.stop("qa", project)
+    assert deployed.read_text() == STABLE_AGENT
+    assert store.start_session(project) is None
+
+
+def test_stable_arm_restores_the_agent(setup, tmp_path):
+    store, project, candidate = setup
+    deployed = project / ".claude" / "agents" / "qa.md"
+    store.start("qa", candidate, projects=[project])
+    store.start_session(project)
+
+    other = tmp_path / "elsewhere"
+    data = json.loads(store.path.read_text())
+    data["qa"]["projects"] = [str(other)]
+    store.path.write_text(json.dumps(data))
+    store.start_session(project)
+
+    assert deployed.read_text() == STABLE_AGENT
+
+
+def test_instructions_canary_and_promotion(tmp_path, monkeypatch):
+    store = CanaryStore(tmp_path / "canary")
+    candidate = tmp_path / "pm.md"
+    candidate.write_text("Delegate everything.\n")
+    store.start(INSTRUCTIONS, candidate, projects=[tmp_path])
+
+    monkeypatch.setenv(INSTRUCTIONS_ENV_VAR, "/explicit.md")
+    assert store.start_session(tmp_path) is None
+    monkeypatch.delenv(INSTRUCTIONS_ENV_VAR)
+
+    assert store.start_session(tmp_path)
+    assert store.candidate_path(INSTRUCTIONS).read_text() == "Delegate everything.\n"
+    monkeypatch.delenv(INSTRUCTIONS_ENV_VAR)
+
+    store.promote(INSTRUCTIONS)
+    assert store.start_session(tmp_path / "other") is None
+    assert INSTRUCTIONS_ENV_VAR in os.environ
+    with pytest.raises(ValueError, match="not active"):
+        store.promote(INSTRUCTIONS)
+
+
+def _transcript(*prompts, errors=0):
+    lines = []
+    for i, prompt in enumerate(prompts):
+        lines.append({"type": "user", "message": {"content": prompt}})
+        lines.append(
+            {
+                "type": "assistant",
+                "message": {
+                    "id": f"m{i}",
+                    "model": "claude-sonnet-4-6",
+                    "usage": {"input_tokens": 1000, "output_tokens": 100},
+                    "content": [],
+                },
+            }
+        )
+    error = {"type": "tool_result", "tool_use_id": "t", "is_error": True}
+    lines += [{"type": "user", "message": {"content": [error]}}] * errors
+    return lines
+
+
+def test_session_outcome():
+    outcome = session_outcome(
+        _transcript("Fix the bug", "that didn't work, try again", errors=2)
+    )
+
+    assert (outcome.turns, outcome.corrections, outcome.tool_errors) == (2, 1, 2)
+    assert outcome.cost_usd > 0
+
+
+def test_status_compares_arms(setup, monkeypatch):
+    from claude_mpm.cli.commands.canary import CanaryCommand
+
+    store, project, candidate = setup
+    store.start("qa", candidate, projects=[project])
+    session_id = store.start_session(project)
+    transcript = project / "transcript.jsonl"
+    transcript.write_text(
+        "\n".join(json.dumps(line) for line in _transcript("Go", "still broken"))
+    )
+    monkeypatch.setattr(
+        "claude_mpm.services.session_analysis.transcript_parser.locate_transcript",
+        lambda sid, cwd: transcript if sid == session_id else project / "none",
+    )
+
+    command = CanaryCommand(store)
+    result = command.run(argparse.Namespace(canary_command="status", name="qa"))
+
+    assert result.success
+    assert "Corrections" in result.message
+    assert result.data["arms"][CANDIDATE]["correction_rate"] == 0.5
+    assert result.data["arms"][STABLE]["sessions"] == 0
+    assert "not meaningful yet" in result.message
+
+    missing = command.run(argparse.Namespace(canary_command="promote", name="nope"))
+    assert missing.message == "No canary for 'nope'"
+
+
+def test_canary_subcommands_parse():
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    parser = create_parser()
+    args = parser.parse_args(
+        ["canary", "start", "qa", "v2.md", "--percent", "25", "--project", "."]
+    )
+    assert (args.name, args.file, args.percent, args.project) == (
+        "qa",
+        "v2.md",
+        25,
+        ["."],
+    )
+    assert parser.parse_args(["canary", "promote", "qa"]).canary_command == "promote"
+    with pytest.raises(SystemExit):
+        parser.parse_args(["canary", "start", "qa", "v2.md", "--percent", "120"])
@@ -0,0 +1,134 @@
+"""
+Tests for the per-project onboarding checklist.
+
+COVERAGE:
+- Default steps check deployed agents, hooks, CI and configuration; custom
+  steps in checklist.yaml check files, config keys and required agents
+- Only manual steps are marked done and undone, with who recorded them
+- checklist status reports several projects and --strict fails if any is
+  incomplete
+"""
+
+import json
+from argparse import Namespace
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.cli.commands.checklist import ChecklistCommand
+from claude_mpm.services.checklist import CHECKLIST_FILE, Checklist
+
+
+def _onboard(root: Path) -> None:
+    agents = root / ".claude" / "agents"
+    agents.mkdir(parents=True)
+    (agents / "engineer.md").write_text("---\nname: engineer\n---\nBody\n")
+    (root / ".claude" / "settings.json").write_text(
+        json.dumps(
+            {
+                "hooks": {
+                    "PreToolUse": [
+                        {"hooks": [{"type": "command", "_mpm": True}]}
+                    ]
+                }
+            }
+        )
+    )
+    workflows = root / ".github" / "workflows"
+    workflows.mkdir(parents=True)
+    (workflows / "ci.yml").write_text("run: claude-mpm checklist status --strict\n")
+    (root / ".claude-mpm").mkdir()
+    (root / ".claude-mpm" / "configuration.yaml").write_text(
+        "agents:\n  max_concurrent: 4\n"
+    )
+
+
+@pytest.fixture
+def project(tmp_path):
+    root = tmp_path / "project"
+    root.mkdir()
+    return root
+
+
+def test_default_and_custom_steps(project):
+    status = Checklist(project).status()
+    assert status.source == "default steps"
+    assert [r.id for r in status.results] == ["agents", "hooks", "ci", "config"]
+    assert status.done == 0
+    assert all(r.hint for r in status.results)
+
+    _onboard(project)
+    assert Checklist(project).status().complete
+
+    (project / CHECKLIST_FILE).write_text(
+        "steps:\n"
+        "  - id: agents\n    check: agents_deployed\n    agents: [engineer, qa]\n"
+        "  - id: limits\n    check: config\n    key: agents.max_concurrent\n"
+        "    equals: 2\n"
+        "  - id: ci\n    check: file\n    path: .github/workflows/*.yml\n"
+        "    contains: 'checklist status'\n"
+        "  - id: odd\n    check: no_such_check\n"
+    )
+    results = {r.id: r for r in Checklist(project).status().results}
+    assert results["agents"].detail == "missing agents: qa"
+    assert results["limits"].detail == "agents.max_concurrent is 4, expected 2"
+    assert results["ci"].done
+    assert results["odd"].detail == "unknown check 'no_such_check'"
+
+    (project / CHECKLIST_FILE).write_text(
+        "steps:\n  - id: a\n    check: manual\n  - id: a\n    check: manual\n"
+    )
+    with pytest.raises(ValueError, match="Duplicate"):
+        Checklist(project).steps()
+
+
+def test_only_manual_steps_are_marked(project):
+    (project / ".claude-mpm").mkdir()
+    (project / CHECKLIST_FILE).write_text(
+        "steps:\n"
+        "  - id: owner\n    title: Team owner agreed\n    check: manual\n"
+        "  - id: agents\n    check: agents_deployed\n"
+    )
+    checklist = Checklist(project)
+
+    with pytest.raises(ValueError, match="checked automatically"):
+        checklist.mark("agents")
+    with pytest.raises(ValueError, match="No checklist step"):
+        checklist.mark("missing")
+
+    checklist.mark("owner", note="Platform team")
+    owner = checklist.status().results[0]
+    assert owner.done
+    assert owner.detail.startswith("marked done by ")
+    state = json.loads(checklist.state_path.read_text())
+    assert state["steps"]["owner"]["note"] == "Platform team"
+
+    checklist.mark("owner", done=False)
+    assert not checklist.status().results[0].done
+
+
+def test_status_of_several_projects(tmp_path, project):
+    other = tmp_path / "other"
+    other.mkdir()
+    _onboard(other)
+    command = ChecklistCommand(project)
+
+    args = Namespace(
+        checklist_command="status",
+        projects=[str(project), str(other)],
+        json=False,
+        strict=False,
+    )
+    result = command.run(args)
+    assert result.success
+    lines = result.message.splitlines()
+    assert lines[0].endswith("0/4  missing: agents, hooks, ci, config")
+    assert lines[1].endswith("4/4  complete")
+
+    args.strict = True
+    assert not command.run(args).success
+
+    args.projects, args.json = [str(other)], True
+    result = command.run(args)
+    assert result.success
+    assert json.loads(result.message)[0]["complete"] is True
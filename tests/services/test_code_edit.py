@@ -0,0 +1,303 @@
+"""
+Tests for syntax-aware code edits.
+
+COVERAGE:
+- Python definitions and identifiers from the ast fallback match tree-sitter
+  (when tree-sitter-python is installed); other languages without a grammar
+  name the package to install
+- The tree-sitter walk: Go methods belong to their receiver type, a type's
+  range covers its declaration, statement_list bodies are unwrapped
+- rename_symbol renames identifiers only (not strings, comments or longer
+  names), can be limited to one definition and refuses names in use
+- insert_method places a method last or after a member, re-indented, and
+  handles empty brace bodies
+- wrap_function wraps a body (keeping the docstring outside) or a whole
+  function in a template
+- Ambiguous names, edits that would not parse and paths outside the root
+  are refused without writing; dry runs only return the diff
+- The code-edit MCP tools
+"""
+
+import asyncio
+import importlib.util
+import types
+
+import pytest
+
+from claude_mpm.mcp.code_edit_server import CodeEditMCPServer
+from claude_mpm.services.code_edit import (
+    LANGUAGES,
+    CodeEditError,
+    CodeEditor,
+    parse_source,
+)
+from claude_mpm.services.code_edit.syntax import (
+    Definition,
+    _parse_python,
+    _parse_tree_sitter,
+)
+
+SOURCE = '''\
+import os
+
+
+class Store:
+    """Keeps records on disk."""
+
+    def __init__(self, path):
+        self.path = path
+
+    @property
+    def size(self):
+        # path is the file
+        return os.path.getsize(self.path)
+
+
+def load(path):
+    """Read a file."""
+    with open(path) as f:
+        return f.read()
+'''
+
+
+@pytest.fixture
+def editor(tmp_path):
+    (tmp_path / "store.py").write_text(SOURCE)
+    return CodeEditor(tmp_path)
+
+
+def test_python_definitions(editor):
+    assert editor.definitions("store.py") == [
+        {"kind": "class", "name": "Store", "line": 4, "end_line": 13},
+        {"kind": "function", "name": "Store.__init__", "line": 7, "end_line": 8},
+        {"kind": "function", "name": "Store.size", "line": 10, "end_line": 13},
+        {"kind": "function", "name": "load", "line": 16, "end_line": 19},
+    ]
+    with pytest.raises(CodeEditError, match="Unsupported file type"):
+        editor.definitions("notes.txt")
+    if not importlib.util.find_spec("tree_sitter_go"):
+        (editor.root / "main.go").write_text("package main\n")
+        with pytest.raises(CodeEditError, match="pip install tree-sitter-go"):
+            editor.definitions("main.go")
+
+
+@pytest.mark.skipif(
+    not importlib.util.find_spec("tree_sitter_python"),
+    reason="tree-sitter-python is not installed",
+)
+def test_ast_fallback_matches_tree_sitter():
+    source = SOURCE.encode()
+    fallback = _parse_python(source)
+    parsed = parse_source(source, LANGUAGES["python"])
+    assert parsed.backend == "tree-sitter"
+
+    def summary(p):
+        return [(d.kind, d.qualified_name, p.line_of(d.start)) for d in p.definitions]
+
+    assert summary(parsed) == summary(fallback)
+    assert [(i.name, i.start) for i in parsed.identifiers] == [
+        (i.name, i.start) for i in fallback.identifiers
+    ]
+
+
+class FakeNode:
+    """Just enough of a tree-sitter node for the tree walker."""
+
+    def __init__(self, type, start, end, children=(), fields=None, named=True):
+        self.type, self.start_byte, self.end_byte = type, start, end
+        self.children = list(children)
+        self.is_named = named
+        self.fields = fields or {}
+        self.parent = None
+        self.has_error = False
+        for child in self.children:
+            child.parent = self
+
+    @property
+    def named_children(self):
+        return [c for c in self.children if c.is_named]
+
+    @property
+    def named_child_count(self):
+        return len(self.named_children)
+
+    def child_by_field_name(self, name):
+        return self.fields.get(name)
+
+
+def test_tree_sitter_walk_for_go():
+    source = b"type Point struct{}\n\nfunc (p *Point) Norm() int {\n\treturn 0\n}\n"
+    name = FakeNode("type_identifier", 5, 10)
+    spec = FakeNode("type_spec", 5, 19, [name], {"name": name})
+    receiver_type = FakeNode("type_identifier", 30, 35)
+    receiver = FakeNode("parameter_list", 26, 36, [receiver_type])
+    method_name = FakeNode("field_identifier", 37, 41)
+    statement = FakeNode("return_statement", 51, 59)
+    body = FakeNode("block", 48, 61, [FakeNode("statement_list", 51, 59, [statement])])
+    method = FakeNode(
+        "method_declaration",
+        21,
+        61,
+        [receiver, method_name, body],
+        {"receiver": receiver, "name": method_name, "body": body},
+    )
+    root = FakeNode(
+        "source_file", 0, 62, [FakeNode("type_declaration", 0, 19, [spec]), method]
+    )
+    parser = types.SimpleNamespace(
+        parse=lambda src: types.SimpleNamespace(root_node=root)
+    )
+
+    parsed = _parse_tree_sitter(parser, LANGUAGES["go"], source)
+    point, norm = parsed.definitions
+    assert (point.kind, point.name, point.start, point.end) == ("type", "Point", 0, 19)
+    assert (norm.qualified_name, norm.block, norm.statements) == (
+        "Point.Norm",
+        (48, 61),
+        (51, 59),
+    )
+    assert [i.name for i in parsed.identifiers] == ["Point", "Point", "Norm"]
+
+
+def test_rename_symbol(editor):
+    target = editor.root / "store.py"
+    result = editor.rename_symbol(
+        "store.py", "path", "filename", within="Store.__init__"
+    )
+    assert result.written and result.changes == 3
+    text = target.read_text()
+    assert "def __init__(self, filename):\n        self.filename = filename" in text
+    assert "# path is the file" in text  # Comments are left alone
+    assert "os.path.getsize(self.path)" in text  # Outside __init__
+
+    result = editor.rename_symbol("store.py", "load", "read_file", dry_run=True)
+    assert not result.written and "+def read_file(path):" in result.diff
+    assert "def load(path):" in target.read_text()
+
+    with pytest.raises(CodeEditError, match="self is already used in Store.__init__"):
+        editor.rename_symbol("store.py", "filename", "self", within="__init__")
+    with pytest.raises(CodeEditError, match="Not a valid identifier"):
+        editor.rename_symbol("store.py", "load", "read-file")
+    with pytest.raises(CodeEditError, match="No identifier named missing"):
+        editor.rename_symbol("store.py", "missing", "other")
+    # A keyword is not a valid name, so the edited file does not parse
+    with pytest.raises(CodeEditError, match="would leave store.py with syntax"):
+        editor.rename_symbol("store.py", "load", "class")
+    assert "def load(path):" in target.read_text()
+
+
+def test_insert_method(editor):
+    target = editor.root / "store.py"
+    method = "def clear(self):\n    open(self.path, 'w').close()\n"
+    editor.insert_method("store.py", "Store", method)
+    text = target.read_text()
+    assert (
+        "        return os.path.getsize(self.path)\n\n"
+        "    def clear(self):\n        open(self.path, 'w').close()\n\n\n"
+        "def load(path):"
+    ) in text
+
+    indented = "        def reset(self):\n            pass"
+    editor.insert_method("store.py", "Store", indented, after="__init__")
+    text = target.read_text()
+    assert "        self.path = path\n\n    def reset(self):\n        pass\n" in text
+
+    with pytest.raises(CodeEditError, match="Store has no member nope"):
+        editor.insert_method("store.py", "Store", method, after="nope")
+    with pytest.raises(CodeEditError, match="No class named load"):
+        editor.insert_method("store.py", "load", method)
+    with pytest.raises(CodeEditError, match="syntax errors; nothing was written"):
+        editor.insert_method("store.py", "Store", "def broken(self:\n    pass")
+
+
+def test_insert_method_into_empty_braces(tmp_path):
+    # Brace handling does not depend on the parser; use a hand-made definition
+    source = b"class Empty {}\n"
+    holder = Definition(kind="class", name="Empty", start=0, end=14, block=(12, 14))
+    edited = CodeEditor(tmp_path)._append_member(
+        source, LANGUAGES["javascript"], holder, "run() {\n  return 1;\n}", "\n"
+    )
+    assert edited == b"class Empty {\n    run() {\n      return 1;\n    }\n}\n"
+
+
+def test_wrap_function(editor):
+    target = editor.root / "store.py"
+    template = """
+        try:
+            {code}
+        except OSError:
+            return None
+    """
+    result = editor.wrap_function("store.py", "load", template)
+    assert result.written
+    assert target.read_text().endswith(
+        'def load(path):\n    """Read a file."""\n    try:\n'
+        "        with open(path) as f:\n            return f.read()\n"
+        "    except OSError:\n        return None\n"
+    )
+
+    editor.wrap_function(
+        "store.py", "Store.size", "if True:\n    {code}", target="function"
+    )
+    assert "    if True:\n        @property\n        def size(self):\n" in (
+        target.read_text()
+    )
+
+    with pytest.raises(CodeEditError, match="exactly one line holding only"):
+        editor.wrap_function("store.py", "load", "try: {code}")
+    with pytest.raises(CodeEditError, match="No function or method named Store"):
+        editor.wrap_function("store.py", "Store", "if x:\n    {code}")
+
+
+def test_refusals(tmp_path):
+    (tmp_path / "dup.py").write_text(
+        "class A:\n    def run(self):\n        pass\n\n\n"
+        "class B:\n    def run(self):\n        pass\n"
+    )
+    (tmp_path / "broken.py").write_text("def f(:\n")
+    editor = CodeEditor(tmp_path / ".")
+
+    with pytest.raises(CodeEditError, match=r"run is ambiguous: A.run \(line 2\)"):
+        editor.wrap_function("dup.py", "run", "if x:\n    {code}")
+    editor.rename_symbol("dup.py", "run", "start", within="B.run")
+    assert "class B:\n    def start(self):" in (tmp_path / "dup.py").read_text()
+
+    with pytest.raises(CodeEditError, match="has syntax errors"):
+        editor.rename_symbol("broken.py", "f", "g")
+    with pytest.raises(CodeEditError, match="is outside"):
+        editor.definitions("../elsewhere.py")
+    with pytest.raises(CodeEditError, match="File not found"):
+        editor.definitions("missing.py")
+
+
+def test_code_edit_mcp_tools(tmp_path):
+    (tmp_path / "store.py").write_text(SOURCE)
+    server = CodeEditMCPServer(project_root=tmp_path)
+
+    def call(name, arguments):
+        return asyncio.run(server._dispatch_tool(name, arguments))
+
+    listed = call("list_definitions", {"path": "store.py"})
+    assert [d["name"] for d in listed["definitions"]][0] == "Store"
+
+    result = call(
+        "rename_symbol",
+        {"path": "store.py", "old_name": "load", "new_name": "fetch", "dry_run": True},
+    )
+    assert result["changes"] == 1 and not result["written"]
+    assert result["backend"] in ("ast", "tree-sitter")
+
+    result = call(
+        "insert_method",
+        {"path": "store.py", "container": "Store", "code": "def x(self): ..."},
+    )
+    assert result["written"]
+    assert "    def x(self): ..." in (tmp_path / "store.py").read_text()
+
+    with pytest.raises(CodeEditError, match="No function or method named nope"):
+        call(
+            "wrap_function",
+            {"path": "store.py", "function": "nope", "template": "if x:\n    {code}"},
+        )
+    with pytest.raises(ValueError, match="Unknown tool"):
+        call("nope", {})
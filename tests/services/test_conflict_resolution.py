@@ -0,0 +1,187 @@
+"""
+Tests for agent-assisted merge conflict resolution.
+
+COVERAGE:
+- Conflict region parsing, including diff3 bases and malformed markers
+- Resolution replies: fenced block, explanation, leftover markers
+- Discovery of conflicted files and per-side history in a real merge
+- One agent per conflict region, prompts carry both sides and history
+- Applying accepted regions only, staging fully resolved files, refusing
+  files changed since the proposals
+- The resolve-conflicts command's review loop
+"""
+
+import argparse
+import subprocess
+
+import pytest
+
+from claude_mpm.cli.commands.resolve_conflicts import ResolveConflictsCommand
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.conflict_resolution import (
+    ConflictError,
+    ConflictResolver,
+    apply_resolutions,
+    parse_conflicts,
+    parse_resolution,
+)
+
+DIFF3 = """\
+a
+<<<<<<< HEAD
+ours
+||||||| base
+old
+=======
+theirs
+>>>>>>> feature
+b
+<<<<<<< HEAD
+x
+=======
+y
+>>>>>>> feature
+"""
+
+
+def git(repo, *args):
+    subprocess.run(["git", *args], cwd=repo, check=True, capture_output=True)
+
+
+@pytest.fixture
+def repo(tmp_path, monkeypatch):
+    """A repository stopped on a merge with two conflicts in app.py."""
+    monkeypatch.chdir(tmp_path)
+    git(tmp_path, "init", "-q", "-b", "main")
+    git(tmp_path, "config", "user.email", "dev@example.com")
+    git(tmp_path, "config", "user.name", "Dev")
+    app = tmp_path / "app.py"
+    body = "def greet():\n    return '{}'\n\n\n# one\n# two\n# three\nLIMIT = {}\n"
+    app.write_text(body.format("hi", 1))
+    (tmp_path / "other.txt").write_text("same\n")
+    git(tmp_path, "add", ".")
+    git(tmp_path, "commit", "-qm", "initial")
+    git(tmp_path, "checkout", "-qb", "feature")
+    app.write_text(body.format("hello", 2))
+    git(tmp_path, "commit", "-qam", "Say hello")
+    git(tmp_path, "checkout", "-q", "main")
+    app.write_text(body.format("hey", 3))
+    git(tmp_path, "commit", "-qam", "Say hey")
+    with pytest.raises(subprocess.CalledProcessError):
+        git(tmp_path, "merge", "feature")
+    return tmp_path
+
+
+class FakeAgent:
+    """Answers each conflict prompt with the incoming side."""
+
+    prompts = []
+
+    def __init__(self, config):
+        self.config = config
+
+    async def run(self, prompt, config=None):
+        FakeAgent.prompts.append(prompt)
+        if "return 'hey'" in prompt.split("Whole file")[0]:
+            block = "```resolution\n    return 'hello'\n```"
+            return AgentResult(text=f"Keep the greeting from feature.\n{block}")
+        return AgentResult(text="I am not sure.")
+
+
+def test_parse_conflicts_with_base():
+    first, second = parse_conflicts(DIFF3)
+    assert (first.start, first.end, first.ours, first.base, first.theirs) == (
+        1,
+        8,
+        ["ours\n"],
+        ["old\n"],
+        ["theirs\n"],
+    )
+    assert (first.ours_label, first.theirs_label) == ("HEAD", "feature")
+    assert second.base is None and second.index == 1
+
+    with pytest.raises(ConflictError, match="not terminated"):
+        parse_conflicts("<<<<<<< HEAD\na\n=======\nb\n")
+
+
+def test_parse_resolution():
+    text, why = parse_resolution("Both.\n```resolution\nx\ny\n```\nDone")
+    assert (text, why) == ("x\ny\n", "Both.\n\nDone")
+    assert parse_resolution("```resolution\n```")[0] == ""
+    with pytest.raises(ConflictError, match="no ```resolution"):
+        parse_resolution("just x")
+    with pytest.raises(ConflictError, match="markers"):
+        parse_resolution("```resolution\n<<<<<<< HEAD\n```")
+
+
+def test_apply_resolutions_keeps_rejected_regions():
+    result = apply_resolutions(DIFF3, {1: "xy"})
+    assert result.endswith("b\nxy\n")
+    assert len(parse_conflicts(result)) == 1
+
+
+def test_propose_and_apply(repo):
+    FakeAgent.prompts = []
+    resolver = ConflictResolver(runtime_factory=FakeAgent)
+    assert resolver.conflicted_paths() == ["app.py"]
+    assert resolver.operation_head() == "MERGE_HEAD"
+    history = {side.ref: side.commits for side in resolver.history("app.py")}
+    assert "Say hey" in history["HEAD"][0] and "Say hello" in history["MERGE_HEAD"][0]
+
+    files = resolver.load()
+    proposals = resolver.propose(files)
+    assert len(FakeAgent.prompts) == 2
+    assert all("Commits on MERGE_HEAD" in p for p in FakeAgent.prompts)
+    first, second = sorted(proposals, key=lambda p: p.hunk.index)
+    assert first.ok and first.explanation == "Keep the greeting from feature."
+    assert "-    return 'hey'\n+    return 'hello'" in first.diff()
+    assert not second.ok and "no ```resolution" in second.error
+
+    assert resolver.apply(files[0], [first]) is False
+    text = (repo / "app.py").read_text()
+    assert "return 'hello'" in text and "LIMIT = 3" in text
+    assert resolver.conflicted_paths() == ["app.py"]
+
+    # The file changed on disk: proposals made against the old text are refused
+    with pytest.raises(ConflictError, match="changed since"):
+        resolver.apply(files[0], [first])
+
+    files = resolver.load(["app.py"])
+    assert resolver.apply(files[0], [_proposal(files[0], "LIMIT = 2\n")]) is True
+    assert resolver.conflicted_paths() == []
+
+    with pytest.raises(ConflictError, match="not in conflict"):
+        resolver.load(["other.txt"])
+
+
+def _proposal(file, resolution):
+    from claude_mpm.services.conflict_resolution import Proposal
+
+    return Proposal(file.path, file.hunks[0], resolution)
+
+
+def test_command_review(repo, capsys):
+    answers = iter(["y"])
+    command = ResolveConflictsCommand(
+        input_func=lambda _prompt: next(answers),
+        resolver_factory=lambda **kw: ConflictResolver(runtime_factory=FakeAgent, **kw),
+    )
+    args = argparse.Namespace(
+        paths=[],
+        model=None,
+        jobs=2,
+        yes=False,
+        dry_run=False,
+        no_stage=False,
+        json=False,
+    )
+    result = command.run(args)
+    assert result.success
+    assert result.data == {"applied": 1, "total": 2, "resolved_files": []}
+    out = capsys.readouterr().out
+    assert "No proposal:" in out and "Wrote 1 resolution(s) to app.py" in out
+
+    args.dry_run = True
+    result = command.run(args)
+    assert "0 of 1 conflict(s)" in result.message
+    assert len(parse_conflicts((repo / "app.py").read_text())) == 1
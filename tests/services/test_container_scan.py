@@ -0,0 +1,239 @@
+"""Tests for Dockerfile and container image scanning.
+
+COVERAGE:
+- Dockerfiles are found by name outside hidden and dependency directories
+- Continuations, comments and heredocs parse into instructions
+- Unpinned base images, root final stages, secret ENV/ARG values and copied
+  secret files are reported; .dockerignore, stage aliases, digests and
+  build-stage copies are respected; ignored rules are left out
+- trivy results become vulnerable-package and secret-in-layer findings; a
+  missing tool is reported once
+- The doctor check turns findings into its status
+"""
+
+import json
+import subprocess
+
+from claude_mpm.core.enums import OperationResult, ValidationSeverity
+from claude_mpm.services.container_scan import (
+    LATEST_TAG,
+    ROOT_USER,
+    SECRET_FILE,
+    SECRET_IN_ENV,
+    ContainerScanConfig,
+    ContainerScanner,
+    find_dockerfiles,
+    parse_dockerfile,
+)
+from claude_mpm.services.diagnostics.checks import ContainerImagesCheck
+
+TRIVY_OUTPUT = {
+    "Results": [
+        {
+            "Target": "python:3.12-slim (debian 12.5)",
+            "Vulnerabilities": [
+                {
+                    "VulnerabilityID": "CVE-2024-0001",
+                    "PkgName": "openssl",
+                    "InstalledVersion": "3.0.11-1",
+                    "FixedVersion": "3.0.13-1",
+                    "Severity": "CRITICAL",
+                    "Title": "openssl: heap overflow",
+                },
+                {
+                    "VulnerabilityID": "CVE-2024-0002",
+                    "PkgName": "zlib",
+                    "InstalledVersion": "1.2.13",
+                    "Severity": "LOW",
+                },
+            ],
+        },
+        {
+            "Target": "/app/config.py",
+            "Secrets": [
+                {
+                    "RuleID": "aws-access-key-id",
+                    "Title": "AWS Access Key ID",
+                    "Severity": "CRITICAL",
+                    "StartLine": 3,
+                }
+            ],
+        },
+    ]
+}
+
+
+def _scan(project, **config):
+    return ContainerScanner(project, ContainerScanConfig(**config)).scan()
+
+
+def test_find_and_parse_dockerfiles(tmp_path):
+    for name in (
+        "Dockerfile",
+        "api/Dockerfile.prod",
+        "web/app.Dockerfile",
+        "Containerfile",
+        "node_modules/pkg/Dockerfile",
+        ".devcontainer/Dockerfile",
+        "docs/Dockerfile.md/readme",
+    ):
+        (tmp_path / name).parent.mkdir(parents=True, exist_ok=True)
+        (tmp_path / name).write_text("FROM scratch\n")
+    assert [p.relative_to(tmp_path).as_posix() for p in find_dockerfiles(tmp_path)] == [
+        "Containerfile",
+        "Dockerfile",
+        "api/Dockerfile.prod",
+        "web/app.Dockerfile",
+    ]
+
+    instructions = parse_dockerfile(
+        "# syntax=docker/dockerfile:1\n"
+        "FROM python:3.12 AS build\n"
+        "RUN apt-get update && \\\n"
+        "    # comment inside a continuation\n"
+        "    apt-get install -y git\n"
+        "RUN <<EOF\n"
+        "USER root\n"
+        "EOF\n"
+        'CMD ["python", "app.py"]\n'
+    )
+    assert [(i.keyword, i.line) for i in instructions] == [
+        ("FROM", 2),
+        ("RUN", 3),
+        ("RUN", 6),
+        ("CMD", 9),
+    ]
+    assert instructions[1].args == "apt-get update && apt-get install -y git"
+
+
+def test_dockerfile_rules(tmp_path):
+    (tmp_path / "Dockerfile").write_text(
+        "ARG BASE=python:3.12\n"
+        "FROM node AS assets\n"
+        "FROM $BASE AS build\n"
+        "FROM ubuntu:latest\n"
+        "FROM debian@sha256:abc123\n"
+        "ENV API_TOKEN=abc123 LOG_LEVEL=info\n"
+        "ENV DB_PASSWORD ${DB_PASSWORD}\n"
+        "ARG GITHUB_TOKEN\n"
+        "COPY --from=assets /app/.env /app/\n"
+        "COPY app.py .env /app/\n"
+        "COPY deploy/ /certs/\n"
+        "USER 0:0\n"
+    )
+    (tmp_path / "app.py").write_text("print()\n")
+    (tmp_path / ".env").write_text("SECRET=1\n")
+    (tmp_path / ".env.example").write_text("SECRET=\n")
+    (tmp_path / "deploy" / "old").mkdir(parents=True)
+    (tmp_path / "deploy" / "server.pem").write_text("-----BEGIN\n")
+    (tmp_path / "deploy" / "old" / "server.pem").write_text("-----BEGIN\n")
+    (tmp_path / ".dockerignore").write_text("# stale certs\n**/old\n")
+
+    findings = [(f.rule, f.line, f.severity) for f in _scan(tmp_path).findings]
+    assert findings == [
+        (SECRET_IN_ENV, 6, "high"),
+        (SECRET_FILE, 10, "high"),
+        (SECRET_FILE, 11, "high"),
+        (LATEST_TAG, 2, "medium"),
+        (LATEST_TAG, 4, "medium"),
+        (ROOT_USER, 12, "medium"),
+    ]
+    report = _scan(tmp_path)
+    assert "abc123" not in json.dumps(report.to_dict())
+    assert report.findings[1].message == "COPY .env puts .env in the image"
+    assert report.findings[2].message == (
+        "COPY deploy/ puts deploy/server.pem in the image"
+    )
+    assert report.summary() == "3 high, 3 medium in 1 Dockerfile"
+
+    (tmp_path / ".dockerignore").write_text("**/*.pem\n.env*\n!.env\n")
+    report = _scan(tmp_path, ignore=[LATEST_TAG])
+    assert [f.rule for f in report.findings] == [
+        SECRET_IN_ENV,
+        SECRET_FILE,
+        ROOT_USER,
+    ]
+
+    (tmp_path / "Dockerfile").write_text("FROM scratch\nUSER app\n")
+    assert _scan(tmp_path).summary() == "No problems found in 1 Dockerfile"
+    (tmp_path / "Dockerfile").write_text("FROM alpine:3.20\n")
+    assert _scan(tmp_path).findings[0].message == (
+        "The final stage runs as root (no USER instruction)"
+    )
+
+
+def test_image_scanning(tmp_path):
+    (tmp_path / "Dockerfile").write_text(
+        "FROM golang:1.22 AS build\nFROM python:3.12-slim\nUSER app\n"
+    )
+    (tmp_path / "worker").mkdir()
+    (tmp_path / "worker" / "Dockerfile").write_text("ARG BASE\nFROM $BASE\nUSER app\n")
+    calls = []
+
+    def tools(cmd, **kwargs):
+        calls.append(cmd)
+        return subprocess.CompletedProcess(cmd, 0, json.dumps(TRIVY_OUTPUT), "")
+
+    config = ContainerScanConfig(images="base")
+    report = ContainerScanner(tmp_path, config, runner=tools).scan()
+    # Base images set by build arguments are unknown until the build
+    assert calls == [
+        [
+            "trivy",
+            "image",
+            "--quiet",
+            "--format",
+            "json",
+            "--scanners",
+            "vuln,secret",
+            "python:3.12-slim",
+        ]
+    ]
+    assert [(f.rule, f.severity, f.id) for f in report.findings] == [
+        ("vulnerable-package", "critical", "CVE-2024-0001"),
+        ("secret-in-layer", "critical", "aws-access-key-id"),
+        ("vulnerable-package", "low", "CVE-2024-0002"),
+    ]
+    assert report.findings[0].remediation.startswith("Upgrade openssl to 3.0.13-1")
+
+    calls.clear()
+    config = ContainerScanConfig(images="build", ignore=["CVE-2024-0002"])
+    report = ContainerScanner(tmp_path, config, runner=tools).scan()
+    assert [c[:2] for c in calls] == [["docker", "build"], ["trivy", "image"]] * 2
+    assert calls[0][-1] == str(tmp_path)
+    assert calls[1][-1] == calls[0][calls[0].index("-t") + 1]
+    assert "CVE-2024-0002" not in [f.id for f in report.findings]
+
+    def missing(cmd, **kwargs):
+        calls.append(cmd)
+        raise FileNotFoundError(cmd[0])
+
+    calls.clear()
+    report = ContainerScanner(tmp_path, config, runner=missing).scan()
+    assert report.errors == ["Dockerfile: docker is not installed"]
+    assert len(calls) == 1
+    assert ContainerScanConfig(images="everything").images == "none"
+
+
+def test_doctor_check(tmp_path):
+    check = ContainerImagesCheck(project_dir=tmp_path)
+    assert not check.should_run()
+
+    (tmp_path / "Dockerfile").write_text("FROM alpine:3.20\nUSER app\n")
+    assert check.should_run()
+    result = check.run()
+    assert result.status == OperationResult.SUCCESS
+    assert result.message == "No problems found in 1 Dockerfile"
+
+    (tmp_path / "Dockerfile").write_text("FROM alpine\nUSER app\n")
+    result = check.run()
+    assert result.status == ValidationSeverity.WARNING
+    assert result.sub_results == []
+    assert result.details == {"dockerfiles": ["Dockerfile"], "findings": {"medium": 1}}
+
+    (tmp_path / "Dockerfile").write_text("FROM alpine\nENV AWS_SECRET_KEY=x\n")
+    result = ContainerImagesCheck(verbose=True, project_dir=tmp_path).run()
+    assert result.status == ValidationSeverity.ERROR
+    assert result.message == "1 high, 2 medium in 1 Dockerfile"
+    assert [r.severity for r in result.sub_results] == ["high", "medium", "medium"]
+    assert result.sub_results[0].category == "secret-in-env (Dockerfile:2)"
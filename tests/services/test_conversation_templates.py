@@ -0,0 +1,152 @@
+"""Tests for conversation templates.
+
+COVERAGE:
+- Variables are filled from values and defaults; unknown, missing and
+  undeclared variables are rejected
+- Project templates win over user and skill source templates, and agents
+  not listed by a template are denied as ``Task(<name>)``
+- ``start`` maps a template onto run options, with the prompt as the first
+  message interactively and as the input headless
+"""
+
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.cli.commands.start import apply_template
+from claude_mpm.services.conversation_templates import (
+    agent_denials,
+    find_template,
+    list_templates,
+    parse_template,
+)
+
+BUG_TRIAGE = """
+description: Reproduce and triage a reported bug
+variables:
+  issue:
+    description: Issue number
+  area:
+    default: the whole project
+prompt: |
+  Triage issue #{{ issue }} in {{area}}.
+agents: [research, qa]
+policies:
+  labels: [bugfix]
+  env: {TRIAGE_MODE: "1"}
+  model: opus
+  disallowed_tools: [WebFetch]
+  skip_permissions: false
+"""
+
+
+def _write(path, text):
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(text)
+
+
+def _deploy(project, *names):
+    for name in names:
+        agent = project / ".claude" / "agents" / f"{name}.md"
+        _write(agent, f"---\nname: {name}\n---\n")
+
+
+def _run_args(**overrides):
+    values = {
+        "template": "bug-triage",
+        "template_vars": ["issue=42"],
+        "resume": None,
+        "mpm_resume": None,
+        "session_labels": ["urgent"],
+        "session_env": None,
+        "chaos": False,
+        "no_dangerously_skip_permissions": False,
+        "max_turns": None,
+        "claude_args": [],
+        "model": None,
+        "disallowedTools": None,
+        "headless": False,
+        "non_interactive": False,
+        "input": None,
+    }
+    values.update(overrides)
+    return Namespace(**values)
+
+
+def test_render_variables(tmp_path):
+    path = tmp_path / "bug-triage.yaml"
+    _write(path, BUG_TRIAGE)
+    template = parse_template(path, "project")
+
+    assert template.render({"issue": "42"}) == "Triage issue #42 in the whole project."
+    assert template.render({}, partial=True).startswith("Triage issue #{{ issue }}")
+    with pytest.raises(ValueError, match="--var issue="):
+        template.render({})
+    with pytest.raises(ValueError, match="no variable"):
+        template.render({"issue": "42", "ticket": "7"})
+
+    _write(path, "prompt: Fix {{ ticket }}\n")
+    with pytest.raises(ValueError, match="undeclared variable"):
+        parse_template(path, "project")
+
+
+def test_lookup_order_and_agent_denials(tmp_path):
+    project, home = tmp_path / "project", tmp_path / "home"
+    _write(
+        project / ".claude-mpm" / "conversation-templates" / "bug-triage.yaml",
+        BUG_TRIAGE,
+    )
+    _write(
+        home / ".claude-mpm" / "conversation-templates" / "bug-triage.yaml",
+        "prompt: user copy\n",
+    )
+    _write(
+        home / ".claude-mpm" / "conversation-templates" / "release.yml",
+        "prompt: Prepare the release\n",
+    )
+
+    assert find_template("bug-triage", project, home).source == "project"
+    assert [(t.name, t.source) for t in list_templates(project, home)] == [
+        ("bug-triage", "project"),
+        ("release", "user"),
+    ]
+    with pytest.raises(ValueError, match="templates list"):
+        find_template("missing", project, home)
+
+    _deploy(project, "research", "qa", "engineer")
+    assert agent_denials(["research", "qa"], project, home) == ["Task(engineer)"]
+    with pytest.raises(ValueError, match="not deployed: ops"):
+        agent_denials(["research", "ops"], project, home)
+
+
+def test_apply_template_to_run_options(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    _write(
+        tmp_path / ".claude-mpm" / "conversation-templates" / "bug-triage.yaml",
+        BUG_TRIAGE,
+    )
+    _deploy(tmp_path, "research", "qa", "engineer")
+
+    args = _run_args(disallowedTools="Bash")
+    apply_template(args, tmp_path)
+
+    assert args.session_labels == ["bugfix", "urgent"]
+    assert args.session_env == ["TRIAGE_MODE=1"]
+    assert args.no_dangerously_skip_permissions is True
+    # The prompt leads so --disallowedTools cannot take it as a value
+    assert args.claude_args == [
+        "Triage issue #42 in the whole project.",
+        "--model",
+        "opus",
+        "--disallowedTools",
+        "WebFetch,Task(engineer),Bash",
+    ]
+    assert args.disallowedTools is None
+
+    args = _run_args(headless=True, model="sonnet")
+    apply_template(args, tmp_path)
+    assert args.input == "Triage issue #42 in the whole project."
+    assert args.claude_args == ["--disallowedTools", "WebFetch,Task(engineer)"]
+
+    with pytest.raises(ValueError, match="cannot be resumed"):
+        apply_template(_run_args(mpm_resume="last"), tmp_path)
@@ -0,0 +1,238 @@
+"""Tests for the shared daemon's foreground service mode."""
+
+import json
+import os
+import signal
+import threading
+import time
+import urllib.error
+import urllib.request
+
+import pytest
+
+from claude_mpm.services import daemon_service
+from claude_mpm.services.daemon_service import (
+    DaemonService,
+    DaemonServiceConfig,
+    DaemonServiceError,
+    request_service_reload,
+)
+from claude_mpm.services.server_discovery import ServerDiscoveryRegistry
+from claude_mpm.services.shared_daemon import SharedDaemon
+
+
+class FakeComponent:
+    registry: dict = {}
+
+    def __init__(self, name):
+        self.name = name
+
+    def status(self):
+        return {"running": self.registry.get(self.name, False)}
+
+    def start(self):
+        self.registry[self.name] = True
+        return True
+
+    def stop(self):
+        self.registry[self.name] = False
+        return True
+
+
+@pytest.fixture
+def daemon(tmp_path):
+    FakeComponent.registry = {}
+    return SharedDaemon(
+        state_dir=tmp_path / "daemon",
+        components={
+            "event_server": lambda host: FakeComponent("event_server"),
+            "commander": lambda host: FakeComponent("commander"),
+        },
+        project_components={},
+        discovery=ServerDiscoveryRegistry(tmp_path / "servers.json"),
+    )
+
+
+class TestConfig:
+    def test_file_then_env_overrides(self, tmp_path):
+        (tmp_path / "configuration.yaml").write_text(
+            "daemon:\n  host: 0.0.0.0\n  health_port: 9000\n"
+            "  components: [event_server]\n"
+        )
+        config = DaemonServiceConfig.load(
+            tmp_path,
+            environ={
+                "CLAUDE_MPM_DAEMON_HEALTH_PORT": "9100",
+                "CLAUDE_MPM_DAEMON_PROJECTS": "/workspace/a:/workspace/b",
+            },
+        )
+
+        assert config.host == "0.0.0.0"
+        assert config.health_port == 9100
+        assert config.components == ["event_server"]
+        assert config.projects == ["/workspace/a", "/workspace/b"]
+
+    def test_missing_directory_is_an_error(self, tmp_path):
+        with pytest.raises(FileNotFoundError):
+            DaemonServiceConfig.load(tmp_path / "nope", environ={})
+
+
+class TestService:
+    def test_health_reflects_configured_components(self, daemon):
+        service = DaemonService(
+            DaemonServiceConfig(components=["event_server"]), daemon
+        )
+        assert service.health()[0] == 503
+
+        daemon.start(only=["event_server"])
+        code, body = service.health()
+        assert code == 200
+        assert body["components"] == {"event_server": True}
+
+    def test_project_component_without_projects_is_healthy(self, daemon):
+        daemon.project_components = {
+            "watched": lambda host, root, entry: FakeComponent("watched")
+        }
+        service = DaemonService(DaemonServiceConfig(components=["watched"]), daemon)
+
+        assert service.health() == (
+            200,
+            {"status": "ok", "components": {"watched": True}},
+        )
+
+    def test_run_serves_healthz_and_stops_components(self, daemon, tmp_path):
+        service = DaemonService(
+            DaemonServiceConfig(
+                health_port=0, projects=[str(tmp_path)], check_interval=0.05
+            ),
+            daemon,
+        )
+        thread = threading.Thread(target=service.run)
+        thread.start()
+        try:
+            for _ in range(100):
+                if service._server is not None:
+                    break
+                threading.Event().wait(0.02)
+            port = service._server.server_address[1]
+            with urllib.request.urlopen(f"http://localhost:{port}/healthz") as resp:
+                assert resp.status == 200
+                assert json.load(resp)["status"] == "ok"
+
+            # A component that dies is restarted by the check loop
+            FakeComponent.registry["commander"] = False
+            for _ in range(100):
+                if FakeComponent.registry["commander"]:
+                    break
+                threading.Event().wait(0.02)
+            assert FakeComponent.registry["commander"] is True
+            assert str(tmp_path.resolve()) in daemon.list_projects()
+
+            with pytest.raises(urllib.error.HTTPError):
+                urllib.request.urlopen(f"http://localhost:{port}/nope")
+        finally:
+            service.stop()
+            thread.join(timeout=5)
+
+        assert not thread.is_alive()
+        assert not any(FakeComponent.registry.values())
+
+
+def _write_config(config_dir, text):
+    path = config_dir / "configuration.yaml"
+    path.write_text(text)
+    # Make sure the change is seen even within one mtime tick
+    stat = path.stat()
+    os.utime(path, ns=(stat.st_atime_ns, stat.st_mtime_ns + 1_000_000_000))
+
+
+def _wait_for(predicate):
+    for _ in range(250):
+        if predicate():
+            return True
+        time.sleep(0.02)
+    return False
+
+
+class TestReload:
+    def test_reload_applies_changes_in_place(self, daemon, tmp_path):
+        project_a, project_b = tmp_path / "a", tmp_path / "b"
+        _write_config(
+            tmp_path,
+            f"daemon:\n  components: [event_server]\n  projects: [{project_a}]\n",
+        )
+        config = DaemonServiceConfig.load(tmp_path, environ={})
+        service = DaemonService(config, daemon, config_dir=tmp_path, environ={})
+        daemon.start(only=config.components)
+        daemon.attach(project_a)
+
+        _write_config(
+            tmp_path,
+            "daemon:\n  host: 0.0.0.0\n  components: [event_server, commander]\n"
+            f"  projects: [{project_b}]\n  check_interval: 5\n",
+        )
+        result = service.reload()
+        assert result.changes == [
+            "started commander",
+            f"detached {project_a.resolve()}",
+            f"attached {project_b.resolve()}",
+            "check_interval 30s -> 5s",
+        ]
+        assert result.restart_required == ["host localhost -> 0.0.0.0"]
+        assert service.config.host == "localhost"
+        assert service.config.check_interval == 5
+        assert FakeComponent.registry == {"event_server": True, "commander": True}
+        assert list(daemon.list_projects()) == [str(project_b.resolve())]
+        assert daemon.service()["last_reload"]["changes"] == result.changes
+
+        # Dropping a component stops only that one
+        _write_config(
+            tmp_path,
+            "daemon:\n  components: [commander]\n  check_interval: 5\n"
+            f"  projects: [{project_b}]\n",
+        )
+        assert service.reload().changes == ["stopped event_server"]
+        assert FakeComponent.registry == {"event_server": False, "commander": True}
+
+        # A file that no longer parses keeps the running configuration
+        _write_config(tmp_path, "daemon: [unclosed\n")
+        result = service.reload()
+        assert "could not read the configuration" in result.error
+        assert service.config.components == ["commander"]
+        assert result.summary().startswith("kept the running configuration")
+
+    def test_file_change_and_sighup_reload_the_running_service(
+        self, daemon, tmp_path, monkeypatch
+    ):
+        monkeypatch.setattr(daemon_service, "WATCH_INTERVAL", 0.05)
+        base = "daemon:\n  health_port: 0\n  components: "
+        _write_config(tmp_path, base + "[event_server]\n")
+        config = DaemonServiceConfig.load(tmp_path, environ={})
+        service = DaemonService(config, daemon, config_dir=tmp_path, environ={})
+        with pytest.raises(DaemonServiceError, match="No daemon service"):
+            request_service_reload(daemon)
+
+        thread = threading.Thread(target=service.run)
+        thread.start()
+        previous = signal.signal(signal.SIGHUP, lambda *_: service.request_reload())
+        try:
+            assert _wait_for(lambda: daemon.service() is not None)
+            assert daemon.service()["pid"] == os.getpid()
+
+            # Editing the file is enough
+            _write_config(tmp_path, base + "[event_server, commander]\n")
+            assert _wait_for(lambda: FakeComponent.registry.get("commander"))
+            assert FakeComponent.registry["event_server"] is True
+
+            # config reload signals the service and reports what it did
+            pid, result = request_service_reload(daemon, timeout=5)
+            assert pid == os.getpid()
+            assert result.changes == []
+            assert result.summary() == "no changes"
+        finally:
+            signal.signal(signal.SIGHUP, previous)
+            service.stop()
+            thread.join(timeout=5)
+
+        assert not thread.is_alive()
+        assert daemon.service() is None
@@ -0,0 +1,123 @@
+"""Tests for the shared daemon's scheduler and watcher processes.
+
+COVERAGE:
+- Scheduled jobs run once per attached project when due, with their runs
+  recorded in the project's namespace; a failing job does not stop others
+- Jobs read the project's own configuration
+- Agent watchers follow attach and detach without a restart
+"""
+
+import pytest
+
+from claude_mpm.core.state_files import read_json
+from claude_mpm.services.agents.agent_hot_reload import AgentReloader
+from claude_mpm.services.daemon_workers import (
+    SCHEDULER_FILE,
+    AgentWatchers,
+    DaemonScheduler,
+    WorkerProcess,
+    project_config,
+)
+from claude_mpm.services.server_discovery import ServerDiscoveryRegistry
+from claude_mpm.services.shared_daemon import SharedDaemon
+
+
+@pytest.fixture
+def daemon(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    return SharedDaemon(
+        state_dir=tmp_path / "daemon",
+        components={},
+        project_components={},
+        discovery=ServerDiscoveryRegistry(tmp_path / "servers.json"),
+    )
+
+
+@pytest.fixture
+def projects(tmp_path, daemon):
+    roots = [tmp_path / "a", tmp_path / "b"]
+    for root in roots:
+        root.mkdir()
+        daemon.attach(root)
+    return roots
+
+
+class TestScheduler:
+    def test_runs_due_jobs_once_per_project(self, daemon, projects):
+        calls = []
+        scheduler = DaemonScheduler(
+            daemon, jobs={"sweep": (60.0, lambda root: calls.append(root))}
+        )
+
+        ran = scheduler.tick(now=1000.0)
+
+        assert sorted(calls) == sorted(p.resolve() for p in projects)
+        assert len(ran) == 2
+        assert scheduler.tick(now=1030.0) == []
+        assert len(scheduler.tick(now=1060.0)) == 2
+
+    def test_records_runs_in_the_project_namespace(self, daemon, projects):
+        def fail(root):
+            if root.name == "a":
+                raise RuntimeError("boom")
+
+        DaemonScheduler(daemon, jobs={"sweep": (60.0, fail)}).tick(now=1000.0)
+
+        entries = daemon.list_projects()
+        runs = {
+            root.name: read_json(
+                daemon.namespace_dir(entries[str(root.resolve())]["namespace"])
+                / SCHEDULER_FILE,
+                {},
+            )["jobs"]["sweep"]
+            for root in projects
+        }
+        assert runs["a"] == {"last_run": 1000.0, "error": "boom"}
+        assert runs["b"] == {"last_run": 1000.0, "error": None}
+
+    def test_jobs_read_the_project_configuration(self, tmp_path):
+        config = tmp_path / ".claude-mpm" / "configuration.yaml"
+        config.parent.mkdir()
+        config.write_text("storage:\n  auto_prune: true\n")
+
+        assert project_config(tmp_path) == {"storage": {"auto_prune": True}}
+        assert project_config(tmp_path / "missing") == {}
+
+
+class FakeObserver:
+    def __init__(self):
+        self.watches = {}
+
+    def schedule(self, handler, path, recursive=False):
+        watch = object()
+        self.watches[watch] = path
+        return watch
+
+    def unschedule(self, watch):
+        del self.watches[watch]
+
+
+class TestAgentWatchers:
+    def test_follows_attach_and_detach(self, daemon, projects, monkeypatch):
+        monkeypatch.setattr(AgentReloader, "event_handler", lambda self: None)
+        for root in projects:
+            (root / ".claude-mpm" / "agent-overrides").mkdir(parents=True)
+        observer = FakeObserver()
+        watchers = AgentWatchers(daemon, observer=observer)
+
+        watchers.sync()
+        assert set(watchers.reloaders) == {str(p.resolve()) for p in projects}
+        assert len(observer.watches) == 2
+
+        daemon.detach(projects[0])
+        watchers.sync()
+
+        assert set(watchers.reloaders) == {str(projects[1].resolve())}
+        assert list(observer.watches.values()) == [
+            str((projects[1] / ".claude-mpm" / "agent-overrides").resolve())
+        ]
+
+
+def test_unknown_worker_is_rejected(tmp_path):
+    with pytest.raises(ValueError):
+        WorkerProcess("nope", state_dir=tmp_path)
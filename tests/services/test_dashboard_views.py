@@ -0,0 +1,92 @@
+"""
+Tests for saved dashboard views.
+
+COVERAGE:
+- Views are saved, replaced by name, listed by name and deleted; values the
+  dashboard does not know are refused
+- view save/list/show/open/delete through the CLI command; open builds the
+  ?view= URL without a browser
+- Entries the dashboard saved with JSON numbers load; invalid ones are skipped
+"""
+
+from argparse import Namespace
+
+import pytest
+
+from claude_mpm.cli.commands.view import ViewCommand
+from claude_mpm.services.dashboard_views import DashboardView, DashboardViews
+
+
+@pytest.fixture
+def views(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    return DashboardViews(tmp_path / ".claude-mpm" / "dashboard-views.json")
+
+
+def test_save_list_delete(views):
+    views.save(DashboardView(name="Blocked", project="all", label="Blocked"))
+    views.save(DashboardView(name="agents-today", tab="agents", since="today"))
+    assert [view.name for view in views.all()] == ["agents-today", "blocked"]
+    assert views.get("blocked").label == "blocked"
+
+    views.save(DashboardView(name="blocked", project="all", left_width=60))
+    assert views.get("blocked").label == ""
+    assert views.get("blocked").summary() == "tab=events project=all left_width=60"
+
+    for invalid in (
+        DashboardView(name="bad name"),
+        DashboardView(name="x", tab="costs"),
+        DashboardView(name="x", since="yesterday"),
+        DashboardView(name="x", left_width=95),
+    ):
+        with pytest.raises(ValueError):
+            views.save(invalid)
+
+    assert views.delete("agents-today")
+    assert not views.delete("agents-today")
+    assert [view.name for view in views.all()] == ["blocked"]
+
+
+def test_view_command(views):
+    command = ViewCommand(views)
+
+    def run(view_command, **kwargs):
+        return command.run(Namespace(view_command=view_command, **kwargs))
+
+    saved = run(
+        "save",
+        name="blocked",
+        description="Blocked everywhere",
+        tab="events",
+        project="all",
+        label="blocked",
+        stream="",
+        since="",
+        left_width=None,
+    )
+    assert saved.success
+    listing = run("list", json=False).message
+    assert "blocked  tab=events project=all label=blocked" in listing
+    assert "Blocked everywhere" in listing
+    assert run("show", name="blocked", json=True).data["label"] == "blocked"
+
+    opened = run("open", name="blocked", host="localhost", port=1, no_browser=True)
+    assert opened.data["url"] == "http://localhost:1/?view=blocked"
+    missing = run("open", name="nope", host="localhost", port=1, no_browser=True)
+    assert not missing.success
+
+    assert run("delete", name="blocked").success
+    assert not run("delete", name="blocked").success
+
+
+
+def test_views_file_written_by_dashboard(views):
+    views.path.parent.mkdir(parents=True)
+    views.path.write_text(
+        '{"version": 1, "views": {'
+        '"wide": {"tab": "tools", "left_width": 70.0},'
+        '"broken": {"tab": "costs"}}}'
+    )
+    assert [view.name for view in views.all()] == ["wide"]
+    assert views.get("wide").left_width == 70
+    assert views.get("broken") is None
@@ -0,0 +1,142 @@
+"""
+Tests for optional at-rest encryption.
+
+COVERAGE:
+- Text is encrypted only for enabled scopes; reads detect encryption
+- Encrypting and decrypting files in place, per scope, by atomic replace
+- Transparent use by the event log
+- The Fernet backend with a key from the environment
+- Enabled but unusable encryption raises instead of writing plaintext
+- The storage encrypt/decrypt/cat commands
+"""
+
+import argparse
+import json
+
+import pytest
+
+from claude_mpm.cli.commands.storage import StorageCommand
+from claude_mpm.services import data_encryption
+from claude_mpm.services.data_encryption import (
+    DataEncryption,
+    DataEncryptionError,
+    EncryptionConfig,
+    is_encrypted,
+    scope_files,
+)
+from claude_mpm.services.event_log import EventLog
+from claude_mpm.services.storage_retention import StorageManager, StoragePolicy
+
+
+class ReverseBackend:
+    """Stand-in cipher so the file handling is tested without a keyring."""
+
+    name = "keyring"
+
+    def encrypt(self, data):
+        return data[::-1]
+
+    def decrypt(self, data):
+        return data[::-1]
+
+
+@pytest.fixture
+def encryption():
+    config = EncryptionConfig(enabled=True, scopes=["memories", "events"])
+    return DataEncryption(config, backends={"keyring": ReverseBackend()})
+
+
+def test_protect_encrypts_only_enabled_scopes(tmp_path, encryption):
+    memory = tmp_path / "engineer_memories.md"
+    response = tmp_path / "response.json"
+
+    memory.write_text(encryption.protect("- use uv", "memories"))
+    response.write_text(encryption.protect("{}", "transcripts"))
+
+    assert is_encrypted(memory.read_bytes())
+    assert b"use uv" not in memory.read_bytes()
+    assert response.read_text() == "{}"
+    assert encryption.read_text(memory) == "- use uv"
+    assert encryption.read_text(response) == "{}"
+
+
+def test_encrypt_and_decrypt_files_in_place(tmp_path, encryption):
+    memories = tmp_path / ".claude-mpm" / "memories"
+    memories.mkdir(parents=True)
+    (memories / "PM_memories.md").write_text("- PM note")
+    (memories / "README.md").write_text("readme")
+
+    files = scope_files("memories", tmp_path, home_dir=tmp_path / "home")
+    assert [p.name for p in files] == ["PM_memories.md"]
+
+    inode = files[0].stat().st_ino
+    assert encryption.encrypt_file(files[0]) is True
+    assert files[0].stat().st_ino != inode
+    assert encryption.encrypt_file(files[0]) is False
+    assert is_encrypted(files[0].read_bytes())
+    assert encryption.decrypt_file(files[0]) is True
+    assert files[0].read_text() == "- PM note"
+    with pytest.raises(ValueError):
+        scope_files("bogus", tmp_path)
+
+
+def test_event_log_reads_and_writes_encrypted(tmp_path, encryption, monkeypatch):
+    monkeypatch.setattr(data_encryption, "_default", lambda: encryption)
+    log_file = tmp_path / "event_log.json"
+
+    EventLog(log_file).append_event("hook.error", {"message": "secret token"})
+
+    assert is_encrypted(log_file.read_bytes())
+    events = EventLog(log_file).list_events()
+    assert events[0]["payload"]["message"] == "secret token"
+
+
+def test_fernet_backend_with_env_key(tmp_path, monkeypatch):
+    fernet = pytest.importorskip("cryptography.fernet")
+    monkeypatch.setenv("CLAUDE_MPM_DATA_KEY", fernet.Fernet.generate_key().decode())
+    encryption = DataEncryption(EncryptionConfig(enabled=True))
+    path = tmp_path / "event_log.json"
+
+    path.write_text(encryption.protect(json.dumps([{"id": 1}]), "events"))
+
+    assert json.loads(encryption.read_text(path)) == [{"id": 1}]
+    monkeypatch.setenv("CLAUDE_MPM_DATA_KEY", fernet.Fernet.generate_key().decode())
+    with pytest.raises(DataEncryptionError):
+        DataEncryption(EncryptionConfig(enabled=True)).read_text(path)
+
+
+def test_misconfigured_encryption_fails_closed(monkeypatch):
+    data_encryption._default.cache_clear()
+    config = EncryptionConfig(enabled=True, backend="bogus")
+    monkeypatch.setattr(EncryptionConfig, "load", classmethod(lambda cls: config))
+    try:
+        with pytest.raises(DataEncryptionError, match="enabled"):
+            data_encryption.protect("- note", "memories")
+
+        config.enabled = False
+        assert data_encryption.protect("- note", "memories") == "- note"
+    finally:
+        data_encryption._default.cache_clear()
+
+
+def test_storage_encrypt_decrypt_cat(tmp_path, encryption):
+    project = tmp_path / "proj"
+    (project / ".claude-mpm").mkdir(parents=True)
+    log_file = project / ".claude-mpm" / "event_log.json"
+    log_file.write_text("[]")
+    manager = StorageManager(tmp_path / "home", project, StoragePolicy())
+    command = StorageCommand(manager, encryption)
+
+    result = command.run(argparse.Namespace(storage_command="encrypt", scope=None))
+    assert result.success and result.data == [str(log_file)]
+    assert is_encrypted(log_file.read_bytes())
+
+    cat = command.run(argparse.Namespace(storage_command="cat", path=str(log_file)))
+    assert cat.message == "[]"
+
+    result = command.run(argparse.Namespace(storage_command="decrypt", scope=None))
+    assert result.success and log_file.read_text() == "[]"
+
+    encryption.config.enabled = False
+    result = command.run(argparse.Namespace(storage_command="encrypt", scope=None))
+    assert not result.success
@@ -0,0 +1,87 @@
+"""
+Tests for content-hash aware deployment writes.
+
+COVERAGE:
+- sync_directory writes only added and changed files, removes files gone
+  from the source and leaves unchanged files (and their mtimes) alone
+- A symlinked target is replaced by a real directory
+- write_if_changed skips identical content
+- deploy_agent_file does not rewrite an agent whose content is unchanged
+"""
+
+import os
+
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+from claude_mpm.services.deployment_delta import (
+    DeploymentDelta,
+    sync_directory,
+    write_if_changed,
+)
+
+
+def _tree(root, files):
+    for rel, content in files.items():
+        path = root / rel
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(content)
+
+
+def test_sync_directory_writes_only_the_delta(tmp_path):
+    source, target = tmp_path / "source", tmp_path / "target"
+    _tree(
+        source,
+        {"SKILL.md": "v1", "references/a.md": "a", "references/b.md": "b"},
+    )
+    first = sync_directory(source, target)
+    assert first.added == ["SKILL.md", "references/a.md", "references/b.md"]
+
+    os.utime(target / "references" / "a.md", (0, 0))
+    (source / "SKILL.md").write_text("v2")
+    (source / "references" / "b.md").unlink()
+    _tree(source, {"scripts/run.sh": "echo"})
+
+    delta = sync_directory(source, target)
+    assert delta == DeploymentDelta(
+        added=["scripts/run.sh"],
+        changed=["SKILL.md"],
+        removed=["references/b.md"],
+        unchanged=["references/a.md"],
+    )
+    assert delta.summary() == "1 changed, 1 added, 1 removed"
+    assert (target / "SKILL.md").read_text() == "v2"
+    assert not (target / "references" / "b.md").exists()
+    assert (target / "references" / "a.md").stat().st_mtime == 0
+
+    again = sync_directory(source, target)
+    assert not again.has_changes
+    assert again.summary() == "no changes"
+
+
+def test_sync_directory_replaces_symlinked_target(tmp_path):
+    source, elsewhere = tmp_path / "source", tmp_path / "elsewhere"
+    _tree(source, {"SKILL.md": "real"})
+    _tree(elsewhere, {"SKILL.md": "linked"})
+    target = tmp_path / "target"
+    target.symlink_to(elsewhere)
+
+    delta = sync_directory(source, target)
+    assert delta.added == ["SKILL.md"]
+    assert not target.is_symlink()
+    assert (elsewhere / "SKILL.md").read_text() == "linked"
+
+
+def test_write_if_changed_and_agent_deployment(tmp_path):
+    target = tmp_path / "out" / "file.md"
+    assert write_if_changed(target, "hello")
+    assert not write_if_changed(target, "hello")
+    assert write_if_changed(target, "hello again")
+
+    source = tmp_path / "engineer.md"
+    source.write_text("---\nname: engineer\n---\n# Engineer\n")
+    deploy_dir = tmp_path / ".claude" / "agents"
+    assert deploy_agent_file(source, deploy_dir).action == "deployed"
+    deployed = deploy_dir / "engineer.md"
+    os.utime(deployed, (0, 0))
+
+    assert deploy_agent_file(source, deploy_dir, force=True).action == "skipped"
+    assert deployed.stat().st_mtime == 0
@@ -0,0 +1,175 @@
+"""
+Tests for diffing deployed agents and skills against their sources.
+
+COVERAGE:
+- A freshly deployed agent shows no modifications, even though deployment
+  adds frontmatter to it
+- Local edits to a deployed agent show as + lines against the recorded source
+- Without a manifest entry the agent source is found through the template
+  lookup
+- --latest compares with the newest cached template, not the pinned one;
+  --rev compares with the deployed file at a git revision
+- Skill directories report changed, added and removed files
+- Agents and skills that are not deployed or have no source raise
+  FileNotFoundError
+- agents diff and skills diff subcommands parse
+"""
+
+import shutil
+import subprocess
+
+import pytest
+
+from claude_mpm.services.agents.agents_lock import AgentsLock
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+from claude_mpm.services.deployment_diff import (
+    ADDED,
+    CHANGED,
+    REMOVED,
+    agent_diff,
+    agent_revision_diff,
+    skill_diff,
+)
+from claude_mpm.services.deployment_integrity import record_directory
+
+AGENT = "---\nname: qa-checker\ndescription: Checks\n---\n\nRun the tests.\n"
+
+
+@pytest.fixture
+def dirs(tmp_path):
+    project = tmp_path / "project"
+    home = tmp_path / "home"
+    (project / ".claude" / "agents").mkdir(parents=True)
+    (home / ".claude-mpm" / "cache" / "agents" / "repo").mkdir(parents=True)
+    return project, home
+
+
+def test_deployed_agent_matches_its_source(dirs):
+    project, home = dirs
+    source = home / ".claude-mpm" / "cache" / "agents" / "repo" / "qa-checker.md"
+    source.write_text(AGENT)
+    deploy_agent_file(source, project / ".claude" / "agents")
+
+    diff = agent_diff("qa-checker", project, home)
+
+    assert diff.source == source
+    assert not diff.modified
+    assert diff.render() == ""
+
+
+def test_local_edits_show_as_additions(dirs):
+    project, home = dirs
+    source = home / ".claude-mpm" / "cache" / "agents" / "repo" / "qa-checker.md"
+    source.write_text(AGENT)
+    deploy_agent_file(source, project / ".claude" / "agents")
+    deployed = project / ".claude" / "agents" / "qa-checker.md"
+    deployed.write_text(deployed.read_text() + "Also run the linter.\n")
+
+    diff = agent_diff("qa-checker", project, home)
+    rendered = diff.render()
+
+    assert diff.modified
+    assert diff.files[0].status == CHANGED
+    assert "--- source/qa-checker.md" in rendered
+    assert "+Also run the linter." in rendered
+    assert "-Run the tests." not in rendered
+
+
+def test_agent_without_record_uses_template_lookup(dirs):
+    project, home = dirs
+    template = project / ".claude-mpm" / "agents" / "reviewer.md"
+    template.parent.mkdir(parents=True)
+    template.write_text("---\nname: reviewer\n---\nReview.\n")
+    (project / ".claude" / "agents" / "reviewer.md").write_text("edited\n")
+
+    diff = agent_diff("reviewer", project, home)
+
+    assert diff.source == template
+    assert "+edited" in diff.render()
+
+
+def test_latest_template_and_git_revision(dirs):
+    project, home = dirs
+    source = home / ".claude-mpm" / "cache" / "agents" / "repo" / "qa-checker.md"
+    source.write_text(AGENT)
+    AgentsLock(project).pin("qa-checker", source)
+    deploy_agent_file(source, project / ".claude" / "agents")
+    source.write_text(AGENT.replace("Run the tests.", "Run the tests twice."))
+
+    assert not agent_diff("qa-checker", project, home).modified
+    latest = agent_diff("qa-checker", project, home, latest=True)
+    assert latest.source == source
+    assert "--- latest/qa-checker.md" in latest.render()
+    assert "-Run the tests twice." in latest.render()
+
+    def git(*args):
+        subprocess.run(["git", *args], cwd=project, check=True, capture_output=True)
+
+    git("init", "-q")
+    git("add", ".claude")
+    git("-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "agents")
+    deployed = project / ".claude" / "agents" / "qa-checker.md"
+    deployed.write_text(deployed.read_text().replace("Run the tests.", "Lint."))
+
+    diff = agent_revision_diff("qa-checker", "HEAD", project, home)
+    assert (diff.revision, diff.source) == ("HEAD", None)
+    assert "--- HEAD/qa-checker.md" in diff.render()
+    assert "+Lint." in diff.render()
+    with pytest.raises(FileNotFoundError, match="not in revision"):
+        agent_revision_diff("qa-checker", "no-such-ref", project, home)
+
+
+def test_missing_agent_or_source(dirs):
+    project, home = dirs
+    with pytest.raises(FileNotFoundError, match="not deployed"):
+        agent_diff("ghost", project, home)
+
+    (project / ".claude" / "agents" / "orphan-xyz.md").write_text("x")
+    with pytest.raises(FileNotFoundError, match="No source"):
+        agent_diff("orphan-xyz", project, home)
+
+
+def test_skill_diff_reports_each_file(tmp_path):
+    source = tmp_path / "cache" / "tdd"
+    (source / "scripts").mkdir(parents=True)
+    (source / "SKILL.md").write_text("---\nname: tdd\n---\nRed, green.\n")
+    (source / "scripts" / "run.sh").write_text("pytest\n")
+    (source / "logo.png").write_bytes(b"\x89PNG\xff")
+    deployed = tmp_path / "project" / ".claude" / "skills" / "tdd"
+    shutil.copytree(source, deployed)
+    record_directory(deployed, source)
+
+    assert not skill_diff("tdd", tmp_path / "project", tmp_path / "home").modified
+
+    (deployed / "SKILL.md").write_text("---\nname: tdd\n---\nRed, green, refactor.\n")
+    (deployed / "scripts" / "run.sh").unlink()
+    (deployed / "notes.md").write_text("mine\n")
+    (deployed / "logo.png").write_bytes(b"\x89PNG\x00")
+
+    diff = skill_diff("tdd", tmp_path / "project", tmp_path / "home")
+    statuses = {f.path: f.status for f in diff.files}
+    rendered = diff.render()
+
+    assert statuses == {
+        "SKILL.md": CHANGED,
+        "logo.png": CHANGED,
+        "notes.md": ADDED,
+        "scripts/run.sh": REMOVED,
+    }
+    assert "+Red, green, refactor." in rendered
+    assert "Binary file logo.png differs" in rendered
+    assert "Only in deployed copy: notes.md" in rendered
+    assert "Only in source: scripts/run.sh" in rendered
+
+
+def test_diff_subcommands_parse():
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    parser = create_parser()
+    assert parser.parse_args(["agents", "diff", "qa"]).agent_name == "qa"
+    args = parser.parse_args(["agents", "diff", "qa", "--rev", "HEAD~1", "--json"])
+    assert (args.rev, args.latest, args.json) == ("HEAD~1", False, True)
+    with pytest.raises(SystemExit):
+        parser.parse_args(["agents", "diff", "qa", "--rev", "HEAD", "--latest"])
+    args = parser.parse_args(["skills", "diff", "tdd", "--json"])
+    assert (args.skill_name, args.json) == ("tdd", True)
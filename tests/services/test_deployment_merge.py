@@ -0,0 +1,167 @@
+"""
+Tests for three-way merging local edits with agent and skill updates.
+
+COVERAGE:
+- merge3 takes whichever side changed a region, keeps edits to different
+  regions from both sides, and marks overlapping edits as conflicts
+- Redeploying an agent keeps local edits when upstream did not change,
+  merges them with an upstream change elsewhere, and records a conflict
+  (leaving the deployed file alone) when both changed the same lines
+- force=True takes upstream and clears the conflict
+- Agents deployed before bases were kept fall back to the integrity manifest
+- Skill directories synced with merge=True report merged, kept and
+  conflicting files, which are not recorded in the integrity manifest
+- integrity resolve --take and the interactive prompt resolve conflicts
+"""
+
+import argparse
+
+from claude_mpm.services.agents.deployment_utils import deploy_agent_file
+from claude_mpm.services.deployment_delta import sync_directory
+from claude_mpm.services.deployment_integrity import (
+    IntegrityManifest,
+    record_directory,
+)
+from claude_mpm.services.deployment_merge import (
+    BASE_DIR,
+    has_conflict_markers,
+    merge3,
+    pending_conflicts,
+)
+
+BASE = "---\nname: qa\n---\n\nRun the tests.\n\nReport failures.\n"
+
+
+def _deploy(tmp_path, content, **kwargs):
+    source = tmp_path / "cache" / "qa.md"
+    source.parent.mkdir(parents=True, exist_ok=True)
+    source.write_text(content)
+    agents = tmp_path / "project" / ".claude" / "agents"
+    return deploy_agent_file(source, agents, ensure_frontmatter=False, **kwargs)
+
+
+def test_merge3_regions():
+    base = "a\nb\nc\nd\n"
+
+    assert merge3(base, "A\nb\nc\nd\n", "a\nb\nc\nD\n").text == "A\nb\nc\nD\n"
+    assert merge3(base, base, "a\nc\nd\n").text == "a\nc\nd\n"
+    assert merge3(base, "a\nb\nc\nd\ne\n", base).text == "a\nb\nc\nd\ne\n"
+
+    result = merge3(base, "a\nX\nc\nd\n", "a\nY\nc\nd\n")
+    assert result.conflicts == 1
+    assert result.text == (
+        "a\n<<<<<<< deployed\nX\n=======\nY\n>>>>>>> upstream\nc\nd\n"
+    )
+    assert has_conflict_markers(result.text)
+
+
+def test_agent_update_keeps_or_merges_local_edits(tmp_path):
+    _deploy(tmp_path, BASE)
+    deployed = tmp_path / "project" / ".claude" / "agents" / "qa.md"
+    deployed.write_text(BASE.replace("Run the tests.", "Run the tests twice."))
+
+    # Upstream unchanged: local edits stay
+    assert _deploy(tmp_path, BASE).action == "skipped"
+    assert "twice" in deployed.read_text()
+
+    # Upstream changed elsewhere: both changes end up deployed
+    result = _deploy(tmp_path, BASE + "\nBe brief.\n")
+    assert result.action == "merged"
+    assert deployed.read_text() == (
+        BASE.replace("Run the tests.", "Run the tests twice.") + "\nBe brief.\n"
+    )
+
+
+def test_agent_conflict_is_recorded_and_forced_away(tmp_path):
+    _deploy(tmp_path, BASE)
+    deployed = tmp_path / "project" / ".claude" / "agents" / "qa.md"
+    edited = BASE.replace("Run the tests.", "Run the unit tests.")
+    deployed.write_text(edited)
+
+    result = _deploy(tmp_path, BASE.replace("Run the tests.", "Run all tests."))
+
+    assert result.action == "conflict"
+    assert deployed.read_text() == edited
+    (conflict,) = pending_conflicts(tmp_path / "project")
+    assert conflict.path == ".claude/agents/qa.md"
+    assert conflict.merged().conflicts == 1
+
+    _deploy(tmp_path, BASE.replace("Run the tests.", "Run all tests."), force=True)
+    assert "Run all tests." in deployed.read_text()
+    assert pending_conflicts(tmp_path / "project") == []
+
+
+def test_agent_without_base_uses_integrity_manifest(tmp_path):
+    _deploy(tmp_path, BASE)
+    project = tmp_path / "project"
+    deployed = project / ".claude" / "agents" / "qa.md"
+    (project / BASE_DIR / ".claude" / "agents" / "qa.md").unlink()
+
+    # Deployed file untouched since the recorded deployment: take upstream
+    assert _deploy(tmp_path, BASE + "v2\n").action == "updated"
+
+    (project / BASE_DIR / ".claude" / "agents" / "qa.md").unlink()
+    deployed.write_text(BASE + "v2\nmine\n")
+    assert _deploy(tmp_path, BASE + "v3\n").action == "conflict"
+
+
+def test_skill_sync_merges_and_skips_recording_conflicts(tmp_path):
+    source = tmp_path / "cache" / "tdd"
+    source.mkdir(parents=True)
+    (source / "SKILL.md").write_text("one\ntwo\nthree\n")
+    (source / "notes.md").write_text("alpha\n")
+    (source / "tips.md").write_text("x\n")
+    target = tmp_path / "project" / ".claude" / "skills" / "tdd"
+    sync_directory(source, target, merge=True)
+    record_directory(target, source)
+
+    (target / "SKILL.md").write_text("ONE\ntwo\nthree\n")
+    (target / "notes.md").write_text("beta\n")
+    (target / "tips.md").write_text("y\n")
+    (source / "SKILL.md").write_text("one\ntwo\nTHREE\n")
+    (source / "notes.md").write_text("gamma\n")
+
+    delta = sync_directory(source, target, merge=True)
+    record_directory(target, source, exclude=delta.kept + delta.conflicts)
+
+    assert (delta.merged, delta.kept, delta.conflicts) == (
+        ["SKILL.md"],
+        ["tips.md"],
+        ["notes.md"],
+    )
+    assert delta.summary() == "1 merged, 1 conflicting"
+    assert (target / "SKILL.md").read_text() == "ONE\ntwo\nTHREE\n"
+    assert (target / "notes.md").read_text() == "beta\n"
+    issues = {i.path for i in IntegrityManifest(tmp_path / "project").verify()}
+    assert issues == {".claude/skills/tdd/notes.md", ".claude/skills/tdd/tips.md"}
+
+
+def test_integrity_resolve(tmp_path):
+    from claude_mpm.cli.commands.integrity import (
+        IntegrityCommand,
+        prompt_resolutions,
+    )
+
+    project = tmp_path / "project"
+    deployed = project / ".claude" / "agents" / "qa.md"
+    _deploy(tmp_path, BASE)
+    deployed.write_text(BASE.replace("Report failures.", "Report every failure."))
+    _deploy(tmp_path, BASE.replace("Report failures.", "Report failures briefly."))
+
+    answers = iter(["e", "e"])
+    edits = iter(["<<<<<<< still\n", "resolved by hand\n"])
+    resolved = prompt_resolutions(
+        pending_conflicts(project), lambda _: next(answers), lambda _: next(edits)
+    )
+    assert resolved == 1
+    assert deployed.read_text() == "resolved by hand\n"
+    assert pending_conflicts(project) == []
+
+    # The resolution is the new deployed state; the next update conflicts again
+    _deploy(tmp_path, BASE.replace("Report failures.", "Report nothing."))
+    command = IntegrityCommand(roots=[project])
+    args = argparse.Namespace(integrity_command="resolve", take="upstream")
+    result = command.run(args)
+    assert result.message == "Resolved 1 of 1 merge conflict(s)"
+    assert "Report nothing." in deployed.read_text()
+    assert IntegrityManifest(project).verify() == []
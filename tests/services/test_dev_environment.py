@@ -0,0 +1,125 @@
+"""
+Tests for the declarative dev environment.
+
+COVERAGE:
+- The dev_environment config section parses and rejects bad values
+- Versions match on their declared components
+- Missing versions are installed through mise and its environment is
+  exported; untrusted workspaces are only checked
+- Without a version manager tools are checked on PATH, and strict specs stop
+  the session
+- The doctor check reports missing tools
+"""
+
+import os
+import stat
+
+import pytest
+
+from claude_mpm.services.dev_environment import (
+    EnvironmentSpec,
+    resolve_environment,
+    version_matches,
+)
+
+FAKE_MISE = """#!/bin/sh
+# install: put node 20 in the mise data dir; env: PATH with it when installed
+if [ "$1" = install ]; then
+    echo "$@" >> "$MISE_DATA_DIR/calls"
+    mkdir -p "$MISE_DATA_DIR/node20/bin"
+    printf '#!/bin/sh\\necho v20.11.1\\n' > "$MISE_DATA_DIR/node20/bin/node"
+    chmod +x "$MISE_DATA_DIR/node20/bin/node"
+elif [ "$1" = env ]; then
+    if [ -d "$MISE_DATA_DIR/node20" ]; then
+        echo "{\\"PATH\\": \\"$MISE_DATA_DIR/node20/bin:$PATH\\"}"
+    else
+        echo "{\\"PATH\\": \\"$PATH\\"}"
+    fi
+fi
+"""
+
+
+def _script(path, body):
+    path.write_text(body)
+    path.chmod(path.stat().st_mode | stat.S_IEXEC)
+
+
+@pytest.fixture
+def tools(tmp_path, monkeypatch):
+    """A PATH holding only node 18 and a fake mise."""
+    bin_dir = tmp_path / "bin"
+    bin_dir.mkdir()
+    _script(bin_dir / "node", "#!/bin/sh\necho v18.19.0\n")
+    _script(bin_dir / "mise", FAKE_MISE)
+    monkeypatch.setenv("PATH", f"{bin_dir}{os.pathsep}/usr/bin{os.pathsep}/bin")
+    monkeypatch.setenv("MISE_DATA_DIR", str(tmp_path / "mise"))
+    (tmp_path / "mise").mkdir()
+    return bin_dir
+
+
+def test_spec_parsing_and_version_matching():
+    assert EnvironmentSpec.from_dict(None) is None
+    assert EnvironmentSpec.from_dict({"tools": {}}) is None
+    spec = EnvironmentSpec.from_dict({"tools": {"node": 20}, "strict": True})
+    assert (spec.tools, spec.manager, spec.strict) == ({"node": "20"}, "auto", True)
+    with pytest.raises(ValueError, match="manager"):
+        EnvironmentSpec.from_dict({"tools": {"node": "20"}, "manager": "nvm"})
+
+    assert version_matches("20", "20.11.1")
+    assert version_matches("1.22", "1.22.3")
+    assert not version_matches("1.2", "1.22.3")
+    assert version_matches("latest", "0.1")
+
+
+def test_provisions_and_exports_with_mise(tmp_path, tools):
+    spec = EnvironmentSpec(tools={"node": "20"}, env={"NODE_ENV": "development"})
+
+    checked = resolve_environment(spec, tmp_path, provision=False)
+    assert checked.manager == "mise"
+    assert checked.summary() == "node 20: found 18.19.0"
+    assert not (tmp_path / "mise" / "calls").exists()
+
+    report = resolve_environment(spec, tmp_path)
+    assert report.ok, report.summary()
+    assert report.provisioned == ["node"]
+    assert (tmp_path / "mise" / "calls").read_text().strip() == "install node@20"
+    assert report.env["PATH"].startswith(str(tmp_path / "mise" / "node20" / "bin"))
+    assert report.env["NODE_ENV"] == "development"
+    assert report.summary() == "node 20.11.1"
+
+
+def test_without_manager_and_strict(tmp_path, tools, monkeypatch):
+    from claude_mpm.cli.commands import run
+
+    spec = EnvironmentSpec(tools={"node": "18", "protogen": "3"}, manager="none")
+    report = resolve_environment(spec, tmp_path)
+    assert report.manager is None
+    assert report.summary() == "protogen 3: not found"
+
+    spec.strict = True
+    monkeypatch.setattr(EnvironmentSpec, "from_config", classmethod(lambda c: spec))
+    monkeypatch.delenv("CLAUDE_MPM_WORKSPACE_RESTRICTED", raising=False)
+    with pytest.raises(SystemExit):
+        run._apply_dev_environment()
+
+    spec.tools = {"node": "20"}
+    spec.manager = "auto"
+    monkeypatch.setenv("CLAUDE_MPM_WORKSPACE_RESTRICTED", "1")
+    with pytest.raises(SystemExit):
+        run._apply_dev_environment()
+    assert not (tmp_path / "mise" / "calls").exists()
+
+
+def test_doctor_check(tmp_path, tools, monkeypatch):
+    from claude_mpm.core.enums import ValidationSeverity
+    from claude_mpm.services.diagnostics.checks import DevEnvironmentCheck
+
+    spec = EnvironmentSpec(tools={"node": "20"})
+    monkeypatch.setattr(EnvironmentSpec, "from_config", classmethod(lambda c: spec))
+
+    check = DevEnvironmentCheck()
+    assert check.should_run()
+    result = check.run()
+    assert result.status == ValidationSeverity.WARNING
+    assert result.fix_command == "mise install node@20"
+    assert result.details["tools"]["node"]["found"] == "18.19.0"
@@ -0,0 +1,208 @@
+"""
+Tests for agent evaluation suites.
+
+COVERAGE:
+- Suite parsing and validation errors
+- Each assertion type, including rubric grading via a fake grader
+- Runner scoring, workspace seeding and file/tests assertions
+- History regressions and the eval CLI exit code
+"""
+
+import argparse
+import sys
+import textwrap
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.cli.commands.evals import EvalCommand
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.evals import (
+    EvalHistory,
+    EvalRunner,
+    EvalSuiteError,
+    find_regressions,
+    load_suite,
+)
+from claude_mpm.services.evals.assertions import (
+    CaseContext,
+    parse_grade,
+    run_assertion,
+)
+
+AGENT_MD = "---\nname: helper\nversion: 2.1.0\n---\n\nYou are helpful.\n"
+
+SUITE = textwrap.dedent(
+    f"""\
+    name: helper-basics
+    agent: helper
+    cases:
+      - id: writes-file
+        prompt: write greeting
+        files:
+          test_greeting.py: |
+            from pathlib import Path
+            assert Path("greeting.txt").read_text() == "hello"
+        assertions:
+          - {{type: contains, value: Done}}
+          - {{type: regex, pattern: "wrote \\\\w+\\\\.txt"}}
+          - {{type: file-created, path: greeting.txt, contains: hello}}
+          - {{type: tests-pass, command: "{sys.executable} test_greeting.py"}}
+      - id: graded
+        prompt: explain
+        assertions:
+          - {{type: rubric, rubric: Is polite, threshold: 0.5}}
+    """
+)
+
+
+class FakeAgent:
+    """Writes greeting.txt into the case workspace and replies."""
+
+    def __init__(self, config, text="hello"):
+        self.config = config
+        self.text = text
+
+    async def run(self, prompt, config=None):
+        if prompt == "write greeting":
+            (Path(self.config.cwd) / "greeting.txt").write_text(self.text)
+            return AgentResult(text="Done, wrote greeting.txt")
+        return AgentResult(text="Certainly! Here you go.")
+
+
+class FakeGrader:
+    def __init__(self, score=0.9):
+        self.score = score
+
+    def grade(self, rubric, output):
+        return self.score, f"graded {rubric!r}"
+
+
+@pytest.fixture
+def project(tmp_path):
+    agents = tmp_path / ".claude" / "agents"
+    agents.mkdir(parents=True)
+    (agents / "helper.md").write_text(AGENT_MD)
+    (tmp_path / "suite.yaml").write_text(SUITE)
+    return tmp_path
+
+
+def _runner(project, text="hello", score=0.9):
+    return EvalRunner(
+        runtime_factory=lambda config: FakeAgent(config, text),
+        grader=FakeGrader(score),
+        project_dir=project,
+    )
+
+
+def test_load_suite(project):
+    suite = load_suite(project / "suite.yaml")
+
+    assert suite.name == "helper-basics"
+    assert [c.id for c in suite.cases] == ["writes-file", "graded"]
+    assert suite.permission_mode == "acceptEdits"
+
+
+@pytest.mark.parametrize(
+    "body, message",
+    [
+        ("cases: []", "agent"),
+        ("agent: a\ncases: []", "no cases"),
+        ("agent: a\ncases:\n  - {id: x, prompt: p}", "no assertions"),
+        (
+            "agent: a\ncases:\n  - {id: x, prompt: p, assertions: [{type: nope}]}",
+            "unknown assertion type",
+        ),
+        (
+            "agent: a\ncases:\n  - {id: x, prompt: p, assertions: [{type: regex}]}",
+            "needs 'pattern'",
+        ),
+    ],
+)
+def test_invalid_suites(tmp_path, body, message):
+    path = tmp_path / "bad.yaml"
+    path.write_text(body)
+
+    with pytest.raises(EvalSuiteError) as exc:
+        load_suite(path)
+    assert message in str(exc.value)
+
+
+def test_assertions(tmp_path):
+    ctx = CaseContext(output="Hello World", workspace=tmp_path)
+
+    assert run_assertion({"type": "contains", "value": "World"}, ctx).passed
+    assert not run_assertion({"type": "contains", "value": "world"}, ctx).passed
+    assert run_assertion(
+        {"type": "contains", "value": "world", "ignore_case": True}, ctx
+    ).passed
+    assert run_assertion({"type": "regex", "pattern": r"H\w+o"}, ctx).passed
+    assert not run_assertion({"type": "regex", "pattern": "("}, ctx).passed
+    assert not run_assertion({"type": "file-created", "path": "../x"}, ctx).passed
+    assert not run_assertion({"type": "tests-pass", "command": "exit 3"}, ctx).passed
+    assert not run_assertion({"type": "rubric", "rubric": "r"}, ctx).passed
+
+
+def test_parse_grade():
+    assert parse_grade('Sure: {"score": 0.8, "reason": "ok"}') == (0.8, "ok")
+    assert parse_grade('{"score": 7}')[0] == 1.0
+    assert parse_grade("no json")[0] == 0.0
+
+
+def test_runner_scores_cases(project):
+    result = _runner(project).run(load_suite(project / "suite.yaml"))
+
+    assert result.agent_version == "2.1.0"
+    assert len(result.prompt_hash) == 12
+    assert [c.passed for c in result.cases] == [True, True]
+    assert result.score == pytest.approx(0.95)
+    assert result.cases[0].workspace is None
+
+
+def test_runner_failing_assertions_lower_score(project):
+    result = _runner(project, text="bye", score=0.2).run(
+        load_suite(project / "suite.yaml")
+    )
+
+    first, graded = result.cases
+    assert not first.passed
+    assert first.score == pytest.approx(0.5)
+    assert not graded.passed
+    assert graded.score == pytest.approx(0.2)
+
+
+def test_find_regressions():
+    before = {"cases": [{"id": "a", "passed": True, "score": 1.0}]}
+    after = {"cases": [{"id": "a", "passed": False, "score": 0.5}]}
+
+    assert find_regressions(before, after) == [
+        {"id": "a", "before": 1.0, "after": 0.5, "now_failing": True}
+    ]
+    assert find_regressions(after, before) == []
+
+
+def test_eval_command_records_history_and_flags_regressions(project, capsys):
+    history = EvalHistory(project / "history.jsonl")
+    args = argparse.Namespace(
+        eval_command="run",
+        suite=project / "suite.yaml",
+        agent=None,
+        model=None,
+        replay=None,
+        min_score=None,
+        no_history=False,
+        keep_workspaces=False,
+        json=False,
+    )
+
+    good = EvalCommand(runner=_runner(project), history=history).run(args)
+    bad = EvalCommand(runner=_runner(project, text="bye"), history=history).run(args)
+
+    assert good.success
+    assert not bad.success
+    assert "REGRESSION writes-file" in bad.message
+    assert [r["passed"] for r in history.runs(suite="helper-basics")] == [2, 1]
+
+    args.eval_command, args.suite, args.limit = "history", None, 20
+    listing = EvalCommand(history=history).run(args)
+    assert listing.message.count("helper-basics") == 2
@@ -0,0 +1,135 @@
+"""
+Tests for feature flags.
+
+COVERAGE:
+- A flag's value comes from the environment, then the project, the user and
+  the cached organization config; locked org flags ignore the other layers
+- The organization config is fetched into the cache, kept when a fetch
+  fails and dropped when no org URL is configured
+- flags list/enable/disable, including unknown and locked flags
+- The llmlingua hook and run's exported environment consult the flags
+"""
+
+import argparse
+import json
+import os
+
+import pytest
+
+from claude_mpm.services.feature_flags import (
+    ENV,
+    ORG,
+    PROJECT,
+    USER,
+    FeatureFlags,
+    org_cache_path,
+    refresh_org_flags,
+)
+
+
+@pytest.fixture(autouse=True)
+def home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    for name in ("AGENT_TEAMS", "LLMLINGUA"):
+        monkeypatch.delenv(f"CLAUDE_MPM_FLAG_{name}", raising=False)
+    monkeypatch.delenv("CLAUDE_MPM_ORG_FLAGS_URL", raising=False)
+    return tmp_path / "home"
+
+
+def _org(tmp_path, document):
+    path = tmp_path / "org-flags.json"
+    path.write_text(json.dumps(document))
+    return refresh_org_flags(str(path))
+
+
+def test_layers_and_locks(tmp_path, monkeypatch):
+    project = tmp_path / "project"
+    _org(tmp_path, {"flags": {"llmlingua": True, "retired": True}})
+
+    flags = FeatureFlags(project)
+    assert (flags.state("llmlingua").source, flags.is_enabled("llmlingua")) == (
+        ORG,
+        True,
+    )
+    flags.set("llmlingua", False)
+    assert FeatureFlags(project).state("llmlingua").source == USER
+    flags.set("llmlingua", True, project=True)
+    assert FeatureFlags(project).state("llmlingua").source == PROJECT
+    assert not FeatureFlags(tmp_path / "other").is_enabled("llmlingua")
+
+    monkeypatch.setenv("CLAUDE_MPM_FLAG_LLMLINGUA", "off")
+    assert FeatureFlags(project).state("llmlingua").source == ENV
+    assert not FeatureFlags(project).is_enabled("llmlingua")
+
+    _org(tmp_path, {"flags": {"llmlingua": True}, "locked": ["llmlingua"]})
+    state = FeatureFlags(project).state("llmlingua")
+    assert (state.enabled, state.locked) == (True, True)
+    with pytest.raises(ValueError, match="locked"):
+        FeatureFlags(project).set("llmlingua", False)
+
+
+def test_org_config_refresh(tmp_path):
+    _org(tmp_path, {"flags": {"agent_teams": True}})
+    cached = json.loads(org_cache_path().read_text())
+    assert cached["flags"] == {"agent_teams": True}
+
+    # A failed fetch keeps the last copy
+    assert refresh_org_flags(str(tmp_path / "missing.json"))["flags"] == {
+        "agent_teams": True
+    }
+    assert FeatureFlags(tmp_path).is_enabled("agent_teams")
+
+    # No org URL configured: the org config no longer applies
+    assert refresh_org_flags("") is None
+    assert not org_cache_path().exists()
+    assert not FeatureFlags(tmp_path).is_enabled("agent_teams")
+
+
+def test_flags_command(tmp_path, monkeypatch):
+    from claude_mpm.cli.commands.flags import FlagsCommand
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    parser = create_parser()
+    command = FlagsCommand(project_root=tmp_path, refresh=None)
+
+    result = command.run(parser.parse_args(["flags", "enable", "agent_teams"]))
+    assert result.success
+    assert "Turned 'agent_teams' on" in result.message
+
+    result = command.run(parser.parse_args(["flags", "list", "--json"]))
+    states = {row["name"]: row for row in result.data}
+    assert (states["agent_teams"]["enabled"], states["agent_teams"]["source"]) == (
+        True,
+        USER,
+    )
+    assert "Agent Teams" in command.run(parser.parse_args(["flags", "list"])).message
+
+    monkeypatch.setenv("CLAUDE_MPM_FLAG_AGENT_TEAMS", "1")
+    args = parser.parse_args(["flags", "disable", "agent_teams", "--project"])
+    result = command.run(args)
+    assert "CLAUDE_MPM_FLAG_AGENT_TEAMS is set" in result.message
+    assert (tmp_path / ".claude-mpm" / "feature-flags.json").exists()
+
+    result = command.run(argparse.Namespace(flags_command="enable", flag="llmlinga"))
+    assert not result.success
+    assert "did you mean 'llmlingua'?" in result.message
+
+
+def test_subsystems_consult_flags(tmp_path, monkeypatch):
+    from claude_mpm.cli.commands.run import _export_feature_flags
+    from claude_mpm.hooks import llmlingua_hook
+
+    monkeypatch.chdir(tmp_path)
+    monkeypatch.delenv("CLAUDE_MPM_USE_LLMLINGUA", raising=False)
+    monkeypatch.delenv("CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS", raising=False)
+    assert not llmlingua_hook._is_enabled()
+
+    flags = FeatureFlags(tmp_path)
+    flags.set("llmlingua", True)
+    flags.set("agent_teams", True)
+    assert llmlingua_hook._is_enabled()
+    monkeypatch.setenv("CLAUDE_MPM_USE_LLMLINGUA", "0")
+    assert not llmlingua_hook._is_enabled()
+
+    _export_feature_flags()
+    assert os.environ["CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS"] == "1"
@@ -0,0 +1,211 @@
+"""
+Tests for golden transcript regression testing.
+
+COVERAGE:
+- Recording a transcript into the golden store and reading it back
+- Tool-call and reply diffing, including strict path comparison
+- Replaying prompts in one session inside a project copy
+- The golden CLI exit code on behaviour changes
+"""
+
+import argparse
+import json
+
+from claude_mpm.cli.commands.golden import GoldenCommand
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.evals.golden import (
+    GoldenReplayer,
+    GoldenStore,
+    diff_turns,
+    record_golden,
+)
+from claude_mpm.services.model.recordings import RecordedToolCall, RecordedTurn
+
+
+def _transcript(path, cwd):
+    lines = [
+        {"type": "user", "message": {"content": "fix the bug"}},
+        {
+            "type": "assistant",
+            "message": {
+                "content": [
+                    {
+                        "type": "tool_use",
+                        "id": "t1",
+                        "name": "Read",
+                        "input": {"file_path": f"{cwd}/app.py"},
+                    }
+                ]
+            },
+        },
+        {
+            "type": "user",
+            "message": {
+                "content": [
+                    {"type": "tool_result", "tool_use_id": "t1", "content": "x = 1"}
+                ]
+            },
+        },
+        {
+            "type": "assistant",
+            "message": {
+                "content": [
+                    {
+                        "type": "tool_use",
+                        "id": "t2",
+                        "name": "Edit",
+                        "input": {"file_path": f"{cwd}/app.py"},
+                    },
+                    {"type": "text", "text": "Fixed the off-by-one in app.py."},
+                ]
+            },
+        },
+    ]
+    path.write_text("\n".join(json.dumps(line) for line in lines))
+    return path
+
+
+class FakeRuntime:
+    """Reports tool calls the way the SDK runtime does."""
+
+    def __init__(self, config, tools):
+        self.config = config
+        self.tools = tools
+        self.calls = []
+
+    async def run(self, prompt, config=None):
+        self.calls.append(("run", prompt))
+        return self._result()
+
+    async def resume(self, session_id, prompt, config=None):
+        self.calls.append(("resume", session_id, prompt))
+        return self._result()
+
+    def _result(self):
+        return AgentResult(
+            text="Fixed the off-by-one in app.py.",
+            session_id="s-1",
+            tool_calls=[
+                {"tool_name": name, "input": {"file_path": f"{self.config.cwd}/app.py"}}
+                for name in self.tools
+            ],
+        )
+
+
+def test_record_and_load_roundtrip(tmp_path):
+    transcript = _transcript(tmp_path / "s.jsonl", "/work/proj")
+    store = GoldenStore(tmp_path / "golden")
+
+    store.save(record_golden("fix-bug", transcript, "/work/proj"))
+    golden = store.load("fix-bug")
+
+    assert [g.name for g in store.load_all()] == ["fix-bug"]
+    assert golden.cwd == "/work/proj"
+    assert golden.tool_call_count == 2
+    assert [c.name for c in golden.turns[0].tool_calls] == ["Read", "Edit"]
+
+
+def test_diff_turns_reports_tool_and_reply_changes():
+    golden = [
+        RecordedTurn(
+            "fix",
+            "Fixed it.",
+            [
+                RecordedToolCall("Read", {"file_path": "/a/app.py"}),
+                RecordedToolCall("Edit", {"file_path": "/a/app.py"}),
+            ],
+        ),
+        RecordedTurn("test", "All green."),
+    ]
+    same = [
+        RecordedTurn(
+            "fix",
+            "Fixed it!",
+            [
+                RecordedToolCall("Read", {"file_path": "/b/app.py"}),
+                RecordedToolCall("Edit", {"file_path": "/b/app.py"}),
+            ],
+        )
+    ]
+
+    diff = diff_turns("g", golden, same, "/a", "/b", strict=True)
+    assert not diff.turns[0].changed
+    assert diff.turns[1].missing
+    assert diff.regressed
+
+    changed = [RecordedTurn("fix", "Nope", [RecordedToolCall("Write")])]
+    turn = diff_turns("g", golden[:1], changed).turns[0]
+    assert turn.tool_changes == ["- Read", "- Edit", "+ Write"]
+    assert turn.reply_changed
+
+
+def test_replayer_runs_prompts_in_one_session_in_a_copy(tmp_path):
+    project = tmp_path / "proj"
+    project.mkdir()
+    (project / "app.py").write_text("x = 1\n")
+    golden = record_golden(
+        "fix-bug", _transcript(tmp_path / "s.jsonl", str(project)), str(project)
+    )
+    golden.turns.append(RecordedTurn("and the tests", ""))
+    runtimes = []
+
+    def factory(config):
+        runtimes.append(FakeRuntime(config, ["Read", "Edit"]))
+        return runtimes[-1]
+
+    replayer = GoldenReplayer(
+        runtime_factory=factory,
+        project_dir=project,
+        transcript_locator=lambda sid, cwd: tmp_path / "missing.jsonl",
+    )
+    actual = replayer.replay(golden)
+
+    assert runtimes[0].calls == [
+        ("run", "fix the bug"),
+        ("resume", "s-1", "and the tests"),
+    ]
+    assert replayer.workspace != project
+    assert not replayer.workspace.exists()
+    diff = diff_turns(
+        "fix-bug", golden.turns[:1], actual[:1], golden.cwd, str(replayer.workspace)
+    )
+    assert not diff.regressed
+
+
+def test_golden_command_flags_changed_behaviour(tmp_path, monkeypatch, capsys):
+    monkeypatch.chdir(tmp_path)
+    store = GoldenStore(tmp_path / "golden")
+    transcript = _transcript(tmp_path / "s.jsonl", str(tmp_path))
+    args = argparse.Namespace(
+        golden_command="record", name="fix-bug", session=str(transcript), force=False
+    )
+
+    assert GoldenCommand(store=store).run(args).success
+    assert not GoldenCommand(store=store).run(args).success
+
+    def replayer(tools):
+        return GoldenReplayer(
+            runtime_factory=lambda config: FakeRuntime(config, tools),
+            project_dir=tmp_path,
+            in_place=True,
+            transcript_locator=lambda sid, cwd: tmp_path / "missing.jsonl",
+        )
+
+    run_args = argparse.Namespace(
+        golden_command="run",
+        names=[],
+        against=None,
+        model=None,
+        permission_mode="acceptEdits",
+        strict=True,
+        in_place=True,
+        keep_workspace=False,
+        json=False,
+    )
+    ok = GoldenCommand(store=store, replayer=replayer(["Read", "Edit"])).run(run_args)
+    bad = GoldenCommand(store=store, replayer=replayer(["Write"])).run(run_args)
+
+    assert ok.success
+    assert not bad.success
+    assert "+ Write(app.py)" in bad.message
+    assert "0/1 goldens unchanged" in bad.message
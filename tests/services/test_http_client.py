@@ -0,0 +1,76 @@
+"""Tests for the proxy and CA bundle settings of skill and agent fetches.
+
+COVERAGE:
+- network settings are read from config; unset ones fall back to the
+  environment, including SSL_CERT_FILE for the CA bundle
+- Configured proxies are skipped for no_proxy hosts
+- get and session requests carry the proxy and CA bundle, and explicit
+  arguments win
+"""
+
+import requests
+
+from claude_mpm.services import http_client
+from claude_mpm.services.http_client import NetworkConfig
+
+
+def test_request_kwargs(tmp_path, monkeypatch):
+    for name in ("REQUESTS_CA_BUNDLE", "CURL_CA_BUNDLE", "SSL_CERT_FILE"):
+        monkeypatch.delenv(name, raising=False)
+    bundle = tmp_path / "corp-ca.pem"
+    bundle.write_text("-----BEGIN CERTIFICATE-----\n")
+
+    config = NetworkConfig.load(
+        {
+            "network": {
+                "https_proxy": "http://proxy.corp:8080",
+                "no_proxy": "localhost,.corp.example",
+                "ca_bundle": str(bundle),
+                "verify": False,
+            }
+        }
+    )
+    assert config == NetworkConfig(
+        https_proxy="http://proxy.corp:8080",
+        no_proxy="localhost,.corp.example",
+        ca_bundle=str(bundle),
+    )
+    assert config.request_kwargs("https://api.github.com/repos/a/b") == {
+        "proxies": {"https": "http://proxy.corp:8080"},
+        "verify": str(bundle),
+    }
+    assert config.request_kwargs("https://git.corp.example/skills") == {
+        "verify": str(bundle)
+    }
+
+    assert NetworkConfig().request_kwargs("https://github.com") == {}
+    monkeypatch.setenv("SSL_CERT_FILE", str(bundle))
+    assert NetworkConfig().request_kwargs("https://github.com") == {
+        "verify": str(bundle)
+    }
+
+
+def test_get_and_session(monkeypatch):
+    config = NetworkConfig(https_proxy="http://proxy.corp:8080", ca_bundle="/ca.pem")
+    calls = []
+
+    def fake_get(url, **kwargs):
+        calls.append(kwargs)
+
+    def fake_request(self, method, url, *args, **kwargs):
+        calls.append(kwargs)
+
+    monkeypatch.setattr(requests, "get", fake_get)
+    monkeypatch.setattr(requests.Session, "request", fake_request)
+
+    http_client.get("https://github.com/a/b", config, timeout=5)
+    http_client.get("https://github.com/a/b", config, verify=False)
+    http_client.session(config).get("https://raw.githubusercontent.com/a/b")
+    assert calls[0] == {
+        "proxies": {"https": "http://proxy.corp:8080"},
+        "verify": "/ca.pem",
+        "timeout": 5,
+    }
+    assert calls[1]["verify"] is False
+    assert calls[2]["proxies"] == {"https": "http://proxy.corp:8080"}
+    assert calls[2]["verify"] == "/ca.pem"
@@ -0,0 +1,149 @@
+"""
+Tests for the policy-controlled HTTP tool and its fixtures.
+
+COVERAGE:
+- Host allowlist (exact and wildcard) and config loading
+- Requests against a local server, recorded and replayed as fixtures
+- Sensitive headers are redacted; redirects are checked against the allowlist
+- Response size limit
+- The http MCP tools
+"""
+
+import asyncio
+import json
+import threading
+from http.server import BaseHTTPRequestHandler, HTTPServer
+
+import pytest
+
+from claude_mpm.mcp.http_server import HttpMCPServer
+from claude_mpm.services.http_fixtures import (
+    REDACTED,
+    FixtureSet,
+    HttpPolicyError,
+    HttpRequestTool,
+    HttpToolConfig,
+)
+
+
+class _Handler(BaseHTTPRequestHandler):
+    def do_GET(self):
+        if self.path == "/redirect-out":
+            self.send_response(302)
+            self.send_header("Location", "http://example.com/")
+            self.end_headers()
+            return
+        if self.path == "/redirect-in":
+            self.send_response(302)
+            self.send_header("Location", "/users/1")
+            self.end_headers()
+            return
+        body = b"x" * 4096 if self.path == "/big" else b'{"id": 1, "name": "Ada"}'
+        self.send_response(200)
+        self.send_header("Content-Type", "application/json")
+        self.send_header("Set-Cookie", "session=secret")
+        self.send_header("Content-Length", str(len(body)))
+        self.end_headers()
+        self.wfile.write(body)
+
+    def do_POST(self):
+        length = int(self.headers["Content-Length"])
+        body = self.rfile.read(length)
+        self.send_response(201)
+        self.send_header("Content-Type", "application/json")
+        self.end_headers()
+        self.wfile.write(body)
+
+    def log_message(self, *args):
+        pass
+
+
+@pytest.fixture
+def server():
+    httpd = HTTPServer(("127.0.0.1", 0), _Handler)
+    thread = threading.Thread(target=httpd.serve_forever, daemon=True)
+    thread.start()
+    yield f"http://127.0.0.1:{httpd.server_port}"
+    httpd.shutdown()
+
+
+@pytest.fixture
+def tool(tmp_path):
+    return HttpRequestTool(tmp_path, HttpToolConfig(max_response_bytes=1024))
+
+
+class _Config:
+    values = {"http_tool": {"allowed_hosts": ["*.staging.example"], "timeout": 5}}
+
+    def get(self, key, default=None):
+        return self.values.get(key, default)
+
+
+def test_allowlist_and_config():
+    config = HttpToolConfig.load(_Config())
+
+    assert config.allows("https://api.staging.example/v1")
+    assert not config.allows("https://staging.example.evil.com/")
+    assert not config.allows("ftp://api.staging.example/")
+    assert config.timeout == 5
+    assert HttpToolConfig().allows("http://localhost:8000/")
+
+
+def test_request_records_redacted_fixture(tmp_path, tool, server):
+    exchange = tool.request(
+        "get",
+        f"{server}/users/1",
+        headers={"Authorization": "Bearer token"},
+        record_as="get user",
+    )
+
+    assert exchange.response["status"] == 200
+    assert exchange.json() == {"id": 1, "name": "Ada"}
+    assert exchange.request["headers"]["Authorization"] == REDACTED
+    assert exchange.response["headers"]["Set-Cookie"] == REDACTED
+
+    fixtures = FixtureSet(tmp_path / "tests" / "fixtures" / "http")
+    assert fixtures.names() == ["get_user"]
+    stored = fixtures.path("get user").read_text()
+    assert "Bearer token" not in stored and "secret" not in stored
+    assert fixtures.find("GET", f"{server}/users/1").json()["name"] == "Ada"
+
+
+def test_json_body_round_trip(tool, server):
+    exchange = tool.request("POST", f"{server}/users", json_body={"name": "Grace"})
+
+    assert exchange.response["status"] == 201
+    assert exchange.request["headers"]["Content-Type"] == "application/json"
+    assert exchange.json() == {"name": "Grace"}
+
+
+def test_policy_violations(tool, server):
+    with pytest.raises(HttpPolicyError, match="Host not allowed: example.com"):
+        tool.request("GET", "https://example.com/")
+    with pytest.raises(HttpPolicyError, match="Host not allowed: example.com"):
+        tool.request("GET", f"{server}/redirect-out")
+    with pytest.raises(HttpPolicyError, match="limit is 1024"):
+        tool.request("GET", f"{server}/big")
+    with pytest.raises(HttpPolicyError, match="Unsupported HTTP method"):
+        tool.request("TRACE", f"{server}/")
+
+    followed = tool.request("GET", f"{server}/redirect-in")
+    assert followed.response["url"].endswith("/users/1")
+
+
+def test_mcp_tools(tmp_path, server):
+    mcp = HttpMCPServer(project_root=tmp_path)
+
+    result = asyncio.run(
+        mcp._dispatch_tool(
+            "http_request",
+            {"method": "GET", "url": f"{server}/users/1", "record_as": "user"},
+        )
+    )
+    listed = asyncio.run(mcp._dispatch_tool("list_http_fixtures", {}))
+    fixture = asyncio.run(mcp._dispatch_tool("get_http_fixture", {"name": "user"}))
+
+    assert json.loads(result["response"]["body"])["id"] == 1
+    assert result["fixture"].endswith("tests/fixtures/http/user.json")
+    assert listed["fixtures"] == ["user"]
+    assert fixture["request"]["url"] == f"{server}/users/1"
@@ -0,0 +1,177 @@
+"""
+Tests for the project knowledge graph.
+
+COVERAGE:
+- Extraction of services, endpoints, schemas and CODEOWNERS owners
+- Endpoint → schema links from handler signatures
+- Search ranking, relations and JSON persistence
+- Folding a session's edits back into the graph
+- The graph CLI command
+"""
+
+import argparse
+import json
+
+import pytest
+
+from claude_mpm.cli.commands.graph import GraphCommand
+from claude_mpm.services.knowledge_graph import (
+    KnowledgeGraph,
+    KnowledgeGraphBuilder,
+    default_graph_path,
+)
+
+
+@pytest.fixture
+def project(tmp_path):
+    root = tmp_path / "proj"
+    (root / "api" / "app").mkdir(parents=True)
+    (root / "web" / "src" / "types").mkdir(parents=True)
+    (root / ".github").mkdir()
+    (root / "api" / "pyproject.toml").write_text('[project]\nname = "billing-api"\n')
+    (root / "api" / "app" / "models.py").write_text(
+        "from dataclasses import dataclass\n"
+        "from pydantic import BaseModel\n\n\n"
+        "class User(BaseModel):\n"
+        "    id: int\n"
+        "    email: str\n\n"
+        "    def label(self) -> str:\n"
+        "        return self.email\n\n\n"
+        "@dataclass\n"
+        "class Invoice:\n"
+        "    total: float\n\n\n"
+        "class Helper:\n"
+        "    pass\n"
+    )
+    (root / "api" / "app" / "routes.py").write_text(
+        '@router.get("/users/{user_id}")\n'
+        "async def get_user(user_id: int) -> User:\n"
+        "    ...\n\n"
+        '@app.route("/invoices", methods=["GET", "POST"])\n'
+        "def invoices(body: Invoice):\n"
+        "    ...\n"
+    )
+    (root / "web" / "package.json").write_text('{"name": "web"}')
+    (root / "web" / "src" / "server.ts").write_text(
+        "app.get('/health', (req, res) => res.send('ok'))\n"
+    )
+    (root / "web" / "src" / "types" / "user.ts").write_text(
+        "export interface WebUser { id: number }\n"
+    )
+    (root / ".github" / "CODEOWNERS").write_text(
+        "*       @org/platform\n/web/   @org/frontend\n"
+    )
+    return root
+
+
+def _build(project):
+    return KnowledgeGraphBuilder(project, use_git=False).build()
+
+
+def test_build_extracts_entities(project):
+    graph = _build(project)
+
+    assert graph.get("service:api").name == "billing-api"
+    assert {e.name for e in graph.search(type="endpoint")} == {
+        "GET /users/{user_id}",
+        "GET /invoices",
+        "POST /invoices",
+        "GET /health",
+    }
+    user = graph.get("schema:api:User")
+    assert user.attributes["kind"] == "pydantic"
+    assert user.attributes["fields"] == ["id", "email"]
+    assert graph.get("schema:api:Invoice").attributes["kind"] == "dataclass"
+    assert graph.get("schema:web:WebUser") is not None
+    assert graph.search("Helper") == []
+
+
+def test_relations_link_owners_services_and_schemas(project):
+    graph = _build(project)
+
+    web = {(n["relation"], n["entity"]["id"]) for n in graph.neighbors("service:web")}
+    assert ("owns", "owner:@org/frontend") in web
+    assert ("exposes", "endpoint:web:GET /health") in web
+
+    endpoint = graph.describe("endpoint:api:GET /users/{user_id}")
+    assert endpoint["attributes"]["handler"] == "get_user"
+    assert {n["entity"]["id"] for n in endpoint["relations"]} == {
+        "service:api",
+        "schema:api:User",
+    }
+    # The last matching CODEOWNERS rule wins
+    api_owners = [n for n in graph.neighbors("service:api") if n["relation"] == "owns"]
+    assert [n["entity"]["name"] for n in api_owners] == ["@org/platform"]
+
+
+def test_search_ranks_name_matches_and_roundtrips(project, tmp_path):
+    graph = _build(project)
+
+    assert graph.search("user")[0].id == "schema:api:User"
+
+    path = tmp_path / "graph.json"
+    graph.save(path)
+    loaded = KnowledgeGraph.load(path)
+    assert loaded.summary()["by_type"] == graph.summary()["by_type"]
+    assert loaded.relations == graph.relations
+    assert loaded.updated_at is not None
+
+
+def test_update_from_session_reextracts_edited_files(project, tmp_path):
+    graph = _build(project)
+    routes = project / "api" / "app" / "routes.py"
+    routes.write_text(
+        '@router.delete("/users/{user_id}")\ndef delete_user(user_id: int):\n    ...\n'
+    )
+    transcript = tmp_path / "sess-1.jsonl"
+    transcript.write_text(
+        json.dumps(
+            {
+                "type": "assistant",
+                "message": {
+                    "content": [
+                        {
+                            "type": "tool_use",
+                            "name": "Edit",
+                            "input": {"file_path": str(routes)},
+                        },
+                        {
+                            "type": "tool_use",
+                            "name": "Write",
+                            "input": {"file_path": "/elsewhere/notes.py"},
+                        },
+                    ]
+                },
+            }
+        )
+    )
+
+    touched = KnowledgeGraphBuilder(project, use_git=False).update_from_session(
+        graph, transcript
+    )
+
+    assert touched == ["api/app/routes.py"]
+    endpoints = {e.name for e in graph.search(type="endpoint")}
+    assert "DELETE /users/{user_id}" in endpoints
+    assert "GET /invoices" not in endpoints
+    deleted = graph.get("endpoint:api:DELETE /users/{user_id}")
+    assert deleted.attributes["last_session"] == "sess-1"
+    assert graph.get("schema:api:User") is not None
+
+
+def test_graph_command_builds_and_shows(project):
+    command = GraphCommand(project_dir=project)
+
+    built = command.run(argparse.Namespace(graph_command="build"))
+    shown = command.run(
+        argparse.Namespace(graph_command="show", id="service:web", json=False)
+    )
+    missing = command.run(
+        argparse.Namespace(graph_command="show", id="service:nope", json=False)
+    )
+
+    assert built.success
+    assert default_graph_path(project).exists()
+    assert "exposes → GET /health" in shown.message
+    assert "owns by → @org/frontend" in shown.message
+    assert not missing.success
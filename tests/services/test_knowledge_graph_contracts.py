@@ -0,0 +1,183 @@
+"""
+Tests for OpenAPI/protobuf contract awareness.
+
+COVERAGE:
+- Path normalisation across FastAPI, Express and Flask parameter styles
+- OpenAPI and .proto parsing
+- Linking contract operations to handlers and reporting drift
+- Contract entities in the knowledge graph and the graph contracts command
+"""
+
+import argparse
+
+import pytest
+
+from claude_mpm.cli.commands.graph import GraphCommand
+from claude_mpm.services.knowledge_graph import (
+    ContractAnalyzer,
+    KnowledgeGraphBuilder,
+)
+from claude_mpm.services.knowledge_graph.contracts import (
+    SCHEMA_EXTRA_FIELD,
+    SCHEMA_MISSING_FIELD,
+    UNDOCUMENTED,
+    UNIMPLEMENTED,
+    parse_proto,
+    paths_match,
+)
+
+OPENAPI = """\
+openapi: 3.0.0
+info:
+  title: Users
+  version: "1"
+paths:
+  /api/users/{id}:
+    get:
+      operationId: getUser
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+  /api/users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/User"
+      responses:
+        "201":
+          description: created
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id: {type: integer}
+        email: {type: string}
+        name: {type: string}
+"""
+
+PROTO = """\
+syntax = "proto3";
+package users.v1;
+
+service UserService {
+  rpc GetUser (GetUserRequest) returns (User);  // lookup
+  rpc StreamUsers (Empty) returns (stream User);
+}
+
+message User {
+  int64 id = 1;
+  repeated string roles = 2;
+  message Address { string city = 1; }
+}
+"""
+
+
+@pytest.fixture
+def project(tmp_path):
+    root = tmp_path / "proj"
+    (root / "app").mkdir(parents=True)
+    (root / "pyproject.toml").write_text('[project]\nname = "users"\n')
+    (root / "openapi.yaml").write_text(OPENAPI)
+    (root / "users.proto").write_text(PROTO)
+    (root / "app" / "models.py").write_text(
+        "class User(BaseModel):\n    id: int\n    email: str\n    age: int\n"
+    )
+    (root / "app" / "routes.py").write_text(
+        '@router.get("/users/{user_id}")\n'
+        "def get_user(user_id: int) -> User:\n"
+        "    ...\n\n"
+        '@router.delete("/users/{user_id}")\n'
+        "def delete_user(user_id: int):\n"
+        "    ...\n"
+    )
+    (root / "app" / "grpc_server.py").write_text(
+        "class UserService(users_pb2_grpc.UserServiceServicer):\n"
+        "    def GetUser(self, request, context):\n"
+        "        ...\n"
+    )
+    (root / "app" / "users_pb2_grpc.py").write_text(
+        "class UserServiceServicer:\n"
+        "    def StreamUsers(self, request, context):\n"
+        "        raise NotImplementedError\n"
+    )
+    return root
+
+
+def test_paths_match_across_frameworks():
+    assert paths_match("/users/{id}", "/users/:userId")
+    assert paths_match("/users/{id}/", "/users/<int:id>")
+    assert paths_match("/api/v1/users/{id}", "/users/{user_id}")
+    assert not paths_match("/users/{id}", "/accounts/{id}")
+    assert not paths_match("/api/users", "/")
+
+
+def test_parse_proto_services_and_messages():
+    operations, schemas = parse_proto(PROTO, "users.proto")
+
+    assert [op.name for op in operations] == [
+        "users.v1.UserService.GetUser",
+        "users.v1.UserService.StreamUsers",
+    ]
+    assert operations[0].request == "GetUserRequest"
+    assert operations[1].response == "User"
+    user = next(s for s in schemas if s.name == "User")
+    assert user.fields == ["id", "roles"]
+
+
+def test_analyze_links_operations_and_reports_drift(project):
+    graph = KnowledgeGraphBuilder(project, use_git=False).build()
+
+    report = ContractAnalyzer(project).analyze(graph)
+
+    by_name = {op.name: op for op in report.operations}
+    assert by_name["GET /api/users/{id}"].implemented_by == [
+        "endpoint:.:GET /users/{user_id}"
+    ]
+    assert by_name["GET /api/users/{id}"].response == "User"
+    assert by_name["users.v1.UserService.GetUser"].implemented_by == [
+        "app/grpc_server.py:2"
+    ]
+    issues = {(issue.kind, issue.subject) for issue in report.issues}
+    assert issues == {
+        (UNIMPLEMENTED, "POST /api/users"),
+        (UNIMPLEMENTED, "users.v1.UserService.StreamUsers"),
+        (UNDOCUMENTED, "DELETE /users/{user_id}"),
+        (SCHEMA_MISSING_FIELD, "User"),
+        (SCHEMA_EXTRA_FIELD, "User"),
+    }
+    missing = next(i for i in report.issues if i.kind == SCHEMA_MISSING_FIELD)
+    assert "User.name" in missing.detail
+    assert missing.file == "app/models.py"
+
+
+def test_contracts_join_the_knowledge_graph(project):
+    graph = KnowledgeGraphBuilder(project, use_git=False).build()
+
+    contract = graph.describe("contract:openapi.yaml:GET /api/users/{id}")
+
+    assert contract["attributes"]["operation_id"] == "getUser"
+    assert [(n["relation"], n["entity"]["id"]) for n in contract["relations"]] == [
+        ("implements", "endpoint:.:GET /users/{user_id}")
+    ]
+
+
+def test_graph_contracts_command_fails_on_drift(project):
+    args = argparse.Namespace(graph_command="contracts", json=False)
+
+    result = GraphCommand(project_dir=project).run(args)
+
+    assert not result.success
+    assert "2/4 contract operations implemented" in result.message
+    assert "POST /api/users" in result.message
+
+    (project / "openapi.yaml").unlink()
+    (project / "users.proto").unlink()
+    clean = GraphCommand(project_dir=project).run(args)
+    assert clean.success
+    assert clean.message == "No OpenAPI specs or .proto files found"
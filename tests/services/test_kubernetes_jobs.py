@@ -0,0 +1,201 @@
+"""
+Tests for batch agent runs as Kubernetes Jobs.
+
+COVERAGE:
+- Runs files are parsed and validated
+- Job manifests carry per-run resources, the clone step and the API key
+- A batch keeps at most ``parallelism`` Jobs active and collects results
+  into the event log as Jobs finish
+- Batch state survives reopening, and cancel deletes the batch's Jobs
+"""
+
+import argparse
+import json
+import subprocess
+
+import pytest
+
+from claude_mpm.cli.commands.batch import BatchCommand
+from claude_mpm.services.event_log import EventLog
+from claude_mpm.services.kubernetes_jobs import (
+    ACTIVE,
+    EVENT_COMPLETED,
+    EVENT_FAILED,
+    FAILED,
+    PENDING,
+    SUCCEEDED,
+    KubernetesBatch,
+    KubernetesConfig,
+    load_runs,
+    parse_result,
+)
+
+
+def _result_line(text, is_error=False):
+    return json.dumps(
+        {
+            "type": "result",
+            "subtype": "success",
+            "result": text,
+            "is_error": is_error,
+            "session_id": "s-1",
+            "total_cost_usd": 0.25,
+            "num_turns": 3,
+        }
+    )
+
+
+class FakeKubectl:
+    """Stands in for ``kubectl``, with Jobs finishing when told to."""
+
+    def __init__(self):
+        self.jobs = {}
+        self.calls = []
+
+    def finish(self, name, condition="Complete", output=None):
+        self.jobs[name]["status"] = {
+            "conditions": [{"type": condition, "status": "True"}]
+        }
+        self.jobs[name]["output"] = output or _result_line(f"done {name}")
+
+    def __call__(self, command, input=None, **kwargs):
+        args = command[command.index("--namespace") + 2 :]
+        self.calls.append(args)
+        stdout = ""
+        if args[0] == "apply":
+            job = json.loads(input)
+            self.jobs[job["metadata"]["name"]] = job
+        elif args[0] == "get":
+            stdout = json.dumps({"items": list(self.jobs.values())})
+        elif args[0] == "logs":
+            stdout = self.jobs[args[1].split("/", 1)[1]].get("output", "")
+        elif args[0] == "delete":
+            self.jobs.clear()
+        return subprocess.CompletedProcess(command, 0, stdout, "")
+
+
+@pytest.fixture
+def kubectl():
+    return FakeKubectl()
+
+
+@pytest.fixture
+def runs_file(tmp_path):
+    path = tmp_path / "runs.jsonl"
+    path.write_text(
+        "\n".join(
+            json.dumps(entry)
+            for entry in [
+                {"id": "api", "prompt": "Review", "repo": "https://x/api.git"},
+                {"prompt": "Summarise", "cpu": "2", "memory": "4Gi"},
+                {"id": "web", "prompt": "Audit", "max_turns": 5},
+            ]
+        )
+    )
+    return path
+
+
+def _batch(runs_file, tmp_path, kubectl, **config):
+    return KubernetesBatch.create(
+        load_runs(runs_file),
+        batch_id="Nightly Audit",
+        config=KubernetesConfig(**config),
+        state_dir=tmp_path / "batches",
+        kubectl=kubectl,
+        event_log=EventLog(tmp_path / "event_log.json"),
+    )
+
+
+def test_load_runs_validates(tmp_path, runs_file):
+    assert [run.id for run in load_runs(runs_file)] == ["api", "run-2", "web"]
+
+    bad = tmp_path / "bad.json"
+    bad.write_text(json.dumps([{"id": "a", "prompt": "x"}, {"id": "a", "prompt": "y"}]))
+    with pytest.raises(ValueError, match="Duplicate"):
+        load_runs(bad)
+    bad.write_text(json.dumps([{"id": "a"}]))
+    with pytest.raises(ValueError, match="no prompt"):
+        load_runs(bad)
+
+
+def test_manifest(runs_file, tmp_path, kubectl):
+    batch = _batch(runs_file, tmp_path, kubectl, image="registry/mpm:1")
+    api, summarise, web = batch.runs
+
+    job = batch.manifest(api)
+    pod = job["spec"]["template"]["spec"]
+    container = pod["containers"][0]
+    assert job["metadata"]["labels"]["claude-mpm/batch"] == "nightly-audit"
+    assert len(job["metadata"]["name"]) <= 63
+    assert pod["initContainers"][0]["command"][-2:] == [
+        "https://x/api.git",
+        "/workspace/repo",
+    ]
+    assert container["workingDir"] == "/workspace/repo"
+    assert container["image"] == "registry/mpm:1"
+    assert container["command"][:5] == [
+        "claude-mpm",
+        "run",
+        "--headless",
+        "-i",
+        "Review",
+    ]
+    assert container["env"][0]["valueFrom"]["secretKeyRef"]["name"] == "claude-mpm"
+
+    limits = batch.manifest(summarise)["spec"]["template"]["spec"]["containers"][0]
+    assert limits["resources"]["limits"] == {"cpu": "2", "memory": "4Gi"}
+    web_job = batch.manifest(web)["spec"]["template"]["spec"]
+    assert "initContainers" not in web_job
+    assert "--max-turns" in web_job["containers"][0]["command"]
+
+
+def test_parallelism_and_result_collection(runs_file, tmp_path, kubectl):
+    batch = _batch(runs_file, tmp_path, kubectl, parallelism=2)
+
+    assert batch.step() == {PENDING: 1, ACTIVE: 2, SUCCEEDED: 0, FAILED: 0}
+    api, summarise, web = batch.runs
+    kubectl.finish(api.job)
+    kubectl.finish(summarise.job, condition="Failed", output="OOMKilled\n")
+
+    assert batch.step() == {PENDING: 0, ACTIVE: 1, SUCCEEDED: 1, FAILED: 1}
+    assert api.result["text"] == f"done {api.job}"
+    assert api.result["cost_usd"] == 0.25
+    assert (batch.logs_dir / "api.log").is_file()
+
+    kubectl.finish(web.job, output=_result_line("boom", is_error=True))
+    summary = batch.run(sleep=lambda _: None)
+    assert summary[SUCCEEDED] == 1 and summary[FAILED] == 2
+
+    events = EventLog(tmp_path / "event_log.json")
+    assert len(events.list_events(event_type=EVENT_COMPLETED)) == 1
+    failed = events.list_events(event_type=EVENT_FAILED, status="pending")
+    assert {e["payload"]["run_id"] for e in failed} == {"run-2", "web"}
+
+
+def test_reopen_status_and_cancel(runs_file, tmp_path, kubectl):
+    batch = _batch(runs_file, tmp_path, kubectl, parallelism=1)
+    batch.step()
+
+    reopened = KubernetesBatch.open("nightly-audit", tmp_path / "batches")
+    assert reopened.runs[0].status == ACTIVE
+    assert reopened.config.parallelism == 1
+
+    command = BatchCommand(state_dir=tmp_path / "batches", kubectl=kubectl)
+    listing = command.run(
+        argparse.Namespace(batch_command="status", batch_id=None, json=False)
+    )
+    assert "nightly-audit" in listing.message
+
+    result = command.run(
+        argparse.Namespace(batch_command="cancel", batch_id="nightly-audit")
+    )
+    assert result.success
+    assert ["delete", "jobs", "-l", "claude-mpm/batch=nightly-audit"] in kubectl.calls
+    reopened = KubernetesBatch.open("nightly-audit", tmp_path / "batches")
+    assert reopened.done
+
+
+def test_parse_result_without_result_message():
+    result = parse_result("Error: image pull failed\n")
+    assert result.is_error
+    assert "image pull" in result.text
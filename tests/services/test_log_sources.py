@@ -0,0 +1,182 @@
+"""
+Tests for project log sources.
+
+COVERAGE:
+- Relative and ISO time parsing, timestamps in log lines
+- Registry add/replace/remove
+- File queries with time range, grep and limit; tracebacks stay together
+- Docker and journald command construction
+- The logs command and the query_logs MCP tool
+"""
+
+import argparse
+import asyncio
+import subprocess
+from datetime import datetime, timezone
+
+import pytest
+
+from claude_mpm.cli.commands.logs import LogsCommand
+from claude_mpm.mcp.logs_server import LogsMCPServer
+from claude_mpm.services import log_sources
+from claude_mpm.services.log_sources import (
+    DOCKER,
+    FILE,
+    LogQuery,
+    LogSource,
+    LogSourceError,
+    LogSourceRegistry,
+    line_timestamp,
+    parse_time,
+)
+
+APP_LOG = """\
+2026-03-01T10:00:00Z INFO starting server
+2026-03-01T10:05:00Z ERROR request failed
+Traceback (most recent call last):
+  File "app.py", line 3, in handler
+KeyError: 'user_id'
+2026-03-01T10:10:00Z INFO request ok
+"""
+
+
+@pytest.fixture
+def project(tmp_path):
+    (tmp_path / "logs").mkdir()
+    (tmp_path / "logs" / "app.log").write_text(APP_LOG)
+    LogSourceRegistry(tmp_path).add(LogSource("app", FILE, "logs/app.log"))
+    return tmp_path
+
+
+def test_time_parsing():
+    now = datetime(2026, 3, 1, 12, 0, tzinfo=timezone.utc)
+    assert parse_time("90m", now) == datetime(2026, 3, 1, 10, 30, tzinfo=timezone.utc)
+    assert parse_time("2026-03-01T10:00:00+01:00") == datetime(
+        2026, 3, 1, 9, 0, tzinfo=timezone.utc
+    )
+    assert parse_time(None) is None
+    with pytest.raises(LogSourceError):
+        parse_time("yesterday")
+
+    stamp = line_timestamp("2026-03-01T10:00:00.123456789Z stdout hi")
+    assert stamp == datetime(2026, 3, 1, 10, 0, 0, 123456, tzinfo=timezone.utc)
+    assert line_timestamp("no time here") is None
+
+
+def test_registry_add_replace_remove(tmp_path):
+    registry = LogSourceRegistry(tmp_path)
+    registry.add(LogSource("api", FILE, "api.log"))
+    registry.add(LogSource("api", DOCKER, "api-1"))
+
+    assert [s.to_dict() for s in registry.sources()] == [
+        {"name": "api", "kind": DOCKER, "target": "api-1"}
+    ]
+    with pytest.raises(LogSourceError):
+        registry.add(LogSource("db", "syslog", "x"))
+    assert registry.remove("api")
+    assert not registry.remove("api")
+
+
+def test_file_query_filters(project):
+    logs = LogQuery(project)
+
+    errors = logs.query(grep="keyerror")
+    window = logs.query(
+        since="2026-03-01T10:04:00+00:00", until="2026-03-01T10:06:00+00:00"
+    )
+    latest = logs.query(limit=1)
+
+    assert [line.text for line in errors] == ["KeyError: 'user_id'"]
+    assert errors[0].timestamp == "2026-03-01T10:05:00+00:00"
+    assert [line.text.split()[0] for line in window] == [
+        "2026-03-01T10:05:00Z",
+        "Traceback",
+        "File",
+        "KeyError:",
+    ]
+    assert [line.text for line in latest] == ["2026-03-01T10:10:00Z INFO request ok"]
+    with pytest.raises(LogSourceError, match="Unknown log source"):
+        logs.query(source="worker")
+
+
+def test_docker_and_journald_commands(tmp_path, monkeypatch):
+    registry = LogSourceRegistry(tmp_path)
+    registry.add(LogSource("api", DOCKER, "api-1"))
+    registry.add(LogSource("web", "journald", "nginx.service"))
+    calls = []
+
+    def fake_run(command, **kwargs):
+        calls.append(command)
+        if command[0] == "docker":
+            return subprocess.CompletedProcess(
+                command,
+                0,
+                stdout="2026-03-01T10:00:02.5Z served /\n",
+                stderr="2026-03-01T10:00:01Z boom\n",
+            )
+        return subprocess.CompletedProcess(
+            command,
+            0,
+            stdout="2026-03-01T10:00:03+0000 host nginx[1]: up\n",
+            stderr="",
+        )
+
+    monkeypatch.setattr(log_sources.subprocess, "run", fake_run)
+
+    lines = LogQuery(tmp_path).query(since="2026-03-01T09:00:00+00:00")
+
+    assert calls[0] == [
+        "docker",
+        "logs",
+        "--timestamps",
+        "--since",
+        "2026-03-01T09:00:00+00:00",
+        "api-1",
+    ]
+    assert calls[1][:6] == [
+        "journalctl",
+        "--no-pager",
+        "-o",
+        "short-iso-precise",
+        "-u",
+        "nginx.service",
+    ]
+    assert "--since" in calls[1]
+    assert [(line.source, line.text.split()[-1]) for line in lines] == [
+        ("api", "boom"),
+        ("api", "/"),
+        ("web", "up"),
+    ]
+
+
+def test_logs_command_and_mcp_tool(tmp_path):
+    command = LogsCommand(project_dir=tmp_path)
+    added = command.run(
+        argparse.Namespace(
+            logs_command="add", name="app", file="app.log", docker=None, journald=None
+        )
+    )
+    (tmp_path / "app.log").write_text(APP_LOG)
+    queried = command.run(
+        argparse.Namespace(
+            logs_command="query",
+            source="app",
+            since=None,
+            until=None,
+            grep="ERROR",
+            case_sensitive=True,
+            limit=10,
+            json=False,
+        )
+    )
+
+    assert added.success
+    assert queried.message == "2026-03-01T10:05:00Z ERROR request failed"
+
+    server = LogsMCPServer(project_root=tmp_path)
+    listed = asyncio.run(server._dispatch_tool("list_log_sources", {}))
+    result = asyncio.run(
+        server._dispatch_tool("query_logs", {"grep": "request", "limit": 5})
+    )
+    assert listed["sources"][0]["target"] == "app.log"
+    assert result["count"] == 2
@@ -0,0 +1,234 @@
+"""
+Tests for detached tracking of long-running commands.
+
+COVERAGE:
+- Progress is parsed from percentages, "N/M" counters and custom patterns,
+  reading redrawn progress bars at their latest state
+- Starting a task records it and launches a detached watcher, without
+  overwriting what the watcher has already saved
+- The watcher runs the command, tracks progress and GPU samples, and records
+  progress, completion and failure in the event log
+- Stopped tasks are recorded as stopped; tasks whose watcher died as lost
+- The tasks command starts, lists and shows tasks
+"""
+
+import argparse
+import os
+import subprocess
+import sys
+import threading
+import time
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.cli.commands.tasks import TasksCommand
+from claude_mpm.services.event_log import EventLog
+from claude_mpm.services.long_tasks import (
+    EVENT_COMPLETED,
+    EVENT_FAILED,
+    EVENT_PROGRESS,
+    EVENT_STARTED,
+    FAILED,
+    LOST,
+    RUNNING,
+    STOPPED,
+    SUCCEEDED,
+    LongTaskConfig,
+    TaskTracker,
+    parse_progress,
+)
+
+GPU = {
+    "index": 0,
+    "name": "A100",
+    "utilization": 97,
+    "memory_used_mb": 30000,
+    "memory_total_mb": 40960,
+}
+# Prints an epoch counter and a tqdm-style bar that redraws itself
+TRAIN = (
+    "import sys, time\n"
+    "for epoch in range(1, 5):\n"
+    "    print(f'epoch {epoch}/4 loss=0.{9 - epoch}', flush=True)\n"
+    "    time.sleep(0.05)\n"
+    "sys.stdout.write('eval:  50%|#####     |\\reval: 100%|##########|\\n')\n"
+)
+
+
+class FakeWatcher:
+    """What ``start`` launches; the test runs ``watch`` itself."""
+
+    def __init__(self, calls):
+        self.calls = calls
+
+    def __call__(self, argv, **kwargs):
+        self.calls.append((argv, kwargs))
+        self.pid = os.getpid()
+        return self
+
+
+@pytest.fixture
+def events(tmp_path):
+    return EventLog(tmp_path / "event_log.json")
+
+
+@pytest.fixture
+def tracker(tmp_path, events):
+    return TaskTracker(
+        state_dir=tmp_path / "tasks",
+        config=LongTaskConfig(poll_interval=0.02, progress_step=25),
+        event_log=events,
+        gpu_sampler=lambda: [GPU],
+        launch=FakeWatcher([]),
+    )
+
+
+def _event_types(events):
+    return [event["event_type"] for event in events.events]
+
+
+def test_parse_progress():
+    assert parse_progress("epoch 3/10 loss=0.4") == (30.0, "epoch 3/10 loss=0.4")
+    assert parse_progress("Step 120 of 480") == (25.0, "Step 120 of 480")
+    assert parse_progress("[12/48] Building CXX object foo.o")[0] == 25.0
+    assert parse_progress(" 10%|#  |\r 45%|####  |\rdone\n")[0] == 45.0
+    assert parse_progress("ran 3 tests") is None
+    assert parse_progress("loss 250%") is None
+
+    pattern = r"processed (?P<current>\d+) rows of (?P<total>\d+)"
+    assert parse_progress("processed 5 rows of 20, 90% cached", pattern)[0] == 25.0
+
+
+def test_start_launches_detached_watcher(tracker, tmp_path, events):
+    task = tracker.start(["python", "train.py"], name="Train GPT", cwd=tmp_path)
+
+    assert task.id.startswith("train-gpt-")
+    assert task.status == RUNNING
+    ((argv, kwargs),) = tracker.launch.calls
+    assert argv[1:] == [
+        "-m",
+        "claude_mpm.services.long_tasks",
+        str(tmp_path / "tasks" / task.id),
+    ]
+    assert kwargs["start_new_session"] is True
+    assert tracker.get(task.id).watcher_pid == os.getpid()
+    assert _event_types(events) == [EVENT_STARTED]
+
+    with pytest.raises(ValueError, match="No command"):
+        tracker.start([])
+    with pytest.raises(ValueError, match="Invalid progress pattern"):
+        tracker.start(["true"], progress_pattern="(")
+
+
+def test_start_keeps_what_the_watcher_saved(tracker, tmp_path):
+    def launch(argv, **kwargs):
+        # A fast watcher has started the command before start() returns
+        task = tracker.get(Path(argv[-1]).name)
+        task.pid = 4242
+        tracker._save(task)
+        return FakeWatcher([])(argv, **kwargs)
+
+    tracker.launch = launch
+    task = tracker.start(["python", "train.py"], cwd=tmp_path)
+
+    assert task.pid == 4242
+    saved = tracker.get(task.id)
+    assert (saved.pid, saved.watcher_pid) == (4242, os.getpid())
+
+
+def test_watch_tracks_progress_and_completion(tracker, tmp_path, events):
+    task = tracker.start([sys.executable, "-c", TRAIN], cwd=tmp_path)
+
+    task = tracker.watch(task.id)
+
+    assert task.status == SUCCEEDED
+    assert task.exit_code == 0
+    assert task.percent == 100.0
+    assert task.gpu == [GPU]
+    assert tracker.tail(task.id).splitlines() == [
+        "epoch 1/4 loss=0.8",
+        "epoch 2/4 loss=0.7",
+        "epoch 3/4 loss=0.6",
+        "epoch 4/4 loss=0.5",
+        "eval: 100%|##########|",
+    ]
+    types = _event_types(events)
+    assert types[0] == EVENT_STARTED
+    assert types[-1] == EVENT_COMPLETED
+    assert EVENT_PROGRESS in types
+    assert set(types[1:-1]) == {EVENT_PROGRESS}
+    completed = events.list_events(event_type=EVENT_COMPLETED)[0]
+    assert completed["status"] == "resolved"
+    assert completed["payload"]["exit_code"] == 0
+
+    failing = tracker.start([sys.executable, "-c", "print('boom'); exit(3)"])
+    failing = tracker.watch(failing.id)
+    assert (failing.status, failing.exit_code, failing.last_line) == (
+        FAILED,
+        3,
+        "boom",
+    )
+    failed = events.list_events(event_type=EVENT_FAILED)[0]
+    assert failed["status"] == "pending"
+
+    missing = tracker.watch(tracker.start(["no-such-command-xyz"]).id)
+    assert missing.status == FAILED
+    assert "Could not start" in missing.last_line
+
+
+def test_stop_and_lost_tasks(tracker, tmp_path):
+    task = tracker.start([sys.executable, "-c", "import time; time.sleep(30)"])
+    watcher = threading.Thread(target=tracker.watch, args=(task.id,))
+    watcher.start()
+    deadline = time.time() + 10
+    while not tracker.get(task.id).pid and time.time() < deadline:
+        time.sleep(0.02)
+
+    assert tracker.stop(task.id).status == RUNNING
+    watcher.join(timeout=10)
+    assert tracker.get(task.id).status == STOPPED
+
+    dead = subprocess.Popen([sys.executable, "-c", "pass"])
+    dead.wait()
+    lost = tracker.start(["sleep", "1"])
+    lost.watcher_pid = dead.pid
+    tracker._save(lost)
+    assert tracker.get(lost.id).status == LOST
+    assert tracker.stop(lost.id).status == LOST
+
+
+def test_tasks_command(tracker, tmp_path, capsys):
+    command = TasksCommand(tracker=tracker)
+
+    args = argparse.Namespace(
+        tasks_command="start",
+        task_command=["--", sys.executable, "-c", "print('50%')"],
+        name="half",
+        task_cwd=str(tmp_path),
+        progress_pattern=None,
+    )
+    result = command.run(args)
+    assert result.success
+    task_id = result.data["id"]
+    assert result.data["command"][0] == sys.executable
+    tracker.watch(task_id)
+
+    result = command.run(
+        argparse.Namespace(tasks_command="list", running=False, json=False)
+    )
+    assert task_id in result.message
+    assert "succeeded" in result.message
+    assert "100%" in result.message
+
+    result = command.run(
+        argparse.Namespace(tasks_command="show", task_id=task_id, json=False)
+    )
+    assert "Exit:     0" in result.message
+    assert result.message.endswith("50%")
+
+    result = command.run(
+        argparse.Namespace(tasks_command="show", task_id="nope", json=False)
+    )
+    assert not result.success
+    assert "No task 'nope'" in result.message
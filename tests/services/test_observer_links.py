@@ -0,0 +1,150 @@
+"""
+Tests for read-only observer links.
+
+COVERAGE:
+- A link opens only with its token, only until it expires or is revoked,
+  and the links file holds no usable token
+- Observers are refused without a valid token, join their session's room,
+  and get copies of that session's events only
+- Observers of a revoked link are disconnected
+- The observer page connects under /observe/, never to /socket.io/, and
+  pins the Socket.IO client with SRI
+- Link management refuses other origins
+- dashboard share creates, lists and revokes links
+"""
+
+import asyncio
+import json
+from argparse import Namespace
+from datetime import UTC, datetime, timedelta
+from types import SimpleNamespace
+
+import pytest
+
+from claude_mpm.cli.commands.dashboard import DashboardCommand
+from claude_mpm.services.monitor import observers
+from claude_mpm.services.monitor.observers import (
+    NAMESPACE,
+    ObserverHub,
+    ObserverLinks,
+    session_room,
+)
+
+
+class FakeSio:
+    def __init__(self):
+        self.rooms = {}
+        self.emitted = []
+        self.disconnected = []
+
+    async def enter_room(self, sid, room, namespace=None):
+        self.rooms[sid] = (room, namespace)
+
+    async def emit(self, event, data, to=None, room=None, namespace=None):
+        self.emitted.append((event, data, to or room, namespace))
+
+    async def disconnect(self, sid, namespace=None):
+        self.disconnected.append(sid)
+
+
+def test_links(tmp_path):
+    links = ObserverLinks(tmp_path)
+    link, token = links.create("sess-1", hours=2, label="demo")
+
+    assert links.verify(token) == link
+    assert links.verify(token + "x") is None
+    assert links.verify(None) is None
+    assert token not in (tmp_path / ".claude-mpm" / "observer-links.json").read_text()
+    assert "token_sha256" not in link.to_dict()
+    assert link.expired(datetime.now(UTC) + timedelta(hours=3))
+
+    with pytest.raises(ValueError):
+        links.create("")
+    assert links.revoke(link.id)
+    assert links.verify(token) is None
+    assert not links.revoke(link.id)
+
+
+def test_hub_relays_one_session_read_only(tmp_path, monkeypatch):
+    links = ObserverLinks(tmp_path)
+    link, token = links.create("sess-1")
+    sio = FakeSio()
+    hub = ObserverHub(sio, links)
+
+    async def scenario():
+        assert not await hub.connect("bad", {}, {"token": "nope"})
+        assert await hub.connect("viewer", {"QUERY_STRING": f"token={token}"})
+        await hub.relay("hook_event", {"session_id": "sess-1", "subtype": "pre_tool"})
+        await hub.relay("hook_event", {"session_id": "sess-2", "subtype": "pre_tool"})
+        await hub.relay("system_event", {"subtype": "heartbeat"})
+
+        links.revoke(link.id)
+        monkeypatch.setattr(observers, "RECHECK_SECONDS", 0)
+        await hub.relay("hook_event", {"session_id": "sess-1"})
+
+    asyncio.run(scenario())
+
+    assert sio.rooms == {"viewer": (session_room("sess-1"), NAMESPACE)}
+    assert sio.emitted[0] == ("observer:session", link.to_dict(), "viewer", NAMESPACE)
+    relayed = [(e[2], e[1].get("session_id")) for e in sio.emitted[1:3]]
+    assert relayed == [
+        (session_room("sess-1"), "sess-1"),
+        (session_room("sess-2"), "sess-2"),
+    ]
+    assert all(e[3] == NAMESPACE for e in sio.emitted)
+    assert sio.disconnected == ["viewer"]
+    assert hub.observers == {}
+
+
+def test_dashboard_share(tmp_path, monkeypatch):
+    monkeypatch.chdir(tmp_path)
+    command = DashboardCommand()
+    monkeypatch.setattr(command, "_resolve_port", lambda args, negotiate: 8770)
+
+    def share(**kwargs):
+        defaults = {"session_id": None, "hours": 8.0, "label": "", "port": None}
+        defaults.update(kwargs)
+        return command._share_session(Namespace(**defaults))
+
+    created = share(session_id="sess-1", label="pairing")
+    assert created.success
+    assert created.data["url"].startswith("http://localhost:8770/observe/")
+    token = created.data["url"].rsplit("/", 1)[1]
+    assert ObserverLinks(tmp_path).verify(token).label == "pairing"
+
+    listed = share(list=True)
+    assert [link["session_id"] for link in listed.data["links"]] == ["sess-1"]
+    assert "pairing" in listed.message
+    json.dumps(listed.data)
+
+    assert share(revoke=created.data["link"]["id"]).success
+    assert share(list=True).data["links"] == []
+    assert not share().success
+
+
+def test_observer_page_stays_under_observe():
+    """Forwarding /observe/ must be enough, so /socket.io/ is never exposed."""
+    from claude_mpm.services.monitor.routes.observer import OBSERVER_PAGE
+
+    assert observers.SOCKETIO_PATH.startswith("observe/")
+    assert f'path: "/{observers.SOCKETIO_PATH}"' in OBSERVER_PAGE
+    assert 'integrity="sha384-' in OBSERVER_PAGE
+    assert 'crossorigin="anonymous"' in OBSERVER_PAGE
+
+
+@pytest.mark.parametrize(
+    ("origin", "allowed"),
+    [
+        (None, True),
+        ("http://localhost:8765", True),
+        ("http://localhost:8766", False),
+        ("https://evil.example", False),
+    ],
+)
+def test_link_management_refuses_other_origins(origin, allowed):
+    from claude_mpm.services.monitor.routes.observer import _same_origin
+
+    headers = {"Origin": origin} if origin else {}
+    request = SimpleNamespace(headers=headers, host="localhost:8765")
+
+    assert _same_origin(request) is allowed
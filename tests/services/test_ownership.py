@@ -0,0 +1,148 @@
+"""Tests for resolving code owners.
+
+COVERAGE:
+- CODEOWNERS pattern semantics: unanchored, anchored, directories, globs
+- Resolution order: mapping file rules, then CODEOWNERS (last match wins,
+  ownerless rules), then git blame of the file or of the referenced lines
+- File references found in text, and routing owners across files
+- Files changed on a branch
+- The owners command
+"""
+
+import argparse
+import subprocess
+
+import pytest
+
+from claude_mpm.cli.commands.owners import OwnersCommand
+from claude_mpm.services import ownership
+from claude_mpm.services.ownership import (
+    OwnershipConfig,
+    OwnershipResolver,
+    find_file_refs,
+    pattern_matches,
+)
+
+
+def git(repo, *args, author="alice"):
+    subprocess.run(
+        ["git", "-c", f"user.name={author}", "-c", f"user.email={author}@x.io", *args],
+        cwd=repo,
+        check=True,
+        capture_output=True,
+    )
+
+
+@pytest.fixture
+def repo(tmp_path, monkeypatch):
+    """app.py: lines 1-2 by alice, line 3 by bob; billing/api.py by bob."""
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    monkeypatch.setattr(ownership, "_CONFIG_CACHE", {})
+    git(tmp_path, "init", "-q", "-b", "main")
+    (tmp_path / "app.py").write_text("a = 1\nb = 2\n")
+    (tmp_path / "billing").mkdir()
+    (tmp_path / "billing" / "api.py").write_text("x = 1\n")
+    git(tmp_path, "add", ".")
+    git(tmp_path, "commit", "-qm", "initial")
+    (tmp_path / "app.py").write_text("a = 1\nb = 2\nc = 3\n")
+    (tmp_path / "billing" / "api.py").write_text("x = 2\n")
+    git(tmp_path, "commit", "-qam", "more", author="bob")
+    return tmp_path
+
+
+def test_pattern_matches():
+    assert pattern_matches("*", "a/b.py")
+    assert pattern_matches("*.py", "src/deep/x.py")
+    assert not pattern_matches("*.py", "src/x.js")
+    assert pattern_matches("/build/", "build/out/x")
+    assert not pattern_matches("/build/", "src/build/x")
+    assert pattern_matches("docs/", "src/docs/guide.md")
+    assert pattern_matches("apps/", "apps/web/index.ts")
+    assert pattern_matches("docs/*", "docs/a.md")
+    assert not pattern_matches("docs/*", "docs/deep/a.md")
+    assert pattern_matches("**/logs", "a/b/logs/x.log")
+    assert pattern_matches("src/billing", "src/billing/api.py")
+    assert not pattern_matches("src/billing", "src/billing2/api.py")
+
+
+def test_resolution_order(repo):
+    resolver = OwnershipResolver(repo, OwnershipConfig(enabled=True))
+    assert resolver.owners_for("app.py").to_dict() == {
+        "path": "app.py",
+        "owners": ["alice@x.io"],
+        "source": "blame",
+        "rule": None,
+    }
+    # Blame narrowed to line 3 finds its author
+    assert resolver.owners_for("app.py", 3, 3).owners == ["bob@x.io"]
+
+    (repo / ".github").mkdir()
+    (repo / ".github" / "CODEOWNERS").write_text(
+        "* @org/core\nbilling/ @org/payments # money\n*.md\n"
+    )
+    (repo / ".claude-mpm").mkdir()
+    (repo / ".claude-mpm" / "owners.yaml").write_text(
+        "paths:\n  billing/api.py: '@carol'\nauthors:\n  bob@x.io: '@bob'\n"
+    )
+    resolver = OwnershipResolver(repo, OwnershipConfig(enabled=True))
+    assert resolver.owners_for("app.py").owners == ["@org/core"]
+    billing = resolver.owners_for("billing/api.py")
+    assert (billing.owners, billing.source) == (["@carol"], "mapping")
+    readme = resolver.owners_for("README.md")
+    assert (readme.owners, readme.source, readme.rule) == ([], "CODEOWNERS", "*.md")
+
+
+def test_blame_can_be_turned_off(repo):
+    config = OwnershipConfig(enabled=True, blame=False)
+    assert OwnershipResolver(repo, config).owners_for("app.py").owners == []
+
+
+def test_file_refs_and_routing(repo):
+    text = "Crash in `app.py:3` (see ./billing/api.py#L1-L1, not missing.py:9)"
+    refs = find_file_refs(text, repo)
+    assert [(r.path, r.start, r.end) for r in refs] == [
+        ("app.py", 3, 3),
+        ("billing/api.py", 1, 1),
+    ]
+
+    (repo / ".claude-mpm").mkdir()
+    (repo / ".claude-mpm" / "owners.yaml").write_text(
+        "authors:\n  bob@x.io: '@bob'\nassignees:\n  '@bob': '@robert'\n"
+    )
+    resolver = OwnershipResolver(repo, OwnershipConfig(enabled=True))
+    (route,) = resolver.route(refs)
+    assert (route.owner, route.paths, route.source) == (
+        "@bob",
+        ["app.py", "billing/api.py"],
+        "blame",
+    )
+    assert resolver.assignee_for("@bob") == "@robert"
+
+
+def test_changed_files(repo):
+    git(repo, "checkout", "-qb", "feature")
+    (repo / "new.py").write_text("n = 1\n")
+    git(repo, "add", ".")
+    git(repo, "commit", "-qm", "new")
+    resolver = OwnershipResolver(repo, OwnershipConfig())
+    assert resolver.changed_files() == ["new.py"]
+    assert resolver.changed_files("main") == ["new.py"]
+    assert resolver.changed_files("nope") == []
+
+
+def test_owners_command(repo, monkeypatch, capsys):
+    monkeypatch.chdir(repo / "billing")
+    args = argparse.Namespace(
+        paths=["api.py", "../app.py:1-2"], changed=False, base=None, json=False
+    )
+    result = OwnersCommand().run(args)
+    assert result.success and result.message == "2 of 2 file(s) have an owner"
+    out = capsys.readouterr().out.splitlines()
+    assert out == [
+        "billing/api.py  bob@x.io  [blame]",
+        "app.py:1-2      alice@x.io  [blame]",
+    ]
+
+    args.paths = ["gone.py"]
+    assert OwnersCommand().run(args).message == "No such file: gone.py"
+    assert OwnersCommand().validate_args(argparse.Namespace(paths=[], changed=False))
@@ -0,0 +1,135 @@
+"""
+Tests for page screenshots and DOM snapshots.
+
+COVERAGE:
+- Only local dev URLs are accepted
+- Capturing through Playwright (faked) stores artifacts per session
+- Listing and loading captures; ids from URLs cannot escape the captures dir
+- The page-capture MCP tools
+"""
+
+import asyncio
+import sys
+import types
+
+import pytest
+
+from claude_mpm.mcp.page_capture_server import PageCaptureMCPServer
+from claude_mpm.services.page_capture import (
+    DOM,
+    SCREENSHOT,
+    PageCaptureError,
+    PageCaptureService,
+    is_local_url,
+)
+
+
+class _FakePage:
+    def __init__(self, calls):
+        self.calls = calls
+        self.handlers = {}
+
+    def on(self, event, handler):
+        self.handlers[event] = handler
+
+    def goto(self, url, **kwargs):
+        self.calls.append(("goto", url))
+        self.handlers["console"](types.SimpleNamespace(type="error", text="boom"))
+        self.handlers["console"](types.SimpleNamespace(type="log", text="hi"))
+        return types.SimpleNamespace(status=200)
+
+    def title(self):
+        return "Home"
+
+    def screenshot(self, path, full_page):
+        self.calls.append(("screenshot", full_page))
+        with open(path, "wb") as handle:
+            handle.write(b"\x89PNG")
+
+    def content(self):
+        return "<html><body><h1>Home</h1></body></html>"
+
+
+@pytest.fixture
+def fake_playwright(monkeypatch):
+    calls = []
+
+    class _Playwright:
+        def __enter__(self):
+            browser = types.SimpleNamespace(
+                new_page=lambda viewport: _FakePage(calls),
+                close=lambda: calls.append(("close",)),
+            )
+            return types.SimpleNamespace(
+                chromium=types.SimpleNamespace(launch=lambda headless: browser)
+            )
+
+        def __exit__(self, *exc):
+            return False
+
+    sync_api = types.ModuleType("playwright.sync_api")
+    sync_api.sync_playwright = _Playwright
+    sync_api.Error = RuntimeError
+    monkeypatch.setitem(sys.modules, "playwright", types.ModuleType("playwright"))
+    monkeypatch.setitem(sys.modules, "playwright.sync_api", sync_api)
+    return calls
+
+
+def test_only_local_urls():
+    assert is_local_url("http://localhost:5173/")
+    assert is_local_url("http://127.0.0.1:8000/admin")
+    assert is_local_url("https://app.localhost/")
+    assert not is_local_url("https://example.com/")
+    assert not is_local_url("file:///etc/passwd")
+
+
+def test_rejects_remote_url_and_unknown_mode(tmp_path):
+    service = PageCaptureService(tmp_path)
+    with pytest.raises(PageCaptureError, match="Only local dev URLs"):
+        service.capture("https://example.com/")
+    with pytest.raises(PageCaptureError, match="Unknown mode"):
+        service.capture("http://localhost:3000/", mode="video")
+
+
+def test_capture_stores_artifacts_per_session(tmp_path, fake_playwright):
+    service = PageCaptureService(tmp_path)
+
+    capture = service.capture("http://localhost:3000/", session_id="s1", full_page=True)
+
+    assert capture.title == "Home"
+    assert capture.status == 200
+    assert capture.console_errors == ["boom"]
+    assert ("screenshot", True) in fake_playwright
+    assert fake_playwright[-1] == ("close",)
+    assert service.artifact_path(capture, SCREENSHOT).read_bytes() == b"\x89PNG"
+    assert "<h1>Home</h1>" in service.artifact_path(capture, DOM).read_text()
+    assert [c.id for c in service.captures("s1")] == [capture.id]
+    assert service.captures("s2") == []
+    assert service.get("s1", capture.id).url == "http://localhost:3000/"
+
+
+def test_ids_cannot_escape_captures_dir(tmp_path):
+    service = PageCaptureService(tmp_path)
+    (tmp_path / ".claude-mpm" / "secret.json").parent.mkdir(parents=True)
+    (tmp_path / ".claude-mpm" / "secret.json").write_text("{}")
+
+    assert service.get("..", "secret") is None
+    assert service.get("../..", "../secret") is None
+
+
+def test_mcp_capture_and_list(tmp_path, fake_playwright, monkeypatch):
+    monkeypatch.setenv("CLAUDE_SESSION_ID", "abc")
+    server = PageCaptureMCPServer(project_root=tmp_path)
+
+    captured = asyncio.run(
+        server._dispatch_tool(
+            "capture_page", {"url": "http://localhost:5173/", "mode": "dom"}
+        )
+    )
+    listed = asyncio.run(server._dispatch_tool("list_captures", {}))
+
+    assert captured["session_id"] == "abc"
+    assert captured["screenshot_path"] is None
+    assert captured["dom_html"].startswith("<html>")
+    assert not captured["dom_truncated"]
+    assert [c["id"] for c in listed["captures"]] == [captured["id"]]
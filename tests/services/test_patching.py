@@ -0,0 +1,214 @@
+"""
+Tests for the fuzz-tolerant patch engine.
+
+COVERAGE:
+- Parsing git and plain unified diffs, tolerating wrong hunk counts, blank
+  context lines without their space and CRLF; rejecting binary patches
+- exact places moved hunks; fuzzy tolerates whitespace drift (re-indenting
+  added lines) and stale context lines; ast places a hunk in the function
+  the header names and rejects patches that break the syntax
+- Diagnostics name the closest region and the first differing line
+- Nothing is written by a dry run or when any file fails; files are created,
+  deleted and keep their line endings and final newline
+- The patch command checks and applies patch files
+"""
+
+import argparse
+
+import pytest
+
+from claude_mpm.cli.commands.patch import PatchCommand
+from claude_mpm.services.patching import (
+    PatchConfig,
+    PatchEngine,
+    PatchParseError,
+    parse_patch,
+)
+
+CONFIG = PatchConfig(max_fuzz=2)
+
+SOURCE = """\
+def load(path):
+    with open(path) as f:
+        data = f.read()
+    return data
+
+
+def save(path, data):
+    with open(path, "w") as f:
+        f.write(data)
+"""
+
+SAVE_PATCH = """\
+diff --git a/store.py b/store.py
+--- a/store.py
++++ b/store.py
+@@ -7,3 +7,4 @@ def save(path, data):
+ def save(path, data):
+     with open(path, "w") as f:
+         f.write(data)
++    return len(data)
+"""
+
+
+def _engine(tmp_path, *strategies):
+    strategies = list(strategies) or None
+    return PatchEngine(root=tmp_path, config=CONFIG, strategies=strategies)
+
+
+def test_parse_patch():
+    (file_patch,) = parse_patch(SAVE_PATCH)
+    assert (file_patch.old_path, file_patch.new_path) == ("store.py", "store.py")
+    (hunk,) = file_patch.hunks
+    assert (hunk.old_start, hunk.old_count, hunk.new_count) == (7, 3, 4)
+    assert hunk.section == "def save(path, data):"
+    assert hunk.after[-1] == "    return len(data)"
+
+    # Plain diff -u paths are kept; a wrong count and a stripped blank
+    # context line are tolerated with a warning
+    sloppy = (
+        "--- src/notes.txt\r\n+++ src/notes.txt\r\n@@ -1,2 +1,2 @@\r\n"
+        " one\r\n\r\n-three\r\n+3\r\n\\ No newline at end of file\r\n"
+    )
+    (file_patch,) = parse_patch(sloppy)
+    assert file_patch.path == "src/notes.txt"
+    (hunk,) = file_patch.hunks
+    assert hunk.before == ["one", "", "three"]
+    assert "header counts -2,+2 but the hunk has -3,+3" in hunk.warnings[0]
+    assert not hunk.new_eof_newline
+
+    with pytest.raises(PatchParseError, match="binary"):
+        parse_patch("--- a/x.png\n+++ b/x.png\nBinary files differ\n")
+    with pytest.raises(PatchParseError, match="no file changes"):
+        parse_patch("just some text\n")
+
+
+def test_exact_and_fuzzy_placement(tmp_path):
+    target = tmp_path / "store.py"
+    target.write_text("# header\n\n" + SOURCE)
+
+    result = _engine(tmp_path, "exact").apply(SAVE_PATCH)
+    assert result.ok and result.written
+    (hunk,) = result.files[0].hunks
+    assert (hunk.strategy, hunk.line, hunk.offset) == ("exact", 9, 2)
+    assert target.read_text().endswith("        f.write(data)\n    return len(data)\n")
+
+    # The file is indented with tabs and the patch with spaces, and one
+    # context line is stale
+    target.write_text(
+        SOURCE.replace("    ", "\t").replace('"w"', '"wb"').replace("save", "dump")
+    )
+    drifted = SAVE_PATCH.replace("save", "dump").replace(
+        "+    return len(data)", "+        f.flush()\n+    return len(data)"
+    )
+    failed = _engine(tmp_path, "exact").check(drifted)
+    assert not failed.ok
+    diagnostic = failed.files[0].hunks[0].diagnostic
+    assert "closest match is at line 7" in diagnostic
+    assert "expected '    with open(path, \"w\") as f:'" in diagnostic
+    assert "found    '\\twith open(path, \"wb\") as f:'" in diagnostic
+
+    result = _engine(tmp_path, "exact", "fuzzy").apply(drifted)
+    assert result.ok, result
+    (hunk,) = result.files[0].hunks
+    assert hunk.strategy == "fuzzy"
+    assert hunk.fuzz == 2
+    assert "whitespace differs" in hunk.notes
+    assert target.read_text().endswith(
+        '\twith open(path, "wb") as f:\n\t\tf.write(data)\n\t\tf.flush()\n'
+        "\treturn len(data)\n"
+    )
+
+
+def test_ast_strategy_uses_hunk_scope_and_checks_syntax(tmp_path):
+    target = tmp_path / "store.py"
+    target.write_text(SOURCE.replace("f.write(data)", "data = f.read()"))
+    # Both functions contain the context line; the header names save()
+    patch = (
+        "--- a/store.py\n+++ b/store.py\n"
+        "@@ -2,1 +2,2 @@ def save(path, data):\n"
+        "         data = f.read()\n"
+        "+        data = data.strip()\n"
+    )
+
+    result = _engine(tmp_path, "ast", "fuzzy").apply(patch)
+    assert result.ok, result
+    (hunk,) = result.files[0].hunks
+    assert hunk.strategy == "ast"
+    assert "matched inside save" in hunk.notes
+    assert hunk.line == 9
+
+    broken = patch.replace("data.strip()", "data.strip(")
+    result = _engine(tmp_path, "ast", "fuzzy").apply(broken)
+    assert not result.ok and not result.written
+    assert "ast: patched file does not parse" in result.files[0].error
+
+
+def test_all_or_nothing_and_file_lifecycle(tmp_path):
+    (tmp_path / "store.py").write_text(SOURCE)
+    (tmp_path / "crlf.txt").write_bytes(b"one\r\ntwo")
+    (tmp_path / "old.txt").write_text("bye\n")
+    patch = (
+        SAVE_PATCH
+        + "--- a/crlf.txt\n+++ b/crlf.txt\n@@ -1,2 +1,2 @@\n one\n-two\n+2\n"
+        "\\ No newline at end of file\n"
+        + "--- /dev/null\n+++ b/new/file.txt\n@@ -0,0 +1,2 @@\n+hello\n+world\n"
+        + "--- a/old.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-bye\n"
+    )
+    engine = _engine(tmp_path)
+
+    result = engine.check(patch)
+    assert result.ok and not result.written
+    assert [f.action for f in result.files] == ["modify", "modify", "create", "delete"]
+    assert (tmp_path / "old.txt").exists()
+
+    missing = patch + "--- a/nope.txt\n+++ b/nope.txt\n@@ -1 +1 @@\n-a\n+b\n"
+    result = engine.apply(missing)
+    assert not result.ok and not result.written
+    assert result.files[-1].error == "file not found"
+    assert (tmp_path / "store.py").read_text() == SOURCE
+    assert not (tmp_path / "new").exists()
+
+    result = engine.apply(patch)
+    assert result.ok and result.written
+    assert (tmp_path / "crlf.txt").read_bytes() == b"one\r\n2"
+    assert (tmp_path / "new" / "file.txt").read_text() == "hello\nworld\n"
+    assert not (tmp_path / "old.txt").exists()
+
+    escape = "--- a/../x.txt\n+++ b/../x.txt\n@@ -1 +1 @@\n-a\n+b\n"
+    assert "outside" in engine.check(escape).files[0].error
+    with pytest.raises(ValueError, match="Unknown patch strategy: nope"):
+        _engine(tmp_path, "nope")
+
+
+def test_patch_command(tmp_path, capsys):
+    (tmp_path / "store.py").write_text(SOURCE)
+    patch_file = tmp_path / "change.patch"
+    patch_file.write_text(SAVE_PATCH.replace("\n def save", "\n  def save"))
+    args = argparse.Namespace(
+        patch_command="check",
+        patch_file=str(patch_file),
+        strategies=None,
+        max_fuzz=None,
+        strip=None,
+        directory=str(tmp_path),
+        json=False,
+        dry_run=False,
+    )
+
+    result = PatchCommand().run(args)
+    assert result.success
+    assert "nothing written" in result.message
+    assert "applied at line 7, ast, whitespace differs" in capsys.readouterr().out
+    assert "return len" not in (tmp_path / "store.py").read_text()
+
+    args.patch_command = "apply"
+    args.strategies = ["exact"]
+    result = PatchCommand().run(args)
+    assert not result.success
+    assert "no files were changed" in result.message
+    assert "did not apply" in capsys.readouterr().out
+
+    args.strategies = None
+    assert PatchCommand().run(args).success
+    assert "return len" in (tmp_path / "store.py").read_text()
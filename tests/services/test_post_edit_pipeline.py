@@ -0,0 +1,208 @@
+"""
+Tests for the post-edit formatting pipeline.
+
+COVERAGE:
+- Project settings override the user's per language and step; a language or
+  step set to false is dropped; new languages can be added
+- Imports run before the formatter; rewritten files are reported as changed,
+  failing steps as failed without stopping the next step
+- Tools are found in the project's node_modules/.bin before PATH; missing
+  tools are skipped
+- Files outside the project, excluded, or of unknown types are left alone
+- The PostToolUse hook only runs for edit tools when the pipeline is enabled
+  and returns a note for the agent when the file was rewritten
+- post-edit show and run
+"""
+
+import json
+import sys
+from pathlib import Path
+from types import SimpleNamespace
+
+import pytest
+
+from claude_mpm.cli.commands.post_edit import PostEditCommand
+from claude_mpm.hooks.claude_hooks.handlers.tool_handler import (
+    _run_post_edit_pipeline,
+)
+from claude_mpm.services.post_edit_pipeline import (
+    CHANGED,
+    FAILED,
+    SKIPPED,
+    UNCHANGED,
+    PostEditPipeline,
+    run_for_tool_event,
+)
+
+# Sorts the lines starting with "import", then appends a marker line once
+SORT_IMPORTS = """\
+import sys
+path = sys.argv[1]
+lines = open(path).read().splitlines()
+imports = sorted(l for l in lines if l.startswith("import "))
+rest = [l for l in lines if not l.startswith("import ")]
+open(path, "w").write("\\n".join(imports + rest) + "\\n")
+"""
+FORMAT = """\
+import sys
+path = sys.argv[1]
+text = open(path).read()
+if "# formatted" not in text:
+    open(path, "w").write(text + "# formatted\\n")
+"""
+FAIL = "import sys\nprint('cannot parse line 3', file=sys.stderr)\nsys.exit(2)\n"
+
+
+def _tool(root: Path, name: str, body: str) -> None:
+    bin_dir = root / "node_modules" / ".bin"
+    bin_dir.mkdir(parents=True, exist_ok=True)
+    script = bin_dir / name
+    script.write_text(f"#!{sys.executable}\n{body}")
+    script.chmod(0o755)
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    root = tmp_path / "project"
+    root.mkdir()
+    _tool(root, "sort-imports", SORT_IMPORTS)
+    _tool(root, "fmt", FORMAT)
+    _tool(root, "broken", FAIL)
+    return root
+
+
+def _config(root: Path, section: dict) -> None:
+    path = root / ".claude-mpm" / "configuration.yaml"
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(json.dumps({"post_edit": section}))
+
+
+PYTHON = {"python": {"imports": "sort-imports {file}", "formatter": "fmt {file}"}}
+
+
+def test_settings_merge(project, tmp_path):
+    user = tmp_path / "home" / ".claude-mpm" / "config" / "configuration.yaml"
+    user.parent.mkdir(parents=True)
+    user.write_text(
+        json.dumps(
+            {
+                "post_edit": {
+                    "timeout": 3,
+                    "languages": {"python": {"imports": "sort-imports {file}"}},
+                }
+            }
+        )
+    )
+    _config(
+        project,
+        {
+            "enabled": True,
+            "languages": {
+                "python": {"formatter": "fmt {file}"},
+                "go": {"imports": False},
+                "rust": False,
+                "proto": {"suffixes": [".proto"], "formatter": ["fmt", "{file}"]},
+            },
+        },
+    )
+    pipeline = PostEditPipeline.for_project(project)
+    assert pipeline.enabled and pipeline.timeout == 3
+    assert [(s.name, s.command) for s in pipeline.steps_for("python")] == [
+        ("imports", ["sort-imports", "{file}"]),
+        ("formatter", ["fmt", "{file}"]),
+    ]
+    assert [s.name for s in pipeline.steps_for("go")] == ["formatter"]
+    assert pipeline.language_for(Path("lib.rs")) is None
+    assert pipeline.language_for(Path("api.PROTO")) == "proto"
+
+    rows = {(r["language"], r["step"]): r for r in pipeline.describe()}
+    assert rows["python", "formatter"]["tool"].endswith("node_modules/.bin/fmt")
+
+    with pytest.raises(ValueError, match="must be a mapping"):
+        PostEditPipeline(project, {"languages": {"python": "black"}})
+
+
+def test_run_steps(project):
+    pipeline = PostEditPipeline(project, {"languages": PYTHON})
+    target = project / "app.py"
+    target.write_text("import sys\nimport os\n")
+
+    result = pipeline.run(target)
+    assert result.path == "app.py" and result.changed
+    assert [(s.name, s.status) for s in result.steps] == [
+        ("imports", CHANGED),
+        ("formatter", CHANGED),
+    ]
+    assert target.read_text() == "import os\nimport sys\n# formatted\n"
+    assert result.summary() == "app.py: sort-imports rewrote it; fmt rewrote it"
+
+    result = pipeline.run("app.py")
+    assert [s.status for s in result.steps] == [UNCHANGED, UNCHANGED]
+    assert result.summary() == "app.py: already formatted"
+
+    pipeline = PostEditPipeline(
+        project,
+        {"languages": {"python": {"imports": "broken {file}", "formatter": "nope"}}},
+    )
+    result = pipeline.run(target)
+    assert [(s.status, s.detail) for s in result.steps] == [
+        (FAILED, "cannot parse line 3"),
+        (SKIPPED, "nope is not installed"),
+    ]
+    assert result.failed and not result.changed
+
+
+def test_files_left_alone(project, tmp_path):
+    config = {"languages": PYTHON, "exclude": ["vendor/*"]}
+    pipeline = PostEditPipeline(project, config)
+    (project / "vendor" / "lib").mkdir(parents=True)
+    (project / "vendor" / "lib" / "x.py").write_text("import b\nimport a\n")
+    (project / "notes.txt").write_text("text")
+    (tmp_path / "outside.py").write_text("import b\nimport a\n")
+
+    assert pipeline.run(project / "vendor" / "lib" / "x.py") is None
+    assert pipeline.run(project / "notes.txt") is None
+    assert pipeline.run(tmp_path / "outside.py") is None
+    assert pipeline.run(project / "missing.py") is None
+    assert (tmp_path / "outside.py").read_text() == "import b\nimport a\n"
+
+
+def test_hook_runs_for_enabled_edits(project):
+    target = project / "app.py"
+    target.write_text("import sys\nimport os\n")
+    event = {
+        "tool_name": "Edit",
+        "cwd": str(project),
+        "tool_input": {"file_path": str(target)},
+    }
+
+    _config(project, {"languages": PYTHON})
+    assert run_for_tool_event(event) is None  # Not enabled
+    assert _run_post_edit_pipeline(event) == ""
+
+    _config(project, {"enabled": True, "languages": PYTHON})
+    assert run_for_tool_event({**event, "tool_name": "Read"}) is None
+    note = _run_post_edit_pipeline(event)
+    assert note.startswith("Post-edit pipeline: app.py: sort-imports rewrote it")
+    assert note.endswith("Re-read the file before editing it again.")
+    assert _run_post_edit_pipeline(event) == ""  # Already formatted
+
+
+def test_post_edit_command(project):
+    _config(project, {"languages": PYTHON})
+    (project / "app.py").write_text("import sys\nimport os\n")
+    command = PostEditCommand(project)
+
+    result = command.run(SimpleNamespace(post_edit_command="show", json=True))
+    assert result.success and result.data["enabled"] is False
+    assert {"language": "python", "step": "imports"}.items() <= (
+        result.data["steps"][0].items()
+    )
+
+    result = command.run(
+        SimpleNamespace(post_edit_command="run", files=[str(project / "app.py")])
+    )
+    assert result.success
+    assert "imports: sort-imports changed" in result.message
+    assert command.validate_args(SimpleNamespace(post_edit_command=None))
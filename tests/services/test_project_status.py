@@ -0,0 +1,118 @@
+"""
+Tests for the project status summary.
+
+COVERAGE:
+- Agents with versions and integrity state, skills, hooks split into
+  claude-mpm's and others, MCP servers and recent sessions are collected
+- MCP server arguments and URLs are not included
+- The markdown file is written to .claude-mpm/README.md by default and
+  re-writing an unchanged project gives the same content
+- status prints counts, --deep the full summary, --write the file path
+"""
+
+import json
+from argparse import Namespace
+
+from claude_mpm.services.project_status import (
+    STATUS_FILE,
+    collect_project_status,
+    write_status_file,
+)
+
+
+def _project(tmp_path):
+    agents = tmp_path / ".claude" / "agents"
+    agents.mkdir(parents=True)
+    (agents / "engineer.md").write_text("---\nname: engineer\nversion: 3.2.1\n---\nx")
+    (agents / "qa.md").write_text("no frontmatter")
+    skill = tmp_path / ".claude" / "skills" / "tdd"
+    skill.mkdir(parents=True)
+    (skill / "SKILL.md").write_text(
+        "---\nname: tdd\ndescription: Test | first\nmetadata:\n  version: 1.0.0\n---\n"
+    )
+    hooks = {
+        "PreToolUse": [
+            {
+                "matcher": "*",
+                "hooks": [
+                    {"type": "command", "command": "/x/hook.sh", "_mpm": True},
+                    {"type": "command", "command": "lint.sh"},
+                ],
+            }
+        ]
+    }
+    (tmp_path / ".claude" / "settings.json").write_text(json.dumps({"hooks": hooks}))
+    servers = {
+        "memory": {"command": "/usr/bin/kuzu-memory", "args": ["--token", "s3cret"]},
+        "remote": {"url": "https://mcp.example.com/sse?key=s3cret"},
+    }
+    (tmp_path / ".mcp.json").write_text(json.dumps({"mcpServers": servers}))
+    responses = tmp_path / ".claude-mpm" / "responses"
+    responses.mkdir(parents=True)
+    (responses / "r1.json").write_text(
+        json.dumps(
+            {
+                "session_id": "abcdef123456",
+                "timestamp": "2026-10-01T10:00:00+00:00",
+                "agent": "engineer",
+                "metadata": {"git_branch": "main"},
+            }
+        )
+    )
+    return tmp_path
+
+
+def test_collects_everything_in_the_project(tmp_path):
+    status = collect_project_status(_project(tmp_path))
+
+    assert status.agents == [
+        {"name": "engineer", "version": "3.2.1", "commit": None, "state": "unrecorded"},
+        {"name": "qa", "version": "-", "commit": None, "state": "unrecorded"},
+    ]
+    assert status.skills[0]["version"] == "1.0.0"
+    assert status.hooks == [{"event": "PreToolUse", "mpm": 1, "other": 1}]
+    assert status.mcp_servers == [
+        {"name": "memory", "command": "kuzu-memory"},
+        {"name": "remote", "command": "mcp.example.com"},
+    ]
+    assert status.sessions[0]["session_id"] == "abcdef12"
+    assert "s3cret" not in json.dumps(status.to_dict())
+
+
+def test_empty_project(tmp_path):
+    status = collect_project_status(tmp_path)
+
+    assert status.counts() == dict.fromkeys(
+        ("agents", "skills", "hooks", "mcp_servers", "sessions"), 0
+    )
+    assert "## Agents\n\nNone." in status.render_markdown()
+
+
+def test_write_status_file_is_stable(tmp_path):
+    status = collect_project_status(_project(tmp_path))
+
+    path = write_status_file(status)
+    first = path.read_text()
+    write_status_file(collect_project_status(tmp_path))
+
+    assert path == tmp_path / STATUS_FILE
+    assert path.read_text() == first
+    assert "| tdd | 1.0.0 | Test \\| first |" in first
+    assert "| engineer | 3.2.1 | - | unrecorded |" in first
+
+
+def test_status_command_modes(tmp_path, capsys):
+    from claude_mpm.cli.commands.status import StatusCommand
+
+    command = StatusCommand(project_root=_project(tmp_path))
+
+    summary = command.run(Namespace(deep=False, write=None, json=False)).message
+    assert "Agents:      2 deployed" in summary
+    assert "Hooks:       2 on 1 event(s)" in summary
+
+    deep = command.run(Namespace(deep=True, write=None, json=False, sessions=0))
+    assert "| engineer |" in deep.message
+    assert "## Recent sessions\n\nNone." in deep.message
+
+    written = command.run(Namespace(deep=False, write="", json=False))
+    assert written.message == f"Wrote {tmp_path.resolve() / STATUS_FILE}"
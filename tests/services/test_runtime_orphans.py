@@ -0,0 +1,116 @@
+"""Tests for the runtime orphan scanner used by ``cleanup --orphans``."""
+
+import os
+import socket
+import subprocess
+import sys
+import time
+
+import pytest
+
+from claude_mpm.services.runtime_orphans import RuntimeOrphan, RuntimeOrphanScanner
+from claude_mpm.services.server_discovery import ServerDiscoveryRegistry
+
+
+def _dead_pid() -> int:
+    proc = subprocess.Popen([sys.executable, "-c", "pass"])
+    proc.wait()
+    return proc.pid
+
+
+def _age(path, seconds=3600):
+    past = time.time() - seconds
+    os.utime(path, (past, past))
+
+
+@pytest.fixture
+def scanner(tmp_path):
+    user_dir = tmp_path / "user"
+    project_dir = tmp_path / "project" / ".claude-mpm"
+    user_dir.mkdir()
+    project_dir.mkdir(parents=True)
+    return RuntimeOrphanScanner(
+        user_dir=user_dir,
+        project_dir=project_dir,
+        discovery=ServerDiscoveryRegistry(user_dir / "servers.json"),
+    )
+
+
+class TestPidFiles:
+    def test_dead_pid_file_is_orphan(self, scanner):
+        path = scanner.user_dir / "monitor.pid"
+        path.write_text(str(_dead_pid()))
+
+        orphans = scanner.scan_pid_files()
+
+        assert [o.target for o in orphans] == [str(path)]
+
+    def test_live_pid_file_is_kept(self, scanner):
+        (scanner.project_dir / "serve.pid").write_text(str(os.getpid()))
+        assert scanner.scan_pid_files() == []
+
+
+class TestLockFiles:
+    @pytest.mark.parametrize(
+        "name", ["config.json.lock", "skills.lock", "agents.lock", "uv.lock"]
+    )
+    def test_lock_files_are_never_reaped(self, name, tmp_path, monkeypatch, scanner):
+        from argparse import Namespace
+
+        from claude_mpm.cli.commands.cleanup import _cleanup_orphans
+
+        monkeypatch.setenv("HOME", str(tmp_path / "home"))
+        monkeypatch.chdir(scanner.project_dir.parent)
+        path = scanner.project_dir / name
+        path.write_text("")
+        _age(path)
+
+        assert scanner.scan() == []
+        _cleanup_orphans(Namespace(include_tmux=False, dry_run=False, force=True))
+        assert path.exists()
+
+
+class TestSocketFiles:
+    def test_dead_socket_is_orphan(self, scanner):
+        path = scanner.user_dir / "inject.sock"
+        server = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+        server.bind(str(path))
+        server.close()
+
+        assert [o.kind for o in scanner.scan_socket_files()] == ["socket_file"]
+
+    def test_listening_socket_is_kept(self, scanner):
+        path = scanner.user_dir / "live.sock"
+        server = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+        server.bind(str(path))
+        server.listen(1)
+        try:
+            assert scanner.scan_socket_files() == []
+        finally:
+            server.close()
+
+
+class TestReap:
+    def test_dry_scan_then_reap_removes_files(self, scanner):
+        pid_file = scanner.user_dir / "monitor.pid"
+        pid_file.write_text(str(_dead_pid()))
+        sock_file = scanner.user_dir / "dead.sock"
+        server = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+        server.bind(str(sock_file))
+        server.close()
+
+        orphans = scanner.scan()
+        assert pid_file.exists() and sock_file.exists()
+
+        results = scanner.reap(orphans)
+
+        assert all(ok for _, ok, _ in results)
+        assert not pid_file.exists()
+        assert not sock_file.exists()
+
+    def test_refuses_to_signal_self(self, scanner):
+        orphan = RuntimeOrphan("server", "pid:self", "test", os.getpid())
+        [(_, ok, message)] = scanner.reap([orphan])
+
+        assert ok is False
+        assert "refusing" in message
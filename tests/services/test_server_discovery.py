@@ -0,0 +1,133 @@
+"""Tests for the user-level dashboard server discovery registry."""
+
+import os
+import socket
+
+import pytest
+
+from claude_mpm.services.server_discovery import (
+    DISCOVERY_PORT_RANGE,
+    ServerDiscoveryRegistry,
+)
+
+DEAD_PID = 2**22 + 12345  # Above the default Linux pid_max
+
+
+@pytest.fixture
+def registry(tmp_path):
+    return ServerDiscoveryRegistry(registry_path=tmp_path / "servers.json")
+
+
+class TestRegistration:
+    def test_register_and_lookup(self, registry, tmp_path):
+        project = tmp_path / "proj"
+        project.mkdir()
+
+        registry.register(project, 8770)
+
+        entry = registry.lookup(project)
+        assert entry["port"] == 8770
+        assert entry["pid"] == os.getpid()
+
+    def test_lookup_nearest_walks_up_to_project_root(self, registry, tmp_path):
+        nested = tmp_path / "src" / "pkg"
+        nested.mkdir(parents=True)
+        registry.register(tmp_path, 8770)
+
+        assert registry.lookup_nearest(nested)["port"] == 8770
+        assert registry.lookup(nested) is None
+
+    def test_lookup_ignores_dead_process(self, registry, tmp_path):
+        registry.register(tmp_path, 8770, pid=DEAD_PID)
+        assert registry.lookup(tmp_path) is None
+
+    def test_port_reassigned_to_new_project(self, registry, tmp_path):
+        a, b = tmp_path / "a", tmp_path / "b"
+        a.mkdir()
+        b.mkdir()
+
+        registry.register(a, 8771, pid=os.getppid())
+        registry.register(b, 8771)
+
+        assert registry.lookup(a) is None
+        assert registry.lookup(b)["port"] == 8771
+
+    def test_same_process_may_serve_several_projects(self, registry, tmp_path):
+        a, b = tmp_path / "a", tmp_path / "b"
+        a.mkdir()
+        b.mkdir()
+
+        registry.register(a, 8771)
+        registry.register(b, 8771)
+
+        assert registry.lookup(a)["port"] == 8771
+        assert registry.lookup(b)["port"] == 8771
+
+    def test_unregister_checks_pid(self, registry, tmp_path):
+        registry.register(tmp_path, 8772)
+
+        assert not registry.unregister(tmp_path, pid=DEAD_PID)
+        assert registry.unregister(tmp_path, pid=os.getpid())
+        assert registry.lookup(tmp_path) is None
+
+    def test_list_and_prune(self, registry, tmp_path):
+        a, b = tmp_path / "a", tmp_path / "b"
+        a.mkdir()
+        b.mkdir()
+        registry.register(a, 8775)
+        registry.register(b, 8774, pid=DEAD_PID)
+
+        assert [s["port"] for s in registry.list_servers()] == [8775]
+        assert registry.prune_dead() == 1
+
+    def test_corrupt_registry_is_ignored(self, registry):
+        registry.registry_path.write_text("{not json")
+        assert registry.list_servers() == []
+
+
+class TestNegotiatePort:
+    def test_reuses_existing_server_port(self, registry, tmp_path):
+        registry.register(tmp_path, 8779)
+        assert registry.negotiate_port(tmp_path) == 8779
+
+    def test_skips_ports_claimed_by_other_projects(
+        self, registry, tmp_path, monkeypatch
+    ):
+        other = tmp_path / "other"
+        other.mkdir()
+        registry.register(other, DISCOVERY_PORT_RANGE.start)
+        monkeypatch.setattr(
+            ServerDiscoveryRegistry, "_port_free", staticmethod(lambda *_a: True)
+        )
+
+        port = registry.negotiate_port(tmp_path / "mine")
+        assert port == DISCOVERY_PORT_RANGE.start + 1
+
+    def test_prefers_requested_port(self, registry, tmp_path, monkeypatch):
+        monkeypatch.setattr(
+            ServerDiscoveryRegistry, "_port_free", staticmethod(lambda *_a: True)
+        )
+        assert registry.negotiate_port(tmp_path, preferred_port=8780) == 8780
+
+    def test_skips_excluded_ports(self, registry, tmp_path, monkeypatch):
+        monkeypatch.setattr(
+            ServerDiscoveryRegistry, "_port_free", staticmethod(lambda *_a: True)
+        )
+        start = DISCOVERY_PORT_RANGE.start
+        port = registry.negotiate_port(
+            tmp_path, preferred_port=start, exclude={start, start + 1}
+        )
+        assert port == start + 2
+
+    def test_skips_bound_ports(self, registry, tmp_path):
+        with socket.socket(socket.AF_INET, socket.SOCK_STREAM) as sock:
+            sock.bind(("localhost", 0))
+            sock.listen()
+            busy = sock.getsockname()[1]
+            assert registry._port_free(busy) is False
+
+    def test_returns_none_when_exhausted(self, registry, tmp_path, monkeypatch):
+        monkeypatch.setattr(
+            ServerDiscoveryRegistry, "_port_free", staticmethod(lambda *_a: False)
+        )
+        assert registry.negotiate_port(tmp_path) is None
@@ -0,0 +1,125 @@
+"""Tests for UI service idle session hibernation.
+
+COVERAGE:
+- Idle sessions past hibernate_after_minutes stop their subprocess, persist
+  their history and free their slot; sessions that cannot be resumed are
+  left to the inactivity timeout
+- The next message respawns claude with --resume, or reports an error when
+  every slot is taken
+- Hibernated sessions survive a restart of the service
+"""
+
+import asyncio
+import json
+from datetime import timedelta
+
+import pytest
+
+from claude_mpm.services.ui_service import process_manager as pm_module
+from claude_mpm.services.ui_service.models.session import (
+    SessionCreate,
+    SessionStatus,
+)
+from claude_mpm.services.ui_service.process_manager import ProcessManager
+from tests.services.test_session_priority import FakeProcess
+
+
+@pytest.fixture
+def spawned(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+    commands = []
+
+    async def spawn(*cmd, **kwargs):
+        commands.append(list(cmd))
+        if len(commands) > 2:
+            # Rehydrated sessions answer in stub mode
+            raise FileNotFoundError("claude")
+        return FakeProcess()
+
+    monkeypatch.setattr(pm_module.asyncio, "create_subprocess_exec", spawn)
+    return commands
+
+
+def test_hibernate_and_rehydrate(spawned, tmp_path):
+    manager = ProcessManager(max_sessions=1, hibernate_after_minutes=15)
+
+    async def scenario():
+        session = await manager.create_session(SessionCreate(bare=True))
+        session.claude_session_id = "claude-1"
+        session.message_history.append({"role": "user", "content": "earlier"})
+        process = session.process
+
+        await manager.reap_idle(session.last_activity + timedelta(minutes=10))
+        assert session.status == SessionStatus.idle
+        await manager.reap_idle(session.last_activity + timedelta(minutes=16))
+        assert session.status == SessionStatus.hibernated
+        assert session.process is None
+        assert process.returncode is not None
+        saved = json.loads(
+            (tmp_path / ".claude-mpm" / "sessions" / f"{session.id}.json").read_text()
+        )
+        assert saved["status"] == "hibernated"
+        assert saved["message_history"] == [{"role": "user", "content": "earlier"}]
+
+        # A restarted service wakes the session on its next message
+        restarted = ProcessManager()
+        restarted._load_persisted_sessions()
+        loaded = restarted.get_session(session.id)
+        assert (loaded.status, loaded.bare) == (SessionStatus.hibernated, True)
+        assert loaded.message_history == saved["message_history"]
+
+        # The slot is free; a message cannot wake the session while it is taken
+        other = await manager.create_session(SessionCreate())
+        events = [e async for e in manager.send_message(session.id, "hello")]
+        assert [e.type for e in events] == ["error"]
+        assert "cannot wake hibernated session" in events[0].data["message"]
+        assert session.status == SessionStatus.hibernated
+
+        # Hibernated sessions are not removed by the inactivity timeout
+        await manager.reap_idle(session.last_activity + timedelta(days=1))
+        assert manager.list_sessions() == [session]
+        assert other.process.returncode is not None
+
+        events = [e async for e in manager.send_message(session.id, "hello")]
+        assert [e.type for e in events] == ["assistant", "result"]
+        assert spawned[-1] == [
+            "claude",
+            "--output-format",
+            "stream-json",
+            "--print",
+            "--resume",
+            "claude-1",
+            "--bare",
+            "--model",
+            "claude-opus-4-5",
+        ]
+        assert session.status == SessionStatus.idle
+        assert session.hibernated_at is None
+        assert [m["content"] for m in session.message_history] == [
+            "earlier",
+            "hello",
+            events[0].content,
+        ]
+
+    asyncio.run(scenario())
+
+
+def test_sessions_without_resume_id_time_out(spawned):
+    manager = ProcessManager(hibernate_after_minutes=15)
+
+    async def scenario():
+        session = await manager.create_session(SessionCreate())
+        with pytest.raises(RuntimeError, match="no Claude session ID"):
+            await manager.hibernate(session.id)
+        await manager.reap_idle(session.last_activity + timedelta(minutes=30))
+        assert session.status == SessionStatus.idle
+        await manager.reap_idle(session.last_activity + timedelta(minutes=61))
+        assert manager.list_sessions() == []
+
+        session = await manager.create_session(SessionCreate())
+        session.claude_session_id = "claude-2"
+        session.status = SessionStatus.busy
+        with pytest.raises(RuntimeError, match="only idle sessions"):
+            await manager.hibernate(session.id)
+
+    asyncio.run(scenario())
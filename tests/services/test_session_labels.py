@@ -0,0 +1,102 @@
+"""
+Tests for session labels and the label taxonomy.
+
+COVERAGE:
+- Labels are normalized, deduplicated and checked against a strict
+  labels.yaml; removing a session's last label drops it from the file
+- Labels from run --label are recorded under the session id at SessionStart
+  (fail-open on invalid ones) and --label is not passed on to Claude Code
+- labels sessions and costs filter by label, and costs total the sessions'
+  transcripts, counting labeled sessions without one as missing
+"""
+
+from argparse import Namespace
+from types import SimpleNamespace
+
+import pytest
+
+from claude_mpm.cli.commands.labels import LabelsCommand
+from claude_mpm.cli.commands.run import filter_claude_mpm_args
+from claude_mpm.services import session_labels
+from claude_mpm.services.session_analysis import transcript_parser
+from claude_mpm.services.session_labels import (
+    SessionLabels,
+    label_costs,
+    record_session_start,
+)
+
+
+def test_add_remove_and_strict_taxonomy(tmp_path):
+    store = SessionLabels(tmp_path)
+    assert store.add("s1", ["Bugfix,customer-x", "bugfix"]) == ["bugfix", "customer-x"]
+    assert store.add("s2", ["spike"]) == ["spike"]
+    with pytest.raises(ValueError, match="Invalid label"):
+        store.add("s1", ["no spaces"])
+
+    assert store.remove("s1", ["customer-x"]) == ["bugfix"]
+    assert store.remove("s2") == []
+    assert store.all() == {"s1": ["bugfix"]}
+
+    (tmp_path / ".claude-mpm" / "labels.yaml").write_text(
+        "strict: true\nlabels:\n  bugfix: Fixing a defect\n  spike: Exploration\n"
+    )
+    with pytest.raises(ValueError, match="Unknown label"):
+        store.add("s1", ["customer-x"])
+    assert store.counts() == {"bugfix": 1, "spike": 0}
+
+
+def test_launch_labels_recorded_at_session_start(tmp_path, monkeypatch):
+    monkeypatch.setenv(session_labels.ENV_VAR, "bugfix,customer-x")
+    event = {"session_id": "s1", "cwd": str(tmp_path)}
+    assert record_session_start(event) == ["bugfix", "customer-x"]
+    assert SessionLabels(tmp_path).labels("s1") == ["bugfix", "customer-x"]
+
+    monkeypatch.setenv(session_labels.ENV_VAR, "not valid!")
+    assert record_session_start({"session_id": "s2", "cwd": str(tmp_path)}) == []
+    assert record_session_start({"cwd": str(tmp_path)}) == []
+
+    args = ["--label", "bugfix", "--model", "opus", "--label", "spike"]
+    assert filter_claude_mpm_args(args) == ["--model", "opus"]
+
+
+def test_sessions_and_costs_by_label(tmp_path, monkeypatch):
+    store = SessionLabels(tmp_path)
+    store.add("s1", ["bugfix", "customer-x"])
+    store.add("s2", ["bugfix"])
+    store.add("s3", ["spike"])
+    transcripts = tmp_path / "transcripts"
+    transcripts.mkdir()
+    (transcripts / "s1.jsonl").write_text("")
+    (transcripts / "s3.jsonl").write_text("")
+    reports = {
+        "s1": SimpleNamespace(total_turns=4, grand_total_cost_usd=1.5),
+        "s3": SimpleNamespace(total_turns=2, grand_total_cost_usd=0.25),
+    }
+    monkeypatch.setattr(
+        transcript_parser,
+        "locate_transcript",
+        lambda session_id, cwd: transcripts / f"{session_id}.jsonl",
+    )
+    monkeypatch.setattr(
+        transcript_parser, "parse_session", lambda session_id, cwd: reports[session_id]
+    )
+
+    costs = {cost.label: cost.to_dict() for cost in label_costs(tmp_path)}
+    assert costs["bugfix"] == {
+        "label": "bugfix",
+        "sessions": 1,
+        "turns": 4,
+        "cost_usd": 1.5,
+        "missing": 1,
+    }
+    assert costs["spike"]["cost_usd"] == 0.25
+    assert [c.label for c in label_costs(tmp_path, ["customer-x"])] == ["customer-x"]
+
+    command = LabelsCommand(project_dir=tmp_path)
+    sessions = command.run(
+        Namespace(labels_command="sessions", label=["bugfix"], json=True)
+    )
+    assert [row["session_id"] for row in sessions.data] == ["s1", "s2"]
+    report = command.run(Namespace(labels_command="costs", label=None, json=False))
+    assert report.message.splitlines()[1].startswith("bugfix")
+    assert "(1 without transcript)" in report.message
@@ -0,0 +1,173 @@
+"""Tests for UI service session priorities and preemption.
+
+COVERAGE:
+- Sessions default to interactive; priority is persisted and reported
+- An interactive session created at capacity pauses the least recently
+  active batch session (SIGSTOP) and is refused when there is none
+- Terminating a session resumes preempted sessions (SIGCONT); manually
+  paused sessions stay paused and resume only when a slot is free
+- Messages to a paused session wait for the resume
+- Waiting turns are served interactive first, then background, then batch
+"""
+
+import asyncio
+import json
+import signal
+
+import pytest
+
+from claude_mpm.services.ui_service import process_manager as pm_module
+from claude_mpm.services.ui_service.models.session import (
+    SessionCreate,
+    SessionPriority,
+    SessionStatus,
+)
+from claude_mpm.services.ui_service.process_manager import (
+    ProcessManager,
+    TurnScheduler,
+)
+
+INTERACTIVE = SessionCreate()
+BATCH = SessionCreate(priority="batch")
+
+
+class FakeProcess:
+    """Records signals instead of running the claude CLI."""
+
+    def __init__(self):
+        self.pid = 4242
+        self.returncode = None
+        self.stdout = None
+        self.signals = []
+
+    def send_signal(self, signum):
+        self.signals.append(signum)
+
+    def terminate(self):
+        self.returncode = -15
+
+    async def wait(self):
+        return self.returncode
+
+
+@pytest.fixture
+def manager(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path))
+
+    async def spawn(*cmd, **kwargs):
+        return FakeProcess()
+
+    monkeypatch.setattr(pm_module.asyncio, "create_subprocess_exec", spawn)
+    return ProcessManager(max_sessions=2)
+
+
+def test_preemption(manager, tmp_path):
+    async def scenario():
+        first = await manager.create_session(BATCH)
+        second = await manager.create_session(BATCH)
+        second.last_activity = first.last_activity.replace(year=2000)
+        with pytest.raises(RuntimeError, match="Maximum sessions"):
+            await manager.create_session(SessionCreate(priority="background"))
+
+        interactive = await manager.create_session(INTERACTIVE)
+        assert second.status == SessionStatus.paused
+        assert second.preempted_by == interactive.id
+        assert second.process.signals == [signal.SIGSTOP]
+        assert first.status == SessionStatus.idle
+        state = second.to_state()
+        assert (state.priority, state.preempted_by) == (
+            SessionPriority.batch,
+            interactive.id,
+        )
+        saved = json.loads(
+            (tmp_path / ".claude-mpm" / "sessions" / f"{second.id}.json").read_text()
+        )
+        assert (saved["priority"], saved["status"]) == ("batch", "paused")
+
+        # Only batch sessions are preempted
+        await manager.create_session(INTERACTIVE)
+        with pytest.raises(RuntimeError, match="Maximum sessions"):
+            await manager.create_session(INTERACTIVE)
+        assert first.status == SessionStatus.paused
+
+        await manager.terminate(interactive.id)
+        assert second.status == SessionStatus.idle
+        assert second.process.signals == [signal.SIGSTOP, signal.SIGCONT]
+        assert second.preempted_by is None
+        assert first.status == SessionStatus.paused
+
+    asyncio.run(scenario())
+
+
+def test_manual_pause_and_resume(manager):
+    async def scenario():
+        batch = await manager.create_session(BATCH)
+        other = await manager.create_session(INTERACTIVE)
+        await manager.pause(batch.id)
+        assert batch.status == SessionStatus.paused
+
+        third = await manager.create_session(INTERACTIVE)
+        with pytest.raises(RuntimeError, match="running"):
+            await manager.resume(batch.id)
+
+        # Manually paused sessions are not resumed automatically
+        await manager.terminate(third.id)
+        assert batch.status == SessionStatus.paused
+
+        # A message waits until the session is resumed
+        batch.process = None
+        reply = asyncio.create_task(_collect(manager, batch.id))
+        await asyncio.sleep(0)
+        assert not reply.done()
+        await manager.resume(batch.id)
+        events = await reply
+        assert [e.type for e in events] == ["assistant", "result"]
+        assert batch.status == SessionStatus.idle
+
+        await manager.terminate(other.id)
+        await manager.pause(batch.id)
+        await manager.terminate(batch.id)
+        assert manager.list_sessions() == []
+
+    asyncio.run(scenario())
+
+
+async def _collect(manager, session_id):
+    return [event async for event in manager.send_message(session_id, "hi")]
+
+
+def test_turns_are_served_by_priority():
+    async def scenario():
+        turns = TurnScheduler(max_active=1)
+        served = []
+
+        async def turn(name, priority):
+            await turns.acquire(priority)
+            served.append(name)
+            await asyncio.sleep(0)
+            turns.release()
+
+        await turns.acquire(SessionPriority.batch)
+        tasks = [
+            asyncio.create_task(turn("batch", SessionPriority.batch)),
+            asyncio.create_task(turn("background", SessionPriority.background)),
+            asyncio.create_task(turn("gone", SessionPriority.interactive)),
+            asyncio.create_task(turn("interactive", SessionPriority.interactive)),
+        ]
+        await asyncio.sleep(0)
+        assert turns.waiting == 4
+        tasks[2].cancel()
+        await asyncio.sleep(0)
+        assert turns.waiting == 3
+        turns.release()
+        await asyncio.gather(*tasks[:2], tasks[3])
+        assert served == ["interactive", "background", "batch"]
+        assert (turns.active, turns.waiting) == (0, 0)
+
+        # Without a limit turns never wait
+        unlimited = TurnScheduler()
+        for _ in range(5):
+            await unlimited.acquire(SessionPriority.batch)
+        assert unlimited.active == 5
+
+    asyncio.run(scenario())
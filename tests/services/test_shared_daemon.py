@@ -0,0 +1,249 @@
+"""Tests for the shared user-level daemon supervisor.
+
+COVERAGE:
+- User-level components start, stop and report failures
+- Each attached project gets its own event server on its own port
+- Attaching or detaching a project starts or stops only its components
+- Namespaces get their own state directory
+- serve_project routes a project's startup through a running daemon
+"""
+
+import os
+from concurrent.futures import ThreadPoolExecutor
+
+import pytest
+
+from claude_mpm.services.server_discovery import ServerDiscoveryRegistry
+from claude_mpm.services.shared_daemon import (
+    EVENT_SERVER_PORT,
+    SHARED_DAEMON_ENV,
+    SharedDaemon,
+    project_namespace,
+)
+
+
+class FakeComponent:
+    """Stand-in for ProjectEventServer / WorkerProcess / ServeDaemon."""
+
+    registry: dict = {}
+
+    def __init__(self, name, port=None, fail=False):
+        self.name = name
+        self.port = port
+        self.fail = fail
+
+    def status(self):
+        running = self.registry.get(self.name, False)
+        return {
+            "running": running,
+            "port": self.port,
+            "pid": os.getpid() if running else None,
+        }
+
+    def start(self):
+        if self.fail:
+            return False
+        self.registry[self.name] = True
+        return True
+
+    def stop(self):
+        self.registry[self.name] = False
+        return True
+
+
+def _event_server(host, root, entry):
+    return FakeComponent(f"event_server:{entry['namespace']}", entry.get("port"))
+
+
+@pytest.fixture
+def daemon(tmp_path, monkeypatch):
+    FakeComponent.registry = {}
+    monkeypatch.setattr(
+        ServerDiscoveryRegistry, "_port_free", staticmethod(lambda *_a: True)
+    )
+    monkeypatch.delenv(SHARED_DAEMON_ENV, raising=False)
+    return SharedDaemon(
+        state_dir=tmp_path / "daemon",
+        components={
+            "scheduler": lambda host: FakeComponent("scheduler"),
+            "commander": lambda host: FakeComponent("commander", 7777),
+        },
+        project_components={"event_server": _event_server},
+        discovery=ServerDiscoveryRegistry(tmp_path / "servers.json"),
+    )
+
+
+def _projects(tmp_path, *names):
+    paths = [tmp_path / name for name in names]
+    for path in paths:
+        path.mkdir()
+    return paths
+
+
+def _running(daemon, project):
+    namespace = project_namespace(project)
+    return FakeComponent.registry.get(f"event_server:{namespace}", False)
+
+
+class TestLifecycle:
+    def test_start_and_stop_all_components(self, daemon):
+        assert daemon.start() == {
+            "event_server": True,
+            "scheduler": True,
+            "commander": True,
+        }
+        assert daemon.is_running() is True
+        assert daemon.status()["running"] is True
+
+        assert daemon.stop() == {
+            "commander": True,
+            "scheduler": True,
+            "event_server": True,
+        }
+        assert daemon.is_running() is False
+        assert daemon.status()["running"] is False
+
+    def test_start_subset(self, daemon):
+        assert daemon.start(only=["scheduler"]) == {"scheduler": True}
+        status = daemon.status()
+        assert status["components"]["commander"]["running"] is False
+
+    def test_start_reports_failures(self, daemon):
+        daemon.components["commander"] = lambda host: FakeComponent(
+            "commander", 7777, fail=True
+        )
+        assert daemon.start()["commander"] is False
+
+    def test_component_names_start_with_project_components(self, daemon):
+        assert daemon.component_names() == ["event_server", "scheduler", "commander"]
+
+
+class TestProjects:
+    def test_namespace_is_stable_and_unique(self, tmp_path):
+        a = tmp_path / "one" / "app"
+        b = tmp_path / "two" / "app"
+        a.mkdir(parents=True)
+        b.mkdir(parents=True)
+
+        assert project_namespace(a) == project_namespace(a)
+        assert project_namespace(a) != project_namespace(b)
+        assert project_namespace(a).startswith("app-")
+
+    def test_each_project_gets_its_own_event_server(self, daemon, tmp_path):
+        a, b = _projects(tmp_path, "a", "b")
+        daemon.attach(a)
+        daemon.attach(b)
+
+        daemon.start()
+
+        projects = daemon.list_projects()
+        ports = {projects[str(p.resolve())]["port"] for p in (a, b)}
+        assert ports == {EVENT_SERVER_PORT, EVENT_SERVER_PORT + 1}
+        assert _running(daemon, a) and _running(daemon, b)
+        instances = daemon.status()["components"]["event_server"]["instances"]
+        assert set(instances) == {project_namespace(a), project_namespace(b)}
+
+    def test_attach_while_running_starts_the_project(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+        daemon.start()
+
+        entry = daemon.attach(a)
+
+        assert entry["started"] == {"event_server": True}
+        assert entry["port"] == EVENT_SERVER_PORT
+        assert _running(daemon, a)
+
+    def test_attach_while_stopped_starts_nothing(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+
+        entry = daemon.attach(a)
+
+        assert "started" not in entry
+        assert not _running(daemon, a)
+
+    def test_attach_creates_the_namespace_dir(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+
+        entry = daemon.attach(a)
+
+        assert daemon.namespace_dir(entry["namespace"]).is_dir()
+
+    def test_detach_stops_only_that_project(self, daemon, tmp_path):
+        a, b = _projects(tmp_path, "a", "b")
+        daemon.start()
+        daemon.attach(a)
+        daemon.attach(b)
+
+        assert daemon.detach(a) is True
+        assert daemon.detach(a) is False
+
+        assert not _running(daemon, a)
+        assert _running(daemon, b)
+        assert set(daemon.list_projects()) == {str(b.resolve())}
+
+    def test_project_keeps_its_port_across_restarts(self, daemon, tmp_path):
+        a, b = _projects(tmp_path, "a", "b")
+        daemon.start()
+        daemon.attach(a)
+        daemon.attach(b)
+        before = {k: v["port"] for k, v in daemon.list_projects().items()}
+
+        daemon.stop()
+        daemon.start()
+
+        after = {k: v["port"] for k, v in daemon.list_projects().items()}
+        assert after == before
+
+    def test_stop_keeps_attachments(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+        daemon.start()
+        daemon.attach(a)
+
+        daemon.stop()
+
+        assert not _running(daemon, a)
+        assert str(a.resolve()) in daemon.list_projects()
+
+    def test_concurrent_attaches_are_all_kept(self, daemon, tmp_path):
+        projects = _projects(tmp_path, *(f"p{i}" for i in range(8)))
+
+        with ThreadPoolExecutor(max_workers=8) as pool:
+            list(pool.map(daemon.attach, projects))
+
+        assert set(daemon.list_projects()) == {str(p.resolve()) for p in projects}
+        assert not list(daemon.state_dir.glob("*.tmp"))
+
+
+class TestServeProject:
+    def test_serves_when_running(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+        daemon.start()
+
+        entry = daemon.serve_project(a)
+
+        assert entry["port"] == EVENT_SERVER_PORT
+        assert entry["namespace"] == project_namespace(a)
+        assert _running(daemon, a)
+
+    def test_none_when_not_running(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+
+        assert daemon.serve_project(a) is None
+        assert not _running(daemon, a)
+
+    def test_none_inside_daemon_processes(self, daemon, tmp_path, monkeypatch):
+        (a,) = _projects(tmp_path, "a")
+        daemon.start()
+        monkeypatch.setenv(SHARED_DAEMON_ENV, "1")
+
+        assert daemon.serve_project(a) is None
+        assert str(a.resolve()) not in daemon.list_projects()
+
+    def test_none_when_the_event_server_fails(self, daemon, tmp_path):
+        (a,) = _projects(tmp_path, "a")
+        daemon.start()
+        daemon.project_components["event_server"] = lambda host, root, entry: (
+            FakeComponent("event_server:failing", fail=True)
+        )
+
+        assert daemon.serve_project(a) is None
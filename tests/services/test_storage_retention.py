@@ -0,0 +1,134 @@
+"""
+Tests for storage retention and the storage command.
+
+COVERAGE:
+- Status reports usage and what the policy would prune
+- Pruning by age and by total size, sparing recently written files
+- Artifacts are pruned through the artifact store
+- Policy loading from configuration
+"""
+
+import argparse
+import os
+import time
+
+import pytest
+
+from claude_mpm.cli.commands.storage import StorageCommand
+from claude_mpm.services.artifact_store import ArtifactStore, RetentionPolicy
+from claude_mpm.services.storage_retention import (
+    CategoryPolicy,
+    StorageManager,
+    StoragePolicy,
+)
+
+
+def _write(path, size=1024, age_days=0.0):
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_bytes(b"x" * size)
+    stamp = time.time() - age_days * 86400
+    os.utime(path, (stamp, stamp))
+    return path
+
+
+@pytest.fixture
+def manager(tmp_path):
+    policy = StoragePolicy(
+        categories={
+            "transcripts": CategoryPolicy(30, None),
+            "events": CategoryPolicy(None, 1),
+            "logs": CategoryPolicy(None, None),
+            "caches": CategoryPolicy(7, None),
+        },
+        artifacts=RetentionPolicy(10, None, None),
+    )
+    return StorageManager(tmp_path / "home", tmp_path / "proj", policy)
+
+
+def test_status_reports_usage_and_prunable(manager):
+    home = manager.home_dir
+    _write(home / "sessions" / "old.json", age_days=40)
+    _write(home / "sessions" / "new.json", age_days=1)
+    _write(manager.project_dir / ".claude-mpm" / "responses" / "r.json", age_days=2)
+
+    usage = {u.name: u for u in manager.status()}
+
+    assert usage["transcripts"].files == 3
+    assert usage["transcripts"].size == 3 * 1024
+    assert usage["transcripts"].prunable_files == 1
+    assert usage["transcripts"].oldest is not None
+    assert usage["caches"].files == 0
+
+
+def test_prune_by_age_and_size(manager):
+    home = manager.home_dir
+    old = _write(home / "sessions" / "a" / "old.json", age_days=40)
+    kept = _write(home / "sessions" / "new.json", age_days=1)
+    # 1.5MB of events over a 1MB limit: the oldest goes, the live file stays
+    oldest = _write(home / "event_spool" / "1.jsonl", size=512 * 1024, age_days=3)
+    middle = _write(home / "event_spool" / "2.jsonl", size=512 * 1024, age_days=2)
+    live = _write(home / "event_spool" / "3.jsonl", size=512 * 1024)
+
+    dry = {r.name: r for r in manager.prune(dry_run=True)}
+    assert dry["transcripts"].files == 1 and old.exists()
+
+    results = {r.name: r for r in manager.prune()}
+
+    assert not old.exists() and not old.parent.exists()
+    assert kept.exists()
+    assert results["transcripts"].files == 1
+    assert not oldest.exists()
+    assert middle.exists() and live.exists()
+    assert results["events"].size == 512 * 1024
+
+
+def test_prune_artifacts_through_store(manager, tmp_path):
+    store = ArtifactStore(manager.project_dir, RetentionPolicy(None, None, None))
+    source = _write(tmp_path / "report.md")
+    artifact = store.add(source, session_id="old")
+    manifest = store.root / "old" / "manifest.json"
+    manifest.write_text(
+        manifest.read_text().replace(artifact.created_at, "2000-01-01T00:00:00+00:00")
+    )
+
+    results = {r.name: r for r in manager.prune(categories=["artifacts"])}
+
+    assert results["artifacts"].sessions == ["old"]
+    assert store.artifacts() == []
+    with pytest.raises(ValueError):
+        manager.prune(categories=["bogus"])
+
+
+def test_policy_load_from_config():
+    assert StoragePolicy.load({}).auto_prune is False
+
+    config = {
+        "storage": {
+            "auto_prune": False,
+            "retention": {"logs": {"max_age_days": 3, "max_size_mb": None}},
+        },
+        "artifacts": {"retention": {"max_sessions": 5}},
+    }
+
+    policy = StoragePolicy.load(config)
+
+    assert policy.auto_prune is False
+    assert policy.categories["logs"] == CategoryPolicy(3, None)
+    assert policy.categories["caches"] == CategoryPolicy(30, 1024)
+    assert policy.artifacts.max_sessions == 5
+
+
+def test_storage_command(manager):
+    _write(manager.home_dir / "tree-cache" / "c.json", age_days=10)
+    command = StorageCommand(manager)
+
+    status = command.run(argparse.Namespace(storage_command="status", json=False))
+    assert status.success
+    assert "caches" in status.message
+
+    result = command.run(
+        argparse.Namespace(storage_command="prune", category=["caches"], dry_run=False)
+    )
+    assert result.success
+    assert "Removed 1 file(s)" in result.message
+    assert command.validate_args(argparse.Namespace(storage_command=None))
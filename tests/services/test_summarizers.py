@@ -0,0 +1,139 @@
+"""
+Tests for the pluggable summarizer backends.
+
+COVERAGE:
+- Each use case gets its configured backend and settings, the project's
+  configuration applying over the user's, and other use cases the default
+- A failing backend falls back to the extractive one unless fallback is off
+- The claude backend sends the text on stdin with the configured model, and
+  summarize --backend and resume log compaction go through the backends
+"""
+
+import os
+import stat
+from types import SimpleNamespace
+
+import pytest
+
+from claude_mpm.cli.commands.summarize import summarize_command
+from claude_mpm.services.infrastructure.resume_log_generator import (
+    ResumeLogGenerator,
+)
+from claude_mpm.services.summarizers import (
+    ClaudeSummarizer,
+    ExtractiveSummarizer,
+    LocalSummarizer,
+    SummarizerError,
+    summarizer_for,
+)
+
+TEXT = (
+    "The release pipeline was rewritten to publish wheels from CI only. "
+    "Manual uploads are no longer allowed.\n\n"
+    "However, the signing key still lives on one laptop and must move to "
+    "the secrets manager before the next release.\n\n"
+    "Next, the team will remove the legacy upload script entirely."
+)
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    user = tmp_path / "home" / ".claude-mpm" / "config"
+    user.mkdir(parents=True)
+    (user / "configuration.yaml").write_text(
+        "summarization:\n"
+        "  default: local\n"
+        "  local: {model: qwen2.5:3b, host: 'http://127.0.0.1:9'}\n"
+        "  use_cases:\n"
+        "    compaction: claude\n"
+    )
+    project = tmp_path / "project"
+    (project / ".claude-mpm").mkdir(parents=True)
+    (project / ".claude-mpm" / "configuration.yaml").write_text(
+        "summarization:\n"
+        "  claude: {model: sonnet}\n"
+        "  use_cases:\n"
+        "    document: {backend: claude, model: haiku, timeout: 30}\n"
+    )
+    return project
+
+
+def _fake_claude(tmp_path, monkeypatch, script):
+    bin_dir = tmp_path / "bin"
+    bin_dir.mkdir(exist_ok=True)
+    claude = bin_dir / "claude"
+    claude.write_text(f"#!/bin/sh\n{script}\n")
+    claude.chmod(claude.stat().st_mode | stat.S_IEXEC)
+    monkeypatch.setenv("PATH", f"{bin_dir}{os.pathsep}{os.environ['PATH']}")
+
+
+def test_use_cases_resolve_backends_and_settings(project):
+    document = summarizer_for("document", project).backend
+    assert isinstance(document, ClaudeSummarizer)
+    assert (document.model, document.timeout) == ("haiku", 30.0)
+
+    compaction = summarizer_for("compaction", project).backend
+    assert isinstance(compaction, ClaudeSummarizer)
+    assert compaction.model == "sonnet"
+
+    digest = summarizer_for("digest", project)
+    assert isinstance(digest.backend, LocalSummarizer)
+    assert digest.backend.model == "qwen2.5:3b"
+    assert isinstance(digest.fallback, ExtractiveSummarizer)
+
+    override = summarizer_for("document", project, backend="extractive")
+    assert isinstance(override.backend, ExtractiveSummarizer)
+    assert override.fallback is None
+    with pytest.raises(ValueError, match="Unknown summarizer backend"):
+        summarizer_for("document", project, backend="gpt")
+
+
+def test_failing_backend_falls_back(project):
+    # Nothing listens on port 9, so the local backend fails
+    summary = summarizer_for("digest", project).summarize(TEXT, 40)
+    assert summary.backend == "extractive"
+    assert summary.fell_back
+    assert summary.text.startswith("The release pipeline")
+
+    config = project / ".claude-mpm" / "configuration.yaml"
+    config.write_text("summarization:\n  fallback: false\n")
+    with pytest.raises(SummarizerError, match="Ollama"):
+        summarizer_for("digest", project).summarize(TEXT, 40)
+
+
+def test_claude_backend_used_by_summarize_and_compaction(
+    project, tmp_path, monkeypatch, capsys
+):
+    calls = tmp_path / "calls"
+    _fake_claude(
+        tmp_path,
+        monkeypatch,
+        f'echo "$@" >> {calls}; cat > /dev/null; echo "Wheels ship from CI."',
+    )
+    monkeypatch.chdir(project)
+    document = project / "notes.md"
+    document.write_text(TEXT)
+
+    args = SimpleNamespace(
+        file_path=str(document),
+        style="bullet_points",
+        max_words=20,
+        output="text",
+        lines=None,
+        backend=None,
+    )
+    assert summarize_command(args) == 0
+    assert capsys.readouterr().out.strip() == "Wheels ship from CI."
+    assert "--model haiku" in calls.read_text()
+    assert "markdown bullet list" in calls.read_text()
+
+    generator = ResumeLogGenerator(storage_dir=tmp_path / "resume-logs")
+    resume_log = generator.generate_from_session_state(
+        "s1", {"mission_summary": " ".join(["word"] * 800)}
+    )
+    assert resume_log.mission_summary == "Wheels ship from CI."
+    assert "--model sonnet" in calls.read_text().splitlines()[-1]
+
+    short = generator.generate_from_session_state("s2", {"mission_summary": TEXT})
+    assert short.mission_summary == TEXT
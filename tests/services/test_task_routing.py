@@ -0,0 +1,166 @@
+"""
+Tests for task-type routing of delegations.
+
+COVERAGE:
+- Routes parse from agents.routing with built-in keywords, bad routes are
+  skipped, and an A/B split picks deterministically per delegation and
+  spreads traffic between its variants
+- The PreToolUse hook rewrites subagent_type for a matching delegation,
+  records the decision, and leaves delegations to undeployed agents alone
+- The report reads each routed delegation's outcome from the session
+  transcript, and the better variant needs enough measured delegations
+"""
+
+import json
+from collections import Counter
+from datetime import UTC, datetime
+
+from claude_mpm.hooks.task_routing_hook import build_routing_response
+from claude_mpm.services.session_analysis.transcript_parser import _encode_cwd
+from claude_mpm.services.task_routing import (
+    MIN_MEASURED,
+    RoutingDecision,
+    TaskRouter,
+    VariantStats,
+    better_variant,
+    load_routes,
+)
+
+
+def _configure(project, routing):
+    (project / ".claude-mpm").mkdir(parents=True, exist_ok=True)
+    (project / ".claude-mpm" / "configuration.yaml").write_text(
+        json.dumps({"agents": {"routing": routing}})
+    )
+
+
+def _deploy(project, *agents):
+    (project / ".claude" / "agents").mkdir(parents=True, exist_ok=True)
+    for agent in agents:
+        (project / ".claude" / "agents" / f"{agent}.md").write_text("---\n---\n")
+
+
+def test_routes_and_weighted_split(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    project = tmp_path / "project"
+    _configure(
+        project,
+        {
+            "refactor": {"agents": {"engineer": 3, "engineer-v2": 1}},
+            "docs": {"agent": "documentation", "keywords": ["readme"]},
+            "broken": {"agents": {"engineer": -1}},
+        },
+    )
+    routes = {route.category: route for route in load_routes(project)}
+
+    assert set(routes) == {"refactor", "docs"}
+    assert routes["refactor"].is_split
+    assert "refactor" in routes["refactor"].keywords
+    assert routes["docs"].matches("Update the README", "engineer")
+    assert not routes["docs"].matches("Fix the login page", "engineer")
+
+    split = routes["refactor"]
+    assert split.pick("toolu_1") == split.pick("toolu_1")
+    picks = Counter(split.pick(f"toolu_{i}") for i in range(400))
+    assert set(picks) == {"engineer", "engineer-v2"}
+    assert picks["engineer"] > picks["engineer-v2"]
+
+
+def test_hook_rewrites_and_records(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    project = tmp_path / "project"
+    _configure(project, {"documentation": "documentation", "test-writing": "qa"})
+    _deploy(project, "engineer", "documentation")
+
+    def event(prompt, tool_use_id):
+        return {
+            "tool_name": "Agent",
+            "tool_use_id": tool_use_id,
+            "session_id": "s1",
+            "cwd": str(project),
+            "tool_input": {
+                "subagent_type": "engineer",
+                "description": "Delegation",
+                "prompt": prompt,
+            },
+        }
+
+    output = build_routing_response(event("Update the documentation", "t1"))
+    hook = output["hookSpecificOutput"]
+    assert hook["updatedInput"]["subagent_type"] == "documentation"
+    assert hook["updatedInput"]["prompt"] == "Update the documentation"
+    assert "'engineer'" in hook["additionalContext"]
+
+    # qa is not deployed, so the PM's choice stands and nothing is recorded
+    assert build_routing_response(event("Add tests for the parser", "t2")) == {}
+    assert build_routing_response(event("Fix the login page", "t3")) == {}
+
+    decisions = TaskRouter(project).decisions()
+    assert [(d.category, d.agent, d.tool_use_id) for d in decisions] == [
+        ("documentation", "documentation", "t1")
+    ]
+
+
+def test_report_reads_outcomes(tmp_path, monkeypatch):
+    home = tmp_path / "home"
+    monkeypatch.setenv("HOME", str(home))
+    project = tmp_path / "project"
+    project.mkdir()
+    router = TaskRouter(project, routes=[])
+    now = datetime.now(UTC).isoformat()
+    for tool_use_id, agent in (("t1", "engineer"), ("t2", "engineer-v2")):
+        router.record(
+            RoutingDecision(
+                category="refactor",
+                requested="engineer",
+                agent=agent,
+                split=True,
+                tool_use_id=tool_use_id,
+                session_id="s1",
+                at=now,
+            )
+        )
+
+    def result(tool_use_id, is_error, tokens):
+        return {
+            "type": "user",
+            "message": {
+                "content": [
+                    {
+                        "type": "tool_result",
+                        "tool_use_id": tool_use_id,
+                        "is_error": is_error,
+                    }
+                ]
+            },
+            "toolUseResult": {"totalDurationMs": 30000, "totalTokens": tokens},
+        }
+
+    def prompt(text):
+        return {"type": "user", "message": {"content": text}}
+
+    transcript = home / ".claude" / "projects" / _encode_cwd(str(project))
+    transcript.mkdir(parents=True)
+    (transcript / "s1.jsonl").write_text(
+        "\n".join(
+            json.dumps(line)
+            for line in (
+                result("t1", False, 9000),
+                prompt("That didn't work, try again"),
+                result("t2", False, 6000),
+                prompt("Thanks, now commit it"),
+            )
+        )
+    )
+
+    stats = router.report()["refactor"]
+    assert stats["engineer"].corrections == 1
+    assert stats["engineer"].success_rate == 0.0
+    assert stats["engineer-v2"].success_rate == 1.0
+    assert stats["engineer-v2"].avg_duration_s == 30.0
+    assert stats["engineer-v2"].avg_tokens == 6000
+    assert better_variant(stats) is None  # too few measured delegations
+
+    measured = {name: VariantStats(name, measured=MIN_MEASURED) for name in "ab"}
+    measured["a"].errors = 1
+    assert better_variant(measured) == "b"
@@ -0,0 +1,210 @@
+"""Tests for the STRIDE threat model drafted from the code.
+
+COVERAGE:
+- Dangerous calls are found per language; request input and authentication
+  near endpoints are recognised; comments are ignored
+- The document lists entry points, findings and threats by STRIDE category
+- Updates rescan only changed files, keep edited Status and Mitigation
+  lines, move threats no longer found to Resolved, and leave an unchanged
+  document alone; --check reports an out-of-date document
+"""
+
+import pytest
+
+from claude_mpm.services.threat_model import (
+    CODE_INJECTION,
+    COMMAND_INJECTION,
+    ELEVATION_OF_PRIVILEGE,
+    INFORMATION_DISCLOSURE,
+    REPUDIATION,
+    SPOOFING,
+    SQL_INJECTION,
+    SSRF,
+    TAMPERING,
+    XSS,
+    ThreatModeler,
+    read_edits,
+    scan_source,
+)
+
+APP = """\
+import logging
+import subprocess
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+@app.route("/orders", methods=["POST"])
+def create_order():
+    item = request.json["item"]
+    db.execute(f"INSERT INTO orders VALUES ('{item}')")
+    return {}
+
+
+@app.get("/admin/jobs")
+def jobs():
+    return subprocess.run("ps " + request.args["q"], shell=True)
+
+
+@app.get("/me")
+@login_required
+def me(body: Account):
+    return {}
+"""
+
+MODELS = """\
+from pydantic import BaseModel
+
+
+class Account(BaseModel):
+    email: str
+    password_hash: str
+"""
+
+
+@pytest.fixture
+def project(tmp_path, monkeypatch):
+    monkeypatch.setenv("HOME", str(tmp_path / "home"))
+    root = tmp_path / "shop"
+    (root / "api").mkdir(parents=True)
+    (root / "tests").mkdir()
+    (root / "pyproject.toml").write_text(
+        '[project]\nname = "shop"\n[project.scripts]\nshop = "shop.cli:main"\n'
+    )
+    (root / "api" / "app.py").write_text(APP)
+    (root / "api" / "models.py").write_text(MODELS)
+    (root / "tests" / "test_app.py").write_text('eval("1")\n')
+    return root
+
+
+def test_scan_source():
+    scan = scan_source(
+        "api/views.py",
+        "import yaml\n"
+        "# eval(text)\n"
+        "data = eval(request.args['q'])\n"
+        "cursor.execute('SELECT * FROM t WHERE id = %s' % user_id)\n"
+        "cursor.execute('SELECT * FROM t WHERE id = %s', (user_id,))\n"
+        "requests.get(url, timeout=5)\n"
+        "requests.get('https://example.com')\n"
+        "html = Markup(body)\n",
+    )
+    assert [(f.rule, f.line) for f in scan.findings] == [
+        (SQL_INJECTION, 4),
+        (CODE_INJECTION, 3),
+        (SSRF, 6),
+        (XSS, 8),
+    ]
+    assert scan.reads_input
+    assert not scan.auth_lines
+
+    scan = scan_source(
+        "web/server.ts",
+        "app.use(passport.authenticate('jwt'));\n"
+        "app.post('/run', (req, res) => {\n"
+        "  exec(`convert ${req.body.file}`);\n"
+        "  pattern.exec(text);\n"
+        "});\n",
+    )
+    assert [(f.rule, f.line) for f in scan.findings] == [(COMMAND_INJECTION, 3)]
+    assert scan.reads_input
+    assert scan.authenticates(2)
+
+
+def test_draft_threat_model(project):
+    update = ThreatModeler(project, use_git=False).update()
+    assert update.changed
+    assert update.rescanned == 3
+    assert update.new == [t.id for t in update.model.threats]
+    by_title = {t.title: t for t in update.model.threats}
+    assert by_title["Endpoints of shop without authentication"].evidence == [
+        "`GET /admin/jobs` (api/app.py:15)",
+        "`POST /orders` (api/app.py:8)",
+    ]
+    assert [(t.category, t.severity) for t in update.model.threats] == [
+        (SPOOFING, "high"),
+        (TAMPERING, "high"),
+        (TAMPERING, "medium"),
+        (REPUDIATION, "medium"),
+        (INFORMATION_DISCLOSURE, "medium"),
+        ("denial-of-service", "medium"),
+        (ELEVATION_OF_PRIVILEGE, "high"),
+        (ELEVATION_OF_PRIVILEGE, "high"),
+    ]
+    # logging is imported but nothing is logged
+    assert "Changes through shop are not logged" in by_title
+    assert "Sensitive fields of Account reach endpoints" in by_title
+
+    document = (project / "docs" / "threat-model.md").read_text()
+    assert document.startswith("# Threat Model: shop\n")
+    assert "| `shop` | cli | pyproject.toml (shop.cli:main) | local user |" in document
+    assert "| `GET /me` | http | api/app.py:20 | found |" in document
+    assert "tests/test_app.py" not in document
+    assert document.index("### Spoofing") < document.index("### Tampering")
+
+
+def test_update_keeps_edits(project):
+    modeler = ThreatModeler(project, use_git=False)
+    first = modeler.update()
+    path = project / "docs" / "threat-model.md"
+    sql = next(t for t in first.model.threats if t.title.startswith("SQL"))
+    shell = next(t for t in first.model.threats if t.title.startswith("Shell"))
+    document = path.read_text()
+    section = document.index(f"#### {shell.id}")
+    document = document[:section] + document[section:].replace(
+        "- **Status:** open\n- **Mitigation:** Run the program",
+        "- **Status:** accepted\n- **Mitigation:** Only ops can reach it; run it",
+        1,
+    )
+    path.write_text(document)
+    assert read_edits(document)[shell.id] == {
+        "status": "accepted",
+        "mitigation": "Only ops can reach it; run it with an argument list, "
+        "without a shell, and validate the arguments",
+    }
+
+    # Edits alone do not make the document out of date
+    update = modeler.update(check=True)
+    assert not update.changed
+    assert update.rescanned == 0
+
+    app = project / "api" / "app.py"
+    app.write_text(app.read_text().replace("    db.execute(", "    # db.execute("))
+    assert modeler.update(check=True).changed
+    assert path.read_text() == document
+
+    update = modeler.update()
+    assert update.rescanned == 1
+    assert update.resolved == [sql.id]
+    assert update.new == []
+    document = path.read_text()
+    assert "- **Status:** accepted\n- **Mitigation:** Only ops can reach it" in (
+        document
+    )
+    assert f"| {sql.id} | SQL built from strings in api/app.py |" in document
+    assert not modeler.update().changed
+
+    # A threat that comes back is new again
+    app.write_text(app.read_text().replace("    # db.execute(", "    db.execute("))
+    assert modeler.update().new == [sql.id]
+    assert f"| {sql.id} |" not in path.read_text()
+
+
+def test_threat_model_command(project, monkeypatch, capsys):
+    from claude_mpm.cli.commands.threat_model import manage_threat_model
+    from claude_mpm.cli.parsers.base_parser import create_parser
+
+    monkeypatch.chdir(project)
+    parse = create_parser().parse_args
+    args = parse(["analyze", "threat-model", "--check"])
+    assert args.analyze_command == "threat-model"
+    assert manage_threat_model(args) == 1
+    assert "out of date" in capsys.readouterr().out
+    assert not (project / "docs").exists()
+
+    assert manage_threat_model(parse(["analyze", "threat-model", "-o", "TM.md"])) == 0
+    out = capsys.readouterr().out
+    assert out.startswith("Updated TM.md: 8 threats (4 high, 4 medium); 8 new")
+    assert manage_threat_model(parse(["analyze", "threat-model", "-o", "TM.md"])) == 0
+    assert capsys.readouterr().out.startswith("Unchanged TM.md")
@@ -0,0 +1,319 @@
+"""
+Tests for the broker-backed work queue.
+
+COVERAGE:
+- Both brokers hand each task to exactly one worker, oldest first
+- Tasks held by a worker that stops heartbeating are re-queued, then failed
+  after max_attempts
+- A worker declared dead cannot overwrite the result of the re-run
+- QueueWorker registers, runs tasks through its runner and unregisters
+- Retry policies come from config per agent and task type; failures are
+  classified, transient ones retried after a backoff, and every attempt is
+  in the task's history
+- Tasks wait for the tasks they depend on and fail when one of them fails
+
+The Redis broker runs against a small in-memory stand-in that implements the
+commands it uses.
+"""
+
+import argparse
+import threading
+
+import pytest
+
+from claude_mpm.cli.commands.work_queue import WorkQueueCommand
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.retry_policy import RetryPolicies, classify_failure
+from claude_mpm.services.work_queue import (
+    FAILED,
+    PENDING,
+    RUNNING,
+    SUCCEEDED,
+    QueueTask,
+    QueueWorker,
+    RedisBroker,
+    SqliteBroker,
+    WorkerInfo,
+    WorkQueueConfig,
+    create_broker,
+)
+
+
+class FakeRedis:
+    def __init__(self):
+        self.strings, self.lists, self.hashes, self.zsets = {}, {}, {}, {}
+        self.lock = threading.Lock()
+
+    def set(self, key, value):
+        self.strings[key] = value
+
+    def get(self, key):
+        return self.strings.get(key)
+
+    def rpush(self, key, value):
+        self.lists.setdefault(key, []).append(value)
+
+    def lpush(self, key, value):
+        self.lists.setdefault(key, []).insert(0, value)
+
+    def lmove(self, src, dst, src_side, dst_side):
+        with self.lock:
+            items = self.lists.get(src, [])
+            if not items:
+                return None
+            value = items.pop() if src_side == "RIGHT" else items.pop(0)
+            target = self.lists.setdefault(dst, [])
+            target.append(value) if dst_side == "RIGHT" else target.insert(0, value)
+            return value
+
+    def lrem(self, key, count, value):
+        items = self.lists.get(key, [])
+        removed = items.count(value)
+        self.lists[key] = [v for v in items if v != value]
+        return removed
+
+    def lrange(self, key, start, end):
+        return list(self.lists.get(key, []))
+
+    def hset(self, key, field, value):
+        self.hashes.setdefault(key, {})[field] = value
+
+    def hget(self, key, field):
+        return self.hashes.get(key, {}).get(field)
+
+    def hgetall(self, key):
+        return dict(self.hashes.get(key, {}))
+
+    def hdel(self, key, field):
+        self.hashes.get(key, {}).pop(field, None)
+
+    def zadd(self, key, mapping):
+        self.zsets.setdefault(key, {}).update(mapping)
+
+    def zrangebyscore(self, key, low, high):
+        return [m for m, s in self.zsets.get(key, {}).items() if low <= s <= high]
+
+    def zrem(self, key, member):
+        return int(self.zsets.get(key, {}).pop(member, None) is not None)
+
+    def close(self):
+        pass
+
+
+@pytest.fixture(params=["sqlite", "redis"])
+def broker(request, tmp_path):
+    if request.param == "sqlite":
+        broker = SqliteBroker(tmp_path / "queue.db")
+    else:
+        broker = RedisBroker("redis://test", client=FakeRedis())
+    yield broker
+    broker.close()
+
+
+def _register(broker, worker_id):
+    broker.register(WorkerInfo(id=worker_id, host="h", pid=1, started_at="t"))
+
+
+def test_claim_is_fifo_and_exclusive(broker):
+    first = broker.enqueue(QueueTask(prompt="one"))
+    second = broker.enqueue(QueueTask(prompt="two"))
+    _register(broker, "w1")
+    _register(broker, "w2")
+
+    assert broker.claim("w1").id == first.id
+    claimed = broker.claim("w2")
+    assert claimed.id == second.id
+    assert claimed.status == RUNNING and claimed.attempts == 1
+    assert broker.claim("w1") is None
+
+    claimed.status = SUCCEEDED
+    assert broker.complete(claimed, "w2")
+    assert broker.get(second.id).status == SUCCEEDED
+    assert [t.id for t in broker.tasks(RUNNING)] == [first.id]
+
+
+def test_dead_worker_tasks_are_requeued_then_failed(broker):
+    task = broker.enqueue(QueueTask(prompt="flaky"))
+    _register(broker, "dead")
+    broker.claim("dead")
+    assert broker.requeue_dead(timeout=60, max_attempts=2) == []
+
+    # A negative timeout makes every heartbeat count as missed
+    recovered = broker.requeue_dead(timeout=-1, max_attempts=2)
+    assert [(t.id, t.status) for t in recovered] == [(task.id, PENDING)]
+    assert broker.workers() == []
+
+    _register(broker, "dead-again")
+    assert broker.claim("dead-again").attempts == 2
+    recovered = broker.requeue_dead(timeout=-1, max_attempts=2)
+    assert recovered[0].status == FAILED
+    assert broker.claim("anyone") is None
+
+    # The first worker comes back, but the task is no longer its to complete
+    task.status = SUCCEEDED
+    assert not broker.complete(task, "dead")
+    assert broker.get(task.id).status == FAILED
+
+
+def test_worker_runs_tasks_and_unregisters(broker, tmp_path):
+    broker.enqueue(QueueTask(prompt="ok", cwd=str(tmp_path)))
+    broker.enqueue(QueueTask(prompt="bad", cwd=str(tmp_path)))
+    seen = []
+
+    def runner(task, cwd, timeout):
+        seen.append((task.prompt, cwd))
+        return AgentResult(text=f"did {task.prompt}", is_error=task.prompt == "bad")
+
+    config = WorkQueueConfig(broker="unused", heartbeat_interval=0.01)
+    worker = QueueWorker(broker, config, worker_id="w1", runner=runner)
+    assert worker.run(once=True) == 2
+
+    assert seen == [("ok", tmp_path), ("bad", tmp_path)]
+    statuses = {t.prompt: (t.status, t.result["text"]) for t in broker.tasks()}
+    assert statuses == {"ok": (SUCCEEDED, "did ok"), "bad": (FAILED, "did bad")}
+    assert broker.workers() == []
+
+
+def test_config_and_command(tmp_path):
+    config = WorkQueueConfig.load(
+        config={"work_queue": {"queue": "nightly", "max_attempts": 5}},
+        environ={"CLAUDE_MPM_QUEUE_BROKER": f"sqlite:///{tmp_path}/q.db"},
+    )
+    assert (config.queue, config.max_attempts) == ("nightly", 5)
+    broker = create_broker(config)
+    assert isinstance(broker, SqliteBroker)
+    with pytest.raises(ValueError):
+        create_broker(WorkQueueConfig(broker="nats://localhost:4222"))
+
+    command = WorkQueueCommand(broker=broker, config=config)
+    submitted = command.run(
+        argparse.Namespace(
+            work_queue_command="submit",
+            prompt="Audit",
+            cwd=None,
+            repo="https://x/r.git",
+            ref=None,
+            model=None,
+            task_max_turns=None,
+        )
+    )
+    assert submitted.success
+    status = command.run(argparse.Namespace(work_queue_command="status", json=False))
+    assert "1 pending" in status.message
+    broker.close()
+
+
+def test_retry_policies():
+    policies = RetryPolicies.from_config(
+        {
+            "backoff": 10,
+            "max_backoff": 25,
+            "retry_on": ["timeout", "bogus"],
+            "agents": {"Research-Agent": {"max_attempts": 5}},
+            "task_types": {"migration": {"max_attempts": 1}},
+        },
+        max_attempts=2,
+    )
+    assert policies.default.retry_on == ("timeout",)
+    assert [policies.default.delay(n) for n in (1, 2, 3)] == [10, 20, 25]
+    assert policies.for_task(agent="research").max_attempts == 5
+    assert policies.for_task("research", "migration").max_attempts == 1
+    assert policies.for_task("qa").max_attempts == 2
+    assert policies.default.should_retry("timeout", 1)
+    assert not policies.default.should_retry("timeout", 2)
+    assert not policies.default.should_retry("error", 1)
+
+    assert classify_failure("API Error: 429 rate limit exceeded") == "rate_limit"
+    assert classify_failure("Timed out after 3600s") == "timeout"
+    assert classify_failure("fatal: Could not resolve host: x") == "network"
+    assert classify_failure("Tests still fail") == "error"
+
+
+def test_failed_tasks_are_retried(broker, tmp_path):
+    flaky = broker.enqueue(QueueTask(prompt="flaky", cwd=str(tmp_path)))
+    risky = broker.enqueue(
+        QueueTask(prompt="slow", cwd=str(tmp_path), task_type="migration")
+    )
+    broken = broker.enqueue(QueueTask(prompt="broken", cwd=str(tmp_path)))
+    runs = []
+
+    def runner(task, cwd, timeout):
+        runs.append(task.prompt)
+        if task.prompt == "flaky" and runs.count("flaky") < 3:
+            return AgentResult(text="API Error: overloaded", is_error=True)
+        if task.prompt == "slow":
+            return AgentResult(text="Timed out after 10s", is_error=True)
+        if task.prompt == "broken":
+            return AgentResult(text="The tests still fail", is_error=True)
+        return AgentResult(text="done")
+
+    config = WorkQueueConfig(
+        broker="unused",
+        heartbeat_interval=0.01,
+        retry={"backoff": 0, "task_types": {"migration": {"max_attempts": 1}}},
+    )
+    worker = QueueWorker(broker, config, worker_id="w1", runner=runner)
+    assert worker.run(once=True) == 5
+    # A retry keeps its place at the front of the queue
+    assert runs == ["flaky", "flaky", "flaky", "slow", "broken"]
+
+    task = broker.get(flaky.id)
+    assert (task.status, task.attempts) == (SUCCEEDED, 3)
+    assert [h["failure"] for h in task.history] == ["rate_limit", "rate_limit", None]
+    assert task.history[0]["retry_in"] == 0
+    assert task.history[0]["worker"] == "w1"
+    task = broker.get(risky.id)
+    assert (task.status, task.attempts) == (FAILED, 1)
+    assert task.detail == "timeout; gave up after 1 attempt(s)"
+    task = broker.get(broken.id)
+    assert (task.status, task.history[0]["failure"]) == (FAILED, "error")
+
+    # A backoff keeps the task out of reach until it is due
+    broker.enqueue(QueueTask(prompt="slow", cwd=str(tmp_path)))
+    config.retry = {"backoff": 60}
+    worker = QueueWorker(broker, config, worker_id="w2", runner=runner)
+    assert worker.run(once=True) == 1
+    [waiting] = broker.tasks(PENDING)
+    assert waiting.not_before and "retrying in 60s" in waiting.detail
+    _register(broker, "w3")
+    assert broker.claim("w3") is None
+
+
+def test_tasks_wait_for_their_dependencies(broker):
+    build = broker.enqueue(QueueTask(prompt="build"))
+    deploy = broker.enqueue(QueueTask(prompt="deploy", depends_on=[build.id]))
+    lint = broker.enqueue(QueueTask(prompt="lint"))
+    _register(broker, "w1")
+
+    claimed = broker.claim("w1")
+    assert claimed.id == build.id
+    assert broker.claim("w1").id == lint.id
+    assert broker.claim("w1") is None
+    claimed.status = SUCCEEDED
+    broker.complete(claimed, "w1")
+    assert broker.claim("w1").id == deploy.id
+
+    doomed = broker.enqueue(QueueTask(prompt="publish", depends_on=["missing"]))
+    assert broker.claim("w1") is None
+    task = broker.get(doomed.id)
+    assert (task.status, task.attempts) == (FAILED, 0)
+    assert task.history[0]["failure"] == "dependency"
+    assert task.detail == "Dependency missing does not exist"
+
+    command = WorkQueueCommand(broker=broker, config=WorkQueueConfig(broker="x"))
+    args = argparse.Namespace(
+        work_queue_command="submit",
+        prompt="Release",
+        cwd=None,
+        repo=None,
+        ref=None,
+        model=None,
+        task_max_turns=None,
+        agent="ops",
+        task_type=None,
+        depends_on=["nope"],
+    )
+    assert command.run(args).message == "No task nope"
+    args.depends_on = [deploy.id]
+    submitted = command.run(args)
+    assert broker.get(submitted.data["id"]).depends_on == [deploy.id]
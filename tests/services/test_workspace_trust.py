@@ -0,0 +1,156 @@
+"""
+Tests for the workspace trust model.
+
+COVERAGE:
+- Trust decisions are keyed by repository root and inherited by subdirectories
+- The first run prompts once interactively; headless runs never prompt and
+  report the restriction on stderr
+- Configured trusted paths and the disabled switch, read from the user's
+  config only, never from the repository's
+- Restricted sessions deny shell and network tools in the PreToolUse dispatcher
+- The trust command records and forgets decisions
+"""
+
+import argparse
+
+import pytest
+
+from claude_mpm.cli.commands.trust import TrustCommand
+from claude_mpm.hooks import pretooluse_dispatcher
+from claude_mpm.services import workspace_trust
+from claude_mpm.services.workspace_trust import (
+    TRUSTED,
+    UNKNOWN,
+    UNTRUSTED,
+    TrustConfig,
+    WorkspaceTrustStore,
+    resolve_workspace_trust,
+)
+
+
+@pytest.fixture
+def repo(tmp_path):
+    root = tmp_path / "repo"
+    (root / ".git").mkdir(parents=True)
+    (root / "src" / "pkg").mkdir(parents=True)
+    return root
+
+
+@pytest.fixture
+def store(tmp_path):
+    return WorkspaceTrustStore(tmp_path / "trusted.json", TrustConfig())
+
+
+@pytest.fixture(autouse=True)
+def untrusted_env(monkeypatch):
+    monkeypatch.delenv(workspace_trust.TRUST_ENV_VAR, raising=False)
+    monkeypatch.delenv(workspace_trust.RESTRICTED_ENV_VAR, raising=False)
+
+
+def test_decisions_apply_to_the_whole_repository(repo, store):
+    assert store.status(repo) == UNKNOWN
+
+    assert store.trust(repo / "src") == repo.resolve()
+
+    assert store.status(repo / "src" / "pkg") == TRUSTED
+    store.distrust(repo)
+    assert store.status(repo) == UNTRUSTED
+    assert len(store.entries()) == 1
+    assert store.forget(repo / "src")
+    assert store.status(repo) == UNKNOWN
+
+
+def test_most_specific_decision_wins(tmp_path, repo, store):
+    store.trust(tmp_path)
+    store.distrust(repo)
+
+    assert store.status(repo) == UNTRUSTED
+    assert store.status(tmp_path / "other") == TRUSTED
+
+
+def test_prompts_once_and_remembers(repo, store):
+    answers = iter(["y"])
+    prompts = []
+
+    def ask(prompt):
+        prompts.append(prompt)
+        return next(answers)
+
+    assert resolve_workspace_trust(repo, store, interactive=True, ask=ask)
+    assert resolve_workspace_trust(repo, store, interactive=True, ask=ask)
+    assert len(prompts) == 1
+    assert str(repo.resolve()) in prompts[0]
+
+
+def test_declining_or_no_terminal_runs_restricted(repo, store, capsys):
+    assert not resolve_workspace_trust(repo, store, interactive=False)
+    assert store.status(repo) == UNKNOWN
+    notice = capsys.readouterr()
+    assert notice.out == ""
+    assert "restricted" in notice.err
+    assert "claude-mpm trust add" in notice.err
+    assert workspace_trust.TRUST_ENV_VAR in notice.err
+
+    assert not resolve_workspace_trust(repo, store, interactive=True, ask=lambda _: "")
+    assert store.status(repo) == UNTRUSTED
+
+
+def test_configured_paths_env_and_disabled(tmp_path, repo, monkeypatch):
+    path = tmp_path / "trusted.json"
+    config = TrustConfig(trusted_paths=[str(tmp_path)])
+    assert WorkspaceTrustStore(path, config).status(repo) == TRUSTED
+    disabled = WorkspaceTrustStore(path, TrustConfig(enabled=False))
+    assert disabled.status(repo) == TRUSTED
+
+    monkeypatch.setenv(workspace_trust.TRUST_ENV_VAR, "1")
+    store = WorkspaceTrustStore(path, TrustConfig())
+    assert resolve_workspace_trust(repo, store, interactive=False)
+
+
+def test_project_config_cannot_grant_trust(tmp_path, repo, monkeypatch):
+    home = tmp_path / "home"
+    monkeypatch.setenv("HOME", str(home))
+    monkeypatch.chdir(repo)
+    (repo / ".claude-mpm").mkdir()
+    (repo / ".claude-mpm" / "configuration.yaml").write_text(
+        "workspace_trust:\n  enabled: false\n  trusted_paths: ['/']\n"
+    )
+    assert TrustConfig.load() == TrustConfig()
+    assert WorkspaceTrustStore(tmp_path / "t.json").status(repo) == UNKNOWN
+
+    (home / ".claude-mpm").mkdir(parents=True)
+    (home / ".claude-mpm" / "configuration.yaml").write_text(
+        f"workspace_trust:\n  trusted_paths: ['{tmp_path}']\n"
+    )
+    assert WorkspaceTrustStore(tmp_path / "t.json").status(repo) == TRUSTED
+
+
+def test_restricted_session_denies_shell_and_network(monkeypatch):
+    event = {
+        "hook_event_name": "PreToolUse",
+        "tool_name": "Bash",
+        "tool_input": {"command": "ls"},
+    }
+    monkeypatch.setenv(workspace_trust.RESTRICTED_ENV_VAR, "1")
+
+    response = pretooluse_dispatcher.dispatch(event)
+    assert response["hookSpecificOutput"]["permissionDecision"] == "deny"
+    fetch = pretooluse_dispatcher.dispatch({**event, "tool_name": "WebFetch"})
+    assert fetch["hookSpecificOutput"]["permissionDecision"] == "deny"
+    read = pretooluse_dispatcher.dispatch({**event, "tool_name": "Read"})
+    assert read.get("hookSpecificOutput", {}).get("permissionDecision") != "deny"
+
+
+def test_trust_command(repo, store):
+    command = TrustCommand(store)
+
+    result = command.run(argparse.Namespace(trust_command="add", path=str(repo)))
+    assert result.success
+    assert store.status(repo) == TRUSTED
+
+    listed = command.run(argparse.Namespace(trust_command="list", json=False))
+    assert str(repo.resolve()) in listed.message
+
+    command.run(argparse.Namespace(trust_command="remove", path=str(repo)))
+    result = command.run(argparse.Namespace(trust_command="remove", path=str(repo)))
+    assert not result.success
@@ -0,0 +1,175 @@
+#!/usr/bin/env python3
+"""
+Unit tests for lessons learned from verification gate failures.
+
+Tests cover:
+- Verification command detection and changed-file tracking
+- Cause → fix solution text built from the files edited before the fix
+- LearningExtractionHook proposing instead of writing verification lessons
+- LessonProposalStore approve/reject and deduplication
+"""
+
+from datetime import UTC, datetime
+from unittest.mock import Mock
+
+from claude_mpm.hooks.base_hook import HookContext, HookType
+from claude_mpm.hooks.failure_learning import (
+    FailureDetectionHook,
+    FixDetectionHook,
+    LearningExtractionHook,
+)
+from claude_mpm.services.memory.failure_tracker import (
+    FailureTracker,
+    get_failure_tracker,
+    reset_failure_tracker,
+)
+from claude_mpm.services.memory.lesson_proposals import (
+    APPROVED,
+    LessonProposal,
+    LessonProposalStore,
+)
+
+
+def _context(**data):
+    return HookContext(
+        hook_type=HookType.POST_DELEGATION,
+        data=data,
+        metadata={},
+        timestamp=datetime.now(UTC),
+    )
+
+
+class TestVerificationTracking:
+    """Test verification command detection and fix attribution."""
+
+    def setup_method(self):
+        reset_failure_tracker()
+
+    def test_is_verification_command(self):
+        for command in (
+            "pytest tests/ -x",
+            "cd app && npm run test",
+            "python -m mypy src",
+            "cargo clippy",
+            "make lint",
+        ):
+            assert FailureTracker.is_verification_command(command), command
+        for command in ("ls -la", "git status", "", None):
+            assert not FailureTracker.is_verification_command(command)
+
+    def test_changed_files_become_the_fix(self):
+        tracker = get_failure_tracker()
+        tracker.detect_failure(
+            "Bash",
+            "FAILED tests/test_api.py::test_login",
+            {"command": "pytest tests/test_api.py"},
+        )
+        tracker.record_change("/proj/src/api.py")
+        tracker.record_change("/proj/src/api.py")
+        fix_event, failure = tracker.detect_fix(
+            "Bash", "1 passed", context={"command": "pytest tests/test_api.py"}
+        )
+
+        learning = tracker.extract_learning(fix_event, failure)
+
+        assert failure.changed_files == ["/proj/src/api.py"]
+        assert tracker.is_verification_failure(failure)
+        assert learning.solution == (
+            "Changed api.py; `pytest tests/test_api.py` passes again"
+        )
+        assert learning.to_lesson().startswith("Testing: Test failed: ")
+
+
+class TestVerificationLessonCycle:
+    """Test the hook chain proposing verification lessons."""
+
+    def setup_method(self):
+        reset_failure_tracker()
+        self.failure_hook = FailureDetectionHook()
+        self.fix_hook = FixDetectionHook()
+
+    def _run_cycle(self, learning_hook):
+        command = {"command": "pytest -q"}
+        self.failure_hook.execute(
+            _context(
+                tool_name="Bash",
+                output="FAILED tests/test_x.py::test_y - AssertionError",
+                exit_code=1,
+                tool_input=command,
+                **command,
+            )
+        )
+        self.fix_hook.execute(
+            _context(
+                tool_name="Edit", exit_code=0, tool_input={"file_path": "/p/x.py"}
+            )
+        )
+        fix = self.fix_hook.execute(
+            _context(tool_name="Bash", output="3 passed", exit_code=0, **command)
+        )
+        learning_context = _context()
+        learning_context.metadata = fix.metadata
+        return learning_hook.execute(learning_context)
+
+    def test_verification_lesson_is_proposed_not_written(self, tmp_path):
+        store = LessonProposalStore(tmp_path)
+        hook = LearningExtractionHook(lesson_store=store)
+        hook._memory_manager = Mock()
+
+        result = self._run_cycle(hook)
+
+        assert result.metadata["learning_proposed"]
+        hook._memory_manager.update_agent_memory.assert_not_called()
+        [lesson] = store.pending()
+        assert lesson.id == result.metadata["lesson_id"]
+        assert lesson.context["changed_files"] == ["/p/x.py"]
+        assert "x.py" in lesson.fix
+
+    def test_approval_can_be_disabled(self, tmp_path):
+        store = LessonProposalStore(tmp_path)
+        hook = LearningExtractionHook(require_approval=False, lesson_store=store)
+        hook._memory_manager = Mock()
+        hook._memory_manager.update_agent_memory.return_value = True
+
+        result = self._run_cycle(hook)
+
+        assert not result.metadata.get("learning_proposed")
+        hook._memory_manager.update_agent_memory.assert_called_once()
+        assert store.pending() == []
+
+
+class TestLessonProposalStore:
+    """Test persistence and approval of lesson proposals."""
+
+    def _proposal(self):
+        reset_failure_tracker()
+        tracker = get_failure_tracker()
+        tracker.detect_failure("Bash", "FAILED test_a", {"command": "pytest"})
+        tracker.record_change("a.py")
+        fix_event, failure = tracker.detect_fix("Bash", "ok", exit_code=0)
+        return LessonProposal.from_learning(
+            tracker.extract_learning(fix_event, failure, target_agent="qa")
+        )
+
+    def test_propose_is_idempotent(self, tmp_path):
+        store = LessonProposalStore(tmp_path)
+        proposal = self._proposal()
+
+        assert store.propose(proposal)
+        assert not store.propose(proposal)
+        assert len(store.pending()) == 1
+
+    def test_approved_lesson_is_written_once(self, tmp_path):
+        store = LessonProposalStore(tmp_path)
+        proposal = self._proposal()
+        store.propose(proposal)
+        manager = Mock()
+        manager.add_learning.return_value = True
+
+        [approved] = store.approve([proposal.id], manager, agent="engineer")
+
+        manager.add_learning.assert_called_once_with("engineer", proposal.memory_item)
+        assert approved.status == APPROVED
+        assert store.pending() == []
+        assert store.reject([proposal.id]) == []
+        assert store.all()[0].status == APPROVED
@@ -378,3 +378,48 @@ def test_find_profiles_dir_fallback(monkeypatch):
         expected = test_dir / ".claude-mpm" / "profiles"
         # Compare resolved paths to handle symlinks
         assert manager.profiles_dir.resolve() == expected.resolve()
+
+
+def test_for_project_reads_project_config(profiles_dir):
+    """The project's configuration.yaml selects the profile to load."""
+    project_dir = profiles_dir.parent.parent
+    assert ProfileManager.configured_profile(project_dir) is None
+    assert ProfileManager.for_project(project_dir) is None
+
+    config = project_dir / ".claude-mpm" / "configuration.yaml"
+    config.write_text("profile: minimal\n")
+    assert ProfileManager.configured_profile(project_dir) == "minimal"
+
+    config.write_text("active_profile: framework-development\n")
+    manager = ProfileManager.for_project(project_dir)
+    assert manager.active_profile == "framework-development"
+
+    config.write_text("active_profile: non-existent\n")
+    assert ProfileManager.for_project(project_dir) is None
+
+
+def test_filter_skills_by_source_and_name(profiles_dir):
+    """Skills must come from a listed source and match the enabled names."""
+    _create_test_profile(
+        profiles_dir / "backend.yaml",
+        {
+            "profile": {"name": "backend"},
+            "skills": {"sources": ["system"], "enabled": ["flask", "pytest"]},
+        },
+    )
+    skills = [
+        {"name": "flask", "deployment_name": "python-flask", "source_id": "system"},
+        {"name": "pytest", "deployment_name": "pytest", "source_id": "community"},
+        {"name": "react", "deployment_name": "react", "source_id": "system"},
+    ]
+    manager = ProfileManager(profiles_dir=profiles_dir)
+    assert manager.filter_skills(skills) == skills
+
+    manager.load_profile("backend")
+    assert manager.get_skill_sources() == {"system"}
+    assert manager.get_filtering_summary()["skill_sources_count"] == 1
+    assert [s["name"] for s in manager.filter_skills(skills)] == ["flask"]
+    assert manager.is_skill_source_enabled("community") is False
+
+    manager.load_profile("framework-development")
+    assert [s["name"] for s in manager.filter_skills(skills)] == ["flask", "pytest"]
@@ -0,0 +1,102 @@
+"""Tests for concurrency-safe state file helpers.
+
+COVERAGE:
+- write_atomic replaces the file in one step, keeps its mode and leaves no
+  temporary files behind
+- update_json serialises concurrent read-modify-write cycles
+- merge_records keeps records written by another process
+- The state lock is re-entrant within a thread
+- A lock-wait timeout names the PID holding the lock
+"""
+
+import json
+import os
+import threading
+from pathlib import Path
+
+import pytest
+
+from claude_mpm.core.config_file_lock import ConfigFileLockTimeout
+from claude_mpm.core.state_files import (
+    merge_records,
+    read_json,
+    state_lock,
+    update_json,
+    write_atomic,
+)
+
+
+def test_write_atomic_keeps_mode_and_cleans_up(tmp_path: Path) -> None:
+    path = tmp_path / "state" / "registry.json"
+    write_atomic(path, "{}")
+    os.chmod(path, 0o600)
+
+    write_atomic(path, '{"a": 1}')
+
+    assert json.loads(path.read_text()) == {"a": 1}
+    assert path.stat().st_mode & 0o777 == 0o600
+    assert [p.name for p in path.parent.iterdir()] == ["registry.json"]
+
+
+def test_update_json_serialises_concurrent_writers(tmp_path: Path) -> None:
+    path = tmp_path / "counter.json"
+
+    def bump(data):
+        data["count"] = data.get("count", 0) + 1
+
+    def worker():
+        for _ in range(20):
+            update_json(path, bump)
+
+    threads = [threading.Thread(target=worker) for _ in range(4)]
+    for t in threads:
+        t.start()
+    for t in threads:
+        t.join()
+
+    assert read_json(path) == {"count": 80}
+
+
+def test_merge_records_keeps_other_writers_records(tmp_path: Path) -> None:
+    path = tmp_path / "sessions.json"
+    write_atomic(path, json.dumps({"a": 1, "b": 2}))
+    baseline = read_json(path)
+
+    # Another process adds a record after we read the file
+    update_json(path, lambda data: data.update(c=3))
+
+    merged = merge_records(path, {"a": 10}, baseline)
+
+    assert merged == {"a": 10, "c": 3}
+    assert read_json(path) == merged
+
+
+def test_state_lock_is_reentrant(tmp_path: Path) -> None:
+    path = tmp_path / "sources.yaml"
+    with state_lock(path, timeout=0.5):
+        update_json(tmp_path / "other.json", lambda data: None)
+        with state_lock(path, timeout=0.5):
+            write_atomic(path, "x: 1\n")
+    assert path.read_text() == "x: 1\n"
+
+
+def test_timeout_names_lock_holder(tmp_path: Path) -> None:
+    path = tmp_path / "registry.json"
+    held = threading.Event()
+    done = threading.Event()
+
+    def holder():
+        with state_lock(path):
+            held.set()
+            done.wait(5)
+
+    t = threading.Thread(target=holder)
+    t.start()
+    try:
+        held.wait(5)
+        with pytest.raises(ConfigFileLockTimeout, match=f"PID {os.getpid()}"):
+            with state_lock(path, timeout=0.2):
+                pass
+    finally:
+        done.set()
+        t.join()
@@ -0,0 +1,172 @@
+"""
+Tests for the warm agent session pool.
+
+COVERAGE:
+- start() opens and warms ``size`` sessions; tasks get them as hits and the
+  pool refills in the background
+- Sessions are retired after max_uses, on errors and when idle too long
+- An empty pool hands out a cold session instead of waiting
+- Failed warm-ups are counted and do not enter the pool
+- PooledTaskRunner sends only matching tasks to the pool
+"""
+
+import asyncio
+
+from claude_mpm.services.agents.agent_runtime import AgentResult
+from claude_mpm.services.agents.session_pool import (
+    PooledTaskRunner,
+    SessionPoolConfig,
+    WarmSessionPool,
+)
+from claude_mpm.services.work_queue import QueueTask
+
+
+class FakeSession:
+    def __init__(self, runner):
+        self.runner = runner
+        self.prompts = []
+        self.open = False
+
+    async def __aenter__(self):
+        self.open = True
+        return self
+
+    async def __aexit__(self, *exc):
+        self.open = False
+
+    async def query(self, prompt):
+        self.prompts.append(prompt)
+        if prompt in self.runner.failing:
+            return AgentResult(text="boom", is_error=True)
+        return AgentResult(text=f"done: {prompt}")
+
+    async def interrupt(self):
+        pass
+
+
+class FakeRunner:
+    def __init__(self, failing=()):
+        self.failing = set(failing)
+        self.sessions = []
+
+    def interruptible(self):
+        session = FakeSession(self)
+        self.sessions.append(session)
+        return session
+
+
+class Clock:
+    def __init__(self):
+        self.now = 0.0
+
+    def __call__(self):
+        return self.now
+
+
+async def _settle():
+    """Let the background refill task finish."""
+    for _ in range(10):
+        await asyncio.sleep(0)
+
+
+def _pool(runner, clock=None, **config):
+    config = SessionPoolConfig(enabled=True, warmup_prompt="warm", **config)
+    return WarmSessionPool(runner, config, clock=clock or Clock())
+
+
+def test_warm_sessions_are_used_and_replaced():
+    runner = FakeRunner()
+
+    async def scenario():
+        pool = _pool(runner, size=2)
+        await pool.start()
+        assert pool.idle == 2
+        assert all(s.prompts == ["warm"] for s in runner.sessions)
+
+        result = await pool.run("fix typo")
+        assert result.text == "done: fix typo"
+        await _settle()
+        assert pool.idle == 2
+        await pool.close()
+        return pool
+
+    pool = asyncio.run(scenario())
+    # One session served the task and was retired (max_uses=1); one replaced it
+    assert runner.sessions[0].prompts == ["warm", "fix typo"]
+    assert (pool.stats.hits, pool.stats.misses, pool.stats.opened) == (1, 0, 3)
+    assert not any(s.open for s in runner.sessions)
+
+
+def test_reuse_error_and_idle_retirement():
+    runner = FakeRunner(failing={"bad"})
+    clock = Clock()
+
+    async def scenario():
+        pool = _pool(runner, clock=clock, size=1, max_uses=3, max_idle_seconds=60)
+        await pool.start()
+        await pool.run("one")
+        await pool.run("two")
+        assert len(runner.sessions) == 1  # reused, no warm-up in between
+
+        await pool.run("bad")
+        assert not runner.sessions[0].open
+        await _settle()
+        assert pool.idle == 1
+
+        clock.now = 120
+        await pool.run("late")
+        await pool.close()
+        return pool
+
+    pool = asyncio.run(scenario())
+    assert runner.sessions[0].prompts == ["warm", "one", "two", "bad"]
+    # The idle session went stale, so "late" ran on a cold session
+    assert runner.sessions[-1].prompts == ["late"]
+    assert pool.stats.misses == 1
+
+
+def test_failed_warmup_is_not_pooled():
+    runner = FakeRunner(failing={"warm"})
+
+    async def scenario():
+        pool = _pool(runner, size=2)
+        await pool.start()
+        assert pool.idle == 0
+        result = await pool.run("task")
+        await pool.close()
+        return pool, result
+
+    pool, result = asyncio.run(scenario())
+    assert result.text == "done: task"
+    assert pool.stats.warmup_failures >= 2
+    assert pool.stats.misses == 1
+
+
+def test_pooled_task_runner_falls_back_for_other_tasks(tmp_path):
+    runner = FakeRunner()
+    fallback_calls = []
+
+    def fallback(task, cwd, timeout):
+        fallback_calls.append(task.prompt)
+        return AgentResult(text="headless")
+
+    config = SessionPoolConfig(enabled=True, size=1, warmup_prompt="warm")
+    pooled = PooledTaskRunner(tmp_path, config, fallback=fallback, runner=runner)
+    try:
+        assert pooled(QueueTask(prompt="here"), tmp_path, 30).text == "done: here"
+        other = tmp_path / "other"
+        other.mkdir()
+        pooled(QueueTask(prompt="elsewhere"), other, 30)
+        pooled(QueueTask(prompt="opus", model="opus"), tmp_path, 30)
+    finally:
+        pooled.close()
+    assert fallback_calls == ["elsewhere", "opus"]
+    assert pooled.pool.stats.hits == 1
+
+
+def test_config_load():
+    config = SessionPoolConfig.load(
+        config={"session_pool": {"enabled": True, "size": 4, "max_uses": 0}}
+    )
+    assert (config.enabled, config.size, config.max_uses) == (True, 4, 1)
+    assert config.warmup_prompt
@@ -0,0 +1,84 @@
+"""
+Tests for parallel bulk operations with per-item reporting.
+
+COVERAGE:
+- Results keep input order when items finish out of order
+- An exception fails only its own item; the batch carries on
+- Progress is reported once per item, from the calling thread
+- The report summary, exit code and rendered lines
+- deploy_agents_to_project reports each failed agent with its reason
+"""
+
+import threading
+import time
+
+from claude_mpm.services.agents.sources.git_source_sync_service import (
+    GitSourceSyncService,
+)
+from claude_mpm.utils.bulk_operations import FAILED, BulkItem, run_bulk
+
+
+def test_parallel_results_keep_input_order():
+    def work(n):
+        time.sleep(0.01 * (5 - n))  # later items finish first
+        return BulkItem(f"item-{n}", "deployed")
+
+    report = run_bulk(range(5), work, max_workers=5)
+
+    assert [item.name for item in report.items] == [f"item-{n}" for n in range(5)]
+    assert report.exit_code == 0
+
+
+def test_failures_do_not_stop_the_batch():
+    def work(name):
+        if name == "bad":
+            raise OSError("disk full")
+        return BulkItem(name, "skipped" if name == "same" else "updated")
+
+    progress = []
+    caller = threading.current_thread()
+
+    def on_progress(done):
+        assert threading.current_thread() is caller
+        progress.append(done)
+
+    report = run_bulk(
+        ["a", "bad", "same", "b"],
+        work,
+        operation_name="Deploy",
+        max_workers=3,
+        progress_callback=on_progress,
+    )
+
+    assert report.failed == [BulkItem("bad", FAILED, "disk full")]
+    assert report.names("updated") == ["a", "b"]
+    assert report.exit_code == 1
+    assert sorted(progress) == [1, 2, 3, 4]
+    assert report.summary() == "2 updated, 1 failed, 1 skipped"
+
+    lines = []
+    report.render(lines.append)
+    assert "  ✗ bad: failed (disk full)" in lines
+    assert lines[-1] == "Deploy: 2 updated, 1 failed, 1 skipped"
+    assert report.to_dict()["failed"] == 1
+
+
+def test_agent_deployment_reports_each_failure(tmp_path):
+    cache_dir = tmp_path / "cache"
+    agents_dir = cache_dir / "repo" / "agents"
+    agents_dir.mkdir(parents=True)
+    (agents_dir / "engineer.md").write_text("---\nname: engineer\n---\n# Engineer\n")
+
+    # Bypass __init__ (network sessions, state DB); only the cache is needed
+    service = object.__new__(GitSourceSyncService)
+    service.cache_dir = cache_dir
+    project_dir = tmp_path / "project"
+    project_dir.mkdir()
+
+    result = service.deploy_agents_to_project(
+        project_dir, agent_list=["engineer.md", "missing.md"], max_workers=4
+    )
+
+    assert result["deployed"] == ["engineer.md"]
+    assert result["failed"] == ["missing.md"]
+    assert result["errors"] == {"missing.md": "not found in cache"}
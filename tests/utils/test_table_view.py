@@ -0,0 +1,117 @@
+"""
+Tests for table and tree rendering of list commands.
+
+COVERAGE:
+- --sort orders numbers numerically, text case-insensitively, supports
+  descending and custom sort keys, and keeps empty values last
+- --columns selects and orders columns; unknown columns name the choices
+- Tables render aligned plain text when color is off
+- Ticket trees nest children under their epic or issue
+"""
+
+import argparse
+
+import pytest
+
+from claude_mpm.cli.list_columns import SKILL_SOURCE_COLUMNS, TICKET_COLUMNS
+from claude_mpm.utils.table_view import (
+    TableView,
+    TreeNode,
+    add_table_arguments,
+    parse_columns,
+    render_tree,
+    sort_rows,
+)
+from claude_mpm.utils.theme import CliTheme, set_theme
+
+
+@pytest.fixture(autouse=True)
+def _plain_theme():
+    set_theme(CliTheme(name="no-color", color=False))
+    yield
+    set_theme(None)
+
+
+SOURCES = [
+    {"id": "beta", "enabled": True, "priority": 100, "branch": "main"},
+    {"id": "Alpha", "enabled": False, "priority": 20, "branch": None},
+    {"id": "gamma", "enabled": True, "priority": 3, "branch": "dev"},
+]
+
+
+def test_sort_rows():
+    by_priority = sort_rows(SOURCES, "priority", SKILL_SOURCE_COLUMNS)
+    assert [row["priority"] for row in by_priority] == [3, 20, 100]
+
+    by_id_desc = sort_rows(SOURCES, "-id", SKILL_SOURCE_COLUMNS)
+    assert [row["id"] for row in by_id_desc] == ["gamma", "beta", "Alpha"]
+
+    for spec in ("branch", "-branch"):
+        assert sort_rows(SOURCES, spec, SKILL_SOURCE_COLUMNS)[-1]["id"] == "Alpha"
+
+    tickets = [{"priority": p} for p in ("low", "critical", "medium", "high")]
+    ranked = sort_rows(tickets, "priority", TICKET_COLUMNS)
+    assert [t["priority"] for t in ranked] == ["critical", "high", "medium", "low"]
+
+
+def test_unknown_column_lists_choices():
+    with pytest.raises(ValueError, match="choose from: id, enabled"):
+        sort_rows(SOURCES, "nope", SKILL_SOURCE_COLUMNS)
+    with pytest.raises(ValueError, match="Unknown column 'nope'"):
+        parse_columns("id,nope", SKILL_SOURCE_COLUMNS)
+
+
+def test_table_from_args_sorts_and_selects_columns():
+    parser = argparse.ArgumentParser()
+    add_table_arguments(parser, SKILL_SOURCE_COLUMNS)
+    args = parser.parse_args(["--sort=-priority", "--columns", "priority,id"])
+
+    output = TableView.from_args(SKILL_SOURCE_COLUMNS, SOURCES, args).render()
+    lines = [line.strip() for line in output.splitlines() if line.strip()]
+
+    assert lines[0].split() == ["Priority", "ID"]
+    assert [line.split()[-1] for line in lines[2:]] == ["beta", "Alpha", "gamma"]
+    assert "Branch" not in output
+    assert "\x1b[" not in output
+
+
+def test_table_formats_values():
+    output = TableView(SKILL_SOURCE_COLUMNS, SOURCES, show="id,enabled,branch")
+    rendered = output.render()
+
+    assert "Enabled" in rendered
+    assert "Disabled" in rendered
+    alpha = next(line for line in rendered.splitlines() if "Alpha" in line)
+    assert alpha.split() == ["Alpha", "Disabled", "-"]
+
+
+def test_render_tree_and_ticket_tree():
+    from claude_mpm.services.ticket_services import TicketFormatterService
+
+    rendered = render_tree("root", [TreeNode("a", [TreeNode("b")]), TreeNode("c")])
+    assert rendered.splitlines()[0] == "root"
+    assert "└── b" in rendered
+
+    tickets = [
+        {"id": "EP-1", "title": "Epic", "status": "open"},
+        {
+            "id": "TSK-2",
+            "title": "Child task",
+            "status": "done",
+            "metadata": {"parent_epic": "EP-1", "ticket_type": "task"},
+        },
+        {
+            "id": "TSK-3",
+            "title": "Orphan",
+            "status": "open",
+            "metadata": {"parent_epic": "EP-404"},
+        },
+    ]
+    tree = TicketFormatterService().format_ticket_tree(tickets).splitlines()
+
+    epic = next(i for i, line in enumerate(tree) if "[EP-1]" in line)
+    child = next(i for i, line in enumerate(tree) if "[TSK-2]" in line)
+    orphan = next(line for line in tree if "[TSK-3]" in line)
+    assert child == epic + 1
+    assert tree[child].index("[TSK-2]") > tree[epic].index("[EP-1]")
+    assert orphan.index("[TSK-3]") == tree[epic].index("[EP-1]")
@@ -0,0 +1,123 @@
+"""
+Tests for CLI color themes.
+
+COVERAGE:
+- NO_COLOR and non-terminal output turn color off; FORCE_COLOR turns it on
+- Theme selection from config and CLAUDE_MPM_THEME, with fallback for
+  unknown names
+- Custom palette entries override roles; bad entries are ignored
+- Without color, styles are empty, diffs are unchanged and badges stay readable
+- Diff lines and doctor status badges are colored by role
+"""
+
+import io
+
+import pytest
+
+from claude_mpm.utils.theme import (
+    NO_COLOR_THEME,
+    THEMES,
+    CliTheme,
+    color_enabled,
+    set_theme,
+)
+
+
+class _Config:
+    def __init__(self, section):
+        self.section = section
+
+    def get(self, key, default=None):
+        return self.section if key == "cli_theme" else default
+
+
+class _Tty(io.StringIO):
+    def isatty(self):
+        return True
+
+
+@pytest.fixture(autouse=True)
+def _reset_theme():
+    yield
+    set_theme(None)
+
+
+def test_color_detection():
+    assert color_enabled({}, _Tty()) is True
+    assert color_enabled({}, io.StringIO()) is False
+    assert color_enabled({"NO_COLOR": "1"}, _Tty()) is False
+    assert color_enabled({"NO_COLOR": ""}, _Tty()) is True
+    assert color_enabled({"FORCE_COLOR": "1"}, io.StringIO()) is True
+
+
+def test_theme_selection_and_custom_palette():
+    config = _Config(
+        {
+            "name": "light",
+            "colors": {
+                "success": "bold magenta",
+                "no.such.role": "red",
+                "error": "not a [style",
+            },
+        }
+    )
+
+    theme = CliTheme.load(config, environ={}, stream=_Tty())
+    assert theme.name == "light"
+    assert theme.style("success") == "bold magenta"
+    assert theme.style("error") == THEMES["light"]["error"]
+    assert "no.such.role" not in theme.styles
+
+    from_env = CliTheme.load(
+        config, environ={"CLAUDE_MPM_THEME": "no-color"}, stream=_Tty()
+    )
+    assert from_env.name == NO_COLOR_THEME
+    assert from_env.color is False
+
+    unknown = CliTheme.load(_Config({"name": "neon"}), environ={}, stream=_Tty())
+    assert unknown.name == "default"
+
+
+def test_without_color_output_is_plain():
+    theme = CliTheme.load(_Config({}), environ={"NO_COLOR": "1"}, stream=_Tty())
+    diff = "--- a\n+++ b\n@@ -1 +1 @@\n-old\n+new\n"
+
+    assert theme.style("success") == ""
+    assert theme.markup("heading", "Title") == "Title"
+    assert theme.ansi("error", "boom") == "boom"
+    assert theme.diff(diff) == diff
+    assert theme.badge("OK", "ok") == "[OK]"
+
+
+def test_diff_and_badges_are_colored_by_role():
+    theme = CliTheme.load(_Config({}), environ={}, stream=_Tty())
+
+    lines = theme.diff(" same\n-old\n+new\n").splitlines()
+    assert lines[0] == " same"
+    assert lines[1] == theme.ansi("diff.removed", "-old")
+    assert lines[2] == theme.ansi("diff.added", "+new")
+    assert "\x1b[" in lines[2]
+
+    badge = theme.badge("Error", "error")
+    assert " Error " in badge
+    assert badge.startswith("\x1b[")
+    assert theme.markup("heading", "Title") == "[bold cyan]Title[/]"
+
+
+def test_doctor_reporter_uses_theme_badges(capsys):
+    from claude_mpm.core.enums import OperationResult
+    from claude_mpm.services.diagnostics.doctor_reporter import DoctorReporter
+    from claude_mpm.services.diagnostics.models import DiagnosticResult
+
+    theme = CliTheme.load(_Config({}), environ={"FORCE_COLOR": "1"})
+    set_theme(theme)
+    result = DiagnosticResult(
+        category="Installation", status=OperationResult.SUCCESS, message="fine"
+    )
+
+    DoctorReporter(use_color=True)._print_result(result)
+    assert theme.badge("OK", "ok") in capsys.readouterr().out
+
+    set_theme(CliTheme.load(_Config({}), environ={"NO_COLOR": "1"}))
+    DoctorReporter(use_color=True)._print_result(result)
+    assert "Installation: OK" in capsys.readouterr().out